@@ -186,6 +186,11 @@ func HandleBackgroundNotification(strConvID, body, serverMessageBody, sender str
 		return errors.New("stale notification")
 	}
 
+	if !chat.ShouldNotifyThisDevice(ctx, gc, uid) {
+		kbCtx.Log.CDebugf(ctx, "HandleBackgroundNotification: routed away from this device")
+		return errors.New("notification routed to another device")
+	}
+
 	// only display and ack this notification if we actually have something to display
 	if pusher != nil && (len(chatNotification.Message.Plaintext) > 0 || len(chatNotification.Message.ServerMessage) > 0) {
 		pusher.DisplayChatNotification(&chatNotification)
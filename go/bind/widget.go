@@ -0,0 +1,29 @@
+package keybase
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/keybase/client/go/chat"
+	context "golang.org/x/net/context"
+)
+
+// ReadChatWidgetFeed returns the chat widget feed at path, as JSON, opened
+// with the key the app enabled it with. The widget calls it without Init,
+// so it mustn't touch the service's globals.
+func ReadChatWidgetFeed(path string, key []byte) (string, error) {
+	if len(key) != 32 {
+		return "", fmt.Errorf("the widget feed key must be 32 bytes, not %d", len(key))
+	}
+	var fkey [32]byte
+	copy(fkey[:], key)
+	feed, err := chat.ReadWidgetFeedFile(context.Background(), path, fkey)
+	if err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(feed)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
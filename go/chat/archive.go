@@ -1,15 +1,13 @@
 package chat
 
 import (
-	"archive/tar"
-	"compress/gzip"
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path"
-	"path/filepath"
 	"sort"
 	"sync"
 	"time"
@@ -51,6 +49,10 @@ type ChatArchiveRegistry struct {
 
 	edb        *encrypteddb.EncryptedDB
 	jobHistory chat1.ArchiveChatHistory
+
+	// Indexes of completed jobs' output, for searching.
+	searchMu      sync.Mutex
+	searchIndexes map[chat1.ArchiveJobID]*archiveSearchIndex
 }
 
 type ArchiveJobNotFoundError struct {
@@ -83,6 +85,8 @@ func NewChatArchiveRegistry(g *globals.Context, remoteClient func() chat1.Remote
 		runningJobs:  make(map[chat1.ArchiveJobID]types.CancelArchiveFn),
 		jobHistory:   chat1.ArchiveChatHistory{JobHistory: make(map[chat1.ArchiveJobID]chat1.ArchiveChatJob)},
 		edb:          encrypteddb.New(g.ExternalG(), dbFn, keyFn),
+
+		searchIndexes: make(map[chat1.ArchiveJobID]*archiveSearchIndex),
 	}
 	switch r.G().GetAppType() {
 	case libkb.MobileAppType:
@@ -200,7 +204,7 @@ func (r *ChatArchiveRegistry) monitorAppState() error {
 			default:
 				cancel()
 				ctx, cancel = context.WithCancel(context.Background())
-				r.bgPauseAllJobsLocked(ctx)
+				_ = r.bgPauseAllJobsLocked(ctx)
 			}
 		}
 	}
@@ -224,9 +228,12 @@ func (r *ChatArchiveRegistry) Start(ctx context.Context, uid gregor1.UID) {
 		return r.resumeAllBgJobs(context.Background())
 	})
 	r.eg.Go(r.monitorAppState)
+	r.eg.Go(func() error {
+		return r.scheduleLoop(r.stopCh)
+	})
 }
 
-func (r *ChatArchiveRegistry) bgPauseAllJobsLocked(ctx context.Context) {
+func (r *ChatArchiveRegistry) bgPauseAllJobsLocked(ctx context.Context) error {
 	for jobID, cancel := range r.runningJobs {
 		job := cancel()
 		job.Status = chat1.ArchiveChatJobStatus_BACKGROUND_PAUSED
@@ -235,7 +242,7 @@ func (r *ChatArchiveRegistry) bgPauseAllJobsLocked(ctx context.Context) {
 	r.runningJobs = make(map[chat1.ArchiveJobID]func() chat1.ArchiveChatJob)
 
 	r.dirty = true
-	_ = r.flushLocked(ctx)
+	return r.flushLocked(ctx)
 }
 
 // Pause running jobs marking as BACKGROUND_PAUSED
@@ -246,7 +253,7 @@ func (r *ChatArchiveRegistry) Stop(ctx context.Context) chan struct{} {
 	ch := make(chan struct{})
 	if r.started {
 		r.started = false
-		r.bgPauseAllJobsLocked(ctx)
+		_ = r.bgPauseAllJobsLocked(ctx)
 		close(r.stopCh)
 		go func() {
 			r.Debug(context.Background(), "Stop: waiting for shutdown")
@@ -261,6 +268,26 @@ func (r *ChatArchiveRegistry) Stop(ctx context.Context) chan struct{} {
 
 }
 
+// Pause running jobs marking as BACKGROUND_PAUSED ahead of a restart, after
+// which they're resumed like on any startup. If the restart doesn't happen,
+// they're resumed here after the same delay.
+func (r *ChatArchiveRegistry) OnDrain(mctx libkb.MetaContext) (err error) {
+	ctx := mctx.Ctx()
+	defer r.Trace(ctx, &err, "OnDrain")()
+	r.Lock()
+	defer r.Unlock()
+	if !r.started {
+		return nil
+	}
+	if err := r.bgPauseAllJobsLocked(ctx); err != nil {
+		return err
+	}
+	r.eg.Go(func() error {
+		return r.resumeAllBgJobs(context.Background())
+	})
+	return nil
+}
+
 func (r *ChatArchiveRegistry) OnDbNuke(mctx libkb.MetaContext) (err error) {
 	defer r.Trace(mctx.Ctx(), &err, "ChatArchiveRegistry.OnDbNuke")()
 	r.Lock()
@@ -295,9 +322,17 @@ func (r *ChatArchiveRegistry) List(ctx context.Context) (res chat1.ArchiveChatLi
 	}
 
 	for _, job := range r.jobHistory.JobHistory {
+		// Only the job itself needs its passphrase.
+		job.Request.Passphrase = ""
 		res.Jobs = append(res.Jobs, job)
 	}
 	sort.Sort(ByJobStartedAt(res.Jobs))
+	if len(r.jobHistory.Schedules) > 0 {
+		res.Schedules = make(map[chat1.ArchiveJobID]chat1.ArchiveChatSchedule, len(r.jobHistory.Schedules))
+		for jobID, schedule := range r.jobHistory.Schedules {
+			res.Schedules[jobID] = schedule
+		}
+	}
 	return res, nil
 }
 
@@ -337,6 +372,7 @@ func (r *ChatArchiveRegistry) Delete(ctx context.Context, jobID chat1.ArchiveJob
 		return NewArchiveJobNotFoundError(jobID)
 	}
 	delete(r.jobHistory.JobHistory, jobID)
+	delete(r.jobHistory.Schedules, jobID)
 	r.dirty = true
 	if deleteOutputPath {
 		err = os.RemoveAll(job.Request.OutputPath)
@@ -457,7 +493,15 @@ type ChatArchiver struct {
 	sync.Mutex
 	messagesComplete int64
 	messagesTotal    int64
-	remoteClient     func() chat1.RemoteInterface
+	// convID -> its progress, sent along with the job's
+	convProgress map[string]chat1.ArchiveChatConvProgress
+	remoteClient func() chat1.RemoteInterface
+	// teamID -> redactor, for teams with redaction rules
+	redactors map[keybase1.TeamID]*archiveRedactor
+	// Set if the job writes an archive.sqlite.
+	database *archiveDatabase
+	// Set if the job dedupes crossposted messages.
+	deduper *archiveMessageDeduper
 }
 
 func NewChatArchiver(g *globals.Context, uid gregor1.UID, remoteClient func() chat1.RemoteInterface) *ChatArchiver {
@@ -466,6 +510,7 @@ func NewChatArchiver(g *globals.Context, uid gregor1.UID, remoteClient func() ch
 		DebugLabeler: utils.NewDebugLabeler(g.ExternalG(), "ChatArchiver", false),
 		uid:          uid,
 		remoteClient: remoteClient,
+		convProgress: make(map[string]chat1.ArchiveChatConvProgress),
 	}
 	switch c.G().GetAppType() {
 	case libkb.MobileAppType:
@@ -476,21 +521,38 @@ func NewChatArchiver(g *globals.Context, uid gregor1.UID, remoteClient func() ch
 	return c
 }
 
-func (c *ChatArchiver) notifyProgress(ctx context.Context, jobID chat1.ArchiveJobID, pagination chat1.Pagination) {
+func (c *ChatArchiver) notifyProgress(ctx context.Context, jobID chat1.ArchiveJobID, convID chat1.ConversationID,
+	pagination chat1.Pagination) {
 	c.Lock()
 	defer c.Unlock()
 	c.messagesComplete += int64(pagination.Num)
+	c.G().ExternalG().Telemetry().Count("chat.archive.messages", int64(pagination.Num))
 	if c.messagesComplete > c.messagesTotal || pagination.Last {
 		// total messages is capped to the convs expunge, don't over report.
 		c.messagesComplete = c.messagesTotal
 	}
-	c.G().NotifyRouter.HandleChatArchiveProgress(ctx, jobID, c.messagesComplete, c.messagesTotal)
+	key := convID.DbShortFormString()
+	if progress, ok := c.convProgress[key]; ok {
+		c.convProgress[key] = advanceArchiveConvProgress(progress, int64(pagination.Num), pagination.Last)
+	}
+	c.notifyProgressLocked(ctx, jobID, convID)
 }
 
 func (c *ChatArchiver) archiveName(conv chat1.ConversationLocal) string {
 	return chatrender.ConvName(c.G().GlobalContext, conv, c.G().GlobalContext.Env.GetUsername().String())
 }
 
+func (c *ChatArchiver) redactor(conv chat1.ConversationLocal) *archiveRedactor {
+	if conv.GetMembersType() != chat1.ConversationMembersType_TEAM {
+		return nil
+	}
+	teamID, err := TLFIDToTeamID(conv.Info.Triple.Tlfid)
+	if err != nil {
+		return nil
+	}
+	return c.redactors[teamID]
+}
+
 func (c *ChatArchiver) attachmentName(msg chat1.MessageUnboxedValid) string {
 	body := msg.MessageBody
 	typ, err := body.MessageType()
@@ -504,17 +566,70 @@ func (c *ChatArchiver) attachmentName(msg chat1.MessageUnboxedValid) string {
 	return ""
 }
 
-func (c *ChatArchiver) checkpointConv(ctx context.Context, f *os.File, cp chat1.ArchiveChatConvCheckpoint, convID chat1.ConversationID, job *chat1.ArchiveChatJob) (err error) {
+// attachmentPath returns where msg's attachment goes, relative to its
+// conversation's directory, or "" if it isn't one.
+func (c *ChatArchiver) attachmentPath(req chat1.ArchiveChatJobRequest, msg chat1.MessageUnboxedValid,
+	redactor *archiveRedactor) string {
+	name := redactor.Redact(c.attachmentName(msg))
+	if len(name) == 0 || !req.DedupeAttachments {
+		return name
+	}
+	if storeName := archiveAttachmentStoreName(msg.MessageBody.Attachment().Object, name); len(storeName) > 0 {
+		return archiveStoredAttachmentPath(storeName)
+	}
+	return name
+}
+
+// archiveFileOffset flushes f, which may be nil, and returns its size.
+func archiveFileOffset(f *os.File) (int64, error) {
+	if f == nil {
+		return 0, nil
+	}
+	err := f.Sync()
+	if err != nil {
+		return 0, err
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+	return stat.Size(), nil
+}
+
+// openArchiveFileAt opens the file at p to carry on writing at offset,
+// throwing away whatever was written after it.
+func openArchiveFileAt(p string, offset int64) (*os.File, error) {
+	f, err := os.OpenFile(p, os.O_RDWR|os.O_CREATE, libkb.PermFile)
+	if err != nil {
+		return nil, err
+	}
+	err = f.Truncate(offset)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	_, err = f.Seek(offset, 0)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+func (c *ChatArchiver) checkpointConv(ctx context.Context, textFile, jsonFile, historyFile *os.File, cp chat1.ArchiveChatConvCheckpoint, convID chat1.ConversationID, job *chat1.ArchiveChatJob) (err error) {
 	// Flush and update the registry
-	err = f.Sync()
+	cp.Offset, err = archiveFileOffset(textFile)
 	if err != nil {
 		return err
 	}
-	stat, err := f.Stat()
+	cp.JsonOffset, err = archiveFileOffset(jsonFile)
+	if err != nil {
+		return err
+	}
+	cp.HistoryOffset, err = archiveFileOffset(historyFile)
 	if err != nil {
 		return err
 	}
-	cp.Offset = stat.Size()
 
 	c.Lock()
 	// Mark our overall progress.
@@ -527,7 +642,7 @@ func (c *ChatArchiver) checkpointConv(ctx context.Context, f *os.File, cp chat1.
 	return c.G().ArchiveRegistry.Set(ctx, nil, *job)
 }
 
-func (c *ChatArchiver) archiveConv(ctx context.Context, job *chat1.ArchiveChatJob, conv chat1.ConversationLocal) error {
+func (c *ChatArchiver) archiveConv(ctx context.Context, job *chat1.ArchiveChatJob, conv chat1.ConversationLocal) (err error) {
 	c.Lock()
 	cp, ok := job.Checkpoints[conv.Info.Id.DbShortFormString()]
 	c.Unlock()
@@ -538,33 +653,112 @@ func (c *ChatArchiver) archiveConv(ctx context.Context, job *chat1.ArchiveChatJo
 		}
 	}
 
-	convArchivePath := path.Join(job.Request.OutputPath, c.archiveName(conv), "chat.txt")
-	f, err := os.OpenFile(convArchivePath, os.O_RDWR|os.O_CREATE, libkb.PermFile)
-	if err != nil {
-		return err
+	format := job.Request.Format
+	convArchivePath := path.Join(job.Request.OutputPath, c.archiveName(conv), archiveChatFilename)
+	var textFile, jsonFile *os.File
+	if format == chat1.ArchiveChatFormat_TEXT || format == chat1.ArchiveChatFormat_BOTH {
+		textFile, err = openArchiveFileAt(convArchivePath, cp.Offset)
+		if err != nil {
+			return err
+		}
+		defer textFile.Close()
 	}
-	err = f.Truncate(cp.Offset)
-	if err != nil {
-		return err
+	if format == chat1.ArchiveChatFormat_JSON || format == chat1.ArchiveChatFormat_BOTH {
+		jsonFile, err = openArchiveFileAt(path.Join(job.Request.OutputPath, c.archiveName(conv),
+			archiveMessagesFilename), cp.JsonOffset)
+		if err != nil {
+			return err
+		}
+		defer jsonFile.Close()
 	}
-	_, err = f.Seek(cp.Offset, 0)
-	if err != nil {
-		return err
+	var historyFile *os.File
+	if job.Request.IncludeHistory {
+		historyFile, err = openArchiveFileAt(path.Join(job.Request.OutputPath, c.archiveName(conv),
+			archiveHistoryFilename), cp.HistoryOffset)
+		if err != nil {
+			return err
+		}
+		defer historyFile.Close()
 	}
-	defer f.Close()
 
-	firstPage := cp.Offset == 0
+	// A JSON page of only reactions is empty, so don't go by the offsets.
+	firstPage := !ok
+	redactor := c.redactor(conv)
+	metadataOnly := job.Request.AttachmentsMetadataOnly
+	dedupe := job.Request.DedupeAttachments
+	attachmentsPath := path.Join(job.Request.OutputPath, c.archiveName(conv), archiveAttachmentsFilename)
+	attachmentInfos := []archiveAttachmentInfo{}
+	if (metadataOnly || dedupe) && !firstPage {
+		attachmentInfos, err = readArchiveAttachments(attachmentsPath)
+		if err != nil {
+			return err
+		}
+	}
+	if c.database != nil {
+		err = c.database.writeConversation(ctx, conv, c.archiveName(conv))
+		if err != nil {
+			return err
+		}
+	}
+	if job.Request.IncludeContacts && firstPage {
+		contacts, err := getConversationContacts(ctx, c.G(), conv)
+		if err != nil {
+			return err
+		}
+		err = writeArchiveContacts(path.Join(job.Request.OutputPath, c.archiveName(conv),
+			archiveContactsFilename), contacts)
+		if err != nil {
+			return err
+		}
+	}
+	width := 1024
+	if job.Request.LegalTranscript {
+		width = archiveLegalTranscriptWidth - archiveLegalTranscriptLineNumberWidth
+	}
+	if cp.Pagination.Last {
+		// Archived before the job was paused.
+		c.notifyConvPhase(ctx, job.Request.JobID, conv.Info.Id, chat1.ArchiveChatConvPhase_DONE)
+	}
 	for !cp.Pagination.Last {
+		// The same page again, as stored, before Pull below changes the
+		// pagination.
+		var rawMsgs []chat1.MessageUnboxed
+		if historyFile != nil {
+			pagination := cp.Pagination
+			raw, err := c.G().ConvSource.Pull(ctx, conv.Info.Id, c.uid,
+				chat1.GetThreadReason_ARCHIVE, nil,
+				&chat1.GetThreadQuery{
+					MarkAsRead:               false,
+					DisableResolveSupersedes: true,
+					After:                    job.Request.After,
+					Before:                   job.Request.Before,
+				}, &pagination)
+			if err != nil {
+				return err
+			}
+			rawMsgs, _ = filterArchiveRange(raw.Messages, job.Request.After, job.Request.Before)
+			rawMsgs, _ = filterArchiveAppend(rawMsgs, cp.AppendAfter)
+			for i, j := 0, len(rawMsgs)-1; i < j; i, j = i+1, j-1 {
+				rawMsgs[i], rawMsgs[j] = rawMsgs[j], rawMsgs[i]
+			}
+		}
+
+		pullDone := c.G().ExternalG().TimeTelemetry("chat.archive.page_pull")
 		thread, err := c.G().ConvSource.Pull(ctx, conv.Info.Id, c.uid,
 			chat1.GetThreadReason_ARCHIVE, nil,
 			&chat1.GetThreadQuery{
 				MarkAsRead: false,
+				After:      job.Request.After,
+				Before:     job.Request.Before,
 			}, &cp.Pagination)
+		pullDone()
 		if err != nil {
 			return err
 		}
 
-		msgs := thread.Messages
+		msgs, pastRange := filterArchiveRange(thread.Messages, job.Request.After, job.Request.Before)
+		msgs, pastNew := filterArchiveAppend(msgs, cp.AppendAfter)
+		cp.MaxMsgID = archiveMaxMsgID(cp.MaxMsgID, msgs)
 
 		// reverse the thread in place so we render in descending order in the file.
 		for i, j := 0, len(msgs)-1; i < j; i, j = i+1, j-1 {
@@ -581,9 +775,56 @@ func (c *ChatArchiver) archiveConv(ctx context.Context, job *chat1.ArchiveChatJo
 			},
 		}
 
-		err = view.RenderToWriter(c.G().GlobalContext, f, 1024, false)
-		if err != nil {
-			return err
+		if textFile != nil {
+			var buf bytes.Buffer
+			err = view.RenderToWriter(c.G().GlobalContext, &buf, width, false)
+			if err != nil {
+				return err
+			}
+			_, err = textFile.WriteString(redactor.Redact(buf.String()))
+			if err != nil {
+				return err
+			}
+		}
+		var jsonMsgs []archiveJSONMessage
+		if jsonFile != nil || c.database != nil {
+			jsonMsgs = newArchiveJSONMessages(msgs, redactor, func(msg chat1.MessageUnboxedValid) string {
+				if metadataOnly {
+					return ""
+				}
+				return c.attachmentPath(job.Request, msg, redactor)
+			}, time.Now())
+		}
+		if jsonFile != nil {
+			fileMsgs := jsonMsgs
+			if c.deduper != nil {
+				fileMsgs = c.deduper.dedupe(c.archiveName(conv), jsonMsgs)
+			}
+			lines, err := encodeArchiveJSONMessages(fileMsgs)
+			if err != nil {
+				return err
+			}
+			_, err = jsonFile.Write(lines)
+			if err != nil {
+				return err
+			}
+		}
+		if c.database != nil {
+			err = c.database.writeMessages(ctx, conv.Info.Id, jsonMsgs)
+			if err != nil {
+				return err
+			}
+		}
+		if historyFile != nil {
+			lines, err := encodeArchiveHistoryEvents(
+				newArchiveHistoryEvents(rawMsgs, redactor, time.Now()))
+			if err != nil {
+				return err
+			}
+			_, err = historyFile.Write(lines)
+			if err != nil {
+				return err
+			}
 		}
 
 		// Check for any attachment messages and download them alongside the chat.
@@ -591,6 +832,7 @@ func (c *ChatArchiver) archiveConv(ctx context.Context, job *chat1.ArchiveChatJo
 		// Fetch attachments in parallel but limit the number since we
 		// also allow parallel conv fetching.
 		eg.SetLimit(5)
+		downloading := false
 		for _, m := range msgs {
 			if !m.IsValidFull() {
 				continue
@@ -601,9 +843,42 @@ func (c *ChatArchiver) archiveConv(ctx context.Context, job *chat1.ArchiveChatJo
 			if err != nil {
 				return err
 			}
-			if typ == chat1.MessageType_ATTACHMENT {
+			if typ == chat1.MessageType_ATTACHMENT && metadataOnly {
+				attachmentInfos = appendArchiveAttachment(attachmentInfos,
+					newArchiveAttachmentInfo(msg, redactor))
+			} else if typ == chat1.MessageType_ATTACHMENT {
+				attachmentPath := path.Join(job.Request.OutputPath, c.archiveName(conv),
+					redactor.Redact(c.attachmentName(msg)))
+				obj := body.Attachment().Object
+				if reason := archiveAttachmentSkipReason(job.Request, obj); len(reason) > 0 {
+					info := newArchiveAttachmentInfo(msg, redactor)
+					info.Skipped = reason
+					err = writeArchiveAttachmentPlaceholder(attachmentPath+archiveSkippedAttachmentSuffix, info)
+					if err != nil {
+						return err
+					}
+					continue
+				}
+				var storeName string
+				if dedupe {
+					storeName = archiveAttachmentStoreName(obj, attachmentPath)
+				}
+				if len(storeName) > 0 {
+					info := newArchiveAttachmentInfo(msg, redactor)
+					info.Stored = archiveStoredAttachmentPath(storeName)
+					attachmentInfos = appendArchiveAttachment(attachmentInfos, info)
+					downloading = true
+					eg.Go(func() error {
+						storeDir := path.Join(job.Request.OutputPath, archiveAttachmentStoreDirname)
+						return storeArchiveAttachment(storeDir, storeName, obj.PtHash, func(sink io.WriteCloser) error {
+							return attachments.Download(ctx, c.G(), c.uid, conv.Info.Id,
+								msg.ServerHeader.MessageID, sink, false, func(_, _ int64) {}, c.remoteClient)
+						})
+					})
+					continue
+				}
+				downloading = true
 				eg.Go(func() error {
-					attachmentPath := path.Join(job.Request.OutputPath, c.archiveName(conv), c.attachmentName(msg))
 					f, err := os.Create(attachmentPath)
 					if err != nil {
 						return err
@@ -619,29 +894,89 @@ func (c *ChatArchiver) archiveConv(ctx context.Context, job *chat1.ArchiveChatJo
 				})
 			}
 		}
+		if downloading {
+			c.notifyConvPhase(ctx, job.Request.JobID, conv.Info.Id, chat1.ArchiveChatConvPhase_ATTACHMENTS)
+		}
 		err = eg.Wait()
 		if err != nil {
 			return err
 		}
+		if metadataOnly || dedupe {
+			err = writeArchiveAttachments(attachmentsPath, attachmentInfos)
+			if err != nil {
+				return err
+			}
+		}
+
+		if pastRange || pastNew {
+			// The rest of the conv is older than the range, or already
+			// archived.
+			thread.Pagination.Last = true
+		}
 
 		// update our progress percentage in the UI
-		c.notifyProgress(ctx, job.Request.JobID, *thread.Pagination)
+		c.notifyProgress(ctx, job.Request.JobID, conv.Info.Id, *thread.Pagination)
 
 		// update our pagination so we can correctly fetch the next page and marking progress in our checkpoint.
 		firstPage = false
 		cp.Pagination = *thread.Pagination
 		cp.Pagination.Num = c.pageSize
 		cp.Pagination.Previous = nil
-		ierr := c.checkpointConv(ctx, f, cp, conv.Info.Id, job)
+		ierr := c.checkpointConv(ctx, textFile, jsonFile, historyFile, cp, conv.Info.Id, job)
 		if ierr != nil {
 			c.Debug(ctx, ierr.Error())
 		}
 	}
+	if job.Request.LegalTranscript {
+		return c.writeLegalTranscript(ctx, conv, convArchivePath)
+	}
 	return nil
 }
 
 func (c *ChatArchiver) ArchiveChat(ctx context.Context, arg chat1.ArchiveChatJobRequest) (outpath string, err error) {
 	defer c.Trace(ctx, &err, "ArchiveChat")()
+	defer c.G().ExternalG().TimeTelemetry("chat.archive.job")()
+
+	if _, ok := chat1.ArchiveChatFormatRevMap[arg.Format]; !ok {
+		return "", fmt.Errorf("unknown archive format %v", arg.Format)
+	}
+	if arg.LegalTranscript && arg.Format == chat1.ArchiveChatFormat_JSON {
+		return "", errors.New("a legal transcript needs the text format")
+	}
+	if err := checkArchiveRange(arg.After, arg.Before); err != nil {
+		return "", err
+	}
+	if err := checkArchiveAttachmentOptions(arg); err != nil {
+		return "", err
+	}
+	if err := checkArchiveCompression(arg); err != nil {
+		return "", err
+	}
+	if err := checkArchiveEncryption(arg); err != nil {
+		return "", err
+	}
+	if err := checkArchiveDedupeMessages(arg); err != nil {
+		return "", err
+	}
+
+	jobInfo, err := c.G().ArchiveRegistry.Get(ctx, arg.JobID)
+	newJob := err != nil
+	if newJob {
+		if _, ok := err.(ArchiveJobNotFoundError); !ok {
+			return "", err
+		}
+	}
+	var appendTo chat1.ArchiveChatJob
+	if newJob && len(arg.AppendTo) > 0 {
+		appendTo, err = c.G().ArchiveRegistry.Get(ctx, arg.AppendTo)
+		if err != nil {
+			return "", err
+		}
+		arg, err = archiveAppendRequest(arg, appendTo)
+		if err != nil {
+			return "", err
+		}
+	}
 
 	if len(arg.OutputPath) == 0 {
 		arg.OutputPath = path.Join(c.G().GlobalContext.Env.GetDownloadsDir(), fmt.Sprintf("kbchat-%s", arg.JobID))
@@ -652,6 +987,12 @@ func (c *ChatArchiver) ArchiveChat(ctx context.Context, arg chat1.ArchiveChatJob
 	if err != nil {
 		return "", err
 	}
+	if arg.DedupeAttachments {
+		err = os.MkdirAll(path.Join(arg.OutputPath, archiveAttachmentStoreDirname), os.ModePerm)
+		if err != nil {
+			return "", err
+		}
+	}
 
 	// Resolve query to a set of convIDs.
 	iboxRes, _, err := c.G().InboxSource.Read(ctx, c.uid, types.ConversationLocalizerBlocking,
@@ -659,11 +1000,40 @@ func (c *ChatArchiver) ArchiveChat(ctx context.Context, arg chat1.ArchiveChatJob
 	if err != nil {
 		return "", err
 	}
-	convs := iboxRes.Convs
+	if newJob {
+		jobInfo = chat1.ArchiveChatJob{
+			Request:     arg,
+			StartedAt:   gregor1.ToTime(time.Now()),
+			Checkpoints: make(map[string]chat1.ArchiveChatConvCheckpoint),
+		}
+		if len(arg.AppendTo) > 0 {
+			jobInfo.Checkpoints = archiveAppendCheckpoints(appendTo, c.pageSize)
+		}
+		// Resumed jobs keep the rules they started with, so the whole
+		// export is redacted the same way.
+		jobInfo.AppliedRedactionRules, err = loadArchiveRedactionRules(ctx, c.G(), c.remoteClient, c.uid,
+			iboxRes.Convs)
+		if err != nil {
+			return "", err
+		}
+		if len(arg.AppendTo) > 0 {
+			jobInfo.AppliedRedactionRules = mergeArchiveRedactionRules(
+				appendTo.AppliedRedactionRules, jobInfo.AppliedRedactionRules)
+		}
+	}
 
-	// Fetch size of each conv to track progress.
-	for _, conv := range convs {
-		c.messagesTotal += int64(conv.MaxVisibleMsgID() - conv.GetMaxDeletedUpTo())
+	// Leave out the convs with nothing in the range, or nothing new to
+	// append.
+	convs := make([]chat1.ConversationLocal, 0, len(iboxRes.Convs))
+	for _, conv := range iboxRes.Convs {
+		estimate, appending := archiveJobMessagesEstimate(jobInfo, conv)
+		if estimate == 0 && (appending || arg.After != nil || arg.Before != nil) {
+			continue
+		}
+		convs = append(convs, conv)
+		// Fetch size of each conv to track progress.
+		c.messagesTotal += estimate
+		c.setConvProgress(conv, estimate)
 
 		convArchivePath := path.Join(arg.OutputPath, c.archiveName(conv))
 		err = os.MkdirAll(convArchivePath, os.ModePerm)
@@ -671,16 +1041,18 @@ func (c *ChatArchiver) ArchiveChat(ctx context.Context, arg chat1.ArchiveChatJob
 			return "", err
 		}
 	}
-
-	jobInfo, err := c.G().ArchiveRegistry.Get(ctx, arg.JobID)
-	if err != nil {
-		if _, ok := err.(ArchiveJobNotFoundError); !ok {
+	c.redactors = make(map[keybase1.TeamID]*archiveRedactor)
+	for _, applied := range jobInfo.AppliedRedactionRules {
+		c.redactors[applied.TeamID], err = newArchiveRedactor(applied.Rules)
+		if err != nil {
 			return "", err
 		}
-		jobInfo = chat1.ArchiveChatJob{
-			Request:     arg,
-			StartedAt:   gregor1.ToTime(time.Now()),
-			Checkpoints: make(map[string]chat1.ArchiveChatConvCheckpoint),
+	}
+	if len(jobInfo.AppliedRedactionRules) > 0 {
+		err = os.WriteFile(path.Join(arg.OutputPath, "redactions.txt"),
+			[]byte(formatArchiveRedactionSummary(jobInfo.AppliedRedactionRules)), libkb.PermFile)
+		if err != nil {
+			return "", err
 		}
 	}
 	// Presume to resume
@@ -724,6 +1096,8 @@ func (c *ChatArchiver) ArchiveChat(ctx context.Context, arg chat1.ArchiveChatJob
 		if err != nil {
 			jobInfo.Status = chat1.ArchiveChatJobStatus_ERROR
 			jobInfo.Err = err.Error()
+		} else {
+			jobInfo.Request.Passphrase = ""
 		}
 		ierr := c.G().ArchiveRegistry.Set(ctx, nil, jobInfo)
 		if ierr != nil {
@@ -734,8 +1108,47 @@ func (c *ChatArchiver) ArchiveChat(ctx context.Context, arg chat1.ArchiveChatJob
 		c.G().NotifyRouter.HandleChatArchiveComplete(ctx, arg.JobID)
 	}()
 
+	// Wait for other jobs writing to the same disk to finish, rather than
+	// running out of space halfway through.
+	mctx := libkb.NewMetaContext(ctx, c.G().GlobalContext)
+	reservationKey := fmt.Sprintf("chat-archive:%s", arg.JobID)
+	err = c.G().DiskSpaceReservations.Reserve(mctx, reservationKey, "chat archive "+string(arg.JobID),
+		[]keybase1.DiskSpaceNeed{{
+			Path:  arg.OutputPath,
+			Bytes: archiveDiskSpaceEstimate(arg, c.messagesTotal-jobInfo.MessagesComplete),
+		}}, true /* wait */, 0)
+	if err != nil {
+		return "", err
+	}
+	defer c.G().DiskSpaceReservations.Release(mctx, reservationKey)
+
+	if arg.DedupeMessages {
+		c.deduper = newArchiveMessageDeduper()
+		if !newJob || len(arg.AppendTo) > 0 {
+			convDirs := make([]string, 0, len(iboxRes.Convs))
+			for _, conv := range iboxRes.Convs {
+				convDirs = append(convDirs, c.archiveName(conv))
+			}
+			if err := c.deduper.load(arg.OutputPath, convDirs); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if arg.SqliteDatabase {
+		c.database, err = openArchiveDatabase(ctx, path.Join(arg.OutputPath, archiveDatabaseFilename))
+		if err != nil {
+			return "", err
+		}
+		defer func() {
+			if c.database != nil {
+				_ = c.database.Close()
+			}
+		}()
+	}
+
 	// For each conv, fetch batches of messages until all are fetched.
-	//    - Messages are rendered in a text format and attachments are downloaded to the archive path.
+	//    - Messages are rendered in a text format and/or as JSON lines and attachments are downloaded to the archive path.
 	eg.SetLimit(10)
 	for _, conv := range convs {
 		conv := conv
@@ -747,11 +1160,22 @@ func (c *ChatArchiver) ArchiveChat(ctx context.Context, arg chat1.ArchiveChatJob
 	if err != nil {
 		return "", err
 	}
+	if c.database != nil {
+		// Close it before it's compressed.
+		err = c.database.Close()
+		c.database = nil
+		if err != nil {
+			return "", err
+		}
+	}
 
-	outpath = arg.OutputPath
-	if arg.Compress {
-		outpath += ".tar.gzip"
-		err = tarGzip(arg.OutputPath, outpath)
+	outpath = arg.OutputPath + archiveOutputExt(arg)
+	if outpath != arg.OutputPath {
+		if arg.Encryption != chat1.ArchiveChatEncryption_NONE {
+			err = c.encryptArchive(ctx, arg, arg.OutputPath, outpath)
+		} else {
+			err = tarArchive(arg.OutputPath, outpath, arg.Compression, arg.CompressionLevel)
+		}
 		if err != nil {
 			return "", err
 		}
@@ -764,50 +1188,31 @@ func (c *ChatArchiver) ArchiveChat(ctx context.Context, arg chat1.ArchiveChatJob
 	return outpath, nil
 }
 
-func tarGzip(inPath, outPath string) error {
-	f, err := os.Create(outPath)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-
-	zr := gzip.NewWriter(f)
-	defer zr.Close()
-	tw := tar.NewWriter(zr)
-	defer tw.Close()
+// archiveEstimatedBytesPerMessage is roughly what a message takes up in an
+// archive. Attachments can take up far more, but there's no telling how
+// many there are without paging through the messages.
+const archiveEstimatedBytesPerMessage = 1024
 
-	err = filepath.Walk(inPath, func(fp string, fi os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-		header, err := tar.FileInfoHeader(fi, fp)
-		if err != nil {
-			return err
-		}
-		name, err := filepath.Rel(inPath, filepath.ToSlash(fp))
-		if err != nil {
-			return err
-		}
-		header.Name = name
-
-		if err := tw.WriteHeader(header); err != nil {
-			return err
-		}
-		if fi.IsDir() {
-			return nil
-		}
-		file, err := os.Open(fp)
-		if err != nil {
-			return err
-		}
-		defer file.Close()
-		if _, err := io.Copy(tw, file); err != nil {
-			return err
-		}
-		return nil
-	})
-	if err != nil {
-		return err
+// archiveDiskSpaceEstimate estimates the disk space needed to archive
+// messages more messages.
+func archiveDiskSpaceEstimate(req chat1.ArchiveChatJobRequest, messages int64) int64 {
+	if messages <= 0 {
+		return 0
 	}
-	return nil
+	copies := int64(1)
+	if req.Format == chat1.ArchiveChatFormat_BOTH {
+		copies++
+	}
+	if req.SqliteDatabase {
+		copies++
+	}
+	if req.IncludeHistory {
+		copies++
+	}
+	bytes := messages * archiveEstimatedBytesPerMessage * copies
+	if req.Compress || req.Encryption != chat1.ArchiveChatEncryption_NONE {
+		// The packed copy is written before the archive is removed.
+		bytes *= 2
+	}
+	return bytes
 }
@@ -0,0 +1,152 @@
+package chat
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// A job can append to the output of an earlier, completed one, to keep a
+// rolling archive without downloading everything again. It starts from the
+// earlier job's checkpoints, so each conversation's files are written on
+// from where they ended, and pages only go back as far as the newest
+// message they already have. Conversations the earlier job didn't have are
+// archived from the start. The appending job keeps the checkpoints, so it
+// can be appended to in turn.
+
+// checkArchiveAppendable checks that prev's output can be appended to, once
+// it's complete.
+func checkArchiveAppendable(prev chat1.ArchiveChatJob) error {
+	switch {
+	case prev.Request.Compress:
+		return fmt.Errorf("job %s's output was compressed, so it can't be appended to", prev.Request.JobID)
+	case prev.Request.Encryption != chat1.ArchiveChatEncryption_NONE:
+		return fmt.Errorf("job %s's output was encrypted, so it can't be appended to", prev.Request.JobID)
+	case prev.Request.Before != nil:
+		return fmt.Errorf("job %s only archived messages from before a date, so it can't be appended to",
+			prev.Request.JobID)
+	}
+	return nil
+}
+
+// archiveAppendRequest returns req, which appends to prev, with prev's
+// output path and the options for what it writes.
+func archiveAppendRequest(req chat1.ArchiveChatJobRequest, prev chat1.ArchiveChatJob) (
+	chat1.ArchiveChatJobRequest, error) {
+	if prev.Status != chat1.ArchiveChatJobStatus_COMPLETE {
+		return req, fmt.Errorf("job %s isn't complete, so it can't be appended to", prev.Request.JobID)
+	}
+	if err := checkArchiveAppendable(prev); err != nil {
+		return req, err
+	}
+	switch {
+	case req.Compress:
+		return req, errors.New("a job appending to another's output can't compress it")
+	case req.Encryption != chat1.ArchiveChatEncryption_NONE:
+		return req, errors.New("a job appending to another's output can't encrypt it")
+	case req.After != nil || req.Before != nil:
+		return req, errors.New("a job appending to another's output can't have a date range")
+	case len(req.OutputPath) > 0 && req.OutputPath != prev.Request.OutputPath:
+		return req, fmt.Errorf("a job appending to %s has to write to its output path, %s",
+			prev.Request.JobID, prev.Request.OutputPath)
+	}
+	req.OutputPath = prev.Request.OutputPath
+	if req.Query == nil {
+		req.Query = prev.Request.Query
+	}
+	req.Format = prev.Request.Format
+	req.AttachmentsMetadataOnly = prev.Request.AttachmentsMetadataOnly
+	req.SkipAttachments = prev.Request.SkipAttachments
+	req.MaxAttachmentSize = prev.Request.MaxAttachmentSize
+	req.AttachmentMimeTypes = prev.Request.AttachmentMimeTypes
+	req.DedupeAttachments = prev.Request.DedupeAttachments
+	req.DedupeMessages = prev.Request.DedupeMessages
+	req.IncludeContacts = prev.Request.IncludeContacts
+	req.LegalTranscript = prev.Request.LegalTranscript
+	req.SqliteDatabase = prev.Request.SqliteDatabase
+	req.IncludeHistory = prev.Request.IncludeHistory
+	req.After = prev.Request.After
+	return req, nil
+}
+
+// archiveAppendCheckpoints returns the checkpoints for a job appending to
+// prev's output.
+func archiveAppendCheckpoints(prev chat1.ArchiveChatJob, pageSize int) map[string]chat1.ArchiveChatConvCheckpoint {
+	res := make(map[string]chat1.ArchiveChatConvCheckpoint, len(prev.Checkpoints))
+	for convID, cp := range prev.Checkpoints {
+		cp.Pagination = chat1.Pagination{Num: pageSize}
+		cp.AppendAfter = cp.MaxMsgID
+		res[convID] = cp
+	}
+	return res
+}
+
+// filterArchiveAppend returns the messages in msgs newer than after, and
+// whether any of them weren't, in which case there's nothing new on older
+// pages.
+func filterArchiveAppend(msgs []chat1.MessageUnboxed, after chat1.MessageID) (
+	res []chat1.MessageUnboxed, pastNew bool) {
+	if after == 0 {
+		return msgs, false
+	}
+	res = make([]chat1.MessageUnboxed, 0, len(msgs))
+	for _, m := range msgs {
+		if m.GetMessageID() <= after {
+			pastNew = true
+			continue
+		}
+		res = append(res, m)
+	}
+	return res, pastNew
+}
+
+// archiveMaxMsgID returns the newest of newest and the messages in msgs.
+func archiveMaxMsgID(newest chat1.MessageID, msgs []chat1.MessageUnboxed) chat1.MessageID {
+	for _, m := range msgs {
+		if id := m.GetMessageID(); id > newest {
+			newest = id
+		}
+	}
+	return newest
+}
+
+// archiveAppendMessagesEstimate estimates how many of conv's messages are
+// newer than after.
+func archiveAppendMessagesEstimate(conv chat1.ConversationLocal, after chat1.MessageID) int64 {
+	if conv.MaxVisibleMsgID() <= after {
+		return 0
+	}
+	return int64(conv.MaxVisibleMsgID() - after)
+}
+
+// archiveJobMessagesEstimate estimates how many of conv's messages job
+// archives, and whether it's appending them to what an earlier job
+// archived.
+func archiveJobMessagesEstimate(job chat1.ArchiveChatJob, conv chat1.ConversationLocal) (
+	estimate int64, appending bool) {
+	cp, ok := job.Checkpoints[conv.Info.Id.DbShortFormString()]
+	if ok && cp.AppendAfter > 0 {
+		return archiveAppendMessagesEstimate(conv, cp.AppendAfter), true
+	}
+	return archiveRangeMessagesEstimate(conv, job.Request.After, job.Request.Before), false
+}
+
+// mergeArchiveRedactionRules returns the rules the earlier job applied, and
+// those in loaded for the teams it had none for. Teams keep the rules the
+// earlier job applied, so the whole archive is redacted the same way.
+func mergeArchiveRedactionRules(prev, loaded []chat1.ArchiveAppliedRedactionRules) (
+	res []chat1.ArchiveAppliedRedactionRules) {
+	res = append(res, prev...)
+	seen := make(map[keybase1.TeamID]bool, len(prev))
+	for _, applied := range prev {
+		seen[applied.TeamID] = true
+	}
+	for _, applied := range loaded {
+		if !seen[applied.TeamID] {
+			res = append(res, applied)
+		}
+	}
+	return res
+}
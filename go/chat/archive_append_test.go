@@ -0,0 +1,120 @@
+package chat
+
+import (
+	"testing"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveAppend(t *testing.T) {
+	conv := func(id string, maxMsgID chat1.MessageID) chat1.ConversationLocal {
+		return chat1.ConversationLocal{
+			Info:        chat1.ConversationInfoLocal{Id: chat1.ConversationID(id)},
+			MaxMessages: []chat1.MessageSummary{{MsgID: maxMsgID, MessageType: chat1.MessageType_TEXT}},
+		}
+	}
+	key := conv("a", 0).Info.Id.DbShortFormString()
+	prevQuery := &chat1.GetInboxLocalQuery{}
+	prev := chat1.ArchiveChatJob{
+		Request: chat1.ArchiveChatJobRequest{
+			JobID:          "arc-1",
+			OutputPath:     "/tmp/kbchat-arc-1",
+			Query:          prevQuery,
+			Format:         chat1.ArchiveChatFormat_BOTH,
+			SqliteDatabase: true,
+			IncludeHistory: true,
+			DedupeMessages: true,
+		},
+		Status: chat1.ArchiveChatJobStatus_COMPLETE,
+		Checkpoints: map[string]chat1.ArchiveChatConvCheckpoint{
+			key: {
+				Pagination: chat1.Pagination{Num: 10, Last: true},
+				Offset:     100,
+				JsonOffset: 200,
+				MaxMsgID:   42,
+			},
+		},
+	}
+
+	req, err := archiveAppendRequest(chat1.ArchiveChatJobRequest{JobID: "arc-2", AppendTo: "arc-1"}, prev)
+	require.NoError(t, err)
+	require.Equal(t, prev.Request.OutputPath, req.OutputPath)
+	require.Equal(t, prevQuery, req.Query)
+	require.Equal(t, chat1.ArchiveChatFormat_BOTH, req.Format)
+	require.True(t, req.SqliteDatabase)
+	require.True(t, req.IncludeHistory)
+	require.True(t, req.DedupeMessages)
+	require.Equal(t, chat1.ArchiveJobID("arc-2"), req.JobID)
+
+	for _, bad := range []chat1.ArchiveChatJobRequest{
+		{OutputPath: "/tmp/elsewhere"},
+		{Compress: true},
+		{Encryption: chat1.ArchiveChatEncryption_SELF},
+		{After: &prev.StartedAt},
+	} {
+		_, err = archiveAppendRequest(bad, prev)
+		require.Error(t, err)
+	}
+	running := prev
+	running.Status = chat1.ArchiveChatJobStatus_RUNNING
+	_, err = archiveAppendRequest(chat1.ArchiveChatJobRequest{}, running)
+	require.Error(t, err)
+	compressed := prev
+	compressed.Request.Compress = true
+	_, err = archiveAppendRequest(chat1.ArchiveChatJobRequest{}, compressed)
+	require.Error(t, err)
+	encrypted := prev
+	encrypted.Request.Encryption = chat1.ArchiveChatEncryption_SELF
+	_, err = archiveAppendRequest(chat1.ArchiveChatJobRequest{}, encrypted)
+	require.Error(t, err)
+
+	// Carries on writing where the files end, after the newest message.
+	cps := archiveAppendCheckpoints(prev, 50)
+	require.Equal(t, chat1.ArchiveChatConvCheckpoint{
+		Pagination:  chat1.Pagination{Num: 50},
+		Offset:      100,
+		JsonOffset:  200,
+		MaxMsgID:    42,
+		AppendAfter: 42,
+	}, cps[key])
+	job := chat1.ArchiveChatJob{Checkpoints: cps}
+	estimate, appending := archiveJobMessagesEstimate(job, conv("a", 50))
+	require.True(t, appending)
+	require.Equal(t, int64(8), estimate)
+	estimate, appending = archiveJobMessagesEstimate(job, conv("a", 40))
+	require.True(t, appending)
+	require.Zero(t, estimate)
+	estimate, appending = archiveJobMessagesEstimate(job, conv("b", 40))
+	require.False(t, appending)
+	require.Equal(t, int64(40), estimate)
+
+	msg := func(id chat1.MessageID) chat1.MessageUnboxed {
+		return chat1.NewMessageUnboxedWithValid(chat1.MessageUnboxedValid{
+			ServerHeader: chat1.MessageServerHeader{MessageID: id, Ctime: gregor1.Time(id)},
+		})
+	}
+	// Newest first, like a page.
+	page := []chat1.MessageUnboxed{msg(44), msg(43), msg(42), msg(41)}
+	res, pastNew := filterArchiveAppend(page, 42)
+	require.True(t, pastNew)
+	require.Len(t, res, 2)
+	require.Equal(t, chat1.MessageID(44), archiveMaxMsgID(42, res))
+	require.Equal(t, chat1.MessageID(50), archiveMaxMsgID(50, res))
+	res, pastNew = filterArchiveAppend(page, 0)
+	require.False(t, pastNew)
+	require.Len(t, res, 4)
+
+	rules := func(teamID keybase1.TeamID, pattern string) chat1.ArchiveAppliedRedactionRules {
+		return chat1.ArchiveAppliedRedactionRules{
+			TeamID: teamID,
+			Rules:  []chat1.ArchiveRedactionRule{{Name: "r", Pattern: pattern}},
+		}
+	}
+	merged := mergeArchiveRedactionRules(
+		[]chat1.ArchiveAppliedRedactionRules{rules("t1", "old")},
+		[]chat1.ArchiveAppliedRedactionRules{rules("t1", "new"), rules("t2", "other")})
+	require.Equal(t, []chat1.ArchiveAppliedRedactionRules{rules("t1", "old"), rules("t2", "other")}, merged)
+}
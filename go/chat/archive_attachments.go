@@ -0,0 +1,231 @@
+package chat
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"mime"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+)
+
+// In attachments metadata-only mode, an archive doesn't download attachments.
+// Instead each conversation gets an attachments.json next to its chat.txt,
+// listing every attachment with enough detail (sender, size, hashes) to match
+// it up with a copy of the original later.
+//
+// Jobs can also skip downloading some attachments, or all of them, to fit
+// media-heavy teams on small disks. Each one skipped gets a placeholder
+// where it would have been, with the same details and why it was skipped.
+//
+// With attachments deduplicated, the same file forwarded to many
+// conversations is only downloaded and kept once. Each attachment goes in
+// an attachments directory at the top of the output, named by the SHA-256
+// sum it was sent with, and each conversation's attachments.json lists its
+// attachments with where they are there. Very old attachments don't have a
+// sum to go by, so they're downloaded to the conversation's directory as
+// usual.
+
+const archiveAttachmentsFilename = "attachments.json"
+
+const archiveAttachmentStoreDirname = "attachments"
+
+const archiveSkippedAttachmentSuffix = ".skipped.json"
+
+type archiveAttachmentInfo struct {
+	MessageID chat1.MessageID `json:"messageID"`
+	Sent      time.Time       `json:"sent"`
+	Sender    string          `json:"sender"`
+	Filename  string          `json:"filename"`
+	Title     string          `json:"title,omitempty"`
+	MimeType  string          `json:"mimeType,omitempty"`
+	Size      int64           `json:"size"`
+	// Hex SHA-256 hashes of the file and of its encrypted upload. Very old
+	// attachments don't have a plaintext hash.
+	PlaintextSHA256 string `json:"plaintextSHA256,omitempty"`
+	EncryptedSHA256 string `json:"encryptedSHA256,omitempty"`
+	// Why it wasn't downloaded, in placeholders.
+	Skipped string `json:"skipped,omitempty"`
+	// Where it's kept, relative to the conversation's directory, when
+	// attachments are deduplicated.
+	Stored string `json:"stored,omitempty"`
+}
+
+func newArchiveAttachmentInfo(msg chat1.MessageUnboxedValid, redactor *archiveRedactor) archiveAttachmentInfo {
+	obj := msg.MessageBody.Attachment().Object
+	return archiveAttachmentInfo{
+		MessageID:       msg.ServerHeader.MessageID,
+		Sent:            gregor1.FromTime(msg.ServerHeader.Ctime),
+		Sender:          msg.SenderUsername,
+		Filename:        redactor.Redact(obj.Filename),
+		Title:           redactor.Redact(obj.Title),
+		MimeType:        obj.MimeType,
+		Size:            obj.Size,
+		PlaintextSHA256: hex.EncodeToString(obj.PtHash),
+		EncryptedSHA256: hex.EncodeToString(obj.EncHash),
+	}
+}
+
+// appendArchiveAttachment adds info to infos, unless it's already there
+// because we're redoing a page after a resume.
+func appendArchiveAttachment(infos []archiveAttachmentInfo, info archiveAttachmentInfo) []archiveAttachmentInfo {
+	for _, existing := range infos {
+		if existing.MessageID == info.MessageID {
+			return infos
+		}
+	}
+	return append(infos, info)
+}
+
+// readArchiveAttachments loads the attachments written so far by a job we're
+// resuming.
+func readArchiveAttachments(p string) ([]archiveAttachmentInfo, error) {
+	buf, err := os.ReadFile(p)
+	if os.IsNotExist(err) {
+		return []archiveAttachmentInfo{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	infos := []archiveAttachmentInfo{}
+	if err := json.Unmarshal(buf, &infos); err != nil {
+		return nil, err
+	}
+	return infos, nil
+}
+
+func writeArchiveAttachments(p string, infos []archiveAttachmentInfo) error {
+	buf, err := json.MarshalIndent(infos, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p, buf, libkb.PermFile)
+}
+
+func checkArchiveAttachmentOptions(req chat1.ArchiveChatJobRequest) error {
+	if req.MaxAttachmentSize < 0 {
+		return errors.New("the maximum attachment size can't be negative")
+	}
+	if req.DedupeAttachments && req.AttachmentsMetadataOnly {
+		return errors.New("attachments can't be deduplicated when they aren't downloaded")
+	}
+	for _, pattern := range req.AttachmentMimeTypes {
+		if !strings.Contains(pattern, "/") {
+			return fmt.Errorf("invalid MIME type %q; use one like image/* or application/pdf", pattern)
+		}
+	}
+	return nil
+}
+
+// matchArchiveMimeType is whether mimeType matches one of patterns, which
+// are MIME types or, like image/*, whole top-level types.
+func matchArchiveMimeType(mimeType string, patterns []string) bool {
+	if mediaType, _, err := mime.ParseMediaType(mimeType); err == nil {
+		mimeType = mediaType
+	}
+	mimeType = strings.ToLower(mimeType)
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		if prefix := strings.TrimSuffix(pattern, "*"); prefix != pattern {
+			if strings.HasPrefix(mimeType, prefix) {
+				return true
+			}
+		} else if mimeType == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// archiveAttachmentSkipReason returns why the job doesn't download an
+// attachment, or "" if it does.
+func archiveAttachmentSkipReason(req chat1.ArchiveChatJobRequest, obj chat1.Asset) string {
+	switch {
+	case req.SkipAttachments:
+		return "attachments weren't archived"
+	case req.MaxAttachmentSize > 0 && obj.Size > req.MaxAttachmentSize:
+		return fmt.Sprintf("larger than the %s limit", humanize.Bytes(uint64(req.MaxAttachmentSize)))
+	case len(req.AttachmentMimeTypes) > 0 && !matchArchiveMimeType(obj.MimeType, req.AttachmentMimeTypes):
+		return fmt.Sprintf("only %s attachments were archived", strings.Join(req.AttachmentMimeTypes, ", "))
+	}
+	return ""
+}
+
+func writeArchiveAttachmentPlaceholder(p string, info archiveAttachmentInfo) error {
+	buf, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p, buf, libkb.PermFile)
+}
+
+// archiveAttachmentStoreName returns the name of obj's file in the
+// attachment store, keeping the extension of name, where it would go
+// otherwise. It's "" if obj doesn't have a sum to go by.
+func archiveAttachmentStoreName(obj chat1.Asset, name string) string {
+	if len(obj.PtHash) == 0 {
+		return ""
+	}
+	return hex.EncodeToString(obj.PtHash) + strings.ToLower(path.Ext(name))
+}
+
+// archiveStoredAttachmentPath returns where the store's storeName is,
+// relative to a conversation's directory.
+func archiveStoredAttachmentPath(storeName string) string {
+	return path.Join("..", archiveAttachmentStoreDirname, storeName)
+}
+
+// archiveHashingFile hashes what's written to it.
+type archiveHashingFile struct {
+	*os.File
+	hash hash.Hash
+}
+
+func (f archiveHashingFile) Write(p []byte) (int, error) {
+	n, err := f.File.Write(p)
+	f.hash.Write(p[:n])
+	return n, err
+}
+
+// storeArchiveAttachment downloads an attachment with download into the
+// store at storeDir as storeName, unless it's already there. It's checked
+// against sum, what it was sent with, before it's kept.
+func storeArchiveAttachment(storeDir, storeName string, sum chat1.Hash,
+	download func(io.WriteCloser) error) error {
+	storePath := path.Join(storeDir, storeName)
+	if _, err := os.Stat(storePath); err == nil {
+		return nil
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	// Another conversation might download the same one at the same time;
+	// whichever renames its download last replaces the other's with the
+	// same thing.
+	f, err := os.CreateTemp(storeDir, ".download-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	h := sha256.New()
+	err = download(archiveHashingFile{File: f, hash: h})
+	f.Close()
+	if err != nil {
+		return err
+	}
+	if actual := h.Sum(nil); !bytes.Equal(actual, sum) {
+		return fmt.Errorf("attachment %s downloaded with SHA-256 %x, not the %x it was sent with",
+			storeName, actual, []byte(sum))
+	}
+	return os.Rename(f.Name(), storePath)
+}
@@ -0,0 +1,144 @@
+package chat
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveAttachmentsMetadata(t *testing.T) {
+	sent := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	msg := chat1.MessageUnboxedValid{
+		ServerHeader: chat1.MessageServerHeader{
+			MessageID: 12,
+			Ctime:     gregor1.ToTime(sent),
+		},
+		SenderUsername: "alice",
+		MessageBody: chat1.NewMessageBodyWithAttachment(chat1.MessageAttachment{
+			Object: chat1.Asset{
+				Filename: "OPS-12 notes.pdf",
+				Title:    "notes for OPS-12",
+				MimeType: "application/pdf",
+				Size:     1234,
+				PtHash:   chat1.Hash{0xab, 0xcd},
+				EncHash:  chat1.Hash{0x01, 0x02},
+			},
+		}),
+	}
+	redactor, err := newArchiveRedactor([]chat1.ArchiveRedactionRule{
+		{Name: "ticket", Pattern: `OPS-[0-9]+`},
+	})
+	require.NoError(t, err)
+
+	info := newArchiveAttachmentInfo(msg, redactor)
+	require.True(t, sent.Equal(info.Sent))
+	require.Equal(t, archiveAttachmentInfo{
+		MessageID:       12,
+		Sent:            info.Sent,
+		Sender:          "alice",
+		Filename:        "[REDACTED] notes.pdf",
+		Title:           "notes for [REDACTED]",
+		MimeType:        "application/pdf",
+		Size:            1234,
+		PlaintextSHA256: "abcd",
+		EncryptedSHA256: "0102",
+	}, info)
+
+	infos := appendArchiveAttachment(nil, info)
+	infos = appendArchiveAttachment(infos, info)
+	require.Len(t, infos, 1)
+
+	dir := t.TempDir()
+	p := filepath.Join(dir, archiveAttachmentsFilename)
+	empty, err := readArchiveAttachments(p)
+	require.NoError(t, err)
+	require.Empty(t, empty)
+
+	require.NoError(t, writeArchiveAttachments(p, infos))
+	_, err = os.Stat(p)
+	require.NoError(t, err)
+	read, err := readArchiveAttachments(p)
+	require.NoError(t, err)
+	require.Len(t, read, 1)
+	require.Equal(t, "[REDACTED] notes.pdf", read[0].Filename)
+	require.True(t, sent.Equal(read[0].Sent))
+}
+
+func TestArchiveAttachmentSkipReason(t *testing.T) {
+	image := chat1.Asset{MimeType: "image/jpeg", Size: 2 << 20}
+	video := chat1.Asset{MimeType: "video/mp4; codecs=avc1", Size: 200 << 20}
+	pdf := chat1.Asset{MimeType: "application/pdf", Size: 1 << 10}
+
+	var req chat1.ArchiveChatJobRequest
+	for _, obj := range []chat1.Asset{image, video, pdf} {
+		require.Empty(t, archiveAttachmentSkipReason(req, obj))
+	}
+
+	req.MaxAttachmentSize = 10 << 20
+	require.Empty(t, archiveAttachmentSkipReason(req, image))
+	require.Contains(t, archiveAttachmentSkipReason(req, video), "larger than")
+
+	req = chat1.ArchiveChatJobRequest{AttachmentMimeTypes: []string{"image/*", "Application/PDF"}}
+	require.NoError(t, checkArchiveAttachmentOptions(req))
+	require.Empty(t, archiveAttachmentSkipReason(req, image))
+	require.Empty(t, archiveAttachmentSkipReason(req, pdf))
+	require.NotEmpty(t, archiveAttachmentSkipReason(req, video))
+	req.AttachmentMimeTypes = []string{"video/mp4"}
+	require.Empty(t, archiveAttachmentSkipReason(req, video))
+
+	req = chat1.ArchiveChatJobRequest{SkipAttachments: true}
+	require.NotEmpty(t, archiveAttachmentSkipReason(req, pdf))
+
+	require.Error(t, checkArchiveAttachmentOptions(chat1.ArchiveChatJobRequest{MaxAttachmentSize: -1}))
+	require.Error(t, checkArchiveAttachmentOptions(chat1.ArchiveChatJobRequest{AttachmentMimeTypes: []string{"image"}}))
+}
+
+func TestArchiveAttachmentStore(t *testing.T) {
+	content := []byte("the same forwarded file")
+	sum := sha256.Sum256(content)
+	obj := chat1.Asset{Filename: "report.PDF", PtHash: chat1.Hash(sum[:])}
+	storeName := archiveAttachmentStoreName(obj, "2024-03-01 12.00.00 (12) - report.PDF")
+	require.Equal(t, hex.EncodeToString(sum[:])+".pdf", storeName)
+	require.Equal(t, "../attachments/"+storeName, archiveStoredAttachmentPath(storeName))
+	require.Empty(t, archiveAttachmentStoreName(chat1.Asset{Filename: "old.jpg"}, "old.jpg"))
+
+	storeDir := t.TempDir()
+	downloads := 0
+	download := func(sink io.WriteCloser) error {
+		downloads++
+		_, err := sink.Write(content)
+		if err != nil {
+			return err
+		}
+		return sink.Close()
+	}
+	// The second conversation to have it finds it already there.
+	for i := 0; i < 2; i++ {
+		require.NoError(t, storeArchiveAttachment(storeDir, storeName, obj.PtHash, download))
+	}
+	require.Equal(t, 1, downloads)
+	stored, err := os.ReadFile(filepath.Join(storeDir, storeName))
+	require.NoError(t, err)
+	require.Equal(t, content, stored)
+
+	// One that doesn't match its sum isn't kept.
+	other := sha256.Sum256([]byte("something else"))
+	err = storeArchiveAttachment(storeDir, "other.pdf", chat1.Hash(other[:]), download)
+	require.Error(t, err)
+	entries, err := os.ReadDir(storeDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	require.Error(t, checkArchiveAttachmentOptions(chat1.ArchiveChatJobRequest{
+		AttachmentsMetadataOnly: true,
+		DedupeAttachments:       true,
+	}))
+}
@@ -0,0 +1,176 @@
+package chat
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/klauspost/compress/zstd"
+)
+
+// A compressed job's output is packed into one tarball once it's complete,
+// compressed with gzip, or zstd, which is much faster on media-heavy
+// archives, or not at all. Whatever reads a tarball back tells which it is
+// from its first bytes.
+
+const archiveMaxZstdLevel = 22
+
+var (
+	archiveGzipMagic = []byte{0x1f, 0x8b}
+	archiveZstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+func checkArchiveCompression(req chat1.ArchiveChatJobRequest) error {
+	if !req.Compress {
+		if req.Compression != chat1.ArchiveChatCompression_GZIP || req.CompressionLevel != 0 {
+			return errors.New("a compression codec or level needs the output to be compressed")
+		}
+		return nil
+	}
+	level := req.CompressionLevel
+	switch req.Compression {
+	case chat1.ArchiveChatCompression_GZIP:
+		if level < 0 || level > gzip.BestCompression {
+			return fmt.Errorf("gzip compression levels go from 1 to %d", gzip.BestCompression)
+		}
+	case chat1.ArchiveChatCompression_ZSTD:
+		if level < 0 || level > archiveMaxZstdLevel {
+			return fmt.Errorf("zstd compression levels go from 1 to %d", archiveMaxZstdLevel)
+		}
+	case chat1.ArchiveChatCompression_NONE:
+		if level != 0 {
+			return errors.New("an uncompressed tarball has no compression level")
+		}
+	default:
+		return fmt.Errorf("unknown archive compression %v", req.Compression)
+	}
+	return nil
+}
+
+// archiveCompressedExt is what's added to the output path of a job's
+// tarball.
+func archiveCompressedExt(compression chat1.ArchiveChatCompression) string {
+	switch compression {
+	case chat1.ArchiveChatCompression_ZSTD:
+		return ".tar.zst"
+	case chat1.ArchiveChatCompression_NONE:
+		return ".tar"
+	default:
+		return ".tar.gzip"
+	}
+}
+
+func newArchiveCompressor(w io.Writer, compression chat1.ArchiveChatCompression,
+	level int) (io.WriteCloser, error) {
+	switch compression {
+	case chat1.ArchiveChatCompression_GZIP:
+		if level == 0 {
+			level = gzip.DefaultCompression
+		}
+		return gzip.NewWriterLevel(w, level)
+	case chat1.ArchiveChatCompression_ZSTD:
+		var opts []zstd.EOption
+		if level != 0 {
+			opts = append(opts, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
+		}
+		return zstd.NewWriter(w, opts...)
+	case chat1.ArchiveChatCompression_NONE:
+		return nopWriteCloser{w}, nil
+	default:
+		return nil, fmt.Errorf("unknown archive compression %v", compression)
+	}
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+// newArchiveDecompressor returns the tarball in r, however it was
+// compressed.
+func newArchiveDecompressor(r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	// A short read just means it's too small to be compressed.
+	magic, _ := br.Peek(len(archiveZstdMagic))
+	switch {
+	case bytes.HasPrefix(magic, archiveGzipMagic):
+		return gzip.NewReader(br)
+	case bytes.HasPrefix(magic, archiveZstdMagic):
+		zr, err := zstd.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		return zr.IOReadCloser(), nil
+	default:
+		return io.NopCloser(br), nil
+	}
+}
+
+// tarArchive packs the directory inPath into a tarball at outPath.
+func tarArchive(inPath, outPath string, compression chat1.ArchiveChatCompression, level int) (err error) {
+	f, err := os.Create(outPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	return writeTarArchive(inPath, f, compression, level)
+}
+
+// writeTarArchive packs the directory inPath into a tarball written to w.
+func writeTarArchive(inPath string, w io.Writer, compression chat1.ArchiveChatCompression, level int) error {
+	zw, err := newArchiveCompressor(w, compression, level)
+	if err != nil {
+		return err
+	}
+	tw := tar.NewWriter(zw)
+
+	err = filepath.Walk(inPath, func(fp string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(fi, fp)
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(inPath, filepath.ToSlash(fp))
+		if err != nil {
+			return err
+		}
+		header.Name = name
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			return nil
+		}
+		file, err := os.Open(fp)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		if _, err := io.Copy(tw, file); err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return zw.Close()
+}
@@ -0,0 +1,75 @@
+package chat
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveCompression(t *testing.T) {
+	req := chat1.ArchiveChatJobRequest{}
+	require.NoError(t, checkArchiveCompression(req))
+	req.Compression = chat1.ArchiveChatCompression_ZSTD
+	require.Error(t, checkArchiveCompression(req))
+	req.Compress = true
+	req.CompressionLevel = 19
+	require.NoError(t, checkArchiveCompression(req))
+	req.CompressionLevel = 23
+	require.Error(t, checkArchiveCompression(req))
+	req.Compression = chat1.ArchiveChatCompression_GZIP
+	req.CompressionLevel = 9
+	require.NoError(t, checkArchiveCompression(req))
+	req.CompressionLevel = 10
+	require.Error(t, checkArchiveCompression(req))
+	req.Compression = chat1.ArchiveChatCompression_NONE
+	require.Error(t, checkArchiveCompression(req))
+	req.CompressionLevel = 0
+	require.NoError(t, checkArchiveCompression(req))
+
+	dir := t.TempDir()
+	inPath := filepath.Join(dir, "out")
+	require.NoError(t, os.MkdirAll(filepath.Join(inPath, "alice,bob"), 0700))
+	contents := bytes.Repeat([]byte("hello\n"), 1000)
+	require.NoError(t, os.WriteFile(filepath.Join(inPath, "alice,bob", "chat.txt"), contents, 0600))
+	for _, test := range []struct {
+		compression chat1.ArchiveChatCompression
+		level       int
+	}{
+		{chat1.ArchiveChatCompression_GZIP, 0},
+		{chat1.ArchiveChatCompression_GZIP, 1},
+		{chat1.ArchiveChatCompression_ZSTD, 0},
+		{chat1.ArchiveChatCompression_ZSTD, 19},
+		{chat1.ArchiveChatCompression_NONE, 0},
+	} {
+		outPath := inPath + archiveCompressedExt(test.compression)
+		require.NoError(t, tarArchive(inPath, outPath, test.compression, test.level))
+		f, err := os.Open(outPath)
+		require.NoError(t, err)
+		zr, err := newArchiveDecompressor(f)
+		require.NoError(t, err)
+		tr := tar.NewReader(zr)
+		found := false
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			if header.Name == "alice,bob/chat.txt" {
+				buf, err := io.ReadAll(tr)
+				require.NoError(t, err)
+				require.Equal(t, contents, buf)
+				found = true
+			}
+		}
+		require.True(t, found, "%v", test.compression)
+		require.NoError(t, zr.Close())
+		require.NoError(t, f.Close())
+	}
+}
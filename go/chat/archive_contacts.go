@@ -0,0 +1,184 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/externals"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/keybase/client/go/uidmap"
+)
+
+// Contact export turns the participants of a conversation into vCards, for
+// importing into an address book or CRM. Each card has the participant's
+// full name and username, and URLs for their Keybase profile and each of
+// their proofs. Archives can write them to a participants.vcf next to each
+// conversation's chat.txt.
+
+const archiveContactsFilename = "participants.vcf"
+
+// vCard lines longer than this many bytes get folded.
+const vcardMaxLineBytes = 75
+
+type conversationContact struct {
+	Username string
+	FullName string
+	URLs     []string
+}
+
+func vcardEscape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		",", `\,`,
+		";", `\;`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(s)
+}
+
+// vcardFold splits a line into CRLF-terminated lines of at most
+// vcardMaxLineBytes each, continuing them with a leading space, without
+// breaking up any UTF-8 characters.
+func vcardFold(line string) string {
+	var b strings.Builder
+	limit := vcardMaxLineBytes
+	for len(line) > limit {
+		i := limit
+		for i > 0 && !utf8.RuneStart(line[i]) {
+			i--
+		}
+		b.WriteString(line[:i])
+		b.WriteString("\r\n ")
+		line = line[i:]
+		// Leave room for the leading space.
+		limit = vcardMaxLineBytes - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+	return b.String()
+}
+
+// vcard renders the contact as a vCard 3.0 card.
+func (c conversationContact) vcard() string {
+	name := c.FullName
+	if len(name) == 0 {
+		name = c.Username
+	}
+	lines := []string{
+		"BEGIN:VCARD",
+		"VERSION:3.0",
+		"FN:" + vcardEscape(name),
+		"N:;" + vcardEscape(name) + ";;;",
+		"NICKNAME:" + vcardEscape(c.Username),
+	}
+	for _, u := range c.URLs {
+		// URLs aren't escaped as text values are, but a stray newline would
+		// still break the card.
+		lines = append(lines, "URL:"+strings.NewReplacer("\r", "", "\n", "").Replace(u))
+	}
+	lines = append(lines, "END:VCARD")
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(vcardFold(line))
+	}
+	return b.String()
+}
+
+func formatVCards(contacts []conversationContact) string {
+	var b strings.Builder
+	for _, c := range contacts {
+		b.WriteString(c.vcard())
+	}
+	return b.String()
+}
+
+// proofURL returns a URL for the proof of the given service (the key from a
+// service summary) and remote username, or "" if we don't know one.
+func proofURL(ctx context.Context, g *globals.Context, service, value string) string {
+	switch service {
+	case "twitter":
+		return fmt.Sprintf("https://twitter.com/%s", value)
+	case "github":
+		return fmt.Sprintf("https://github.com/%s", value)
+	case "reddit":
+		return fmt.Sprintf("https://reddit.com/user/%s", value)
+	case "hackernews":
+		return fmt.Sprintf("https://news.ycombinator.com/user?id=%s", value)
+	case "facebook":
+		return fmt.Sprintf("https://facebook.com/%s", value)
+	case "https:", "http:":
+		// Web proofs are keyed by their protocol.
+		return fmt.Sprintf("%s//%s", service, value)
+	case "dns":
+		return fmt.Sprintf("http://%s", value)
+	}
+	if g.GetProofServices() == nil {
+		return ""
+	}
+	serviceType := g.GetProofServices().GetServiceType(ctx, service)
+	if serviceType, ok := serviceType.(*externals.GenericSocialProofServiceType); ok {
+		profileURL, err := serviceType.ProfileURL(value)
+		if err == nil {
+			return profileURL
+		}
+	}
+	return ""
+}
+
+// getConversationContacts returns a contact for each participant of conv.
+// Proofs come from the service summary cache, which is refreshed from the
+// server if it's stale.
+func getConversationContacts(ctx context.Context, g *globals.Context, conv chat1.ConversationLocal) (res []conversationContact, err error) {
+	uids := make([]keybase1.UID, 0, len(conv.Info.Participants))
+	for _, p := range conv.Info.Participants {
+		uid, err := g.GetUPAKLoader().LookupUID(ctx, libkb.NewNormalizedUsername(p.Username))
+		if err != nil {
+			return nil, err
+		}
+		uids = append(uids, uid)
+	}
+
+	var summaries map[keybase1.UID]libkb.UserServiceSummaryPackage
+	if g.ServiceMapper != nil {
+		const serviceMapFreshness = 12 * time.Hour
+		summaries = g.ServiceMapper.MapUIDsToServiceSummaries(ctx, g.GlobalContext, uids,
+			serviceMapFreshness, uidmap.DefaultNetworkBudget)
+	}
+
+	siteURI := libkb.SiteURILookup[g.GetEnv().GetRunMode()]
+	for i, p := range conv.Info.Participants {
+		contact := conversationContact{Username: p.Username}
+		if p.Fullname != nil {
+			contact.FullName = *p.Fullname
+		}
+		if len(siteURI) > 0 {
+			contact.URLs = append(contact.URLs, fmt.Sprintf("%s/%s", siteURI, p.Username))
+		}
+		serviceMap := summaries[uids[i]].ServiceMap
+		services := make([]string, 0, len(serviceMap))
+		for service := range serviceMap {
+			services = append(services, service)
+		}
+		sort.Strings(services)
+		for _, service := range services {
+			if u := proofURL(ctx, g, service, serviceMap[service]); len(u) > 0 {
+				contact.URLs = append(contact.URLs, u)
+			}
+		}
+		res = append(res, contact)
+	}
+	return res, nil
+}
+
+func writeArchiveContacts(p string, contacts []conversationContact) error {
+	return os.WriteFile(p, []byte(formatVCards(contacts)), libkb.PermFile)
+}
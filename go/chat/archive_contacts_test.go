@@ -0,0 +1,47 @@
+package chat
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConversationContactsVCard(t *testing.T) {
+	contacts := []conversationContact{
+		{
+			Username: "alice",
+			FullName: "Smith, Alice; CEO",
+			URLs:     []string{"https://keybase.io/alice", "https://github.com/alice"},
+		},
+		{Username: "bob"},
+	}
+	vcards := formatVCards(contacts)
+	require.Equal(t, strings.Join([]string{
+		"BEGIN:VCARD",
+		"VERSION:3.0",
+		`FN:Smith\, Alice\; CEO`,
+		`N:;Smith\, Alice\; CEO;;;`,
+		"NICKNAME:alice",
+		"URL:https://keybase.io/alice",
+		"URL:https://github.com/alice",
+		"END:VCARD",
+		"BEGIN:VCARD",
+		"VERSION:3.0",
+		"FN:bob",
+		"N:;bob;;;",
+		"NICKNAME:bob",
+		"END:VCARD",
+		"",
+	}, "\r\n"), vcards)
+
+	// Long lines are folded without splitting up characters.
+	long := conversationContact{Username: "carol", FullName: strings.Repeat("é", 60)}
+	for _, line := range strings.Split(strings.TrimSuffix(long.vcard(), "\r\n"), "\r\n") {
+		require.LessOrEqual(t, len(line), vcardMaxLineBytes)
+		require.True(t, utf8.ValidString(line))
+	}
+	unfolded := strings.ReplaceAll(long.vcard(), "\r\n ", "")
+	require.Contains(t, unfolded, "FN:"+strings.Repeat("é", 60)+"\r\n")
+}
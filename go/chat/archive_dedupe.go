@@ -0,0 +1,141 @@
+package chat
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/keybase/client/go/protocol/chat1"
+)
+
+// Announcements crossposted to many channels of a team end up as the same
+// message in each of them. A job that dedupes messages writes the body of
+// such a message in only one conversation's messages.jsonl; its copies
+// elsewhere say which conversation and message has it. Conversations are
+// archived in parallel, so which copy keeps the body isn't fixed. Only text
+// messages are deduped, and chat.txt and archive.sqlite still have every
+// copy in full.
+
+// archiveDuplicateWindow is how far apart copies of a message can have
+// been sent.
+const archiveDuplicateWindow = 2 * time.Minute
+
+// archiveMessageRef is a message in another conversation's messages.jsonl,
+// by the name of the conversation's directory.
+type archiveMessageRef struct {
+	Conv      string          `json:"conv"`
+	MessageID chat1.MessageID `json:"messageID"`
+}
+
+type archiveDedupeKey struct {
+	sender string
+	sum    [sha256.Size]byte
+}
+
+type archiveDedupeEntry struct {
+	ref   archiveMessageRef
+	ctime time.Time
+}
+
+type archiveMessageDeduper struct {
+	sync.Mutex
+	seen map[archiveDedupeKey][]archiveDedupeEntry
+}
+
+func newArchiveMessageDeduper() *archiveMessageDeduper {
+	return &archiveMessageDeduper{
+		seen: make(map[archiveDedupeKey][]archiveDedupeEntry),
+	}
+}
+
+func checkArchiveDedupeMessages(req chat1.ArchiveChatJobRequest) error {
+	if req.DedupeMessages && req.Format == chat1.ArchiveChatFormat_TEXT {
+		return errors.New("deduping messages needs the JSON format")
+	}
+	return nil
+}
+
+func archiveMessageDedupable(msg archiveJSONMessage) bool {
+	return msg.Type == "text" && len(msg.Body) > 0 && msg.DuplicateOf == nil
+}
+
+// add records msg, from conv, and returns the message it's a copy of, if
+// it's one.
+func (d *archiveMessageDeduper) add(conv string, msg archiveJSONMessage) (res archiveMessageRef, dup bool) {
+	if !archiveMessageDedupable(msg) {
+		return res, false
+	}
+	key := archiveDedupeKey{sender: msg.Sender, sum: sha256.Sum256([]byte(msg.Body))}
+	self := archiveMessageRef{Conv: conv, MessageID: msg.MessageID}
+	d.Lock()
+	defer d.Unlock()
+	for _, entry := range d.seen[key] {
+		if entry.ref == self {
+			// Written again after the job was resumed.
+			return res, false
+		}
+		if entry.ref.Conv == conv {
+			// Said twice in one conversation, which isn't crossposting.
+			continue
+		}
+		diff := msg.Ctime.Sub(entry.ctime)
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff <= archiveDuplicateWindow {
+			return entry.ref, true
+		}
+	}
+	d.seen[key] = append(d.seen[key], archiveDedupeEntry{ref: self, ctime: msg.Ctime})
+	return res, false
+}
+
+// dedupe returns msgs, from conv, with the copies of messages already seen
+// replaced by references to them.
+func (d *archiveMessageDeduper) dedupe(conv string, msgs []archiveJSONMessage) []archiveJSONMessage {
+	res := make([]archiveJSONMessage, 0, len(msgs))
+	for _, msg := range msgs {
+		if ref, dup := d.add(conv, msg); dup {
+			msg.Body = ""
+			msg.DuplicateOf = &ref
+		}
+		res = append(res, msg)
+	}
+	return res
+}
+
+// load records the messages already written to the messages.jsonl files of
+// the conversations in convDirs, for a job that's resumed or appends to
+// another's output.
+func (d *archiveMessageDeduper) load(outputPath string, convDirs []string) error {
+	for _, conv := range convDirs {
+		f, err := os.Open(path.Join(outputPath, conv, archiveMessagesFilename))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+		for scanner.Scan() {
+			var msg archiveJSONMessage
+			if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+				// A line cut short when the job stopped; it's written
+				// again.
+				continue
+			}
+			d.add(conv, msg)
+		}
+		err = scanner.Err()
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package chat
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveDedupeMessages(t *testing.T) {
+	require.NoError(t, checkArchiveDedupeMessages(chat1.ArchiveChatJobRequest{
+		DedupeMessages: true,
+		Format:         chat1.ArchiveChatFormat_BOTH,
+	}))
+	require.Error(t, checkArchiveDedupeMessages(chat1.ArchiveChatJobRequest{
+		DedupeMessages: true,
+		Format:         chat1.ArchiveChatFormat_TEXT,
+	}))
+
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	text := func(id chat1.MessageID, sender, body string, ctime time.Time) archiveJSONMessage {
+		return archiveJSONMessage{MessageID: id, Type: "text", Sender: sender, Body: body, Ctime: ctime}
+	}
+	d := newArchiveMessageDeduper()
+	general := d.dedupe("acme#general", []archiveJSONMessage{
+		text(10, "alice", "all hands at 3", now),
+		text(11, "alice", "all hands at 3", now.Add(time.Second)),
+	})
+	// Saying it twice in one conversation isn't crossposting.
+	require.Equal(t, "all hands at 3", general[1].Body)
+	require.Nil(t, general[1].DuplicateOf)
+
+	msgs := []archiveJSONMessage{
+		text(20, "alice", "all hands at 3", now.Add(30*time.Second)),
+		text(21, "bob", "all hands at 3", now.Add(30*time.Second)),
+		text(22, "alice", "all hands at 3", now.Add(time.Hour)),
+		{MessageID: 23, Type: "edit", Sender: "alice", Body: "all hands at 3", Ctime: now},
+	}
+	random := d.dedupe("acme#random", msgs)
+	require.Equal(t, "", random[0].Body)
+	require.Equal(t, &archiveMessageRef{Conv: "acme#general", MessageID: 10}, random[0].DuplicateOf)
+	// Someone else, too late, or not a text message.
+	for _, msg := range random[1:] {
+		require.Equal(t, "all hands at 3", msg.Body)
+		require.Nil(t, msg.DuplicateOf)
+	}
+	// The messages passed in are left alone, for archive.sqlite.
+	require.Equal(t, "all hands at 3", msgs[0].Body)
+
+	// A resumed job picks up what's written, and doesn't take a message
+	// written again for a copy of itself.
+	dir := t.TempDir()
+	for conv, written := range map[string][]archiveJSONMessage{
+		"acme#general": general,
+		"acme#random":  random,
+	} {
+		lines, err := encodeArchiveJSONMessages(written)
+		require.NoError(t, err)
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, conv), 0700))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, conv, archiveMessagesFilename),
+			append(lines, []byte(`{"messageID": 30, "ty`)...), 0600))
+	}
+	d = newArchiveMessageDeduper()
+	require.NoError(t, d.load(dir, []string{"acme#general", "acme#random", "acme#empty"}))
+	again := d.dedupe("acme#general", general[:1])
+	require.Equal(t, "all hands at 3", again[0].Body)
+	later := d.dedupe("acme#ops", []archiveJSONMessage{text(5, "alice", "all hands at 3", now)})
+	require.Equal(t, &archiveMessageRef{Conv: "acme#general", MessageID: 10}, later[0].DuplicateOf)
+}
@@ -0,0 +1,136 @@
+package chat
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/keybase/client/go/engine"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/saltpack"
+	"golang.org/x/net/context"
+)
+
+// An encrypted job's output is packed into a tarball as it's encrypted, so
+// the only copy of it that's left is the saltpack file. It's signed by this
+// device if it's encrypted to the user's keys, and anonymous if it's
+// encrypted to a passphrase.
+
+const archiveEncryptedExt = ".saltpack"
+
+var errArchiveEncrypted = errors.New("the job's output is encrypted; decrypt it to read it")
+
+func checkArchiveEncryption(req chat1.ArchiveChatJobRequest) error {
+	switch req.Encryption {
+	case chat1.ArchiveChatEncryption_NONE, chat1.ArchiveChatEncryption_SELF:
+		if len(req.Passphrase) > 0 {
+			return errors.New("a passphrase is only used for passphrase encryption")
+		}
+	case chat1.ArchiveChatEncryption_PASSPHRASE:
+		if len(req.Passphrase) < libkb.MinPassphraseLength {
+			return fmt.Errorf("the passphrase must be at least %d characters", libkb.MinPassphraseLength)
+		}
+	default:
+		return fmt.Errorf("unknown archive encryption %v", req.Encryption)
+	}
+	return nil
+}
+
+// archiveOutputExt is what's added to the output path of a job whose output
+// is packed into one file.
+func archiveOutputExt(req chat1.ArchiveChatJobRequest) string {
+	ext := ""
+	if req.Compress {
+		ext = archiveCompressedExt(req.Compression)
+	} else if req.Encryption != chat1.ArchiveChatEncryption_NONE {
+		ext = archiveCompressedExt(chat1.ArchiveChatCompression_NONE)
+	}
+	if req.Encryption != chat1.ArchiveChatEncryption_NONE {
+		ext += archiveEncryptedExt
+	}
+	return ext
+}
+
+func (c *ChatArchiver) archiveEncryptArg(ctx context.Context, req chat1.ArchiveChatJobRequest) (
+	arg libkb.SaltpackEncryptArg, err error) {
+	arg.Binary = true
+	switch req.Encryption {
+	case chat1.ArchiveChatEncryption_SELF:
+		mctx := libkb.NewMetaContext(ctx, c.G().ExternalG())
+		kf := engine.NewSaltpackUserKeyfinder(libkb.SaltpackRecipientKeyfinderArg{
+			UseEntityKeys: true,
+			UseDeviceKeys: true,
+			UsePaperKeys:  true,
+		})
+		if err := engine.RunEngine2(mctx, kf); err != nil {
+			return arg, err
+		}
+		for _, kid := range kf.GetPublicKIDs() {
+			gk, err := libkb.ImportKeypairFromKID(kid)
+			if err != nil {
+				return arg, err
+			}
+			kp, ok := gk.(libkb.NaclDHKeyPair)
+			if !ok {
+				return arg, libkb.KeyCannotEncryptError{}
+			}
+			arg.Receivers = append(arg.Receivers, kp.Public)
+		}
+		signingKey, err := engine.GetMySecretKey(ctx, c.G().ExternalG(),
+			libkb.DeviceSigningKeyType, "encrypt chat archive")
+		if err != nil {
+			return arg, err
+		}
+		kp, ok := signingKey.(libkb.NaclSigningKeyPair)
+		if !ok || kp.Private == nil {
+			return arg, libkb.KeyCannotSignError{}
+		}
+		arg.SenderSigning = kp
+	case chat1.ArchiveChatEncryption_PASSPHRASE:
+		receiver, err := libkb.NewSaltpackPassphraseReceiver(req.Passphrase)
+		if err != nil {
+			return arg, err
+		}
+		arg.SymmetricReceivers = []saltpack.ReceiverSymmetricKey{receiver}
+	default:
+		return arg, fmt.Errorf("archive encryption %v has no keys", req.Encryption)
+	}
+	return arg, nil
+}
+
+// encryptArchive packs the directory inPath into a tarball, compressed as
+// req says, and encrypts it to outPath.
+func (c *ChatArchiver) encryptArchive(ctx context.Context, req chat1.ArchiveChatJobRequest,
+	inPath, outPath string) (err error) {
+	arg, err := c.archiveEncryptArg(ctx, req)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(outPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			// Don't leave half of it lying around.
+			_ = os.Remove(outPath)
+		}
+	}()
+	arg.Sink = f
+	plainsink, err := libkb.NewSaltpackEncryptStream(&arg)
+	if err != nil {
+		return err
+	}
+	compression, level := req.Compression, req.CompressionLevel
+	if !req.Compress {
+		compression, level = chat1.ArchiveChatCompression_NONE, 0
+	}
+	if err := writeTarArchive(inPath, plainsink, compression, level); err != nil {
+		return err
+	}
+	return plainsink.Close()
+}
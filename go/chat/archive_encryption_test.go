@@ -0,0 +1,75 @@
+package chat
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/stretchr/testify/require"
+)
+
+type archiveDecryptSink struct {
+	bytes.Buffer
+}
+
+func (archiveDecryptSink) Close() error { return nil }
+
+func TestArchiveEncryption(t *testing.T) {
+	tc := libkb.SetupTest(t, "archive-encryption", 0)
+	defer tc.Cleanup()
+
+	req := chat1.ArchiveChatJobRequest{}
+	require.NoError(t, checkArchiveEncryption(req))
+	require.Equal(t, "", archiveOutputExt(req))
+	req.Passphrase = "correct horse battery"
+	require.Error(t, checkArchiveEncryption(req))
+	req.Encryption = chat1.ArchiveChatEncryption_PASSPHRASE
+	require.NoError(t, checkArchiveEncryption(req))
+	require.Equal(t, ".tar.saltpack", archiveOutputExt(req))
+	req.Compress = true
+	req.Compression = chat1.ArchiveChatCompression_ZSTD
+	require.Equal(t, ".tar.zst.saltpack", archiveOutputExt(req))
+	req.Passphrase = "short"
+	require.Error(t, checkArchiveEncryption(req))
+
+	dir := t.TempDir()
+	inPath := filepath.Join(dir, "out")
+	require.NoError(t, os.MkdirAll(filepath.Join(inPath, "alice,bob"), 0700))
+	contents := []byte("hello\n")
+	require.NoError(t, os.WriteFile(filepath.Join(inPath, "alice,bob", "chat.txt"), contents, 0600))
+
+	req = chat1.ArchiveChatJobRequest{
+		Encryption: chat1.ArchiveChatEncryption_PASSPHRASE,
+		Passphrase: "correct horse battery",
+	}
+	outPath := inPath + archiveOutputExt(req)
+	require.NoError(t, (&ChatArchiver{}).encryptArchive(context.Background(), req, inPath, outPath))
+	ciphertext, err := os.ReadFile(outPath)
+	require.NoError(t, err)
+	require.False(t, bytes.Contains(ciphertext, contents))
+
+	mctx := libkb.NewMetaContextForTest(tc)
+	var sink archiveDecryptSink
+	err = libkb.SaltpackPassphraseDecrypt(mctx, bytes.NewReader(ciphertext), &sink, "wrong horse battery")
+	require.Error(t, err)
+	sink.Reset()
+	err = libkb.SaltpackPassphraseDecrypt(mctx, bytes.NewReader(ciphertext), &sink, req.Passphrase)
+	require.NoError(t, err)
+	tr := tar.NewReader(&sink.Buffer)
+	found := false
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			break
+		}
+		if header.Name == "alice,bob/chat.txt" {
+			found = true
+		}
+	}
+	require.True(t, found)
+}
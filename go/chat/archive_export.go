@@ -0,0 +1,166 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/keybase/client/go/encrypteddb"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+const archiveChatHistoryExportVersion = 1
+
+// archiveChatExportKeyFn returns the key jobs in an export are sealed with,
+// derived from the given generation of the per-user key so that any of the
+// user's devices can import it.
+func archiveChatExportKeyFn(mctx libkb.MetaContext, gen keybase1.PerUserKeyGeneration) encrypteddb.KeyFn {
+	return func(ctx context.Context) (res [32]byte, err error) {
+		pukring, err := mctx.G().GetPerUserKeyring(ctx)
+		if err != nil {
+			return res, err
+		}
+		seed, err := pukring.GetSeedByGenerationOrSync(mctx.WithCtx(ctx), gen)
+		if err != nil {
+			return res, err
+		}
+		key, err := seed.DeriveSymmetricKey(libkb.DeriveReasonPUKChatArchiveExport)
+		if err != nil {
+			return res, err
+		}
+		return key, nil
+	}
+}
+
+// exportArchiveChatJob leaves only the job's ID, status and progress in the
+// clear, and seals the rest with keyFn. A nil keyFn exports the job in
+// plaintext.
+func exportArchiveChatJob(ctx context.Context, job chat1.ArchiveChatJob,
+	keyFn encrypteddb.KeyFn) (res chat1.ArchiveChatExportedJob, err error) {
+	res = chat1.ArchiveChatExportedJob{
+		JobID:            job.Request.JobID,
+		StartedAt:        job.StartedAt,
+		Status:           job.Status,
+		MessagesTotal:    job.MessagesTotal,
+		MessagesComplete: job.MessagesComplete,
+	}
+	if keyFn == nil {
+		job = job.DeepCopy()
+		res.Plaintext = &job
+		return res, nil
+	}
+	res.Sealed, err = encrypteddb.EncodeBox(ctx, job, keyFn)
+	if err != nil {
+		return chat1.ArchiveChatExportedJob{}, err
+	}
+	return res, nil
+}
+
+// importArchiveChatJob recovers a job exported by exportArchiveChatJob.
+func importArchiveChatJob(ctx context.Context, exported chat1.ArchiveChatExportedJob,
+	keyFn encrypteddb.KeyFn) (job chat1.ArchiveChatJob, err error) {
+	switch {
+	case exported.Plaintext != nil:
+		job = exported.Plaintext.DeepCopy()
+	case len(exported.Sealed) > 0:
+		if keyFn == nil {
+			return job, errors.New("export has sealed jobs but no per-user key generation")
+		}
+		if err := encrypteddb.DecodeBox(ctx, exported.Sealed, keyFn, &job); err != nil {
+			return job, fmt.Errorf("unable to open job %s: %v", exported.JobID, err)
+		}
+	default:
+		return job, fmt.Errorf("job %s is empty", exported.JobID)
+	}
+	if job.Request.JobID != exported.JobID {
+		return job, fmt.Errorf("job %s has sealed job ID %s", exported.JobID, job.Request.JobID)
+	}
+	// Nothing is running the job here, so it can only be resumed by hand.
+	switch job.Status {
+	case chat1.ArchiveChatJobStatus_RUNNING, chat1.ArchiveChatJobStatus_BACKGROUND_PAUSED:
+		job.Status = chat1.ArchiveChatJobStatus_PAUSED
+	}
+	return job, nil
+}
+
+// Export returns the job history. Unless plaintext is set, the parts of each
+// job that say what was archived and where to, like the query and output
+// path, are sealed with a key from the current per-user key.
+func (r *ChatArchiveRegistry) Export(ctx context.Context, plaintext bool) (res chat1.ArchiveChatHistoryExport, err error) {
+	defer r.Trace(ctx, &err, "Export(plaintext=%v)", plaintext)()
+	list, err := r.List(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	res.Version = archiveChatHistoryExportVersion
+	var keyFn encrypteddb.KeyFn
+	if !plaintext {
+		mctx := r.G().MetaContext(ctx)
+		pukring, err := mctx.G().GetPerUserKeyring(ctx)
+		if err != nil {
+			return res, err
+		}
+		if err := pukring.Sync(mctx); err != nil {
+			return res, err
+		}
+		if !pukring.HasAnyKeys() {
+			return res, errors.New("a per-user key is needed to encrypt the export")
+		}
+		res.PukGen = pukring.CurrentGeneration()
+		keyFn = archiveChatExportKeyFn(mctx, res.PukGen)
+	}
+	for _, job := range list.Jobs {
+		exported, err := exportArchiveChatJob(ctx, job, keyFn)
+		if err != nil {
+			return chat1.ArchiveChatHistoryExport{}, err
+		}
+		res.Jobs = append(res.Jobs, exported)
+	}
+	return res, nil
+}
+
+// Import adds the jobs in an export to the history, skipping any that are
+// already in it.
+func (r *ChatArchiveRegistry) Import(ctx context.Context, export chat1.ArchiveChatHistoryExport) (res chat1.ArchiveChatImportHistoryRes, err error) {
+	defer r.Trace(ctx, &err, "Import")()
+	if export.Version > archiveChatHistoryExportVersion {
+		return res, fmt.Errorf("export version %d is newer than supported version %d",
+			export.Version, archiveChatHistoryExportVersion)
+	}
+	var keyFn encrypteddb.KeyFn
+	if export.PukGen > 0 {
+		keyFn = archiveChatExportKeyFn(r.G().MetaContext(ctx), export.PukGen)
+	}
+	// Open everything before touching the history, so a bad export doesn't
+	// get half imported.
+	jobs := make([]chat1.ArchiveChatJob, 0, len(export.Jobs))
+	for _, exported := range export.Jobs {
+		job, err := importArchiveChatJob(ctx, exported, keyFn)
+		if err != nil {
+			return res, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	err = r.initLocked(ctx)
+	if err != nil {
+		return res, err
+	}
+	for _, job := range jobs {
+		if _, ok := r.jobHistory.JobHistory[job.Request.JobID]; ok {
+			res.Skipped++
+			continue
+		}
+		r.jobHistory.JobHistory[job.Request.JobID] = job
+		res.Imported++
+	}
+	if res.Imported > 0 {
+		r.dirty = true
+	}
+	return res, nil
+}
@@ -0,0 +1,72 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveChatJobExport(t *testing.T) {
+	ctx := context.TODO()
+	keyFn := func(key byte) func(context.Context) ([32]byte, error) {
+		return func(context.Context) ([32]byte, error) {
+			return [32]byte{key}, nil
+		}
+	}
+	name := "alice,bob"
+	job := chat1.ArchiveChatJob{
+		Request: chat1.ArchiveChatJobRequest{
+			JobID:      "job1",
+			OutputPath: "/home/alice/secret-archive",
+			Query: &chat1.GetInboxLocalQuery{
+				Name: &chat1.NameQuery{Name: name, MembersType: chat1.ConversationMembersType_IMPTEAMNATIVE},
+			},
+		},
+		StartedAt:        gregor1.Time(1234),
+		Status:           chat1.ArchiveChatJobStatus_RUNNING,
+		MessagesTotal:    10,
+		MessagesComplete: 4,
+	}
+
+	exported, err := exportArchiveChatJob(ctx, job, keyFn(1))
+	require.NoError(t, err)
+	require.Nil(t, exported.Plaintext)
+	require.Equal(t, job.Request.JobID, exported.JobID)
+	require.Equal(t, job.Status, exported.Status)
+	require.Equal(t, job.MessagesComplete, exported.MessagesComplete)
+	data, err := json.Marshal(exported)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "secret-archive")
+	require.NotContains(t, string(data), name)
+
+	imported, err := importArchiveChatJob(ctx, exported, keyFn(1))
+	require.NoError(t, err)
+	require.Equal(t, job.Request.OutputPath, imported.Request.OutputPath)
+	require.Equal(t, name, imported.Request.Query.Name.Name)
+	// It isn't running on the importing device.
+	require.Equal(t, chat1.ArchiveChatJobStatus_PAUSED, imported.Status)
+
+	_, err = importArchiveChatJob(ctx, exported, keyFn(2))
+	require.Error(t, err)
+	_, err = importArchiveChatJob(ctx, exported, nil)
+	require.Error(t, err)
+	tampered := exported.DeepCopy()
+	tampered.JobID = "job2"
+	_, err = importArchiveChatJob(ctx, tampered, keyFn(1))
+	require.Error(t, err)
+
+	plaintext, err := exportArchiveChatJob(ctx, job, nil)
+	require.NoError(t, err)
+	require.Empty(t, plaintext.Sealed)
+	require.NotNil(t, plaintext.Plaintext)
+	imported, err = importArchiveChatJob(ctx, plaintext, nil)
+	require.NoError(t, err)
+	require.Equal(t, job.Request.OutputPath, imported.Request.OutputPath)
+
+	_, err = importArchiveChatJob(ctx, chat1.ArchiveChatExportedJob{JobID: "empty"}, nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,134 @@
+package chat
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+)
+
+// Archives with history also write a history.jsonl next to each
+// conversation's other files, from the messages as they're stored rather
+// than as they're shown, with a line for each of these events in message ID
+// order:
+//
+//   - "reaction", "edit", "delete" and "deleteHistory": a message that
+//     changed others. Reactions that were taken back and edits that were
+//     deleted have deletedBy set, and no target or body if it's gone.
+//   - "original": the body a message had before it was edited or deleted,
+//     where it's still stored locally.
+//   - "tombstone": a message that was deleted and whose body is gone.
+//
+// Together with messages.jsonl, which has what the messages say now, that's
+// a record of how the conversation got there.
+
+const archiveHistoryFilename = "history.jsonl"
+
+type archiveHistoryEvent struct {
+	MessageID chat1.MessageID `json:"messageID"`
+	Event     string          `json:"event"`
+	// For originals and tombstones, the type of the message.
+	MessageType   string    `json:"messageType,omitempty"`
+	Sender        string    `json:"sender"`
+	Device        string    `json:"device"`
+	DeviceRevoked bool      `json:"deviceRevoked,omitempty"`
+	Ctime         time.Time `json:"ctime"`
+	// The message reacted to or edited.
+	Target chat1.MessageID `json:"target,omitempty"`
+	// The messages deleted.
+	Targets []chat1.MessageID `json:"targets,omitempty"`
+	// Everything up to this message was deleted.
+	Upto chat1.MessageID `json:"upto,omitempty"`
+	// The reaction, the edited text or the original body.
+	Body      string          `json:"body,omitempty"`
+	DeletedBy chat1.MessageID `json:"deletedBy,omitempty"`
+}
+
+func newArchiveHistoryEvent(msg chat1.MessageUnboxedValid, redactor *archiveRedactor,
+	now time.Time) (res archiveHistoryEvent, ok bool) {
+	typ := msg.ClientHeader.MessageType
+	bodyType, err := msg.MessageBody.MessageType()
+	if err != nil {
+		return res, false
+	}
+	// Bodies that are gone or exploded stay out.
+	hasBody := bodyType == typ && !(msg.IsEphemeral() && msg.IsEphemeralExpired(now))
+	res.MessageID = msg.ServerHeader.MessageID
+	res.Sender = msg.SenderUsername
+	res.Device = msg.SenderDeviceName
+	res.DeviceRevoked = msg.SenderDeviceRevokedAt != nil
+	res.Ctime = gregor1.FromTime(msg.ServerHeader.Ctime)
+	res.DeletedBy = msg.ServerHeader.SupersededBy
+
+	switch typ {
+	case chat1.MessageType_REACTION:
+		res.Event = "reaction"
+		if hasBody {
+			res.Target = msg.MessageBody.Reaction().MessageID
+			res.Body = msg.MessageBody.Reaction().Body
+		}
+	case chat1.MessageType_EDIT:
+		res.Event = "edit"
+		if hasBody {
+			res.Target = msg.MessageBody.Edit().MessageID
+			res.Body = redactor.Redact(msg.MessageBody.Edit().Body)
+		}
+	case chat1.MessageType_DELETE:
+		res.Event = "delete"
+		if hasBody {
+			res.Targets = msg.MessageBody.Delete().MessageIDs
+		}
+	case chat1.MessageType_DELETEHISTORY:
+		res.Event = "deleteHistory"
+		if hasBody {
+			res.Upto = msg.MessageBody.Deletehistory().Upto
+		}
+	default:
+		if _, ok := archiveJSONMessageType(typ); !ok || res.DeletedBy == 0 {
+			// Nothing happened to it, so messages.jsonl has all of it.
+			return res, false
+		}
+		res.MessageType = strings.ToLower(typ.String())
+		if bodyType == chat1.MessageType_NONE {
+			res.Event = "tombstone"
+		} else {
+			res.Event = "original"
+			if hasBody {
+				res.Body = redactor.Redact(archiveJSONBody(msg.MessageBody, typ))
+			}
+		}
+	}
+	return res, true
+}
+
+// newArchiveHistoryEvents returns the events in msgs, which have to be
+// pulled without resolving supersedes.
+func newArchiveHistoryEvents(msgs []chat1.MessageUnboxed, redactor *archiveRedactor,
+	now time.Time) (res []archiveHistoryEvent) {
+	for _, m := range msgs {
+		if !m.IsValid() {
+			continue
+		}
+		event, ok := newArchiveHistoryEvent(m.Valid(), redactor, now)
+		if !ok {
+			continue
+		}
+		res = append(res, event)
+	}
+	return res
+}
+
+func encodeArchiveHistoryEvents(events []archiveHistoryEvent) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	for _, event := range events {
+		if err := enc.Encode(event); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
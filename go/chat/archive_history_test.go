@@ -0,0 +1,96 @@
+package chat
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveHistoryEvents(t *testing.T) {
+	sent := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	valid := func(id chat1.MessageID, typ chat1.MessageType, body chat1.MessageBody) chat1.MessageUnboxedValid {
+		return chat1.MessageUnboxedValid{
+			ClientHeader: chat1.MessageClientHeaderVerified{MessageType: typ},
+			ServerHeader: chat1.MessageServerHeader{
+				MessageID: id,
+				Ctime:     gregor1.ToTime(sent),
+			},
+			SenderUsername:   "alice",
+			SenderDeviceName: "laptop",
+			MessageBody:      body,
+		}
+	}
+
+	// As stored: the original text of 10, the edit that changed it, a
+	// reaction to it that was taken back, and 14, deleted by 15.
+	original := valid(10, chat1.MessageType_TEXT,
+		chat1.NewMessageBodyWithText(chat1.MessageText{Body: "see OPS-12"}))
+	original.ServerHeader.SupersededBy = 11
+	untouched := valid(9, chat1.MessageType_TEXT,
+		chat1.NewMessageBodyWithText(chat1.MessageText{Body: "hi"}))
+	edit := valid(11, chat1.MessageType_EDIT,
+		chat1.NewMessageBodyWithEdit(chat1.MessageEdit{MessageID: 10, Body: "see OPS-13"}))
+	reaction := valid(12, chat1.MessageType_REACTION, chat1.MessageBody{})
+	reaction.ServerHeader.SupersededBy = 13
+	unreact := valid(13, chat1.MessageType_DELETE,
+		chat1.NewMessageBodyWithDelete(chat1.MessageDelete{MessageIDs: []chat1.MessageID{12}}))
+	deleted := valid(14, chat1.MessageType_TEXT, chat1.MessageBody{})
+	deleted.ServerHeader.SupersededBy = 15
+	del := valid(15, chat1.MessageType_DELETE,
+		chat1.NewMessageBodyWithDelete(chat1.MessageDelete{MessageIDs: []chat1.MessageID{14}}))
+
+	redactor, err := newArchiveRedactor([]chat1.ArchiveRedactionRule{
+		{Name: "ticket", Pattern: `OPS-[0-9]+`},
+	})
+	require.NoError(t, err)
+	var msgs []chat1.MessageUnboxed
+	for _, m := range []chat1.MessageUnboxedValid{untouched, original, edit, reaction, unreact, deleted, del} {
+		msgs = append(msgs, chat1.NewMessageUnboxedWithValid(m))
+	}
+	out, err := encodeArchiveHistoryEvents(newArchiveHistoryEvents(msgs, redactor, sent))
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(string(out), "\n"), "\n")
+	require.Len(t, lines, 6)
+	var res []archiveHistoryEvent
+	for _, line := range lines {
+		var e archiveHistoryEvent
+		require.NoError(t, json.Unmarshal([]byte(line), &e))
+		res = append(res, e)
+	}
+
+	require.Equal(t, chat1.MessageID(10), res[0].MessageID)
+	require.Equal(t, "original", res[0].Event)
+	require.Equal(t, "text", res[0].MessageType)
+	require.Equal(t, "see [REDACTED]", res[0].Body)
+	require.Equal(t, chat1.MessageID(11), res[0].DeletedBy)
+	require.Equal(t, "alice", res[0].Sender)
+	require.True(t, sent.Equal(res[0].Ctime))
+
+	require.Equal(t, "edit", res[1].Event)
+	require.Equal(t, chat1.MessageID(10), res[1].Target)
+	require.Equal(t, "see [REDACTED]", res[1].Body)
+	require.Zero(t, res[1].DeletedBy)
+
+	// The reaction's body is gone, and what it reacted to with it.
+	require.Equal(t, "reaction", res[2].Event)
+	require.Zero(t, res[2].Target)
+	require.Empty(t, res[2].Body)
+	require.Equal(t, chat1.MessageID(13), res[2].DeletedBy)
+
+	require.Equal(t, "delete", res[3].Event)
+	require.Equal(t, []chat1.MessageID{12}, res[3].Targets)
+
+	require.Equal(t, "tombstone", res[4].Event)
+	require.Equal(t, chat1.MessageID(14), res[4].MessageID)
+	require.Equal(t, "text", res[4].MessageType)
+	require.Equal(t, chat1.MessageID(15), res[4].DeletedBy)
+
+	require.Equal(t, "delete", res[5].Event)
+	require.Equal(t, []chat1.MessageID{14}, res[5].Targets)
+}
@@ -0,0 +1,427 @@
+package chat
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/chat/storage"
+	"github.com/keybase/client/go/encrypteddb"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+)
+
+// An archive's output can be imported back into the local chat database, so
+// conversations whose history has been purged from the server can still be
+// read. Imported conversations are kept apart from the inbox and the chat
+// storage, since there's nothing on the server to check them against: an
+// index of them, and each one's messages in pages of archiveImportPageSize,
+// oldest first. They're only ever read, replaced by importing them again, or
+// deleted.
+
+const archiveImportPageSize = 500
+
+// archiveImportDefaultNum is how many messages a page of an imported thread
+// has when the caller doesn't say.
+const archiveImportDefaultNum = 50
+
+type archiveImportIndex struct {
+	Convs []chat1.ArchiveChatImportedConv `codec:"c"`
+}
+
+type archiveImportPage struct {
+	Messages []chat1.ArchiveChatImportedMessage `codec:"m"`
+}
+
+// archiveImportConv is a conversation read from an archive's output.
+type archiveImportConv struct {
+	// The conversation's directory in the output.
+	name   string
+	convID *chat1.ConversationID
+	msgs   []archiveJSONMessage
+}
+
+type ArchiveImportedConvNotFoundError struct {
+	id string
+}
+
+func (e ArchiveImportedConvNotFoundError) Error() string {
+	return fmt.Sprintf("imported conversation not found: %s", e.id)
+}
+
+func archiveImportDB(g *globals.Context, uid gregor1.UID) *encrypteddb.EncryptedDB {
+	dbFn := func(g *libkb.GlobalContext) *libkb.JSONLocalDb {
+		return g.LocalChatDb
+	}
+	keyFn := func(ctx context.Context) ([32]byte, error) {
+		return storage.GetSecretBoxKeyWithUID(ctx, g.ExternalG(), uid)
+	}
+	return encrypteddb.New(g.ExternalG(), dbFn, keyFn)
+}
+
+func archiveImportIndexKey(uid gregor1.UID) libkb.DbKey {
+	return libkb.DbKey{
+		Typ: libkb.DBChatArchiveImport,
+		Key: fmt.Sprintf("%s:index", uid),
+	}
+}
+
+func archiveImportPageKey(uid gregor1.UID, id string, page int) libkb.DbKey {
+	return libkb.DbKey{
+		Typ: libkb.DBChatArchiveImport,
+		Key: fmt.Sprintf("%s:%s:%d", uid, id, page),
+	}
+}
+
+func archiveImportPages(messages int) int {
+	return (messages + archiveImportPageSize - 1) / archiveImportPageSize
+}
+
+// archiveImportedConvID makes the ID of an imported conversation, which is
+// the same whichever archive it's imported from.
+func archiveImportedConvID(conv archiveImportConv) string {
+	var sum [sha256.Size]byte
+	if conv.convID != nil {
+		sum = sha256.Sum256(*conv.convID)
+	} else {
+		sum = sha256.Sum256([]byte(conv.name))
+	}
+	return hex.EncodeToString(sum[:16])
+}
+
+// readArchiveMessagesFile reads a conversation's messages.jsonl. A line cut
+// short at the end, by a job that stopped, is left out.
+func readArchiveMessagesFile(filename string) (res []archiveJSONMessage, err error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	var badLine error
+	for scanner.Scan() {
+		if badLine != nil {
+			return nil, badLine
+		}
+		var msg archiveJSONMessage
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			badLine = fmt.Errorf("%s: %v", filename, err)
+			continue
+		}
+		res = append(res, msg)
+	}
+	return res, scanner.Err()
+}
+
+// readArchiveForImport reads the conversations in the archive output at
+// inputPath, from its archive.sqlite if it has one, or else from its
+// messages.jsonl files.
+func readArchiveForImport(ctx context.Context, inputPath string) (res []archiveImportConv, err error) {
+	info, err := os.Stat(inputPath)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s isn't an archive's output directory; unpack or decrypt it first", inputPath)
+	}
+	dbPath := filepath.Join(inputPath, archiveDatabaseFilename)
+	if _, err := os.Stat(dbPath); err == nil {
+		return readArchiveDatabase(ctx, dbPath)
+	}
+
+	entries, err := os.ReadDir(inputPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name() == archiveAttachmentStoreDirname {
+			continue
+		}
+		msgs, err := readArchiveMessagesFile(filepath.Join(inputPath, entry.Name(), archiveMessagesFilename))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		res = append(res, archiveImportConv{name: entry.Name(), msgs: msgs})
+	}
+	if len(res) == 0 {
+		return nil, fmt.Errorf("%s has no messages.jsonl or archive.sqlite; only archives in the JSON "+
+			"format or with a database can be imported", inputPath)
+	}
+
+	// Fill in the bodies of messages deduped into other conversations.
+	bodies := make(map[archiveMessageRef]string)
+	for _, conv := range res {
+		for _, msg := range conv.msgs {
+			if msg.DuplicateOf == nil {
+				bodies[archiveMessageRef{Conv: conv.name, MessageID: msg.MessageID}] = msg.Body
+			}
+		}
+	}
+	for _, conv := range res {
+		for i, msg := range conv.msgs {
+			if msg.DuplicateOf != nil {
+				conv.msgs[i].Body = bodies[*msg.DuplicateOf]
+				conv.msgs[i].DuplicateOf = nil
+			}
+		}
+	}
+	return res, nil
+}
+
+// newArchiveImportedMessages converts the messages of conv, in the archive
+// output at inputPath. Edits are left out, since the messages they edit
+// already have the edited bodies.
+func newArchiveImportedMessages(inputPath string, conv archiveImportConv) (
+	res []chat1.ArchiveChatImportedMessage) {
+	for _, msg := range conv.msgs {
+		if msg.Type == "edit" {
+			continue
+		}
+		imported := chat1.ArchiveChatImportedMessage{
+			MsgID:     msg.MessageID,
+			Type:      msg.Type,
+			Sender:    msg.Sender,
+			Device:    msg.Device,
+			Ctime:     gregor1.ToTime(msg.Ctime),
+			Body:      msg.Body,
+			ReplyTo:   msg.ReplyTo,
+			Deleted:   msg.Deleted,
+			Exploded:  msg.Exploded,
+			Reactions: msg.Reactions,
+		}
+		if att := msg.Attachment; att != nil {
+			imported.AttachmentFilename = att.Filename
+			if len(att.Path) > 0 {
+				attachmentPath := filepath.Join(inputPath, conv.name, att.Path)
+				if _, err := os.Stat(attachmentPath); err == nil {
+					imported.AttachmentPath = attachmentPath
+				}
+			}
+		}
+		res = append(res, imported)
+	}
+	return res
+}
+
+// mergeArchiveImportedMessages returns the messages in prev and msgs,
+// oldest first, with the ones in msgs replacing those in prev with the same
+// IDs.
+func mergeArchiveImportedMessages(prev, msgs []chat1.ArchiveChatImportedMessage) (
+	res []chat1.ArchiveChatImportedMessage) {
+	byID := make(map[chat1.MessageID]chat1.ArchiveChatImportedMessage, len(prev)+len(msgs))
+	for _, msg := range prev {
+		byID[msg.MsgID] = msg
+	}
+	for _, msg := range msgs {
+		byID[msg.MsgID] = msg
+	}
+	res = make([]chat1.ArchiveChatImportedMessage, 0, len(byID))
+	for _, msg := range byID {
+		res = append(res, msg)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].MsgID < res[j].MsgID })
+	return res
+}
+
+func loadArchiveImportIndexLocked(ctx context.Context, edb *encrypteddb.EncryptedDB, uid gregor1.UID) (
+	index archiveImportIndex, err error) {
+	_, err = edb.Get(ctx, archiveImportIndexKey(uid), &index)
+	return index, err
+}
+
+func loadArchiveImportPage(ctx context.Context, edb *encrypteddb.EncryptedDB, uid gregor1.UID, id string,
+	page int) (res []chat1.ArchiveChatImportedMessage, err error) {
+	var p archiveImportPage
+	found, err := edb.Get(ctx, archiveImportPageKey(uid, id, page), &p)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("page %d of imported conversation %s is missing", page, id)
+	}
+	return p.Messages, nil
+}
+
+func findArchiveImportedConv(index archiveImportIndex, id string) (int, bool) {
+	for i, conv := range index.Convs {
+		if conv.Id == id {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// ImportArchive loads the conversations in the archive output at inputPath
+// into the local chat database, merging them into any imported before.
+func ImportArchive(ctx context.Context, g *globals.Context, uid gregor1.UID, inputPath string) (
+	res chat1.ArchiveChatImportRes, err error) {
+	inputPath, err = filepath.Abs(inputPath)
+	if err != nil {
+		return res, err
+	}
+	convs, err := readArchiveForImport(ctx, inputPath)
+	if err != nil {
+		return res, err
+	}
+
+	g.ArchiveImportLock.Lock()
+	defer g.ArchiveImportLock.Unlock()
+	edb := archiveImportDB(g, uid)
+	index, err := loadArchiveImportIndexLocked(ctx, edb, uid)
+	if err != nil {
+		return res, err
+	}
+	for _, conv := range convs {
+		msgs := newArchiveImportedMessages(inputPath, conv)
+		if len(msgs) == 0 {
+			continue
+		}
+		read := len(msgs)
+		imported := chat1.ArchiveChatImportedConv{
+			Id:     archiveImportedConvID(conv),
+			Name:   conv.name,
+			ConvID: conv.convID,
+		}
+		i, found := findArchiveImportedConv(index, imported.Id)
+		if found {
+			var prev []chat1.ArchiveChatImportedMessage
+			for page := 0; page < archiveImportPages(index.Convs[i].Messages); page++ {
+				p, err := loadArchiveImportPage(ctx, edb, uid, imported.Id, page)
+				if err != nil {
+					return res, err
+				}
+				prev = append(prev, p...)
+			}
+			msgs = mergeArchiveImportedMessages(prev, msgs)
+		} else {
+			msgs = mergeArchiveImportedMessages(nil, msgs)
+		}
+		for page := 0; page < archiveImportPages(len(msgs)); page++ {
+			end := (page + 1) * archiveImportPageSize
+			if end > len(msgs) {
+				end = len(msgs)
+			}
+			err := edb.Put(ctx, archiveImportPageKey(uid, imported.Id, page),
+				archiveImportPage{Messages: msgs[page*archiveImportPageSize : end]})
+			if err != nil {
+				return res, err
+			}
+		}
+
+		imported.SourcePath = inputPath
+		imported.ImportedAt = gregor1.ToTime(g.Clock().Now())
+		imported.Messages = len(msgs)
+		imported.FirstCtime = msgs[0].Ctime
+		imported.LastCtime = msgs[0].Ctime
+		for _, msg := range msgs {
+			if msg.Ctime < imported.FirstCtime {
+				imported.FirstCtime = msg.Ctime
+			}
+			if msg.Ctime > imported.LastCtime {
+				imported.LastCtime = msg.Ctime
+			}
+		}
+		if found {
+			index.Convs[i] = imported
+		} else {
+			index.Convs = append(index.Convs, imported)
+		}
+		res.Convs = append(res.Convs, imported)
+		res.MessagesImported += read
+	}
+	if len(res.Convs) == 0 {
+		return res, errors.New("the archive has no messages to import")
+	}
+	if err := edb.Put(ctx, archiveImportIndexKey(uid), index); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+// ListImportedArchiveConvs returns the imported conversations, the ones
+// with the newest messages first.
+func ListImportedArchiveConvs(ctx context.Context, g *globals.Context, uid gregor1.UID) (
+	res []chat1.ArchiveChatImportedConv, err error) {
+	g.ArchiveImportLock.Lock()
+	defer g.ArchiveImportLock.Unlock()
+	index, err := loadArchiveImportIndexLocked(ctx, archiveImportDB(g, uid), uid)
+	if err != nil {
+		return nil, err
+	}
+	res = index.Convs
+	sort.SliceStable(res, func(i, j int) bool { return res[i].LastCtime > res[j].LastCtime })
+	return res, nil
+}
+
+// GetImportedArchiveThread returns up to num of an imported conversation's
+// messages from before beforeMsgID, or the newest if it's 0, newest first.
+func GetImportedArchiveThread(ctx context.Context, g *globals.Context, uid gregor1.UID, id string,
+	beforeMsgID chat1.MessageID, num int) (res chat1.ArchiveChatImportedThread, err error) {
+	if num <= 0 {
+		num = archiveImportDefaultNum
+	}
+	g.ArchiveImportLock.Lock()
+	defer g.ArchiveImportLock.Unlock()
+	edb := archiveImportDB(g, uid)
+	index, err := loadArchiveImportIndexLocked(ctx, edb, uid)
+	if err != nil {
+		return res, err
+	}
+	i, found := findArchiveImportedConv(index, id)
+	if !found {
+		return res, ArchiveImportedConvNotFoundError{id: id}
+	}
+	res.Conv = index.Convs[i]
+	for page := archiveImportPages(res.Conv.Messages) - 1; page >= 0; page-- {
+		msgs, err := loadArchiveImportPage(ctx, edb, uid, id, page)
+		if err != nil {
+			return res, err
+		}
+		for j := len(msgs) - 1; j >= 0; j-- {
+			if beforeMsgID > 0 && msgs[j].MsgID >= beforeMsgID {
+				continue
+			}
+			if len(res.Messages) == num {
+				res.More = true
+				return res, nil
+			}
+			res.Messages = append(res.Messages, msgs[j])
+		}
+	}
+	return res, nil
+}
+
+// DeleteImportedArchiveConv removes an imported conversation and its
+// messages.
+func DeleteImportedArchiveConv(ctx context.Context, g *globals.Context, uid gregor1.UID, id string) error {
+	g.ArchiveImportLock.Lock()
+	defer g.ArchiveImportLock.Unlock()
+	edb := archiveImportDB(g, uid)
+	index, err := loadArchiveImportIndexLocked(ctx, edb, uid)
+	if err != nil {
+		return err
+	}
+	i, found := findArchiveImportedConv(index, id)
+	if !found {
+		return ArchiveImportedConvNotFoundError{id: id}
+	}
+	for page := 0; page < archiveImportPages(index.Convs[i].Messages); page++ {
+		if err := edb.Delete(ctx, archiveImportPageKey(uid, id, page)); err != nil {
+			return err
+		}
+	}
+	index.Convs = append(index.Convs[:i], index.Convs[i+1:]...)
+	return edb.Put(ctx, archiveImportIndexKey(uid), index)
+}
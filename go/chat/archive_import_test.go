@@ -0,0 +1,110 @@
+package chat
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveImportRead(t *testing.T) {
+	ctx := context.Background()
+	sent := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	general := []archiveJSONMessage{
+		{MessageID: 1, Type: "text", Sender: "alice", Ctime: sent, Body: "launch is friday",
+			SupersededBy: 3},
+		{MessageID: 2, Type: "attachment", Sender: "bob", Ctime: sent.Add(time.Minute),
+			Attachment: &archiveJSONAttachment{
+				archiveAttachmentInfo: archiveAttachmentInfo{MessageID: 2, Filename: "plan.pdf"},
+				Path:                  "plan.pdf",
+			}},
+		{MessageID: 3, Type: "edit", Sender: "alice", Ctime: sent.Add(2 * time.Minute),
+			Body: "launch is friday", Edits: 1},
+	}
+	random := []archiveJSONMessage{
+		{MessageID: 7, Type: "text", Sender: "alice", Ctime: sent,
+			DuplicateOf: &archiveMessageRef{Conv: "acme#general", MessageID: 1}},
+	}
+
+	dir := t.TempDir()
+	_, err := readArchiveForImport(ctx, dir)
+	require.Error(t, err)
+	for conv, msgs := range map[string][]archiveJSONMessage{
+		"acme#general": general,
+		"acme#random":  random,
+	} {
+		lines, err := encodeArchiveJSONMessages(msgs)
+		require.NoError(t, err)
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, conv), 0700))
+		// A job that stopped can leave half a line at the end.
+		require.NoError(t, os.WriteFile(filepath.Join(dir, conv, archiveMessagesFilename),
+			append(lines, []byte(`{"messageID": 9, "ty`)...), 0600))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "acme#general", "plan.pdf"), []byte("%PDF"), 0600))
+
+	convs, err := readArchiveForImport(ctx, dir)
+	require.NoError(t, err)
+	require.Len(t, convs, 2)
+	require.Equal(t, "acme#general", convs[0].name)
+	require.Nil(t, convs[0].convID)
+	require.Len(t, convs[0].msgs, 3)
+	// The deduped copy gets its body back.
+	require.Equal(t, "launch is friday", convs[1].msgs[0].Body)
+	require.Nil(t, convs[1].msgs[0].DuplicateOf)
+
+	imported := newArchiveImportedMessages(dir, convs[0])
+	require.Len(t, imported, 2)
+	require.Equal(t, filepath.Join(dir, "acme#general", "plan.pdf"), imported[1].AttachmentPath)
+	require.Equal(t, "plan.pdf", imported[1].AttachmentFilename)
+	require.NotEqual(t, archiveImportedConvID(convs[0]), archiveImportedConvID(convs[1]))
+
+	// A bad line in the middle is an error, not something to skip.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "acme#random", archiveMessagesFilename),
+		[]byte("{\"messageID\": 9, \"ty\n{}\n"), 0600))
+	_, err = readArchiveForImport(ctx, dir)
+	require.Error(t, err)
+
+	if checkArchiveDatabaseSupported() != nil {
+		return
+	}
+	// An archive.sqlite is read instead of the messages.jsonl files.
+	db, err := openArchiveDatabase(ctx, filepath.Join(dir, archiveDatabaseFilename))
+	require.NoError(t, err)
+	convID := chat1.ConversationID([]byte{1, 2, 3})
+	_, err = db.db.ExecContext(ctx, `INSERT INTO conversations
+		(id, name, members_type, topic_type, directory) VALUES (?, ?, ?, ?, ?)`,
+		convID.String(), "acme", "team", "chat", "acme#general")
+	require.NoError(t, err)
+	general[0].Reactions = map[string][]string{":+1:": {"bob"}}
+	require.NoError(t, db.writeMessages(ctx, convID, general))
+	require.NoError(t, db.Close())
+	convs, err = readArchiveForImport(ctx, dir)
+	require.NoError(t, err)
+	require.Len(t, convs, 1)
+	require.Equal(t, "acme#general", convs[0].name)
+	require.Equal(t, convID, *convs[0].convID)
+	require.Len(t, convs[0].msgs, 3)
+	require.Equal(t, sent, convs[0].msgs[0].Ctime)
+	require.Equal(t, map[string][]string{":+1:": {"bob"}}, convs[0].msgs[0].Reactions)
+	require.Equal(t, "plan.pdf", convs[0].msgs[1].Attachment.Path)
+	require.Equal(t, chat1.MessageID(1), convs[0].msgs[2].Edits)
+}
+
+func TestArchiveImportMerge(t *testing.T) {
+	msg := func(id chat1.MessageID, body string) chat1.ArchiveChatImportedMessage {
+		return chat1.ArchiveChatImportedMessage{MsgID: id, Body: body}
+	}
+	merged := mergeArchiveImportedMessages(
+		[]chat1.ArchiveChatImportedMessage{msg(1, "a"), msg(2, "b")},
+		[]chat1.ArchiveChatImportedMessage{msg(3, "c"), msg(2, "b, edited")})
+	require.Equal(t, []chat1.ArchiveChatImportedMessage{
+		msg(1, "a"), msg(2, "b, edited"), msg(3, "c"),
+	}, merged)
+	require.Equal(t, 0, archiveImportPages(0))
+	require.Equal(t, 1, archiveImportPages(archiveImportPageSize))
+	require.Equal(t, 2, archiveImportPages(archiveImportPageSize+1))
+}
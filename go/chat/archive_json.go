@@ -0,0 +1,184 @@
+package chat
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+)
+
+// Archives in the JSON format write a messages.jsonl next to (or instead of)
+// each conversation's chat.txt, with a line for each message in the same
+// order as chat.txt. Unlike the rendered text, each line keeps the message's
+// ID, the sending device, its reactions, what it edits or was edited by, and
+// a reference to its attachment, so archives can be processed by programs.
+// Reactions and other messages that only change other messages don't get
+// lines of their own, except for edits.
+
+const archiveMessagesFilename = "messages.jsonl"
+
+type archiveJSONMessage struct {
+	MessageID     chat1.MessageID `json:"messageID"`
+	Type          string          `json:"type"`
+	Sender        string          `json:"sender"`
+	Device        string          `json:"device"`
+	DeviceRevoked bool            `json:"deviceRevoked,omitempty"`
+	Ctime         time.Time       `json:"ctime"`
+	Body          string          `json:"body,omitempty"`
+	ReplyTo       chat1.MessageID `json:"replyTo,omitempty"`
+	// For edits, the message they edit.
+	Edits chat1.MessageID `json:"edits,omitempty"`
+	// The latest edit or deletion of the message. Its body is already the
+	// edited one.
+	SupersededBy chat1.MessageID        `json:"supersededBy,omitempty"`
+	Deleted      bool                   `json:"deleted,omitempty"`
+	Exploded     bool                   `json:"exploded,omitempty"`
+	Reactions    map[string][]string    `json:"reactions,omitempty"`
+	Attachment   *archiveJSONAttachment `json:"attachment,omitempty"`
+	// For a crossposted message whose body is written with another copy of
+	// it, that copy.
+	DuplicateOf *archiveMessageRef `json:"duplicateOf,omitempty"`
+}
+
+type archiveJSONAttachment struct {
+	archiveAttachmentInfo
+	// Where the attachment was downloaded to, relative to the conversation's
+	// directory. Empty in attachments metadata-only mode.
+	Path string `json:"path,omitempty"`
+}
+
+func archiveJSONMessageType(typ chat1.MessageType) (string, bool) {
+	switch typ {
+	case chat1.MessageType_TEXT,
+		chat1.MessageType_ATTACHMENT,
+		chat1.MessageType_EDIT,
+		chat1.MessageType_HEADLINE,
+		chat1.MessageType_JOIN,
+		chat1.MessageType_LEAVE,
+		chat1.MessageType_SYSTEM,
+		chat1.MessageType_SENDPAYMENT,
+		chat1.MessageType_REQUESTPAYMENT,
+		chat1.MessageType_FLIP,
+		chat1.MessageType_POLL,
+		chat1.MessageType_TASK:
+		return strings.ToLower(typ.String()), true
+	default:
+		return "", false
+	}
+}
+
+func archiveJSONBody(body chat1.MessageBody, typ chat1.MessageType) string {
+	switch typ {
+	case chat1.MessageType_TEXT:
+		return body.Text().Body
+	case chat1.MessageType_EDIT:
+		return body.Edit().Body
+	case chat1.MessageType_ATTACHMENT:
+		return body.Attachment().Object.Title
+	case chat1.MessageType_HEADLINE:
+		return body.Headline().Headline
+	case chat1.MessageType_SYSTEM:
+		return body.System().String()
+	case chat1.MessageType_FLIP:
+		return body.Flip().Text
+	case chat1.MessageType_POLL:
+		return body.Poll().Question
+	case chat1.MessageType_TASK:
+		return body.Task().Title
+	default:
+		return ""
+	}
+}
+
+// newArchiveJSONMessage returns the line for msg, or false if it doesn't
+// get one. attachmentPath is where its attachment was downloaded to, if it
+// was.
+func newArchiveJSONMessage(msg chat1.MessageUnboxedValid, redactor *archiveRedactor,
+	attachmentPath string, now time.Time) (res archiveJSONMessage, ok bool) {
+	typ := msg.ClientHeader.MessageType
+	res.Type, ok = archiveJSONMessageType(typ)
+	if !ok {
+		return res, false
+	}
+	bodyType, err := msg.MessageBody.MessageType()
+	if err != nil {
+		return res, false
+	}
+	res.MessageID = msg.ServerHeader.MessageID
+	res.Sender = msg.SenderUsername
+	res.Device = msg.SenderDeviceName
+	res.DeviceRevoked = msg.SenderDeviceRevokedAt != nil
+	res.Ctime = gregor1.FromTime(msg.ServerHeader.Ctime)
+	res.SupersededBy = msg.ServerHeader.SupersededBy
+	if typ == chat1.MessageType_EDIT && bodyType == chat1.MessageType_EDIT {
+		res.Edits = msg.MessageBody.Edit().MessageID
+	}
+	if msg.ReplyTo != nil {
+		res.ReplyTo = msg.ReplyTo.GetMessageID()
+	}
+	switch {
+	case bodyType == chat1.MessageType_NONE:
+		// Deleted messages have no body left.
+		res.Deleted = true
+	case msg.IsEphemeral() && msg.IsEphemeralExpired(now):
+		res.Exploded = true
+	default:
+		res.Body = redactor.Redact(archiveJSONBody(msg.MessageBody, typ))
+	}
+	if len(msg.Reactions.Reactions) > 0 {
+		res.Reactions = make(map[string][]string)
+		for reaction, users := range msg.Reactions.Reactions {
+			for username := range users {
+				res.Reactions[reaction] = append(res.Reactions[reaction], username)
+			}
+			sort.Strings(res.Reactions[reaction])
+		}
+	}
+	if bodyType == chat1.MessageType_ATTACHMENT && !res.Exploded {
+		res.Attachment = &archiveJSONAttachment{
+			archiveAttachmentInfo: newArchiveAttachmentInfo(msg, redactor),
+			Path:                  attachmentPath,
+		}
+	}
+	return res, true
+}
+
+// newArchiveJSONMessages returns the lines for msgs, skipping the ones that
+// don't get one. attachmentPath returns where a message's attachment was
+// downloaded to, if it was.
+func newArchiveJSONMessages(msgs []chat1.MessageUnboxed, redactor *archiveRedactor,
+	attachmentPath func(chat1.MessageUnboxedValid) string, now time.Time) (res []archiveJSONMessage) {
+	for _, m := range msgs {
+		if !m.IsValidFull() && !m.IsValidDeleted() {
+			continue
+		}
+		line, ok := newArchiveJSONMessage(m.Valid(), redactor, attachmentPath(m.Valid()), now)
+		if !ok {
+			continue
+		}
+		res = append(res, line)
+	}
+	return res
+}
+
+func encodeArchiveJSONMessages(lines []archiveJSONMessage) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	for _, line := range lines {
+		// Encode ends each line with a newline.
+		if err := enc.Encode(line); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func formatArchiveJSONMessages(msgs []chat1.MessageUnboxed, redactor *archiveRedactor,
+	attachmentPath func(chat1.MessageUnboxedValid) string, now time.Time) ([]byte, error) {
+	return encodeArchiveJSONMessages(newArchiveJSONMessages(msgs, redactor, attachmentPath, now))
+}
@@ -0,0 +1,94 @@
+package chat
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveJSONMessages(t *testing.T) {
+	sent := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	valid := func(id chat1.MessageID, typ chat1.MessageType, body chat1.MessageBody) chat1.MessageUnboxedValid {
+		return chat1.MessageUnboxedValid{
+			ClientHeader: chat1.MessageClientHeaderVerified{MessageType: typ},
+			ServerHeader: chat1.MessageServerHeader{
+				MessageID: id,
+				Ctime:     gregor1.ToTime(sent),
+			},
+			SenderUsername:   "alice",
+			SenderDeviceName: "laptop",
+			MessageBody:      body,
+		}
+	}
+
+	text := valid(10, chat1.MessageType_TEXT,
+		chat1.NewMessageBodyWithText(chat1.MessageText{Body: "see OPS-12, edited"}))
+	text.ServerHeader.SupersededBy = 11
+	text.Reactions = chat1.ReactionMap{Reactions: map[string]map[string]chat1.Reaction{
+		":+1:": {"bob": {ReactionMsgID: 13}, "carol": {ReactionMsgID: 12}},
+	}}
+	edit := valid(11, chat1.MessageType_EDIT,
+		chat1.NewMessageBodyWithEdit(chat1.MessageEdit{MessageID: 10, Body: "see OPS-12, edited"}))
+	reaction := valid(12, chat1.MessageType_REACTION,
+		chat1.NewMessageBodyWithReaction(chat1.MessageReaction{MessageID: 10, Body: ":+1:"}))
+	deleted := valid(14, chat1.MessageType_TEXT, chat1.MessageBody{})
+	att := valid(15, chat1.MessageType_ATTACHMENT,
+		chat1.NewMessageBodyWithAttachment(chat1.MessageAttachment{
+			Object: chat1.Asset{Filename: "notes.pdf", Title: "notes", Size: 1234},
+		}))
+
+	redactor, err := newArchiveRedactor([]chat1.ArchiveRedactionRule{
+		{Name: "ticket", Pattern: `OPS-[0-9]+`},
+	})
+	require.NoError(t, err)
+	msgs := []chat1.MessageUnboxed{
+		chat1.NewMessageUnboxedWithValid(text),
+		chat1.NewMessageUnboxedWithValid(edit),
+		chat1.NewMessageUnboxedWithValid(reaction),
+		chat1.NewMessageUnboxedWithValid(deleted),
+		chat1.NewMessageUnboxedWithValid(att),
+	}
+	out, err := formatArchiveJSONMessages(msgs, redactor, func(msg chat1.MessageUnboxedValid) string {
+		if msg.ServerHeader.MessageID == 15 {
+			return "attachment.pdf"
+		}
+		return ""
+	}, sent)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(string(out), "\n"), "\n")
+	require.Len(t, lines, 4)
+	var res []archiveJSONMessage
+	for _, line := range lines {
+		var m archiveJSONMessage
+		require.NoError(t, json.Unmarshal([]byte(line), &m))
+		res = append(res, m)
+	}
+
+	require.Equal(t, chat1.MessageID(10), res[0].MessageID)
+	require.Equal(t, "text", res[0].Type)
+	require.Equal(t, "alice", res[0].Sender)
+	require.Equal(t, "laptop", res[0].Device)
+	require.True(t, sent.Equal(res[0].Ctime))
+	require.Equal(t, "see [REDACTED], edited", res[0].Body)
+	require.Equal(t, chat1.MessageID(11), res[0].SupersededBy)
+	require.Equal(t, map[string][]string{":+1:": {"bob", "carol"}}, res[0].Reactions)
+
+	require.Equal(t, "edit", res[1].Type)
+	require.Equal(t, chat1.MessageID(10), res[1].Edits)
+
+	require.Equal(t, chat1.MessageID(14), res[2].MessageID)
+	require.True(t, res[2].Deleted)
+	require.Empty(t, res[2].Body)
+
+	require.Equal(t, "attachment", res[3].Type)
+	require.NotNil(t, res[3].Attachment)
+	require.Equal(t, "notes.pdf", res[3].Attachment.Filename)
+	require.Equal(t, int64(1234), res[3].Attachment.Size)
+	require.Equal(t, "attachment.pdf", res[3].Attachment.Path)
+}
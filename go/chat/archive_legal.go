@@ -0,0 +1,162 @@
+package chat
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/keybase/client/go/engine"
+	"github.com/keybase/client/go/kbcrypto"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+const archiveLegalTranscriptFilename = "transcript.txt"
+
+// archiveLegalTranscriptWidth is how wide a legal transcript's pages are, so
+// they print without wrapping. Messages are rendered a little narrower, to
+// leave room for line numbers.
+const archiveLegalTranscriptWidth = 100
+const archiveLegalTranscriptLineNumberWidth = 4 // "%2d  "
+
+// archiveLegalTranscriptLinesPerPage is how many numbered lines go on each
+// page, as on a deposition transcript, so any line can be cited as
+// page:line.
+const archiveLegalTranscriptLinesPerPage = 25
+
+// archiveLegalTranscriptCover is what the cover page of a legal transcript
+// says about it.
+type archiveLegalTranscriptCover struct {
+	ConvID     chat1.ConversationID
+	ConvName   string
+	ExportedBy string
+	Device     string
+	ExportedAt time.Time
+	Hash       string
+	Lines      int
+	Pages      int
+}
+
+// statement is the part of the cover page that's signed.
+func (c archiveLegalTranscriptCover) statement() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Conversation:     %s\n", c.ConvName)
+	fmt.Fprintf(&b, "Conversation ID:  %s\n", c.ConvID)
+	fmt.Fprintf(&b, "Exported by:      %s (device %s)\n", c.ExportedBy, c.Device)
+	fmt.Fprintf(&b, "Exported at:      %s\n", c.ExportedAt.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&b, "Length:           %d lines on %d pages\n", c.Lines, c.Pages)
+	fmt.Fprintf(&b, "Export hash:      sha256:%s\n", c.Hash)
+	return b.String()
+}
+
+// archiveLegalTranscriptHash hashes the transcript's lines, each ending in a
+// newline, which is also how they appear in chat.txt.
+func archiveLegalTranscriptHash(lines []string) string {
+	h := sha256.New()
+	for _, line := range lines {
+		_, _ = io.WriteString(h, line+"\n")
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func archiveLegalTranscriptPages(lines []string) int {
+	pages := (len(lines) + archiveLegalTranscriptLinesPerPage - 1) / archiveLegalTranscriptLinesPerPage
+	if pages == 0 {
+		return 1
+	}
+	return pages
+}
+
+// writeArchiveLegalTranscript writes the cover page, with sig by the device
+// key kid over its statement, and then lines on numbered pages headed with
+// the conversation ID and export hash. Pages are separated by form feeds.
+func writeArchiveLegalTranscript(w io.Writer, cover archiveLegalTranscriptCover,
+	kid keybase1.KID, sig string, lines []string) error {
+	bw := bufio.NewWriter(w)
+	rule := strings.Repeat("-", archiveLegalTranscriptWidth)
+	fmt.Fprintf(bw, "KEYBASE CHAT TRANSCRIPT\n\n%s\n", rule)
+	fmt.Fprint(bw, cover.statement())
+	fmt.Fprintf(bw, "%s\n\n", rule)
+	fmt.Fprintf(bw, "The lines between the rules were signed, with the signature prefix\n%q, by the device key\n%s.\n",
+		kbcrypto.SignaturePrefixChatLegalTranscript, kid)
+	fmt.Fprint(bw, "The export hash is the SHA-256 of the transcript's lines, without page\n")
+	fmt.Fprint(bw, "headers or line numbers, each ending in a newline.\n\n")
+	fmt.Fprintf(bw, "Signature:        %s\n", sig)
+
+	for page := 1; page <= cover.Pages; page++ {
+		pageNum := fmt.Sprintf("Page %d of %d", page, cover.Pages)
+		convID := fmt.Sprintf("Conversation %s", cover.ConvID)
+		fmt.Fprintf(bw, "\f%-*s%s\n", archiveLegalTranscriptWidth-len(pageNum), convID, pageNum)
+		fmt.Fprintf(bw, "Export sha256:%s\n%s\n", cover.Hash, rule)
+		start := (page - 1) * archiveLegalTranscriptLinesPerPage
+		for i := start; i < start+archiveLegalTranscriptLinesPerPage && i < len(lines); i++ {
+			fmt.Fprintf(bw, "%2d  %s\n", i-start+1, lines[i])
+		}
+	}
+	return bw.Flush()
+}
+
+func (c *ChatArchiver) signLegalTranscript(ctx context.Context, statement string) (
+	kid keybase1.KID, sig string, err error) {
+	signingKey, err := engine.GetMySecretKey(ctx, c.G().ExternalG(),
+		libkb.DeviceSigningKeyType, "sign chat transcript")
+	if err != nil {
+		return kid, sig, err
+	}
+	kp, ok := signingKey.(libkb.NaclSigningKeyPair)
+	if !ok || kp.Private == nil {
+		return kid, sig, libkb.KeyCannotSignError{}
+	}
+	info, err := kp.SignV2([]byte(statement), kbcrypto.SignaturePrefixChatLegalTranscript)
+	if err != nil {
+		return kid, sig, err
+	}
+	return kp.GetKID(), base64.StdEncoding.EncodeToString(info.Sig[:]), nil
+}
+
+// writeLegalTranscript writes a transcript.txt next to the conversation's
+// chat.txt at chatPath, from the lines already rendered there, so it has
+// the same messages, redacted the same way. It's rewritten from scratch if
+// the job is resumed.
+func (c *ChatArchiver) writeLegalTranscript(ctx context.Context,
+	conv chat1.ConversationLocal, chatPath string) error {
+	content, err := os.ReadFile(chatPath)
+	if err != nil {
+		return err
+	}
+	var lines []string
+	if len(content) > 0 {
+		lines = strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	}
+	cover := archiveLegalTranscriptCover{
+		ConvID:     conv.Info.Id,
+		ConvName:   c.archiveName(conv),
+		ExportedBy: c.G().GlobalContext.Env.GetUsername().String(),
+		Device:     c.G().ActiveDevice.Name(),
+		ExportedAt: time.Now(),
+		Hash:       archiveLegalTranscriptHash(lines),
+		Lines:      len(lines),
+		Pages:      archiveLegalTranscriptPages(lines),
+	}
+	kid, sig, err := c.signLegalTranscript(ctx, cover.statement())
+	if err != nil {
+		return fmt.Errorf("unable to sign transcript: %v", err)
+	}
+
+	f, err := os.OpenFile(path.Join(path.Dir(chatPath), archiveLegalTranscriptFilename),
+		os.O_WRONLY|os.O_CREATE|os.O_TRUNC, libkb.PermFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return writeArchiveLegalTranscript(f, cover, kid, sig, lines)
+}
@@ -0,0 +1,66 @@
+package chat
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveLegalTranscript(t *testing.T) {
+	require.Equal(t, 1, archiveLegalTranscriptPages(nil))
+	require.Equal(t, 1, archiveLegalTranscriptPages(make([]string, 25)))
+	require.Equal(t, 2, archiveLegalTranscriptPages(make([]string, 26)))
+	// The hash is of the lines as they are in chat.txt.
+	require.Equal(t, "911169ddaaf146aff539f58c26c489af3b892dff0fe283c1c264c65ae5aa59a2",
+		archiveLegalTranscriptHash([]string{"a", "b"}))
+
+	var lines []string
+	for i := 1; i <= 30; i++ {
+		lines = append(lines, fmt.Sprintf("message %d", i))
+	}
+	convID := chat1.ConversationID([]byte{0xab, 0xcd})
+	cover := archiveLegalTranscriptCover{
+		ConvID:     convID,
+		ConvName:   "acme#general",
+		ExportedBy: "alice",
+		Device:     "laptop",
+		ExportedAt: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		Hash:       archiveLegalTranscriptHash(lines),
+		Lines:      len(lines),
+		Pages:      archiveLegalTranscriptPages(lines),
+	}
+	require.Contains(t, cover.statement(), "Exported at:      2024-03-01T12:00:00Z\n")
+	require.Contains(t, cover.statement(), "Length:           30 lines on 2 pages\n")
+
+	var buf bytes.Buffer
+	require.NoError(t, writeArchiveLegalTranscript(&buf, cover,
+		keybase1.KID("0120abcd"), "c2lnbmF0dXJl", lines))
+	pages := strings.Split(buf.String(), "\f")
+	require.Len(t, pages, 3)
+	require.Contains(t, pages[0], cover.statement())
+	require.Contains(t, pages[0], "0120abcd")
+	require.Contains(t, pages[0], "Signature:        c2lnbmF0dXJl\n")
+
+	for i, page := range pages[1:] {
+		pageLines := strings.Split(strings.TrimSuffix(page, "\n"), "\n")
+		require.Len(t, pageLines[0], archiveLegalTranscriptWidth)
+		require.True(t, strings.HasPrefix(pageLines[0], "Conversation abcd "))
+		require.True(t, strings.HasSuffix(pageLines[0], fmt.Sprintf("Page %d of 2", i+1)))
+		require.Equal(t, "Export sha256:"+cover.Hash, pageLines[1])
+	}
+	second := strings.Split(strings.TrimSuffix(pages[2], "\n"), "\n")[3:]
+	require.Equal(t, []string{
+		" 1  message 26",
+		" 2  message 27",
+		" 3  message 28",
+		" 4  message 29",
+		" 5  message 30",
+	}, second)
+	require.Contains(t, pages[1], "25  message 25\n")
+}
@@ -0,0 +1,67 @@
+package chat
+
+import (
+	"context"
+
+	"github.com/keybase/client/go/protocol/chat1"
+)
+
+// Progress notifications carry the progress of the conversation they're
+// about, as well as the job's, so the GUI can show which conversations are
+// done. A conversation's total is the same estimate the job's total is made
+// from, and it's done once its last page has been archived. While a page's
+// attachments are downloading, it's in the attachments phase.
+
+// advanceArchiveConvProgress returns progress once another page of num
+// messages has been archived, which is the last if last is set.
+func advanceArchiveConvProgress(progress chat1.ArchiveChatConvProgress, num int64,
+	last bool) chat1.ArchiveChatConvProgress {
+	progress.MessagesComplete += num
+	progress.Phase = chat1.ArchiveChatConvPhase_MESSAGES
+	if progress.MessagesComplete > progress.MessagesTotal || last {
+		// Like the job's, the total is only an estimate.
+		progress.MessagesComplete = progress.MessagesTotal
+	}
+	if last {
+		progress.Phase = chat1.ArchiveChatConvPhase_DONE
+	}
+	return progress
+}
+
+// setConvProgress starts tracking conv's progress, with total the estimate
+// of how many of its messages the job archives.
+func (c *ChatArchiver) setConvProgress(conv chat1.ConversationLocal, total int64) {
+	c.Lock()
+	defer c.Unlock()
+	c.convProgress[conv.Info.Id.DbShortFormString()] = chat1.ArchiveChatConvProgress{
+		ConvID:        conv.Info.Id,
+		ConvName:      c.archiveName(conv),
+		MessagesTotal: total,
+	}
+}
+
+// notifyConvPhase notifies the UI that convID's archiving is in phase,
+// without any more messages having been archived.
+func (c *ChatArchiver) notifyConvPhase(ctx context.Context, jobID chat1.ArchiveJobID,
+	convID chat1.ConversationID, phase chat1.ArchiveChatConvPhase) {
+	c.Lock()
+	defer c.Unlock()
+	key := convID.DbShortFormString()
+	if progress, ok := c.convProgress[key]; ok {
+		progress.Phase = phase
+		if phase == chat1.ArchiveChatConvPhase_DONE {
+			progress.MessagesComplete = progress.MessagesTotal
+		}
+		c.convProgress[key] = progress
+	}
+	c.notifyProgressLocked(ctx, jobID, convID)
+}
+
+func (c *ChatArchiver) notifyProgressLocked(ctx context.Context, jobID chat1.ArchiveJobID,
+	convID chat1.ConversationID) {
+	var conv *chat1.ArchiveChatConvProgress
+	if progress, ok := c.convProgress[convID.DbShortFormString()]; ok {
+		conv = &progress
+	}
+	c.G().NotifyRouter.HandleChatArchiveProgress(ctx, jobID, c.messagesComplete, c.messagesTotal, conv)
+}
@@ -0,0 +1,32 @@
+package chat
+
+import (
+	"testing"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdvanceArchiveConvProgress(t *testing.T) {
+	progress := chat1.ArchiveChatConvProgress{
+		ConvName:      "alice,bob",
+		MessagesTotal: 250,
+		Phase:         chat1.ArchiveChatConvPhase_ATTACHMENTS,
+	}
+	progress = advanceArchiveConvProgress(progress, 100, false)
+	require.Equal(t, int64(100), progress.MessagesComplete)
+	require.Equal(t, chat1.ArchiveChatConvPhase_MESSAGES, progress.Phase)
+	progress = advanceArchiveConvProgress(progress, 100, false)
+	require.Equal(t, int64(200), progress.MessagesComplete)
+
+	// The total is only an estimate, so don't go over it.
+	over := advanceArchiveConvProgress(progress, 100, false)
+	require.Equal(t, int64(250), over.MessagesComplete)
+	require.Equal(t, chat1.ArchiveChatConvPhase_MESSAGES, over.Phase)
+
+	// A short last page still finishes it.
+	done := advanceArchiveConvProgress(progress, 10, true)
+	require.Equal(t, int64(250), done.MessagesComplete)
+	require.Equal(t, chat1.ArchiveChatConvPhase_DONE, done.Phase)
+	require.Equal(t, "alice,bob", done.ConvName)
+}
@@ -0,0 +1,94 @@
+package chat
+
+import (
+	"errors"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+)
+
+// Archive jobs can be limited to the messages sent in a range of time, from
+// After (inclusive) to Before (exclusive), like "everything from 2023" or
+// "the last 90 days". The range goes in the Pull query, but pages are still
+// filtered here by the messages' ctimes, and a conversation is done as soon
+// as a page reaches back past After, since pages go from newest to oldest.
+
+func checkArchiveRange(after, before *gregor1.Time) error {
+	if after != nil && before != nil && *after >= *before {
+		return errors.New("the start of the date range must be before its end")
+	}
+	return nil
+}
+
+func inArchiveRange(ctime gregor1.Time, after, before *gregor1.Time) bool {
+	if after != nil && ctime < *after {
+		return false
+	}
+	if before != nil && ctime >= *before {
+		return false
+	}
+	return true
+}
+
+// filterArchiveRange returns the messages in msgs sent in the range, and
+// whether any of them were sent before it, in which case there's nothing in
+// range on older pages. Messages without a ctime, like ones that failed to
+// unbox, are only kept if there's no range.
+func filterArchiveRange(msgs []chat1.MessageUnboxed, after, before *gregor1.Time) (
+	res []chat1.MessageUnboxed, pastRange bool) {
+	if after == nil && before == nil {
+		return msgs, false
+	}
+	res = make([]chat1.MessageUnboxed, 0, len(msgs))
+	for _, m := range msgs {
+		ctime := m.Ctime()
+		if ctime == 0 {
+			continue
+		}
+		if after != nil && ctime < *after {
+			pastRange = true
+		}
+		if inArchiveRange(ctime, after, before) {
+			res = append(res, m)
+		}
+	}
+	return res, pastRange
+}
+
+// archiveRangeMessagesEstimate estimates how many of conv's messages were
+// sent in the range, going by how much of the time between the
+// conversation's creation and its last activity the range covers. It's 0 if
+// the range doesn't overlap that time at all.
+func archiveRangeMessagesEstimate(conv chat1.ConversationLocal, after, before *gregor1.Time) int64 {
+	total := int64(conv.MaxVisibleMsgID() - conv.GetMaxDeletedUpTo())
+	if total <= 0 || (after == nil && before == nil) {
+		return total
+	}
+	var start gregor1.Time
+	if conv.CreatorInfo != nil {
+		start = conv.CreatorInfo.Ctime
+	}
+	end := conv.GetMtime()
+	if after != nil && end < *after {
+		return 0
+	}
+	if before != nil && start != 0 && start >= *before {
+		return 0
+	}
+	if start == 0 || end <= start {
+		// No idea how the messages are spread out.
+		return total
+	}
+	lo, hi := start, end
+	if after != nil && *after > lo {
+		lo = *after
+	}
+	if before != nil && *before < hi {
+		hi = *before
+	}
+	estimate := int64(float64(total) * float64(hi-lo) / float64(end-start))
+	if estimate < 1 {
+		estimate = 1
+	}
+	return estimate
+}
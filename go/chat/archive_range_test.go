@@ -0,0 +1,54 @@
+package chat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveRange(t *testing.T) {
+	day := func(d int) gregor1.Time {
+		return gregor1.ToTime(time.Date(2023, 1, d, 0, 0, 0, 0, time.UTC))
+	}
+	after, before := day(10), day(20)
+	require.NoError(t, checkArchiveRange(&after, &before))
+	require.NoError(t, checkArchiveRange(nil, &before))
+	require.Error(t, checkArchiveRange(&before, &after))
+
+	msg := func(id chat1.MessageID, ctime gregor1.Time) chat1.MessageUnboxed {
+		return chat1.NewMessageUnboxedWithValid(chat1.MessageUnboxedValid{
+			ServerHeader: chat1.MessageServerHeader{MessageID: id, Ctime: ctime},
+		})
+	}
+	// Newest first, like a page.
+	page := []chat1.MessageUnboxed{msg(4, day(21)), msg(3, day(20)), msg(2, day(15)), msg(1, day(10))}
+	res, pastRange := filterArchiveRange(page, &after, &before)
+	require.False(t, pastRange)
+	require.Len(t, res, 2)
+	require.Equal(t, chat1.MessageID(2), res[0].GetMessageID())
+	require.Equal(t, chat1.MessageID(1), res[1].GetMessageID())
+	res, pastRange = filterArchiveRange(append(page, msg(0, day(9))), &after, nil)
+	require.True(t, pastRange)
+	require.Len(t, res, 4)
+	res, pastRange = filterArchiveRange(page, nil, nil)
+	require.False(t, pastRange)
+	require.Len(t, res, 4)
+
+	// 100 messages from the 1st to the 31st.
+	conv := chat1.ConversationLocal{
+		CreatorInfo: &chat1.ConversationCreatorInfoLocal{Ctime: day(1)},
+		ReaderInfo:  chat1.ConversationReaderInfo{Mtime: day(31)},
+		MaxMessages: []chat1.MessageSummary{{MsgID: 100, MessageType: chat1.MessageType_TEXT}},
+	}
+	require.Equal(t, int64(100), archiveRangeMessagesEstimate(conv, nil, nil))
+	require.Equal(t, int64(33), archiveRangeMessagesEstimate(conv, &after, &before))
+	require.Equal(t, int64(70), archiveRangeMessagesEstimate(conv, &after, nil))
+	late, early := day(31)+1, day(1)
+	require.Zero(t, archiveRangeMessagesEstimate(conv, &late, nil))
+	require.Zero(t, archiveRangeMessagesEstimate(conv, nil, &early))
+	conv.CreatorInfo = nil
+	require.Equal(t, int64(100), archiveRangeMessagesEstimate(conv, &after, &before))
+}
@@ -0,0 +1,179 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// Redaction rules let team admins scrub secrets (API keys, ticket IDs, etc)
+// from archive exports of their team's channels. They're stored in an
+// admin-only dev conversation, like the welcome message, so a member running
+// an export can check that an admin set them. They don't stop a member from
+// reading or copying the messages any other way.
+
+const archiveRedactionRulesName = "__archive_redaction_rules"
+const archiveRedactionDefaultReplacement = "[REDACTED]"
+const archiveRedactionMaxRules = 50
+
+func checkArchiveRedactionRules(rules chat1.ArchiveRedactionRules) error {
+	if len(rules.Rules) > archiveRedactionMaxRules {
+		return fmt.Errorf("at most %d redaction rules are allowed; got %d", archiveRedactionMaxRules, len(rules.Rules))
+	}
+	names := make(map[string]bool)
+	for _, rule := range rules.Rules {
+		if len(rule.Name) == 0 {
+			return errors.New("redaction rules must have a name")
+		}
+		if names[rule.Name] {
+			return fmt.Errorf("duplicate redaction rule %q", rule.Name)
+		}
+		names[rule.Name] = true
+		if len(rule.Pattern) == 0 {
+			return fmt.Errorf("redaction rule %q has no pattern", rule.Name)
+		}
+		if _, err := regexp.Compile(rule.Pattern); err != nil {
+			return fmt.Errorf("redaction rule %q: %v", rule.Name, err)
+		}
+	}
+	return nil
+}
+
+func getArchiveRedactionRules(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, teamID keybase1.TeamID) (rules chat1.ArchiveRedactionRules, err error) {
+	conv, err := getWelcomeMessageConv(ctx, g, uid, teamID)
+	if err != nil {
+		return rules, err
+	}
+	s := NewConvDevConversationBackedStorage(g, chat1.TopicType_DEV, true /* adminOnly */, ri)
+	found, _, err := s.Get(ctx, uid, conv.GetConvID(), archiveRedactionRulesName, &rules, false)
+	switch err.(type) {
+	case nil:
+	case *DevStorageAdminOnlyError:
+		// Not written by an admin, so ignore it.
+		return chat1.ArchiveRedactionRules{}, nil
+	default:
+		return rules, err
+	}
+	if !found {
+		return chat1.ArchiveRedactionRules{}, nil
+	}
+	if err := checkArchiveRedactionRules(rules); err != nil {
+		return rules, err
+	}
+	return rules, nil
+}
+
+func setArchiveRedactionRules(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, teamID keybase1.TeamID, rules chat1.ArchiveRedactionRules) (err error) {
+	if err := checkArchiveRedactionRules(rules); err != nil {
+		return err
+	}
+	conv, err := getWelcomeMessageConv(ctx, g, uid, teamID)
+	if err != nil {
+		return err
+	}
+	s := NewConvDevConversationBackedStorage(g, chat1.TopicType_DEV, true /* adminOnly */, ri)
+	return s.Put(ctx, uid, conv.GetConvID(), archiveRedactionRulesName, rules)
+}
+
+// archiveRedactor applies one team's redaction rules to exported text.
+type archiveRedactor struct {
+	res          []*regexp.Regexp
+	replacements []string
+}
+
+func newArchiveRedactor(rules []chat1.ArchiveRedactionRule) (*archiveRedactor, error) {
+	r := &archiveRedactor{}
+	for _, rule := range rules {
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("redaction rule %q: %v", rule.Name, err)
+		}
+		replacement := rule.Replacement
+		if len(replacement) == 0 {
+			replacement = archiveRedactionDefaultReplacement
+		}
+		r.res = append(r.res, re)
+		r.replacements = append(r.replacements, replacement)
+	}
+	return r, nil
+}
+
+// Redact is safe to call on a nil redactor.
+func (r *archiveRedactor) Redact(s string) string {
+	if r == nil {
+		return s
+	}
+	for i, re := range r.res {
+		s = re.ReplaceAllLiteralString(s, r.replacements[i])
+	}
+	return s
+}
+
+// loadArchiveRedactionRules fetches the redaction rules for every team the
+// convs belong to. Teams without rules are left out.
+func loadArchiveRedactionRules(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, convs []chat1.ConversationLocal) (res []chat1.ArchiveAppliedRedactionRules, err error) {
+	seen := make(map[keybase1.TeamID]bool)
+	for _, conv := range convs {
+		if conv.GetMembersType() != chat1.ConversationMembersType_TEAM {
+			continue
+		}
+		teamID, err := TLFIDToTeamID(conv.Info.Triple.Tlfid)
+		if err != nil {
+			return nil, err
+		}
+		if seen[teamID] {
+			continue
+		}
+		seen[teamID] = true
+		rules, err := getArchiveRedactionRules(ctx, g, ri, uid, teamID)
+		switch err.(type) {
+		case nil:
+		case libkb.NotFoundError:
+			continue
+		default:
+			// Better to fail than to export something an admin wanted
+			// scrubbed.
+			return nil, fmt.Errorf("unable to load redaction rules for %s: %v", conv.Info.TlfName, err)
+		}
+		if len(rules.Rules) == 0 {
+			continue
+		}
+		res = append(res, chat1.ArchiveAppliedRedactionRules{
+			TeamID:   teamID,
+			TeamName: conv.Info.TlfName,
+			Rules:    rules.Rules,
+		})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].TeamName < res[j].TeamName })
+	return res, nil
+}
+
+// formatArchiveRedactionSummary lists the rules applied to an export, for the
+// summary written next to it.
+func formatArchiveRedactionSummary(applied []chat1.ArchiveAppliedRedactionRules) string {
+	var b strings.Builder
+	b.WriteString("Redaction rules applied to this archive, as set by team admins:\n")
+	for _, team := range applied {
+		fmt.Fprintf(&b, "\n%s:\n", team.TeamName)
+		for _, rule := range team.Rules {
+			replacement := rule.Replacement
+			if len(replacement) == 0 {
+				replacement = archiveRedactionDefaultReplacement
+			}
+			fmt.Fprintf(&b, "  %s: /%s/ -> %q\n", rule.Name, rule.Pattern, replacement)
+		}
+	}
+	return b.String()
+}
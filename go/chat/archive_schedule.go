@@ -0,0 +1,229 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// Jobs can be rerun on a schedule, daily or weekly at a local time of day,
+// to keep a rolling archive. Each run appends the messages sent since to the
+// output of the run before, starting with the job the schedule was set on.
+// The registry keeps the schedules with the job history and checks for due
+// ones every minute; a run that came due while the service wasn't running,
+// or the app was in the background, happens as soon as it can.
+
+const archiveScheduleCheckInterval = time.Minute
+
+func checkArchiveSchedule(schedule chat1.ArchiveChatSchedule) error {
+	if schedule.MinuteOfDay < 0 || schedule.MinuteOfDay >= 24*60 {
+		return errors.New("the time of day to run at must be between 00:00 and 23:59")
+	}
+	switch schedule.Frequency {
+	case chat1.ArchiveChatScheduleFrequency_DAILY:
+	case chat1.ArchiveChatScheduleFrequency_WEEKLY:
+		if schedule.Weekday < 0 || schedule.Weekday > 6 {
+			return errors.New("the day of the week to run on must be between 0 (Sunday) and 6 (Saturday)")
+		}
+	default:
+		return fmt.Errorf("unknown schedule frequency %v", schedule.Frequency)
+	}
+	return nil
+}
+
+// nextArchiveScheduleRun returns the first time the schedule runs after
+// after.
+func nextArchiveScheduleRun(schedule chat1.ArchiveChatSchedule, after time.Time) time.Time {
+	after = after.Local()
+	next := time.Date(after.Year(), after.Month(), after.Day(),
+		schedule.MinuteOfDay/60, schedule.MinuteOfDay%60, 0, 0, time.Local)
+	days := 1
+	if schedule.Frequency == chat1.ArchiveChatScheduleFrequency_WEEKLY {
+		days = 7
+		next = next.AddDate(0, 0, (schedule.Weekday-int(next.Weekday())+7)%7)
+	}
+	for !next.After(after) {
+		next = next.AddDate(0, 0, days)
+	}
+	return next
+}
+
+// scheduledArchiveRequest returns the request for a scheduled run after
+// last, the job the schedule last ran, or nil if last is still going. A
+// run that failed is tried again, carrying on from where it stopped.
+func scheduledArchiveRequest(last chat1.ArchiveChatJob, runID chat1.ArchiveJobID) *chat1.ArchiveChatJobRequest {
+	switch last.Status {
+	case chat1.ArchiveChatJobStatus_ERROR:
+		req := last.Request
+		return &req
+	case chat1.ArchiveChatJobStatus_COMPLETE:
+		return &chat1.ArchiveChatJobRequest{
+			JobID:            runID,
+			AppendTo:         last.Request.JobID,
+			IdentifyBehavior: keybase1.TLFIdentifyBehavior_CHAT_GUI,
+		}
+	default:
+		return nil
+	}
+}
+
+func newArchiveJobID() (chat1.ArchiveJobID, error) {
+	id, err := libkb.RandInt()
+	if err != nil {
+		return "", err
+	}
+	return chat1.ArchiveJobID(fmt.Sprintf("arc-%d", id&0xFFFFFFF)), nil
+}
+
+// SetSchedule reruns the job on schedule, or stops doing so if schedule is
+// nil. Changing a job's schedule keeps appending to its last run.
+func (r *ChatArchiveRegistry) SetSchedule(ctx context.Context, jobID chat1.ArchiveJobID,
+	schedule *chat1.ArchiveChatSchedule) (err error) {
+	defer r.Trace(ctx, &err, "SetSchedule(%v)", jobID)()
+	r.Lock()
+	defer r.Unlock()
+	err = r.initLocked(ctx)
+	if err != nil {
+		return err
+	}
+
+	prev, scheduled := r.jobHistory.Schedules[jobID]
+	if schedule == nil {
+		if !scheduled {
+			return fmt.Errorf("job %s isn't scheduled", jobID)
+		}
+		delete(r.jobHistory.Schedules, jobID)
+		r.dirty = true
+		return nil
+	}
+	job, ok := r.jobHistory.JobHistory[jobID]
+	if !ok {
+		return NewArchiveJobNotFoundError(jobID)
+	}
+	if err := checkArchiveSchedule(*schedule); err != nil {
+		return err
+	}
+	if err := checkArchiveAppendable(job); err != nil {
+		return err
+	}
+	for scheduledID, other := range r.jobHistory.Schedules {
+		if scheduledID != jobID && other.LastJobID == jobID {
+			return fmt.Errorf("job %s was run on %s's schedule; change that instead", jobID, scheduledID)
+		}
+	}
+
+	res := *schedule
+	res.LastJobID = jobID
+	if scheduled {
+		res.LastJobID = prev.LastJobID
+	}
+	res.NextRun = gregor1.ToTime(nextArchiveScheduleRun(res, r.clock.Now()))
+	if r.jobHistory.Schedules == nil {
+		r.jobHistory.Schedules = make(map[chat1.ArchiveJobID]chat1.ArchiveChatSchedule)
+	}
+	r.jobHistory.Schedules[jobID] = res
+	r.dirty = true
+	return nil
+}
+
+type scheduledArchiveRun struct {
+	scheduleID chat1.ArchiveJobID
+	// The run before, if this is a new one.
+	prevJobID chat1.ArchiveJobID
+	req       chat1.ArchiveChatJobRequest
+}
+
+func (r *ChatArchiveRegistry) scheduleLoop(stopCh chan struct{}) error {
+	ctx := context.Background()
+	r.Debug(ctx, "scheduleLoop: starting")
+	for {
+		select {
+		case <-stopCh:
+			r.Debug(ctx, "scheduleLoop: shutting down")
+			return nil
+		case <-r.clock.After(archiveScheduleCheckInterval):
+			r.runDueSchedules(ctx)
+		}
+	}
+}
+
+func (r *ChatArchiveRegistry) runDueSchedules(ctx context.Context) {
+	if r.G().MobileAppState.State() != keybase1.MobileAppState_FOREGROUND {
+		// Jobs get paused in the background anyway.
+		return
+	}
+	r.Lock()
+	defer r.Unlock()
+	err := r.initLocked(ctx)
+	if err != nil {
+		r.Debug(ctx, "runDueSchedules: %s", err)
+		return
+	}
+	now := r.clock.Now()
+	for scheduleID, schedule := range r.jobHistory.Schedules {
+		if gregor1.FromTime(schedule.NextRun).After(now) {
+			continue
+		}
+		schedule.NextRun = gregor1.ToTime(nextArchiveScheduleRun(schedule, now))
+		r.jobHistory.Schedules[scheduleID] = schedule
+		r.dirty = true
+
+		last, ok := r.jobHistory.JobHistory[schedule.LastJobID]
+		if !ok {
+			r.Debug(ctx, "runDueSchedules: %s's last run %s was deleted, dropping the schedule",
+				scheduleID, schedule.LastJobID)
+			delete(r.jobHistory.Schedules, scheduleID)
+			continue
+		}
+		runID, err := newArchiveJobID()
+		if err != nil {
+			r.Debug(ctx, "runDueSchedules: %s", err)
+			continue
+		}
+		req := scheduledArchiveRequest(last, runID)
+		if req == nil {
+			r.Debug(ctx, "runDueSchedules: %s's last run %s is %v, skipping this run",
+				scheduleID, schedule.LastJobID, last.Status)
+			continue
+		}
+		run := scheduledArchiveRun{scheduleID: scheduleID, req: *req}
+		if req.JobID == runID {
+			run.prevJobID = schedule.LastJobID
+			schedule.LastJobID = runID
+			r.jobHistory.Schedules[scheduleID] = schedule
+		}
+		go r.runScheduled(r.uid, run)
+	}
+}
+
+func (r *ChatArchiveRegistry) runScheduled(uid gregor1.UID, run scheduledArchiveRun) {
+	ctx := globals.ChatCtx(context.Background(), r.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil,
+		NewSimpleIdentifyNotifier(r.G()))
+	r.Debug(ctx, "runScheduled: running %s on %s's schedule", run.req.JobID, run.scheduleID)
+	_, err := NewChatArchiver(r.G(), uid, r.remoteClient).ArchiveChat(ctx, run.req)
+	if err == nil || len(run.prevJobID) == 0 {
+		return
+	}
+	r.Debug(ctx, "runScheduled: %s failed: %s", run.req.JobID, err)
+
+	// If it failed before it started, the next run appends to the same job
+	// this one was going to.
+	r.Lock()
+	defer r.Unlock()
+	if _, ok := r.jobHistory.JobHistory[run.req.JobID]; ok {
+		return
+	}
+	schedule, ok := r.jobHistory.Schedules[run.scheduleID]
+	if ok && schedule.LastJobID == run.req.JobID {
+		schedule.LastJobID = run.prevJobID
+		r.jobHistory.Schedules[run.scheduleID] = schedule
+		r.dirty = true
+	}
+}
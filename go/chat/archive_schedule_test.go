@@ -0,0 +1,59 @@
+package chat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveSchedule(t *testing.T) {
+	daily := chat1.ArchiveChatSchedule{
+		Frequency:   chat1.ArchiveChatScheduleFrequency_DAILY,
+		MinuteOfDay: 2*60 + 30,
+	}
+	require.NoError(t, checkArchiveSchedule(daily))
+	// A Wednesday.
+	now := time.Date(2026, 10, 14, 1, 0, 0, 0, time.Local)
+	require.Equal(t, time.Date(2026, 10, 14, 2, 30, 0, 0, time.Local), nextArchiveScheduleRun(daily, now))
+	now = time.Date(2026, 10, 14, 2, 30, 0, 0, time.Local)
+	require.Equal(t, time.Date(2026, 10, 15, 2, 30, 0, 0, time.Local), nextArchiveScheduleRun(daily, now))
+
+	weekly := daily
+	weekly.Frequency = chat1.ArchiveChatScheduleFrequency_WEEKLY
+	weekly.Weekday = int(time.Monday)
+	require.NoError(t, checkArchiveSchedule(weekly))
+	require.Equal(t, time.Date(2026, 10, 19, 2, 30, 0, 0, time.Local), nextArchiveScheduleRun(weekly, now))
+	weekly.Weekday = int(time.Wednesday)
+	require.Equal(t, time.Date(2026, 10, 21, 2, 30, 0, 0, time.Local), nextArchiveScheduleRun(weekly, now))
+	now = time.Date(2026, 10, 14, 0, 0, 0, 0, time.Local)
+	require.Equal(t, time.Date(2026, 10, 14, 2, 30, 0, 0, time.Local), nextArchiveScheduleRun(weekly, now))
+
+	for _, bad := range []chat1.ArchiveChatSchedule{
+		{MinuteOfDay: -1},
+		{MinuteOfDay: 24 * 60},
+		{Frequency: chat1.ArchiveChatScheduleFrequency_WEEKLY, Weekday: 7},
+		{Frequency: chat1.ArchiveChatScheduleFrequency(5)},
+	} {
+		require.Error(t, checkArchiveSchedule(bad))
+	}
+
+	last := chat1.ArchiveChatJob{
+		Request: chat1.ArchiveChatJobRequest{JobID: "arc-1", OutputPath: "/tmp/kbchat-arc-1"},
+		Status:  chat1.ArchiveChatJobStatus_COMPLETE,
+	}
+	req := scheduledArchiveRequest(last, "arc-2")
+	require.NotNil(t, req)
+	require.Equal(t, chat1.ArchiveJobID("arc-2"), req.JobID)
+	require.Equal(t, chat1.ArchiveJobID("arc-1"), req.AppendTo)
+	// The failed run is tried again.
+	last.Status = chat1.ArchiveChatJobStatus_ERROR
+	req = scheduledArchiveRequest(last, "arc-2")
+	require.NotNil(t, req)
+	require.Equal(t, last.Request, *req)
+	last.Status = chat1.ArchiveChatJobStatus_RUNNING
+	require.Nil(t, scheduledArchiveRequest(last, "arc-2"))
+	last.Status = chat1.ArchiveChatJobStatus_PAUSED
+	require.Nil(t, scheduledArchiveRequest(last, "arc-2"))
+}
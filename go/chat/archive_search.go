@@ -0,0 +1,291 @@
+package chat
+
+import (
+	"archive/tar"
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/keybase/client/go/protocol/chat1"
+)
+
+// Archive search looks through the chat.txt files completed archive jobs
+// wrote, so old history can still be found once it's been pruned from the
+// local chat cache. The registry knows where each job's output is; its
+// messages are indexed the first time it's searched and kept in memory
+// until the output changes or the job is deleted.
+
+const archiveChatFilename = "chat.txt"
+
+// How many hits a search returns if it isn't asked for a number.
+const defaultArchiveSearchMaxHits = 100
+
+// The rows of a chat.txt start with the message ID, then an optional unread
+// mark, then the author and time, each in brackets. Anything after them is
+// the message; lines that don't start that way continue the message above.
+var archiveChatRowRegexp = regexp.MustCompile(`^\s*\[(\d+)\]\s*\*?\s*\[([^\]]*)\](.*)$`)
+
+type archiveSearchMessage struct {
+	convName      string
+	msgID         chat1.MessageID
+	authorAndTime string
+	body          string
+	// The author, time and body in lower case, for matching.
+	text string
+}
+
+func (m archiveSearchMessage) matches(terms []string) bool {
+	for _, term := range terms {
+		if !strings.Contains(m.text, term) {
+			return false
+		}
+	}
+	return true
+}
+
+type archiveSearchIndex struct {
+	// When the output was last written to.
+	modTime time.Time
+	msgs    []archiveSearchMessage
+}
+
+func archiveSearchTerms(query string) []string {
+	return strings.Fields(strings.ToLower(query))
+}
+
+// archiveJobOutputPath returns where a completed job's output is.
+func archiveJobOutputPath(job chat1.ArchiveChatJob) string {
+	return job.Request.OutputPath + archiveOutputExt(job.Request)
+}
+
+func parseArchiveChatText(convName string, r io.Reader) (res []archiveSearchMessage, err error) {
+	finish := func(msg *archiveSearchMessage) {
+		if msg == nil {
+			return
+		}
+		msg.text = strings.ToLower(msg.authorAndTime + "\n" + msg.body)
+		res = append(res, *msg)
+	}
+	var cur *archiveSearchMessage
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if match := archiveChatRowRegexp.FindStringSubmatch(line); match != nil {
+			msgID, err := strconv.ParseUint(match[1], 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			finish(cur)
+			cur = &archiveSearchMessage{
+				convName:      convName,
+				msgID:         chat1.MessageID(msgID),
+				authorAndTime: strings.TrimSpace(match[2]),
+				body:          strings.TrimSpace(match[3]),
+			}
+			continue
+		}
+		// Skip the headline and revoked device note, which come before and
+		// after the messages of a page.
+		line = strings.TrimSpace(line)
+		if cur == nil || len(line) == 0 || strings.HasPrefix(line, "Note: Messages with (!)") {
+			continue
+		}
+		cur.body += "\n" + line
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	finish(cur)
+	return res, nil
+}
+
+// archiveOutputModTime returns when the output of a job was last written.
+func archiveOutputModTime(outputPath string) (modTime time.Time, err error) {
+	fi, err := os.Stat(outputPath)
+	if err != nil {
+		return modTime, err
+	}
+	if !fi.IsDir() {
+		return fi.ModTime(), nil
+	}
+	paths, err := filepath.Glob(filepath.Join(outputPath, "*", archiveChatFilename))
+	if err != nil {
+		return modTime, err
+	}
+	for _, p := range paths {
+		fi, err := os.Stat(p)
+		if err != nil {
+			return modTime, err
+		}
+		if fi.ModTime().After(modTime) {
+			modTime = fi.ModTime()
+		}
+	}
+	return modTime, nil
+}
+
+func loadArchiveSearchIndex(outputPath string, modTime time.Time) (res *archiveSearchIndex, err error) {
+	res = &archiveSearchIndex{modTime: modTime}
+	fi, err := os.Stat(outputPath)
+	if err != nil {
+		return nil, err
+	}
+	if fi.IsDir() {
+		paths, err := filepath.Glob(filepath.Join(outputPath, "*", archiveChatFilename))
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range paths {
+			msgs, err := loadArchiveChatFile(filepath.Base(filepath.Dir(p)), p)
+			if err != nil {
+				return nil, err
+			}
+			res.msgs = append(res.msgs, msgs...)
+		}
+		return res, nil
+	}
+
+	f, err := os.Open(outputPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	zr, err := newArchiveDecompressor(f)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	tr := tar.NewReader(zr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		name := filepath.ToSlash(header.Name)
+		if header.Typeflag != tar.TypeReg || path.Base(name) != archiveChatFilename ||
+			path.Dir(name) == "." {
+			continue
+		}
+		msgs, err := parseArchiveChatText(path.Base(path.Dir(name)), tr)
+		if err != nil {
+			return nil, err
+		}
+		res.msgs = append(res.msgs, msgs...)
+	}
+	return res, nil
+}
+
+func loadArchiveChatFile(convName, p string) ([]archiveSearchMessage, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parseArchiveChatText(convName, f)
+}
+
+// searchIndexFor returns the index of a completed job's output, reading it
+// again if it's changed since it was indexed.
+func (r *ChatArchiveRegistry) searchIndexFor(ctx context.Context, job chat1.ArchiveChatJob) (*archiveSearchIndex, error) {
+	if job.Request.Encryption != chat1.ArchiveChatEncryption_NONE {
+		return nil, errArchiveEncrypted
+	}
+	outputPath := archiveJobOutputPath(job)
+	modTime, err := archiveOutputModTime(outputPath)
+	if err != nil {
+		return nil, err
+	}
+	index, ok := r.searchIndexes[job.Request.JobID]
+	if ok && index.modTime.Equal(modTime) {
+		return index, nil
+	}
+	r.Debug(ctx, "searchIndexFor: indexing %s", outputPath)
+	index, err = loadArchiveSearchIndex(outputPath, modTime)
+	if err != nil {
+		return nil, err
+	}
+	r.searchIndexes[job.Request.JobID] = index
+	return index, nil
+}
+
+func (r *ChatArchiveRegistry) Search(ctx context.Context, query string, maxHits int) (res chat1.ArchiveChatSearchRes, err error) {
+	defer r.Trace(ctx, &err, "Search")()
+	defer r.G().ExternalG().TimeTelemetry("chat.archive.search")()
+	terms := archiveSearchTerms(query)
+	if len(terms) == 0 {
+		return res, errors.New("search query required")
+	}
+	if maxHits <= 0 {
+		maxHits = defaultArchiveSearchMaxHits
+	}
+	list, err := r.List(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	// Indexing reads the outputs from disk, so don't hold up the registry
+	// while doing it.
+	r.searchMu.Lock()
+	defer r.searchMu.Unlock()
+	completed := make(map[chat1.ArchiveJobID]bool)
+	// Jobs whose output a later job appended to, and so has all of.
+	appendedTo := make(map[chat1.ArchiveJobID]bool)
+	// Newest jobs first.
+	for i := len(list.Jobs) - 1; i >= 0; i-- {
+		job := list.Jobs[i]
+		if job.Status != chat1.ArchiveChatJobStatus_COMPLETE {
+			continue
+		}
+		if len(job.Request.AppendTo) > 0 {
+			appendedTo[job.Request.AppendTo] = true
+		}
+		if appendedTo[job.Request.JobID] {
+			continue
+		}
+		completed[job.Request.JobID] = true
+		if res.Truncated {
+			continue
+		}
+		index, err := r.searchIndexFor(ctx, job)
+		if err != nil {
+			r.Debug(ctx, "Search: unable to index %s: %v", job.Request.JobID, err)
+			delete(r.searchIndexes, job.Request.JobID)
+			res.UnreadableJobs = append(res.UnreadableJobs, job.Request.JobID)
+			continue
+		}
+		for _, msg := range index.msgs {
+			if !msg.matches(terms) {
+				continue
+			}
+			if len(res.Hits) == maxHits {
+				res.Truncated = true
+				break
+			}
+			res.Hits = append(res.Hits, chat1.ArchiveChatSearchHit{
+				JobID:         job.Request.JobID,
+				ConvName:      msg.convName,
+				MsgID:         msg.msgID,
+				AuthorAndTime: msg.authorAndTime,
+				Body:          msg.body,
+			})
+		}
+	}
+	// Forget about jobs that have been deleted.
+	for jobID := range r.searchIndexes {
+		if !completed[jobID] {
+			delete(r.searchIndexes, jobID)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,69 @@
+package chat
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/stretchr/testify/require"
+)
+
+const testArchiveChatText = `headline: planning
+
+[3]  [alice 2024-01-02 15:04:05]     see you at the Harbor
+[2] *[bob 2024-01-02 15:03:00]       who's bringing
+                                     the snacks?
+[1]  [alice 2024-01-02 15:00:00]     hi
+
+Note: Messages with (!) next to the sender were sent from a device that is now revoked.
+`
+
+func TestArchiveChatSearchParse(t *testing.T) {
+	msgs, err := parseArchiveChatText("alice,bob", strings.NewReader(testArchiveChatText))
+	require.NoError(t, err)
+	require.Len(t, msgs, 3)
+	require.Equal(t, chat1.MessageID(3), msgs[0].msgID)
+	require.Equal(t, "alice 2024-01-02 15:04:05", msgs[0].authorAndTime)
+	require.Equal(t, "see you at the Harbor", msgs[0].body)
+	require.Equal(t, "who's bringing\nthe snacks?", msgs[1].body)
+	require.Equal(t, "hi", msgs[2].body)
+
+	require.True(t, msgs[0].matches(archiveSearchTerms("HARBOR alice")))
+	require.False(t, msgs[0].matches(archiveSearchTerms("harbor bob")))
+	require.True(t, msgs[1].matches(archiveSearchTerms("bringing snacks")))
+}
+
+func TestArchiveChatSearchIndex(t *testing.T) {
+	dir := t.TempDir()
+	outputPath := filepath.Join(dir, "kbchat-job1")
+	convDir := filepath.Join(outputPath, "alice,bob")
+	require.NoError(t, os.MkdirAll(convDir, 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(convDir, archiveChatFilename),
+		[]byte(testArchiveChatText), 0600))
+
+	for _, compress := range []bool{false, true} {
+		job := chat1.ArchiveChatJob{
+			Request: chat1.ArchiveChatJobRequest{
+				JobID:      "job1",
+				OutputPath: outputPath,
+				Compress:   compress,
+			},
+		}
+		if compress {
+			require.NoError(t, tarArchive(outputPath, archiveJobOutputPath(job), chat1.ArchiveChatCompression_GZIP, 0))
+		}
+		modTime, err := archiveOutputModTime(archiveJobOutputPath(job))
+		require.NoError(t, err)
+		require.False(t, modTime.Equal(time.Time{}))
+		index, err := loadArchiveSearchIndex(archiveJobOutputPath(job), modTime)
+		require.NoError(t, err)
+		require.Len(t, index.msgs, 3)
+		require.Equal(t, "alice,bob", index.msgs[0].convName)
+	}
+
+	_, err := archiveOutputModTime(filepath.Join(dir, "missing"))
+	require.Error(t, err)
+}
@@ -0,0 +1,359 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package chat
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/keybase/client/go/protocol/chat1"
+)
+
+// Archives with a database also write everything into a single
+// archive.sqlite at the top of the archive, for searching and querying with
+// other tools. It has a row in conversations for each conversation, one in
+// messages for each line there'd be in messages.jsonl, and their reactions
+// and attachments in tables of their own. messages_fts is an FTS4 index of
+// the message bodies, with the messages' row IDs as its doc IDs:
+//
+//	SELECT m.* FROM messages m JOIN messages_fts f ON f.docid = m.id
+//	WHERE messages_fts MATCH 'launch'
+//
+// Times are UTC in the ISO 8601 format SQLite's date functions take. A page
+// of messages is written in one transaction before the job checkpoints, and
+// rewriting a message replaces it, so resumed jobs don't leave duplicates.
+// SQLite needs cgo, so only builds with cgo can write one, and mobile
+// builds leave it out.
+
+const archiveDatabaseFilename = "archive.sqlite"
+
+const archiveDatabaseTimeFormat = "2006-01-02T15:04:05.000Z"
+
+var archiveDatabaseSchema = []string{
+	`CREATE TABLE IF NOT EXISTS conversations (
+		id TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		channel TEXT,
+		members_type TEXT NOT NULL,
+		topic_type TEXT NOT NULL,
+		-- Where the conversation's files are, relative to the archive.
+		directory TEXT NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS messages (
+		id INTEGER PRIMARY KEY,
+		conv_id TEXT NOT NULL REFERENCES conversations(id),
+		message_id INTEGER NOT NULL,
+		type TEXT NOT NULL,
+		sender TEXT NOT NULL,
+		device TEXT NOT NULL,
+		device_revoked INTEGER NOT NULL,
+		sent TEXT NOT NULL,
+		body TEXT NOT NULL,
+		reply_to INTEGER,
+		edits INTEGER,
+		superseded_by INTEGER,
+		deleted INTEGER NOT NULL,
+		exploded INTEGER NOT NULL,
+		UNIQUE (conv_id, message_id)
+	)`,
+	`CREATE INDEX IF NOT EXISTS messages_sent ON messages (sent)`,
+	`CREATE TABLE IF NOT EXISTS reactions (
+		conv_id TEXT NOT NULL,
+		message_id INTEGER NOT NULL,
+		reaction TEXT NOT NULL,
+		username TEXT NOT NULL,
+		PRIMARY KEY (conv_id, message_id, reaction, username)
+	)`,
+	`CREATE TABLE IF NOT EXISTS attachments (
+		conv_id TEXT NOT NULL,
+		message_id INTEGER NOT NULL,
+		filename TEXT NOT NULL,
+		title TEXT,
+		mime_type TEXT,
+		size INTEGER NOT NULL,
+		plaintext_sha256 TEXT,
+		encrypted_sha256 TEXT,
+		-- Relative to the conversation's directory. Null if the attachment
+		-- wasn't downloaded.
+		path TEXT,
+		PRIMARY KEY (conv_id, message_id)
+	)`,
+	`CREATE VIRTUAL TABLE IF NOT EXISTS messages_fts USING fts4(content="messages", body)`,
+}
+
+type archiveDatabase struct {
+	db *sql.DB
+}
+
+// archiveDatabaseDSN returns the URI to open the database at filename
+// with, escaped so that a ? or # in the path doesn't end it.
+func archiveDatabaseDSN(filename string, params url.Values) (string, error) {
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return "", err
+	}
+	p := filepath.ToSlash(abs)
+	if !strings.HasPrefix(p, "/") {
+		// A Windows path, which SQLite wants as /C:/...
+		p = "/" + p
+	}
+	u := url.URL{Scheme: "file", Path: p, RawQuery: params.Encode()}
+	return u.String(), nil
+}
+
+func sqlOpenArchiveDatabase(filename string, params url.Values) (*sql.DB, error) {
+	if err := checkArchiveDatabaseSupported(); err != nil {
+		return nil, err
+	}
+	params.Set("_busy_timeout", "10000")
+	dsn, err := archiveDatabaseDSN(filename, params)
+	if err != nil {
+		return nil, err
+	}
+	return sql.Open(archiveDatabaseDriver, dsn)
+}
+
+func openArchiveDatabase(ctx context.Context, filename string) (*archiveDatabase, error) {
+	db, err := sqlOpenArchiveDatabase(filename, url.Values{})
+	if err != nil {
+		return nil, err
+	}
+	// Conversations are archived in parallel, but SQLite only has one
+	// writer at a time anyway.
+	db.SetMaxOpenConns(1)
+	for _, stmt := range archiveDatabaseSchema {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+	return &archiveDatabase{db: db}, nil
+}
+
+func (d *archiveDatabase) Close() error {
+	return d.db.Close()
+}
+
+func archiveDatabaseNullID(id chat1.MessageID) interface{} {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
+
+func archiveDatabaseNullString(s string) interface{} {
+	if len(s) == 0 {
+		return nil
+	}
+	return s
+}
+
+func (d *archiveDatabase) writeConversation(ctx context.Context, conv chat1.ConversationLocal,
+	directory string) error {
+	var channel interface{}
+	if conv.GetMembersType() == chat1.ConversationMembersType_TEAM {
+		channel = conv.Info.TopicName
+	}
+	_, err := d.db.ExecContext(ctx, `INSERT OR REPLACE INTO conversations
+		(id, name, channel, members_type, topic_type, directory) VALUES (?, ?, ?, ?, ?, ?)`,
+		conv.GetConvID().String(), conv.Info.TlfName, channel,
+		strings.ToLower(conv.GetMembersType().String()),
+		strings.ToLower(conv.GetTopicType().String()), directory)
+	return err
+}
+
+func (d *archiveDatabase) writeMessage(ctx context.Context, tx *sql.Tx, convID string,
+	msg archiveJSONMessage) error {
+	// The index has to be updated before the row it indexes changes, since
+	// deleting from it reads the old body.
+	var id int64
+	err := tx.QueryRowContext(ctx, `SELECT id FROM messages WHERE conv_id = ? AND message_id = ?`,
+		convID, msg.MessageID).Scan(&id)
+	switch err {
+	case nil:
+		if _, err := tx.ExecContext(ctx, `DELETE FROM messages_fts WHERE docid = ?`, id); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM messages WHERE id = ?`, id); err != nil {
+			return err
+		}
+	case sql.ErrNoRows:
+	default:
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, `INSERT INTO messages
+		(conv_id, message_id, type, sender, device, device_revoked, sent, body,
+		reply_to, edits, superseded_by, deleted, exploded)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		convID, msg.MessageID, msg.Type, msg.Sender, msg.Device, msg.DeviceRevoked,
+		msg.Ctime.UTC().Format(archiveDatabaseTimeFormat), msg.Body,
+		archiveDatabaseNullID(msg.ReplyTo), archiveDatabaseNullID(msg.Edits),
+		archiveDatabaseNullID(msg.SupersededBy), msg.Deleted, msg.Exploded)
+	if err != nil {
+		return err
+	}
+	if id, err = res.LastInsertId(); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO messages_fts (docid, body) VALUES (?, ?)`,
+		id, msg.Body); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM reactions WHERE conv_id = ? AND message_id = ?`,
+		convID, msg.MessageID); err != nil {
+		return err
+	}
+	for reaction, usernames := range msg.Reactions {
+		for _, username := range usernames {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO reactions
+				(conv_id, message_id, reaction, username) VALUES (?, ?, ?, ?)`,
+				convID, msg.MessageID, reaction, username); err != nil {
+				return err
+			}
+		}
+	}
+
+	if att := msg.Attachment; att != nil {
+		if _, err := tx.ExecContext(ctx, `INSERT OR REPLACE INTO attachments
+			(conv_id, message_id, filename, title, mime_type, size, plaintext_sha256,
+			encrypted_sha256, path) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			convID, msg.MessageID, att.Filename, archiveDatabaseNullString(att.Title),
+			archiveDatabaseNullString(att.MimeType), att.Size,
+			archiveDatabaseNullString(att.PlaintextSHA256), archiveDatabaseNullString(att.EncryptedSHA256),
+			archiveDatabaseNullString(att.Path)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeMessages writes a page of a conversation's messages in one
+// transaction.
+func (d *archiveDatabase) writeMessages(ctx context.Context, convID chat1.ConversationID,
+	msgs []archiveJSONMessage) (err error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+	for _, msg := range msgs {
+		if err := d.writeMessage(ctx, tx, convID.String(), msg); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// readArchiveDatabase reads the conversations and messages in the
+// archive.sqlite at filename, without changing it.
+func readArchiveDatabase(ctx context.Context, filename string) (res []archiveImportConv, err error) {
+	db, err := sqlOpenArchiveDatabase(filename, url.Values{"mode": {"ro"}})
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	rows, err := db.QueryContext(ctx, `SELECT id, directory FROM conversations ORDER BY directory`)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]int)
+	for rows.Next() {
+		var id, directory string
+		if err := rows.Scan(&id, &directory); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		conv := archiveImportConv{name: directory}
+		if convID, err := chat1.MakeConvID(id); err == nil {
+			conv.convID = &convID
+		}
+		byID[id] = len(res)
+		res = append(res, conv)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = db.QueryContext(ctx, `SELECT m.conv_id, m.message_id, m.type, m.sender, m.device,
+		m.device_revoked, m.sent, m.body, m.reply_to, m.edits, m.superseded_by, m.deleted,
+		m.exploded, a.filename, a.path
+		FROM messages m LEFT JOIN attachments a
+		ON a.conv_id = m.conv_id AND a.message_id = m.message_id
+		ORDER BY m.conv_id, m.message_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var convID, sent string
+		var msg archiveJSONMessage
+		var replyTo, edits, supersededBy sql.NullInt64
+		var filename, attachmentPath sql.NullString
+		if err := rows.Scan(&convID, &msg.MessageID, &msg.Type, &msg.Sender, &msg.Device,
+			&msg.DeviceRevoked, &sent, &msg.Body, &replyTo, &edits, &supersededBy, &msg.Deleted,
+			&msg.Exploded, &filename, &attachmentPath); err != nil {
+			return nil, err
+		}
+		i, ok := byID[convID]
+		if !ok {
+			continue
+		}
+		if msg.Ctime, err = time.Parse(archiveDatabaseTimeFormat, sent); err != nil {
+			return nil, err
+		}
+		msg.ReplyTo = chat1.MessageID(replyTo.Int64)
+		msg.Edits = chat1.MessageID(edits.Int64)
+		msg.SupersededBy = chat1.MessageID(supersededBy.Int64)
+		if filename.Valid {
+			msg.Attachment = &archiveJSONAttachment{Path: attachmentPath.String}
+			msg.Attachment.Filename = filename.String
+		}
+		res[i].msgs = append(res[i].msgs, msg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = db.QueryContext(ctx, `SELECT conv_id, message_id, reaction, username FROM reactions`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	reactions := make(map[string]map[chat1.MessageID]map[string][]string)
+	for rows.Next() {
+		var convID, reaction, username string
+		var msgID chat1.MessageID
+		if err := rows.Scan(&convID, &msgID, &reaction, &username); err != nil {
+			return nil, err
+		}
+		if reactions[convID] == nil {
+			reactions[convID] = make(map[chat1.MessageID]map[string][]string)
+		}
+		if reactions[convID][msgID] == nil {
+			reactions[convID][msgID] = make(map[string][]string)
+		}
+		reactions[convID][msgID][reaction] = append(reactions[convID][msgID][reaction], username)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	for convID, i := range byID {
+		for j, msg := range res[i].msgs {
+			res[i].msgs[j].Reactions = reactions[convID][msg.MessageID]
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,18 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+//go:build cgo && !ios && !android
+// +build cgo,!ios,!android
+
+package chat
+
+import (
+	// Registers the sqlite3 driver.
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const archiveDatabaseDriver = "sqlite3"
+
+func checkArchiveDatabaseSupported() error {
+	return nil
+}
@@ -0,0 +1,15 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+//go:build !cgo || ios || android
+// +build !cgo ios android
+
+package chat
+
+import "errors"
+
+const archiveDatabaseDriver = ""
+
+func checkArchiveDatabaseSupported() error {
+	return errors.New("SQLite archive databases aren't supported on this platform")
+}
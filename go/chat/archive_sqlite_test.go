@@ -0,0 +1,120 @@
+//go:build cgo && !ios && !android
+// +build cgo,!ios,!android
+
+package chat
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveDatabase(t *testing.T) {
+	ctx := context.Background()
+	db, err := openArchiveDatabase(ctx, filepath.Join(t.TempDir(), archiveDatabaseFilename))
+	require.NoError(t, err)
+	defer db.Close()
+
+	convID := chat1.ConversationID([]byte{1, 2, 3})
+	sent := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	msgs := []archiveJSONMessage{
+		{
+			MessageID: 10,
+			Type:      "text",
+			Sender:    "alice",
+			Device:    "laptop",
+			Ctime:     sent,
+			Body:      "the launch is on friday",
+			Reactions: map[string][]string{":+1:": {"bob", "carol"}},
+		},
+		{
+			MessageID: 11,
+			Type:      "attachment",
+			Sender:    "bob",
+			Device:    "phone",
+			Ctime:     sent.Add(time.Minute),
+			Body:      "launch plan",
+			Attachment: &archiveJSONAttachment{
+				archiveAttachmentInfo: archiveAttachmentInfo{
+					MessageID: 11,
+					Filename:  "plan.pdf",
+					Size:      1234,
+				},
+				Path: "plan.pdf",
+			},
+		},
+		{
+			MessageID: 12,
+			Type:      "text",
+			Sender:    "carol",
+			Device:    "desktop",
+			Ctime:     sent.Add(2 * time.Minute),
+			Deleted:   true,
+		},
+	}
+	require.NoError(t, db.writeMessages(ctx, convID, msgs))
+
+	search := func(query string) (ids []chat1.MessageID) {
+		rows, err := db.db.QueryContext(ctx, `SELECT m.message_id FROM messages m
+			JOIN messages_fts f ON f.docid = m.id WHERE messages_fts MATCH ? ORDER BY m.message_id`, query)
+		require.NoError(t, err)
+		defer rows.Close()
+		for rows.Next() {
+			var id chat1.MessageID
+			require.NoError(t, rows.Scan(&id))
+			ids = append(ids, id)
+		}
+		require.NoError(t, rows.Err())
+		return ids
+	}
+	require.Equal(t, []chat1.MessageID{10, 11}, search("launch"))
+	require.Equal(t, []chat1.MessageID{10}, search("friday"))
+
+	var sentStr string
+	var reactions int
+	require.NoError(t, db.db.QueryRowContext(ctx,
+		`SELECT sent FROM messages WHERE message_id = 10`).Scan(&sentStr))
+	require.Equal(t, "2026-03-04T05:06:07.000Z", sentStr)
+	require.NoError(t, db.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM reactions WHERE message_id = 10`).Scan(&reactions))
+	require.Equal(t, 2, reactions)
+	var filename, path string
+	require.NoError(t, db.db.QueryRowContext(ctx,
+		`SELECT filename, path FROM attachments WHERE message_id = 11`).Scan(&filename, &path))
+	require.Equal(t, "plan.pdf", filename)
+	require.Equal(t, "plan.pdf", path)
+
+	// Writing a page again, as a resumed job does, replaces what was there.
+	msgs[0].Body = "the launch moved to monday"
+	msgs[0].Reactions = map[string][]string{":+1:": {"bob"}}
+	require.NoError(t, db.writeMessages(ctx, convID, msgs))
+	require.Equal(t, []chat1.MessageID{10, 11}, search("launch"))
+	require.Empty(t, search("friday"))
+	require.Equal(t, []chat1.MessageID{10}, search("monday"))
+	var count int
+	require.NoError(t, db.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM messages`).Scan(&count))
+	require.Equal(t, 3, count)
+	require.NoError(t, db.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM reactions WHERE message_id = 10`).Scan(&reactions))
+	require.Equal(t, 1, reactions)
+}
+
+func TestArchiveDatabaseDSN(t *testing.T) {
+	ctx := context.Background()
+	// A ? or # in the path is part of the filename.
+	dir := filepath.Join(t.TempDir(), "a?b#c")
+	filename := filepath.Join(dir, archiveDatabaseFilename)
+	require.NoError(t, os.MkdirAll(dir, 0700))
+	db, err := openArchiveDatabase(ctx, filename)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+	_, err = os.Stat(filename)
+	require.NoError(t, err)
+	_, err = readArchiveDatabase(ctx, filename)
+	require.NoError(t, err)
+}
@@ -0,0 +1,358 @@
+package chat
+
+import (
+	"archive/tar"
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/keybase/client/go/chat/types"
+	"github.com/keybase/client/go/protocol/chat1"
+)
+
+// Verifying a job re-reads its output, a directory or a compressed tarball,
+// to find what an interrupted run or a bad disk left behind. Each
+// conversation's files have to be at least as long as its checkpoint says
+// was written, and no longer once the job is complete, since a resumed run
+// writes over what was there from the checkpoint on without truncating it.
+// Attachments are checked against the SHA-256 sums they were sent with,
+// wherever the output lists them: messages.jsonl, attachments.json, and the
+// names of the files in the attachment store.
+
+type archiveOutputFile struct {
+	size   int64
+	sha256 string
+}
+
+// archiveOutputScan is what's been read of a job's output.
+type archiveOutputScan struct {
+	// Slash paths relative to the output -> what's there.
+	files map[string]archiveOutputFile
+	// Slash paths of attachments -> the hex SHA-256 sums they were sent
+	// with, or "" if that isn't known.
+	attachments map[string]string
+	issues      []chat1.ArchiveChatVerifyIssue
+}
+
+func newArchiveOutputScan() *archiveOutputScan {
+	return &archiveOutputScan{
+		files:       make(map[string]archiveOutputFile),
+		attachments: make(map[string]string),
+	}
+}
+
+func (s *archiveOutputScan) issue(p string, problem chat1.ArchiveChatVerifyProblem, format string,
+	args ...interface{}) {
+	s.issues = append(s.issues, chat1.ArchiveChatVerifyIssue{
+		Path:    p,
+		Problem: problem,
+		Detail:  fmt.Sprintf(format, args...),
+	})
+}
+
+// expectAttachment notes that there's an attachment at p, relative to the
+// output, which was sent with sum.
+func (s *archiveOutputScan) expectAttachment(p, sum string) {
+	p = path.Clean(p)
+	if strings.HasPrefix(p, "../") {
+		return
+	}
+	if len(s.attachments[p]) == 0 {
+		s.attachments[p] = sum
+	}
+}
+
+// archiveStoreNameSum returns the sum an attachment in the store is named
+// by, as by archiveAttachmentStoreName.
+func archiveStoreNameSum(name string) (string, bool) {
+	sum := name
+	if i := strings.IndexByte(name, '.'); i >= 0 {
+		sum = name[:i]
+	}
+	if len(sum) != 2*sha256.Size {
+		return "", false
+	}
+	if _, err := hex.DecodeString(sum); err != nil {
+		return "", false
+	}
+	return strings.ToLower(sum), true
+}
+
+type archiveCountingHash struct {
+	hash.Hash
+	n int64
+}
+
+func (h *archiveCountingHash) Write(p []byte) (int, error) {
+	h.n += int64(len(p))
+	return h.Hash.Write(p)
+}
+
+// addFile reads the file at name, a slash path relative to the output. The
+// file is kept with what was read of it even if reading fails.
+func (s *archiveOutputScan) addFile(name string, r io.Reader) error {
+	h := &archiveCountingHash{Hash: sha256.New()}
+	tee := io.TeeReader(r, h)
+	dir := path.Dir(name)
+	switch path.Base(name) {
+	case archiveMessagesFilename:
+		s.readMessages(name, tee)
+	case archiveAttachmentsFilename:
+		s.readAttachments(name, tee)
+	}
+	_, err := io.Copy(io.Discard, tee)
+	s.files[name] = archiveOutputFile{size: h.n, sha256: hex.EncodeToString(h.Sum(nil))}
+	if dir == archiveAttachmentStoreDirname {
+		if sum, ok := archiveStoreNameSum(path.Base(name)); ok {
+			s.expectAttachment(name, sum)
+		}
+	}
+	return err
+}
+
+func (s *archiveOutputScan) readMessages(name string, r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		var msg archiveJSONMessage
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			s.issue(name, chat1.ArchiveChatVerifyProblem_UNREADABLE, "line %d: %v", line, err)
+			continue
+		}
+		if msg.Attachment != nil && len(msg.Attachment.Path) > 0 {
+			s.expectAttachment(path.Join(path.Dir(name), msg.Attachment.Path),
+				msg.Attachment.PlaintextSHA256)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		s.issue(name, chat1.ArchiveChatVerifyProblem_UNREADABLE, "%v", err)
+	}
+}
+
+func (s *archiveOutputScan) readAttachments(name string, r io.Reader) {
+	var infos []archiveAttachmentInfo
+	if err := json.NewDecoder(r).Decode(&infos); err != nil {
+		s.issue(name, chat1.ArchiveChatVerifyProblem_UNREADABLE, "%v", err)
+		return
+	}
+	for _, info := range infos {
+		if len(info.Stored) > 0 {
+			s.expectAttachment(path.Join(path.Dir(name), info.Stored), info.PlaintextSHA256)
+		}
+	}
+}
+
+// scanArchiveOutput reads everything in the output at outputPath, a
+// directory or a tarball. What can't be read is an issue, rather than an
+// error, so as much is checked as can be.
+func scanArchiveOutput(outputPath string) (*archiveOutputScan, error) {
+	s := newArchiveOutputScan()
+	fi, err := os.Stat(outputPath)
+	if err != nil {
+		return nil, err
+	}
+	if fi.IsDir() {
+		err = filepath.Walk(outputPath, func(fp string, fi os.FileInfo, err error) error {
+			name, rerr := filepath.Rel(outputPath, fp)
+			if rerr != nil {
+				return rerr
+			}
+			name = filepath.ToSlash(name)
+			if err != nil {
+				s.issue(name, chat1.ArchiveChatVerifyProblem_UNREADABLE, "%v", err)
+				if fi != nil && fi.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !fi.Mode().IsRegular() {
+				return nil
+			}
+			f, err := os.Open(fp)
+			if err != nil {
+				s.issue(name, chat1.ArchiveChatVerifyProblem_UNREADABLE, "%v", err)
+				return nil
+			}
+			defer f.Close()
+			if err := s.addFile(name, f); err != nil {
+				s.issue(name, chat1.ArchiveChatVerifyProblem_UNREADABLE, "%v", err)
+			}
+			return nil
+		})
+		return s, err
+	}
+
+	f, err := os.Open(outputPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	zr, err := newArchiveDecompressor(f)
+	if err != nil {
+		s.issue(filepath.Base(outputPath), chat1.ArchiveChatVerifyProblem_UNREADABLE, "%v", err)
+		return s, nil
+	}
+	defer zr.Close()
+	tr := tar.NewReader(zr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			s.issue(filepath.Base(outputPath), chat1.ArchiveChatVerifyProblem_UNREADABLE, "%v", err)
+			break
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := filepath.ToSlash(header.Name)
+		if err := s.addFile(name, tr); err != nil {
+			// The rest of a truncated tarball can't be read either.
+			s.issue(name, chat1.ArchiveChatVerifyProblem_UNREADABLE, "%v", err)
+			break
+		}
+	}
+	return s, nil
+}
+
+// checkOffset checks the file at name against its checkpoint's offset.
+func (s *archiveOutputScan) checkOffset(name string, offset int64, complete bool) {
+	f, ok := s.files[name]
+	switch {
+	case !ok:
+		s.issue(name, chat1.ArchiveChatVerifyProblem_MISSING, "%d bytes were written", offset)
+	case f.size < offset:
+		s.issue(name, chat1.ArchiveChatVerifyProblem_TRUNCATED, "%d bytes, but %d were written",
+			f.size, offset)
+	case complete && f.size > offset:
+		s.issue(name, chat1.ArchiveChatVerifyProblem_TRAILING_DATA,
+			"%d bytes past what was written, left by an interrupted run", f.size-offset)
+	}
+}
+
+// checkCheckpoints checks the files of each conversation in job's
+// checkpoints, with convDirs giving their directories, and returns how many
+// it checked.
+func (s *archiveOutputScan) checkCheckpoints(job chat1.ArchiveChatJob, convDirs map[string]string) (checked int) {
+	complete := job.Status == chat1.ArchiveChatJobStatus_COMPLETE
+	format := job.Request.Format
+	convIDs := make([]string, 0, len(job.Checkpoints))
+	for convID := range job.Checkpoints {
+		convIDs = append(convIDs, convID)
+	}
+	sort.Strings(convIDs)
+	for _, convID := range convIDs {
+		cp := job.Checkpoints[convID]
+		dir, ok := convDirs[convID]
+		if !ok {
+			s.issue(convID, chat1.ArchiveChatVerifyProblem_UNKNOWN_CONVERSATION,
+				"its files can't be found")
+			continue
+		}
+		checked++
+		if complete && !cp.Pagination.Last {
+			s.issue(dir, chat1.ArchiveChatVerifyProblem_INCOMPLETE, "not all of its messages were archived")
+		}
+		if format == chat1.ArchiveChatFormat_TEXT || format == chat1.ArchiveChatFormat_BOTH {
+			s.checkOffset(path.Join(dir, archiveChatFilename), cp.Offset, complete)
+		}
+		if format == chat1.ArchiveChatFormat_JSON || format == chat1.ArchiveChatFormat_BOTH {
+			s.checkOffset(path.Join(dir, archiveMessagesFilename), cp.JsonOffset, complete)
+		}
+		if job.Request.IncludeHistory {
+			s.checkOffset(path.Join(dir, archiveHistoryFilename), cp.HistoryOffset, complete)
+		}
+	}
+	return checked
+}
+
+// checkAttachments checks each attachment the output lists, and returns
+// how many it checked.
+func (s *archiveOutputScan) checkAttachments() (checked int) {
+	paths := make([]string, 0, len(s.attachments))
+	for p := range s.attachments {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		checked++
+		sum := s.attachments[p]
+		f, ok := s.files[p]
+		switch {
+		case !ok:
+			s.issue(p, chat1.ArchiveChatVerifyProblem_MISSING, "attachment wasn't downloaded")
+		case len(sum) > 0 && f.sha256 != sum:
+			s.issue(p, chat1.ArchiveChatVerifyProblem_CHECKSUM_MISMATCH,
+				"SHA-256 %s, not the %s it was sent with", f.sha256, sum)
+		}
+	}
+	return checked
+}
+
+// archiveJobVerifyPath returns where job's output is now. A compressed or
+// encrypted job's output is only packed up once it's complete.
+func archiveJobVerifyPath(job chat1.ArchiveChatJob) string {
+	if len(archiveOutputExt(job.Request)) > 0 {
+		if _, err := os.Stat(archiveJobOutputPath(job)); err == nil {
+			return archiveJobOutputPath(job)
+		}
+	}
+	return job.Request.OutputPath
+}
+
+func (c *ChatArchiver) VerifyArchive(ctx context.Context, jobID chat1.ArchiveJobID) (
+	res chat1.ArchiveChatVerifyRes, err error) {
+	defer c.Trace(ctx, &err, "VerifyArchive")()
+	job, err := c.G().ArchiveRegistry.Get(ctx, jobID)
+	if err != nil {
+		return res, err
+	}
+	res.JobID = jobID
+	res.OutputPath = archiveJobVerifyPath(job)
+	if res.OutputPath != job.Request.OutputPath &&
+		job.Request.Encryption != chat1.ArchiveChatEncryption_NONE {
+		return res, errArchiveEncrypted
+	}
+
+	scan, err := scanArchiveOutput(res.OutputPath)
+	if os.IsNotExist(err) {
+		res.Issues = append(res.Issues, chat1.ArchiveChatVerifyIssue{
+			Path:    res.OutputPath,
+			Problem: chat1.ArchiveChatVerifyProblem_MISSING,
+			Detail:  "the job's output has been moved or deleted",
+		})
+		return res, nil
+	} else if err != nil {
+		return res, err
+	}
+
+	// Checkpoints are by conversation ID, so look up where each one's files
+	// are.
+	iboxRes, _, err := c.G().InboxSource.Read(ctx, c.uid, types.ConversationLocalizerBlocking,
+		types.InboxSourceDataSourceAll, nil, job.Request.Query)
+	if err != nil {
+		return res, err
+	}
+	convDirs := make(map[string]string, len(iboxRes.Convs))
+	for _, conv := range iboxRes.Convs {
+		convDirs[conv.Info.Id.DbShortFormString()] = c.archiveName(conv)
+	}
+	res.ConvsChecked = scan.checkCheckpoints(job, convDirs)
+	res.AttachmentsChecked = scan.checkAttachments()
+	res.FilesChecked = len(scan.files)
+	res.Issues = append(res.Issues, scan.issues...)
+	return res, nil
+}
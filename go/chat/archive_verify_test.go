@@ -0,0 +1,136 @@
+package chat
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveVerify(t *testing.T) {
+	outputPath := t.TempDir()
+	write := func(name, contents string) {
+		p := filepath.Join(outputPath, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0700))
+		require.NoError(t, os.WriteFile(p, []byte(contents), 0600))
+	}
+	sum := func(contents string) string {
+		h := sha256.Sum256([]byte(contents))
+		return hex.EncodeToString(h[:])
+	}
+	messages := func(msgs ...archiveJSONMessage) string {
+		var res string
+		for _, msg := range msgs {
+			buf, err := json.Marshal(msg)
+			require.NoError(t, err)
+			res += string(buf) + "\n"
+		}
+		return res
+	}
+
+	// alice,bob has all it should, with one of its attachments stored.
+	storeName := sum("photo") + ".jpg"
+	write("attachments/"+storeName, "photo")
+	write("alice,bob/chat.txt", "0123456789")
+	write("alice,bob/report.pdf", "report")
+	aliceMessages := messages(
+		archiveJSONMessage{MessageID: 1, Type: "text", Body: "hi"},
+		archiveJSONMessage{MessageID: 2, Type: "attachment", Attachment: &archiveJSONAttachment{
+			archiveAttachmentInfo: archiveAttachmentInfo{PlaintextSHA256: sum("report")},
+			Path:                  "report.pdf",
+		}},
+		archiveJSONMessage{MessageID: 3, Type: "attachment", Attachment: &archiveJSONAttachment{
+			archiveAttachmentInfo: archiveAttachmentInfo{PlaintextSHA256: sum("photo")},
+			Path:                  archiveStoredAttachmentPath(storeName),
+		}},
+	)
+	write("alice,bob/messages.jsonl", aliceMessages)
+
+	// carol,dave's chat.txt was cut short, messages.jsonl has a stale tail,
+	// and one attachment is corrupt and another's missing.
+	write("carol,dave/chat.txt", "01234")
+	write("carol,dave/scan.png", "not the scan")
+	carolMessages := messages(archiveJSONMessage{MessageID: 1, Type: "attachment",
+		Attachment: &archiveJSONAttachment{
+			archiveAttachmentInfo: archiveAttachmentInfo{PlaintextSHA256: sum("scan")},
+			Path:                  "scan.png",
+		}})
+	write("carol,dave/messages.jsonl", carolMessages+"{\"messageID\": 2, \"ty")
+	write("carol,dave/attachments.json", `[{"messageID": 3, "stored": "../attachments/`+sum("gone")+`"}]`)
+
+	job := chat1.ArchiveChatJob{
+		Request: chat1.ArchiveChatJobRequest{
+			OutputPath: outputPath,
+			Format:     chat1.ArchiveChatFormat_BOTH,
+		},
+		Status: chat1.ArchiveChatJobStatus_COMPLETE,
+		Checkpoints: map[string]chat1.ArchiveChatConvCheckpoint{
+			"aa": {
+				Pagination: chat1.Pagination{Last: true},
+				Offset:     10,
+				JsonOffset: int64(len(aliceMessages)),
+			},
+			"cc": {
+				Pagination: chat1.Pagination{Last: true},
+				Offset:     10,
+				JsonOffset: int64(len(carolMessages)),
+			},
+			"ee": {Pagination: chat1.Pagination{Last: true}},
+		},
+	}
+	convDirs := map[string]string{"aa": "alice,bob", "cc": "carol,dave"}
+
+	type issue struct {
+		path    string
+		problem chat1.ArchiveChatVerifyProblem
+	}
+	check := func(scan *archiveOutputScan) {
+		require.Equal(t, 2, scan.checkCheckpoints(job, convDirs))
+		require.Equal(t, 4, scan.checkAttachments())
+		var issues []issue
+		for _, i := range scan.issues {
+			issues = append(issues, issue{i.Path, i.Problem})
+		}
+		require.ElementsMatch(t, []issue{
+			{"carol,dave/messages.jsonl", chat1.ArchiveChatVerifyProblem_UNREADABLE},
+			{"ee", chat1.ArchiveChatVerifyProblem_UNKNOWN_CONVERSATION},
+			{"carol,dave/chat.txt", chat1.ArchiveChatVerifyProblem_TRUNCATED},
+			{"carol,dave/messages.jsonl", chat1.ArchiveChatVerifyProblem_TRAILING_DATA},
+			{"attachments/" + sum("gone"), chat1.ArchiveChatVerifyProblem_MISSING},
+			{"carol,dave/scan.png", chat1.ArchiveChatVerifyProblem_CHECKSUM_MISMATCH},
+		}, issues)
+	}
+
+	scan, err := scanArchiveOutput(outputPath)
+	require.NoError(t, err)
+	require.Len(t, scan.files, 8)
+	check(scan)
+
+	// The same once it's packed up, however it's compressed.
+	job.Request.Compress = true
+	for _, compression := range []chat1.ArchiveChatCompression{
+		chat1.ArchiveChatCompression_GZIP,
+		chat1.ArchiveChatCompression_ZSTD,
+		chat1.ArchiveChatCompression_NONE,
+	} {
+		job.Request.Compression = compression
+		tarPath := archiveJobOutputPath(job)
+		require.NoError(t, tarArchive(outputPath, tarPath, compression, 0))
+		require.Equal(t, tarPath, archiveJobVerifyPath(job))
+		scan, err = scanArchiveOutput(tarPath)
+		require.NoError(t, err)
+		check(scan)
+	}
+
+	// Leftover downloads in the store aren't attachments.
+	_, ok := archiveStoreNameSum(".download-1234")
+	require.False(t, ok)
+	s, ok := archiveStoreNameSum(storeName)
+	require.True(t, ok)
+	require.Equal(t, sum("photo"), s)
+}
@@ -0,0 +1,332 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/keybase/client/go/chat/attachments"
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/chat/types"
+	"github.com/keybase/client/go/chat/utils"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/keybase/client/go/teams"
+)
+
+// Attachment expiry deletes the uploaded files of a team's attachments once
+// they're older than the team's policy allows, leaving the messages (and
+// their titles) in place. The policy, and how far each channel has been
+// expired through, are kept in the team's admin-only dev storage, so any
+// admin's device can pick up where another left off. Channels whose
+// retention policy deletes messages at least as soon are left alone, since
+// retention deletes their assets along with them.
+
+const attachmentExpiryPolicyName = "__attachment_expiry_policy"
+const attachmentExpiryProgressName = "__attachment_expiry_progress"
+const attachmentExpiryPageSize = 100
+const attachmentExpiryMaxDays = 10 * 365
+
+func checkAttachmentExpiryPolicy(policy chat1.AttachmentExpiryPolicy) error {
+	if policy.Days < 0 || policy.Days > attachmentExpiryMaxDays {
+		return fmt.Errorf("attachment expiry must be between 1 and %d days, or 0 to turn it off; got %d",
+			attachmentExpiryMaxDays, policy.Days)
+	}
+	return nil
+}
+
+func getAttachmentExpiryPolicy(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, teamID keybase1.TeamID) (policy chat1.AttachmentExpiryPolicy, err error) {
+	conv, err := getWelcomeMessageConv(ctx, g, uid, teamID)
+	if err != nil {
+		return policy, err
+	}
+	s := NewConvDevConversationBackedStorage(g, chat1.TopicType_DEV, true /* adminOnly */, ri)
+	found, _, err := s.Get(ctx, uid, conv.GetConvID(), attachmentExpiryPolicyName, &policy, false)
+	switch err.(type) {
+	case nil:
+	case *DevStorageAdminOnlyError:
+		// Not written by an admin, so ignore it.
+		return chat1.AttachmentExpiryPolicy{}, nil
+	default:
+		return policy, err
+	}
+	if !found {
+		return chat1.AttachmentExpiryPolicy{}, nil
+	}
+	if err := checkAttachmentExpiryPolicy(policy); err != nil {
+		return policy, err
+	}
+	return policy, nil
+}
+
+func setAttachmentExpiryPolicy(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, teamID keybase1.TeamID, policy chat1.AttachmentExpiryPolicy) (err error) {
+	if err := checkAttachmentExpiryPolicy(policy); err != nil {
+		return err
+	}
+	conv, err := getWelcomeMessageConv(ctx, g, uid, teamID)
+	if err != nil {
+		return err
+	}
+	s := NewConvDevConversationBackedStorage(g, chat1.TopicType_DEV, true /* adminOnly */, ri)
+	return s.Put(ctx, uid, conv.GetConvID(), attachmentExpiryPolicyName, policy)
+}
+
+func getAttachmentExpiryProgress(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, convID chat1.ConversationID) (progress chat1.AttachmentExpiryProgress, err error) {
+	s := NewConvDevConversationBackedStorage(g, chat1.TopicType_DEV, true /* adminOnly */, ri)
+	found, _, err := s.Get(ctx, uid, convID, attachmentExpiryProgressName, &progress, false)
+	switch err.(type) {
+	case nil:
+	case *DevStorageAdminOnlyError:
+		// Not written by an admin, so start over.
+		return chat1.AttachmentExpiryProgress{}, nil
+	default:
+		return progress, err
+	}
+	if !found {
+		return chat1.AttachmentExpiryProgress{}, nil
+	}
+	return progress, nil
+}
+
+// retentionAge returns how old messages get before conv's retention policy
+// deletes them, if it does.
+func retentionAge(conv chat1.ConversationLocal) (time.Duration, bool) {
+	policy := conv.ConvRetention
+	if policy != nil {
+		if typ, err := policy.Typ(); err == nil && typ == chat1.RetentionPolicyType_INHERIT {
+			policy = nil
+		}
+	}
+	if policy == nil {
+		policy = conv.TeamRetention
+	}
+	if policy == nil {
+		return 0, false
+	}
+	typ, err := policy.Typ()
+	if err != nil {
+		return 0, false
+	}
+	switch typ {
+	case chat1.RetentionPolicyType_EXPIRE:
+		return policy.Expire().Age.ToDuration(), true
+	case chat1.RetentionPolicyType_EPHEMERAL:
+		return policy.Ephemeral().Age.ToDuration(), true
+	default:
+		return 0, false
+	}
+}
+
+// attachmentExpiryCoveredByRetention says whether conv's retention policy
+// deletes messages before their attachments would expire.
+func attachmentExpiryCoveredByRetention(conv chat1.ConversationLocal, expiry time.Duration) bool {
+	age, ok := retentionAge(conv)
+	return ok && age <= expiry
+}
+
+// findExpiredAttachments returns the assets of the attachments in convID sent
+// before cutoff and after the message expiredThrough, and the newest of their
+// messages.
+func findExpiredAttachments(ctx context.Context, g *globals.Context, uid gregor1.UID,
+	convID chat1.ConversationID, cutoff gregor1.Time, expiredThrough chat1.MessageID) (
+	assets []chat1.Asset, newest chat1.MessageID, numAttachments int, err error) {
+	pagination := &chat1.Pagination{Num: attachmentExpiryPageSize}
+	for {
+		thread, err := g.ConvSource.Pull(ctx, convID, uid, chat1.GetThreadReason_GENERAL, nil,
+			&chat1.GetThreadQuery{
+				MarkAsRead:   false,
+				MessageTypes: []chat1.MessageType{chat1.MessageType_ATTACHMENT},
+				Before:       &cutoff,
+			}, pagination)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		pastRange := false
+		for _, m := range thread.Messages {
+			if m.GetMessageID() <= expiredThrough {
+				// Pages go from newest to oldest, so the rest are done.
+				pastRange = true
+				break
+			}
+			if !m.IsValidFull() {
+				continue
+			}
+			msg := m.Valid()
+			if msg.IsEphemeral() || msg.ServerHeader.Ctime >= cutoff {
+				// Exploding messages take their assets with them.
+				continue
+			}
+			msgAssets := utils.AssetsForMessage(g, msg.MessageBody)
+			if len(msgAssets) == 0 {
+				continue
+			}
+			assets = append(assets, msgAssets...)
+			numAttachments++
+			if msg.ServerHeader.MessageID > newest {
+				newest = msg.ServerHeader.MessageID
+			}
+		}
+		if pastRange || thread.Pagination == nil || thread.Pagination.Last {
+			return assets, newest, numAttachments, nil
+		}
+		pagination = thread.Pagination
+		pagination.Num = attachmentExpiryPageSize
+		pagination.Previous = nil
+	}
+}
+
+// expireTeamAttachments deletes the assets of the attachments in teamID's
+// channels that are older than its policy allows, or with dryRun, only
+// reports what it would delete.
+func expireTeamAttachments(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, teamID keybase1.TeamID, dryRun bool) (res chat1.AttachmentExpiryReport, err error) {
+	policy, err := getAttachmentExpiryPolicy(ctx, g, ri, uid, teamID)
+	if err != nil {
+		return res, err
+	}
+	if policy.Days == 0 {
+		return res, errors.New("the team has no attachment expiry policy")
+	}
+	convs, err := g.TeamChannelSource.GetChannelsFull(ctx, uid, chat1.TLFID(teamID.ToBytes()),
+		chat1.TopicType_CHAT)
+	if err != nil {
+		return res, err
+	}
+	if len(convs) == 0 {
+		return res, errors.New("the team has no channels")
+	}
+	op, err := teams.CanUserPerform(ctx, g.ExternalG(), convs[0].Info.TlfName)
+	if err != nil {
+		return res, err
+	}
+	if !op.DeleteOtherMessages {
+		return res, errors.New("only team admins can expire attachments")
+	}
+	general, err := getWelcomeMessageConv(ctx, g, uid, teamID)
+	if err != nil {
+		return res, err
+	}
+	progress, err := getAttachmentExpiryProgress(ctx, g, ri, uid, general.GetConvID())
+	if err != nil {
+		return res, err
+	}
+	if progress.ExpiredThrough == nil {
+		progress.ExpiredThrough = make(map[string]chat1.MessageID)
+	}
+
+	expiry := time.Duration(policy.Days) * 24 * time.Hour
+	res.Days = policy.Days
+	res.Cutoff = gregor1.ToTime(g.Clock().Now().Add(-expiry))
+	res.DryRun = dryRun
+	changed := false
+	for _, conv := range convs {
+		if conv.Info.MemberStatus != chat1.ConversationMemberStatus_ACTIVE {
+			continue
+		}
+		convReport := chat1.AttachmentExpiryConv{
+			ConvID:  conv.GetConvID(),
+			Channel: conv.GetTopicName(),
+		}
+		if attachmentExpiryCoveredByRetention(conv, expiry) {
+			convReport.CoveredByRetention = true
+			res.Convs = append(res.Convs, convReport)
+			continue
+		}
+		convIDStr := conv.GetConvID().String()
+		assets, newest, numAttachments, err := findExpiredAttachments(ctx, g, uid, conv.GetConvID(),
+			res.Cutoff, progress.ExpiredThrough[convIDStr])
+		if err != nil {
+			g.GetLog().CDebugf(ctx, "expireTeamAttachments: unable to load %s: %v", convIDStr, err)
+			continue
+		}
+		if numAttachments == 0 {
+			continue
+		}
+		for _, asset := range assets {
+			convReport.NumBytes += asset.Size
+		}
+		convReport.NumAttachments = numAttachments
+		if !dryRun {
+			if err := g.AttachmentURLSrv.GetAttachmentFetcher().DeleteAssets(ctx, conv.GetConvID(), assets,
+				ri, attachments.NewS3Signer(ri)); err != nil {
+				g.GetLog().CDebugf(ctx, "expireTeamAttachments: unable to delete assets in %s: %v",
+					convIDStr, err)
+				continue
+			}
+			progress.ExpiredThrough[convIDStr] = newest
+			changed = true
+		}
+		res.NumAttachments += convReport.NumAttachments
+		res.NumBytes += convReport.NumBytes
+		res.Convs = append(res.Convs, convReport)
+	}
+	sort.Slice(res.Convs, func(i, j int) bool {
+		return res.Convs[i].Channel < res.Convs[j].Channel
+	})
+	if changed {
+		s := NewConvDevConversationBackedStorage(g, chat1.TopicType_DEV, true /* adminOnly */, ri)
+		if err := s.Put(ctx, uid, general.GetConvID(), attachmentExpiryProgressName, progress); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+// AttachmentExpiryBackgroundRound enforces the attachment expiry policies of
+// the teams this user is an admin of.
+func AttachmentExpiryBackgroundRound(ctx context.Context, g *globals.Context,
+	ri func() chat1.RemoteInterface) (err error) {
+	defer g.CTrace(ctx, "AttachmentExpiryBackgroundRound", &err)()
+	uid, err := utils.AssertLoggedInUID(ctx, g)
+	if err != nil {
+		return err
+	}
+	topicType := chat1.TopicType_CHAT
+	inbox, err := g.InboxSource.ReadUnverified(ctx, uid, types.InboxSourceDataSourceLocalOnly,
+		&chat1.GetInboxQuery{
+			MembersTypes: []chat1.ConversationMembersType{chat1.ConversationMembersType_TEAM},
+			TopicType:    &topicType,
+		})
+	if err != nil {
+		return err
+	}
+	seen := make(map[string]bool)
+	for _, rc := range inbox.ConvsUnverified {
+		tlfID := rc.Conv.Metadata.IdTriple.Tlfid
+		if seen[tlfID.String()] {
+			continue
+		}
+		seen[tlfID.String()] = true
+		if rc.Conv.ReaderInfo == nil || !rc.Conv.ReaderInfo.UntrustedTeamRole.IsAdminOrAbove() {
+			continue
+		}
+		teamID, err := TLFIDToTeamID(tlfID)
+		if err != nil {
+			continue
+		}
+		policy, err := getAttachmentExpiryPolicy(ctx, g, ri, uid, teamID)
+		if err != nil {
+			g.GetLog().CDebugf(ctx, "AttachmentExpiryBackgroundRound: unable to get policy for %s: %v",
+				teamID, err)
+			continue
+		}
+		if policy.Days == 0 {
+			continue
+		}
+		report, err := expireTeamAttachments(ctx, g, ri, uid, teamID, false)
+		if err != nil {
+			g.GetLog().CDebugf(ctx, "AttachmentExpiryBackgroundRound: unable to expire attachments for %s: %v",
+				teamID, err)
+			continue
+		}
+		g.GetLog().CDebugf(ctx, "AttachmentExpiryBackgroundRound: expired %d attachments (%d bytes) for %s",
+			report.NumAttachments, report.NumBytes, teamID)
+	}
+	return nil
+}
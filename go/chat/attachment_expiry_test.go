@@ -0,0 +1,39 @@
+package chat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachmentExpiryCoveredByRetention(t *testing.T) {
+	expire := func(d time.Duration) *chat1.RetentionPolicy {
+		p := chat1.NewRetentionPolicyWithExpire(chat1.RpExpire{Age: gregor1.ToDurationSec(d)})
+		return &p
+	}
+	inherit := chat1.NewRetentionPolicyWithInherit(chat1.RpInherit{})
+	retain := chat1.NewRetentionPolicyWithRetain(chat1.RpRetain{})
+	day := 24 * time.Hour
+
+	var conv chat1.ConversationLocal
+	require.False(t, attachmentExpiryCoveredByRetention(conv, 30*day))
+
+	conv.TeamRetention = expire(7 * day)
+	require.True(t, attachmentExpiryCoveredByRetention(conv, 30*day))
+	require.False(t, attachmentExpiryCoveredByRetention(conv, day))
+
+	// The channel's own policy wins over the team's, unless it inherits.
+	conv.ConvRetention = &retain
+	require.False(t, attachmentExpiryCoveredByRetention(conv, 30*day))
+	conv.ConvRetention = &inherit
+	require.True(t, attachmentExpiryCoveredByRetention(conv, 30*day))
+	conv.ConvRetention = expire(60 * day)
+	require.False(t, attachmentExpiryCoveredByRetention(conv, 30*day))
+
+	require.NoError(t, checkAttachmentExpiryPolicy(chat1.AttachmentExpiryPolicy{}))
+	require.NoError(t, checkAttachmentExpiryPolicy(chat1.AttachmentExpiryPolicy{Days: 90}))
+	require.Error(t, checkAttachmentExpiryPolicy(chat1.AttachmentExpiryPolicy{Days: -1}))
+}
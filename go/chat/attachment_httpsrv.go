@@ -408,6 +408,15 @@ func (r *AttachmentHTTPSrv) makeError(ctx context.Context, w http.ResponseWriter
 	w.WriteHeader(code)
 }
 
+// fetchErrorCode is the status for a failed fetch, which is forbidden if it
+// was an automatic download the network's policy didn't allow.
+func fetchErrorCode(err error) int {
+	if err == attachments.ErrAutoDownloadBlocked {
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
+
 func (r *AttachmentHTTPSrv) shouldServeContent(ctx context.Context, asset chat1.Asset, req *http.Request) bool {
 	noStream := "true" == req.URL.Query().Get("nostream")
 	if noStream {
@@ -485,7 +494,7 @@ func (r *AttachmentHTTPSrv) serveVideoHostPage(ctx context.Context, w http.Respo
 					<video id="vid" style="width: 100%%; height: 100%%; object-fit:fill; border-radius: 4px" poster="%s" src="%s" preload="none" playsinline webkit-playsinline />
 				</body>
 			</html>
-		`, req.URL.Query().Get("poster"), req.URL.String()+"&contentforce=true"))); err != nil {
+		`, req.URL.Query().Get("poster"), req.URL.String()+"&contentforce=true&manual=true"))); err != nil {
 			r.Debug(ctx, "serve: failed to write HTML video player: %s", err)
 		}
 		return true
@@ -499,6 +508,11 @@ func (r *AttachmentHTTPSrv) serveAttachment(ctx context.Context, w http.Response
 	preview := "true" == req.URL.Query().Get("prev")
 	noAnim := "true" == req.URL.Query().Get("noanim")
 	isEmoji := "true" == req.URL.Query().Get("isemoji")
+	// The app asks for attachments the user opens with manual=true, anything
+	// else is an automatic download.
+	if "true" != req.URL.Query().Get("manual") {
+		ctx = globals.CtxAddAttachmentAutoDownload(ctx, preview)
+	}
 	key := req.URL.Query().Get("key")
 	r.Lock()
 	pairInt, ok := r.urlMap.Get(key)
@@ -537,7 +551,7 @@ func (r *AttachmentHTTPSrv) serveAttachment(ctx context.Context, w http.Response
 			req.Header.Get("Range"))
 		rs, err := r.fetcher.StreamAttachment(ctx, pair.ConvID, asset, r.ri, r)
 		if err != nil {
-			r.makeError(ctx, w, http.StatusInternalServerError, "failed to get streamer: %s", err)
+			r.makeError(ctx, w, fetchErrorCode(err), "failed to get streamer: %s", err)
 			return
 		}
 		http.ServeContent(w, req, asset.Filename, time.Time{}, rs)
@@ -546,7 +560,7 @@ func (r *AttachmentHTTPSrv) serveAttachment(ctx context.Context, w http.Response
 		if noAnim {
 			var buf bytes.Buffer
 			if err := r.fetcher.FetchAttachment(ctx, &buf, pair.ConvID, asset, r.ri, r, blankProgress); err != nil {
-				r.makeError(ctx, w, http.StatusInternalServerError, "failed to fetch attachment: %s", err)
+				r.makeError(ctx, w, fetchErrorCode(err), "failed to fetch attachment: %s", err)
 				return
 			}
 			bufReader := attachments.NewBufReadResetter(buf.Bytes())
@@ -560,7 +574,7 @@ func (r *AttachmentHTTPSrv) serveAttachment(ctx context.Context, w http.Response
 			}
 		} else {
 			if err := r.fetcher.FetchAttachment(ctx, w, pair.ConvID, asset, r.ri, r, blankProgress); err != nil {
-				r.makeError(ctx, w, http.StatusInternalServerError, "failed to fetch attachment: %s", err)
+				r.makeError(ctx, w, fetchErrorCode(err), "failed to fetch attachment: %s", err)
 				return
 			}
 		}
@@ -638,6 +652,9 @@ func NewRemoteAttachmentFetcher(g *globals.Context, store attachments.Store) *Re
 func (r *RemoteAttachmentFetcher) StreamAttachment(ctx context.Context, convID chat1.ConversationID,
 	asset chat1.Asset, ri func() chat1.RemoteInterface, signer s3.Signer) (res io.ReadSeeker, err error) {
 	defer r.Trace(ctx, &err, "StreamAttachment")()
+	if err := attachments.CheckAutoDownload(ctx, r.G(), asset); err != nil {
+		return nil, err
+	}
 	// Grab S3 params for the conversation
 	s3params, err := ri().GetS3Params(ctx, convID)
 	if err != nil {
@@ -650,6 +667,9 @@ func (r *RemoteAttachmentFetcher) FetchAttachment(ctx context.Context, w io.Writ
 	convID chat1.ConversationID, asset chat1.Asset,
 	ri func() chat1.RemoteInterface, signer s3.Signer, progress types.ProgressReporter) (err error) {
 	defer r.Trace(ctx, &err, "FetchAttachment")()
+	if err := attachments.CheckAutoDownload(ctx, r.G(), asset); err != nil {
+		return err
+	}
 	// Grab S3 params for the conversation
 	s3params, err := ri().GetS3Params(ctx, convID)
 	if err != nil {
@@ -813,6 +833,9 @@ func (c *CachingAttachmentFetcher) FetchAttachment(ctx context.Context, w io.Wri
 			return c.store.DecryptAsset(ctx, w, fileReader, asset, progress)
 		}
 	}
+	if err := attachments.CheckAutoDownload(ctx, c.G(), asset); err != nil {
+		return err
+	}
 
 	// Grab S3 params for the conversation
 	s3params, err := ri().GetS3Params(ctx, convID)
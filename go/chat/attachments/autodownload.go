@@ -0,0 +1,104 @@
+package attachments
+
+import (
+	"errors"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"golang.org/x/net/context"
+)
+
+// Automatic downloads are the previews and attachments the app fetches on its
+// own to show a conversation. Which of them are allowed depends on the kind of
+// network the device is on; anything the user asks for, or that's already
+// cached, is always fetched.
+
+var ErrAutoDownloadBlocked = errors.New("automatic download not allowed on this network")
+
+const defaultCellularMaxAttachmentSize = 10 * 1024 * 1024
+
+func DefaultAutoDownloadPolicy() chat1.AttachmentAutoDownloadPolicy {
+	return chat1.AttachmentAutoDownloadPolicy{
+		Wifi: chat1.AttachmentAutoDownloadRule{
+			Previews:    true,
+			Attachments: true,
+		},
+		Cellular: chat1.AttachmentAutoDownloadRule{
+			Previews:          true,
+			Attachments:       true,
+			MaxAttachmentSize: defaultCellularMaxAttachmentSize,
+		},
+		Metered: chat1.AttachmentAutoDownloadRule{
+			Previews: true,
+		},
+	}
+}
+
+func autoDownloadPolicyDbKey() libkb.DbKey {
+	return libkb.DbKey{
+		Typ: libkb.DBChatAttachmentAutoDownload,
+		Key: "v0-policy",
+	}
+}
+
+func GetAutoDownloadPolicy(ctx context.Context, g *globals.Context) (res chat1.AttachmentAutoDownloadPolicy, err error) {
+	found, err := g.LocalChatDb.GetInto(&res, autoDownloadPolicyDbKey())
+	if err != nil {
+		return res, err
+	}
+	if !found {
+		return DefaultAutoDownloadPolicy(), nil
+	}
+	return res, nil
+}
+
+func SetAutoDownloadPolicy(ctx context.Context, g *globals.Context, policy chat1.AttachmentAutoDownloadPolicy) error {
+	for _, rule := range []chat1.AttachmentAutoDownloadRule{policy.Wifi, policy.Cellular, policy.Metered} {
+		if rule.MaxAttachmentSize < 0 {
+			return errors.New("maxAttachmentSize must not be negative")
+		}
+	}
+	return g.LocalChatDb.PutObj(autoDownloadPolicyDbKey(), nil, policy)
+}
+
+// AutoDownloadAllowed returns whether policy lets asset, a preview or a full
+// attachment, be downloaded on its own on a network of class.
+func AutoDownloadAllowed(policy chat1.AttachmentAutoDownloadPolicy, class libkb.NetworkClass,
+	asset chat1.Asset, preview bool) bool {
+	var rule chat1.AttachmentAutoDownloadRule
+	switch class {
+	case libkb.NetworkClassUnmetered:
+		rule = policy.Wifi
+	case libkb.NetworkClassCellular:
+		rule = policy.Cellular
+	case libkb.NetworkClassMetered:
+		rule = policy.Metered
+	default:
+		return false
+	}
+	if preview {
+		return rule.Previews
+	}
+	if !rule.Attachments {
+		return false
+	}
+	return rule.MaxAttachmentSize == 0 || asset.Size <= rule.MaxAttachmentSize
+}
+
+// CheckAutoDownload returns ErrAutoDownloadBlocked if ctx is for an automatic
+// download of asset that isn't allowed on the current network.
+func CheckAutoDownload(ctx context.Context, g *globals.Context, asset chat1.Asset) error {
+	preview, ok := globals.CtxAttachmentAutoDownload(ctx)
+	if !ok {
+		return nil
+	}
+	policy, err := GetAutoDownloadPolicy(ctx, g)
+	if err != nil {
+		return err
+	}
+	if !AutoDownloadAllowed(policy, g.MobileNetState.Class(), asset, preview) {
+		return ErrAutoDownloadBlocked
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package attachments
+
+import (
+	"testing"
+
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutoDownloadAllowed(t *testing.T) {
+	require.Equal(t, libkb.NetworkClassUnmetered, libkb.ClassifyNetwork(keybase1.MobileNetworkState_NOTAVAILABLE, false))
+	require.Equal(t, libkb.NetworkClassMetered, libkb.ClassifyNetwork(keybase1.MobileNetworkState_WIFI, true))
+	require.Equal(t, libkb.NetworkClassCellular, libkb.ClassifyNetwork(keybase1.MobileNetworkState_CELLULAR, true))
+	require.Equal(t, libkb.NetworkClassMetered, libkb.ClassifyNetwork(keybase1.MobileNetworkState_UNKNOWN, false))
+	require.Equal(t, libkb.NetworkClassOffline, libkb.ClassifyNetwork(keybase1.MobileNetworkState_NONE, false))
+
+	policy := DefaultAutoDownloadPolicy()
+	small := chat1.Asset{Size: 1024}
+	big := chat1.Asset{Size: defaultCellularMaxAttachmentSize + 1}
+	for _, test := range []struct {
+		class   libkb.NetworkClass
+		asset   chat1.Asset
+		preview bool
+		allowed bool
+	}{
+		{libkb.NetworkClassUnmetered, big, false, true},
+		{libkb.NetworkClassCellular, small, false, true},
+		{libkb.NetworkClassCellular, big, false, false},
+		{libkb.NetworkClassCellular, big, true, true},
+		{libkb.NetworkClassMetered, small, false, false},
+		{libkb.NetworkClassMetered, small, true, true},
+		{libkb.NetworkClassOffline, small, true, false},
+	} {
+		require.Equal(t, test.allowed, AutoDownloadAllowed(policy, test.class, test.asset, test.preview),
+			"%v size: %d preview: %v", test.class, test.asset.Size, test.preview)
+	}
+
+	policy.Wifi.Previews = false
+	require.False(t, AutoDownloadAllowed(policy, libkb.NetworkClassUnmetered, small, true))
+	require.True(t, AutoDownloadAllowed(policy, libkb.NetworkClassUnmetered, small, false))
+}
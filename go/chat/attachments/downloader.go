@@ -71,3 +71,63 @@ func Download(ctx context.Context, g *globals.Context, uid gregor1.UID,
 	}
 	return nil
 }
+
+// downloadRangeChunkSize is how much of a ranged download we copy between
+// progress updates.
+const downloadRangeChunkSize = 1 << 20
+
+// DownloadRange is like Download but only writes length bytes of the
+// attachment starting at offset, so a download that got cut off can pick up
+// where it left off. A length of 0 means to the end of the attachment. It
+// returns the size of the whole attachment, and progress is reported in
+// terms of the whole attachment too.
+func DownloadRange(ctx context.Context, g *globals.Context, uid gregor1.UID,
+	convID chat1.ConversationID, messageID chat1.MessageID, sink io.WriteCloser, showPreview bool,
+	offset, length int64, progress func(int64, int64), ri func() chat1.RemoteInterface) (size int64, err error) {
+	defer func() {
+		if cerr := sink.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	obj, err := AssetFromMessage(ctx, g, uid, convID, messageID, showPreview)
+	if err != nil {
+		return 0, err
+	}
+	size = obj.Size
+	if offset < 0 || length < 0 {
+		return size, errors.New("offset and length must not be negative")
+	}
+	if offset > size {
+		return size, fmt.Errorf("offset %d is past the end of the attachment (%d bytes)", offset, size)
+	}
+	if length == 0 || offset+length > size {
+		length = size - offset
+	}
+	record := rpc.NewNetworkInstrumenter(g.ExternalG().RemoteNetworkInstrumenterStorage, "ChatAttachmentDownload")
+	defer func() { _ = record.RecordAndFinish(ctx, length) }()
+
+	fetcher := g.AttachmentURLSrv.GetAttachmentFetcher()
+	rs, err := fetcher.StreamAttachment(ctx, convID, obj, ri, NewS3Signer(ri))
+	if err != nil {
+		return size, err
+	}
+	if _, err := rs.Seek(offset, io.SeekStart); err != nil {
+		return size, err
+	}
+	for copied := int64(0); copied < length; {
+		chunk := length - copied
+		if chunk > downloadRangeChunkSize {
+			chunk = downloadRangeChunkSize
+		}
+		n, err := io.CopyN(sink, rs, chunk)
+		copied += n
+		if progress != nil {
+			progress(offset+copied, size)
+		}
+		if err != nil {
+			return size, err
+		}
+	}
+	return size, nil
+}
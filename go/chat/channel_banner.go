@@ -0,0 +1,239 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/chat/types"
+	"github.com/keybase/client/go/chat/utils"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/keybase/client/go/teams"
+)
+
+// Channel banners are announcements team admins put at the top of a
+// channel for a while: an outage, a migration, a deadline. They sit between
+// headlines, which stay until someone changes them, and pinned messages,
+// which are part of the conversation. Every banner has an expiry and comes
+// down by itself when that's up. They're kept as team policies with an
+// audit log of every change, the same way as incident mode, and expired
+// banners are only dropped from there (and logged as expired) the next time
+// an admin changes one.
+
+const channelBannersName = "__channel_banners"
+
+const channelBannerAuditLogName = "__channel_banner_audit_log"
+
+// The longest a banner can be up for at once.
+const maxChannelBannerDuration = 30 * 24 * time.Hour
+
+// In characters.
+const maxChannelBannerLength = 280
+
+var channelBanners = teamPolicy[chat1.ChannelBannerSettings]{
+	name:      channelBannersName,
+	cacheTime: time.Minute,
+}
+
+var channelBannerAuditLog = teamAuditLog[chat1.ChannelBannerAuditLog, chat1.ChannelBannerAuditEntry]{
+	teamPolicy: teamPolicy[chat1.ChannelBannerAuditLog]{name: channelBannerAuditLogName},
+	entries: func(log *chat1.ChannelBannerAuditLog) *[]chat1.ChannelBannerAuditEntry {
+		return &log.Entries
+	},
+}
+
+func channelBannerExpired(banner chat1.ChannelBanner, now time.Time) bool {
+	return !now.Before(banner.ExpiresAt.Time())
+}
+
+// activeChannelBanner returns convID's banner in settings, or nil if it
+// doesn't have one or it's expired.
+func activeChannelBanner(settings chat1.ChannelBannerSettings, convID chat1.ConversationID,
+	now time.Time) *chat1.ChannelBanner {
+	for _, banner := range settings.Banners {
+		if banner.ConvID.Eq(convID) && !channelBannerExpired(banner, now) {
+			res := banner.DeepCopy()
+			return &res
+		}
+	}
+	return nil
+}
+
+func checkChannelBanner(text string, duration time.Duration) error {
+	if len(text) == 0 {
+		return nil
+	}
+	if utf8.RuneCountInString(text) > maxChannelBannerLength {
+		return fmt.Errorf("banners can be at most %d characters long", maxChannelBannerLength)
+	}
+	if duration <= 0 {
+		return errors.New("banners need an expiry")
+	}
+	if duration > maxChannelBannerDuration {
+		return fmt.Errorf("banners can be put up for at most %v at a time", maxChannelBannerDuration)
+	}
+	return nil
+}
+
+// applyChannelBanner puts text up as convID's banner until now+duration, or
+// takes its banner down if text is empty, dropping any banners that have
+// expired. It returns the new settings and the audit entries for the change,
+// newest first, or no entries if nothing changed.
+func applyChannelBanner(settings chat1.ChannelBannerSettings, convID chat1.ConversationID, channel,
+	admin, text string, duration time.Duration, now time.Time) (res chat1.ChannelBannerSettings,
+	entries []chat1.ChannelBannerAuditEntry) {
+	var expired []chat1.ChannelBannerAuditEntry
+	wasUp := false
+	for _, b := range settings.Banners {
+		switch {
+		case channelBannerExpired(b, now):
+			expired = append([]chat1.ChannelBannerAuditEntry{{
+				Ctime:     b.ExpiresAt,
+				Admin:     b.SetBy,
+				ConvID:    b.ConvID,
+				Channel:   b.Channel,
+				Action:    chat1.ChannelBannerAction_EXPIRED,
+				Text:      b.Text,
+				ExpiresAt: b.ExpiresAt,
+			}}, expired...)
+		case b.ConvID.Eq(convID):
+			wasUp = true
+		default:
+			res.Banners = append(res.Banners, b)
+		}
+	}
+	ctime := gregor1.ToTime(now)
+	if len(text) > 0 {
+		expiresAt := gregor1.ToTime(now.Add(duration))
+		res.Banners = append(res.Banners, chat1.ChannelBanner{
+			ConvID:    convID,
+			Channel:   channel,
+			Text:      text,
+			SetBy:     admin,
+			Ctime:     ctime,
+			ExpiresAt: expiresAt,
+		})
+		entries = append(entries, chat1.ChannelBannerAuditEntry{
+			Ctime:     ctime,
+			Admin:     admin,
+			ConvID:    convID,
+			Channel:   channel,
+			Action:    chat1.ChannelBannerAction_SET,
+			Text:      text,
+			ExpiresAt: expiresAt,
+		})
+	} else if wasUp {
+		entries = append(entries, chat1.ChannelBannerAuditEntry{
+			Ctime:   ctime,
+			Admin:   admin,
+			ConvID:  convID,
+			Channel: channel,
+			Action:  chat1.ChannelBannerAction_CLEARED,
+		})
+	}
+	return res, append(entries, expired...)
+}
+
+func getChannelBanner(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, convID chat1.ConversationID) (*chat1.ChannelBanner, error) {
+	conv, err := utils.GetVerifiedConv(ctx, g, uid, convID, types.InboxSourceDataSourceAll)
+	if err != nil {
+		return nil, err
+	}
+	if conv.GetMembersType() != chat1.ConversationMembersType_TEAM {
+		return nil, nil
+	}
+	teamID, err := TLFIDToTeamID(conv.Info.Triple.Tlfid)
+	if err != nil {
+		return nil, err
+	}
+	now := g.Clock().Now()
+	settings, err := channelBanners.refresh(ctx, g, ri, uid, teamID, now)
+	if err != nil {
+		return nil, err
+	}
+	return activeChannelBanner(settings, convID, now), nil
+}
+
+func setChannelBanner(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, convID chat1.ConversationID, text string, duration time.Duration) error {
+	text = strings.TrimSpace(text)
+	if err := checkChannelBanner(text, duration); err != nil {
+		return err
+	}
+	conv, err := utils.GetVerifiedConv(ctx, g, uid, convID, types.InboxSourceDataSourceAll)
+	if err != nil {
+		return err
+	}
+	if conv.GetMembersType() != chat1.ConversationMembersType_TEAM ||
+		conv.GetTopicType() != chat1.TopicType_CHAT {
+		return errors.New("banners only work in team channels")
+	}
+	op, err := teams.CanUserPerform(ctx, g.ExternalG(), conv.Info.TlfName)
+	if err != nil {
+		return err
+	}
+	if !op.SetMinWriterRole {
+		return errors.New("only team admins can change banners")
+	}
+	teamID, err := TLFIDToTeamID(conv.Info.Triple.Tlfid)
+	if err != nil {
+		return err
+	}
+	settings, err := channelBanners.load(ctx, g, ri, uid, teamID)
+	if err != nil {
+		return err
+	}
+	settings, entries := applyChannelBanner(settings, convID, conv.GetTopicName(),
+		g.Env.GetUsername().String(), text, duration, g.Clock().Now())
+	if len(entries) == 0 {
+		return nil
+	}
+	if err := channelBanners.store(ctx, g, ri, uid, teamID, settings); err != nil {
+		return err
+	}
+	if err := channelBannerAuditLog.add(ctx, g, ri, uid, teamID, entries...); err != nil {
+		g.GetLog().CDebugf(ctx, "setChannelBanner: unable to record audit entries: %v", err)
+	}
+	return nil
+}
+
+func getChannelBannerAuditLog(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, teamID keybase1.TeamID) (chat1.ChannelBannerAuditLog, error) {
+	return channelBannerAuditLog.load(ctx, g, ri, uid, teamID)
+}
+
+// fillChannelBanners sets the banners of the team channels in convs, loading
+// each team's banners at most once. Banners are extra, so failing to load
+// them only leaves them out.
+func fillChannelBanners(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, convs []chat1.ConversationLocal) {
+	if ri == nil {
+		return
+	}
+	now := g.Clock().Now()
+	failed := make(map[keybase1.TeamID]bool)
+	for i, conv := range convs {
+		if conv.Error != nil || conv.GetMembersType() != chat1.ConversationMembersType_TEAM ||
+			conv.GetTopicType() != chat1.TopicType_CHAT {
+			continue
+		}
+		teamID, err := TLFIDToTeamID(conv.Info.Triple.Tlfid)
+		if err != nil || failed[teamID] {
+			continue
+		}
+		settings, err := channelBanners.get(ctx, g, ri, uid, teamID, now)
+		if err != nil {
+			g.GetLog().CDebugf(ctx, "fillChannelBanners: unable to load banners for %s: %v", teamID, err)
+			failed[teamID] = true
+			continue
+		}
+		convs[i].Info.Banner = activeChannelBanner(settings, conv.GetConvID(), now)
+	}
+}
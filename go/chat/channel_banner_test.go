@@ -0,0 +1,69 @@
+package chat
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyChannelBanner(t *testing.T) {
+	general := chat1.ConversationID([]byte{1})
+	ops := chat1.ConversationID([]byte{2})
+	start := time.Unix(1700000000, 0)
+
+	require.NoError(t, checkChannelBanner("deploys are frozen", time.Hour))
+	require.NoError(t, checkChannelBanner("", 0))
+	require.Error(t, checkChannelBanner("deploys are frozen", 0))
+	require.Error(t, checkChannelBanner("deploys are frozen", maxChannelBannerDuration+time.Minute))
+	require.Error(t, checkChannelBanner(strings.Repeat("x", maxChannelBannerLength+1), time.Hour))
+
+	// Put banners up in two channels.
+	settings, entries := applyChannelBanner(chat1.ChannelBannerSettings{}, general, "general", "alice",
+		"deploys are frozen", time.Hour, start)
+	require.Len(t, entries, 1)
+	require.Equal(t, chat1.ChannelBannerAction_SET, entries[0].Action)
+	require.Equal(t, "deploys are frozen", entries[0].Text)
+	require.Equal(t, start.Add(time.Hour), entries[0].ExpiresAt.Time())
+	settings, _ = applyChannelBanner(settings, ops, "ops", "bob", "migration tonight", 3*time.Hour, start)
+	require.Len(t, settings.Banners, 2)
+	banner := activeChannelBanner(settings, general, start.Add(30*time.Minute))
+	require.NotNil(t, banner)
+	require.Equal(t, "deploys are frozen", banner.Text)
+	require.Equal(t, "alice", banner.SetBy)
+
+	// The first one expires by itself.
+	later := start.Add(2 * time.Hour)
+	require.Nil(t, activeChannelBanner(settings, general, later))
+	require.NotNil(t, activeChannelBanner(settings, ops, later))
+
+	// Clearing a channel without a banner changes nothing, except to log
+	// and drop the expired one.
+	settings, entries = applyChannelBanner(settings, general, "general", "bob", "", 0, later)
+	require.Len(t, settings.Banners, 1)
+	require.Len(t, entries, 1)
+	require.Equal(t, chat1.ChannelBannerAction_EXPIRED, entries[0].Action)
+	require.Equal(t, "alice", entries[0].Admin)
+	require.Equal(t, "deploys are frozen", entries[0].Text)
+	require.Equal(t, start.Add(time.Hour), entries[0].Ctime.Time())
+	settings, entries = applyChannelBanner(settings, general, "general", "bob", "", 0, later)
+	require.Len(t, settings.Banners, 1)
+	require.Empty(t, entries)
+
+	// Setting it again replaces it.
+	settings, _ = applyChannelBanner(settings, ops, "ops", "bob", "migration moved to friday",
+		4*time.Hour, later)
+	require.Len(t, settings.Banners, 1)
+	banner = activeChannelBanner(settings, ops, start.Add(5*time.Hour))
+	require.NotNil(t, banner)
+	require.Equal(t, "migration moved to friday", banner.Text)
+
+	// And down.
+	settings, entries = applyChannelBanner(settings, ops, "ops", "alice", "", 0, later)
+	require.Empty(t, settings.Banners)
+	require.Len(t, entries, 1)
+	require.Equal(t, chat1.ChannelBannerAction_CLEARED, entries[0].Action)
+	require.Nil(t, activeChannelBanner(settings, ops, later))
+}
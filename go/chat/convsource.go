@@ -119,6 +119,31 @@ func (s *baseConversationSource) addConversationCards(ctx context.Context, uid g
 	thread.Messages[addLeftOf] = chat1.NewMessageUnboxedWithJourneycard(*card)
 }
 
+// addIdentityChangeCards checks for identity changes of the other
+// participants if we have the conversation to know where the alerts go and
+// the load is one that checks, and adds the alerts to the thread.
+func (s *baseConversationSource) addIdentityChangeCards(ctx context.Context, uid gregor1.UID,
+	reason chat1.GetThreadReason, convID chat1.ConversationID, convOptional *chat1.ConversationLocal,
+	thread *chat1.ThreadView) {
+	if journeycardShouldNotRunOnReason[reason] {
+		return
+	}
+	var alerts []chat1.IdentityChangeAlert
+	var err error
+	if convOptional != nil && identityChangeCheckReasons[reason] {
+		ctxShort, ctxShortCancel := context.WithTimeout(ctx, 2*time.Second)
+		defer ctxShortCancel()
+		alerts, err = checkIdentityChanges(ctxShort, s.G(), uid, convID, convOptional.MaxVisibleMsgID())
+	} else {
+		alerts, err = getIdentityChanges(s.G(), uid, convID)
+	}
+	if err != nil {
+		s.Debug(ctx, "addIdentityChangeCards: error getting identity changes: %s", err)
+		return
+	}
+	addIdentityChangeCards(thread, alerts)
+}
+
 func (s *baseConversationSource) getRi(customRi func() chat1.RemoteInterface) chat1.RemoteInterface {
 	if customRi != nil {
 		return customRi()
@@ -174,6 +199,7 @@ func (s *baseConversationSource) postProcessThread(ctx context.Context, uid greg
 
 	// Add any conversation cards
 	s.addConversationCards(ctx, uid, reason, conv.GetConvID(), verifiedConv, thread)
+	s.addIdentityChangeCards(ctx, uid, reason, conv.GetConvID(), verifiedConv, thread)
 
 	// Fetch outbox and tack onto the result
 	outbox := storage.NewOutbox(s.G(), uid)
@@ -313,6 +313,39 @@ func (e RestrictedBotChannelError) IsImmediateFail() (chat1.OutboxErrorType, boo
 
 // =============================================================================
 
+type NewMemberRestrictedError struct {
+	Msg string
+}
+
+func (e NewMemberRestrictedError) Error() string {
+	return e.Msg
+}
+
+func (e NewMemberRestrictedError) IsImmediateFail() (chat1.OutboxErrorType, bool) {
+	return chat1.OutboxErrorType_MISC, true
+}
+
+// =============================================================================
+
+type UntrustedDeviceError struct {
+	Channel          string
+	MinDeviceAgeDays int
+	// When the device will be old enough to post.
+	TrustedAt time.Time
+}
+
+func (e UntrustedDeviceError) Error() string {
+	return fmt.Sprintf("only devices provisioned at least %d days ago can post in #%s; this one can "+
+		"post after %s, or once a team admin approves it", e.MinDeviceAgeDays, e.Channel,
+		e.TrustedAt.Format("2006-01-02 15:04"))
+}
+
+func (e UntrustedDeviceError) IsImmediateFail() (chat1.OutboxErrorType, bool) {
+	return chat1.OutboxErrorType_MISC, true
+}
+
+// =============================================================================
+
 type BoxingCryptKeysError struct {
 	Err error
 }
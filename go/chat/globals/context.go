@@ -23,6 +23,7 @@ type messageSkipsKeyTyp int
 type unboxModeKeyTyp int
 type emojiHarvesterKeyTyp int
 type ctxMutexKeyTyp int
+type autoDownloadKeyTyp int
 
 var kfKey keyfinderKey
 var inKey identifyNotifierKey
@@ -36,6 +37,7 @@ var messageSkipsKey messageSkipsKeyTyp
 var unboxModeKey unboxModeKeyTyp
 var emojiHarvesterKey emojiHarvesterKeyTyp
 var ctxMutexKey ctxMutexKeyTyp
+var autoDownloadKey autoDownloadKeyTyp
 
 type identModeData struct {
 	mode   keybase1.TLFIdentifyBehavior
@@ -149,6 +151,17 @@ func CtxUnboxMode(ctx context.Context) types.UnboxMode {
 	return types.UnboxModeFull
 }
 
+// CtxAddAttachmentAutoDownload marks attachment fetches under ctx as ones the
+// app makes on its own, of a preview or of the full attachment.
+func CtxAddAttachmentAutoDownload(ctx context.Context, preview bool) context.Context {
+	return context.WithValue(ctx, autoDownloadKey, preview)
+}
+
+func CtxAttachmentAutoDownload(ctx context.Context) (preview bool, ok bool) {
+	preview, ok = ctx.Value(autoDownloadKey).(bool)
+	return preview, ok
+}
+
 func CtxOverrideNameInfoSource(ctx context.Context) (types.NameInfoSource, bool) {
 	if ni, ok := ctx.Value(nameInfoOverrideKey).(types.NameInfoSource); ok {
 		return ni, true
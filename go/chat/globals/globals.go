@@ -3,6 +3,7 @@ package globals
 import (
 	"fmt"
 	"regexp"
+	"sync"
 
 	"github.com/keybase/client/go/badges"
 	"github.com/keybase/client/go/chat/types"
@@ -49,6 +50,10 @@ type ChatContext struct {
 	EmojiSource          types.EmojiSource                // emoji support
 	EphemeralTracker     types.EphemeralTracker           // tracking of ephemeral msg caches
 	ArchiveRegistry      types.ChatArchiveRegistry        // Metadata store of chat archives
+	TeamPolicyCache      types.TeamPolicyCache            // team policies from admin-only dev storage
+	IdentityChangeLock   sync.Mutex                       // serializes updates to identity change alerts
+	WidgetFeedLock       sync.Mutex                       // serializes updates to the widget feed
+	ArchiveImportLock    sync.Mutex                       // guards the imported archive conversations
 }
 
 func (c *ChatContext) Describe() string {
@@ -0,0 +1,165 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/chat/search"
+	"github.com/keybase/client/go/chat/types"
+	"github.com/keybase/client/go/chat/utils"
+	"github.com/keybase/client/go/chatrender"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// History search looks for a message both in the live history, with the
+// inbox search index, and in the output of completed archive jobs, so it
+// still turns up once retention has removed it from the conversation. Hits
+// from both are named the way archives name a conversation's directory, so
+// a message that's in both can be told apart and only returned once.
+
+// An archive's author and time is the sender, a mark if the device was
+// revoked, the device name if there was one, then the time.
+var archiveAuthorAndTimeRegexp = regexp.MustCompile(
+	`^(\S+?)(?:\(!\))?(?:\s+<[^>]*>)?\s+(\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2})$`)
+
+// parseArchiveAuthorAndTime returns the sender and time of a message in an
+// archive. Archives are written in local time. The time is zero if it can't
+// be told, and the sender is everything if neither can.
+func parseArchiveAuthorAndTime(authorAndTime string) (sender string, ctime gregor1.Time) {
+	match := archiveAuthorAndTimeRegexp.FindStringSubmatch(authorAndTime)
+	if match == nil {
+		return authorAndTime, 0
+	}
+	t, err := time.ParseInLocation("2006-01-02 15:04:05", match[2], time.Local)
+	if err != nil {
+		return match[1], 0
+	}
+	return match[1], gregor1.ToTime(t)
+}
+
+func archiveHistorySearchHits(hits []chat1.ArchiveChatSearchHit) (res []chat1.ChatHistorySearchHit) {
+	for _, hit := range hits {
+		sender, ctime := parseArchiveAuthorAndTime(hit.AuthorAndTime)
+		res = append(res, chat1.ChatHistorySearchHit{
+			Source:   chat1.ChatHistorySearchSource_ARCHIVE,
+			ConvName: hit.ConvName,
+			JobID:    hit.JobID,
+			MsgID:    hit.MsgID,
+			Sender:   sender,
+			Ctime:    ctime,
+			Body:     hit.Body,
+		})
+	}
+	return res
+}
+
+// liveHistorySearchHits returns the hits in res, with convName giving the
+// name of each conversation.
+func liveHistorySearchHits(res *chat1.ChatSearchInboxResults, convName func(chat1.ChatSearchInboxHit) string) (
+	hits []chat1.ChatHistorySearchHit) {
+	if res == nil {
+		return nil
+	}
+	for _, inboxHit := range res.Hits {
+		name := convName(inboxHit)
+		for _, searchHit := range inboxHit.Hits {
+			if !searchHit.HitMessage.IsValid() {
+				continue
+			}
+			msg := searchHit.HitMessage.Valid()
+			convID := inboxHit.ConvID
+			hits = append(hits, chat1.ChatHistorySearchHit{
+				Source:   chat1.ChatHistorySearchSource_LIVE,
+				ConvName: name,
+				ConvID:   &convID,
+				MsgID:    msg.MessageID,
+				Sender:   msg.SenderUsername,
+				Ctime:    msg.Ctime,
+				Body:     msg.BodySummary,
+			})
+		}
+	}
+	return hits
+}
+
+// mergeChatHistorySearchHits returns the live and archived hits, newest
+// first and at most maxHits of them, and whether there were more. A message
+// in both is only returned from the live history.
+func mergeChatHistorySearchHits(live, archived []chat1.ChatHistorySearchHit, maxHits int) (
+	res []chat1.ChatHistorySearchHit, truncated bool) {
+	type msgKey struct {
+		convName string
+		msgID    chat1.MessageID
+	}
+	seen := make(map[msgKey]bool, len(live))
+	res = make([]chat1.ChatHistorySearchHit, 0, len(live)+len(archived))
+	for _, hit := range live {
+		seen[msgKey{hit.ConvName, hit.MsgID}] = true
+		res = append(res, hit)
+	}
+	for _, hit := range archived {
+		key := msgKey{hit.ConvName, hit.MsgID}
+		if seen[key] {
+			continue
+		}
+		// Several jobs can have archived the same message.
+		seen[key] = true
+		res = append(res, hit)
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Ctime > res[j].Ctime
+	})
+	if maxHits > 0 && len(res) > maxHits {
+		return res[:maxHits], true
+	}
+	return res, false
+}
+
+func searchChatHistory(ctx context.Context, g *globals.Context, uid gregor1.UID, query string,
+	maxHits int) (res chat1.ChatHistorySearchRes, err error) {
+	if len(strings.TrimSpace(query)) == 0 {
+		return res, errors.New("search query required")
+	}
+	defer g.ExternalG().TimeTelemetry("chat.history_search")()
+	if maxHits <= 0 {
+		maxHits = defaultArchiveSearchMaxHits
+	}
+
+	username := g.GetEnv().GetUsernameForUID(keybase1.UID(uid.String())).String()
+	liveQuery, opts := search.UpgradeSearchOptsFromQuery(query, chat1.SearchOpts{
+		MaxHits: maxHits,
+	}, username)
+	var live []chat1.ChatHistorySearchHit
+	if len(liveQuery) > 0 {
+		inboxRes, err := g.Indexer.Search(ctx, liveQuery, query, opts, nil, nil)
+		if err != nil {
+			return res, err
+		}
+		if inboxRes != nil {
+			res.PercentIndexed = inboxRes.PercentIndexed
+		}
+		live = liveHistorySearchHits(inboxRes, func(hit chat1.ChatSearchInboxHit) string {
+			conv, err := utils.GetVerifiedConv(ctx, g, uid, hit.ConvID, types.InboxSourceDataSourceLocalOnly)
+			if err != nil {
+				return hit.ConvName
+			}
+			return chatrender.ConvName(g.GlobalContext, conv, username)
+		})
+	}
+
+	archiveRes, err := g.ArchiveRegistry.Search(ctx, query, maxHits)
+	if err != nil {
+		return res, err
+	}
+	res.UnreadableJobs = archiveRes.UnreadableJobs
+	res.Hits, res.Truncated = mergeChatHistorySearchHits(live, archiveHistorySearchHits(archiveRes.Hits), maxHits)
+	res.Truncated = res.Truncated || archiveRes.Truncated
+	return res, nil
+}
@@ -0,0 +1,63 @@
+package chat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseArchiveAuthorAndTime(t *testing.T) {
+	at := time.Date(2019, 3, 4, 5, 6, 7, 0, time.Local)
+	for _, authorAndTime := range []string{
+		"alice 2019-03-04 05:06:07",
+		"alice(!) 2019-03-04 05:06:07",
+		"alice <work laptop> 2019-03-04 05:06:07",
+	} {
+		sender, ctime := parseArchiveAuthorAndTime(authorAndTime)
+		require.Equal(t, "alice", sender, authorAndTime)
+		require.Equal(t, gregor1.ToTime(at), ctime, authorAndTime)
+	}
+	sender, ctime := parseArchiveAuthorAndTime("???")
+	require.Equal(t, "???", sender)
+	require.Zero(t, ctime)
+}
+
+func TestMergeChatHistorySearchHits(t *testing.T) {
+	convID := chat1.ConversationID("c")
+	hit := func(source chat1.ChatHistorySearchSource, convName string, msgID chat1.MessageID,
+		ctime gregor1.Time) chat1.ChatHistorySearchHit {
+		res := chat1.ChatHistorySearchHit{
+			Source:   source,
+			ConvName: convName,
+			MsgID:    msgID,
+			Ctime:    ctime,
+		}
+		if source == chat1.ChatHistorySearchSource_LIVE {
+			res.ConvID = &convID
+		} else {
+			res.JobID = "arc-1"
+		}
+		return res
+	}
+	live := []chat1.ChatHistorySearchHit{
+		hit(chat1.ChatHistorySearchSource_LIVE, "alice,bob", 30, 300),
+		hit(chat1.ChatHistorySearchSource_LIVE, "alice,bob", 20, 200),
+	}
+	archived := []chat1.ChatHistorySearchHit{
+		hit(chat1.ChatHistorySearchSource_ARCHIVE, "alice,bob", 20, 200),
+		hit(chat1.ChatHistorySearchSource_ARCHIVE, "alice,bob", 5, 50),
+		hit(chat1.ChatHistorySearchSource_ARCHIVE, "alice,bob", 5, 50),
+		hit(chat1.ChatHistorySearchSource_ARCHIVE, "team [#general]", 20, 250),
+	}
+
+	res, truncated := mergeChatHistorySearchHits(live, archived, 10)
+	require.False(t, truncated)
+	require.Equal(t, []chat1.ChatHistorySearchHit{live[0], archived[3], live[1], archived[1]}, res)
+
+	res, truncated = mergeChatHistorySearchHits(live, archived, 2)
+	require.True(t, truncated)
+	require.Equal(t, []chat1.ChatHistorySearchHit{live[0], archived[3]}, res)
+}
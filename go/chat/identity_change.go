@@ -0,0 +1,276 @@
+package chat
+
+import (
+	"context"
+	"sort"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/chat/types"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// Identity change alerts are noticed by each device as it loads a thread:
+// the other participants' eldest seqnos and active devices are compared
+// against what they were the last time this device loaded the thread, and
+// a reset or a new device gets an alert after the latest message at the
+// time. The alerts are shown in the thread like journey cards until the
+// user acknowledges them. The first load of a thread only records what's
+// there, so there's nothing to alert about.
+
+const (
+	// identityChangeMaxParticipants is the most participants a conversation
+	// can have and still get alerts. In a big team they would be noise, and
+	// loading everyone on each thread load would be slow.
+	identityChangeMaxParticipants = 10
+	// identityChangeMaxAlerts is the most unacknowledged alerts kept per
+	// conversation; the oldest go first.
+	identityChangeMaxAlerts = 20
+	// identityChangeFirstOrdinal is the ordinal of the first alert after a
+	// message, after the journey card that could be there.
+	identityChangeFirstOrdinal = 2
+)
+
+// identityChangeCheckReasons are the thread loads that check for new
+// identity changes, which loads each participant. Other loads only show the
+// alerts we already have.
+var identityChangeCheckReasons = map[chat1.GetThreadReason]bool{
+	chat1.GetThreadReason_GENERAL:    true,
+	chat1.GetThreadReason_FOREGROUND: true,
+}
+
+type identityChangeMember struct {
+	EldestSeqno keybase1.Seqno `codec:"e"`
+	// Devices maps the IDs of the member's active devices to their names.
+	Devices map[keybase1.DeviceID]string `codec:"d"`
+}
+
+type identityChangeConvState struct {
+	Members map[keybase1.UID]identityChangeMember `codec:"m"`
+	Alerts  []chat1.IdentityChangeAlert           `codec:"a"`
+}
+
+func identityChangeDbKey(uid gregor1.UID, convID chat1.ConversationID) libkb.DbKey {
+	return libkb.DbKey{
+		Typ: libkb.DBChatIdentityChanges,
+		Key: uid.String() + "|" + convID.String(),
+	}
+}
+
+func identityChangeMemberFromUPAK(upak keybase1.UserPlusKeysV2) identityChangeMember {
+	member := identityChangeMember{
+		EldestSeqno: upak.EldestSeqno,
+		Devices:     make(map[keybase1.DeviceID]string),
+	}
+	for _, key := range upak.DeviceKeys {
+		if !key.Base.IsSibkey || key.Base.Revocation != nil || len(key.DeviceID) == 0 {
+			continue
+		}
+		member.Devices[key.DeviceID] = key.DeviceDescription
+	}
+	return member
+}
+
+// identityChanges returns the alerts for username going from prev to cur.
+// A reset is one alert, whatever happened to the devices along with it.
+func identityChanges(username string, prev, cur identityChangeMember) (res []chat1.IdentityChangeAlert) {
+	if prev.EldestSeqno != cur.EldestSeqno {
+		return []chat1.IdentityChangeAlert{{
+			Username:   username,
+			ChangeType: chat1.IdentityChangeType_RESET,
+		}}
+	}
+	for deviceID, name := range cur.Devices {
+		if _, ok := prev.Devices[deviceID]; ok {
+			continue
+		}
+		res = append(res, chat1.IdentityChangeAlert{
+			Username:   username,
+			ChangeType: chat1.IdentityChangeType_NEW_DEVICE,
+			DeviceName: name,
+		})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].DeviceName < res[j].DeviceName })
+	return res
+}
+
+func loadIdentityChangeStateLocked(g *globals.Context, uid gregor1.UID,
+	convID chat1.ConversationID) (state identityChangeConvState, err error) {
+	_, err = g.LocalChatDb.GetInto(&state, identityChangeDbKey(uid, convID))
+	if err != nil {
+		return state, err
+	}
+	if state.Members == nil {
+		state.Members = make(map[keybase1.UID]identityChangeMember)
+	}
+	return state, nil
+}
+
+// loadIdentityChangeMembers loads the other participants of convID, unless
+// there are too many of them. Ones that fail to load are left out.
+func loadIdentityChangeMembers(ctx context.Context, g *globals.Context, uid gregor1.UID,
+	convID chat1.ConversationID) (members map[keybase1.UID]identityChangeMember,
+	usernames map[keybase1.UID]string, err error) {
+	uids, err := g.ParticipantsSource.Get(ctx, uid, convID, types.InboxSourceDataSourceLocalOnly)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(uids) > identityChangeMaxParticipants+1 {
+		return nil, nil, nil
+	}
+	members = make(map[keybase1.UID]identityChangeMember)
+	usernames = make(map[keybase1.UID]string)
+	for _, participant := range uids {
+		if participant.Eq(uid) {
+			continue
+		}
+		kuid := keybase1.UID(participant.String())
+		// The UPAK loader hears about changes to users, so what it has
+		// cached is good enough and saves going to the server every load.
+		upak, _, err := g.GetUPAKLoader().LoadV2(
+			libkb.NewLoadUserByUIDArg(ctx, g.ExternalG(), kuid).WithStaleOK(true))
+		if err != nil {
+			g.Log.CDebugf(ctx, "loadIdentityChangeMembers: failed to load %s: %v", kuid, err)
+			continue
+		}
+		members[kuid] = identityChangeMemberFromUPAK(upak.Current)
+		usernames[kuid] = upak.Current.Username
+	}
+	return members, usernames, nil
+}
+
+// checkIdentityChanges compares the other participants of convID against
+// what this device saw of them last time, raises a notification for each
+// change, and returns the conversation's unacknowledged alerts. latestID is
+// the latest message in the conversation, which new alerts go after.
+func checkIdentityChanges(ctx context.Context, g *globals.Context, uid gregor1.UID,
+	convID chat1.ConversationID, latestID chat1.MessageID) ([]chat1.IdentityChangeAlert, error) {
+	// Load everyone before taking the lock, since it can mean the network.
+	members, usernames, err := loadIdentityChangeMembers(ctx, g, uid, convID)
+	if err != nil {
+		// Just show what we already know about.
+		g.Log.CDebugf(ctx, "checkIdentityChanges: failed to load participants: %v", err)
+	}
+
+	g.IdentityChangeLock.Lock()
+	defer g.IdentityChangeLock.Unlock()
+	state, err := loadIdentityChangeStateLocked(g, uid, convID)
+	if err != nil {
+		return nil, err
+	}
+	kuids := make([]keybase1.UID, 0, len(members))
+	for kuid := range members {
+		kuids = append(kuids, kuid)
+	}
+	sort.Slice(kuids, func(i, j int) bool { return usernames[kuids[i]] < usernames[kuids[j]] })
+	var alerts []chat1.IdentityChangeAlert
+	changed := false
+	for _, kuid := range kuids {
+		cur := members[kuid]
+		prev, ok := state.Members[kuid]
+		if ok && identityChangeMembersEqual(prev, cur) {
+			continue
+		}
+		state.Members[kuid] = cur
+		changed = true
+		if !ok {
+			continue
+		}
+		for _, alert := range identityChanges(usernames[kuid], prev, cur) {
+			alert.Ctime = gregor1.ToTime(g.GetClock().Now())
+			alert.PrevID = latestID
+			alerts = append(alerts, alert)
+		}
+	}
+	if !changed {
+		return state.Alerts, nil
+	}
+	state.Alerts = append(state.Alerts, alerts...)
+	if len(state.Alerts) > identityChangeMaxAlerts {
+		state.Alerts = state.Alerts[len(state.Alerts)-identityChangeMaxAlerts:]
+	}
+	if err := g.LocalChatDb.PutObj(identityChangeDbKey(uid, convID), nil, state); err != nil {
+		return nil, err
+	}
+	for _, alert := range alerts {
+		g.NotifyRouter.HandleChatIdentityChangeAlert(ctx, keybase1.UID(uid.String()), convID, alert)
+	}
+	return state.Alerts, nil
+}
+
+// getIdentityChanges returns the unacknowledged alerts of convID without
+// checking for new ones.
+func getIdentityChanges(g *globals.Context, uid gregor1.UID,
+	convID chat1.ConversationID) ([]chat1.IdentityChangeAlert, error) {
+	g.IdentityChangeLock.Lock()
+	defer g.IdentityChangeLock.Unlock()
+	state, err := loadIdentityChangeStateLocked(g, uid, convID)
+	if err != nil {
+		return nil, err
+	}
+	return state.Alerts, nil
+}
+
+func identityChangeMembersEqual(a, b identityChangeMember) bool {
+	if a.EldestSeqno != b.EldestSeqno || len(a.Devices) != len(b.Devices) {
+		return false
+	}
+	for deviceID, name := range a.Devices {
+		if otherName, ok := b.Devices[deviceID]; !ok || otherName != name {
+			return false
+		}
+	}
+	return true
+}
+
+// addIdentityChangeCards slots a card for each alert in to the left of its
+// prev and any cards already there, like a journey card. Alerts whose prev
+// isn't in the thread are left out, so they don't show up at the edge of a
+// far away page.
+func addIdentityChangeCards(thread *chat1.ThreadView, alerts []chat1.IdentityChangeAlert) {
+	ordinals := make(map[chat1.MessageID]int)
+	for _, alert := range alerts {
+		addLeftOf := -1
+		for i, msg := range thread.Messages {
+			if msg.GetMessageID() == alert.PrevID && !msg.IsJourneycard() {
+				addLeftOf = i
+				break
+			}
+		}
+		if addLeftOf < 0 {
+			continue
+		}
+		for addLeftOf > 0 && thread.Messages[addLeftOf-1].IsJourneycard() &&
+			thread.Messages[addLeftOf-1].Journeycard().PrevID == alert.PrevID {
+			addLeftOf--
+		}
+		alert := alert
+		card := chat1.MessageUnboxedJourneycard{
+			PrevID:         alert.PrevID,
+			Ordinal:        identityChangeFirstOrdinal + ordinals[alert.PrevID],
+			CardType:       chat1.JourneycardType_IDENTITY_CHANGE,
+			IdentityChange: &alert,
+		}
+		ordinals[alert.PrevID]++
+		thread.Messages = append(thread.Messages, chat1.MessageUnboxed{})
+		copy(thread.Messages[addLeftOf+1:], thread.Messages[addLeftOf:])
+		thread.Messages[addLeftOf] = chat1.NewMessageUnboxedWithJourneycard(card)
+	}
+}
+
+func acknowledgeIdentityChanges(ctx context.Context, g *globals.Context, uid gregor1.UID,
+	convID chat1.ConversationID) error {
+	g.IdentityChangeLock.Lock()
+	defer g.IdentityChangeLock.Unlock()
+	state, err := loadIdentityChangeStateLocked(g, uid, convID)
+	if err != nil {
+		return err
+	}
+	if len(state.Alerts) == 0 {
+		return nil
+	}
+	state.Alerts = nil
+	return g.LocalChatDb.PutObj(identityChangeDbKey(uid, convID), nil, state)
+}
@@ -0,0 +1,105 @@
+package chat
+
+import (
+	"testing"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdentityChanges(t *testing.T) {
+	upak := keybase1.UserPlusKeysV2{
+		EldestSeqno: 1,
+		DeviceKeys: map[keybase1.KID]keybase1.PublicKeyV2NaCl{
+			"k1": {
+				Base:              keybase1.PublicKeyV2Base{IsSibkey: true},
+				DeviceID:          "d1",
+				DeviceDescription: "laptop",
+			},
+			"k2": {
+				Base:              keybase1.PublicKeyV2Base{IsSibkey: false},
+				DeviceID:          "d1",
+				DeviceDescription: "laptop",
+			},
+			"k3": {
+				Base: keybase1.PublicKeyV2Base{
+					IsSibkey:   true,
+					Revocation: &keybase1.SignatureMetadata{},
+				},
+				DeviceID:          "d3",
+				DeviceDescription: "old phone",
+			},
+		},
+	}
+	prev := identityChangeMemberFromUPAK(upak)
+	require.Equal(t, map[keybase1.DeviceID]string{"d1": "laptop"}, prev.Devices)
+	require.Empty(t, identityChanges("alice", prev, prev))
+	require.True(t, identityChangeMembersEqual(prev, prev))
+
+	upak.DeviceKeys["k4"] = keybase1.PublicKeyV2NaCl{
+		Base:              keybase1.PublicKeyV2Base{IsSibkey: true},
+		DeviceID:          "d4",
+		DeviceDescription: "phone",
+	}
+	cur := identityChangeMemberFromUPAK(upak)
+	require.False(t, identityChangeMembersEqual(prev, cur))
+	require.Equal(t, []chat1.IdentityChangeAlert{{
+		Username:   "alice",
+		ChangeType: chat1.IdentityChangeType_NEW_DEVICE,
+		DeviceName: "phone",
+	}}, identityChanges("alice", prev, cur))
+
+	// A reset is one alert, new devices and all.
+	upak.EldestSeqno = 5
+	cur = identityChangeMemberFromUPAK(upak)
+	require.Equal(t, []chat1.IdentityChangeAlert{{
+		Username:   "alice",
+		ChangeType: chat1.IdentityChangeType_RESET,
+	}}, identityChanges("alice", prev, cur))
+}
+
+func TestAddIdentityChangeCards(t *testing.T) {
+	placeholder := func(msgID chat1.MessageID) chat1.MessageUnboxed {
+		return chat1.NewMessageUnboxedWithPlaceholder(chat1.MessageUnboxedPlaceholder{MessageID: msgID})
+	}
+	thread := chat1.ThreadView{Messages: []chat1.MessageUnboxed{
+		placeholder(3),
+		chat1.NewMessageUnboxedWithJourneycard(chat1.MessageUnboxedJourneycard{PrevID: 2, Ordinal: 1}),
+		placeholder(2),
+		placeholder(1),
+	}}
+	alerts := []chat1.IdentityChangeAlert{
+		{Username: "alice", ChangeType: chat1.IdentityChangeType_RESET, PrevID: 2},
+		{Username: "bob", ChangeType: chat1.IdentityChangeType_NEW_DEVICE, PrevID: 2},
+		{Username: "carol", ChangeType: chat1.IdentityChangeType_RESET, PrevID: 3},
+		// Its prev is on another page.
+		{Username: "dave", ChangeType: chat1.IdentityChangeType_RESET, PrevID: 10},
+	}
+	addIdentityChangeCards(&thread, alerts)
+
+	type card struct {
+		username string
+		ordinal  int
+	}
+	var res []interface{}
+	for _, msg := range thread.Messages {
+		if !msg.IsJourneycard() || msg.Journeycard().IdentityChange == nil {
+			res = append(res, msg.GetMessageID())
+			continue
+		}
+		jc := msg.Journeycard()
+		require.Equal(t, chat1.JourneycardType_IDENTITY_CHANGE, jc.CardType)
+		res = append(res, card{jc.IdentityChange.Username, jc.Ordinal})
+	}
+	// Newest first, with later alerts after the same message newer.
+	require.Equal(t, []interface{}{
+		card{"carol", 2},
+		chat1.MessageID(3),
+		card{"bob", 3},
+		card{"alice", 2},
+		chat1.MessageID(2), // the journey card
+		chat1.MessageID(2),
+		chat1.MessageID(1),
+	}, res)
+}
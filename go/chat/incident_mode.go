@@ -0,0 +1,213 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/chat/types"
+	"github.com/keybase/client/go/chat/utils"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/keybase/client/go/teams"
+)
+
+// Incident mode lets team admins make a channel notify everyone in it, even
+// members who have muted it, while an incident is going on. It's always set
+// for a bounded time and lapses by itself when that's up, so a forgotten
+// incident doesn't leave members unable to mute the channel. The settings
+// and an audit log of every change are team policies. Expired channels are
+// only dropped from the settings (and logged as expired) the next time an
+// admin changes them; until then they're ignored because of their expiry
+// time.
+
+const incidentModeName = "__incident_mode"
+
+const incidentModeAuditLogName = "__incident_mode_audit_log"
+
+// The longest incident mode can be turned on for at once.
+const maxIncidentModeDuration = 24 * time.Hour
+
+// How long the push handler waits on loading incident mode settings in the
+// background.
+const incidentModeRefreshTimeout = time.Minute
+
+var incidentMode = teamPolicy[chat1.IncidentModeSettings]{
+	name:      incidentModeName,
+	cacheTime: time.Minute,
+}
+
+var incidentModeAuditLog = teamAuditLog[chat1.IncidentModeAuditLog, chat1.IncidentModeAuditEntry]{
+	teamPolicy: teamPolicy[chat1.IncidentModeAuditLog]{name: incidentModeAuditLogName},
+	entries: func(log *chat1.IncidentModeAuditLog) *[]chat1.IncidentModeAuditEntry {
+		return &log.Entries
+	},
+}
+
+func incidentModeExpired(channel chat1.IncidentModeChannel, now time.Time) bool {
+	return !now.Before(channel.ExpiresAt.Time())
+}
+
+// activeIncidentModeChannels returns settings without any expired channels.
+func activeIncidentModeChannels(settings chat1.IncidentModeSettings, now time.Time) (res chat1.IncidentModeSettings) {
+	for _, channel := range settings.Channels {
+		if !incidentModeExpired(channel, now) {
+			res.Channels = append(res.Channels, channel)
+		}
+	}
+	return res
+}
+
+func incidentModeActive(settings chat1.IncidentModeSettings, convID chat1.ConversationID, now time.Time) bool {
+	for _, channel := range settings.Channels {
+		if channel.ConvID.Eq(convID) && !incidentModeExpired(channel, now) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkIncidentModeDuration(duration time.Duration) error {
+	if duration < 0 {
+		return errors.New("incident mode duration cannot be negative")
+	}
+	if duration > maxIncidentModeDuration {
+		return fmt.Errorf("incident mode can be turned on for at most %v at a time", maxIncidentModeDuration)
+	}
+	return nil
+}
+
+// applyIncidentMode turns incident mode on in convID until now+duration, or
+// off if duration is 0, dropping any channels that have expired. It returns
+// the new settings and the audit entries for the change, newest first, or no
+// entries if nothing changed.
+func applyIncidentMode(settings chat1.IncidentModeSettings, convID chat1.ConversationID, channel,
+	admin string, duration time.Duration, now time.Time) (res chat1.IncidentModeSettings,
+	entries []chat1.IncidentModeAuditEntry) {
+	var expired []chat1.IncidentModeAuditEntry
+	wasActive := false
+	for _, c := range settings.Channels {
+		switch {
+		case incidentModeExpired(c, now):
+			expired = append([]chat1.IncidentModeAuditEntry{{
+				Ctime:     c.ExpiresAt,
+				Admin:     c.SetBy,
+				ConvID:    c.ConvID,
+				Channel:   c.Channel,
+				Action:    chat1.IncidentModeAction_EXPIRED,
+				ExpiresAt: c.ExpiresAt,
+			}}, expired...)
+		case c.ConvID.Eq(convID):
+			wasActive = true
+		default:
+			res.Channels = append(res.Channels, c)
+		}
+	}
+	ctime := gregor1.ToTime(now)
+	if duration > 0 {
+		expiresAt := gregor1.ToTime(now.Add(duration))
+		res.Channels = append(res.Channels, chat1.IncidentModeChannel{
+			ConvID:    convID,
+			Channel:   channel,
+			SetBy:     admin,
+			Ctime:     ctime,
+			ExpiresAt: expiresAt,
+		})
+		entries = append(entries, chat1.IncidentModeAuditEntry{
+			Ctime:     ctime,
+			Admin:     admin,
+			ConvID:    convID,
+			Channel:   channel,
+			Action:    chat1.IncidentModeAction_ENABLED,
+			ExpiresAt: expiresAt,
+		})
+	} else if wasActive {
+		entries = append(entries, chat1.IncidentModeAuditEntry{
+			Ctime:   ctime,
+			Admin:   admin,
+			ConvID:  convID,
+			Channel: channel,
+			Action:  chat1.IncidentModeAction_DISABLED,
+		})
+	}
+	return res, append(entries, expired...)
+}
+
+func getIncidentMode(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, teamID keybase1.TeamID) (res chat1.IncidentModeSettings, err error) {
+	now := g.Clock().Now()
+	settings, err := incidentMode.refresh(ctx, g, ri, uid, teamID, now)
+	if err != nil {
+		return res, err
+	}
+	return activeIncidentModeChannels(settings, now), nil
+}
+
+func setIncidentMode(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, convID chat1.ConversationID, duration time.Duration) error {
+	if err := checkIncidentModeDuration(duration); err != nil {
+		return err
+	}
+	conv, err := utils.GetVerifiedConv(ctx, g, uid, convID, types.InboxSourceDataSourceAll)
+	if err != nil {
+		return err
+	}
+	if conv.GetMembersType() != chat1.ConversationMembersType_TEAM ||
+		conv.GetTopicType() != chat1.TopicType_CHAT {
+		return errors.New("incident mode only works in team channels")
+	}
+	op, err := teams.CanUserPerform(ctx, g.ExternalG(), conv.Info.TlfName)
+	if err != nil {
+		return err
+	}
+	if !op.SetMinWriterRole {
+		return errors.New("only team admins can change incident mode")
+	}
+	teamID, err := TLFIDToTeamID(conv.Info.Triple.Tlfid)
+	if err != nil {
+		return err
+	}
+	settings, err := incidentMode.load(ctx, g, ri, uid, teamID)
+	if err != nil {
+		return err
+	}
+	settings, entries := applyIncidentMode(settings, convID, conv.GetTopicName(),
+		g.Env.GetUsername().String(), duration, g.Clock().Now())
+	if len(entries) == 0 {
+		return nil
+	}
+	if err := incidentMode.store(ctx, g, ri, uid, teamID, settings); err != nil {
+		return err
+	}
+	if err := incidentModeAuditLog.add(ctx, g, ri, uid, teamID, entries...); err != nil {
+		g.GetLog().CDebugf(ctx, "setIncidentMode: unable to record audit entries: %v", err)
+	}
+	return nil
+}
+
+func getIncidentModeAuditLog(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, teamID keybase1.TeamID) (chat1.IncidentModeAuditLog, error) {
+	return incidentModeAuditLog.load(ctx, g, ri, uid, teamID)
+}
+
+// inIncidentMode says whether conv is a team channel in incident mode. It's
+// only called for muted channels while handling pushes, so it goes by the
+// cached settings rather than wait on dev storage; refresh is true if they
+// should be loaded again for next time.
+func inIncidentMode(g *globals.Context, conv chat1.ConversationLocal) (active bool,
+	teamID keybase1.TeamID, refresh bool) {
+	if conv.GetMembersType() != chat1.ConversationMembersType_TEAM ||
+		conv.GetTopicType() != chat1.TopicType_CHAT {
+		return false, teamID, false
+	}
+	teamID, err := TLFIDToTeamID(conv.Info.Triple.Tlfid)
+	if err != nil {
+		return false, teamID, false
+	}
+	now := g.Clock().Now()
+	settings, fresh := incidentMode.cached(g, teamID, now)
+	return incidentModeActive(settings, conv.GetConvID(), now), teamID, !fresh
+}
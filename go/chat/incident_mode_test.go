@@ -0,0 +1,60 @@
+package chat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyIncidentMode(t *testing.T) {
+	oncall := chat1.ConversationID([]byte{1})
+	ops := chat1.ConversationID([]byte{2})
+	start := time.Unix(1700000000, 0)
+
+	require.NoError(t, checkIncidentModeDuration(2*time.Hour))
+	require.Error(t, checkIncidentModeDuration(-time.Hour))
+	require.Error(t, checkIncidentModeDuration(maxIncidentModeDuration+time.Minute))
+
+	// Turn it on in two channels.
+	settings, entries := applyIncidentMode(chat1.IncidentModeSettings{}, oncall, "oncall", "alice",
+		time.Hour, start)
+	require.Len(t, entries, 1)
+	require.Equal(t, chat1.IncidentModeAction_ENABLED, entries[0].Action)
+	require.Equal(t, start.Add(time.Hour), entries[0].ExpiresAt.Time())
+	settings, _ = applyIncidentMode(settings, ops, "ops", "bob", 3*time.Hour, start)
+	require.Len(t, settings.Channels, 2)
+	require.True(t, incidentModeActive(settings, oncall, start.Add(30*time.Minute)))
+	require.True(t, incidentModeActive(settings, ops, start.Add(30*time.Minute)))
+
+	// The first one expires by itself.
+	later := start.Add(2 * time.Hour)
+	require.False(t, incidentModeActive(settings, oncall, later))
+	require.True(t, incidentModeActive(settings, ops, later))
+	require.Len(t, activeIncidentModeChannels(settings, later).Channels, 1)
+
+	// Turning off a channel that isn't on changes nothing, except to log
+	// and drop the expired one.
+	settings, entries = applyIncidentMode(settings, oncall, "oncall", "bob", 0, later)
+	require.Len(t, settings.Channels, 1)
+	require.Len(t, entries, 1)
+	require.Equal(t, chat1.IncidentModeAction_EXPIRED, entries[0].Action)
+	require.Equal(t, "alice", entries[0].Admin)
+	require.Equal(t, start.Add(time.Hour), entries[0].Ctime.Time())
+	settings, entries = applyIncidentMode(settings, oncall, "oncall", "bob", 0, later)
+	require.Len(t, settings.Channels, 1)
+	require.Empty(t, entries)
+
+	// Turning it on again extends it.
+	settings, _ = applyIncidentMode(settings, ops, "ops", "bob", 4*time.Hour, later)
+	require.Len(t, settings.Channels, 1)
+	require.True(t, incidentModeActive(settings, ops, start.Add(5*time.Hour)))
+
+	// And off.
+	settings, entries = applyIncidentMode(settings, ops, "ops", "alice", 0, later)
+	require.Empty(t, settings.Channels)
+	require.Len(t, entries, 1)
+	require.Equal(t, chat1.IncidentModeAction_DISABLED, entries[0].Action)
+	require.False(t, incidentModeActive(settings, ops, later))
+}
@@ -0,0 +1,210 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/keybase/client/go/teams"
+)
+
+// Membership hooks send a bot an event for every join, leave and addition in
+// a team, so it can keep other systems in step with the team. The hook is a
+// team policy. There's no server side to it: each admin device sends the
+// events for the messages it gets pushed, so an event is sent once per admin
+// device in the channel, and joins and leaves in channels no admin is in
+// aren't sent at all.
+
+const membershipHookName = "__membership_hook"
+
+var membershipHook = teamPolicy[chat1.MembershipHook]{
+	name:      membershipHookName,
+	cacheTime: 5 * time.Minute,
+}
+
+// How long sending the events for one message can take.
+const membershipEventsTimeout = time.Minute
+
+// The most messages the push handler sends events for at once. Events for
+// messages past that are dropped.
+const maxMembershipEventSends = 10
+
+// membershipEventPrefix starts the messages with events in them, so bots can
+// tell them from anything else they're sent.
+const membershipEventPrefix = "!membership-event "
+
+// normalizeMembershipHook returns hook with its channel names as topic
+// names, or an error if it doesn't make sense.
+func normalizeMembershipHook(hook chat1.MembershipHook) (res chat1.MembershipHook, err error) {
+	res.BotUsername = strings.TrimSpace(hook.BotUsername)
+	if len(res.BotUsername) == 0 {
+		if len(hook.Channels) > 0 {
+			return res, errors.New("a membership hook needs a bot to send events to")
+		}
+		return res, nil
+	}
+	for _, channel := range hook.Channels {
+		channel = strings.TrimPrefix(strings.TrimSpace(channel), "#")
+		if len(channel) == 0 {
+			return res, errors.New("channel names can't be empty")
+		}
+		res.Channels = append(res.Channels, channel)
+	}
+	return res, nil
+}
+
+func getMembershipHook(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, teamID keybase1.TeamID) (chat1.MembershipHook, error) {
+	return membershipHook.refresh(ctx, g, ri, uid, teamID, g.Clock().Now())
+}
+
+func setMembershipHook(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, teamID keybase1.TeamID, hook chat1.MembershipHook) error {
+	hook, err := normalizeMembershipHook(hook)
+	if err != nil {
+		return err
+	}
+	if len(hook.BotUsername) > 0 {
+		if _, err := g.GetUPAKLoader().LookupUID(ctx,
+			libkb.NewNormalizedUsername(hook.BotUsername)); err != nil {
+			return err
+		}
+	}
+	return membershipHook.store(ctx, g, ri, uid, teamID, hook)
+}
+
+func newMembershipEvent(conv chat1.ConversationLocal, msg chat1.MessageUnboxedValid,
+	typ chat1.MembershipEventType, username string) chat1.MembershipEvent {
+	return chat1.MembershipEvent{
+		// Each event is about one user, and a message is about each user
+		// at most once.
+		Id: fmt.Sprintf("%s:%d:%s", conv.GetConvID().DbShortFormString(),
+			msg.ServerHeader.MessageID, username),
+		Type:     typ,
+		Team:     conv.Info.TlfName,
+		Channel:  conv.GetTopicName(),
+		Username: username,
+		Ctime:    msg.ServerHeader.Ctime,
+	}
+}
+
+// membershipEventsFromMessage returns the events for msg, a message in
+// conv, that hook sends.
+func membershipEventsFromMessage(hook chat1.MembershipHook, conv chat1.ConversationLocal,
+	msg chat1.MessageUnboxed) (events []chat1.MembershipEvent) {
+	if len(hook.BotUsername) == 0 || !msg.IsValid() {
+		return nil
+	}
+	valid := msg.Valid()
+	inChannels := len(hook.Channels) == 0
+	for _, channel := range hook.Channels {
+		if channel == conv.GetTopicName() {
+			inChannels = true
+		}
+	}
+	switch msg.GetMessageType() {
+	case chat1.MessageType_JOIN:
+		if inChannels {
+			events = append(events, newMembershipEvent(conv, valid,
+				chat1.MembershipEventType_JOINED, valid.SenderUsername))
+		}
+	case chat1.MessageType_LEAVE:
+		if inChannels {
+			events = append(events, newMembershipEvent(conv, valid,
+				chat1.MembershipEventType_LEFT, valid.SenderUsername))
+		}
+	case chat1.MessageType_SYSTEM:
+		body := valid.MessageBody.System()
+		typ, err := body.SystemType()
+		if err != nil || typ != chat1.MessageSystemType_ADDEDTOTEAM {
+			return nil
+		}
+		added := body.Addedtoteam()
+		addees := added.BulkAdds
+		if len(added.Addee) > 0 {
+			addees = append([]string{added.Addee}, addees...)
+		}
+		for _, addee := range addees {
+			event := newMembershipEvent(conv, valid, chat1.MembershipEventType_ADDED_TO_TEAM, addee)
+			event.Actor = added.Adder
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+func formatMembershipEvent(event chat1.MembershipEvent) (string, error) {
+	buf, err := json.Marshal(event)
+	if err != nil {
+		return "", err
+	}
+	return membershipEventPrefix + string(buf), nil
+}
+
+// mayHaveMembershipEvents says whether msg, a message in conv, is one a
+// membership hook could send events for.
+func mayHaveMembershipEvents(conv *chat1.ConversationLocal, msg chat1.MessageUnboxed) bool {
+	if conv == nil || conv.GetMembersType() != chat1.ConversationMembersType_TEAM ||
+		conv.GetTopicType() != chat1.TopicType_CHAT {
+		return false
+	}
+	switch msg.GetMessageType() {
+	case chat1.MessageType_JOIN, chat1.MessageType_LEAVE, chat1.MessageType_SYSTEM:
+		return true
+	default:
+		return false
+	}
+}
+
+// sendMembershipEventsFromMessage sends the team's bot the events for msg,
+// if the team has a membership hook and the current user is one of its
+// admins. The push handler runs it in the background for every new message
+// that may have events.
+func sendMembershipEventsFromMessage(ctx context.Context, g *globals.Context,
+	ri func() chat1.RemoteInterface, uid gregor1.UID, conv *chat1.ConversationLocal, msg chat1.MessageUnboxed) {
+	if ri == nil || !mayHaveMembershipEvents(conv, msg) {
+		return
+	}
+	teamID, err := TLFIDToTeamID(conv.Info.Triple.Tlfid)
+	if err != nil {
+		return
+	}
+	hook, err := membershipHook.get(ctx, g, ri, uid, teamID, g.Clock().Now())
+	if err != nil {
+		g.GetLog().CDebugf(ctx, "sendMembershipEventsFromMessage: unable to load hook: %v", err)
+		return
+	}
+	events := membershipEventsFromMessage(hook, *conv, msg)
+	if len(events) == 0 {
+		return
+	}
+	op, err := teams.CanUserPerform(ctx, g.ExternalG(), conv.Info.TlfName)
+	if err != nil {
+		g.GetLog().CDebugf(ctx, "sendMembershipEventsFromMessage: unable to check role: %v", err)
+		return
+	}
+	if !op.ManageMembers {
+		return
+	}
+	name := strings.Join([]string{g.Env.GetUsername().String(), hook.BotUsername}, ",")
+	for _, event := range events {
+		text, err := formatMembershipEvent(event)
+		if err != nil {
+			g.GetLog().CDebugf(ctx, "sendMembershipEventsFromMessage: %v", err)
+			return
+		}
+		if _, err := g.ChatHelper.SendTextByNameNonblock(ctx, name, nil,
+			chat1.ConversationMembersType_IMPTEAMNATIVE, keybase1.TLFIdentifyBehavior_CHAT_SKIP,
+			text, nil); err != nil {
+			g.GetLog().CDebugf(ctx, "sendMembershipEventsFromMessage: unable to send %s: %v", event.Id, err)
+		}
+	}
+}
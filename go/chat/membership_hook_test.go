@@ -0,0 +1,79 @@
+package chat
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMembershipHookEvents(t *testing.T) {
+	_, err := normalizeMembershipHook(chat1.MembershipHook{Channels: []string{"eng"}})
+	require.Error(t, err)
+	_, err = normalizeMembershipHook(chat1.MembershipHook{BotUsername: "acmebot", Channels: []string{" "}})
+	require.Error(t, err)
+	hook, err := normalizeMembershipHook(chat1.MembershipHook{
+		BotUsername: " acmebot ",
+		Channels:    []string{"#eng"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, chat1.MembershipHook{BotUsername: "acmebot", Channels: []string{"eng"}}, hook)
+
+	conv := func(channel string) chat1.ConversationLocal {
+		return chat1.ConversationLocal{
+			Info: chat1.ConversationInfoLocal{
+				Id:        chat1.ConversationID([]byte{1, 2, 3}),
+				TlfName:   "acme",
+				TopicName: channel,
+			},
+		}
+	}
+	msg := func(id chat1.MessageID, sender string, body chat1.MessageBody) chat1.MessageUnboxed {
+		typ, err := body.MessageType()
+		require.NoError(t, err)
+		return chat1.NewMessageUnboxedWithValid(chat1.MessageUnboxedValid{
+			ClientHeader:   chat1.MessageClientHeaderVerified{MessageType: typ},
+			ServerHeader:   chat1.MessageServerHeader{MessageID: id, Ctime: 1000},
+			SenderUsername: sender,
+			MessageBody:    body,
+		})
+	}
+	join := msg(10, "alice", chat1.NewMessageBodyWithJoin(chat1.MessageJoin{}))
+	leave := msg(11, "bob", chat1.NewMessageBodyWithLeave(chat1.MessageLeave{}))
+	added := msg(12, "carol", chat1.NewMessageBodyWithSystem(chat1.NewMessageSystemWithAddedtoteam(
+		chat1.MessageSystemAddedToTeam{Adder: "carol", Addee: "dave", BulkAdds: []string{"erin"}})))
+	text := msg(13, "alice", chat1.NewMessageBodyWithText(chat1.MessageText{Body: "hi"}))
+
+	events := membershipEventsFromMessage(hook, conv("eng"), join)
+	require.Len(t, events, 1)
+	require.Equal(t, chat1.MembershipEventType_JOINED, events[0].Type)
+	require.Equal(t, "alice", events[0].Username)
+	require.Equal(t, "acme", events[0].Team)
+	require.Equal(t, "eng", events[0].Channel)
+	events = membershipEventsFromMessage(hook, conv("eng"), leave)
+	require.Len(t, events, 1)
+	require.Equal(t, chat1.MembershipEventType_LEFT, events[0].Type)
+
+	// Joins and leaves in other channels aren't sent, but additions are.
+	require.Empty(t, membershipEventsFromMessage(hook, conv("random"), join))
+	events = membershipEventsFromMessage(hook, conv("general"), added)
+	require.Len(t, events, 2)
+	require.Equal(t, "dave", events[0].Username)
+	require.Equal(t, "erin", events[1].Username)
+	require.Equal(t, "carol", events[1].Actor)
+	require.NotEqual(t, events[0].Id, events[1].Id)
+
+	require.Empty(t, membershipEventsFromMessage(hook, conv("eng"), text))
+	require.Empty(t, membershipEventsFromMessage(chat1.MembershipHook{}, conv("eng"), join))
+	require.Len(t, membershipEventsFromMessage(chat1.MembershipHook{BotUsername: "acmebot"},
+		conv("random"), join), 1)
+
+	formatted, err := formatMembershipEvent(events[0])
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(formatted, membershipEventPrefix))
+	var parsed chat1.MembershipEvent
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(formatted, membershipEventPrefix)), &parsed))
+	require.Equal(t, events[0], parsed)
+}
@@ -0,0 +1,285 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/chat/types"
+	"github.com/keybase/client/go/chat/utils"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// Mention digests are built by each device from the messages it already has
+// cached for muted team channels, so a channel being muted doesn't cost any
+// extra fetching. The settings are stored in the user's dev conversation
+// like notification routing, and cached in the local chat db next to the
+// time of this device's last digest.
+
+const mentionDigestName = "__mention_digest"
+
+const (
+	// mentionDigestPeriod is how often a digest is sent.
+	mentionDigestPeriod = 24 * time.Hour
+	// mentionDigestPageSize is how many cached messages are looked at per
+	// conversation.
+	mentionDigestPageSize = 200
+	// mentionDigestMaxItems is how many mentions are kept per conversation;
+	// the rest are only counted.
+	mentionDigestMaxItems = 3
+	// mentionDigestSnippetLen is the most runes of a message in a digest.
+	mentionDigestSnippetLen = 100
+)
+
+func mentionDigestSettingsDbKey(uid gregor1.UID) libkb.DbKey {
+	return libkb.DbKey{
+		Typ: libkb.DBChatMentionDigest,
+		Key: "settings|" + uid.String(),
+	}
+}
+
+func mentionDigestLastDbKey(uid gregor1.UID) libkb.DbKey {
+	return libkb.DbKey{
+		Typ: libkb.DBChatMentionDigest,
+		Key: "last|" + uid.String(),
+	}
+}
+
+func putCachedMentionDigestSettings(g *globals.Context, uid gregor1.UID,
+	settings chat1.MentionDigestSettings) error {
+	return g.LocalChatDb.PutObj(mentionDigestSettingsDbKey(uid), nil, settings)
+}
+
+func getMentionDigestSettings(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID) (settings chat1.MentionDigestSettings, err error) {
+	found, err := NewDevConversationBackedStorage(g, ri).Get(ctx, uid, mentionDigestName, &settings)
+	if err != nil {
+		return settings, err
+	}
+	if !found {
+		settings = chat1.MentionDigestSettings{}
+	}
+	if err := putCachedMentionDigestSettings(g, uid, settings); err != nil {
+		g.Log.CDebugf(ctx, "getMentionDigestSettings: failed to cache: %v", err)
+	}
+	return settings, nil
+}
+
+func setMentionDigestSettings(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, settings chat1.MentionDigestSettings) error {
+	if err := NewDevConversationBackedStorage(g, ri).Put(ctx, uid, mentionDigestName, settings); err != nil {
+		return err
+	}
+	return putCachedMentionDigestSettings(g, uid, settings)
+}
+
+// updateMentionDigestSettingsFromMessage picks up settings changed on another
+// of our devices, when the message storing them comes in.
+func updateMentionDigestSettingsFromMessage(ctx context.Context, g *globals.Context, uid gregor1.UID,
+	conv *chat1.ConversationLocal, msg chat1.MessageUnboxed) {
+	if conv == nil || conv.GetTopicType() != chat1.TopicType_DEV ||
+		conv.GetMembersType() != chat1.ConversationMembersType_IMPTEAMNATIVE ||
+		conv.Info.TopicName != mentionDigestName || !msg.IsValid() ||
+		!msg.Valid().ClientHeader.Sender.Eq(uid) ||
+		!msg.Valid().MessageBody.IsType(chat1.MessageType_TEXT) {
+		return
+	}
+	var settings chat1.MentionDigestSettings
+	if err := json.Unmarshal([]byte(msg.Valid().MessageBody.Text().Body), &settings); err != nil {
+		g.Log.CDebugf(ctx, "updateMentionDigestSettingsFromMessage: failed to parse: %v", err)
+		return
+	}
+	if err := putCachedMentionDigestSettings(g, uid, settings); err != nil {
+		g.Log.CDebugf(ctx, "updateMentionDigestSettingsFromMessage: failed to cache: %v", err)
+	}
+}
+
+// loadMentionDigestSettings prefers the cached settings, so the scheduler
+// doesn't need the network unless it's never seen them.
+func loadMentionDigestSettings(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID) (settings chat1.MentionDigestSettings, err error) {
+	found, err := g.LocalChatDb.GetInto(&settings, mentionDigestSettingsDbKey(uid))
+	if err != nil {
+		g.Log.CDebugf(ctx, "loadMentionDigestSettings: failed to read cache: %v", err)
+	} else if found {
+		return settings, nil
+	}
+	return getMentionDigestSettings(ctx, g, ri, uid)
+}
+
+func mentionDigestTeamOptedOut(settings chat1.MentionDigestSettings, teamID keybase1.TeamID) bool {
+	for _, optedOut := range settings.OptedOutTeams {
+		if optedOut.Eq(teamID) {
+			return true
+		}
+	}
+	return false
+}
+
+// mentionDigestSnippet flattens a message body onto one line, and shortens it
+// to mentionDigestSnippetLen runes.
+func mentionDigestSnippet(body string) string {
+	body = strings.Join(strings.Fields(body), " ")
+	runes := []rune(body)
+	if len(runes) <= mentionDigestSnippetLen {
+		return body
+	}
+	return string(runes[:mentionDigestSnippetLen-1]) + "…"
+}
+
+func mentionsUser(msg chat1.MessageUnboxedValid, uid gregor1.UID) bool {
+	for _, mentioned := range msg.AtMentions {
+		if mentioned.Eq(uid) {
+			return true
+		}
+	}
+	return false
+}
+
+// collectMentionDigestItems finds the messages in msgs, newest first, that
+// were sent in [since, until) by someone else and @-mention uid. It returns
+// how many there are, and the newest mentionDigestMaxItems of them.
+func collectMentionDigestItems(msgs []chat1.MessageUnboxed, uid gregor1.UID,
+	since, until time.Time) (count int, items []chat1.MentionDigestItem) {
+	for _, msg := range msgs {
+		if !msg.IsValid() {
+			continue
+		}
+		valid := msg.Valid()
+		ctime := valid.ServerHeader.Ctime.Time()
+		if ctime.Before(since) {
+			break
+		}
+		if !ctime.Before(until) || valid.ClientHeader.Sender.Eq(uid) ||
+			!valid.MessageBody.IsType(chat1.MessageType_TEXT) || !mentionsUser(valid, uid) {
+			continue
+		}
+		count++
+		if len(items) < mentionDigestMaxItems {
+			items = append(items, chat1.MentionDigestItem{
+				MsgID:   valid.ServerHeader.MessageID,
+				Sender:  valid.SenderUsername,
+				Ctime:   valid.ServerHeader.Ctime,
+				Snippet: mentionDigestSnippet(valid.MessageBody.Text().Body),
+			})
+		}
+	}
+	return count, items
+}
+
+// buildMentionDigest looks through the cached messages of every muted team
+// channel that the settings don't opt out of.
+func buildMentionDigest(ctx context.Context, g *globals.Context, uid gregor1.UID,
+	settings chat1.MentionDigestSettings, since, until time.Time) (res chat1.MentionDigest, err error) {
+	res.Since = gregor1.ToTime(since)
+	res.Until = gregor1.ToTime(until)
+	ib, err := g.InboxSource.ReadUnverified(ctx, uid, types.InboxSourceDataSourceLocalOnly, nil)
+	if err != nil {
+		return res, err
+	}
+	for _, rc := range ib.ConvsUnverified {
+		if rc.Conv.Metadata.Status != chat1.ConversationStatus_MUTED ||
+			rc.GetMembersType() != chat1.ConversationMembersType_TEAM ||
+			rc.GetTopicType() != chat1.TopicType_CHAT {
+			continue
+		}
+		if rc.GetMtime().Time().Before(since) {
+			continue
+		}
+		teamID, err := TLFIDToTeamID(rc.Conv.Metadata.IdTriple.Tlfid)
+		if err != nil {
+			g.Log.CDebugf(ctx, "buildMentionDigest: bad team ID for %s: %v", rc.ConvIDStr, err)
+			continue
+		}
+		if mentionDigestTeamOptedOut(settings, teamID) {
+			continue
+		}
+		tv, err := g.ConvSource.PullLocalOnly(ctx, rc.GetConvID(), uid, chat1.GetThreadReason_GENERAL,
+			nil, &chat1.Pagination{Num: mentionDigestPageSize}, 0)
+		if err != nil {
+			g.Log.CDebugf(ctx, "buildMentionDigest: no cached messages for %s: %v", rc.ConvIDStr, err)
+			continue
+		}
+		count, items := collectMentionDigestItems(tv.Messages, uid, since, until)
+		if count == 0 {
+			continue
+		}
+		var tlfName string
+		if rc.LocalMetadata != nil {
+			tlfName = rc.LocalMetadata.Name
+		}
+		res.Convs = append(res.Convs, chat1.MentionDigestConv{
+			ConvID:       rc.GetConvID(),
+			TlfName:      tlfName,
+			Channel:      rc.GetTopicName(),
+			MentionCount: count,
+			Items:        items,
+		})
+	}
+	sort.Slice(res.Convs, func(i, j int) bool {
+		return res.Convs[i].Items[0].Ctime > res.Convs[j].Items[0].Ctime
+	})
+	return res, nil
+}
+
+// GetMentionDigest builds a digest of the last mentionDigestPeriod, without
+// sending it or touching the schedule.
+func GetMentionDigest(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID) (res chat1.MentionDigest, err error) {
+	settings, err := loadMentionDigestSettings(ctx, g, ri, uid)
+	if err != nil {
+		return res, err
+	}
+	now := g.Clock().Now()
+	return buildMentionDigest(ctx, g, uid, settings, now.Add(-mentionDigestPeriod), now)
+}
+
+// MentionDigestBackgroundRound sends a mention digest notification if it's
+// been mentionDigestPeriod since this device's last one. Digests without any
+// mentions aren't sent, but still count as the last one.
+func MentionDigestBackgroundRound(ctx context.Context, g *globals.Context,
+	ri func() chat1.RemoteInterface) (err error) {
+	defer g.CTrace(ctx, "MentionDigestBackgroundRound", &err)()
+	uid, err := utils.AssertLoggedInUID(ctx, g)
+	if err != nil {
+		return err
+	}
+	now := g.Clock().Now()
+	var last gregor1.Time
+	found, err := g.LocalChatDb.GetInto(&last, mentionDigestLastDbKey(uid))
+	if err != nil {
+		return err
+	}
+	if !found {
+		// Start the schedule now, rather than digesting everything cached.
+		return g.LocalChatDb.PutObj(mentionDigestLastDbKey(uid), nil, gregor1.ToTime(now))
+	}
+	since := last.Time()
+	if now.Sub(since) < mentionDigestPeriod {
+		return nil
+	}
+	if now.Sub(since) > 2*mentionDigestPeriod {
+		// Been offline a while; don't reach back further than a period.
+		since = now.Add(-mentionDigestPeriod)
+	}
+	settings, err := loadMentionDigestSettings(ctx, g, ri, uid)
+	if err != nil {
+		return err
+	}
+	if !settings.Disabled {
+		digest, err := buildMentionDigest(ctx, g, uid, settings, since, now)
+		if err != nil {
+			return err
+		}
+		if len(digest.Convs) > 0 {
+			g.NotifyRouter.HandleChatMentionDigest(ctx, keybase1.UID(uid.String()), digest)
+		}
+	}
+	return g.LocalChatDb.PutObj(mentionDigestLastDbKey(uid), nil, gregor1.ToTime(now))
+}
@@ -0,0 +1,63 @@
+package chat
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectMentionDigestItems(t *testing.T) {
+	me := gregor1.UID{0x01}
+	them := gregor1.UID{0x02}
+	until := time.Date(2024, 5, 2, 12, 0, 0, 0, time.UTC)
+	since := until.Add(-mentionDigestPeriod)
+
+	msg := func(id chat1.MessageID, sender gregor1.UID, at time.Time, body string,
+		mentions ...gregor1.UID) chat1.MessageUnboxed {
+		return chat1.NewMessageUnboxedWithValid(chat1.MessageUnboxedValid{
+			ClientHeader: chat1.MessageClientHeaderVerified{
+				Sender:      sender,
+				MessageType: chat1.MessageType_TEXT,
+			},
+			ServerHeader: chat1.MessageServerHeader{
+				MessageID: id,
+				Ctime:     gregor1.ToTime(at),
+			},
+			MessageBody:    chat1.NewMessageBodyWithText(chat1.MessageText{Body: body}),
+			SenderUsername: "bob",
+			AtMentions:     mentions,
+		})
+	}
+	// Newest first, like a thread.
+	msgs := []chat1.MessageUnboxed{
+		msg(9, them, until.Add(time.Minute), "too new @me", me),
+		msg(8, them, until.Add(-1*time.Hour), "hey\n@me  look", me),
+		msg(7, me, until.Add(-2*time.Hour), "talking to myself @me", me),
+		msg(6, them, until.Add(-3*time.Hour), "not you @carol", them),
+		msg(5, them, until.Add(-4*time.Hour), "@me one", me),
+		msg(4, them, until.Add(-5*time.Hour), "@me two", me),
+		msg(3, them, until.Add(-6*time.Hour), "@me three", me),
+		msg(2, them, since.Add(-time.Minute), "too old @me", me),
+	}
+	count, items := collectMentionDigestItems(msgs, me, since, until)
+	require.Equal(t, 4, count)
+	require.Len(t, items, mentionDigestMaxItems)
+	require.Equal(t, chat1.MessageID(8), items[0].MsgID)
+	require.Equal(t, "hey @me look", items[0].Snippet)
+	require.Equal(t, "bob", items[0].Sender)
+	require.Equal(t, chat1.MessageID(4), items[2].MsgID)
+
+	long := mentionDigestSnippet(strings.Repeat("a", 2*mentionDigestSnippetLen))
+	require.Len(t, []rune(long), mentionDigestSnippetLen)
+	require.True(t, strings.HasSuffix(long, "…"))
+
+	team := keybase1.TeamID("3b2ae1ecf3a5b4a1cfa5d67c1f4e2a24")
+	settings := chat1.MentionDigestSettings{OptedOutTeams: []keybase1.TeamID{team}}
+	require.True(t, mentionDigestTeamOptedOut(settings, team))
+	require.False(t, mentionDigestTeamOptedOut(settings, keybase1.TeamID("5b2ae1ecf3a5b4a1cfa5d67c1f4e2a24")))
+}
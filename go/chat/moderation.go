@@ -0,0 +1,245 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/chat/types"
+	"github.com/keybase/client/go/chat/utils"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/keybase/client/go/teams"
+)
+
+// Bulk deletion lets team admins clean up after a spammer without deleting
+// messages one at a time: it deletes every message in a channel from some
+// senders, or in a time range, with a dry run first to confirm the count.
+// Each run is recorded in the team's moderation audit log, which lives in
+// the team's admin-only dev storage like the new member restrictions.
+
+const moderationAuditLogName = "__moderation_audit_log"
+
+var moderationAuditLog = teamAuditLog[chat1.ModerationAuditLog, chat1.ModerationAuditEntry]{
+	teamPolicy: teamPolicy[chat1.ModerationAuditLog]{name: moderationAuditLogName},
+	entries: func(log *chat1.ModerationAuditLog) *[]chat1.ModerationAuditEntry {
+		return &log.Entries
+	},
+}
+
+// How many messages to look at per page when finding what to delete.
+const bulkDeletePageSize = 100
+
+// The kinds of message bulk deletion removes unless it's asked for fewer.
+var bulkDeleteMessageTypes = []chat1.MessageType{
+	chat1.MessageType_TEXT,
+	chat1.MessageType_ATTACHMENT,
+	chat1.MessageType_REACTION,
+	chat1.MessageType_FLIP,
+	chat1.MessageType_REQUESTPAYMENT,
+	chat1.MessageType_POLL,
+	chat1.MessageType_TASK,
+}
+
+type bulkDeleteFilter struct {
+	senders map[string]bool
+	after   *gregor1.Time
+	before  *gregor1.Time
+	types   []chat1.MessageType
+}
+
+func newBulkDeleteFilter(senders []string, after, before *gregor1.Time,
+	messageTypes []chat1.MessageType) (f bulkDeleteFilter, err error) {
+	if len(senders) == 0 && after == nil && before == nil {
+		return f, errors.New("bulk deletion needs senders or a time range; " +
+			"use delete-history to delete everything")
+	}
+	if after != nil && before != nil && *after >= *before {
+		return f, errors.New("the start of the time range must be before its end")
+	}
+	f.senders = make(map[string]bool, len(senders))
+	for _, sender := range senders {
+		f.senders[libkb.NewNormalizedUsername(sender).String()] = true
+	}
+	f.after, f.before = after, before
+	if len(messageTypes) == 0 {
+		f.types = bulkDeleteMessageTypes
+		return f, nil
+	}
+	for _, typ := range messageTypes {
+		found := false
+		for _, allowed := range bulkDeleteMessageTypes {
+			if typ == allowed {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return f, fmt.Errorf("%v messages can't be bulk deleted", typ)
+		}
+	}
+	f.types = messageTypes
+	return f, nil
+}
+
+func (f bulkDeleteFilter) matches(msg chat1.MessageUnboxedValid) bool {
+	if len(f.senders) > 0 &&
+		!f.senders[libkb.NewNormalizedUsername(msg.SenderUsername).String()] {
+		return false
+	}
+	ctime := msg.ServerHeader.Ctime
+	if f.after != nil && ctime <= *f.after {
+		return false
+	}
+	if f.before != nil && ctime >= *f.before {
+		return false
+	}
+	for _, typ := range f.types {
+		if msg.ClientHeader.MessageType == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// findBulkDeleteMessages returns the IDs of the messages in convID that
+// match f, newest first.
+func findBulkDeleteMessages(ctx context.Context, g *globals.Context, uid gregor1.UID,
+	convID chat1.ConversationID, f bulkDeleteFilter) (res []chat1.MessageID, err error) {
+	pagination := &chat1.Pagination{Num: bulkDeletePageSize}
+	for {
+		thread, err := g.ConvSource.Pull(ctx, convID, uid, chat1.GetThreadReason_GENERAL, nil,
+			&chat1.GetThreadQuery{
+				MarkAsRead: false,
+				// Reactions are folded into what they react to otherwise.
+				DisableResolveSupersedes: true,
+				MessageTypes:             f.types,
+				Before:                   f.before,
+				After:                    f.after,
+			}, pagination)
+		if err != nil {
+			return nil, err
+		}
+		pastRange := false
+		for _, m := range thread.Messages {
+			if !m.IsValidFull() {
+				continue
+			}
+			msg := m.Valid()
+			if f.after != nil && msg.ServerHeader.Ctime <= *f.after {
+				// Pages go from newest to oldest, so there's nothing more.
+				pastRange = true
+				continue
+			}
+			if f.matches(msg) {
+				res = append(res, msg.ServerHeader.MessageID)
+			}
+		}
+		if pastRange || thread.Pagination == nil || thread.Pagination.Last {
+			return res, nil
+		}
+		pagination = thread.Pagination
+		pagination.Num = bulkDeletePageSize
+		pagination.Previous = nil
+	}
+}
+
+func checkBulkDeleteAllowed(ctx context.Context, g *globals.Context, conv chat1.ConversationLocal) error {
+	if conv.GetMembersType() != chat1.ConversationMembersType_TEAM {
+		return errors.New("bulk deletion only works in team channels")
+	}
+	op, err := teams.CanUserPerform(ctx, g.ExternalG(), conv.Info.TlfName)
+	if err != nil {
+		return err
+	}
+	if !op.DeleteOtherMessages {
+		return errors.New("only team admins can delete other members' messages")
+	}
+	return nil
+}
+
+func bulkDeleteMessages(ctx context.Context, g *globals.Context, sender types.Sender,
+	ri func() chat1.RemoteInterface, uid gregor1.UID, arg chat1.BulkDeleteMessagesLocalArg) (
+	res chat1.BulkDeleteMessagesRes, err error) {
+	f, err := newBulkDeleteFilter(arg.Senders, arg.After, arg.Before, arg.MessageTypes)
+	if err != nil {
+		return res, err
+	}
+	conv, err := utils.GetVerifiedConv(ctx, g, uid, arg.ConvID, types.InboxSourceDataSourceAll)
+	if err != nil {
+		return res, err
+	}
+	if err := checkBulkDeleteAllowed(ctx, g, conv); err != nil {
+		return res, err
+	}
+	msgIDs, err := findBulkDeleteMessages(ctx, g, uid, arg.ConvID, f)
+	if err != nil {
+		return res, err
+	}
+	res.NumMatched = len(msgIDs)
+	if arg.DryRun || len(msgIDs) == 0 {
+		return res, nil
+	}
+
+	defer func() {
+		if res.NumDeleted == 0 {
+			return
+		}
+		// Record what we managed to delete even if we didn't get through
+		// all of it.
+		entry := chat1.ModerationAuditEntry{
+			Ctime:        gregor1.ToTime(time.Now()),
+			Admin:        g.Env.GetUsername().String(),
+			ConvID:       arg.ConvID,
+			Channel:      conv.GetTopicName(),
+			Senders:      arg.Senders,
+			After:        arg.After,
+			Before:       arg.Before,
+			MessageTypes: arg.MessageTypes,
+			NumDeleted:   res.NumDeleted,
+		}
+		if aerr := addModerationAuditEntry(ctx, g, ri, uid, conv, entry); aerr != nil {
+			g.GetLog().CDebugf(ctx, "bulkDeleteMessages: unable to record audit entry: %v", aerr)
+		}
+	}()
+	for _, msgID := range msgIDs {
+		msg := chat1.MessagePlaintext{
+			ClientHeader: chat1.MessageClientHeader{
+				Conv:        conv.Info.Triple,
+				TlfName:     conv.Info.TlfName,
+				TlfPublic:   conv.Info.Visibility == keybase1.TLFVisibility_PUBLIC,
+				MessageType: chat1.MessageType_DELETE,
+				Supersedes:  msgID,
+				Deletes:     []chat1.MessageID{msgID},
+			},
+			// The sender fills in any edits of the message to delete along
+			// with it; reactions don't have any.
+			MessageBody: chat1.NewMessageBodyWithDelete(chat1.MessageDelete{
+				MessageIDs: []chat1.MessageID{msgID},
+			}),
+		}
+		if _, _, err := sender.Send(ctx, arg.ConvID, msg, 0, nil, nil, nil); err != nil {
+			return res, fmt.Errorf("deleted %d of %d messages: %w", res.NumDeleted, len(msgIDs), err)
+		}
+		res.NumDeleted++
+	}
+	return res, nil
+}
+
+func getModerationAuditLog(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, teamID keybase1.TeamID) (chat1.ModerationAuditLog, error) {
+	return moderationAuditLog.load(ctx, g, ri, uid, teamID)
+}
+
+func addModerationAuditEntry(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, conv chat1.ConversationLocal, entry chat1.ModerationAuditEntry) error {
+	teamID, err := TLFIDToTeamID(conv.Info.Triple.Tlfid)
+	if err != nil {
+		return err
+	}
+	return moderationAuditLog.add(ctx, g, ri, uid, teamID, entry)
+}
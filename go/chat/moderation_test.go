@@ -0,0 +1,49 @@
+package chat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkDeleteFilter(t *testing.T) {
+	now := time.Now()
+	hourAgo := gregor1.ToTime(now.Add(-time.Hour))
+	dayAgo := gregor1.ToTime(now.Add(-24 * time.Hour))
+
+	// Something has to narrow it down.
+	_, err := newBulkDeleteFilter(nil, nil, nil, nil)
+	require.Error(t, err)
+	_, err = newBulkDeleteFilter(nil, &hourAgo, &dayAgo, nil)
+	require.Error(t, err)
+	_, err = newBulkDeleteFilter([]string{"spammer"}, nil, nil,
+		[]chat1.MessageType{chat1.MessageType_SYSTEM})
+	require.Error(t, err)
+
+	msg := func(sender string, typ chat1.MessageType, ctime time.Time) chat1.MessageUnboxedValid {
+		return chat1.MessageUnboxedValid{
+			ClientHeader:   chat1.MessageClientHeaderVerified{MessageType: typ},
+			ServerHeader:   chat1.MessageServerHeader{Ctime: gregor1.ToTime(ctime)},
+			SenderUsername: sender,
+		}
+	}
+
+	f, err := newBulkDeleteFilter([]string{"Spammer"}, &dayAgo, nil, nil)
+	require.NoError(t, err)
+	require.True(t, f.matches(msg("spammer", chat1.MessageType_TEXT, now)))
+	require.True(t, f.matches(msg("spammer", chat1.MessageType_REACTION, now)))
+	require.False(t, f.matches(msg("alice", chat1.MessageType_TEXT, now)))
+	require.False(t, f.matches(msg("spammer", chat1.MessageType_TEXT, now.Add(-48*time.Hour))))
+	require.False(t, f.matches(msg("spammer", chat1.MessageType_JOIN, now)))
+
+	// Just reactions, from anyone in the last day up to an hour ago.
+	f, err = newBulkDeleteFilter(nil, &dayAgo, &hourAgo,
+		[]chat1.MessageType{chat1.MessageType_REACTION})
+	require.NoError(t, err)
+	require.True(t, f.matches(msg("alice", chat1.MessageType_REACTION, now.Add(-2*time.Hour))))
+	require.False(t, f.matches(msg("alice", chat1.MessageType_REACTION, now)))
+	require.False(t, f.matches(msg("alice", chat1.MessageType_TEXT, now.Add(-2*time.Hour))))
+}
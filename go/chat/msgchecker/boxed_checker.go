@@ -37,7 +37,8 @@ func checkMessageBoxedLength(msg chat1.MessageBoxed) error {
 		chat1.MessageType_ATTACHMENTUPLOADED,
 		chat1.MessageType_PIN:
 		return boxedFieldLengthChecker("sanity check", len(msg.BodyCiphertext.E), BoxedSanityLength)
-	case chat1.MessageType_TEXT, chat1.MessageType_FLIP, chat1.MessageType_UNFURL:
+	case chat1.MessageType_TEXT, chat1.MessageType_FLIP, chat1.MessageType_UNFURL,
+		chat1.MessageType_POLL, chat1.MessageType_TASK:
 		return boxedFieldLengthChecker("TEXT message", len(msg.BodyCiphertext.E), textMsgLength)
 	case chat1.MessageType_EDIT:
 		return boxedFieldLengthChecker("EDIT message", len(msg.BodyCiphertext.E), textMsgLength)
@@ -9,6 +9,11 @@ const (
 	HeadlineMaxLength           = 280
 	TopicMaxLength              = 20
 	RequestPaymentTextMaxLength = 240
+	PollQuestionMaxLength       = 280
+	PollOptionMaxLength         = 200
+	PollMinOptions              = 2
+	PollMaxOptions              = 20
+	TaskTitleMaxLength          = 280
 )
 
 const (
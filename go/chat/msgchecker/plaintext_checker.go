@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/keybase/client/go/protocol/chat1"
 )
@@ -110,6 +111,10 @@ func checkMessagePlaintextLength(msg chat1.MessagePlaintext) error {
 	case chat1.MessageType_REQUESTPAYMENT:
 		return plaintextFieldLengthChecker("request payment note",
 			len(msg.MessageBody.Requestpayment().Note), RequestPaymentTextMaxLength)
+	case chat1.MessageType_POLL:
+		return checkPoll(msg.MessageBody.Poll())
+	case chat1.MessageType_TASK:
+		return checkTask(msg.MessageBody.Task())
 	default:
 		typ, err := msg.MessageBody.MessageType()
 		if err != nil {
@@ -119,6 +124,37 @@ func checkMessagePlaintextLength(msg chat1.MessagePlaintext) error {
 	}
 }
 
+func checkPoll(poll chat1.MessagePoll) error {
+	if len(strings.TrimSpace(poll.Question)) == 0 {
+		return errors.New("poll question cannot be empty")
+	}
+	if err := plaintextFieldLengthChecker("poll question", len(poll.Question),
+		PollQuestionMaxLength); err != nil {
+		return err
+	}
+	if len(poll.Options) < PollMinOptions || len(poll.Options) > PollMaxOptions {
+		return fmt.Errorf("a poll needs between %d and %d options, found %d",
+			PollMinOptions, PollMaxOptions, len(poll.Options))
+	}
+	for _, option := range poll.Options {
+		if len(strings.TrimSpace(option)) == 0 {
+			return errors.New("poll options cannot be empty")
+		}
+		if err := plaintextFieldLengthChecker("poll option", len(option),
+			PollOptionMaxLength); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkTask(task chat1.MessageTask) error {
+	if len(strings.TrimSpace(task.Title)) == 0 {
+		return errors.New("task title cannot be empty")
+	}
+	return plaintextFieldLengthChecker("task title", len(task.Title), TaskTitleMaxLength)
+}
+
 func CheckMessagePlaintext(msg chat1.MessagePlaintext) error {
 	return checkMessagePlaintextLength(msg)
 }
@@ -0,0 +1,245 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/keybase/client/go/teams"
+	"mvdan.cc/xurls/v2"
+)
+
+// New member restrictions let admins of large open teams slow down spammers:
+// members who joined recently can only post so many messages an hour, and
+// can't post links or attachments at first. They're a team policy, kept with
+// the others in the team's admin-only dev storage.
+
+const newMemberRestrictionsName = "__new_member_restrictions"
+
+var newMemberRestrictions = teamPolicy[chat1.NewMemberRestrictions]{
+	name:      newMemberRestrictionsName,
+	cacheTime: 5 * time.Minute,
+}
+
+var newMemberLinkRegexp = xurls.Relaxed()
+
+type newMemberSend struct {
+	at       time.Time
+	outboxID string
+}
+
+// newMemberTracker remembers recent sends by restricted members, to enforce
+// the hourly limit. Sends are only tracked in memory, so a restart resets the
+// count.
+type newMemberTracker struct {
+	sync.Mutex
+	sends map[keybase1.TeamID][]newMemberSend
+}
+
+func newNewMemberTracker() *newMemberTracker {
+	return &newMemberTracker{
+		sends: make(map[keybase1.TeamID][]newMemberSend),
+	}
+}
+
+// recordSend counts a send in teamID, unless it's a retry of one we've
+// already counted, and returns how many sends there have been in the last
+// hour before this one.
+func (t *newMemberTracker) recordSend(teamID keybase1.TeamID, outboxID string, now time.Time) (recent int, retry bool) {
+	t.Lock()
+	defer t.Unlock()
+	var kept []newMemberSend
+	for _, send := range t.sends[teamID] {
+		if now.Sub(send.at) > time.Hour {
+			continue
+		}
+		if len(outboxID) > 0 && send.outboxID == outboxID {
+			retry = true
+		}
+		kept = append(kept, send)
+	}
+	recent = len(kept)
+	if !retry {
+		kept = append(kept, newMemberSend{at: now, outboxID: outboxID})
+	}
+	t.sends[teamID] = kept
+	return recent, retry
+}
+
+func (t *newMemberTracker) forgetSend(teamID keybase1.TeamID, outboxID string) {
+	t.Lock()
+	defer t.Unlock()
+	sends := t.sends[teamID]
+	for i, send := range sends {
+		if send.outboxID == outboxID {
+			t.sends[teamID] = append(sends[:i], sends[i+1:]...)
+			return
+		}
+	}
+}
+
+func (t *newMemberTracker) clear() {
+	t.Lock()
+	defer t.Unlock()
+	t.sends = make(map[keybase1.TeamID][]newMemberSend)
+}
+
+func checkNewMemberRestrictionsValid(r chat1.NewMemberRestrictions) error {
+	if r.NewMemberHours < 0 || r.MaxMessagesPerHour < 0 || r.BlockLinksAndAttachmentsHours < 0 {
+		return errors.New("new member restrictions cannot be negative")
+	}
+	if r.BlockLinksAndAttachmentsHours > r.NewMemberHours {
+		return errors.New("links and attachments can only be blocked while a member is new")
+	}
+	return nil
+}
+
+func setNewMemberRestrictions(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, teamID keybase1.TeamID, r chat1.NewMemberRestrictions) error {
+	if err := checkNewMemberRestrictionsValid(r); err != nil {
+		return err
+	}
+	return newMemberRestrictions.store(ctx, g, ri, uid, teamID, r)
+}
+
+func setNewMemberRestrictionsExempt(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, teamID keybase1.TeamID, username string, exempt bool) error {
+	exemptUID, err := g.GetUPAKLoader().LookupUID(ctx, libkb.NewNormalizedUsername(username))
+	if err != nil {
+		return err
+	}
+	r, err := newMemberRestrictions.load(ctx, g, ri, uid, teamID)
+	if err != nil {
+		return err
+	}
+	var exemptUIDs []keybase1.UID
+	for _, u := range r.ExemptUIDs {
+		if !u.Equal(exemptUID) {
+			exemptUIDs = append(exemptUIDs, u)
+		}
+	}
+	if exempt {
+		exemptUIDs = append(exemptUIDs, exemptUID)
+	}
+	r.ExemptUIDs = exemptUIDs
+	return setNewMemberRestrictions(ctx, g, ri, uid, teamID, r)
+}
+
+func isNewMemberRestrictedType(typ chat1.MessageType) bool {
+	switch typ {
+	case chat1.MessageType_TEXT, chat1.MessageType_ATTACHMENT, chat1.MessageType_EDIT:
+		return true
+	default:
+		return false
+	}
+}
+
+// newMemberRestrictionError says why a member who joined at joinedAt can't
+// send a message of type typ, or returns nil if they can. recentSends is how
+// many messages they've sent to the team in the last hour.
+func newMemberRestrictionError(r chat1.NewMemberRestrictions, joinedAt, now time.Time,
+	recentSends int, typ chat1.MessageType, hasLink bool) error {
+	memberFor := now.Sub(joinedAt)
+	if r.NewMemberHours == 0 || memberFor >= time.Duration(r.NewMemberHours)*time.Hour {
+		return nil
+	}
+	if memberFor < time.Duration(r.BlockLinksAndAttachmentsHours)*time.Hour {
+		wait := (time.Duration(r.BlockLinksAndAttachmentsHours)*time.Hour - memberFor).Round(time.Minute)
+		switch {
+		case typ == chat1.MessageType_ATTACHMENT:
+			return NewMemberRestrictedError{Msg: fmt.Sprintf(
+				"new members of this team can't post attachments yet; try again in %v", wait)}
+		case hasLink:
+			return NewMemberRestrictedError{Msg: fmt.Sprintf(
+				"new members of this team can't post links yet; try again in %v", wait)}
+		}
+	}
+	if typ != chat1.MessageType_EDIT && r.MaxMessagesPerHour > 0 && recentSends >= r.MaxMessagesPerHour {
+		return NewMemberRestrictedError{Msg: fmt.Sprintf(
+			"new members of this team can only post %d messages an hour", r.MaxMessagesPerHour)}
+	}
+	return nil
+}
+
+func newMemberMessageHasLink(msg chat1.MessagePlaintext) bool {
+	var body string
+	switch {
+	case msg.MessageBody.IsType(chat1.MessageType_TEXT):
+		body = msg.MessageBody.Text().Body
+	case msg.MessageBody.IsType(chat1.MessageType_EDIT):
+		body = msg.MessageBody.Edit().Body
+	default:
+		return false
+	}
+	return newMemberLinkRegexp.MatchString(body)
+}
+
+// checkNewMemberRestrictions is called on every message we prepare, and
+// fails if the team restricts new members and we are one.
+func (s *BlockingSender) checkNewMemberRestrictions(ctx context.Context, uid gregor1.UID,
+	conv chat1.ConversationLocal, msg chat1.MessagePlaintext) (err error) {
+	if !isNewMemberRestrictedType(msg.ClientHeader.MessageType) {
+		return nil
+	}
+	if conv.ReaderInfo.UntrustedTeamRole.IsAdminOrAbove() ||
+		conv.ReaderInfo.UntrustedTeamRole.IsBotLike() {
+		return nil
+	}
+	r, teamID, ok, err := prepareTeamPolicy(ctx, s, uid, conv, newMemberRestrictions)
+	if !ok {
+		return err
+	}
+	now := s.clock.Now()
+	if r.NewMemberHours == 0 {
+		return nil
+	}
+	me := keybase1.UID(uid.String())
+	for _, exempt := range r.ExemptUIDs {
+		if exempt.Equal(me) {
+			return nil
+		}
+	}
+
+	uv, err := s.G().GetMeUV(ctx)
+	if err != nil {
+		return err
+	}
+	team, err := teams.Load(ctx, s.G().ExternalG(), keybase1.LoadTeamArg{ID: teamID})
+	if err != nil {
+		return err
+	}
+	joinedAt, err := team.UserLastJoinTime(uv)
+	if err != nil {
+		// Implicit admins and the like don't have a join time.
+		s.Debug(ctx, "checkNewMemberRestrictions: no join time: %v", err)
+		return nil
+	}
+
+	outboxID := ""
+	if msg.ClientHeader.OutboxID != nil {
+		outboxID = msg.ClientHeader.OutboxID.String()
+	}
+	recent := 0
+	countsTowardLimit := msg.ClientHeader.MessageType != chat1.MessageType_EDIT
+	if countsTowardLimit {
+		var retry bool
+		recent, retry = s.G().TeamPolicyCache.RecordNewMemberSend(teamID, outboxID, now)
+		if retry {
+			// Already let through once.
+			return nil
+		}
+	}
+	err = newMemberRestrictionError(r, joinedAt.Time(), now, recent,
+		msg.ClientHeader.MessageType, newMemberMessageHasLink(msg))
+	if err != nil && countsTowardLimit && len(outboxID) > 0 {
+		s.G().TeamPolicyCache.ForgetNewMemberSend(teamID, outboxID)
+	}
+	return err
+}
@@ -0,0 +1,68 @@
+package chat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMemberRestrictionError(t *testing.T) {
+	r := chat1.NewMemberRestrictions{
+		NewMemberHours:                24,
+		MaxMessagesPerHour:            3,
+		BlockLinksAndAttachmentsHours: 6,
+	}
+	require.NoError(t, checkNewMemberRestrictionsValid(r))
+	joined := time.Now()
+
+	// Brand new: no links or attachments, and a limit on messages.
+	now := joined.Add(time.Hour)
+	require.NoError(t, newMemberRestrictionError(r, joined, now, 0, chat1.MessageType_TEXT, false))
+	require.Error(t, newMemberRestrictionError(r, joined, now, 0, chat1.MessageType_TEXT, true))
+	require.Error(t, newMemberRestrictionError(r, joined, now, 0, chat1.MessageType_EDIT, true))
+	require.Error(t, newMemberRestrictionError(r, joined, now, 0, chat1.MessageType_ATTACHMENT, false))
+	err := newMemberRestrictionError(r, joined, now, 3, chat1.MessageType_TEXT, false)
+	require.IsType(t, NewMemberRestrictedError{}, err)
+	require.NoError(t, newMemberRestrictionError(r, joined, now, 3, chat1.MessageType_EDIT, false))
+
+	// Links are allowed after a while, but the limit still applies.
+	now = joined.Add(12 * time.Hour)
+	require.NoError(t, newMemberRestrictionError(r, joined, now, 0, chat1.MessageType_TEXT, true))
+	require.NoError(t, newMemberRestrictionError(r, joined, now, 0, chat1.MessageType_ATTACHMENT, false))
+	require.Error(t, newMemberRestrictionError(r, joined, now, 3, chat1.MessageType_TEXT, false))
+
+	// No longer new.
+	now = joined.Add(25 * time.Hour)
+	require.NoError(t, newMemberRestrictionError(r, joined, now, 100, chat1.MessageType_ATTACHMENT, true))
+
+	// Off.
+	require.NoError(t, newMemberRestrictionError(chat1.NewMemberRestrictions{}, joined, joined, 100,
+		chat1.MessageType_ATTACHMENT, true))
+
+	require.Error(t, checkNewMemberRestrictionsValid(chat1.NewMemberRestrictions{NewMemberHours: -1}))
+	require.Error(t, checkNewMemberRestrictionsValid(chat1.NewMemberRestrictions{
+		NewMemberHours: 1, BlockLinksAndAttachmentsHours: 2}))
+}
+
+func TestNewMemberTrackerSends(t *testing.T) {
+	tracker := newNewMemberTracker()
+	teamID := keybase1.TeamID("ffff")
+	now := time.Now()
+	recent, retry := tracker.recordSend(teamID, "a", now)
+	require.Equal(t, 0, recent)
+	require.False(t, retry)
+	recent, retry = tracker.recordSend(teamID, "b", now.Add(time.Minute))
+	require.Equal(t, 1, recent)
+	require.False(t, retry)
+	_, retry = tracker.recordSend(teamID, "a", now.Add(2*time.Minute))
+	require.True(t, retry)
+	tracker.forgetSend(teamID, "b")
+	recent, _ = tracker.recordSend(teamID, "c", now.Add(3*time.Minute))
+	require.Equal(t, 1, recent)
+	// Old sends drop out of the count.
+	recent, _ = tracker.recordSend(teamID, "d", now.Add(2*time.Hour))
+	require.Equal(t, 0, recent)
+}
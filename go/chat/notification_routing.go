@@ -0,0 +1,190 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// Notification routing preferences are stored in the user's own dev
+// conversation, so they're encrypted and shared by all of the user's devices.
+// Each device keeps a copy in its local chat db, which is what the
+// notification code checks; it's updated whenever the preferences are loaded
+// or set, and when one of our other devices posts new ones.
+
+const notificationRoutingName = "__notification_routing"
+
+const minutesPerDay = 24 * 60
+
+func notificationRoutingDbKey(uid gregor1.UID) libkb.DbKey {
+	return libkb.DbKey{
+		Typ: libkb.DBChatNotificationRouting,
+		Key: uid.String(),
+	}
+}
+
+// notificationRoutingLocation is the time zone the preferences' windows are
+// in. Note that time.LoadLocation("") is UTC, not local time.
+func notificationRoutingLocation(prefs chat1.NotificationRoutingPreferences) (*time.Location, error) {
+	if len(prefs.TimeZone) == 0 {
+		return time.Local, nil
+	}
+	return time.LoadLocation(prefs.TimeZone)
+}
+
+func checkNotificationRoutingPreferences(prefs chat1.NotificationRoutingPreferences) error {
+	if _, err := notificationRoutingLocation(prefs); err != nil {
+		return fmt.Errorf("unknown time zone %q: %v", prefs.TimeZone, err)
+	}
+	devices := make(map[keybase1.DeviceID]bool)
+	for _, rule := range prefs.Rules {
+		if len(rule.DeviceID) == 0 {
+			return errors.New("notification routing rules must have a device")
+		}
+		if devices[rule.DeviceID] {
+			return fmt.Errorf("more than one notification routing rule for device %s", rule.DeviceID)
+		}
+		devices[rule.DeviceID] = true
+		for _, window := range rule.Windows {
+			if window.StartMinute < 0 || window.StartMinute >= minutesPerDay ||
+				window.EndMinute < 0 || window.EndMinute > minutesPerDay {
+				return fmt.Errorf("invalid notification window %d-%d", window.StartMinute, window.EndMinute)
+			}
+			for _, day := range window.Weekdays {
+				if day < int(time.Sunday) || day > int(time.Saturday) {
+					return fmt.Errorf("invalid weekday %d", day)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// notificationWindowContains says whether the window covers t, which should
+// already be in the preferences' time zone.
+func notificationWindowContains(window chat1.NotificationRoutingWindow, t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+	day := t.Weekday()
+	if window.StartMinute > window.EndMinute {
+		// Wraps past midnight. If we're in the early part, the window
+		// started yesterday.
+		if minute < window.EndMinute {
+			day = (day + 6) % 7
+		} else if minute < window.StartMinute {
+			return false
+		}
+	} else if minute < window.StartMinute || minute >= window.EndMinute {
+		return false
+	}
+	if len(window.Weekdays) == 0 {
+		return true
+	}
+	for _, d := range window.Weekdays {
+		if time.Weekday(d) == day {
+			return true
+		}
+	}
+	return false
+}
+
+// notificationRoutingAllows says whether deviceID should get a notification
+// at now.
+func notificationRoutingAllows(prefs chat1.NotificationRoutingPreferences,
+	deviceID keybase1.DeviceID, now time.Time) bool {
+	for _, rule := range prefs.Rules {
+		if !rule.DeviceID.Eq(deviceID) {
+			continue
+		}
+		loc, err := notificationRoutingLocation(prefs)
+		if err != nil {
+			// Checked when they were set, so don't drop notifications over
+			// it now.
+			return true
+		}
+		now = now.In(loc)
+		for _, window := range rule.Windows {
+			if notificationWindowContains(window, now) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func putCachedNotificationRouting(g *globals.Context, uid gregor1.UID,
+	prefs chat1.NotificationRoutingPreferences) error {
+	return g.LocalChatDb.PutObj(notificationRoutingDbKey(uid), nil, prefs)
+}
+
+func getNotificationRoutingPreferences(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID) (prefs chat1.NotificationRoutingPreferences, err error) {
+	found, err := NewDevConversationBackedStorage(g, ri).Get(ctx, uid, notificationRoutingName, &prefs)
+	if err != nil {
+		return prefs, err
+	}
+	if !found {
+		prefs = chat1.NotificationRoutingPreferences{}
+	}
+	if err := putCachedNotificationRouting(g, uid, prefs); err != nil {
+		g.Log.CDebugf(ctx, "getNotificationRoutingPreferences: failed to cache: %v", err)
+	}
+	return prefs, nil
+}
+
+func setNotificationRoutingPreferences(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, prefs chat1.NotificationRoutingPreferences) error {
+	if err := checkNotificationRoutingPreferences(prefs); err != nil {
+		return err
+	}
+	if err := NewDevConversationBackedStorage(g, ri).Put(ctx, uid, notificationRoutingName, prefs); err != nil {
+		return err
+	}
+	return putCachedNotificationRouting(g, uid, prefs)
+}
+
+// updateNotificationRoutingFromMessage picks up preferences set on another of
+// our devices, when the message storing them comes in.
+func updateNotificationRoutingFromMessage(ctx context.Context, g *globals.Context, uid gregor1.UID,
+	conv *chat1.ConversationLocal, msg chat1.MessageUnboxed) {
+	if conv == nil || conv.GetTopicType() != chat1.TopicType_DEV ||
+		conv.GetMembersType() != chat1.ConversationMembersType_IMPTEAMNATIVE ||
+		conv.Info.TopicName != notificationRoutingName || !msg.IsValid() ||
+		!msg.Valid().ClientHeader.Sender.Eq(uid) ||
+		!msg.Valid().MessageBody.IsType(chat1.MessageType_TEXT) {
+		return
+	}
+	var prefs chat1.NotificationRoutingPreferences
+	if err := json.Unmarshal([]byte(msg.Valid().MessageBody.Text().Body), &prefs); err != nil {
+		g.Log.CDebugf(ctx, "updateNotificationRoutingFromMessage: failed to parse: %v", err)
+		return
+	}
+	if err := putCachedNotificationRouting(g, uid, prefs); err != nil {
+		g.Log.CDebugf(ctx, "updateNotificationRoutingFromMessage: failed to cache: %v", err)
+	}
+}
+
+// ShouldNotifyThisDevice checks the user's notification routing preferences
+// to see whether this device should show a notification right now. It only
+// looks at the locally cached preferences, so it's cheap enough to call for
+// every notification.
+func ShouldNotifyThisDevice(ctx context.Context, g *globals.Context, uid gregor1.UID) bool {
+	var prefs chat1.NotificationRoutingPreferences
+	found, err := g.LocalChatDb.GetInto(&prefs, notificationRoutingDbKey(uid))
+	if err != nil {
+		g.Log.CDebugf(ctx, "ShouldNotifyThisDevice: failed to read preferences: %v", err)
+		return true
+	}
+	if !found {
+		return true
+	}
+	return notificationRoutingAllows(prefs, g.ActiveDevice.DeviceID(), g.Clock().Now())
+}
@@ -0,0 +1,77 @@
+package chat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationRoutingAllows(t *testing.T) {
+	phone := keybase1.DeviceID("01")
+	laptop := keybase1.DeviceID("02")
+	other := keybase1.DeviceID("03")
+	prefs := chat1.NotificationRoutingPreferences{
+		TimeZone: "UTC",
+		Rules: []chat1.NotificationRoutingRule{
+			{
+				// Evenings, wrapping past midnight.
+				DeviceID: phone,
+				Windows:  []chat1.NotificationRoutingWindow{{StartMinute: 18 * 60, EndMinute: 2 * 60}},
+			},
+			{
+				// Work hours on weekdays.
+				DeviceID: laptop,
+				Windows: []chat1.NotificationRoutingWindow{{
+					StartMinute: 9 * 60,
+					EndMinute:   17 * 60,
+					Weekdays:    []int{1, 2, 3, 4, 5},
+				}},
+			},
+		},
+	}
+	require.NoError(t, checkNotificationRoutingPreferences(prefs))
+
+	at := func(day, hour, minute int) time.Time {
+		// January 2023 started on a Sunday.
+		return time.Date(2023, time.January, 1+day, hour, minute, 0, 0, time.UTC)
+	}
+	monday := 1
+	friday := 5
+	saturday := 6
+
+	require.True(t, notificationRoutingAllows(prefs, phone, at(monday, 19, 0)))
+	require.True(t, notificationRoutingAllows(prefs, phone, at(monday, 1, 59)))
+	require.False(t, notificationRoutingAllows(prefs, phone, at(monday, 2, 0)))
+	require.False(t, notificationRoutingAllows(prefs, phone, at(monday, 12, 0)))
+
+	require.True(t, notificationRoutingAllows(prefs, laptop, at(monday, 9, 0)))
+	require.False(t, notificationRoutingAllows(prefs, laptop, at(monday, 17, 0)))
+	require.True(t, notificationRoutingAllows(prefs, laptop, at(friday, 16, 59)))
+	require.False(t, notificationRoutingAllows(prefs, laptop, at(saturday, 12, 0)))
+
+	// Devices without a rule always get notifications.
+	require.True(t, notificationRoutingAllows(prefs, other, at(saturday, 3, 0)))
+
+	// Windows that wrap past midnight belong to the day they start on.
+	prefs.Rules[0].Windows[0].Weekdays = []int{friday}
+	require.True(t, notificationRoutingAllows(prefs, phone, at(saturday, 1, 0)))
+	require.False(t, notificationRoutingAllows(prefs, phone, at(friday, 1, 0)))
+
+	// The time zone applies to the windows.
+	prefs.TimeZone = "America/New_York"
+	require.True(t, notificationRoutingAllows(prefs, laptop, at(monday, 14, 0)))
+	require.False(t, notificationRoutingAllows(prefs, laptop, at(monday, 9, 0)))
+
+	require.Error(t, checkNotificationRoutingPreferences(chat1.NotificationRoutingPreferences{
+		TimeZone: "Nowhere/Special"}))
+	require.Error(t, checkNotificationRoutingPreferences(chat1.NotificationRoutingPreferences{
+		Rules: []chat1.NotificationRoutingRule{{DeviceID: phone}, {DeviceID: phone}}}))
+	require.Error(t, checkNotificationRoutingPreferences(chat1.NotificationRoutingPreferences{
+		Rules: []chat1.NotificationRoutingRule{{
+			DeviceID: phone,
+			Windows:  []chat1.NotificationRoutingWindow{{StartMinute: 0, EndMinute: 25 * 60}},
+		}}}))
+}
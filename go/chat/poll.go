@@ -0,0 +1,184 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/chat/types"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+)
+
+// A vote on a poll is a REACTION message on it, with a body naming the
+// option voted for, so votes are sent, synced and aggregated onto the poll
+// message the same way as any other reactions. Taking a vote back is
+// toggling its reaction off.
+
+const pollVoteReactionPrefix = "poll:"
+
+func pollVoteReaction(option int) string {
+	return pollVoteReactionPrefix + strconv.Itoa(option)
+}
+
+// parsePollVoteReaction returns the option a reaction is a vote for, if it's
+// a vote.
+func parsePollVoteReaction(body string) (option int, ok bool) {
+	if !strings.HasPrefix(body, pollVoteReactionPrefix) {
+		return 0, false
+	}
+	option, err := strconv.Atoi(strings.TrimPrefix(body, pollVoteReactionPrefix))
+	if err != nil || option < 0 {
+		return 0, false
+	}
+	return option, true
+}
+
+// tallyPoll counts the votes in the reactions on the poll msgID. If someone
+// has more than one vote in a single-select poll, from voting on two
+// devices at once, only their latest one counts.
+func tallyPoll(msgID chat1.MessageID, poll chat1.MessagePoll,
+	reactions chat1.ReactionMap) chat1.PollResults {
+	votes := make(map[string][]int)
+	latest := make(map[string]chat1.MessageID)
+	for body, users := range reactions.Reactions {
+		option, ok := parsePollVoteReaction(body)
+		if !ok || option >= len(poll.Options) {
+			continue
+		}
+		for username, reaction := range users {
+			if !poll.MultiSelect {
+				if reaction.ReactionMsgID < latest[username] {
+					continue
+				}
+				latest[username] = reaction.ReactionMsgID
+				votes[username] = nil
+			}
+			votes[username] = append(votes[username], option)
+		}
+	}
+
+	res := chat1.PollResults{
+		MsgID:       msgID,
+		Question:    poll.Question,
+		MultiSelect: poll.MultiSelect,
+		Options:     make([]chat1.PollOptionResult, len(poll.Options)),
+	}
+	for i, option := range poll.Options {
+		res.Options[i] = chat1.PollOptionResult{
+			Index:  i,
+			Option: option,
+			Voters: []string{},
+		}
+	}
+	for username, options := range votes {
+		for _, option := range options {
+			res.Options[option].Voters = append(res.Options[option].Voters, username)
+		}
+		res.TotalVoters++
+	}
+	for i := range res.Options {
+		sort.Strings(res.Options[i].Voters)
+	}
+	return res
+}
+
+// getPoll returns the poll msgID, with the reactions that are its votes.
+func getPoll(ctx context.Context, g *globals.Context, uid gregor1.UID,
+	convID chat1.ConversationID, msgID chat1.MessageID) (chat1.MessageUnboxedValid, chat1.MessagePoll, error) {
+	msg, err := g.ConvSource.GetMessage(ctx, convID, uid, msgID, nil, nil, true)
+	if err != nil {
+		return chat1.MessageUnboxedValid{}, chat1.MessagePoll{}, err
+	}
+	if !msg.IsValid() {
+		return chat1.MessageUnboxedValid{}, chat1.MessagePoll{}, errors.New("poll message is invalid")
+	}
+	valid := msg.Valid()
+	if !valid.MessageBody.IsType(chat1.MessageType_POLL) {
+		return chat1.MessageUnboxedValid{}, chat1.MessagePoll{}, fmt.Errorf(
+			"message %d is not a poll", msgID)
+	}
+	return valid, valid.MessageBody.Poll(), nil
+}
+
+// pollVoteChanges returns the options to vote for, and the options to take
+// back the current vote for, to go from voted to options.
+func pollVoteChanges(poll chat1.MessagePoll, voted map[int]bool, options []int) (
+	add, remove []int, err error) {
+	if !poll.MultiSelect && len(options) > 1 {
+		return nil, nil, errors.New("only one option can be picked in this poll")
+	}
+	want := make(map[int]bool)
+	for _, option := range options {
+		if option < 0 || option >= len(poll.Options) {
+			return nil, nil, fmt.Errorf("the poll has no option %d", option+1)
+		}
+		want[option] = true
+	}
+	for option := range want {
+		if !voted[option] {
+			add = append(add, option)
+		}
+	}
+	for option := range voted {
+		if !want[option] {
+			remove = append(remove, option)
+		}
+	}
+	sort.Ints(add)
+	sort.Ints(remove)
+	return add, remove, nil
+}
+
+// pollVotesFromUser returns the options username currently has a vote for.
+func pollVotesFromUser(reactions chat1.ReactionMap, username string) map[int]bool {
+	voted := make(map[int]bool)
+	for body, users := range reactions.Reactions {
+		option, ok := parsePollVoteReaction(body)
+		if !ok {
+			continue
+		}
+		if _, ok := users[username]; ok {
+			voted[option] = true
+		}
+	}
+	return voted
+}
+
+// votePoll changes the current user's vote on the poll msgID to options. A
+// reaction the user already has is toggled off by sending it again, so
+// taking back a vote and voting are the same message.
+func votePoll(ctx context.Context, g *globals.Context, sender types.Sender, uid gregor1.UID,
+	convID chat1.ConversationID, msgID chat1.MessageID, options []int) error {
+	valid, poll, err := getPoll(ctx, g, uid, convID, msgID)
+	if err != nil {
+		return err
+	}
+	username := g.Env.GetUsername().String()
+	add, remove, err := pollVoteChanges(poll, pollVotesFromUser(valid.Reactions, username), options)
+	if err != nil {
+		return err
+	}
+	for _, option := range append(remove, add...) {
+		msg := chat1.MessagePlaintext{
+			ClientHeader: chat1.MessageClientHeader{
+				MessageType: chat1.MessageType_REACTION,
+				Supersedes:  msgID,
+				TlfName:     valid.ClientHeader.TlfName,
+				TlfPublic:   valid.ClientHeader.TlfPublic,
+			},
+			MessageBody: chat1.NewMessageBodyWithReaction(chat1.MessageReaction{
+				MessageID: msgID,
+				Body:      pollVoteReaction(option),
+			}),
+		}
+		if _, _, err := sender.Send(ctx, convID, msg, 0, nil, nil, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package chat
+
+import (
+	"testing"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPollVoteReaction(t *testing.T) {
+	option, ok := parsePollVoteReaction(pollVoteReaction(3))
+	require.True(t, ok)
+	require.Equal(t, 3, option)
+	for _, body := range []string{":+1:", "poll:", "poll:x", "poll:-1"} {
+		_, ok := parsePollVoteReaction(body)
+		require.False(t, ok, body)
+	}
+}
+
+func TestTallyPoll(t *testing.T) {
+	reactions := chat1.ReactionMap{
+		Reactions: map[string]map[string]chat1.Reaction{
+			pollVoteReaction(0): {
+				"alice": {ReactionMsgID: 10},
+				"bob":   {ReactionMsgID: 11},
+			},
+			pollVoteReaction(1): {
+				"bob":     {ReactionMsgID: 12},
+				"charlie": {ReactionMsgID: 13},
+			},
+			// Not votes, or not for an option the poll has.
+			":+1:":              {"dave": {ReactionMsgID: 14}},
+			pollVoteReaction(5): {"erin": {ReactionMsgID: 15}},
+		},
+	}
+	poll := chat1.MessagePoll{
+		Question: "Lunch?",
+		Options:  []string{"tacos", "pho", "salad"},
+	}
+
+	// Only bob's later vote counts.
+	res := tallyPoll(7, poll, reactions)
+	require.Equal(t, chat1.MessageID(7), res.MsgID)
+	require.Equal(t, 3, res.TotalVoters)
+	require.Equal(t, []string{"alice"}, res.Options[0].Voters)
+	require.Equal(t, []string{"bob", "charlie"}, res.Options[1].Voters)
+	require.Equal(t, []string{}, res.Options[2].Voters)
+	require.Equal(t, "salad", res.Options[2].Option)
+
+	poll.MultiSelect = true
+	res = tallyPoll(7, poll, reactions)
+	require.Equal(t, 3, res.TotalVoters)
+	require.Equal(t, []string{"alice", "bob"}, res.Options[0].Voters)
+	require.Equal(t, []string{"bob", "charlie"}, res.Options[1].Voters)
+}
+
+func TestPollVoteChanges(t *testing.T) {
+	poll := chat1.MessagePoll{Options: []string{"a", "b", "c"}}
+	add, remove, err := pollVoteChanges(poll, map[int]bool{0: true}, []int{2})
+	require.NoError(t, err)
+	require.Equal(t, []int{2}, add)
+	require.Equal(t, []int{0}, remove)
+
+	add, remove, err = pollVoteChanges(poll, map[int]bool{0: true}, nil)
+	require.NoError(t, err)
+	require.Empty(t, add)
+	require.Equal(t, []int{0}, remove)
+
+	_, _, err = pollVoteChanges(poll, nil, []int{0, 1})
+	require.Error(t, err)
+	_, _, err = pollVoteChanges(poll, nil, []int{3})
+	require.Error(t, err)
+
+	poll.MultiSelect = true
+	add, remove, err = pollVoteChanges(poll, map[int]bool{0: true, 1: true}, []int{1, 2})
+	require.NoError(t, err)
+	require.Equal(t, []int{2}, add)
+	require.Equal(t, []int{0}, remove)
+}
@@ -211,6 +211,12 @@ type PushHandler struct {
 	identNotifier types.IdentifyNotifier
 	orderer       *gregorMessageOrderer
 	typingMonitor *TypingMonitor
+	ri            func() chat1.RemoteInterface
+	// a slot for each membership event send in progress
+	membershipEventSlots chan struct{}
+	// teams whose incident mode settings are being loaded
+	incidentModeMu        sync.Mutex
+	incidentModeRefreshes map[keybase1.TeamID]bool
 
 	// testing only
 	testingIgnoreBroadcasts bool
@@ -218,16 +224,24 @@ type PushHandler struct {
 
 func NewPushHandler(g *globals.Context) *PushHandler {
 	p := &PushHandler{
-		Contextified:  globals.NewContextified(g),
-		DebugLabeler:  utils.NewDebugLabeler(g.ExternalG(), "PushHandler", false),
-		identNotifier: NewCachingIdentifyNotifier(g),
-		orderer:       newGregorMessageOrderer(g),
-		typingMonitor: NewTypingMonitor(g),
+		Contextified:          globals.NewContextified(g),
+		DebugLabeler:          utils.NewDebugLabeler(g.ExternalG(), "PushHandler", false),
+		identNotifier:         NewCachingIdentifyNotifier(g),
+		orderer:               newGregorMessageOrderer(g),
+		typingMonitor:         NewTypingMonitor(g),
+		membershipEventSlots:  make(chan struct{}, maxMembershipEventSends),
+		incidentModeRefreshes: make(map[keybase1.TeamID]bool),
 	}
 	p.identNotifier.ResetOnGUIConnect()
 	return p
 }
 
+// SetRemoteInterface lets the handler load team settings that decide
+// whether to notify, like incident mode. Without it, those are skipped.
+func (g *PushHandler) SetRemoteInterface(ri func() chat1.RemoteInterface) {
+	g.ri = ri
+}
+
 func (g *PushHandler) Start(ctx context.Context, _ gregor1.UID) {
 	defer g.Trace(ctx, nil, "Start")()
 	g.startMu.Lock()
@@ -255,6 +269,61 @@ func (g *PushHandler) Stop(ctx context.Context) chan struct{} {
 	return ch
 }
 
+// sendMembershipEvents sends the events for msg, if the team has a membership
+// hook, in the background with the handler's other work, so Stop waits for
+// it. If too many sends are already going, msg's events are dropped rather
+// than holding up the handler.
+func (g *PushHandler) sendMembershipEvents(ctx context.Context, uid gregor1.UID,
+	conv *chat1.ConversationLocal, msg chat1.MessageUnboxed) {
+	if g.ri == nil || !mayHaveMembershipEvents(conv, msg) {
+		return
+	}
+	select {
+	case g.membershipEventSlots <- struct{}{}:
+	default:
+		g.Debug(ctx, "sendMembershipEvents: too many sends in progress, dropping events for %d",
+			msg.GetMessageID())
+		return
+	}
+	ctx = globals.BackgroundChatCtx(ctx, g.G())
+	g.eg.Go(func() error {
+		defer func() { <-g.membershipEventSlots }()
+		ctx, cancel := context.WithTimeout(ctx, membershipEventsTimeout)
+		defer cancel()
+		sendMembershipEventsFromMessage(ctx, g.G(), g.ri, uid, conv, msg)
+		return nil
+	})
+}
+
+// refreshIncidentMode loads teamID's incident mode settings in the
+// background, so the next message in one of its muted channels knows
+// whether to notify. There's only one load going per team at a time.
+func (g *PushHandler) refreshIncidentMode(ctx context.Context, uid gregor1.UID, teamID keybase1.TeamID) {
+	if g.ri == nil {
+		return
+	}
+	g.incidentModeMu.Lock()
+	defer g.incidentModeMu.Unlock()
+	if g.incidentModeRefreshes[teamID] {
+		return
+	}
+	g.incidentModeRefreshes[teamID] = true
+	ctx = globals.BackgroundChatCtx(ctx, g.G())
+	g.eg.Go(func() error {
+		defer func() {
+			g.incidentModeMu.Lock()
+			defer g.incidentModeMu.Unlock()
+			delete(g.incidentModeRefreshes, teamID)
+		}()
+		ctx, cancel := context.WithTimeout(ctx, incidentModeRefreshTimeout)
+		defer cancel()
+		if _, err := incidentMode.refresh(ctx, g.G(), g.ri, uid, teamID, g.G().Clock().Now()); err != nil {
+			g.Debug(ctx, "refreshIncidentMode: unable to load settings: %v", err)
+		}
+		return nil
+	})
+}
+
 func (g *PushHandler) SetClock(clock clockwork.Clock) {
 	g.orderer.SetClock(clock)
 }
@@ -399,7 +468,23 @@ func (g *PushHandler) shouldDisplayDesktopNotification(ctx context.Context,
 	if conv == nil || conv.Notifications == nil {
 		return false
 	}
+	incidentMode := false
 	if !utils.GetConversationStatusBehavior(conv.Info.Status).DesktopNotifications {
+		if conv.Info.Status != chat1.ConversationStatus_MUTED {
+			return false
+		}
+		active, teamID, refresh := inIncidentMode(g.G(), *conv)
+		if refresh {
+			g.refreshIncidentMode(ctx, uid, teamID)
+		}
+		if !active {
+			return false
+		}
+		// Incident mode overrides the mute.
+		incidentMode = true
+	}
+	if !ShouldNotifyThisDevice(ctx, g.G(), uid) {
+		g.Debug(ctx, "shouldDisplayDesktopNotification: routed away from this device")
 		return false
 	}
 	if msg.IsValid() {
@@ -437,7 +522,7 @@ func (g *PushHandler) shouldDisplayDesktopNotification(ctx context.Context,
 			}
 
 			// Check for generic hit on desktop right off and return true if we hit
-			if conv.Notifications.Settings[apptype][kind] {
+			if conv.Notifications.Settings[apptype][kind] || incidentMode {
 				return true
 			}
 			for _, at := range msg.Valid().AtMentions {
@@ -610,6 +695,11 @@ func (g *PushHandler) Activity(ctx context.Context, m gregor.OutOfBandMessage) (
 					g.Debug(ctx, "chat activity: error making page: %v", err)
 				}
 
+				updateNotificationRoutingFromMessage(ctx, g.G(), uid, conv, decmsg)
+				updateMentionDigestSettingsFromMessage(ctx, g.G(), uid, conv, decmsg)
+				updateWidgetFeedFromMessage(ctx, g.G(), uid, conv, decmsg)
+				invalidateTeamPoliciesFromMessage(g.G(), conv)
+				g.sendMembershipEvents(ctx, uid, conv, decmsg)
 				desktopNotification := g.shouldDisplayDesktopNotification(ctx, uid, conv, decmsg, nm.UntrustedTeamRole)
 				notificationSnippet := ""
 				if desktopNotification {
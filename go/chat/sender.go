@@ -447,6 +447,13 @@ func (s *BlockingSender) processReactionMessage(ctx context.Context, uid gregor1
 		msg.MessageBody = chat1.NewMessageBodyWithDelete(chat1.MessageDelete{
 			MessageIDs: []chat1.MessageID{reactionMsgID},
 		})
+	} else if _, isVote := parsePollVoteReaction(msg.MessageBody.Reaction().Body); isVote {
+		// poll votes aren't popular reactions, and don't notify the poll's
+		// author
+		s.Debug(ctx, "processReactionMessage: poll vote")
+	} else if isTaskUpdateReaction(msg.MessageBody.Reaction().Body) {
+		// same for task updates
+		s.Debug(ctx, "processReactionMessage: task update")
 	} else {
 		// bookkeep the reaction used so we can keep track of the user's
 		// popular reactions in the UI
@@ -849,6 +856,18 @@ func (s *BlockingSender) Prepare(ctx context.Context, plaintext chat1.MessagePla
 			return res, err
 		}
 
+		// Check the team's restrictions on new members
+		if err = s.checkNewMemberRestrictions(ctx, uid, *conv, msg); err != nil {
+			s.Debug(ctx, "Prepare: new member restriction: %s", err)
+			return res, err
+		}
+
+		// Check the channel's trusted device policy
+		if err = s.checkTrustedDevicePolicy(ctx, uid, *conv, msg); err != nil {
+			s.Debug(ctx, "Prepare: trusted device policy: %s", err)
+			return res, err
+		}
+
 		// Add and check prev pointers
 		msg, err = s.addPrevPointersAndCheckConvID(ctx, msg, *conv)
 		if err != nil {
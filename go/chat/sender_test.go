@@ -284,6 +284,7 @@ func setupTest(t *testing.T, numUsers int) (context.Context, *kbtest.ChatMockWor
 
 	g.EphemeralTracker = NewEphemeralTracker(g)
 	g.EphemeralTracker.Start(context.TODO(), uid)
+	g.TeamPolicyCache = NewTeamPolicyCache()
 	purger := NewBackgroundEphemeralPurger(g)
 	purger.SetClock(world.Fc)
 	g.EphemeralPurger = purger
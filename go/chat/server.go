@@ -314,6 +314,7 @@ func (h *Server) GetInboxAndUnboxLocal(ctx context.Context, arg chat1.GetInboxAn
 	default:
 		return res, err
 	}
+	fillChannelBanners(ctx, h.G(), h.remoteClient, uid, ib.Convs)
 
 	return chat1.GetInboxAndUnboxLocalRes{
 		Conversations:    ib.Convs,
@@ -628,6 +629,129 @@ func (h *Server) GetInboxSummaryForCLILocal(ctx context.Context, arg chat1.GetIn
 	return res, nil
 }
 
+// sortRemoteConvsForCLI sorts convs newest first, with the unread ones
+// first for InboxQueryForCLISort_UNREAD_FIRST. Ties are broken by
+// conversation ID, so the order doesn't change between calls.
+func sortRemoteConvsForCLI(convs []types.RemoteConversation, order chat1.InboxQueryForCLISort) {
+	sort.Slice(convs, func(i, j int) bool {
+		a, b := convs[i], convs[j]
+		if order == chat1.InboxQueryForCLISort_UNREAD_FIRST {
+			aUnread := a.Conv.IsUnreadFromMsgID(a.GetReadMsgID())
+			bUnread := b.Conv.IsUnreadFromMsgID(b.GetReadMsgID())
+			if aUnread != bUnread {
+				return aUnread
+			}
+		}
+		if aMtime, bMtime := utils.GetConvMtime(a), utils.GetConvMtime(b); aMtime != bMtime {
+			return aMtime > bMtime
+		}
+		return a.ConvIDStr < b.ConvIDStr
+	})
+}
+
+// sortConvLocalsByNameForCLI sorts convs by their TLF name, and then by
+// channel.
+func sortConvLocalsByNameForCLI(convs []chat1.ConversationLocal) {
+	sort.Slice(convs, func(i, j int) bool {
+		a, b := convs[i], convs[j]
+		if a.Info.TlfName != b.Info.TlfName {
+			return a.Info.TlfName < b.Info.TlfName
+		}
+		if a.Info.TopicName != b.Info.TopicName {
+			return a.Info.TopicName < b.Info.TopicName
+		}
+		return a.GetConvID().String() < b.GetConvID().String()
+	})
+}
+
+func (h *Server) QueryInboxForCLILocal(ctx context.Context, arg chat1.InboxQueryForCLI) (res chat1.InboxQueryForCLIRes, err error) {
+	var identBreaks []keybase1.TLFIdentifyFailure
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_CLI, &identBreaks,
+		h.identNotifier)
+	defer h.Trace(ctx, &err, "QueryInboxForCLILocal")()
+	defer func() { h.setResultRateLimit(ctx, &res) }()
+	defer func() { err = h.handleOfflineError(ctx, err, &res) }()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return res, err
+	}
+	if _, ok := chat1.InboxQueryForCLISortRevMap[arg.Sort]; !ok {
+		return res, fmt.Errorf("unknown sort order %v", arg.Sort)
+	}
+
+	var query chat1.GetInboxQuery
+	query.ComputeActiveList = true
+	if len(arg.After) > 0 {
+		after, err := utils.ParseTimeFromRFC3339OrDurationFromPast(h.G(), arg.After)
+		if err != nil {
+			return res, fmt.Errorf("parsing time or duration (%s) error: %s", arg.After, err)
+		}
+		gafter := gregor1.ToTime(after)
+		query.After = &gafter
+	}
+	if len(arg.Before) > 0 {
+		before, err := utils.ParseTimeFromRFC3339OrDurationFromPast(h.G(), arg.Before)
+		if err != nil {
+			return res, fmt.Errorf("parsing time or duration (%s) error: %s", arg.Before, err)
+		}
+		gbefore := gregor1.ToTime(before)
+		query.Before = &gbefore
+	}
+	if arg.TopicType != chat1.TopicType_NONE {
+		query.TopicType = &arg.TopicType
+	}
+	if arg.Visibility != keybase1.TLFVisibility_ANY {
+		query.TlfVisibility = &arg.Visibility
+	}
+	if len(arg.TeamName) > 0 {
+		nameInfo, err := CreateNameInfoSource(ctx, h.G(), chat1.ConversationMembersType_TEAM).LookupID(ctx,
+			arg.TeamName, false)
+		if err != nil {
+			return res, err
+		}
+		query.TlfID = &nameInfo.ID
+		query.MembersTypes = []chat1.ConversationMembersType{chat1.ConversationMembersType_TEAM}
+	} else {
+		// Without a team, a team is listed once, like in the rest of the
+		// CLI.
+		query.OneChatTypePerTLF = new(bool)
+		*query.OneChatTypePerTLF = true
+	}
+	query.Status = arg.Status
+	query.MemberStatus = arg.MemberStatus
+	query.UnreadOnly = arg.UnreadOnly
+
+	ib, err := h.G().InboxSource.ReadUnverified(ctx, uid, types.InboxSourceDataSourceAll, &query)
+	if err != nil {
+		return res, err
+	}
+	convs := ib.ConvsUnverified
+	if arg.Sort == chat1.InboxQueryForCLISort_NAME {
+		// Names are only known once the conversations are localized.
+		res.Conversations, _, err = h.G().InboxSource.Localize(ctx, uid, convs,
+			types.ConversationLocalizerBlocking)
+		if err != nil {
+			return res, err
+		}
+		sortConvLocalsByNameForCLI(res.Conversations)
+		if arg.Limit > 0 && len(res.Conversations) > arg.Limit {
+			res.Conversations = res.Conversations[:arg.Limit]
+		}
+	} else {
+		sortRemoteConvsForCLI(convs, arg.Sort)
+		if arg.Limit > 0 && len(convs) > arg.Limit {
+			convs = convs[:arg.Limit]
+		}
+		res.Conversations, _, err = h.G().InboxSource.Localize(ctx, uid, convs,
+			types.ConversationLocalizerBlocking)
+		if err != nil {
+			return res, err
+		}
+	}
+	res.Offline = h.G().InboxSource.IsOffline(ctx)
+	return res, nil
+}
+
 func (h *Server) GetConversationForCLILocal(ctx context.Context, arg chat1.GetConversationForCLILocalQuery) (res chat1.GetConversationForCLILocalRes, err error) {
 	var identBreaks []keybase1.TLFIdentifyFailure
 	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_CLI, &identBreaks,
@@ -635,7 +759,8 @@ func (h *Server) GetConversationForCLILocal(ctx context.Context, arg chat1.GetCo
 	defer h.Trace(ctx, &err, "GetConversationForCLILocal")()
 	defer func() { h.setResultRateLimit(ctx, &res) }()
 	defer func() { err = h.handleOfflineError(ctx, err, &res) }()
-	if _, err = utils.AssertLoggedInUID(ctx, h.G()); err != nil {
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
 		return res, err
 	}
 
@@ -647,6 +772,9 @@ func (h *Server) GetConversationForCLILocal(ctx context.Context, arg chat1.GetCo
 	}
 
 	convLocal := arg.Conv
+	convs := []chat1.ConversationLocal{convLocal}
+	fillChannelBanners(ctx, h.G(), h.remoteClient, uid, convs)
+	convLocal = convs[0]
 
 	var since time.Time
 	if arg.Since != nil {
@@ -1299,6 +1427,8 @@ func (h *Server) DownloadAttachmentLocal(ctx context.Context, arg chat1.Download
 		MessageID:        arg.MessageID,
 		Preview:          arg.Preview,
 		IdentifyBehavior: arg.IdentifyBehavior,
+		Offset:           arg.Offset,
+		Length:           arg.Length,
 	}
 	cli := h.getStreamUICli()
 	darg.Sink = libkb.NewRemoteStreamBuffered(arg.Sink, cli, arg.SessionID)
@@ -1388,6 +1518,10 @@ type downloadAttachmentArg struct {
 	Sink             io.WriteCloser
 	Preview          bool
 	IdentifyBehavior keybase1.TLFIdentifyBehavior
+	// Offset and Length select a byte range; both zero means the whole
+	// attachment.
+	Offset int64
+	Length int64
 }
 
 func (h *Server) downloadAttachmentLocal(ctx context.Context, uid gregor1.UID, arg downloadAttachmentArg) (res chat1.DownloadAttachmentLocalRes, err error) {
@@ -1402,8 +1536,14 @@ func (h *Server) downloadAttachmentLocal(ctx context.Context, uid gregor1.UID, a
 	h.Debug(ctx, "downloadAttachmentLocal: fetching asset from attachment message: convID: %s messageID: %d",
 		arg.ConversationID, arg.MessageID)
 
-	err = attachments.Download(ctx, h.G(), uid, arg.ConversationID,
-		arg.MessageID, arg.Sink, arg.Preview, progress, h.remoteClient)
+	var size int64
+	if arg.Offset > 0 || arg.Length > 0 {
+		size, err = attachments.DownloadRange(ctx, h.G(), uid, arg.ConversationID,
+			arg.MessageID, arg.Sink, arg.Preview, arg.Offset, arg.Length, progress, h.remoteClient)
+	} else {
+		err = attachments.Download(ctx, h.G(), uid, arg.ConversationID,
+			arg.MessageID, arg.Sink, arg.Preview, progress, h.remoteClient)
+	}
 	if err != nil {
 		return res, err
 	}
@@ -1412,6 +1552,7 @@ func (h *Server) downloadAttachmentLocal(ctx context.Context, uid gregor1.UID, a
 
 	return chat1.DownloadAttachmentLocalRes{
 		IdentifyFailures: identBreaks,
+		Size:             size,
 	}, nil
 }
 
@@ -1943,6 +2084,138 @@ func (h *Server) SetGlobalAppNotificationSettingsLocal(ctx context.Context,
 	return setGlobalAppNotificationSettings(ctx, h.G(), h.remoteClient, strSettings)
 }
 
+func (h *Server) GetNotificationRoutingPreferences(ctx context.Context) (res chat1.NotificationRoutingPreferences, err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "GetNotificationRoutingPreferences")()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return res, err
+	}
+	return getNotificationRoutingPreferences(ctx, h.G(), h.remoteClient, uid)
+}
+
+func (h *Server) SetNotificationRoutingPreferences(ctx context.Context,
+	prefs chat1.NotificationRoutingPreferences) (err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "SetNotificationRoutingPreferences")()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return err
+	}
+	return setNotificationRoutingPreferences(ctx, h.G(), h.remoteClient, uid, prefs)
+}
+
+func (h *Server) GetMentionDigestSettings(ctx context.Context) (res chat1.MentionDigestSettings, err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "GetMentionDigestSettings")()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return res, err
+	}
+	return getMentionDigestSettings(ctx, h.G(), h.remoteClient, uid)
+}
+
+func (h *Server) SetMentionDigestSettings(ctx context.Context,
+	settings chat1.MentionDigestSettings) (err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "SetMentionDigestSettings")()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return err
+	}
+	return setMentionDigestSettings(ctx, h.G(), h.remoteClient, uid, settings)
+}
+
+func (h *Server) GetMentionDigest(ctx context.Context) (res chat1.MentionDigest, err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "GetMentionDigest")()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return res, err
+	}
+	return GetMentionDigest(ctx, h.G(), h.remoteClient, uid)
+}
+
+func (h *Server) GetIncidentMode(ctx context.Context, teamID keybase1.TeamID) (res chat1.IncidentModeSettings, err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "GetIncidentMode")()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return res, err
+	}
+	return getIncidentMode(ctx, h.G(), h.remoteClient, uid, teamID)
+}
+
+func (h *Server) SetIncidentMode(ctx context.Context, arg chat1.SetIncidentModeArg) (err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "SetIncidentMode(%s, %v)", arg.ConvID, arg.Duration)()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return err
+	}
+	return setIncidentMode(ctx, h.G(), h.remoteClient, uid, arg.ConvID, arg.Duration.ToDuration())
+}
+
+func (h *Server) GetIncidentModeAuditLog(ctx context.Context, teamID keybase1.TeamID) (res chat1.IncidentModeAuditLog, err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "GetIncidentModeAuditLog")()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return res, err
+	}
+	return getIncidentModeAuditLog(ctx, h.G(), h.remoteClient, uid, teamID)
+}
+
+func (h *Server) GetChannelBanner(ctx context.Context, convID chat1.ConversationID) (res *chat1.ChannelBanner, err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "GetChannelBanner(%s)", convID)()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return nil, err
+	}
+	return getChannelBanner(ctx, h.G(), h.remoteClient, uid, convID)
+}
+
+func (h *Server) SetChannelBanner(ctx context.Context, arg chat1.SetChannelBannerArg) (err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "SetChannelBanner(%s, %v)", arg.ConvID, arg.Duration)()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return err
+	}
+	return setChannelBanner(ctx, h.G(), h.remoteClient, uid, arg.ConvID, arg.Text, arg.Duration.ToDuration())
+}
+
+func (h *Server) GetChannelBannerAuditLog(ctx context.Context, teamID keybase1.TeamID) (res chat1.ChannelBannerAuditLog, err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "GetChannelBannerAuditLog")()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return res, err
+	}
+	return getChannelBannerAuditLog(ctx, h.G(), h.remoteClient, uid, teamID)
+}
+
+func (h *Server) GetMembershipHook(ctx context.Context, teamID keybase1.TeamID) (res chat1.MembershipHook, err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "GetMembershipHook")()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return res, err
+	}
+	return getMembershipHook(ctx, h.G(), h.remoteClient, uid, teamID)
+}
+
+func (h *Server) SetMembershipHook(ctx context.Context, arg chat1.SetMembershipHookArg) (err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "SetMembershipHook(%s)", arg.Hook.BotUsername)()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return err
+	}
+	return setMembershipHook(ctx, h.G(), h.remoteClient, uid, arg.TeamID, arg.Hook)
+}
+
 func (h *Server) GetGlobalAppNotificationSettingsLocal(ctx context.Context) (res chat1.GlobalAppNotificationSettings, err error) {
 	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
 	defer h.Trace(ctx, &err, "GetGlobalAppNotificationSettings")()
@@ -4130,3 +4403,547 @@ func (h *Server) ArchiveChatResume(ctx context.Context, arg chat1.ArchiveChatRes
 
 	return h.G().ArchiveRegistry.Resume(ctx, arg.JobID)
 }
+
+func (h *Server) ArchiveChatSetSchedule(ctx context.Context, arg chat1.ArchiveChatSetScheduleArg) (err error) {
+	var identBreaks []keybase1.TLFIdentifyFailure
+	ctx = globals.ChatCtx(ctx, h.G(), arg.IdentifyBehavior, &identBreaks,
+		h.identNotifier)
+	defer h.Trace(ctx, &err, "ArchiveChatSetSchedule")()
+	_, err = utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return err
+	}
+
+	return h.G().ArchiveRegistry.SetSchedule(ctx, arg.JobID, arg.Schedule)
+}
+
+func (h *Server) ArchiveChatExportHistory(ctx context.Context, arg chat1.ArchiveChatExportHistoryArg) (err error) {
+	var identBreaks []keybase1.TLFIdentifyFailure
+	ctx = globals.ChatCtx(ctx, h.G(), arg.IdentifyBehavior, &identBreaks,
+		h.identNotifier)
+	defer h.Trace(ctx, &err, "ArchiveChatExportHistory")()
+	_, err = utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return err
+	}
+	if len(arg.OutputPath) == 0 {
+		return errors.New("output path required")
+	}
+
+	export, err := h.G().ArchiveRegistry.Export(ctx, arg.Plaintext)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return err
+	}
+	// Even a sealed export says when jobs ran, so keep it to ourselves, and
+	// don't clobber anything.
+	f, err := os.OpenFile(arg.OutputPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (h *Server) ArchiveChatImportHistory(ctx context.Context, arg chat1.ArchiveChatImportHistoryArg) (res chat1.ArchiveChatImportHistoryRes, err error) {
+	var identBreaks []keybase1.TLFIdentifyFailure
+	ctx = globals.ChatCtx(ctx, h.G(), arg.IdentifyBehavior, &identBreaks,
+		h.identNotifier)
+	defer h.Trace(ctx, &err, "ArchiveChatImportHistory")()
+	_, err = utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return res, err
+	}
+
+	data, err := os.ReadFile(arg.InputPath)
+	if err != nil {
+		return res, err
+	}
+	var export chat1.ArchiveChatHistoryExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return res, fmt.Errorf("%s is not a job history export: %v", arg.InputPath, err)
+	}
+	return h.G().ArchiveRegistry.Import(ctx, export)
+}
+
+func (h *Server) ArchiveChatSearch(ctx context.Context, arg chat1.ArchiveChatSearchArg) (res chat1.ArchiveChatSearchRes, err error) {
+	var identBreaks []keybase1.TLFIdentifyFailure
+	ctx = globals.ChatCtx(ctx, h.G(), arg.IdentifyBehavior, &identBreaks,
+		h.identNotifier)
+	defer h.Trace(ctx, &err, "ArchiveChatSearch")()
+	_, err = utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return res, err
+	}
+
+	return h.G().ArchiveRegistry.Search(ctx, arg.Query, arg.MaxHits)
+}
+
+func (h *Server) ArchiveChatVerify(ctx context.Context, arg chat1.ArchiveChatVerifyArg) (res chat1.ArchiveChatVerifyRes, err error) {
+	var identBreaks []keybase1.TLFIdentifyFailure
+	ctx = globals.ChatCtx(ctx, h.G(), arg.IdentifyBehavior, &identBreaks,
+		h.identNotifier)
+	defer h.Trace(ctx, &err, "ArchiveChatVerify")()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return res, err
+	}
+
+	return NewChatArchiver(h.G(), uid, h.remoteClient).VerifyArchive(ctx, arg.JobID)
+}
+
+func (h *Server) ArchiveChatImport(ctx context.Context, arg chat1.ArchiveChatImportArg) (res chat1.ArchiveChatImportRes, err error) {
+	var identBreaks []keybase1.TLFIdentifyFailure
+	ctx = globals.ChatCtx(ctx, h.G(), arg.IdentifyBehavior, &identBreaks,
+		h.identNotifier)
+	defer h.Trace(ctx, &err, "ArchiveChatImport")()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return res, err
+	}
+	if len(arg.InputPath) == 0 {
+		return res, errors.New("input path required")
+	}
+
+	return ImportArchive(ctx, h.G(), uid, arg.InputPath)
+}
+
+func (h *Server) ArchiveChatListImported(ctx context.Context, identifyBehavior keybase1.TLFIdentifyBehavior) (res []chat1.ArchiveChatImportedConv, err error) {
+	var identBreaks []keybase1.TLFIdentifyFailure
+	ctx = globals.ChatCtx(ctx, h.G(), identifyBehavior, &identBreaks,
+		h.identNotifier)
+	defer h.Trace(ctx, &err, "ArchiveChatListImported")()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return res, err
+	}
+
+	return ListImportedArchiveConvs(ctx, h.G(), uid)
+}
+
+func (h *Server) ArchiveChatGetImportedThread(ctx context.Context, arg chat1.ArchiveChatGetImportedThreadArg) (res chat1.ArchiveChatImportedThread, err error) {
+	var identBreaks []keybase1.TLFIdentifyFailure
+	ctx = globals.ChatCtx(ctx, h.G(), arg.IdentifyBehavior, &identBreaks,
+		h.identNotifier)
+	defer h.Trace(ctx, &err, "ArchiveChatGetImportedThread")()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return res, err
+	}
+
+	return GetImportedArchiveThread(ctx, h.G(), uid, arg.Id, arg.BeforeMsgID, arg.Num)
+}
+
+func (h *Server) ArchiveChatDeleteImported(ctx context.Context, arg chat1.ArchiveChatDeleteImportedArg) (err error) {
+	var identBreaks []keybase1.TLFIdentifyFailure
+	ctx = globals.ChatCtx(ctx, h.G(), arg.IdentifyBehavior, &identBreaks,
+		h.identNotifier)
+	defer h.Trace(ctx, &err, "ArchiveChatDeleteImported")()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return err
+	}
+
+	return DeleteImportedArchiveConv(ctx, h.G(), uid, arg.Id)
+}
+
+func (h *Server) SearchChatHistory(ctx context.Context, arg chat1.SearchChatHistoryArg) (res chat1.ChatHistorySearchRes, err error) {
+	var identBreaks []keybase1.TLFIdentifyFailure
+	ctx = globals.ChatCtx(ctx, h.G(), arg.IdentifyBehavior, &identBreaks,
+		h.identNotifier)
+	defer h.Trace(ctx, &err, "SearchChatHistory")()
+	defer h.suspendBgConvLoads(ctx)()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return res, err
+	}
+
+	res, err = searchChatHistory(h.getInboxSearchContext(ctx), h.G(), uid, arg.Query, arg.MaxHits)
+	if err != nil {
+		return res, err
+	}
+	res.IdentifyFailures = identBreaks
+	return res, nil
+}
+
+func (h *Server) GetConversationContacts(ctx context.Context, arg chat1.GetConversationContactsArg) (res chat1.ConversationContactsRes, err error) {
+	var identBreaks []keybase1.TLFIdentifyFailure
+	ctx = globals.ChatCtx(ctx, h.G(), arg.IdentifyBehavior, &identBreaks, h.identNotifier)
+	defer h.Trace(ctx, &err, "GetConversationContacts")()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return res, err
+	}
+	conv, err := utils.GetVerifiedConv(ctx, h.G(), uid, arg.ConvID, types.InboxSourceDataSourceAll)
+	if err != nil {
+		return res, err
+	}
+	contacts, err := getConversationContacts(ctx, h.G(), conv)
+	if err != nil {
+		return res, err
+	}
+	return chat1.ConversationContactsRes{
+		Vcards:           formatVCards(contacts),
+		Count:            len(contacts),
+		IdentifyFailures: identBreaks,
+	}, nil
+}
+
+func (h *Server) GetArchiveRedactionRules(ctx context.Context, teamID keybase1.TeamID) (res chat1.ArchiveRedactionRules, err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "GetArchiveRedactionRules")()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return res, err
+	}
+	return getArchiveRedactionRules(ctx, h.G(), h.remoteClient, uid, teamID)
+}
+
+func (h *Server) SetArchiveRedactionRules(ctx context.Context, arg chat1.SetArchiveRedactionRulesArg) (err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "SetArchiveRedactionRules")()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return err
+	}
+	return setArchiveRedactionRules(ctx, h.G(), h.remoteClient, uid, arg.TeamID, arg.Rules)
+}
+
+func (h *Server) GetNewMemberRestrictions(ctx context.Context, teamID keybase1.TeamID) (res chat1.NewMemberRestrictions, err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "GetNewMemberRestrictions")()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return res, err
+	}
+	return newMemberRestrictions.load(ctx, h.G(), h.remoteClient, uid, teamID)
+}
+
+func (h *Server) SetNewMemberRestrictions(ctx context.Context, arg chat1.SetNewMemberRestrictionsArg) (err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "SetNewMemberRestrictions")()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return err
+	}
+	return setNewMemberRestrictions(ctx, h.G(), h.remoteClient, uid, arg.TeamID, arg.Restrictions)
+}
+
+func (h *Server) SetNewMemberRestrictionsExempt(ctx context.Context, arg chat1.SetNewMemberRestrictionsExemptArg) (err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "SetNewMemberRestrictionsExempt(%s, %v)", arg.Username, arg.Exempt)()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return err
+	}
+	return setNewMemberRestrictionsExempt(ctx, h.G(), h.remoteClient, uid, arg.TeamID, arg.Username, arg.Exempt)
+}
+
+func (h *Server) GetTrustedDevicePolicy(ctx context.Context, convID chat1.ConversationID) (res *chat1.TrustedDeviceChannelPolicy, err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "GetTrustedDevicePolicy(%s)", convID)()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return nil, err
+	}
+	return getTrustedDevicePolicy(ctx, h.G(), h.remoteClient, uid, convID)
+}
+
+func (h *Server) SetTrustedDevicePolicy(ctx context.Context, arg chat1.SetTrustedDevicePolicyArg) (err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "SetTrustedDevicePolicy(%s, %d)", arg.ConvID, arg.MinDeviceAgeDays)()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return err
+	}
+	return setTrustedDevicePolicy(ctx, h.G(), h.remoteClient, uid, arg.ConvID, arg.MinDeviceAgeDays)
+}
+
+func (h *Server) SetTrustedDeviceApproved(ctx context.Context, arg chat1.SetTrustedDeviceApprovedArg) (err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "SetTrustedDeviceApproved(%s, %s, %s, %v)", arg.ConvID, arg.Username,
+		arg.DeviceName, arg.Approved)()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return err
+	}
+	return setTrustedDeviceApproved(ctx, h.G(), h.remoteClient, uid, arg.ConvID, arg.Username,
+		arg.DeviceName, arg.Approved)
+}
+
+func (h *Server) BulkDeleteMessagesLocal(ctx context.Context, arg chat1.BulkDeleteMessagesLocalArg) (res chat1.BulkDeleteMessagesRes, err error) {
+	var identBreaks []keybase1.TLFIdentifyFailure
+	ctx = globals.ChatCtx(ctx, h.G(), arg.IdentifyBehavior, &identBreaks, h.identNotifier)
+	defer h.Trace(ctx, &err, "BulkDeleteMessagesLocal(%s, dryRun: %v)", arg.ConvID, arg.DryRun)()
+	defer func() { res.IdentifyFailures = identBreaks }()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return res, err
+	}
+	sender := NewBlockingSender(h.G(), h.boxer, h.remoteClient)
+	return bulkDeleteMessages(ctx, h.G(), sender, h.remoteClient, uid, arg)
+}
+
+func (h *Server) GetModerationAuditLog(ctx context.Context, teamID keybase1.TeamID) (res chat1.ModerationAuditLog, err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "GetModerationAuditLog")()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return res, err
+	}
+	return getModerationAuditLog(ctx, h.G(), h.remoteClient, uid, teamID)
+}
+
+func (h *Server) GetAttachmentExpiryPolicy(ctx context.Context, teamID keybase1.TeamID) (res chat1.AttachmentExpiryPolicy, err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "GetAttachmentExpiryPolicy")()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return res, err
+	}
+	return getAttachmentExpiryPolicy(ctx, h.G(), h.remoteClient, uid, teamID)
+}
+
+func (h *Server) SetAttachmentExpiryPolicy(ctx context.Context, arg chat1.SetAttachmentExpiryPolicyArg) (err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "SetAttachmentExpiryPolicy")()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return err
+	}
+	return setAttachmentExpiryPolicy(ctx, h.G(), h.remoteClient, uid, arg.TeamID, arg.Policy)
+}
+
+func (h *Server) ExpireTeamAttachments(ctx context.Context, arg chat1.ExpireTeamAttachmentsArg) (res chat1.AttachmentExpiryReport, err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "ExpireTeamAttachments")()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return res, err
+	}
+	return expireTeamAttachments(ctx, h.G(), h.remoteClient, uid, arg.TeamID, arg.DryRun)
+}
+
+func (h *Server) SplitConversationLocal(ctx context.Context, arg chat1.SplitConversationLocalArg) (res chat1.SplitConversationLocalRes, err error) {
+	var identBreaks []keybase1.TLFIdentifyFailure
+	ctx = globals.ChatCtx(ctx, h.G(), arg.IdentifyBehavior, &identBreaks, h.identNotifier)
+	defer h.Trace(ctx, &err, "SplitConversationLocal")()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return res, err
+	}
+	res, err = NewConversationSplitter(h.G(), uid, h).Split(ctx, arg)
+	if err != nil {
+		return res, err
+	}
+	res.IdentifyFailures = identBreaks
+	return res, nil
+}
+
+func (h *Server) PostPoll(ctx context.Context, arg chat1.PostPollArg) (res chat1.PostLocalRes, err error) {
+	defer h.Trace(ctx, &err, "PostPoll")()
+	options := make([]string, 0, len(arg.Options))
+	for _, option := range arg.Options {
+		options = append(options, strings.TrimSpace(option))
+	}
+	return h.PostLocal(ctx, chat1.PostLocalArg{
+		ConversationID: arg.ConvID,
+		Msg: chat1.MessagePlaintext{
+			ClientHeader: chat1.MessageClientHeader{
+				MessageType: chat1.MessageType_POLL,
+			},
+			MessageBody: chat1.NewMessageBodyWithPoll(chat1.MessagePoll{
+				Question:    strings.TrimSpace(arg.Question),
+				Options:     options,
+				MultiSelect: arg.MultiSelect,
+			}),
+		},
+		IdentifyBehavior: arg.IdentifyBehavior,
+	})
+}
+
+func (h *Server) VotePoll(ctx context.Context, arg chat1.VotePollArg) (err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), arg.IdentifyBehavior, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "VotePoll(%s, %d)", arg.ConvID, arg.MsgID)()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return err
+	}
+	sender := NewBlockingSender(h.G(), h.boxer, h.remoteClient)
+	return votePoll(ctx, h.G(), sender, uid, arg.ConvID, arg.MsgID, arg.Options)
+}
+
+func (h *Server) GetPollResults(ctx context.Context, arg chat1.GetPollResultsArg) (res chat1.PollResults, err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), arg.IdentifyBehavior, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "GetPollResults(%s, %d)", arg.ConvID, arg.MsgID)()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return res, err
+	}
+	valid, poll, err := getPoll(ctx, h.G(), uid, arg.ConvID, arg.MsgID)
+	if err != nil {
+		return res, err
+	}
+	return tallyPoll(arg.MsgID, poll, valid.Reactions), nil
+}
+
+func (h *Server) PostTask(ctx context.Context, arg chat1.PostTaskArg) (res chat1.PostLocalRes, err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), arg.IdentifyBehavior, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "PostTask")()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return res, err
+	}
+	assignee, err := checkTaskAssignee(ctx, h.G(), uid, arg.ConvID, arg.Assignee)
+	if err != nil {
+		return res, err
+	}
+	return h.PostLocal(ctx, chat1.PostLocalArg{
+		ConversationID: arg.ConvID,
+		Msg: chat1.MessagePlaintext{
+			ClientHeader: chat1.MessageClientHeader{
+				MessageType: chat1.MessageType_TASK,
+			},
+			MessageBody: chat1.NewMessageBodyWithTask(chat1.MessageTask{
+				Title:    strings.TrimSpace(arg.Title),
+				Assignee: assignee,
+			}),
+		},
+		IdentifyBehavior: arg.IdentifyBehavior,
+	})
+}
+
+func (h *Server) AssignTask(ctx context.Context, arg chat1.AssignTaskArg) (err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), arg.IdentifyBehavior, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "AssignTask(%s, %d)", arg.ConvID, arg.MsgID)()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return err
+	}
+	sender := NewBlockingSender(h.G(), h.boxer, h.remoteClient)
+	return assignTask(ctx, h.G(), sender, uid, arg.ConvID, arg.MsgID, arg.Assignee)
+}
+
+func (h *Server) SetTaskDone(ctx context.Context, arg chat1.SetTaskDoneArg) (err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), arg.IdentifyBehavior, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "SetTaskDone(%s, %d, %v)", arg.ConvID, arg.MsgID, arg.Done)()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return err
+	}
+	sender := NewBlockingSender(h.G(), h.boxer, h.remoteClient)
+	return setTaskDone(ctx, h.G(), sender, uid, arg.ConvID, arg.MsgID, arg.Done)
+}
+
+func (h *Server) ListOpenTasks(ctx context.Context, arg chat1.ListOpenTasksArg) (res []chat1.TaskInfo, err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), arg.IdentifyBehavior, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "ListOpenTasks(%s)", arg.ConvID)()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return nil, err
+	}
+	conv, err := utils.GetVerifiedConv(ctx, h.G(), uid, arg.ConvID, types.InboxSourceDataSourceAll)
+	if err != nil {
+		return nil, err
+	}
+	return listOpenTasks(ctx, h.G(), uid, conv)
+}
+
+func (h *Server) ListOpenTeamTasks(ctx context.Context, teamID keybase1.TeamID) (res []chat1.TaskInfo, err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "ListOpenTeamTasks(%s)", teamID)()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return nil, err
+	}
+	return listOpenTeamTasks(ctx, h.G(), uid, teamID)
+}
+
+func (h *Server) EnableWidgetFeed(ctx context.Context, arg chat1.EnableWidgetFeedArg) (err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "EnableWidgetFeed(%d)", arg.MaxRecent)()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return err
+	}
+	return enableWidgetFeed(ctx, h.G(), uid, arg.Key, arg.MaxRecent)
+}
+
+func (h *Server) DisableWidgetFeed(ctx context.Context) (err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "DisableWidgetFeed")()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return err
+	}
+	return disableWidgetFeed(ctx, h.G(), uid)
+}
+
+func (h *Server) PinWidgetFeedMessage(ctx context.Context, arg chat1.PinWidgetFeedMessageArg) (err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "PinWidgetFeedMessage(%s, %d, %v)", arg.ConvID, arg.MsgID, arg.Pinned)()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return err
+	}
+	return pinWidgetFeedMessage(ctx, h.G(), h.remoteClient, uid, arg.ConvID, arg.MsgID, arg.Pinned)
+}
+
+func (h *Server) GetWidgetFeed(ctx context.Context) (res chat1.WidgetFeed, err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "GetWidgetFeed")()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return res, err
+	}
+	return getWidgetFeed(ctx, h.G(), uid)
+}
+
+func (h *Server) AcknowledgeIdentityChanges(ctx context.Context, convID chat1.ConversationID) (err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "AcknowledgeIdentityChanges(%s)", convID)()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return err
+	}
+	return acknowledgeIdentityChanges(ctx, h.G(), uid, convID)
+}
+
+func (h *Server) GetAttachmentAutoDownloadPolicy(ctx context.Context) (res chat1.AttachmentAutoDownloadPolicy, err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "GetAttachmentAutoDownloadPolicy")()
+	return attachments.GetAutoDownloadPolicy(ctx, h.G())
+}
+
+func (h *Server) SetAttachmentAutoDownloadPolicy(ctx context.Context,
+	policy chat1.AttachmentAutoDownloadPolicy) (err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "SetAttachmentAutoDownloadPolicy")()
+	return attachments.SetAutoDownloadPolicy(ctx, h.G(), policy)
+}
+
+func (h *Server) GetTeamReadme(ctx context.Context, teamID keybase1.TeamID) (res chat1.TeamReadme, err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "GetTeamReadme")()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return res, err
+	}
+	return getTeamReadme(ctx, h.G(), h.remoteClient, uid, teamID)
+}
+
+func (h *Server) SetTeamReadme(ctx context.Context, arg chat1.SetTeamReadmeArg) (err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "SetTeamReadme")()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return err
+	}
+	return setTeamReadme(ctx, h.G(), h.remoteClient, uid, arg.TeamID, arg.Readme)
+}
@@ -474,6 +474,7 @@ func (c *chatTestContext) as(t *testing.T, user *kbtest.FakeUser) *chatTestUserC
 	g.EmojiSource = NewDevConvEmojiSource(g, func() chat1.RemoteInterface { return ri })
 	g.EphemeralTracker = NewEphemeralTracker(g)
 	g.EphemeralTracker.Start(context.TODO(), uid)
+	g.TeamPolicyCache = NewTeamPolicyCache()
 
 	tc.G.ChatHelper = NewHelper(g, func() chat1.RemoteInterface { return ri })
 
@@ -1899,6 +1900,56 @@ func TestChatSrvGetInboxSummaryForCLILocal(t *testing.T) {
 	})
 }
 
+func TestChatSrvQueryInboxForCLILocal(t *testing.T) {
+	runWithMemberTypes(t, func(mt chat1.ConversationMembersType) {
+		ctc := makeChatTestContext(t, "QueryInboxForCLILocal", 4)
+		defer ctc.cleanup()
+		users := ctc.users()
+
+		withUser1 := mustCreateConversationForTest(t, ctc, users[0], chat1.TopicType_CHAT,
+			mt, ctc.as(t, users[1]).user())
+		mustPostLocalForTest(t, ctc, users[1], withUser1, chat1.NewMessageBodyWithText(chat1.MessageText{Body: "hello"}))
+		withUser2 := mustCreateConversationForTest(t, ctc, users[0], chat1.TopicType_CHAT,
+			mt, ctc.as(t, users[2]).user())
+		mustPostLocalForTest(t, ctc, users[0], withUser2, chat1.NewMessageBodyWithText(chat1.MessageText{Body: "hello"}))
+		withUser3 := mustCreateConversationForTest(t, ctc, users[0], chat1.TopicType_CHAT,
+			mt, ctc.as(t, users[3]).user())
+		mustPostLocalForTest(t, ctc, users[0], withUser3, chat1.NewMessageBodyWithText(chat1.MessageText{Body: "hello"}))
+
+		ctx := ctc.as(t, users[0]).startCtx
+		query := func(q chat1.InboxQueryForCLI) []chat1.ConversationLocal {
+			q.TopicType = chat1.TopicType_CHAT
+			res, err := ctc.as(t, users[0]).chatLocalHandler().QueryInboxForCLILocal(ctx, q)
+			require.NoError(t, err)
+			return res.Conversations
+		}
+
+		convs := query(chat1.InboxQueryForCLI{Limit: 2})
+		require.Len(t, convs, 2)
+		require.True(t, convs[0].Info.Id.Eq(withUser3.Id))
+		require.True(t, convs[1].Info.Id.Eq(withUser2.Id))
+
+		convs = query(chat1.InboxQueryForCLI{UnreadOnly: true})
+		require.Len(t, convs, 1)
+		require.True(t, convs[0].Info.Id.Eq(withUser1.Id))
+
+		convs = query(chat1.InboxQueryForCLI{Sort: chat1.InboxQueryForCLISort_UNREAD_FIRST})
+		require.Len(t, convs, 3)
+		require.True(t, convs[0].Info.Id.Eq(withUser1.Id))
+		require.True(t, convs[1].Info.Id.Eq(withUser3.Id))
+
+		convs = query(chat1.InboxQueryForCLI{Sort: chat1.InboxQueryForCLISort_NAME})
+		require.Len(t, convs, 3)
+		require.True(t, sort.SliceIsSorted(convs, func(i, j int) bool {
+			return convs[i].Info.TlfName < convs[j].Info.TlfName
+		}))
+
+		_, err := ctc.as(t, users[0]).chatLocalHandler().QueryInboxForCLILocal(ctx,
+			chat1.InboxQueryForCLI{Sort: chat1.InboxQueryForCLISort(100)})
+		require.Error(t, err)
+	})
+}
+
 func TestChatSrvGetMessagesLocal(t *testing.T) {
 	runWithMemberTypes(t, func(mt chat1.ConversationMembersType) {
 		ctc := makeChatTestContext(t, "GetMessagesLocal", 2)
@@ -8385,3 +8436,67 @@ func TestChatSrvGetLastActiveAt(t *testing.T) {
 		require.NotZero(t, lastActiveAt)
 	})
 }
+
+func TestChatSrvSplitConversation(t *testing.T) {
+	runWithMemberTypes(t, func(mt chat1.ConversationMembersType) {
+		switch mt {
+		case chat1.ConversationMembersType_TEAM:
+		default:
+			return
+		}
+
+		ctc := makeChatTestContext(t, "TestChatSrvSplitConversation", 2)
+		defer ctc.cleanup()
+		users := ctc.users()
+
+		tc := ctc.as(t, users[0])
+		created := mustCreateConversationForTest(t, ctc, users[0], chat1.TopicType_CHAT, mt, users[1])
+		pivot := mustPostLocalForTest(t, ctc, users[0], created,
+			chat1.NewMessageBodyWithText(chat1.MessageText{Body: "on topic"}))
+		mustPostLocalForTest(t, ctc, users[1], created,
+			chat1.NewMessageBodyWithText(chat1.MessageText{Body: "drifting"}))
+		last := mustPostLocalForTest(t, ctc, users[0], created,
+			chat1.NewMessageBodyWithText(chat1.MessageText{Body: "way off"}))
+
+		topicName := "offtopic"
+		arg := chat1.SplitConversationLocalArg{
+			ConvID:           created.Id,
+			AfterMsgID:       pivot,
+			TopicName:        topicName,
+			DryRun:           true,
+			IdentifyBehavior: keybase1.TLFIdentifyBehavior_CHAT_CLI,
+		}
+		res, err := tc.chatLocalHandler().SplitConversationLocal(context.TODO(), arg)
+		require.NoError(t, err)
+		require.Nil(t, res.NewConvID)
+		require.Equal(t, 2, res.MessagesMoved)
+		require.Equal(t, last, res.LastMsgID)
+		convs, err := tc.h.G().ChatHelper.FindConversations(context.TODO(), created.TlfName, &topicName,
+			chat1.TopicType_CHAT, mt, keybase1.TLFVisibility_PRIVATE)
+		require.NoError(t, err)
+		require.Zero(t, len(convs))
+
+		arg.DryRun = false
+		res, err = tc.chatLocalHandler().SplitConversationLocal(context.TODO(), arg)
+		require.NoError(t, err)
+		require.NotNil(t, res.NewConvID)
+		require.Equal(t, 2, res.MessagesMoved)
+
+		tv, err := tc.chatLocalHandler().GetThreadLocal(context.TODO(), chat1.GetThreadLocalArg{
+			ConversationID: *res.NewConvID,
+			Query: &chat1.GetThreadQuery{
+				MessageTypes: []chat1.MessageType{chat1.MessageType_TEXT},
+			},
+		})
+		require.NoError(t, err)
+		msgs := tv.Thread.Messages
+		// header plus the two moved messages, newest first
+		require.Len(t, msgs, 3)
+		require.True(t, strings.HasSuffix(msgs[0].Valid().MessageBody.Text().Body, "way off"))
+		require.True(t, strings.HasSuffix(msgs[1].Valid().MessageBody.Text().Body, "drifting"))
+
+		// The channel exists now, so splitting into it again is refused.
+		_, err = tc.chatLocalHandler().SplitConversationLocal(context.TODO(), arg)
+		require.Error(t, err)
+	})
+}
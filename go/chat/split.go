@@ -0,0 +1,260 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/chat/types"
+	"github.com/keybase/client/go/chat/utils"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// convSplitPoster is the subset of the chat server used to repost messages
+// into the new channel.
+type convSplitPoster interface {
+	NewConversationLocal(context.Context, chat1.NewConversationLocalArg) (chat1.NewConversationLocalRes, error)
+	PostLocal(context.Context, chat1.PostLocalArg) (chat1.PostLocalRes, error)
+	ForwardMessage(context.Context, chat1.ForwardMessageArg) (chat1.PostLocalRes, error)
+	PostDeleteNonblock(context.Context, chat1.PostDeleteNonblockArg) (chat1.PostLocalNonblockRes, error)
+}
+
+// ConversationSplitter moves the tail of a team conversation into a new
+// channel, for when a thread in #general deserves a home of its own. The
+// moved messages are reposted by the caller as imported history, with the
+// original author and send time kept in the body.
+type ConversationSplitter struct {
+	globals.Contextified
+	utils.DebugLabeler
+
+	uid      gregor1.UID
+	poster   convSplitPoster
+	pageSize int
+}
+
+func NewConversationSplitter(g *globals.Context, uid gregor1.UID, poster convSplitPoster) *ConversationSplitter {
+	c := &ConversationSplitter{
+		Contextified: globals.NewContextified(g),
+		DebugLabeler: utils.NewDebugLabeler(g.ExternalG(), "ConversationSplitter", false),
+		uid:          uid,
+		poster:       poster,
+	}
+	switch c.G().GetAppType() {
+	case libkb.MobileAppType:
+		c.pageSize = defaultPageSizeMobile
+	default:
+		c.pageSize = defaultPageSizeDesktop
+	}
+	return c
+}
+
+func (c *ConversationSplitter) canMove(msg chat1.MessageUnboxedValid) bool {
+	if msg.IsEphemeral() {
+		// Reposting would either drop or restart the explode timer, neither
+		// of which is what the sender asked for.
+		return false
+	}
+	switch msg.ClientHeader.MessageType {
+	case chat1.MessageType_TEXT, chat1.MessageType_ATTACHMENT:
+		return true
+	default:
+		return false
+	}
+}
+
+// collect returns the visible messages in the conversation after afterMsgID,
+// oldest first, along with how many of them can't be moved.
+func (c *ConversationSplitter) collect(ctx context.Context, convID chat1.ConversationID,
+	afterMsgID chat1.MessageID) (msgs []chat1.MessageUnboxedValid, skipped int, err error) {
+	pagination := &chat1.Pagination{Num: c.pageSize}
+	done := false
+	for !done {
+		thread, err := c.G().ConvSource.Pull(ctx, convID, c.uid,
+			chat1.GetThreadReason_GENERAL, nil,
+			&chat1.GetThreadQuery{
+				MarkAsRead:   false,
+				MessageTypes: chat1.VisibleChatMessageTypes(),
+			}, pagination)
+		if err != nil {
+			return nil, 0, err
+		}
+		// Pages come back newest first.
+		for _, msg := range thread.Messages {
+			if msg.GetMessageID() <= afterMsgID {
+				done = true
+				break
+			}
+			if !msg.IsValid() {
+				skipped++
+				continue
+			}
+			mvalid := msg.Valid()
+			if !c.canMove(mvalid) {
+				skipped++
+				continue
+			}
+			msgs = append(msgs, mvalid)
+		}
+		if thread.Pagination == nil || thread.Pagination.Last {
+			done = true
+		} else {
+			pagination = &chat1.Pagination{
+				Num:  c.pageSize,
+				Next: thread.Pagination.Next,
+			}
+		}
+	}
+	for i, j := 0, len(msgs)-1; i < j; i, j = i+1, j-1 {
+		msgs[i], msgs[j] = msgs[j], msgs[i]
+	}
+	return msgs, skipped, nil
+}
+
+func (c *ConversationSplitter) attribution(msg chat1.MessageUnboxedValid) string {
+	// Don't use an @-mention here, or everyone in the history gets pinged
+	// once per message.
+	return fmt.Sprintf("*%s* (%s)", msg.SenderUsername,
+		msg.ServerHeader.Ctime.Time().UTC().Format(time.RFC822))
+}
+
+func (c *ConversationSplitter) postText(ctx context.Context, conv chat1.ConversationLocal,
+	body string, identifyBehavior keybase1.TLFIdentifyBehavior) error {
+	_, err := c.poster.PostLocal(ctx, chat1.PostLocalArg{
+		ConversationID: conv.GetConvID(),
+		Msg: chat1.MessagePlaintext{
+			ClientHeader: chat1.MessageClientHeader{
+				Conv:        conv.Info.Triple,
+				TlfName:     conv.Info.TlfName,
+				TlfPublic:   conv.Info.Visibility == keybase1.TLFVisibility_PUBLIC,
+				MessageType: chat1.MessageType_TEXT,
+			},
+			MessageBody: chat1.NewMessageBodyWithText(chat1.MessageText{Body: body}),
+		},
+		IdentifyBehavior:   identifyBehavior,
+		SkipInChatPayments: true,
+	})
+	return err
+}
+
+func (c *ConversationSplitter) move(ctx context.Context, src, dst chat1.ConversationLocal,
+	msg chat1.MessageUnboxedValid, identifyBehavior keybase1.TLFIdentifyBehavior) error {
+	switch msg.ClientHeader.MessageType {
+	case chat1.MessageType_ATTACHMENT:
+		title := c.attribution(msg)
+		if obj := msg.MessageBody.Attachment().Object; len(obj.Title) > 0 {
+			title += ": " + utils.QuoteAtMentions(ctx, obj.Title)
+		}
+		_, err := c.poster.ForwardMessage(ctx, chat1.ForwardMessageArg{
+			SrcConvID:        src.GetConvID(),
+			DstConvID:        dst.GetConvID(),
+			MsgID:            msg.ServerHeader.MessageID,
+			IdentifyBehavior: identifyBehavior,
+			Title:            title,
+		})
+		return err
+	default:
+		// The mentions in the body already notified everyone when it was
+		// first sent.
+		body := fmt.Sprintf("%s:\n%s", c.attribution(msg),
+			utils.QuoteAtMentions(ctx, msg.MessageBody.Text().Body))
+		return c.postText(ctx, dst, body, identifyBehavior)
+	}
+}
+
+func (c *ConversationSplitter) Split(ctx context.Context, arg chat1.SplitConversationLocalArg) (res chat1.SplitConversationLocalRes, err error) {
+	defer c.Trace(ctx, &err, "Split")()
+
+	if arg.AfterMsgID == 0 {
+		return res, errors.New("must specify the message to split after")
+	}
+	if len(arg.TopicName) == 0 {
+		return res, errors.New("must specify a name for the new channel")
+	}
+	src, err := utils.GetVerifiedConv(ctx, c.G(), c.uid, arg.ConvID, types.InboxSourceDataSourceAll)
+	if err != nil {
+		return res, err
+	}
+	if src.GetMembersType() != chat1.ConversationMembersType_TEAM {
+		return res, errors.New("only team conversations can be split into a new channel")
+	}
+	existing, err := c.G().ChatHelper.FindConversations(ctx, src.Info.TlfName, &arg.TopicName,
+		chat1.TopicType_CHAT, chat1.ConversationMembersType_TEAM, src.Info.Visibility)
+	if err != nil {
+		return res, err
+	}
+	if len(existing) > 0 {
+		return res, fmt.Errorf("a channel named %s already exists", arg.TopicName)
+	}
+
+	msgs, skipped, err := c.collect(ctx, arg.ConvID, arg.AfterMsgID)
+	if err != nil {
+		return res, err
+	}
+	res.MessagesSkipped = skipped
+	if len(msgs) == 0 {
+		return res, fmt.Errorf("no messages to move after message %d", arg.AfterMsgID)
+	}
+	res.FirstMsgID = msgs[0].ServerHeader.MessageID
+	res.LastMsgID = msgs[len(msgs)-1].ServerHeader.MessageID
+	if arg.DryRun {
+		res.MessagesMoved = len(msgs)
+		return res, nil
+	}
+
+	ncres, err := c.poster.NewConversationLocal(ctx, chat1.NewConversationLocalArg{
+		TlfName:          src.Info.TlfName,
+		TopicType:        chat1.TopicType_CHAT,
+		TlfVisibility:    src.Info.Visibility,
+		TopicName:        &arg.TopicName,
+		MembersType:      chat1.ConversationMembersType_TEAM,
+		IdentifyBehavior: arg.IdentifyBehavior,
+	})
+	if err != nil {
+		return res, err
+	}
+	dst := ncres.Conv
+	dstConvID := dst.GetConvID()
+	res.NewConvID = &dstConvID
+
+	header := fmt.Sprintf("Imported %d messages from #%s.", len(msgs), src.GetTopicName())
+	if err := c.postText(ctx, dst, header, arg.IdentifyBehavior); err != nil {
+		return res, err
+	}
+	total := int64(len(msgs))
+	for _, msg := range msgs {
+		if err := c.move(ctx, src, dst, msg, arg.IdentifyBehavior); err != nil {
+			return res, fmt.Errorf("moved %d of %d messages to #%s, and deleted none of the originals: %w",
+				res.MessagesMoved, len(msgs), arg.TopicName, err)
+		}
+		res.MessagesMoved++
+		c.G().NotifyRouter.HandleChatSplitConversationProgress(ctx, arg.ConvID,
+			int64(res.MessagesMoved), total)
+	}
+
+	if arg.DeleteOriginals {
+		// Only once every message is safely in the new channel.
+		for deleted, msg := range msgs {
+			if _, err := c.poster.PostDeleteNonblock(ctx, chat1.PostDeleteNonblockArg{
+				ConversationID:   arg.ConvID,
+				TlfName:          src.Info.TlfName,
+				TlfPublic:        src.Info.Visibility == keybase1.TLFVisibility_PUBLIC,
+				Supersedes:       msg.ServerHeader.MessageID,
+				IdentifyBehavior: arg.IdentifyBehavior,
+			}); err != nil {
+				return res, fmt.Errorf("moved all %d messages to #%s, but deleted only %d of the originals: %w",
+					res.MessagesMoved, arg.TopicName, deleted, err)
+			}
+		}
+	}
+
+	pointer := fmt.Sprintf("This conversation continues in #%s.", arg.TopicName)
+	if err := c.postText(ctx, src, pointer, arg.IdentifyBehavior); err != nil {
+		c.Debug(ctx, "Split: unable to post pointer to new channel: %v", err)
+	}
+	return res, nil
+}
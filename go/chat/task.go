@@ -0,0 +1,254 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/chat/types"
+	"github.com/keybase/client/go/chat/utils"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// Like poll votes, changes to a task are REACTION messages on it, so they
+// sync and are aggregated onto the task message the same way as any other
+// reactions. Marking a task done or reopening it is a "task:done" or
+// "task:open" reaction, and assigning it is a "task:assign:<username>"
+// reaction, with no username to unassign it. Whichever of each kind came
+// last wins, no matter who sent it. Since sending a reaction the user
+// already has takes it back, sending one again takes it back first, so
+// that it's the latest.
+
+const (
+	taskReactionPrefix       = "task:"
+	taskDoneReaction         = taskReactionPrefix + "done"
+	taskOpenReaction         = taskReactionPrefix + "open"
+	taskAssignReactionPrefix = taskReactionPrefix + "assign:"
+)
+
+// How many messages to look at per page when listing tasks.
+const taskListPageSize = 100
+
+func isTaskUpdateReaction(body string) bool {
+	return strings.HasPrefix(body, taskReactionPrefix)
+}
+
+func taskAssignReaction(assignee string) string {
+	return taskAssignReactionPrefix + assignee
+}
+
+type taskState struct {
+	assignee string
+	done     bool
+	doneBy   string
+}
+
+// latestTaskReaction returns the latest reaction whose body matches, and
+// who sent it.
+func latestTaskReaction(reactions chat1.ReactionMap, matches func(string) bool) (
+	body, username string, found bool) {
+	var latest chat1.MessageID
+	for b, users := range reactions.Reactions {
+		if !matches(b) {
+			continue
+		}
+		for u, reaction := range users {
+			if reaction.ReactionMsgID > latest {
+				latest = reaction.ReactionMsgID
+				body, username, found = b, u, true
+			}
+		}
+	}
+	return body, username, found
+}
+
+// getTaskState works out who task is assigned to and whether it's done from
+// the reactions on it.
+func getTaskState(task chat1.MessageTask, reactions chat1.ReactionMap) (res taskState) {
+	res.assignee = task.Assignee
+	if body, _, ok := latestTaskReaction(reactions, func(b string) bool {
+		return strings.HasPrefix(b, taskAssignReactionPrefix)
+	}); ok {
+		res.assignee = strings.TrimPrefix(body, taskAssignReactionPrefix)
+	}
+	if body, username, ok := latestTaskReaction(reactions, func(b string) bool {
+		return b == taskDoneReaction || b == taskOpenReaction
+	}); ok && body == taskDoneReaction {
+		res.done = true
+		res.doneBy = username
+	}
+	return res
+}
+
+func newTaskInfo(conv chat1.ConversationLocal, msg chat1.MessageUnboxedValid) chat1.TaskInfo {
+	task := msg.MessageBody.Task()
+	state := getTaskState(task, msg.Reactions)
+	info := chat1.TaskInfo{
+		ConvID:   conv.GetConvID(),
+		MsgID:    msg.ServerHeader.MessageID,
+		Title:    task.Title,
+		Creator:  msg.SenderUsername,
+		Ctime:    msg.ServerHeader.Ctime,
+		Assignee: state.assignee,
+		Done:     state.done,
+		DoneBy:   state.doneBy,
+	}
+	if conv.GetMembersType() == chat1.ConversationMembersType_TEAM {
+		info.Channel = conv.GetTopicName()
+	}
+	return info
+}
+
+// checkTaskAssignee returns the normalized assignee, who has to be in
+// convID. No assignee is fine.
+func checkTaskAssignee(ctx context.Context, g *globals.Context, uid gregor1.UID,
+	convID chat1.ConversationID, assignee string) (string, error) {
+	assignee = strings.TrimPrefix(strings.TrimSpace(assignee), "@")
+	if len(assignee) == 0 {
+		return "", nil
+	}
+	assignee = libkb.NewNormalizedUsername(assignee).String()
+	members, err := utils.GetConvParticipantUsernames(ctx, g, uid, convID)
+	if err != nil {
+		return "", err
+	}
+	for _, member := range members {
+		if member == assignee {
+			return assignee, nil
+		}
+	}
+	return "", fmt.Errorf("%s is not in the conversation", assignee)
+}
+
+// getTask returns the task msgID, with the reactions that are its changes.
+func getTask(ctx context.Context, g *globals.Context, uid gregor1.UID,
+	convID chat1.ConversationID, msgID chat1.MessageID) (chat1.MessageUnboxedValid, error) {
+	msg, err := g.ConvSource.GetMessage(ctx, convID, uid, msgID, nil, nil, true)
+	if err != nil {
+		return chat1.MessageUnboxedValid{}, err
+	}
+	if !msg.IsValid() {
+		return chat1.MessageUnboxedValid{}, errors.New("task message is invalid")
+	}
+	valid := msg.Valid()
+	if !valid.MessageBody.IsType(chat1.MessageType_TASK) {
+		return chat1.MessageUnboxedValid{}, fmt.Errorf("message %d is not a task", msgID)
+	}
+	return valid, nil
+}
+
+// sendTaskUpdate sends the reaction body on the task, making it the latest
+// of its kind even if the user already has it.
+func sendTaskUpdate(ctx context.Context, g *globals.Context, sender types.Sender,
+	convID chat1.ConversationID, task chat1.MessageUnboxedValid, body string) error {
+	msg := chat1.MessagePlaintext{
+		ClientHeader: chat1.MessageClientHeader{
+			MessageType: chat1.MessageType_REACTION,
+			Supersedes:  task.ServerHeader.MessageID,
+			TlfName:     task.ClientHeader.TlfName,
+			TlfPublic:   task.ClientHeader.TlfPublic,
+		},
+		MessageBody: chat1.NewMessageBodyWithReaction(chat1.MessageReaction{
+			MessageID: task.ServerHeader.MessageID,
+			Body:      body,
+		}),
+	}
+	if found, _ := task.Reactions.HasReactionFromUser(body, g.Env.GetUsername().String()); found {
+		if _, _, err := sender.Send(ctx, convID, msg, 0, nil, nil, nil); err != nil {
+			return err
+		}
+	}
+	_, _, err := sender.Send(ctx, convID, msg, 0, nil, nil, nil)
+	return err
+}
+
+func assignTask(ctx context.Context, g *globals.Context, sender types.Sender, uid gregor1.UID,
+	convID chat1.ConversationID, msgID chat1.MessageID, assignee string) error {
+	task, err := getTask(ctx, g, uid, convID, msgID)
+	if err != nil {
+		return err
+	}
+	assignee, err = checkTaskAssignee(ctx, g, uid, convID, assignee)
+	if err != nil {
+		return err
+	}
+	if getTaskState(task.MessageBody.Task(), task.Reactions).assignee == assignee {
+		return nil
+	}
+	return sendTaskUpdate(ctx, g, sender, convID, task, taskAssignReaction(assignee))
+}
+
+func setTaskDone(ctx context.Context, g *globals.Context, sender types.Sender, uid gregor1.UID,
+	convID chat1.ConversationID, msgID chat1.MessageID, done bool) error {
+	task, err := getTask(ctx, g, uid, convID, msgID)
+	if err != nil {
+		return err
+	}
+	if getTaskState(task.MessageBody.Task(), task.Reactions).done == done {
+		return nil
+	}
+	body := taskOpenReaction
+	if done {
+		body = taskDoneReaction
+	}
+	return sendTaskUpdate(ctx, g, sender, convID, task, body)
+}
+
+// listOpenTasks returns the tasks in conv that aren't done, newest first.
+func listOpenTasks(ctx context.Context, g *globals.Context, uid gregor1.UID,
+	conv chat1.ConversationLocal) (res []chat1.TaskInfo, err error) {
+	pagination := &chat1.Pagination{Num: taskListPageSize}
+	for {
+		thread, err := g.ConvSource.Pull(ctx, conv.GetConvID(), uid, chat1.GetThreadReason_GENERAL, nil,
+			&chat1.GetThreadQuery{
+				MarkAsRead:   false,
+				MessageTypes: []chat1.MessageType{chat1.MessageType_TASK},
+			}, pagination)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range thread.Messages {
+			if !m.IsValidFull() || !m.Valid().MessageBody.IsType(chat1.MessageType_TASK) {
+				continue
+			}
+			if info := newTaskInfo(conv, m.Valid()); !info.Done {
+				res = append(res, info)
+			}
+		}
+		if thread.Pagination == nil || thread.Pagination.Last {
+			return res, nil
+		}
+		pagination = thread.Pagination
+	}
+}
+
+// listOpenTeamTasks returns the tasks that aren't done in every channel of
+// teamID the user is in, newest first. Channels that can't be loaded are
+// skipped.
+func listOpenTeamTasks(ctx context.Context, g *globals.Context, uid gregor1.UID,
+	teamID keybase1.TeamID) (res []chat1.TaskInfo, err error) {
+	convs, err := g.TeamChannelSource.GetChannelsFull(ctx, uid, chat1.TLFID(teamID.ToBytes()),
+		chat1.TopicType_CHAT)
+	if err != nil {
+		return nil, err
+	}
+	for _, conv := range convs {
+		if conv.Info.MemberStatus != chat1.ConversationMemberStatus_ACTIVE {
+			continue
+		}
+		tasks, err := listOpenTasks(ctx, g, uid, conv)
+		if err != nil {
+			g.GetLog().CDebugf(ctx, "listOpenTeamTasks: unable to load %s: %v", conv.GetConvID(), err)
+			continue
+		}
+		res = append(res, tasks...)
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Ctime > res[j].Ctime })
+	return res, nil
+}
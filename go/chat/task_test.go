@@ -0,0 +1,45 @@
+package chat
+
+import (
+	"testing"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskState(t *testing.T) {
+	task := chat1.MessageTask{Title: "Rotate the certs", Assignee: "alice"}
+	state := getTaskState(task, chat1.ReactionMap{})
+	require.Equal(t, "alice", state.assignee)
+	require.False(t, state.done)
+
+	reactions := chat1.ReactionMap{
+		Reactions: map[string]map[string]chat1.Reaction{
+			taskAssignReaction("bob"): {"alice": {ReactionMsgID: 10}},
+			taskDoneReaction:          {"bob": {ReactionMsgID: 11}},
+			// Not task updates.
+			":+1:": {"charlie": {ReactionMsgID: 12}},
+		},
+	}
+	state = getTaskState(task, reactions)
+	require.Equal(t, "bob", state.assignee)
+	require.True(t, state.done)
+	require.Equal(t, "bob", state.doneBy)
+
+	// The latest change of each kind wins, whoever made it.
+	reactions.Reactions[taskOpenReaction] = map[string]chat1.Reaction{
+		"charlie": {ReactionMsgID: 13},
+	}
+	reactions.Reactions[taskAssignReaction("")] = map[string]chat1.Reaction{
+		"bob": {ReactionMsgID: 14},
+	}
+	state = getTaskState(task, reactions)
+	require.Empty(t, state.assignee)
+	require.False(t, state.done)
+	require.Empty(t, state.doneBy)
+
+	require.True(t, isTaskUpdateReaction(taskAssignReaction("bob")))
+	require.True(t, isTaskUpdateReaction(taskDoneReaction))
+	require.False(t, isTaskUpdateReaction(pollVoteReaction(1)))
+	require.False(t, isTaskUpdateReaction(":tada:"))
+}
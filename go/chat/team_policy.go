@@ -0,0 +1,253 @@
+package chat
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/chat/types"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// Team policies are settings team admins keep in the team's admin-only dev
+// storage, next to the welcome message: new member restrictions, incident
+// mode, channel banners, membership hooks and trusted device policies. Only
+// admins can write them, so anything else stored under their names is
+// ignored. The ones that restrict sending are enforced when the sender's own
+// client prepares a message, so they keep honest clients in line but don't
+// stop a modified one.
+
+type teamPolicyKey struct {
+	teamID keybase1.TeamID
+	name   string
+}
+
+type cachedTeamPolicy struct {
+	policy    interface{}
+	fetchedAt time.Time
+}
+
+// TeamPolicyCache is the in-memory types.TeamPolicyCache. It also remembers
+// recent sends by restricted new members, since those are only tracked in
+// memory too.
+type TeamPolicyCache struct {
+	sync.Mutex
+	policies   map[teamPolicyKey]cachedTeamPolicy
+	newMembers *newMemberTracker
+}
+
+var _ types.TeamPolicyCache = (*TeamPolicyCache)(nil)
+
+func NewTeamPolicyCache() *TeamPolicyCache {
+	return &TeamPolicyCache{
+		policies:   make(map[teamPolicyKey]cachedTeamPolicy),
+		newMembers: newNewMemberTracker(),
+	}
+}
+
+func (c *TeamPolicyCache) Get(teamID keybase1.TeamID, name string, maxAge time.Duration,
+	now time.Time) (interface{}, bool) {
+	c.Lock()
+	defer c.Unlock()
+	cached, ok := c.policies[teamPolicyKey{teamID: teamID, name: name}]
+	if !ok || now.Sub(cached.fetchedAt) > maxAge {
+		return nil, false
+	}
+	return cached.policy, true
+}
+
+func (c *TeamPolicyCache) Put(teamID keybase1.TeamID, name string, policy interface{}, now time.Time) {
+	c.Lock()
+	defer c.Unlock()
+	c.policies[teamPolicyKey{teamID: teamID, name: name}] = cachedTeamPolicy{policy: policy, fetchedAt: now}
+}
+
+func (c *TeamPolicyCache) Invalidate(teamID keybase1.TeamID, name string) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.policies, teamPolicyKey{teamID: teamID, name: name})
+}
+
+func (c *TeamPolicyCache) RecordNewMemberSend(teamID keybase1.TeamID, outboxID string,
+	now time.Time) (recent int, retry bool) {
+	return c.newMembers.recordSend(teamID, outboxID, now)
+}
+
+func (c *TeamPolicyCache) ForgetNewMemberSend(teamID keybase1.TeamID, outboxID string) {
+	c.newMembers.forgetSend(teamID, outboxID)
+}
+
+func (c *TeamPolicyCache) clearCache() {
+	c.Lock()
+	defer c.Unlock()
+	c.policies = make(map[teamPolicyKey]cachedTeamPolicy)
+	c.newMembers.clear()
+}
+
+func (c *TeamPolicyCache) OnLogout(mctx libkb.MetaContext) error {
+	c.clearCache()
+	return nil
+}
+
+func (c *TeamPolicyCache) OnDbNuke(mctx libkb.MetaContext) error {
+	c.clearCache()
+	return nil
+}
+
+// teamPolicy is a policy of type T stored under name. Loaded policies are
+// used for cacheTime before they're loaded again; the push handler also drops
+// them when it sees an admin change them.
+type teamPolicy[T any] struct {
+	name      string
+	cacheTime time.Duration
+}
+
+// load reads the team's policy from dev storage, or returns the zero policy if
+// there isn't one.
+func (p teamPolicy[T]) load(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, teamID keybase1.TeamID) (res T, err error) {
+	var zero T
+	conv, err := getWelcomeMessageConv(ctx, g, uid, teamID)
+	if err != nil {
+		return res, err
+	}
+	s := NewConvDevConversationBackedStorage(g, chat1.TopicType_DEV, true /* adminOnly */, ri)
+	found, _, err := s.Get(ctx, uid, conv.GetConvID(), p.name, &res, false)
+	switch err.(type) {
+	case nil:
+	case *DevStorageAdminOnlyError:
+		// Not written by an admin, so ignore it.
+		return zero, nil
+	default:
+		return res, err
+	}
+	if !found {
+		return zero, nil
+	}
+	return res, nil
+}
+
+// refresh loads the team's policy and caches it.
+func (p teamPolicy[T]) refresh(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, teamID keybase1.TeamID, now time.Time) (res T, err error) {
+	res, err = p.load(ctx, g, ri, uid, teamID)
+	if err != nil {
+		return res, err
+	}
+	g.TeamPolicyCache.Put(teamID, p.name, res, now)
+	return res, nil
+}
+
+// get returns the team's policy, from the cache if it was loaded recently
+// enough.
+func (p teamPolicy[T]) get(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, teamID keybase1.TeamID, now time.Time) (res T, err error) {
+	if cached, ok := g.TeamPolicyCache.Get(teamID, p.name, p.cacheTime, now); ok {
+		if res, ok := cached.(T); ok {
+			return res, nil
+		}
+	}
+	return p.refresh(ctx, g, ri, uid, teamID, now)
+}
+
+// cached returns the team's policy from the cache without loading it, for
+// callers that can't wait on dev storage. It's the zero policy if none is
+// cached, and fresh is false unless it was loaded recently enough.
+func (p teamPolicy[T]) cached(g *globals.Context, teamID keybase1.TeamID, now time.Time) (res T, fresh bool) {
+	if cached, ok := g.TeamPolicyCache.Get(teamID, p.name, p.cacheTime, now); ok {
+		if res, ok := cached.(T); ok {
+			return res, true
+		}
+	}
+	if cached, ok := g.TeamPolicyCache.Get(teamID, p.name, math.MaxInt64, now); ok {
+		if res, ok := cached.(T); ok {
+			return res, false
+		}
+	}
+	return res, false
+}
+
+// store writes policy as the team's policy. Only admins' writes count.
+func (p teamPolicy[T]) store(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, teamID keybase1.TeamID, policy T) error {
+	conv, err := getWelcomeMessageConv(ctx, g, uid, teamID)
+	if err != nil {
+		return err
+	}
+	s := NewConvDevConversationBackedStorage(g, chat1.TopicType_DEV, true /* adminOnly */, ri)
+	if err := s.Put(ctx, uid, conv.GetConvID(), p.name, policy); err != nil {
+		return err
+	}
+	g.TeamPolicyCache.Invalidate(teamID, p.name)
+	return nil
+}
+
+// The audit logs keep only this many of the most recent entries.
+const maxTeamAuditLogEntries = 500
+
+// teamAuditLog is a team policy of type L that logs admins' changes as a list
+// of E, newest first. entries returns the list in a log.
+type teamAuditLog[L, E any] struct {
+	teamPolicy[L]
+	entries func(*L) *[]E
+}
+
+// add records entries, given newest first, at the front of the team's log.
+// It fails rather than start a new log if the current one can't be loaded.
+func (l teamAuditLog[L, E]) add(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, teamID keybase1.TeamID, entries ...E) error {
+	log, err := l.load(ctx, g, ri, uid, teamID)
+	if err != nil {
+		return err
+	}
+	logEntries := l.entries(&log)
+	*logEntries = append(append([]E{}, entries...), *logEntries...)
+	if len(*logEntries) > maxTeamAuditLogEntries {
+		*logEntries = (*logEntries)[:maxTeamAuditLogEntries]
+	}
+	return l.store(ctx, g, ri, uid, teamID, log)
+}
+
+// prepareTeamPolicy returns conv's team's policy p for a check when we
+// prepare a message. ok is false if conv isn't a team channel, or if the
+// policy can't be loaded, in which case the message goes out unchecked
+// rather than failing on dev storage.
+func prepareTeamPolicy[T any](ctx context.Context, s *BlockingSender, uid gregor1.UID,
+	conv chat1.ConversationLocal, p teamPolicy[T]) (res T, teamID keybase1.TeamID, ok bool, err error) {
+	if conv.GetMembersType() != chat1.ConversationMembersType_TEAM ||
+		conv.GetTopicType() != chat1.TopicType_CHAT {
+		return res, teamID, false, nil
+	}
+	teamID, err = TLFIDToTeamID(conv.Info.Triple.Tlfid)
+	if err != nil {
+		return res, teamID, false, err
+	}
+	res, err = p.get(ctx, s.G(), s.getRi, uid, teamID, s.clock.Now())
+	if err != nil {
+		if _, ok := err.(libkb.NotFoundError); !ok {
+			s.Debug(ctx, "prepareTeamPolicy: unable to load %s: %v", p.name, err)
+		}
+		return res, teamID, false, nil
+	}
+	return res, teamID, true, nil
+}
+
+// invalidateTeamPoliciesFromMessage drops the cached policy a message in
+// conv changes when the push handler sees it, so it takes effect right away
+// instead of when the cache runs out.
+func invalidateTeamPoliciesFromMessage(g *globals.Context, conv *chat1.ConversationLocal) {
+	if conv == nil || conv.GetTopicType() != chat1.TopicType_DEV ||
+		conv.GetMembersType() != chat1.ConversationMembersType_TEAM {
+		return
+	}
+	teamID, err := TLFIDToTeamID(conv.Info.Triple.Tlfid)
+	if err != nil {
+		return
+	}
+	g.TeamPolicyCache.Invalidate(teamID, conv.Info.TopicName)
+}
@@ -0,0 +1,72 @@
+package chat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamPolicyCache(t *testing.T) {
+	cache := NewTeamPolicyCache()
+	teamID := keybase1.TeamID("ffff")
+	otherTeamID := keybase1.TeamID("eeee")
+	now := time.Now()
+	r := chat1.NewMemberRestrictions{NewMemberHours: 24}
+	cache.Put(teamID, newMemberRestrictionsName, r, now)
+
+	cached, ok := cache.Get(teamID, newMemberRestrictionsName, time.Minute, now.Add(time.Second))
+	require.True(t, ok)
+	require.Equal(t, r, cached)
+	_, ok = cache.Get(teamID, newMemberRestrictionsName, time.Minute, now.Add(2*time.Minute))
+	require.False(t, ok)
+	_, ok = cache.Get(otherTeamID, newMemberRestrictionsName, time.Minute, now)
+	require.False(t, ok)
+	_, ok = cache.Get(teamID, "__other_policy", time.Minute, now)
+	require.False(t, ok)
+
+	// Invalidating another policy leaves this one alone.
+	cache.Invalidate(teamID, "__other_policy")
+	_, ok = cache.Get(teamID, newMemberRestrictionsName, time.Minute, now)
+	require.True(t, ok)
+	cache.Invalidate(teamID, newMemberRestrictionsName)
+	_, ok = cache.Get(teamID, newMemberRestrictionsName, time.Minute, now)
+	require.False(t, ok)
+
+	cache.Put(teamID, newMemberRestrictionsName, r, now)
+	recent, _ := cache.RecordNewMemberSend(teamID, "a", now)
+	require.Equal(t, 0, recent)
+	cache.clearCache()
+	_, ok = cache.Get(teamID, newMemberRestrictionsName, time.Minute, now)
+	require.False(t, ok)
+	recent, _ = cache.RecordNewMemberSend(teamID, "b", now)
+	require.Equal(t, 0, recent)
+}
+
+func TestTeamPolicyCached(t *testing.T) {
+	cache := NewTeamPolicyCache()
+	g := globals.NewContext(nil, &globals.ChatContext{TeamPolicyCache: cache})
+	teamID := keybase1.TeamID("ffff")
+	now := time.Now()
+
+	settings, fresh := incidentMode.cached(g, teamID, now)
+	require.False(t, fresh)
+	require.Equal(t, chat1.IncidentModeSettings{}, settings)
+
+	s := chat1.IncidentModeSettings{Channels: []chat1.IncidentModeChannel{{
+		ExpiresAt: gregor1.ToTime(now.Add(time.Hour)),
+	}}}
+	cache.Put(teamID, incidentModeName, s, now)
+	settings, fresh = incidentMode.cached(g, teamID, now.Add(time.Second))
+	require.True(t, fresh)
+	require.Equal(t, s, settings)
+
+	// Stale settings are still returned until they're loaded again.
+	settings, fresh = incidentMode.cached(g, teamID, now.Add(time.Hour))
+	require.False(t, fresh)
+	require.Equal(t, s, settings)
+}
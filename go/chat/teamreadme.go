@@ -0,0 +1,130 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/chat/utils"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// A team's readme lives in its admin-only dev storage, next to the welcome
+// message, so any member can read it but only admins can change it. When an
+// admin's client adds someone to the team, it DMs them the readme.
+
+const teamReadmeName = "__team_readme"
+
+const (
+	teamReadmeDescriptionMaxLen = 1000
+	teamReadmeWelcomeTextMaxLen = 2000
+	teamReadmeMaxLinks          = 10
+	teamReadmeLinkMaxLen        = 500
+)
+
+func isTeamReadmeEmpty(r chat1.TeamReadme) bool {
+	return len(r.Description) == 0 && len(r.Links) == 0 && len(r.WelcomeText) == 0
+}
+
+func checkTeamReadmeValid(r chat1.TeamReadme) error {
+	if len(r.Description) > teamReadmeDescriptionMaxLen {
+		return fmt.Errorf("description must be at most %d characters; was %d",
+			teamReadmeDescriptionMaxLen, len(r.Description))
+	}
+	if len(r.WelcomeText) > teamReadmeWelcomeTextMaxLen {
+		return fmt.Errorf("welcome text must be at most %d characters; was %d",
+			teamReadmeWelcomeTextMaxLen, len(r.WelcomeText))
+	}
+	if len(r.Links) > teamReadmeMaxLinks {
+		return fmt.Errorf("a readme can have at most %d links; had %d", teamReadmeMaxLinks, len(r.Links))
+	}
+	for _, link := range r.Links {
+		if len(link) > teamReadmeLinkMaxLen {
+			return fmt.Errorf("links must be at most %d characters; %q was %d",
+				teamReadmeLinkMaxLen, link, len(link))
+		}
+		u, err := url.Parse(link)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+			return fmt.Errorf("%q is not an http or https link", link)
+		}
+	}
+	return nil
+}
+
+// formatTeamReadmeDM returns the DM sent to new members of teamname.
+func formatTeamReadmeDM(teamname string, r chat1.TeamReadme) string {
+	parts := []string{fmt.Sprintf("Welcome to %s!", teamname)}
+	if len(r.WelcomeText) > 0 {
+		parts = append(parts, r.WelcomeText)
+	}
+	if len(r.Description) > 0 {
+		parts = append(parts, fmt.Sprintf("About %s:\n%s", teamname, r.Description))
+	}
+	if len(r.Links) > 0 {
+		links := []string{"Links:"}
+		for _, link := range r.Links {
+			links = append(links, "- "+link)
+		}
+		parts = append(parts, strings.Join(links, "\n"))
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+func getTeamReadme(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, teamID keybase1.TeamID) (res chat1.TeamReadme, err error) {
+	conv, err := getWelcomeMessageConv(ctx, g, uid, teamID)
+	if err != nil {
+		return res, err
+	}
+	s := NewConvDevConversationBackedStorage(g, chat1.TopicType_DEV, true /* adminOnly */, ri)
+	found, _, err := s.Get(ctx, uid, conv.GetConvID(), teamReadmeName, &res, false)
+	switch err.(type) {
+	case nil:
+	case *DevStorageAdminOnlyError:
+		// Not written by an admin, so ignore it.
+		return chat1.TeamReadme{}, nil
+	default:
+		return res, err
+	}
+	if !found {
+		return chat1.TeamReadme{}, nil
+	}
+	return res, nil
+}
+
+func setTeamReadme(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, teamID keybase1.TeamID, r chat1.TeamReadme) error {
+	if err := checkTeamReadmeValid(r); err != nil {
+		return err
+	}
+	conv, err := getWelcomeMessageConv(ctx, g, uid, teamID)
+	if err != nil {
+		return err
+	}
+	s := NewConvDevConversationBackedStorage(g, chat1.TopicType_DEV, true /* adminOnly */, ri)
+	return s.Put(ctx, uid, conv.GetConvID(), teamReadmeName, r)
+}
+
+// SendTeamReadme DMs teamID's readme to username, who was just added to it,
+// unless the team doesn't have one.
+func (h *Helper) SendTeamReadme(ctx context.Context, teamID keybase1.TeamID, teamname, username string) error {
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return err
+	}
+	r, err := getTeamReadme(ctx, h.G(), h.ri, uid, teamID)
+	if err != nil {
+		return err
+	}
+	if isTeamReadmeEmpty(r) {
+		return nil
+	}
+	dm := fmt.Sprintf("%s,%s", h.G().Env.GetUsername(), username)
+	_, err = h.SendTextByNameNonblock(ctx, dm, nil, chat1.ConversationMembersType_IMPTEAMNATIVE,
+		keybase1.TLFIdentifyBehavior_CHAT_CLI, formatTeamReadmeDM(teamname, r), nil)
+	return err
+}
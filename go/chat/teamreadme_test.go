@@ -0,0 +1,44 @@
+package chat
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamReadme(t *testing.T) {
+	r := chat1.TeamReadme{
+		Description: "Rocket-powered products",
+		Links:       []string{"https://acme.example/handbook", "http://acme.example/faq"},
+		WelcomeText: "Say hi in #general!",
+	}
+	require.NoError(t, checkTeamReadmeValid(r))
+	require.NoError(t, checkTeamReadmeValid(chat1.TeamReadme{}))
+	require.True(t, isTeamReadmeEmpty(chat1.TeamReadme{}))
+	require.False(t, isTeamReadmeEmpty(r))
+
+	require.Equal(t, `Welcome to acme!
+
+Say hi in #general!
+
+About acme:
+Rocket-powered products
+
+Links:
+- https://acme.example/handbook
+- http://acme.example/faq`, formatTeamReadmeDM("acme", r))
+	require.Equal(t, "Welcome to acme!\n\nHello", formatTeamReadmeDM("acme",
+		chat1.TeamReadme{WelcomeText: "Hello"}))
+
+	for _, link := range []string{"acme.example", "javascript:alert(1)", "ftp://acme.example", "https://"} {
+		require.Error(t, checkTeamReadmeValid(chat1.TeamReadme{Links: []string{link}}), link)
+	}
+	require.Error(t, checkTeamReadmeValid(chat1.TeamReadme{
+		Description: strings.Repeat("a", teamReadmeDescriptionMaxLen+1),
+	}))
+	require.Error(t, checkTeamReadmeValid(chat1.TeamReadme{
+		Links: make([]string, teamReadmeMaxLinks+1),
+	}))
+}
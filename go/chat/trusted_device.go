@@ -0,0 +1,295 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/chat/types"
+	"github.com/keybase/client/go/chat/utils"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/keybase/client/go/teams"
+)
+
+// Trusted device policies limit who can post in a team's sensitive channels
+// to devices that have been around for a while, since a device someone just
+// provisioned with a stolen paper key or password is the likeliest to be
+// doing damage. Admins can approve devices to post before they're old
+// enough. The policies for all of a team's channels are one team policy.
+
+const trustedDevicePoliciesName = "__trusted_device_policies"
+
+// The oldest a channel can require devices to be, in days.
+const maxTrustedDeviceMinAgeDays = 365
+
+var trustedDevicePolicies = teamPolicy[chat1.TrustedDevicePolicies]{
+	name:      trustedDevicePoliciesName,
+	cacheTime: 5 * time.Minute,
+}
+
+// channelTrustedDevicePolicy returns convID's policy in policies, or nil if
+// it doesn't have one.
+func channelTrustedDevicePolicy(policies chat1.TrustedDevicePolicies,
+	convID chat1.ConversationID) *chat1.TrustedDeviceChannelPolicy {
+	for _, policy := range policies.Channels {
+		if policy.ConvID.Eq(convID) {
+			res := policy.DeepCopy()
+			return &res
+		}
+	}
+	return nil
+}
+
+func checkTrustedDeviceMinAge(days int) error {
+	if days < 0 {
+		return errors.New("the minimum device age can't be negative")
+	}
+	if days > maxTrustedDeviceMinAgeDays {
+		return fmt.Errorf("devices can be required to be at most %d days old", maxTrustedDeviceMinAgeDays)
+	}
+	return nil
+}
+
+// applyTrustedDevicePolicy sets convID's minimum device age, keeping the
+// devices approved in it, or removes its policy if days is 0.
+func applyTrustedDevicePolicy(policies chat1.TrustedDevicePolicies, convID chat1.ConversationID,
+	channel, admin string, days int, now time.Time) (res chat1.TrustedDevicePolicies) {
+	var approved []chat1.TrustedDeviceApproval
+	for _, policy := range policies.Channels {
+		if policy.ConvID.Eq(convID) {
+			approved = policy.Approved
+			continue
+		}
+		res.Channels = append(res.Channels, policy)
+	}
+	if days > 0 {
+		res.Channels = append(res.Channels, chat1.TrustedDeviceChannelPolicy{
+			ConvID:           convID,
+			Channel:          channel,
+			MinDeviceAgeDays: days,
+			Approved:         approved,
+			SetBy:            admin,
+			Ctime:            gregor1.ToTime(now),
+		})
+	}
+	return res
+}
+
+// applyTrustedDeviceApproval approves approval's device to post in convID,
+// or takes its approval away.
+func applyTrustedDeviceApproval(policies chat1.TrustedDevicePolicies, convID chat1.ConversationID,
+	approval chat1.TrustedDeviceApproval, approved bool) (res chat1.TrustedDevicePolicies, err error) {
+	found := false
+	for _, policy := range policies.Channels {
+		if policy.ConvID.Eq(convID) {
+			found = true
+			policy = policy.DeepCopy()
+			var kept []chat1.TrustedDeviceApproval
+			for _, a := range policy.Approved {
+				if !a.DeviceID.Eq(approval.DeviceID) {
+					kept = append(kept, a)
+				}
+			}
+			if approved {
+				kept = append(kept, approval)
+			}
+			policy.Approved = kept
+		}
+		res.Channels = append(res.Channels, policy)
+	}
+	if !found {
+		return res, errors.New("the channel doesn't have a trusted device policy")
+	}
+	return res, nil
+}
+
+// trustedDevicePolicyError says why deviceID, provisioned at provisionedAt,
+// can't post under policy, or returns nil if it can.
+func trustedDevicePolicyError(policy *chat1.TrustedDeviceChannelPolicy, deviceID keybase1.DeviceID,
+	provisionedAt, now time.Time) error {
+	if policy == nil || policy.MinDeviceAgeDays == 0 {
+		return nil
+	}
+	for _, approval := range policy.Approved {
+		if approval.DeviceID.Eq(deviceID) {
+			return nil
+		}
+	}
+	trustedAt := provisionedAt.Add(time.Duration(policy.MinDeviceAgeDays) * 24 * time.Hour)
+	if !now.Before(trustedAt) {
+		return nil
+	}
+	return UntrustedDeviceError{
+		Channel:          policy.Channel,
+		MinDeviceAgeDays: policy.MinDeviceAgeDays,
+		TrustedAt:        trustedAt,
+	}
+}
+
+func isTrustedDevicePolicyType(typ chat1.MessageType) bool {
+	switch typ {
+	case chat1.MessageType_TEXT, chat1.MessageType_ATTACHMENT, chat1.MessageType_EDIT,
+		chat1.MessageType_HEADLINE:
+		return true
+	default:
+		return false
+	}
+}
+
+// findDeviceKey returns the active signing key of upak's device named
+// deviceName, or of deviceID if deviceName is empty.
+func findDeviceKey(upak keybase1.UserPlusKeysV2, deviceID keybase1.DeviceID,
+	deviceName string) *keybase1.PublicKeyV2NaCl {
+	for _, key := range upak.DeviceKeys {
+		if !key.Base.IsSibkey || key.Base.Revocation != nil {
+			continue
+		}
+		if (len(deviceName) > 0 && key.DeviceDescription == deviceName) ||
+			(len(deviceName) == 0 && key.DeviceID.Eq(deviceID)) {
+			res := key
+			return &res
+		}
+	}
+	return nil
+}
+
+// thisDeviceProvisionedAt returns when the device we're running on was
+// provisioned.
+func thisDeviceProvisionedAt(ctx context.Context, g *globals.Context, uid gregor1.UID) (time.Time, error) {
+	upak, _, err := g.GetUPAKLoader().LoadV2(
+		libkb.NewLoadUserByUIDArg(ctx, g.ExternalG(), keybase1.UID(uid.String())))
+	if err != nil {
+		return time.Time{}, err
+	}
+	key := findDeviceKey(upak.Current, g.ActiveDevice.DeviceID(), "")
+	if key == nil {
+		return time.Time{}, errors.New("this device's key isn't in our sigchain")
+	}
+	return key.Base.Provisioning.Time.Time(), nil
+}
+
+func getTrustedDevicePolicy(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, convID chat1.ConversationID) (*chat1.TrustedDeviceChannelPolicy, error) {
+	conv, err := utils.GetVerifiedConv(ctx, g, uid, convID, types.InboxSourceDataSourceAll)
+	if err != nil {
+		return nil, err
+	}
+	if conv.GetMembersType() != chat1.ConversationMembersType_TEAM {
+		return nil, nil
+	}
+	teamID, err := TLFIDToTeamID(conv.Info.Triple.Tlfid)
+	if err != nil {
+		return nil, err
+	}
+	policies, err := trustedDevicePolicies.refresh(ctx, g, ri, uid, teamID, g.Clock().Now())
+	if err != nil {
+		return nil, err
+	}
+	return channelTrustedDevicePolicy(policies, convID), nil
+}
+
+// changeTrustedDevicePolicies checks that we can change convID's policy, and
+// writes back what change makes of the team's policies.
+func changeTrustedDevicePolicies(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, convID chat1.ConversationID, change func(conv chat1.ConversationLocal,
+		policies chat1.TrustedDevicePolicies) (chat1.TrustedDevicePolicies, error)) error {
+	conv, err := utils.GetVerifiedConv(ctx, g, uid, convID, types.InboxSourceDataSourceAll)
+	if err != nil {
+		return err
+	}
+	if conv.GetMembersType() != chat1.ConversationMembersType_TEAM ||
+		conv.GetTopicType() != chat1.TopicType_CHAT {
+		return errors.New("trusted device policies only work in team channels")
+	}
+	op, err := teams.CanUserPerform(ctx, g.ExternalG(), conv.Info.TlfName)
+	if err != nil {
+		return err
+	}
+	if !op.SetMinWriterRole {
+		return errors.New("only team admins can change trusted device policies")
+	}
+	teamID, err := TLFIDToTeamID(conv.Info.Triple.Tlfid)
+	if err != nil {
+		return err
+	}
+	policies, err := trustedDevicePolicies.load(ctx, g, ri, uid, teamID)
+	if err != nil {
+		return err
+	}
+	// Otherwise a new device could approve itself, or turn the policy off.
+	provisionedAt, err := thisDeviceProvisionedAt(ctx, g, uid)
+	if err != nil {
+		return err
+	}
+	if err := trustedDevicePolicyError(channelTrustedDevicePolicy(policies, convID),
+		g.ActiveDevice.DeviceID(), provisionedAt, g.Clock().Now()); err != nil {
+		return fmt.Errorf("this device can't change the channel's policy yet: %v", err)
+	}
+	policies, err = change(conv, policies)
+	if err != nil {
+		return err
+	}
+	return trustedDevicePolicies.store(ctx, g, ri, uid, teamID, policies)
+}
+
+func setTrustedDevicePolicy(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, convID chat1.ConversationID, days int) error {
+	if err := checkTrustedDeviceMinAge(days); err != nil {
+		return err
+	}
+	return changeTrustedDevicePolicies(ctx, g, ri, uid, convID,
+		func(conv chat1.ConversationLocal, policies chat1.TrustedDevicePolicies) (chat1.TrustedDevicePolicies, error) {
+			return applyTrustedDevicePolicy(policies, convID, conv.GetTopicName(),
+				g.Env.GetUsername().String(), days, g.Clock().Now()), nil
+		})
+}
+
+func setTrustedDeviceApproved(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, convID chat1.ConversationID, username, deviceName string, approved bool) error {
+	upak, _, err := g.GetUPAKLoader().LoadV2(libkb.NewLoadUserByNameArg(g.ExternalG(), username))
+	if err != nil {
+		return err
+	}
+	key := findDeviceKey(upak.Current, "", deviceName)
+	if key == nil {
+		return fmt.Errorf("%s has no device named %q", username, deviceName)
+	}
+	approval := chat1.TrustedDeviceApproval{
+		Uid:        upak.Current.Uid,
+		DeviceID:   key.DeviceID,
+		Username:   upak.Current.Username,
+		DeviceName: deviceName,
+		ApprovedBy: g.Env.GetUsername().String(),
+	}
+	return changeTrustedDevicePolicies(ctx, g, ri, uid, convID,
+		func(_ chat1.ConversationLocal, policies chat1.TrustedDevicePolicies) (chat1.TrustedDevicePolicies, error) {
+			return applyTrustedDeviceApproval(policies, convID, approval, approved)
+		})
+}
+
+// checkTrustedDevicePolicy is called on every message we prepare, and fails
+// if the channel has a trusted device policy this device doesn't meet.
+func (s *BlockingSender) checkTrustedDevicePolicy(ctx context.Context, uid gregor1.UID,
+	conv chat1.ConversationLocal, msg chat1.MessagePlaintext) (err error) {
+	if !isTrustedDevicePolicyType(msg.ClientHeader.MessageType) {
+		return nil
+	}
+	policies, _, ok, err := prepareTeamPolicy(ctx, s, uid, conv, trustedDevicePolicies)
+	if !ok {
+		return err
+	}
+	policy := channelTrustedDevicePolicy(policies, conv.GetConvID())
+	if policy == nil {
+		return nil
+	}
+	provisionedAt, err := thisDeviceProvisionedAt(ctx, s.G(), uid)
+	if err != nil {
+		return err
+	}
+	return trustedDevicePolicyError(policy, s.G().ActiveDevice.DeviceID(), provisionedAt, s.clock.Now())
+}
@@ -0,0 +1,67 @@
+package chat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrustedDevicePolicy(t *testing.T) {
+	releases := chat1.ConversationID([]byte{1})
+	general := chat1.ConversationID([]byte{2})
+	now := time.Unix(1700000000, 0)
+	day := 24 * time.Hour
+	laptop := keybase1.DeviceID("0123456789abcdef0123456789abcd18")
+	phone := keybase1.DeviceID("fedcba9876543210fedcba9876543218")
+
+	require.NoError(t, checkTrustedDeviceMinAge(0))
+	require.NoError(t, checkTrustedDeviceMinAge(7))
+	require.Error(t, checkTrustedDeviceMinAge(-1))
+	require.Error(t, checkTrustedDeviceMinAge(maxTrustedDeviceMinAgeDays+1))
+
+	// Approving a device needs a policy to approve it under.
+	approval := chat1.TrustedDeviceApproval{DeviceID: phone, Username: "alice", DeviceName: "phone"}
+	_, err := applyTrustedDeviceApproval(chat1.TrustedDevicePolicies{}, releases, approval, true)
+	require.Error(t, err)
+
+	policies := applyTrustedDevicePolicy(chat1.TrustedDevicePolicies{}, releases, "releases", "alice", 7, now)
+	require.Nil(t, channelTrustedDevicePolicy(policies, general))
+	policy := channelTrustedDevicePolicy(policies, releases)
+	require.NotNil(t, policy)
+	require.Equal(t, 7, policy.MinDeviceAgeDays)
+	require.Equal(t, "alice", policy.SetBy)
+
+	// A device that's old enough can post; a new one can't until it is.
+	require.NoError(t, trustedDevicePolicyError(policy, laptop, now.Add(-30*day), now))
+	require.NoError(t, trustedDevicePolicyError(policy, laptop, now.Add(-7*day), now))
+	err = trustedDevicePolicyError(policy, phone, now.Add(-2*day), now)
+	require.IsType(t, UntrustedDeviceError{}, err)
+	require.Equal(t, now.Add(5*day), err.(UntrustedDeviceError).TrustedAt)
+	require.NoError(t, trustedDevicePolicyError(nil, phone, now, now))
+
+	// Until an admin approves it.
+	policies, err = applyTrustedDeviceApproval(policies, releases, approval, true)
+	require.NoError(t, err)
+	policy = channelTrustedDevicePolicy(policies, releases)
+	require.NoError(t, trustedDevicePolicyError(policy, phone, now.Add(-2*day), now))
+
+	// Changing the minimum age keeps approvals; removing the policy drops
+	// them.
+	policies = applyTrustedDevicePolicy(policies, releases, "releases", "bob", 14, now)
+	policy = channelTrustedDevicePolicy(policies, releases)
+	require.Equal(t, 14, policy.MinDeviceAgeDays)
+	require.Len(t, policy.Approved, 1)
+	policies, err = applyTrustedDeviceApproval(policies, releases, approval, false)
+	require.NoError(t, err)
+	policy = channelTrustedDevicePolicy(policies, releases)
+	require.Empty(t, policy.Approved)
+	require.Error(t, trustedDevicePolicyError(policy, phone, now.Add(-2*day), now))
+	policies = applyTrustedDevicePolicy(policies, releases, "releases", "bob", 0, now)
+	require.Empty(t, policies.Channels)
+
+	require.True(t, isTrustedDevicePolicyType(chat1.MessageType_TEXT))
+	require.False(t, isTrustedDevicePolicyType(chat1.MessageType_REACTION))
+}
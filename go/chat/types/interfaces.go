@@ -666,6 +666,32 @@ type ChatArchiveRegistry interface {
 	Pause(ctx context.Context, jobID chat1.ArchiveJobID) (err error)
 	// Resume a paused job
 	Resume(ctx context.Context, jobID chat1.ArchiveJobID) (err error)
+	// Rerun a job on a schedule, appending to its output, or stop if schedule is nil
+	SetSchedule(ctx context.Context, jobID chat1.ArchiveJobID, schedule *chat1.ArchiveChatSchedule) (err error)
+	// Export the job history, sealing sensitive fields unless plaintext is set
+	Export(ctx context.Context, plaintext bool) (res chat1.ArchiveChatHistoryExport, err error)
+	// Import jobs from an export into the history
+	Import(ctx context.Context, export chat1.ArchiveChatHistoryExport) (res chat1.ArchiveChatImportHistoryRes, err error)
+	// Search the messages in the output of completed jobs
+	Search(ctx context.Context, query string, maxHits int) (res chat1.ArchiveChatSearchRes, err error)
+	OnDbNuke(libkb.MetaContext) error
+	// Pause running jobs ahead of a restart, to be resumed after it
+	OnDrain(libkb.MetaContext) error
+}
+
+// TeamPolicyCache holds the policies team admins keep in a team's admin-only
+// dev storage, by team and storage name, so sending or getting pushed a
+// message doesn't have to load them each time.
+type TeamPolicyCache interface {
+	Get(teamID keybase1.TeamID, name string, maxAge time.Duration, now time.Time) (interface{}, bool)
+	Put(teamID keybase1.TeamID, name string, policy interface{}, now time.Time)
+	Invalidate(teamID keybase1.TeamID, name string)
+	// Count a send in teamID by a member the team's new member restrictions
+	// apply to, unless it's a retry of outboxID, returning how many sends
+	// there have been in the last hour before it
+	RecordNewMemberSend(teamID keybase1.TeamID, outboxID string, now time.Time) (recent int, retry bool)
+	ForgetNewMemberSend(teamID keybase1.TeamID, outboxID string)
+	OnLogout(libkb.MetaContext) error
 	OnDbNuke(libkb.MetaContext) error
 }
 
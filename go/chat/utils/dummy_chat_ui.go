@@ -235,3 +235,15 @@ func (d DummyChatNotifications) ChatParticipantsInfo(context.Context,
 	map[chat1.ConvIDStr][]chat1.UIParticipant) error {
 	return nil
 }
+func (d DummyChatNotifications) ChatSplitConversationProgress(context.Context,
+	chat1.ChatSplitConversationProgressArg) error {
+	return nil
+}
+func (d DummyChatNotifications) ChatMentionDigest(context.Context,
+	chat1.ChatMentionDigestArg) error {
+	return nil
+}
+func (d DummyChatNotifications) ChatIdentityChangeAlert(context.Context,
+	chat1.ChatIdentityChangeAlertArg) error {
+	return nil
+}
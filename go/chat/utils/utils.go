@@ -824,6 +824,22 @@ func ParseAtMentionedItems(ctx context.Context, g *globals.Context, body string,
 	return atRes, maybeRes, chanRes
 }
 
+// QuoteAtMentions wraps each @-mention in body that isn't already quoted in
+// backticks, so reposting body doesn't notify anyone.
+func QuoteAtMentions(ctx context.Context, body string) string {
+	var res strings.Builder
+	prev := 0
+	for _, m := range parseRegexpNames(ctx, body, atMentionRegExp) {
+		// The match doesn't include the @.
+		start, end := m.position[0]-1, m.position[1]
+		res.WriteString(body[prev:start])
+		res.WriteString("`" + body[start:end] + "`")
+		prev = end
+	}
+	res.WriteString(body[prev:])
+	return res.String()
+}
+
 func SystemMessageMentions(ctx context.Context, g *globals.Context, uid gregor1.UID,
 	body chat1.MessageSystem) (atMentions []gregor1.UID, chanMention chat1.ChannelMention, channelNameMentions []chat1.ChannelNameMention) {
 	typ, err := body.SystemType()
@@ -1169,6 +1185,10 @@ func GetMsgSnippetBody(ctx context.Context, g *globals.Context, uid gregor1.UID,
 		return msgBody.Flip().Text, ""
 	case chat1.MessageType_PIN:
 		return "Pinned message", ""
+	case chat1.MessageType_POLL:
+		return fmt.Sprintf("Poll: %s", msgBody.Poll().Question), ""
+	case chat1.MessageType_TASK:
+		return fmt.Sprintf("Task: %s", msgBody.Task().Title), ""
 	case chat1.MessageType_ATTACHMENT:
 		obj := msgBody.Attachment().Object
 		title := obj.Title
@@ -2067,6 +2087,7 @@ func PresentMessageUnboxed(ctx context.Context, g *globals.Context, rawMsg chat1
 			CardType:       journeycard.CardType,
 			HighlightMsgID: journeycard.HighlightMsgID,
 			OpenTeam:       journeycard.OpenTeam,
+			IdentityChange: journeycard.IdentityChange,
 		})
 	default:
 		g.MetaContext(ctx).Debug("PresentMessageUnboxed: unhandled MessageUnboxedState: %v", state)
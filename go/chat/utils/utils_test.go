@@ -56,6 +56,15 @@ func TestParseAtMentionsNames(t *testing.T) {
 	require.Equal(t, expected, names)
 }
 
+func TestQuoteAtMentions(t *testing.T) {
+	ctx := context.TODO()
+	require.Equal(t, "`@here` ping `@mike#general` and `@jim`, not jim@example.com",
+		QuoteAtMentions(ctx, "@here ping @mike#general and @jim, not jim@example.com"))
+	require.Equal(t, "already `@quoted` and\n```\n@fenced\n```",
+		QuoteAtMentions(ctx, "already `@quoted` and\n```\n@fenced\n```"))
+	require.Equal(t, "no mentions", QuoteAtMentions(ctx, "no mentions"))
+}
+
 type testTeamChannelSource struct {
 	channels []string
 }
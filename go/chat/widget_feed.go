@@ -0,0 +1,408 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/chat/storage"
+	"github.com/keybase/client/go/chat/types"
+	"github.com/keybase/client/go/chat/utils"
+	"github.com/keybase/client/go/encrypteddb"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+)
+
+// The widget feed is kept up to date from the push handler as messages come
+// in, so the mobile home screen widget only has to open one small file
+// instead of waking up the service. The feed and its key are also kept in
+// the local chat db, sealed like the rest of the chat cache, so an update
+// doesn't have to read the file back. The file is sealed with the key the
+// app gave us, which it shares with the widget but we don't share with the
+// other extensions that can read the shared cache directory.
+
+const widgetFeedFileName = "chat-widget-feed"
+
+const (
+	// widgetFeedDefaultMaxRecent is how many conversations the feed follows
+	// if the app doesn't say.
+	widgetFeedDefaultMaxRecent = 10
+	widgetFeedMaxRecent        = 50
+	// widgetFeedMaxPinned is the most pinned messages; a widget has room for
+	// a handful at best.
+	widgetFeedMaxPinned = 10
+	// widgetFeedSnippetLen is the most runes of a message in the feed.
+	widgetFeedSnippetLen = 100
+)
+
+var errWidgetFeedDisabled = errors.New("the widget feed isn't enabled")
+
+type widgetFeedState struct {
+	Key       [32]byte         `codec:"k"`
+	MaxRecent int              `codec:"m"`
+	Feed      chat1.WidgetFeed `codec:"f"`
+}
+
+func widgetFeedDbKey(uid gregor1.UID) libkb.DbKey {
+	return libkb.DbKey{
+		Typ: libkb.DBChatWidgetFeed,
+		Key: uid.String(),
+	}
+}
+
+func widgetFeedDB(g *globals.Context, uid gregor1.UID) *encrypteddb.EncryptedDB {
+	dbFn := func(g *libkb.GlobalContext) *libkb.JSONLocalDb {
+		return g.LocalChatDb
+	}
+	keyFn := func(ctx context.Context) ([32]byte, error) {
+		return storage.GetSecretBoxKeyWithUID(ctx, g.ExternalG(), uid)
+	}
+	return encrypteddb.New(g.ExternalG(), dbFn, keyFn)
+}
+
+// WidgetFeedPath is where the feed file goes, in the cache directory the app
+// shares with its extensions.
+func WidgetFeedPath(g *libkb.GlobalContext) string {
+	return filepath.Join(g.Env.GetSharedCacheDir(), widgetFeedFileName)
+}
+
+func widgetFeedKeyFn(key [32]byte) encrypteddb.KeyFn {
+	return func(context.Context) ([32]byte, error) {
+		return key, nil
+	}
+}
+
+func writeWidgetFeedFile(ctx context.Context, log libkb.SafeWriteLogger, path string,
+	key [32]byte, feed chat1.WidgetFeed) error {
+	b, err := encrypteddb.EncodeBox(ctx, feed, widgetFeedKeyFn(key))
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), libkb.PermDir); err != nil {
+		return err
+	}
+	return libkb.NewFile(path, b, 0600).Save(log)
+}
+
+// ReadWidgetFeedFile opens the feed file at path with the key the app
+// enabled the feed with. It's for the widget, so it doesn't need a running
+// service.
+func ReadWidgetFeedFile(ctx context.Context, path string, key [32]byte) (
+	feed chat1.WidgetFeed, err error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return feed, err
+	}
+	err = encrypteddb.DecodeBox(ctx, b, widgetFeedKeyFn(key), &feed)
+	return feed, err
+}
+
+func loadWidgetFeedStateLocked(ctx context.Context, g *globals.Context, uid gregor1.UID) (
+	state widgetFeedState, found bool, err error) {
+	found, err = widgetFeedDB(g, uid).Get(ctx, widgetFeedDbKey(uid), &state)
+	return state, found, err
+}
+
+func saveWidgetFeedStateLocked(ctx context.Context, g *globals.Context, uid gregor1.UID,
+	state widgetFeedState) error {
+	state.Feed.Version++
+	state.Feed.Updated = gregor1.ToTime(g.Clock().Now())
+	if err := widgetFeedDB(g, uid).Put(ctx, widgetFeedDbKey(uid), state); err != nil {
+		return err
+	}
+	return writeWidgetFeedFile(ctx, g.Log, WidgetFeedPath(g.ExternalG()), state.Key, state.Feed)
+}
+
+// widgetFeedSnippet flattens a message body onto one line, and shortens it to
+// widgetFeedSnippetLen runes.
+func widgetFeedSnippet(body string) string {
+	body = strings.Join(strings.Fields(body), " ")
+	runes := []rune(body)
+	if len(runes) <= widgetFeedSnippetLen {
+		return body
+	}
+	return string(runes[:widgetFeedSnippetLen-1]) + "…"
+}
+
+// widgetFeedItem makes a feed item of msg, if it's a message the widget
+// should show. Exploding messages are left out, since the widget can't make
+// them go away on time.
+func widgetFeedItem(ctx context.Context, g *globals.Context, uid gregor1.UID,
+	conv chat1.ConversationLocal, msg chat1.MessageUnboxed) (item chat1.WidgetFeedItem, ok bool) {
+	if !msg.IsValidFull() || msg.Valid().IsEphemeral() {
+		return item, false
+	}
+	switch msg.GetMessageType() {
+	case chat1.MessageType_TEXT, chat1.MessageType_ATTACHMENT:
+	default:
+		return item, false
+	}
+	valid := msg.Valid()
+	snippet, _ := utils.GetMsgSnippetBody(ctx, g, uid, conv.GetConvID(), msg)
+	item = chat1.WidgetFeedItem{
+		ConvID:  conv.GetConvID(),
+		TlfName: conv.Info.TlfName,
+		MsgID:   valid.ServerHeader.MessageID,
+		Sender:  valid.SenderUsername,
+		Ctime:   valid.ServerHeader.Ctime,
+		Snippet: widgetFeedSnippet(snippet),
+	}
+	if conv.GetMembersType() == chat1.ConversationMembersType_TEAM {
+		item.Channel = conv.Info.TopicName
+	}
+	return item, true
+}
+
+// widgetFeedAddRecent makes item the latest message of its conversation,
+// moving the conversation to the front of the recent ones, and drops the
+// least recently active ones past maxRecent. It says whether anything
+// changed, which it doesn't if the conversation already has a later message.
+func widgetFeedAddRecent(items []chat1.WidgetFeedItem, item chat1.WidgetFeedItem,
+	maxRecent int) ([]chat1.WidgetFeedItem, bool) {
+	var pinned, recent []chat1.WidgetFeedItem
+	for _, it := range items {
+		switch {
+		case it.Pinned:
+			pinned = append(pinned, it)
+		case it.ConvID.Eq(item.ConvID):
+			if it.MsgID >= item.MsgID {
+				return items, false
+			}
+		default:
+			recent = append(recent, it)
+		}
+	}
+	item.Pinned = false
+	recent = append([]chat1.WidgetFeedItem{item}, recent...)
+	if len(recent) > maxRecent {
+		recent = recent[:maxRecent]
+	}
+	return append(pinned, recent...), true
+}
+
+// widgetFeedSetPinned pins item at the front of the feed, or unpins the
+// message it's for.
+func widgetFeedSetPinned(items []chat1.WidgetFeedItem, item chat1.WidgetFeedItem,
+	pin bool) ([]chat1.WidgetFeedItem, error) {
+	res := make([]chat1.WidgetFeedItem, 0, len(items)+1)
+	numPinned := 0
+	for _, it := range items {
+		if it.Pinned && it.ConvID.Eq(item.ConvID) && it.MsgID == item.MsgID {
+			continue
+		}
+		if it.Pinned {
+			numPinned++
+		}
+		res = append(res, it)
+	}
+	if !pin {
+		return res, nil
+	}
+	if numPinned >= widgetFeedMaxPinned {
+		return nil, fmt.Errorf("at most %d messages can be pinned to the widget",
+			widgetFeedMaxPinned)
+	}
+	item.Pinned = true
+	return append([]chat1.WidgetFeedItem{item}, res...), nil
+}
+
+// widgetFeedApplySupersede updates the feed for an edit or delete of
+// messages in it. It says whether anything changed.
+func widgetFeedApplySupersede(items []chat1.WidgetFeedItem, convID chat1.ConversationID,
+	body chat1.MessageBody) ([]chat1.WidgetFeedItem, bool) {
+	changed := false
+	res := make([]chat1.WidgetFeedItem, 0, len(items))
+	for _, it := range items {
+		if !it.ConvID.Eq(convID) {
+			res = append(res, it)
+			continue
+		}
+		switch {
+		case body.IsType(chat1.MessageType_EDIT) && body.Edit().MessageID == it.MsgID:
+			it.Snippet = widgetFeedSnippet(body.Edit().Body)
+			changed = true
+		case body.IsType(chat1.MessageType_DELETE) && widgetFeedDeletes(body.Delete(), it.MsgID):
+			changed = true
+			continue
+		}
+		res = append(res, it)
+	}
+	return res, changed
+}
+
+func widgetFeedDeletes(del chat1.MessageDelete, msgID chat1.MessageID) bool {
+	for _, id := range del.MessageIDs {
+		if id == msgID {
+			return true
+		}
+	}
+	return false
+}
+
+// updateWidgetFeedFromMessage keeps the widget feed up to date with a new
+// message, if the feed is enabled.
+func updateWidgetFeedFromMessage(ctx context.Context, g *globals.Context, uid gregor1.UID,
+	conv *chat1.ConversationLocal, msg chat1.MessageUnboxed) {
+	if conv == nil || conv.GetTopicType() != chat1.TopicType_CHAT || !msg.IsValid() {
+		return
+	}
+	g.WidgetFeedLock.Lock()
+	defer g.WidgetFeedLock.Unlock()
+	state, found, err := loadWidgetFeedStateLocked(ctx, g, uid)
+	if err != nil {
+		g.Log.CDebugf(ctx, "updateWidgetFeedFromMessage: failed to load: %v", err)
+		return
+	}
+	if !found {
+		return
+	}
+	var changed bool
+	switch msg.GetMessageType() {
+	case chat1.MessageType_EDIT, chat1.MessageType_DELETE:
+		state.Feed.Items, changed = widgetFeedApplySupersede(state.Feed.Items,
+			conv.GetConvID(), msg.Valid().MessageBody)
+	default:
+		if conv.Info.Status == chat1.ConversationStatus_MUTED {
+			return
+		}
+		item, ok := widgetFeedItem(ctx, g, uid, *conv, msg)
+		if !ok {
+			return
+		}
+		state.Feed.Items, changed = widgetFeedAddRecent(state.Feed.Items, item, state.MaxRecent)
+	}
+	if !changed {
+		return
+	}
+	if err := saveWidgetFeedStateLocked(ctx, g, uid, state); err != nil {
+		g.Log.CDebugf(ctx, "updateWidgetFeedFromMessage: failed to save: %v", err)
+	}
+}
+
+// seedWidgetFeed fills in the recent part of a new feed from the snippets of
+// the inbox we have cached, so the widget has something to show before the
+// next message comes in.
+func seedWidgetFeed(ctx context.Context, g *globals.Context, uid gregor1.UID,
+	items []chat1.WidgetFeedItem, maxRecent int) []chat1.WidgetFeedItem {
+	topicType := chat1.TopicType_CHAT
+	inbox, _, err := g.InboxSource.Read(ctx, uid, types.ConversationLocalizerBlocking,
+		types.InboxSourceDataSourceLocalOnly, nil, &chat1.GetInboxLocalQuery{
+			TopicType: &topicType,
+		})
+	if err != nil {
+		g.Log.CDebugf(ctx, "seedWidgetFeed: failed to read the inbox: %v", err)
+		return items
+	}
+	convs := inbox.Convs
+	// Oldest first, so the latest ends up at the front.
+	sort.SliceStable(convs, func(i, j int) bool {
+		return convs[i].GetMtime() < convs[j].GetMtime()
+	})
+	for _, conv := range convs {
+		if conv.Error != nil || conv.Info.SnippetMsg == nil ||
+			conv.Info.Status == chat1.ConversationStatus_MUTED {
+			continue
+		}
+		item, ok := widgetFeedItem(ctx, g, uid, conv, *conv.Info.SnippetMsg)
+		if !ok {
+			continue
+		}
+		items, _ = widgetFeedAddRecent(items, item, maxRecent)
+	}
+	return items
+}
+
+func enableWidgetFeed(ctx context.Context, g *globals.Context, uid gregor1.UID,
+	key []byte, maxRecent int) error {
+	if len(key) != 32 {
+		return fmt.Errorf("the widget feed key must be 32 bytes, not %d", len(key))
+	}
+	switch {
+	case maxRecent == 0:
+		maxRecent = widgetFeedDefaultMaxRecent
+	case maxRecent < 0 || maxRecent > widgetFeedMaxRecent:
+		return fmt.Errorf("maxRecent must be between 1 and %d", widgetFeedMaxRecent)
+	}
+	g.WidgetFeedLock.Lock()
+	defer g.WidgetFeedLock.Unlock()
+	state, _, err := loadWidgetFeedStateLocked(ctx, g, uid)
+	if err != nil {
+		return err
+	}
+	copy(state.Key[:], key)
+	state.MaxRecent = maxRecent
+	// Keep the pins of a feed that's already enabled, but start the recent
+	// ones over, in case maxRecent went up.
+	var pinned []chat1.WidgetFeedItem
+	for _, it := range state.Feed.Items {
+		if it.Pinned {
+			pinned = append(pinned, it)
+		}
+	}
+	state.Feed.Items = seedWidgetFeed(ctx, g, uid, pinned, maxRecent)
+	return saveWidgetFeedStateLocked(ctx, g, uid, state)
+}
+
+func disableWidgetFeed(ctx context.Context, g *globals.Context, uid gregor1.UID) error {
+	g.WidgetFeedLock.Lock()
+	defer g.WidgetFeedLock.Unlock()
+	if err := widgetFeedDB(g, uid).Delete(ctx, widgetFeedDbKey(uid)); err != nil {
+		return err
+	}
+	err := os.Remove(WidgetFeedPath(g.ExternalG()))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func pinWidgetFeedMessage(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface,
+	uid gregor1.UID, convID chat1.ConversationID, msgID chat1.MessageID, pin bool) error {
+	item := chat1.WidgetFeedItem{ConvID: convID, MsgID: msgID}
+	if pin {
+		conv, err := utils.GetVerifiedConv(ctx, g, uid, convID, types.InboxSourceDataSourceAll)
+		if err != nil {
+			return err
+		}
+		msg, err := g.ConvSource.GetMessage(ctx, convID, uid, msgID, nil, ri, true)
+		if err != nil {
+			return err
+		}
+		var ok bool
+		if item, ok = widgetFeedItem(ctx, g, uid, conv, msg); !ok {
+			return errors.New("only text messages and attachments can be pinned to the widget")
+		}
+	}
+	g.WidgetFeedLock.Lock()
+	defer g.WidgetFeedLock.Unlock()
+	state, found, err := loadWidgetFeedStateLocked(ctx, g, uid)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errWidgetFeedDisabled
+	}
+	state.Feed.Items, err = widgetFeedSetPinned(state.Feed.Items, item, pin)
+	if err != nil {
+		return err
+	}
+	return saveWidgetFeedStateLocked(ctx, g, uid, state)
+}
+
+func getWidgetFeed(ctx context.Context, g *globals.Context, uid gregor1.UID) (
+	chat1.WidgetFeed, error) {
+	g.WidgetFeedLock.Lock()
+	defer g.WidgetFeedLock.Unlock()
+	state, _, err := loadWidgetFeedStateLocked(ctx, g, uid)
+	if err != nil {
+		return chat1.WidgetFeed{}, err
+	}
+	// An empty feed, with version 0, says it isn't enabled.
+	return state.Feed, nil
+}
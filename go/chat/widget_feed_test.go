@@ -0,0 +1,97 @@
+package chat
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/keybase/client/go/logger"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWidgetFeedItems(t *testing.T) {
+	convA := chat1.ConversationID{0x0a}
+	convB := chat1.ConversationID{0x0b}
+	convC := chat1.ConversationID{0x0c}
+	item := func(convID chat1.ConversationID, msgID chat1.MessageID) chat1.WidgetFeedItem {
+		return chat1.WidgetFeedItem{ConvID: convID, MsgID: msgID, Snippet: "hi"}
+	}
+	ids := func(items []chat1.WidgetFeedItem) (res []chat1.MessageID) {
+		for _, it := range items {
+			res = append(res, it.MsgID)
+		}
+		return res
+	}
+
+	var items []chat1.WidgetFeedItem
+	var changed bool
+	items, _ = widgetFeedAddRecent(items, item(convA, 1), 2)
+	items, _ = widgetFeedAddRecent(items, item(convB, 2), 2)
+	require.Equal(t, []chat1.MessageID{2, 1}, ids(items))
+	// A later message moves its conversation to the front, an earlier one
+	// doesn't change anything.
+	items, changed = widgetFeedAddRecent(items, item(convA, 3), 2)
+	require.True(t, changed)
+	require.Equal(t, []chat1.MessageID{3, 2}, ids(items))
+	_, changed = widgetFeedAddRecent(items, item(convB, 1), 2)
+	require.False(t, changed)
+	// The least recent conversation goes past maxRecent.
+	items, _ = widgetFeedAddRecent(items, item(convC, 4), 2)
+	require.Equal(t, []chat1.MessageID{4, 3}, ids(items))
+
+	// Pins stay at the front, and don't count against maxRecent.
+	items, err := widgetFeedSetPinned(items, item(convB, 2), true)
+	require.NoError(t, err)
+	require.Equal(t, []chat1.MessageID{2, 4, 3}, ids(items))
+	require.True(t, items[0].Pinned)
+	items, _ = widgetFeedAddRecent(items, item(convB, 5), 2)
+	require.Equal(t, []chat1.MessageID{2, 5, 4}, ids(items))
+
+	items, changed = widgetFeedApplySupersede(items, convB, chat1.NewMessageBodyWithEdit(
+		chat1.MessageEdit{MessageID: 2, Body: "edited\nhere"}))
+	require.True(t, changed)
+	require.Equal(t, "edited here", items[0].Snippet)
+	items, changed = widgetFeedApplySupersede(items, convC, chat1.NewMessageBodyWithDelete(
+		chat1.MessageDelete{MessageIDs: []chat1.MessageID{4}}))
+	require.True(t, changed)
+	require.Equal(t, []chat1.MessageID{2, 5}, ids(items))
+	_, changed = widgetFeedApplySupersede(items, convA, chat1.NewMessageBodyWithDelete(
+		chat1.MessageDelete{MessageIDs: []chat1.MessageID{2}}))
+	require.False(t, changed)
+
+	items, err = widgetFeedSetPinned(items, item(convB, 2), false)
+	require.NoError(t, err)
+	require.Equal(t, []chat1.MessageID{5}, ids(items))
+	for i := 0; i < widgetFeedMaxPinned; i++ {
+		items, err = widgetFeedSetPinned(items, item(convA, chat1.MessageID(10+i)), true)
+		require.NoError(t, err)
+	}
+	_, err = widgetFeedSetPinned(items, item(convA, 100), true)
+	require.Error(t, err)
+
+	long := widgetFeedSnippet(strings.Repeat("a", 2*widgetFeedSnippetLen))
+	require.Len(t, []rune(long), widgetFeedSnippetLen)
+}
+
+func TestWidgetFeedFile(t *testing.T) {
+	ctx := context.TODO()
+	path := filepath.Join(t.TempDir(), "shared", widgetFeedFileName)
+	key := [32]byte{0x01}
+	feed := chat1.WidgetFeed{
+		Version: 3,
+		Items: []chat1.WidgetFeedItem{{
+			ConvID:  chat1.ConversationID{0x0a},
+			TlfName: "alice,bob",
+			MsgID:   7,
+			Snippet: "hi",
+		}},
+	}
+	require.NoError(t, writeWidgetFeedFile(ctx, logger.NewTestLogger(t), path, key, feed))
+	res, err := ReadWidgetFeedFile(ctx, path, key)
+	require.NoError(t, err)
+	require.Equal(t, feed, res)
+	_, err = ReadWidgetFeedFile(ctx, path, [32]byte{0x02})
+	require.Error(t, err)
+}
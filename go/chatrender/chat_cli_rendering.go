@@ -368,6 +368,11 @@ func (v ConversationView) RenderToWriter(g *libkb.GlobalContext, writer io.Write
 	if headline != "" && !v.Opts.SkipHeadline {
 		fmt.Fprintf(writer, "headline: %s\n\n", headline)
 	}
+	if banner := v.Conversation.Info.Banner; banner != nil && !v.Opts.SkipHeadline &&
+		time.Now().Before(banner.ExpiresAt.Time()) {
+		fmt.Fprintf(writer, "banner: %s (from %s, until %s)\n\n", banner.Text, banner.SetBy,
+			banner.ExpiresAt.Time().Local().Format("2006-01-02 15:04"))
+	}
 
 	table := &flexibletable.Table{}
 	for i := len(v.Messages) - 1; i >= 0; i-- {
@@ -480,6 +485,31 @@ func formatSystemMessage(body chat1.MessageSystem) string {
 	return fmt.Sprintf("[%s]", m)
 }
 
+// formatPollMessage shows the poll's options numbered the way `keybase chat
+// poll --vote` takes them.
+func formatPollMessage(msgID chat1.MessageID, body chat1.MessagePoll) string {
+	kind := "pick one"
+	if body.MultiSelect {
+		kind = "pick any"
+	}
+	lines := []string{fmt.Sprintf("[poll %d, %s] %s", msgID, kind, body.Question)}
+	for i, option := range body.Options {
+		lines = append(lines, fmt.Sprintf("  %d. %s", i+1, option))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatTaskMessage shows the task with the message ID `keybase chat task`
+// takes. Who it's assigned to now, and whether it's done, are in `keybase
+// chat task --list`.
+func formatTaskMessage(msgID chat1.MessageID, body chat1.MessageTask) string {
+	s := fmt.Sprintf("[task %d] %s", msgID, body.Title)
+	if len(body.Assignee) > 0 {
+		s += fmt.Sprintf(" (for @%s)", body.Assignee)
+	}
+	return s
+}
+
 func formatSendPaymentMessage(g *libkb.GlobalContext, opts RenderOptions, body chat1.MessageSendPayment) string {
 	ctx := context.Background()
 	if opts.GetWalletClient == nil {
@@ -563,11 +593,18 @@ func formatRequestPaymentMessage(g *libkb.GlobalContext, opts RenderOptions, bod
 		view += "\n> " + body.Note
 	}
 
-	if details.Status == stellar1.RequestStatus_CANCELED {
-		// If canceled, add "[canceled]" prefix.
+	switch details.Status {
+	case stellar1.RequestStatus_CANCELED:
 		view = "[canceled] " + view
-	} else {
-		// If not, append request ID for cancel-request command.
+	case stellar1.RequestStatus_DECLINED:
+		view = "[declined] " + view
+	case stellar1.RequestStatus_EXPIRED:
+		view = "[expired] " + view
+	case stellar1.RequestStatus_DONE:
+		view = "[paid] " + view
+	default:
+		// Still pending, so append request ID for the cancel-request
+		// command.
 		view += fmt.Sprintf("\n[Request ID: %s]", body.RequestID)
 	}
 
@@ -651,6 +688,12 @@ func newMessageViewValid(g *libkb.GlobalContext, opts RenderOptions, conversatio
 		mv.Body = m.MessageBody.Flip().Text
 	case chat1.MessageType_PIN:
 		mv.Renderable = false
+	case chat1.MessageType_POLL:
+		mv.Renderable = true
+		mv.Body = formatPollMessage(m.ServerHeader.MessageID, m.MessageBody.Poll())
+	case chat1.MessageType_TASK:
+		mv.Renderable = true
+		mv.Body = formatTaskMessage(m.ServerHeader.MessageID, m.MessageBody.Task())
 	default:
 		return mv, fmt.Errorf(fmt.Sprintf("unsupported MessageType: %s", typ.String()))
 	}
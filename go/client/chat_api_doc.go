@@ -46,6 +46,11 @@ Upload an attachment:
 Download an attachment:
     {"method": "download", "params": {"options": {"channel": {"name": "you,them"}, "message_id": 59, "output": "/tmp/movie.mp4"}}}
 
+Download part of an attachment, or pick up an interrupted download where it left off
+("progress" objects are written before the result):
+    {"method": "downloadattachment", "params": {"options": {"channel": {"name": "you,them"}, "message_id": 59, "offset": 1048576, "length": 1048576, "output": "/tmp/movie.part2"}}}
+    {"method": "downloadattachment", "params": {"options": {"channel": {"name": "you,them"}, "message_id": 59, "resume": true, "output": "/tmp/movie.mp4"}}}
+
 Peek into a conversation (doesn't mark messages as read):
     {"method": "read", "params": {"options": {"channel": {"name": "you,them"}, "peek": true}}}
 
@@ -10,6 +10,7 @@ import (
 	"io"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/keybase/client/go/chat/utils"
@@ -30,6 +31,7 @@ const (
 	methodDelete              = "delete"
 	methodAttach              = "attach"
 	methodDownload            = "download"
+	methodDownloadAttachment  = "downloadattachment"
 	methodSetStatus           = "setstatus"
 	methodMark                = "mark"
 	methodSearchInbox         = "searchinbox"
@@ -69,6 +71,7 @@ type ChatAPIHandler interface {
 	DeleteV1(context.Context, Call, io.Writer) error
 	AttachV1(context.Context, Call, io.Writer) error
 	DownloadV1(context.Context, Call, io.Writer) error
+	DownloadAttachmentV1(context.Context, Call, io.Writer) error
 	SetStatusV1(context.Context, Call, io.Writer) error
 	MarkV1(context.Context, Call, io.Writer) error
 	SearchInboxV1(context.Context, Call, io.Writer) error
@@ -356,6 +359,39 @@ func (a downloadOptionsV1) Check() error {
 	return nil
 }
 
+type downloadAttachmentOptionsV1 struct {
+	Channel        ChatChannel
+	ConversationID chat1.ConvIDStr `json:"conversation_id"`
+	MessageID      chat1.MessageID `json:"message_id"`
+	Output         string
+	Preview        bool
+	Offset         int64
+	Length         int64
+	Resume         bool
+}
+
+func (a downloadAttachmentOptionsV1) Check() error {
+	if err := checkChannelConv(methodDownloadAttachment, a.Channel, a.ConversationID); err != nil {
+		return err
+	}
+	if a.MessageID == 0 {
+		return ErrInvalidOptions{version: 1, method: methodDownloadAttachment, err: fmt.Errorf("invalid message id '%d'", a.MessageID)}
+	}
+	if len(strings.TrimSpace(a.Output)) == 0 {
+		return ErrInvalidOptions{version: 1, method: methodDownloadAttachment, err: errors.New("empty output filename")}
+	}
+	if a.Offset < 0 || a.Length < 0 {
+		return ErrInvalidOptions{version: 1, method: methodDownloadAttachment, err: errors.New("offset and length must not be negative")}
+	}
+	if a.Resume && a.Offset > 0 {
+		return ErrInvalidOptions{version: 1, method: methodDownloadAttachment, err: errors.New("resume picks the offset from the output file, don't give one")}
+	}
+	if a.Resume && a.Output == "-" {
+		return ErrInvalidOptions{version: 1, method: methodDownloadAttachment, err: errors.New("can't resume a download to stdout")}
+	}
+	return nil
+}
+
 type setStatusOptionsV1 struct {
 	Channel        ChatChannel
 	ConversationID chat1.ConvIDStr `json:"conversation_id"`
@@ -821,6 +857,78 @@ func (a *ChatAPI) DownloadV1(ctx context.Context, c Call, w io.Writer) error {
 		utils.DummyChatNotifications{}), w)
 }
 
+// chatAPIDownloadProgress writes a progress frame for each download
+// progress notification about the message, until the reply is written.
+type chatAPIDownloadProgress struct {
+	utils.DummyChatNotifications
+	sync.Mutex
+	call   Call
+	w      io.Writer
+	indent bool
+	msgID  chat1.MessageID
+	done   bool
+}
+
+type chatAPIDownloadProgressFrame struct {
+	Jsonrpc  string                           `json:"jsonrpc,omitempty"`
+	ID       int                              `json:"id,omitempty"`
+	Progress chat1.DownloadAttachmentProgress `json:"progress"`
+}
+
+func (p *chatAPIDownloadProgress) ChatAttachmentDownloadProgress(ctx context.Context,
+	arg chat1.ChatAttachmentDownloadProgressArg) error {
+	p.Lock()
+	defer p.Unlock()
+	if p.done || arg.MsgID != p.msgID {
+		return nil
+	}
+	enc := json.NewEncoder(p.w)
+	if p.indent {
+		enc.SetIndent("", "    ")
+	}
+	return enc.Encode(chatAPIDownloadProgressFrame{
+		Jsonrpc: p.call.Jsonrpc,
+		ID:      p.call.ID,
+		Progress: chat1.DownloadAttachmentProgress{
+			MessageID:     arg.MsgID,
+			BytesComplete: arg.BytesComplete,
+			BytesTotal:    arg.BytesTotal,
+		},
+	})
+}
+
+func (p *chatAPIDownloadProgress) finish(reply Reply) error {
+	p.Lock()
+	defer p.Unlock()
+	p.done = true
+	return encodeReply(p.call, reply, p.w, p.indent)
+}
+
+func (a *ChatAPI) DownloadAttachmentV1(ctx context.Context, c Call, w io.Writer) error {
+	if len(c.Params.Options) == 0 {
+		return ErrInvalidOptions{version: 1, method: methodDownloadAttachment, err: errors.New("empty options")}
+	}
+	var opts downloadAttachmentOptionsV1
+	if err := json.Unmarshal(c.Params.Options, &opts); err != nil {
+		return err
+	}
+	if err := opts.Check(); err != nil {
+		return err
+	}
+
+	progress := &chatAPIDownloadProgress{
+		call:   c,
+		w:      w,
+		indent: a.indent,
+		msgID:  opts.MessageID,
+	}
+	if opts.Output == "-" {
+		// The attachment itself goes to stdout, so keep it clean.
+		progress.done = true
+	}
+	return progress.finish(a.svcHandler.DownloadAttachmentV1(ctx, opts, NewChatAPIUI(), progress))
+}
+
 func (a *ChatAPI) SetStatusV1(ctx context.Context, c Call, w io.Writer) error {
 	if len(c.Params.Options) == 0 {
 		return ErrInvalidOptions{version: 1, method: methodSetStatus, err: errors.New("empty options")}
@@ -290,3 +290,15 @@ func (d *chatNotificationDisplay) ChatParticipantsInfo(context.Context,
 	map[chat1.ConvIDStr][]chat1.UIParticipant) error {
 	return nil
 }
+func (d *chatNotificationDisplay) ChatSplitConversationProgress(context.Context,
+	chat1.ChatSplitConversationProgressArg) error {
+	return nil
+}
+func (d *chatNotificationDisplay) ChatMentionDigest(context.Context,
+	chat1.ChatMentionDigestArg) error {
+	return nil
+}
+func (d *chatNotificationDisplay) ChatIdentityChangeAlert(context.Context,
+	chat1.ChatIdentityChangeAlertArg) error {
+	return nil
+}
@@ -26,6 +26,7 @@ type handlerTracker struct {
 	deleteV1            int
 	attachV1            int
 	downloadV1          int
+	downloadAttachV1    int
 	setstatusV1         int
 	markV1              int
 	searchInboxV1       int
@@ -97,6 +98,11 @@ func (h *handlerTracker) DownloadV1(context.Context, Call, io.Writer) error {
 	return nil
 }
 
+func (h *handlerTracker) DownloadAttachmentV1(context.Context, Call, io.Writer) error {
+	h.downloadAttachV1++
+	return nil
+}
+
 func (h *handlerTracker) SetStatusV1(context.Context, Call, io.Writer) error {
 	h.setstatusV1++
 	return nil
@@ -271,6 +277,11 @@ func (c *chatEcho) DownloadV1(context.Context, downloadOptionsV1, chat1.ChatUiIn
 	return Reply{Result: echoOK}
 }
 
+func (c *chatEcho) DownloadAttachmentV1(context.Context, downloadAttachmentOptionsV1, chat1.ChatUiInterface,
+	chat1.NotifyChatInterface) Reply {
+	return Reply{Result: echoOK}
+}
+
 func (c *chatEcho) SetStatusV1(context.Context, setStatusOptionsV1) Reply {
 	return Reply{Result: echoOK}
 }
@@ -387,6 +398,7 @@ type topTest struct {
 	deleteV1            int
 	attachV1            int
 	downloadV1          int
+	downloadAttachV1    int
 	markV1              int
 	searchInboxV1       int
 	searchRegexpV1      int
@@ -434,6 +446,7 @@ var topTests = []topTest{
 	{input: `{"id": 30, "method": "delete", "params":{"version": 1}}`, deleteV1: 1},
 	{input: `{"method": "attach", "params":{"version": 1}}`, attachV1: 1},
 	{input: `{"method": "download", "params":{"version": 1, "options": {"message_id": 34, "channel": {"name": "a123,nfnf,t_bob"}, "output": "/tmp/file"}}}`, downloadV1: 1},
+	{input: `{"method": "downloadattachment", "params":{"version": 1, "options": {"message_id": 34, "channel": {"name": "a123,nfnf,t_bob"}, "output": "/tmp/file"}}}`, downloadAttachV1: 1},
 	{input: `{"id": 39, "method": "mark", "params":{"version": 1}}`, markV1: 1},
 	{input: `{"id": 39, "method": "searchinbox", "params":{"version": 1}}`, searchInboxV1: 1},
 	{input: `{"id": 39, "method": "searchregexp", "params":{"version": 1}}`, searchRegexpV1: 1},
@@ -488,6 +501,9 @@ func TestChatAPIVersionHandlerTop(t *testing.T) {
 		if h.downloadV1 != test.downloadV1 {
 			t.Errorf("test %d: input %s => downloadV1 = %d, expected %d", i, test.input, h.downloadV1, test.downloadV1)
 		}
+		if h.downloadAttachV1 != test.downloadAttachV1 {
+			t.Errorf("test %d: input %s => downloadAttachV1 = %d, expected %d", i, test.input, h.downloadAttachV1, test.downloadAttachV1)
+		}
 		if h.markV1 != test.markV1 {
 			t.Errorf("test %d: input %s => markV1 = %d, expected %d", i, test.input, h.markV1, test.markV1)
 		}
@@ -714,6 +730,22 @@ var optTests = []optTest{
 		input:  `{"method": "download", "params":{"version": 1, "options": {"message_id": 34, "channel": {"name": "a123,nfnf,t_bob"}, "preview": true, "output": "/tmp/file"}}}`,
 		output: `{"result":{"status":"ok"}}`,
 	},
+	{
+		input:  `{"method": "downloadattachment", "params":{"version": 1, "options": {"message_id": 34, "channel": {"name": "a123,nfnf,t_bob"}, "offset": 1024, "length": 4096, "output": "/tmp/file"}}}`,
+		output: `{"result":{"status":"ok"}}`,
+	},
+	{
+		input:  `{"method": "downloadattachment", "params":{"version": 1, "options": {"message_id": 34, "channel": {"name": "a123,nfnf,t_bob"}, "resume": true, "output": "/tmp/file"}}}`,
+		output: `{"result":{"status":"ok"}}`,
+	},
+	{
+		input:  `{"method": "downloadattachment", "params":{"version": 1, "options": {"message_id": 34, "channel": {"name": "a123,nfnf,t_bob"}, "offset": -1, "output": "/tmp/file"}}}`,
+		output: `{"error":{"code":0,"message":"invalid downloadattachment v1 options: offset and length must not be negative"}}`,
+	},
+	{
+		input:  `{"method": "downloadattachment", "params":{"version": 1, "options": {"message_id": 34, "channel": {"name": "a123,nfnf,t_bob"}, "resume": true, "output": "-"}}}`,
+		output: `{"error":{"code":0,"message":"invalid downloadattachment v1 options: can't resume a download to stdout"}}`,
+	},
 	{
 		input:  `{"method": "setstatus", "params":{"version": 1, "options": {"channel": {"name": "a123,nfnf,t_bob"}}}}`,
 		output: `{"error":{"code":0,"message":"invalid setstatus v1 options: unsupported status: ''"}}`,
@@ -48,6 +48,8 @@ func (d *ChatAPIVersionHandler) handleV1(ctx context.Context, c Call, w io.Write
 		return d.handler.AttachV1(ctx, c, w)
 	case methodDownload:
 		return d.handler.DownloadV1(ctx, c, w)
+	case methodDownloadAttachment:
+		return d.handler.DownloadAttachmentV1(ctx, c, w)
 	case methodSetStatus:
 		return d.handler.SetStatusV1(ctx, c, w)
 	case methodMark:
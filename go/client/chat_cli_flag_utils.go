@@ -281,30 +281,6 @@ func makeChatCLIConversationFetcher(ctx *cli.Context, tlfName string, markAsRead
 	return fetcher, nil
 }
 
-func makeChatCLIInboxFetcherActivitySorted(ctx *cli.Context) (fetcher chatCLIInboxFetcher, err error) {
-	if fetcher.query.TopicType, err = parseConversationTopicType(ctx); err != nil {
-		return chatCLIInboxFetcher{}, err
-	}
-
-	fetcher.query.UnreadFirst = false
-	fetcher.query.ActivitySortedLimit = ctx.Int("number")
-	fetcher.query.After = ctx.String("since")
-
-	if ctx.Bool("private") {
-		fetcher.query.Visibility = keybase1.TLFVisibility_PRIVATE
-	} else if ctx.Bool("public") {
-		fetcher.query.Visibility = keybase1.TLFVisibility_PUBLIC
-	} else {
-		fetcher.query.Visibility = keybase1.TLFVisibility_ANY
-	}
-
-	if !ctx.Bool("include-hidden") {
-		fetcher.query.Status = utils.VisibleChatConversationStatuses()
-	}
-
-	return fetcher, nil
-}
-
 func makeChatCLIInboxFetcherUnreadFirst(ctx *cli.Context) (fetcher chatCLIInboxFetcher, err error) {
 	if fetcher.query.TopicType, err = parseConversationTopicType(ctx); err != nil {
 		return fetcher, err
@@ -100,6 +100,10 @@ func (n *ChatCLINotifications) ChatArchiveProgress(ctx context.Context,
 	if n.noOutput {
 		return nil
 	}
+	if arg.Conv != nil && arg.Conv.Phase == chat1.ArchiveChatConvPhase_ATTACHMENTS {
+		// Nothing more has been archived yet.
+		return nil
+	}
 	percent := int((100 * arg.MessagesComplete) / arg.MessagesTotal)
 	if n.lastProgressPercent == 0 || percent == 100 || percent-n.lastProgressPercent >= 10 {
 		w := n.terminal.ErrorWriter()
@@ -110,6 +114,21 @@ func (n *ChatCLINotifications) ChatArchiveProgress(ctx context.Context,
 	return nil
 }
 
+func (n *ChatCLINotifications) ChatSplitConversationProgress(ctx context.Context,
+	arg chat1.ChatSplitConversationProgressArg) error {
+	if n.noOutput || arg.MessagesTotal == 0 {
+		return nil
+	}
+	percent := int((100 * arg.MessagesComplete) / arg.MessagesTotal)
+	if n.lastProgressPercent == 0 || percent == 100 || percent-n.lastProgressPercent >= 10 {
+		w := n.terminal.ErrorWriter()
+		fmt.Fprintf(w, "Split progress %d%% (%d of %d messages moved)\n", percent,
+			arg.MessagesComplete, arg.MessagesTotal)
+		n.lastProgressPercent = percent
+	}
+	return nil
+}
+
 type ChatCLIUI struct {
 	libkb.Contextified
 	terminal libkb.TerminalUI
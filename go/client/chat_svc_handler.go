@@ -3,6 +3,7 @@ package client
 import (
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
@@ -29,6 +30,7 @@ type ChatServiceHandler interface {
 	DeleteV1(context.Context, deleteOptionsV1) Reply
 	AttachV1(context.Context, attachOptionsV1, chat1.ChatUiInterface, chat1.NotifyChatInterface) Reply
 	DownloadV1(context.Context, downloadOptionsV1, chat1.ChatUiInterface, chat1.NotifyChatInterface) Reply
+	DownloadAttachmentV1(context.Context, downloadAttachmentOptionsV1, chat1.ChatUiInterface, chat1.NotifyChatInterface) Reply
 	SetStatusV1(context.Context, setStatusOptionsV1) Reply
 	MarkV1(context.Context, markOptionsV1) Reply
 	SearchInboxV1(context.Context, searchInboxOptionsV1) Reply
@@ -1019,6 +1021,99 @@ func (c *chatServiceHandler) downloadV1NoStream(ctx context.Context, opts downlo
 	return Reply{Result: res}
 }
 
+// DownloadAttachmentV1 implements ChatServiceHandler.DownloadAttachmentV1.
+func (c *chatServiceHandler) DownloadAttachmentV1(ctx context.Context, opts downloadAttachmentOptionsV1,
+	chatUI chat1.ChatUiInterface, notifyUI chat1.NotifyChatInterface) Reply {
+	offset := opts.Offset
+	var fsink Sink
+	switch {
+	case opts.Output == "-":
+		fsink = &StdoutSink{}
+	case opts.Resume:
+		fi, err := os.Stat(opts.Output)
+		switch {
+		case err == nil:
+			offset = fi.Size()
+		case !os.IsNotExist(err):
+			return c.errReply(err)
+		}
+		fsink = NewFileSinkAppend(c.G(), opts.Output)
+	default:
+		fsink = NewFileSink(c.G(), opts.Output)
+	}
+	csink := &countingSink{Sink: fsink}
+	defer csink.Close()
+	sink := c.G().XStreams.ExportWriter(csink)
+
+	c.chatUI.RegisterChatUI(chatUI)
+	defer c.chatUI.DeregisterChatUI(chatUI)
+	client, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return c.errReply(err)
+	}
+	protocols := []rpc.Protocol{
+		NewStreamUIProtocol(c.G()),
+		chat1.ChatUiProtocol(c.chatUI),
+		chat1.NotifyChatProtocol(notifyUI),
+	}
+	if err := RegisterProtocolsWithContext(protocols, c.G()); err != nil {
+		return c.errReply(err)
+	}
+	cli, err := GetNotifyCtlClient(c.G())
+	if err != nil {
+		return c.errReply(err)
+	}
+	channels := keybase1.NotificationChannels{
+		Chatattachments: true,
+	}
+	if err := cli.SetNotifications(context.TODO(), channels); err != nil {
+		return c.errReply(err)
+	}
+
+	convID, rlimits, err := c.resolveAPIConvID(ctx, opts.ConversationID, opts.Channel)
+	if err != nil {
+		return c.errReply(err)
+	}
+
+	arg := chat1.DownloadAttachmentLocalArg{
+		SessionID:        getSessionID(chatUI),
+		ConversationID:   convID,
+		MessageID:        opts.MessageID,
+		Sink:             sink,
+		Preview:          opts.Preview,
+		IdentifyBehavior: keybase1.TLFIdentifyBehavior_CHAT_CLI,
+		Offset:           offset,
+		Length:           opts.Length,
+	}
+
+	dres, err := client.DownloadAttachmentLocal(ctx, arg)
+	if err != nil {
+		return c.errReply(err)
+	}
+	rlimits = append(rlimits, dres.RateLimits...)
+	if opts.Output != "-" {
+		if err := attachments.Quarantine(ctx, opts.Output); err != nil {
+			c.G().Log.Warning("failed to quarantine attachment download: %s", err)
+		}
+	}
+
+	size := dres.Size
+	if offset == 0 && opts.Length == 0 {
+		// A whole download doesn't report the size, but we just wrote it.
+		size = csink.n
+	}
+	res := chat1.DownloadAttachmentRes{
+		Message:          fmt.Sprintf("attachment downloaded to %s", opts.Output),
+		Offset:           offset,
+		Bytes:            csink.n,
+		Size:             size,
+		RateLimits:       c.aggRateLimits(rlimits),
+		IdentifyFailures: dres.IdentifyFailures,
+	}
+
+	return Reply{Result: res}
+}
+
 // SetStatusV1 implements ChatServiceHandler.SetStatusV1.
 func (c *chatServiceHandler) SetStatusV1(ctx context.Context, opts setStatusOptionsV1) Reply {
 	var rlimits []chat1.RateLimit
@@ -18,15 +18,29 @@ func NewCmdChat(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command
 		newCmdChatAPI(cl, g),
 		newCmdChatAPIListen(cl, g),
 		newCmdChatArchive(cl, g),
+		newCmdChatArchiveBrowse(cl, g),
+		newCmdChatArchiveDecrypt(cl, g),
 		newCmdChatArchiveDelete(cl, g),
+		newCmdChatArchiveExport(cl, g),
+		newCmdChatArchiveImport(cl, g),
 		newCmdChatArchiveList(cl, g),
+		newCmdChatArchiveLoad(cl, g),
 		newCmdChatArchivePause(cl, g),
+		newCmdChatArchiveRedaction(cl, g),
 		newCmdChatArchiveResume(cl, g),
+		newCmdChatArchiveSearch(cl, g),
+		newCmdChatArchiveVerify(cl, g),
+		newCmdChatAttachmentExpiry(cl, g),
+		newCmdChatBanner(cl, g),
+		newCmdChatBulkDelete(cl, g),
 		newCmdChatDefaultChannels(cl, g),
 		newCmdChatDeleteChannel(cl, g),
 		newCmdChatDeleteHistory(cl, g),
 		newCmdChatDownload(cl, g),
+		newCmdChatExportContacts(cl, g),
 		newCmdChatHide(cl, g),
+		newCmdChatHistorySearch(cl, g),
+		newCmdChatIncidentMode(cl, g),
 		newCmdChatJoinChannel(cl, g),
 		newCmdChatLeaveChannel(cl, g),
 		newCmdChatRenameChannel(cl, g),
@@ -35,7 +49,13 @@ func NewCmdChat(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command
 		newCmdChatListChannels(cl, g),
 		newCmdChatListMembers(cl, g),
 		newCmdChatListUnread(cl, g),
+		newCmdChatMembershipHook(cl, g),
+		newCmdChatMentionDigest(cl, g),
+		newCmdChatModerationLog(cl, g),
 		newCmdChatMute(cl, g),
+		newCmdChatNewMemberRestrictions(cl, g),
+		newCmdChatNotificationRouting(cl, g),
+		newCmdChatPoll(cl, g),
 		newCmdChatRead(cl, g),
 		newCmdChatReAddMember(cl, g),
 		newCmdChatReport(cl, g),
@@ -45,6 +65,9 @@ func NewCmdChat(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command
 		newCmdChatSearchInbox(cl, g),
 		newCmdChatSearchRegexp(cl, g),
 		newCmdChatSend(cl, g),
+		newCmdChatSplit(cl, g),
+		newCmdChatTask(cl, g),
+		newCmdChatTrustedDevices(cl, g),
 		newCmdChatUpload(cl, g),
 		newCmdChatAddBotMember(cl, g),
 		newCmdChatRemoveBotMember(cl, g),
@@ -1,13 +1,19 @@
 package client
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
+	"strings"
+	"time"
 
+	humanize "github.com/dustin/go-humanize"
 	"github.com/keybase/cli"
+	"github.com/keybase/client/go/chat/utils"
 	"github.com/keybase/client/go/libcmdline"
 	"github.com/keybase/client/go/libkb"
 	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
 	"github.com/keybase/client/go/protocol/keybase1"
 	"github.com/keybase/go-framed-msgpack-rpc/rpc"
 	"golang.org/x/net/context"
@@ -18,6 +24,24 @@ type CmdChatArchive struct {
 	resolvingRequest chatConversationResolvingRequest
 	outputPath       string
 	compress         bool
+	compression      chat1.ArchiveChatCompression
+	compressionLevel int
+	encryption       chat1.ArchiveChatEncryption
+	metadataOnly     bool
+	skipAttachments  bool
+	maxAttachment    int64
+	attachmentTypes  []string
+	dedupe           bool
+	dedupeMessages   bool
+	contacts         bool
+	legalTranscript  bool
+	format           chat1.ArchiveChatFormat
+	sqlite           bool
+	history          bool
+	after            *gregor1.Time
+	before           *gregor1.Time
+	appendTo         chat1.ArchiveJobID
+	schedule         *chat1.ArchiveChatSchedule
 }
 
 func NewCmdChatArchiveRunner(g *libkb.GlobalContext) *CmdChatArchive {
@@ -36,13 +60,109 @@ func newCmdChatArchive(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.C
 			cl.SetLogForward(libcmdline.LogForwardNone)
 		},
 		Flags: append(getConversationResolverFlags(), []cli.Flag{
-			cli.StringFlag{
+			cli.BoolFlag{
 				Name:  "compress",
 				Usage: "Compress the output",
 			},
+			cli.StringFlag{
+				Name: "compression",
+				Usage: `[gzip|zstd|none] How to compress the output; implies --compress.
+	zstd is much faster on archives with lots of media, and none packs
+	it into a plain tarball. Defaults to gzip.`,
+			},
+			cli.IntFlag{
+				Name: "compression-level",
+				Usage: `From 1 (fastest) to 9 (smallest) for gzip, or to 22 for zstd.
+	Defaults to the codec's default.`,
+			},
+			cli.StringFlag{
+				Name: "encrypt",
+				Usage: `[self|passphrase] Pack the output into one file encrypted with
+	saltpack, to your devices and paper keys (decrypt it with keybase
+	decrypt) or to a passphrase you're asked for (decrypt it with keybase
+	chat archive-decrypt).`,
+			},
 			cli.StringFlag{
 				Name:  "o, outfile",
 				Usage: "Output directory name for the archive",
+			},
+			cli.BoolFlag{
+				Name: "attachments-metadata-only",
+				Usage: `Don't download attachments; list their filenames, sizes, senders
+	and hashes in an attachments.json for each conversation instead`,
+			},
+			cli.BoolFlag{
+				Name:  "skip-attachments",
+				Usage: "Don't download attachments; write a placeholder for each instead",
+			},
+			cli.StringFlag{
+				Name: "max-attachment-size",
+				Usage: `Don't download attachments bigger than this, like 20MB; write a
+	placeholder for each instead`,
+			},
+			cli.StringFlag{
+				Name: "attachment-types",
+				Usage: `Only download attachments of these comma-separated MIME types, like
+	image/* or image/*,application/pdf; write a placeholder for the others`,
+			},
+			cli.BoolFlag{
+				Name: "dedupe-attachments",
+				Usage: `Keep one copy of each distinct attachment in an attachments directory,
+	listed in an attachments.json for each conversation that has it`,
+			},
+			cli.BoolFlag{
+				Name: "dedupe-messages",
+				Usage: `Write the body of a message crossposted to several conversations in
+	only one messages.jsonl, and refer to it from the others. Needs
+	--format=json or --format=both.`,
+			},
+			cli.BoolFlag{
+				Name:  "contacts",
+				Usage: "Save each conversation's participants as vCards in a participants.vcf",
+			},
+			cli.BoolFlag{
+				Name: "legal-transcript",
+				Usage: `Also write a printable transcript.txt of each conversation, with
+	numbered lines, page headers and a cover page signed by this device`,
+			},
+			cli.StringFlag{
+				Name: "format",
+				Usage: `[text|json|both] Write messages as text in a chat.txt, as JSON
+	lines with IDs, devices, reactions and edits in a messages.jsonl, or both.
+	Defaults to text.`,
+			},
+			cli.BoolFlag{
+				Name: "sqlite",
+				Usage: `Also write the conversations, messages and attachment metadata to an
+	archive.sqlite database, with a full-text index of the messages`,
+			},
+			cli.BoolFlag{
+				Name: "history",
+				Usage: `Also write each conversation's reactions, edits and deletions to a
+	history.jsonl, with messages' bodies from before they were edited or
+	deleted where they're still stored on this device`,
+			},
+			cli.StringFlag{
+				Name: "after",
+				Usage: `Only archive messages sent on or after this date (2023-01-01), time
+	(RFC3339) or long ago (90d)`,
+			},
+			cli.StringFlag{
+				Name:  "before",
+				Usage: `Only archive messages sent before this date, time or long ago, like --after`,
+			},
+			cli.StringFlag{
+				Name: "append-to",
+				Usage: `Append the messages sent since to the output of this completed
+	archive job, writing the same files it did. Archives all the
+	conversations it did unless a conversation is given.`,
+			},
+			cli.StringFlag{
+				Name: "schedule",
+				Usage: `[daily|weekly] Once this job is done, rerun it daily or weekly,
+	appending the messages sent since to its output. Runs at the time of
+	day and on the day of the week it's started, unless given like
+	"daily 02:30" or "weekly sun 02:30". Delete the job to stop it.`,
 			}}...),
 	}
 }
@@ -114,10 +234,37 @@ func (c *CmdChatArchive) Run() error {
 		JobID:            chat1.ArchiveJobID(fmt.Sprintf("arc-%d", jobID)),
 		OutputPath:       c.outputPath,
 		Compress:         c.compress,
+		Compression:      c.compression,
+		CompressionLevel: c.compressionLevel,
+		Encryption:       c.encryption,
 		Query:            &query,
 		IdentifyBehavior: keybase1.TLFIdentifyBehavior_CHAT_CLI,
+
+		AttachmentsMetadataOnly: c.metadataOnly,
+		SkipAttachments:         c.skipAttachments,
+		MaxAttachmentSize:       c.maxAttachment,
+		AttachmentMimeTypes:     c.attachmentTypes,
+		DedupeAttachments:       c.dedupe,
+		DedupeMessages:          c.dedupeMessages,
+		IncludeContacts:         c.contacts,
+		LegalTranscript:         c.legalTranscript,
+		Format:                  c.format,
+		SqliteDatabase:          c.sqlite,
+		IncludeHistory:          c.history,
+		After:                   c.after,
+		Before:                  c.before,
+		AppendTo:                c.appendTo,
+	}
+	if len(c.appendTo) > 0 && c.resolvingRequest.TlfName == "" {
+		// The job appended to has the query.
+		arg.Query = nil
 	}
 	ui := c.G().UI.GetTerminalUI()
+	if c.encryption == chat1.ArchiveChatEncryption_PASSPHRASE {
+		if arg.Passphrase, err = c.promptPassphrase(); err != nil {
+			return err
+		}
+	}
 	ui.Printf("Starting archive %s \n", arg.JobID)
 
 	res, err := client.ArchiveChat(context.TODO(), arg)
@@ -131,6 +278,18 @@ func (c *CmdChatArchive) Run() error {
 
 	ui.Printf("Archive completed, saved at %s \n", outputPath)
 
+	if c.schedule != nil {
+		err = client.ArchiveChatSetSchedule(context.TODO(), chat1.ArchiveChatSetScheduleArg{
+			JobID:            arg.JobID,
+			Schedule:         c.schedule,
+			IdentifyBehavior: keybase1.TLFIdentifyBehavior_CHAT_CLI,
+		})
+		if err != nil {
+			return err
+		}
+		ui.Printf("Scheduled %s to run %s\n", arg.JobID, formatChatArchiveSchedule(*c.schedule))
+	}
+
 	return nil
 }
 
@@ -145,9 +304,198 @@ func (c *CmdChatArchive) ParseArgv(ctx *cli.Context) (err error) {
 	}
 	c.outputPath = ctx.String("outfile")
 	c.compress = ctx.Bool("compress")
+	if compression := ctx.String("compression"); len(compression) > 0 {
+		var ok bool
+		c.compression, ok = chat1.ArchiveChatCompressionMap[strings.ToUpper(compression)]
+		if !ok {
+			return fmt.Errorf("unknown compression %q; expected gzip, zstd or none", compression)
+		}
+		c.compress = true
+	}
+	if ctx.IsSet("compression-level") {
+		if !c.compress {
+			return errors.New("--compression-level needs --compress or --compression")
+		}
+		c.compressionLevel = ctx.Int("compression-level")
+	}
+	if encryption := ctx.String("encrypt"); len(encryption) > 0 {
+		var ok bool
+		c.encryption, ok = chat1.ArchiveChatEncryptionMap[strings.ToUpper(encryption)]
+		if !ok || c.encryption == chat1.ArchiveChatEncryption_NONE {
+			return fmt.Errorf("unknown encryption %q; expected self or passphrase", encryption)
+		}
+	}
+	c.metadataOnly = ctx.Bool("attachments-metadata-only")
+	c.skipAttachments = ctx.Bool("skip-attachments")
+	if size := ctx.String("max-attachment-size"); len(size) > 0 {
+		bytes, err := humanize.ParseBytes(size)
+		if err != nil {
+			return fmt.Errorf("invalid size %q; use one like 20MB", size)
+		}
+		c.maxAttachment = int64(bytes)
+	}
+	if types := ctx.String("attachment-types"); len(types) > 0 {
+		for _, typ := range strings.Split(types, ",") {
+			if typ = strings.TrimSpace(typ); len(typ) > 0 {
+				c.attachmentTypes = append(c.attachmentTypes, typ)
+			}
+		}
+	}
+	c.dedupe = ctx.Bool("dedupe-attachments")
+	if c.metadataOnly && (c.skipAttachments || c.maxAttachment > 0 || len(c.attachmentTypes) > 0 || c.dedupe) {
+		return errors.New("--attachments-metadata-only doesn't download any attachments, so it can't be " +
+			"used with --skip-attachments, --max-attachment-size, --attachment-types or --dedupe-attachments")
+	}
+	c.contacts = ctx.Bool("contacts")
+	c.legalTranscript = ctx.Bool("legal-transcript")
+	c.sqlite = ctx.Bool("sqlite")
+	c.history = ctx.Bool("history")
+	if c.after, err = parseChatArchiveTime(ctx.String("after")); err != nil {
+		return err
+	}
+	if c.before, err = parseChatArchiveTime(ctx.String("before")); err != nil {
+		return err
+	}
+	if c.after != nil && c.before != nil && *c.after >= *c.before {
+		return errors.New("--after has to be earlier than --before")
+	}
+	if format := ctx.String("format"); len(format) > 0 {
+		var ok bool
+		c.format, ok = chat1.ArchiveChatFormatMap[strings.ToUpper(format)]
+		if !ok {
+			return fmt.Errorf("unknown format %q; expected text, json or both", format)
+		}
+	}
+	if c.legalTranscript && c.format == chat1.ArchiveChatFormat_JSON {
+		return errors.New("--legal-transcript needs --format=text or --format=both")
+	}
+	c.dedupeMessages = ctx.Bool("dedupe-messages")
+	if c.dedupeMessages && c.format == chat1.ArchiveChatFormat_TEXT {
+		return errors.New("--dedupe-messages needs --format=json or --format=both")
+	}
+	c.appendTo = chat1.ArchiveJobID(ctx.String("append-to"))
+	if len(c.appendTo) > 0 {
+		for _, flag := range []string{"compress", "compression", "compression-level", "encrypt",
+			"attachments-metadata-only", "skip-attachments", "max-attachment-size", "attachment-types",
+			"dedupe-attachments", "dedupe-messages", "contacts", "legal-transcript", "format", "sqlite",
+			"history", "after", "before"} {
+			if ctx.IsSet(flag) {
+				return fmt.Errorf("--%s can't be used with --append-to, which writes what the job "+
+					"appended to did", flag)
+			}
+		}
+	}
+	if schedule := ctx.String("schedule"); len(schedule) > 0 {
+		if c.compress || c.encryption != chat1.ArchiveChatEncryption_NONE || c.before != nil {
+			return errors.New("--schedule can't be used with --compress, --encrypt or --before, since " +
+				"each run appends to the output of the one before")
+		}
+		if c.schedule, err = parseChatArchiveSchedule(schedule, time.Now()); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+func (c *CmdChatArchive) promptPassphrase() (string, error) {
+	ui := c.G().UI.GetTerminalUI()
+	passphrase, err := ui.PromptPassword(PromptDescriptorChatArchivePassphrase,
+		fmt.Sprintf("Passphrase to encrypt the archive with (%d+ characters): ", libkb.MinPassphraseLength))
+	if err != nil {
+		return "", err
+	}
+	if len(passphrase) < libkb.MinPassphraseLength {
+		return "", fmt.Errorf("the passphrase must be at least %d characters", libkb.MinPassphraseLength)
+	}
+	again, err := ui.PromptPassword(PromptDescriptorChatArchivePassphrase, "Passphrase again: ")
+	if err != nil {
+		return "", err
+	}
+	if again != passphrase {
+		return "", errors.New("the passphrases don't match")
+	}
+	return passphrase, nil
+}
+
+// parseChatArchiveSchedule parses "daily" or "weekly", optionally followed
+// by a day of the week for weekly schedules and then a time of day. They
+// default to now's.
+func parseChatArchiveSchedule(s string, now time.Time) (*chat1.ArchiveChatSchedule, error) {
+	fields := strings.Fields(strings.ToLower(s))
+	if len(fields) == 0 {
+		return nil, errors.New("empty schedule")
+	}
+	res := chat1.ArchiveChatSchedule{
+		MinuteOfDay: now.Hour()*60 + now.Minute(),
+		Weekday:     int(now.Weekday()),
+	}
+	switch fields[0] {
+	case "daily":
+		res.Frequency = chat1.ArchiveChatScheduleFrequency_DAILY
+	case "weekly":
+		res.Frequency = chat1.ArchiveChatScheduleFrequency_WEEKLY
+		if len(fields) > 1 {
+			if weekday, ok := parseWeekday(fields[1]); ok {
+				res.Weekday = int(weekday)
+				fields = fields[1:]
+			}
+		}
+	default:
+		return nil, fmt.Errorf("invalid schedule %q; expected daily or weekly", s)
+	}
+	switch len(fields) {
+	case 1:
+	case 2:
+		t, err := time.Parse("15:04", fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid time of day %q; use one like 02:30", fields[1])
+		}
+		res.MinuteOfDay = t.Hour()*60 + t.Minute()
+	default:
+		return nil, fmt.Errorf("invalid schedule %q; use one like \"daily 02:30\" or \"weekly sun 02:30\"", s)
+	}
+	return &res, nil
+}
+
+func parseWeekday(s string) (time.Weekday, bool) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		name := strings.ToLower(weekday.String())
+		if s == name || s == name[:3] {
+			return weekday, true
+		}
+	}
+	return 0, false
+}
+
+func formatChatArchiveSchedule(schedule chat1.ArchiveChatSchedule) string {
+	at := fmt.Sprintf("%02d:%02d", schedule.MinuteOfDay/60, schedule.MinuteOfDay%60)
+	if schedule.Frequency == chat1.ArchiveChatScheduleFrequency_WEEKLY {
+		return fmt.Sprintf("weekly on %s at %s", time.Weekday(schedule.Weekday), at)
+	}
+	return fmt.Sprintf("daily at %s", at)
+}
+
+// parseChatArchiveTime parses a date in the local time zone, an RFC3339
+// time, or how long ago, like 90d or 12h.
+func parseChatArchiveTime(s string) (*gregor1.Time, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		t, err = time.Parse(time.RFC3339, s)
+	}
+	if err != nil {
+		d, derr := utils.ParseDurationExtended(s)
+		if derr != nil {
+			return nil, fmt.Errorf("invalid time %q; use a date like 2023-01-01, an RFC3339 time or a duration like 90d", s)
+		}
+		t = time.Now().Add(-d)
+	}
+	res := gregor1.ToTime(t)
+	return &res, nil
+}
+
 func (c *CmdChatArchive) GetUsage() libkb.Usage {
 	return libkb.Usage{
 		Config: true,
@@ -0,0 +1,137 @@
+package client
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"golang.org/x/net/context"
+)
+
+// CmdChatArchiveBrowse lists the conversations loaded with archive-load, or
+// shows the messages of one of them.
+type CmdChatArchiveBrowse struct {
+	libkb.Contextified
+	id     string
+	before chat1.MessageID
+	num    int
+	delete bool
+}
+
+func NewCmdChatArchiveBrowseRunner(g *libkb.GlobalContext) *CmdChatArchiveBrowse {
+	return &CmdChatArchiveBrowse{
+		Contextified: libkb.NewContextified(g),
+	}
+}
+
+func newCmdChatArchiveBrowse(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "archive-browse",
+		Usage:        "List the conversations loaded from chat archives, or read one",
+		ArgumentHelp: "[<conversation ID>]",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(NewCmdChatArchiveBrowseRunner(g), "archive-browse", c)
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+		Flags: []cli.Flag{
+			cli.IntFlag{
+				Name:  "before",
+				Usage: "Show the messages before this message ID",
+			},
+			cli.IntFlag{
+				Name:  "n, number",
+				Value: 50,
+				Usage: "Specify the number of messages to show",
+			},
+			cli.BoolFlag{
+				Name:  "delete",
+				Usage: "Remove the loaded conversation",
+			},
+		},
+	}
+}
+
+func (c *CmdChatArchiveBrowse) Run() error {
+	client, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return err
+	}
+	ui := c.G().UI.GetTerminalUI()
+	ctx := context.TODO()
+
+	switch {
+	case c.delete:
+		return client.ArchiveChatDeleteImported(ctx, chat1.ArchiveChatDeleteImportedArg{
+			Id:               c.id,
+			IdentifyBehavior: keybase1.TLFIdentifyBehavior_CHAT_CLI,
+		})
+	case len(c.id) == 0:
+		convs, err := client.ArchiveChatListImported(ctx, keybase1.TLFIdentifyBehavior_CHAT_CLI)
+		if err != nil {
+			return err
+		}
+		if len(convs) == 0 {
+			ui.Printf("No conversations loaded\n")
+		}
+		for _, conv := range convs {
+			ui.Printf("%s %s: %d messages, %s to %s, from %s\n", conv.Id, conv.Name, conv.Messages,
+				conv.FirstCtime.Time().Format("2006-01-02"), conv.LastCtime.Time().Format("2006-01-02"),
+				conv.SourcePath)
+		}
+		return nil
+	}
+
+	thread, err := client.ArchiveChatGetImportedThread(ctx, chat1.ArchiveChatGetImportedThreadArg{
+		Id:               c.id,
+		BeforeMsgID:      c.before,
+		Num:              c.num,
+		IdentifyBehavior: keybase1.TLFIdentifyBehavior_CHAT_CLI,
+	})
+	if err != nil {
+		return err
+	}
+	// Oldest first, like a conversation reads.
+	for i := len(thread.Messages) - 1; i >= 0; i-- {
+		msg := thread.Messages[i]
+		body := msg.Body
+		switch {
+		case msg.Deleted:
+			body = "[deleted]"
+		case len(msg.AttachmentPath) > 0:
+			body = strings.TrimSpace(body + " " + msg.AttachmentPath)
+		case len(msg.AttachmentFilename) > 0:
+			body = strings.TrimSpace(body + " [" + msg.AttachmentFilename + "]")
+		}
+		ui.Printf("[%d] [%s %s] %s\n", msg.MsgID, msg.Sender,
+			msg.Ctime.Time().Format("2006-01-02 15:04:05"), strings.ReplaceAll(body, "\n", "\n\t"))
+	}
+	if thread.More && len(thread.Messages) > 0 {
+		ui.Printf("\nUse --before %d to see older messages\n", thread.Messages[len(thread.Messages)-1].MsgID)
+	}
+	return nil
+}
+
+func (c *CmdChatArchiveBrowse) ParseArgv(ctx *cli.Context) error {
+	if len(ctx.Args()) > 1 {
+		return errors.New("only one conversation can be read at a time")
+	}
+	c.id = ctx.Args().Get(0)
+	c.before = chat1.MessageID(ctx.Int("before"))
+	c.num = ctx.Int("number")
+	c.delete = ctx.Bool("delete")
+	if c.delete && len(c.id) == 0 {
+		return errors.New("--delete needs the conversation to remove")
+	}
+	return nil
+}
+
+func (c *CmdChatArchiveBrowse) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+		API:    true,
+	}
+}
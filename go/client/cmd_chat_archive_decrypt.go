@@ -0,0 +1,97 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+)
+
+// CmdChatArchiveDecrypt decrypts the output of an archive job encrypted to
+// a passphrase. It doesn't need the service, so it works on any machine the
+// archive's copied to.
+type CmdChatArchiveDecrypt struct {
+	libkb.Contextified
+	inPath  string
+	outPath string
+}
+
+func NewCmdChatArchiveDecryptRunner(g *libkb.GlobalContext) *CmdChatArchiveDecrypt {
+	return &CmdChatArchiveDecrypt{
+		Contextified: libkb.NewContextified(g),
+	}
+}
+
+func newCmdChatArchiveDecrypt(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "archive-decrypt",
+		Usage:        "Decrypt an archive encrypted with a passphrase",
+		ArgumentHelp: "<archive.saltpack> [-o filename]",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(NewCmdChatArchiveDecryptRunner(g), "archive-decrypt", c)
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "o, outfile",
+				Usage: "Where to write the tarball; defaults to the archive's name without .saltpack",
+			},
+		},
+		Description: `Archives encrypted to your own keys are decrypted with
+   "keybase decrypt -i <archive.saltpack> -o <tarball>" instead.`,
+	}
+}
+
+func (c *CmdChatArchiveDecrypt) Run() (err error) {
+	passphrase, err := c.G().UI.GetTerminalUI().PromptPassword(PromptDescriptorChatArchivePassphrase,
+		"Archive passphrase: ")
+	if err != nil {
+		return err
+	}
+	in, err := os.Open(c.inPath)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(c.outPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = out.Close()
+			_ = os.Remove(c.outPath)
+		}
+	}()
+	err = libkb.SaltpackPassphraseDecrypt(libkb.NewMetaContextTODO(c.G()), in, out, passphrase)
+	if err != nil {
+		return err
+	}
+	c.G().UI.GetTerminalUI().Printf("Decrypted to %s\n", c.outPath)
+	return nil
+}
+
+func (c *CmdChatArchiveDecrypt) ParseArgv(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		return errors.New("the archive to decrypt is required")
+	}
+	c.inPath = ctx.Args().Get(0)
+	c.outPath = ctx.String("outfile")
+	if len(c.outPath) == 0 {
+		if !strings.HasSuffix(c.inPath, ".saltpack") {
+			return fmt.Errorf("%s doesn't end in .saltpack, so an output file is required", c.inPath)
+		}
+		c.outPath = strings.TrimSuffix(c.inPath, ".saltpack")
+	}
+	return nil
+}
+
+func (c *CmdChatArchiveDecrypt) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+	}
+}
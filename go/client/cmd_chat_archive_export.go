@@ -0,0 +1,154 @@
+package client
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"golang.org/x/net/context"
+)
+
+type CmdChatArchiveExport struct {
+	libkb.Contextified
+	outputPath string
+	plaintext  bool
+}
+
+func NewCmdChatArchiveExportRunner(g *libkb.GlobalContext) *CmdChatArchiveExport {
+	return &CmdChatArchiveExport{
+		Contextified: libkb.NewContextified(g),
+	}
+}
+
+func newCmdChatArchiveExport(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "archive-export",
+		Usage:        "Export the history of chat archive jobs",
+		ArgumentHelp: "<output file>",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(NewCmdChatArchiveExportRunner(g), "archive-export", c)
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  "plaintext",
+				Usage: "Don't encrypt the queries and output paths of jobs",
+			},
+		},
+		Description: `The queries and output paths of exported jobs are encrypted so only your
+   devices can read them, with "keybase chat archive-import".`,
+	}
+}
+
+func (c *CmdChatArchiveExport) Run() error {
+	ui := c.G().UI.GetTerminalUI()
+	if c.plaintext {
+		ok, err := ui.PromptYesNo(PromptDescriptorChatArchiveExportPlaintext,
+			"The export will show who you archived chats with and where they were saved. Export in plaintext?",
+			libkb.PromptDefaultNo)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return NotConfirmedError{}
+		}
+	}
+
+	client, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return err
+	}
+	err = client.ArchiveChatExportHistory(context.TODO(), chat1.ArchiveChatExportHistoryArg{
+		OutputPath:       c.outputPath,
+		Plaintext:        c.plaintext,
+		IdentifyBehavior: keybase1.TLFIdentifyBehavior_CHAT_CLI,
+	})
+	if err != nil {
+		return err
+	}
+	ui.Printf("Job history exported to %s\n", c.outputPath)
+	return nil
+}
+
+func (c *CmdChatArchiveExport) ParseArgv(ctx *cli.Context) (err error) {
+	if len(ctx.Args()) != 1 {
+		return fmt.Errorf("output file is required")
+	}
+	// The service writes the file, from its own working directory.
+	c.outputPath, err = filepath.Abs(ctx.Args().Get(0))
+	if err != nil {
+		return err
+	}
+	c.plaintext = ctx.Bool("plaintext")
+	return nil
+}
+
+func (c *CmdChatArchiveExport) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+		API:    true,
+	}
+}
+
+type CmdChatArchiveImport struct {
+	libkb.Contextified
+	inputPath string
+}
+
+func NewCmdChatArchiveImportRunner(g *libkb.GlobalContext) *CmdChatArchiveImport {
+	return &CmdChatArchiveImport{
+		Contextified: libkb.NewContextified(g),
+	}
+}
+
+func newCmdChatArchiveImport(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "archive-import",
+		Usage:        "Import chat archive jobs from an export",
+		ArgumentHelp: "<export file>",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(NewCmdChatArchiveImportRunner(g), "archive-import", c)
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+	}
+}
+
+func (c *CmdChatArchiveImport) Run() error {
+	client, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return err
+	}
+	res, err := client.ArchiveChatImportHistory(context.TODO(), chat1.ArchiveChatImportHistoryArg{
+		InputPath:        c.inputPath,
+		IdentifyBehavior: keybase1.TLFIdentifyBehavior_CHAT_CLI,
+	})
+	if err != nil {
+		return err
+	}
+	ui := c.G().UI.GetTerminalUI()
+	ui.Printf("Imported %d job(s)", res.Imported)
+	if res.Skipped > 0 {
+		ui.Printf(", skipped %d already known", res.Skipped)
+	}
+	ui.Printf("\n")
+	return nil
+}
+
+func (c *CmdChatArchiveImport) ParseArgv(ctx *cli.Context) (err error) {
+	if len(ctx.Args()) != 1 {
+		return fmt.Errorf("export file is required")
+	}
+	c.inputPath, err = filepath.Abs(ctx.Args().Get(0))
+	return err
+}
+
+func (c *CmdChatArchiveImport) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+		API:    true,
+	}
+}
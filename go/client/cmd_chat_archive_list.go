@@ -62,6 +62,16 @@ Progress: %d%% (%d of %d messages archived)
 		if job.Err != "" {
 			ui.Printf("Err: %s\n", job.Err)
 		}
+		for _, applied := range job.AppliedRedactionRules {
+			for _, rule := range applied.Rules {
+				ui.Printf("Redacted: %s (%s)\n", rule.Name, applied.TeamName)
+			}
+		}
+		if schedule, ok := res.Schedules[job.Request.JobID]; ok {
+			ui.Printf("Schedule: %s, next at %s, appending to %s\n", formatChatArchiveSchedule(schedule),
+				chatrender.FmtTime(gregor1.FromTime(schedule.NextRun), chatrender.RenderOptions{UseDateTime: true}),
+				schedule.LastJobID)
+		}
 		ui.Printf("\n")
 	}
 	return nil
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"errors"
+	"path/filepath"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"golang.org/x/net/context"
+)
+
+type CmdChatArchiveLoad struct {
+	libkb.Contextified
+	inputPath string
+}
+
+func NewCmdChatArchiveLoadRunner(g *libkb.GlobalContext) *CmdChatArchiveLoad {
+	return &CmdChatArchiveLoad{
+		Contextified: libkb.NewContextified(g),
+	}
+}
+
+func newCmdChatArchiveLoad(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "archive-load",
+		Usage:        "Load a chat archive's messages back in, to read them once they're gone from the server",
+		ArgumentHelp: "<archive directory>",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(NewCmdChatArchiveLoadRunner(g), "archive-load", c)
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+		Description: `The archive has to be a directory written with --format=json,
+   --format=both or --sqlite. Loaded conversations are read-only, and are
+   read with "keybase chat archive-browse". Loading a conversation again
+   merges in its messages from the newer archive.`,
+	}
+}
+
+func (c *CmdChatArchiveLoad) Run() error {
+	client, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return err
+	}
+
+	res, err := client.ArchiveChatImport(context.TODO(), chat1.ArchiveChatImportArg{
+		InputPath:        c.inputPath,
+		IdentifyBehavior: keybase1.TLFIdentifyBehavior_CHAT_CLI,
+	})
+	if err != nil {
+		return err
+	}
+	ui := c.G().UI.GetTerminalUI()
+	for _, conv := range res.Convs {
+		ui.Printf("%s %s: %d messages\n", conv.Id, conv.Name, conv.Messages)
+	}
+	ui.Printf("Loaded %d messages from %d conversations\n", res.MessagesImported, len(res.Convs))
+	return nil
+}
+
+func (c *CmdChatArchiveLoad) ParseArgv(ctx *cli.Context) (err error) {
+	if len(ctx.Args()) != 1 {
+		return errors.New("the archive to load is required")
+	}
+	// The service may not share our working directory.
+	c.inputPath, err = filepath.Abs(ctx.Args().Get(0))
+	return err
+}
+
+func (c *CmdChatArchiveLoad) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+		API:    true,
+	}
+}
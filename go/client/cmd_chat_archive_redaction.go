@@ -0,0 +1,138 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	context "golang.org/x/net/context"
+)
+
+type CmdChatArchiveRedaction struct {
+	libkb.Contextified
+	tlfName string
+	rules   []chat1.ArchiveRedactionRule
+	clear   bool
+}
+
+func NewCmdChatArchiveRedactionRunner(g *libkb.GlobalContext) *CmdChatArchiveRedaction {
+	return &CmdChatArchiveRedaction{
+		Contextified: libkb.NewContextified(g),
+	}
+}
+
+func newCmdChatArchiveRedaction(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "archive-redaction",
+		Usage:        "Set or get the redaction rules applied to archives of a team's channels",
+		ArgumentHelp: "<team>",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(NewCmdChatArchiveRedactionRunner(g), "archive-redaction", c)
+			cl.SetNoStandalone()
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+		Flags: []cli.Flag{
+			cli.StringSliceFlag{
+				Name: "rule",
+				Usage: `Add a rule of the form name:pattern, where pattern is a regular
+	expression. Can be specified multiple times. Replaces any existing rules.`,
+			},
+			cli.StringFlag{
+				Name:  "replacement",
+				Usage: `Text to replace matches of the given rules with (default "[REDACTED]").`,
+			},
+			cli.BoolFlag{
+				Name:  "clear",
+				Usage: "Remove all redaction rules.",
+			},
+		},
+		Description: `Team admins can set redaction rules that are applied to every
+   "keybase chat archive" export of the team's channels, whoever runs it.
+   The rules applied are listed in redactions.txt in the export.
+
+   EXAMPLE:
+
+   keybase chat archive-redaction acme --rule 'aws:AKIA[0-9A-Z]{16}' --rule 'ticket:OPS-[0-9]+'`,
+	}
+}
+
+func (c *CmdChatArchiveRedaction) Run() (err error) {
+	chatClient, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return err
+	}
+	cli, err := GetTeamsClient(c.G())
+	if err != nil {
+		return err
+	}
+	teamID, err := cli.GetTeamID(context.Background(), c.tlfName)
+	if err != nil {
+		return err
+	}
+
+	if c.clear || len(c.rules) > 0 {
+		err = chatClient.SetArchiveRedactionRules(context.TODO(), chat1.SetArchiveRedactionRulesArg{
+			TeamID: teamID,
+			Rules:  chat1.ArchiveRedactionRules{Rules: c.rules},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	rules, err := chatClient.GetArchiveRedactionRules(context.TODO(), teamID)
+	if err != nil {
+		return err
+	}
+	dui := c.G().UI.GetDumbOutputUI()
+	if len(rules.Rules) == 0 {
+		dui.Printf("No redaction rules for %s.\n", c.tlfName)
+		return nil
+	}
+	dui.Printf("Redaction rules for %s are:\n", c.tlfName)
+	for _, rule := range rules.Rules {
+		replacement := rule.Replacement
+		if len(replacement) == 0 {
+			replacement = "[REDACTED]"
+		}
+		dui.Printf("\t%s: /%s/ -> %q\n", rule.Name, rule.Pattern, replacement)
+	}
+	return nil
+}
+
+func (c *CmdChatArchiveRedaction) ParseArgv(ctx *cli.Context) (err error) {
+	if len(ctx.Args()) != 1 {
+		return BadArgsError{"Expected exactly one arg"}
+	}
+	c.tlfName = ctx.Args().Get(0)
+	c.clear = ctx.Bool("clear")
+	replacement := ctx.String("replacement")
+	for _, r := range ctx.StringSlice("rule") {
+		parts := strings.SplitN(r, ":", 2)
+		if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+			return BadArgsError{fmt.Sprintf("rule %q must be of the form name:pattern", r)}
+		}
+		c.rules = append(c.rules, chat1.ArchiveRedactionRule{
+			Name:        parts[0],
+			Pattern:     parts[1],
+			Replacement: replacement,
+		})
+	}
+	if c.clear && len(c.rules) > 0 {
+		return BadArgsError{"--clear can't be used with --rule"}
+	}
+	if len(replacement) > 0 && len(c.rules) == 0 {
+		return BadArgsError{"--replacement needs at least one --rule"}
+	}
+	return nil
+}
+
+func (c *CmdChatArchiveRedaction) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+		API:    true,
+	}
+}
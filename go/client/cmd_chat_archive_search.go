@@ -0,0 +1,90 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"golang.org/x/net/context"
+)
+
+type CmdChatArchiveSearch struct {
+	libkb.Contextified
+	query   string
+	maxHits int
+}
+
+func NewCmdChatArchiveSearchRunner(g *libkb.GlobalContext) *CmdChatArchiveSearch {
+	return &CmdChatArchiveSearch{
+		Contextified: libkb.NewContextified(g),
+	}
+}
+
+func newCmdChatArchiveSearch(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "archive-search",
+		Usage:        "Search the messages of completed chat archives",
+		ArgumentHelp: "<query>",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(NewCmdChatArchiveSearchRunner(g), "archive-search", c)
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+		Flags: []cli.Flag{
+			cli.IntFlag{
+				Name:  "max-hits",
+				Value: 100,
+				Usage: "Specify the maximum number of messages to show",
+			},
+		},
+	}
+}
+
+func (c *CmdChatArchiveSearch) Run() error {
+	client, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return err
+	}
+
+	res, err := client.ArchiveChatSearch(context.TODO(), chat1.ArchiveChatSearchArg{
+		Query:            c.query,
+		MaxHits:          c.maxHits,
+		IdentifyBehavior: keybase1.TLFIdentifyBehavior_CHAT_CLI,
+	})
+	if err != nil {
+		return err
+	}
+	ui := c.G().UI.GetTerminalUI()
+	for _, hit := range res.Hits {
+		ui.Printf("%s %s [%d] [%s] %s\n", hit.JobID, hit.ConvName, hit.MsgID,
+			hit.AuthorAndTime, strings.ReplaceAll(hit.Body, "\n", "\n\t"))
+	}
+	if len(res.Hits) == 0 {
+		ui.Printf("No messages found\n")
+	} else if res.Truncated {
+		ui.Printf("\nShowing the first %d messages; use --max-hits to see more\n", len(res.Hits))
+	}
+	for _, jobID := range res.UnreadableJobs {
+		ui.Printf("Couldn't read the output of %s; it may have been moved or deleted\n", jobID)
+	}
+	return nil
+}
+
+func (c *CmdChatArchiveSearch) ParseArgv(ctx *cli.Context) (err error) {
+	if len(ctx.Args()) == 0 {
+		return fmt.Errorf("search query required")
+	}
+	c.query = strings.Join(ctx.Args(), " ")
+	c.maxHits = ctx.Int("max-hits")
+	return nil
+}
+
+func (c *CmdChatArchiveSearch) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+		API:    true,
+	}
+}
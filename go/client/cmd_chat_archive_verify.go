@@ -0,0 +1,84 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"golang.org/x/net/context"
+)
+
+type CmdChatArchiveVerify struct {
+	libkb.Contextified
+	jobID chat1.ArchiveJobID
+}
+
+func NewCmdChatArchiveVerifyRunner(g *libkb.GlobalContext) *CmdChatArchiveVerify {
+	return &CmdChatArchiveVerify{
+		Contextified: libkb.NewContextified(g),
+	}
+}
+
+func newCmdChatArchiveVerify(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "archive-verify",
+		Usage:        "Check an archive job's output for missing, truncated or corrupted files",
+		ArgumentHelp: "job-id",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(NewCmdChatArchiveVerifyRunner(g), "archive-verify", c)
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+	}
+}
+
+func (c *CmdChatArchiveVerify) Run() error {
+	client, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return err
+	}
+
+	res, err := client.ArchiveChatVerify(context.TODO(), chat1.ArchiveChatVerifyArg{
+		JobID:            c.jobID,
+		IdentifyBehavior: keybase1.TLFIdentifyBehavior_CHAT_CLI,
+	})
+	if err != nil {
+		return err
+	}
+
+	ui := c.G().UI.GetTerminalUI()
+	ui.Printf("Output: %s\n", res.OutputPath)
+	for _, issue := range res.Issues {
+		problem := strings.ReplaceAll(issue.Problem.String(), "_", " ")
+		if len(issue.Detail) > 0 {
+			ui.Printf("%-22s%s (%s)\n", problem, issue.Path, issue.Detail)
+		} else {
+			ui.Printf("%-22s%s\n", problem, issue.Path)
+		}
+	}
+	ui.Printf("Checked %d conversations, %d files and %d attachments\n",
+		res.ConvsChecked, res.FilesChecked, res.AttachmentsChecked)
+	if len(res.Issues) > 0 {
+		return fmt.Errorf("archive verification found %d issue(s)", len(res.Issues))
+	}
+	ui.Printf("No issues found\n")
+	return nil
+}
+
+func (c *CmdChatArchiveVerify) ParseArgv(ctx *cli.Context) (err error) {
+	if len(ctx.Args()) != 1 {
+		return fmt.Errorf("job-id is required")
+	}
+	c.jobID = chat1.ArchiveJobID(ctx.Args().Get(0))
+	return nil
+}
+
+func (c *CmdChatArchiveVerify) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+		API:    true,
+	}
+}
@@ -0,0 +1,164 @@
+package client
+
+import (
+	"encoding/json"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	context "golang.org/x/net/context"
+)
+
+type CmdChatAttachmentExpiry struct {
+	libkb.Contextified
+	tlfName string
+	days    *int
+	expire  bool
+	dryRun  bool
+	json    bool
+}
+
+func NewCmdChatAttachmentExpiryRunner(g *libkb.GlobalContext) *CmdChatAttachmentExpiry {
+	return &CmdChatAttachmentExpiry{
+		Contextified: libkb.NewContextified(g),
+	}
+}
+
+func newCmdChatAttachmentExpiry(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "attachment-expiry",
+		Usage:        "Set or get how long a team's attachments are kept",
+		ArgumentHelp: "<team>",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(NewCmdChatAttachmentExpiryRunner(g), "attachment-expiry", c)
+			cl.SetNoStandalone()
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+		Flags: []cli.Flag{
+			cli.IntFlag{
+				Name:  "days",
+				Usage: "How many days attachments are kept. 0 turns expiry off.",
+			},
+			cli.BoolFlag{
+				Name:  "expire",
+				Usage: "Expire old attachments now, instead of waiting for the background job.",
+			},
+			cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Report what would be expired, without deleting anything.",
+			},
+			cli.BoolFlag{
+				Name:  "j, json",
+				Usage: "Output the report as JSON",
+			},
+		},
+		Description: `Team admins can have the files of their team's attachments deleted once
+   they're older than some number of days, to keep the storage of busy teams
+   in check. The messages stay, with their titles, but the files can't be
+   downloaded anymore. Admins' devices expire attachments in the background.
+   Channels whose retention policy deletes messages sooner are left to it.
+   Without any flags, shows the current policy.
+
+   EXAMPLES:
+
+   keybase chat attachment-expiry acme --days 90
+   keybase chat attachment-expiry acme --dry-run`,
+	}
+}
+
+func (c *CmdChatAttachmentExpiry) Run() (err error) {
+	chatClient, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return err
+	}
+	cli, err := GetTeamsClient(c.G())
+	if err != nil {
+		return err
+	}
+	teamID, err := cli.GetTeamID(context.Background(), c.tlfName)
+	if err != nil {
+		return err
+	}
+
+	if c.days != nil {
+		err = chatClient.SetAttachmentExpiryPolicy(context.TODO(), chat1.SetAttachmentExpiryPolicyArg{
+			TeamID: teamID,
+			Policy: chat1.AttachmentExpiryPolicy{Days: *c.days},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	dui := c.G().UI.GetDumbOutputUI()
+	if !c.expire && !c.dryRun {
+		policy, err := chatClient.GetAttachmentExpiryPolicy(context.TODO(), teamID)
+		if err != nil {
+			return err
+		}
+		if policy.Days == 0 {
+			dui.Printf("Attachments in %s don't expire.\n", c.tlfName)
+		} else {
+			dui.Printf("Attachments in %s expire after %d days.\n", c.tlfName, policy.Days)
+		}
+		return nil
+	}
+
+	report, err := chatClient.ExpireTeamAttachments(context.TODO(), chat1.ExpireTeamAttachmentsArg{
+		TeamID: teamID,
+		DryRun: c.dryRun,
+	})
+	if err != nil {
+		return err
+	}
+	if c.json {
+		b, err := json.MarshalIndent(report, "", "    ")
+		if err != nil {
+			return err
+		}
+		dui.Printf("%s\n", b)
+		return nil
+	}
+	verb := "Expired"
+	if report.DryRun {
+		verb = "Would expire"
+	}
+	dui.Printf("%s %d attachments (%s) sent before %s.\n", verb, report.NumAttachments,
+		humanize.Bytes(uint64(report.NumBytes)), report.Cutoff.Time().Format("2006-01-02"))
+	for _, conv := range report.Convs {
+		if conv.CoveredByRetention {
+			dui.Printf("\t#%s: left to its retention policy\n", conv.Channel)
+			continue
+		}
+		dui.Printf("\t#%s: %d attachments (%s)\n", conv.Channel, conv.NumAttachments,
+			humanize.Bytes(uint64(conv.NumBytes)))
+	}
+	return nil
+}
+
+func (c *CmdChatAttachmentExpiry) ParseArgv(ctx *cli.Context) (err error) {
+	if len(ctx.Args()) != 1 {
+		return BadArgsError{"Expected exactly one arg"}
+	}
+	c.tlfName = ctx.Args().Get(0)
+	if ctx.IsSet("days") {
+		days := ctx.Int("days")
+		c.days = &days
+	}
+	c.expire = ctx.Bool("expire")
+	c.dryRun = ctx.Bool("dry-run")
+	c.json = ctx.Bool("json")
+	if c.json && !c.expire && !c.dryRun {
+		return BadArgsError{"--json only applies to --expire and --dry-run"}
+	}
+	return nil
+}
+
+func (c *CmdChatAttachmentExpiry) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+		API:    true,
+	}
+}
@@ -0,0 +1,180 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"golang.org/x/net/context"
+)
+
+type CmdChatBanner struct {
+	libkb.Contextified
+	resolvingRequest chatConversationResolvingRequest
+	text             *string
+	duration         time.Duration
+	showLog          bool
+}
+
+func NewCmdChatBannerRunner(g *libkb.GlobalContext) *CmdChatBanner {
+	return &CmdChatBanner{
+		Contextified: libkb.NewContextified(g),
+	}
+}
+
+func newCmdChatBanner(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "banner",
+		Usage:        "Put an announcement at the top of a team channel, for a while",
+		ArgumentHelp: "<conversation> [--set=<text> --for=<duration>] [--clear] [--log]",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(NewCmdChatBannerRunner(g), "banner", c)
+			cl.SetNoStandalone()
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+		Flags: append(getConversationResolverFlags(), []cli.Flag{
+			cli.StringFlag{
+				Name:  "set",
+				Usage: "Put this text up as the channel's banner",
+			},
+			cli.StringFlag{
+				Name:  "for",
+				Usage: "How long to keep the banner up, e.g. 4h or 72h (at most 720h)",
+			},
+			cli.BoolFlag{
+				Name:  "clear",
+				Usage: "Take the channel's banner down",
+			},
+			cli.BoolFlag{
+				Name:  "log",
+				Usage: "Show when banners were put up and taken down in the team's channels",
+			},
+		}...),
+		Description: `Team admins can put a banner at the top of a channel, for things members
+   should see for a while, like an outage or a deadline. Unlike a headline,
+   a banner always has an expiry, and it's taken down when that's up. Every
+   change is recorded in the team's banner log. Without any flags, shows the
+   channel's banner.
+
+   EXAMPLE:
+
+   keybase chat banner acme --channel general --set "Deploys are frozen until Monday" --for 72h`,
+	}
+}
+
+func (c *CmdChatBanner) ParseArgv(ctx *cli.Context) (err error) {
+	if len(ctx.Args()) != 1 {
+		return BadArgsError{"Expected exactly one conversation"}
+	}
+	if c.resolvingRequest, err = parseConversationResolvingRequest(ctx, ctx.Args().Get(0)); err != nil {
+		return err
+	}
+	set := strings.TrimSpace(ctx.String("set"))
+	clearBanner := ctx.Bool("clear")
+	switch {
+	case len(set) > 0 && clearBanner:
+		return BadArgsError{"Only one of --set and --clear can be given"}
+	case len(set) > 0:
+		f := ctx.String("for")
+		if len(f) == 0 {
+			return BadArgsError{"--set needs --for"}
+		}
+		c.duration, err = time.ParseDuration(f)
+		if err != nil || c.duration <= 0 {
+			return BadArgsError{fmt.Sprintf("invalid duration %q; use e.g. 4h or 72h", f)}
+		}
+		c.text = &set
+	case clearBanner:
+		empty := ""
+		c.text = &empty
+	}
+	c.showLog = ctx.Bool("log")
+	return nil
+}
+
+func (c *CmdChatBanner) Run() (err error) {
+	ctx := context.TODO()
+	if err := annotateResolvingRequest(c.G(), &c.resolvingRequest); err != nil {
+		return err
+	}
+	resolver, err := newChatConversationResolver(c.G())
+	if err != nil {
+		return err
+	}
+	conv, _, err := resolver.Resolve(ctx, c.resolvingRequest, chatConversationResolvingBehavior{
+		CreateIfNotExists: false,
+		Interactive:       false,
+		IdentifyBehavior:  keybase1.TLFIdentifyBehavior_CHAT_CLI,
+	})
+	if err != nil {
+		return err
+	}
+	if conv.GetMembersType() != chat1.ConversationMembersType_TEAM {
+		return fmt.Errorf("banners only work in team channels")
+	}
+
+	if c.text != nil {
+		err = resolver.ChatClient.SetChannelBanner(ctx, chat1.SetChannelBannerArg{
+			ConvID:   conv.GetConvID(),
+			Text:     *c.text,
+			Duration: gregor1.ToDurationSec(c.duration),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	dui := c.G().UI.GetDumbOutputUI()
+	if c.showLog {
+		teamID, err := keybase1.TeamIDFromString(conv.Info.Triple.Tlfid.String())
+		if err != nil {
+			return err
+		}
+		log, err := resolver.ChatClient.GetChannelBannerAuditLog(ctx, teamID)
+		if err != nil {
+			return err
+		}
+		if len(log.Entries) == 0 {
+			dui.Printf("No banners have been put up in %s.\n", conv.Info.TlfName)
+		}
+		for _, e := range log.Entries {
+			when := e.Ctime.Time().Format("2006-01-02 15:04")
+			switch e.Action {
+			case chat1.ChannelBannerAction_SET:
+				dui.Printf("%s\t%s put up a banner in #%s until %s: %s\n", when, e.Admin,
+					e.Channel, e.ExpiresAt.Time().Format("2006-01-02 15:04"), e.Text)
+			case chat1.ChannelBannerAction_CLEARED:
+				dui.Printf("%s\t%s took down the banner in #%s\n", when, e.Admin, e.Channel)
+			case chat1.ChannelBannerAction_EXPIRED:
+				dui.Printf("%s\tthe banner %s put up in #%s expired: %s\n", when, e.Admin,
+					e.Channel, e.Text)
+			}
+		}
+		return nil
+	}
+
+	banner, err := resolver.ChatClient.GetChannelBanner(ctx, conv.GetConvID())
+	if err != nil {
+		return err
+	}
+	if banner == nil {
+		dui.Printf("#%s has no banner.\n", conv.GetTopicName())
+		return nil
+	}
+	dui.Printf("%s\n(set by %s, until %s)\n", banner.Text, banner.SetBy,
+		banner.ExpiresAt.Time().Format("2006-01-02 15:04"))
+	return nil
+}
+
+func (c *CmdChatBanner) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+		API:    true,
+	}
+}
@@ -0,0 +1,204 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"golang.org/x/net/context"
+)
+
+type CmdChatBulkDelete struct {
+	libkb.Contextified
+	resolvingRequest chatConversationResolvingRequest
+	senders          []string
+	newerThan        gregor1.DurationSec
+	newerThanDesc    string
+	olderThan        gregor1.DurationSec
+	olderThanDesc    string
+	messageTypes     []chat1.MessageType
+	force            bool
+}
+
+func NewCmdChatBulkDeleteRunner(g *libkb.GlobalContext) *CmdChatBulkDelete {
+	return &CmdChatBulkDelete{
+		Contextified: libkb.NewContextified(g),
+	}
+}
+
+func newCmdChatBulkDelete(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "bulk-delete",
+		Usage:        "Delete messages in a team channel in bulk, by sender or time",
+		ArgumentHelp: "<conversation> [--sender=<username>] [--newer-than=<interval>] [--older-than=<interval>]",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(NewCmdChatBulkDeleteRunner(g), "bulk-delete", c)
+			cl.SetNoStandalone()
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+		Flags: append(getConversationResolverFlags(), []cli.Flag{
+			cli.StringSliceFlag{
+				Name:  "sender",
+				Usage: "Only delete messages from this user. Can be specified multiple times.",
+			},
+			cli.StringFlag{
+				Name:  "newer-than",
+				Usage: "Only delete messages newer than e.g. 2h, 3d, 1w",
+			},
+			cli.StringFlag{
+				Name:  "older-than",
+				Usage: "Only delete messages older than e.g. 2h, 3d, 1w",
+			},
+			cli.BoolFlag{
+				Name:  "reactions-only",
+				Usage: "Only delete reactions",
+			},
+			cli.BoolFlag{
+				Name:  "f, force",
+				Usage: "Don't ask for confirmation",
+			},
+		}...),
+		Description: `Team admins can delete the messages of other members of a channel in
+   bulk, to clean up spam. Pick the messages by sender, by time, or both.
+   Text, attachments, reactions, coin flips and payment requests are
+   deleted. Each deletion is recorded in the team's moderation log; see
+   "keybase chat moderation-log".
+
+   EXAMPLE:
+
+   keybase chat bulk-delete acme --channel general --sender spammer --newer-than 1d`,
+	}
+}
+
+func (c *CmdChatBulkDelete) ParseArgv(ctx *cli.Context) (err error) {
+	if len(ctx.Args()) != 1 {
+		return BadArgsError{"Expected exactly one conversation"}
+	}
+	if c.resolvingRequest, err = parseConversationResolvingRequest(ctx, ctx.Args().Get(0)); err != nil {
+		return err
+	}
+	c.senders = ctx.StringSlice("sender")
+	// The parsing is the same as delete-history's --age.
+	ageParser := &CmdChatDeleteHistory{}
+	if s := ctx.String("newer-than"); len(s) > 0 {
+		if c.newerThan, c.newerThanDesc, err = ageParser.parseAge(s); err != nil {
+			return err
+		}
+	}
+	if s := ctx.String("older-than"); len(s) > 0 {
+		if c.olderThan, c.olderThanDesc, err = ageParser.parseAge(s); err != nil {
+			return err
+		}
+	}
+	if len(c.senders) == 0 && len(c.newerThanDesc) == 0 && len(c.olderThanDesc) == 0 {
+		return BadArgsError{"Specify at least one of --sender, --newer-than or --older-than"}
+	}
+	if ctx.Bool("reactions-only") {
+		c.messageTypes = []chat1.MessageType{chat1.MessageType_REACTION}
+	}
+	c.force = ctx.Bool("force")
+	return nil
+}
+
+func (c *CmdChatBulkDelete) describe(chatFullName string) string {
+	what := "messages"
+	if len(c.messageTypes) > 0 {
+		what = "reactions"
+	}
+	desc := fmt.Sprintf("%s in [%s]", what, chatFullName)
+	if len(c.senders) > 0 {
+		desc += fmt.Sprintf(" from %s", strings.Join(c.senders, ", "))
+	}
+	if len(c.newerThanDesc) > 0 {
+		desc += fmt.Sprintf(" newer than %s", c.newerThanDesc)
+	}
+	if len(c.olderThanDesc) > 0 {
+		if len(c.newerThanDesc) > 0 {
+			desc += " and"
+		}
+		desc += fmt.Sprintf(" older than %s", c.olderThanDesc)
+	}
+	return desc
+}
+
+func (c *CmdChatBulkDelete) Run() (err error) {
+	ctx := context.TODO()
+	if err := annotateResolvingRequest(c.G(), &c.resolvingRequest); err != nil {
+		return err
+	}
+	resolver, err := newChatConversationResolver(c.G())
+	if err != nil {
+		return err
+	}
+	conv, _, err := resolver.Resolve(ctx, c.resolvingRequest, chatConversationResolvingBehavior{
+		CreateIfNotExists: false,
+		Interactive:       false,
+		IdentifyBehavior:  keybase1.TLFIdentifyBehavior_CHAT_CLI,
+	})
+	if err != nil {
+		return err
+	}
+
+	arg := chat1.BulkDeleteMessagesLocalArg{
+		ConvID:           conv.GetConvID(),
+		Senders:          c.senders,
+		MessageTypes:     c.messageTypes,
+		IdentifyBehavior: keybase1.TLFIdentifyBehavior_CHAT_CLI,
+	}
+	now := time.Now()
+	if len(c.newerThanDesc) > 0 {
+		after := gregor1.ToTime(now.Add(-c.newerThan.ToDuration()))
+		arg.After = &after
+	}
+	if len(c.olderThanDesc) > 0 {
+		before := gregor1.ToTime(now.Add(-c.olderThan.ToDuration()))
+		arg.Before = &before
+	}
+
+	chatFullName := conv.Info.TlfName
+	if len(conv.Info.TopicName) > 0 {
+		chatFullName = fmt.Sprintf("%s#%s", conv.Info.TlfName, conv.Info.TopicName)
+	}
+	ui := c.G().UI.GetTerminalUI()
+	if !c.force {
+		arg.DryRun = true
+		res, err := resolver.ChatClient.BulkDeleteMessagesLocal(ctx, arg)
+		if err != nil {
+			return err
+		}
+		if res.NumMatched == 0 {
+			ui.Printf("No %s.\n", c.describe(chatFullName))
+			return nil
+		}
+		ok, err := ui.PromptYesNo(PromptDescriptorChatBulkDelete,
+			fmt.Sprintf("Permanently delete %d %s?", res.NumMatched, c.describe(chatFullName)),
+			libkb.PromptDefaultNo)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+		arg.DryRun = false
+	}
+
+	res, err := resolver.ChatClient.BulkDeleteMessagesLocal(ctx, arg)
+	if err != nil {
+		return err
+	}
+	ui.Printf("Deleted %d of %d matching messages.\n", res.NumDeleted, res.NumMatched)
+	return nil
+}
+
+func (c *CmdChatBulkDelete) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+		API:    true,
+	}
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+type CmdChatExportContacts struct {
+	libkb.Contextified
+	resolvingRequest chatConversationResolvingRequest
+	outputPath       string
+}
+
+func newCmdChatExportContacts(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "export-contacts",
+		Usage:        "Export the participants of a conversation as vCards",
+		ArgumentHelp: "<conversation> [-o filename.vcf]",
+		Action: func(c *cli.Context) {
+			cmd := &CmdChatExportContacts{Contextified: libkb.NewContextified(g)}
+			cl.ChooseCommand(cmd, "export-contacts", c)
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+		Flags: append(getConversationResolverFlags(), []cli.Flag{
+			cli.StringFlag{
+				Name:  "o, outfile",
+				Usage: "Write the vCards to a file instead of stdout",
+			},
+		}...),
+	}
+}
+
+func (c *CmdChatExportContacts) ParseArgv(ctx *cli.Context) (err error) {
+	if len(ctx.Args()) != 1 {
+		return fmt.Errorf("must specify a conversation")
+	}
+	if c.resolvingRequest, err = parseConversationResolvingRequest(ctx, ctx.Args()[0]); err != nil {
+		return err
+	}
+	c.outputPath = ctx.String("outfile")
+	return nil
+}
+
+func (c *CmdChatExportContacts) Run() error {
+	ctx := context.Background()
+	if err := annotateResolvingRequest(c.G(), &c.resolvingRequest); err != nil {
+		return err
+	}
+	resolver, err := newChatConversationResolver(c.G())
+	if err != nil {
+		return err
+	}
+	conv, _, err := resolver.Resolve(ctx, c.resolvingRequest, chatConversationResolvingBehavior{
+		CreateIfNotExists: false,
+		Interactive:       false,
+		IdentifyBehavior:  keybase1.TLFIdentifyBehavior_CHAT_CLI,
+	})
+	if err != nil {
+		return err
+	}
+	res, err := resolver.ChatClient.GetConversationContacts(ctx, chat1.GetConversationContactsArg{
+		ConvID:           conv.GetConvID(),
+		IdentifyBehavior: keybase1.TLFIdentifyBehavior_CHAT_CLI,
+	})
+	if err != nil {
+		return err
+	}
+
+	ui := c.G().UI.GetTerminalUI()
+	if len(c.outputPath) == 0 {
+		return ui.Output(res.Vcards)
+	}
+	if err := os.WriteFile(c.outputPath, []byte(res.Vcards), 0600); err != nil {
+		return err
+	}
+	ui.Printf("Exported %d contacts to %s\n", res.Count, c.outputPath)
+	return nil
+}
+
+func (c *CmdChatExportContacts) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		API:       true,
+		KbKeyring: true,
+		Config:    true,
+	}
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"golang.org/x/net/context"
+)
+
+type CmdChatHistorySearch struct {
+	libkb.Contextified
+	query   string
+	maxHits int
+}
+
+func NewCmdChatHistorySearchRunner(g *libkb.GlobalContext) *CmdChatHistorySearch {
+	return &CmdChatHistorySearch{
+		Contextified: libkb.NewContextified(g),
+	}
+}
+
+func newCmdChatHistorySearch(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "history-search",
+		Usage:        "Search your chat history and completed chat archives together",
+		ArgumentHelp: "<query>",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(NewCmdChatHistorySearchRunner(g), "history-search", c)
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+		Flags: []cli.Flag{
+			cli.IntFlag{
+				Name:  "max-hits",
+				Value: 100,
+				Usage: "Specify the maximum number of messages to show",
+			},
+		},
+	}
+}
+
+func (c *CmdChatHistorySearch) Run() error {
+	client, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return err
+	}
+
+	res, err := client.SearchChatHistory(context.TODO(), chat1.SearchChatHistoryArg{
+		Query:            c.query,
+		MaxHits:          c.maxHits,
+		IdentifyBehavior: keybase1.TLFIdentifyBehavior_CHAT_CLI,
+	})
+	if err != nil {
+		return err
+	}
+	ui := c.G().UI.GetTerminalUI()
+	for _, hit := range res.Hits {
+		source := "live"
+		if hit.Source == chat1.ChatHistorySearchSource_ARCHIVE {
+			source = string(hit.JobID)
+		}
+		when := "?"
+		if hit.Ctime != 0 {
+			when = gregor1.FromTime(hit.Ctime).Format("2006-01-02 15:04:05")
+		}
+		ui.Printf("%s %s [%d] [%s %s] %s\n", source, hit.ConvName, hit.MsgID, hit.Sender, when,
+			strings.ReplaceAll(hit.Body, "\n", "\n\t"))
+	}
+	if len(res.Hits) == 0 {
+		ui.Printf("No messages found\n")
+	} else if res.Truncated {
+		ui.Printf("\nShowing the newest %d messages; use --max-hits to see more\n", len(res.Hits))
+	}
+	if res.PercentIndexed < 100 {
+		ui.Printf("Your chat history is %d%% indexed, so some live messages may be missing\n", res.PercentIndexed)
+	}
+	for _, jobID := range res.UnreadableJobs {
+		ui.Printf("Couldn't read the output of %s; it may have been moved or deleted\n", jobID)
+	}
+	return nil
+}
+
+func (c *CmdChatHistorySearch) ParseArgv(ctx *cli.Context) (err error) {
+	if len(ctx.Args()) == 0 {
+		return fmt.Errorf("search query required")
+	}
+	c.query = strings.Join(ctx.Args(), " ")
+	c.maxHits = ctx.Int("max-hits")
+	return nil
+}
+
+func (c *CmdChatHistorySearch) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+		API:    true,
+	}
+}
@@ -0,0 +1,171 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"golang.org/x/net/context"
+)
+
+type CmdChatIncidentMode struct {
+	libkb.Contextified
+	resolvingRequest chatConversationResolvingRequest
+	duration         *time.Duration
+	showLog          bool
+}
+
+func NewCmdChatIncidentModeRunner(g *libkb.GlobalContext) *CmdChatIncidentMode {
+	return &CmdChatIncidentMode{
+		Contextified: libkb.NewContextified(g),
+	}
+}
+
+func newCmdChatIncidentMode(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "incident-mode",
+		Usage:        "Make a team channel notify members who muted it, for a while",
+		ArgumentHelp: "<conversation> [--on=<duration>] [--off] [--log]",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(NewCmdChatIncidentModeRunner(g), "incident-mode", c)
+			cl.SetNoStandalone()
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+		Flags: append(getConversationResolverFlags(), []cli.Flag{
+			cli.StringFlag{
+				Name:  "on",
+				Usage: "Turn incident mode on for this long, e.g. 30m or 4h (at most 24h)",
+			},
+			cli.BoolFlag{
+				Name:  "off",
+				Usage: "Turn incident mode off",
+			},
+			cli.BoolFlag{
+				Name:  "log",
+				Usage: "Show when incident mode was turned on and off in the team's channels",
+			},
+		}...),
+		Description: `While a channel is in incident mode, its members get notifications for it
+   even if they've muted it, as though they hadn't. Team admins can turn it
+   on for up to a day at a time, for on-call and incident communication; it
+   turns itself off when the time is up. Every change is recorded in the
+   team's incident mode log. Without any flags, shows which of the team's
+   channels are in incident mode.
+
+   EXAMPLE:
+
+   keybase chat incident-mode acme --channel oncall --on 2h`,
+	}
+}
+
+func (c *CmdChatIncidentMode) ParseArgv(ctx *cli.Context) (err error) {
+	if len(ctx.Args()) != 1 {
+		return BadArgsError{"Expected exactly one conversation"}
+	}
+	if c.resolvingRequest, err = parseConversationResolvingRequest(ctx, ctx.Args().Get(0)); err != nil {
+		return err
+	}
+	on := ctx.String("on")
+	off := ctx.Bool("off")
+	switch {
+	case len(on) > 0 && off:
+		return BadArgsError{"Only one of --on and --off can be given"}
+	case len(on) > 0:
+		d, err := time.ParseDuration(on)
+		if err != nil || d <= 0 {
+			return BadArgsError{fmt.Sprintf("invalid duration %q; use e.g. 30m or 4h", on)}
+		}
+		c.duration = &d
+	case off:
+		d := time.Duration(0)
+		c.duration = &d
+	}
+	c.showLog = ctx.Bool("log")
+	return nil
+}
+
+func (c *CmdChatIncidentMode) Run() (err error) {
+	ctx := context.TODO()
+	if err := annotateResolvingRequest(c.G(), &c.resolvingRequest); err != nil {
+		return err
+	}
+	resolver, err := newChatConversationResolver(c.G())
+	if err != nil {
+		return err
+	}
+	conv, _, err := resolver.Resolve(ctx, c.resolvingRequest, chatConversationResolvingBehavior{
+		CreateIfNotExists: false,
+		Interactive:       false,
+		IdentifyBehavior:  keybase1.TLFIdentifyBehavior_CHAT_CLI,
+	})
+	if err != nil {
+		return err
+	}
+	if conv.GetMembersType() != chat1.ConversationMembersType_TEAM {
+		return fmt.Errorf("incident mode only works in team channels")
+	}
+	teamID, err := keybase1.TeamIDFromString(conv.Info.Triple.Tlfid.String())
+	if err != nil {
+		return err
+	}
+
+	if c.duration != nil {
+		err = resolver.ChatClient.SetIncidentMode(ctx, chat1.SetIncidentModeArg{
+			ConvID:   conv.GetConvID(),
+			Duration: gregor1.ToDurationSec(*c.duration),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	dui := c.G().UI.GetDumbOutputUI()
+	if c.showLog {
+		log, err := resolver.ChatClient.GetIncidentModeAuditLog(ctx, teamID)
+		if err != nil {
+			return err
+		}
+		if len(log.Entries) == 0 {
+			dui.Printf("Incident mode has never been used in %s.\n", conv.Info.TlfName)
+		}
+		for _, e := range log.Entries {
+			when := e.Ctime.Time().Format("2006-01-02 15:04")
+			switch e.Action {
+			case chat1.IncidentModeAction_ENABLED:
+				dui.Printf("%s\t%s turned on incident mode in #%s until %s\n", when, e.Admin,
+					e.Channel, e.ExpiresAt.Time().Format("2006-01-02 15:04"))
+			case chat1.IncidentModeAction_DISABLED:
+				dui.Printf("%s\t%s turned off incident mode in #%s\n", when, e.Admin, e.Channel)
+			case chat1.IncidentModeAction_EXPIRED:
+				dui.Printf("%s\tincident mode set by %s in #%s expired\n", when, e.Admin, e.Channel)
+			}
+		}
+		return nil
+	}
+
+	settings, err := resolver.ChatClient.GetIncidentMode(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	if len(settings.Channels) == 0 {
+		dui.Printf("No channels in %s are in incident mode.\n", conv.Info.TlfName)
+		return nil
+	}
+	for _, channel := range settings.Channels {
+		dui.Printf("#%s is in incident mode until %s (set by %s)\n", channel.Channel,
+			channel.ExpiresAt.Time().Format("2006-01-02 15:04"), channel.SetBy)
+	}
+	return nil
+}
+
+func (c *CmdChatIncidentMode) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+		API:    true,
+	}
+}
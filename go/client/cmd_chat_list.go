@@ -4,18 +4,28 @@
 package client
 
 import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
 	"golang.org/x/net/context"
 
 	"github.com/keybase/cli"
+	"github.com/keybase/client/go/chat/utils"
 	"github.com/keybase/client/go/chatrender"
 	"github.com/keybase/client/go/libcmdline"
 	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/keybase1"
 )
 
 type cmdChatList struct {
 	libkb.Contextified
-	fetcher        chatCLIInboxFetcher
+	query          chat1.InboxQueryForCLI
 	showDeviceName bool
+	json           bool
 }
 
 func newCmdChatList(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
@@ -24,40 +34,191 @@ func newCmdChatList(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Comm
 		Usage:        "List conversations, sorted by activity.",
 		Aliases:      []string{"ls"},
 		ArgumentHelp: "",
+		Examples: `
+List unread conversations in a team:
+    keybase chat list --unread --team=acme
+List the channels you've left, by name:
+    keybase chat list --team=acme --membership=left --sort=name
+List everything as JSON:
+    keybase chat list --include-hidden --membership=any --json
+`,
 		Action: func(c *cli.Context) {
 			cl.ChooseCommand(&cmdChatList{Contextified: libkb.NewContextified(g)}, "list", c)
 			cl.SetNoStandalone()
 			cl.SetLogForward(libcmdline.LogForwardNone)
 		},
-		Flags: getInboxFetcherActivitySortedFlags(),
+		Flags: append(getInboxFetcherActivitySortedFlags(),
+			cli.StringFlag{
+				Name:  "before",
+				Usage: `Only show conversations without activity since the given time. Same format as --since.`,
+			},
+			cli.BoolFlag{
+				Name:  "unread",
+				Usage: "Only show conversations with unread messages",
+			},
+			cli.StringFlag{
+				Name:  "team",
+				Usage: "Only show the channels of the given team",
+			},
+			cli.StringFlag{
+				Name: "membership",
+				Usage: `Only show conversations you have the given membership in, a comma-separated list of
+	"active", "preview", "left", "removed", "reset" and "never-joined", or "any". Defaults to active,preview,reset.`,
+			},
+			cli.StringFlag{
+				Name:  "sort",
+				Usage: `How to sort conversations: "activity" (default), "unread" (unread first) or "name"`,
+			},
+			cli.BoolFlag{
+				Name:  "j, json",
+				Usage: "Output as JSON",
+			},
+		),
 	}
 }
 
-func (c *cmdChatList) Run() error {
-	conversations, err := c.fetcher.fetch(context.TODO(), c.G())
-	if err != nil {
-		return err
+func parseChatListSort(s string) (chat1.InboxQueryForCLISort, error) {
+	switch strings.ToLower(s) {
+	case "", "activity":
+		return chat1.InboxQueryForCLISort_ACTIVITY, nil
+	case "unread":
+		return chat1.InboxQueryForCLISort_UNREAD_FIRST, nil
+	case "name":
+		return chat1.InboxQueryForCLISort_NAME, nil
+	default:
+		return 0, fmt.Errorf("invalid sort %q; has to be one of activity, unread or name", s)
 	}
+}
 
-	if err = chatrender.ConversationListView(conversations).Show(c.G(), c.G().Env.GetUsername().String(),
-		c.showDeviceName); err != nil {
-		return err
+func parseChatListMembership(s string) (res []chat1.ConversationMemberStatus, err error) {
+	if len(s) == 0 {
+		// The service's default.
+		return nil, nil
 	}
-
-	// TODO: print summary of inbox. e.g.
-	//		+44 older chats (--time=7d to see 25 more)
-
-	return nil
+	if strings.ToLower(s) == "any" {
+		for status := range chat1.ConversationMemberStatusRevMap {
+			res = append(res, status)
+		}
+		return res, nil
+	}
+	for _, name := range strings.Split(s, ",") {
+		key := strings.ToUpper(strings.Replace(strings.TrimSpace(name), "-", "_", -1))
+		status, ok := chat1.ConversationMemberStatusMap[key]
+		if !ok {
+			return nil, fmt.Errorf("invalid membership %q", name)
+		}
+		res = append(res, status)
+	}
+	return res, nil
 }
 
 func (c *cmdChatList) ParseArgv(ctx *cli.Context) (err error) {
-	if c.fetcher, err = makeChatCLIInboxFetcherActivitySorted(ctx); err != nil {
+	if len(ctx.Args()) != 0 {
+		return fmt.Errorf("list takes no arguments")
+	}
+	if c.query.TopicType, err = parseConversationTopicType(ctx); err != nil {
+		return err
+	}
+	switch {
+	case ctx.Bool("private"):
+		c.query.Visibility = keybase1.TLFVisibility_PRIVATE
+	case ctx.Bool("public"):
+		c.query.Visibility = keybase1.TLFVisibility_PUBLIC
+	default:
+		c.query.Visibility = keybase1.TLFVisibility_ANY
+	}
+	if !ctx.Bool("include-hidden") {
+		c.query.Status = utils.VisibleChatConversationStatuses()
+	}
+	if c.query.MemberStatus, err = parseChatListMembership(ctx.String("membership")); err != nil {
 		return err
 	}
+	if c.query.Sort, err = parseChatListSort(ctx.String("sort")); err != nil {
+		return err
+	}
+	c.query.TeamName = ctx.String("team")
+	c.query.UnreadOnly = ctx.Bool("unread")
+	c.query.After = ctx.String("since")
+	c.query.Before = ctx.String("before")
+	c.query.Limit = ctx.Int("number")
 	c.showDeviceName = ctx.Bool("show-device-name")
+	c.json = ctx.Bool("json")
 	return nil
 }
 
+// chatListJSONConv is what --json prints for each conversation. It's kept
+// separate from chat1.ConversationLocal so the output stays the same when
+// the protocol changes.
+type chatListJSONConv struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Channel      string    `json:"channel,omitempty"`
+	MembersType  string    `json:"membersType"`
+	TopicType    string    `json:"topicType"`
+	Visibility   string    `json:"visibility"`
+	Status       string    `json:"status"`
+	MemberStatus string    `json:"memberStatus"`
+	Unread       bool      `json:"unread"`
+	ActiveAt     time.Time `json:"activeAt"`
+	Error        string    `json:"error,omitempty"`
+}
+
+func newChatListJSONConv(conv chat1.ConversationLocal) chatListJSONConv {
+	res := chatListJSONConv{
+		ID:           conv.GetConvID().String(),
+		Name:         conv.Info.TlfName,
+		MembersType:  strings.ToLower(conv.GetMembersType().String()),
+		TopicType:    strings.ToLower(conv.GetTopicType().String()),
+		Visibility:   strings.ToLower(conv.Info.Visibility.String()),
+		Status:       strings.ToLower(conv.Info.Status.String()),
+		MemberStatus: strings.ToLower(conv.Info.MemberStatus.String()),
+		Unread:       conv.ReaderInfo.ReadMsgid < conv.ReaderInfo.MaxMsgid,
+		ActiveAt:     gregor1.FromTime(conv.GetMtime()).UTC(),
+	}
+	if conv.GetMembersType() == chat1.ConversationMembersType_TEAM {
+		res.Channel = conv.Info.TopicName
+	}
+	if conv.Error != nil {
+		res.Name = conv.Error.UnverifiedTLFName
+		res.Error = conv.Error.Message
+	}
+	return res
+}
+
+func (c *cmdChatList) showJSON(conversations []chat1.ConversationLocal) error {
+	convs := make([]chatListJSONConv, 0, len(conversations))
+	for _, conv := range conversations {
+		convs = append(convs, newChatListJSONConv(conv))
+	}
+	out, err := json.MarshalIndent(convs, "", "  ")
+	if err != nil {
+		return err
+	}
+	return c.G().UI.GetTerminalUI().OutputDesc(OutputDescriptorChatList, string(out)+"\n")
+}
+
+func (c *cmdChatList) Run() error {
+	ctx := context.TODO()
+	chatClient, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return fmt.Errorf("Getting chat service client error: %s", err)
+	}
+	res, err := chatClient.QueryInboxForCLILocal(ctx, c.query)
+	if err != nil {
+		return err
+	}
+	if res.Offline {
+		_, _ = c.G().UI.GetTerminalUI().ErrorWriter().Write([]byte(
+			ColorString(c.G(), "yellow", "WARNING: inbox results obtained in OFFLINE mode\n")))
+	}
+
+	if c.json {
+		return c.showJSON(res.Conversations)
+	}
+	return chatrender.ConversationListView(res.Conversations).Show(c.G(), c.G().Env.GetUsername().String(),
+		c.showDeviceName)
+}
+
 func (c *cmdChatList) GetUsage() libkb.Usage {
 	return libkb.Usage{
 		Config: true,
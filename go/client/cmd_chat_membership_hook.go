@@ -0,0 +1,129 @@
+package client
+
+import (
+	"strings"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	context "golang.org/x/net/context"
+)
+
+type CmdChatMembershipHook struct {
+	libkb.Contextified
+	tlfName string
+	hook    *chat1.MembershipHook
+}
+
+func NewCmdChatMembershipHookRunner(g *libkb.GlobalContext) *CmdChatMembershipHook {
+	return &CmdChatMembershipHook{
+		Contextified: libkb.NewContextified(g),
+	}
+}
+
+func newCmdChatMembershipHook(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "membership-hook",
+		Usage:        "Set or get the bot told about people joining and leaving a team",
+		ArgumentHelp: "<team>",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(NewCmdChatMembershipHookRunner(g), "membership-hook", c)
+			cl.SetNoStandalone()
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "bot",
+				Usage: "Send membership events to this bot.",
+			},
+			cli.StringSliceFlag{
+				Name:  "channel",
+				Usage: "Only send joins and leaves in this channel. Can be specified multiple times.",
+			},
+			cli.BoolFlag{
+				Name:  "off",
+				Usage: "Stop sending membership events.",
+			},
+		},
+		Description: `Team admins can have a bot told whenever someone joins or leaves one of
+   the team's channels, or is added to the team, to automate things like
+   granting access elsewhere. Admins' devices send the bot each event as
+   JSON in a direct message starting with "!membership-event ", so the bot
+   gets it once from every admin device in the channel and should only act
+   on each event id once. Without any flags, shows the current hook.
+
+   EXAMPLE:
+
+   keybase chat membership-hook acme --bot acmebot --channel eng`,
+	}
+}
+
+func (c *CmdChatMembershipHook) Run() (err error) {
+	chatClient, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return err
+	}
+	cli, err := GetTeamsClient(c.G())
+	if err != nil {
+		return err
+	}
+	teamID, err := cli.GetTeamID(context.Background(), c.tlfName)
+	if err != nil {
+		return err
+	}
+
+	if c.hook != nil {
+		err = chatClient.SetMembershipHook(context.TODO(), chat1.SetMembershipHookArg{
+			TeamID: teamID,
+			Hook:   *c.hook,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	hook, err := chatClient.GetMembershipHook(context.TODO(), teamID)
+	if err != nil {
+		return err
+	}
+	dui := c.G().UI.GetDumbOutputUI()
+	switch {
+	case len(hook.BotUsername) == 0:
+		dui.Printf("%s has no membership hook.\n", c.tlfName)
+	case len(hook.Channels) == 0:
+		dui.Printf("Membership events in %s are sent to %s.\n", c.tlfName, hook.BotUsername)
+	default:
+		dui.Printf("Additions to %s and joins and leaves in #%s are sent to %s.\n", c.tlfName,
+			strings.Join(hook.Channels, ", #"), hook.BotUsername)
+	}
+	return nil
+}
+
+func (c *CmdChatMembershipHook) ParseArgv(ctx *cli.Context) (err error) {
+	if len(ctx.Args()) != 1 {
+		return BadArgsError{"Expected exactly one arg"}
+	}
+	c.tlfName = ctx.Args().Get(0)
+	bot := ctx.String("bot")
+	channels := ctx.StringSlice("channel")
+	switch {
+	case ctx.Bool("off"):
+		if len(bot) > 0 || len(channels) > 0 {
+			return BadArgsError{"--off can't be given with --bot or --channel"}
+		}
+		c.hook = &chat1.MembershipHook{}
+	case len(bot) > 0:
+		c.hook = &chat1.MembershipHook{BotUsername: bot, Channels: channels}
+	case len(channels) > 0:
+		return BadArgsError{"--bot is required to set the hook"}
+	}
+	return nil
+}
+
+func (c *CmdChatMembershipHook) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+		API:    true,
+	}
+}
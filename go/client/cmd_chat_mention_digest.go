@@ -0,0 +1,208 @@
+package client
+
+import (
+	"strings"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	context "golang.org/x/net/context"
+)
+
+type CmdChatMentionDigest struct {
+	libkb.Contextified
+	enable  bool
+	disable bool
+	optOut  []string
+	optIn   []string
+	show    bool
+}
+
+func NewCmdChatMentionDigestRunner(g *libkb.GlobalContext) *CmdChatMentionDigest {
+	return &CmdChatMentionDigest{
+		Contextified: libkb.NewContextified(g),
+	}
+}
+
+func newCmdChatMentionDigest(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:  "mention-digest",
+		Usage: "Manage the daily digest of mentions in muted channels",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(NewCmdChatMentionDigestRunner(g), "mention-digest", c)
+			cl.SetNoStandalone()
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  "enable",
+				Usage: "Turn mention digests on.",
+			},
+			cli.BoolFlag{
+				Name:  "disable",
+				Usage: "Turn mention digests off.",
+			},
+			cli.StringSliceFlag{
+				Name:  "opt-out",
+				Usage: "Don't include a team's channels in digests. Can be specified multiple times.",
+			},
+			cli.StringSliceFlag{
+				Name:  "opt-in",
+				Usage: "Include a team's channels in digests again. Can be specified multiple times.",
+			},
+			cli.BoolFlag{
+				Name:  "show",
+				Usage: "Show the mentions from the last day now.",
+			},
+		},
+		Description: `Once a day, each of your devices sends a notification summarizing the
+   messages in your muted team channels that @-mention you, built from the
+   messages it has cached. Settings are shared by all of your devices.
+
+   EXAMPLES:
+
+   Skip the channels of a busy team:
+
+       keybase chat mention-digest --opt-out bigteam
+
+   See what you'd get in a digest right now:
+
+       keybase chat mention-digest --show`,
+	}
+}
+
+func (c *CmdChatMentionDigest) Run() (err error) {
+	chatClient, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return err
+	}
+	settings, err := chatClient.GetMentionDigestSettings(context.TODO())
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	if c.enable || c.disable {
+		settings.Disabled = c.disable
+		changed = true
+	}
+	if len(c.optOut) > 0 || len(c.optIn) > 0 {
+		teamsClient, err := GetTeamsClient(c.G())
+		if err != nil {
+			return err
+		}
+		for _, name := range c.optOut {
+			teamID, err := teamsClient.GetTeamID(context.TODO(), name)
+			if err != nil {
+				return err
+			}
+			if !mentionDigestHasTeam(settings.OptedOutTeams, teamID) {
+				settings.OptedOutTeams = append(settings.OptedOutTeams, teamID)
+			}
+		}
+		for _, name := range c.optIn {
+			teamID, err := teamsClient.GetTeamID(context.TODO(), name)
+			if err != nil {
+				return err
+			}
+			var teams []keybase1.TeamID
+			for _, optedOut := range settings.OptedOutTeams {
+				if !optedOut.Eq(teamID) {
+					teams = append(teams, optedOut)
+				}
+			}
+			settings.OptedOutTeams = teams
+		}
+		changed = true
+	}
+	if changed {
+		if err := chatClient.SetMentionDigestSettings(context.TODO(), settings); err != nil {
+			return err
+		}
+	}
+
+	dui := c.G().UI.GetDumbOutputUI()
+	if settings.Disabled {
+		dui.Printf("Mention digests are off.\n")
+	} else {
+		dui.Printf("Mention digests are on.\n")
+	}
+	if len(settings.OptedOutTeams) > 0 {
+		teamsClient, err := GetTeamsClient(c.G())
+		if err != nil {
+			return err
+		}
+		var names []string
+		for _, teamID := range settings.OptedOutTeams {
+			name := teamID.String()
+			if teamName, err := teamsClient.GetTeamName(context.TODO(), teamID); err == nil {
+				name = teamName.String()
+			}
+			names = append(names, name)
+		}
+		dui.Printf("Opted out teams: %s\n", strings.Join(names, ", "))
+	}
+	if !c.show {
+		return nil
+	}
+	digest, err := chatClient.GetMentionDigest(context.TODO())
+	if err != nil {
+		return err
+	}
+	c.printDigest(dui, digest)
+	return nil
+}
+
+func mentionDigestHasTeam(teams []keybase1.TeamID, teamID keybase1.TeamID) bool {
+	for _, t := range teams {
+		if t.Eq(teamID) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *CmdChatMentionDigest) printDigest(dui libkb.DumbOutputUI, digest chat1.MentionDigest) {
+	if len(digest.Convs) == 0 {
+		dui.Printf("No mentions in muted channels since %s.\n",
+			digest.Since.Time().Format("2006-01-02 15:04"))
+		return
+	}
+	for _, conv := range digest.Convs {
+		dui.Printf("%s#%s: %d mention(s)\n", conv.TlfName, conv.Channel, conv.MentionCount)
+		for _, item := range conv.Items {
+			dui.Printf("\t%s %s: %s\n", item.Ctime.Time().Format("15:04"), item.Sender, item.Snippet)
+		}
+	}
+}
+
+func (c *CmdChatMentionDigest) ParseArgv(ctx *cli.Context) (err error) {
+	if len(ctx.Args()) != 0 {
+		return BadArgsError{"mention-digest takes no arguments"}
+	}
+	c.enable = ctx.Bool("enable")
+	c.disable = ctx.Bool("disable")
+	if c.enable && c.disable {
+		return BadArgsError{"--enable and --disable can't be used together"}
+	}
+	c.optOut = ctx.StringSlice("opt-out")
+	c.optIn = ctx.StringSlice("opt-in")
+	for _, out := range c.optOut {
+		for _, in := range c.optIn {
+			if strings.EqualFold(out, in) {
+				return BadArgsError{"can't --opt-out and --opt-in the same team"}
+			}
+		}
+	}
+	c.show = ctx.Bool("show")
+	return nil
+}
+
+func (c *CmdChatMentionDigest) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+		API:    true,
+	}
+}
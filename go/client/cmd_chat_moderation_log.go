@@ -0,0 +1,96 @@
+package client
+
+import (
+	"strings"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"golang.org/x/net/context"
+)
+
+type CmdChatModerationLog struct {
+	libkb.Contextified
+	tlfName string
+}
+
+func NewCmdChatModerationLogRunner(g *libkb.GlobalContext) *CmdChatModerationLog {
+	return &CmdChatModerationLog{
+		Contextified: libkb.NewContextified(g),
+	}
+}
+
+func newCmdChatModerationLog(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "moderation-log",
+		Usage:        "Show the bulk deletions admins have made in a team's channels",
+		ArgumentHelp: "<team>",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(NewCmdChatModerationLogRunner(g), "moderation-log", c)
+			cl.SetNoStandalone()
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+	}
+}
+
+func (c *CmdChatModerationLog) Run() (err error) {
+	chatClient, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return err
+	}
+	cli, err := GetTeamsClient(c.G())
+	if err != nil {
+		return err
+	}
+	teamID, err := cli.GetTeamID(context.Background(), c.tlfName)
+	if err != nil {
+		return err
+	}
+	log, err := chatClient.GetModerationAuditLog(context.TODO(), teamID)
+	if err != nil {
+		return err
+	}
+	dui := c.G().UI.GetDumbOutputUI()
+	if len(log.Entries) == 0 {
+		dui.Printf("No bulk deletions in %s.\n", c.tlfName)
+		return nil
+	}
+	for _, e := range log.Entries {
+		var filters []string
+		if len(e.Senders) > 0 {
+			filters = append(filters, "from "+strings.Join(e.Senders, ", "))
+		}
+		if e.After != nil {
+			filters = append(filters, "after "+e.After.Time().Format("2006-01-02 15:04"))
+		}
+		if e.Before != nil {
+			filters = append(filters, "before "+e.Before.Time().Format("2006-01-02 15:04"))
+		}
+		if len(e.MessageTypes) > 0 {
+			var types []string
+			for _, typ := range e.MessageTypes {
+				types = append(types, strings.ToLower(typ.String()))
+			}
+			filters = append(filters, "only "+strings.Join(types, ", "))
+		}
+		dui.Printf("%s\t%s deleted %d messages in #%s (%s)\n",
+			e.Ctime.Time().Format("2006-01-02 15:04"), e.Admin, e.NumDeleted,
+			e.Channel, strings.Join(filters, "; "))
+	}
+	return nil
+}
+
+func (c *CmdChatModerationLog) ParseArgv(ctx *cli.Context) (err error) {
+	if len(ctx.Args()) != 1 {
+		return BadArgsError{"Expected exactly one arg"}
+	}
+	c.tlfName = ctx.Args().Get(0)
+	return nil
+}
+
+func (c *CmdChatModerationLog) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+		API:    true,
+	}
+}
@@ -0,0 +1,179 @@
+package client
+
+import (
+	"strings"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	context "golang.org/x/net/context"
+)
+
+type CmdChatNewMemberRestrictions struct {
+	libkb.Contextified
+	tlfName      string
+	restrictions *chat1.NewMemberRestrictions
+	exempt       []string
+	unexempt     []string
+}
+
+func NewCmdChatNewMemberRestrictionsRunner(g *libkb.GlobalContext) *CmdChatNewMemberRestrictions {
+	return &CmdChatNewMemberRestrictions{
+		Contextified: libkb.NewContextified(g),
+	}
+}
+
+func newCmdChatNewMemberRestrictions(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "new-member-restrictions",
+		Usage:        "Set or get the posting restrictions on new members of a team",
+		ArgumentHelp: "<team>",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(NewCmdChatNewMemberRestrictionsRunner(g), "new-member-restrictions", c)
+			cl.SetNoStandalone()
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+		Flags: []cli.Flag{
+			cli.IntFlag{
+				Name:  "hours",
+				Usage: "How many hours after joining a member counts as new. 0 turns restrictions off.",
+			},
+			cli.IntFlag{
+				Name:  "max-per-hour",
+				Usage: "How many messages a new member can post per hour. 0 means no limit.",
+			},
+			cli.IntFlag{
+				Name:  "block-links-hours",
+				Usage: "How many hours after joining a member can't post links or attachments.",
+			},
+			cli.StringSliceFlag{
+				Name:  "exempt",
+				Usage: "Exempt a user from the restrictions. Can be specified multiple times.",
+			},
+			cli.StringSliceFlag{
+				Name:  "unexempt",
+				Usage: "Stop exempting a user from the restrictions. Can be specified multiple times.",
+			},
+		},
+		Description: `Team admins can restrict what members of a team who joined recently
+   can post, to slow down spam in open teams. Admins, bots and exempt users
+   aren't restricted. Without any flags, shows the current restrictions.
+
+   EXAMPLE:
+
+   keybase chat new-member-restrictions acme --hours 24 --max-per-hour 10 --block-links-hours 6`,
+	}
+}
+
+func (c *CmdChatNewMemberRestrictions) Run() (err error) {
+	chatClient, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return err
+	}
+	cli, err := GetTeamsClient(c.G())
+	if err != nil {
+		return err
+	}
+	teamID, err := cli.GetTeamID(context.Background(), c.tlfName)
+	if err != nil {
+		return err
+	}
+
+	if c.restrictions != nil {
+		current, err := chatClient.GetNewMemberRestrictions(context.TODO(), teamID)
+		if err != nil {
+			return err
+		}
+		c.restrictions.ExemptUIDs = current.ExemptUIDs
+		err = chatClient.SetNewMemberRestrictions(context.TODO(), chat1.SetNewMemberRestrictionsArg{
+			TeamID:       teamID,
+			Restrictions: *c.restrictions,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	for _, username := range c.exempt {
+		err = chatClient.SetNewMemberRestrictionsExempt(context.TODO(), chat1.SetNewMemberRestrictionsExemptArg{
+			TeamID:   teamID,
+			Username: username,
+			Exempt:   true,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	for _, username := range c.unexempt {
+		err = chatClient.SetNewMemberRestrictionsExempt(context.TODO(), chat1.SetNewMemberRestrictionsExemptArg{
+			TeamID:   teamID,
+			Username: username,
+			Exempt:   false,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	r, err := chatClient.GetNewMemberRestrictions(context.TODO(), teamID)
+	if err != nil {
+		return err
+	}
+	dui := c.G().UI.GetDumbOutputUI()
+	if r.NewMemberHours == 0 {
+		dui.Printf("New members of %s are not restricted.\n", c.tlfName)
+	} else {
+		dui.Printf("For their first %d hours, new members of %s:\n", r.NewMemberHours, c.tlfName)
+		if r.MaxMessagesPerHour > 0 {
+			dui.Printf("\tcan post at most %d messages an hour\n", r.MaxMessagesPerHour)
+		}
+		if r.BlockLinksAndAttachmentsHours > 0 {
+			dui.Printf("\tcan't post links or attachments for %d hours\n", r.BlockLinksAndAttachmentsHours)
+		}
+	}
+	if len(r.ExemptUIDs) > 0 {
+		userClient, err := GetUserClient(c.G())
+		if err != nil {
+			return err
+		}
+		var names []string
+		for _, uid := range r.ExemptUIDs {
+			user, err := userClient.LoadUser(context.TODO(), keybase1.LoadUserArg{Uid: uid})
+			if err != nil {
+				names = append(names, uid.String())
+				continue
+			}
+			names = append(names, user.Username)
+		}
+		dui.Printf("Exempt: %s\n", strings.Join(names, ", "))
+	}
+	return nil
+}
+
+func (c *CmdChatNewMemberRestrictions) ParseArgv(ctx *cli.Context) (err error) {
+	if len(ctx.Args()) != 1 {
+		return BadArgsError{"Expected exactly one arg"}
+	}
+	c.tlfName = ctx.Args().Get(0)
+	if ctx.IsSet("hours") || ctx.IsSet("max-per-hour") || ctx.IsSet("block-links-hours") {
+		if !ctx.IsSet("hours") {
+			return BadArgsError{"--hours is required to change the restrictions"}
+		}
+		c.restrictions = &chat1.NewMemberRestrictions{
+			NewMemberHours:                ctx.Int("hours"),
+			MaxMessagesPerHour:            ctx.Int("max-per-hour"),
+			BlockLinksAndAttachmentsHours: ctx.Int("block-links-hours"),
+		}
+	}
+	c.exempt = ctx.StringSlice("exempt")
+	c.unexempt = ctx.StringSlice("unexempt")
+	return nil
+}
+
+func (c *CmdChatNewMemberRestrictions) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+		API:    true,
+	}
+}
@@ -0,0 +1,256 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	context "golang.org/x/net/context"
+)
+
+var notificationRoutingWeekdays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+type CmdChatNotificationRouting struct {
+	libkb.Contextified
+	device      string
+	windows     []chat1.NotificationRoutingWindow
+	always      string
+	timeZone    string
+	setTimeZone bool
+}
+
+func NewCmdChatNotificationRoutingRunner(g *libkb.GlobalContext) *CmdChatNotificationRouting {
+	return &CmdChatNotificationRouting{
+		Contextified: libkb.NewContextified(g),
+	}
+}
+
+func newCmdChatNotificationRouting(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:  "notification-routing",
+		Usage: "Choose when each of your devices gets chat notifications",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(NewCmdChatNotificationRoutingRunner(g), "notification-routing", c)
+			cl.SetNoStandalone()
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "device",
+				Usage: "Name of the device to only notify during the given --window times.",
+			},
+			cli.StringSliceFlag{
+				Name: "window",
+				Usage: `A time of day to notify --device, like 09:00-17:00. Can be specified
+	multiple times.`,
+			},
+			cli.StringFlag{
+				Name:  "days",
+				Usage: "Days the windows apply on, like mon,tue,wed (default every day).",
+			},
+			cli.StringFlag{
+				Name:  "always",
+				Usage: "Name of a device to always notify again.",
+			},
+			cli.StringFlag{
+				Name:  "time-zone",
+				Usage: `Time zone for the windows, like America/New_York (default each device's own).`,
+			},
+		},
+		Description: `Notification routing preferences are shared by all of your devices. A
+   device only gets chat notifications during its windows; devices you
+   haven't set windows for always get them.
+
+   EXAMPLES:
+
+   Only notify your phone in the evening:
+
+       keybase chat notification-routing --device phone --window 18:00-23:00
+
+   Only notify your laptop during work hours:
+
+       keybase chat notification-routing --device laptop --window 09:00-17:00 --days mon,tue,wed,thu,fri`,
+	}
+}
+
+func parseNotificationRoutingTime(s string) (int, error) {
+	var hour, minute int
+	if _, err := fmt.Sscanf(s, "%d:%d", &hour, &minute); err != nil {
+		return 0, fmt.Errorf("invalid time %q; use HH:MM", s)
+	}
+	if hour < 0 || hour > 24 || minute < 0 || minute > 59 || (hour == 24 && minute != 0) {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	return hour*60 + minute, nil
+}
+
+func formatNotificationRoutingTime(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+func (c *CmdChatNotificationRouting) lookupDevice(devices []keybase1.Device, name string) (keybase1.Device, error) {
+	for _, device := range devices {
+		if strings.EqualFold(device.Name, name) {
+			return device, nil
+		}
+	}
+	return keybase1.Device{}, fmt.Errorf("no device named %q", name)
+}
+
+func (c *CmdChatNotificationRouting) Run() (err error) {
+	chatClient, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return err
+	}
+	deviceClient, err := GetDeviceClient(c.G())
+	if err != nil {
+		return err
+	}
+	devices, err := deviceClient.DeviceList(context.TODO(), 0)
+	if err != nil {
+		return err
+	}
+	prefs, err := chatClient.GetNotificationRoutingPreferences(context.TODO())
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	for _, name := range []string{c.device, c.always} {
+		if len(name) == 0 {
+			continue
+		}
+		device, err := c.lookupDevice(devices, name)
+		if err != nil {
+			return err
+		}
+		var rules []chat1.NotificationRoutingRule
+		for _, rule := range prefs.Rules {
+			if !rule.DeviceID.Eq(device.DeviceID) {
+				rules = append(rules, rule)
+			}
+		}
+		if name == c.device {
+			rules = append(rules, chat1.NotificationRoutingRule{
+				DeviceID: device.DeviceID,
+				Windows:  c.windows,
+			})
+		}
+		prefs.Rules = rules
+		changed = true
+	}
+	if c.setTimeZone {
+		prefs.TimeZone = c.timeZone
+		changed = true
+	}
+	if changed {
+		if err := chatClient.SetNotificationRoutingPreferences(context.TODO(), prefs); err != nil {
+			return err
+		}
+	}
+
+	dui := c.G().UI.GetDumbOutputUI()
+	if len(prefs.Rules) == 0 {
+		dui.Printf("All of your devices get notifications.\n")
+		return nil
+	}
+	timeZone := prefs.TimeZone
+	if len(timeZone) == 0 {
+		timeZone = "each device's local time"
+	}
+	dui.Printf("Notification windows (%s):\n", timeZone)
+	for _, rule := range prefs.Rules {
+		name := rule.DeviceID.String()
+		for _, device := range devices {
+			if device.DeviceID.Eq(rule.DeviceID) {
+				name = device.Name
+				break
+			}
+		}
+		var windows []string
+		for _, window := range rule.Windows {
+			s := formatNotificationRoutingTime(window.StartMinute) + "-" +
+				formatNotificationRoutingTime(window.EndMinute)
+			if len(window.Weekdays) > 0 {
+				var days []string
+				for _, day := range window.Weekdays {
+					days = append(days, notificationRoutingWeekdays[day])
+				}
+				s += " " + strings.Join(days, ",")
+			}
+			windows = append(windows, s)
+		}
+		if len(windows) == 0 {
+			windows = append(windows, "never")
+		}
+		dui.Printf("\t%s: %s\n", name, strings.Join(windows, "; "))
+	}
+	return nil
+}
+
+func (c *CmdChatNotificationRouting) ParseArgv(ctx *cli.Context) (err error) {
+	if len(ctx.Args()) != 0 {
+		return BadArgsError{"notification-routing takes no arguments"}
+	}
+	c.device = ctx.String("device")
+	c.always = ctx.String("always")
+	c.timeZone = ctx.String("time-zone")
+	c.setTimeZone = ctx.IsSet("time-zone")
+	if len(c.device) > 0 && strings.EqualFold(c.device, c.always) {
+		return BadArgsError{"--device and --always can't name the same device"}
+	}
+
+	var weekdays []int
+	if days := ctx.String("days"); len(days) > 0 {
+		for _, day := range strings.Split(days, ",") {
+			found := false
+			for i, name := range notificationRoutingWeekdays {
+				if strings.EqualFold(strings.TrimSpace(day), name) {
+					weekdays = append(weekdays, i)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return BadArgsError{fmt.Sprintf("unknown day %q", day)}
+			}
+		}
+	}
+	for _, w := range ctx.StringSlice("window") {
+		parts := strings.SplitN(w, "-", 2)
+		if len(parts) != 2 {
+			return BadArgsError{fmt.Sprintf("window %q must be of the form HH:MM-HH:MM", w)}
+		}
+		start, err := parseNotificationRoutingTime(parts[0])
+		if err != nil {
+			return BadArgsError{err.Error()}
+		}
+		end, err := parseNotificationRoutingTime(parts[1])
+		if err != nil {
+			return BadArgsError{err.Error()}
+		}
+		c.windows = append(c.windows, chat1.NotificationRoutingWindow{
+			StartMinute: start % (24 * 60),
+			EndMinute:   end,
+			Weekdays:    weekdays,
+		})
+	}
+	if len(c.device) > 0 && len(c.windows) == 0 {
+		return BadArgsError{"--device needs at least one --window"}
+	}
+	if len(c.device) == 0 && (len(c.windows) > 0 || len(weekdays) > 0) {
+		return BadArgsError{"--window and --days need a --device"}
+	}
+	return nil
+}
+
+func (c *CmdChatNotificationRouting) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+		API:    true,
+	}
+}
@@ -0,0 +1,190 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"golang.org/x/net/context"
+)
+
+type CmdChatPoll struct {
+	libkb.Contextified
+	resolvingRequest chatConversationResolvingRequest
+	question         string
+	options          []string
+	multiSelect      bool
+	vote             chat1.MessageID
+	choices          []int
+	results          chat1.MessageID
+	json             bool
+}
+
+func NewCmdChatPollRunner(g *libkb.GlobalContext) *CmdChatPoll {
+	return &CmdChatPoll{
+		Contextified: libkb.NewContextified(g),
+	}
+}
+
+func newCmdChatPoll(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "poll",
+		Usage:        "Create a poll, vote on one, or see its results",
+		ArgumentHelp: "<conversation> [<question> <option>...] [--vote=<poll> --choice=<n>...] [--results=<poll>]",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(NewCmdChatPollRunner(g), "poll", c)
+			cl.SetNoStandalone()
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+		Flags: append(getConversationResolverFlags(), []cli.Flag{
+			cli.BoolFlag{
+				Name:  "multi",
+				Usage: "Let voters pick more than one option",
+			},
+			cli.IntFlag{
+				Name:  "vote",
+				Usage: "Vote on the poll with this message ID",
+			},
+			cli.IntSliceFlag{
+				Name:  "choice",
+				Usage: "Option to vote for, numbered from 1; repeat for more than one. Without any, takes back your vote",
+				Value: &cli.IntSlice{},
+			},
+			cli.IntFlag{
+				Name:  "results",
+				Usage: "Show the results of the poll with this message ID",
+			},
+			cli.BoolFlag{
+				Name:  "j, json",
+				Usage: "Output results as JSON",
+			},
+		}...),
+		Description: `Polls are chat messages with a question and numbered options. Votes are
+   kept as reactions on the poll, so they sync like any other reaction, and
+   voting again replaces your earlier vote. Polls show their message ID in
+   'keybase chat read'.
+
+   EXAMPLES:
+
+   keybase chat poll acme --channel lunch "Where to?" tacos pho salad
+   keybase chat poll acme --channel lunch --vote 1234 --choice 2
+   keybase chat poll acme --channel lunch --results 1234`,
+	}
+}
+
+func (c *CmdChatPoll) ParseArgv(ctx *cli.Context) (err error) {
+	if len(ctx.Args()) < 1 {
+		return BadArgsError{"Expected a conversation"}
+	}
+	if c.resolvingRequest, err = parseConversationResolvingRequest(ctx, ctx.Args().Get(0)); err != nil {
+		return err
+	}
+	c.vote = chat1.MessageID(ctx.Int("vote"))
+	c.results = chat1.MessageID(ctx.Int("results"))
+	c.json = ctx.Bool("json")
+	c.multiSelect = ctx.Bool("multi")
+	rest := ctx.Args()[1:]
+	switch {
+	case c.vote > 0 && c.results > 0:
+		return BadArgsError{"Only one of --vote and --results can be given"}
+	case c.vote > 0 || c.results > 0:
+		if len(rest) > 0 {
+			return BadArgsError{"A question and options are only for creating a poll"}
+		}
+		for _, choice := range ctx.IntSlice("choice") {
+			if choice < 1 {
+				return BadArgsError{fmt.Sprintf("invalid choice %d; options are numbered from 1", choice)}
+			}
+			c.choices = append(c.choices, choice-1)
+		}
+	default:
+		if len(rest) < 3 {
+			return BadArgsError{"Expected a question and at least two options"}
+		}
+		c.question = rest[0]
+		c.options = rest[1:]
+	}
+	return nil
+}
+
+func (c *CmdChatPoll) Run() (err error) {
+	ctx := context.TODO()
+	if err := annotateResolvingRequest(c.G(), &c.resolvingRequest); err != nil {
+		return err
+	}
+	resolver, err := newChatConversationResolver(c.G())
+	if err != nil {
+		return err
+	}
+	conv, _, err := resolver.Resolve(ctx, c.resolvingRequest, chatConversationResolvingBehavior{
+		CreateIfNotExists: false,
+		Interactive:       false,
+		IdentifyBehavior:  keybase1.TLFIdentifyBehavior_CHAT_CLI,
+	})
+	if err != nil {
+		return err
+	}
+
+	dui := c.G().UI.GetDumbOutputUI()
+	switch {
+	case c.vote > 0:
+		return resolver.ChatClient.VotePoll(ctx, chat1.VotePollArg{
+			ConvID:           conv.GetConvID(),
+			MsgID:            c.vote,
+			Options:          c.choices,
+			IdentifyBehavior: keybase1.TLFIdentifyBehavior_CHAT_CLI,
+		})
+	case c.results > 0:
+		res, err := resolver.ChatClient.GetPollResults(ctx, chat1.GetPollResultsArg{
+			ConvID:           conv.GetConvID(),
+			MsgID:            c.results,
+			IdentifyBehavior: keybase1.TLFIdentifyBehavior_CHAT_CLI,
+		})
+		if err != nil {
+			return err
+		}
+		if c.json {
+			b, err := json.MarshalIndent(res, "", "    ")
+			if err != nil {
+				return err
+			}
+			dui.Printf("%s\n", b)
+			return nil
+		}
+		dui.Printf("%s\n", res.Question)
+		for _, option := range res.Options {
+			dui.Printf("  %d. %s: %d", option.Index+1, option.Option, len(option.Voters))
+			if len(option.Voters) > 0 {
+				dui.Printf(" (%s)", strings.Join(option.Voters, ", "))
+			}
+			dui.Printf("\n")
+		}
+		dui.Printf("%d voted\n", res.TotalVoters)
+		return nil
+	default:
+		res, err := resolver.ChatClient.PostPoll(ctx, chat1.PostPollArg{
+			ConvID:           conv.GetConvID(),
+			Question:         c.question,
+			Options:          c.options,
+			MultiSelect:      c.multiSelect,
+			IdentifyBehavior: keybase1.TLFIdentifyBehavior_CHAT_CLI,
+		})
+		if err != nil {
+			return err
+		}
+		dui.Printf("Posted poll %d.\n", res.MessageID)
+		return nil
+	}
+}
+
+func (c *CmdChatPoll) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+		API:    true,
+	}
+}
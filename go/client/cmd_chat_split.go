@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/chat/utils"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/keybase/go-framed-msgpack-rpc/rpc"
+)
+
+type CmdChatSplit struct {
+	libkb.Contextified
+	resolvingRequest chatConversationResolvingRequest
+	afterMsgID       chat1.MessageID
+	topicName        string
+	dryRun           bool
+	deleteOriginals  bool
+}
+
+func newCmdChatSplit(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "split",
+		Usage:        "Move all messages after a given message into a new channel",
+		ArgumentHelp: "<team> <message-id> <new-channel> [--channel=<source-channel>]",
+		Action: func(c *cli.Context) {
+			cmd := &CmdChatSplit{Contextified: libkb.NewContextified(g)}
+			cl.ChooseCommand(cmd, "split", c)
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+		Flags: append(getConversationResolverFlags(), []cli.Flag{
+			cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Report which messages would be moved without changing anything",
+			},
+			cli.BoolFlag{
+				Name:  "delete-originals",
+				Usage: "Delete the messages from the source channel once they are copied (admins only)",
+			},
+		}...),
+	}
+}
+
+func (c *CmdChatSplit) ParseArgv(ctx *cli.Context) (err error) {
+	if len(ctx.Args()) != 3 {
+		return fmt.Errorf("must specify a team, a message id, and a name for the new channel")
+	}
+	if c.resolvingRequest, err = parseConversationResolvingRequest(ctx, ctx.Args()[0]); err != nil {
+		return err
+	}
+	id, err := strconv.ParseUint(ctx.Args()[1], 10, 64)
+	if err != nil {
+		return err
+	}
+	c.afterMsgID = chat1.MessageID(id)
+	c.topicName = utils.SanitizeTopicName(ctx.Args()[2])
+	c.dryRun = ctx.Bool("dry-run")
+	c.deleteOriginals = ctx.Bool("delete-originals")
+	return nil
+}
+
+func (c *CmdChatSplit) Run() error {
+	ctx := context.Background()
+	protocols := []rpc.Protocol{
+		chat1.NotifyChatProtocol(NewChatCLINotifications(c.G())),
+	}
+	if err := RegisterProtocolsWithContext(protocols, c.G()); err != nil {
+		return err
+	}
+	notifyCli, err := GetNotifyCtlClient(c.G())
+	if err != nil {
+		return err
+	}
+	if err := notifyCli.SetNotifications(ctx, keybase1.NotificationChannels{Chat: true}); err != nil {
+		return err
+	}
+
+	if err := annotateResolvingRequest(c.G(), &c.resolvingRequest); err != nil {
+		return err
+	}
+	if c.resolvingRequest.MembersType != chat1.ConversationMembersType_TEAM {
+		return fmt.Errorf("only team conversations can be split")
+	}
+	resolver, err := newChatConversationResolver(c.G())
+	if err != nil {
+		return err
+	}
+	conv, _, err := resolver.Resolve(ctx, c.resolvingRequest, chatConversationResolvingBehavior{
+		CreateIfNotExists: false,
+		Interactive:       false,
+		IdentifyBehavior:  keybase1.TLFIdentifyBehavior_CHAT_CLI,
+	})
+	if err != nil {
+		return err
+	}
+	res, err := resolver.ChatClient.SplitConversationLocal(ctx, chat1.SplitConversationLocalArg{
+		ConvID:           conv.GetConvID(),
+		AfterMsgID:       c.afterMsgID,
+		TopicName:        c.topicName,
+		DryRun:           c.dryRun,
+		DeleteOriginals:  c.deleteOriginals,
+		IdentifyBehavior: keybase1.TLFIdentifyBehavior_CHAT_CLI,
+	})
+	if err != nil {
+		return err
+	}
+
+	ui := c.G().UI.GetTerminalUI()
+	verb := "Moved"
+	if c.dryRun {
+		verb = "Would move"
+	}
+	ui.Printf("%s %d messages (%d through %d) to #%s", verb, res.MessagesMoved,
+		res.FirstMsgID, res.LastMsgID, c.topicName)
+	if res.MessagesSkipped > 0 {
+		ui.Printf(", skipping %d that can't be reposted", res.MessagesSkipped)
+	}
+	ui.Printf("\n")
+	return nil
+}
+
+func (c *CmdChatSplit) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		API:       true,
+		KbKeyring: true,
+		Config:    true,
+	}
+}
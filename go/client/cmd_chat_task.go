@@ -0,0 +1,244 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"golang.org/x/net/context"
+)
+
+type CmdChatTask struct {
+	libkb.Contextified
+	resolvingRequest chatConversationResolvingRequest
+	title            string
+	assignee         string
+	unassign         bool
+	task             chat1.MessageID
+	done             chat1.MessageID
+	reopen           chat1.MessageID
+	list             bool
+	allChannels      bool
+	json             bool
+}
+
+func NewCmdChatTaskRunner(g *libkb.GlobalContext) *CmdChatTask {
+	return &CmdChatTask{
+		Contextified: libkb.NewContextified(g),
+	}
+}
+
+func newCmdChatTask(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "task",
+		Usage:        "Create, assign or finish a task, or list open tasks",
+		ArgumentHelp: "<conversation> [<title>] [--assign=<user>] [--task=<task>] [--done=<task>] [--reopen=<task>] [--list]",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(NewCmdChatTaskRunner(g), "task", c)
+			cl.SetNoStandalone()
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+		Flags: append(getConversationResolverFlags(), []cli.Flag{
+			cli.StringFlag{
+				Name:  "assign",
+				Usage: "Assign the new task, or the one given by --task, to this member",
+			},
+			cli.BoolFlag{
+				Name:  "unassign",
+				Usage: "Unassign the task given by --task",
+			},
+			cli.IntFlag{
+				Name:  "task",
+				Usage: "Message ID of the task to assign",
+			},
+			cli.IntFlag{
+				Name:  "done",
+				Usage: "Mark the task with this message ID done",
+			},
+			cli.IntFlag{
+				Name:  "reopen",
+				Usage: "Mark the task with this message ID not done",
+			},
+			cli.BoolFlag{
+				Name:  "list",
+				Usage: "List the open tasks in the conversation",
+			},
+			cli.BoolFlag{
+				Name:  "all-channels",
+				Usage: "With --list, list the open tasks in every channel of the team you're in",
+			},
+			cli.BoolFlag{
+				Name:  "j, json",
+				Usage: "With --list, output the tasks as JSON",
+			},
+		}...),
+		Description: `Tasks are chat messages with a title that can be assigned to a member of
+   the conversation and marked done. Assignments and completion are kept as
+   reactions on the task, so they sync like any other reaction; the latest
+   change wins, whoever made it. Tasks show their message ID in 'keybase
+   chat read'.
+
+   EXAMPLES:
+
+   keybase chat task acme --channel ops "Rotate the TLS certs" --assign alice
+   keybase chat task acme --channel ops --task 1234 --assign bob
+   keybase chat task acme --channel ops --done 1234
+   keybase chat task acme --list --all-channels`,
+	}
+}
+
+func (c *CmdChatTask) ParseArgv(ctx *cli.Context) (err error) {
+	if len(ctx.Args()) < 1 {
+		return BadArgsError{"Expected a conversation"}
+	}
+	if c.resolvingRequest, err = parseConversationResolvingRequest(ctx, ctx.Args().Get(0)); err != nil {
+		return err
+	}
+	c.assignee = ctx.String("assign")
+	c.unassign = ctx.Bool("unassign")
+	c.task = chat1.MessageID(ctx.Int("task"))
+	c.done = chat1.MessageID(ctx.Int("done"))
+	c.reopen = chat1.MessageID(ctx.Int("reopen"))
+	c.list = ctx.Bool("list")
+	c.allChannels = ctx.Bool("all-channels")
+	c.json = ctx.Bool("json")
+	c.title = strings.Join(ctx.Args()[1:], " ")
+
+	actions := 0
+	for _, set := range []bool{len(c.title) > 0, c.task > 0, c.done > 0, c.reopen > 0, c.list} {
+		if set {
+			actions++
+		}
+	}
+	switch {
+	case actions == 0:
+		return BadArgsError{"Expected a title, --task, --done, --reopen or --list"}
+	case actions > 1:
+		return BadArgsError{"Only one of a title, --task, --done, --reopen and --list can be given"}
+	case c.task > 0 && len(c.assignee) == 0 && !c.unassign:
+		return BadArgsError{"--task needs --assign or --unassign"}
+	case c.unassign && (c.task == 0 || len(c.assignee) > 0):
+		return BadArgsError{"--unassign only goes with --task"}
+	case len(c.assignee) > 0 && len(c.title) == 0 && c.task == 0:
+		return BadArgsError{"--assign only goes with a title or --task"}
+	case (c.allChannels || c.json) && !c.list:
+		return BadArgsError{"--all-channels and --json only go with --list"}
+	}
+	return nil
+}
+
+func (c *CmdChatTask) Run() (err error) {
+	ctx := context.TODO()
+	if err := annotateResolvingRequest(c.G(), &c.resolvingRequest); err != nil {
+		return err
+	}
+	resolver, err := newChatConversationResolver(c.G())
+	if err != nil {
+		return err
+	}
+	conv, _, err := resolver.Resolve(ctx, c.resolvingRequest, chatConversationResolvingBehavior{
+		CreateIfNotExists: false,
+		Interactive:       false,
+		IdentifyBehavior:  keybase1.TLFIdentifyBehavior_CHAT_CLI,
+	})
+	if err != nil {
+		return err
+	}
+
+	dui := c.G().UI.GetDumbOutputUI()
+	switch {
+	case c.task > 0:
+		return resolver.ChatClient.AssignTask(ctx, chat1.AssignTaskArg{
+			ConvID:           conv.GetConvID(),
+			MsgID:            c.task,
+			Assignee:         c.assignee,
+			IdentifyBehavior: keybase1.TLFIdentifyBehavior_CHAT_CLI,
+		})
+	case c.done > 0 || c.reopen > 0:
+		msgID := c.done
+		if c.reopen > 0 {
+			msgID = c.reopen
+		}
+		return resolver.ChatClient.SetTaskDone(ctx, chat1.SetTaskDoneArg{
+			ConvID:           conv.GetConvID(),
+			MsgID:            msgID,
+			Done:             c.done > 0,
+			IdentifyBehavior: keybase1.TLFIdentifyBehavior_CHAT_CLI,
+		})
+	case c.list:
+		var tasks []chat1.TaskInfo
+		if c.allChannels {
+			if conv.GetMembersType() != chat1.ConversationMembersType_TEAM {
+				return fmt.Errorf("--all-channels only works in team conversations")
+			}
+			teamID, err := keybase1.TeamIDFromString(conv.Info.Triple.Tlfid.String())
+			if err != nil {
+				return err
+			}
+			tasks, err = resolver.ChatClient.ListOpenTeamTasks(ctx, teamID)
+			if err != nil {
+				return err
+			}
+		} else {
+			tasks, err = resolver.ChatClient.ListOpenTasks(ctx, chat1.ListOpenTasksArg{
+				ConvID:           conv.GetConvID(),
+				IdentifyBehavior: keybase1.TLFIdentifyBehavior_CHAT_CLI,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if c.json {
+			if tasks == nil {
+				tasks = []chat1.TaskInfo{}
+			}
+			b, err := json.MarshalIndent(tasks, "", "    ")
+			if err != nil {
+				return err
+			}
+			dui.Printf("%s\n", b)
+			return nil
+		}
+		if len(tasks) == 0 {
+			dui.Printf("No open tasks.\n")
+			return nil
+		}
+		for _, task := range tasks {
+			where := ""
+			if c.allChannels {
+				where = fmt.Sprintf("#%s ", task.Channel)
+			}
+			assignee := "unassigned"
+			if len(task.Assignee) > 0 {
+				assignee = "@" + task.Assignee
+			}
+			dui.Printf("%s%d\t%s (%s, from %s)\n", where, task.MsgID, task.Title, assignee,
+				task.Creator)
+		}
+		return nil
+	default:
+		res, err := resolver.ChatClient.PostTask(ctx, chat1.PostTaskArg{
+			ConvID:           conv.GetConvID(),
+			Title:            c.title,
+			Assignee:         c.assignee,
+			IdentifyBehavior: keybase1.TLFIdentifyBehavior_CHAT_CLI,
+		})
+		if err != nil {
+			return err
+		}
+		dui.Printf("Posted task %d.\n", res.MessageID)
+		return nil
+	}
+}
+
+func (c *CmdChatTask) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+		API:    true,
+	}
+}
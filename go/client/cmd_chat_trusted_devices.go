@@ -0,0 +1,174 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"golang.org/x/net/context"
+)
+
+type CmdChatTrustedDevices struct {
+	libkb.Contextified
+	resolvingRequest chatConversationResolvingRequest
+	minAgeDays       *int
+	approveUser      string
+	approve          bool
+	deviceName       string
+}
+
+func NewCmdChatTrustedDevicesRunner(g *libkb.GlobalContext) *CmdChatTrustedDevices {
+	return &CmdChatTrustedDevices{
+		Contextified: libkb.NewContextified(g),
+	}
+}
+
+func newCmdChatTrustedDevices(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "trusted-devices",
+		Usage:        "Only let devices that have been around for a while post in a team channel",
+		ArgumentHelp: "<conversation> [--min-age-days=<days>] [--off] [--approve|--unapprove <username> --device <name>]",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(NewCmdChatTrustedDevicesRunner(g), "trusted-devices", c)
+			cl.SetNoStandalone()
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+		Flags: append(getConversationResolverFlags(), []cli.Flag{
+			cli.IntFlag{
+				Name:  "min-age-days",
+				Usage: "Only let devices provisioned at least this many days ago post",
+			},
+			cli.BoolFlag{
+				Name:  "off",
+				Usage: "Remove the channel's policy",
+			},
+			cli.StringFlag{
+				Name:  "approve",
+				Usage: "Let this user's --device post whatever its age",
+			},
+			cli.StringFlag{
+				Name:  "unapprove",
+				Usage: "Take back an approval given with --approve",
+			},
+			cli.StringFlag{
+				Name:  "device",
+				Usage: "The device to approve or unapprove",
+			},
+		}...),
+		Description: `Team admins can keep devices provisioned in the last few days from
+   posting in a sensitive channel, so a device someone added with a stolen
+   paper key or password can't post there right away. Admins can approve
+   devices to post before they're old enough. The policy can only be changed
+   from a device it already lets post. Without any flags, shows the channel's
+   policy.
+
+   EXAMPLE:
+
+   keybase chat trusted-devices acme --channel releases --min-age-days 7`,
+	}
+}
+
+func (c *CmdChatTrustedDevices) ParseArgv(ctx *cli.Context) (err error) {
+	if len(ctx.Args()) != 1 {
+		return BadArgsError{"Expected exactly one conversation"}
+	}
+	if c.resolvingRequest, err = parseConversationResolvingRequest(ctx, ctx.Args().Get(0)); err != nil {
+		return err
+	}
+	set := 0
+	if ctx.IsSet("min-age-days") {
+		days := ctx.Int("min-age-days")
+		if days <= 0 {
+			return BadArgsError{"--min-age-days has to be positive; use --off to remove the policy"}
+		}
+		c.minAgeDays = &days
+		set++
+	}
+	if ctx.Bool("off") {
+		off := 0
+		c.minAgeDays = &off
+		set++
+	}
+	if approve := ctx.String("approve"); len(approve) > 0 {
+		c.approveUser, c.approve = approve, true
+		set++
+	}
+	if unapprove := ctx.String("unapprove"); len(unapprove) > 0 {
+		c.approveUser, c.approve = unapprove, false
+		set++
+	}
+	if set > 1 {
+		return BadArgsError{"Only one of --min-age-days, --off, --approve and --unapprove can be given"}
+	}
+	c.deviceName = ctx.String("device")
+	if len(c.approveUser) > 0 && len(c.deviceName) == 0 {
+		return BadArgsError{"--approve and --unapprove need --device"}
+	}
+	return nil
+}
+
+func (c *CmdChatTrustedDevices) Run() (err error) {
+	ctx := context.TODO()
+	if err := annotateResolvingRequest(c.G(), &c.resolvingRequest); err != nil {
+		return err
+	}
+	resolver, err := newChatConversationResolver(c.G())
+	if err != nil {
+		return err
+	}
+	conv, _, err := resolver.Resolve(ctx, c.resolvingRequest, chatConversationResolvingBehavior{
+		CreateIfNotExists: false,
+		Interactive:       false,
+		IdentifyBehavior:  keybase1.TLFIdentifyBehavior_CHAT_CLI,
+	})
+	if err != nil {
+		return err
+	}
+	if conv.GetMembersType() != chat1.ConversationMembersType_TEAM {
+		return fmt.Errorf("trusted device policies only work in team channels")
+	}
+
+	switch {
+	case c.minAgeDays != nil:
+		err = resolver.ChatClient.SetTrustedDevicePolicy(ctx, chat1.SetTrustedDevicePolicyArg{
+			ConvID:           conv.GetConvID(),
+			MinDeviceAgeDays: *c.minAgeDays,
+		})
+	case len(c.approveUser) > 0:
+		err = resolver.ChatClient.SetTrustedDeviceApproved(ctx, chat1.SetTrustedDeviceApprovedArg{
+			ConvID:     conv.GetConvID(),
+			Username:   c.approveUser,
+			DeviceName: c.deviceName,
+			Approved:   c.approve,
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	dui := c.G().UI.GetDumbOutputUI()
+	policy, err := resolver.ChatClient.GetTrustedDevicePolicy(ctx, conv.GetConvID())
+	if err != nil {
+		return err
+	}
+	if policy == nil {
+		dui.Printf("Any device can post in #%s.\n", conv.GetTopicName())
+		return nil
+	}
+	dui.Printf("Only devices provisioned at least %d days ago can post in #%s (set by %s on %s).\n",
+		policy.MinDeviceAgeDays, policy.Channel, policy.SetBy, policy.Ctime.Time().Format("2006-01-02"))
+	for _, approval := range policy.Approved {
+		dui.Printf("  %s's %q is approved by %s\n", approval.Username, approval.DeviceName, approval.ApprovedBy)
+	}
+	return nil
+}
+
+func (c *CmdChatTrustedDevices) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+		API:    true,
+	}
+}
@@ -0,0 +1,485 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"golang.org/x/net/context"
+)
+
+// Shell completion works off the cli command tree itself, so new commands
+// complete without anyone having to touch the scripts. The scripts call
+// back into `keybase completion words` with the words typed so far, which
+// walks the tree to find the command being typed, and offers its
+// subcommands, its flags, or for its arguments, whatever its ArgumentHelp
+// says they are: team names, conversations and archive job IDs come from
+// the service, if it's running.
+
+// How long to wait on the service for names to offer; it's better to offer
+// nothing than to hang the shell.
+const completionServiceTimeout = 3 * time.Second
+
+type completionArgKind int
+
+const (
+	completionArgNone completionArgKind = iota
+	completionArgTeam
+	completionArgConversation
+	completionArgJobID
+)
+
+// completionArgKindsByName maps the argument names used in ArgumentHelp to
+// what can complete them.
+var completionArgKindsByName = map[string]completionArgKind{
+	"team":             completionArgTeam,
+	"team name":        completionArgTeam,
+	"conversation":     completionArgConversation,
+	"src-conversation": completionArgConversation,
+	"dst-conversation": completionArgConversation,
+	"job id":           completionArgJobID,
+	"job-id":           completionArgJobID,
+}
+
+// An argument in ArgumentHelp: "<team name>", "[conversation", or a bare
+// word like "job-id", maybe followed by "...".
+var completionArgRegexp = regexp.MustCompile(`(?:<([^<>]+)>|\[([^<>\[\]]+)|([^\s<>\[\]]+))`)
+
+// completionArgKinds reads the positional arguments a command takes from its
+// ArgumentHelp. If the last one can be repeated, variadic is set.
+func completionArgKinds(argumentHelp string) (kinds []completionArgKind, variadic bool) {
+	for _, match := range completionArgRegexp.FindAllStringSubmatch(argumentHelp, -1) {
+		name := strings.TrimSpace(match[1] + match[2] + match[3])
+		if name == "..." {
+			variadic = len(kinds) > 0
+			continue
+		}
+		if strings.HasPrefix(name, "-") {
+			// Flags come after the positional arguments.
+			break
+		}
+		variadic = strings.HasSuffix(name, "...")
+		name = strings.ToLower(strings.TrimSuffix(name, "..."))
+		kinds = append(kinds, completionArgKindsByName[name])
+	}
+	return kinds, variadic
+}
+
+// completionFlagNames returns the names of f as typed, and whether it takes
+// a value.
+func completionFlagNames(f cli.Flag) (names []string, takesValue bool) {
+	var name string
+	switch f := f.(type) {
+	case cli.BoolFlag:
+		name = f.Name
+	case cli.StringFlag:
+		name, takesValue = f.Name, true
+	case cli.IntFlag:
+		name, takesValue = f.Name, true
+	case cli.DurationFlag:
+		name, takesValue = f.Name, true
+	case cli.StringSliceFlag:
+		name, takesValue = f.Name, true
+	case cli.IntSliceFlag:
+		name, takesValue = f.Name, true
+	default:
+		return nil, false
+	}
+	for _, n := range strings.Split(name, ",") {
+		n = strings.TrimSpace(n)
+		switch len(n) {
+		case 0:
+		case 1:
+			names = append(names, "-"+n)
+		default:
+			names = append(names, "--"+n)
+		}
+	}
+	return names, takesValue
+}
+
+// completionState is where the words typed so far leave off.
+type completionState struct {
+	// The names of the commands typed, from the top.
+	path []string
+	// The command being typed, or nil if none has been yet.
+	cmd         *cli.Command
+	subcommands []cli.Command
+	flags       []cli.Flag
+	// How many of cmd's positional arguments have been typed.
+	numArgs int
+	// The last word typed is a flag that wants a value.
+	wantsFlagValue bool
+}
+
+func completionFindCommand(cmds []cli.Command, name string) *cli.Command {
+	for i := range cmds {
+		if cmds[i].Name == name {
+			return &cmds[i]
+		}
+		for _, alias := range cmds[i].Aliases {
+			if alias == name {
+				return &cmds[i]
+			}
+		}
+	}
+	return nil
+}
+
+// completionWalk follows words, the complete words typed after "keybase",
+// down the command tree.
+func completionWalk(cmds []cli.Command, globalFlags []cli.Flag,
+	words []string) (state completionState) {
+	state.subcommands = cmds
+	state.flags = globalFlags
+	for _, word := range words {
+		if state.wantsFlagValue {
+			state.wantsFlagValue = false
+			continue
+		}
+		if strings.HasPrefix(word, "-") && len(word) > 1 {
+			if strings.Contains(word, "=") {
+				continue
+			}
+			for _, f := range state.flags {
+				names, takesValue := completionFlagNames(f)
+				for _, name := range names {
+					if name == word {
+						state.wantsFlagValue = takesValue
+					}
+				}
+			}
+			continue
+		}
+		if state.numArgs == 0 {
+			if cmd := completionFindCommand(state.subcommands, word); cmd != nil {
+				state.path = append(state.path, cmd.Name)
+				state.cmd = cmd
+				state.subcommands = cmd.Subcommands
+				state.flags = cmd.Flags
+				continue
+			}
+		}
+		state.numArgs++
+	}
+	return state
+}
+
+// argKind returns what the next positional argument of the command is.
+func (s completionState) argKind() completionArgKind {
+	if s.cmd == nil {
+		return completionArgNone
+	}
+	kinds, variadic := completionArgKinds(s.cmd.ArgumentHelp)
+	switch {
+	case s.numArgs < len(kinds):
+		return kinds[s.numArgs]
+	case variadic && len(kinds) > 0:
+		return kinds[len(kinds)-1]
+	default:
+		return completionArgNone
+	}
+}
+
+// candidates returns what cur, the word being typed, could be, getting
+// names for arguments from lookup.
+func (s completionState) candidates(cur string,
+	lookup func(kind completionArgKind, path []string) []string) (res []string) {
+	if s.wantsFlagValue {
+		// Left to the shell's own completion.
+		return nil
+	}
+	if strings.HasPrefix(cur, "-") {
+		for _, f := range s.flags {
+			names, _ := completionFlagNames(f)
+			res = append(res, names...)
+		}
+	} else {
+		if s.numArgs == 0 {
+			for _, cmd := range s.subcommands {
+				res = append(res, cmd.Name)
+			}
+		}
+		if kind := s.argKind(); kind != completionArgNone {
+			res = append(res, lookup(kind, s.path)...)
+		}
+	}
+	matches := res[:0]
+	for _, candidate := range res {
+		if strings.HasPrefix(candidate, cur) {
+			matches = append(matches, candidate)
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+const completionBashScript = `# bash completion for keybase; load it with
+#   source <(keybase completion bash)
+_keybase_completion() {
+    local IFS=$'\n'
+    COMPREPLY=($(keybase completion words -- "${COMP_WORDS[@]:1:$COMP_CWORD}" 2>/dev/null))
+}
+complete -o default -F _keybase_completion keybase
+`
+
+const completionZshScript = `#compdef keybase
+# zsh completion for keybase; load it with
+#   source <(keybase completion zsh)
+_keybase() {
+    local out
+    out=$(keybase completion words -- "${(@)words[2,CURRENT]}" 2>/dev/null)
+    if [[ -n $out ]]; then
+        local -a candidates
+        candidates=("${(@f)out}")
+        compadd -a candidates
+    else
+        _files
+    fi
+}
+compdef _keybase keybase
+`
+
+const completionFishScript = `# fish completion for keybase; load it with
+#   keybase completion fish | source
+function __keybase_complete
+    set -l tokens (commandline -opc)
+    set -e tokens[1]
+    set -l out (keybase completion words -- $tokens (commandline -ct) 2>/dev/null)
+    if test (count $out) -gt 0
+        printf '%s\n' $out
+    else
+        __fish_complete_path (commandline -ct)
+    end
+end
+complete -c keybase -f -a '(__keybase_complete)'
+`
+
+var completionScripts = map[string]string{
+	"bash": completionBashScript,
+	"zsh":  completionZshScript,
+	"fish": completionFishScript,
+}
+
+// CmdCompletion is the 'completion' command.
+type CmdCompletion struct {
+	libkb.Contextified
+	shell string
+}
+
+// NewCmdCompletion creates a new cli.Command.
+func NewCmdCompletion(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "completion",
+		Usage:        "Print a shell completion script",
+		ArgumentHelp: "<bash|zsh|fish>",
+		Description: `Print a script that sets up tab completion of keybase commands in
+   the given shell. Add it to your shell's startup file, e.g.:
+
+     source <(keybase completion bash)
+
+   Team names, conversations and archive job IDs are completed too, while
+   the service is running.`,
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(&CmdCompletion{
+				Contextified: libkb.NewContextified(g)}, "completion", c)
+			cl.SetForkCmd(libcmdline.NoFork)
+			cl.SetLogForward(libcmdline.LogForwardNone)
+			cl.SetSkipOutOfDateCheck()
+		},
+		Subcommands: []cli.Command{
+			newCmdCompletionWords(cl, g),
+		},
+	}
+}
+
+// Run runs the command in client/server mode.
+func (c *CmdCompletion) Run() error {
+	_, err := c.G().UI.GetDumbOutputUI().Printf("%s", completionScripts[c.shell])
+	return err
+}
+
+// ParseArgv parses the arguments.
+func (c *CmdCompletion) ParseArgv(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		return errors.New("completion takes one argument, the shell")
+	}
+	c.shell = ctx.Args()[0]
+	if _, ok := completionScripts[c.shell]; !ok {
+		return fmt.Errorf("unsupported shell %q; must be bash, zsh or fish", c.shell)
+	}
+	return nil
+}
+
+// GetUsage says what this command needs to operate.
+func (c *CmdCompletion) GetUsage() libkb.Usage {
+	return libkb.Usage{}
+}
+
+// CmdCompletionWords is the 'completion words' command, which the
+// completion scripts call.
+type CmdCompletionWords struct {
+	libkb.Contextified
+	cl *libcmdline.CommandLine
+	// The words typed after "keybase", the last of them the one being
+	// completed.
+	words []string
+}
+
+func newCmdCompletionWords(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "words",
+		Usage:        "List the ways to complete a command line (used by the completion scripts)",
+		ArgumentHelp: "-- <word>...",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(&CmdCompletionWords{
+				Contextified: libkb.NewContextified(g),
+				cl:           cl,
+			}, "words", c)
+			cl.SetForkCmd(libcmdline.NoFork)
+			cl.SetLogForward(libcmdline.LogForwardNone)
+			cl.SetSkipOutOfDateCheck()
+		},
+	}
+}
+
+// Run runs the command in client/server mode.
+func (c *CmdCompletionWords) Run() error {
+	var typed []string
+	cur := ""
+	if len(c.words) > 0 {
+		typed, cur = c.words[:len(c.words)-1], c.words[len(c.words)-1]
+	}
+	state := completionWalk(c.cl.GetCommands(), c.cl.GetGlobalFlags(), typed)
+	dui := c.G().UI.GetDumbOutputUI()
+	for _, candidate := range state.candidates(cur, c.lookup) {
+		if _, err := dui.Printf("%s\n", candidate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lookup asks the service for the names an argument of the command at path
+// could take. Any error just means there's nothing to offer.
+func (c *CmdCompletionWords) lookup(kind completionArgKind, path []string) []string {
+	ctx, cancel := context.WithTimeout(context.Background(), completionServiceTimeout)
+	defer cancel()
+	var names []string
+	var err error
+	switch kind {
+	case completionArgTeam:
+		names, err = c.teamNames(ctx)
+	case completionArgConversation:
+		names, err = c.conversationNames(ctx)
+	case completionArgJobID:
+		if len(path) > 0 && path[0] == "chat" {
+			names, err = c.chatArchiveJobIDs(ctx)
+		} else {
+			names, err = c.fsArchiveJobIDs(ctx)
+		}
+	}
+	if err != nil {
+		c.G().Log.Debug("completion lookup error: %v", err)
+		return nil
+	}
+	return names
+}
+
+func (c *CmdCompletionWords) teamNames(ctx context.Context) (names []string, err error) {
+	cli, err := GetTeamsClient(c.G())
+	if err != nil {
+		return nil, err
+	}
+	list, err := cli.TeamListUnverified(ctx, keybase1.TeamListUnverifiedArg{})
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	for _, team := range list.Teams {
+		if !seen[team.FqName] {
+			seen[team.FqName] = true
+			names = append(names, team.FqName)
+		}
+	}
+	return names, nil
+}
+
+func (c *CmdCompletionWords) conversationNames(ctx context.Context) (names []string, err error) {
+	cli, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return nil, err
+	}
+	res, err := cli.GetInboxSummaryForCLILocal(ctx, chat1.GetInboxSummaryForCLILocalQuery{
+		TopicType:  chat1.TopicType_CHAT,
+		Visibility: keybase1.TLFVisibility_ANY,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Team channels are picked with --channel, so only the team's name is
+	// the conversation.
+	seen := make(map[string]bool)
+	for _, conv := range res.Conversations {
+		if !seen[conv.Info.TlfName] {
+			seen[conv.Info.TlfName] = true
+			names = append(names, conv.Info.TlfName)
+		}
+	}
+	return names, nil
+}
+
+func (c *CmdCompletionWords) fsArchiveJobIDs(ctx context.Context) (ids []string, err error) {
+	cli, err := GetSimpleFSClient(c.G())
+	if err != nil {
+		return nil, err
+	}
+	status, err := cli.SimpleFSGetArchiveStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for jobID := range status.Jobs {
+		ids = append(ids, jobID)
+	}
+	return ids, nil
+}
+
+func (c *CmdCompletionWords) chatArchiveJobIDs(ctx context.Context) (ids []string, err error) {
+	cli, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return nil, err
+	}
+	res, err := cli.ArchiveChatList(ctx, keybase1.TLFIdentifyBehavior_CHAT_CLI)
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range res.Jobs {
+		ids = append(ids, string(job.Request.JobID))
+	}
+	return ids, nil
+}
+
+// ParseArgv parses the arguments.
+func (c *CmdCompletionWords) ParseArgv(ctx *cli.Context) error {
+	c.words = ctx.Args()
+	if len(c.words) > 0 && c.words[0] == "--" {
+		c.words = c.words[1:]
+	}
+	return nil
+}
+
+// GetUsage says what this command needs to operate.
+func (c *CmdCompletionWords) GetUsage() libkb.Usage {
+	return libkb.Usage{}
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"testing"
+
+	"github.com/keybase/client/go/externals"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompletionArgKinds(t *testing.T) {
+	for _, test := range []struct {
+		help     string
+		kinds    []completionArgKind
+		variadic bool
+	}{
+		{"<team name> --user=<username>", []completionArgKind{completionArgTeam}, false},
+		{"[team name] [--user=username]", []completionArgKind{completionArgTeam}, false},
+		{"[<conversation>] [-o filename]", []completionArgKind{completionArgConversation}, false},
+		{"[conversation [message]]",
+			[]completionArgKind{completionArgConversation, completionArgNone}, false},
+		{"<job ID>...", []completionArgKind{completionArgJobID}, true},
+		{"job-id", []completionArgKind{completionArgJobID}, false},
+		{"<job ID> <bytes per second, e.g. 1MB>",
+			[]completionArgKind{completionArgJobID, completionArgNone}, false},
+		{"", nil, false},
+	} {
+		kinds, variadic := completionArgKinds(test.help)
+		require.Equal(t, test.kinds, kinds, test.help)
+		require.Equal(t, test.variadic, variadic, test.help)
+	}
+}
+
+func TestCompletionWalk(t *testing.T) {
+	cl := libcmdline.NewCommandLine(true, GetExtraFlags())
+	cl.AddCommands(GetCommands(cl, externals.NewGlobalContextInit()))
+	cmds, globalFlags := cl.GetCommands(), cl.GetGlobalFlags()
+
+	var lookedUp []completionArgKind
+	var lookedUpPath []string
+	lookup := func(kind completionArgKind, path []string) []string {
+		lookedUp = append(lookedUp, kind)
+		lookedUpPath = path
+		switch kind {
+		case completionArgTeam:
+			return []string{"acme", "acme.sub", "beta"}
+		case completionArgJobID:
+			return []string{"kbfs-archive-job-a"}
+		}
+		return nil
+	}
+	complete := func(words ...string) []string {
+		lookedUp, lookedUpPath = nil, nil
+		state := completionWalk(cmds, globalFlags, words[:len(words)-1])
+		return state.candidates(words[len(words)-1], lookup)
+	}
+
+	require.Contains(t, complete("com"), "completion")
+	require.Contains(t, complete("fs", "archive", ""), "retry")
+	require.Contains(t, complete("fs", "archive", "start", "--max-r"), "--max-retries")
+	require.Empty(t, lookedUp)
+
+	// Global flags that take values don't throw off where we are.
+	require.Equal(t, []string{"acme", "acme.sub"},
+		complete("--home", "/tmp/kb", "team", "add-member", "ac"))
+	require.Equal(t, []completionArgKind{completionArgTeam}, lookedUp)
+
+	// A job ID for each argument, with the command found by its alias.
+	require.Equal(t, []string{"kbfs-archive-job-a"},
+		complete("fs", "archive", "cancel", "kbfs-archive-job-b", ""))
+	require.Equal(t, []string{"fs", "archive", "dismiss"}, lookedUpPath)
+
+	// No names for the value of a flag.
+	require.Empty(t, complete("team", "remove-member", "acme", "--user", ""))
+}
@@ -4,10 +4,14 @@
 package client
 
 import (
+	"time"
+
 	"github.com/keybase/cli"
 	"github.com/keybase/client/go/install"
 	"github.com/keybase/client/go/libcmdline"
 	"github.com/keybase/client/go/libkb"
+	keybase1 "github.com/keybase/client/go/protocol/keybase1"
+	"golang.org/x/net/context"
 )
 
 func NewCmdCtl(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
@@ -17,6 +21,7 @@ func NewCmdCtl(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
 		NewCmdCtlReload(cl, g),
 		NewCmdCtlRestart(cl, g),
 		NewCmdCtlLogRotate(cl, g),
+		NewCmdCtlTelemetry(cl, g),
 		NewCmdWatchdog(cl, g),
 		NewCmdCtlAppExit(cl, g),
 		NewCmdWait(cl, g),
@@ -47,3 +52,45 @@ func defaultCtlComponents(enable bool) map[string]bool { //nolint
 	}
 	return components
 }
+
+const defaultCtlDrainTimeout = 2 * time.Minute
+
+// ctlDrainFlags are for draining background jobs before a restart.
+var ctlDrainFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name: "drain",
+		Usage: "Pause long-running background jobs, like chat and KBFS archives, " +
+			"with their progress saved before restarting, so they carry on afterwards.",
+	},
+	cli.DurationFlag{
+		Name:  "drain-timeout",
+		Value: defaultCtlDrainTimeout,
+		Usage: "With --drain, how long to wait for background jobs before restarting anyway.",
+	},
+}
+
+// ctlDrainBackgroundJobs has the service drain its background jobs, and
+// reports how that went. Jobs that didn't drain are stopped the usual way
+// by the restart, so failing to drain doesn't stop it.
+func ctlDrainBackgroundJobs(g *libkb.GlobalContext, timeout time.Duration) {
+	cli, err := GetCtlClient(g)
+	if err != nil {
+		g.Log.Warning("Not draining background jobs: %s", err)
+		return
+	}
+	g.Log.Info("Draining background jobs...")
+	res, err := cli.DrainBackgroundJobs(context.TODO(), keybase1.DrainBackgroundJobsArg{
+		Timeout: keybase1.DurationSec(timeout.Seconds()),
+	})
+	if err != nil {
+		g.Log.Warning("Draining background jobs failed: %s", err)
+		return
+	}
+	for _, r := range res {
+		if r.Drained {
+			g.Log.Info("Drained %s", r.Name)
+		} else {
+			g.Log.Warning("Couldn't drain %s: %s", r.Name, r.Error)
+		}
+	}
+}
@@ -7,6 +7,7 @@
 package client
 
 import (
+	"errors"
 	"time"
 
 	"golang.org/x/net/context"
@@ -21,8 +22,9 @@ func NewCmdCtlRestart(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Co
 	return cli.Command{
 		Name:  "restart",
 		Usage: "Restart the background keybase service",
+		Flags: ctlDrainFlags,
 		Action: func(c *cli.Context) {
-			cl.ChooseCommand(&CmdCtlRestart{libkb.NewContextified(g)}, "restart", c)
+			cl.ChooseCommand(&CmdCtlRestart{Contextified: libkb.NewContextified(g)}, "restart", c)
 			cl.SetForkCmd(libcmdline.NoFork)
 			cl.SetNoStandalone()
 		},
@@ -31,9 +33,16 @@ func NewCmdCtlRestart(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Co
 
 type CmdCtlRestart struct {
 	libkb.Contextified
+	drain        bool
+	drainTimeout time.Duration
 }
 
 func (s *CmdCtlRestart) ParseArgv(ctx *cli.Context) error {
+	s.drain = ctx.Bool("drain")
+	s.drainTimeout = ctx.Duration("drain-timeout")
+	if s.drainTimeout <= 0 {
+		return errors.New("--drain-timeout must be positive")
+	}
 	return nil
 }
 
@@ -47,6 +56,10 @@ func (s *CmdCtlRestart) Run() error {
 		return err
 	}
 
+	if s.drain {
+		ctlDrainBackgroundJobs(s.G(), s.drainTimeout)
+	}
+
 	cli, err := GetCtlClient(s.G())
 	if err != nil {
 		return err
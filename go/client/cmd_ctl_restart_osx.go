@@ -7,9 +7,12 @@
 package client
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/keybase/cli"
+	"github.com/keybase/client/go/install"
 	"github.com/keybase/client/go/libcmdline"
 	"github.com/keybase/client/go/libkb"
 )
@@ -19,7 +22,7 @@ func NewCmdCtlRestart(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Co
 	return cli.Command{
 		Name:  "restart",
 		Usage: "Restart the keybase services",
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			cli.StringFlag{
 				Name:  "include",
 				Usage: fmt.Sprintf("Stop only specified components, comma separated. Specify %v.", availableCtlComponents),
@@ -28,7 +31,7 @@ func NewCmdCtlRestart(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Co
 				Name:  "exclude",
 				Usage: fmt.Sprintf("Stop all except excluded components, comma separated. Specify %v.", availableCtlComponents),
 			},
-		},
+		}, ctlDrainFlags...),
 		Action: func(c *cli.Context) {
 			cl.ChooseCommand(&cmdCtlRestart{Contextified: libkb.NewContextified(g)}, "restart", c)
 			cl.SetForkCmd(libcmdline.NoFork)
@@ -40,15 +43,28 @@ func NewCmdCtlRestart(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Co
 
 type cmdCtlRestart struct {
 	libkb.Contextified
-	components map[string]bool
+	components   map[string]bool
+	drain        bool
+	drainTimeout time.Duration
 }
 
 func (s *cmdCtlRestart) ParseArgv(ctx *cli.Context) error {
 	s.components = ctlParseArgv(ctx)
+	s.drain = ctx.Bool("drain")
+	s.drainTimeout = ctx.Duration("drain-timeout")
+	if s.drainTimeout <= 0 {
+		return errors.New("--drain-timeout must be positive")
+	}
 	return nil
 }
 
 func (s *cmdCtlRestart) Run() error {
+	// The jobs are the service's and KBFS's, so there's only something to
+	// drain if one of them is being restarted.
+	if s.drain && (s.components[install.ComponentNameService.String()] ||
+		s.components[install.ComponentNameKBFS.String()]) {
+		ctlDrainBackgroundJobs(s.G(), s.drainTimeout)
+	}
 	err := ctlStop(s.G(), s.components)
 	if err != nil {
 		return err
@@ -0,0 +1,133 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	keybase1 "github.com/keybase/client/go/protocol/keybase1"
+	"golang.org/x/net/context"
+)
+
+func NewCmdCtlTelemetry(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:  "telemetry",
+		Usage: "Show the performance counters and timings the service has recorded",
+		Description: `Telemetry is only recorded with telemetry_mode set to local in the
+   config, and is kept in memory; it's never sent anywhere.`,
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  "s, summary",
+				Usage: "Total up the events with each name",
+			},
+			cli.StringFlag{
+				Name:  "prefix",
+				Usage: "Only show events whose names start with this, like chat.archive",
+			},
+		},
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(&CmdCtlTelemetry{Contextified: libkb.NewContextified(g)}, "telemetry", c)
+			cl.SetForkCmd(libcmdline.NoFork)
+			cl.SetNoStandalone()
+		},
+	}
+}
+
+type CmdCtlTelemetry struct {
+	libkb.Contextified
+	summary bool
+	prefix  string
+}
+
+func (s *CmdCtlTelemetry) ParseArgv(ctx *cli.Context) error {
+	if len(ctx.Args()) > 0 {
+		return errors.New("no arguments required")
+	}
+	s.summary = ctx.Bool("summary")
+	s.prefix = ctx.String("prefix")
+	return nil
+}
+
+type telemetrySummary struct {
+	name  string
+	typ   keybase1.TelemetryEventType
+	n     int64
+	total int64
+	max   int64
+}
+
+func summarizeTelemetry(events []keybase1.TelemetryEvent) (res []telemetrySummary) {
+	index := make(map[string]int)
+	for _, event := range events {
+		i, ok := index[event.Name]
+		if !ok {
+			i = len(res)
+			index[event.Name] = i
+			res = append(res, telemetrySummary{name: event.Name, typ: event.Type})
+		}
+		res[i].n++
+		res[i].total += event.Value
+		if event.Value > res[i].max {
+			res[i].max = event.Value
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].name < res[j].name })
+	return res
+}
+
+func (s *CmdCtlTelemetry) Run() (err error) {
+	cli, err := GetCtlClient(s.G())
+	if err != nil {
+		return err
+	}
+	res, err := cli.GetTelemetryEvents(context.TODO(), 0)
+	if err != nil {
+		return err
+	}
+	ui := s.G().UI.GetTerminalUI()
+	if !res.Enabled {
+		ui.Printf("Telemetry is off; set telemetry_mode to local in the config to record it\n")
+		return nil
+	}
+	var events []keybase1.TelemetryEvent
+	for _, event := range res.Events {
+		if strings.HasPrefix(event.Name, s.prefix) {
+			events = append(events, event)
+		}
+	}
+	if len(events) == 0 {
+		ui.Printf("No events recorded\n")
+		return nil
+	}
+
+	if s.summary {
+		for _, summary := range summarizeTelemetry(events) {
+			if summary.typ == keybase1.TelemetryEventType_TIMING {
+				ui.Printf("%s: %d timed, average %dms, max %dms\n", summary.name, summary.n,
+					summary.total/summary.n, summary.max)
+			} else {
+				ui.Printf("%s: %d\n", summary.name, summary.total)
+			}
+		}
+		return nil
+	}
+	for _, event := range events {
+		when := keybase1.FromTime(event.Time).Format("2006-01-02 15:04:05.000")
+		if event.Type == keybase1.TelemetryEventType_TIMING {
+			ui.Printf("%s %s %dms\n", when, event.Name, event.Value)
+		} else {
+			ui.Printf("%s %s %+d\n", when, event.Name, event.Value)
+		}
+	}
+	return nil
+}
+
+func (s *CmdCtlTelemetry) GetUsage() libkb.Usage {
+	return libkb.Usage{}
+}
@@ -4,6 +4,7 @@
 package client
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -45,6 +46,10 @@ func (p *CmdProve) ParseArgv(ctx *cli.Context) error {
 		p.arg.Username = ctx.Args()[1]
 	}
 
+	if err := p.parseProvisioner(ctx); err != nil {
+		return err
+	}
+
 	if libkb.RemoteServiceTypes[p.arg.Service] == keybase1.ProofType_ROOTER {
 		p.arg.Auto = ctx.Bool("auto")
 		if p.arg.Auto && len(p.arg.Username) == 0 {
@@ -54,6 +59,41 @@ func (p *CmdProve) ParseArgv(ctx *cli.Context) error {
 	return nil
 }
 
+// cloudflareAPITokenEnv is where the Cloudflare API token comes from, so
+// it's not on the command line.
+const cloudflareAPITokenEnv = "CLOUDFLARE_API_TOKEN"
+
+func (p *CmdProve) parseProvisioner(ctx *cli.Context) error {
+	webhookURL := ctx.String("webhook-url")
+	switch provisioner := ctx.String("provisioner"); provisioner {
+	case "":
+		if len(webhookURL) > 0 {
+			return errors.New("--webhook-url needs --provisioner=webhook")
+		}
+	case "cloudflare":
+		token := os.Getenv(cloudflareAPITokenEnv)
+		if len(token) == 0 {
+			return fmt.Errorf("set %s to a Cloudflare API token that can edit the domain's DNS records",
+				cloudflareAPITokenEnv)
+		}
+		p.arg.Provisioner = &keybase1.ProofProvisioner{
+			Type:     keybase1.ProofProvisionerType_CLOUDFLARE,
+			ApiToken: token,
+		}
+	case "webhook":
+		if len(webhookURL) == 0 {
+			return errors.New("--provisioner=webhook needs --webhook-url")
+		}
+		p.arg.Provisioner = &keybase1.ProofProvisioner{
+			Type: keybase1.ProofProvisionerType_WEBHOOK,
+			Url:  webhookURL,
+		}
+	default:
+		return fmt.Errorf("unknown provisioner %q; must be cloudflare or webhook", provisioner)
+	}
+	return nil
+}
+
 func (p *CmdProve) fileOutputHook(txt string) (err error) {
 	p.G().Log.Info("Writing proof to file '" + p.output + "'...")
 	err = os.WriteFile(p.output, []byte(txt), os.FileMode(0644))
@@ -169,6 +209,15 @@ func NewCmdProve(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command
 				Name:  "all, a",
 				Usage: "List the full gamut of available services",
 			},
+			cli.StringFlag{
+				Name: "provisioner",
+				Usage: "Publish a dns or web proof with cloudflare (using the token in " +
+					cloudflareAPITokenEnv + ") or webhook, and wait for it to check out.",
+			},
+			cli.StringFlag{
+				Name:  "webhook-url",
+				Usage: "The https URL the webhook provisioner POSTs the proof to.",
+			},
 		},
 		Action: func(c *cli.Context) {
 			cl.ChooseCommand(&CmdProve{Contextified: libkb.NewContextified(g)}, "prove", c)
@@ -41,6 +41,7 @@ func (c *CmdShowNotifications) Run() error {
 		keybase1.NotifyTrackingProtocol(display),
 		keybase1.NotifyAuditProtocol(display),
 		keybase1.NotifyRuntimeStatsProtocol(display),
+		keybase1.NotifySimpleFSArchiveProgressProtocol(display),
 	}
 	channels := keybase1.NotificationChannels{
 		Session:      true,
@@ -49,6 +50,8 @@ func (c *CmdShowNotifications) Run() error {
 		Tracking:     true,
 		Audit:        true,
 		Runtimestats: true,
+
+		Simplefsarchive: true,
 	}
 
 	if err := RegisterProtocolsWithContext(protocols, c.G()); err != nil {
@@ -143,6 +146,20 @@ func (d *notificationDisplay) FSFavoritesChanged(_ context.Context) error {
 	return d.printf("KBFS favorites changed\n")
 }
 
+func (d *notificationDisplay) SimpleFSArchiveProgress(_ context.Context,
+	progress keybase1.SimpleFSArchiveProgress) error {
+	return d.printf("KBFS archive job %s %s: %d/%d bytes copied (+%d), %d bytes zipped (+%d), %d bytes uploaded (+%d)\n",
+		progress.JobID, progress.Phase, progress.BytesCopied, progress.BytesTotal,
+		progress.BytesCopiedDelta, progress.BytesZipped, progress.BytesZippedDelta,
+		progress.BytesUploaded, progress.BytesUploadedDelta)
+}
+
+func (d *notificationDisplay) FSQuotaAlert(_ context.Context,
+	alert keybase1.SimpleFSQuotaAlert) error {
+	return d.printf("KBFS quota alert: usage over %d%% (%d/%d bytes)\n",
+		alert.Threshold, alert.UsageBytes, alert.LimitBytes)
+}
+
 func (d *notificationDisplay) FSActivity(_ context.Context, notification keybase1.FSNotification) error {
 	return d.printf("KBFS notification: %+v\n", notification)
 }
@@ -20,6 +20,17 @@ func NewCmdSignup(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Comman
 	cmd := cli.Command{
 		Name:  "signup",
 		Usage: "Signup for a new account",
+		Description: `Signup for a new account. With --headless, signup runs without any prompts,
+   for CI and other bots that get a device of their own. The account has no
+   passphrase, so the secret that unlocks the device's keys lives only in the
+   chosen secret store, which the service then keeps using:
+
+     keybase signup --headless --no-passphrase-prompt --username mybot \
+       --device-name ci-runner-1 --secret-store file
+
+   Give the device a name that says where it runs, so it's easy to find and
+   revoke later. No paper key is made unless --paper-key is passed, in which
+   case it's printed with the rest of the output.`,
 		Action: func(c *cli.Context) {
 			cl.ChooseCommand(NewCmdSignupRunner(g), "signup", c)
 		},
@@ -48,6 +59,27 @@ func NewCmdSignup(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Comman
 				Name:  "force",
 				Usage: "(dangerous) Ignore any reasons not to signup right now",
 			},
+			cli.BoolFlag{
+				Name: "headless",
+				Usage: "Signup without any prompts, e.g. for a CI bot. Needs --username, " +
+					"--device-name, --secret-store and --no-passphrase-prompt.",
+			},
+			cli.BoolFlag{
+				Name:  "no-passphrase-prompt",
+				Usage: "With --headless, confirm the account has no passphrase and relies on the secret store.",
+			},
+			cli.StringFlag{
+				Name:  "device-name",
+				Usage: "With --headless, the public name of this device, e.g. ci-runner-1.",
+			},
+			cli.StringFlag{
+				Name:  "secret-store",
+				Usage: "With --headless, where to keep the device's secret: file or system.",
+			},
+			cli.BoolFlag{
+				Name:  "paper-key",
+				Usage: "With --headless, also make a paper key and print it.",
+			},
 		},
 	}
 
@@ -93,6 +125,8 @@ type CmdSignup struct {
 	genPGP             bool
 	genPaper           bool
 	force              bool
+	headless           bool
+	secretStoreBackend keybase1.SecretStoreBackend
 
 	// Test option to not call to requestInvitationCode for bypassing
 	// invitation code.
@@ -169,7 +203,14 @@ func (s *CmdSignup) ParseArgv(ctx *cli.Context) (err error) {
 
 	s.force = ctx.Bool("force")
 
-	if ctx.Bool("batch") {
+	if ctx.Bool("headless") {
+		if err := s.parseHeadless(ctx); err != nil {
+			return err
+		}
+	} else if ctx.Bool("no-passphrase-prompt") || len(ctx.String("device-name")) > 0 ||
+		len(ctx.String("secret-store")) > 0 || ctx.Bool("paper-key") {
+		return BadArgsError{"--no-passphrase-prompt, --device-name, --secret-store and --paper-key are only for --headless"}
+	} else if ctx.Bool("batch") {
 		s.fields = &PromptFields{
 			email:           &Field{Value: &s.defaultEmail},
 			code:            &Field{Value: &s.code},
@@ -197,6 +238,60 @@ func (s *CmdSignup) ParseArgv(ctx *cli.Context) (err error) {
 	return err
 }
 
+// parseHeadless sets up a signup that never prompts. Everything that would
+// otherwise be asked for has to be on the command line, and since the
+// account won't have a passphrase, the user has to say so explicitly.
+func (s *CmdSignup) parseHeadless(ctx *cli.Context) error {
+	if !ctx.Bool("no-passphrase-prompt") {
+		return BadArgsError{"--headless signups have no passphrase, so the device's secret store " +
+			"is the only way into the account; pass --no-passphrase-prompt to confirm"}
+	}
+	if ctx.Bool("set-password") || len(s.defaultPassphrase) > 0 {
+		return BadArgsError{"--headless can't be used with a passphrase"}
+	}
+	if ctx.Bool("batch") {
+		return BadArgsError{"--headless and --batch can't be used together"}
+	}
+	if len(s.defaultUsername) == 0 {
+		return BadArgsError{"--headless needs --username"}
+	}
+	deviceName := ctx.String("device-name")
+	if len(deviceName) == 0 {
+		// Bot devices get names of their own, so they're easy to tell
+		// apart (and revoke) in the device list.
+		return BadArgsError{"--headless needs --device-name"}
+	}
+	if !libkb.CheckDeviceName.F(deviceName) {
+		return BadArgsError{fmt.Sprintf("bad --device-name: %s", libkb.CheckDeviceName.Hint)}
+	}
+	switch ctx.String("secret-store") {
+	case "file":
+		s.secretStoreBackend = keybase1.SecretStoreBackend_FILE
+	case "system":
+		s.secretStoreBackend = keybase1.SecretStoreBackend_SYSTEM
+	case "":
+		return BadArgsError{"--headless needs --secret-store (file or system)"}
+	default:
+		return BadArgsError{fmt.Sprintf("unknown --secret-store %q; use file or system",
+			ctx.String("secret-store"))}
+	}
+
+	s.defaultDevice = deviceName
+	s.fields = &PromptFields{
+		email:           &Field{Value: &s.defaultEmail},
+		code:            &Field{Value: &s.code},
+		username:        &Field{Value: &s.defaultUsername},
+		deviceName:      &Field{Value: &s.defaultDevice},
+		passphraseRetry: &Field{},
+	}
+	s.headless = true
+	s.doPrompt = false
+	s.storeSecret = true
+	s.randomPassphrase = true
+	s.genPaper = ctx.Bool("paper-key")
+	return nil
+}
+
 func (s *CmdSignup) successMessage() error {
 	username := s.fields.username.GetValue()
 	msg := fmt.Sprintf(`
@@ -256,6 +351,9 @@ func (s *CmdSignup) checkRegistered() (err error) {
 		return
 	}
 
+	if s.headless {
+		return fmt.Errorf("already registered; pass --force to signup anyway")
+	}
 	if !s.doPrompt {
 		return nil
 	}
@@ -331,6 +429,10 @@ func (s *CmdSignup) runEngine() (retry bool, err error) {
 		SkipMail:    s.skipMail,
 		GenPGPBatch: s.genPGP,
 		GenPaper:    s.genPaper,
+		SkipGPG:     s.headless,
+
+		Headless:           s.headless,
+		SecretStoreBackend: s.secretStoreBackend,
 	}
 	if s.fields.email != nil {
 		email := s.fields.email.GetValue()
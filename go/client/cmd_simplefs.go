@@ -55,9 +55,12 @@ func NewCmdSimpleFS(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Comm
 			NewCmdSimpleFSUploads(cl, g),
 			NewCmdSimpleFSCancelUploads(cl, g),
 			NewCmdSimpleFSArchive(cl, g),
+			NewCmdSimpleFSLock(cl, g),
+			NewCmdSimpleFSUnlock(cl, g),
 			NewCmdSimpleFSSearch(cl, g),
 			NewCmdSimpleFSResetIndex(cl, g),
 			NewCmdSimpleFSIndexProgress(cl, g),
+			NewCmdSimpleFSRepairCache(cl, g),
 		}, getBuildSpecificFSCommands(cl, g)...),
 	}
 }
@@ -0,0 +1,159 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	keybase1 "github.com/keybase/client/go/protocol/keybase1"
+	isatty "github.com/mattn/go-isatty"
+	"golang.org/x/net/context"
+)
+
+// archiveWatchInterval is how often 'fs archive status --watch' checks on
+// the jobs. The service sends progress at most once a second anyway.
+const archiveWatchInterval = time.Second
+
+// archiveJobPhaseProgress returns how many of the job's bytes are done in
+// its current phase, if it's a phase that goes through them all.
+func archiveJobPhaseProgress(job keybase1.SimpleFSArchiveJobStatus) (
+	done int64, ok bool) {
+	if job.BytesTotal == 0 {
+		return 0, false
+	}
+	switch {
+	case job.Phase == keybase1.SimpleFSArchiveJobPhase_Copying:
+		return job.BytesCopied, true
+	case job.Phase == keybase1.SimpleFSArchiveJobPhase_Zipping && job.BytesUploaded == 0:
+		return job.BytesZipped, true
+	}
+	return 0, false
+}
+
+// archiveJobEnded says whether the job won't make any more progress on its
+// own, and returns an error if that's because it failed.
+func archiveJobEnded(job keybase1.SimpleFSArchiveJobStatus) (bool, error) {
+	switch {
+	case job.Phase == keybase1.SimpleFSArchiveJobPhase_Done:
+		return true, nil
+	case job.Phase == keybase1.SimpleFSArchiveJobPhase_Failed:
+		if job.Error == nil {
+			return true, fmt.Errorf("archive job %s failed", job.Desc.JobID)
+		}
+		return true, fmt.Errorf("archive job %s failed: %s",
+			job.Desc.JobID, job.Error.Error)
+	case job.Error != nil &&
+		job.Error.ErrorClass == keybase1.SimpleFSArchiveJobErrorClass_Fatal:
+		return true, fmt.Errorf("archive job %s won't be retried: %s",
+			job.Desc.JobID, job.Error.Error)
+	}
+	return false, nil
+}
+
+// archiveWatchLine is the one line about job in the live display.
+func archiveWatchLine(job keybase1.SimpleFSArchiveJobStatus,
+	now time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s  %s", job.Desc.JobID, job.Phase.String())
+	if job.Paused {
+		b.WriteString(" [paused]")
+	}
+	if progress := simpleFSArchiveJobProgress(job); len(progress) > 0 {
+		fmt.Fprintf(&b, "  %s", progress)
+	}
+	if done, ok := archiveJobPhaseProgress(job); ok {
+		fmt.Fprintf(&b, " (%s / %s)", humanize.Bytes(uint64(done)),
+			humanize.Bytes(uint64(job.BytesTotal)))
+	}
+	if remaining, ok := simpleFSArchiveJobRemaining(job, now); ok {
+		fmt.Fprintf(&b, "  %s", remaining)
+	}
+	if job.Error != nil {
+		fmt.Fprintf(&b, "  error: %s", job.Error.Error)
+	}
+	return b.String()
+}
+
+// watchJobs shows the progress of the jobs given, or of all the jobs there are
+// when it starts, until they've all ended. It returns the first error any
+// of them ended with.
+func (c *CmdSimpleFSArchiveStatus) watchJobs(ctx context.Context,
+	cli keybase1.SimpleFSClient) error {
+	status, err := cli.SimpleFSGetArchiveStatus(ctx)
+	if err != nil {
+		return err
+	}
+	jobs, err := sortedSimpleFSArchiveJobs(status, c.jobIDs)
+	if err != nil {
+		return err
+	}
+	if len(jobs) == 0 {
+		return errors.New("no archive jobs to watch")
+	}
+	jobIDs := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		jobIDs = append(jobIDs, job.Desc.JobID)
+	}
+
+	ui := c.G().UI.GetTerminalUI()
+	redraw := isatty.IsTerminal(os.Stdout.Fd())
+	var lastLines []string
+	for {
+		now := time.Now()
+		lines := make([]string, 0, len(jobIDs))
+		allEnded := true
+		var jobErr error
+		for _, jobID := range jobIDs {
+			job, ok := status.Jobs[jobID]
+			if !ok {
+				lines = append(lines, fmt.Sprintf("%s  dismissed", jobID))
+				continue
+			}
+			lines = append(lines, archiveWatchLine(job, now))
+			ended, err := archiveJobEnded(job)
+			allEnded = allEnded && ended
+			if err != nil && jobErr == nil {
+				jobErr = err
+			}
+		}
+
+		switch {
+		case redraw:
+			// Go back up over the last display and draw over it.
+			if len(lastLines) > 0 {
+				ui.PrintfUnescaped("\x1b[%dA", len(lastLines))
+			}
+			for _, line := range lines {
+				ui.PrintfUnescaped("\x1b[2K")
+				ui.Printf("%s\n", line)
+			}
+		default:
+			// Only print what's changed, so logs don't fill up.
+			for i, line := range lines {
+				if lastLines == nil || lastLines[i] != line {
+					ui.Printf("%s\n", line)
+				}
+			}
+		}
+		lastLines = lines
+		if allEnded {
+			return jobErr
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(archiveWatchInterval):
+		}
+		status, err = cli.SimpleFSGetArchiveStatus(ctx)
+		if err != nil {
+			return err
+		}
+	}
+}
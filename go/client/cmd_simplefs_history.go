@@ -17,8 +17,10 @@ import (
 // CmdSimpleFSHistory is the 'fs history' command.
 type CmdSimpleFSHistory struct {
 	libkb.Contextified
-	path    keybase1.Path
-	deletes bool
+	path          keybase1.Path
+	deletes       bool
+	startRevision keybase1.KBFSRevision
+	limit         int
 }
 
 // NewCmdSimpleFSHistory creates a new cli.Command.
@@ -26,8 +28,8 @@ func NewCmdSimpleFSHistory(
 	cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
 	return cli.Command{
 		Name:         "history",
-		ArgumentHelp: "[path-to-folder]",
-		Usage:        "output the edit history for a user or folder",
+		ArgumentHelp: "[path-to-folder-or-file]",
+		Usage:        "output the edit history for a user, folder or file",
 		Action: func(c *cli.Context) {
 			cl.ChooseCommand(&CmdSimpleFSHistory{
 				Contextified: libkb.NewContextified(g)}, "history", c)
@@ -38,7 +40,19 @@ func NewCmdSimpleFSHistory(
 				Name:  "d, deletes",
 				Usage: "Show the recently-deleted files",
 			},
+			cli.IntFlag{
+				Name:  "start-revision",
+				Usage: "For a file, the revision to start listing history from",
+			},
+			cli.IntFlag{
+				Name:  "n, limit",
+				Value: 20,
+				Usage: "For a file, the most revisions to list",
+			},
 		},
+		Description: `Given a file, lists the revisions of its folder in which
+   the file changed, newest first. Use "keybase fs read --rev <revision>"
+   to fetch the file as of one of them.`,
 	}
 }
 
@@ -58,6 +72,15 @@ func (c *CmdSimpleFSHistory) Run() error {
 			c.output(h)
 		}
 	} else {
+		e, err := cli.SimpleFSStat(context.TODO(), keybase1.SimpleFSStatArg{
+			Path: c.path,
+		})
+		if err != nil {
+			return err
+		}
+		if e.DirentType != keybase1.DirentType_DIR {
+			return c.outputFileHistory(cli)
+		}
 		history, err := cli.SimpleFSFolderEditHistory(context.TODO(), c.path)
 		if err != nil {
 			return err
@@ -89,9 +112,40 @@ func (c *CmdSimpleFSHistory) output(h keybase1.FSFolderEditHistory) {
 	}
 }
 
+func (c *CmdSimpleFSHistory) outputFileHistory(
+	cli keybase1.SimpleFSInterface) error {
+	res, err := cli.SimpleFSFileHistory(
+		context.TODO(), keybase1.SimpleFSFileHistoryArg{
+			Path:          c.path,
+			StartRevision: c.startRevision,
+			Num:           c.limit,
+		})
+	if err != nil {
+		return err
+	}
+
+	ui := c.G().UI.GetTerminalUI()
+	for _, r := range res.Revisions {
+		e := r.Entry
+		ui.Printf("%d)\t%s\t%d\t%s\n",
+			r.Revision, keybase1.FormatTime(e.Time), e.Size,
+			e.LastWriterUnverified.Username)
+	}
+	if res.NextRevision > 0 {
+		ui.Printf("\nFor older revisions, use --start-revision %d\n",
+			res.NextRevision)
+	}
+	return nil
+}
+
 // ParseArgv gets the optional path, if any.
 func (c *CmdSimpleFSHistory) ParseArgv(ctx *cli.Context) error {
 	c.deletes = ctx.Bool("deletes")
+	c.startRevision = keybase1.KBFSRevision(ctx.Int("start-revision"))
+	c.limit = ctx.Int("limit")
+	if c.startRevision < 0 || c.limit <= 0 {
+		return fmt.Errorf("--start-revision can't be negative, and --limit must be positive")
+	}
 
 	if len(ctx.Args()) > 1 {
 		return fmt.Errorf("wrong number of arguments")
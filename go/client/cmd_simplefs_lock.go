@@ -0,0 +1,171 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"fmt"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	keybase1 "github.com/keybase/client/go/protocol/keybase1"
+	"golang.org/x/net/context"
+)
+
+// CmdSimpleFSLock is the 'fs lock' command.
+type CmdSimpleFSLock struct {
+	libkb.Contextified
+	path  *keybase1.KBFSPath
+	lease keybase1.DurationMsec
+}
+
+// NewCmdSimpleFSLock creates a new cli.Command.
+func NewCmdSimpleFSLock(
+	cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "lock",
+		ArgumentHelp: "[path-to-file]",
+		Usage: "take an advisory lock on a file in a shared folder, or list " +
+			"the locks held by this device if no path is given",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(&CmdSimpleFSLock{
+				Contextified: libkb.NewContextified(g)}, "lock", c)
+			cl.SetNoStandalone()
+		},
+		Flags: []cli.Flag{
+			cli.DurationFlag{
+				Name:  "l, lease",
+				Usage: "release the lock automatically after this long (default 5m, max 1h)",
+			},
+		},
+	}
+}
+
+func printSimpleFSLockInfo(ui libkb.TerminalUI, info keybase1.SimpleFSLockInfo) {
+	ui.Printf("%s\t(acquired %s, expires %s)\n", info.Path.Path,
+		info.AcquireTime.Time(), info.LeaseExpiration.Time())
+}
+
+// Run runs the command in client/server mode.
+func (c *CmdSimpleFSLock) Run() error {
+	cli, err := GetSimpleFSClient(c.G())
+	if err != nil {
+		return err
+	}
+
+	ui := c.G().UI.GetTerminalUI()
+	if c.path == nil {
+		locks, err := cli.SimpleFSListLocks(context.TODO())
+		if err != nil {
+			return err
+		}
+		for _, info := range locks {
+			printSimpleFSLockInfo(ui, info)
+		}
+		return nil
+	}
+
+	info, err := cli.SimpleFSLock(context.TODO(), keybase1.SimpleFSLockArg{
+		Path:  *c.path,
+		Lease: c.lease,
+	})
+	if err != nil {
+		return err
+	}
+	printSimpleFSLockInfo(ui, info)
+	return nil
+}
+
+// ParseArgv gets the optional path, if any.
+func (c *CmdSimpleFSLock) ParseArgv(ctx *cli.Context) error {
+	switch len(ctx.Args()) {
+	case 0:
+	case 1:
+		p, err := makeSimpleFSPath(ctx.Args()[0])
+		if err != nil {
+			return err
+		}
+		pathType, err := p.PathType()
+		if err != nil {
+			return err
+		}
+		if pathType != keybase1.PathType_KBFS {
+			return fmt.Errorf("only KBFS paths can be locked")
+		}
+		kbfsPath := p.Kbfs()
+		c.path = &kbfsPath
+	default:
+		return fmt.Errorf("wrong number of arguments")
+	}
+	c.lease = keybase1.ToDurationMsec(ctx.Duration("lease"))
+	return nil
+}
+
+// GetUsage says what this command needs to operate.
+func (c *CmdSimpleFSLock) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config:    true,
+		KbKeyring: true,
+		API:       true,
+	}
+}
+
+// CmdSimpleFSUnlock is the 'fs unlock' command.
+type CmdSimpleFSUnlock struct {
+	libkb.Contextified
+	path keybase1.KBFSPath
+}
+
+// NewCmdSimpleFSUnlock creates a new cli.Command.
+func NewCmdSimpleFSUnlock(
+	cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "unlock",
+		ArgumentHelp: "<path-to-file>",
+		Usage:        "release an advisory lock taken with `keybase fs lock`",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(&CmdSimpleFSUnlock{
+				Contextified: libkb.NewContextified(g)}, "unlock", c)
+			cl.SetNoStandalone()
+		},
+	}
+}
+
+// Run runs the command in client/server mode.
+func (c *CmdSimpleFSUnlock) Run() error {
+	cli, err := GetSimpleFSClient(c.G())
+	if err != nil {
+		return err
+	}
+	return cli.SimpleFSUnlock(context.TODO(), c.path)
+}
+
+// ParseArgv gets the path.
+func (c *CmdSimpleFSUnlock) ParseArgv(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		return fmt.Errorf("wrong number of arguments")
+	}
+	p, err := makeSimpleFSPath(ctx.Args()[0])
+	if err != nil {
+		return err
+	}
+	pathType, err := p.PathType()
+	if err != nil {
+		return err
+	}
+	if pathType != keybase1.PathType_KBFS {
+		return fmt.Errorf("only KBFS paths can be unlocked")
+	}
+	c.path = p.Kbfs()
+	return nil
+}
+
+// GetUsage says what this command needs to operate.
+func (c *CmdSimpleFSUnlock) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config:    true,
+		KbKeyring: true,
+		API:       true,
+	}
+}
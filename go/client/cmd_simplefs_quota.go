@@ -6,6 +6,8 @@ package client
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/keybase/cli"
 	"github.com/keybase/client/go/libcmdline"
@@ -22,6 +24,9 @@ type CmdSimpleFSQuota struct {
 	archived bool
 	json     bool
 	teamName keybase1.TeamName
+
+	alerts    *[]int
+	alertChat *bool
 }
 
 // NewCmdSimpleFSQuota creates a new cli.Command.
@@ -57,6 +62,15 @@ func NewCmdSimpleFSQuota(
 				Name:  "team",
 				Usage: "print quota usage for a team, instead of the logged-in user",
 			},
+			cli.StringFlag{
+				Name: "alerts",
+				Usage: "set the usage percentages that trigger an alert, " +
+					"like 80,95, or 'off'",
+			},
+			cli.StringFlag{
+				Name:  "alert-chat",
+				Usage: "also send alerts as a chat message to yourself (on|off)",
+			},
 		},
 	}
 }
@@ -68,17 +82,41 @@ func (c *CmdSimpleFSQuota) Run() error {
 		return err
 	}
 
-	var usage keybase1.SimpleFSQuotaUsage
-	if c.teamName.Depth() == 0 {
-		usage, err = cli.SimpleFSGetUserQuotaUsage(context.TODO())
-	} else {
-		usage, err = cli.SimpleFSGetTeamQuotaUsage(context.TODO(), c.teamName)
+	if c.teamName.Depth() != 0 {
+		usage, err := cli.SimpleFSGetTeamQuotaUsage(context.TODO(), c.teamName)
+		if err != nil {
+			return err
+		}
+		return c.output(usage, nil)
 	}
+
+	alertSettings, err := cli.SimpleFSGetQuotaAlertSettings(context.TODO())
 	if err != nil {
 		return err
 	}
+	if c.alerts != nil || c.alertChat != nil {
+		if c.alerts != nil {
+			alertSettings.Thresholds = *c.alerts
+		}
+		if c.alertChat != nil {
+			alertSettings.ChatToSelf = *c.alertChat
+		}
+		err = cli.SimpleFSSetQuotaAlertSettings(context.TODO(), alertSettings)
+		if err != nil {
+			return err
+		}
+		// Read them back, since the thresholds are normalized.
+		alertSettings, err = cli.SimpleFSGetQuotaAlertSettings(context.TODO())
+		if err != nil {
+			return err
+		}
+	}
 
-	return c.output(usage)
+	usage, err := cli.SimpleFSGetUserQuotaUsage(context.TODO())
+	if err != nil {
+		return err
+	}
+	return c.output(usage, &alertSettings)
 }
 
 func humanizeBytes(n int64, bytesOnly bool) string {
@@ -106,9 +144,26 @@ type simpleFSQuotaStruct struct {
 	UsageBytes    int64
 	ArchivedBytes int64 `json:",omitempty"`
 	QuotaBytes    int64
+	Alerts        *keybase1.SimpleFSQuotaAlertSettings `json:",omitempty"`
+}
+
+func formatQuotaAlerts(settings keybase1.SimpleFSQuotaAlertSettings) string {
+	if len(settings.Thresholds) == 0 {
+		return "off"
+	}
+	thresholds := make([]string, 0, len(settings.Thresholds))
+	for _, t := range settings.Thresholds {
+		thresholds = append(thresholds, fmt.Sprintf("%d%%", t))
+	}
+	res := strings.Join(thresholds, ", ")
+	if settings.ChatToSelf {
+		res += " (also sent to chat)"
+	}
+	return res
 }
 
-func (c *CmdSimpleFSQuota) output(usage keybase1.SimpleFSQuotaUsage) error {
+func (c *CmdSimpleFSQuota) output(usage keybase1.SimpleFSQuotaUsage,
+	alertSettings *keybase1.SimpleFSQuotaAlertSettings) error {
 	ui := c.G().UI.GetTerminalUI()
 	usageBytes, archiveBytes, limitBytes :=
 		usage.UsageBytes, usage.ArchiveBytes, usage.LimitBytes
@@ -126,6 +181,9 @@ func (c *CmdSimpleFSQuota) output(usage keybase1.SimpleFSQuotaUsage) error {
 		if c.archived {
 			data.ArchivedBytes = archiveBytes
 		}
+		if !c.git {
+			data.Alerts = alertSettings
+		}
 		output, err := json.Marshal(data)
 		if err != nil {
 			return err
@@ -139,6 +197,10 @@ func (c *CmdSimpleFSQuota) output(usage keybase1.SimpleFSQuotaUsage) error {
 		ui.Printf("Archived:\t%s\n", c.humanizeBytes(archiveBytes))
 	}
 	ui.Printf("Quota:\t\t%s\n", c.humanizeBytes(limitBytes))
+	// Alerts only watch the regular KBFS usage.
+	if alertSettings != nil && !c.git {
+		ui.Printf("Alerts:\t\t%s\n", formatQuotaAlerts(*alertSettings))
+	}
 	return nil
 }
 
@@ -157,6 +219,35 @@ func (c *CmdSimpleFSQuota) ParseArgv(ctx *cli.Context) error {
 		c.teamName = teamName
 	}
 
+	if ctx.IsSet("alerts") {
+		var alerts []int
+		if s := ctx.String("alerts"); s != "off" {
+			for _, t := range strings.Split(s, ",") {
+				threshold, err := strconv.Atoi(
+					strings.TrimSuffix(strings.TrimSpace(t), "%"))
+				if err != nil {
+					return fmt.Errorf("bad alert threshold %q", t)
+				}
+				alerts = append(alerts, threshold)
+			}
+		}
+		c.alerts = &alerts
+	}
+	if ctx.IsSet("alert-chat") {
+		var alertChat bool
+		switch ctx.String("alert-chat") {
+		case "on":
+			alertChat = true
+		case "off":
+		default:
+			return fmt.Errorf("--alert-chat must be on or off")
+		}
+		c.alertChat = &alertChat
+	}
+	if (c.alerts != nil || c.alertChat != nil) && c.teamName.Depth() != 0 {
+		return fmt.Errorf("quota alerts can only be set for the logged-in user")
+	}
+
 	return nil
 }
 
@@ -0,0 +1,118 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// CmdSimpleFSRepairCache is the 'fs repair-cache' command.
+type CmdSimpleFSRepairCache struct {
+	libkb.Contextified
+	rebuild bool
+}
+
+// NewCmdSimpleFSRepairCache creates a new cli.Command.
+func NewCmdSimpleFSRepairCache(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:  "repair-cache",
+		Usage: "check the local KBFS caches, and rebuild any corrupt ones",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(&CmdSimpleFSRepairCache{
+				Contextified: libkb.NewContextified(g)}, "repair-cache", c)
+			cl.SetNoStandalone()
+		},
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name: "rebuild",
+				Usage: `rebuild every cache, even ones that look fine; cached
+	blocks are downloaded again as needed`,
+			},
+		},
+	}
+}
+
+func printCacheRepairs(
+	ui libkb.TerminalUI, repairs []keybase1.SimpleFSCacheDBRepair) {
+	for _, r := range repairs {
+		ui.Printf("%s\t%s\t%s\n",
+			keybase1.FromTime(r.Time).Format(time.RFC3339), r.Db, r.Reason)
+		if r.QuarantinePath != "" {
+			ui.Printf("\tmoved to %s\n", r.QuarantinePath)
+		}
+	}
+}
+
+// Run runs the command in client/server mode.
+func (c *CmdSimpleFSRepairCache) Run() error {
+	cli, err := GetSimpleFSClient(c.G())
+	if err != nil {
+		return err
+	}
+
+	ctx := context.TODO()
+	before, err := cli.SimpleFSGetCacheRepairProgress(ctx)
+	if err != nil {
+		return err
+	}
+	err = cli.SimpleFSRepairCache(ctx, c.rebuild)
+	if err != nil {
+		return err
+	}
+
+	ui := c.G().UI.GetTerminalUI()
+	var p keybase1.SimpleFSCacheRepairProgress
+	lastDB := keybase1.SimpleFSCacheDB(-1)
+	for {
+		time.Sleep(500 * time.Millisecond)
+		p, err = cli.SimpleFSGetCacheRepairProgress(ctx)
+		if err != nil {
+			return err
+		}
+		if !p.InProgress && p.StartTime != before.StartTime {
+			break
+		}
+		if p.InProgress && p.CurrDB != lastDB {
+			ui.Printf("Checking %s (%d/%d)\n", p.CurrDB, p.DbsDone+1, p.DbsTotal)
+			lastDB = p.CurrDB
+		}
+	}
+
+	if len(p.Repairs) > len(before.Repairs) {
+		ui.Printf("\nRebuilt:\n")
+		printCacheRepairs(ui, p.Repairs[len(before.Repairs):])
+	} else {
+		ui.Printf("No corrupt caches found\n")
+	}
+	if p.Error != "" {
+		return errors.New(p.Error)
+	}
+	return nil
+}
+
+// ParseArgv parses the arguments.
+func (c *CmdSimpleFSRepairCache) ParseArgv(ctx *cli.Context) error {
+	if len(ctx.Args()) != 0 {
+		return fmt.Errorf("wrong number of arguments")
+	}
+	c.rebuild = ctx.Bool("rebuild")
+	return nil
+}
+
+// GetUsage says what this command needs to operate.
+func (c *CmdSimpleFSRepairCache) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config:    true,
+		KbKeyring: true,
+		API:       true,
+	}
+}
@@ -4,7 +4,9 @@
 package client
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/keybase/client/go/protocol/keybase1"
 	"github.com/stretchr/testify/require"
@@ -53,3 +55,119 @@ func TestMakeSimpleFSPath(t *testing.T) {
 		"/keybase/private/jdoe", 0, "", "1h30s",
 		keybase1.PathType_KBFS_ARCHIVED)
 }
+
+func TestArchiveJobRemaining(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	job := keybase1.SimpleFSArchiveJobStatus{
+		Desc:        keybase1.SimpleFSArchiveJobDesc{JobID: "job"},
+		Phase:       keybase1.SimpleFSArchiveJobPhase_Copying,
+		BytesTotal:  1000,
+		BytesCopied: 100,
+	}
+	// No estimate from the service yet.
+	_, ok := simpleFSArchiveJobRemaining(job, now)
+	require.False(t, ok)
+	check := func(d time.Duration, expected string) {
+		job.EstimatedCompletion = keybase1.ToTime(now.Add(d))
+		remaining, ok := simpleFSArchiveJobRemaining(job, now)
+		require.True(t, ok)
+		require.Equal(t, expected, remaining)
+	}
+	check(20*time.Second, "less than a minute remaining")
+	check(14*time.Minute+10*time.Second, "about 14 minutes remaining")
+	check(5*time.Hour+20*time.Minute, "about 5 hours remaining")
+	check(72*time.Hour, "about 3 days remaining")
+	job.Paused = true
+	_, ok = simpleFSArchiveJobRemaining(job, now)
+	require.False(t, ok)
+	job.Paused = false
+
+	job.Phase = keybase1.SimpleFSArchiveJobPhase_Zipping
+	ended, err := archiveJobEnded(job)
+	require.False(t, ended)
+	require.NoError(t, err)
+	job.Phase = keybase1.SimpleFSArchiveJobPhase_Done
+	ended, err = archiveJobEnded(job)
+	require.True(t, ended)
+	require.NoError(t, err)
+	job.Phase = keybase1.SimpleFSArchiveJobPhase_Failed
+	job.Error = &keybase1.SimpleFSArchiveJobErrorState{Error: "disk full"}
+	ended, err = archiveJobEnded(job)
+	require.True(t, ended)
+	require.EqualError(t, err, "archive job job failed: disk full")
+}
+
+func TestArchiveStatusJSON(t *testing.T) {
+	job := keybase1.SimpleFSArchiveJobStatus{
+		Desc: keybase1.SimpleFSArchiveJobDesc{
+			JobID:   "job",
+			Label:   "weekly",
+			JobType: keybase1.SimpleFSArchiveJobType_Archive,
+			KbfsPathWithRevision: keybase1.KBFSArchivedPath{
+				Path: "/keybase/private/jdoe"},
+			ZipFilePath: "/tmp/jdoe.zip",
+			StartTime:   keybase1.ToTime(time.Unix(1700000000, 0)),
+			Destination: keybase1.SimpleFSArchiveDestination{
+				Type:     keybase1.SimpleFSArchiveDestinationType_WebDAV,
+				Url:      "https://dav.example.com/jdoe.zip",
+				Username: "jdoe",
+				Password: "hunter2",
+			},
+		},
+		Phase:      keybase1.SimpleFSArchiveJobPhase_Done,
+		BytesTotal: 10,
+	}
+	status := keybase1.SimpleFSArchiveStatus{
+		Jobs:        map[string]keybase1.SimpleFSArchiveJobStatus{"job": job},
+		LastUpdated: keybase1.ToTime(time.Unix(1700000100, 0)),
+		Schedules: map[string]keybase1.SimpleFSArchiveSchedule{
+			"b": {ScheduleID: "b"},
+			"a": {ScheduleID: "a"},
+		},
+	}
+	b, err := simpleFSArchiveStatusJSON(status, []keybase1.SimpleFSArchiveJobStatus{job}, true)
+	require.NoError(t, err)
+	require.NotContains(t, string(b), "hunter2")
+	var res struct {
+		LastUpdated time.Time `json:"lastUpdated"`
+		Jobs        []struct {
+			JobID      string                            `json:"jobID"`
+			Label      string                            `json:"label"`
+			JobType    string                            `json:"jobType"`
+			Path       string                            `json:"path"`
+			Phase      string                            `json:"phase"`
+			BytesTotal int64                             `json:"bytesTotal"`
+			Details    keybase1.SimpleFSArchiveJobStatus `json:"details"`
+		} `json:"jobs"`
+		Schedules []keybase1.SimpleFSArchiveSchedule `json:"schedules"`
+	}
+	require.NoError(t, json.Unmarshal(b, &res))
+	require.True(t, res.LastUpdated.Equal(status.LastUpdated.Time()))
+	require.Len(t, res.Jobs, 1)
+	require.Equal(t, "job", res.Jobs[0].JobID)
+	require.Equal(t, "weekly", res.Jobs[0].Label)
+	require.Equal(t, "Archive", res.Jobs[0].JobType)
+	require.Equal(t, "/keybase/private/jdoe", res.Jobs[0].Path)
+	require.Equal(t, job.Phase.String(), res.Jobs[0].Phase)
+	require.Equal(t, int64(10), res.Jobs[0].BytesTotal)
+	// The details have everything but the password.
+	dest := res.Jobs[0].Details.Desc.Destination
+	require.Equal(t, "jdoe", dest.Username)
+	require.Equal(t, job.Desc.Destination.Url, dest.Url)
+	require.Empty(t, dest.Password)
+	require.Equal(t, "hunter2", job.Desc.Destination.Password)
+	require.Len(t, res.Schedules, 2)
+	require.Equal(t, "a", res.Schedules[0].ScheduleID)
+	require.Equal(t, "b", res.Schedules[1].ScheduleID)
+
+	// Asking about particular jobs leaves out the schedules.
+	b, err = simpleFSArchiveStatusJSON(status, []keybase1.SimpleFSArchiveJobStatus{job}, false)
+	require.NoError(t, err)
+	require.NotContains(t, string(b), "schedules")
+
+	// The list output is just the summary, without the destination at all.
+	b, err = json.Marshal(newSimpleFSArchiveJobJSON(job))
+	require.NoError(t, err)
+	require.NotContains(t, string(b), "hunter2")
+	require.NotContains(t, string(b), "details")
+}
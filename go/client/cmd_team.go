@@ -18,6 +18,7 @@ func NewCmdTeam(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command
 		newCmdTeamAddMembersBulk(cl, g),
 		newCmdTeamRemoveMember(cl, g),
 		newCmdTeamEditMember(cl, g),
+		newCmdTeamExternalSync(cl, g),
 		newCmdTeamListMemberships(cl, g),
 		newCmdTeamShowTree(cl, g),
 		newCmdTeamRename(cl, g),
@@ -30,10 +31,13 @@ func NewCmdTeam(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command
 		newCmdTeamAPI(cl, g),
 		newCmdTeamSettings(cl, g),
 		newCmdTeamProfileLoad(cl, g),
+		newCmdTeamReadme(cl, g),
 		newCmdTeamFTL(cl, g),
 		newCmdTeamBotSettings(cl, g),
 		newCmdTeamSearch(cl, g),
 		newCmdTeamGenerateSeitan(cl, g),
+		newCmdTeamListInviteTokens(cl, g),
+		newCmdTeamRevokeInviteToken(cl, g),
 		newCmdTeamGenerateInvitelink(cl, g),
 	}
 	subcommands = append(subcommands, getBuildSpecificTeamCommands(cl, g)...)
@@ -0,0 +1,375 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+func newCmdTeamExternalSync(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "external-sync",
+		Usage:        "Sync team membership from a SCIM endpoint or LDIF export",
+		ArgumentHelp: "[arguments...]",
+		Subcommands: []cli.Command{
+			newCmdTeamExternalSyncRun(cl, g),
+			newCmdTeamExternalSyncSet(cl, g),
+			newCmdTeamExternalSyncClear(cl, g),
+			newCmdTeamExternalSyncList(cl, g),
+		},
+	}
+}
+
+var teamExternalSyncSourceFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "scim",
+		Usage: "Base URL of a SCIM 2.0 endpoint.",
+	},
+	cli.StringFlag{
+		Name:  "scim-token-file",
+		Usage: "File containing the bearer token for the SCIM endpoint.",
+	},
+	cli.StringFlag{
+		Name:  "ldif",
+		Usage: "Path to an LDIF export.",
+	},
+	cli.StringFlag{
+		Name:  "r, role",
+		Value: "reader",
+		Usage: "Role to give new members (reader or writer).",
+	},
+	cli.BoolFlag{
+		Name:  "remove-missing",
+		Usage: "Remove readers and writers who aren't in the directory.",
+	},
+}
+
+func parseTeamExternalSyncConfig(ctx *cli.Context) (config keybase1.TeamExternalSyncConfig, team string, err error) {
+	team, err = ParseOneTeamName(ctx)
+	if err != nil {
+		return config, "", err
+	}
+	scim, ldif := ctx.String("scim"), ctx.String("ldif")
+	switch {
+	case len(scim) > 0 && len(ldif) > 0:
+		return config, "", errors.New("specify only one of --scim or --ldif")
+	case len(scim) > 0:
+		config.SourceType = keybase1.TeamExternalSyncSourceType_SCIM
+		config.Source = scim
+		if tokenFile := ctx.String("scim-token-file"); len(tokenFile) > 0 {
+			token, err := ioutil.ReadFile(tokenFile)
+			if err != nil {
+				return config, "", err
+			}
+			config.BearerToken = strings.TrimSpace(string(token))
+		}
+	case len(ldif) > 0:
+		config.SourceType = keybase1.TeamExternalSyncSourceType_LDIF
+		config.Source = ldif
+	default:
+		return config, "", errors.New("specify one of --scim or --ldif")
+	}
+	config.Role, err = ParseRole(ctx)
+	if err != nil {
+		return config, "", err
+	}
+	config.RemoveMissing = ctx.Bool("remove-missing")
+	return config, team, nil
+}
+
+func teamExternalSyncTeamID(g *libkb.GlobalContext, team string) (keybase1.TeamID, error) {
+	cli, err := GetTeamsClient(g)
+	if err != nil {
+		return "", err
+	}
+	return cli.GetTeamID(context.Background(), team)
+}
+
+type CmdTeamExternalSyncRun struct {
+	libkb.Contextified
+	team   string
+	config keybase1.TeamExternalSyncConfig
+	dryRun bool
+}
+
+func newCmdTeamExternalSyncRun(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "run",
+		ArgumentHelp: "<team name>",
+		Usage:        "Sync team membership once.",
+		Action: func(c *cli.Context) {
+			cmd := &CmdTeamExternalSyncRun{Contextified: libkb.NewContextified(g)}
+			cl.ChooseCommand(cmd, "run", c)
+		},
+		Flags: append([]cli.Flag{
+			cli.BoolFlag{
+				Name:  "n, dry-run",
+				Usage: "Show what would change without changing anything.",
+			},
+		}, teamExternalSyncSourceFlags...),
+		Description: teamExternalSyncDoc,
+	}
+}
+
+func (c *CmdTeamExternalSyncRun) ParseArgv(ctx *cli.Context) (err error) {
+	c.config, c.team, err = parseTeamExternalSyncConfig(ctx)
+	c.dryRun = ctx.Bool("dry-run")
+	return err
+}
+
+func (c *CmdTeamExternalSyncRun) Run() error {
+	cli, err := GetTeamsClient(c.G())
+	if err != nil {
+		return err
+	}
+	c.config.TeamID, err = teamExternalSyncTeamID(c.G(), c.team)
+	if err != nil {
+		return err
+	}
+	report, err := cli.TeamExternalSync(context.Background(), keybase1.TeamExternalSyncArg{
+		Config: c.config,
+		DryRun: c.dryRun,
+	})
+	if err != nil {
+		return err
+	}
+	printTeamExternalSyncReport(c.G(), report)
+	return nil
+}
+
+func (c *CmdTeamExternalSyncRun) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config:    true,
+		API:       true,
+		KbKeyring: true,
+	}
+}
+
+func printTeamExternalSyncReport(g *libkb.GlobalContext, report keybase1.TeamExternalSyncReport) {
+	dui := g.UI.GetTerminalUI()
+	if report.DryRun {
+		dui.Printf("Dry run; no changes were made.\n")
+	}
+	dui.Printf("Directory entries: %d\n", report.SourceCount)
+	dui.Printf("Unchanged members: %d\n", report.UnchangedCount)
+	for _, section := range []struct {
+		title string
+		names []string
+	}{
+		{"Added", report.Added},
+		{"Removed", report.Removed},
+		{"Unresolved emails", report.Unresolved},
+		{"Failures", report.Failures},
+	} {
+		if len(section.names) == 0 {
+			continue
+		}
+		dui.Printf("%s (%d):\n", section.title, len(section.names))
+		for _, name := range section.names {
+			dui.Printf("    %s\n", name)
+		}
+	}
+}
+
+type CmdTeamExternalSyncSet struct {
+	libkb.Contextified
+	team   string
+	config keybase1.TeamExternalSyncConfig
+}
+
+func newCmdTeamExternalSyncSet(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "set",
+		ArgumentHelp: "<team name>",
+		Usage:        "Sync team membership on a schedule.",
+		Action: func(c *cli.Context) {
+			cmd := &CmdTeamExternalSyncSet{Contextified: libkb.NewContextified(g)}
+			cl.ChooseCommand(cmd, "set", c)
+		},
+		Flags: append([]cli.Flag{
+			cli.DurationFlag{
+				Name:  "i, interval",
+				Value: 24 * time.Hour,
+				Usage: "How often to sync.",
+			},
+		}, teamExternalSyncSourceFlags...),
+		Description: teamExternalSyncDoc,
+	}
+}
+
+func (c *CmdTeamExternalSyncSet) ParseArgv(ctx *cli.Context) (err error) {
+	c.config, c.team, err = parseTeamExternalSyncConfig(ctx)
+	if err != nil {
+		return err
+	}
+	interval := ctx.Duration("interval")
+	if interval <= 0 {
+		return errors.New("--interval must be positive")
+	}
+	c.config.Interval = keybase1.DurationSec(interval.Seconds())
+	return nil
+}
+
+func (c *CmdTeamExternalSyncSet) Run() error {
+	cli, err := GetTeamsClient(c.G())
+	if err != nil {
+		return err
+	}
+	c.config.TeamID, err = teamExternalSyncTeamID(c.G(), c.team)
+	if err != nil {
+		return err
+	}
+	return cli.TeamSetExternalSync(context.Background(), keybase1.TeamSetExternalSyncArg{
+		Config: c.config,
+	})
+}
+
+func (c *CmdTeamExternalSyncSet) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config:    true,
+		API:       true,
+		KbKeyring: true,
+	}
+}
+
+type CmdTeamExternalSyncClear struct {
+	libkb.Contextified
+	team string
+}
+
+func newCmdTeamExternalSyncClear(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "clear",
+		ArgumentHelp: "<team name>",
+		Usage:        "Stop syncing team membership.",
+		Action: func(c *cli.Context) {
+			cmd := &CmdTeamExternalSyncClear{Contextified: libkb.NewContextified(g)}
+			cl.ChooseCommand(cmd, "clear", c)
+		},
+	}
+}
+
+func (c *CmdTeamExternalSyncClear) ParseArgv(ctx *cli.Context) (err error) {
+	c.team, err = ParseOneTeamName(ctx)
+	return err
+}
+
+func (c *CmdTeamExternalSyncClear) Run() error {
+	cli, err := GetTeamsClient(c.G())
+	if err != nil {
+		return err
+	}
+	teamID, err := teamExternalSyncTeamID(c.G(), c.team)
+	if err != nil {
+		return err
+	}
+	return cli.TeamClearExternalSync(context.Background(), keybase1.TeamClearExternalSyncArg{
+		TeamID: teamID,
+	})
+}
+
+func (c *CmdTeamExternalSyncClear) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config:    true,
+		API:       true,
+		KbKeyring: true,
+	}
+}
+
+type CmdTeamExternalSyncList struct {
+	libkb.Contextified
+}
+
+func newCmdTeamExternalSyncList(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:  "list",
+		Usage: "List scheduled team membership syncs.",
+		Action: func(c *cli.Context) {
+			cmd := &CmdTeamExternalSyncList{Contextified: libkb.NewContextified(g)}
+			cl.ChooseCommand(cmd, "list", c)
+		},
+	}
+}
+
+func (c *CmdTeamExternalSyncList) ParseArgv(ctx *cli.Context) error {
+	if len(ctx.Args()) != 0 {
+		return errors.New("list takes no arguments")
+	}
+	return nil
+}
+
+func (c *CmdTeamExternalSyncList) Run() error {
+	cli, err := GetTeamsClient(c.G())
+	if err != nil {
+		return err
+	}
+	syncs, err := cli.TeamListExternalSyncs(context.Background(), 0)
+	if err != nil {
+		return err
+	}
+	dui := c.G().UI.GetTerminalUI()
+	if len(syncs) == 0 {
+		dui.Printf("No external syncs configured.\n")
+		return nil
+	}
+	for _, state := range syncs {
+		dui.Printf("%s: %s %s as %s every %s\n", state.Config.TeamID,
+			state.Config.SourceType, state.Config.Source, strings.ToLower(state.Config.Role.String()),
+			state.Config.Interval.Duration())
+		if state.LastRun == 0 {
+			dui.Printf("    never run\n")
+			continue
+		}
+		dui.Printf("    last run: %s\n", state.LastRun.Time().Format(time.RFC1123))
+		if len(state.LastError) > 0 {
+			dui.Printf("    last error: %s\n", state.LastError)
+		} else if state.LastReport != nil {
+			dui.Printf("    added %d, removed %d, unresolved %d, failures %d\n",
+				len(state.LastReport.Added), len(state.LastReport.Removed),
+				len(state.LastReport.Unresolved), len(state.LastReport.Failures))
+		}
+	}
+	return nil
+}
+
+func (c *CmdTeamExternalSyncList) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config:    true,
+		API:       true,
+		KbKeyring: true,
+	}
+}
+
+const teamExternalSyncDoc = `"keybase team external-sync" keeps a team's membership in line with
+a directory you already maintain, matching directory entries to Keybase
+users by email address. Only users who have verified that email on
+Keybase can be matched.
+
+EXAMPLES:
+
+Preview a sync from an LDAP export:
+
+    keybase team external-sync run acme --ldif=people.ldif --dry-run
+
+Add everyone in a SCIM directory as writers once:
+
+    keybase team external-sync run acme --scim=https://idp.example.com/scim/v2 \
+        --scim-token-file=token.txt --role=writer
+
+Sync every day, removing readers and writers who leave the directory:
+
+    keybase team external-sync set acme --scim=https://idp.example.com/scim/v2 \
+        --scim-token-file=token.txt --remove-missing --interval=24h
+
+Owners, admins and bots are never removed by a sync.
+`
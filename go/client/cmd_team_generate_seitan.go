@@ -19,6 +19,9 @@ type CmdTeamGenerateSeitan struct {
 	Role     keybase1.TeamRole
 	FullName string
 	Number   string
+	Channels []string
+	// WelcomeDM is sent to the invitee once the token is redeemed.
+	WelcomeDM string
 }
 
 func newCmdTeamGenerateSeitan(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
@@ -43,6 +46,14 @@ func newCmdTeamGenerateSeitan(cl *libcmdline.CommandLine, g *libkb.GlobalContext
 				Name:  "number",
 				Usage: "invitee's phone number",
 			},
+			cli.StringSliceFlag{
+				Name:  "channel",
+				Usage: "channel to add the invitee to once they join; can be specified multiple times",
+			},
+			cli.StringFlag{
+				Name:  "welcome-dm",
+				Usage: "message to send the invitee once they join; {username} and {team} are filled in",
+			},
 		},
 		Description: teamGenerateSeitanDoc,
 	}
@@ -69,6 +80,8 @@ func (c *CmdTeamGenerateSeitan) ParseArgv(ctx *cli.Context) error {
 
 	c.FullName = ctx.String("fullname")
 	c.Number = ctx.String("number")
+	c.Channels = ctx.StringSlice("channel")
+	c.WelcomeDM = ctx.String("welcome-dm")
 
 	return nil
 }
@@ -83,15 +96,32 @@ func (c *CmdTeamGenerateSeitan) Run() error {
 	labelSms.F = c.FullName
 	labelSms.N = c.Number
 
-	arg := keybase1.TeamCreateSeitanTokenV2Arg{
-		Teamname: c.Team,
-		Role:     c.Role,
-		Label:    keybase1.NewSeitanKeyLabelWithSms(labelSms),
-	}
-
-	res, err := cli.TeamCreateSeitanTokenV2(context.Background(), arg)
-	if err != nil {
-		return err
+	label := keybase1.NewSeitanKeyLabelWithSms(labelSms)
+
+	var res keybase1.SeitanIKeyV2
+	if len(c.Channels) > 0 || len(c.WelcomeDM) > 0 {
+		withExtras, err := cli.TeamCreateSeitanTokenWithExtras(context.Background(),
+			keybase1.TeamCreateSeitanTokenWithExtrasArg{
+				Teamname:          c.Team,
+				Role:              c.Role,
+				Label:             label,
+				Channels:          c.Channels,
+				WelcomeDMTemplate: c.WelcomeDM,
+			})
+		if err != nil {
+			return err
+		}
+		res = withExtras.Ikey
+	} else {
+		arg := keybase1.TeamCreateSeitanTokenV2Arg{
+			Teamname: c.Team,
+			Role:     c.Role,
+			Label:    label,
+		}
+		res, err = cli.TeamCreateSeitanTokenV2(context.Background(), arg)
+		if err != nil {
+			return err
+		}
 	}
 
 	dui := c.G().UI.GetDumbOutputUI()
@@ -115,4 +145,9 @@ Optionally, full name and phone number can be provided (using
 --fullname and --number flags) to label created token to make them
 easier to distinguish. Label data is encrypted and visible only to
 admins.
+
+With --channel and --welcome-dm, the new member is also added to the
+given channels and sent a DM when they join. These are kept on this
+device, so they're only applied if this device is the one that adds
+the new member. Use "keybase team list-invite-tokens" to see them.
 `
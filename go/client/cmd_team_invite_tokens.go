@@ -0,0 +1,138 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+type CmdTeamListInviteTokens struct {
+	libkb.Contextified
+	team string
+}
+
+func newCmdTeamListInviteTokens(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "list-invite-tokens",
+		ArgumentHelp: "<team name>",
+		Usage:        "List outstanding invite tokens that add to channels or send a welcome DM.",
+		Action: func(c *cli.Context) {
+			cmd := &CmdTeamListInviteTokens{Contextified: libkb.NewContextified(g)}
+			cl.ChooseCommand(cmd, "list-invite-tokens", c)
+		},
+	}
+}
+
+func (c *CmdTeamListInviteTokens) ParseArgv(ctx *cli.Context) (err error) {
+	c.team, err = ParseOneTeamName(ctx)
+	return err
+}
+
+func (c *CmdTeamListInviteTokens) Run() error {
+	cli, err := GetTeamsClient(c.G())
+	if err != nil {
+		return err
+	}
+	teamID, err := cli.GetTeamID(context.Background(), c.team)
+	if err != nil {
+		return err
+	}
+	res, err := cli.TeamListSeitanInviteExtras(context.Background(), keybase1.TeamListSeitanInviteExtrasArg{
+		TeamID: teamID,
+	})
+	if err != nil {
+		return err
+	}
+	dui := c.G().UI.GetTerminalUI()
+	if len(res) == 0 {
+		dui.Printf("No invite tokens with channels or a welcome DM.\n")
+		return nil
+	}
+	for _, extras := range res {
+		dui.Printf("%s: %s, created %s\n", extras.InviteID,
+			strings.ToLower(extras.Role.String()), extras.Ctime.Time().Format("2006-01-02 15:04"))
+		if len(extras.Channels) > 0 {
+			dui.Printf("    channels: #%s\n", strings.Join(extras.Channels, ", #"))
+		}
+		if len(extras.WelcomeDMTemplate) > 0 {
+			dui.Printf("    welcome DM: %q\n", extras.WelcomeDMTemplate)
+		}
+	}
+	return nil
+}
+
+func (c *CmdTeamListInviteTokens) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config:    true,
+		API:       true,
+		KbKeyring: true,
+	}
+}
+
+type CmdTeamRevokeInviteToken struct {
+	libkb.Contextified
+	team     string
+	inviteID keybase1.TeamInviteID
+}
+
+func newCmdTeamRevokeInviteToken(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "revoke-invite-token",
+		ArgumentHelp: "<team name> --invite-id <id>",
+		Usage:        "Cancel an invite token.",
+		Action: func(c *cli.Context) {
+			cmd := &CmdTeamRevokeInviteToken{Contextified: libkb.NewContextified(g)}
+			cl.ChooseCommand(cmd, "revoke-invite-token", c)
+		},
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "i, invite-id",
+				Usage: "ID of the invite, as shown by list-invite-tokens",
+			},
+		},
+	}
+}
+
+func (c *CmdTeamRevokeInviteToken) ParseArgv(ctx *cli.Context) (err error) {
+	c.team, err = ParseOneTeamName(ctx)
+	if err != nil {
+		return err
+	}
+	inviteID := ctx.String("invite-id")
+	if len(inviteID) == 0 {
+		return errors.New("--invite-id is required")
+	}
+	c.inviteID, err = keybase1.TeamInviteIDFromString(inviteID)
+	return err
+}
+
+func (c *CmdTeamRevokeInviteToken) Run() error {
+	cli, err := GetTeamsClient(c.G())
+	if err != nil {
+		return err
+	}
+	teamID, err := cli.GetTeamID(context.Background(), c.team)
+	if err != nil {
+		return err
+	}
+	return cli.TeamRevokeSeitanInvite(context.Background(), keybase1.TeamRevokeSeitanInviteArg{
+		TeamID:   teamID,
+		InviteID: c.inviteID,
+	})
+}
+
+func (c *CmdTeamRevokeInviteToken) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config:    true,
+		API:       true,
+		KbKeyring: true,
+	}
+}
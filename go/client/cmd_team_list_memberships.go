@@ -20,6 +20,7 @@ type CmdTeamListMemberships struct {
 	userAssertion        string
 	includeImplicitTeams bool
 	showAll              bool
+	allUsers             bool
 	verbose              bool
 	showInviteID         bool
 	verified             bool
@@ -51,6 +52,10 @@ func newCmdTeamListMemberships(cl *libcmdline.CommandLine, g *libkb.GlobalContex
 			Name:  "all",
 			Usage: "Show all members of all teams you belong to",
 		},
+		cli.BoolFlag{
+			Name:  "all-users",
+			Usage: "Show which subteams of the team each of its members is in (admins only)",
+		},
 		cli.BoolFlag{
 			Name:  "show-invite-id",
 			Usage: "Show invite IDs",
@@ -71,7 +76,7 @@ func newCmdTeamListMemberships(cl *libcmdline.CommandLine, g *libkb.GlobalContex
 	}
 	return cli.Command{
 		Name:         "list-memberships",
-		ArgumentHelp: "[team name] [--user=username]",
+		ArgumentHelp: "[team name] [--user=username] [--all-users]",
 		Aliases:      []string{"list-members"},
 		Usage:        "List your teams, or people on a team.",
 		Action: func(c *cli.Context) {
@@ -93,6 +98,7 @@ func (c *CmdTeamListMemberships) ParseArgv(ctx *cli.Context) error {
 	c.includeImplicitTeams = ctx.Bool("include-implicit-teams")
 	c.verified = ctx.Bool("verified")
 	c.showAll = ctx.Bool("all")
+	c.allUsers = ctx.Bool("all-users")
 	c.showInviteID = ctx.Bool("show-invite-id")
 
 	if c.showAll {
@@ -104,6 +110,15 @@ func (c *CmdTeamListMemberships) ParseArgv(ctx *cli.Context) error {
 		}
 	}
 
+	if c.allUsers {
+		if c.team == "" {
+			return errors.New("--all-users needs a team name")
+		}
+		if c.userAssertion != "" {
+			return errors.New("cannot specify a user and --all-users, please choose one")
+		}
+	}
+
 	c.json = ctx.Bool("json")
 	c.verbose = ctx.Bool("verbose")
 
@@ -116,6 +131,9 @@ func (c *CmdTeamListMemberships) Run() error {
 		return err
 	}
 
+	if c.allUsers {
+		return c.runSubteamCoverage(cli)
+	}
 	if c.team != "" {
 		return c.runGet(cli)
 	}
@@ -133,6 +151,18 @@ func (c *CmdTeamListMemberships) runGet(cli keybase1.TeamsClient) error {
 	return renderer.output(res, c.team, c.verbose)
 }
 
+func (c *CmdTeamListMemberships) runSubteamCoverage(cli keybase1.TeamsClient) error {
+	res, err := cli.TeamGetSubteamCoverage(context.Background(), keybase1.TeamGetSubteamCoverageArg{
+		TeamName: c.team,
+	})
+	if err != nil {
+		return err
+	}
+
+	renderer := newTeamMembersRenderer(c.G(), c.json, c.showInviteID)
+	return renderer.outputSubteamCoverage(res)
+}
+
 func (c *CmdTeamListMemberships) runUser(cli keybase1.TeamsClient) error {
 	var err error
 	var list keybase1.AnnotatedTeamList
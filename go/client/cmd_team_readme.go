@@ -0,0 +1,168 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+)
+
+type CmdTeamReadme struct {
+	libkb.Contextified
+	team string
+
+	// These are non-nil when they're being changed.
+	description *string
+	links       []string
+	welcomeText *string
+	clearLinks  bool
+	clear       bool
+}
+
+func newCmdTeamReadme(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "readme",
+		ArgumentHelp: "<team name>",
+		Usage:        "Show or edit the readme DM'd to new team members.",
+		Examples: `
+Show the readme:
+    keybase team readme acme
+Set the description and welcome text:
+    keybase team readme acme --description="Rocket-powered products" --welcome-text="Say hi in #general!"
+Replace the links:
+    keybase team readme acme --link=https://acme.example/handbook --link=https://acme.example/faq
+Remove the readme, so new members aren't sent anything:
+    keybase team readme acme --clear
+`,
+		Action: func(c *cli.Context) {
+			cmd := &CmdTeamReadme{Contextified: libkb.NewContextified(g)}
+			cl.ChooseCommand(cmd, "readme", c)
+		},
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "description",
+				Usage: "Set what the team is about",
+			},
+			cli.StringSliceFlag{
+				Name:  "link",
+				Usage: "Set the links, replacing any there were; can be given more than once",
+				Value: &cli.StringSlice{},
+			},
+			cli.BoolFlag{
+				Name:  "clear-links",
+				Usage: "Remove the links",
+			},
+			cli.StringFlag{
+				Name:  "welcome-text",
+				Usage: "Set the text that starts the DM",
+			},
+			cli.BoolFlag{
+				Name:  "clear",
+				Usage: "Remove the whole readme",
+			},
+		},
+	}
+}
+
+func (c *CmdTeamReadme) ParseArgv(ctx *cli.Context) (err error) {
+	c.team, err = ParseOneTeamName(ctx)
+	if err != nil {
+		return err
+	}
+	if ctx.IsSet("description") {
+		description := ctx.String("description")
+		c.description = &description
+	}
+	if ctx.IsSet("welcome-text") {
+		welcomeText := ctx.String("welcome-text")
+		c.welcomeText = &welcomeText
+	}
+	c.links = ctx.StringSlice("link")
+	c.clearLinks = ctx.Bool("clear-links")
+	c.clear = ctx.Bool("clear")
+	if c.clearLinks && len(c.links) > 0 {
+		return errors.New("--link and --clear-links can't be given together")
+	}
+	if c.clear && (c.description != nil || c.welcomeText != nil || len(c.links) > 0 || c.clearLinks) {
+		return errors.New("--clear can't be given with anything else")
+	}
+	return nil
+}
+
+func (c *CmdTeamReadme) editing() bool {
+	return c.clear || c.description != nil || c.welcomeText != nil || len(c.links) > 0 || c.clearLinks
+}
+
+func (c *CmdTeamReadme) Run() error {
+	ctx := context.Background()
+	teamsCli, err := GetTeamsClient(c.G())
+	if err != nil {
+		return err
+	}
+	teamID, err := teamsCli.GetTeamID(ctx, c.team)
+	if err != nil {
+		return err
+	}
+	if err := CheckAndStartStandaloneChat(c.G(), chat1.ConversationMembersType_TEAM); err != nil {
+		return err
+	}
+	cli, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return err
+	}
+
+	readme, err := cli.GetTeamReadme(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	if c.editing() {
+		switch {
+		case c.clear:
+			readme = chat1.TeamReadme{}
+		default:
+			if c.description != nil {
+				readme.Description = *c.description
+			}
+			if c.welcomeText != nil {
+				readme.WelcomeText = *c.welcomeText
+			}
+			if len(c.links) > 0 || c.clearLinks {
+				readme.Links = c.links
+			}
+		}
+		return cli.SetTeamReadme(ctx, chat1.SetTeamReadmeArg{
+			TeamID: teamID,
+			Readme: readme,
+		})
+	}
+
+	dui := c.G().UI.GetTerminalUI()
+	if len(readme.Description) == 0 && len(readme.WelcomeText) == 0 && len(readme.Links) == 0 {
+		dui.Printf("%s has no readme.\n", c.team)
+		return nil
+	}
+	if len(readme.Description) > 0 {
+		dui.Printf("Description:  %s\n", readme.Description)
+	}
+	if len(readme.WelcomeText) > 0 {
+		dui.Printf("Welcome text: %s\n", readme.WelcomeText)
+	}
+	for _, link := range readme.Links {
+		dui.Printf("Link:         %s\n", link)
+	}
+	return nil
+}
+
+func (c *CmdTeamReadme) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config:    true,
+		API:       true,
+		KbKeyring: true,
+	}
+}
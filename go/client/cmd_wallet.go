@@ -19,6 +19,7 @@ func newCmdWallet(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Comman
 		// newCmdWalletCancelAll(cl, g),
 		// newCmdWalletCancelRequest(cl, g),
 		// newCmdWalletChangeTrustlineLimit(cl, g),
+		// newCmdWalletDeclineRequest(cl, g),
 		// newCmdWalletDeleteTrustline(cl, g),
 		newCmdWalletDetail(cl, g),
 		newCmdWalletExport(cl, g),
@@ -31,6 +32,7 @@ func newCmdWallet(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Comman
 		// newCmdWalletPopularAssets(cl, g),
 		// newCmdWalletRename(cl, g),
 		// newCmdWalletRequest(cl, g),
+		// newCmdWalletRequests(cl, g),
 		// newCmdWalletSend(cl, g),
 		// newCmdWalletSendPathPayment(cl, g),
 		// newCmdWalletSetCurrency(cl, g),
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"errors"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/stellar1"
+	"golang.org/x/net/context"
+)
+
+type CmdWalletDeclineRequest struct {
+	libkb.Contextified
+	ID string
+}
+
+func newCmdWalletDeclineRequest(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	cmd := &CmdWalletDeclineRequest{
+		Contextified: libkb.NewContextified(g),
+	}
+	return cli.Command{
+		Name:         "decline-request",
+		Usage:        "Decline a payment request made of you",
+		ArgumentHelp: "<request id>",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(cmd, "decline-request", c)
+		},
+	}
+}
+
+func (c *CmdWalletDeclineRequest) ParseArgv(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		return errors.New("decline-request needs request id as the argument")
+	}
+
+	c.ID = ctx.Args()[0]
+	return nil
+}
+
+func (c *CmdWalletDeclineRequest) Run() (err error) {
+	defer transformStellarCLIError(&err)
+	cli, err := GetWalletClient(c.G())
+	if err != nil {
+		return err
+	}
+
+	requestID, err := stellar1.KeybaseRequestIDFromString(c.ID)
+	if err != nil {
+		return err
+	}
+
+	return cli.DeclineRequestLocal(context.Background(), stellar1.DeclineRequestLocalArg{
+		ReqID: requestID,
+	})
+}
+
+func (c *CmdWalletDeclineRequest) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config:    true,
+		API:       true,
+		KbKeyring: true,
+	}
+}
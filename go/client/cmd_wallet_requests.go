@@ -0,0 +1,72 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"golang.org/x/net/context"
+)
+
+type CmdWalletRequests struct {
+	libkb.Contextified
+}
+
+func newCmdWalletRequests(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	cmd := &CmdWalletRequests{
+		Contextified: libkb.NewContextified(g),
+	}
+	return cli.Command{
+		Name:  "requests",
+		Usage: "List payment requests waiting to be paid, made by you or of you",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(cmd, "requests", c)
+		},
+	}
+}
+
+func (c *CmdWalletRequests) ParseArgv(ctx *cli.Context) error {
+	if len(ctx.Args()) != 0 {
+		return errors.New("expected no arguments")
+	}
+	return nil
+}
+
+func (c *CmdWalletRequests) Run() (err error) {
+	defer transformStellarCLIError(&err)
+	cli, err := GetWalletClient(c.G())
+	if err != nil {
+		return err
+	}
+
+	requests, err := cli.GetPendingRequestsLocal(context.Background(), 0)
+	if err != nil {
+		return err
+	}
+
+	dui := c.G().UI.GetDumbOutputUI()
+	if len(requests) == 0 {
+		dui.Printf("No pending payment requests.\n")
+		return nil
+	}
+	for _, request := range requests {
+		var who string
+		if request.FromCurrentUser {
+			who = fmt.Sprintf("you asked %s for", request.ToAssertion)
+		} else {
+			who = fmt.Sprintf("%s asked you for", request.FromAssertion)
+		}
+		dui.Printf("%s\t%s %s\n", request.Id, who, request.AmountDescription)
+	}
+	return nil
+}
+
+func (c *CmdWalletRequests) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config:    true,
+		API:       true,
+		KbKeyring: true,
+	}
+}
@@ -24,6 +24,7 @@ func GetCommands(cl *libcmdline.CommandLine, g *libkb.GlobalContext) []cli.Comma
 		NewCmdChat(cl, g),
 		NewCmdCompatDir(cl, g),
 		NewCmdCompatPush(cl, g),
+		NewCmdCompletion(cl, g),
 		NewCmdConfig(cl, g),
 		NewCmdCtl(cl, g),
 		NewCmdCurrency(cl, g),
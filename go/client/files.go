@@ -247,12 +247,20 @@ type FileSink struct {
 	opened bool
 	closed bool
 	failed bool
+	append bool
 }
 
 func NewFileSink(g *libkb.GlobalContext, s string) *FileSink {
 	return &FileSink{Contextified: libkb.NewContextified(g), name: s}
 }
 
+// NewFileSinkAppend makes a FileSink that appends to the file instead of
+// truncating it, and leaves what was written in place on error so the
+// write can be resumed.
+func NewFileSinkAppend(g *libkb.GlobalContext, s string) *FileSink {
+	return &FileSink{Contextified: libkb.NewContextified(g), name: s, append: true}
+}
+
 func (s *FileSink) Open() error {
 	// Lazy-open on first write
 	return nil
@@ -266,6 +274,9 @@ func (s *FileSink) lazyOpen() error {
 		err = fmt.Errorf("open previously failed")
 	} else if !s.opened {
 		flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+		if s.append {
+			flags = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+		}
 		mode := libkb.UmaskablePermFile
 		f, err := os.OpenFile(s.name, flags, mode)
 		if err != nil {
@@ -305,7 +316,7 @@ func (s *FileSink) Close() error {
 
 func (s *FileSink) HitError(e error) error {
 	var err error
-	if e != nil && s.opened {
+	if e != nil && s.opened && !s.append {
 		s.G().Log.Debug("Deleting file %s after error %s", s.name, e)
 		err = os.Remove(s.name)
 	}
@@ -313,6 +324,18 @@ func (s *FileSink) HitError(e error) error {
 
 }
 
+// countingSink counts the bytes written through to the wrapped Sink.
+type countingSink struct {
+	Sink
+	n int64
+}
+
+func (s *countingSink) Write(b []byte) (n int, err error) {
+	n, err = s.Sink.Write(b)
+	s.n += int64(n)
+	return n, err
+}
+
 type UnixFilter struct {
 	libkb.Contextified
 	sink    Sink
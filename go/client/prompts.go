@@ -74,6 +74,9 @@ const (
 	PromptDescriptorStellarURIAmount
 	PromptDescriptorAccountDeleteConfirmation
 	PromptDescriptorChatEmojiRemove
+	PromptDescriptorChatArchiveExportPlaintext
+	PromptDescriptorChatBulkDelete
+	PromptDescriptorChatArchivePassphrase
 )
 
 const (
@@ -83,4 +86,5 @@ const (
 	OutputDescriptorHomeDump
 	OutputDescriptorBadgeDump
 	OutputDescriptorTeamList
+	OutputDescriptorChatList
 )
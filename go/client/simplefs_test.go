@@ -134,6 +134,13 @@ func (s SimpleFSMock) SimpleFSGetRevisions(
 	return nil
 }
 
+// SimpleFSFileHistory - Get the modification history of a single file
+func (s SimpleFSMock) SimpleFSFileHistory(
+	_ context.Context, _ keybase1.SimpleFSFileHistoryArg) (
+	keybase1.SimpleFSFileHistory, error) {
+	return keybase1.SimpleFSFileHistory{}, nil
+}
+
 // SimpleFSReadRevisions - Get list of revisions in progress. Can
 // indicate status of pending to get more entries.
 func (s SimpleFSMock) SimpleFSReadRevisions(
@@ -240,6 +247,18 @@ func (s SimpleFSMock) SimpleFSGetTeamQuotaUsage(
 	return keybase1.SimpleFSQuotaUsage{}, nil
 }
 
+// SimpleFSGetQuotaAlertSettings implements the SimpleFSInterface.
+func (s SimpleFSMock) SimpleFSGetQuotaAlertSettings(_ context.Context) (
+	keybase1.SimpleFSQuotaAlertSettings, error) {
+	return keybase1.SimpleFSQuotaAlertSettings{}, nil
+}
+
+// SimpleFSSetQuotaAlertSettings implements the SimpleFSInterface.
+func (s SimpleFSMock) SimpleFSSetQuotaAlertSettings(
+	_ context.Context, _ keybase1.SimpleFSQuotaAlertSettings) error {
+	return nil
+}
+
 // SimpleFSGetFolder implements the SimpleFSInterface.
 func (s SimpleFSMock) SimpleFSGetFolder(
 	_ context.Context, _ keybase1.KBFSPath) (
@@ -443,6 +462,121 @@ func (k SimpleFSMock) SimpleFSGetArchiveStatus(ctx context.Context) (
 	return keybase1.SimpleFSArchiveStatus{}, nil
 }
 
+func (k SimpleFSMock) SimpleFSArchiveSearchJobs(ctx context.Context,
+	query string) ([]keybase1.SimpleFSArchiveJobStatus, error) {
+	return nil, nil
+}
+
+func (k SimpleFSMock) SimpleFSArchiveListAllDevices(ctx context.Context) (
+	[]keybase1.SimpleFSArchiveJobSummary, error) {
+	return nil, nil
+}
+
+func (k SimpleFSMock) SimpleFSLock(ctx context.Context,
+	arg keybase1.SimpleFSLockArg) (info keybase1.SimpleFSLockInfo, err error) {
+	return keybase1.SimpleFSLockInfo{}, nil
+}
+
+func (k SimpleFSMock) SimpleFSUnlock(ctx context.Context,
+	path keybase1.KBFSPath) (err error) {
+	return nil
+}
+
+func (k SimpleFSMock) SimpleFSListLocks(ctx context.Context) (
+	[]keybase1.SimpleFSLockInfo, error) {
+	return nil, nil
+}
+
+func (k SimpleFSMock) SimpleFSArchiveSetBytesPerSecond(ctx context.Context,
+	arg keybase1.SimpleFSArchiveSetBytesPerSecondArg) (err error) {
+	return nil
+}
+
+func (k SimpleFSMock) SimpleFSArchiveSchedule(ctx context.Context,
+	arg keybase1.SimpleFSArchiveScheduleArg) (keybase1.SimpleFSArchiveSchedule, error) {
+	return keybase1.SimpleFSArchiveSchedule{}, nil
+}
+
+func (k SimpleFSMock) SimpleFSArchiveUnschedule(ctx context.Context,
+	scheduleID string) (err error) {
+	return nil
+}
+
+func (k SimpleFSMock) SimpleFSArchiveCheckArchive(ctx context.Context,
+	jobID string) (keybase1.SimpleFSArchiveCheckArchiveResult, error) {
+	return keybase1.SimpleFSArchiveCheckArchiveResult{}, nil
+}
+
+func (k SimpleFSMock) SimpleFSArchiveVerifyJob(ctx context.Context,
+	arg keybase1.SimpleFSArchiveVerifyJobArg) (keybase1.SimpleFSArchiveCheckArchiveResult, error) {
+	return keybase1.SimpleFSArchiveCheckArchiveResult{}, nil
+}
+
+func (k SimpleFSMock) SimpleFSArchiveRestore(ctx context.Context,
+	arg keybase1.SimpleFSArchiveRestoreArg) (keybase1.SimpleFSArchiveJobDesc, error) {
+	return keybase1.SimpleFSArchiveJobDesc{}, nil
+}
+
+func (k SimpleFSMock) SimpleFSArchiveReadInfo(ctx context.Context,
+	zipFilePath string) (keybase1.SimpleFSArchiveInfo, error) {
+	return keybase1.SimpleFSArchiveInfo{}, nil
+}
+
+func (k SimpleFSMock) SimpleFSArchiveGetStagingUsage(ctx context.Context) (
+	keybase1.SimpleFSArchiveStagingUsage, error) {
+	return keybase1.SimpleFSArchiveStagingUsage{}, nil
+}
+
+func (k SimpleFSMock) SimpleFSArchiveSetMaxStagingBytes(ctx context.Context,
+	maxStagingBytes int64) (err error) {
+	return nil
+}
+
+func (k SimpleFSMock) SimpleFSRepairCache(ctx context.Context,
+	rebuild bool) (err error) {
+	return nil
+}
+
+func (k SimpleFSMock) SimpleFSGetCacheRepairProgress(
+	ctx context.Context) (keybase1.SimpleFSCacheRepairProgress, error) {
+	return keybase1.SimpleFSCacheRepairProgress{}, nil
+}
+
+func (k SimpleFSMock) SimpleFSArchiveSetRetention(ctx context.Context,
+	retention keybase1.SimpleFSArchiveRetention) (err error) {
+	return nil
+}
+
+func (k SimpleFSMock) SimpleFSArchiveSetLabel(ctx context.Context,
+	arg keybase1.SimpleFSArchiveSetLabelArg) (err error) {
+	return nil
+}
+
+func (k SimpleFSMock) SimpleFSArchiveCheckParts(ctx context.Context,
+	partsManifestPath string) (keybase1.SimpleFSArchiveCheckArchiveResult, error) {
+	return keybase1.SimpleFSArchiveCheckArchiveResult{}, nil
+}
+
+func (k SimpleFSMock) SimpleFSArchivePauseJob(ctx context.Context,
+	jobID string) (err error) {
+	return nil
+}
+
+func (k SimpleFSMock) SimpleFSArchiveResumeJob(ctx context.Context,
+	jobID string) (err error) {
+	return nil
+}
+
+func (k SimpleFSMock) SimpleFSArchiveRetryJob(ctx context.Context,
+	jobID string) (err error) {
+	return nil
+}
+
+func (k SimpleFSMock) SimpleFSArchiveDrain(ctx context.Context,
+	timeout keybase1.DurationSec) (err error) {
+	return nil
+}
+
 /*
  file source cases:
  1. file
@@ -184,3 +184,48 @@ func (c *teamMembersRenderer) outputTeams(list keybase1.AnnotatedTeamList, showA
 	c.tabw.Flush()
 	return nil
 }
+
+// outputSubteamCoverage prints a matrix of the team's members by its
+// subteams, with each member's role in each subteam, or "-" where they're
+// missing from it.
+func (c *teamMembersRenderer) outputSubteamCoverage(res keybase1.TeamSubteamCoverage) error {
+	if c.json {
+		b, err := json.MarshalIndent(res, "", "    ")
+		if err != nil {
+			return err
+		}
+		dui := c.G().UI.GetDumbOutputUI()
+		_, err = dui.Printf(string(b) + "\n")
+		return err
+	}
+
+	dui := c.G().UI.GetTerminalUI()
+	if len(res.Subteams) == 0 {
+		dui.Printf("%s has no subteams.\n", res.Team)
+		return nil
+	}
+	c.tabw = new(tabwriter.Writer)
+	c.tabw.Init(dui.OutputWriter(), 0, 8, 2, ' ', 0)
+
+	fmt.Fprintf(c.tabw, "USER\t%s", res.Team)
+	for _, subteam := range res.Subteams {
+		fmt.Fprintf(c.tabw, "\t%s", subteam)
+	}
+	fmt.Fprintf(c.tabw, "\n")
+	for _, member := range res.Members {
+		roles := make(map[string]keybase1.TeamRole, len(member.MemberOf))
+		for _, m := range member.MemberOf {
+			roles[m.Subteam.String()] = m.Role
+		}
+		fmt.Fprintf(c.tabw, "%s\t%s", member.Username, member.Role.HumanString())
+		for _, subteam := range res.Subteams {
+			cell := "-"
+			if role, ok := roles[subteam.String()]; ok {
+				cell = role.HumanString()
+			}
+			fmt.Fprintf(c.tabw, "\t%s", cell)
+		}
+		fmt.Fprintf(c.tabw, "\n")
+	}
+	return c.tabw.Flush()
+}
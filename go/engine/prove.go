@@ -32,6 +32,11 @@ type Prove struct {
 
 	remoteNameNormalized string
 
+	// Set if the proof is published by a provisioner rather than by hand.
+	provisioner proofProvisioner
+	placement   proofPlacement
+	proofText   string
+
 	libkb.Contextified
 }
 
@@ -169,6 +174,10 @@ func (p *Prove) doPrechecks(m libkb.MetaContext) (err error) {
 }
 
 func (p *Prove) doWarnings(m libkb.MetaContext) (err error) {
+	if p.provisioner != nil {
+		// The user isn't going to post anything.
+		return nil
+	}
 	if mu := p.serviceType.PreProofWarning(p.remoteNameNormalized); mu != nil {
 		var ok bool
 		arg := keybase1.PreProofWarningArg{Text: mu.Export()}
@@ -245,6 +254,10 @@ func (p *Prove) instructAction(m libkb.MetaContext) (err error) {
 	if txt, err = p.serviceType.FormatProofText(m, p.postRes, p.me.GetNormalizedName().String(), p.remoteNameNormalized, p.sigID); err != nil {
 		return err
 	}
+	if p.provisioner != nil {
+		p.proofText = txt
+		return nil
+	}
 	err = m.UIs().ProveUI.OutputInstructions(m.Ctx(), keybase1.OutputInstructionsArg{
 		Instructions: mkp.Export(),
 		// If we don't trim newlines here, we'll run into an issue where e.g.
@@ -387,6 +400,31 @@ func (p *Prove) getServiceType(m libkb.MetaContext) (err error) {
 	return nil
 }
 
+func (p *Prove) checkProvisioner(m libkb.MetaContext) (err error) {
+	if p.arg.Provisioner == nil {
+		return nil
+	}
+	if p.placement, err = proofPlacementFor(p.serviceType); err != nil {
+		return err
+	}
+	p.provisioner, err = newProofProvisioner(m, *p.arg.Provisioner)
+	return err
+}
+
+// provision publishes the proof and waits until it can be seen.
+func (p *Prove) provision(m libkb.MetaContext) (err error) {
+	hostname, err := proofHostname(p.placement, p.remoteNameNormalized)
+	if err != nil {
+		return err
+	}
+	m.UIs().LogUI.Info("Publishing the proof for %s", hostname)
+	if err = p.provisioner.publish(m, p.placement, hostname, p.proofText); err != nil {
+		return err
+	}
+	m.UIs().LogUI.Info("Waiting for the proof to be visible")
+	return waitForProofPropagation(m, p.placement, p.remoteNameNormalized, hostname, p.proofText)
+}
+
 // SigID returns the signature id of the proof posted to the
 // server.
 func (p *Prove) SigID() keybase1.SigID {
@@ -405,6 +443,10 @@ func (p *Prove) Run(m libkb.MetaContext) (err error) {
 	if err = p.getServiceType(m); err != nil {
 		return err
 	}
+	stage("CheckProvisioner")
+	if err = p.checkProvisioner(m); err != nil {
+		return err
+	}
 	stage("LoadMe")
 	if err = p.loadMe(m); err != nil {
 		return err
@@ -449,6 +491,19 @@ func (p *Prove) Run(m libkb.MetaContext) (err error) {
 		return err
 	}
 
+	if p.provisioner != nil {
+		stage("Provision")
+		if err = p.provision(m); err != nil {
+			return err
+		}
+		stage("VerifyLoop")
+		if err = p.verifyLoop(m); err != nil {
+			return err
+		}
+		m.UIs().LogUI.Notice("Success!")
+		return nil
+	}
+
 	if !p.arg.PromptPosted {
 		m.Debug("PromptPosted not set, prove run finished")
 		return nil
@@ -0,0 +1,331 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package engine
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	libkb "github.com/keybase/client/go/libkb"
+	keybase1 "github.com/keybase/client/go/protocol/keybase1"
+)
+
+// A DNS or web proof can be published by a provisioner rather than by hand.
+// Cloudflare adds the proof's TXT record with the user's API token, and a
+// webhook is sent the proof and trusted to publish it wherever it goes.
+// Either way, the proof is then looked for where the checker will look,
+// until it's visible from here, before waiting on the server's check.
+
+type proofPlacement int
+
+const (
+	// A TXT record on _keybase.<domain>.
+	proofPlacementDNS proofPlacement = iota
+	// <site>/.well-known/keybase.txt
+	proofPlacementWellKnown
+)
+
+func (p proofPlacement) String() string {
+	switch p {
+	case proofPlacementDNS:
+		return "dns"
+	case proofPlacementWellKnown:
+		return "web"
+	default:
+		return fmt.Sprintf("proofPlacement(%d)", int(p))
+	}
+}
+
+const (
+	proofDNSRecordPrefix = "_keybase."
+	proofWellKnownPath   = "/.well-known/keybase.txt"
+	cloudflareAPIBase    = "https://api.cloudflare.com/client/v4"
+	// How long to wait for a provisioned proof to be visible.
+	proofPropagationTimeout = 10 * time.Minute
+	proofPropagationPoll    = 10 * time.Second
+)
+
+// proofProvisioner publishes the text of a proof for a domain or site.
+type proofProvisioner interface {
+	publish(m libkb.MetaContext, placement proofPlacement, hostname, txt string) error
+}
+
+func newProofProvisioner(m libkb.MetaContext, arg keybase1.ProofProvisioner) (proofProvisioner, error) {
+	switch arg.Type {
+	case keybase1.ProofProvisionerType_CLOUDFLARE:
+		if len(arg.ApiToken) == 0 {
+			return nil, errors.New("a Cloudflare API token is required")
+		}
+		return &cloudflareProofProvisioner{
+			apiBase:  cloudflareAPIBase,
+			apiToken: arg.ApiToken,
+			client:   libkb.ProxyHTTPClient(m.G(), m.G().Env, "CloudflareProofProvisioner"),
+		}, nil
+	case keybase1.ProofProvisionerType_WEBHOOK:
+		u, err := url.Parse(arg.Url)
+		if err != nil {
+			return nil, err
+		}
+		if u.Scheme != "https" {
+			return nil, fmt.Errorf("webhook URL must use https: %s", arg.Url)
+		}
+		return &webhookProofProvisioner{
+			url:    arg.Url,
+			client: libkb.ProxyHTTPClient(m.G(), m.G().Env, "WebhookProofProvisioner"),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown proof provisioner %v", arg.Type)
+	}
+}
+
+// proofPlacementFor returns where a proof for serviceType goes, if it can be
+// provisioned.
+func proofPlacementFor(serviceType libkb.ServiceType) (proofPlacement, error) {
+	switch serviceType.GetTypeName() {
+	case "dns":
+		return proofPlacementDNS, nil
+	case "web":
+		return proofPlacementWellKnown, nil
+	default:
+		return 0, fmt.Errorf("%s proofs can't be provisioned", serviceType.DisplayName())
+	}
+}
+
+// proofHostname returns the domain or site a proof's remote name is for.
+// Web proofs are named by their URL, like https://example.com.
+func proofHostname(placement proofPlacement, remoteName string) (string, error) {
+	if placement == proofPlacementDNS {
+		return remoteName, nil
+	}
+	u, err := url.Parse(remoteName)
+	if err != nil {
+		return "", err
+	}
+	if len(u.Host) == 0 {
+		return "", fmt.Errorf("no hostname in %s", remoteName)
+	}
+	return u.Host, nil
+}
+
+// proofZoneCandidates returns the zones domain could be in, longest first,
+// leaving out the top-level domain.
+func proofZoneCandidates(domain string) (res []string) {
+	labels := strings.Split(strings.TrimSuffix(domain, "."), ".")
+	for i := 0; i < len(labels)-1; i++ {
+		res = append(res, strings.Join(labels[i:], "."))
+	}
+	return res
+}
+
+type cloudflareProofProvisioner struct {
+	apiBase  string
+	apiToken string
+	client   *http.Client
+}
+
+var _ proofProvisioner = (*cloudflareProofProvisioner)(nil)
+
+type cloudflareResponse struct {
+	Success bool `json:"success"`
+	Errors  []struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"errors"`
+	Result json.RawMessage `json:"result"`
+}
+
+func (c *cloudflareProofProvisioner) call(m libkb.MetaContext, method, path string, body interface{},
+	result interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		buf, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(buf)
+	}
+	req, err := http.NewRequest(method, c.apiBase+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(m.Ctx())
+	req.Header.Set("Authorization", "Bearer "+c.apiToken)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	var res cloudflareResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return fmt.Errorf("Cloudflare returned %s", resp.Status)
+	}
+	if !res.Success {
+		if len(res.Errors) > 0 {
+			return fmt.Errorf("Cloudflare error %d: %s", res.Errors[0].Code, res.Errors[0].Message)
+		}
+		return fmt.Errorf("Cloudflare returned %s", resp.Status)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(res.Result, result)
+}
+
+// zoneID returns the ID of the Cloudflare zone domain is in.
+func (c *cloudflareProofProvisioner) zoneID(m libkb.MetaContext, domain string) (string, error) {
+	for _, name := range proofZoneCandidates(domain) {
+		var zones []struct {
+			ID string `json:"id"`
+		}
+		err := c.call(m, "GET", "/zones?name="+url.QueryEscape(name), nil, &zones)
+		if err != nil {
+			return "", err
+		}
+		if len(zones) > 0 {
+			return zones[0].ID, nil
+		}
+	}
+	return "", fmt.Errorf("no Cloudflare zone for %s", domain)
+}
+
+func (c *cloudflareProofProvisioner) publish(m libkb.MetaContext, placement proofPlacement, domain,
+	txt string) (err error) {
+	defer m.Trace(fmt.Sprintf("cloudflareProofProvisioner.publish(%s)", domain), &err)()
+	if placement != proofPlacementDNS {
+		return errors.New("Cloudflare can only provision DNS proofs")
+	}
+	zoneID, err := c.zoneID(m, domain)
+	if err != nil {
+		return err
+	}
+	return c.call(m, "POST", "/zones/"+url.PathEscape(zoneID)+"/dns_records", map[string]interface{}{
+		"type":    "TXT",
+		"name":    proofDNSRecordPrefix + domain,
+		"content": strings.TrimSpace(txt),
+		"ttl":     120,
+	}, nil)
+}
+
+type webhookProofProvisioner struct {
+	url    string
+	client *http.Client
+}
+
+var _ proofProvisioner = (*webhookProofProvisioner)(nil)
+
+// webhookProof is what a webhook is sent. Name is the TXT record or the
+// path the proof goes at.
+type webhookProof struct {
+	Type     string `json:"type"`
+	Hostname string `json:"hostname"`
+	Name     string `json:"name"`
+	Content  string `json:"content"`
+}
+
+func newWebhookProof(placement proofPlacement, hostname, txt string) webhookProof {
+	res := webhookProof{
+		Type:     placement.String(),
+		Hostname: hostname,
+		Content:  txt,
+	}
+	switch placement {
+	case proofPlacementDNS:
+		res.Name = proofDNSRecordPrefix + hostname
+		res.Content = strings.TrimSpace(txt)
+	case proofPlacementWellKnown:
+		res.Name = proofWellKnownPath
+	}
+	return res
+}
+
+func (w *webhookProofProvisioner) publish(m libkb.MetaContext, placement proofPlacement, hostname,
+	txt string) (err error) {
+	defer m.Trace(fmt.Sprintf("webhookProofProvisioner.publish(%s)", hostname), &err)()
+	buf, err := json.Marshal(newWebhookProof(placement, hostname, txt))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", w.url, bytes.NewReader(buf))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(m.Ctx())
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// proofTextVisible returns whether txt is in found, a TXT record or a
+// proof file, as published.
+func proofTextVisible(found []string, txt string) bool {
+	txt = strings.TrimSpace(txt)
+	for _, f := range found {
+		if strings.Contains(f, txt) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupProof returns what's where the proof goes.
+func lookupProof(m libkb.MetaContext, client *http.Client, placement proofPlacement, remoteName,
+	hostname string) ([]string, error) {
+	if placement == proofPlacementDNS {
+		return net.DefaultResolver.LookupTXT(m.Ctx(), proofDNSRecordPrefix+hostname)
+	}
+	req, err := http.NewRequest("GET", strings.TrimSuffix(remoteName, "/")+proofWellKnownPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req.WithContext(m.Ctx()))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned %s", req.URL, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	return []string{string(body)}, nil
+}
+
+// waitForProofPropagation polls where the proof goes until txt is there.
+func waitForProofPropagation(m libkb.MetaContext, placement proofPlacement, remoteName, hostname,
+	txt string) (err error) {
+	defer m.Trace(fmt.Sprintf("waitForProofPropagation(%s)", hostname), &err)()
+	m, cancel := m.WithTimeout(proofPropagationTimeout)
+	defer cancel()
+	client := libkb.ProxyHTTPClient(m.G(), m.G().Env, "ProofPropagation")
+	for {
+		found, err := lookupProof(m, client, placement, remoteName, hostname)
+		if err != nil {
+			m.Debug("waitForProofPropagation: not found yet: %v", err)
+		} else if proofTextVisible(found, txt) {
+			return nil
+		}
+		wakeAt := m.G().Clock().Now().Add(proofPropagationPoll)
+		if err := libkb.SleepUntilWithContext(m.Ctx(), m.G().Clock(), wakeAt); err != nil {
+			return fmt.Errorf("proof for %s wasn't visible after %v", hostname, proofPropagationTimeout)
+		}
+	}
+}
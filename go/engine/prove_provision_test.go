@@ -0,0 +1,106 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package engine
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProofProvisionHelpers(t *testing.T) {
+	require.Equal(t, []string{"www.example.co.uk", "example.co.uk", "co.uk"},
+		proofZoneCandidates("www.example.co.uk"))
+	require.Empty(t, proofZoneCandidates("localhost"))
+
+	host, err := proofHostname(proofPlacementWellKnown, "https://example.com")
+	require.NoError(t, err)
+	require.Equal(t, "example.com", host)
+	host, err = proofHostname(proofPlacementDNS, "example.com")
+	require.NoError(t, err)
+	require.Equal(t, "example.com", host)
+
+	require.True(t, proofTextVisible([]string{"v=spf1", "keybase-site-verification=abc"},
+		"keybase-site-verification=abc\n"))
+	require.False(t, proofTextVisible([]string{"keybase-site-verification=xyz"},
+		"keybase-site-verification=abc"))
+
+	proof := newWebhookProof(proofPlacementDNS, "example.com", "keybase-site-verification=abc\n")
+	require.Equal(t, webhookProof{
+		Type:     "dns",
+		Hostname: "example.com",
+		Name:     "_keybase.example.com",
+		Content:  "keybase-site-verification=abc",
+	}, proof)
+	proof = newWebhookProof(proofPlacementWellKnown, "example.com", "proof\n")
+	require.Equal(t, "web", proof.Type)
+	require.Equal(t, proofWellKnownPath, proof.Name)
+	require.Equal(t, "proof\n", proof.Content)
+}
+
+func TestCloudflareProofProvisioner(t *testing.T) {
+	tc := SetupEngineTest(t, "prove")
+	defer tc.Cleanup()
+	m := NewMetaContextForTest(tc)
+
+	var record map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer sekrit", r.Header.Get("Authorization"))
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/zones":
+			var result []map[string]string
+			if r.URL.Query().Get("name") == "example.com" {
+				result = append(result, map[string]string{"id": "zone1"})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "result": result})
+		case r.Method == "POST" && r.URL.Path == "/zones/zone1/dns_records":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&record))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "result": record})
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"errors":  []map[string]interface{}{{"code": 10000, "message": "Authentication error"}},
+			})
+		}
+	}))
+	defer srv.Close()
+
+	c := &cloudflareProofProvisioner{apiBase: srv.URL, apiToken: "sekrit", client: srv.Client()}
+	err := c.publish(m, proofPlacementDNS, "www.example.com", "keybase-site-verification=abc\n")
+	require.NoError(t, err)
+	require.Equal(t, "TXT", record["type"])
+	require.Equal(t, "_keybase.www.example.com", record["name"])
+	require.Equal(t, "keybase-site-verification=abc", record["content"])
+
+	err = c.publish(m, proofPlacementWellKnown, "example.com", "proof")
+	require.Error(t, err)
+	err = c.publish(m, proofPlacementDNS, "example.org", "proof")
+	require.Error(t, err)
+}
+
+func TestWebhookProofProvisioner(t *testing.T) {
+	tc := SetupEngineTest(t, "prove")
+	defer tc.Cleanup()
+	m := NewMetaContextForTest(tc)
+
+	var got webhookProof
+	status := http.StatusNoContent
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "POST", r.Method)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	w := &webhookProofProvisioner{url: srv.URL, client: srv.Client()}
+	require.NoError(t, w.publish(m, proofPlacementWellKnown, "example.com", "proof\n"))
+	require.Equal(t, newWebhookProof(proofPlacementWellKnown, "example.com", "proof\n"), got)
+
+	status = http.StatusInternalServerError
+	require.Error(t, w.publish(m, proofPlacementDNS, "example.com", "proof"))
+}
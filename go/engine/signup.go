@@ -5,6 +5,7 @@ package engine
 
 import (
 	"encoding/base64"
+	"errors"
 	"fmt"
 
 	"github.com/keybase/client/go/libkb"
@@ -51,6 +52,12 @@ type SignupEngineRunArg struct {
 	// the main flow; you need to supply a bot token to signup with them.
 	BotToken keybase1.BotToken
 
+	// Headless signups are for CI and other bots that run on a device of
+	// their own. They never prompt, have random PWs, and keep the device's
+	// secret in SecretStoreBackend, which must be given explicitly.
+	Headless           bool
+	SecretStoreBackend keybase1.SecretStoreBackend
+
 	// Used in tests for reproducible key generation
 	naclSigningKeyPair    libkb.NaclKeyPair
 	naclEncryptionKeyPair libkb.NaclKeyPair
@@ -98,6 +105,12 @@ func (s *SignupEngine) Run(m libkb.MetaContext) (err error) {
 		return err
 	}
 
+	if s.arg.Headless {
+		if err = s.checkHeadless(m); err != nil {
+			return err
+		}
+	}
+
 	// StoreSecret is required if we are doing NOPW
 	if !s.arg.StoreSecret && s.arg.GenerateRandomPassphrase && s.arg.BotToken.IsNil() {
 		return fmt.Errorf("cannot SignUp with StoreSecret=false and GenerateRandomPassphrase=true")
@@ -194,6 +207,31 @@ func (s *SignupEngine) Run(m libkb.MetaContext) (err error) {
 	return nil
 }
 
+// checkHeadless makes sure a headless signup has everything it needs up
+// front, since there's nobody to ask, and switches the secret store to the
+// requested backend. With no passphrase, the stored secret is the only way
+// back into the device once the service restarts.
+func (s *SignupEngine) checkHeadless(m libkb.MetaContext) error {
+	switch {
+	case s.arg.BotToken.Exists():
+		return errors.New("headless signups can't use a bot token")
+	case !s.arg.GenerateRandomPassphrase || len(s.arg.Passphrase) > 0:
+		return errors.New("headless signups can't have a passphrase")
+	case !s.arg.StoreSecret:
+		return errors.New("headless signups need to store their secret")
+	case s.arg.SecretStoreBackend == keybase1.SecretStoreBackend_DEFAULT:
+		return errors.New("headless signups need an explicit secret store backend")
+	case len(s.arg.DeviceName) == 0:
+		return errors.New("headless signups need an explicit device name")
+	}
+	// Nobody is around to answer GPG prompts.
+	s.arg.SkipGPG = true
+	if err := m.G().SetSecretStoreBackend(m, s.arg.SecretStoreBackend); err != nil {
+		return SecretStoreNotFunctionalError{err}
+	}
+	return nil
+}
+
 func (s *SignupEngine) doGPG(m libkb.MetaContext) error {
 
 	if s.arg.SkipGPG {
@@ -374,7 +412,12 @@ func (s *SignupEngine) registerDevice(m libkb.MetaContext, deviceName string, ra
 		m.Warning("error saving session file: %s", err)
 	}
 
-	s.storeSecret(m, randomPw)
+	if err := s.storeSecret(m, randomPw); err != nil {
+		if s.arg.Headless {
+			return SecretStoreNotFunctionalError{err}
+		}
+		m.Warning("StoreSecret error: %s", err)
+	}
 
 	m.Debug("registered new device: %s", m.G().Env.GetDeviceID())
 	m.Debug("eldest kid: %s", s.me.GetEldestKID())
@@ -382,20 +425,17 @@ func (s *SignupEngine) registerDevice(m libkb.MetaContext, deviceName string, ra
 	return nil
 }
 
-func (s *SignupEngine) storeSecret(m libkb.MetaContext, randomPw bool) {
-	defer m.Trace("SignupEngine#storeSecret", nil)()
+func (s *SignupEngine) storeSecret(m libkb.MetaContext, randomPw bool) (err error) {
+	defer m.Trace("SignupEngine#storeSecret", &err)()
 
 	// Create the secret store as late as possible here, as the username may
 	// change during the signup process.
 	if !s.arg.StoreSecret {
 		m.Debug("not storing secret; disabled")
-		return
+		return nil
 	}
 
-	w := libkb.StoreSecretAfterLoginWithLKSWithOptions(m, s.me.GetNormalizedName(), s.lks, &libkb.SecretStoreOptions{RandomPw: randomPw})
-	if w != nil {
-		m.Warning("StoreSecret error: %s", w)
-	}
+	return libkb.StoreSecretAfterLoginWithLKSWithOptions(m, s.me.GetNormalizedName(), s.lks, &libkb.SecretStoreOptions{RandomPw: randomPw})
 }
 
 func (s *SignupEngine) storeSecretForRecovery(m libkb.MetaContext) (err error) {
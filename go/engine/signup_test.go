@@ -404,6 +404,41 @@ func TestSignupWithBadSecretStore(t *testing.T) {
 	require.IsType(t, libkb.NotFoundError{}, err)
 }
 
+func TestSignupHeadless(t *testing.T) {
+	tc := SetupEngineTest(t, "signup_headless")
+	defer tc.Cleanup()
+
+	fu, _ := NewFakeUser("sup")
+	arg := MakeTestSignupEngineRunArg(fu)
+	arg.Headless = true
+	arg.StoreSecret = true
+	arg.GenerateRandomPassphrase = true
+	arg.Passphrase = ""
+	_, err := CreateAndSignupFakeUserSafeWithArg(tc.G, fu, arg)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "explicit secret store backend")
+
+	// Make sure user has not signed up - the engine should fail before running
+	// signup_join.
+	loadArg := libkb.NewLoadUserByNameArg(tc.G, fu.Username).WithPublicKeyOptional()
+	_, err = libkb.LoadUser(loadArg)
+	require.Error(t, err)
+	require.IsType(t, libkb.NotFoundError{}, err)
+
+	arg.SecretStoreBackend = keybase1.SecretStoreBackend_FILE
+	arg.DeviceName = "ci-runner-1"
+	_, err = CreateAndSignupFakeUserSafeWithArg(tc.G, fu, arg)
+	require.NoError(t, err)
+
+	// The service keeps using the file store, even after a restart.
+	forceFile, isSet := tc.G.Env.GetConfig().GetForceSecretStoreFile()
+	require.True(t, isSet)
+	require.True(t, forceFile)
+	users, err := tc.G.SecretStore().GetUsersWithStoredSecrets(NewMetaContextForTest(tc))
+	require.NoError(t, err)
+	require.Contains(t, users, fu.Username)
+}
+
 func assertNoFiles(t *testing.T, dir string, files []string) {
 	err := filepath.Walk(dir,
 		func(path string, info os.FileInfo, err error) error {
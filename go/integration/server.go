@@ -0,0 +1,75 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+// Package integration serves the read-only gRPC API for third-party tools
+// described in protocol/grpc/keybase/integration/v1/integration.proto, whose
+// generated code is in protocol/integration1.
+package integration
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"os"
+	"strings"
+
+	"github.com/keybase/client/go/protocol/integration1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// NewServer returns a gRPC server for srv, which only answers calls carrying
+// token. It serves plaintext HTTP/2, as gRPC clients send to plaintext
+// targets.
+func NewServer(srv integration1.IntegrationServer, token string) *grpc.Server {
+	s := grpc.NewServer(grpc.UnaryInterceptor(
+		func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo,
+			handler grpc.UnaryHandler) (interface{}, error) {
+			if !authorized(ctx, token) {
+				return nil, status.Error(codes.Unauthenticated, "missing or wrong integration token")
+			}
+			return handler(ctx, req)
+		}))
+	integration1.RegisterIntegrationServer(s, srv)
+	return s
+}
+
+// authorized returns whether the call in ctx carries token as
+// "authorization: Bearer <token>" metadata.
+func authorized(ctx context.Context, token string) bool {
+	md, _ := metadata.FromIncomingContext(ctx)
+	const prefix = "Bearer "
+	for _, auth := range md.Get("authorization") {
+		if strings.HasPrefix(auth, prefix) && subtle.ConstantTimeCompare(
+			[]byte(strings.TrimPrefix(auth, prefix)), []byte(token)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadOrCreateToken returns the token in the file at path, first writing a
+// new random one there, readable only by the user, if there isn't one.
+func LoadOrCreateToken(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if token := strings.TrimSpace(string(b)); len(token) > 0 {
+			return token, nil
+		}
+	case !os.IsNotExist(err):
+		return "", err
+	}
+	var raw [32]byte
+	if _, err := rand.Read(raw[:]); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(raw[:])
+	if err := os.WriteFile(path, []byte(token+"\n"), 0600); err != nil {
+		return "", err
+	}
+	return token, nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/keybase/client/go/protocol/integration1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+type testBackend struct {
+	integration1.UnimplementedIntegrationServer
+	lastReadChat *integration1.ReadChatRequest
+}
+
+func (b *testBackend) GetStatus(context.Context, *integration1.GetStatusRequest) (
+	*integration1.GetStatusResponse, error) {
+	return &integration1.GetStatusResponse{LoggedIn: true, Username: "alice", Version: "6.5.0"}, nil
+}
+
+func (b *testBackend) ListConversations(context.Context, *integration1.ListConversationsRequest) (
+	*integration1.ListConversationsResponse, error) {
+	return nil, errors.New("inbox unavailable")
+}
+
+func (b *testBackend) ReadChat(_ context.Context, req *integration1.ReadChatRequest) (
+	*integration1.ReadChatResponse, error) {
+	b.lastReadChat = req
+	if len(req.ConversationId) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no conversation ID")
+	}
+	return &integration1.ReadChatResponse{
+		Messages: []*integration1.ChatMessage{{Id: 1, Text: "hi"}},
+	}, nil
+}
+
+// startTestServer serves backend on localhost and returns a client for it.
+func startTestServer(t *testing.T, backend integration1.IntegrationServer) integration1.IntegrationClient {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := NewServer(backend, "sekrit")
+	go func() { _ = s.Serve(ln) }()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.Dial(ln.Addr().String(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return integration1.NewIntegrationClient(conn)
+}
+
+func withToken(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+	backend := &testBackend{}
+	client := startTestServer(t, backend)
+
+	_, err := client.GetStatus(ctx, &integration1.GetStatusRequest{})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = client.GetStatus(withToken(ctx, "wrong"), &integration1.GetStatusRequest{})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx = withToken(ctx, "sekrit")
+	res, err := client.GetStatus(ctx, &integration1.GetStatusRequest{})
+	require.NoError(t, err)
+	require.True(t, proto.Equal(&integration1.GetStatusResponse{
+		LoggedIn: true, Username: "alice", Version: "6.5.0",
+	}, res), "%v", res)
+
+	chat, err := client.ReadChat(ctx, &integration1.ReadChatRequest{ConversationId: "0000abcd", Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, "0000abcd", backend.lastReadChat.ConversationId)
+	require.Equal(t, int32(10), backend.lastReadChat.Limit)
+	require.Len(t, chat.Messages, 1)
+	require.Equal(t, "hi", chat.Messages[0].Text)
+
+	_, err = client.ReadChat(ctx, &integration1.ReadChatRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Equal(t, "no conversation ID", status.Convert(err).Message())
+	_, err = client.ListConversations(ctx, &integration1.ListConversationsRequest{})
+	require.Equal(t, codes.Unknown, status.Code(err))
+	_, err = client.ListFiles(ctx, &integration1.ListFilesRequest{})
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestLoadOrCreateToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "integration_token")
+	token, err := LoadOrCreateToken(path)
+	require.NoError(t, err)
+	require.Len(t, token, 64)
+	fi, err := os.Stat(path)
+	require.NoError(t, err)
+	if os.PathSeparator == '/' {
+		require.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+	}
+	again, err := LoadOrCreateToken(path)
+	require.NoError(t, err)
+	require.Equal(t, token, again)
+}
@@ -18,6 +18,8 @@ const (
 	// Chat prefixes for each MessageBoxedVersion.
 	SignaturePrefixChatMBv1 SignaturePrefix = "Keybase-Chat-1"
 	SignaturePrefixChatMBv2 SignaturePrefix = "Keybase-Chat-2"
+	// For the cover page of a chat archive's legal transcript.
+	SignaturePrefixChatLegalTranscript SignaturePrefix = "Keybase-Chat-Legal-Transcript-1"
 )
 
 func (p SignaturePrefix) IsWhitelisted() bool {
@@ -27,7 +29,7 @@ func (p SignaturePrefix) IsWhitelisted() bool {
 	switch p {
 	case SignaturePrefixKBFS, SignaturePrefixSigchain, SignaturePrefixChatAttachment,
 		SignaturePrefixNIST, SignaturePrefixChatMBv1, SignaturePrefixChatMBv2,
-		SignaturePrefixSigchain3, SignaturePrefixTeamStore:
+		SignaturePrefixSigchain3, SignaturePrefixTeamStore, SignaturePrefixChatLegalTranscript:
 		return true
 	default:
 		return false
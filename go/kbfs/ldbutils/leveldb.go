@@ -12,6 +12,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/keybase/client/go/kbfs/ioutil"
 	"github.com/keybase/client/go/logger"
@@ -25,6 +26,7 @@ import (
 
 const (
 	diskCacheVersionFilename string = "version"
+	corruptDbSuffix          string = ".corrupt-"
 	// Metered specified that this DB should be metered.
 	Metered = true
 	// Unmetered specified that this DB should not be metered.
@@ -150,6 +152,51 @@ func OpenLevelDbWithOptions(stor storage.Storage, options *opt.Options) (
 	return &LevelDb{db, stor}, nil
 }
 
+// IsCorrupted returns whether err, which may be wrapped, means a
+// leveldb's files are corrupt past what leveldb.Recover can fix.
+func IsCorrupted(err error) bool {
+	return ldberrors.IsCorrupted(errors.Cause(err))
+}
+
+// NewCorruptedError marks err, an error reading something back out of a
+// leveldb, as meaning that the DB is corrupt.
+func NewCorruptedError(err error) error {
+	return ldberrors.NewErrCorrupted(storage.FileDesc{}, err)
+}
+
+// QuarantineDb moves the leveldb directory at dbPath aside, so that a
+// fresh DB can be created in its place while the corrupt one is kept
+// around for debugging. Only the most recently quarantined copy is kept.
+// If the directory can't be moved, it's removed instead, and
+// quarantinePath is empty.
+func QuarantineDb(dbPath string) (quarantinePath string, err error) {
+	_, err = ioutil.Stat(dbPath)
+	switch {
+	case ioutil.IsNotExist(err):
+		return "", nil
+	case err != nil:
+		return "", err
+	}
+
+	old, err := filepath.Glob(dbPath + corruptDbSuffix + "*")
+	if err != nil {
+		return "", err
+	}
+	for _, p := range old {
+		err = ioutil.RemoveAll(p)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	quarantinePath = dbPath + corruptDbSuffix +
+		time.Now().Format("20060102-150405")
+	if err := ioutil.Rename(dbPath, quarantinePath); err == nil {
+		return quarantinePath, nil
+	}
+	return "", ioutil.RemoveAll(dbPath)
+}
+
 // OpenLevelDb opens or recovers a leveldb.DB with a passed-in
 // storage.Storage as its underlying storage layer.
 func OpenLevelDb(
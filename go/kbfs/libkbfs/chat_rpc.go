@@ -958,3 +958,24 @@ func (c *ChatRPC) ChatWelcomeMessageLoaded(
 func (c *ChatRPC) ChatParticipantsInfo(context.Context, map[chat1.ConvIDStr][]chat1.UIParticipant) error {
 	return nil
 }
+
+// ChatSplitConversationProgress implements the chat1.NotifyChatInterface
+// for ChatRPC.
+func (c *ChatRPC) ChatSplitConversationProgress(
+	_ context.Context, _ chat1.ChatSplitConversationProgressArg) error {
+	return nil
+}
+
+// ChatMentionDigest implements the chat1.NotifyChatInterface for
+// ChatRPC.
+func (c *ChatRPC) ChatMentionDigest(
+	_ context.Context, _ chat1.ChatMentionDigestArg) error {
+	return nil
+}
+
+// ChatIdentityChangeAlert implements the chat1.NotifyChatInterface for
+// ChatRPC.
+func (c *ChatRPC) ChatIdentityChangeAlert(
+	_ context.Context, _ chat1.ChatIdentityChangeAlertArg) error {
+	return nil
+}
@@ -114,6 +114,7 @@ type ConfigLocal struct {
 	rwpWaitTime        time.Duration
 	diskLimiter        DiskLimiter
 	syncedTlfs         map[tlf.ID]FolderSyncConfig // if nil, couldn't load DB
+	cacheRepairs       cacheRepairs
 	syncedTlfPaths     map[string]bool
 	defaultBlockType   keybase1.BlockType
 	kbfsService        *KBFSService
@@ -266,7 +267,7 @@ func NewConfigLocal(mode InitMode,
 	config.SetCodec(kbfscodec.NewMsgpack())
 	if diskCacheMode == DiskCacheModeLocal {
 		// Any error is logged in the function itself.
-		_ = config.loadSyncedTlfsLocked()
+		_ = config.loadOrRebuildSyncedTlfsLocked(false)
 	}
 	config.SetClock(data.WallClock{})
 	config.SetReporter(NewReporterSimple(config.Clock(), 10))
@@ -1414,7 +1415,8 @@ func (c *ConfigLocal) cleanSyncBlockCache() {
 }
 
 func (c *ConfigLocal) resetDiskBlockCacheLocked() error {
-	dbc, err := newDiskBlockCacheWrapped(c, c.storageRoot, c.mode)
+	dbc, err := newDiskBlockCacheWrapped(
+		c, c.storageRoot, c.mode, &c.cacheRepairs)
 	if err != nil {
 		return err
 	}
@@ -1577,7 +1579,7 @@ func (c *ConfigLocal) loadSyncedTlfsLocked() (err error) {
 		if val != nil {
 			err = c.codec.Decode(val, &config)
 			if err != nil {
-				return err
+				return ldbutils.NewCorruptedError(err)
 			}
 		} else {
 			// For backwards-compatibility, consider a nil value to
@@ -1589,6 +1591,9 @@ func (c *ConfigLocal) loadSyncedTlfsLocked() (err error) {
 			syncedTlfPaths[config.TlfPath] = true
 		}
 	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
 	c.syncedTlfs = syncedTlfs
 	c.syncedTlfPaths = syncedTlfPaths
 	return ldb.Write(deleteBatch, nil)
@@ -1830,7 +1835,7 @@ func (c *ConfigLocal) SetDiskCacheMode(m DiskCacheMode) {
 	c.diskCacheMode = m
 	if c.diskCacheMode == DiskCacheModeLocal {
 		// Any error is logged in the function itself.
-		_ = c.loadSyncedTlfsLocked()
+		_ = c.loadOrRebuildSyncedTlfsLocked(false)
 	}
 }
 
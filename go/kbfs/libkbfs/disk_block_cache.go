@@ -122,6 +122,9 @@ type DiskBlockCacheLocal struct {
 	useCh      chan struct{}
 	startedCh  chan struct{}
 	startErrCh chan struct{}
+	// startErr is why the cache didn't start; only set once startErrCh
+	// is closed.
+	startErr   error
 	shutdownCh chan struct{}
 	doneCh     chan struct{}
 
@@ -298,6 +301,7 @@ func newDiskBlockCacheLocalFromStorage(
 	go func() {
 		err := cache.syncBlockCountsAndUnrefsFromDb()
 		if err != nil {
+			cache.startErr = err
 			close(startErrCh)
 			closer()
 			log.Warning("Disabling disk block cache due to error syncing the "+
@@ -442,6 +446,17 @@ func (cache *DiskBlockCacheLocal) WaitUntilStarted() error {
 	}
 }
 
+// corrupted waits until this cache has started, and returns whether it
+// failed to because its DBs are corrupt, along with why.
+func (cache *DiskBlockCacheLocal) corrupted() (bool, error) {
+	select {
+	case <-cache.startedCh:
+		return false, nil
+	case <-cache.startErrCh:
+		return ldbutils.IsCorrupted(cache.startErr), cache.startErr
+	}
+}
+
 func (cache *DiskBlockCacheLocal) decodeLastUnref(buf []byte) (
 	rev kbfsmd.Revision, err error) {
 	var entry lastUnrefEntry
@@ -485,7 +500,7 @@ func (cache *DiskBlockCacheLocal) syncBlockCountsAndUnrefsFromDb() error {
 		metadata := DiskBlockCacheMetadata{}
 		err := cache.config.Codec().Decode(iter.Value(), &metadata)
 		if err != nil {
-			return err
+			return ldbutils.NewCorruptedError(err)
 		}
 		size := uint64(metadata.BlockSize)
 		tlfCounts[metadata.TlfID]++
@@ -495,6 +510,9 @@ func (cache *DiskBlockCacheLocal) syncBlockCountsAndUnrefsFromDb() error {
 		numBlocks++
 		totalSize += size
 	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
 	cache.tlfCounts = tlfCounts
 	cache.numBlocks = numBlocks
 	cache.tlfSizes = tlfSizes
@@ -511,15 +529,18 @@ func (cache *DiskBlockCacheLocal) syncBlockCountsAndUnrefsFromDb() error {
 		var tlfID tlf.ID
 		err := tlfID.UnmarshalBinary(lastUnrefIter.Key())
 		if err != nil {
-			return err
+			return ldbutils.NewCorruptedError(err)
 		}
 
 		rev, err := cache.decodeLastUnref(lastUnrefIter.Value())
 		if err != nil {
-			return err
+			return ldbutils.NewCorruptedError(err)
 		}
 		tlfLastUnrefs[tlfID] = rev
 	}
+	if err := lastUnrefIter.Error(); err != nil {
+		return err
+	}
 	cache.tlfLastUnrefs = tlfLastUnrefs
 
 	return nil
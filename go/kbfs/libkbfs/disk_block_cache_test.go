@@ -996,3 +996,65 @@ func TestDiskBlockCacheRemoveBrokenBlocks(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 1, wsCache.numBlocks)
 }
+
+func TestDiskBlockCacheRepair(t *testing.T) {
+	t.Parallel()
+	t.Log("Test that repairing an on-disk cache only rebuilds it when asked " +
+		"to or when it's corrupt.")
+
+	tempdir, err := ioutil.TempDir(os.TempDir(), "kbfscache")
+	require.NoError(t, err)
+	defer func() {
+		err := ioutil.RemoveAll(tempdir)
+		require.NoError(t, err)
+	}()
+
+	// Borrow the disk limiter of an in-memory cache.
+	memCache, config := initDiskBlockCacheTest(t)
+	shutdownDiskBlockCacheTest(memCache)
+	repairs := &cacheRepairs{}
+	cache := &diskBlockCacheWrapped{
+		config:      config,
+		storageRoot: tempdir,
+		repairs:     repairs,
+	}
+	mode := config.Mode()
+	cache.workingSetCache, err = cache.openLocalCache(
+		workingSetCacheLimitTrackerType, workingSetCacheFolderName, mode)
+	require.NoError(t, err)
+	defer shutdownDiskBlockCacheTest(cache)
+	err = cache.WaitUntilStarted(DiskBlockWorkingSetCache)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	tlfID := tlf.FakeID(1, tlf.Private)
+	blockPtr, _, blockEncoded, serverHalf := setupBlockForDiskCache(t, config)
+	err = cache.Put(
+		ctx, tlfID, blockPtr.ID, blockEncoded, serverHalf,
+		DiskBlockWorkingSetCache)
+	require.NoError(t, err)
+
+	t.Log("A healthy cache is left alone.")
+	err = cache.repairCache(ctx, workingSetCacheLimitTrackerType,
+		workingSetCacheFolderName, mode, false)
+	require.NoError(t, err)
+	require.Empty(t, repairs.get().Repairs)
+	_, _, _, err = cache.Get(ctx, tlfID, blockPtr.ID, DiskBlockWorkingSetCache)
+	require.NoError(t, err)
+
+	t.Log("A rebuilt cache starts over empty, with the old one moved aside.")
+	err = cache.repairCache(ctx, workingSetCacheLimitTrackerType,
+		workingSetCacheFolderName, mode, true)
+	require.NoError(t, err)
+	err = cache.WaitUntilStarted(DiskBlockWorkingSetCache)
+	require.NoError(t, err)
+	_, _, _, err = cache.Get(ctx, tlfID, blockPtr.ID, DiskBlockWorkingSetCache)
+	require.EqualError(t, err, data.NoSuchBlockError{ID: blockPtr.ID}.Error())
+
+	rebuilt := repairs.get().Repairs
+	require.Len(t, rebuilt, 1)
+	require.Equal(t, keybase1.SimpleFSCacheDB_BLOCK_CACHE, rebuilt[0].Db)
+	require.NotEmpty(t, rebuilt[0].QuarantinePath)
+	_, err = ioutil.Stat(rebuilt[0].QuarantinePath)
+	require.NoError(t, err)
+}
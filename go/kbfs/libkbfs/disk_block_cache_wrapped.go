@@ -5,7 +5,6 @@
 package libkbfs
 
 import (
-	"path/filepath"
 	"sync"
 
 	"github.com/keybase/client/go/kbfs/data"
@@ -39,6 +38,8 @@ type diskBlockCacheConfig interface {
 type diskBlockCacheWrapped struct {
 	config      diskBlockCacheConfig
 	storageRoot string
+	// Where rebuilt caches are recorded; may be nil.
+	repairs *cacheRepairs
 	// Protects the caches
 	mtx             sync.RWMutex
 	workingSetCache *DiskBlockCacheLocal
@@ -52,14 +53,9 @@ func (cache *diskBlockCacheWrapped) enableCache(
 	typ diskLimitTrackerType, cacheFolder string, mode InitMode) (err error) {
 	cache.mtx.Lock()
 	defer cache.mtx.Unlock()
-	var cachePtr **DiskBlockCacheLocal
-	switch typ {
-	case syncCacheLimitTrackerType:
-		cachePtr = &cache.syncCache
-	case workingSetCacheLimitTrackerType:
-		cachePtr = &cache.workingSetCache
-	default:
-		return errors.New("invalid disk cache type")
+	cachePtr, err := cache.cachePtrLocked(typ)
+	if err != nil {
+		return err
 	}
 	if *cachePtr != nil {
 		// We already have a cache of the desired type. Thus, this method is
@@ -70,19 +66,21 @@ func (cache *diskBlockCacheWrapped) enableCache(
 		*cachePtr, err = newDiskBlockCacheLocalForTest(
 			cache.config, typ)
 	} else {
-		cacheStorageRoot := filepath.Join(cache.storageRoot, cacheFolder)
-		*cachePtr, err = newDiskBlockCacheLocal(
-			cache.config, typ, cacheStorageRoot, mode)
+		*cachePtr, err = cache.openLocalCache(typ, cacheFolder, mode)
+		if err == nil {
+			go cache.rebuildIfCorrupt(typ, cacheFolder, mode, *cachePtr)
+		}
 	}
 	return err
 }
 
 func newDiskBlockCacheWrapped(
-	config diskBlockCacheConfig, storageRoot string, mode InitMode) (
-	cache *diskBlockCacheWrapped, err error) {
+	config diskBlockCacheConfig, storageRoot string, mode InitMode,
+	repairs *cacheRepairs) (cache *diskBlockCacheWrapped, err error) {
 	cache = &diskBlockCacheWrapped{
 		config:      config,
 		storageRoot: storageRoot,
+		repairs:     repairs,
 	}
 	err = cache.enableCache(
 		workingSetCacheLimitTrackerType, workingSetCacheFolderName, mode)
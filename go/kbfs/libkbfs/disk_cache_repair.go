@@ -0,0 +1,376 @@
+// Copyright 2026 Keybase Inc. All rights reserved.
+// Use of this source code is governed by a BSD
+// license that can be found in the LICENSE file.
+
+package libkbfs
+
+import (
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/keybase/client/go/kbfs/ldbutils"
+	"github.com/keybase/client/go/kbfs/tlf"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"golang.org/x/net/context"
+)
+
+// The local block caches and the synced TLF config are checked as they're
+// opened: leveldb.Recover is tried on a corrupt manifest, and the block
+// caches read all their metadata back before they start. A store that's
+// still corrupt after that is moved aside and replaced with a fresh one,
+// rather than leaving KBFS without it. The block caches start over empty;
+// the synced TLF config keeps whichever configs could still be read.
+
+// cacheRepairs keeps track of which local cache stores have been rebuilt,
+// and of the progress of any repair asked for through
+// Config.RepairCache. The zero value is ready to use.
+type cacheRepairs struct {
+	lock     sync.Mutex
+	progress keybase1.SimpleFSCacheRepairProgress
+}
+
+func (r *cacheRepairs) record(
+	db keybase1.SimpleFSCacheDB, reason error, quarantinePath string) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.progress.Repairs = append(r.progress.Repairs,
+		keybase1.SimpleFSCacheDBRepair{
+			Db:             db,
+			Time:           keybase1.ToTime(time.Now()),
+			Reason:         reason.Error(),
+			QuarantinePath: quarantinePath,
+		})
+}
+
+func (r *cacheRepairs) start(dbsTotal int) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.progress.InProgress {
+		return errors.New("a cache repair is already in progress")
+	}
+	r.progress.InProgress = true
+	r.progress.DbsDone = 0
+	r.progress.DbsTotal = dbsTotal
+	r.progress.StartTime = keybase1.ToTime(time.Now())
+	r.progress.EndTime = 0
+	r.progress.Error = ""
+	return nil
+}
+
+func (r *cacheRepairs) startDB(db keybase1.SimpleFSCacheDB) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.progress.CurrDB = db
+}
+
+func (r *cacheRepairs) finishDB() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.progress.DbsDone++
+}
+
+func (r *cacheRepairs) finish(err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.progress.InProgress = false
+	r.progress.CurrDB = 0
+	r.progress.EndTime = keybase1.ToTime(time.Now())
+	if err != nil {
+		r.progress.Error = err.Error()
+	}
+}
+
+func (r *cacheRepairs) get() keybase1.SimpleFSCacheRepairProgress {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.progress.DeepCopy()
+}
+
+func cacheDBForDiskBlockCache(
+	typ diskLimitTrackerType) keybase1.SimpleFSCacheDB {
+	if typ == syncCacheLimitTrackerType {
+		return keybase1.SimpleFSCacheDB_SYNC_CACHE
+	}
+	return keybase1.SimpleFSCacheDB_BLOCK_CACHE
+}
+
+// checkDbs reads everything in the cache's DBs back, and returns an error
+// if any of it is corrupt.
+func (cache *DiskBlockCacheLocal) checkDbs(ctx context.Context) error {
+	if corrupt, err := cache.corrupted(); corrupt {
+		return err
+	}
+	cache.lock.RLock()
+	defer cache.lock.RUnlock()
+	if err := cache.checkCacheLocked("Block(checkDbs)"); err != nil {
+		return err
+	}
+	for _, db := range []*ldbutils.LevelDb{
+		cache.metaDb, cache.tlfDb, cache.lastUnrefDb, cache.blockDb} {
+		iter := db.NewIterator(nil, nil)
+		for iter.Next() {
+			select {
+			case <-ctx.Done():
+				iter.Release()
+				return ctx.Err()
+			default:
+			}
+		}
+		iter.Release()
+		if err := iter.Error(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// openLocalCache opens the on-disk cache of type typ in cacheFolder. If
+// its DBs are too corrupt to open, it moves them aside and opens a fresh
+// cache instead.
+func (cache *diskBlockCacheWrapped) openLocalCache(
+	typ diskLimitTrackerType, cacheFolder string, mode InitMode) (
+	*DiskBlockCacheLocal, error) {
+	cacheStorageRoot := filepath.Join(cache.storageRoot, cacheFolder)
+	local, err := newDiskBlockCacheLocal(
+		cache.config, typ, cacheStorageRoot, mode)
+	if !ldbutils.IsCorrupted(err) {
+		return local, err
+	}
+	log := cache.config.MakeLogger("DBC")
+	log.Warning("Disk block cache of type %s is corrupt; rebuilding it: %+v",
+		typ, err)
+	quarantinePath, qErr := ldbutils.QuarantineDb(cacheStorageRoot)
+	if qErr != nil {
+		return nil, qErr
+	}
+	cache.repairs.record(cacheDBForDiskBlockCache(typ), err, quarantinePath)
+	return newDiskBlockCacheLocal(cache.config, typ, cacheStorageRoot, mode)
+}
+
+// rebuildIfCorrupt waits for local to start, and rebuilds it if it
+// couldn't because its DBs are corrupt. Until then, it's disabled like
+// any cache that failed to start.
+func (cache *diskBlockCacheWrapped) rebuildIfCorrupt(
+	typ diskLimitTrackerType, cacheFolder string, mode InitMode,
+	local *DiskBlockCacheLocal) {
+	corrupt, err := local.corrupted()
+	if !corrupt {
+		return
+	}
+	ctx := context.Background()
+	log := cache.config.MakeLogger("DBC")
+	log.CWarningf(ctx, "Disk block cache of type %s is corrupt; "+
+		"rebuilding it: %+v", typ, err)
+	err = cache.rebuildCache(ctx, typ, cacheFolder, mode, local, err)
+	if err != nil {
+		log.CWarningf(ctx, "Couldn't rebuild disk block cache: %+v", err)
+	}
+}
+
+func (cache *diskBlockCacheWrapped) cachePtrLocked(
+	typ diskLimitTrackerType) (**DiskBlockCacheLocal, error) {
+	switch typ {
+	case syncCacheLimitTrackerType:
+		return &cache.syncCache, nil
+	case workingSetCacheLimitTrackerType:
+		return &cache.workingSetCache, nil
+	default:
+		return nil, errors.New("invalid disk cache type")
+	}
+}
+
+// rebuildCache replaces the cache of type typ, if it's still old, with a
+// fresh, empty one, moving its DBs aside. Everything else using the
+// cache waits until that's done.
+func (cache *diskBlockCacheWrapped) rebuildCache(ctx context.Context,
+	typ diskLimitTrackerType, cacheFolder string, mode InitMode,
+	old *DiskBlockCacheLocal, reason error) error {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+	cachePtr, err := cache.cachePtrLocked(typ)
+	if err != nil {
+		return err
+	}
+	if *cachePtr != old {
+		// Someone else got to it first.
+		return nil
+	}
+	if old != nil {
+		<-old.Shutdown(ctx)
+	}
+	cacheStorageRoot := filepath.Join(cache.storageRoot, cacheFolder)
+	quarantinePath, err := ldbutils.QuarantineDb(cacheStorageRoot)
+	if err != nil {
+		return err
+	}
+	cache.repairs.record(cacheDBForDiskBlockCache(typ), reason, quarantinePath)
+	local, err := newDiskBlockCacheLocal(
+		cache.config, typ, cacheStorageRoot, mode)
+	if err != nil {
+		if typ == syncCacheLimitTrackerType {
+			// Like at startup, go on without a sync cache.
+			*cachePtr = nil
+		}
+		return err
+	}
+	*cachePtr = local
+	go cache.rebuildIfCorrupt(typ, cacheFolder, mode, local)
+	return nil
+}
+
+// repairCache checks the cache of type typ, and rebuilds it if it's
+// corrupt, missing, or if rebuild is set.
+func (cache *diskBlockCacheWrapped) repairCache(ctx context.Context,
+	typ diskLimitTrackerType, cacheFolder string, mode InitMode,
+	rebuild bool) error {
+	cache.mtx.RLock()
+	cachePtr, err := cache.cachePtrLocked(typ)
+	if err != nil {
+		cache.mtx.RUnlock()
+		return err
+	}
+	local := *cachePtr
+	cache.mtx.RUnlock()
+
+	var reason error
+	switch {
+	case rebuild:
+		reason = errors.New("rebuild requested")
+	case local == nil:
+		reason = errors.New("cache couldn't be opened")
+	default:
+		err := local.checkDbs(ctx)
+		switch {
+		case ldbutils.IsCorrupted(err):
+			reason = err
+		case err != nil:
+			return err
+		default:
+			return nil
+		}
+	}
+	return cache.rebuildCache(ctx, typ, cacheFolder, mode, local, reason)
+}
+
+// rebuildSyncedTlfsDbLocked moves the synced TLF config DB aside and
+// replaces it with a fresh one holding every config that can still be
+// read from it.
+func (c *ConfigLocal) rebuildSyncedTlfsDbLocked(reason error) error {
+	salvaged := new(leveldb.Batch)
+	old, err := c.openConfigLevelDB(syncedTlfConfigFolderName)
+	if err == nil {
+		iter := old.NewIterator(nil, nil)
+		for iter.Next() {
+			if _, err := tlf.ParseID(string(iter.Key())); err != nil {
+				continue
+			}
+			if val := iter.Value(); val != nil {
+				var config FolderSyncConfig
+				if err := c.codec.Decode(val, &config); err != nil {
+					continue
+				}
+			}
+			salvaged.Put(iter.Key(), iter.Value())
+		}
+		iter.Release()
+		_ = old.Close()
+	}
+
+	quarantinePath, err := ldbutils.QuarantineDb(
+		filepath.Join(c.storageRoot, syncedTlfConfigFolderName))
+	if err != nil {
+		return err
+	}
+	c.cacheRepairs.record(
+		keybase1.SimpleFSCacheDB_SYNC_CONFIG, reason, quarantinePath)
+	c.MakeLogger("").Warning(
+		"Rebuilt the synced TLF config DB, keeping %d configs: %+v",
+		salvaged.Len(), reason)
+	ldb, err := c.openConfigLevelDB(syncedTlfConfigFolderName)
+	if err != nil {
+		return err
+	}
+	defer ldb.Close()
+	return ldb.Write(salvaged, nil)
+}
+
+// loadOrRebuildSyncedTlfsLocked loads the synced TLF configs, first
+// rebuilding their DB if it's corrupt or if rebuild is set.
+func (c *ConfigLocal) loadOrRebuildSyncedTlfsLocked(rebuild bool) error {
+	if c.mode.IsTestMode() {
+		return c.loadSyncedTlfsLocked()
+	}
+	if rebuild {
+		err := c.rebuildSyncedTlfsDbLocked(errors.New("rebuild requested"))
+		if err != nil {
+			return err
+		}
+		return c.loadSyncedTlfsLocked()
+	}
+	err := c.loadSyncedTlfsLocked()
+	if !ldbutils.IsCorrupted(err) {
+		// Anything else might not last, like the transient iOS
+		// storage permission errors `cleanSyncBlockCache` retries.
+		return err
+	}
+	if err := c.rebuildSyncedTlfsDbLocked(err); err != nil {
+		return err
+	}
+	return c.loadSyncedTlfsLocked()
+}
+
+// RepairCache implements the Config interface for ConfigLocal.
+func (c *ConfigLocal) RepairCache(ctx context.Context, rebuild bool) (
+	err error) {
+	if err := c.cacheRepairs.start(3); err != nil {
+		return err
+	}
+	defer func() { c.cacheRepairs.finish(err) }()
+
+	c.lock.RLock()
+	dbc, ok := c.diskBlockCache.(*diskBlockCacheWrapped)
+	mode := c.mode
+	c.lock.RUnlock()
+	if !ok {
+		return errors.New("the disk cache isn't kept by this process")
+	}
+
+	c.cacheRepairs.startDB(keybase1.SimpleFSCacheDB_BLOCK_CACHE)
+	err = dbc.repairCache(ctx, workingSetCacheLimitTrackerType,
+		workingSetCacheFolderName, mode, rebuild)
+	if err != nil {
+		return err
+	}
+	c.cacheRepairs.finishDB()
+
+	c.cacheRepairs.startDB(keybase1.SimpleFSCacheDB_SYNC_CACHE)
+	err = dbc.repairCache(ctx, syncCacheLimitTrackerType,
+		syncCacheFolderName, mode, rebuild)
+	if err != nil {
+		return err
+	}
+	c.cacheRepairs.finishDB()
+
+	c.cacheRepairs.startDB(keybase1.SimpleFSCacheDB_SYNC_CONFIG)
+	err = func() error {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		return c.loadOrRebuildSyncedTlfsLocked(rebuild)
+	}()
+	if err != nil {
+		return err
+	}
+	c.cacheRepairs.finishDB()
+	return nil
+}
+
+// CacheRepairProgress implements the Config interface for ConfigLocal.
+func (c *ConfigLocal) CacheRepairProgress() keybase1.SimpleFSCacheRepairProgress {
+	return c.cacheRepairs.get()
+}
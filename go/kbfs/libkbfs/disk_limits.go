@@ -0,0 +1,12 @@
+// Copyright 2026 Keybase Inc. All rights reserved.
+// Use of this source code is governed by a BSD
+// license that can be found in the LICENSE file.
+
+package libkbfs
+
+// GetAvailableDiskBytes returns how many bytes an unprivileged user can
+// still write to the logical disk containing the given path.
+func GetAvailableDiskBytes(path string) (uint64, error) {
+	availableBytes, _, _, _, err := getDiskLimits(path)
+	return availableBytes, err
+}
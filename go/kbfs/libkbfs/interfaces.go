@@ -83,6 +83,16 @@ type diskBlockCacheSetter interface {
 	MakeDiskBlockCacheIfNotExists() error
 }
 
+type cacheRepairer interface {
+	// RepairCache checks the local block caches and synced TLF config,
+	// moving aside and rebuilding any that are corrupt, or all of them if
+	// rebuild is true.
+	RepairCache(ctx context.Context, rebuild bool) error
+	// CacheRepairProgress returns the progress of the current or last
+	// call to RepairCache, and the stores rebuilt so far.
+	CacheRepairProgress() keybase1.SimpleFSCacheRepairProgress
+}
+
 type diskBlockCacheFractionSetter interface {
 	SetDiskBlockCacheFraction(float64)
 }
@@ -707,6 +717,24 @@ type KeybaseService interface {
 	// DecryptFavorites decrypts cached favorites stored on disk.
 	DecryptFavorites(ctx context.Context, dataToDecrypt []byte) ([]byte, error)
 
+	// EncryptArchiveState encrypts the SimpleFS archive state to store on
+	// disk.
+	EncryptArchiveState(ctx context.Context, dataToEncrypt []byte) ([]byte, error)
+
+	// DecryptArchiveState decrypts the SimpleFS archive state stored on
+	// disk.
+	DecryptArchiveState(ctx context.Context, dataToDecrypt []byte) ([]byte, error)
+
+	// ReserveDiskSpace reserves local disk space for a job that's about to
+	// write needs, replacing anything already reserved under key, so it
+	// doesn't race the service's own jobs for it. If wait is true and there
+	// isn't room, it waits for other jobs to release theirs.
+	ReserveDiskSpace(ctx context.Context, key, description string,
+		needs []keybase1.DiskSpaceNeed, wait bool) error
+
+	// ReleaseDiskSpace releases the disk space reserved under key, if any.
+	ReleaseDiskSpace(ctx context.Context, key string) error
+
 	// NotifyOnlineStatusChanged notifies about online/offline status
 	// changes.
 	NotifyOnlineStatusChanged(ctx context.Context, online bool) error
@@ -729,6 +757,15 @@ type KeybaseService interface {
 	// changed.
 	NotifyFavoritesChanged(ctx context.Context) error
 
+	// NotifyArchiveProgress sends a notification about the progress
+	// of an archive job.
+	NotifyArchiveProgress(
+		ctx context.Context, progress keybase1.SimpleFSArchiveProgress) error
+
+	// NotifyQuotaAlert sends a notification that the user's quota usage
+	// crossed one of their alert thresholds.
+	NotifyQuotaAlert(ctx context.Context, alert keybase1.SimpleFSQuotaAlert) error
+
 	// FlushUserFromLocalCache instructs this layer to clear any
 	// KBFS-side, locally-cached information about the given user.
 	// This does NOT involve communication with the daemon, this is
@@ -2218,6 +2255,7 @@ type Config interface {
 	currentSessionGetterGetter
 	diskBlockCacheGetter
 	diskBlockCacheSetter
+	cacheRepairer
 	diskBlockCacheFractionSetter
 	syncBlockCacheFractionSetter
 	diskMDCacheGetter
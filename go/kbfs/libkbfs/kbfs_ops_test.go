@@ -4436,7 +4436,7 @@ func (b bserverPutToDiskCache) Put(
 
 func enableDiskCacheForTest(
 	t *testing.T, config *ConfigLocal, tempdir string) *diskBlockCacheWrapped {
-	dbc, err := newDiskBlockCacheWrapped(config, "", config.Mode())
+	dbc, err := newDiskBlockCacheWrapped(config, "", config.Mode(), nil)
 	require.NoError(t, err)
 	config.diskBlockCache = dbc
 	err = dbc.workingSetCache.WaitUntilStarted()
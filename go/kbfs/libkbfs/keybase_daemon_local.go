@@ -376,6 +376,37 @@ func (k *KeybaseDaemonLocal) DecryptFavorites(ctx context.Context,
 	return nil, checkContext(ctx)
 }
 
+// EncryptArchiveState implements KeybaseService for KeybaseDaemonLocal. There
+// are no local storage keys here, so the data is stored as is.
+func (k *KeybaseDaemonLocal) EncryptArchiveState(ctx context.Context,
+	dataToEncrypt []byte) ([]byte, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+	return append([]byte(nil), dataToEncrypt...), nil
+}
+
+// DecryptArchiveState implements KeybaseService for KeybaseDaemonLocal.
+func (k *KeybaseDaemonLocal) DecryptArchiveState(ctx context.Context,
+	dataToDecrypt []byte) ([]byte, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+	return append([]byte(nil), dataToDecrypt...), nil
+}
+
+// ReserveDiskSpace implements KeybaseService for KeybaseDaemonLocal. There
+// are no other jobs here to race for the space.
+func (k *KeybaseDaemonLocal) ReserveDiskSpace(ctx context.Context,
+	_, _ string, _ []keybase1.DiskSpaceNeed, _ bool) error {
+	return checkContext(ctx)
+}
+
+// ReleaseDiskSpace implements KeybaseService for KeybaseDaemonLocal.
+func (k *KeybaseDaemonLocal) ReleaseDiskSpace(ctx context.Context, _ string) error {
+	return checkContext(ctx)
+}
+
 // NotifyOnlineStatusChanged implements KeybaseDaemon for KeybaseDeamonLocal.
 func (k *KeybaseDaemonLocal) NotifyOnlineStatusChanged(ctx context.Context, online bool) error {
 	return checkContext(ctx)
@@ -386,6 +417,18 @@ func (k *KeybaseDaemonLocal) NotifyFavoritesChanged(ctx context.Context) error {
 	return checkContext(ctx)
 }
 
+// NotifyArchiveProgress implements KeybaseDaemon for KeybaseDeamonLocal.
+func (k *KeybaseDaemonLocal) NotifyArchiveProgress(
+	ctx context.Context, _ keybase1.SimpleFSArchiveProgress) error {
+	return checkContext(ctx)
+}
+
+// NotifyQuotaAlert implements KeybaseDaemon for KeybaseDeamonLocal.
+func (k *KeybaseDaemonLocal) NotifyQuotaAlert(
+	ctx context.Context, _ keybase1.SimpleFSQuotaAlert) error {
+	return checkContext(ctx)
+}
+
 // Notify implements KeybaseDaemon for KeybaseDeamonLocal.
 func (k *KeybaseDaemonLocal) Notify(ctx context.Context, notification *keybase1.FSNotification) error {
 	return checkContext(ctx)
@@ -1194,6 +1194,34 @@ func (k *KeybaseServiceBase) DecryptFavorites(ctx context.Context, dataToEncrypt
 	return k.kbfsClient.DecryptFavorites(ctx, dataToEncrypt)
 }
 
+// EncryptArchiveState encrypts the SimpleFS archive state to store on disk.
+func (k *KeybaseServiceBase) EncryptArchiveState(ctx context.Context, dataToEncrypt []byte) (res []byte, err error) {
+	return k.kbfsClient.EncryptArchiveState(ctx, dataToEncrypt)
+}
+
+// DecryptArchiveState decrypts the SimpleFS archive state stored on disk.
+func (k *KeybaseServiceBase) DecryptArchiveState(ctx context.Context, dataToDecrypt []byte) (res []byte, err error) {
+	return k.kbfsClient.DecryptArchiveState(ctx, dataToDecrypt)
+}
+
+// ReserveDiskSpace implements the KeybaseService interface for
+// KeybaseServiceBase.
+func (k *KeybaseServiceBase) ReserveDiskSpace(ctx context.Context,
+	key, description string, needs []keybase1.DiskSpaceNeed, wait bool) error {
+	return k.kbfsClient.ReserveDiskSpace(ctx, keybase1.ReserveDiskSpaceArg{
+		Key:         key,
+		Description: description,
+		Needs:       needs,
+		Wait:        wait,
+	})
+}
+
+// ReleaseDiskSpace implements the KeybaseService interface for
+// KeybaseServiceBase.
+func (k *KeybaseServiceBase) ReleaseDiskSpace(ctx context.Context, key string) error {
+	return k.kbfsClient.ReleaseDiskSpace(ctx, key)
+}
+
 // NotifyOnlineStatusChanged implements the KeybaseService interface for
 // KeybaseServiceBase.
 func (k *KeybaseServiceBase) NotifyOnlineStatusChanged(ctx context.Context,
@@ -1234,6 +1262,20 @@ func (k *KeybaseServiceBase) NotifyFavoritesChanged(ctx context.Context) error {
 	return k.kbfsClient.FSFavoritesChangedEvent(ctx)
 }
 
+// NotifyArchiveProgress implements the KeybaseService interface for
+// KeybaseServiceBase.
+func (k *KeybaseServiceBase) NotifyArchiveProgress(
+	ctx context.Context, progress keybase1.SimpleFSArchiveProgress) error {
+	return k.kbfsClient.FSArchiveProgressEvent(ctx, progress)
+}
+
+// NotifyQuotaAlert implements the KeybaseService interface for
+// KeybaseServiceBase.
+func (k *KeybaseServiceBase) NotifyQuotaAlert(
+	ctx context.Context, alert keybase1.SimpleFSQuotaAlert) error {
+	return k.kbfsClient.FSQuotaAlertEvent(ctx, alert)
+}
+
 // OnPathChange implements the SubscriptionNotifier interface.
 func (k *KeybaseServiceBase) OnPathChange(
 	clientID SubscriptionManagerClientID,
@@ -38,6 +38,10 @@ type KeybaseServiceMeasured struct {
 	favoriteListTimer                metrics.Timer
 	encryptFavoritesTimer            metrics.Timer
 	decryptFavoritesTimer            metrics.Timer
+	encryptArchiveStateTimer         metrics.Timer
+	decryptArchiveStateTimer         metrics.Timer
+	reserveDiskSpaceTimer            metrics.Timer
+	releaseDiskSpaceTimer            metrics.Timer
 	notifyTimer                      metrics.Timer
 	notifyPathUpdatedTimer           metrics.Timer
 	putGitMetadataTimer              metrics.Timer
@@ -71,6 +75,14 @@ func NewKeybaseServiceMeasured(delegate KeybaseService, r metrics.Registry) Keyb
 		"EncryptFavorites", r)
 	decryptFavoritesTimer := metrics.GetOrRegisterTimer("KeybaseService."+
 		"DecryptFavorites", r)
+	encryptArchiveStateTimer := metrics.GetOrRegisterTimer("KeybaseService."+
+		"EncryptArchiveState", r)
+	decryptArchiveStateTimer := metrics.GetOrRegisterTimer("KeybaseService."+
+		"DecryptArchiveState", r)
+	reserveDiskSpaceTimer := metrics.GetOrRegisterTimer("KeybaseService."+
+		"ReserveDiskSpace", r)
+	releaseDiskSpaceTimer := metrics.GetOrRegisterTimer("KeybaseService."+
+		"ReleaseDiskSpace", r)
 	notifyTimer := metrics.GetOrRegisterTimer("KeybaseService.Notify", r)
 	notifyPathUpdatedTimer := metrics.GetOrRegisterTimer("KeybaseService.NotifyPathUpdated", r)
 	putGitMetadataTimer := metrics.GetOrRegisterTimer(
@@ -98,6 +110,10 @@ func NewKeybaseServiceMeasured(delegate KeybaseService, r metrics.Registry) Keyb
 		favoriteListTimer:                favoriteListTimer,
 		encryptFavoritesTimer:            encryptFavoritesTimer,
 		decryptFavoritesTimer:            decryptFavoritesTimer,
+		encryptArchiveStateTimer:         encryptArchiveStateTimer,
+		decryptArchiveStateTimer:         decryptArchiveStateTimer,
+		reserveDiskSpaceTimer:            reserveDiskSpaceTimer,
+		releaseDiskSpaceTimer:            releaseDiskSpaceTimer,
 		notifyTimer:                      notifyTimer,
 		notifyPathUpdatedTimer:           notifyPathUpdatedTimer,
 		putGitMetadataTimer:              putGitMetadataTimer,
@@ -288,6 +304,46 @@ func (k KeybaseServiceMeasured) DecryptFavorites(ctx context.Context,
 	return dataOut, err
 }
 
+// EncryptArchiveState implements the KeybaseService interface for
+// KeybaseServiceMeasured.
+func (k KeybaseServiceMeasured) EncryptArchiveState(ctx context.Context,
+	dataIn []byte) (dataOut []byte, err error) {
+	k.encryptArchiveStateTimer.Time(func() {
+		dataOut, err = k.delegate.EncryptArchiveState(ctx, dataIn)
+	})
+	return dataOut, err
+}
+
+// DecryptArchiveState implements the KeybaseService interface for
+// KeybaseServiceMeasured.
+func (k KeybaseServiceMeasured) DecryptArchiveState(ctx context.Context,
+	dataIn []byte) (dataOut []byte, err error) {
+	k.decryptArchiveStateTimer.Time(func() {
+		dataOut, err = k.delegate.DecryptArchiveState(ctx, dataIn)
+	})
+	return dataOut, err
+}
+
+// ReserveDiskSpace implements the KeybaseService interface for
+// KeybaseServiceMeasured.
+func (k KeybaseServiceMeasured) ReserveDiskSpace(ctx context.Context,
+	key, description string, needs []keybase1.DiskSpaceNeed, wait bool) (err error) {
+	k.reserveDiskSpaceTimer.Time(func() {
+		err = k.delegate.ReserveDiskSpace(ctx, key, description, needs, wait)
+	})
+	return err
+}
+
+// ReleaseDiskSpace implements the KeybaseService interface for
+// KeybaseServiceMeasured.
+func (k KeybaseServiceMeasured) ReleaseDiskSpace(ctx context.Context,
+	key string) (err error) {
+	k.releaseDiskSpaceTimer.Time(func() {
+		err = k.delegate.ReleaseDiskSpace(ctx, key)
+	})
+	return err
+}
+
 // NotifyOnlineStatusChanged implements the KeybaseService interface for
 // KeybaseServiceMeasured.
 func (k KeybaseServiceMeasured) NotifyOnlineStatusChanged(
@@ -346,6 +402,26 @@ func (k KeybaseServiceMeasured) NotifyFavoritesChanged(
 	return err
 }
 
+// NotifyArchiveProgress implements the KeybaseService interface for
+// KeybaseServiceMeasured.
+func (k KeybaseServiceMeasured) NotifyArchiveProgress(
+	ctx context.Context, progress keybase1.SimpleFSArchiveProgress) (err error) {
+	k.notifyTimer.Time(func() {
+		err = k.delegate.NotifyArchiveProgress(ctx, progress)
+	})
+	return err
+}
+
+// NotifyQuotaAlert implements the KeybaseService interface for
+// KeybaseServiceMeasured.
+func (k KeybaseServiceMeasured) NotifyQuotaAlert(
+	ctx context.Context, alert keybase1.SimpleFSQuotaAlert) (err error) {
+	k.notifyTimer.Time(func() {
+		err = k.delegate.NotifyQuotaAlert(ctx, alert)
+	})
+	return err
+}
+
 // FlushUserFromLocalCache implements the KeybaseService interface for
 // KeybaseServiceMeasured.
 func (k KeybaseServiceMeasured) FlushUserFromLocalCache(
@@ -2242,6 +2242,21 @@ func (mr *MockKeybaseServiceMockRecorder) CurrentSession(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CurrentSession", reflect.TypeOf((*MockKeybaseService)(nil).CurrentSession), arg0, arg1)
 }
 
+// DecryptArchiveState mocks base method.
+func (m *MockKeybaseService) DecryptArchiveState(arg0 context.Context, arg1 []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecryptArchiveState", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecryptArchiveState indicates an expected call of DecryptArchiveState.
+func (mr *MockKeybaseServiceMockRecorder) DecryptArchiveState(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecryptArchiveState", reflect.TypeOf((*MockKeybaseService)(nil).DecryptArchiveState), arg0, arg1)
+}
+
 // DecryptFavorites mocks base method.
 func (m *MockKeybaseService) DecryptFavorites(arg0 context.Context, arg1 []byte) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -2257,6 +2272,21 @@ func (mr *MockKeybaseServiceMockRecorder) DecryptFavorites(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecryptFavorites", reflect.TypeOf((*MockKeybaseService)(nil).DecryptFavorites), arg0, arg1)
 }
 
+// EncryptArchiveState mocks base method.
+func (m *MockKeybaseService) EncryptArchiveState(arg0 context.Context, arg1 []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EncryptArchiveState", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EncryptArchiveState indicates an expected call of EncryptArchiveState.
+func (mr *MockKeybaseServiceMockRecorder) EncryptArchiveState(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EncryptArchiveState", reflect.TypeOf((*MockKeybaseService)(nil).EncryptArchiveState), arg0, arg1)
+}
+
 // EncryptFavorites mocks base method.
 func (m *MockKeybaseService) EncryptFavorites(arg0 context.Context, arg1 []byte) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -2462,6 +2492,20 @@ func (mr *MockKeybaseServiceMockRecorder) Notify(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockKeybaseService)(nil).Notify), arg0, arg1)
 }
 
+// NotifyArchiveProgress mocks base method.
+func (m *MockKeybaseService) NotifyArchiveProgress(arg0 context.Context, arg1 keybase1.SimpleFSArchiveProgress) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyArchiveProgress", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyArchiveProgress indicates an expected call of NotifyArchiveProgress.
+func (mr *MockKeybaseServiceMockRecorder) NotifyArchiveProgress(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyArchiveProgress", reflect.TypeOf((*MockKeybaseService)(nil).NotifyArchiveProgress), arg0, arg1)
+}
+
 // NotifyFavoritesChanged mocks base method.
 func (m *MockKeybaseService) NotifyFavoritesChanged(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -2518,6 +2562,20 @@ func (mr *MockKeybaseServiceMockRecorder) NotifyPathUpdated(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyPathUpdated", reflect.TypeOf((*MockKeybaseService)(nil).NotifyPathUpdated), arg0, arg1)
 }
 
+// NotifyQuotaAlert mocks base method.
+func (m *MockKeybaseService) NotifyQuotaAlert(arg0 context.Context, arg1 keybase1.SimpleFSQuotaAlert) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyQuotaAlert", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyQuotaAlert indicates an expected call of NotifyQuotaAlert.
+func (mr *MockKeybaseServiceMockRecorder) NotifyQuotaAlert(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyQuotaAlert", reflect.TypeOf((*MockKeybaseService)(nil).NotifyQuotaAlert), arg0, arg1)
+}
+
 // NotifySyncStatus mocks base method.
 func (m *MockKeybaseService) NotifySyncStatus(arg0 context.Context, arg1 *keybase1.FSPathSyncStatus) error {
 	m.ctrl.T.Helper()
@@ -2570,6 +2628,34 @@ func (mr *MockKeybaseServiceMockRecorder) PutGitMetadata(arg0, arg1, arg2, arg3
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutGitMetadata", reflect.TypeOf((*MockKeybaseService)(nil).PutGitMetadata), arg0, arg1, arg2, arg3)
 }
 
+// ReleaseDiskSpace mocks base method.
+func (m *MockKeybaseService) ReleaseDiskSpace(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseDiskSpace", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseDiskSpace indicates an expected call of ReleaseDiskSpace.
+func (mr *MockKeybaseServiceMockRecorder) ReleaseDiskSpace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseDiskSpace", reflect.TypeOf((*MockKeybaseService)(nil).ReleaseDiskSpace), arg0, arg1)
+}
+
+// ReserveDiskSpace mocks base method.
+func (m *MockKeybaseService) ReserveDiskSpace(arg0 context.Context, arg1, arg2 string, arg3 []keybase1.DiskSpaceNeed, arg4 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveDiskSpace", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReserveDiskSpace indicates an expected call of ReserveDiskSpace.
+func (mr *MockKeybaseServiceMockRecorder) ReserveDiskSpace(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveDiskSpace", reflect.TypeOf((*MockKeybaseService)(nil).ReserveDiskSpace), arg0, arg1, arg2, arg3, arg4)
+}
+
 // Resolve mocks base method.
 func (m *MockKeybaseService) Resolve(arg0 context.Context, arg1 string, arg2 keybase1.OfflineAvailability) (kbun.NormalizedUsername, keybase1.UserOrTeamID, error) {
 	m.ctrl.T.Helper()
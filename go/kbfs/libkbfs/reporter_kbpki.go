@@ -388,6 +388,7 @@ func (r *ReporterKBPKI) send(ctx context.Context) {
 				nt != keybase1.FSNotificationType_INITIALIZED &&
 				nt != keybase1.FSNotificationType_CONNECTION &&
 				nt != keybase1.FSNotificationType_SYNC_CONFIG_CHANGED &&
+				nt != keybase1.FSNotificationType_ARCHIVE &&
 				st != keybase1.FSStatusCode_ERROR {
 				continue
 			}
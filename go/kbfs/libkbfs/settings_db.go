@@ -4,6 +4,7 @@ import (
 	"os"
 	"path"
 	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/keybase/client/go/kbfs/idutil"
@@ -29,8 +30,16 @@ const (
 
 	sfmiBannerDismissedKey = "sfmiBannerDismissed"
 	syncOnCellularKey      = "syncOnCellular"
+
+	quotaAlertThresholdsKey    = "quotaAlertThresholds"
+	quotaAlertChatToSelfKey    = "quotaAlertChatToSelf"
+	quotaAlertLastThresholdKey = "quotaAlertLastThreshold"
 )
 
+// DefaultQuotaAlertThresholds are the quota usage percentages that trigger
+// an alert when the user hasn't configured their own.
+var DefaultQuotaAlertThresholds = []int{80, 95}
+
 // ErrNoSettingsDB is returned when there is no settings DB potentially due to
 // multiple concurrent KBFS instances.
 var ErrNoSettingsDB = errors.New("no settings DB")
@@ -254,3 +263,115 @@ func (db *SettingsDB) SetSyncOnCellular(
 	return db.Put(getSettingsDbKey(uid, syncOnCellularKey),
 		[]byte(strconv.FormatBool(syncOnCellular)), nil)
 }
+
+// QuotaAlertSettings returns the logged-in user's quota alert settings.
+func (db *SettingsDB) QuotaAlertSettings(ctx context.Context) (
+	keybase1.SimpleFSQuotaAlertSettings, error) {
+	uid := db.getUID(ctx)
+	if uid == keybase1.UID("") {
+		return keybase1.SimpleFSQuotaAlertSettings{}, errNoSession
+	}
+
+	thresholds := append([]int(nil), DefaultQuotaAlertThresholds...)
+	thresholdsBytes, err :=
+		db.Get(getSettingsDbKey(uid, quotaAlertThresholdsKey), nil)
+	switch errors.Cause(err) {
+	case leveldb.ErrNotFound:
+		db.vlogger.CLogf(ctx, libkb.VLog1,
+			"quotaAlertThresholds not set; using default value")
+	case nil:
+		// An empty value means alerts were turned off.
+		thresholds = nil
+		for _, t := range strings.Split(string(thresholdsBytes), ",") {
+			if len(t) == 0 {
+				continue
+			}
+			threshold, err := strconv.Atoi(t)
+			if err != nil {
+				return keybase1.SimpleFSQuotaAlertSettings{}, err
+			}
+			thresholds = append(thresholds, threshold)
+		}
+	default:
+		db.logger.CWarningf(ctx,
+			"reading quotaAlertThresholds from leveldb error: %+v", err)
+		return keybase1.SimpleFSQuotaAlertSettings{}, err
+	}
+
+	var chatToSelf bool
+	chatToSelfBytes, err :=
+		db.Get(getSettingsDbKey(uid, quotaAlertChatToSelfKey), nil)
+	switch errors.Cause(err) {
+	case leveldb.ErrNotFound:
+		db.vlogger.CLogf(ctx, libkb.VLog1,
+			"quotaAlertChatToSelf not set; using default value")
+	case nil:
+		chatToSelf, err = strconv.ParseBool(string(chatToSelfBytes))
+		if err != nil {
+			return keybase1.SimpleFSQuotaAlertSettings{}, err
+		}
+	default:
+		db.logger.CWarningf(ctx,
+			"reading quotaAlertChatToSelf from leveldb error: %+v", err)
+		return keybase1.SimpleFSQuotaAlertSettings{}, err
+	}
+
+	return keybase1.SimpleFSQuotaAlertSettings{
+		Thresholds: thresholds,
+		ChatToSelf: chatToSelf,
+	}, nil
+}
+
+// SetQuotaAlertSettings sets the quota alert settings for the logged-in
+// user. The thresholds are expected to be sorted already.
+func (db *SettingsDB) SetQuotaAlertSettings(
+	ctx context.Context, settings keybase1.SimpleFSQuotaAlertSettings) error {
+	uid := db.getUID(ctx)
+	if uid == keybase1.UID("") {
+		return errNoSession
+	}
+	thresholds := make([]string, 0, len(settings.Thresholds))
+	for _, t := range settings.Thresholds {
+		thresholds = append(thresholds, strconv.Itoa(t))
+	}
+	err := db.Put(getSettingsDbKey(uid, quotaAlertThresholdsKey),
+		[]byte(strings.Join(thresholds, ",")), nil)
+	if err != nil {
+		return err
+	}
+	return db.Put(getSettingsDbKey(uid, quotaAlertChatToSelfKey),
+		[]byte(strconv.FormatBool(settings.ChatToSelf)), nil)
+}
+
+// QuotaAlertLastThreshold returns the highest quota alert threshold that
+// has already fired for the logged-in user, or 0 if none has.
+func (db *SettingsDB) QuotaAlertLastThreshold(ctx context.Context) (int, error) {
+	uid := db.getUID(ctx)
+	if uid == keybase1.UID("") {
+		return 0, errNoSession
+	}
+	lastBytes, err :=
+		db.Get(getSettingsDbKey(uid, quotaAlertLastThresholdKey), nil)
+	switch errors.Cause(err) {
+	case leveldb.ErrNotFound:
+		return 0, nil
+	case nil:
+		return strconv.Atoi(string(lastBytes))
+	default:
+		db.logger.CWarningf(ctx,
+			"reading quotaAlertLastThreshold from leveldb error: %+v", err)
+		return 0, err
+	}
+}
+
+// SetQuotaAlertLastThreshold records the highest quota alert threshold
+// that has fired for the logged-in user.
+func (db *SettingsDB) SetQuotaAlertLastThreshold(
+	ctx context.Context, threshold int) error {
+	uid := db.getUID(ctx)
+	if uid == keybase1.UID("") {
+		return errNoSession
+	}
+	return db.Put(getSettingsDbKey(uid, quotaAlertLastThresholdKey),
+		[]byte(strconv.Itoa(threshold)), nil)
+}
@@ -14,18 +14,26 @@ import (
 	"hash"
 	"io"
 	"io/fs"
+	"math/rand"
 	"os"
+	"path"
 	"path/filepath"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/keybase/client/go/kbfs/libkbfs"
+	"github.com/keybase/client/go/libkb"
 	"github.com/keybase/client/go/protocol/keybase1"
 	"github.com/pkg/errors"
 	"golang.org/x/net/context"
+	"golang.org/x/time/rate"
 	"gopkg.in/src-d/go-billy.v4"
 )
 
+// loadArchiveStateFromJsonGz reads the unencrypted state file written before
+// the state was encrypted at rest. It's only used to migrate from it.
 func loadArchiveStateFromJsonGz(ctx context.Context, simpleFS *SimpleFS, filePath string) (state *keybase1.SimpleFSArchiveState, err error) {
 	f, err := os.Open(filePath)
 	if err != nil {
@@ -33,79 +41,153 @@ func loadArchiveStateFromJsonGz(ctx context.Context, simpleFS *SimpleFS, filePat
 		return nil, err
 	}
 	defer f.Close()
-	gzReader, err := gzip.NewReader(f)
+	return decodeArchiveState(ctx, simpleFS, f)
+}
+
+func decodeArchiveState(ctx context.Context, simpleFS *SimpleFS, r io.Reader) (state *keybase1.SimpleFSArchiveState, err error) {
+	err = decodeArchiveStateValue(r, &state)
 	if err != nil {
-		simpleFS.log.CErrorf(ctx, "loadArchiveStateFromJsonGz: creating gzip reader error: %v", err)
+		simpleFS.log.CErrorf(ctx, "decodeArchiveState: decoding state error: %v", err)
 		return nil, err
 	}
-	decoder := json.NewDecoder(gzReader)
-	err = decoder.Decode(&state)
+	return state, nil
+}
+
+func encodeArchiveState(s *keybase1.SimpleFSArchiveState) ([]byte, error) {
+	return encodeArchiveStateValue(s)
+}
+
+// decodeArchiveStateValue decodes gzipped JSON written by
+// encodeArchiveStateValue into v.
+func decodeArchiveStateValue(r io.Reader, v interface{}) error {
+	gzReader, err := gzip.NewReader(r)
 	if err != nil {
-		simpleFS.log.CErrorf(ctx, "loadArchiveStateFromJsonGz: decoding state file error: %v", err)
-		return nil, err
+		return fmt.Errorf("creating gzip reader error: %v", err)
 	}
-	return state, nil
+	return json.NewDecoder(gzReader).Decode(v)
 }
 
-func writeArchiveStateIntoJsonGz(ctx context.Context, simpleFS *SimpleFS, filePath string, s *keybase1.SimpleFSArchiveState) error {
-	err := os.MkdirAll(filepath.Dir(filePath), 0755)
+func encodeArchiveStateValue(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	gzWriter := gzip.NewWriter(&buf)
+	err := json.NewEncoder(gzWriter).Encode(v)
 	if err != nil {
-		simpleFS.log.CErrorf(ctx, "writeArchiveStateIntoJsonGz: os.MkdirAll error: %v", err)
-		return err
+		return nil, err
 	}
-	f, err := os.Create(filePath)
+	err = gzWriter.Close()
 	if err != nil {
-		simpleFS.log.CErrorf(ctx, "writeArchiveStateIntoJsonGz: creating state file error: %v", err)
-		return err
+		return nil, err
 	}
-	defer f.Close()
-
-	gzWriter := gzip.NewWriter(f)
-	defer gzWriter.Close()
+	return buf.Bytes(), nil
+}
 
-	encoder := json.NewEncoder(gzWriter)
-	err = encoder.Encode(s)
+// loadEncryptedArchiveState reads the encrypted state file the state was
+// kept in before it moved to archiveStateStore. It's only used to migrate
+// from it. The service holds the key, so this fails if it's not reachable
+// or nobody is logged in.
+func loadEncryptedArchiveState(ctx context.Context, simpleFS *SimpleFS, filePath string) (state *keybase1.SimpleFSArchiveState, err error) {
+	encrypted, err := os.ReadFile(filePath)
 	if err != nil {
-		simpleFS.log.CErrorf(ctx, "writeArchiveStateIntoJsonGz: encoding state file error: %v", err)
-		return err
+		return nil, err
+	}
+	data, err := simpleFS.config.KeybaseService().DecryptArchiveState(ctx, encrypted)
+	if err != nil {
+		simpleFS.log.CErrorf(ctx, "loadEncryptedArchiveState: decrypting state file error: %v", err)
+		return nil, archiveStateKeyError{err}
 	}
+	return decodeArchiveState(ctx, simpleFS, bytes.NewReader(data))
+}
 
-	return nil
+// archiveStateKeyError means the service couldn't encrypt or decrypt the
+// state. That's usually temporary (e.g. nobody is logged in yet), so it's
+// retried rather than starting over with a new state.
+type archiveStateKeyError struct {
+	err error
 }
 
-type errorState struct {
-	err       error
-	nextRetry time.Time
+func (e archiveStateKeyError) Error() string {
+	return fmt.Sprintf("encrypting or decrypting archive state: %v", e.err)
 }
 
+// archiveStateLoadRetryInterval is how long to wait before trying to load
+// the state again after archiveStateKeyError.
+const archiveStateLoadRetryInterval = 10 * time.Second
+
 type archiveManager struct {
 	simpleFS *SimpleFS
 
 	// Just use a regular mutex rather than a rw one so all writes to
 	// persistent storage are synchronized.
-	mu               sync.Mutex
-	state            *keybase1.SimpleFSArchiveState
-	jobCtxCancellers map[string]func()
-	// jobID -> errorState. Populated when an error has happened. It's only
-	// valid for these phases:
-	//
-	//   keybase1.SimpleFSArchiveJobPhase_Indexing
-	//   keybase1.SimpleFSArchiveJobPhase_Copying
-	//   keybase1.SimpleFSArchiveJobPhase_Zipping
-	//
-	// When nextRetry is current errorRetryWorker delete the errorState from
-	// this map, while also putting them back to the previous phase so the
-	// worker can pick it up.
-	errors map[string]errorState
-
-	indexingWorkerSignal chan struct{}
-	copyingWorkerSignal  chan struct{}
-	zippingWorkerSignal  chan struct{}
+	mu    sync.Mutex
+	state *keybase1.SimpleFSArchiveState
+	// Set when state has changed since it was last written, for
+	// stateFlushWorker to write it. Protected by mu.
+	stateDirty bool
+	// Bumped on every change to state that needs writing and on every
+	// synchronous flush, so that stateFlushWorker can tell whether the copy
+	// it wrote from is older than what's already been written. Protected by
+	// mu.
+	stateGen uint64
+	// Serializes writes to store, since stateFlushWorker writes without
+	// holding mu. If both are needed, mu is taken first.
+	flushMu sync.Mutex
+	// The newest stateGen written to store. Protected by flushMu.
+	flushedGen uint64
+	// Where state is persisted. Opened when the state is loaded.
+	store *archiveStateStore
+	// Who the state belongs to, as of when it was loaded.
+	owner archiveOwner
+	// jobID -> the task of the worker working on the job, if any.
+	jobTasks map[string]*archiveJobTask
+	// jobID -> copy throttle. Created when a job enters the copying phase, so
+	// that simpleFSArchiveSetBytesPerSecond can adjust it in place, and
+	// dropped when the phase ends.
+	throttles map[string]*rate.Limiter
+	// Returns the free bytes on the volume holding a path. Replaced in tests.
+	getAvailableDiskBytes func(path string) (uint64, error)
+	// Protects diskReservations. If both are needed, mu is taken first.
+	diskReservationsMu sync.Mutex
+	// The jobs that have reserved disk space with the service.
+	diskReservations map[string]bool
+	// Sends a progress notification for a copying or zipping job. Replaced
+	// in tests.
+	notifyProgress func(ctx context.Context, progress keybase1.SimpleFSArchiveProgress) error
+	// Sends the notification for a job finishing or failing. Replaced in
+	// tests.
+	notify func(ctx context.Context, notification *keybase1.FSNotification)
+
+	// Closed once state has been loaded. The state is encrypted by the
+	// service, which isn't around yet when we're created, so it's loaded in
+	// the background and everything else waits for it.
+	stateLoaded chan struct{}
+
+	indexingWorkerSignal  chan struct{}
+	copyingWorkerSignal   chan struct{}
+	zippingWorkerSignal   chan struct{}
+	scheduleWorkerSignal  chan struct{}
+	retentionWorkerSignal chan struct{}
+	summaryWorkerSignal   chan struct{}
+	stateFlushSignal      chan struct{}
+
+	diskReservationWorkerSignal chan struct{}
+
+	// Until when workers don't pick up jobs, after drain. Protected by mu.
+	drainedUntil time.Time
 
 	ctxCancel func()
 }
 
+// getStateFilePath is where the state was kept, encrypted, before it moved
+// to archiveStateStore.
 func getStateFilePath(simpleFS *SimpleFS) string {
+	username := simpleFS.config.KbEnv().GetUsername()
+	cacheDir := simpleFS.getCacheDir()
+	return filepath.Join(cacheDir, fmt.Sprintf("kbfs-archive-%s.enc", username))
+}
+
+// getLegacyStateFilePath is where the state was kept before it was
+// encrypted.
+func getLegacyStateFilePath(simpleFS *SimpleFS) string {
 	username := simpleFS.config.KbEnv().GetUsername()
 	cacheDir := simpleFS.getCacheDir()
 	return filepath.Join(cacheDir, fmt.Sprintf("kbfs-archive-%s.json.gz", username))
@@ -117,19 +199,106 @@ func (m *archiveManager) flushStateFileLocked(ctx context.Context) error {
 		return ctx.Err()
 	default:
 	}
-	err := writeArchiveStateIntoJsonGz(ctx, m.simpleFS, getStateFilePath(m.simpleFS), m.state)
+	select {
+	case <-m.stateLoaded:
+	default:
+		// Don't clobber a state we haven't been able to read yet.
+		return nil
+	}
+	if m.store == nil {
+		// Already shut down.
+		return nil
+	}
+	m.stateGen++
+	m.stateDirty = false
+	m.flushMu.Lock()
+	defer m.flushMu.Unlock()
+	err := m.store.write(ctx, m.state)
 	if err != nil {
 		m.simpleFS.log.CErrorf(ctx,
-			"archiveManager.flushStateFileLocked: writing state file error: %v", err)
+			"archiveManager.flushStateFileLocked: writing state error: %v", err)
 		return err
 	}
+	m.flushedGen = m.stateGen
 	return nil
 }
 
-func (m *archiveManager) flushStateFile(ctx context.Context) error {
+// markStateDirtyLocked records that the state needs to be written, and
+// wakes stateFlushWorker to write it soon, so that workers don't have to
+// wait on the disk after every step.
+func (m *archiveManager) markStateDirtyLocked() {
+	m.stateDirty = true
+	m.stateGen++
+	m.signal(m.stateFlushSignal)
+}
+
+func (m *archiveManager) markStateDirty() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	return m.flushStateFileLocked(ctx)
+	m.markStateDirtyLocked()
+}
+
+// archiveStateFlushDelay is how long stateFlushWorker lets changes pile up
+// before writing them all at once.
+const archiveStateFlushDelay = 500 * time.Millisecond
+
+// flushDirtyState writes a copy of the state if it has changed, holding mu
+// only long enough to make the copy.
+func (m *archiveManager) flushDirtyState(ctx context.Context) {
+	m.mu.Lock()
+	if !m.stateDirty || m.store == nil {
+		m.mu.Unlock()
+		return
+	}
+	state := m.state.DeepCopy()
+	gen := m.stateGen
+	store := m.store
+	m.stateDirty = false
+	m.mu.Unlock()
+
+	m.flushMu.Lock()
+	defer m.flushMu.Unlock()
+	if gen <= m.flushedGen {
+		// A synchronous flush has already written this or something newer,
+		// or the store has been closed since.
+		return
+	}
+	err := store.write(ctx, &state)
+	if err != nil {
+		m.simpleFS.log.CWarningf(ctx,
+			"archiveManager.flushDirtyState: writing state error: %v", err)
+		return
+	}
+	m.flushedGen = gen
+}
+
+func (m *archiveManager) stateFlushWorker(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.stateFlushSignal:
+		}
+		select {
+		case <-ctx.Done():
+			// shutdown flushes whatever is left.
+			return
+		case <-time.After(archiveStateFlushDelay):
+		}
+		m.flushDirtyState(ctx)
+	}
+}
+
+// closeStoreLocked closes the store, making sure stateFlushWorker doesn't
+// write to it afterward.
+func (m *archiveManager) closeStoreLocked(ctx context.Context) {
+	m.flushMu.Lock()
+	defer m.flushMu.Unlock()
+	if err := m.store.close(); err != nil {
+		m.simpleFS.log.CWarningf(ctx, "closing archive state store error: %v", err)
+	}
+	m.store = nil
+	m.flushedGen = m.stateGen
 }
 
 func (m *archiveManager) signal(ch chan struct{}) {
@@ -153,64 +322,369 @@ func (m *archiveManager) shutdown(ctx context.Context) {
 	if err != nil {
 		m.simpleFS.log.CWarningf(ctx, "m.flushStateFileLocked error: %v", err)
 	}
+	if m.store != nil {
+		m.closeStoreLocked(ctx)
+	}
+}
+
+// ArchiveNotEnoughSpaceError is returned when there isn't enough free disk
+// space to stage and zip an archive job.
+type ArchiveNotEnoughSpaceError struct {
+	Path      string
+	Needed    uint64
+	Available uint64
+}
+
+// Error implements the error interface for ArchiveNotEnoughSpaceError.
+func (e ArchiveNotEnoughSpaceError) Error() string {
+	return fmt.Sprintf(
+		"not enough disk space to archive: %s needs %d bytes free but only has %d",
+		e.Path, e.Needed, e.Available)
+}
+
+// checkDiskSpace makes sure there's room for a job archiving bytesTotal
+// bytes: one copy in the staging directory and one in the zip file. If both
+// are on the same volume, that volume needs room for both. The space is
+// then reserved with the service, which fails if other jobs have already
+// reserved it.
+func (m *archiveManager) checkDiskSpace(ctx context.Context,
+	desc keybase1.SimpleFSArchiveJobDesc, bytesTotal int64) error {
+	if bytesTotal <= 0 {
+		return nil
+	}
+	type volumeNeed struct {
+		path   string
+		needed uint64
+	}
+	var order []string
+	needs := make(map[string]*volumeNeed)
+	for _, need := range archiveDiskSpaceNeeds(desc, bytesTotal, bytesTotal) {
+		// The staging directory and the zip file's directory might not have
+		// been created yet.
+		p := libkb.NearestExistingPath(need.Path)
+		volume, err := libkb.DiskVolumeID(p)
+		if err != nil {
+			return err
+		}
+		if v, ok := needs[volume]; ok {
+			v.needed += uint64(need.Bytes)
+			continue
+		}
+		needs[volume] = &volumeNeed{path: p, needed: uint64(need.Bytes)}
+		order = append(order, volume)
+	}
+	for _, volume := range order {
+		need := needs[volume]
+		available, err := m.getAvailableDiskBytes(need.path)
+		if err != nil {
+			return err
+		}
+		m.simpleFS.log.CDebugf(ctx, "checkDiskSpace: %s needs %d bytes, has %d",
+			need.path, need.needed, available)
+		if available < need.needed {
+			return ArchiveNotEnoughSpaceError{
+				Path:      need.path,
+				Needed:    need.needed,
+				Available: available,
+			}
+		}
+	}
+	return m.reserveDiskSpace(ctx, desc, bytesTotal, bytesTotal)
 }
 
 func (m *archiveManager) startJob(ctx context.Context, job keybase1.SimpleFSArchiveJobDesc) error {
 	m.simpleFS.log.CDebugf(ctx, "+ archiveManager.startJob %#+v", job)
 	defer m.simpleFS.log.CDebugf(ctx, "- archiveManager.startJob")
 
+	if err := m.waitForState(ctx); err != nil {
+		return err
+	}
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	if !m.owner.loggedIn {
+		if err := checkLoggedOutArchiveJob(job); err != nil {
+			return err
+		}
+	}
 	if _, ok := m.state.Jobs[job.JobID]; ok {
 		return errors.New("job ID already exists")
 	}
-	m.state.Jobs[job.JobID] = keybase1.SimpleFSArchiveJobState{
+	jobState := keybase1.SimpleFSArchiveJobState{
 		Desc:  job,
 		Phase: keybase1.SimpleFSArchiveJobPhase_Queued,
 	}
+	if len(job.BaseJobID) > 0 {
+		// Keep our own copy of the base manifest, so the base job can be
+		// dismissed while this one is still running.
+		base, ok := m.state.Jobs[job.BaseJobID]
+		if !ok {
+			return errors.New("base job not found")
+		}
+		jobState.BaseManifest = base.DeepCopy().Manifest
+		// The base job's size is our best guess until indexing is done, and
+		// lets us fail before doing any work.
+		if err := m.checkDiskSpace(ctx, job, base.BytesTotal); err != nil {
+			return err
+		}
+	}
+	m.state.Jobs[job.JobID] = jobState
 	m.state.LastUpdated = keybase1.ToTime(time.Now())
 	m.signal(m.indexingWorkerSignal)
 	return m.flushStateFileLocked(ctx)
 }
 
+// getIncrementalBaseRevision checks that baseJobID can be the base of an
+// incremental job archiving kbfsPath, and returns the revision it archived.
+func (m *archiveManager) getIncrementalBaseRevision(ctx context.Context,
+	baseJobID string, kbfsPath string) (keybase1.KBFSRevision, error) {
+	if err := m.waitForState(ctx); err != nil {
+		return 0, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	base, ok := m.state.Jobs[baseJobID]
+	if !ok {
+		return 0, errors.New("base job not found")
+	}
+	if base.Phase != keybase1.SimpleFSArchiveJobPhase_Done {
+		return 0, fmt.Errorf("base job %s is not done yet", baseJobID)
+	}
+	if base.Desc.JobType == keybase1.SimpleFSArchiveJobType_Restore {
+		return 0, fmt.Errorf("base job %s is a restore job", baseJobID)
+	}
+	if len(base.Desc.Sources) > 0 {
+		return 0, fmt.Errorf("base job %s archived more than one path", baseJobID)
+	}
+	if base.Desc.KbfsPathWithRevision.Path != kbfsPath {
+		return 0, fmt.Errorf("base job %s archived %s, not %s", baseJobID,
+			base.Desc.KbfsPathWithRevision.Path, kbfsPath)
+	}
+	param := base.Desc.KbfsPathWithRevision.ArchivedParam
+	if typ, err := param.KBFSArchivedType(); err != nil ||
+		typ != keybase1.KBFSArchivedType_REVISION {
+		return 0, fmt.Errorf("base job %s is not pinned to a revision", baseJobID)
+	}
+	return param.Revision(), nil
+}
+
 func (m *archiveManager) cancelOrDismissJob(ctx context.Context,
 	jobID string) (err error) {
 	m.simpleFS.log.CDebugf(ctx, "+ archiveManager.cancelOrDismissJob")
 	defer m.simpleFS.log.CDebugf(ctx, "- archiveManager.cancelOrDismissJob %s", jobID)
+	if err := m.waitForState(ctx); err != nil {
+		return err
+	}
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	return m.cancelOrDismissJobLocked(ctx, jobID)
+}
+
+func (m *archiveManager) cancelOrDismissJobLocked(ctx context.Context,
+	jobID string) (err error) {
+	job, ok := m.state.Jobs[jobID]
+	if !ok {
+		return errors.New("job not found")
+	}
+	delete(m.throttles, jobID)
+
+	task, ok := m.jobTasks[jobID]
+	if !ok {
+		m.removeJobLocked(ctx, jobID)
+		return nil
+	}
+	if job.Phase == keybase1.SimpleFSArchiveJobPhase_Cancelling {
+		return nil
+	}
+	// A worker has the job. Have it stop, and remove the job once it's let
+	// go of it.
+	job.Phase = keybase1.SimpleFSArchiveJobPhase_Cancelling
+	m.state.Jobs[jobID] = job
+	m.state.LastUpdated = keybase1.ToTime(time.Now())
+	task.interrupt()
+	go m.finishCancelling(jobID, task)
+	return nil
+}
 
-	if cancel, ok := m.jobCtxCancellers[jobID]; ok {
-		cancel()
-		delete(m.jobCtxCancellers, jobID)
+// finishCancelling removes a job that was canceled while a worker had it,
+// once the worker is done with it.
+func (m *archiveManager) finishCancelling(jobID string, task *archiveJobTask) {
+	<-task.done
+	ctx := context.Background()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.removeJobLocked(ctx, jobID)
+	m.state.LastUpdated = keybase1.ToTime(time.Now())
+	err := m.flushStateFileLocked(ctx)
+	if err != nil {
+		m.simpleFS.log.CWarningf(ctx, "m.flushStateFileLocked error: %v", err)
 	}
+}
 
+// removeJobLocked forgets about jobID and removes its staging files. No
+// worker may have the job.
+func (m *archiveManager) removeJobLocked(ctx context.Context, jobID string) {
 	job, ok := m.state.Jobs[jobID]
 	if !ok {
-		return errors.New("job not found")
+		return
 	}
 	delete(m.state.Jobs, jobID)
 
-	err = os.RemoveAll(job.Desc.StagingPath)
+	err := os.RemoveAll(job.Desc.StagingPath)
 	if err != nil {
 		m.simpleFS.log.CWarningf(ctx, "removing staging path %q for job %s error: %v",
 			job.Desc.StagingPath, jobID, err)
 	}
+	// A job waiting for staging space might fit now.
+	m.signal(m.copyingWorkerSignal)
+	m.signal(m.diskReservationWorkerSignal)
+}
 
-	return nil
+// pauseJob stops any work on jobID and keeps the workers from picking it up
+// until it's resumed. Its state and staging files are kept, so it carries on
+// from where it was.
+func (m *archiveManager) pauseJob(ctx context.Context, jobID string) (err error) {
+	m.simpleFS.log.CDebugf(ctx, "+ archiveManager.pauseJob %s", jobID)
+	defer m.simpleFS.log.CDebugf(ctx, "- archiveManager.pauseJob %s err: %v", jobID, err)
+	if err := m.waitForState(ctx); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.state.Jobs[jobID]
+	if !ok {
+		return errors.New("job not found")
+	}
+	switch job.Phase {
+	case keybase1.SimpleFSArchiveJobPhase_Done:
+		return errors.New("job is already done")
+	case keybase1.SimpleFSArchiveJobPhase_Cancelling:
+		return errors.New("job is being canceled")
+	case keybase1.SimpleFSArchiveJobPhase_Failed:
+		return errors.New("job has failed; retry it instead")
+	}
+	if job.Paused {
+		return nil
+	}
+	job.Paused = true
+	waitingToRetry := job.ErrorState != nil
+	job.ErrorState = nil
+	m.state.Jobs[jobID] = job
+
+	if waitingToRetry {
+		// No worker is running it; it was waiting to be retried.
+		m.resetInterruptedPhaseLocked(ctx, jobID)
+	}
+	if task, ok := m.jobTasks[jobID]; ok {
+		// The worker will notice the job is paused when it fails, and put
+		// it back in the phase before.
+		task.interrupt()
+	}
+	m.signal(m.diskReservationWorkerSignal)
+	m.state.LastUpdated = keybase1.ToTime(time.Now())
+	return m.flushStateFileLocked(ctx)
+}
+
+func (m *archiveManager) resumeJob(ctx context.Context, jobID string) (err error) {
+	m.simpleFS.log.CDebugf(ctx, "+ archiveManager.resumeJob %s", jobID)
+	defer m.simpleFS.log.CDebugf(ctx, "- archiveManager.resumeJob %s err: %v", jobID, err)
+	if err := m.waitForState(ctx); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.state.Jobs[jobID]
+	if !ok {
+		return errors.New("job not found")
+	}
+	if !job.Paused {
+		return errors.New("job is not paused")
+	}
+	switch job.Phase {
+	case keybase1.SimpleFSArchiveJobPhase_Indexing,
+		keybase1.SimpleFSArchiveJobPhase_Copying,
+		keybase1.SimpleFSArchiveJobPhase_Zipping:
+		// Resuming now could have two workers on the same job.
+		return errors.New("job is still being paused; try again shortly")
+	case keybase1.SimpleFSArchiveJobPhase_Cancelling:
+		return errors.New("job is being canceled")
+	}
+	job.Paused = false
+	m.state.Jobs[jobID] = job
+	m.state.LastUpdated = keybase1.ToTime(time.Now())
+	m.signal(m.indexingWorkerSignal)
+	m.signal(m.copyingWorkerSignal)
+	m.signal(m.zippingWorkerSignal)
+	m.signal(m.diskReservationWorkerSignal)
+	return m.flushStateFileLocked(ctx)
+}
+
+func archiveThrottleLimit(bytesPerSecond int64) rate.Limit {
+	if bytesPerSecond <= 0 {
+		return rate.Inf
+	}
+	return rate.Limit(bytesPerSecond)
+}
+
+// getThrottle returns the copy throttle for jobID, creating it from
+// bytesPerSecond if the job doesn't have one yet.
+func (m *archiveManager) getThrottle(
+	jobID string, bytesPerSecond int64) *rate.Limiter {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if limiter, ok := m.throttles[jobID]; ok {
+		return limiter
+	}
+	limiter := rate.NewLimiter(
+		archiveThrottleLimit(bytesPerSecond), archiveCopyChunkSize)
+	m.throttles[jobID] = limiter
+	return limiter
+}
+
+// dropThrottle forgets jobID's copy throttle once it's done copying. The job
+// keeps its rate, so if it copies again, the new throttle starts from that.
+func (m *archiveManager) dropThrottle(jobID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.throttles, jobID)
+}
+
+func (m *archiveManager) setBytesPerSecond(ctx context.Context,
+	jobID string, bytesPerSecond int64) error {
+	m.simpleFS.log.CDebugf(ctx, "+ archiveManager.setBytesPerSecond %s %d", jobID, bytesPerSecond)
+	defer m.simpleFS.log.CDebugf(ctx, "- archiveManager.setBytesPerSecond")
+
+	if bytesPerSecond < 0 {
+		return errors.New("bytesPerSecond cannot be negative")
+	}
+
+	if err := m.waitForState(ctx); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.state.Jobs[jobID]
+	if !ok {
+		return errors.New("job not found")
+	}
+	job.Desc.BytesPerSecond = bytesPerSecond
+	m.state.Jobs[jobID] = job
+	if limiter, ok := m.throttles[jobID]; ok {
+		limiter.SetLimit(archiveThrottleLimit(bytesPerSecond))
+	}
+	m.state.LastUpdated = keybase1.ToTime(time.Now())
+	return m.flushStateFileLocked(ctx)
 }
 
 func (m *archiveManager) getCurrentState(ctx context.Context) (
-	state keybase1.SimpleFSArchiveState, errorStates map[string]errorState) {
+	state keybase1.SimpleFSArchiveState, err error) {
 	m.simpleFS.log.CDebugf(ctx, "+ archiveManager.getCurrentState")
 	defer m.simpleFS.log.CDebugf(ctx, "- archiveManager.getCurrentState")
+	if err := m.waitForState(ctx); err != nil {
+		return state, err
+	}
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	errorStates = make(map[string]errorState)
-	for jobID, errState := range m.errors {
-		errorStates[jobID] = errState
-	}
-	return m.state.DeepCopy(), errorStates
+	return m.state.DeepCopy(), nil
 }
 
 func (m *archiveManager) changeJobPhaseLocked(ctx context.Context,
@@ -220,8 +694,18 @@ func (m *archiveManager) changeJobPhaseLocked(ctx context.Context,
 		m.simpleFS.log.CWarningf(ctx, "job %s not found. it might have been canceled", jobID)
 		return
 	}
+	if copy.Phase == keybase1.SimpleFSArchiveJobPhase_Cancelling {
+		// It's removed once the worker lets go of it.
+		return
+	}
 	copy.Phase = newPhase
+	// The estimate is for the phase the job was in.
+	copy.EstimatedCompletion = 0
+	if newPhase == keybase1.SimpleFSArchiveJobPhase_Done {
+		copy.DoneTime = keybase1.ToTime(time.Now())
+	}
 	m.state.Jobs[jobID] = copy
+	m.signal(m.diskReservationWorkerSignal)
 }
 func (m *archiveManager) changeJobPhase(ctx context.Context,
 	jobID string, newPhase keybase1.SimpleFSArchiveJobPhase) {
@@ -230,78 +714,325 @@ func (m *archiveManager) changeJobPhase(ctx context.Context,
 	m.changeJobPhaseLocked(ctx, jobID, newPhase)
 }
 
+// finishJobPhase moves jobID on to newPhase after a worker is done with
+// the phase before it, which starts the retry count over.
+func (m *archiveManager) finishJobPhase(ctx context.Context,
+	jobID string, newPhase keybase1.SimpleFSArchiveJobPhase) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.changeJobPhaseLocked(ctx, jobID, newPhase)
+	job, ok := m.state.Jobs[jobID]
+	if !ok {
+		return
+	}
+	job.RetryCount = 0
+	m.state.Jobs[jobID] = job
+}
+
 func (m *archiveManager) startWorkerTask(ctx context.Context,
 	eligiblePhase keybase1.SimpleFSArchiveJobPhase,
 	newPhase keybase1.SimpleFSArchiveJobPhase) (jobID string, jobCtx context.Context, ok bool) {
-	jobCtx, cancel := context.WithCancel(ctx)
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	for jobID := range m.state.Jobs {
-		if m.state.Jobs[jobID].Phase == eligiblePhase {
-			m.changeJobPhaseLocked(ctx, jobID, newPhase)
-			m.jobCtxCancellers[jobID] = cancel
-			return jobID, jobCtx, true
+	if m.drainingLocked() {
+		return "", nil, false
+	}
+	for id, job := range m.state.Jobs {
+		if job.Phase != eligiblePhase || job.Paused {
+			continue
+		}
+		if newPhase == keybase1.SimpleFSArchiveJobPhase_Copying &&
+			!m.fitsStagingCapLocked(id) {
+			continue
+		}
+		if len(jobID) == 0 ||
+			archiveJobRunsBefore(job.Desc, m.state.Jobs[jobID].Desc) {
+			jobID = id
 		}
 	}
-	return "", nil, false
+	if len(jobID) == 0 {
+		return "", nil, false
+	}
+	jobCtx, cancel := context.WithCancel(ctx)
+	task := newArchiveJobTask(cancel)
+	m.changeJobPhaseLocked(ctx, jobID, newPhase)
+	m.jobTasks[jobID] = task
+	return jobID, withArchiveJobTask(jobCtx, task), true
+}
+
+// finishWorkerTask lets go of jobID once the worker that picked it up with
+// startWorkerTask is done updating its state.
+func (m *archiveManager) finishWorkerTask(jobID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	task, ok := m.jobTasks[jobID]
+	if !ok {
+		return
+	}
+	delete(m.jobTasks, jobID)
+	task.cancel()
+	close(task.done)
+	// Nothing's making progress on the job until a worker picks it up again.
+	if job, ok := m.state.Jobs[jobID]; ok {
+		job.EstimatedCompletion = 0
+		m.state.Jobs[jobID] = job
+	}
 }
 
-const archiveErrorRetryDuration = time.Minute
+// archiveJobRunsBefore says whether a worker should pick up job a before
+// job b: higher priority first, then the oldest.
+func archiveJobRunsBefore(a, b keybase1.SimpleFSArchiveJobDesc) bool {
+	if a.Priority != b.Priority {
+		return a.Priority > b.Priority
+	}
+	if a.StartTime != b.StartTime {
+		return a.StartTime < b.StartTime
+	}
+	return a.JobID < b.JobID
+}
 
 func (m *archiveManager) setJobError(
 	ctx context.Context, jobID string, err error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	nextRetry := time.Now().Add(archiveErrorRetryDuration)
-	m.simpleFS.log.CErrorf(ctx, "job %s nextRetry: %s", jobID, nextRetry)
-	m.errors[jobID] = errorState{
-		err:       err,
-		nextRetry: nextRetry,
+	if m.state.Jobs[jobID].Phase == keybase1.SimpleFSArchiveJobPhase_Cancelling {
+		m.simpleFS.log.CDebugf(ctx, "job %s is being canceled; not retrying after: %v", jobID, err)
+		return
+	}
+	if m.state.Jobs[jobID].Paused {
+		// Most likely the error is from pausing the job, which cancels it.
+		// Either way, it'll be tried again when it's resumed.
+		m.simpleFS.log.CDebugf(ctx, "job %s is paused; not retrying after: %v", jobID, err)
+		m.resetInterruptedPhaseLocked(ctx, jobID)
+		return
+	}
+	if m.drainingLocked() {
+		// Same as for pausing, but it's picked up again by itself.
+		m.simpleFS.log.CDebugf(ctx, "jobs are drained; not retrying %s after: %v", jobID, err)
+		m.resetInterruptedPhaseLocked(ctx, jobID)
+		return
+	}
+	job, ok := m.state.Jobs[jobID]
+	if !ok {
+		return
+	}
+	errState := keybase1.SimpleFSArchiveJobErrorState{
+		Error:      err.Error(),
+		ErrorClass: archiveJobErrorClass(err),
+		RetryCount: job.RetryCount,
+	}
+	switch {
+	case errState.ErrorClass != keybase1.SimpleFSArchiveJobErrorClass_Retryable:
+		m.simpleFS.log.CErrorf(ctx,
+			"job %s failed with an error retrying won't fix", jobID)
+	case job.RetryCount >= archiveJobMaxRetries(job.Desc):
+		m.simpleFS.log.CErrorf(ctx, "job %s failed after %d retries", jobID, job.RetryCount)
+	default:
+		nextRetry := time.Now().Add(
+			archiveErrorRetryDelay(job.RetryCount, rand.Float64()))
+		m.simpleFS.log.CErrorf(ctx, "job %s nextRetry: %s", jobID, nextRetry)
+		errState.NextRetry = keybase1.ToTime(nextRetry)
+		job.ErrorState = &errState
+		m.state.Jobs[jobID] = job
+		return
 	}
+	job.ErrorState = &errState
+	m.state.Jobs[jobID] = job
+	m.failJobLocked(ctx, jobID)
+	m.notifyJobLocked(ctx, jobID, err)
 }
 
-func (m *archiveManager) doIndexing(ctx context.Context, jobID string) (err error) {
-	m.simpleFS.log.CDebugf(ctx, "+ doIndexing %s", jobID)
-	defer func() { m.simpleFS.log.CDebugf(ctx, "- doIndexing %s err: %v", jobID, err) }()
+// archiveJobErrorClass says whether a job that failed with err is worth
+// retrying as is.
+func archiveJobErrorClass(err error) keybase1.SimpleFSArchiveJobErrorClass {
+	var (
+		formatErr   ArchiveEntryFormatError
+		volumeErr   ArchiveVolumeTooSmallError
+		conflictErr ArchiveRestoreConflictError
+		mismatchErr ArchiveRestoreMismatchError
+	)
+	switch {
+	case errors.As(err, &formatErr), errors.As(err, &volumeErr),
+		errors.As(err, &conflictErr), errors.As(err, &mismatchErr):
+		return keybase1.SimpleFSArchiveJobErrorClass_Fatal
+	default:
+		return keybase1.SimpleFSArchiveJobErrorClass_Retryable
+	}
+}
 
-	jobDesc := func() keybase1.SimpleFSArchiveJobDesc {
-		m.mu.Lock()
-		defer m.mu.Unlock()
-		return m.state.Jobs[jobID].Desc
-	}()
+// archiveGlobMatches says whether any of the patterns matches p, or the
+// base name of p.
+func archiveGlobMatches(patterns []string, p string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, p); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, path.Base(p)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// archiveEntryIncluded applies the job's include and exclude globs to the
+// path p within the job. A pattern that matches a directory applies to
+// everything in it.
+func archiveEntryIncluded(desc keybase1.SimpleFSArchiveJobDesc, p string) bool {
+	included := len(desc.IncludeGlobs) == 0
+	for dir := p; dir != "." && dir != "/" && len(dir) > 0; dir = path.Dir(dir) {
+		if archiveGlobMatches(desc.ExcludeGlobs, dir) {
+			return false
+		}
+		if !included && archiveGlobMatches(desc.IncludeGlobs, dir) {
+			included = true
+		}
+	}
+	return included
+}
+
+func checkArchiveGlobs(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("bad glob pattern %q: %v", pattern, err)
+		}
+	}
+	return nil
+}
+
+// archiveJobSources returns the paths the job archives. A single-path job
+// has one source, with an empty root.
+func archiveJobSources(desc keybase1.SimpleFSArchiveJobDesc) []keybase1.SimpleFSArchiveSource {
+	if len(desc.Sources) > 0 {
+		return desc.Sources
+	}
+	return []keybase1.SimpleFSArchiveSource{{Path: desc.KbfsPathWithRevision}}
+}
+
+// archiveEntrySource splits a path within the job into the root of the
+// source it's from, and its path within that source.
+func archiveEntrySource(desc keybase1.SimpleFSArchiveJobDesc,
+	entryPathWithinJob string) (root string, entryPathWithinSource string) {
+	if len(desc.Sources) == 0 {
+		return "", entryPathWithinJob
+	}
+	if i := strings.IndexByte(entryPathWithinJob, '/'); i >= 0 {
+		return entryPathWithinJob[:i], entryPathWithinJob[i+1:]
+	}
+	return entryPathWithinJob, ""
+}
+
+// archiveSourceRoots picks a top-level directory name for each source of a
+// multi-path job, based on the names of the archived paths. Repeated names
+// get a numeric suffix.
+func archiveSourceRoots(names []string) []string {
+	used := make(map[string]bool, len(names))
+	roots := make([]string, 0, len(names))
+	for _, name := range names {
+		root := name
+		for i := 2; used[root]; i++ {
+			root = fmt.Sprintf("%s-%d", name, i)
+		}
+		used[root] = true
+		roots = append(roots, root)
+	}
+	return roots
+}
+
+// listArchiveSource lists everything under one of a job's sources.
+func (m *archiveManager) listArchiveSource(ctx context.Context,
+	source keybase1.SimpleFSArchiveSource) ([]keybase1.Dirent, error) {
 	opid, err := m.simpleFS.SimpleFSMakeOpid(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer m.simpleFS.SimpleFSClose(ctx, opid)
 	filter := keybase1.ListFilter_NO_FILTER
 	err = m.simpleFS.SimpleFSListRecursive(ctx, keybase1.SimpleFSListRecursiveArg{
 		OpID:   opid,
-		Path:   keybase1.NewPathWithKbfsArchived(jobDesc.KbfsPathWithRevision),
+		Path:   keybase1.NewPathWithKbfsArchived(source.Path),
 		Filter: filter,
 	})
 	err = m.simpleFS.SimpleFSWait(ctx, opid)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	listResult, err := m.simpleFS.SimpleFSReadList(ctx, opid)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return listResult.Entries, nil
+}
 
+func (m *archiveManager) doIndexing(ctx context.Context, jobID string) (err error) {
+	m.simpleFS.log.CDebugf(ctx, "+ doIndexing %s", jobID)
+	defer func() { m.simpleFS.log.CDebugf(ctx, "- doIndexing %s err: %v", jobID, err) }()
+
+	jobDesc := func() keybase1.SimpleFSArchiveJobDesc {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		return m.state.Jobs[jobID].Desc
+	}()
 	var bytesTotal int64
+	var skippedLargeFiles []keybase1.SimpleFSArchiveLargeFile
+	var zipFormat archiveZipFormatChecker
 	manifest := make(map[string]keybase1.SimpleFSArchiveFile)
-	for _, e := range listResult.Entries {
-		manifest[e.Name] = keybase1.SimpleFSArchiveFile{
-			State:      keybase1.SimpleFSFileArchiveState_ToDo,
-			DirentType: e.DirentType,
+	for _, source := range archiveJobSources(jobDesc) {
+		entries, err := m.listArchiveSource(ctx, source)
+		if err != nil {
+			return err
 		}
-		if e.DirentType == keybase1.DirentType_FILE ||
-			e.DirentType == keybase1.DirentType_EXEC {
-			bytesTotal += int64(e.Size)
+		if len(source.Root) > 0 {
+			// Give each source's root a directory entry of its own, like
+			// the directories within it get.
+			manifest[source.Root] = keybase1.SimpleFSArchiveFile{
+				State:      keybase1.SimpleFSFileArchiveState_ToDo,
+				DirentType: keybase1.DirentType_DIR,
+			}
+		}
+		for _, e := range entries {
+			if !archiveEntryIncluded(jobDesc, e.Name) {
+				continue
+			}
+			entryPathWithinJob := path.Join(source.Root, e.Name)
+			isFile := e.DirentType == keybase1.DirentType_FILE ||
+				e.DirentType == keybase1.DirentType_EXEC
+			if isFile && jobDesc.MaxFileSize > 0 && int64(e.Size) > jobDesc.MaxFileSize {
+				// Keep it in the manifest so an incremental job based on this
+				// one doesn't think it was deleted.
+				manifest[entryPathWithinJob] = keybase1.SimpleFSArchiveFile{
+					State:      keybase1.SimpleFSFileArchiveState_Skipped,
+					DirentType: e.DirentType,
+				}
+				skippedLargeFiles = append(skippedLargeFiles,
+					keybase1.SimpleFSArchiveLargeFile{Path: entryPathWithinJob, Size: int64(e.Size)})
+				continue
+			}
+			err = zipFormat.add(path.Join(jobDesc.TargetName, entryPathWithinJob),
+				isFile || e.DirentType == keybase1.DirentType_SYM, int64(e.Size))
+			if err != nil {
+				return err
+			}
+			manifest[entryPathWithinJob] = keybase1.SimpleFSArchiveFile{
+				State:      keybase1.SimpleFSFileArchiveState_ToDo,
+				DirentType: e.DirentType,
+			}
+			if isFile {
+				bytesTotal += int64(e.Size)
+			}
 		}
 	}
+	sort.Slice(skippedLargeFiles, func(i, j int) bool {
+		return skippedLargeFiles[i].Path < skippedLargeFiles[j].Path
+	})
+	if zipFormat.needsZip64() {
+		m.simpleFS.log.CDebugf(ctx, "Job %s needs zip64: %d entries, "+
+			"largest %d bytes, %d bytes total", jobID, zipFormat.entries,
+			zipFormat.maxBytes, zipFormat.totalBytes)
+	}
+
+	// Check for space now rather than running out halfway through copying.
+	spaceErr := m.checkDiskSpace(ctx, jobDesc, bytesTotal)
 
 	func() {
 		m.mu.Lock()
@@ -312,11 +1043,29 @@ func (m *archiveManager) doIndexing(ctx context.Context, jobID string) (err erro
 			m.simpleFS.log.CWarningf(ctx, "job %s not found. it might have been canceled", jobID)
 			return
 		}
-		jobCopy.Manifest = manifest
 		jobCopy.BytesTotal = bytesTotal
+		if spaceErr != nil {
+			jobCopy.Error = spaceErr.Error()
+			m.state.Jobs[jobID] = jobCopy
+			return
+		}
+		jobCopy.Error = ""
+		jobCopy.Manifest = manifest
+		jobCopy.SkippedLargeFiles = skippedLargeFiles
+		jobCopy.Deleted = nil
+		for p, baseEntry := range jobCopy.BaseManifest {
+			if len(baseEntry.MaterializedFrom) > 0 {
+				// Indexing doesn't look under symlinks.
+				continue
+			}
+			if _, ok := manifest[p]; !ok && archiveEntryIncluded(jobDesc, p) {
+				jobCopy.Deleted = append(jobCopy.Deleted, p)
+			}
+		}
+		sort.Strings(jobCopy.Deleted)
 		m.state.Jobs[jobID] = jobCopy
 	}()
-	return nil
+	return spaceErr
 }
 
 func (m *archiveManager) indexingWorker(ctx context.Context) {
@@ -340,20 +1089,22 @@ func (m *archiveManager) indexingWorker(ctx context.Context) {
 
 		m.simpleFS.log.CDebugf(ctx, "indexing: %s", jobID)
 
-		err := m.doIndexing(jobCtx, jobID)
+		var err error
+		if m.isRestoreJob(jobID) {
+			err = m.doRestoreIndexing(jobCtx, jobID)
+		} else {
+			err = m.doIndexing(jobCtx, jobID)
+		}
 		if err == nil {
 			m.simpleFS.log.CDebugf(jobCtx, "indexing done on job %s", jobID)
-			m.changeJobPhase(jobCtx, jobID, keybase1.SimpleFSArchiveJobPhase_Indexed)
+			m.finishJobPhase(jobCtx, jobID, keybase1.SimpleFSArchiveJobPhase_Indexed)
 			m.signal(m.copyingWorkerSignal) // Done indexing! Notify the copying worker.
 		} else {
 			m.simpleFS.log.CErrorf(jobCtx, "indexing error on job %s: %v", jobID, err)
 			m.setJobError(ctx, jobID, err)
 		}
-
-		err = m.flushStateFile(ctx)
-		if err != nil {
-			m.simpleFS.log.CWarningf(ctx, "m.flushStateFileLocked error: %v", err)
-		}
+		m.finishWorkerTask(jobID)
+		m.markStateDirty()
 	}
 }
 
@@ -385,16 +1136,21 @@ func newSHA256TeeReader(inner io.Reader) (r *sha256TeeReader) {
 
 type bytesUpdaterFunc = func(delta int64)
 
+const archiveCopyChunkSize = 64 * 1024
+
+// ctxAwareCopy copies from `from` to `to` in chunks, checking ctx between
+// chunks. If limiter is non-nil, it's used to throttle the copy; its burst
+// must be at least archiveCopyChunkSize.
 func ctxAwareCopy(
 	ctx context.Context, to io.Writer, from io.Reader,
-	bytesUpdater bytesUpdaterFunc) error {
+	limiter *rate.Limiter, bytesUpdater bytesUpdaterFunc) error {
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
 		}
-		n, err := io.CopyN(to, from, 64*1024)
+		n, err := io.CopyN(to, from, archiveCopyChunkSize)
 		switch err {
 		case nil:
 			bytesUpdater(n)
@@ -404,13 +1160,19 @@ func ctxAwareCopy(
 		default:
 			return err
 		}
+		if limiter != nil {
+			if err = limiter.WaitN(ctx, int(n)); err != nil {
+				return err
+			}
+		}
 	}
 }
 
 func (m *archiveManager) copyFileFromBeginning(ctx context.Context,
 	srcDirFS billy.Filesystem, entryPathWithinJob string,
-	localPath string, mode os.FileMode,
-	bytesCopiedUpdater bytesUpdaterFunc) (sha256Sum []byte, err error) {
+	localPath string, mode os.FileMode, limiter *rate.Limiter,
+	bytesCopiedUpdater bytesUpdaterFunc,
+	checkpoint archiveCheckpointFunc) (sha256Sum []byte, err error) {
 	m.simpleFS.log.CDebugf(ctx, "+ copyFileFromBeginning %s", entryPathWithinJob)
 	defer func() { m.simpleFS.log.CDebugf(ctx, "- copyFileFromBeginning %s err: %v", entryPathWithinJob, err) }()
 
@@ -420,17 +1182,31 @@ func (m *archiveManager) copyFileFromBeginning(ctx context.Context,
 	}
 	defer src.Close()
 
+	if checkpoint != nil {
+		// What's there is about to be overwritten.
+		checkpoint(nil)
+	}
 	dst, err := os.OpenFile(localPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
 	if err != nil {
-		return nil, fmt.Errorf("os.OpenFile(%s) error: %v", localPath, err)
+		return nil, fmt.Errorf("os.OpenFile(%s) error: %w", localPath, err)
 	}
 	defer dst.Close()
+	defer trackArchiveFile(ctx, dst)()
 
 	teeReader := newSHA256TeeReader(src)
 
-	err = ctxAwareCopy(ctx, dst, teeReader, bytesCopiedUpdater)
+	sparseDst := newArchiveSparseWriter(dst)
+	checkpointer := newArchiveCopyCheckpointer(dst, sparseDst, teeReader.h, checkpoint)
+	err = ctxAwareCopy(ctx, sparseDst, teeReader, limiter,
+		checkpointer.wrap(bytesCopiedUpdater, func(err error) {
+			m.simpleFS.log.CWarningf(ctx, "[%s] checkpoint error: %v", entryPathWithinJob, err)
+		}))
+	if err != nil {
+		return nil, fmt.Errorf("[%s] io.CopyN error: %w", entryPathWithinJob, err)
+	}
+	err = sparseDst.finish()
 	if err != nil {
-		return nil, fmt.Errorf("[%s] io.CopyN error: %v", entryPathWithinJob, err)
+		return nil, fmt.Errorf("[%s] finishing sparse copy error: %w", entryPathWithinJob, err)
 	}
 
 	// We didn't continue from a previously interrupted copy, so don't
@@ -438,113 +1214,443 @@ func (m *archiveManager) copyFileFromBeginning(ctx context.Context,
 	return teeReader.getSum(), nil
 }
 
+// copyFilePickupPrevious picks up the interrupted copy of a file from its
+// checkpoint cp, throwing away whatever of the dstSize bytes already there
+// came after it. If it can't, it copies the file from the beginning.
 func (m *archiveManager) copyFilePickupPrevious(ctx context.Context,
 	srcDirFS billy.Filesystem, entryPathWithinJob string,
-	localPath string, srcSeekOffset int64, mode os.FileMode,
-	bytesCopiedUpdater bytesUpdaterFunc) (sha256Sum []byte, err error) {
-	m.simpleFS.log.CDebugf(ctx, "+ copyFilePickupPrevious %s", entryPathWithinJob)
+	localPath string, dstSize int64, cp keybase1.SimpleFSArchiveCopyCheckpoint,
+	mode os.FileMode, limiter *rate.Limiter, bytesCopiedUpdater bytesUpdaterFunc,
+	checkpoint archiveCheckpointFunc) (sha256Sum []byte, err error) {
+	m.simpleFS.log.CDebugf(ctx, "+ copyFilePickupPrevious %s from %d", entryPathWithinJob, cp.Offset)
 	defer func() { m.simpleFS.log.CDebugf(ctx, "- copyFilePickupPrevious %s err: %v", entryPathWithinJob, err) }()
 
+	h := sha256.New()
+	err = restoreSHA256Checkpoint(h, cp)
+	if err == nil && cp.Offset > dstSize {
+		err = fmt.Errorf("checkpoint at %d is past the end of the %d bytes copied",
+			cp.Offset, dstSize)
+	}
+	if err != nil {
+		m.simpleFS.log.CInfof(ctx,
+			"can't pick up the copy of %s from its checkpoint: %v. Will copy from the beginning",
+			entryPathWithinJob, err)
+		bytesCopiedUpdater(-dstSize)
+		return m.copyFileFromBeginning(ctx, srcDirFS, entryPathWithinJob, localPath, mode, limiter,
+			bytesCopiedUpdater, checkpoint)
+	}
+
 	src, err := srcDirFS.Open(entryPathWithinJob)
 	if err != nil {
 		return nil, fmt.Errorf("srcDirFS.Open(%s) error: %v", entryPathWithinJob, err)
 	}
 	defer src.Close()
 
-	_, err = src.Seek(srcSeekOffset, io.SeekStart)
+	_, err = src.Seek(cp.Offset, io.SeekStart)
 	if err != nil {
 		return nil, fmt.Errorf("[%s] src.Seek error: %v", entryPathWithinJob, err)
 	}
 
-	// Copy the file.
-	if err = func() error {
-		dst, err := os.OpenFile(localPath, os.O_APPEND|os.O_WRONLY|os.O_CREATE, mode)
-		if err != nil {
-			return fmt.Errorf("os.OpenFile(%s) error: %v", localPath, err)
+	dst, err := os.OpenFile(localPath, os.O_WRONLY, mode)
+	if err != nil {
+		return nil, fmt.Errorf("os.OpenFile(%s) error: %w", localPath, err)
+	}
+	defer dst.Close()
+	defer trackArchiveFile(ctx, dst)()
+
+	// What was written after the checkpoint might not have made it to
+	// disk intact.
+	err = dst.Truncate(cp.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("[%s] dst.Truncate error: %v", entryPathWithinJob, err)
+	}
+	bytesCopiedUpdater(cp.Offset - dstSize)
+
+	sparseDst := newArchiveSparseWriterAt(dst, cp.Offset)
+	checkpointer := newArchiveCopyCheckpointer(dst, sparseDst, h, checkpoint)
+	err = ctxAwareCopy(ctx, sparseDst, io.TeeReader(src, h), limiter,
+		checkpointer.wrap(bytesCopiedUpdater, func(err error) {
+			m.simpleFS.log.CWarningf(ctx, "[%s] checkpoint error: %v", entryPathWithinJob, err)
+		}))
+	if err != nil {
+		return nil, fmt.Errorf("[%s] io.CopyN error: %w", entryPathWithinJob, err)
+	}
+	err = sparseDst.finish()
+	if err != nil {
+		return nil, fmt.Errorf("[%s] finishing sparse copy error: %w", entryPathWithinJob, err)
+	}
+
+	// The hash picked up where the checkpoint left off, so it covers the
+	// whole file without reading any of it again.
+	return h.Sum(nil), nil
+}
+
+// copyFile copies a file into localPath, picking up from checkpoint cp if
+// an interrupted copy left dstSize bytes there. A copy interrupted before
+// its first checkpoint, or staged before copies had them, starts over,
+// which reads less than checking what's there against the source would.
+// checkpoint, if non-nil, records the copy's checkpoints as it goes.
+func (m *archiveManager) copyFile(ctx context.Context,
+	srcDirFS billy.Filesystem, entryPathWithinJob string,
+	localPath string, dstSize int64, cp *keybase1.SimpleFSArchiveCopyCheckpoint,
+	mode os.FileMode, limiter *rate.Limiter, bytesCopiedUpdater bytesUpdaterFunc,
+	checkpoint archiveCheckpointFunc) (sha256Sum []byte, err error) {
+	if dstSize == 0 || cp == nil {
+		bytesCopiedUpdater(-dstSize)
+		return m.copyFileFromBeginning(ctx, srcDirFS, entryPathWithinJob, localPath, mode, limiter,
+			bytesCopiedUpdater, checkpoint)
+	}
+	return m.copyFilePickupPrevious(ctx, srcDirFS, entryPathWithinJob, localPath, dstSize, *cp,
+		mode, limiter, bytesCopiedUpdater, checkpoint)
+}
+
+// unchangedSinceBase hashes the source file and says whether it matches
+// baseSHA256SumHex from the base job's manifest. The bytes read count
+// towards the copy progress only if the file is unchanged, since otherwise
+// it's about to be copied.
+func (m *archiveManager) unchangedSinceBase(ctx context.Context,
+	srcDirFS billy.Filesystem, entryPathWithinJob string, baseSHA256SumHex string,
+	limiter *rate.Limiter, bytesCopiedUpdater bytesUpdaterFunc) (unchanged bool, err error) {
+	src, err := srcDirFS.Open(entryPathWithinJob)
+	if err != nil {
+		return false, fmt.Errorf("srcDirFS.Open(%s) error: %v", entryPathWithinJob, err)
+	}
+	defer src.Close()
+
+	var size int64
+	h := sha256.New()
+	err = ctxAwareCopy(ctx, h, src, limiter, func(delta int64) {
+		size += delta
+		bytesCopiedUpdater(delta)
+	})
+	if err != nil {
+		return false, fmt.Errorf("[%s] io.CopyN error: %v", entryPathWithinJob, err)
+	}
+	if hex.EncodeToString(h.Sum(nil)) == baseSHA256SumHex {
+		return true, nil
+	}
+	bytesCopiedUpdater(-size)
+	return false, nil
+}
+
+// writeDeletedList records the paths that are gone since the base job, in
+// a text file next to the target directory so it ends up in the zip.
+func writeDeletedList(desc keybase1.SimpleFSArchiveJobDesc, deleted []string) error {
+	workspaceDir := getWorkspaceDir(desc)
+	err := os.MkdirAll(workspaceDir, 0755)
+	if err != nil {
+		return fmt.Errorf("os.MkdirAll(%s) error: %v", workspaceDir, err)
+	}
+	if len(deleted) == 0 {
+		return nil
+	}
+	listPath := filepath.Join(workspaceDir, fmt.Sprintf(
+		"%s-deleted-since-r%d.txt", desc.TargetName, desc.BaseRevision))
+	content := strings.Join(deleted, "\n") + "\n"
+	err = os.WriteFile(listPath, []byte(content), 0644)
+	if err != nil {
+		return fmt.Errorf("os.WriteFile(%s) error: %v", listPath, err)
+	}
+	return nil
+}
+
+// writeSkippedLargeFilesList records the files left out for being over
+// desc.MaxFileSize, next to the target directory so it ends up in the zip.
+func writeSkippedLargeFilesList(desc keybase1.SimpleFSArchiveJobDesc,
+	skipped []keybase1.SimpleFSArchiveLargeFile) error {
+	if len(skipped) == 0 {
+		return nil
+	}
+	workspaceDir := getWorkspaceDir(desc)
+	err := os.MkdirAll(workspaceDir, 0755)
+	if err != nil {
+		return fmt.Errorf("os.MkdirAll(%s) error: %v", workspaceDir, err)
+	}
+	var b strings.Builder
+	for _, f := range skipped {
+		fmt.Fprintf(&b, "%d\t%s\n", f.Size, f.Path)
+	}
+	listPath := filepath.Join(workspaceDir, fmt.Sprintf(
+		"%s-skipped-over-%d-bytes.txt", desc.TargetName, desc.MaxFileSize))
+	err = os.WriteFile(listPath, []byte(b.String()), 0644)
+	if err != nil {
+		return fmt.Errorf("os.WriteFile(%s) error: %v", listPath, err)
+	}
+	return nil
+}
+
+const (
+	archiveManifestSHA256Name = "manifest.sha256"
+	archiveManifestJSONName   = "manifest.json"
+)
+
+type archiveManifestJSONEntry struct {
+	Path      string `json:"path"`
+	Type      string `json:"type"`
+	Size      int64  `json:"size"`
+	Mtime     string `json:"mtime"`
+	SHA256Hex string `json:"sha256,omitempty"`
+	// Mode is the entry's Unix permission bits in octal, like "0755".
+	Mode string `json:"mode,omitempty"`
+	// MimeType is set for files whose type could be told, for viewers that
+	// want to show icons or pick an app to open them with.
+	MimeType string `json:"mimeType,omitempty"`
+	// Volume is set when the zip is split into volumes.
+	Volume int `json:"volume,omitempty"`
+}
+
+// writeChecksumManifest writes manifest.sha256 next to the target directory,
+// in the format `sha256sum -c` understands when run from the directory the
+// zip is extracted into. If desc.WriteManifestJSON is set, manifest.json
+// lists sizes, mtimes and MIME types as well. Only files copied into this
+// zip are listed, so unchanged files of an incremental job are left out.
+func writeChecksumManifest(desc keybase1.SimpleFSArchiveJobDesc,
+	manifest map[string]keybase1.SimpleFSArchiveFile) error {
+	workspaceDir := getWorkspaceDir(desc)
+	err := os.MkdirAll(workspaceDir, 0755)
+	if err != nil {
+		return fmt.Errorf("os.MkdirAll(%s) error: %v", workspaceDir, err)
+	}
+
+	entryPaths := make([]string, 0, len(manifest))
+	for entryPathWithinJob, entry := range manifest {
+		if entry.State != keybase1.SimpleFSFileArchiveState_Complete ||
+			entry.DirentType == keybase1.DirentType_DIR {
+			continue
 		}
-		defer dst.Close()
+		entryPaths = append(entryPaths, entryPathWithinJob)
+	}
+	sort.Strings(entryPaths)
 
-		err = ctxAwareCopy(ctx, dst, src, bytesCopiedUpdater)
+	var sums strings.Builder
+	jsonEntries := make([]archiveManifestJSONEntry, 0, len(entryPaths))
+	for _, entryPathWithinJob := range entryPaths {
+		entry := manifest[entryPathWithinJob]
+		zipPath := path.Join(desc.TargetName, entryPathWithinJob)
+		if len(entry.Sha256SumHex) > 0 {
+			fmt.Fprintf(&sums, "%s  %s\n", entry.Sha256SumHex, zipPath)
+		}
+		if !desc.WriteManifestJSON {
+			continue
+		}
+		localPath := filepath.Join(workspaceDir, desc.TargetName, entryPathWithinJob)
+		fi, err := os.Lstat(localPath)
 		if err != nil {
-			return fmt.Errorf("[%s] io.CopyN error: %v", entryPathWithinJob, err)
+			return fmt.Errorf("os.Lstat(%s) error: %v", localPath, err)
 		}
+		mtime := fi.ModTime()
+		if entry.Mtime != 0 {
+			mtime = entry.Mtime.Time()
+		}
+		jsonEntry := archiveManifestJSONEntry{
+			Path:      zipPath,
+			Type:      strings.ToLower(entry.DirentType.String()),
+			Size:      fi.Size(),
+			Mtime:     mtime.UTC().Format(time.RFC3339),
+			SHA256Hex: entry.Sha256SumHex,
+		}
+		if entry.Mode != 0 {
+			jsonEntry.Mode = fmt.Sprintf("%04o", entry.Mode)
+		}
+		if fi.Mode().IsRegular() {
+			jsonEntry.MimeType = detectMimeType(localPath, fi.Size(),
+				func() (io.ReadCloser, error) { return os.Open(localPath) })
+		}
+		jsonEntries = append(jsonEntries, jsonEntry)
+	}
 
+	sumsPath := filepath.Join(workspaceDir, archiveManifestSHA256Name)
+	err = os.WriteFile(sumsPath, []byte(sums.String()), 0644)
+	if err != nil {
+		return fmt.Errorf("os.WriteFile(%s) error: %v", sumsPath, err)
+	}
+	if !desc.WriteManifestJSON {
 		return nil
-	}(); err != nil {
-		return nil, err
 	}
+	content, err := json.MarshalIndent(jsonEntries, "", "  ")
+	if err != nil {
+		return err
+	}
+	jsonPath := filepath.Join(workspaceDir, archiveManifestJSONName)
+	err = os.WriteFile(jsonPath, append(content, '\n'), 0644)
+	if err != nil {
+		return fmt.Errorf("os.WriteFile(%s) error: %v", jsonPath, err)
+	}
+	return nil
+}
 
-	var size int64
-	// Calculate sha256 and check the sha256 of the copied file since we
-	// continued from a previously interrupted copy.
-	srcSHA256Sum, dstSHA256Sum, err := func() (srcSHA256Sum, dstSHA256Sum []byte, err error) {
-		_, err = src.Seek(0, io.SeekStart)
+func getWorkspaceDir(jobDesc keybase1.SimpleFSArchiveJobDesc) string {
+	return filepath.Join(jobDesc.StagingPath, "workspace")
+}
+
+// copyEntry copies the file, directory or symlink at entryPathWithinSource
+// in srcDirFS to localPath, and returns entry updated with how it went. If
+// base is the same file in the base job's manifest and the file hasn't
+// changed since, it's left out as unchanged. Symlinks and special files are
+// handled according to desc; the entries for what's under a followed
+// directory symlink go in materialized. A file's copy picks up from
+// entry.CopyCheckpoint if it was interrupted, and checkpoint records new
+// checkpoints. On error, entry is returned as is.
+func (m *archiveManager) copyEntry(ctx context.Context,
+	desc keybase1.SimpleFSArchiveJobDesc,
+	srcDirFS billy.Filesystem, entryPathWithinJob, entryPathWithinSource string,
+	localPath string, entry keybase1.SimpleFSArchiveFile,
+	base keybase1.SimpleFSArchiveFile, limiter *rate.Limiter,
+	updateBytesCopied bytesUpdaterFunc, checkpoint archiveCheckpointFunc,
+	materialized map[string]keybase1.SimpleFSArchiveFile) (keybase1.SimpleFSArchiveFile, error) {
+	srcFI, err := srcDirFS.Lstat(entryPathWithinSource)
+	if err != nil {
+		return entry, fmt.Errorf("srcDirFS.LStat(%s) error: %v", entryPathWithinSource, err)
+	}
+	switch {
+	case srcFI.IsDir():
+		err = os.MkdirAll(localPath, 0755)
+		if err != nil {
+			return entry, fmt.Errorf("os.MkdirAll(%s) error: %w", localPath, err)
+		}
+		err = os.Chtimes(localPath, time.Time{}, srcFI.ModTime())
+		if err != nil {
+			return entry, fmt.Errorf("os.Chtimes(%s) error: %v", localPath, err)
+		}
+		entry.Mode = archiveKBFSMode(keybase1.DirentType_DIR)
+		entry.State = keybase1.SimpleFSFileArchiveState_Complete
+	case srcFI.Mode()&os.ModeSymlink != 0: // symlink
+		link, err := srcDirFS.Readlink(entryPathWithinSource)
 		if err != nil {
-			return nil, nil, fmt.Errorf("[%s] src.Seek error: %v", entryPathWithinJob, err)
+			return entry, fmt.Errorf("srcDirFS(%s) error: %v", entryPathWithinSource, err)
+		}
+		entry.SymlinkTarget = link
+		entry.Symlink = desc.Symlinks
+		if desc.Symlinks == keybase1.SimpleFSArchiveSymlinks_SKIP {
+			entry.State = keybase1.SimpleFSFileArchiveState_Skipped
+			return entry, nil
 		}
-		srcSHA256SumHasher := sha256.New()
-		size, err = io.Copy(srcSHA256SumHasher, src)
+		// Call Stat, which follows symlinks, to make sure the link doesn't
+		// escape outside the srcDirFS.
+		_, err = srcDirFS.Stat(entryPathWithinSource)
 		if err != nil {
-			return nil, nil, fmt.Errorf("[%s] io.Copy error: %v", entryPathWithinJob, err)
+			m.simpleFS.log.CWarningf(ctx, "skipping %s due to srcDirFS.Stat error: %v", entryPathWithinJob, err)
+			entry.Symlink = keybase1.SimpleFSArchiveSymlinks_SKIP
+			entry.State = keybase1.SimpleFSFileArchiveState_Skipped
+			return entry, nil
 		}
-		srcSHA256Sum = srcSHA256SumHasher.Sum(nil)
 
-		dst, err := os.Open(localPath)
+		if desc.Symlinks == keybase1.SimpleFSArchiveSymlinks_FOLLOW {
+			followed, err := m.materializeEntry(ctx, srcDirFS, entryPathWithinJob,
+				entryPathWithinJob, entryPathWithinSource, localPath, 0, limiter,
+				materialized)
+			if err != nil {
+				return entry, err
+			}
+			// It's what it points to in the zip, so the checks and
+			// restores treat it that way too.
+			entry.DirentType = followed.DirentType
+			entry.State = followed.State
+			entry.Sha256SumHex = followed.Sha256SumHex
+			entry.Mtime = followed.Mtime
+			entry.Mode = followed.Mode
+			return entry, nil
+		}
+
+		err = os.MkdirAll(filepath.Dir(localPath), 0755)
 		if err != nil {
-			return nil, nil, fmt.Errorf("os.Open(%s) error: %v", localPath, err)
+			return entry, fmt.Errorf("os.MkdirAll(filepath.Dir(%s)) error: %w", localPath, err)
 		}
-		defer dst.Close()
-		dstSHA256SumHasher := sha256.New()
-		_, err = io.Copy(dstSHA256SumHasher, dst)
+		m.simpleFS.log.CInfof(ctx, "calling os.Symlink(%s, %s) ", link, localPath)
+		err = os.Symlink(link, localPath)
 		if err != nil {
-			return nil, nil, fmt.Errorf("[%s] io.Copy error: %v", entryPathWithinJob, err)
+			return entry, fmt.Errorf("os.Symlink(%s, %s) error: %w", link, localPath, err)
+		}
+		// Skipping Chtimes becasue there doesn't seem to be a way to
+		// change time on symlinks; the zip gets the mtime from the
+		// manifest instead.
+		entry.Mtime = keybase1.ToTime(srcFI.ModTime())
+		entry.Mode = archiveKBFSMode(keybase1.DirentType_SYM)
+		entry.State = keybase1.SimpleFSFileArchiveState_Complete
+	case !srcFI.Mode().IsRegular():
+		err = fmt.Errorf("%s is a %s, which can't be archived",
+			entryPathWithinSource, archiveSpecialFileKind(srcFI.Mode()))
+		if desc.SpecialFiles == keybase1.SimpleFSArchiveSpecialFiles_ERROR {
+			return entry, err
+		}
+		m.simpleFS.log.CDebugf(ctx, "skipping %s: %v", entryPathWithinJob, err)
+		entry.State = keybase1.SimpleFSFileArchiveState_Skipped
+		entry.Error = err.Error()
+	default:
+		err = os.MkdirAll(filepath.Dir(localPath), 0755)
+		if err != nil {
+			return entry, fmt.Errorf("os.MkdirAll(filepath.Dir(%s)) error: %w", localPath, err)
 		}
-		dstSHA256Sum = dstSHA256SumHasher.Sum(nil)
 
-		return srcSHA256Sum, dstSHA256Sum, nil
-	}()
-	if err != nil {
-		return nil, err
-	}
+		direntType := keybase1.DirentType_FILE
+		if srcFI.Mode()&0100 != 0 {
+			direntType = keybase1.DirentType_EXEC
+		}
+		mode := archiveFileMode(archiveKBFSMode(direntType))
 
-	if !bytes.Equal(srcSHA256Sum, dstSHA256Sum) {
-		m.simpleFS.log.CInfof(ctx,
-			"file corruption is detected from a previous copy. Will copy from the beginning: ",
-			entryPathWithinJob)
-		bytesCopiedUpdater(-size)
-		return m.copyFileFromBeginning(ctx, srcDirFS, entryPathWithinJob, localPath, mode, bytesCopiedUpdater)
-	}
+		seek := int64(0)
 
-	return srcSHA256Sum, nil
-}
+		dstFI, err := os.Lstat(localPath)
+		switch {
+		case os.IsNotExist(err): // simple copy from the start of file
+			if base.DirentType != entry.DirentType || len(base.Sha256SumHex) == 0 {
+				break
+			}
+			unchanged, err := m.unchangedSinceBase(ctx, srcDirFS,
+				entryPathWithinSource, base.Sha256SumHex, limiter, updateBytesCopied)
+			if err != nil {
+				return entry, err
+			}
+			if unchanged {
+				entry.Sha256SumHex = base.Sha256SumHex
+				entry.State = keybase1.SimpleFSFileArchiveState_Unchanged
+				return entry, nil
+			}
+		case err == nil: // continue from a previously interrupted copy
+			if srcFI.Mode()&os.ModeSymlink == 0 {
+				seek = dstFI.Size()
+			}
+			// otherwise copy from the start of file
+		default:
+			return entry, fmt.Errorf("os.Lstat(%s) error: %v", localPath, err)
+		}
 
-func (m *archiveManager) copyFile(ctx context.Context,
-	srcDirFS billy.Filesystem, entryPathWithinJob string,
-	localPath string, srcSeekOffset int64, mode os.FileMode,
-	bytesCopiedUpdater bytesUpdaterFunc) (sha256Sum []byte, err error) {
-	if srcSeekOffset == 0 {
-		return m.copyFileFromBeginning(ctx, srcDirFS, entryPathWithinJob, localPath, mode, bytesCopiedUpdater)
-	}
-	return m.copyFilePickupPrevious(ctx, srcDirFS, entryPathWithinJob, localPath, srcSeekOffset, mode, bytesCopiedUpdater)
-}
+		sha256Sum, err := m.copyFile(ctx, srcDirFS, entryPathWithinSource, localPath, seek,
+			entry.CopyCheckpoint, mode, limiter, updateBytesCopied, checkpoint)
+		if err != nil {
+			return entry, err
+		}
 
-func getWorkspaceDir(jobDesc keybase1.SimpleFSArchiveJobDesc) string {
-	return filepath.Join(jobDesc.StagingPath, "workspace")
+		err = os.Chtimes(localPath, time.Time{}, srcFI.ModTime())
+		if err != nil {
+			return entry, fmt.Errorf("os.Chtimes(%s) error: %v", localPath, err)
+		}
+
+		entry.Sha256SumHex = hex.EncodeToString(sha256Sum)
+		// The staged copy may end up a hard link sharing its mtime with
+		// others, so keep this file's own.
+		entry.Mtime = keybase1.ToTime(srcFI.ModTime())
+		entry.Mode = archiveUnixMode(mode)
+		entry.CopyCheckpoint = nil
+		entry.State = keybase1.SimpleFSFileArchiveState_Complete
+	}
+	return entry, nil
 }
 
 func (m *archiveManager) doCopying(ctx context.Context, jobID string) (err error) {
 	m.simpleFS.log.CDebugf(ctx, "+ doCopying %s", jobID)
 	defer func() { m.simpleFS.log.CDebugf(ctx, "- doCopying %s err: %v", jobID, err) }()
+	defer m.dropThrottle(jobID)
 
-	desc, manifest := func() (keybase1.SimpleFSArchiveJobDesc, map[string]keybase1.SimpleFSArchiveFile) {
+	job := func() keybase1.SimpleFSArchiveJobState {
 		m.mu.Lock()
 		defer m.mu.Unlock()
-		manifest := make(map[string]keybase1.SimpleFSArchiveFile)
-		for k, v := range m.state.Jobs[jobID].Manifest {
-			manifest[k] = v.DeepCopy()
-		}
-		return m.state.Jobs[jobID].Desc, manifest
+		return m.state.Jobs[jobID].DeepCopy()
 	}()
+	desc, manifest, baseManifest, deleted := job.Desc, job.Manifest, job.BaseManifest, job.Deleted
+	if manifest == nil {
+		manifest = make(map[string]keybase1.SimpleFSArchiveFile)
+	}
 
 	updateManifest := func(manifest map[string]keybase1.SimpleFSArchiveFile) {
 		m.mu.Lock()
@@ -557,25 +1663,34 @@ func (m *archiveManager) doCopying(ctx context.Context, jobID string) (err error
 		m.state.Jobs[jobID] = job
 	}
 
+	progress := m.newProgressNotifier(jobID, keybase1.SimpleFSArchiveJobPhase_Copying)
+	defer progress.flush(ctx)
 	updateBytesCopied := func(delta int64) {
-		m.mu.Lock()
-		defer m.mu.Unlock()
-		// Can override directly since only one worker can work on a give job at a time.
-		job := m.state.Jobs[jobID]
-		job.BytesCopied += delta
-		m.state.Jobs[jobID] = job
+		func() {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			// Can override directly since only one worker can work on a give job at a time.
+			job := m.state.Jobs[jobID]
+			job.BytesCopied += delta
+			m.state.Jobs[jobID] = job
+		}()
+		progress.add(ctx, delta)
 	}
 
-	srcContainingDirFS, finalElem, err := m.simpleFS.getFSIfExists(ctx,
-		keybase1.NewPathWithKbfsArchived(desc.KbfsPathWithRevision))
-	if err != nil {
-		return fmt.Errorf("getFSIfExists error: %v", err)
-	}
-	srcDirFS, err := srcContainingDirFS.Chroot(finalElem)
-	if err != nil {
-		return fmt.Errorf("srcContainingDirFS.Chroot error: %v", err)
+	srcDirFSes := make(map[string]billy.Filesystem) // source root -> FS
+	for _, source := range archiveJobSources(desc) {
+		srcContainingDirFS, finalElem, err := m.simpleFS.getFSIfExists(ctx,
+			keybase1.NewPathWithKbfsArchived(source.Path))
+		if err != nil {
+			return fmt.Errorf("getFSIfExists error: %v", err)
+		}
+		srcDirFSes[source.Root], err = srcContainingDirFS.Chroot(finalElem)
+		if err != nil {
+			return fmt.Errorf("srcContainingDirFS.Chroot error: %v", err)
+		}
 	}
 	dstBase := filepath.Join(getWorkspaceDir(desc), desc.TargetName)
+	limiter := m.getThrottle(jobID, desc.BytesPerSecond)
 
 	entryPaths := make([]string, 0, len(manifest))
 	for entryPathWithinJob := range manifest {
@@ -583,99 +1698,169 @@ func (m *archiveManager) doCopying(ctx context.Context, jobID string) (err error
 	}
 	sort.Strings(entryPaths)
 
+	deduper := newArchiveDeduper(desc, dstBase, manifest)
+
 loopEntryPaths:
 	for _, entryPathWithinJob := range entryPaths {
 		entry := manifest[entryPathWithinJob]
+		if len(entry.MaterializedFrom) > 0 {
+			// Copied along with the symlink it's under.
+			continue loopEntryPaths
+		}
+		switch entry.State {
+		case keybase1.SimpleFSFileArchiveState_Unchanged:
+			// Already compared against the base job before we got interrupted.
+			continue loopEntryPaths
+		case keybase1.SimpleFSFileArchiveState_Skipped:
+			continue loopEntryPaths
+		}
+		if len(entry.DuplicateOf) > 0 {
+			// Linked before we got interrupted. Copying it again would
+			// touch the file it's linked to, so just make sure the link
+			// is there.
+			err = deduper.link(entry.DuplicateOf, entryPathWithinJob)
+			if err == nil {
+				continue loopEntryPaths
+			}
+			m.simpleFS.log.CWarningf(ctx, "relinking %s error: %v; copying it again",
+				entryPathWithinJob, err)
+			entry.DuplicateOf = ""
+		}
 		entry.State = keybase1.SimpleFSFileArchiveState_InProgress
 		manifest[entryPathWithinJob] = entry
 		updateManifest(manifest)
 
 		localPath := filepath.Join(dstBase, entryPathWithinJob)
-		srcFI, err := srcDirFS.Lstat(entryPathWithinJob)
-		if err != nil {
-			return fmt.Errorf("srcDirFS.LStat(%s) error: %v", entryPathWithinJob, err)
+		root, entryPathWithinSource := archiveEntrySource(desc, entryPathWithinJob)
+		srcDirFS, ok := srcDirFSes[root]
+		if !ok {
+			return fmt.Errorf("no source for %s", entryPathWithinJob)
 		}
-		switch {
-		case srcFI.IsDir():
+		if len(entryPathWithinSource) == 0 {
+			// The top-level directory of a multi-path job's source.
 			err = os.MkdirAll(localPath, 0755)
 			if err != nil {
 				return fmt.Errorf("os.MkdirAll(%s) error: %v", localPath, err)
 			}
-			err = os.Chtimes(localPath, time.Time{}, srcFI.ModTime())
-			if err != nil {
-				return fmt.Errorf("os.Chtimes(%s) error: %v", localPath, err)
-			}
 			entry.State = keybase1.SimpleFSFileArchiveState_Complete
 			manifest[entryPathWithinJob] = entry
-		case srcFI.Mode()&os.ModeSymlink != 0: // symlink
-			err = os.MkdirAll(filepath.Dir(localPath), 0755)
-			if err != nil {
-				return fmt.Errorf("os.MkdirAll(filepath.Dir(%s)) error: %v", localPath, err)
-			}
-			// Call Stat, which follows symlinks, to make sure the link doesn't
-			// escape outside the srcDirFS.
-			_, err = srcDirFS.Stat(entryPathWithinJob)
-			if err != nil {
-				m.simpleFS.log.CWarningf(ctx, "skipping %s due to srcDirFS.Stat error: %v", entryPathWithinJob, err)
-				entry.State = keybase1.SimpleFSFileArchiveState_Skipped
-				manifest[entryPathWithinJob] = entry
-				continue loopEntryPaths
-			}
-
-			link, err := srcDirFS.Readlink(entryPathWithinJob)
-			if err != nil {
-				return fmt.Errorf("srcDirFS(%s) error: %v", entryPathWithinJob, err)
+			updateManifest(manifest)
+			continue loopEntryPaths
+		}
+		materialized := make(map[string]keybase1.SimpleFSArchiveFile)
+		checkpoint := func(cp *keybase1.SimpleFSArchiveCopyCheckpoint) {
+			e := manifest[entryPathWithinJob]
+			e.CopyCheckpoint = cp
+			manifest[entryPathWithinJob] = e
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			job := m.state.Jobs[jobID]
+			job.Manifest[entryPathWithinJob] = e.DeepCopy()
+			m.state.Jobs[jobID] = job
+			m.markStateDirtyLocked()
+		}
+		entry, err = m.copyEntry(ctx, desc, srcDirFS, entryPathWithinJob,
+			entryPathWithinSource, localPath, entry,
+			baseManifest[entryPathWithinJob], limiter, updateBytesCopied,
+			checkpoint, materialized)
+		if err == nil {
+			for p, e := range materialized {
+				manifest[p] = e
 			}
-			m.simpleFS.log.CInfof(ctx, "calling os.Symlink(%s, %s) ", link, localPath)
-			err = os.Symlink(link, localPath)
-			if err != nil {
-				return fmt.Errorf("os.Symlink(%s, %s) error: %v", link, localPath, err)
+		} else {
+			if !desc.BestEffort || archiveCopyErrorIsFatal(ctx, err) {
+				return err
 			}
-			// Skipping Chtimes becasue there doesn't seem to be a way to
-			// change time on symlinks.
-			entry.State = keybase1.SimpleFSFileArchiveState_Complete
-			manifest[entryPathWithinJob] = entry
-		default:
-			err = os.MkdirAll(filepath.Dir(localPath), 0755)
-			if err != nil {
-				return fmt.Errorf("os.MkdirAll(filepath.Dir(%s)) error: %v", localPath, err)
+			m.simpleFS.log.CWarningf(ctx, "skipping %s due to error: %v",
+				entryPathWithinJob, err)
+			if entry.DirentType != keybase1.DirentType_DIR {
+				// Don't zip up what we got of it.
+				if rmErr := os.RemoveAll(localPath); rmErr != nil {
+					return fmt.Errorf("os.RemoveAll(%s) error: %v", localPath, rmErr)
+				}
 			}
+			entry.State = keybase1.SimpleFSFileArchiveState_Skipped
+			entry.Error = err.Error()
+			entry.CopyCheckpoint = nil
+		}
+		manifest[entryPathWithinJob] = entry
+		updateManifest(manifest)
 
-			var mode os.FileMode = 0644
-			if srcFI.Mode()&0100 != 0 {
-				mode = 0755
-			}
+		first := deduper.duplicateOf(entryPathWithinJob, entry)
+		if len(first) == 0 {
+			continue loopEntryPaths
+		}
+		err = deduper.link(first, entryPathWithinJob)
+		if err != nil {
+			// Not a big deal; the copy is still there.
+			m.simpleFS.log.CWarningf(ctx, "linking %s to %s error: %v",
+				entryPathWithinJob, first, err)
+			continue loopEntryPaths
+		}
+		entry.DuplicateOf = first
+		manifest[entryPathWithinJob] = entry
+		updateManifest(manifest)
+	}
 
-			seek := int64(0)
+	if desc.AllRevisions {
+		err = m.copyRevisions(ctx, desc, manifest, limiter)
+		if err != nil {
+			return err
+		}
+	}
 
-			dstFI, err := os.Lstat(localPath)
-			switch {
-			case os.IsNotExist(err): // simple copy from the start of file
-			case err == nil: // continue from a previously interrupted copy
-				if srcFI.Mode()&os.ModeSymlink == 0 {
-					seek = dstFI.Size()
-				}
-				// otherwise copy from the start of file
-			default:
-				return fmt.Errorf("os.Lstat(%s) error: %v", localPath, err)
-			}
+	err = writeSkippedLargeFilesList(desc, job.SkippedLargeFiles)
+	if err != nil {
+		return err
+	}
 
-			sha256Sum, err := m.copyFile(ctx,
-				srcDirFS, entryPathWithinJob, localPath, seek, mode, updateBytesCopied)
-			if err != nil {
-				return err
-			}
+	err = writeSkippedFilesList(desc, archiveSkippedFiles(manifest))
+	if err != nil {
+		return err
+	}
 
-			err = os.Chtimes(localPath, time.Time{}, srcFI.ModTime())
-			if err != nil {
-				return fmt.Errorf("os.Chtimes(%s) error: %v", localPath, err)
-			}
+	err = writeChecksumManifest(desc, manifest)
+	if err != nil {
+		return err
+	}
 
-			entry.Sha256SumHex = hex.EncodeToString(sha256Sum)
-			entry.State = keybase1.SimpleFSFileArchiveState_Complete
-			manifest[entryPathWithinJob] = entry
+	owner, err := m.getOwner(ctx)
+	if err != nil {
+		return err
+	}
+	username := ""
+	if owner.loggedIn {
+		username = owner.name
+	}
+	// The label and notes may have been changed while copying.
+	func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		latest := m.state.Jobs[jobID].Desc
+		desc.Label, desc.Notes = latest.Label, latest.Notes
+	}()
+	err = writeArchiveInfo(desc, username, job.BytesTotal)
+	if err != nil {
+		return err
+	}
+
+	if len(desc.BaseJobID) > 0 {
+		err = writeDeletedList(desc, deleted)
+		if err != nil {
+			return err
 		}
-		updateManifest(manifest)
+		// The base manifest isn't needed anymore, so don't keep carrying
+		// it around in the state file.
+		func() {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			job, ok := m.state.Jobs[jobID]
+			if !ok {
+				return
+			}
+			job.BaseManifest = nil
+			m.state.Jobs[jobID] = job
+		}()
 	}
 
 	return nil
@@ -702,28 +1887,38 @@ func (m *archiveManager) copyingWorker(ctx context.Context) {
 
 		m.simpleFS.log.CDebugf(ctx, "copying: %s", jobID)
 
-		err := m.doCopying(jobCtx, jobID)
-		if err == nil {
+		restore := m.isRestoreJob(jobID)
+		var err error
+		if restore {
+			err = m.doRestoring(jobCtx, jobID)
+		} else {
+			err = m.doCopying(jobCtx, jobID)
+		}
+		switch {
+		case err == nil && restore:
+			// Nothing to zip when restoring.
+			m.simpleFS.log.CDebugf(jobCtx, "restoring done on job %s", jobID)
+			m.finishJobPhase(jobCtx, jobID, keybase1.SimpleFSArchiveJobPhase_Done)
+		case err == nil:
 			m.simpleFS.log.CDebugf(jobCtx, "copying done on job %s", jobID)
-			m.changeJobPhase(jobCtx, jobID, keybase1.SimpleFSArchiveJobPhase_Copied)
+			m.finishJobPhase(jobCtx, jobID, keybase1.SimpleFSArchiveJobPhase_Copied)
 			m.signal(m.zippingWorkerSignal) // Done copying! Notify the zipping worker.
-		} else {
+		default:
 			m.simpleFS.log.CErrorf(jobCtx, "copying error on job %s: %v", jobID, err)
 			m.setJobError(ctx, jobID, err)
 		}
-
-		err = m.flushStateFile(ctx)
-		if err != nil {
-			m.simpleFS.log.CWarningf(ctx, "m.flushStateFileLocked error: %v", err)
-		}
+		m.finishWorkerTask(jobID)
+		m.markStateDirty()
 	}
 }
 
 // zipWriterAddDir is adapted from zip.Writer.AddFS in go1.22.0 source because 1) we're
 // not on a version with this function yet, and 2) Go's AddFS doesn't support
 // symlinks; 3) we need bytesZippedUpdater here and we need to use CopyN for it.
-func zipWriterAddDir(ctx context.Context,
-	w *zip.Writer, dirPath string, bytesZippedUpdater bytesUpdaterFunc) error {
+func zipWriterAddDir(ctx context.Context, w *zip.Writer, dirPath string,
+	compression keybase1.SimpleFSArchiveCompression,
+	symlinks keybase1.SimpleFSArchiveSymlinks,
+	attrs map[string]archiveStagedAttrs, bytesZippedUpdater bytesUpdaterFunc) error {
 	fsys := os.DirFS(dirPath)
 	return fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
 		if err != nil {
@@ -732,56 +1927,182 @@ func zipWriterAddDir(ctx context.Context,
 		if d.IsDir() {
 			return nil
 		}
+		if archiveLeaveSymlinkOut(d.Type(), symlinks) {
+			return nil
+		}
 		info, err := d.Info()
 		if err != nil {
 			return err
 		}
-		if !(info.Mode() &^ fs.ModeSymlink).IsRegular() {
-			return errors.New("zip: cannot add non-regular file except symlink")
+		return zipWriterAddFile(
+			ctx, w, dirPath, name, info, compression, attrs[name], bytesZippedUpdater)
+	})
+}
+
+// zipWriterAddFile adds the file at name, relative to dirPath, to w. The
+// mtime and mode in attrs are used instead of the file's own, where set.
+func zipWriterAddFile(ctx context.Context, w *zip.Writer, dirPath string,
+	name string, info fs.FileInfo, compression keybase1.SimpleFSArchiveCompression,
+	attrs archiveStagedAttrs, bytesZippedUpdater bytesUpdaterFunc) error {
+	if !(info.Mode() &^ fs.ModeSymlink).IsRegular() {
+		return errors.New("zip: cannot add non-regular file except symlink")
+	}
+	h, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return err
+	}
+	h.Name = name
+	if !attrs.mtime.IsZero() {
+		h.Modified = attrs.mtime
+	}
+	if attrs.mode != 0 {
+		h.SetMode(info.Mode().Type() | attrs.mode)
+	}
+	h.Method = archiveCompressionMethod(name, compression)
+	fw, err := w.CreateHeader(h)
+	if err != nil {
+		return ArchiveEntryFormatError{Path: name, Reason: err.Error()}
+	}
+	switch {
+	case info.Mode()&fs.ModeSymlink != 0:
+		target, err := os.Readlink(filepath.Join(dirPath, name))
+		if err != nil {
+			return err
 		}
-		h, err := zip.FileInfoHeader(info)
+		_, err = fw.Write([]byte(filepath.ToSlash(target)))
 		if err != nil {
 			return err
 		}
-		h.Name = name
-		h.Method = zip.Deflate
-		fw, err := w.CreateHeader(h)
+		return nil
+	default:
+		f, err := os.Open(filepath.Join(dirPath, filepath.FromSlash(name)))
 		if err != nil {
 			return err
 		}
-		switch {
-		case info.Mode()&fs.ModeSymlink != 0:
-			target, err := os.Readlink(filepath.Join(dirPath, name))
-			if err != nil {
-				return err
-			}
-			_, err = fw.Write([]byte(filepath.ToSlash(target)))
-			if err != nil {
-				return err
-			}
-			return nil
-		default:
-			f, err := fsys.Open(name)
-			if err != nil {
-				return err
-			}
-			defer f.Close()
-			ctxAwareCopy(ctx, fw, f, bytesZippedUpdater)
-			return nil
+		defer f.Close()
+		defer trackArchiveFile(ctx, f)()
+		err = ctxAwareCopy(ctx, fw, f, nil, bytesZippedUpdater)
+		if err != nil {
+			return fmt.Errorf("zipping %s error: %v", name, err)
 		}
-	})
+		return nil
+	}
 }
 
 func (m *archiveManager) doZipping(ctx context.Context, jobID string) (err error) {
 	m.simpleFS.log.CDebugf(ctx, "+ doZipping %s", jobID)
 	defer func() { m.simpleFS.log.CDebugf(ctx, "- doZipping %s err: %v", jobID, err) }()
 
-	jobDesc := func() keybase1.SimpleFSArchiveJobDesc {
+	jobDesc, zipped := func() (keybase1.SimpleFSArchiveJobDesc, bool) {
 		m.mu.Lock()
 		defer m.mu.Unlock()
-		return m.state.Jobs[jobID].Desc
+		job := m.state.Jobs[jobID]
+		return job.Desc, job.Zipped
+	}()
+
+	if !zipped {
+		err = m.zipWorkspace(ctx, jobID, jobDesc)
+		if err != nil {
+			return err
+		}
+		func() {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			job := m.state.Jobs[jobID]
+			job.Zipped = true
+			m.state.Jobs[jobID] = job
+			// Remember the zip is done (and the workspace is gone), in
+			// case the upload is interrupted.
+			m.markStateDirtyLocked()
+		}()
+	}
+
+	volumePaths := func() []string {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		return m.state.Jobs[jobID].DeepCopy().VolumePaths
+	}()
+	return m.uploadZip(ctx, jobID, jobDesc, volumePaths)
+}
+
+// uploadZip sends the zip, or each of its volumes and their parts manifest,
+// to the job's destination, if it has one other than the local disk. A
+// failed upload leaves the zips in place, so it's all that's done again when
+// the job is retried.
+func (m *archiveManager) uploadZip(ctx context.Context, jobID string,
+	jobDesc keybase1.SimpleFSArchiveJobDesc, volumePaths []string) (err error) {
+	if jobDesc.Destination.Type == keybase1.SimpleFSArchiveDestinationType_Local {
+		return nil
+	}
+	zipPaths := []string{jobDesc.ZipFilePath}
+	destinations := []keybase1.SimpleFSArchiveDestination{jobDesc.Destination}
+	if len(volumePaths) > 0 {
+		zipPaths = nil
+		destinations = nil
+		for i, volumePath := range volumePaths {
+			destination, err := archiveVolumeDestination(jobDesc.Destination, i+1)
+			if err != nil {
+				return err
+			}
+			zipPaths = append(zipPaths, volumePath)
+			destinations = append(destinations, destination)
+		}
+		// The parts manifest goes along with them.
+		destination, err := archivePartsManifestDestination(jobDesc.Destination)
+		if err != nil {
+			return err
+		}
+		zipPaths = append(zipPaths, archivePartsManifestPath(jobDesc.ZipFilePath))
+		destinations = append(destinations, destination)
+	}
+
+	// Reset BytesUploaded, since a retried upload starts over.
+	func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		job := m.state.Jobs[jobID]
+		job.BytesUploaded = 0
+		m.state.Jobs[jobID] = job
 	}()
 
+	progress := m.newUploadProgressNotifier(jobID)
+	defer progress.flush(ctx)
+	for i, zipPath := range zipPaths {
+		dest, err := newArchiveDestination(destinations[i], filepath.Base(zipPath))
+		if err != nil {
+			return err
+		}
+		m.simpleFS.log.CDebugf(ctx, "uploading %s to %s", zipPath, dest)
+		err = dest.upload(ctx, zipPath, func(delta int64) {
+			func() {
+				m.mu.Lock()
+				defer m.mu.Unlock()
+				// Can override directly since only one worker can work on a given job at a time.
+				job := m.state.Jobs[jobID]
+				job.BytesUploaded += delta
+				m.state.Jobs[jobID] = job
+			}()
+			progress.add(ctx, delta)
+		})
+		if err != nil {
+			return fmt.Errorf("uploading to %s error: %v", dest, err)
+		}
+	}
+
+	// The zips were only in the staging path to be uploaded.
+	for _, zipPath := range zipPaths {
+		err = os.Remove(zipPath)
+		if err != nil {
+			m.simpleFS.log.CWarningf(ctx, "removing %s error %v", zipPath, err)
+		}
+	}
+	return nil
+}
+
+// zipWorkspace zips up the job's workspace into its ZipFilePath, or into
+// volumes next to it if it has a MaxVolumeBytes, and removes the workspace.
+func (m *archiveManager) zipWorkspace(ctx context.Context, jobID string,
+	jobDesc keybase1.SimpleFSArchiveJobDesc) (err error) {
 	// Reset BytesZipped.
 	func() {
 		m.mu.Lock()
@@ -792,18 +2113,32 @@ func (m *archiveManager) doZipping(ctx context.Context, jobID string) (err error
 		m.state.Jobs[jobID] = job
 	}()
 
+	progress := m.newProgressNotifier(jobID, keybase1.SimpleFSArchiveJobPhase_Zipping)
+	defer progress.flush(ctx)
 	updateBytesZipped := func(delta int64) {
-		m.mu.Lock()
-		defer m.mu.Unlock()
-		// Can override directly since only one worker can work on a give job at a time.
-		job := m.state.Jobs[jobID]
-		job.BytesZipped += delta
-		m.state.Jobs[jobID] = job
+		func() {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			// Can override directly since only one worker can work on a give job at a time.
+			job := m.state.Jobs[jobID]
+			job.BytesZipped += delta
+			m.state.Jobs[jobID] = job
+		}()
+		progress.add(ctx, delta)
 	}
 
 	workspaceDir := getWorkspaceDir(jobDesc)
+	attrs, sums := func() (map[string]archiveStagedAttrs, map[string]string) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		manifest := m.state.Jobs[jobID].Manifest
+		return archiveStagedAttrsFor(jobDesc, manifest), archiveStagedSumsFor(jobDesc, manifest)
+	}()
 
 	err = func() (err error) {
+		if jobDesc.MaxVolumeBytes > 0 {
+			return m.zipWorkspaceVolumes(ctx, jobID, jobDesc, attrs, updateBytesZipped)
+		}
 		mode := os.O_WRONLY | os.O_CREATE | os.O_EXCL
 		if jobDesc.OverwriteZip {
 			mode = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
@@ -818,8 +2153,9 @@ func (m *archiveManager) doZipping(ctx context.Context, jobID string) (err error
 				err = closeErr
 			}
 		}()
+		defer trackArchiveFile(ctx, zipFile)()
 
-		zipWriter := zip.NewWriter(zipFile)
+		zipWriter := newArchiveZipWriter(zipFile, jobDesc.Compression)
 		defer func() {
 			closeErr := zipWriter.Close()
 			if err == nil {
@@ -827,9 +2163,15 @@ func (m *archiveManager) doZipping(ctx context.Context, jobID string) (err error
 			}
 		}()
 
-		err = zipWriterAddDir(ctx, zipWriter, workspaceDir, updateBytesZipped)
+		if jobDesc.Layout == keybase1.SimpleFSArchiveLayout_Store {
+			err = zipWriterAddDirStore(ctx, zipWriter, workspaceDir, jobDesc.TargetName,
+				jobDesc.Compression, jobDesc.Symlinks, attrs, sums, updateBytesZipped)
+		} else {
+			err = zipWriterAddDir(ctx, zipWriter, workspaceDir,
+				jobDesc.Compression, jobDesc.Symlinks, attrs, updateBytesZipped)
+		}
 		if err != nil {
-			return fmt.Errorf("zipWriter.AddFS to %s error: %v", jobDesc.ZipFilePath, err)
+			return fmt.Errorf("zipWriter.AddFS to %s error: %w", jobDesc.ZipFilePath, err)
 		}
 
 		return nil
@@ -848,6 +2190,173 @@ func (m *archiveManager) doZipping(ctx context.Context, jobID string) (err error
 	return nil
 }
 
+// checkArchiveZip reads every file in the zips at zipFilePaths (the volumes
+// of a split job, or else a single zip) and compares it against manifest.
+// Only files under targetName are checked; anything else (like the deleted
+// list of an incremental job) was added by us and isn't in the manifest.
+func checkArchiveZip(ctx context.Context, zipFilePaths []string, targetName string,
+	manifest map[string]keybase1.SimpleFSArchiveFile) (
+	files []keybase1.SimpleFSArchiveFileCheck, err error) {
+	prefix := targetName + "/"
+	seen := make(map[string]bool)
+	for _, zipFilePath := range zipFilePaths {
+		zipFiles, err := checkArchiveZipVolume(
+			ctx, zipFilePath, prefix, manifest, seen)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, zipFiles...)
+	}
+
+	for entryPathWithinJob, entry := range manifest {
+		if seen[entryPathWithinJob] ||
+			entry.State != keybase1.SimpleFSFileArchiveState_Complete ||
+			entry.DirentType == keybase1.DirentType_DIR {
+			// Directories don't get their own zip entries.
+			continue
+		}
+		files = append(files, keybase1.SimpleFSArchiveFileCheck{
+			Path:                 entryPathWithinJob,
+			Result:               keybase1.SimpleFSArchiveFileCheckResult_Missing,
+			ExpectedSha256SumHex: entry.Sha256SumHex,
+		})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, nil
+}
+
+// checkArchiveZipVolume checks the files in one zip for checkArchiveZip,
+// and marks the ones it finds in seen.
+func checkArchiveZipVolume(ctx context.Context, zipFilePath string, prefix string,
+	manifest map[string]keybase1.SimpleFSArchiveFile, seen map[string]bool) (
+	files []keybase1.SimpleFSArchiveFileCheck, err error) {
+	r, err := zip.OpenReader(zipFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("zip.OpenReader(%s) error: %v", zipFilePath, err)
+	}
+	defer r.Close()
+	zipFiles, err := archiveStoreFiles(&r.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, zf := range zipFiles {
+		if !strings.HasPrefix(zf.Name, prefix) {
+			continue
+		}
+		entryPathWithinJob := strings.TrimPrefix(zf.Name, prefix)
+		seen[entryPathWithinJob] = true
+		entry, ok := manifest[entryPathWithinJob]
+		if !ok || entry.State != keybase1.SimpleFSFileArchiveState_Complete {
+			files = append(files, keybase1.SimpleFSArchiveFileCheck{
+				Path:   entryPathWithinJob,
+				Result: keybase1.SimpleFSArchiveFileCheckResult_Unexpected,
+			})
+			continue
+		}
+		check := keybase1.SimpleFSArchiveFileCheck{
+			Path:                 entryPathWithinJob,
+			ExpectedSha256SumHex: entry.Sha256SumHex,
+		}
+		err = func() error {
+			rc, err := zf.Open()
+			if err != nil {
+				return err
+			}
+			defer rc.Close()
+			h := sha256.New()
+			// The zip reader checks the CRC once it reaches the end.
+			err = ctxAwareCopy(ctx, h, rc, nil, func(int64) {})
+			if err != nil {
+				return err
+			}
+			check.ActualSha256SumHex = hex.EncodeToString(h.Sum(nil))
+			return nil
+		}()
+		switch {
+		case ctx.Err() != nil:
+			return nil, ctx.Err()
+		case err != nil:
+			check.Result = keybase1.SimpleFSArchiveFileCheckResult_Unreadable
+			check.Error = err.Error()
+		case len(entry.Sha256SumHex) == 0:
+			// Symlinks don't have a sum; being there is good enough.
+			check.Result = keybase1.SimpleFSArchiveFileCheckResult_Ok
+		case check.ActualSha256SumHex == entry.Sha256SumHex:
+			check.Result = keybase1.SimpleFSArchiveFileCheckResult_Ok
+		default:
+			check.Result = keybase1.SimpleFSArchiveFileCheckResult_Mismatch
+		}
+		files = append(files, check)
+	}
+	return files, nil
+}
+
+// checkableJob returns a copy of the job, if it has a zip here to check.
+func (m *archiveManager) checkableJob(ctx context.Context, jobID string) (
+	job keybase1.SimpleFSArchiveJobState, err error) {
+	if err := m.waitForState(ctx); err != nil {
+		return job, err
+	}
+	job, err = func() (keybase1.SimpleFSArchiveJobState, error) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		job, ok := m.state.Jobs[jobID]
+		if !ok {
+			return keybase1.SimpleFSArchiveJobState{}, errors.New("job not found")
+		}
+		return job.DeepCopy(), nil
+	}()
+	if err != nil {
+		return job, err
+	}
+	if job.Phase != keybase1.SimpleFSArchiveJobPhase_Done {
+		return job, fmt.Errorf("job is in phase %s; only done jobs can be checked", job.Phase)
+	}
+	if job.Desc.JobType == keybase1.SimpleFSArchiveJobType_Restore {
+		return job, errors.New("restore jobs don't make a zip to check")
+	}
+	dest, err := newArchiveDestination(
+		job.Desc.Destination, filepath.Base(job.Desc.ZipFilePath))
+	if err != nil {
+		return job, err
+	}
+	if dest != nil {
+		return job, fmt.Errorf("the zip was uploaded to %s and isn't kept locally", dest)
+	}
+	return job, nil
+}
+
+// checkJobZip checks the zips at zipFilePaths against job's manifest.
+func checkJobZip(ctx context.Context, job keybase1.SimpleFSArchiveJobState,
+	zipFilePaths []string) (result keybase1.SimpleFSArchiveCheckArchiveResult, err error) {
+	result.Desc = job.Desc
+	result.Files, err = checkArchiveZip(ctx, zipFilePaths, job.Desc.TargetName, job.Manifest)
+	if err != nil {
+		return keybase1.SimpleFSArchiveCheckArchiveResult{}, err
+	}
+	for _, f := range result.Files {
+		if f.Result == keybase1.SimpleFSArchiveFileCheckResult_Ok {
+			result.OkCount++
+		} else {
+			result.IssueCount++
+		}
+	}
+	return result, nil
+}
+
+func (m *archiveManager) checkArchive(ctx context.Context, jobID string) (
+	result keybase1.SimpleFSArchiveCheckArchiveResult, err error) {
+	m.simpleFS.log.CDebugf(ctx, "+ checkArchive %s", jobID)
+	defer func() { m.simpleFS.log.CDebugf(ctx, "- checkArchive %s err: %v", jobID, err) }()
+
+	job, err := m.checkableJob(ctx, jobID)
+	if err != nil {
+		return result, err
+	}
+	return checkJobZip(ctx, job, archiveZipPaths(job))
+}
+
 func (m *archiveManager) zippingWorker(ctx context.Context) {
 	for {
 		select {
@@ -872,16 +2381,16 @@ func (m *archiveManager) zippingWorker(ctx context.Context) {
 		err := m.doZipping(jobCtx, jobID)
 		if err == nil {
 			m.simpleFS.log.CDebugf(jobCtx, "zipping done on job %s", jobID)
-			m.changeJobPhase(jobCtx, jobID, keybase1.SimpleFSArchiveJobPhase_Done)
+			m.finishJobPhase(jobCtx, jobID, keybase1.SimpleFSArchiveJobPhase_Done)
+			m.notifyJob(jobCtx, jobID, nil)
+			// Its staging space is free for a waiting job.
+			m.signal(m.copyingWorkerSignal)
 		} else {
 			m.simpleFS.log.CErrorf(jobCtx, "zipping error on job %s: %v", jobID, err)
 			m.setJobError(ctx, jobID, err)
 		}
-
-		err = m.flushStateFile(ctx)
-		if err != nil {
-			m.simpleFS.log.CWarningf(ctx, "m.flushStateFileLocked error: %v", err)
-		}
+		m.finishWorkerTask(jobID)
+		m.markStateDirty()
 	}
 }
 
@@ -927,17 +2436,15 @@ func (m *archiveManager) errorRetryWorker(ctx context.Context) {
 		func() {
 			m.mu.Lock()
 			defer m.mu.Unlock()
-			jobIDs := make([]string, len(m.state.Jobs))
-			for jobID := range m.state.Jobs {
-				jobIDs = append(jobIDs, jobID)
-			}
 		loopJobIDs:
-			for _, jobID := range jobIDs {
-				errState, ok := m.errors[jobID]
-				if !ok {
+			for jobID, job := range m.state.Jobs {
+				errState := job.ErrorState
+				if errState == nil ||
+					errState.ErrorClass != keybase1.SimpleFSArchiveJobErrorClass_Retryable ||
+					job.Phase == keybase1.SimpleFSArchiveJobPhase_Failed {
 					continue loopJobIDs
 				}
-				if time.Now().Before(errState.nextRetry) {
+				if time.Now().Before(errState.NextRetry.Time()) {
 					continue loopJobIDs
 				}
 				m.simpleFS.log.CDebugf(ctx, "retrying job %s", jobID)
@@ -945,10 +2452,13 @@ func (m *archiveManager) errorRetryWorker(ctx context.Context) {
 				if !changed {
 					m.simpleFS.log.CWarningf(ctx,
 						"job %s has an error state %v but an unexpected job phase",
-						jobID, errState.err)
+						jobID, errState.Error)
 					continue loopJobIDs
 				}
-				delete(m.errors, jobID)
+				job = m.state.Jobs[jobID]
+				job.ErrorState = nil
+				job.RetryCount++
+				m.state.Jobs[jobID] = job
 
 				m.signal(m.indexingWorkerSignal)
 				m.signal(m.copyingWorkerSignal)
@@ -958,23 +2468,420 @@ func (m *archiveManager) errorRetryWorker(ctx context.Context) {
 	}
 }
 
+// nextArchiveScheduleRun returns the first time after `after` that matches
+// the schedule, in after's location.
+func nextArchiveScheduleRun(
+	schedule keybase1.SimpleFSArchiveSchedule, after time.Time) time.Time {
+	next := time.Date(after.Year(), after.Month(), after.Day(),
+		schedule.Hour, 0, 0, 0, after.Location())
+	step := 1
+	if schedule.Frequency == keybase1.SimpleFSArchiveScheduleFrequency_Weekly {
+		step = 7
+		next = next.AddDate(0, 0, (schedule.Weekday-int(next.Weekday())+7)%7)
+	}
+	for !next.After(after) {
+		next = next.AddDate(0, 0, step)
+	}
+	return next
+}
+
+func (m *archiveManager) addSchedule(ctx context.Context,
+	schedule keybase1.SimpleFSArchiveSchedule) error {
+	m.simpleFS.log.CDebugf(ctx, "+ archiveManager.addSchedule %#+v", schedule)
+	defer m.simpleFS.log.CDebugf(ctx, "- archiveManager.addSchedule")
+
+	if err := m.waitForState(ctx); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.state.Schedules[schedule.ScheduleID]; ok {
+		return errors.New("schedule ID already exists")
+	}
+	m.state.Schedules[schedule.ScheduleID] = schedule
+	m.state.LastUpdated = keybase1.ToTime(time.Now())
+	m.signal(m.scheduleWorkerSignal)
+	return m.flushStateFileLocked(ctx)
+}
+
+func (m *archiveManager) removeSchedule(ctx context.Context,
+	scheduleID string) error {
+	m.simpleFS.log.CDebugf(ctx, "+ archiveManager.removeSchedule %s", scheduleID)
+	defer m.simpleFS.log.CDebugf(ctx, "- archiveManager.removeSchedule")
+
+	if err := m.waitForState(ctx); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.state.Schedules[scheduleID]; !ok {
+		return errors.New("schedule not found")
+	}
+	delete(m.state.Schedules, scheduleID)
+	m.state.LastUpdated = keybase1.ToTime(time.Now())
+	return m.flushStateFileLocked(ctx)
+}
+
+// rotateScheduledOutputsLocked records the zip files of finished scheduled
+// runs on their schedules, and deletes the oldest ones beyond keepCount
+// along with the jobs that produced them.
+func (m *archiveManager) rotateScheduledOutputsLocked(
+	ctx context.Context) (changed bool) {
+	var done []keybase1.SimpleFSArchiveJobDesc
+	for _, job := range m.state.Jobs {
+		if len(job.Desc.ScheduleID) == 0 ||
+			job.Phase != keybase1.SimpleFSArchiveJobPhase_Done {
+			continue
+		}
+		done = append(done, job.Desc)
+	}
+	sort.Slice(done, func(i, j int) bool {
+		return done[i].StartTime < done[j].StartTime
+	})
+
+loopDone:
+	for _, desc := range done {
+		schedule, ok := m.state.Schedules[desc.ScheduleID]
+		if !ok {
+			continue loopDone
+		}
+		for _, output := range schedule.Outputs {
+			if output == desc.ZipFilePath {
+				continue loopDone
+			}
+		}
+		schedule.Outputs = append(schedule.Outputs, desc.ZipFilePath)
+		for schedule.KeepCount > 0 && len(schedule.Outputs) > schedule.KeepCount {
+			old := schedule.Outputs[0]
+			schedule.Outputs = schedule.Outputs[1:]
+			m.simpleFS.log.CDebugf(ctx, "rotating out %s for schedule %s",
+				old, schedule.ScheduleID)
+			err := os.Remove(old)
+			if err != nil && !os.IsNotExist(err) {
+				m.simpleFS.log.CWarningf(ctx, "removing %q error: %v", old, err)
+			}
+			for jobID, job := range m.state.Jobs {
+				if job.Desc.ScheduleID == schedule.ScheduleID &&
+					job.Desc.ZipFilePath == old {
+					_ = m.cancelOrDismissJobLocked(ctx, jobID)
+				}
+			}
+		}
+		m.state.Schedules[desc.ScheduleID] = schedule
+		changed = true
+	}
+	return changed
+}
+
+// archiveScheduledJobPriority is the priority of scheduled runs, so that
+// jobs the user starts by hand don't wait behind them.
+const archiveScheduledJobPriority = -1
+
+// archiveScheduleRetryDuration is how long to wait before trying again
+// when a scheduled run can't be started, e.g. because we're offline.
+const archiveScheduleRetryDuration = 10 * time.Minute
+
+func (m *archiveManager) startScheduledRun(ctx context.Context,
+	schedule keybase1.SimpleFSArchiveSchedule, now time.Time) {
+	outputPath := filepath.Join(schedule.OutputDir, fmt.Sprintf("%s-%s.zip",
+		filepath.Base(schedule.KbfsPath.Path), now.Format("20060102-150405")))
+	desc, err := m.simpleFS.newArchiveJobDesc(ctx, keybase1.SimpleFSArchiveStartArg{
+		KbfsPath:       schedule.KbfsPath,
+		OutputPath:     outputPath,
+		BytesPerSecond: schedule.BytesPerSecond,
+		Priority:       archiveScheduledJobPriority,
+	})
+	if err == nil && len(desc.JobID) == 0 {
+		err = errors.New("not an archivable KBFS path")
+	}
+	if err == nil {
+		desc.ScheduleID = schedule.ScheduleID
+		err = m.startJob(ctx, desc)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	schedule, ok := m.state.Schedules[schedule.ScheduleID]
+	if !ok {
+		// Unscheduled in the meantime; let the run (if any) finish anyway.
+		return
+	}
+	if err != nil {
+		m.simpleFS.log.CWarningf(ctx,
+			"starting scheduled archive %s error: %v", schedule.ScheduleID, err)
+		schedule.NextRun = keybase1.ToTime(now.Add(archiveScheduleRetryDuration))
+	} else {
+		schedule.LastRun = keybase1.ToTime(now)
+		schedule.LastJobID = desc.JobID
+		schedule.NextRun = keybase1.ToTime(nextArchiveScheduleRun(schedule, now))
+	}
+	m.state.Schedules[schedule.ScheduleID] = schedule
+	m.state.LastUpdated = keybase1.ToTime(time.Now())
+	err = m.flushStateFileLocked(ctx)
+	if err != nil {
+		m.simpleFS.log.CWarningf(ctx, "m.flushStateFileLocked error: %v", err)
+	}
+}
+
+const archiveScheduleCheckInterval = time.Minute
+
+// dueSchedulesLocked returns the schedules that should start a run now.
+// Schedules whose previous run is still going skip this run instead, and
+// skipped is true if there were any.
+func (m *archiveManager) dueSchedulesLocked(ctx context.Context, now time.Time) (
+	due []keybase1.SimpleFSArchiveSchedule, skipped bool) {
+	for scheduleID, schedule := range m.state.Schedules {
+		if now.Before(schedule.NextRun.Time()) {
+			continue
+		}
+		if job, ok := m.state.Jobs[schedule.LastJobID]; ok &&
+			job.Phase != keybase1.SimpleFSArchiveJobPhase_Done &&
+			job.Phase != keybase1.SimpleFSArchiveJobPhase_Failed {
+			// The previous run is still going. Skip this slot rather
+			// than piling up runs of the same directory.
+			m.simpleFS.log.CDebugf(ctx,
+				"schedule %s: previous run %s is still in %s; skipping",
+				scheduleID, schedule.LastJobID, job.Phase)
+			schedule.NextRun = keybase1.ToTime(
+				nextArchiveScheduleRun(schedule, now))
+			m.state.Schedules[scheduleID] = schedule
+			skipped = true
+			continue
+		}
+		due = append(due, schedule)
+	}
+	return due, skipped
+}
+
+func (m *archiveManager) scheduleWorker(ctx context.Context) {
+	ticker := time.NewTicker(archiveScheduleCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-m.scheduleWorkerSignal:
+		}
+
+		now := time.Now()
+		var due []keybase1.SimpleFSArchiveSchedule
+		func() {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			changed := m.rotateScheduledOutputsLocked(ctx)
+			var skipped bool
+			due, skipped = m.dueSchedulesLocked(ctx, now)
+			if changed || skipped {
+				m.state.LastUpdated = keybase1.ToTime(time.Now())
+				m.markStateDirtyLocked()
+			}
+		}()
+
+		for _, schedule := range due {
+			m.startScheduledRun(ctx, schedule, now)
+		}
+	}
+}
+
 func (m *archiveManager) start() {
 	ctx := context.Background()
 	ctx, m.ctxCancel = context.WithCancel(ctx)
-	go m.indexingWorker(m.simpleFS.makeContext(ctx))
-	go m.copyingWorker(m.simpleFS.makeContext(ctx))
-	go m.zippingWorker(m.simpleFS.makeContext(ctx))
-	go m.errorRetryWorker(m.simpleFS.makeContext(ctx))
-	m.signal(m.indexingWorkerSignal)
-	m.signal(m.copyingWorkerSignal)
-	m.signal(m.zippingWorkerSignal)
+	go func() {
+		ctx := m.simpleFS.makeContext(ctx)
+		if err := m.loadState(ctx); err != nil {
+			m.simpleFS.log.CDebugf(ctx, "archiveManager: never loaded state: %v", err)
+			return
+		}
+		go m.indexingWorker(ctx)
+		go m.copyingWorker(ctx)
+		go m.zippingWorker(ctx)
+		go m.errorRetryWorker(ctx)
+		go m.scheduleWorker(ctx)
+		go m.retentionWorker(ctx)
+		go m.summaryWorker(ctx)
+		go m.stateFlushWorker(ctx)
+		go m.diskReservationWorker(ctx)
+		m.signal(m.indexingWorkerSignal)
+		m.signal(m.copyingWorkerSignal)
+		m.signal(m.zippingWorkerSignal)
+		m.signal(m.scheduleWorkerSignal)
+		m.signal(m.retentionWorkerSignal)
+		m.signal(m.summaryWorkerSignal)
+		m.signal(m.diskReservationWorkerSignal)
+	}()
+}
+
+// waitForState blocks until the state has been loaded.
+func (m *archiveManager) waitForState(ctx context.Context) error {
+	select {
+	case <-m.stateLoaded:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// readState loads the state from store, migrating from the older state
+// files if the store is still empty. A nil state with a
+// nil error means there's no state yet.
+func (m *archiveManager) readState(ctx context.Context,
+	store *archiveStateStore) (state *keybase1.SimpleFSArchiveState, err error) {
+	state, err = store.load(ctx)
+	if err != nil || state != nil || !store.owner.loggedIn {
+		// The older state files were all encrypted for someone.
+		return state, err
+	}
+
+	stateFilePath := getStateFilePath(m.simpleFS)
+	legacyStateFilePath := getLegacyStateFilePath(m.simpleFS)
+	_, statErr := os.Stat(stateFilePath)
+	_, legacyStatErr := os.Stat(legacyStateFilePath)
+	switch {
+	case statErr == nil:
+		m.simpleFS.log.CDebugf(ctx, "Migrating archive state from %s", stateFilePath)
+		state, err = loadEncryptedArchiveState(ctx, m.simpleFS, stateFilePath)
+		if _, ok := err.(archiveStateKeyError); ok {
+			return nil, err
+		}
+	case legacyStatErr == nil:
+		m.simpleFS.log.CDebugf(ctx, "Migrating archive state from %s", legacyStateFilePath)
+		state, err = loadArchiveStateFromJsonGz(ctx, m.simpleFS, legacyStateFilePath)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		// Same as a corrupt state; start over.
+		state = nil
+	} else if err := store.write(ctx, state); err != nil {
+		// Keep the old files so we can try again next time.
+		return nil, err
+	}
+	for _, p := range []string{stateFilePath, legacyStateFilePath} {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			m.simpleFS.log.CWarningf(ctx, "Couldn't remove old archive state %s: %v", p, err)
+		}
+	}
+	return state, nil
+}
+
+// getStore opens owner's state store if it isn't open yet, closing the
+// store of whoever was logged in the last time we tried, if it's someone
+// else's.
+func (m *archiveManager) getStore(ctx context.Context, owner archiveOwner) (
+	*archiveStateStore, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.store != nil && m.store.owner == owner {
+		return m.store, nil
+	}
+	if err := ctx.Err(); err != nil {
+		// Shutting down; don't open it again.
+		return nil, err
+	}
+	if m.store != nil {
+		m.closeStoreLocked(ctx)
+	}
+	store, err := openArchiveStateStore(m.simpleFS, owner)
+	if err != nil {
+		return nil, err
+	}
+	m.store = store
+	return store, nil
+}
+
+// loadState reads the state once the service is there to decrypt it,
+// retrying on archiveStateKeyError until it succeeds or ctx is canceled.
+func (m *archiveManager) loadState(ctx context.Context) error {
+	for {
+		var err error
+		if m.simpleFS.config.KeybaseService() == nil {
+			err = errors.New("KeybaseService is not set up")
+		} else {
+			err = m.tryLoadState(ctx)
+		}
+		if err == nil {
+			return nil
+		}
+		m.simpleFS.log.CDebugf(ctx, "archiveManager: loading state error "+
+			"( %v ); retrying in %s", err, archiveStateLoadRetryInterval)
+		select {
+		case <-time.After(archiveStateLoadRetryInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (m *archiveManager) tryLoadState(ctx context.Context) error {
+	owner, err := m.simpleFS.getArchiveOwner(ctx)
+	if err != nil {
+		return err
+	}
+	store, err := m.getStore(ctx, owner)
+	if err != nil {
+		return err
+	}
+	state, err := m.readState(ctx, store)
+	switch errors.Cause(err).(type) {
+	case nil:
+	case archiveStateKeyError:
+		return err
+	default:
+		m.simpleFS.log.CErrorf(ctx, "loading archive state error ( %v ). Creating a new state.", err)
+		state = nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.owner = owner
+	if state == nil {
+		m.state = &keybase1.SimpleFSArchiveState{}
+	} else {
+		m.state = state
+	}
+	if m.state.Jobs == nil {
+		m.state.Jobs = make(map[string]keybase1.SimpleFSArchiveJobState)
+	}
+	if m.state.Schedules == nil {
+		m.state.Schedules = make(map[string]keybase1.SimpleFSArchiveSchedule)
+	}
+	m.resetInterruptedPhasesLocked(ctx)
+	close(m.stateLoaded)
+	if state == nil {
+		if err := m.flushStateFileLocked(ctx); err != nil {
+			m.simpleFS.log.CWarningf(ctx, "creating state file error: %v", err)
+		}
+	}
+	return nil
 }
 
 func (m *archiveManager) resetInterruptedPhasesLocked(ctx context.Context) {
 	// We don't resume indexing and zipping work, so just reset them here.
 	// Copying is resumable but we have per file state tracking so reset the
 	// phase here as well.
-	for jobID := range m.state.Jobs {
+	for jobID, job := range m.state.Jobs {
+		if job.Phase == keybase1.SimpleFSArchiveJobPhase_Cancelling {
+			// We went away before the worker let go of it.
+			m.removeJobLocked(ctx, jobID)
+			continue
+		}
+		if job.Phase == keybase1.SimpleFSArchiveJobPhase_Failed {
+			// It waits for the user to retry it.
+			continue
+		}
+		if job.ErrorState != nil {
+			if job.ErrorState.ErrorClass != keybase1.SimpleFSArchiveJobErrorClass_Retryable {
+				// Left behind by a version that didn't fail jobs on
+				// errors retrying won't fix.
+				m.failJobLocked(ctx, jobID)
+				continue
+			}
+			// Retry it now.
+			job.ErrorState = nil
+			job.RetryCount++
+			m.state.Jobs[jobID] = job
+		}
 		_ = m.resetInterruptedPhaseLocked(ctx, jobID)
 	}
 }
@@ -984,31 +2891,32 @@ func newArchiveManager(simpleFS *SimpleFS) (m *archiveManager, err error) {
 	simpleFS.log.CDebugf(ctx, "+ newArchiveManager")
 	defer simpleFS.log.CDebugf(ctx, "- newArchiveManager")
 	m = &archiveManager{
-		simpleFS:             simpleFS,
-		jobCtxCancellers:     make(map[string]func()),
-		errors:               make(map[string]errorState),
-		indexingWorkerSignal: make(chan struct{}, 1),
-		copyingWorkerSignal:  make(chan struct{}, 1),
-		zippingWorkerSignal:  make(chan struct{}, 1),
-	}
-	stateFilePath := getStateFilePath(simpleFS)
-	m.state, err = loadArchiveStateFromJsonGz(ctx, simpleFS, stateFilePath)
-	switch err {
-	case nil:
-		if m.state.Jobs == nil {
-			m.state.Jobs = make(map[string]keybase1.SimpleFSArchiveJobState)
-		}
-		m.resetInterruptedPhasesLocked(ctx)
-	default:
-		simpleFS.log.CErrorf(ctx, "loadArchiveStateFromJsonGz error ( %v ). Creating a new state.", err)
-		m.state = &keybase1.SimpleFSArchiveState{
-			Jobs: make(map[string]keybase1.SimpleFSArchiveJobState),
-		}
-		err = writeArchiveStateIntoJsonGz(ctx, simpleFS, stateFilePath, m.state)
-		if err != nil {
-			simpleFS.log.CErrorf(ctx, "newArchiveManager: creating state file error: %v", err)
-			return nil, err
-		}
+		simpleFS:              simpleFS,
+		jobTasks:              make(map[string]*archiveJobTask),
+		throttles:             make(map[string]*rate.Limiter),
+		getAvailableDiskBytes: libkbfs.GetAvailableDiskBytes,
+		diskReservations:      make(map[string]bool),
+		notifyProgress: func(ctx context.Context, progress keybase1.SimpleFSArchiveProgress) error {
+			// KeybaseService isn't set up yet when we're created.
+			ks := simpleFS.config.KeybaseService()
+			if ks == nil {
+				return nil
+			}
+			return ks.NotifyArchiveProgress(ctx, progress)
+		},
+		notify: func(ctx context.Context, notification *keybase1.FSNotification) {
+			simpleFS.config.Reporter().Notify(ctx, notification)
+		},
+		stateLoaded:           make(chan struct{}),
+		indexingWorkerSignal:  make(chan struct{}, 1),
+		copyingWorkerSignal:   make(chan struct{}, 1),
+		zippingWorkerSignal:   make(chan struct{}, 1),
+		scheduleWorkerSignal:  make(chan struct{}, 1),
+		retentionWorkerSignal: make(chan struct{}, 1),
+		summaryWorkerSignal:   make(chan struct{}, 1),
+		stateFlushSignal:      make(chan struct{}, 1),
+
+		diskReservationWorkerSignal: make(chan struct{}, 1),
 	}
 	m.start()
 	return m, nil
@@ -0,0 +1,89 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package simplefs
+
+import (
+	"io"
+	"sync"
+
+	"golang.org/x/net/context"
+)
+
+// Canceling the context of a job only stops a copy between chunks, which
+// can take a while if a write is stuck on a slow disk. So the local files a
+// job's worker has open are tracked, and closed when the job is canceled or
+// paused, to interrupt whatever is in flight. (KBFS files aren't: closing
+// one while it's being read isn't safe.) The worker lets go of the job only
+// once it's done updating the job's state, and a canceled job's staging
+// files are removed only after that, so nothing writes into them as they're
+// removed.
+
+// archiveJobTask is a worker's hold on a job, from when it picks up the job
+// until it's done with it.
+type archiveJobTask struct {
+	cancel func()
+	// Closed once the worker has let go of the job.
+	done chan struct{}
+
+	mu          sync.Mutex
+	interrupted bool
+	files       map[io.Closer]bool
+}
+
+func newArchiveJobTask(cancel func()) *archiveJobTask {
+	return &archiveJobTask{
+		cancel: cancel,
+		done:   make(chan struct{}),
+		files:  make(map[io.Closer]bool),
+	}
+}
+
+// trackFile has f closed if the task is interrupted before the returned
+// function is called.
+func (t *archiveJobTask) trackFile(f io.Closer) (untrack func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.interrupted {
+		_ = f.Close()
+		return func() {}
+	}
+	t.files[f] = true
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		delete(t.files, f)
+	}
+}
+
+// interrupt cancels the task's context and closes the files it has open.
+// The worker sees errors from whatever it's in the middle of, and gives up
+// on the job.
+func (t *archiveJobTask) interrupt() {
+	t.cancel()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.interrupted = true
+	for f := range t.files {
+		_ = f.Close()
+	}
+	t.files = nil
+}
+
+type archiveJobTaskKey struct{}
+
+func withArchiveJobTask(
+	ctx context.Context, task *archiveJobTask) context.Context {
+	return context.WithValue(ctx, archiveJobTaskKey{}, task)
+}
+
+// trackArchiveFile has f closed if the job ctx is for is interrupted before
+// the returned function is called. It does nothing for contexts that aren't
+// a job's.
+func trackArchiveFile(ctx context.Context, f io.Closer) (untrack func()) {
+	task, ok := ctx.Value(archiveJobTaskKey{}).(*archiveJobTask)
+	if !ok {
+		return func() {}
+	}
+	return task.trackFile(f)
+}
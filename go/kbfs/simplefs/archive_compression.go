@@ -0,0 +1,70 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package simplefs
+
+import (
+	"archive/zip"
+	"compress/flate"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// archiveCompressedExtensions are the extensions of file types that are
+// already compressed. Deflating them again takes CPU time and saves next to
+// nothing, so they're always stored as is.
+var archiveCompressedExtensions = map[string]bool{
+	// Images
+	".jpg": true, ".jpeg": true, ".png": true, ".gif": true, ".webp": true,
+	".heic": true, ".heif": true, ".avif": true,
+	// Audio and video
+	".mp3": true, ".m4a": true, ".aac": true, ".ogg": true, ".opus": true,
+	".flac": true, ".mp4": true, ".m4v": true, ".mov": true, ".mkv": true,
+	".webm": true, ".avi": true,
+	// Archives and compressed files
+	".zip": true, ".gz": true, ".tgz": true, ".bz2": true, ".xz": true,
+	".zst": true, ".7z": true, ".rar": true, ".jar": true, ".apk": true,
+	// Documents that are zips inside
+	".docx": true, ".xlsx": true, ".pptx": true, ".odt": true, ".epub": true,
+}
+
+func checkArchiveCompression(compression keybase1.SimpleFSArchiveCompression) error {
+	if _, ok := keybase1.SimpleFSArchiveCompressionRevMap[compression]; !ok {
+		return fmt.Errorf("unknown compression %d", compression)
+	}
+	return nil
+}
+
+// archiveCompressionMethod returns the zip method for the file at name.
+func archiveCompressionMethod(name string,
+	compression keybase1.SimpleFSArchiveCompression) uint16 {
+	if compression == keybase1.SimpleFSArchiveCompression_STORE ||
+		archiveCompressedExtensions[strings.ToLower(path.Ext(name))] {
+		return zip.Store
+	}
+	return zip.Deflate
+}
+
+// newArchiveZipWriter returns a zip writer that deflates at the level for
+// compression.
+func newArchiveZipWriter(w io.Writer,
+	compression keybase1.SimpleFSArchiveCompression) *zip.Writer {
+	zw := zip.NewWriter(w)
+	level := flate.DefaultCompression
+	switch compression {
+	case keybase1.SimpleFSArchiveCompression_FAST:
+		level = flate.BestSpeed
+	case keybase1.SimpleFSArchiveCompression_BEST:
+		level = flate.BestCompression
+	default:
+		return zw
+	}
+	zw.RegisterCompressor(zip.Deflate, func(out io.Writer) (io.WriteCloser, error) {
+		return flate.NewWriter(out, level)
+	})
+	return zw
+}
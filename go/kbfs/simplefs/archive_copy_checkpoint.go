@@ -0,0 +1,115 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package simplefs
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"hash"
+	"os"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// While a file is copied into the staging directory, the state of its
+// SHA-256 is checkpointed in the manifest every
+// archiveCopyCheckpointInterval bytes, after syncing what's been written so
+// far. A copy that's interrupted then picks up from its latest checkpoint:
+// whatever was written after it is thrown away, and the hash carries on
+// from the checkpointed state, so only the rest of the file is read,
+// instead of the whole source and staged copy being read again to check
+// them against each other.
+
+// archiveCopyCheckpointInterval is how many bytes of a file are copied
+// between checkpoints.
+const archiveCopyCheckpointInterval = 64 * 1024 * 1024
+
+// archiveCheckpointFunc records the checkpoint of the copy of a file, or
+// clears it if it's nil.
+type archiveCheckpointFunc = func(*keybase1.SimpleFSArchiveCopyCheckpoint)
+
+type archiveCopyCheckpointer struct {
+	dst       *os.File
+	sparseDst *archiveSparseWriter
+	h         hash.Hash
+	record    archiveCheckpointFunc
+	last      int64
+}
+
+// newArchiveCopyCheckpointer returns a checkpointer for a copy into dst
+// through sparseDst, hashed by h. record may be nil, in which case there
+// are no checkpoints.
+func newArchiveCopyCheckpointer(dst *os.File, sparseDst *archiveSparseWriter,
+	h hash.Hash, record archiveCheckpointFunc) *archiveCopyCheckpointer {
+	return &archiveCopyCheckpointer{
+		dst:       dst,
+		sparseDst: sparseDst,
+		h:         h,
+		record:    record,
+		last:      sparseDst.off,
+	}
+}
+
+func (c *archiveCopyCheckpointer) checkpoint() error {
+	// Everything up to the checkpoint has to be on disk before it's
+	// recorded, since resuming trusts it without reading it back.
+	err := c.sparseDst.finish()
+	if err != nil {
+		return err
+	}
+	err = c.dst.Sync()
+	if err != nil {
+		return err
+	}
+	m, ok := c.h.(encoding.BinaryMarshaler)
+	if !ok {
+		return errors.New("hash state can't be marshaled")
+	}
+	state, err := m.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	c.record(&keybase1.SimpleFSArchiveCopyCheckpoint{
+		Offset:      c.sparseDst.off,
+		Sha256State: state,
+	})
+	c.last = c.sparseDst.off
+	return nil
+}
+
+// wrap returns a bytesUpdaterFunc that calls inner, and checkpoints the
+// copy whenever another archiveCopyCheckpointInterval bytes have been
+// written. A failed checkpoint only means there's less to pick up from, so
+// it's passed to onErr rather than failing the copy.
+func (c *archiveCopyCheckpointer) wrap(
+	inner bytesUpdaterFunc, onErr func(error)) bytesUpdaterFunc {
+	if c.record == nil {
+		return inner
+	}
+	return func(delta int64) {
+		inner(delta)
+		if c.sparseDst.off-c.last < archiveCopyCheckpointInterval {
+			return
+		}
+		if err := c.checkpoint(); err != nil {
+			onErr(err)
+		}
+	}
+}
+
+// restoreSHA256Checkpoint puts h, a SHA-256 hash, back in the state it was
+// in at cp.
+func restoreSHA256Checkpoint(
+	h hash.Hash, cp keybase1.SimpleFSArchiveCopyCheckpoint) error {
+	u, ok := h.(encoding.BinaryUnmarshaler)
+	if !ok {
+		return errors.New("hash state can't be unmarshaled")
+	}
+	err := u.UnmarshalBinary(cp.Sha256State)
+	if err != nil {
+		return fmt.Errorf("bad checkpoint at %d: %w", cp.Offset, err)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package simplefs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// Files with the same contents are only staged once: later copies are
+// replaced with hard links to the first, so that a TLF full of duplicated
+// files doesn't take that much more room in the staging path. Hard links
+// share a mode and an mtime, so only files with the same mode are linked,
+// and every copied file's own mtime is kept in the job's manifest to be used
+// when zipping. The zip still has full contents for each of them, since zip
+// has no way of sharing contents between entries that all readers
+// understand, unless the job uses the Store layout (see archive_store.go).
+
+var emptySha256SumHex = func() string {
+	sum := sha256.Sum256(nil)
+	return hex.EncodeToString(sum[:])
+}()
+
+type archiveDedupeKey struct {
+	sha256SumHex string
+	direntType   keybase1.DirentType
+}
+
+// archiveDedupeKeyFor returns what files that entry can be linked with
+// have in common, or false if it can't be linked with any.
+func archiveDedupeKeyFor(entry keybase1.SimpleFSArchiveFile) (
+	archiveDedupeKey, bool) {
+	if entry.State != keybase1.SimpleFSFileArchiveState_Complete ||
+		len(entry.Sha256SumHex) == 0 ||
+		// Not worth a link.
+		entry.Sha256SumHex == emptySha256SumHex {
+		return archiveDedupeKey{}, false
+	}
+	switch entry.DirentType {
+	case keybase1.DirentType_FILE, keybase1.DirentType_EXEC:
+	default:
+		return archiveDedupeKey{}, false
+	}
+	return archiveDedupeKey{entry.Sha256SumHex, entry.DirentType}, true
+}
+
+// archiveDeduper keeps track of the first file staged with each contents.
+type archiveDeduper struct {
+	dstBase string
+	// Where duplicates are linked before being renamed over their copy.
+	tmpPath string
+	firsts  map[archiveDedupeKey]string // -> entryPathWithinJob
+}
+
+// newArchiveDeduper returns a deduper for the job staged in dstBase, which
+// knows about the files in manifest already staged before the job was
+// interrupted.
+func newArchiveDeduper(desc keybase1.SimpleFSArchiveJobDesc, dstBase string,
+	manifest map[string]keybase1.SimpleFSArchiveFile) *archiveDeduper {
+	d := &archiveDeduper{
+		dstBase: dstBase,
+		tmpPath: filepath.Join(desc.StagingPath, "dedupe-link.tmp"),
+		firsts:  make(map[archiveDedupeKey]string),
+	}
+	entryPaths := make([]string, 0, len(manifest))
+	for entryPathWithinJob, entry := range manifest {
+		if len(entry.DuplicateOf) == 0 {
+			entryPaths = append(entryPaths, entryPathWithinJob)
+		}
+	}
+	// Same order doCopying copies them in, so the first is the same as it
+	// was before.
+	sort.Strings(entryPaths)
+	for _, entryPathWithinJob := range entryPaths {
+		key, ok := archiveDedupeKeyFor(manifest[entryPathWithinJob])
+		if !ok {
+			continue
+		}
+		if _, ok := d.firsts[key]; !ok {
+			d.firsts[key] = entryPathWithinJob
+		}
+	}
+	return d
+}
+
+// duplicateOf returns the path of the first file staged with the same
+// contents and mode as the just-copied entry at entryPathWithinJob, or ""
+// if it's the first.
+func (d *archiveDeduper) duplicateOf(
+	entryPathWithinJob string, entry keybase1.SimpleFSArchiveFile) string {
+	key, ok := archiveDedupeKeyFor(entry)
+	if !ok {
+		return ""
+	}
+	first, ok := d.firsts[key]
+	if !ok {
+		d.firsts[key] = entryPathWithinJob
+		return ""
+	}
+	if first == entryPathWithinJob {
+		return ""
+	}
+	return first
+}
+
+// link replaces the staged copy of entryPathWithinJob, if any, with a hard
+// link to the one of first.
+func (d *archiveDeduper) link(first, entryPathWithinJob string) error {
+	firstPath := filepath.Join(d.dstBase, first)
+	localPath := filepath.Join(d.dstBase, entryPathWithinJob)
+	err := os.MkdirAll(filepath.Dir(localPath), 0755)
+	if err != nil {
+		return fmt.Errorf("os.MkdirAll(filepath.Dir(%s)) error: %w", localPath, err)
+	}
+	err = os.Remove(d.tmpPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("os.Remove(%s) error: %v", d.tmpPath, err)
+	}
+	err = os.Link(firstPath, d.tmpPath)
+	if err != nil {
+		return fmt.Errorf("os.Link(%s, %s) error: %v", firstPath, d.tmpPath, err)
+	}
+	// Rename over the copy, so there's always a whole file at localPath.
+	err = os.Rename(d.tmpPath, localPath)
+	if err != nil {
+		_ = os.Remove(d.tmpPath)
+		return fmt.Errorf("os.Rename(%s, %s) error: %v", d.tmpPath, localPath, err)
+	}
+	return nil
+}
+
+// archiveStagedAttrs is what's kept in a job's manifest about a staged file,
+// to be used instead of what the file itself has when zipping it.
+type archiveStagedAttrs struct {
+	mtime time.Time
+	mode  os.FileMode
+}
+
+// archiveStagedAttrsFor returns the mtimes and modes kept in manifest for
+// the files staged under the job's target directory, by their names within
+// the workspace, for zipping.
+func archiveStagedAttrsFor(desc keybase1.SimpleFSArchiveJobDesc,
+	manifest map[string]keybase1.SimpleFSArchiveFile) map[string]archiveStagedAttrs {
+	attrs := make(map[string]archiveStagedAttrs)
+	for entryPathWithinJob, entry := range manifest {
+		if entry.State != keybase1.SimpleFSFileArchiveState_Complete ||
+			(entry.Mtime == 0 && entry.Mode == 0) {
+			continue
+		}
+		var a archiveStagedAttrs
+		if entry.Mtime != 0 {
+			a.mtime = entry.Mtime.Time()
+		}
+		if entry.Mode != 0 {
+			a.mode = archiveFileMode(entry.Mode)
+		}
+		attrs[path.Join(desc.TargetName, entryPathWithinJob)] = a
+	}
+	return attrs
+}
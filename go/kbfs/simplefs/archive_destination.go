@@ -0,0 +1,270 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package simplefs
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"golang.org/x/net/context"
+)
+
+// archiveDestination is somewhere other than the local disk that a finished
+// zip can be uploaded to.
+type archiveDestination interface {
+	// upload sends the zip at zipPath, calling progress with the number of
+	// bytes read from it as it goes.
+	upload(ctx context.Context, zipPath string, progress func(delta int64)) error
+	// String describes the destination for logs and errors, without any
+	// credentials.
+	String() string
+}
+
+// archiveDestinationPath adds zipName to p if p names a directory.
+func archiveDestinationPath(p, zipName string) string {
+	if len(p) == 0 || strings.HasSuffix(p, "/") {
+		return p + zipName
+	}
+	return p
+}
+
+// newArchiveDestination returns the destination a job's zip, named zipName,
+// goes to. It's nil for Local, where the zip is written to its final place
+// directly.
+func newArchiveDestination(dest keybase1.SimpleFSArchiveDestination,
+	zipName string) (archiveDestination, error) {
+	if dest.Type == keybase1.SimpleFSArchiveDestinationType_Local {
+		return nil, nil
+	}
+	u, err := url.Parse(dest.Url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid destination URL: %v", err)
+	}
+	if len(u.Host) == 0 {
+		return nil, fmt.Errorf("destination URL %q has no host", u.Redacted())
+	}
+	switch dest.Type {
+	case keybase1.SimpleFSArchiveDestinationType_S3:
+		if u.Scheme != "s3" {
+			return nil, errors.New("S3 destinations need an s3://bucket/key URL")
+		}
+		return &s3ArchiveDestination{
+			dest:   dest,
+			bucket: u.Host,
+			key:    strings.TrimPrefix(archiveDestinationPath(u.Path, zipName), "/"),
+		}, nil
+	case keybase1.SimpleFSArchiveDestinationType_WebDAV:
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return nil, errors.New("WebDAV destinations need an http(s) URL")
+		}
+		u.Path = archiveDestinationPath(u.Path, zipName)
+		return &webDAVArchiveDestination{dest: dest, url: u}, nil
+	case keybase1.SimpleFSArchiveDestinationType_SFTP:
+		if u.Scheme != "sftp" {
+			return nil, errors.New("SFTP destinations need an sftp://user@host/path URL")
+		}
+		if u.User == nil || len(u.User.Username()) == 0 {
+			return nil, errors.New("SFTP destinations need a user name in the URL")
+		}
+		if len(dest.PrivateKeyPath) == 0 && len(dest.Password) == 0 {
+			return nil, errors.New("SFTP destinations need a private key or a password")
+		}
+		addr := u.Host
+		if len(u.Port()) == 0 {
+			addr += ":22"
+		}
+		return &sftpArchiveDestination{
+			dest: dest,
+			user: u.User.Username(),
+			addr: addr,
+			path: archiveDestinationPath(u.Path, zipName),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown archive destination type %s", dest.Type)
+	}
+}
+
+// archiveUploadReader reports the bytes read through it to progress.
+type archiveUploadReader struct {
+	r        io.Reader
+	progress func(delta int64)
+}
+
+func (r *archiveUploadReader) Read(p []byte) (n int, err error) {
+	n, err = r.r.Read(p)
+	if n > 0 {
+		r.progress(int64(n))
+	}
+	return n, err
+}
+
+// archiveUploadStallTimeout is how long an upload can go without sending
+// anything, or waiting for the server's reply to it, before it's given up
+// on. A var so tests can shorten it.
+var archiveUploadStallTimeout = 5 * time.Minute
+
+// archiveUploadWatchdog cancels an upload that hasn't made any progress for
+// archiveUploadStallTimeout, so a server that stops responding can't hold
+// up the job forever.
+type archiveUploadWatchdog struct {
+	timer   *time.Timer
+	stalled atomic.Bool
+}
+
+// newArchiveUploadWatchdog returns a context for an upload, which is
+// canceled if ctx is or if the upload stalls. Call the returned cancel func
+// once the upload is over.
+func newArchiveUploadWatchdog(ctx context.Context) (
+	*archiveUploadWatchdog, context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	w := &archiveUploadWatchdog{}
+	w.timer = time.AfterFunc(archiveUploadStallTimeout, func() {
+		w.stalled.Store(true)
+		cancel()
+	})
+	return w, ctx, func() {
+		w.timer.Stop()
+		cancel()
+	}
+}
+
+// progress wraps the upload's progress func, putting off the timeout each
+// time it's called.
+func (w *archiveUploadWatchdog) progress(progress func(delta int64)) func(delta int64) {
+	return func(delta int64) {
+		w.timer.Reset(archiveUploadStallTimeout)
+		progress(delta)
+	}
+}
+
+// err says the upload stalled, if that's why it failed with err.
+func (w *archiveUploadWatchdog) err(err error) error {
+	if err != nil && w.stalled.Load() {
+		return fmt.Errorf("no progress for %s: %w", archiveUploadStallTimeout, err)
+	}
+	return err
+}
+
+type s3ArchiveDestination struct {
+	dest   keybase1.SimpleFSArchiveDestination
+	bucket string
+	key    string
+}
+
+var _ archiveDestination = (*s3ArchiveDestination)(nil)
+
+func (d *s3ArchiveDestination) String() string {
+	return fmt.Sprintf("s3://%s/%s", d.bucket, d.key)
+}
+
+func (d *s3ArchiveDestination) upload(ctx context.Context, zipPath string,
+	progress func(delta int64)) error {
+	f, err := os.Open(zipPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	config := aws.Config{}
+	if len(d.dest.Region) > 0 {
+		config.Region = aws.String(d.dest.Region)
+	}
+	if len(d.dest.Endpoint) > 0 {
+		config.Endpoint = aws.String(d.dest.Endpoint)
+		// Most S3-compatible services don't do virtual-hosted buckets.
+		config.S3ForcePathStyle = aws.Bool(true)
+	}
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            config,
+		Profile:           d.dest.Profile,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return err
+	}
+	// The uploader reads the zip a part at a time, so progress runs a part
+	// ahead of what's actually been sent. It only reads the next part once
+	// there's room for it, so the watchdog still sees a stall.
+	watchdog, ctx, cancel := newArchiveUploadWatchdog(ctx)
+	defer cancel()
+	_, err = s3manager.NewUploader(sess).UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket:      aws.String(d.bucket),
+		Key:         aws.String(d.key),
+		ContentType: aws.String("application/zip"),
+		Body:        &archiveUploadReader{r: f, progress: watchdog.progress(progress)},
+	})
+	return watchdog.err(err)
+}
+
+// archiveWebDAVClient has timeouts for connecting, unlike
+// http.DefaultClient; uploads stalling after that are up to the watchdog.
+var archiveWebDAVClient = &http.Client{
+	Transport: &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSHandshakeTimeout:   30 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	},
+}
+
+type webDAVArchiveDestination struct {
+	dest keybase1.SimpleFSArchiveDestination
+	url  *url.URL
+}
+
+var _ archiveDestination = (*webDAVArchiveDestination)(nil)
+
+func (d *webDAVArchiveDestination) String() string {
+	return d.url.Redacted()
+}
+
+func (d *webDAVArchiveDestination) upload(ctx context.Context, zipPath string,
+	progress func(delta int64)) error {
+	f, err := os.Open(zipPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	watchdog, ctx, cancel := newArchiveUploadWatchdog(ctx)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, d.url.String(),
+		&archiveUploadReader{r: f, progress: watchdog.progress(progress)})
+	if err != nil {
+		return err
+	}
+	req.ContentLength = fi.Size()
+	req.Header.Set("Content-Type", "application/zip")
+	if len(d.dest.Username) > 0 {
+		req.SetBasicAuth(d.dest.Username, d.dest.Password)
+	}
+	resp, err := archiveWebDAVClient.Do(req)
+	if err != nil {
+		return watchdog.err(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("PUT %s: %s", d, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package simplefs
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+	"golang.org/x/net/context"
+)
+
+// Besides checking for free space, a job reserves the space it needs with
+// the service once it's been indexed, so it doesn't race chat archives or
+// other jobs for it. What's reserved shrinks as the job copies and zips.
+// diskReservationWorker keeps the reservations of jobs that are under way
+// up to date, which also keeps the service from expiring them, and
+// releases them once the jobs are done, failed, paused or gone.
+
+// archiveDiskReservationInterval is how often diskReservationWorker
+// updates the reservations, besides when a job changes phase. It has to be
+// well under libkb.DiskSpaceReservationTTL.
+const archiveDiskReservationInterval = 10 * time.Minute
+
+// archiveDiskSpaceNeeds returns the space a job still needs: stagingBytes
+// in its staging directory and zipBytes next to its zip file.
+func archiveDiskSpaceNeeds(desc keybase1.SimpleFSArchiveJobDesc,
+	stagingBytes, zipBytes int64) []keybase1.DiskSpaceNeed {
+	return []keybase1.DiskSpaceNeed{
+		{Path: desc.StagingPath, Bytes: stagingBytes},
+		{Path: filepath.Dir(desc.ZipFilePath), Bytes: zipBytes},
+	}
+}
+
+// archiveJobHoldsDiskSpace says whether job has space reserved.
+func archiveJobHoldsDiskSpace(job keybase1.SimpleFSArchiveJobState) bool {
+	if job.Paused || job.Desc.JobType == keybase1.SimpleFSArchiveJobType_Restore {
+		return false
+	}
+	switch job.Phase {
+	case keybase1.SimpleFSArchiveJobPhase_Indexed,
+		keybase1.SimpleFSArchiveJobPhase_Copying,
+		keybase1.SimpleFSArchiveJobPhase_Copied,
+		keybase1.SimpleFSArchiveJobPhase_Zipping:
+		return true
+	default:
+		return false
+	}
+}
+
+func (m *archiveManager) reserveDiskSpace(ctx context.Context,
+	desc keybase1.SimpleFSArchiveJobDesc, stagingBytes, zipBytes int64) error {
+	ks := m.simpleFS.config.KeybaseService()
+	if ks == nil {
+		return nil
+	}
+	err := ks.ReserveDiskSpace(ctx, desc.JobID, "KBFS archive "+desc.JobID,
+		archiveDiskSpaceNeeds(desc, stagingBytes, zipBytes), false)
+	if err != nil {
+		return err
+	}
+	m.diskReservationsMu.Lock()
+	defer m.diskReservationsMu.Unlock()
+	m.diskReservations[desc.JobID] = true
+	return nil
+}
+
+// syncDiskReservations updates the reservations of the jobs that are under
+// way to what they have left to write, and releases the rest.
+func (m *archiveManager) syncDiskReservations(ctx context.Context) {
+	ks := m.simpleFS.config.KeybaseService()
+	if ks == nil {
+		return
+	}
+	type remaining struct {
+		desc         keybase1.SimpleFSArchiveJobDesc
+		stagingBytes int64
+		zipBytes     int64
+	}
+	var active []remaining
+	activeIDs := make(map[string]bool)
+	func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		for jobID, job := range m.state.Jobs {
+			if !archiveJobHoldsDiskSpace(job) {
+				continue
+			}
+			r := remaining{
+				desc:     job.Desc,
+				zipBytes: job.BytesTotal - job.BytesZipped,
+			}
+			if job.Phase == keybase1.SimpleFSArchiveJobPhase_Indexed ||
+				job.Phase == keybase1.SimpleFSArchiveJobPhase_Copying {
+				r.stagingBytes = job.BytesTotal - job.BytesCopied
+			}
+			active = append(active, r)
+			activeIDs[jobID] = true
+		}
+	}()
+
+	for _, r := range active {
+		// The job already has its space, so if there's no room for it
+		// now, it's just not updated until there is.
+		err := m.reserveDiskSpace(ctx, r.desc, r.stagingBytes, r.zipBytes)
+		if err != nil {
+			m.simpleFS.log.CDebugf(ctx, "Couldn't update the disk space "+
+				"reservation of job %s: %v", r.desc.JobID, err)
+		}
+	}
+
+	m.diskReservationsMu.Lock()
+	defer m.diskReservationsMu.Unlock()
+	for jobID := range m.diskReservations {
+		if activeIDs[jobID] {
+			continue
+		}
+		err := ks.ReleaseDiskSpace(ctx, jobID)
+		if err != nil {
+			m.simpleFS.log.CDebugf(ctx, "Couldn't release the disk space "+
+				"reserved by job %s: %v", jobID, err)
+			continue
+		}
+		delete(m.diskReservations, jobID)
+	}
+}
+
+func (m *archiveManager) diskReservationWorker(ctx context.Context) {
+	ticker := time.NewTicker(archiveDiskReservationInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-m.diskReservationWorkerSignal:
+		}
+		m.syncDiskReservations(ctx)
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package simplefs
+
+import (
+	"time"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+	"golang.org/x/net/context"
+)
+
+// Before a managed restart, the service has KBFS drain its archive jobs. The
+// workers are interrupted as if every job had been paused, but the jobs
+// aren't marked paused, and nothing is picked up again for a while. Once the
+// workers have let go of the jobs, the state is flushed, so after the
+// restart each job goes back to the phase before the one it was in, like
+// any job that was interrupted, and carries on from there. A copy keeps the
+// files it already staged. If KBFS isn't restarted after all, the jobs are
+// picked up again after archiveDrainHold.
+
+const archiveDrainHold = 2 * time.Minute
+
+func (m *archiveManager) drainingLocked() bool {
+	return time.Now().Before(m.drainedUntil)
+}
+
+func (m *archiveManager) drain(ctx context.Context) (err error) {
+	m.simpleFS.log.CDebugf(ctx, "+ archiveManager.drain")
+	defer m.simpleFS.log.CDebugf(ctx, "- archiveManager.drain err: %v", err)
+	if err := m.waitForState(ctx); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	m.drainedUntil = time.Now().Add(archiveDrainHold)
+	tasks := make([]*archiveJobTask, 0, len(m.jobTasks))
+	for _, task := range m.jobTasks {
+		task.interrupt()
+		tasks = append(tasks, task)
+	}
+	m.mu.Unlock()
+	time.AfterFunc(archiveDrainHold, m.endDrain)
+
+	for _, task := range tasks {
+		select {
+		case <-task.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.state.LastUpdated = keybase1.ToTime(time.Now())
+	return m.flushStateFileLocked(ctx)
+}
+
+// endDrain has the workers pick jobs up again, unless they've been drained
+// again since.
+func (m *archiveManager) endDrain() {
+	m.mu.Lock()
+	draining := m.drainingLocked()
+	m.mu.Unlock()
+	if draining {
+		return
+	}
+	m.signal(m.indexingWorkerSignal)
+	m.signal(m.copyingWorkerSignal)
+	m.signal(m.zippingWorkerSignal)
+}
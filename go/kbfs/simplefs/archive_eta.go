@@ -0,0 +1,117 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package simplefs
+
+import (
+	"time"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// archiveThroughputWeight is how much the latest progress interval counts
+// for in a job's rolling throughput, to keep its ETA from jumping around.
+const archiveThroughputWeight = 0.3
+
+// archiveThroughputStage is one of the stages of a job that goes through its
+// bytes: copying, zipping or uploading.
+type archiveThroughputStage int
+
+const (
+	archiveStageCopying archiveThroughputStage = iota
+	archiveStageZipping
+	archiveStageUploading
+)
+
+func archiveStageThroughput(t *keybase1.SimpleFSArchiveJobThroughput,
+	stage archiveThroughputStage) *float64 {
+	switch stage {
+	case archiveStageCopying:
+		return &t.Copying
+	case archiveStageZipping:
+		return &t.Zipping
+	default:
+		return &t.Uploading
+	}
+}
+
+// archiveUpdateThroughput folds bytes done over elapsed into the job's
+// rolling throughput for stage.
+func archiveUpdateThroughput(job *keybase1.SimpleFSArchiveJobState,
+	stage archiveThroughputStage, bytes int64, elapsed time.Duration) {
+	if elapsed <= 0 || bytes < 0 {
+		return
+	}
+	rate := float64(bytes) / elapsed.Seconds()
+	throughput := archiveStageThroughput(&job.Throughput, stage)
+	if *throughput > 0 {
+		rate = archiveThroughputWeight*rate +
+			(1-archiveThroughputWeight)*(*throughput)
+	}
+	*throughput = rate
+}
+
+// archiveStagesRemaining returns how many bytes each stage the job still has
+// to go through has left, starting with the one it's in.
+func archiveStagesRemaining(job keybase1.SimpleFSArchiveJobState) (
+	stages []archiveThroughputStage, remaining []int64) {
+	add := func(stage archiveThroughputStage, bytes int64) {
+		if bytes < 0 {
+			bytes = 0
+		}
+		stages = append(stages, stage)
+		remaining = append(remaining, bytes)
+	}
+	// The zip is taken to be about the size of what's in it, since most of
+	// what's worth archiving is already compressed.
+	uploads := job.Desc.JobType != keybase1.SimpleFSArchiveJobType_Restore &&
+		job.Desc.Destination.Type != keybase1.SimpleFSArchiveDestinationType_Local
+	switch job.Phase {
+	case keybase1.SimpleFSArchiveJobPhase_Copying:
+		add(archiveStageCopying, job.BytesTotal-job.BytesCopied)
+		if job.Desc.JobType == keybase1.SimpleFSArchiveJobType_Restore {
+			return stages, remaining
+		}
+		add(archiveStageZipping, job.BytesTotal)
+		if uploads {
+			add(archiveStageUploading, job.BytesTotal)
+		}
+	case keybase1.SimpleFSArchiveJobPhase_Zipping:
+		if !job.Zipped {
+			add(archiveStageZipping, job.BytesTotal-job.BytesZipped)
+			if uploads {
+				add(archiveStageUploading, job.BytesTotal)
+			}
+		} else if uploads {
+			add(archiveStageUploading, job.BytesTotal-job.BytesUploaded)
+		}
+	}
+	return stages, remaining
+}
+
+// archiveEstimatedCompletion returns when the job should be done, going by
+// its throughput, or zero if it can't tell. Stages the job hasn't been
+// through yet are guessed to go as fast as the one it's in.
+func archiveEstimatedCompletion(
+	job keybase1.SimpleFSArchiveJobState, now time.Time) keybase1.Time {
+	if job.Paused || job.ErrorState != nil {
+		return 0
+	}
+	stages, remaining := archiveStagesRemaining(job)
+	if len(stages) == 0 {
+		return 0
+	}
+	current := *archiveStageThroughput(&job.Throughput, stages[0])
+	if current <= 0 {
+		return 0
+	}
+	var secs float64
+	for i, stage := range stages {
+		rate := *archiveStageThroughput(&job.Throughput, stage)
+		if i > 0 && rate <= 0 {
+			rate = current
+		}
+		secs += float64(remaining[i]) / rate
+	}
+	return keybase1.ToTime(now.Add(time.Duration(secs * float64(time.Second))))
+}
@@ -0,0 +1,147 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package simplefs
+
+import (
+	"archive/zip"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// Each entry's Unix permission bits are kept in the job's manifest, and
+// written to the Unix attributes of its zip entry from there, rather than
+// from the staged copy: the staging path might be on a file system that
+// has no exec bit, and hard-linked copies share a mode anyway. KBFS itself
+// only knows whether a file is executable, and has no extended attributes,
+// so nothing is lost archiving out of it. Restoring into it is another
+// matter, and what a restore can't keep is recorded on each entry, for the
+// job's fidelity report.
+
+// archiveMacOSMetadataDir is where macOS puts the extended attributes and
+// resource forks of zipped files, as AppleDouble files named after them.
+const archiveMacOSMetadataDir = "__MACOSX"
+
+// Zip creator systems whose external attributes hold Unix permission bits.
+const (
+	archiveZipCreatorUnix  = 3
+	archiveZipCreatorMacOS = 19
+)
+
+// archiveUnixMode returns the Unix permission bits of mode, e.g. 0755.
+func archiveUnixMode(mode os.FileMode) int {
+	unixMode := int(mode.Perm())
+	if mode&os.ModeSetuid != 0 {
+		unixMode |= 04000
+	}
+	if mode&os.ModeSetgid != 0 {
+		unixMode |= 02000
+	}
+	if mode&os.ModeSticky != 0 {
+		unixMode |= 01000
+	}
+	return unixMode
+}
+
+// archiveFileMode is the reverse of archiveUnixMode.
+func archiveFileMode(unixMode int) os.FileMode {
+	mode := os.FileMode(unixMode) & os.ModePerm
+	if unixMode&04000 != 0 {
+		mode |= os.ModeSetuid
+	}
+	if unixMode&02000 != 0 {
+		mode |= os.ModeSetgid
+	}
+	if unixMode&01000 != 0 {
+		mode |= os.ModeSticky
+	}
+	return mode
+}
+
+// archiveKBFSMode returns the permission bits KBFS gives an entry of
+// direntType. Who can write to it depends on the TLF, not the entry.
+func archiveKBFSMode(direntType keybase1.DirentType) int {
+	switch direntType {
+	case keybase1.DirentType_SYM:
+		return 0777
+	case keybase1.DirentType_DIR, keybase1.DirentType_EXEC:
+		return 0755
+	default:
+		return 0644
+	}
+}
+
+// archiveRestoreMode returns the Unix permission bits of a zip entry, or 0
+// if the zip was made somewhere without them.
+func archiveRestoreMode(zf *zip.File) int {
+	switch zf.CreatorVersion >> 8 {
+	case archiveZipCreatorUnix, archiveZipCreatorMacOS:
+		return archiveUnixMode(zf.Mode())
+	default:
+		return 0
+	}
+}
+
+// archiveRestoreModeLoss says how restoring an entry of direntType with the
+// permission bits mode into KBFS changes them, if it does.
+func archiveRestoreModeLoss(direntType keybase1.DirentType, mode int) string {
+	if mode == 0 || direntType == keybase1.DirentType_SYM {
+		return ""
+	}
+	if kbfsMode := archiveKBFSMode(direntType); mode != kbfsMode {
+		return fmt.Sprintf("permission bits %04o restored as %04o", mode, kbfsMode)
+	}
+	return ""
+}
+
+// archiveMacOSMetadataFor returns the file in the target directory that the
+// AppleDouble file name in the zip has the extended attributes of, if it's
+// one.
+func archiveMacOSMetadataFor(name string, targetName string) (string, bool) {
+	prefix := archiveMacOSMetadataDir + "/" + targetName + "/"
+	if !strings.HasPrefix(name, prefix) {
+		return "", false
+	}
+	dir, file := path.Split(strings.TrimPrefix(name, prefix))
+	if !strings.HasPrefix(file, "._") {
+		return "", false
+	}
+	return dir + strings.TrimPrefix(file, "._"), true
+}
+
+// addArchiveFidelityLoss adds loss to what's recorded about entry.
+func addArchiveFidelityLoss(
+	entry keybase1.SimpleFSArchiveFile, loss string) keybase1.SimpleFSArchiveFile {
+	switch {
+	case len(loss) == 0:
+	case len(entry.FidelityLoss) == 0:
+		entry.FidelityLoss = loss
+	default:
+		entry.FidelityLoss += "; " + loss
+	}
+	return entry
+}
+
+// archiveFidelityLosses returns the entries of manifest that couldn't be
+// kept as they were, sorted by path.
+func archiveFidelityLosses(manifest map[string]keybase1.SimpleFSArchiveFile) (
+	losses []keybase1.SimpleFSArchiveFidelityLoss) {
+	for entryPathWithinJob, entry := range manifest {
+		if len(entry.FidelityLoss) == 0 {
+			continue
+		}
+		losses = append(losses, keybase1.SimpleFSArchiveFidelityLoss{
+			Path: entryPathWithinJob,
+			Loss: entry.FidelityLoss,
+		})
+	}
+	sort.Slice(losses, func(i, j int) bool {
+		return losses[i].Path < losses[j].Path
+	})
+	return losses
+}
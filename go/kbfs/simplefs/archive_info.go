@@ -0,0 +1,160 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package simplefs
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// Every archive's zip has an archive-info.json at its root, saying what was
+// archived, as of when, and by whom, so a zip found years later still
+// explains itself without the job's state.
+
+const (
+	archiveInfoJSONName = "archive-info.json"
+	// Bump this when archive-info.json changes in a way older readers
+	// would get wrong.
+	archiveInfoFormatVersion = 1
+)
+
+type archiveInfoJSONSource struct {
+	Path     string `json:"path"`
+	TlfType  string `json:"tlfType"`
+	TlfName  string `json:"tlfName"`
+	Revision int64  `json:"revision"`
+}
+
+type archiveInfoJSON struct {
+	FormatVersion int    `json:"formatVersion"`
+	Username      string `json:"username,omitempty"`
+	// Copied from the job, for whoever opens the file to see first.
+	Label string `json:"label,omitempty"`
+	Notes string `json:"notes,omitempty"`
+	// When the revisions were pinned, in RFC 3339.
+	ArchivedTime string                          `json:"archivedTime"`
+	Sources      []archiveInfoJSONSource         `json:"sources"`
+	BytesTotal   int64                           `json:"bytesTotal"`
+	Job          keybase1.SimpleFSArchiveJobDesc `json:"job"`
+}
+
+// archiveInfoJobDesc returns desc without anything that shouldn't be left
+// lying around in a zip.
+func archiveInfoJobDesc(
+	desc keybase1.SimpleFSArchiveJobDesc) keybase1.SimpleFSArchiveJobDesc {
+	desc = desc.DeepCopy()
+	desc.Destination.Password = ""
+	return desc
+}
+
+func newArchiveInfoJSON(desc keybase1.SimpleFSArchiveJobDesc,
+	username string, bytesTotal int64) (info archiveInfoJSON, err error) {
+	info = archiveInfoJSON{
+		FormatVersion: archiveInfoFormatVersion,
+		Username:      username,
+		Label:         desc.Label,
+		Notes:         desc.Notes,
+		ArchivedTime:  desc.StartTime.Time().UTC().Format(time.RFC3339),
+		BytesTotal:    bytesTotal,
+		Job:           archiveInfoJobDesc(desc),
+	}
+	for _, source := range archiveJobSources(desc) {
+		t, tlfName, _, _, err := remoteTlfAndPath(
+			keybase1.NewPathWithKbfsArchived(source.Path))
+		if err != nil {
+			return archiveInfoJSON{}, err
+		}
+		infoSource := archiveInfoJSONSource{
+			Path:    source.Path.Path,
+			TlfType: t.PathString(),
+			TlfName: tlfName,
+		}
+		typ, err := source.Path.ArchivedParam.KBFSArchivedType()
+		if err == nil && typ == keybase1.KBFSArchivedType_REVISION {
+			infoSource.Revision = int64(source.Path.ArchivedParam.Revision())
+		}
+		info.Sources = append(info.Sources, infoSource)
+	}
+	return info, nil
+}
+
+// writeArchiveInfo writes archive-info.json next to the target directory,
+// so it ends up at the root of the zip.
+func writeArchiveInfo(desc keybase1.SimpleFSArchiveJobDesc, username string,
+	bytesTotal int64) error {
+	info, err := newArchiveInfoJSON(desc, username, bytesTotal)
+	if err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return err
+	}
+	workspaceDir := getWorkspaceDir(desc)
+	err = os.MkdirAll(workspaceDir, 0755)
+	if err != nil {
+		return fmt.Errorf("os.MkdirAll(%s) error: %v", workspaceDir, err)
+	}
+	infoPath := filepath.Join(workspaceDir, archiveInfoJSONName)
+	err = os.WriteFile(infoPath, append(content, '\n'), 0644)
+	if err != nil {
+		return fmt.Errorf("os.WriteFile(%s) error: %v", infoPath, err)
+	}
+	return nil
+}
+
+// readArchiveInfo reads archive-info.json from the zip at zipFilePath.
+func readArchiveInfo(zipFilePath string) (
+	info keybase1.SimpleFSArchiveInfo, err error) {
+	zr, err := zip.OpenReader(zipFilePath)
+	if err != nil {
+		return info, fmt.Errorf("zip.OpenReader(%s) error: %v", zipFilePath, err)
+	}
+	defer zr.Close()
+	for _, zf := range zr.File {
+		if zf.Name != archiveInfoJSONName {
+			continue
+		}
+		rc, err := zf.Open()
+		if err != nil {
+			return info, err
+		}
+		defer rc.Close()
+		var infoJSON archiveInfoJSON
+		err = json.NewDecoder(rc).Decode(&infoJSON)
+		if err != nil {
+			return info, fmt.Errorf("decoding %s error: %v", archiveInfoJSONName, err)
+		}
+		archivedTime, err := time.Parse(time.RFC3339, infoJSON.ArchivedTime)
+		if err != nil {
+			return info, fmt.Errorf("bad archivedTime %q: %v",
+				infoJSON.ArchivedTime, err)
+		}
+		info = keybase1.SimpleFSArchiveInfo{
+			FormatVersion: infoJSON.FormatVersion,
+			Username:      infoJSON.Username,
+			ArchivedTime:  keybase1.ToTime(archivedTime),
+			BytesTotal:    infoJSON.BytesTotal,
+			Desc:          infoJSON.Job,
+		}
+		for _, source := range infoJSON.Sources {
+			info.Sources = append(info.Sources, keybase1.SimpleFSArchiveInfoSource{
+				Path:     source.Path,
+				TlfType:  source.TlfType,
+				TlfName:  source.TlfName,
+				Revision: keybase1.KBFSRevision(source.Revision),
+			})
+		}
+		return info, nil
+	}
+	return info, fmt.Errorf("%s has no %s; it was made before archives had "+
+		"one, or it's not the last volume of a split archive",
+		zipFilePath, archiveInfoJSONName)
+}
@@ -0,0 +1,111 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package simplefs
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+	"golang.org/x/net/context"
+)
+
+// A job's label and notes are for the user to tell jobs apart once they
+// have a lot of them, like one per team, or to say why a snapshot was
+// taken. They can be changed at any time; the zip's archive-info.json has
+// the ones the job had when its files were done copying. Searching for
+// jobs looks at them along with the paths the jobs are about.
+
+const (
+	archiveMaxLabelLen = 100
+	archiveMaxNotesLen = 4096
+)
+
+func checkArchiveLabel(label, notes string) error {
+	if utf8.RuneCountInString(label) > archiveMaxLabelLen {
+		return fmt.Errorf("label is longer than %d characters", archiveMaxLabelLen)
+	}
+	if strings.ContainsAny(label, "\r\n") {
+		return errors.New("label must be one line")
+	}
+	if len(notes) > archiveMaxNotesLen {
+		return fmt.Errorf("notes are longer than %d bytes", archiveMaxNotesLen)
+	}
+	return nil
+}
+
+func (m *archiveManager) setLabel(ctx context.Context,
+	jobID, label, notes string) error {
+	m.simpleFS.log.CDebugf(ctx, "+ archiveManager.setLabel %s", jobID)
+	defer m.simpleFS.log.CDebugf(ctx, "- archiveManager.setLabel")
+
+	if err := checkArchiveLabel(label, notes); err != nil {
+		return err
+	}
+
+	if err := m.waitForState(ctx); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.state.Jobs[jobID]
+	if !ok {
+		return errors.New("job not found")
+	}
+	job.Desc.Label = label
+	job.Desc.Notes = notes
+	m.state.Jobs[jobID] = job
+	m.state.LastUpdated = keybase1.ToTime(time.Now())
+	return m.flushStateFileLocked(ctx)
+}
+
+// archiveJobSearchText is everything about desc that a search looks at,
+// lower-cased.
+func archiveJobSearchText(desc keybase1.SimpleFSArchiveJobDesc) string {
+	fields := []string{
+		desc.Label,
+		desc.Notes,
+		desc.JobID,
+		desc.KbfsPathWithRevision.Path,
+		desc.ZipFilePath,
+	}
+	for _, source := range desc.Sources {
+		fields = append(fields, source.Path.Path)
+	}
+	return strings.ToLower(strings.Join(fields, "\n"))
+}
+
+// archiveJobMatches returns whether every word of query is in desc's
+// search text.
+func archiveJobMatches(desc keybase1.SimpleFSArchiveJobDesc, query string) bool {
+	text := archiveJobSearchText(desc)
+	for _, word := range strings.Fields(strings.ToLower(query)) {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// searchArchiveJobs returns the jobs matching query, newest first.
+func searchArchiveJobs(jobs map[string]keybase1.SimpleFSArchiveJobStatus,
+	query string) []keybase1.SimpleFSArchiveJobStatus {
+	res := make([]keybase1.SimpleFSArchiveJobStatus, 0, len(jobs))
+	for _, job := range jobs {
+		if archiveJobMatches(job.Desc, query) {
+			res = append(res, job)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Desc.StartTime != res[j].Desc.StartTime {
+			return res[i].Desc.StartTime > res[j].Desc.StartTime
+		}
+		return res[i].Desc.JobID < res[j].Desc.JobID
+	})
+	return res
+}
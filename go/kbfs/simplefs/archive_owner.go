@@ -0,0 +1,84 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package simplefs
+
+import (
+	"fmt"
+
+	"github.com/keybase/client/go/kbfs/idutil"
+	"github.com/keybase/client/go/kbfs/tlf"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// The archive state and staging files belong to whoever is logged in when
+// the archive manager starts, and the state is encrypted with a key derived
+// from their device key. Going by the session rather than the configured
+// username means oneshot logins, which don't write their username to the
+// config, get state of their own too. Logged out, public folders can still
+// be archived: those jobs are kept apart, under archiveLoggedOutOwnerName,
+// and their state isn't encrypted, since there's no device key to use and
+// nothing in a public folder is secret.
+
+// archiveLoggedOutOwnerName owns the archive state while no one is logged
+// in. Usernames can't have hyphens, so it can't be anyone's.
+const archiveLoggedOutOwnerName = "logged-out"
+
+type archiveOwner struct {
+	// The logged-in user's name, or archiveLoggedOutOwnerName.
+	name     string
+	loggedIn bool
+}
+
+// getArchiveOwner returns who the archive state belongs to right now.
+func (k *SimpleFS) getArchiveOwner(ctx context.Context) (archiveOwner, error) {
+	kbpki, err := k.getKBPKI(ctx)
+	if err != nil {
+		return archiveOwner{}, err
+	}
+	session, err := idutil.GetCurrentSessionIfPossible(ctx, kbpki, true)
+	if err != nil {
+		return archiveOwner{}, err
+	}
+	if session.UID.IsNil() {
+		return archiveOwner{name: archiveLoggedOutOwnerName}, nil
+	}
+	return archiveOwner{name: session.Name.String(), loggedIn: true}, nil
+}
+
+// getOwner returns who the loaded state belongs to. Staging directories
+// are named after them too.
+func (m *archiveManager) getOwner(ctx context.Context) (archiveOwner, error) {
+	if err := m.waitForState(ctx); err != nil {
+		return archiveOwner{}, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.owner, nil
+}
+
+// checkLoggedOutArchiveJob checks that job can run without anyone logged
+// in: it only reads public folders, and it keeps its zip locally, since
+// there's no key to keep a remote destination's credentials encrypted with.
+func checkLoggedOutArchiveJob(job keybase1.SimpleFSArchiveJobDesc) error {
+	if job.JobType == keybase1.SimpleFSArchiveJobType_Restore {
+		return errors.New("restoring an archive needs a logged-in user")
+	}
+	if job.Destination.Type != keybase1.SimpleFSArchiveDestinationType_Local {
+		return errors.New("archiving to a remote destination needs a logged-in user")
+	}
+	for _, source := range archiveJobSources(job) {
+		t, _, _, _, err := remoteTlfAndPath(
+			keybase1.NewPathWithKbfsArchived(source.Path))
+		if err != nil {
+			return err
+		}
+		if t != tlf.Public {
+			return fmt.Errorf("%s isn't in a public folder; only public "+
+				"folders can be archived while logged out", source.Path.Path)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package simplefs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/keybase/client/go/kbfs/tlfhandle"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"golang.org/x/net/context"
+)
+
+// archiveProgressInterval is the least time between two progress
+// notifications for the same job.
+const archiveProgressInterval = time.Second
+
+// archiveProgressNotifier sends progress notifications while a job is
+// copying, zipping or uploading, so the GUI doesn't have to poll for them.
+// Bytes are added as they're done, and sent as a delta at most once every
+// archiveProgressInterval.
+type archiveProgressNotifier struct {
+	m     *archiveManager
+	jobID string
+	phase keybase1.SimpleFSArchiveJobPhase
+	// uploading is set when the bytes are uploaded ones, which happens in
+	// the Zipping phase.
+	uploading bool
+
+	mu       sync.Mutex
+	lastSent time.Time
+	pending  int64
+	// Since when the bytes in pending were done, for the job's throughput.
+	pendingSince time.Time
+}
+
+func (m *archiveManager) newProgressNotifier(jobID string,
+	phase keybase1.SimpleFSArchiveJobPhase) *archiveProgressNotifier {
+	return &archiveProgressNotifier{
+		m:            m,
+		jobID:        jobID,
+		phase:        phase,
+		pendingSince: time.Now(),
+	}
+}
+
+func (m *archiveManager) newUploadProgressNotifier(jobID string) *archiveProgressNotifier {
+	n := m.newProgressNotifier(jobID, keybase1.SimpleFSArchiveJobPhase_Zipping)
+	n.uploading = true
+	return n
+}
+
+// add records delta more bytes done, and sends a notification if it's been
+// long enough since the last one.
+func (n *archiveProgressNotifier) add(ctx context.Context, delta int64) {
+	n.mu.Lock()
+	n.pending += delta
+	now := time.Now()
+	if now.Sub(n.lastSent) < archiveProgressInterval {
+		n.mu.Unlock()
+		return
+	}
+	delta, n.pending = n.pending, 0
+	elapsed := now.Sub(n.pendingSince)
+	n.lastSent, n.pendingSince = now, now
+	n.mu.Unlock()
+	n.send(ctx, delta, elapsed)
+}
+
+// flush sends any bytes that haven't been notified about yet. It should be
+// called when the phase is over, so the last notification is up to date.
+func (n *archiveProgressNotifier) flush(ctx context.Context) {
+	n.mu.Lock()
+	delta := n.pending
+	n.pending = 0
+	n.lastSent = time.Now()
+	n.pendingSince = n.lastSent
+	n.mu.Unlock()
+	if delta != 0 {
+		// The tail end of a phase is too short to say much about its
+		// throughput.
+		n.send(ctx, delta, 0)
+	}
+}
+
+func (n *archiveProgressNotifier) stage() archiveThroughputStage {
+	switch {
+	case n.uploading:
+		return archiveStageUploading
+	case n.phase == keybase1.SimpleFSArchiveJobPhase_Zipping:
+		return archiveStageZipping
+	default:
+		return archiveStageCopying
+	}
+}
+
+// send notifies about delta more bytes done. If elapsed is set, the bytes
+// took that long, and the job's throughput and ETA are updated with them.
+func (n *archiveProgressNotifier) send(
+	ctx context.Context, delta int64, elapsed time.Duration) {
+	job := func() keybase1.SimpleFSArchiveJobState {
+		n.m.mu.Lock()
+		defer n.m.mu.Unlock()
+		job, ok := n.m.state.Jobs[n.jobID]
+		if !ok || elapsed <= 0 {
+			return job
+		}
+		// Can override directly since only one worker can work on a given job at a time.
+		archiveUpdateThroughput(&job, n.stage(), delta, elapsed)
+		job.EstimatedCompletion = archiveEstimatedCompletion(job, time.Now())
+		n.m.state.Jobs[n.jobID] = job
+		return job
+	}()
+	progress := keybase1.SimpleFSArchiveProgress{
+		JobID:       n.jobID,
+		Phase:       n.phase,
+		BytesTotal:  job.BytesTotal,
+		BytesCopied: job.BytesCopied,
+		BytesZipped: job.BytesZipped,
+
+		BytesUploaded:       job.BytesUploaded,
+		EstimatedCompletion: job.EstimatedCompletion,
+	}
+	switch n.stage() {
+	case archiveStageUploading:
+		progress.BytesUploadedDelta = delta
+	case archiveStageZipping:
+		progress.BytesZippedDelta = delta
+	default:
+		progress.BytesCopiedDelta = delta
+	}
+	if err := n.m.notifyProgress(ctx, progress); err != nil {
+		n.m.simpleFS.log.CDebugf(ctx,
+			"sending archive progress for job %s error: %v", n.jobID, err)
+	}
+}
+
+// archiveJobNotification returns the notification for a job that's done
+// zipping, or, if err is set, that failed with err and won't go on until the
+// user does something about it. The desktop app shows it as a system
+// notification, so the Files tab doesn't have to be open to find out.
+func archiveJobNotification(jobID string, job keybase1.SimpleFSArchiveJobState,
+	err error) *keybase1.FSNotification {
+	filename := tlfhandle.BuildCanonicalPath(tlfhandle.KeybasePathType,
+		strings.TrimPrefix(job.Desc.KbfsPathWithRevision.Path, "/"))
+	notification := &keybase1.FSNotification{
+		Filename:         filename,
+		NotificationType: keybase1.FSNotificationType_ARCHIVE,
+		Params: map[string]string{
+			"jobID":       jobID,
+			"zipFilePath": job.Desc.ZipFilePath,
+		},
+		LocalTime: keybase1.ToTime(time.Now()),
+	}
+	if err != nil {
+		notification.StatusCode = keybase1.FSStatusCode_ERROR
+		notification.Status = fmt.Sprintf(
+			"Archive of %s failed: %v", filename, err)
+		return notification
+	}
+	notification.StatusCode = keybase1.FSStatusCode_FINISH
+	notification.Status = fmt.Sprintf("Archive of %s complete: %s",
+		filename, humanize.Bytes(uint64(job.BytesTotal)))
+	notification.Params["bytesTotal"] = strconv.FormatInt(job.BytesTotal, 10)
+	return notification
+}
+
+// notifyJobLocked sends the notification for jobID being done, or failing
+// with err.
+func (m *archiveManager) notifyJobLocked(
+	ctx context.Context, jobID string, err error) {
+	job, ok := m.state.Jobs[jobID]
+	if !ok || (err == nil && job.Phase != keybase1.SimpleFSArchiveJobPhase_Done) {
+		// Canceled while it was finishing.
+		return
+	}
+	m.notify(ctx, archiveJobNotification(jobID, job, err))
+	m.signal(m.summaryWorkerSignal)
+}
+
+func (m *archiveManager) notifyJob(
+	ctx context.Context, jobID string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.notifyJobLocked(ctx, jobID, err)
+}
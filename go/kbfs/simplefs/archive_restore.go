@@ -0,0 +1,671 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package simplefs
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/keybase/client/go/kbfs/libcontext"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"gopkg.in/src-d/go-billy.v4"
+)
+
+// Restore jobs go through the same phases as archive jobs: indexing reads
+// the zip and its manifest and checks they agree, copying extracts the zip
+// into KBFS, and then the job is done since there's nothing to zip. The
+// job's manifest is keyed by path within the restored directory, with the
+// sums from manifest.sha256.
+
+// newArchiveRestoreJobDesc builds the description of a job restoring the
+// zip at arg.ZipFilePath into arg.KbfsPath.
+func (k *SimpleFS) newArchiveRestoreJobDesc(ctx context.Context,
+	arg keybase1.SimpleFSArchiveRestoreArg) (
+	jobDesc keybase1.SimpleFSArchiveJobDesc, err error) {
+	if arg.BytesPerSecond < 0 {
+		return keybase1.SimpleFSArchiveJobDesc{},
+			errors.New("bytesPerSecond cannot be negative")
+	}
+	for _, p := range []string{arg.ZipFilePath, arg.ManifestPath} {
+		if len(p) == 0 {
+			continue
+		}
+		if !filepath.IsAbs(p) {
+			return keybase1.SimpleFSArchiveJobDesc{},
+				fmt.Errorf("%s is not an absolute path", p)
+		}
+		if _, err := os.Stat(p); err != nil {
+			return keybase1.SimpleFSArchiveJobDesc{}, err
+		}
+	}
+	if len(arg.ZipFilePath) == 0 {
+		return keybase1.SimpleFSArchiveJobDesc{},
+			errors.New("restoring needs a zip file")
+	}
+	p, err := splitPathFromKbfsPath(keybase1.NewPathWithKbfs(arg.KbfsPath))
+	if err != nil {
+		return keybase1.SimpleFSArchiveJobDesc{}, err
+	}
+	if len(p) < 2 {
+		return keybase1.SimpleFSArchiveJobDesc{},
+			errors.New("restoring needs a path within a TLF")
+	}
+
+	desc := keybase1.SimpleFSArchiveJobDesc{
+		JobType:   keybase1.SimpleFSArchiveJobType_Restore,
+		StartTime: keybase1.ToTime(time.Now()),
+		KbfsPathWithRevision: keybase1.KBFSArchivedPath{
+			Path:             arg.KbfsPath.Path,
+			IdentifyBehavior: arg.KbfsPath.IdentifyBehavior,
+		},
+		ZipFilePath:       arg.ZipFilePath,
+		ManifestPath:      arg.ManifestPath,
+		OverwriteExisting: arg.OverwriteExisting,
+		BytesPerSecond:    arg.BytesPerSecond,
+		Priority:          arg.Priority,
+	}
+	desc.JobID, err = generateArchiveJobID()
+	if err != nil {
+		return keybase1.SimpleFSArchiveJobDesc{}, err
+	}
+	return desc, nil
+}
+
+// parseArchiveManifestSHA256 reads a manifest in sha256sum format, and
+// returns the sums by path.
+func parseArchiveManifestSHA256(r io.Reader) (map[string]string, error) {
+	sums := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if len(text) == 0 {
+			continue
+		}
+		// "<sum>  <path>" for text mode, or "<sum> *<path>" for binary.
+		if len(text) < 67 || (text[64:66] != "  " && text[64:66] != " *") {
+			return nil, fmt.Errorf("manifest line %d is malformed", line)
+		}
+		sum := strings.ToLower(text[:64])
+		if _, err := hex.DecodeString(sum); err != nil {
+			return nil, fmt.Errorf("manifest line %d has a bad sum: %v", line, err)
+		}
+		sums[text[66:]] = sum
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return sums, nil
+}
+
+// readArchiveRestoreManifest returns the sums in the job's manifest file,
+// or in the manifest.sha256 in the zip if it doesn't have one.
+func readArchiveRestoreManifest(desc keybase1.SimpleFSArchiveJobDesc,
+	zr *zip.Reader) (map[string]string, error) {
+	if len(desc.ManifestPath) > 0 {
+		f, err := os.Open(desc.ManifestPath)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return parseArchiveManifestSHA256(f)
+	}
+	for _, zf := range zr.File {
+		if zf.Name != archiveManifestSHA256Name {
+			continue
+		}
+		rc, err := zf.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return parseArchiveManifestSHA256(rc)
+	}
+	return nil, fmt.Errorf("%s has no %s; give the manifest separately",
+		desc.ZipFilePath, archiveManifestSHA256Name)
+}
+
+// archiveRestoreTargetName finds the directory the zip's files are in. The
+// files we add next to it, like the manifests and earlier revisions, are at
+// the top level, and macOS adds the extended attributes of the files next to
+// it too, if the zip was remade there.
+func archiveRestoreTargetName(zr *zip.Reader) (string, error) {
+	targetName := ""
+	for _, zf := range zr.File {
+		i := strings.IndexByte(zf.Name, '/')
+		if i < 0 || zf.Name[:i] == archiveMacOSMetadataDir ||
+			zf.Name[:i] == archiveRevisionsDir {
+			continue
+		}
+		switch {
+		case len(targetName) == 0:
+			targetName = zf.Name[:i]
+		case targetName != zf.Name[:i]:
+			return "", fmt.Errorf(
+				"the zip has more than one top-level directory: %s and %s",
+				targetName, zf.Name[:i])
+		}
+	}
+	if len(targetName) == 0 {
+		return "", errors.New("the zip has no files to restore")
+	}
+	return targetName, nil
+}
+
+// archiveRestoreDirentType works out what a zip entry is from its mode.
+func archiveRestoreDirentType(zf *zip.File) keybase1.DirentType {
+	mode := zf.Mode()
+	switch {
+	case mode&os.ModeSymlink != 0:
+		return keybase1.DirentType_SYM
+	case mode&0100 != 0:
+		return keybase1.DirentType_EXEC
+	default:
+		return keybase1.DirentType_FILE
+	}
+}
+
+// checkArchiveRestorePath makes sure a path from the zip stays within the
+// directory being restored into.
+func checkArchiveRestorePath(p string) error {
+	if path.IsAbs(p) || path.Clean(p) != p || p == ".." ||
+		strings.HasPrefix(p, "../") {
+		return fmt.Errorf("%q in the zip escapes the restore directory", p)
+	}
+	return nil
+}
+
+// isRestoreJob returns whether jobID restores a zip rather than making one.
+func (m *archiveManager) isRestoreJob(jobID string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.state.Jobs[jobID].Desc.JobType ==
+		keybase1.SimpleFSArchiveJobType_Restore
+}
+
+// setJobStateError keeps an error the user has to do something about in the
+// job's state, so it's still shown after a restart.
+func (m *archiveManager) setJobStateError(jobID string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.state.Jobs[jobID]
+	if !ok {
+		return
+	}
+	if err == nil {
+		job.Error = ""
+	} else {
+		job.Error = err.Error()
+	}
+	m.state.Jobs[jobID] = job
+}
+
+func (m *archiveManager) doRestoreIndexing(ctx context.Context, jobID string) (err error) {
+	m.simpleFS.log.CDebugf(ctx, "+ doRestoreIndexing %s", jobID)
+	defer func() { m.simpleFS.log.CDebugf(ctx, "- doRestoreIndexing %s err: %v", jobID, err) }()
+
+	jobDesc := func() keybase1.SimpleFSArchiveJobDesc {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		return m.state.Jobs[jobID].Desc
+	}()
+
+	zr, err := zip.OpenReader(jobDesc.ZipFilePath)
+	if err != nil {
+		return fmt.Errorf("zip.OpenReader(%s) error: %v", jobDesc.ZipFilePath, err)
+	}
+	defer zr.Close()
+
+	// Problems with the zip or the manifest won't go away by retrying.
+	defer func() { m.setJobStateError(jobID, err) }()
+	zr.File, err = archiveStoreFiles(&zr.Reader)
+	if err != nil {
+		return err
+	}
+	sums, err := readArchiveRestoreManifest(jobDesc, &zr.Reader)
+	if err != nil {
+		return err
+	}
+	targetName, err := archiveRestoreTargetName(&zr.Reader)
+	if err != nil {
+		return err
+	}
+
+	prefix := targetName + "/"
+	var bytesTotal int64
+	manifest := make(map[string]keybase1.SimpleFSArchiveFile)
+	var macOSMetadataFor []string
+	for _, zf := range zr.File {
+		if p, ok := archiveMacOSMetadataFor(zf.Name, targetName); ok {
+			macOSMetadataFor = append(macOSMetadataFor, p)
+			continue
+		}
+		entryPathWithinJob := strings.TrimPrefix(zf.Name, prefix)
+		if entryPathWithinJob == zf.Name || len(entryPathWithinJob) == 0 ||
+			strings.HasSuffix(zf.Name, "/") {
+			// Not in the target, or a directory.
+			continue
+		}
+		if err := checkArchiveRestorePath(entryPathWithinJob); err != nil {
+			return err
+		}
+		direntType := archiveRestoreDirentType(zf)
+		sum, ok := sums[zf.Name]
+		if !ok && direntType != keybase1.DirentType_SYM {
+			return fmt.Errorf("%s is in the zip but not in the manifest", zf.Name)
+		}
+		delete(sums, zf.Name)
+		mode := archiveRestoreMode(zf)
+		manifest[entryPathWithinJob] = keybase1.SimpleFSArchiveFile{
+			State:        keybase1.SimpleFSFileArchiveState_ToDo,
+			DirentType:   direntType,
+			Sha256SumHex: sum,
+			Mode:         mode,
+			FidelityLoss: archiveRestoreModeLoss(direntType, mode),
+		}
+		if direntType != keybase1.DirentType_SYM {
+			bytesTotal += int64(zf.UncompressedSize64)
+		}
+	}
+	// KBFS has nowhere to keep extended attributes.
+	for _, p := range macOSMetadataFor {
+		if entry, ok := manifest[p]; ok {
+			manifest[p] = addArchiveFidelityLoss(
+				entry, "extended attributes not restored")
+		}
+	}
+	if len(sums) > 0 {
+		missing := make([]string, 0, len(sums))
+		for p := range sums {
+			missing = append(missing, p)
+		}
+		sort.Strings(missing)
+		return fmt.Errorf("%d file(s) in the manifest are missing from the zip, e.g. %s",
+			len(missing), missing[0])
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	jobCopy, ok := m.state.Jobs[jobID]
+	if !ok {
+		m.simpleFS.log.CWarningf(ctx, "job %s not found. it might have been canceled", jobID)
+		return nil
+	}
+	jobCopy.Desc.TargetName = targetName
+	jobCopy.Manifest = manifest
+	jobCopy.BytesTotal = bytesTotal
+	jobCopy.BytesCopied = 0
+	m.state.Jobs[jobID] = jobCopy
+	return nil
+}
+
+// getArchiveRestoreFS returns the directory being restored into, creating it
+// if needed.
+func (m *archiveManager) getArchiveRestoreFS(ctx context.Context,
+	desc keybase1.SimpleFSArchiveJobDesc) (billy.Filesystem, error) {
+	fs, finalElem, err := m.simpleFS.getFS(ctx, keybase1.NewPathWithKbfs(
+		keybase1.KBFSPath{
+			Path:             desc.KbfsPathWithRevision.Path,
+			IdentifyBehavior: desc.KbfsPathWithRevision.IdentifyBehavior,
+		}))
+	if err != nil {
+		return nil, fmt.Errorf("getFS error: %v", err)
+	}
+	if len(finalElem) == 0 {
+		return fs, nil
+	}
+	err = fs.MkdirAll(finalElem, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("fs.MkdirAll(%s) error: %v", finalElem, err)
+	}
+	return fs.Chroot(finalElem)
+}
+
+// ArchiveRestoreConflictError is returned when a file being restored is
+// already there with different contents, and the job doesn't overwrite.
+type ArchiveRestoreConflictError struct {
+	Path string
+}
+
+// Error implements the error interface for ArchiveRestoreConflictError.
+func (e ArchiveRestoreConflictError) Error() string {
+	return fmt.Sprintf("%s already exists with different contents", e.Path)
+}
+
+// ArchiveRestoreMismatchError is returned when a file in the zip doesn't
+// match its SHA-256 sum in the manifest.
+type ArchiveRestoreMismatchError struct {
+	Path     string
+	Expected string
+	Actual   string
+}
+
+// Error implements the error interface for ArchiveRestoreMismatchError.
+func (e ArchiveRestoreMismatchError) Error() string {
+	return fmt.Sprintf("%s has SHA-256 %s, but the manifest says %s",
+		e.Path, e.Actual, e.Expected)
+}
+
+// archiveRestoreExistingSum returns the SHA-256 sum of the file at p in fs,
+// or nil if there's nothing there.
+func archiveRestoreExistingSum(fs billy.Filesystem, p string) ([]byte, error) {
+	fi, err := fs.Lstat(p)
+	switch {
+	case os.IsNotExist(err):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	case !fi.Mode().IsRegular():
+		// Something else is in the way; it can only be replaced.
+		return []byte{}, nil
+	}
+	f, err := fs.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	teeReader := newSHA256TeeReader(f)
+	_, err = io.Copy(io.Discard, teeReader)
+	if err != nil {
+		return nil, err
+	}
+	return teeReader.getSum(), nil
+}
+
+// restoreArchiveFile extracts one regular file from the zip into fs,
+// checking its sum, and then sets its exec bit and mtime.
+func (m *archiveManager) restoreArchiveFile(ctx context.Context,
+	fs billy.Filesystem, zf *zip.File, entryPathWithinJob string,
+	entry keybase1.SimpleFSArchiveFile, job keybase1.SimpleFSArchiveJobState,
+	bytesCopiedUpdater bytesUpdaterFunc) error {
+	var mode os.FileMode = 0644
+	if entry.DirentType == keybase1.DirentType_EXEC {
+		mode = 0755
+	}
+	err := func() error {
+		rc, err := zf.Open()
+		if err != nil {
+			return fmt.Errorf("opening %s in the zip error: %v", zf.Name, err)
+		}
+		defer rc.Close()
+		dst, err := fs.OpenFile(entryPathWithinJob,
+			os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+		if err != nil {
+			return fmt.Errorf("fs.OpenFile(%s) error: %v", entryPathWithinJob, err)
+		}
+		defer dst.Close()
+		teeReader := newSHA256TeeReader(rc)
+		limiter := m.getThrottle(job.Desc.JobID, job.Desc.BytesPerSecond)
+		err = ctxAwareCopy(ctx, dst, teeReader, limiter, bytesCopiedUpdater)
+		if err != nil {
+			return fmt.Errorf("[%s] io.CopyN error: %v", entryPathWithinJob, err)
+		}
+		if sum := hex.EncodeToString(teeReader.getSum()); sum != entry.Sha256SumHex {
+			return ArchiveRestoreMismatchError{
+				Path:     zf.Name,
+				Expected: entry.Sha256SumHex,
+				Actual:   sum,
+			}
+		}
+		return nil
+	}()
+	if err != nil {
+		if _, ok := err.(ArchiveRestoreMismatchError); ok {
+			// Don't leave a bad copy behind.
+			_ = fs.Remove(entryPathWithinJob)
+		}
+		return err
+	}
+
+	changeFS, ok := fs.(billy.Change)
+	if !ok {
+		return nil
+	}
+	// An existing file keeps its mode when truncated.
+	err = changeFS.Chmod(entryPathWithinJob, mode)
+	if err != nil {
+		return fmt.Errorf("Chmod(%s) error: %v", entryPathWithinJob, err)
+	}
+	if mtime := zf.Modified; !mtime.IsZero() {
+		err = changeFS.Chtimes(entryPathWithinJob, mtime, mtime)
+		if err != nil {
+			return fmt.Errorf("Chtimes(%s) error: %v", entryPathWithinJob, err)
+		}
+	}
+	return nil
+}
+
+// restoreArchiveSymlink recreates a symlink from the zip in fs. It returns
+// whether an identical one was already there.
+func restoreArchiveSymlink(fs billy.Filesystem, zf *zip.File,
+	entryPathWithinJob string, overwrite bool) (unchanged bool, err error) {
+	rc, err := zf.Open()
+	if err != nil {
+		return false, fmt.Errorf("opening %s in the zip error: %v", zf.Name, err)
+	}
+	defer rc.Close()
+	target, err := io.ReadAll(io.LimitReader(rc, 4096))
+	if err != nil {
+		return false, fmt.Errorf("reading %s in the zip error: %v", zf.Name, err)
+	}
+	// Symlinks are checked for escaping when archived, but the zip could
+	// have come from anywhere.
+	if path.IsAbs(string(target)) {
+		return false, fmt.Errorf("%s in the zip links to an absolute path", zf.Name)
+	}
+	if err := checkArchiveRestorePath(
+		path.Join(path.Dir(entryPathWithinJob), string(target))); err != nil {
+		return false, err
+	}
+
+	if _, err := fs.Lstat(entryPathWithinJob); err == nil {
+		if existing, err := fs.Readlink(entryPathWithinJob); err == nil &&
+			existing == string(target) {
+			return true, nil
+		}
+		if !overwrite {
+			return false, ArchiveRestoreConflictError{Path: entryPathWithinJob}
+		}
+		err = fs.Remove(entryPathWithinJob)
+		if err != nil {
+			return false, fmt.Errorf("fs.Remove(%s) error: %v", entryPathWithinJob, err)
+		}
+	}
+	err = fs.Symlink(string(target), entryPathWithinJob)
+	if err != nil {
+		return false, fmt.Errorf("fs.Symlink(%s, %s) error: %v",
+			target, entryPathWithinJob, err)
+	}
+	return false, nil
+}
+
+func (m *archiveManager) doRestoring(ctx context.Context, jobID string) (err error) {
+	m.simpleFS.log.CDebugf(ctx, "+ doRestoring %s", jobID)
+	defer func() { m.simpleFS.log.CDebugf(ctx, "- doRestoring %s err: %v", jobID, err) }()
+	defer m.dropThrottle(jobID)
+
+	job := func() keybase1.SimpleFSArchiveJobState {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		return m.state.Jobs[jobID].DeepCopy()
+	}()
+	desc, manifest := job.Desc, job.Manifest
+
+	zr, err := zip.OpenReader(desc.ZipFilePath)
+	if err != nil {
+		return fmt.Errorf("zip.OpenReader(%s) error: %v", desc.ZipFilePath, err)
+	}
+	defer zr.Close()
+	zr.File, err = archiveStoreFiles(&zr.Reader)
+	if err != nil {
+		return err
+	}
+	prefix := desc.TargetName + "/"
+	zipFiles := make(map[string]*zip.File, len(zr.File))
+	for _, zf := range zr.File {
+		zipFiles[zf.Name] = zf
+	}
+
+	updateEntry := func(entryPathWithinJob string, entry keybase1.SimpleFSArchiveFile) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		// Can override directly since only one worker can work on a give job at a time.
+		job := m.state.Jobs[jobID]
+		job.Manifest[entryPathWithinJob] = entry.DeepCopy()
+		m.state.Jobs[jobID] = job
+	}
+
+	// Files that were in progress are restored from the start again, so
+	// only count what's finished.
+	var bytesCopied int64
+	for entryPathWithinJob, entry := range manifest {
+		if entry.State != keybase1.SimpleFSFileArchiveState_Complete &&
+			entry.State != keybase1.SimpleFSFileArchiveState_Unchanged {
+			continue
+		}
+		if zf, ok := zipFiles[prefix+entryPathWithinJob]; ok &&
+			entry.DirentType != keybase1.DirentType_SYM {
+			bytesCopied += int64(zf.UncompressedSize64)
+		}
+	}
+	func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		job := m.state.Jobs[jobID]
+		job.BytesCopied = bytesCopied
+		m.state.Jobs[jobID] = job
+	}()
+
+	progress := m.newProgressNotifier(jobID, keybase1.SimpleFSArchiveJobPhase_Copying)
+	defer progress.flush(ctx)
+	updateBytesCopied := func(delta int64) {
+		func() {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			// Can override directly since only one worker can work on a give job at a time.
+			job := m.state.Jobs[jobID]
+			job.BytesCopied += delta
+			m.state.Jobs[jobID] = job
+		}()
+		progress.add(ctx, delta)
+	}
+
+	// Syncing the restored files needs a cancellation delayer in ctx.
+	ctx, err = m.simpleFS.startOpWrapContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err := libcontext.CleanupCancellationDelayer(ctx)
+		if err != nil {
+			m.simpleFS.log.CDebugf(ctx, "Error cancelling delayer: %+v", err)
+		}
+	}()
+	fs, err := m.getArchiveRestoreFS(ctx, desc)
+	if err != nil {
+		return err
+	}
+
+	entryPaths := make([]string, 0, len(manifest))
+	for entryPathWithinJob := range manifest {
+		entryPaths = append(entryPaths, entryPathWithinJob)
+	}
+	sort.Strings(entryPaths)
+
+	// Conflicts and bad sums won't go away by retrying.
+	defer func() {
+		switch errors.Cause(err).(type) {
+		case ArchiveRestoreConflictError, ArchiveRestoreMismatchError:
+			m.setJobStateError(jobID, err)
+		case nil:
+			m.setJobStateError(jobID, nil)
+		}
+	}()
+
+loopEntryPaths:
+	for _, entryPathWithinJob := range entryPaths {
+		entry := manifest[entryPathWithinJob]
+		switch entry.State {
+		case keybase1.SimpleFSFileArchiveState_Complete,
+			keybase1.SimpleFSFileArchiveState_Unchanged:
+			continue loopEntryPaths
+		}
+		// We wrote whatever's there if we got interrupted last time.
+		overwrite := desc.OverwriteExisting ||
+			entry.State == keybase1.SimpleFSFileArchiveState_InProgress
+		entry.State = keybase1.SimpleFSFileArchiveState_InProgress
+		updateEntry(entryPathWithinJob, entry)
+
+		zf, ok := zipFiles[prefix+entryPathWithinJob]
+		if !ok {
+			return fmt.Errorf("%s%s is gone from the zip", prefix, entryPathWithinJob)
+		}
+		if dir := path.Dir(entryPathWithinJob); dir != "." {
+			err = fs.MkdirAll(dir, 0755)
+			if err != nil {
+				return fmt.Errorf("fs.MkdirAll(%s) error: %v", dir, err)
+			}
+		}
+
+		if entry.DirentType == keybase1.DirentType_SYM {
+			unchanged, err := restoreArchiveSymlink(fs, zf, entryPathWithinJob, overwrite)
+			if err != nil {
+				return err
+			}
+			entry.State = keybase1.SimpleFSFileArchiveState_Complete
+			if unchanged {
+				entry.State = keybase1.SimpleFSFileArchiveState_Unchanged
+			}
+			updateEntry(entryPathWithinJob, entry)
+			continue loopEntryPaths
+		}
+
+		existingSum, err := archiveRestoreExistingSum(fs, entryPathWithinJob)
+		if err != nil {
+			return fmt.Errorf("checking existing %s error: %v", entryPathWithinJob, err)
+		}
+		switch {
+		case existingSum == nil:
+		case hex.EncodeToString(existingSum) == entry.Sha256SumHex:
+			// Already there, e.g. from restoring the same zip before.
+			updateBytesCopied(int64(zf.UncompressedSize64))
+			entry.State = keybase1.SimpleFSFileArchiveState_Unchanged
+			updateEntry(entryPathWithinJob, entry)
+			continue loopEntryPaths
+		case !overwrite:
+			return ArchiveRestoreConflictError{Path: entryPathWithinJob}
+		case len(existingSum) == 0:
+			err = fs.Remove(entryPathWithinJob)
+			if err != nil {
+				return fmt.Errorf("fs.Remove(%s) error: %v", entryPathWithinJob, err)
+			}
+		}
+
+		err = m.restoreArchiveFile(
+			ctx, fs, zf, entryPathWithinJob, entry, job, updateBytesCopied)
+		if err != nil {
+			return err
+		}
+		entry.State = keybase1.SimpleFSFileArchiveState_Complete
+		updateEntry(entryPathWithinJob, entry)
+	}
+
+	if syncer, ok := fs.(interface{ SyncAll() error }); ok {
+		err = syncer.SyncAll()
+		if err != nil {
+			return fmt.Errorf("SyncAll error: %v", err)
+		}
+	}
+	return nil
+}
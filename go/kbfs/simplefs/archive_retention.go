@@ -0,0 +1,155 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package simplefs
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+	"golang.org/x/net/context"
+)
+
+// Done jobs stay in the state, staging files and all, until they're
+// dismissed. With a retention policy set, retentionWorker dismisses them
+// on its own: once they've been done for longer than doneMaxAgeDays, and
+// the oldest ones beyond maxDoneJobs. A scheduled run is only dismissed
+// once its schedule has recorded its zip, since that's how its outputs get
+// rotated. A dismissed job can't be the base of an incremental job any
+// more.
+
+// archiveRetentionCheckInterval is how often retentionWorker looks for
+// jobs to dismiss, besides when the policy changes.
+const archiveRetentionCheckInterval = time.Hour
+
+func checkArchiveRetention(retention keybase1.SimpleFSArchiveRetention) error {
+	if retention.DoneMaxAgeDays < 0 {
+		return errors.New("doneMaxAgeDays cannot be negative")
+	}
+	if retention.MaxDoneJobs < 0 {
+		return errors.New("maxDoneJobs cannot be negative")
+	}
+	return nil
+}
+
+// archiveJobRetainable returns whether the retention policy applies to job
+// yet.
+func archiveJobRetainable(state *keybase1.SimpleFSArchiveState,
+	job keybase1.SimpleFSArchiveJobState) bool {
+	if job.Phase != keybase1.SimpleFSArchiveJobPhase_Done || job.DoneTime == 0 {
+		return false
+	}
+	if len(job.Desc.ScheduleID) == 0 {
+		return true
+	}
+	schedule, ok := state.Schedules[job.Desc.ScheduleID]
+	if !ok {
+		return true
+	}
+	for _, output := range schedule.Outputs {
+		if output == job.Desc.ZipFilePath {
+			return true
+		}
+	}
+	return false
+}
+
+// archiveJobsPastRetention returns the IDs of the jobs in state that its
+// retention policy says should be dismissed as of now.
+func archiveJobsPastRetention(
+	state *keybase1.SimpleFSArchiveState, now time.Time) (jobIDs []string) {
+	retention := state.Retention
+	if retention.DoneMaxAgeDays == 0 && retention.MaxDoneJobs == 0 {
+		return nil
+	}
+	var done []keybase1.SimpleFSArchiveJobState
+	for _, job := range state.Jobs {
+		if archiveJobRetainable(state, job) {
+			done = append(done, job)
+		}
+	}
+	// Newest first.
+	sort.Slice(done, func(i, j int) bool {
+		if done[i].DoneTime != done[j].DoneTime {
+			return done[i].DoneTime > done[j].DoneTime
+		}
+		return done[i].Desc.JobID < done[j].Desc.JobID
+	})
+	maxAge := time.Duration(retention.DoneMaxAgeDays) * 24 * time.Hour
+	for i, job := range done {
+		tooOld := retention.DoneMaxAgeDays > 0 &&
+			now.Sub(job.DoneTime.Time()) > maxAge
+		tooMany := retention.MaxDoneJobs > 0 && i >= retention.MaxDoneJobs
+		if tooOld || tooMany {
+			jobIDs = append(jobIDs, job.Desc.JobID)
+		}
+	}
+	return jobIDs
+}
+
+// enforceRetentionLocked dismisses the jobs past the retention policy.
+func (m *archiveManager) enforceRetentionLocked(
+	ctx context.Context, now time.Time) (changed bool) {
+	for jobID, job := range m.state.Jobs {
+		if job.Phase == keybase1.SimpleFSArchiveJobPhase_Done && job.DoneTime == 0 {
+			// Done before jobs kept track of when; count from now.
+			job.DoneTime = keybase1.ToTime(now)
+			m.state.Jobs[jobID] = job
+			changed = true
+		}
+	}
+	for _, jobID := range archiveJobsPastRetention(m.state, now) {
+		m.simpleFS.log.CDebugf(ctx, "dismissing job %s past retention", jobID)
+		err := m.cancelOrDismissJobLocked(ctx, jobID)
+		if err != nil {
+			m.simpleFS.log.CWarningf(ctx, "dismissing job %s error: %v", jobID, err)
+			continue
+		}
+		changed = true
+	}
+	return changed
+}
+
+func (m *archiveManager) retentionWorker(ctx context.Context) {
+	ticker := time.NewTicker(archiveRetentionCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-m.retentionWorkerSignal:
+		}
+
+		func() {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			if m.enforceRetentionLocked(ctx, time.Now()) {
+				m.state.LastUpdated = keybase1.ToTime(time.Now())
+				m.markStateDirtyLocked()
+			}
+		}()
+	}
+}
+
+func (m *archiveManager) setRetention(ctx context.Context,
+	retention keybase1.SimpleFSArchiveRetention) error {
+	m.simpleFS.log.CDebugf(ctx, "+ archiveManager.setRetention %#+v", retention)
+	defer m.simpleFS.log.CDebugf(ctx, "- archiveManager.setRetention")
+
+	if err := checkArchiveRetention(retention); err != nil {
+		return err
+	}
+
+	if err := m.waitForState(ctx); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.state.Retention = retention
+	m.state.LastUpdated = keybase1.ToTime(time.Now())
+	m.signal(m.retentionWorkerSignal)
+	return m.flushStateFileLocked(ctx)
+}
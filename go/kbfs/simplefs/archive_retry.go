@@ -0,0 +1,98 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package simplefs
+
+import (
+	"time"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// A job that fails with a retryable error is retried after a delay that
+// doubles with each retry, with some jitter so that jobs that failed
+// together, say when the network went away, don't all retry together. A job
+// that's been retried its maximum number of times without finishing a phase
+// goes into the Failed phase, and is left alone until the user retries it.
+// So does a job that fails with an error retrying won't fix.
+
+const (
+	archiveErrorRetryBaseDelay = time.Minute
+	archiveErrorRetryMaxDelay  = 6 * time.Hour
+	archiveDefaultMaxRetries   = 10
+)
+
+// archiveErrorRetryDelay returns how long to wait before retrying a job
+// that's already been retried retryCount times. jitter, from 0 to 1, takes
+// up to half of the delay off.
+func archiveErrorRetryDelay(retryCount int, jitter float64) time.Duration {
+	delay := archiveErrorRetryBaseDelay
+	for i := 0; i < retryCount && delay < archiveErrorRetryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > archiveErrorRetryMaxDelay {
+		delay = archiveErrorRetryMaxDelay
+	}
+	return delay - time.Duration(jitter*float64(delay)/2)
+}
+
+// archiveJobMaxRetries returns how many times a job is retried before it
+// fails.
+func archiveJobMaxRetries(desc keybase1.SimpleFSArchiveJobDesc) int {
+	if desc.MaxRetries > 0 {
+		return desc.MaxRetries
+	}
+	return archiveDefaultMaxRetries
+}
+
+// failJobLocked puts jobID, which a worker has given up on, in the Failed
+// phase, remembering where a retry should start from.
+func (m *archiveManager) failJobLocked(ctx context.Context, jobID string) {
+	_ = m.resetInterruptedPhaseLocked(ctx, jobID)
+	job, ok := m.state.Jobs[jobID]
+	if !ok {
+		return
+	}
+	job.RetryPhase = job.Phase
+	job.Phase = keybase1.SimpleFSArchiveJobPhase_Failed
+	m.state.Jobs[jobID] = job
+	m.signal(m.diskReservationWorkerSignal)
+}
+
+// retryJob retries jobID right away if it failed, or is waiting to be
+// retried after an error, starting its retry count over.
+func (m *archiveManager) retryJob(ctx context.Context, jobID string) (err error) {
+	m.simpleFS.log.CDebugf(ctx, "+ archiveManager.retryJob %s", jobID)
+	defer m.simpleFS.log.CDebugf(ctx, "- archiveManager.retryJob %s err: %v", jobID, err)
+	if err := m.waitForState(ctx); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.state.Jobs[jobID]
+	if !ok {
+		return errors.New("job not found")
+	}
+	failed := job.Phase == keybase1.SimpleFSArchiveJobPhase_Failed
+	if !failed && job.ErrorState == nil {
+		return errors.New("job hasn't failed")
+	}
+	if failed {
+		job.Phase = job.RetryPhase
+	}
+	job.ErrorState = nil
+	job.RetryCount = 0
+	m.state.Jobs[jobID] = job
+	if !failed {
+		// No worker is running it; it was waiting to be retried.
+		m.resetInterruptedPhaseLocked(ctx, jobID)
+	}
+
+	m.signal(m.indexingWorkerSignal)
+	m.signal(m.copyingWorkerSignal)
+	m.signal(m.zippingWorkerSignal)
+	m.state.LastUpdated = keybase1.ToTime(time.Now())
+	return m.flushStateFileLocked(ctx)
+}
@@ -0,0 +1,260 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package simplefs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/keybase/client/go/kbfs/kbfsmd"
+	"github.com/keybase/client/go/kbfs/libkbfs"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"golang.org/x/time/rate"
+)
+
+// With desc.AllRevisions, an archive job also exports the earlier revisions
+// of each file it copies, so the zip has the file's history and not just
+// its state as of the job's revision. They go under .revisions at the top
+// level of the zip, which mirrors the target directory with a directory for
+// each file holding its revisions, named like r42-20240102T150405Z.txt after
+// the revision and its mtime. A manifest.sha256 in .revisions covers them.
+// Restores leave them alone, and their bytes aren't counted in the job's
+// totals, since how many there are isn't known until the copy gets to them.
+
+const (
+	archiveRevisionsDir        = ".revisions"
+	archiveDefaultMaxRevisions = 10
+	archiveRevisionTimeFormat  = "20060102T150405Z"
+)
+
+func checkArchiveRevisionsArgs(
+	allRevisions bool, maxRevisions int, since keybase1.Time) error {
+	if !allRevisions {
+		if maxRevisions != 0 || since != 0 {
+			return errors.New(
+				"maxRevisions and revisionsSince only apply with allRevisions")
+		}
+		return nil
+	}
+	if maxRevisions < 0 || maxRevisions > maxFileHistoryRevisions {
+		return fmt.Errorf(
+			"maxRevisions must be between 0 and %d", maxFileHistoryRevisions)
+	}
+	return nil
+}
+
+func archiveMaxRevisions(desc keybase1.SimpleFSArchiveJobDesc) int {
+	if desc.MaxRevisions == 0 {
+		return archiveDefaultMaxRevisions
+	}
+	return desc.MaxRevisions
+}
+
+// archiveRevisionZipPath returns where in the zip revision rev of the file
+// at entryPathWithinJob goes. The file's extension is kept so the revision
+// still opens with the right app.
+func archiveRevisionZipPath(desc keybase1.SimpleFSArchiveJobDesc,
+	entryPathWithinJob string, rev kbfsmd.Revision, mtime time.Time) string {
+	name := fmt.Sprintf("r%d-%s%s", rev,
+		mtime.UTC().Format(archiveRevisionTimeFormat), path.Ext(entryPathWithinJob))
+	return path.Join(archiveRevisionsDir, desc.TargetName, entryPathWithinJob, name)
+}
+
+func archiveLocalFileSHA256Hex(localPath string) (string, error) {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("[%s] io.Copy error: %v", localPath, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// earlierRevisions returns the revisions in which the file at filePath
+// changed before the one it has as of param, newest first, up to num of
+// them.
+func (m *archiveManager) earlierRevisions(ctx context.Context,
+	filePath string, param keybase1.KBFSArchivedParam, num int) (
+	[]kbfsmd.Revision, error) {
+	_, _, prs, err := m.simpleFS.getRevisionsFromPath(ctx,
+		keybase1.NewPathWithKbfsArchived(keybase1.KBFSArchivedPath{
+			Path:          filePath,
+			ArchivedParam: param,
+		}))
+	if err != nil {
+		return nil, err
+	}
+	if len(prs) == 0 {
+		return nil, nil
+	}
+	// The first one is the revision the file has as of param.
+	revs, _, err := m.simpleFS.fileHistoryRevisions(ctx, filePath, prs, num+1)
+	if err != nil {
+		return nil, err
+	}
+	return revs[1:], nil
+}
+
+// copyEntryRevisions exports the earlier revisions of the file at filePath
+// into the workspace, and returns their checksums by path within the zip.
+// Revisions already exported before the job was interrupted are just
+// hashed again.
+func (m *archiveManager) copyEntryRevisions(ctx context.Context,
+	desc keybase1.SimpleFSArchiveJobDesc, filePath string,
+	param keybase1.KBFSArchivedParam, entryPathWithinJob string,
+	limiter *rate.Limiter) (sums map[string]string, err error) {
+	revs, err := m.earlierRevisions(ctx, filePath, param, archiveMaxRevisions(desc))
+	if err != nil {
+		return nil, err
+	}
+
+	workspaceDir := getWorkspaceDir(desc)
+	sums = make(map[string]string, len(revs))
+	for _, rev := range revs {
+		srcFS, finalElem, err := m.simpleFS.getFSIfExists(ctx,
+			archivedRevisionPath(filePath, rev))
+		if _, isGC := err.(libkbfs.RevGarbageCollectedError); isGC {
+			// Everything before it is gone too.
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		srcFI, err := srcFS.Lstat(finalElem)
+		if os.IsNotExist(err) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("srcFS.Lstat(%s) error: %v", finalElem, err)
+		}
+		if desc.RevisionsSince != 0 &&
+			srcFI.ModTime().Before(desc.RevisionsSince.Time()) {
+			break
+		}
+		if !srcFI.Mode().IsRegular() {
+			// It was a directory or symlink back then.
+			continue
+		}
+
+		zipPath := archiveRevisionZipPath(desc, entryPathWithinJob, rev, srcFI.ModTime())
+		localPath := filepath.Join(workspaceDir, filepath.FromSlash(zipPath))
+		sum, err := archiveLocalFileSHA256Hex(localPath)
+		if err == nil {
+			sums[zipPath] = sum
+			continue
+		} else if !os.IsNotExist(err) {
+			return nil, err
+		}
+
+		err = os.MkdirAll(filepath.Dir(localPath), 0755)
+		if err != nil {
+			return nil, fmt.Errorf("os.MkdirAll(filepath.Dir(%s)) error: %w", localPath, err)
+		}
+		direntType := keybase1.DirentType_FILE
+		if srcFI.Mode()&0100 != 0 {
+			direntType = keybase1.DirentType_EXEC
+		}
+		// Copy next to it and rename, so a revision that's there is whole.
+		partialPath := localPath + ".partial"
+		sha256Sum, err := m.copyFileFromBeginning(ctx, srcFS, finalElem,
+			partialPath, archiveFileMode(archiveKBFSMode(direntType)), limiter,
+			func(int64) {}, nil)
+		if err != nil {
+			return nil, err
+		}
+		err = os.Chtimes(partialPath, time.Time{}, srcFI.ModTime())
+		if err != nil {
+			return nil, fmt.Errorf("os.Chtimes(%s) error: %v", partialPath, err)
+		}
+		err = os.Rename(partialPath, localPath)
+		if err != nil {
+			return nil, fmt.Errorf("os.Rename(%s) error: %w", partialPath, err)
+		}
+		sums[zipPath] = hex.EncodeToString(sha256Sum)
+	}
+	return sums, nil
+}
+
+// copyRevisions exports the earlier revisions of every file copied into
+// the workspace, and writes their manifest.sha256. Unchanged files of an
+// incremental job are left out, since they have no revisions the base job
+// didn't already see.
+func (m *archiveManager) copyRevisions(ctx context.Context,
+	desc keybase1.SimpleFSArchiveJobDesc,
+	manifest map[string]keybase1.SimpleFSArchiveFile,
+	limiter *rate.Limiter) (err error) {
+	m.simpleFS.log.CDebugf(ctx, "+ copyRevisions %s", desc.JobID)
+	defer func() { m.simpleFS.log.CDebugf(ctx, "- copyRevisions %s err: %v", desc.JobID, err) }()
+
+	sources := make(map[string]keybase1.KBFSArchivedPath)
+	for _, source := range archiveJobSources(desc) {
+		sources[source.Root] = source.Path
+	}
+
+	entryPaths := make([]string, 0, len(manifest))
+	for entryPathWithinJob, entry := range manifest {
+		if entry.State != keybase1.SimpleFSFileArchiveState_Complete {
+			continue
+		}
+		switch entry.DirentType {
+		case keybase1.DirentType_FILE, keybase1.DirentType_EXEC:
+			entryPaths = append(entryPaths, entryPathWithinJob)
+		}
+	}
+	sort.Strings(entryPaths)
+
+	allSums := make(map[string]string)
+	for _, entryPathWithinJob := range entryPaths {
+		root, entryPathWithinSource := archiveEntrySource(desc, entryPathWithinJob)
+		source, ok := sources[root]
+		if !ok {
+			return fmt.Errorf("no source for %s", entryPathWithinJob)
+		}
+		sums, err := m.copyEntryRevisions(ctx, desc,
+			path.Join(source.Path, entryPathWithinSource), source.ArchivedParam,
+			entryPathWithinJob, limiter)
+		if err != nil {
+			if !desc.BestEffort || archiveCopyErrorIsFatal(ctx, err) {
+				return fmt.Errorf("exporting revisions of %s: %w", entryPathWithinJob, err)
+			}
+			m.simpleFS.log.CWarningf(ctx, "skipping revisions of %s due to error: %v",
+				entryPathWithinJob, err)
+			continue
+		}
+		for zipPath, sum := range sums {
+			allSums[zipPath] = sum
+		}
+	}
+	if len(allSums) == 0 {
+		return nil
+	}
+
+	zipPaths := make([]string, 0, len(allSums))
+	for zipPath := range allSums {
+		zipPaths = append(zipPaths, zipPath)
+	}
+	sort.Strings(zipPaths)
+	var b strings.Builder
+	for _, zipPath := range zipPaths {
+		fmt.Fprintf(&b, "%s  %s\n", allSums[zipPath], zipPath)
+	}
+	sumsPath := filepath.Join(getWorkspaceDir(desc), archiveRevisionsDir,
+		archiveManifestSHA256Name)
+	err = os.WriteFile(sumsPath, []byte(b.String()), 0644)
+	if err != nil {
+		return fmt.Errorf("os.WriteFile(%s) error: %v", sumsPath, err)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package simplefs
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"golang.org/x/net/context"
+)
+
+const sftpDialTimeout = 30 * time.Second
+
+type sftpArchiveDestination struct {
+	dest keybase1.SimpleFSArchiveDestination
+	user string
+	addr string
+	path string
+}
+
+var _ archiveDestination = (*sftpArchiveDestination)(nil)
+
+func (d *sftpArchiveDestination) String() string {
+	return fmt.Sprintf("sftp://%s@%s%s", d.user, d.addr, d.path)
+}
+
+func (d *sftpArchiveDestination) clientConfig() (*ssh.ClientConfig, error) {
+	var auth []ssh.AuthMethod
+	if len(d.dest.PrivateKeyPath) > 0 {
+		key, err := os.ReadFile(d.dest.PrivateKeyPath)
+		if err != nil {
+			return nil, err
+		}
+		signer, err := ssh.ParsePrivateKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %v", d.dest.PrivateKeyPath, err)
+		}
+		auth = append(auth, ssh.PublicKeys(signer))
+	}
+	if len(d.dest.Password) > 0 {
+		auth = append(auth, ssh.Password(d.dest.Password))
+	}
+
+	knownHostsPath := d.dest.KnownHostsPath
+	if len(knownHostsPath) == 0 {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		knownHostsPath = filepath.Join(home, ".ssh", "known_hosts")
+	}
+	hostKeyCallback, err := knownhosts.New(knownHostsPath)
+	if err != nil {
+		return nil, err
+	}
+	return &ssh.ClientConfig{
+		User:            d.user,
+		Auth:            auth,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         sftpDialTimeout,
+	}, nil
+}
+
+func (d *sftpArchiveDestination) upload(ctx context.Context, zipPath string,
+	progress func(delta int64)) error {
+	config, err := d.clientConfig()
+	if err != nil {
+		return err
+	}
+	dialer := net.Dialer{Timeout: sftpDialTimeout}
+	netConn, err := dialer.DialContext(ctx, "tcp", d.addr)
+	if err != nil {
+		return err
+	}
+	sshConn, chans, reqs, err := ssh.NewClientConn(netConn, d.addr, config)
+	if err != nil {
+		_ = netConn.Close()
+		return err
+	}
+	client := ssh.NewClient(sshConn, chans, reqs)
+	defer client.Close()
+	// SFTP requests don't take a context, so closing the connection is how
+	// a canceled or stalled upload stops.
+	watchdog, ctx, cancel := newArchiveUploadWatchdog(ctx)
+	defer cancel()
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = client.Close()
+		case <-done:
+		}
+	}()
+
+	sftpClient, err := sftp.NewClient(client, sftp.UseConcurrentWrites(true))
+	if err != nil {
+		return err
+	}
+	defer sftpClient.Close()
+
+	f, err := os.Open(zipPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	remote, err := sftpClient.OpenFile(d.path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
+	if err != nil {
+		return err
+	}
+	// With its size known, the zip is sent with several writes in flight
+	// at once, rather than waiting for each one to be acknowledged.
+	_, err = remote.ReadFrom(&io.LimitedReader{
+		R: &archiveUploadReader{r: f, progress: watchdog.progress(progress)},
+		N: fi.Size(),
+	})
+	if err != nil {
+		_ = remote.Close()
+		return watchdog.err(err)
+	}
+	return watchdog.err(remote.Close())
+}
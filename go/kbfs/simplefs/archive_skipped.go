@@ -0,0 +1,79 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package simplefs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+	"golang.org/x/net/context"
+)
+
+// In best-effort mode, an archive job that can't copy a file or directory
+// skips it, records why in the manifest, and carries on, so one unreadable
+// file doesn't hold up the rest. The files skipped this way are listed in a
+// text file next to the target directory, so the zip says what's missing
+// from it.
+
+// archiveCopyErrorIsFatal says whether err from copying an entry should fail
+// the job even in best-effort mode: the job was canceled or paused, or the
+// staging directory is out of space, which would just fail every entry after
+// this one too.
+func archiveCopyErrorIsFatal(ctx context.Context, err error) bool {
+	return ctx.Err() != nil ||
+		errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, syscall.ENOSPC)
+}
+
+// archiveSkippedFiles returns the entries of manifest that were skipped
+// because of an error, sorted by path.
+func archiveSkippedFiles(manifest map[string]keybase1.SimpleFSArchiveFile) (
+	skipped []keybase1.SimpleFSArchiveSkippedFile) {
+	for entryPathWithinJob, entry := range manifest {
+		if entry.State != keybase1.SimpleFSFileArchiveState_Skipped ||
+			len(entry.Error) == 0 {
+			continue
+		}
+		skipped = append(skipped, keybase1.SimpleFSArchiveSkippedFile{
+			Path:  entryPathWithinJob,
+			Error: entry.Error,
+		})
+	}
+	sort.Slice(skipped, func(i, j int) bool {
+		return skipped[i].Path < skipped[j].Path
+	})
+	return skipped
+}
+
+// writeSkippedFilesList records the entries skipped because of an error,
+// next to the target directory so it ends up in the zip.
+func writeSkippedFilesList(desc keybase1.SimpleFSArchiveJobDesc,
+	skipped []keybase1.SimpleFSArchiveSkippedFile) error {
+	if len(skipped) == 0 {
+		return nil
+	}
+	workspaceDir := getWorkspaceDir(desc)
+	err := os.MkdirAll(workspaceDir, 0755)
+	if err != nil {
+		return fmt.Errorf("os.MkdirAll(%s) error: %v", workspaceDir, err)
+	}
+	var b strings.Builder
+	for _, f := range skipped {
+		fmt.Fprintf(&b, "%s\t%s\n", f.Path, f.Error)
+	}
+	listPath := filepath.Join(workspaceDir,
+		fmt.Sprintf("%s-skipped-errors.txt", desc.TargetName))
+	err = os.WriteFile(listPath, []byte(b.String()), 0644)
+	if err != nil {
+		return fmt.Errorf("os.WriteFile(%s) error: %v", listPath, err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package simplefs
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// A zip can only hold files, directories and symlinks, so other entries a
+// source's filesystem turns up, like fifos, sockets and devices, are either
+// skipped, with why in the manifest, or treated as copy errors, depending
+// on desc.SpecialFiles. Copying files into the workspace leaves holes for
+// blocks of zeros rather than writing them out, so sparse files don't take
+// up their full size in the staging directory.
+
+func checkArchiveSpecialFiles(specialFiles keybase1.SimpleFSArchiveSpecialFiles) error {
+	if _, ok := keybase1.SimpleFSArchiveSpecialFilesRevMap[specialFiles]; !ok {
+		return fmt.Errorf("unknown special files policy %d", specialFiles)
+	}
+	return nil
+}
+
+// archiveSpecialFileKind describes what kind of special file mode is for.
+func archiveSpecialFileKind(mode os.FileMode) string {
+	switch {
+	case mode&os.ModeNamedPipe != 0:
+		return "named pipe"
+	case mode&os.ModeSocket != 0:
+		return "socket"
+	case mode&os.ModeCharDevice != 0:
+		return "character device"
+	case mode&os.ModeDevice != 0:
+		return "device"
+	default:
+		return "irregular file"
+	}
+}
+
+// archiveSparseBlockSize is the size of the blocks of zeros left as holes.
+// It's the usual filesystem block size; holes are only ever whole blocks.
+const archiveSparseBlockSize = 4096
+
+// archiveSparseWriter writes to f from the start, or from where a copy is
+// being picked up, skipping over whole, aligned blocks of zeros instead of
+// writing them. finish must be called once everything is written, to
+// extend the file over a hole at the end.
+//
+// Until then the file can be shorter than what was written to it, which is
+// fine for picking up an interrupted copy, since it goes on from the
+// file's size or from a checkpoint, which finishes the file first.
+type archiveSparseWriter struct {
+	f   *os.File
+	off int64
+}
+
+func newArchiveSparseWriter(f *os.File) *archiveSparseWriter {
+	return &archiveSparseWriter{f: f}
+}
+
+func newArchiveSparseWriterAt(f *os.File, off int64) *archiveSparseWriter {
+	return &archiveSparseWriter{f: f, off: off}
+}
+
+var archiveZeroBlock [archiveSparseBlockSize]byte
+
+func (w *archiveSparseWriter) writeAt(b []byte, off int64) error {
+	if len(b) == 0 {
+		return nil
+	}
+	_, err := w.f.WriteAt(b, off)
+	return err
+}
+
+func (w *archiveSparseWriter) Write(p []byte) (n int, err error) {
+	// Data not written out yet starts at p[start:].
+	start := 0
+	for i := 0; i < len(p); {
+		l := archiveSparseBlockSize - int((w.off+int64(i))%archiveSparseBlockSize)
+		if l > len(p)-i {
+			l = len(p) - i
+		}
+		if l == archiveSparseBlockSize && bytes.Equal(p[i:i+l], archiveZeroBlock[:]) {
+			if err := w.writeAt(p[start:i], w.off+int64(start)); err != nil {
+				return start, err
+			}
+			start = i + l
+		}
+		i += l
+	}
+	if err := w.writeAt(p[start:], w.off+int64(start)); err != nil {
+		return start, err
+	}
+	w.off += int64(len(p))
+	return len(p), nil
+}
+
+func (w *archiveSparseWriter) finish() error {
+	return w.f.Truncate(w.off)
+}
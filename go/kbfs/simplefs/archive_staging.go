@@ -0,0 +1,185 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package simplefs
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+	"golang.org/x/net/context"
+)
+
+// ArchiveStagingCapError is why a job is waiting to start copying: it
+// doesn't fit under the staging cap set with
+// simpleFSArchiveSetMaxStagingBytes, next to the jobs already staged.
+type ArchiveStagingCapError struct {
+	Needed   int64
+	Reserved int64 // By other jobs.
+	Max      int64
+}
+
+// Error implements the error interface for ArchiveStagingCapError.
+func (e ArchiveStagingCapError) Error() string {
+	return fmt.Sprintf(
+		"waiting for staging space: the job needs %d bytes, and other jobs "+
+			"have %d of the %d-byte staging cap", e.Needed, e.Reserved, e.Max)
+}
+
+// archiveJobStagingBytes is how much of the staging cap job counts against.
+// That's all of it from when it starts copying until it's done, since its
+// files can be in its staging path until then. Restore jobs don't stage
+// anything.
+func archiveJobStagingBytes(job keybase1.SimpleFSArchiveJobState) int64 {
+	if job.Desc.JobType == keybase1.SimpleFSArchiveJobType_Restore {
+		return 0
+	}
+	phase := job.Phase
+	if phase == keybase1.SimpleFSArchiveJobPhase_Failed {
+		phase = job.RetryPhase
+	}
+	switch phase {
+	case keybase1.SimpleFSArchiveJobPhase_Copying,
+		keybase1.SimpleFSArchiveJobPhase_Copied,
+		keybase1.SimpleFSArchiveJobPhase_Zipping,
+		keybase1.SimpleFSArchiveJobPhase_Cancelling:
+		return job.BytesTotal
+	case keybase1.SimpleFSArchiveJobPhase_Indexed:
+		if job.BytesCopied > 0 {
+			// Interrupted or paused while copying.
+			return job.BytesTotal
+		}
+	}
+	return 0
+}
+
+// fitsStagingCapLocked says whether the indexed job jobID can start copying
+// without going over the staging cap. If it can't, the job's error says why
+// until it can.
+func (m *archiveManager) fitsStagingCapLocked(jobID string) bool {
+	job := m.state.Jobs[jobID]
+	var err error
+	if max := m.state.MaxStagingBytes; max > 0 &&
+		job.Desc.JobType != keybase1.SimpleFSArchiveJobType_Restore {
+		var reserved int64
+		for id, other := range m.state.Jobs {
+			if id != jobID {
+				reserved += archiveJobStagingBytes(other)
+			}
+		}
+		if reserved+job.BytesTotal > max {
+			err = ArchiveStagingCapError{
+				Needed:   job.BytesTotal,
+				Reserved: reserved,
+				Max:      max,
+			}
+		}
+	}
+
+	errString := ""
+	if err != nil {
+		errString = err.Error()
+	}
+	// Indexing clears the job's error when it's done, so any error an
+	// indexed job has is from here.
+	if job.Error != errString {
+		job.Error = errString
+		m.state.Jobs[jobID] = job
+		m.markStateDirtyLocked()
+	}
+	return err == nil
+}
+
+func (m *archiveManager) setMaxStagingBytes(
+	ctx context.Context, maxStagingBytes int64) error {
+	m.simpleFS.log.CDebugf(ctx, "+ archiveManager.setMaxStagingBytes %d", maxStagingBytes)
+	defer m.simpleFS.log.CDebugf(ctx, "- archiveManager.setMaxStagingBytes")
+
+	if maxStagingBytes < 0 {
+		return errors.New("maxStagingBytes cannot be negative")
+	}
+
+	if err := m.waitForState(ctx); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.state.MaxStagingBytes = maxStagingBytes
+	m.state.LastUpdated = keybase1.ToTime(time.Now())
+	// Waiting jobs might fit now, and the rest should say why they're
+	// waiting.
+	m.signal(m.copyingWorkerSignal)
+	return m.flushStateFileLocked(ctx)
+}
+
+// archiveStagingBytesOnDisk adds up the sizes of the files under
+// stagingPath. A staging path that isn't there yet takes up nothing.
+func archiveStagingBytesOnDisk(stagingPath string) (total int64, err error) {
+	err = filepath.WalkDir(stagingPath,
+		func(p string, d fs.DirEntry, err error) error {
+			switch {
+			case os.IsNotExist(err):
+				// Gone since we listed it, e.g. the job's workspace being
+				// removed after zipping.
+				return nil
+			case err != nil:
+				return err
+			case d.IsDir():
+				return nil
+			}
+			info, err := d.Info()
+			if os.IsNotExist(err) {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			total += info.Size()
+			return nil
+		})
+	return total, err
+}
+
+func (m *archiveManager) getStagingUsage(ctx context.Context) (
+	usage keybase1.SimpleFSArchiveStagingUsage, err error) {
+	m.simpleFS.log.CDebugf(ctx, "+ archiveManager.getStagingUsage")
+	defer m.simpleFS.log.CDebugf(ctx, "- archiveManager.getStagingUsage")
+
+	if err := m.waitForState(ctx); err != nil {
+		return keybase1.SimpleFSArchiveStagingUsage{}, err
+	}
+	func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		usage.MaxStagingBytes = m.state.MaxStagingBytes
+		for jobID, job := range m.state.Jobs {
+			usage.Jobs = append(usage.Jobs, keybase1.SimpleFSArchiveJobStagingUsage{
+				JobID:         jobID,
+				Phase:         job.Phase,
+				StagingPath:   job.Desc.StagingPath,
+				BytesReserved: archiveJobStagingBytes(job),
+			})
+		}
+	}()
+	sort.Slice(usage.Jobs, func(i, j int) bool {
+		return usage.Jobs[i].JobID < usage.Jobs[j].JobID
+	})
+
+	// Walk the staging paths without holding mu, since they can be big.
+	for i, job := range usage.Jobs {
+		usage.Jobs[i].BytesOnDisk, err = archiveStagingBytesOnDisk(job.StagingPath)
+		if err != nil {
+			return keybase1.SimpleFSArchiveStagingUsage{}, fmt.Errorf(
+				"measuring staging path %s of job %s error: %v",
+				job.StagingPath, job.JobID, err)
+		}
+		usage.BytesOnDisk += usage.Jobs[i].BytesOnDisk
+		usage.BytesReserved += job.BytesReserved
+	}
+	return usage, nil
+}
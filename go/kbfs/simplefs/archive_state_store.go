@@ -0,0 +1,200 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package simplefs
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/keybase/client/go/kbfs/ldbutils"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/storage"
+	"golang.org/x/net/context"
+)
+
+const (
+	// archiveStateMetaKey holds everything in the state other than jobs and
+	// schedules, which get a key each.
+	archiveStateMetaKey           = "meta"
+	archiveStateJobKeyPrefix      = "job/"
+	archiveStateScheduleKeyPrefix = "schedule/"
+)
+
+// archiveStateStore keeps the archive state in a leveldb, with a key per job
+// and per schedule. Flushing the state only rewrites the keys that changed,
+// in one atomic batch, so a crash can't leave it half written. Like the
+// state file it replaces, each value is encrypted by the service, unless no
+// one is logged in to encrypt it for.
+type archiveStateStore struct {
+	simpleFS *SimpleFS
+	owner    archiveOwner
+	db       *ldbutils.LevelDb
+	// key -> sum of the plaintext last written under it.
+	written map[string][sha256.Size]byte
+}
+
+func getStateDBPath(simpleFS *SimpleFS, ownerName string) string {
+	cacheDir := simpleFS.getCacheDir()
+	return filepath.Join(cacheDir, fmt.Sprintf("kbfs-archive-%s.ldb", ownerName))
+}
+
+func openArchiveStateStore(simpleFS *SimpleFS, owner archiveOwner) (
+	*archiveStateStore, error) {
+	dbPath := getStateDBPath(simpleFS, owner.name)
+	err := os.MkdirAll(dbPath, 0700)
+	if err != nil {
+		return nil, fmt.Errorf("os.MkdirAll(%s) error: %v", dbPath, err)
+	}
+	stor, err := storage.OpenFile(dbPath, false)
+	if err != nil {
+		return nil, err
+	}
+	db, err := ldbutils.OpenLevelDb(stor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &archiveStateStore{
+		simpleFS: simpleFS,
+		owner:    owner,
+		db:       db,
+		written:  make(map[string][sha256.Size]byte),
+	}, nil
+}
+
+func (s *archiveStateStore) encrypt(ctx context.Context, data []byte) (
+	[]byte, error) {
+	if !s.owner.loggedIn {
+		return data, nil
+	}
+	return s.simpleFS.config.KeybaseService().EncryptArchiveState(ctx, data)
+}
+
+func (s *archiveStateStore) decrypt(ctx context.Context, data []byte) (
+	[]byte, error) {
+	if !s.owner.loggedIn {
+		// The iterator reuses its buffers.
+		return append([]byte(nil), data...), nil
+	}
+	return s.simpleFS.config.KeybaseService().DecryptArchiveState(ctx, data)
+}
+
+func (s *archiveStateStore) close() error {
+	return s.db.Close()
+}
+
+// load reads the whole state. A nil state with a nil error means the store
+// is empty. A job or schedule that can't be decoded is dropped on its own,
+// rather than losing everything else with it.
+func (s *archiveStateStore) load(ctx context.Context) (
+	state *keybase1.SimpleFSArchiveState, err error) {
+	iter := s.db.NewIterator(nil, nil)
+	defer iter.Release()
+	written := make(map[string][sha256.Size]byte)
+	for iter.Next() {
+		if state == nil {
+			state = &keybase1.SimpleFSArchiveState{
+				Jobs:      make(map[string]keybase1.SimpleFSArchiveJobState),
+				Schedules: make(map[string]keybase1.SimpleFSArchiveSchedule),
+			}
+		}
+		key := string(iter.Key())
+		data, err := s.decrypt(ctx, iter.Value())
+		if err != nil {
+			s.simpleFS.log.CErrorf(ctx, "archiveStateStore.load: decrypting %s error: %v", key, err)
+			return nil, archiveStateKeyError{err}
+		}
+		// Anything that fails to decode below isn't put back in the state,
+		// so it's deleted on the next write.
+		written[key] = sha256.Sum256(data)
+		switch {
+		case key == archiveStateMetaKey:
+			var meta keybase1.SimpleFSArchiveState
+			err = decodeArchiveStateValue(bytes.NewReader(data), &meta)
+			state.LastUpdated = meta.LastUpdated
+			state.MaxStagingBytes = meta.MaxStagingBytes
+			state.Retention = meta.Retention
+		case strings.HasPrefix(key, archiveStateJobKeyPrefix):
+			var job keybase1.SimpleFSArchiveJobState
+			err = decodeArchiveStateValue(bytes.NewReader(data), &job)
+			if err == nil {
+				state.Jobs[strings.TrimPrefix(key, archiveStateJobKeyPrefix)] = job
+			}
+		case strings.HasPrefix(key, archiveStateScheduleKeyPrefix):
+			var schedule keybase1.SimpleFSArchiveSchedule
+			err = decodeArchiveStateValue(bytes.NewReader(data), &schedule)
+			if err == nil {
+				state.Schedules[strings.TrimPrefix(key, archiveStateScheduleKeyPrefix)] = schedule
+			}
+		default:
+			// Maybe from a newer version; leave it be.
+			s.simpleFS.log.CWarningf(ctx, "archiveStateStore.load: unknown key %s", key)
+			delete(written, key)
+		}
+		if err != nil {
+			s.simpleFS.log.CErrorf(ctx, "archiveStateStore.load: decoding %s error: %v. Dropping it.", key, err)
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+	s.written = written
+	return state, nil
+}
+
+// write brings the store up to date with state.
+func (s *archiveStateStore) write(ctx context.Context,
+	state *keybase1.SimpleFSArchiveState) error {
+	values := make(map[string]interface{}, 1+len(state.Jobs)+len(state.Schedules))
+	values[archiveStateMetaKey] = keybase1.SimpleFSArchiveState{
+		LastUpdated:     state.LastUpdated,
+		MaxStagingBytes: state.MaxStagingBytes,
+		Retention:       state.Retention,
+	}
+	for jobID, job := range state.Jobs {
+		values[archiveStateJobKeyPrefix+jobID] = job
+	}
+	for scheduleID, schedule := range state.Schedules {
+		values[archiveStateScheduleKeyPrefix+scheduleID] = schedule
+	}
+
+	batch := new(leveldb.Batch)
+	written := make(map[string][sha256.Size]byte, len(values))
+	for key, value := range values {
+		data, err := encodeArchiveStateValue(value)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(data)
+		written[key] = sum
+		if oldSum, ok := s.written[key]; ok && oldSum == sum {
+			continue
+		}
+		encrypted, err := s.encrypt(ctx, data)
+		if err != nil {
+			s.simpleFS.log.CErrorf(ctx, "archiveStateStore.write: encrypting %s error: %v", key, err)
+			return archiveStateKeyError{err}
+		}
+		batch.Put([]byte(key), encrypted)
+	}
+	for key := range s.written {
+		if _, ok := written[key]; !ok {
+			batch.Delete([]byte(key))
+		}
+	}
+	if batch.Len() == 0 {
+		return nil
+	}
+	err := s.db.Write(batch, &opt.WriteOptions{Sync: true})
+	if err != nil {
+		return err
+	}
+	s.written = written
+	return nil
+}
@@ -0,0 +1,281 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package simplefs
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+	"golang.org/x/net/context"
+)
+
+// Zips with the Store layout keep the contents of each distinct file in the
+// job's target directory once, at objects/<sha256>, rather than at the
+// file's path. tree.json maps each of those paths (as they'd be named in a
+// Tree zip) to its contents' sum, mode, mtime and size. Symlinks, and
+// everything outside the target directory (the manifests, earlier revisions
+// and so on), are in the zip as usual.
+//
+// When reading a zip, archiveStoreFiles turns a Store zip's entries back
+// into the ones a Tree zip would have, all reading from the shared objects,
+// so checking and restoring don't need to know about the layout.
+
+const (
+	archiveStoreObjectsDir = "objects"
+	archiveStoreTreeName   = "tree.json"
+	archiveStoreLayoutName = "store"
+)
+
+type archiveStoreTreeEntry struct {
+	SHA256 string    `json:"sha256"`
+	Size   int64     `json:"size"`
+	Mode   uint32    `json:"mode"`
+	Mtime  time.Time `json:"mtime"`
+}
+
+type archiveStoreTree struct {
+	// Always "store", so a tree.json someone else put in a zip isn't taken
+	// for one of ours.
+	Layout string `json:"layout"`
+	// By the name the file would have in a Tree zip.
+	Files map[string]archiveStoreTreeEntry `json:"files"`
+}
+
+func checkArchiveLayout(layout keybase1.SimpleFSArchiveLayout, maxVolumeBytes int64) error {
+	if _, ok := keybase1.SimpleFSArchiveLayoutRevMap[layout]; !ok {
+		return fmt.Errorf("unknown layout %d", layout)
+	}
+	if layout == keybase1.SimpleFSArchiveLayout_Store && maxVolumeBytes > 0 {
+		return errors.New("zips with the store layout can't be split into volumes")
+	}
+	return nil
+}
+
+// archiveStagedSumsFor returns the SHA-256 sums kept in manifest for the
+// files staged under the job's target directory, by their names within the
+// workspace.
+func archiveStagedSumsFor(desc keybase1.SimpleFSArchiveJobDesc,
+	manifest map[string]keybase1.SimpleFSArchiveFile) map[string]string {
+	sums := make(map[string]string)
+	for entryPathWithinJob, entry := range manifest {
+		if entry.State != keybase1.SimpleFSFileArchiveState_Complete ||
+			len(entry.Sha256SumHex) == 0 {
+			continue
+		}
+		sums[path.Join(desc.TargetName, entryPathWithinJob)] = entry.Sha256SumHex
+	}
+	return sums
+}
+
+func archiveFileSHA256(localPath string) (string, error) {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// zipWriterAddStoreObject adds the contents of the file at name, relative to
+// dirPath, to w as the object for sum.
+func zipWriterAddStoreObject(ctx context.Context, w *zip.Writer, dirPath string,
+	name string, sum string, info fs.FileInfo,
+	compression keybase1.SimpleFSArchiveCompression,
+	bytesZippedUpdater bytesUpdaterFunc) error {
+	h := &zip.FileHeader{
+		Name: path.Join(archiveStoreObjectsDir, sum),
+		// By the file's extension, since objects don't have one.
+		Method:             archiveCompressionMethod(name, compression),
+		UncompressedSize64: uint64(info.Size()),
+	}
+	// Objects are shared between files, so their mtimes and modes are only
+	// in the tree.
+	h.SetMode(0644)
+	fw, err := w.CreateHeader(h)
+	if err != nil {
+		return ArchiveEntryFormatError{Path: name, Reason: err.Error()}
+	}
+	f, err := os.Open(filepath.Join(dirPath, filepath.FromSlash(name)))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	defer trackArchiveFile(ctx, f)()
+	err = ctxAwareCopy(ctx, fw, f, nil, bytesZippedUpdater)
+	if err != nil {
+		return fmt.Errorf("zipping %s error: %v", name, err)
+	}
+	return nil
+}
+
+// zipWriterAddDirStore is zipWriterAddDir for the Store layout. The regular
+// files under targetName become objects, using the sums in sums where
+// they're known, and tree.json is added last.
+func zipWriterAddDirStore(ctx context.Context, w *zip.Writer, dirPath string,
+	targetName string, compression keybase1.SimpleFSArchiveCompression,
+	symlinks keybase1.SimpleFSArchiveSymlinks,
+	attrs map[string]archiveStagedAttrs, sums map[string]string,
+	bytesZippedUpdater bytesUpdaterFunc) error {
+	tree := archiveStoreTree{
+		Layout: archiveStoreLayoutName,
+		Files:  make(map[string]archiveStoreTreeEntry),
+	}
+	stored := make(map[string]bool)
+	prefix := targetName + "/"
+	err := fs.WalkDir(os.DirFS(dirPath), ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if archiveLeaveSymlinkOut(d.Type(), symlinks) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if !strings.HasPrefix(name, prefix) || !info.Mode().IsRegular() {
+			return zipWriterAddFile(
+				ctx, w, dirPath, name, info, compression, attrs[name], bytesZippedUpdater)
+		}
+
+		sum, ok := sums[name]
+		if !ok {
+			sum, err = archiveFileSHA256(filepath.Join(dirPath, filepath.FromSlash(name)))
+			if err != nil {
+				return err
+			}
+		}
+		entry := archiveStoreTreeEntry{
+			SHA256: sum,
+			Size:   info.Size(),
+			Mode:   uint32(info.Mode().Perm()),
+			Mtime:  info.ModTime().UTC(),
+		}
+		if a := attrs[name]; a.mode != 0 {
+			entry.Mode = uint32(a.mode.Perm())
+		}
+		if a := attrs[name]; !a.mtime.IsZero() {
+			entry.Mtime = a.mtime.UTC()
+		}
+		tree.Files[name] = entry
+		if stored[sum] {
+			// Already in the zip; count it as zipped.
+			bytesZippedUpdater(info.Size())
+			return nil
+		}
+		stored[sum] = true
+		return zipWriterAddStoreObject(
+			ctx, w, dirPath, name, sum, info, compression, bytesZippedUpdater)
+	})
+	if err != nil {
+		return err
+	}
+
+	content, err := json.MarshalIndent(tree, "", "  ")
+	if err != nil {
+		return err
+	}
+	h := &zip.FileHeader{
+		Name:     archiveStoreTreeName,
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	}
+	h.SetMode(0644)
+	fw, err := w.CreateHeader(h)
+	if err != nil {
+		return err
+	}
+	_, err = fw.Write(append(content, '\n'))
+	return err
+}
+
+func readArchiveStoreTree(zf *zip.File) (tree archiveStoreTree, err error) {
+	rc, err := zf.Open()
+	if err != nil {
+		return tree, err
+	}
+	defer rc.Close()
+	err = json.NewDecoder(rc).Decode(&tree)
+	if err != nil {
+		return tree, fmt.Errorf("decoding %s error: %v", archiveStoreTreeName, err)
+	}
+	return tree, nil
+}
+
+// archiveStoreFiles returns the files in zr. If it's a Store zip, the
+// objects and tree.json are replaced with an entry for each file in the
+// tree, named, dated and with the mode the file would have in a Tree zip,
+// that reads the file's object.
+func archiveStoreFiles(zr *zip.Reader) ([]*zip.File, error) {
+	var treeFile *zip.File
+	for _, zf := range zr.File {
+		if zf.Name == archiveStoreTreeName {
+			treeFile = zf
+			break
+		}
+	}
+	if treeFile == nil {
+		return zr.File, nil
+	}
+	tree, err := readArchiveStoreTree(treeFile)
+	if err != nil {
+		return nil, err
+	}
+	if tree.Layout != archiveStoreLayoutName {
+		return zr.File, nil
+	}
+
+	objectsPrefix := archiveStoreObjectsDir + "/"
+	objects := make(map[string]*zip.File)
+	files := make([]*zip.File, 0, len(zr.File))
+	for _, zf := range zr.File {
+		switch {
+		case zf == treeFile:
+		case strings.HasPrefix(zf.Name, objectsPrefix):
+			objects[strings.TrimPrefix(zf.Name, objectsPrefix)] = zf
+		default:
+			files = append(files, zf)
+		}
+	}
+	names := make([]string, 0, len(tree.Files))
+	for name := range tree.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		entry := tree.Files[name]
+		object, ok := objects[entry.SHA256]
+		if !ok {
+			return nil, fmt.Errorf("%s's contents (%s) are missing from the zip",
+				name, entry.SHA256)
+		}
+		// Only the header differs; reading it reads the object.
+		zf := *object
+		zf.Name = name
+		zf.Modified = entry.Mtime
+		zf.SetMode(os.FileMode(entry.Mode).Perm())
+		files = append(files, &zf)
+	}
+	return files, nil
+}
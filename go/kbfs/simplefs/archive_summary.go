@@ -0,0 +1,267 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package simplefs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// The archive state only lives on the device that ran the jobs, like the
+// zips themselves. So that the user's other devices can list what they've
+// archived, a summary of each finished job is put in the user's kvstore,
+// under archiveSummaryNamespace, keyed by job ID. Summaries are put again
+// whenever a job finishes in a different way than last time, e.g. a failed
+// job that's retried and done, and are left there after the job is
+// dismissed, since the zip usually outlives the job. Restores and jobs
+// made while logged out don't get one.
+
+const archiveSummaryNamespace = "simplefs-archive-jobs-v1"
+
+// archiveSummaryTeamName returns the name of the implicit team with only
+// username in it, whose kvstore the summaries are kept in.
+func archiveSummaryTeamName(username string) string {
+	return username + "," + username
+}
+
+// archiveSummaryRetryInterval is how often summaryWorker tries again to put
+// summaries that couldn't be put, e.g. while offline.
+const archiveSummaryRetryInterval = 10 * time.Minute
+
+// archiveJobNeedsSummary returns whether job's summary should be put.
+func archiveJobNeedsSummary(job keybase1.SimpleFSArchiveJobState) bool {
+	if job.Desc.JobType == keybase1.SimpleFSArchiveJobType_Restore {
+		return false
+	}
+	switch job.Phase {
+	case keybase1.SimpleFSArchiveJobPhase_Done,
+		keybase1.SimpleFSArchiveJobPhase_Failed:
+		return job.SummaryPhase != job.Phase
+	default:
+		return false
+	}
+}
+
+// archiveZipSizeAndSum returns the size of job's zip, and its SHA-256 sum
+// unless it's split into volumes. A zip that isn't on this device (any
+// more) has no size.
+func archiveZipSizeAndSum(
+	job keybase1.SimpleFSArchiveJobState) (size int64, sumHex string, err error) {
+	if len(job.VolumePaths) > 0 {
+		for _, p := range job.VolumePaths {
+			fi, err := os.Stat(p)
+			switch {
+			case os.IsNotExist(err):
+				continue
+			case err != nil:
+				return 0, "", err
+			}
+			size += fi.Size()
+		}
+		return size, "", nil
+	}
+
+	f, err := os.Open(job.Desc.ZipFilePath)
+	switch {
+	case os.IsNotExist(err):
+		return 0, "", nil
+	case err != nil:
+		return 0, "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	size, err = io.Copy(h, f)
+	if err != nil {
+		return 0, "", err
+	}
+	return size, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// newArchiveJobSummary returns the summary of job, made on deviceID and
+// hostname. A failed job doesn't keep track of when it failed, so it ends
+// now.
+func newArchiveJobSummary(job keybase1.SimpleFSArchiveJobState,
+	deviceID keybase1.DeviceID, hostname string,
+	now time.Time) keybase1.SimpleFSArchiveJobSummary {
+	summary := keybase1.SimpleFSArchiveJobSummary{
+		JobID:                job.Desc.JobID,
+		DeviceID:             deviceID,
+		Hostname:             hostname,
+		KbfsPathWithRevision: job.Desc.KbfsPathWithRevision,
+		Label:                job.Desc.Label,
+		Phase:                job.Phase,
+		StartTime:            job.Desc.StartTime,
+		EndTime:              job.DoneTime,
+		ZipFilePath:          job.Desc.ZipFilePath,
+	}
+	if job.Phase == keybase1.SimpleFSArchiveJobPhase_Failed {
+		summary.EndTime = keybase1.ToTime(now)
+		if job.ErrorState != nil {
+			summary.Error = job.ErrorState.Error
+		}
+	}
+	return summary
+}
+
+// kvstoreClient returns a kvstore client, and the team the state's owner
+// keeps their summaries in.
+func (m *archiveManager) kvstoreClient(ctx context.Context) (
+	cli keybase1.KvstoreInterface, teamName string, err error) {
+	owner, err := m.getOwner(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	if !owner.loggedIn {
+		return nil, "", errors.New("job summaries need a logged-in user")
+	}
+	ks := m.simpleFS.config.KeybaseService()
+	if ks == nil {
+		return nil, "", errors.New("no keybase service")
+	}
+	cli = ks.GetKVStoreClient()
+	if cli == nil {
+		return nil, "", errors.New("no kvstore")
+	}
+	return cli, archiveSummaryTeamName(owner.name), nil
+}
+
+// putJobSummary puts the summary of job in the kvstore, and records that
+// it's been put unless the job has moved on since.
+func (m *archiveManager) putJobSummary(
+	ctx context.Context, job keybase1.SimpleFSArchiveJobState) error {
+	cli, teamName, err := m.kvstoreClient(ctx)
+	if err != nil {
+		return err
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		m.simpleFS.log.CDebugf(ctx, "Couldn't get hostname: %+v", err)
+	}
+	summary := newArchiveJobSummary(
+		job, m.simpleFS.config.KbEnv().GetDeviceID(), hostname, time.Now())
+	if job.Phase == keybase1.SimpleFSArchiveJobPhase_Done {
+		summary.ZipSize, summary.ZipSHA256, err = archiveZipSizeAndSum(job)
+		if err != nil {
+			return err
+		}
+	}
+	value, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	_, err = cli.PutKVEntry(ctx, keybase1.PutKVEntryArg{
+		TeamName:   teamName,
+		Namespace:  archiveSummaryNamespace,
+		EntryKey:   job.Desc.JobID,
+		EntryValue: string(value),
+	})
+	if err != nil {
+		return errors.WithMessage(err, "kvstore put error")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	current, ok := m.state.Jobs[job.Desc.JobID]
+	if !ok || current.Phase != job.Phase {
+		// Moved on in the meantime; it'll be put again if it needs to be.
+		return nil
+	}
+	current.SummaryPhase = job.Phase
+	m.state.Jobs[job.Desc.JobID] = current
+	m.markStateDirtyLocked()
+	return nil
+}
+
+func (m *archiveManager) summaryWorker(ctx context.Context) {
+	ticker := time.NewTicker(archiveSummaryRetryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-m.summaryWorkerSignal:
+		}
+
+		var jobs []keybase1.SimpleFSArchiveJobState
+		func() {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			if !m.owner.loggedIn {
+				return
+			}
+			for _, job := range m.state.Jobs {
+				if archiveJobNeedsSummary(job) {
+					jobs = append(jobs, job.DeepCopy())
+				}
+			}
+		}()
+
+		for _, job := range jobs {
+			err := m.putJobSummary(ctx, job)
+			if err != nil {
+				m.simpleFS.log.CDebugf(ctx,
+					"Couldn't put summary of job %s: %+v", job.Desc.JobID, err)
+			}
+		}
+	}
+}
+
+// listAllDevices returns the summaries of the jobs the user has finished
+// on any device, newest first.
+func (m *archiveManager) listAllDevices(ctx context.Context) (
+	summaries []keybase1.SimpleFSArchiveJobSummary, err error) {
+	m.simpleFS.log.CDebugf(ctx, "+ archiveManager.listAllDevices")
+	defer m.simpleFS.log.CDebugf(ctx, "- archiveManager.listAllDevices")
+
+	cli, teamName, err := m.kvstoreClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	list, err := cli.ListKVEntries(ctx, keybase1.ListKVEntriesArg{
+		TeamName:  teamName,
+		Namespace: archiveSummaryNamespace,
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "kvstore list error")
+	}
+	for _, key := range list.EntryKeys {
+		res, err := cli.GetKVEntry(ctx, keybase1.GetKVEntryArg{
+			TeamName:  teamName,
+			Namespace: archiveSummaryNamespace,
+			EntryKey:  key.EntryKey,
+		})
+		if err != nil {
+			return nil, errors.WithMessage(err, "kvstore get error")
+		}
+		if res.EntryValue == nil {
+			// Deleted.
+			continue
+		}
+		var summary keybase1.SimpleFSArchiveJobSummary
+		err = json.Unmarshal([]byte(*res.EntryValue), &summary)
+		if err != nil {
+			m.simpleFS.log.CDebugf(ctx,
+				"Skipping bad summary %s: %+v", key.EntryKey, err)
+			continue
+		}
+		summaries = append(summaries, summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].EndTime != summaries[j].EndTime {
+			return summaries[i].EndTime > summaries[j].EndTime
+		}
+		return summaries[i].JobID < summaries[j].JobID
+	})
+	return summaries, nil
+}
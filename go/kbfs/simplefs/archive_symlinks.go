@@ -0,0 +1,136 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package simplefs
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+	"golang.org/x/net/context"
+	"golang.org/x/time/rate"
+	billy "gopkg.in/src-d/go-billy.v4"
+)
+
+// An archive job keeps symlinks as symlinks, follows them, or leaves them
+// out, according to desc.Symlinks. Following one copies what it points to
+// in its place: a file becomes a copy of the file, and a directory a copy
+// of everything under it, each with its own manifest entry marked with the
+// symlink it came from. Symlinks under a followed directory are followed
+// too, down to archiveMaxSymlinkDepth levels, which is as far as a loop of
+// links gets before what's under it is skipped. The zip only ever has
+// symlinks in it for jobs that keep them.
+
+// archiveMaxSymlinkDepth is how many levels of directories under a
+// followed symlink get copied.
+const archiveMaxSymlinkDepth = 40
+
+func checkArchiveSymlinks(symlinks keybase1.SimpleFSArchiveSymlinks) error {
+	if _, ok := keybase1.SimpleFSArchiveSymlinksRevMap[symlinks]; !ok {
+		return fmt.Errorf("unknown symlinks policy %d", symlinks)
+	}
+	return nil
+}
+
+// archiveLeaveSymlinkOut returns whether a workspace entry of type t stays
+// out of the zip under the symlinks policy. Jobs that don't keep symlinks
+// shouldn't stage any in the first place, so this is just a backstop.
+func archiveLeaveSymlinkOut(
+	t fs.FileMode, symlinks keybase1.SimpleFSArchiveSymlinks) bool {
+	return t&fs.ModeSymlink != 0 &&
+		symlinks != keybase1.SimpleFSArchiveSymlinks_KEEP
+}
+
+// materializeEntry copies what's at entryPathWithinSource in srcDirFS,
+// following symlinks, to localPath, and returns its manifest entry. The
+// entries for what's under a directory go in materialized.
+func (m *archiveManager) materializeEntry(ctx context.Context,
+	srcDirFS billy.Filesystem, linkPathWithinJob string,
+	entryPathWithinJob, entryPathWithinSource string, localPath string,
+	depth int, limiter *rate.Limiter,
+	materialized map[string]keybase1.SimpleFSArchiveFile) (
+	entry keybase1.SimpleFSArchiveFile, err error) {
+	entry.MaterializedFrom = linkPathWithinJob
+	// Stat follows symlinks, and fails for ones that point outside the
+	// srcDirFS.
+	srcFI, err := srcDirFS.Stat(entryPathWithinSource)
+	if err != nil {
+		return entry, fmt.Errorf("srcDirFS.Stat(%s) error: %v", entryPathWithinSource, err)
+	}
+
+	switch {
+	case srcFI.IsDir():
+		err = os.MkdirAll(localPath, 0755)
+		if err != nil {
+			return entry, fmt.Errorf("os.MkdirAll(%s) error: %w", localPath, err)
+		}
+		if depth >= archiveMaxSymlinkDepth {
+			return entry, fmt.Errorf(
+				"%s is more than %d directories under a followed symlink; "+
+					"is there a loop of symlinks?", entryPathWithinJob, archiveMaxSymlinkDepth)
+		}
+		children, err := srcDirFS.ReadDir(entryPathWithinSource)
+		if err != nil {
+			return entry, fmt.Errorf("srcDirFS.ReadDir(%s) error: %v", entryPathWithinSource, err)
+		}
+		for _, child := range children {
+			childPathWithinJob := path.Join(entryPathWithinJob, child.Name())
+			childEntry, err := m.materializeEntry(ctx, srcDirFS, linkPathWithinJob,
+				childPathWithinJob, path.Join(entryPathWithinSource, child.Name()),
+				filepath.Join(localPath, child.Name()), depth+1, limiter, materialized)
+			if err != nil {
+				if archiveCopyErrorIsFatal(ctx, err) {
+					return entry, err
+				}
+				// Skip just this one, like a symlink we keep that
+				// points outside the TLF.
+				m.simpleFS.log.CDebugf(ctx, "skipping %s: %v", childPathWithinJob, err)
+				childEntry.State = keybase1.SimpleFSFileArchiveState_Skipped
+				childEntry.Error = err.Error()
+				childEntry.MaterializedFrom = linkPathWithinJob
+			}
+			materialized[childPathWithinJob] = childEntry
+		}
+		err = os.Chtimes(localPath, time.Time{}, srcFI.ModTime())
+		if err != nil {
+			return entry, fmt.Errorf("os.Chtimes(%s) error: %v", localPath, err)
+		}
+		entry.DirentType = keybase1.DirentType_DIR
+		entry.Mode = archiveKBFSMode(keybase1.DirentType_DIR)
+	case srcFI.Mode().IsRegular():
+		err = os.MkdirAll(filepath.Dir(localPath), 0755)
+		if err != nil {
+			return entry, fmt.Errorf("os.MkdirAll(filepath.Dir(%s)) error: %w", localPath, err)
+		}
+		entry.DirentType = keybase1.DirentType_FILE
+		if srcFI.Mode()&0100 != 0 {
+			entry.DirentType = keybase1.DirentType_EXEC
+		}
+		mode := archiveFileMode(archiveKBFSMode(entry.DirentType))
+		// What's under a followed symlink isn't in the job's totals, since
+		// indexing doesn't look under symlinks.
+		sha256Sum, err := m.copyFileFromBeginning(ctx, srcDirFS,
+			entryPathWithinSource, localPath, mode, limiter, func(int64) {}, nil)
+		if err != nil {
+			return entry, err
+		}
+		err = os.Chtimes(localPath, time.Time{}, srcFI.ModTime())
+		if err != nil {
+			return entry, fmt.Errorf("os.Chtimes(%s) error: %v", localPath, err)
+		}
+		entry.Sha256SumHex = hex.EncodeToString(sha256Sum)
+		entry.Mtime = keybase1.ToTime(srcFI.ModTime())
+		entry.Mode = archiveUnixMode(mode)
+	default:
+		return entry, fmt.Errorf("%s is a %s, which can't be archived",
+			entryPathWithinSource, archiveSpecialFileKind(srcFI.Mode()))
+	}
+	entry.State = keybase1.SimpleFSFileArchiveState_Complete
+	return entry, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package simplefs
+
+import (
+	"errors"
+	"path/filepath"
+	"time"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+	"golang.org/x/net/context"
+)
+
+// A Done job's zip can be verified again later, say after it's been moved to
+// another disk, without copying anything again. It's checked the same way
+// as by checkArchive, but the job keeps when that happened and the files
+// that didn't check out, so they show up in its status. Verifying a moved
+// zip points the job at where it is now.
+
+// archiveMovedZipPaths returns where job's zips are once its zip has been
+// moved to zipFilePath. The volumes of a split zip are expected to have
+// been moved along with it, keeping their names.
+func archiveMovedZipPaths(job keybase1.SimpleFSArchiveJobState, zipFilePath string) []string {
+	if len(job.VolumePaths) == 0 {
+		return []string{zipFilePath}
+	}
+	paths := make([]string, len(job.VolumePaths))
+	for i := range job.VolumePaths {
+		paths[i] = archiveVolumePath(zipFilePath, i+1)
+	}
+	return paths
+}
+
+// archiveVerifyIssues returns the files in result that didn't check out.
+func archiveVerifyIssues(result keybase1.SimpleFSArchiveCheckArchiveResult) (
+	issues []keybase1.SimpleFSArchiveFileCheck) {
+	for _, f := range result.Files {
+		if f.Result != keybase1.SimpleFSArchiveFileCheckResult_Ok {
+			issues = append(issues, f)
+		}
+	}
+	return issues
+}
+
+func (m *archiveManager) verifyJob(ctx context.Context, jobID string, zipFilePath string) (
+	result keybase1.SimpleFSArchiveCheckArchiveResult, err error) {
+	m.simpleFS.log.CDebugf(ctx, "+ archiveManager.verifyJob %s %q", jobID, zipFilePath)
+	defer func() {
+		m.simpleFS.log.CDebugf(ctx, "- archiveManager.verifyJob %s err: %v", jobID, err)
+	}()
+
+	job, err := m.checkableJob(ctx, jobID)
+	if err != nil {
+		return result, err
+	}
+	zipFilePaths := archiveZipPaths(job)
+	moved := len(zipFilePath) > 0 && zipFilePath != job.Desc.ZipFilePath
+	if moved {
+		if !filepath.IsAbs(zipFilePath) {
+			return result, errors.New("the zip's new path must be absolute")
+		}
+		zipFilePaths = archiveMovedZipPaths(job, zipFilePath)
+	}
+	result, err = checkJobZip(ctx, job, zipFilePaths)
+	if err != nil {
+		return result, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.state.Jobs[jobID]
+	if !ok {
+		return result, errors.New("job was dismissed while it was being verified")
+	}
+	if moved {
+		job.Desc.ZipFilePath = zipFilePath
+		if len(job.VolumePaths) > 0 {
+			job.VolumePaths = zipFilePaths
+		}
+		result.Desc = job.Desc
+	}
+	job.VerifiedAt = keybase1.ToTime(time.Now())
+	job.VerifyIssues = archiveVerifyIssues(result)
+	m.state.Jobs[jobID] = job
+	m.state.LastUpdated = keybase1.ToTime(time.Now())
+	return result, m.flushStateFileLocked(ctx)
+}
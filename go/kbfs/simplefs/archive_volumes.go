@@ -0,0 +1,509 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package simplefs
+
+import (
+	"archive/zip"
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+	"golang.org/x/net/context"
+)
+
+// archiveMinVolumeBytes is the smallest volume size a job can ask for. Much
+// smaller and the zip overhead is a good part of each volume.
+const archiveMinVolumeBytes = 1 << 20
+
+const (
+	// archiveVolumeEntryOverhead bounds what a zip entry takes up besides its
+	// name and contents: the local header, data descriptor, and central
+	// directory record, with their zip64 and timestamp extras.
+	archiveVolumeEntryOverhead = 160
+	// archiveVolumeEndOverhead bounds the end of central directory records.
+	archiveVolumeEndOverhead = 128
+)
+
+// ArchiveVolumeTooSmallError is returned when a single file of an archive
+// job doesn't fit in a volume on its own.
+type ArchiveVolumeTooSmallError struct {
+	Path           string
+	Size           int64
+	MaxVolumeBytes int64
+}
+
+func (e ArchiveVolumeTooSmallError) Error() string {
+	return fmt.Sprintf("%s is %d bytes and doesn't fit in a %d-byte volume",
+		e.Path, e.Size, e.MaxVolumeBytes)
+}
+
+// archiveVolumePath returns the path of the given (1-based) volume of the
+// zip at zipFilePath; archive.zip becomes archive.part1.zip, etc.
+func archiveVolumePath(zipFilePath string, volume int) string {
+	return fmt.Sprintf("%s.part%d.zip", strings.TrimSuffix(zipFilePath, ".zip"), volume)
+}
+
+// archivePartsManifestPath returns the path of the parts manifest of the
+// volumes of the zip at zipFilePath; archive.zip's is archive.parts.sha256.
+// It lists the SHA-256 sum of each volume in the format `sha256sum -c`
+// understands, so the whole set can be checked wherever it's copied to.
+func archivePartsManifestPath(zipFilePath string) string {
+	return strings.TrimSuffix(zipFilePath, ".zip") + ".parts.sha256"
+}
+
+// archiveZipPaths returns the zips the job made: its volumes if it was
+// split, or else just its ZipFilePath.
+func archiveZipPaths(job keybase1.SimpleFSArchiveJobState) []string {
+	if len(job.VolumePaths) > 0 {
+		return job.VolumePaths
+	}
+	return []string{job.Desc.ZipFilePath}
+}
+
+// archiveVolumeDestination returns the destination for the given volume.
+// One that names a file gets the same .partN suffix as the local volumes;
+// one that names a directory takes the volume's own name.
+func archiveVolumeDestination(dest keybase1.SimpleFSArchiveDestination,
+	volume int) (keybase1.SimpleFSArchiveDestination, error) {
+	if dest.Type == keybase1.SimpleFSArchiveDestinationType_Local ||
+		len(dest.Url) == 0 {
+		return dest, nil
+	}
+	u, err := url.Parse(dest.Url)
+	if err != nil {
+		return dest, fmt.Errorf("url.Parse(%s) error: %v", dest.Url, err)
+	}
+	if len(u.Path) == 0 || strings.HasSuffix(u.Path, "/") {
+		return dest, nil
+	}
+	u.Path = archiveVolumePath(u.Path, volume)
+	dest.Url = u.String()
+	return dest, nil
+}
+
+// archivePartsManifestDestination is archiveVolumeDestination for the parts
+// manifest.
+func archivePartsManifestDestination(dest keybase1.SimpleFSArchiveDestination) (
+	keybase1.SimpleFSArchiveDestination, error) {
+	if dest.Type == keybase1.SimpleFSArchiveDestinationType_Local ||
+		len(dest.Url) == 0 {
+		return dest, nil
+	}
+	u, err := url.Parse(dest.Url)
+	if err != nil {
+		return dest, fmt.Errorf("url.Parse(%s) error: %v", dest.Url, err)
+	}
+	if len(u.Path) == 0 || strings.HasSuffix(u.Path, "/") {
+		return dest, nil
+	}
+	u.Path = archivePartsManifestPath(u.Path)
+	dest.Url = u.String()
+	return dest, nil
+}
+
+// archiveVolumeEntryBound is an upper bound on the bytes a zip entry takes
+// up. Deflate can grow data it can't compress, but only by a few bytes for
+// each block of it.
+func archiveVolumeEntryBound(name string, size int64) int64 {
+	return size + size/1024 + 64 + 2*int64(len(name)) + archiveVolumeEntryOverhead
+}
+
+// archiveVolumeCentralBound is an upper bound on what a zip entry adds to
+// the central directory.
+func archiveVolumeCentralBound(name string) int64 {
+	return int64(len(name)) + archiveVolumeEntryOverhead
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (n int, err error) {
+	n, err = c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// archiveVolumeWriter writes the volumes of a zip, starting a new one
+// whenever the next file might push the current one over maxBytes.
+type archiveVolumeWriter struct {
+	zipFilePath string
+	maxBytes    int64
+	mode        int
+	compression keybase1.SimpleFSArchiveCompression
+
+	paths []string
+	// sums are the SHA-256 sums of the volumes closed so far.
+	sums []string
+
+	f  *os.File
+	h  hash.Hash
+	cw *countingWriter
+	zw *zip.Writer
+	// centralBytes bounds the central directory of the current volume,
+	// which is only written once it's closed.
+	centralBytes int64
+	entries      int
+}
+
+func (v *archiveVolumeWriter) closeVolume() (err error) {
+	if v.f == nil {
+		return nil
+	}
+	err = v.zw.Close()
+	closeErr := v.f.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		v.sums = append(v.sums, hex.EncodeToString(v.h.Sum(nil)))
+	}
+	v.f, v.h, v.cw, v.zw = nil, nil, nil, nil
+	return err
+}
+
+func (v *archiveVolumeWriter) openVolume() error {
+	volumePath := archiveVolumePath(v.zipFilePath, len(v.paths)+1)
+	f, err := os.OpenFile(volumePath, v.mode, 0666)
+	if err != nil {
+		return fmt.Errorf("os.Create(%s) error: %v", volumePath, err)
+	}
+	v.paths = append(v.paths, volumePath)
+	v.f = f
+	v.h = sha256.New()
+	v.cw = &countingWriter{w: io.MultiWriter(f, v.h)}
+	v.zw = newArchiveZipWriter(v.cw, v.compression)
+	v.centralBytes = 0
+	v.entries = 0
+	return nil
+}
+
+// volumeFor makes room for an entry, and returns the (1-based) volume it
+// goes in.
+func (v *archiveVolumeWriter) volumeFor(name string, size int64) (int, error) {
+	bound := archiveVolumeEntryBound(name, size)
+	if bound+archiveVolumeEndOverhead > v.maxBytes {
+		return 0, ArchiveVolumeTooSmallError{
+			Path:           name,
+			Size:           size,
+			MaxVolumeBytes: v.maxBytes,
+		}
+	}
+	if v.f != nil {
+		if err := v.zw.Flush(); err != nil {
+			return 0, err
+		}
+		if v.entries > 0 && v.cw.n+v.centralBytes+bound+
+			archiveVolumeEndOverhead > v.maxBytes {
+			if err := v.closeVolume(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if v.f == nil {
+		if err := v.openVolume(); err != nil {
+			return 0, err
+		}
+	}
+	v.centralBytes += archiveVolumeCentralBound(name)
+	v.entries++
+	return len(v.paths), nil
+}
+
+// writePartsManifest writes the parts manifest of the closed volumes.
+func (v *archiveVolumeWriter) writePartsManifest() error {
+	var b strings.Builder
+	for i, p := range v.paths {
+		fmt.Fprintf(&b, "%s  %s\n", v.sums[i], filepath.Base(p))
+	}
+	manifestPath := archivePartsManifestPath(v.zipFilePath)
+	err := os.WriteFile(manifestPath, []byte(b.String()), 0644)
+	if err != nil {
+		return fmt.Errorf("os.WriteFile(%s) error: %v", manifestPath, err)
+	}
+	return nil
+}
+
+// setManifestJSONVolumes records the volume of each file in the
+// workspace's manifest.json, if it has one.
+func setManifestJSONVolumes(workspaceDir string, volumes map[string]int) error {
+	jsonPath := filepath.Join(workspaceDir, archiveManifestJSONName)
+	content, err := os.ReadFile(jsonPath)
+	switch {
+	case os.IsNotExist(err):
+		return nil
+	case err != nil:
+		return fmt.Errorf("os.ReadFile(%s) error: %v", jsonPath, err)
+	}
+	var jsonEntries []archiveManifestJSONEntry
+	err = json.Unmarshal(content, &jsonEntries)
+	if err != nil {
+		return fmt.Errorf("json.Unmarshal(%s) error: %v", jsonPath, err)
+	}
+	for i := range jsonEntries {
+		jsonEntries[i].Volume = volumes[jsonEntries[i].Path]
+	}
+	content, err = json.MarshalIndent(jsonEntries, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(jsonPath, append(content, '\n'), 0644)
+	if err != nil {
+		return fmt.Errorf("os.WriteFile(%s) error: %v", jsonPath, err)
+	}
+	return nil
+}
+
+// zipWorkspaceVolumes zips up the job's workspace into volumes of at most
+// MaxVolumeBytes each. The archived files go first, and the manifests and
+// lists we add last, with archive-info.json the very last. The volume of each
+// file is recorded in the job's manifest, and in manifest.json.
+func (m *archiveManager) zipWorkspaceVolumes(ctx context.Context, jobID string,
+	jobDesc keybase1.SimpleFSArchiveJobDesc, attrs map[string]archiveStagedAttrs,
+	updateBytesZipped bytesUpdaterFunc) (err error) {
+	workspaceDir := getWorkspaceDir(jobDesc)
+
+	type workspaceFile struct {
+		name string
+		info fs.FileInfo
+	}
+	var targetFiles, rootFiles []workspaceFile
+	prefix := jobDesc.TargetName + "/"
+	err = fs.WalkDir(os.DirFS(workspaceDir), ".",
+		func(name string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || archiveLeaveSymlinkOut(d.Type(), jobDesc.Symlinks) {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			if strings.HasPrefix(name, prefix) {
+				targetFiles = append(targetFiles, workspaceFile{name, info})
+			} else if name != archiveManifestJSONName && name != archiveInfoJSONName {
+				rootFiles = append(rootFiles, workspaceFile{name, info})
+			}
+			return nil
+		})
+	if err != nil {
+		return fmt.Errorf("walking %s error: %v", workspaceDir, err)
+	}
+
+	mode := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if jobDesc.OverwriteZip {
+		mode = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	v := &archiveVolumeWriter{
+		zipFilePath: jobDesc.ZipFilePath,
+		maxBytes:    jobDesc.MaxVolumeBytes,
+		mode:        mode,
+		compression: jobDesc.Compression,
+	}
+	defer func() {
+		closeErr := v.closeVolume()
+		if err == nil {
+			err = closeErr
+		}
+		var tooSmall ArchiveVolumeTooSmallError
+		if errors.As(err, &tooSmall) {
+			// Retrying won't help; the job needs a bigger volume size.
+			m.setJobStateError(jobID, err)
+		} else {
+			m.setJobStateError(jobID, nil)
+		}
+		if err == nil {
+			return
+		}
+		// Leave nothing half done behind; the retry starts from volume 1.
+		for _, p := range append(v.paths, archivePartsManifestPath(v.zipFilePath)) {
+			if removeErr := os.Remove(p); removeErr != nil && !os.IsNotExist(removeErr) {
+				m.simpleFS.log.CWarningf(ctx, "removing %s error %v", p, removeErr)
+			}
+		}
+	}()
+
+	addFile := func(file workspaceFile) (int, error) {
+		volume, err := v.volumeFor(file.name, file.info.Size())
+		if err != nil {
+			return 0, err
+		}
+		err = zipWriterAddFile(ctx, v.zw, workspaceDir, file.name, file.info,
+			jobDesc.Compression, attrs[file.name], updateBytesZipped)
+		if err != nil {
+			return 0, fmt.Errorf("adding %s to %s error: %w", file.name, v.paths[volume-1], err)
+		}
+		return volume, nil
+	}
+
+	volumes := make(map[string]int, len(targetFiles))
+	for _, file := range targetFiles {
+		volumes[file.name], err = addFile(file)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = setManifestJSONVolumes(workspaceDir, volumes)
+	if err != nil {
+		return err
+	}
+	// archive-info.json goes last, so it's always in the last volume.
+	for _, name := range []string{archiveManifestJSONName, archiveInfoJSONName} {
+		if info, err := os.Lstat(filepath.Join(workspaceDir, name)); err == nil {
+			rootFiles = append(rootFiles, workspaceFile{name, info})
+		}
+	}
+	for _, file := range rootFiles {
+		_, err = addFile(file)
+		if err != nil {
+			return err
+		}
+	}
+	if len(v.paths) == 0 {
+		// An empty job still gets a (single, empty) volume.
+		if err = v.openVolume(); err != nil {
+			return err
+		}
+	}
+	err = v.closeVolume()
+	if err != nil {
+		return err
+	}
+	err = v.writePartsManifest()
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job := m.state.Jobs[jobID]
+	job.VolumePaths = v.paths
+	for name, volume := range volumes {
+		entryPathWithinJob := strings.TrimPrefix(name, prefix)
+		entry, ok := job.Manifest[entryPathWithinJob]
+		if !ok {
+			continue
+		}
+		entry.Volume = volume
+		job.Manifest[entryPathWithinJob] = entry
+	}
+	m.state.Jobs[jobID] = job
+	return nil
+}
+
+// readArchivePartsManifest reads the volume names and sums in the parts
+// manifest at manifestPath.
+func readArchivePartsManifest(manifestPath string) (
+	names []string, sums map[string]string, err error) {
+	f, err := os.Open(manifestPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	sums = make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if len(line) == 0 {
+			continue
+		}
+		sum, name, ok := strings.Cut(line, "  ")
+		if !ok || len(sum) != 2*sha256.Size || len(name) == 0 ||
+			name != filepath.Base(name) {
+			return nil, nil, fmt.Errorf("bad line in %s: %q", manifestPath, line)
+		}
+		if _, ok := sums[name]; !ok {
+			names = append(names, name)
+		}
+		sums[name] = sum
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("reading %s error: %v", manifestPath, err)
+	}
+	if len(names) == 0 {
+		return nil, nil, fmt.Errorf("%s lists no volumes", manifestPath)
+	}
+	return names, sums, nil
+}
+
+// checkArchiveParts checks the volumes listed in the parts manifest at
+// manifestPath, which are in the same directory as it. They're in the
+// result in volume order, followed by any unexpected ones.
+func checkArchiveParts(ctx context.Context, manifestPath string) (
+	result keybase1.SimpleFSArchiveCheckArchiveResult, err error) {
+	names, sums, err := readArchivePartsManifest(manifestPath)
+	if err != nil {
+		return result, err
+	}
+	dir := filepath.Dir(manifestPath)
+
+	for _, name := range names {
+		check := keybase1.SimpleFSArchiveFileCheck{
+			Path:                 name,
+			ExpectedSha256SumHex: sums[name],
+		}
+		check.ActualSha256SumHex, err = archiveLocalFileSHA256Hex(filepath.Join(dir, name))
+		switch {
+		case ctx.Err() != nil:
+			return keybase1.SimpleFSArchiveCheckArchiveResult{}, ctx.Err()
+		case os.IsNotExist(err):
+			check.Result = keybase1.SimpleFSArchiveFileCheckResult_Missing
+		case err != nil:
+			check.Result = keybase1.SimpleFSArchiveFileCheckResult_Unreadable
+			check.Error = err.Error()
+		case check.ActualSha256SumHex == check.ExpectedSha256SumHex:
+			check.Result = keybase1.SimpleFSArchiveFileCheckResult_Ok
+		default:
+			check.Result = keybase1.SimpleFSArchiveFileCheckResult_Mismatch
+		}
+		result.Files = append(result.Files, check)
+	}
+
+	// Volumes of the same zip that the manifest doesn't know about mean
+	// the set got mixed up with another run's.
+	base := strings.TrimSuffix(filepath.Base(manifestPath), ".parts.sha256")
+	others, err := filepath.Glob(filepath.Join(dir, base+".part*.zip"))
+	if err != nil {
+		return keybase1.SimpleFSArchiveCheckArchiveResult{}, err
+	}
+	for _, other := range others {
+		name := filepath.Base(other)
+		if _, ok := sums[name]; ok {
+			continue
+		}
+		result.Files = append(result.Files, keybase1.SimpleFSArchiveFileCheck{
+			Path:   name,
+			Result: keybase1.SimpleFSArchiveFileCheckResult_Unexpected,
+		})
+	}
+
+	for _, f := range result.Files {
+		if f.Result == keybase1.SimpleFSArchiveFileCheckResult_Ok {
+			result.OkCount++
+		} else {
+			result.IssueCount++
+		}
+	}
+	// archive-info.json is always in the last volume.
+	info, err := readArchiveInfo(filepath.Join(dir, names[len(names)-1]))
+	if err == nil {
+		result.Desc = info.Desc
+	}
+	return result, nil
+}
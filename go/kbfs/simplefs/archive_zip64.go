@@ -0,0 +1,78 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package simplefs
+
+import (
+	"fmt"
+)
+
+// Archive zips switch to zip64 records wherever the classic zip format runs
+// out of room: for entries of 4 GiB or more, for entries starting past 4 GiB
+// into the zip, and for zips with more than 65,535 entries. archive/zip
+// writes those on its own as it needs them, so the only entries we can't
+// archive are the ones even zip64 can't describe, which indexing checks for
+// up front rather than failing partway through zipping.
+
+const (
+	// archiveZip32MaxBytes is the largest size or offset the classic zip
+	// format can hold.
+	archiveZip32MaxBytes = 1<<32 - 1
+	// archiveZip32MaxEntries is the most entries the classic zip format can
+	// count.
+	archiveZip32MaxEntries = 1<<16 - 1
+	// archiveZipMaxNameBytes is the longest entry name a zip can hold, with
+	// or without zip64.
+	archiveZipMaxNameBytes = 1<<16 - 1
+)
+
+// ArchiveEntryFormatError is returned when an entry of an archive job can't
+// be represented in a zip.
+type ArchiveEntryFormatError struct {
+	Path   string
+	Reason string
+}
+
+func (e ArchiveEntryFormatError) Error() string {
+	return fmt.Sprintf("%s can't be archived: %s", e.Path, e.Reason)
+}
+
+// archiveZipFormatChecker checks, entry by entry, that the files of a job
+// fit in a zip, and keeps track of whether the zip will need zip64.
+type archiveZipFormatChecker struct {
+	entries    int
+	totalBytes int64
+	maxBytes   int64
+}
+
+// add checks the entry that'll be named zipName in the zip. Only files and
+// symlinks get entries of their own; directories are implied by the names
+// within them.
+func (c *archiveZipFormatChecker) add(zipName string, hasEntry bool,
+	size int64) error {
+	if len(zipName) > archiveZipMaxNameBytes {
+		return ArchiveEntryFormatError{
+			Path: zipName,
+			Reason: fmt.Sprintf("its path is %d bytes, and zips can't hold "+
+				"paths over %d bytes", len(zipName), archiveZipMaxNameBytes),
+		}
+	}
+	if !hasEntry {
+		return nil
+	}
+	c.entries++
+	c.totalBytes += size
+	if size > c.maxBytes {
+		c.maxBytes = size
+	}
+	return nil
+}
+
+// needsZip64 returns whether the zip will have zip64 records, which some
+// older unzip tools can't read. The total is of uncompressed bytes, so this
+// can say yes to a zip that compresses to under 4 GiB.
+func (c *archiveZipFormatChecker) needsZip64() bool {
+	return c.entries > archiveZip32MaxEntries ||
+		c.maxBytes >= archiveZip32MaxBytes ||
+		c.totalBytes >= archiveZip32MaxBytes
+}
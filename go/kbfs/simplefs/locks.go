@@ -0,0 +1,243 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package simplefs
+
+import (
+	stdpath "path"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	billy "gopkg.in/src-d/go-billy.v4"
+)
+
+const (
+	// defaultLockLease is used when the caller doesn't specify a lease.
+	defaultLockLease = 5 * time.Minute
+	// maxLockLease caps how long a single simpleFSLock call can hold a lock
+	// without being renewed, so a crashed client can't wedge a shared file
+	// forever.
+	maxLockLease = time.Hour
+)
+
+// pathLock is an advisory lock held on a KBFS path. The lock itself is
+// implemented by libfs.File.Lock, which takes a lock on the mdserver keyed by
+// the file's path, exactly like what's used for git pushes.
+type pathLock struct {
+	file   billy.File
+	info   keybase1.SimpleFSLockInfo
+	timer  *time.Timer
+	cancel context.CancelFunc
+}
+
+type lockManager struct {
+	k *SimpleFS
+
+	lock      sync.Mutex
+	locks     map[string]*pathLock     // cleaned KBFS path -> lock
+	acquiring map[string]chan struct{} // closed when acquisition finishes
+}
+
+func newLockManager(simpleFS *SimpleFS) *lockManager {
+	return &lockManager{
+		k:         simpleFS,
+		locks:     make(map[string]*pathLock),
+		acquiring: make(map[string]chan struct{}),
+	}
+}
+
+func lockKey(path keybase1.KBFSPath) string {
+	return stdpath.Clean(path.Path)
+}
+
+func clampLockLease(lease keybase1.DurationMsec) time.Duration {
+	d := lease.Duration()
+	switch {
+	case d <= 0:
+		return defaultLockLease
+	case d > maxLockLease:
+		return maxLockLease
+	default:
+		return d
+	}
+}
+
+func (m *lockManager) lockPath(ctx context.Context,
+	path keybase1.KBFSPath, lease keybase1.DurationMsec) (
+	info keybase1.SimpleFSLockInfo, err error) {
+	m.k.log.CDebugf(ctx, "+ lockManager.lockPath %s", path.Path)
+	defer func() { m.k.log.CDebugf(ctx, "- lockManager.lockPath %s err: %v", path.Path, err) }()
+
+	key := lockKey(path)
+	leaseDuration := clampLockLease(lease)
+
+	for {
+		m.lock.Lock()
+		if l, ok := m.locks[key]; ok {
+			if l.timer.Stop() {
+				defer m.lock.Unlock()
+				// Already held by us; just extend the lease.
+				l.timer.Reset(leaseDuration)
+				l.info.LeaseExpiration = keybase1.ToTime(time.Now().Add(leaseDuration))
+				return l.info, nil
+			}
+			// The lease just ran out, and `expire` is waiting on
+			// `m.lock` to release it. Release it here instead, so
+			// `expire` finds it gone, and take the lock again.
+			delete(m.locks, key)
+			m.lock.Unlock()
+			if err := m.release(ctx, l); err != nil {
+				m.k.log.CDebugf(ctx, "releasing expired lock on %s error: %v", key, err)
+			}
+			continue
+		}
+		acquiring, ok := m.acquiring[key]
+		if !ok {
+			break
+		}
+		// Another call on this device is already waiting for this lock.
+		// Wait for it to finish rather than taking it twice, since both
+		// would share the same server-side lock ID.
+		m.lock.Unlock()
+		select {
+		case <-acquiring:
+		case <-ctx.Done():
+			return keybase1.SimpleFSLockInfo{}, ctx.Err()
+		}
+	}
+	acquiring := make(chan struct{})
+	m.acquiring[key] = acquiring
+	m.lock.Unlock()
+	defer func() {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		delete(m.acquiring, key)
+		close(acquiring)
+	}()
+
+	// The FS captures the context it's created with and uses it for all
+	// subsequent lock operations, including the eventual unlock, so it
+	// can't be the RPC context. Cancel it only if the caller gives up while
+	// we're still waiting on the lock.
+	fsCtx, cancel := context.WithCancel(m.k.makeContext(context.Background()))
+	locked := make(chan struct{})
+	defer close(locked)
+	go func() {
+		select {
+		case <-ctx.Done():
+			cancel()
+		case <-locked:
+		}
+	}()
+
+	fs, finalElem, err := m.k.getFSIfExists(fsCtx, keybase1.NewPathWithKbfs(path))
+	if err != nil {
+		cancel()
+		return keybase1.SimpleFSLockInfo{}, err
+	}
+	f, err := fs.Open(finalElem)
+	if err != nil {
+		cancel()
+		return keybase1.SimpleFSLockInfo{}, err
+	}
+	// This blocks while another session holds the lock, since the mdserver
+	// throttles us and the RPC layer keeps retrying.
+	if err = f.Lock(); err != nil {
+		_ = f.Close()
+		cancel()
+		return keybase1.SimpleFSLockInfo{}, err
+	}
+
+	now := time.Now()
+	l := &pathLock{
+		file:   f,
+		cancel: cancel,
+		info: keybase1.SimpleFSLockInfo{
+			Path:            keybase1.KBFSPath{Path: key},
+			AcquireTime:     keybase1.ToTime(now),
+			LeaseExpiration: keybase1.ToTime(now.Add(leaseDuration)),
+		},
+	}
+	l.timer = time.AfterFunc(leaseDuration, func() {
+		m.expire(key, l)
+	})
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.locks[key] = l
+	return l.info, nil
+}
+
+func (m *lockManager) release(ctx context.Context, l *pathLock) error {
+	l.timer.Stop()
+	defer l.cancel()
+	// Close unlocks the file as well, but call Unlock explicitly so the
+	// error isn't lost.
+	if err := l.file.Unlock(); err != nil {
+		m.k.log.CWarningf(ctx, "unlocking %s error: %v", l.info.Path.Path, err)
+		_ = l.file.Close()
+		return err
+	}
+	return l.file.Close()
+}
+
+func (m *lockManager) expire(key string, l *pathLock) {
+	m.lock.Lock()
+	if m.locks[key] != l {
+		// Already released or replaced.
+		m.lock.Unlock()
+		return
+	}
+	delete(m.locks, key)
+	m.lock.Unlock()
+
+	ctx := m.k.makeContext(context.Background())
+	m.k.log.CDebugf(ctx, "lock lease on %s expired", key)
+	_ = m.release(ctx, l)
+}
+
+func (m *lockManager) unlockPath(
+	ctx context.Context, path keybase1.KBFSPath) (err error) {
+	m.k.log.CDebugf(ctx, "+ lockManager.unlockPath %s", path.Path)
+	defer func() { m.k.log.CDebugf(ctx, "- lockManager.unlockPath %s err: %v", path.Path, err) }()
+
+	key := lockKey(path)
+	m.lock.Lock()
+	l, ok := m.locks[key]
+	if !ok {
+		m.lock.Unlock()
+		return errors.Errorf("no lock held on %s", key)
+	}
+	delete(m.locks, key)
+	m.lock.Unlock()
+
+	return m.release(ctx, l)
+}
+
+func (m *lockManager) listLocks() []keybase1.SimpleFSLockInfo {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	infos := make([]keybase1.SimpleFSLockInfo, 0, len(m.locks))
+	for _, l := range m.locks {
+		infos = append(infos, l.info.DeepCopy())
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Path.Path < infos[j].Path.Path
+	})
+	return infos
+}
+
+func (m *lockManager) shutdown(ctx context.Context) {
+	m.lock.Lock()
+	locks := m.locks
+	m.locks = make(map[string]*pathLock)
+	m.lock.Unlock()
+
+	for _, l := range locks {
+		_ = m.release(ctx, l)
+	}
+}
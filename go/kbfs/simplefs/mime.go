@@ -0,0 +1,45 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package simplefs
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+)
+
+// mimeSniffMaxFileSize is the largest file whose contents are sniffed when
+// its extension doesn't give away its MIME type. Sniffing only reads the
+// first 512 bytes, but for a KBFS file even that can mean fetching a block,
+// which isn't worth it for every big file in a listing.
+const mimeSniffMaxFileSize = 1 << 20
+
+// mimeSniffLen is how much of a file http.DetectContentType looks at.
+const mimeSniffLen = 512
+
+// detectMimeType returns the MIME type of the file called name, going by its
+// extension if that's known, or else by sniffing the start of it with open
+// if it's no bigger than mimeSniffMaxFileSize. It returns "" if neither
+// works.
+func detectMimeType(
+	name string, size int64, open func() (io.ReadCloser, error)) string {
+	if t := mime.TypeByExtension(filepath.Ext(name)); t != "" {
+		return t
+	}
+	if size <= 0 || size > mimeSniffMaxFileSize || open == nil {
+		return ""
+	}
+	f, err := open()
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	buf := make([]byte, mimeSniffLen)
+	n, err := io.ReadFull(f, buf)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return ""
+	}
+	return http.DetectContentType(buf[:n])
+}
@@ -0,0 +1,181 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package simplefs
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/keybase/client/go/kbfs/libkbfs"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"golang.org/x/net/context"
+)
+
+// quotaAlertCheckInterval is how often the logged-in user's quota usage is
+// compared against their alert thresholds.
+const quotaAlertCheckInterval = 10 * time.Minute
+
+// quotaAlertToFire returns the threshold to alert on given the current
+// usage, if any, along with the new value to record as the last fired
+// threshold. Only the highest threshold crossed since the last alert fires,
+// so a jump from 50% to 99% sends one alert and not two. Once usage drops
+// back below a threshold, crossing it again alerts again.
+func quotaAlertToFire(thresholds []int, usageBytes, limitBytes int64,
+	lastThreshold int) (fire int, newLastThreshold int) {
+	if limitBytes <= 0 {
+		return 0, lastThreshold
+	}
+	percent := usageBytes * 100 / limitBytes
+	crossed := 0
+	for _, t := range thresholds {
+		if int64(t) <= percent && t > crossed {
+			crossed = t
+		}
+	}
+	if crossed > lastThreshold {
+		return crossed, crossed
+	}
+	return 0, crossed
+}
+
+// cleanQuotaAlertThresholds checks that each threshold is a percentage, and
+// returns them sorted without duplicates.
+func cleanQuotaAlertThresholds(thresholds []int) ([]int, error) {
+	seen := make(map[int]bool, len(thresholds))
+	res := make([]int, 0, len(thresholds))
+	for _, t := range thresholds {
+		if t < 1 || t > 100 {
+			return nil, fmt.Errorf(
+				"quota alert threshold %d%% must be between 1%% and 100%%", t)
+		}
+		if seen[t] {
+			continue
+		}
+		seen[t] = true
+		res = append(res, t)
+	}
+	sort.Ints(res)
+	return res, nil
+}
+
+type quotaAlerter struct {
+	k *SimpleFS
+
+	shutdownCh chan struct{}
+	doneCh     chan struct{}
+}
+
+func newQuotaAlerter(simpleFS *SimpleFS) *quotaAlerter {
+	a := &quotaAlerter{
+		k:          simpleFS,
+		shutdownCh: make(chan struct{}),
+		doneCh:     make(chan struct{}),
+	}
+	go a.loop()
+	return a
+}
+
+func (a *quotaAlerter) loop() {
+	defer close(a.doneCh)
+	ticker := time.NewTicker(quotaAlertCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			ctx := a.k.makeContext(context.Background())
+			if err := a.check(ctx); err != nil {
+				a.k.log.CDebugf(ctx, "Quota alert check failed: %+v", err)
+			}
+		case <-a.shutdownCh:
+			return
+		}
+	}
+}
+
+func (a *quotaAlerter) check(ctx context.Context) error {
+	db := a.k.config.GetSettingsDB()
+	if db == nil {
+		return libkbfs.ErrNoSettingsDB
+	}
+	settings, err := db.QuotaAlertSettings(ctx)
+	if err != nil {
+		return err
+	}
+	lastThreshold, err := db.QuotaAlertLastThreshold(ctx)
+	if err != nil {
+		return err
+	}
+	status, _, err := a.k.config.KBFSOps().Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	fire, newLastThreshold := quotaAlertToFire(settings.Thresholds,
+		status.UsageBytes, status.LimitBytes, lastThreshold)
+	if fire > 0 {
+		a.k.log.CDebugf(ctx, "Quota usage crossed %d%%", fire)
+		ks := a.k.config.KeybaseService()
+		if ks == nil {
+			return nil
+		}
+		err = ks.NotifyQuotaAlert(ctx, keybase1.SimpleFSQuotaAlert{
+			Threshold:  fire,
+			UsageBytes: status.UsageBytes,
+			LimitBytes: status.LimitBytes,
+			ChatToSelf: settings.ChatToSelf,
+		})
+		if err != nil {
+			// Try again on the next check.
+			return err
+		}
+	}
+	if newLastThreshold == lastThreshold {
+		return nil
+	}
+	return db.SetQuotaAlertLastThreshold(ctx, newLastThreshold)
+}
+
+func (a *quotaAlerter) shutdown() {
+	close(a.shutdownCh)
+	<-a.doneCh
+}
+
+// SimpleFSGetQuotaAlertSettings implements the SimpleFSInterface.
+func (k *SimpleFS) SimpleFSGetQuotaAlertSettings(ctx context.Context) (
+	settings keybase1.SimpleFSQuotaAlertSettings, err error) {
+	defer func() {
+		k.log.CDebugf(ctx, "SimpleFSGetQuotaAlertSettings settings=%+v err=%+v",
+			settings, err)
+	}()
+	db := k.config.GetSettingsDB()
+	if db == nil {
+		return keybase1.SimpleFSQuotaAlertSettings{}, libkbfs.ErrNoSettingsDB
+	}
+	return db.QuotaAlertSettings(ctx)
+}
+
+// SimpleFSSetQuotaAlertSettings implements the SimpleFSInterface.
+func (k *SimpleFS) SimpleFSSetQuotaAlertSettings(
+	ctx context.Context, settings keybase1.SimpleFSQuotaAlertSettings) (
+	err error) {
+	defer func() {
+		k.log.CDebugf(ctx, "SimpleFSSetQuotaAlertSettings settings=%+v err=%+v",
+			settings, err)
+	}()
+	db := k.config.GetSettingsDB()
+	if db == nil {
+		return libkbfs.ErrNoSettingsDB
+	}
+	settings.Thresholds, err = cleanQuotaAlertThresholds(settings.Thresholds)
+	if err != nil {
+		return err
+	}
+	if err = db.SetQuotaAlertSettings(ctx, settings); err != nil {
+		return err
+	}
+	k.config.SubscriptionManagerPublisher().PublishChange(
+		keybase1.SubscriptionTopic_SETTINGS)
+	return nil
+}
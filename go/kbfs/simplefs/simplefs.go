@@ -166,6 +166,8 @@ type SimpleFS struct {
 	uploadManager   *uploadManager
 
 	archiveManager *archiveManager
+	lockManager    *lockManager
+	quotaAlerter   *quotaAlerter
 
 	httpClient *http.Client
 }
@@ -257,6 +259,8 @@ func newSimpleFS(appStateUpdater env.AppStateUpdater, config libkbfs.Config) *Si
 	}
 	k.downloadManager = newDownloadManager(k)
 	k.uploadManager = newUploadManager(k)
+	k.lockManager = newLockManager(k)
+	k.quotaAlerter = newQuotaAlerter(k)
 	k.archiveManager, err = newArchiveManager(k)
 	if err != nil {
 		log.Fatalf("initializing archive manager error: %v", err)
@@ -1020,6 +1024,13 @@ func (k *SimpleFS) SimpleFSList(ctx context.Context, arg keybase1.SimpleFSListAr
 					if err != nil {
 						return err
 					}
+					if arg.DetectMimeTypes && fi.Mode().IsRegular() {
+						name := fi.Name()
+						d.MimeType = detectMimeType(name, fi.Size(),
+							func() (io.ReadCloser, error) {
+								return linkFS.Open(name)
+							})
+					}
 					res = append(res, d)
 				}
 				k.updateReadProgress(arg.OpID, 0, int64(len(fis)))
@@ -2269,6 +2280,170 @@ func (k *SimpleFS) SimpleFSReadRevisions(
 	return lr, nil
 }
 
+// The most revisions `SimpleFSFileHistory` will return at once.
+const maxFileHistoryRevisions = 100
+
+func archivedRevisionPath(
+	pathStr string, rev kbfsmd.Revision) keybase1.Path {
+	return keybase1.NewPathWithKbfsArchived(keybase1.KBFSArchivedPath{
+		Path: pathStr,
+		ArchivedParam: keybase1.NewKBFSArchivedParamWithRevision(
+			keybase1.KBFSRevision(rev)),
+	})
+}
+
+// fileHistoryRevisions steps back through the revisions in which the
+// file at `pathStr` changed, starting with the latest one in `prs`,
+// the same way the LAST_FIVE span type does in `doGetRevisions`.  It
+// returns up to `num` of them, newest first, along with the revision
+// to start the next page from, or 0 if there are no older ones.
+func (k *SimpleFS) fileHistoryRevisions(
+	ctx context.Context, pathStr string, prs data.PrevRevisions, num int) (
+	revs []kbfsmd.Revision, next kbfsmd.Revision, err error) {
+	revs = append(revs, prs[0].Revision)
+	expectedCount := uint8(2)
+	nextSlot := 1
+	lastRevision := prs[0].Revision
+	for lastRevision > kbfsmd.RevisionInitial {
+		if len(revs) == num {
+			return revs, lastRevision - 1, nil
+		}
+
+		var rev kbfsmd.Revision
+		if nextSlot < len(prs) && prs[nextSlot].Count == expectedCount {
+			rev = prs[nextSlot].Revision
+		} else {
+			k.log.CDebugf(ctx, "Inspecting revision %d to find previous",
+				lastRevision-1)
+			_, _, prevPRs, err := k.getRevisionsFromPath(
+				ctx, archivedRevisionPath(pathStr, lastRevision-1))
+			if _, isGC := err.(libkbfs.RevGarbageCollectedError); isGC {
+				k.log.CDebugf(ctx, "Hit a GC'd revision: %d", lastRevision-1)
+				return revs, 0, nil
+			} else if os.IsNotExist(err) {
+				k.log.CDebugf(ctx, "File didn't exist as of %d",
+					lastRevision-1)
+				return revs, 0, nil
+			} else if err != nil {
+				return nil, 0, err
+			}
+			if len(prevPRs) == 0 {
+				return revs, 0, nil
+			}
+			rev = prevPRs[0].Revision
+			prs = prevPRs
+			nextSlot = 0      // will be incremented below
+			expectedCount = 1 // will be incremented below
+		}
+
+		revs = append(revs, rev)
+		lastRevision = rev
+		nextSlot++
+		expectedCount++
+	}
+	return revs, 0, nil
+}
+
+// SimpleFSFileHistory - Get the modification history of a single file
+func (k *SimpleFS) SimpleFSFileHistory(
+	ctx context.Context, arg keybase1.SimpleFSFileHistoryArg) (
+	res keybase1.SimpleFSFileHistory, err error) {
+	defer func() { err = translateErr(err) }()
+	ctx, err = k.startSyncOp(ctx, "FileHistory", arg, &arg.Path, nil)
+	if err != nil {
+		return keybase1.SimpleFSFileHistory{}, err
+	}
+	defer func() { k.doneSyncOp(ctx, err) }()
+
+	pathType, err := arg.Path.PathType()
+	if err != nil {
+		return keybase1.SimpleFSFileHistory{}, err
+	}
+	if pathType != keybase1.PathType_KBFS {
+		return keybase1.SimpleFSFileHistory{}, simpleFSError{
+			reason: "File history is only available for KBFS paths"}
+	}
+	num := arg.Num
+	if num <= 0 || num > maxFileHistoryRevisions {
+		num = maxFileHistoryRevisions
+	}
+
+	pathStr := arg.Path.String()
+	startPath := arg.Path
+	if arg.StartRevision > 0 {
+		startPath = archivedRevisionPath(
+			pathStr, kbfsmd.Revision(arg.StartRevision))
+	}
+	_, _, prs, err := k.getRevisionsFromPath(ctx, startPath)
+	if _, isGC := err.(libkbfs.RevGarbageCollectedError); isGC ||
+		(arg.StartRevision > 0 && os.IsNotExist(err)) {
+		// We've already returned everything that's left.
+		return keybase1.SimpleFSFileHistory{}, nil
+	} else if err != nil {
+		return keybase1.SimpleFSFileHistory{}, err
+	}
+	if len(prs) == 0 {
+		return keybase1.SimpleFSFileHistory{}, simpleFSError{
+			reason: "No previous revisions"}
+	}
+
+	revs, next, err := k.fileHistoryRevisions(ctx, pathStr, prs, num)
+	if err != nil {
+		return keybase1.SimpleFSFileHistory{}, err
+	}
+
+	// Fetch all the revisions in parallel to populate the directory
+	// entries.
+	res.Revisions = make([]keybase1.DirentWithRevision, len(revs))
+	eg, groupCtx := errgroup.WithContext(ctx)
+	doStat := func(slot int) error {
+		p := archivedRevisionPath(pathStr, revs[slot])
+		fs, finalElem, err := k.getFSIfExists(groupCtx, p)
+		if _, isGC := err.(libkbfs.RevGarbageCollectedError); isGC {
+			k.log.CDebugf(ctx, "Hit a GC'd revision: %d", revs[slot])
+			return nil
+		} else if err != nil {
+			return err
+		}
+		// Use LStat so we don't follow symlinks.
+		fi, err := fs.Lstat(finalElem)
+		if os.IsNotExist(err) {
+			k.log.CDebugf(ctx, "Ran out of revisions as of %d", revs[slot])
+			return nil
+		} else if err != nil {
+			return err
+		}
+		var rev keybase1.DirentWithRevision
+		err = k.setStat(&rev.Entry, fi, fs)
+		if err != nil {
+			return err
+		}
+		rev.Revision = keybase1.KBFSRevision(revs[slot])
+		res.Revisions[slot] = rev
+		return nil
+	}
+	for i := range revs {
+		i := i
+		eg.Go(func() error { return doStat(i) })
+	}
+	err = eg.Wait()
+	if err != nil {
+		return keybase1.SimpleFSFileHistory{}, err
+	}
+
+	// Stop at the first GC'd revision, since everything before it is
+	// gone too.
+	for i, r := range res.Revisions {
+		if kbfsmd.Revision(r.Revision) == kbfsmd.RevisionUninitialized {
+			res.Revisions = res.Revisions[:i]
+			next = 0
+			break
+		}
+	}
+	res.NextRevision = keybase1.KBFSRevision(next)
+	return res, nil
+}
+
 // SimpleFSMakeOpid - Convenience helper for generating new random value
 func (k *SimpleFS) SimpleFSMakeOpid(_ context.Context) (keybase1.OpID, error) {
 	var opid keybase1.OpID
@@ -3567,6 +3742,30 @@ func (k *SimpleFS) SimpleFSCancelJournalUploads(
 		})
 }
 
+// SimpleFSRepairCache implements the SimpleFSInterface.
+func (k *SimpleFS) SimpleFSRepairCache(ctx context.Context, rebuild bool) error {
+	ctx = k.makeContext(ctx)
+	if k.config.CacheRepairProgress().InProgress {
+		return errors.New("a cache repair is already in progress")
+	}
+	k.log.CDebugf(ctx, "Starting cache repair, rebuild=%t", rebuild)
+	go func() {
+		// Checking a big cache can outlast the RPC.
+		ctx := k.makeContext(context.Background())
+		err := k.config.RepairCache(ctx, rebuild)
+		if err != nil {
+			k.log.CWarningf(ctx, "Cache repair failed: %+v", err)
+		}
+	}()
+	return nil
+}
+
+// SimpleFSGetCacheRepairProgress implements the SimpleFSInterface.
+func (k *SimpleFS) SimpleFSGetCacheRepairProgress(
+	_ context.Context) (keybase1.SimpleFSCacheRepairProgress, error) {
+	return k.config.CacheRepairProgress(), nil
+}
+
 var cacheDirForTest = ""
 
 func setCacheDirForTest(d string) {
@@ -3584,10 +3783,14 @@ func (k *SimpleFS) getCacheDir() string {
 	return k.config.KbEnv().GetCacheDir()
 }
 
-func (k *SimpleFS) getStagingPath(ctx context.Context, jobID string) (stagingPath string) {
-	username := k.config.KbEnv().GetUsername()
+func (k *SimpleFS) getStagingPath(ctx context.Context, jobID string) (
+	stagingPath string, err error) {
+	owner, err := k.archiveManager.getOwner(ctx)
+	if err != nil {
+		return "", err
+	}
 	cacheDir := k.getCacheDir()
-	return filepath.Join(cacheDir, fmt.Sprintf("kbfs-archive-%s-%s", username, jobID))
+	return filepath.Join(cacheDir, fmt.Sprintf("kbfs-archive-%s-%s", owner.name, jobID)), nil
 }
 
 func generateArchiveJobID() (string, error) {
@@ -3600,21 +3803,122 @@ func generateArchiveJobID() (string, error) {
 		base64.RawURLEncoding.EncodeToString(buf)), nil
 }
 
-// SimpleFSArchiveStart implements the SimpleFSInterface.
-func (k *SimpleFS) SimpleFSArchiveStart(ctx context.Context,
+func generateArchiveScheduleID() (string, error) {
+	buf := make([]byte, 8)
+	err := kbfscrypto.RandRead(buf)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("kbfs-archive-schedule-%s",
+		base64.RawURLEncoding.EncodeToString(buf)), nil
+}
+
+// pinArchivePath pins kbfsPath to the current revision of its TLF. ok is
+// false if it's not in a TLF that can be archived.
+func (k *SimpleFS) pinArchivePath(ctx context.Context, kbfsPath keybase1.KBFSPath) (
+	pinned keybase1.KBFSArchivedPath, ok bool, err error) {
+	fb, _, err := k.getFolderBranchFromPath(ctx, keybase1.NewPathWithKbfs(kbfsPath))
+	if err != nil {
+		return keybase1.KBFSArchivedPath{}, false, err
+	}
+	if fb == (data.FolderBranch{}) {
+		return keybase1.KBFSArchivedPath{}, false, nil
+	}
+	status, _, err := k.config.KBFSOps().FolderStatus(ctx, fb)
+	if err != nil {
+		return keybase1.KBFSArchivedPath{}, false, err
+	}
+	return keybase1.KBFSArchivedPath{
+		Path: kbfsPath.Path,
+		ArchivedParam: keybase1.NewKBFSArchivedParamWithRevision(
+			keybase1.KBFSRevision(status.Revision)),
+	}, true, nil
+}
+
+// newArchiveJobDesc builds the description of an archive job for kbfsPath
+// and any additional paths, each pinned to the current revision of its TLF.
+func (k *SimpleFS) newArchiveJobDesc(ctx context.Context,
 	arg keybase1.SimpleFSArchiveStartArg) (jobDesc keybase1.SimpleFSArchiveJobDesc, err error) {
-	ctx = k.makeContext(ctx)
+	if arg.BytesPerSecond < 0 {
+		return keybase1.SimpleFSArchiveJobDesc{},
+			errors.New("bytesPerSecond cannot be negative")
+	}
+	if arg.MaxFileSize < 0 {
+		return keybase1.SimpleFSArchiveJobDesc{},
+			errors.New("maxFileSize cannot be negative")
+	}
+	if arg.MaxVolumeBytes < 0 {
+		return keybase1.SimpleFSArchiveJobDesc{},
+			errors.New("maxVolumeBytes cannot be negative")
+	}
+	if arg.MaxRetries < 0 {
+		return keybase1.SimpleFSArchiveJobDesc{},
+			errors.New("maxRetries cannot be negative")
+	}
+	if arg.MaxVolumeBytes > 0 && arg.MaxVolumeBytes < archiveMinVolumeBytes {
+		return keybase1.SimpleFSArchiveJobDesc{}, fmt.Errorf(
+			"maxVolumeBytes must be at least %d", archiveMinVolumeBytes)
+	}
+
+	if err := checkArchiveCompression(arg.Compression); err != nil {
+		return keybase1.SimpleFSArchiveJobDesc{}, err
+	}
+	if err := checkArchiveSpecialFiles(arg.SpecialFiles); err != nil {
+		return keybase1.SimpleFSArchiveJobDesc{}, err
+	}
+	if err := checkArchiveSymlinks(arg.Symlinks); err != nil {
+		return keybase1.SimpleFSArchiveJobDesc{}, err
+	}
+	if err := checkArchiveLabel(arg.Label, arg.Notes); err != nil {
+		return keybase1.SimpleFSArchiveJobDesc{}, err
+	}
+	if err := checkArchiveLayout(arg.Layout, arg.MaxVolumeBytes); err != nil {
+		return keybase1.SimpleFSArchiveJobDesc{}, err
+	}
+	if err := checkArchiveGlobs(arg.IncludeGlobs); err != nil {
+		return keybase1.SimpleFSArchiveJobDesc{}, err
+	}
+	if err := checkArchiveGlobs(arg.ExcludeGlobs); err != nil {
+		return keybase1.SimpleFSArchiveJobDesc{}, err
+	}
+	err = checkArchiveRevisionsArgs(arg.AllRevisions, arg.MaxRevisions, arg.RevisionsSince)
+	if err != nil {
+		return keybase1.SimpleFSArchiveJobDesc{}, err
+	}
 
 	desc := keybase1.SimpleFSArchiveJobDesc{
-		StartTime:    keybase1.ToTime(time.Now()),
-		OverwriteZip: arg.OverwriteZip,
+		StartTime:      keybase1.ToTime(time.Now()),
+		OverwriteZip:   arg.OverwriteZip,
+		BytesPerSecond: arg.BytesPerSecond,
+		IncludeGlobs:   arg.IncludeGlobs,
+		ExcludeGlobs:   arg.ExcludeGlobs,
+		MaxFileSize:    arg.MaxFileSize,
+
+		WriteManifestJSON: arg.WriteManifestJSON,
+		Priority:          arg.Priority,
+		Destination:       arg.Destination,
+		MaxVolumeBytes:    arg.MaxVolumeBytes,
+		Compression:       arg.Compression,
+		BestEffort:        arg.BestEffort,
+		MaxRetries:        arg.MaxRetries,
+		AllRevisions:      arg.AllRevisions,
+		MaxRevisions:      arg.MaxRevisions,
+		RevisionsSince:    arg.RevisionsSince,
+		SpecialFiles:      arg.SpecialFiles,
+		Symlinks:          arg.Symlinks,
+		Label:             arg.Label,
+		Notes:             arg.Notes,
+		Layout:            arg.Layout,
 	}
 
 	desc.JobID, err = generateArchiveJobID()
 	if err != nil {
 		return keybase1.SimpleFSArchiveJobDesc{}, err
 	}
-	desc.StagingPath = k.getStagingPath(ctx, desc.JobID)
+	desc.StagingPath, err = k.getStagingPath(ctx, desc.JobID)
+	if err != nil {
+		return keybase1.SimpleFSArchiveJobDesc{}, err
+	}
 
 	p, err := splitPathFromKbfsPath(keybase1.NewPathWithKbfs(arg.KbfsPath))
 	if err != nil {
@@ -3625,6 +3929,16 @@ func (k *SimpleFS) SimpleFSArchiveStart(ctx context.Context,
 			errors.New("unexpected number of elements from splitPathFromKbfsPath")
 	}
 	desc.TargetName = p[len(p)-1]
+	if len(arg.AdditionalPaths) > 0 {
+		desc.TargetName = fmt.Sprintf("%s-and-%d-more",
+			desc.TargetName, len(arg.AdditionalPaths))
+	}
+
+	remote := arg.Destination.Type != keybase1.SimpleFSArchiveDestinationType_Local
+	if remote && len(arg.OutputPath) > 0 {
+		return keybase1.SimpleFSArchiveJobDesc{},
+			errors.New("an output path can't be given with a remote destination")
+	}
 
 	desc.ZipFilePath = arg.OutputPath
 	if len(desc.ZipFilePath) == 0 {
@@ -3633,32 +3947,86 @@ func (k *SimpleFS) SimpleFSArchiveStart(ctx context.Context,
 		// allow the user to download the zip file, and when user dismisses the
 		// job, the zip file along with other stuff in the staging path is
 		// deleted.
+		// This is also where the zip waits to be uploaded, for remote
+		// destinations.
 		desc.ZipFilePath = filepath.Join(desc.StagingPath, desc.TargetName+".zip")
 	} else if !strings.HasSuffix(desc.ZipFilePath, ".zip") {
 		desc.ZipFilePath += ".zip"
 	}
+	if _, err := newArchiveDestination(
+		desc.Destination, filepath.Base(desc.ZipFilePath)); err != nil {
+		return keybase1.SimpleFSArchiveJobDesc{}, err
+	}
 
 	// Pin the job to a specific revision so if the TLF changes during the
 	// archive we don't end up mixing two different revisions.
-	{
-		fb, _, err := k.getFolderBranchFromPath(ctx, keybase1.NewPathWithKbfs(arg.KbfsPath))
-		if err != nil {
-			return keybase1.SimpleFSArchiveJobDesc{}, err
+	pinned, ok, err := k.pinArchivePath(ctx, arg.KbfsPath)
+	if err != nil {
+		return keybase1.SimpleFSArchiveJobDesc{}, err
+	}
+	if !ok {
+		return keybase1.SimpleFSArchiveJobDesc{}, nil
+	}
+	desc.KbfsPathWithRevision = pinned
+
+	if len(arg.AdditionalPaths) > 0 {
+		if len(arg.BaseJobID) > 0 {
+			return keybase1.SimpleFSArchiveJobDesc{},
+				errors.New("incremental jobs can only archive one path")
 		}
-		if fb == (data.FolderBranch{}) {
-			return keybase1.SimpleFSArchiveJobDesc{}, nil
+		paths := append([]keybase1.KBFSPath{arg.KbfsPath}, arg.AdditionalPaths...)
+		names := make([]string, 0, len(paths))
+		for _, kbfsPath := range paths {
+			p, err := splitPathFromKbfsPath(keybase1.NewPathWithKbfs(kbfsPath))
+			if err != nil {
+				return keybase1.SimpleFSArchiveJobDesc{}, err
+			}
+			if len(p) == 0 {
+				return keybase1.SimpleFSArchiveJobDesc{},
+					errors.New("unexpected number of elements from splitPathFromKbfsPath")
+			}
+			names = append(names, p[len(p)-1])
+		}
+		roots := archiveSourceRoots(names)
+		desc.Sources = []keybase1.SimpleFSArchiveSource{{Path: pinned, Root: roots[0]}}
+		for i, kbfsPath := range arg.AdditionalPaths {
+			pinned, ok, err := k.pinArchivePath(ctx, kbfsPath)
+			if err != nil {
+				return keybase1.SimpleFSArchiveJobDesc{}, err
+			}
+			if !ok {
+				return keybase1.SimpleFSArchiveJobDesc{},
+					fmt.Errorf("%s can't be archived", kbfsPath.Path)
+			}
+			desc.Sources = append(desc.Sources,
+				keybase1.SimpleFSArchiveSource{Path: pinned, Root: roots[i+1]})
 		}
-		status, _, err := k.config.KBFSOps().FolderStatus(ctx, fb)
+	}
+
+	if len(arg.BaseJobID) > 0 {
+		desc.BaseJobID = arg.BaseJobID
+		desc.BaseRevision, err = k.archiveManager.getIncrementalBaseRevision(
+			ctx, arg.BaseJobID, arg.KbfsPath.Path)
 		if err != nil {
 			return keybase1.SimpleFSArchiveJobDesc{}, err
 		}
-		desc.KbfsPathWithRevision = keybase1.KBFSArchivedPath{
-			Path: arg.KbfsPath.Path,
-			ArchivedParam: keybase1.NewKBFSArchivedParamWithRevision(
-				keybase1.KBFSRevision(status.Revision)),
-		}
 	}
 
+	return desc, nil
+}
+
+// SimpleFSArchiveStart implements the SimpleFSInterface.
+func (k *SimpleFS) SimpleFSArchiveStart(ctx context.Context,
+	arg keybase1.SimpleFSArchiveStartArg) (jobDesc keybase1.SimpleFSArchiveJobDesc, err error) {
+	ctx = k.makeContext(ctx)
+	desc, err := k.newArchiveJobDesc(ctx, arg)
+	if err != nil {
+		return keybase1.SimpleFSArchiveJobDesc{}, err
+	}
+	if len(desc.JobID) == 0 {
+		// Not a KBFS path we can archive; nothing to start.
+		return desc, nil
+	}
 	err = k.archiveManager.startJob(ctx, desc)
 	return desc, err
 }
@@ -3670,14 +4038,49 @@ func (k *SimpleFS) SimpleFSArchiveCancelOrDismissJob(ctx context.Context,
 	return k.archiveManager.cancelOrDismissJob(ctx, jobID)
 }
 
+// SimpleFSArchivePauseJob implements the SimpleFSInterface.
+func (k *SimpleFS) SimpleFSArchivePauseJob(ctx context.Context,
+	jobID string) (err error) {
+	ctx = k.makeContext(ctx)
+	return k.archiveManager.pauseJob(ctx, jobID)
+}
+
+// SimpleFSArchiveResumeJob implements the SimpleFSInterface.
+func (k *SimpleFS) SimpleFSArchiveResumeJob(ctx context.Context,
+	jobID string) (err error) {
+	ctx = k.makeContext(ctx)
+	return k.archiveManager.resumeJob(ctx, jobID)
+}
+
+// SimpleFSArchiveRetryJob implements the SimpleFSInterface.
+func (k *SimpleFS) SimpleFSArchiveRetryJob(ctx context.Context,
+	jobID string) (err error) {
+	ctx = k.makeContext(ctx)
+	return k.archiveManager.retryJob(ctx, jobID)
+}
+
+// SimpleFSArchiveDrain implements the SimpleFSInterface.
+func (k *SimpleFS) SimpleFSArchiveDrain(ctx context.Context,
+	timeout keybase1.DurationSec) (err error) {
+	ctx = k.makeContext(ctx)
+	ctx, cancel := context.WithTimeout(ctx, timeout.Duration())
+	defer cancel()
+	return k.archiveManager.drain(ctx)
+}
+
 // SimpleFSGetArchiveStatus implements the SimpleFSInterface.
 func (k *SimpleFS) SimpleFSGetArchiveStatus(ctx context.Context) (
 	status keybase1.SimpleFSArchiveStatus, err error) {
 	ctx = k.makeContext(ctx)
-	state, errorStates := k.archiveManager.getCurrentState(ctx)
+	state, err := k.archiveManager.getCurrentState(ctx)
+	if err != nil {
+		return status, err
+	}
 	status = keybase1.SimpleFSArchiveStatus{
 		LastUpdated: state.LastUpdated,
 		Jobs:        make(map[string]keybase1.SimpleFSArchiveJobStatus),
+		Schedules:   state.Schedules,
+		Retention:   state.Retention,
 	}
 	for jobID, stateJob := range state.Jobs {
 		statusJob := keybase1.SimpleFSArchiveJobStatus{
@@ -3687,7 +4090,21 @@ func (k *SimpleFS) SimpleFSGetArchiveStatus(ctx context.Context) (
 			BytesCopied: stateJob.BytesCopied,
 			BytesZipped: stateJob.BytesZipped,
 			BytesTotal:  stateJob.BytesTotal,
+
+			SkippedLargeFiles: stateJob.SkippedLargeFiles,
+			SkippedFiles:      archiveSkippedFiles(stateJob.Manifest),
+			FidelityLosses:    archiveFidelityLosses(stateJob.Manifest),
+			Paused:            stateJob.Paused,
+			BytesUploaded:     stateJob.BytesUploaded,
+			VolumePaths:       stateJob.VolumePaths,
+
+			Throughput:          stateJob.Throughput,
+			EstimatedCompletion: stateJob.EstimatedCompletion,
+			VerifiedAt:          stateJob.VerifiedAt,
+			VerifyIssues:        stateJob.VerifyIssues,
 		}
+		// The destination's credentials stay in the state file.
+		statusJob.Desc.Destination.Password = ""
 		for _, item := range stateJob.Manifest {
 			switch item.State {
 			case keybase1.SimpleFSFileArchiveState_ToDo:
@@ -3698,6 +4115,8 @@ func (k *SimpleFS) SimpleFSGetArchiveStatus(ctx context.Context) (
 				statusJob.CompleteCount++
 			case keybase1.SimpleFSFileArchiveState_Skipped:
 				statusJob.SkippedCount++
+			case keybase1.SimpleFSFileArchiveState_Unchanged:
+				statusJob.UnchangedCount++
 			}
 		}
 		{ // get current revision
@@ -3716,10 +4135,12 @@ func (k *SimpleFS) SimpleFSGetArchiveStatus(ctx context.Context) (
 			}
 			statusJob.CurrentTLFRevision = keybase1.KBFSRevision(status.Revision)
 		}
-		if errState, ok := errorStates[jobID]; ok {
+		if stateJob.ErrorState != nil {
+			statusJob.Error = stateJob.ErrorState
+		} else if len(stateJob.Error) > 0 {
+			// Recorded before a restart; it'll be checked again soon.
 			statusJob.Error = &keybase1.SimpleFSArchiveJobErrorState{
-				Error:     errState.err.Error(),
-				NextRetry: keybase1.ToTime(errState.nextRetry),
+				Error: stateJob.Error,
 			}
 		}
 		status.Jobs[jobID] = statusJob
@@ -3727,9 +4148,197 @@ func (k *SimpleFS) SimpleFSGetArchiveStatus(ctx context.Context) (
 	return status, nil
 }
 
+// SimpleFSLock implements the SimpleFSInterface.
+func (k *SimpleFS) SimpleFSLock(ctx context.Context,
+	arg keybase1.SimpleFSLockArg) (info keybase1.SimpleFSLockInfo, err error) {
+	ctx = k.makeContext(ctx)
+	return k.lockManager.lockPath(ctx, arg.Path, arg.Lease)
+}
+
+// SimpleFSUnlock implements the SimpleFSInterface.
+func (k *SimpleFS) SimpleFSUnlock(ctx context.Context,
+	path keybase1.KBFSPath) (err error) {
+	ctx = k.makeContext(ctx)
+	return k.lockManager.unlockPath(ctx, path)
+}
+
+// SimpleFSListLocks implements the SimpleFSInterface.
+func (k *SimpleFS) SimpleFSListLocks(ctx context.Context) (
+	[]keybase1.SimpleFSLockInfo, error) {
+	return k.lockManager.listLocks(), nil
+}
+
+// SimpleFSArchiveSetBytesPerSecond implements the SimpleFSInterface.
+func (k *SimpleFS) SimpleFSArchiveSetBytesPerSecond(ctx context.Context,
+	arg keybase1.SimpleFSArchiveSetBytesPerSecondArg) (err error) {
+	ctx = k.makeContext(ctx)
+	return k.archiveManager.setBytesPerSecond(ctx, arg.JobID, arg.BytesPerSecond)
+}
+
+// SimpleFSArchiveSetLabel implements the SimpleFSInterface.
+func (k *SimpleFS) SimpleFSArchiveSetLabel(ctx context.Context,
+	arg keybase1.SimpleFSArchiveSetLabelArg) (err error) {
+	ctx = k.makeContext(ctx)
+	return k.archiveManager.setLabel(ctx, arg.JobID, arg.Label, arg.Notes)
+}
+
+// SimpleFSArchiveSchedule implements the SimpleFSInterface.
+func (k *SimpleFS) SimpleFSArchiveSchedule(ctx context.Context,
+	arg keybase1.SimpleFSArchiveScheduleArg) (
+	schedule keybase1.SimpleFSArchiveSchedule, err error) {
+	ctx = k.makeContext(ctx)
+
+	if !filepath.IsAbs(arg.OutputDir) {
+		return keybase1.SimpleFSArchiveSchedule{},
+			errors.New("outputDir must be an absolute path")
+	}
+	if arg.Hour < 0 || arg.Hour > 23 {
+		return keybase1.SimpleFSArchiveSchedule{},
+			errors.New("hour must be between 0 and 23")
+	}
+	switch arg.Frequency {
+	case keybase1.SimpleFSArchiveScheduleFrequency_Daily:
+	case keybase1.SimpleFSArchiveScheduleFrequency_Weekly:
+		if arg.Weekday < 0 || arg.Weekday > 6 {
+			return keybase1.SimpleFSArchiveSchedule{},
+				errors.New("weekday must be between 0 (Sunday) and 6")
+		}
+	default:
+		return keybase1.SimpleFSArchiveSchedule{},
+			errors.Errorf("unknown schedule frequency %d", arg.Frequency)
+	}
+	if arg.KeepCount < 0 {
+		return keybase1.SimpleFSArchiveSchedule{},
+			errors.New("keepCount cannot be negative")
+	}
+	if arg.BytesPerSecond < 0 {
+		return keybase1.SimpleFSArchiveSchedule{},
+			errors.New("bytesPerSecond cannot be negative")
+	}
+	p, err := splitPathFromKbfsPath(keybase1.NewPathWithKbfs(arg.KbfsPath))
+	if err != nil {
+		return keybase1.SimpleFSArchiveSchedule{}, err
+	}
+	if len(p) == 0 {
+		return keybase1.SimpleFSArchiveSchedule{},
+			errors.New("unexpected number of elements from splitPathFromKbfsPath")
+	}
+
+	schedule = keybase1.SimpleFSArchiveSchedule{
+		KbfsPath:       arg.KbfsPath,
+		OutputDir:      arg.OutputDir,
+		Frequency:      arg.Frequency,
+		Hour:           arg.Hour,
+		Weekday:        arg.Weekday,
+		KeepCount:      arg.KeepCount,
+		BytesPerSecond: arg.BytesPerSecond,
+	}
+	schedule.ScheduleID, err = generateArchiveScheduleID()
+	if err != nil {
+		return keybase1.SimpleFSArchiveSchedule{}, err
+	}
+	schedule.NextRun = keybase1.ToTime(
+		nextArchiveScheduleRun(schedule, time.Now()))
+	err = k.archiveManager.addSchedule(ctx, schedule)
+	return schedule, err
+}
+
+// SimpleFSArchiveUnschedule implements the SimpleFSInterface.
+func (k *SimpleFS) SimpleFSArchiveUnschedule(ctx context.Context,
+	scheduleID string) (err error) {
+	ctx = k.makeContext(ctx)
+	return k.archiveManager.removeSchedule(ctx, scheduleID)
+}
+
+// SimpleFSArchiveCheckArchive implements the SimpleFSInterface.
+func (k *SimpleFS) SimpleFSArchiveCheckArchive(ctx context.Context,
+	jobID string) (result keybase1.SimpleFSArchiveCheckArchiveResult, err error) {
+	ctx = k.makeContext(ctx)
+	return k.archiveManager.checkArchive(ctx, jobID)
+}
+
+// SimpleFSArchiveVerifyJob implements the SimpleFSInterface.
+func (k *SimpleFS) SimpleFSArchiveVerifyJob(ctx context.Context,
+	arg keybase1.SimpleFSArchiveVerifyJobArg) (
+	result keybase1.SimpleFSArchiveCheckArchiveResult, err error) {
+	ctx = k.makeContext(ctx)
+	return k.archiveManager.verifyJob(ctx, arg.JobID, arg.ZipFilePath)
+}
+
+// SimpleFSArchiveCheckParts implements the SimpleFSInterface.
+func (k *SimpleFS) SimpleFSArchiveCheckParts(ctx context.Context,
+	partsManifestPath string) (keybase1.SimpleFSArchiveCheckArchiveResult, error) {
+	ctx = k.makeContext(ctx)
+	k.log.CDebugf(ctx, "SimpleFSArchiveCheckParts %s", partsManifestPath)
+	return checkArchiveParts(ctx, partsManifestPath)
+}
+
+// SimpleFSArchiveSearchJobs implements the SimpleFSInterface.
+func (k *SimpleFS) SimpleFSArchiveSearchJobs(ctx context.Context,
+	query string) ([]keybase1.SimpleFSArchiveJobStatus, error) {
+	ctx = k.makeContext(ctx)
+	k.log.CDebugf(ctx, "SimpleFSArchiveSearchJobs %q", query)
+	status, err := k.SimpleFSGetArchiveStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return searchArchiveJobs(status.Jobs, query), nil
+}
+
+// SimpleFSArchiveListAllDevices implements the SimpleFSInterface.
+func (k *SimpleFS) SimpleFSArchiveListAllDevices(ctx context.Context) (
+	[]keybase1.SimpleFSArchiveJobSummary, error) {
+	ctx = k.makeContext(ctx)
+	return k.archiveManager.listAllDevices(ctx)
+}
+
+// SimpleFSArchiveRestore implements the SimpleFSInterface.
+func (k *SimpleFS) SimpleFSArchiveRestore(ctx context.Context,
+	arg keybase1.SimpleFSArchiveRestoreArg) (
+	jobDesc keybase1.SimpleFSArchiveJobDesc, err error) {
+	ctx = k.makeContext(ctx)
+	desc, err := k.newArchiveRestoreJobDesc(ctx, arg)
+	if err != nil {
+		return keybase1.SimpleFSArchiveJobDesc{}, err
+	}
+	err = k.archiveManager.startJob(ctx, desc)
+	return desc, err
+}
+
+// SimpleFSArchiveReadInfo implements the SimpleFSInterface.
+func (k *SimpleFS) SimpleFSArchiveReadInfo(ctx context.Context,
+	zipFilePath string) (info keybase1.SimpleFSArchiveInfo, err error) {
+	ctx = k.makeContext(ctx)
+	k.log.CDebugf(ctx, "SimpleFSArchiveReadInfo %s", zipFilePath)
+	return readArchiveInfo(zipFilePath)
+}
+
+// SimpleFSArchiveGetStagingUsage implements the SimpleFSInterface.
+func (k *SimpleFS) SimpleFSArchiveGetStagingUsage(ctx context.Context) (
+	keybase1.SimpleFSArchiveStagingUsage, error) {
+	ctx = k.makeContext(ctx)
+	return k.archiveManager.getStagingUsage(ctx)
+}
+
+// SimpleFSArchiveSetMaxStagingBytes implements the SimpleFSInterface.
+func (k *SimpleFS) SimpleFSArchiveSetMaxStagingBytes(ctx context.Context,
+	maxStagingBytes int64) error {
+	ctx = k.makeContext(ctx)
+	return k.archiveManager.setMaxStagingBytes(ctx, maxStagingBytes)
+}
+
+// SimpleFSArchiveSetRetention implements the SimpleFSInterface.
+func (k *SimpleFS) SimpleFSArchiveSetRetention(ctx context.Context,
+	retention keybase1.SimpleFSArchiveRetention) error {
+	ctx = k.makeContext(ctx)
+	return k.archiveManager.setRetention(ctx, retention)
+}
+
 // Shutdown shuts down SimpleFS.
 func (k *SimpleFS) Shutdown(ctx context.Context) error {
+	k.lockManager.shutdown(ctx)
 	k.archiveManager.shutdown(ctx)
+	k.quotaAlerter.shutdown()
 	if k.indexer == nil {
 		return nil
 	}
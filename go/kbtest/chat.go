@@ -1565,6 +1565,10 @@ func (m *MockChatHelper) BulkAddToConv(context.Context, gregor1.UID, chat1.Conve
 	return nil
 }
 
+func (m *MockChatHelper) SendTeamReadme(context.Context, keybase1.TeamID, string, string) error {
+	return nil
+}
+
 type MockUIRouter struct {
 	ui libkb.ChatUI
 }
@@ -423,6 +423,18 @@ func (p CommandLine) GetAttachmentDisableMulti() (bool, bool) {
 	return p.GetBool("attachment-disable-multi", true)
 }
 
+func (p CommandLine) GetIntegrationGRPCPort() (int, bool) {
+	ret := p.GetGInt("integration-grpc-port")
+	if ret != 0 {
+		return ret, true
+	}
+	return 0, false
+}
+
+func (p CommandLine) GetTelemetryMode() string {
+	return p.GetGString("telemetry-mode")
+}
+
 func (p CommandLine) GetDisableTeamAuditor() (bool, bool) {
 	return p.GetBool("disable-team-auditor", true)
 }
@@ -816,6 +828,17 @@ func (p *CommandLine) AddCommands(cmds []cli.Command) {
 	p.app.Commands = append(p.app.Commands, cmds...)
 }
 
+// GetCommands returns the commands added so far, for commands that need to
+// look over the whole tree (like shell completion).
+func (p *CommandLine) GetCommands() []cli.Command {
+	return p.app.Commands
+}
+
+// GetGlobalFlags returns the flags that go before the command name.
+func (p *CommandLine) GetGlobalFlags() []cli.Flag {
+	return p.app.Flags
+}
+
 func (p *CommandLine) SetDefaultCommand(name string, cmd Command) {
 	p.defaultCmd = name
 	p.app.Action = func(c *cli.Context) {
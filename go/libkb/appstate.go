@@ -111,6 +111,7 @@ type MobileNetState struct {
 	Contextified
 	sync.Mutex
 	state     keybase1.MobileNetworkState
+	metered   bool
 	updateChs []chan keybase1.MobileNetworkState
 }
 
@@ -161,6 +162,21 @@ func (a *MobileNetState) State() keybase1.MobileNetworkState {
 	return a.state
 }
 
+// UpdateMetered records whether the OS says the current connection is
+// metered.
+func (a *MobileNetState) UpdateMetered(metered bool) {
+	a.Lock()
+	defer a.Unlock()
+	a.metered = metered
+}
+
+// Class returns the class of the current connection.
+func (a *MobileNetState) Class() NetworkClass {
+	a.Lock()
+	defer a.Unlock()
+	return ClassifyNetwork(a.state, a.metered)
+}
+
 // --------------------------------------------------
 
 type DesktopAppState struct {
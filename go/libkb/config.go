@@ -1031,6 +1031,14 @@ func (f *JSONConfigFile) GetAttachmentDisableMulti() (bool, bool) {
 	return f.GetBoolAtPath("attachment_disable_multi")
 }
 
+func (f *JSONConfigFile) GetIntegrationGRPCPort() (int, bool) {
+	return f.GetIntAtPath("integration_grpc_port")
+}
+
+func (f *JSONConfigFile) GetTelemetryMode() string {
+	return f.GetTopLevelString("telemetry_mode")
+}
+
 func (f *JSONConfigFile) GetDisableTeamAuditor() (bool, bool) {
 	return f.GetBoolAtPath("disable_team_auditor")
 }
@@ -1071,6 +1079,14 @@ func (f *JSONConfigFile) GetForceSecretStoreFile() (bool, bool) {
 	return f.GetBoolAtPath("force_less_safe_secret_store_file")
 }
 
+func (f *JSONConfigFile) SetForceLinuxKeyring(b bool) error {
+	return f.SetBoolAtPath("force_linux_keyring", b)
+}
+
+func (f *JSONConfigFile) SetForceSecretStoreFile(b bool) error {
+	return f.SetBoolAtPath("force_less_safe_secret_store_file", b)
+}
+
 func (f *JSONConfigFile) GetRuntimeStatsEnabled() (bool, bool) {
 	return f.GetBoolAtPath("runtime_stats_enabled")
 }
@@ -21,6 +21,9 @@ const (
 	DBTeamChain         = 0x10
 	DBUserPlusAllKeysV1 = 0x19
 
+	DBChatNotificationRouting        = 0xa0
+	DBStellarSpendingPolicy          = 0xa1
+	DBTeamExternalSync               = 0xa2
 	DBChatArchiveRegistry            = 0xa3
 	DBIncomingSharePreference        = 0xa4
 	DBChatUserEmojis                 = 0xa5
@@ -51,6 +54,12 @@ const (
 	DBOfflineRPC                     = 0xbe
 	DBChatCollapses                  = 0xbf
 	DBSupportsHiddenFlagStorage      = 0xc0
+	DBTeamSeitanInviteExtras         = 0xc1
+	DBChatMentionDigest              = 0xc2
+	DBChatWidgetFeed                 = 0xc3
+	DBChatIdentityChanges            = 0xc4
+	DBChatAttachmentAutoDownload     = 0xc5
+	DBChatArchiveImport              = 0xc6
 	DBMerkleAudit                    = 0xca
 	DBUnfurler                       = 0xcb
 	DBStellarDisclaimer              = 0xcc
@@ -140,7 +149,8 @@ func IsPermDbKey(typ ObjType) bool {
 		DBContactResolution,
 		DBTeambotKeyWrongKID,
 		DBMisc,
-		DBIncomingSharePreference:
+		DBIncomingSharePreference,
+		DBChatAttachmentAutoDownload:
 		return true
 	default:
 		return false
@@ -0,0 +1,33 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+//go:build !windows
+// +build !windows
+
+package libkb
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// diskAvailableBytes returns how many bytes an unprivileged user can still
+// write to the volume holding path.
+func diskAvailableBytes(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	// Bavail is the free block count for an unprivileged user.
+	return stat.Bavail * uint64(stat.Bsize), nil
+}
+
+// DiskVolumeID identifies the volume holding the existing file or
+// directory at path, so we can tell whether two paths share free space.
+func DiskVolumeID(path string) (string, error) {
+	var stat syscall.Stat_t
+	if err := syscall.Stat(path, &stat); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d", stat.Dev), nil
+}
@@ -0,0 +1,226 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	keybase1 "github.com/keybase/client/go/protocol/keybase1"
+)
+
+// Chat archives in the service and KBFS archives can each write gigabytes
+// to the local disk. They check for room before they start, but two jobs
+// starting at once would both see the same free space, so instead they
+// reserve what they're about to write here. A reservation is only granted
+// if the free space on each volume it writes to covers it on top of
+// everything else reserved there. Reservations are of what a job still has
+// left to write, since what it's already written isn't free anymore, so
+// jobs update theirs as they go.
+
+// DiskSpaceReservationTTL is how long a reservation made over RPC lasts
+// without being updated, so one held by a KBFS that's gone away doesn't
+// hold on to the space forever.
+const DiskSpaceReservationTTL = 30 * time.Minute
+
+// diskSpaceRecheckInterval is how often a reservation that's waiting for
+// room checks again when nothing's been released, since other programs
+// free up space too.
+const diskSpaceRecheckInterval = time.Minute
+
+// DiskSpaceReservationError is returned when there isn't room for a
+// reservation.
+type DiskSpaceReservationError struct {
+	Path      string
+	Needed    uint64
+	Available uint64
+	// What other jobs have reserved on the same volume.
+	Reserved uint64
+}
+
+func (e DiskSpaceReservationError) Error() string {
+	if e.Reserved == 0 {
+		return fmt.Sprintf("not enough disk space: %s needs %d bytes free but only has %d",
+			e.Path, e.Needed, e.Available)
+	}
+	return fmt.Sprintf("not enough disk space: %s needs %d bytes free but only has %d, "+
+		"and other jobs have reserved %d of them", e.Path, e.Needed, e.Available, e.Reserved)
+}
+
+type diskSpaceVolumeNeed struct {
+	// An existing path on the volume.
+	path  string
+	bytes uint64
+}
+
+type diskSpaceReservation struct {
+	description string
+	// volume ID -> need
+	needs map[string]diskSpaceVolumeNeed
+	// Zero if it doesn't expire.
+	expires time.Time
+}
+
+// DiskSpaceReservations keeps track of the disk space that jobs have
+// reserved, by the key each job reserved it under.
+type DiskSpaceReservations struct {
+	Contextified
+	sync.Mutex
+	reservations map[string]diskSpaceReservation
+	// Closed and replaced whenever a reservation goes away, to wake up
+	// the ones waiting for room.
+	released chan struct{}
+
+	// Replaced in tests.
+	getAvailableBytes func(path string) (uint64, error)
+	getVolumeID       func(path string) (string, error)
+}
+
+func NewDiskSpaceReservations(g *GlobalContext) *DiskSpaceReservations {
+	return &DiskSpaceReservations{
+		Contextified:      NewContextified(g),
+		reservations:      make(map[string]diskSpaceReservation),
+		released:          make(chan struct{}),
+		getAvailableBytes: diskAvailableBytes,
+		getVolumeID:       DiskVolumeID,
+	}
+}
+
+// NearestExistingPath returns p, or its closest ancestor that exists,
+// since jobs usually reserve space before creating where they write to.
+func NearestExistingPath(p string) string {
+	for {
+		if _, err := os.Stat(p); err == nil {
+			return p
+		}
+		parent := filepath.Dir(p)
+		if parent == p {
+			return p
+		}
+		p = parent
+	}
+}
+
+func (r *DiskSpaceReservations) volumeNeeds(needs []keybase1.DiskSpaceNeed) (
+	map[string]diskSpaceVolumeNeed, error) {
+	res := make(map[string]diskSpaceVolumeNeed)
+	for _, need := range needs {
+		if need.Bytes <= 0 {
+			continue
+		}
+		p := NearestExistingPath(need.Path)
+		volume, err := r.getVolumeID(p)
+		if err != nil {
+			return nil, err
+		}
+		v, ok := res[volume]
+		if !ok {
+			v.path = p
+		}
+		v.bytes += uint64(need.Bytes)
+		res[volume] = v
+	}
+	return res, nil
+}
+
+func (r *DiskSpaceReservations) releaseLocked(key string) {
+	if _, ok := r.reservations[key]; !ok {
+		return
+	}
+	delete(r.reservations, key)
+	close(r.released)
+	r.released = make(chan struct{})
+}
+
+func (r *DiskSpaceReservations) expireLocked(mctx MetaContext) {
+	now := r.G().Clock().Now()
+	for key, res := range r.reservations {
+		if !res.expires.IsZero() && now.After(res.expires) {
+			mctx.Debug("DiskSpaceReservations: %s (%s) expired", key, res.description)
+			r.releaseLocked(key)
+		}
+	}
+}
+
+// tryReserveLocked makes the reservation if there's room for it. If there
+// isn't, it also says whether there'd be room once other reservations are
+// released.
+func (r *DiskSpaceReservations) tryReserveLocked(mctx MetaContext, key string,
+	res diskSpaceReservation) (couldFit bool, err error) {
+	r.expireLocked(mctx)
+	volumes := make([]string, 0, len(res.needs))
+	for volume := range res.needs {
+		volumes = append(volumes, volume)
+	}
+	sort.Strings(volumes)
+	for _, volume := range volumes {
+		need := res.needs[volume]
+		var reserved uint64
+		for otherKey, other := range r.reservations {
+			if otherKey != key {
+				reserved += other.needs[volume].bytes
+			}
+		}
+		available, err := r.getAvailableBytes(need.path)
+		if err != nil {
+			return false, err
+		}
+		if need.bytes+reserved > available {
+			return need.bytes <= available, DiskSpaceReservationError{
+				Path:      need.path,
+				Needed:    need.bytes,
+				Available: available,
+				Reserved:  reserved,
+			}
+		}
+	}
+	r.reservations[key] = res
+	return true, nil
+}
+
+// Reserve reserves room for needs under key, replacing whatever was
+// already reserved under it. If there isn't room and wait is true, it
+// waits until enough other reservations are released, unless there
+// wouldn't be room even without them. ttl is how long the reservation
+// lasts without being replaced, or zero if it lasts until it's released.
+func (r *DiskSpaceReservations) Reserve(mctx MetaContext, key, description string,
+	needs []keybase1.DiskSpaceNeed, wait bool, ttl time.Duration) (err error) {
+	defer mctx.Trace(fmt.Sprintf("DiskSpaceReservations.Reserve(%s)", key), &err)()
+	res := diskSpaceReservation{description: description}
+	res.needs, err = r.volumeNeeds(needs)
+	if err != nil {
+		return err
+	}
+	for {
+		r.Lock()
+		if ttl > 0 {
+			res.expires = r.G().Clock().Now().Add(ttl)
+		}
+		couldFit, err := r.tryReserveLocked(mctx, key, res)
+		released := r.released
+		r.Unlock()
+		if err == nil || !wait || !couldFit {
+			return err
+		}
+		mctx.Debug("DiskSpaceReservations: %s (%s) waiting: %v", key, description, err)
+		select {
+		case <-released:
+		case <-r.G().Clock().After(diskSpaceRecheckInterval):
+		case <-mctx.Ctx().Done():
+			return mctx.Ctx().Err()
+		}
+	}
+}
+
+// Release releases the space reserved under key, if any.
+func (r *DiskSpaceReservations) Release(mctx MetaContext, key string) {
+	mctx.Debug("DiskSpaceReservations.Release(%s)", key)
+	r.Lock()
+	defer r.Unlock()
+	r.releaseLocked(key)
+}
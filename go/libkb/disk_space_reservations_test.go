@@ -0,0 +1,94 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	keybase1 "github.com/keybase/client/go/protocol/keybase1"
+	"github.com/keybase/clockwork"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskSpaceReservations(t *testing.T) {
+	tc := SetupTest(t, "TestDiskSpaceReservations", 1)
+	defer tc.Cleanup()
+	clock := clockwork.NewFakeClock()
+	tc.G.SetClock(clock)
+	mctx := NewMetaContextForTest(tc)
+
+	// Paths under a and b are on different volumes, with 100 bytes free
+	// each.
+	a, b := t.TempDir(), t.TempDir()
+	r := NewDiskSpaceReservations(tc.G)
+	r.getAvailableBytes = func(string) (uint64, error) { return 100, nil }
+	r.getVolumeID = func(p string) (string, error) {
+		if strings.HasPrefix(p, a) {
+			return "a", nil
+		}
+		return "b", nil
+	}
+	need := func(dir string, bytes int64) []keybase1.DiskSpaceNeed {
+		// These don't exist yet, so they count as being on their parent's
+		// volume.
+		return []keybase1.DiskSpaceNeed{{Path: filepath.Join(dir, "x", "y"), Bytes: bytes}}
+	}
+
+	// Needs on the same volume add up.
+	err := r.Reserve(mctx, "both", "", []keybase1.DiskSpaceNeed{
+		{Path: filepath.Join(a, "staging"), Bytes: 60}, {Path: filepath.Join(a, "out"), Bytes: 60}}, false, 0)
+	require.IsType(t, DiskSpaceReservationError{}, err)
+	require.Equal(t, uint64(120), err.(DiskSpaceReservationError).Needed)
+
+	require.NoError(t, r.Reserve(mctx, "job1", "", need(a, 60), false, 0))
+	require.NoError(t, r.Reserve(mctx, "job2", "", need(b, 60), false, 0))
+	err = r.Reserve(mctx, "job3", "", need(a, 60), false, 0)
+	require.Equal(t, DiskSpaceReservationError{
+		Path: a, Needed: 60, Available: 100, Reserved: 60}, err)
+
+	// Replacing a reservation doesn't count the old one against it.
+	require.NoError(t, r.Reserve(mctx, "job1", "", need(a, 90), false, 0))
+	require.NoError(t, r.Reserve(mctx, "job1", "", need(a, 30), false, 0))
+	require.NoError(t, r.Reserve(mctx, "job3", "", need(a, 60), false, 0))
+	r.Release(mctx, "job3")
+
+	// A waiting reservation goes through once there's room.
+	done := make(chan error, 1)
+	go func() {
+		done <- r.Reserve(mctx, "job4", "", need(a, 80), true, 0)
+	}()
+	select {
+	case err := <-done:
+		t.Fatalf("reserved while job1 still held its space: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	r.Release(mctx, "job1")
+	require.NoError(t, <-done)
+
+	// There's no point waiting for more than there is.
+	err = r.Reserve(mctx, "job5", "", need(b, 150), true, 0)
+	require.IsType(t, DiskSpaceReservationError{}, err)
+
+	// Waiting stops when the context is canceled.
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		done <- r.Reserve(mctx.WithContext(ctx), "job6", "", need(b, 50), true, 0)
+	}()
+	cancel()
+	require.Equal(t, context.Canceled, <-done)
+
+	// Reservations with a TTL expire unless they're replaced.
+	require.NoError(t, r.Reserve(mctx, "job2", "", need(b, 60), false, time.Hour))
+	clock.Advance(50 * time.Minute)
+	require.NoError(t, r.Reserve(mctx, "job2", "", need(b, 60), false, time.Hour))
+	clock.Advance(50 * time.Minute)
+	err = r.Reserve(mctx, "job7", "", need(b, 50), false, 0)
+	require.IsType(t, DiskSpaceReservationError{}, err)
+	clock.Advance(20 * time.Minute)
+	require.NoError(t, r.Reserve(mctx, "job7", "", need(b, 50), false, 0))
+}
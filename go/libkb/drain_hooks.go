@@ -0,0 +1,81 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	keybase1 "github.com/keybase/client/go/protocol/keybase1"
+)
+
+// `keybase ctl restart --drain` has the service call its drain hooks before
+// it stops, so that chat archives, KBFS archives and the like are paused
+// cleanly with their progress saved, rather than cut off wherever they
+// happen to be. Each picks its jobs back up by itself after the restart.
+
+type NamedDrainHook struct {
+	DrainHook
+	name string
+}
+
+func (g *GlobalContext) AddDrainHook(hook DrainHook, name string) {
+	g.hookMu.Lock()
+	defer g.hookMu.Unlock()
+	g.drainHooks = append(g.drainHooks, NamedDrainHook{
+		DrainHook: hook,
+		name:      name,
+	})
+}
+
+// CallDrainHooks calls all the drain hooks at once, and waits up to timeout
+// for them. The result has an entry for each hook, in the order they were
+// added; the ones that didn't finish in time are reported as timed out.
+func (g *GlobalContext) CallDrainHooks(mctx MetaContext, timeout time.Duration) (
+	res []keybase1.BackgroundJobDrainResult) {
+	defer mctx.Trace("GlobalContext.CallDrainHooks", nil)()
+	g.hookMu.RLock()
+	hooks := append([]NamedDrainHook(nil), g.drainHooks...)
+	g.hookMu.RUnlock()
+
+	mctx, cancel := mctx.WithTimeout(timeout)
+	defer cancel()
+	errChs := make([]chan error, len(hooks))
+	for i, h := range hooks {
+		errChs[i] = make(chan error, 1)
+		go func(h NamedDrainHook, errCh chan error) {
+			mctx.Debug("+ Drain hook [%v]", h.name)
+			err := h.OnDrain(mctx)
+			mctx.Debug("- Drain hook [%v] : %v", h.name, err)
+			errCh <- err
+		}(h, errChs[i])
+	}
+
+	for i, h := range hooks {
+		var err error
+		select {
+		case err = <-errChs[i]:
+		case <-mctx.Ctx().Done():
+			// Give it the chance to have finished just as time ran out.
+			select {
+			case err = <-errChs[i]:
+			default:
+				err = mctx.Ctx().Err()
+			}
+		}
+		if err == context.DeadlineExceeded {
+			err = fmt.Errorf("timed out after %s", timeout)
+		}
+		result := keybase1.BackgroundJobDrainResult{Name: h.name}
+		if err != nil {
+			mctx.Warning("| Drain hook [%v] : %s", h.name, err)
+			result.Error = err.Error()
+		} else {
+			result.Drained = true
+		}
+		res = append(res, result)
+	}
+	return res
+}
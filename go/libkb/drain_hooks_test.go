@@ -0,0 +1,58 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testDrainHook func(mctx MetaContext) error
+
+func (f testDrainHook) OnDrain(mctx MetaContext) error {
+	return f(mctx)
+}
+
+func TestCallDrainHooks(t *testing.T) {
+	tc := SetupTest(t, "TestCallDrainHooks", 1)
+	defer tc.Cleanup()
+	mctx := NewMetaContextForTest(tc)
+
+	require.Empty(t, tc.G.CallDrainHooks(mctx, time.Second))
+
+	drained := make(chan struct{})
+	tc.G.AddDrainHook(testDrainHook(func(mctx MetaContext) error {
+		close(drained)
+		return nil
+	}), "quick")
+	tc.G.AddDrainHook(testDrainHook(func(mctx MetaContext) error {
+		return errors.New("no state")
+	}), "broken")
+	// Ignores the deadline; the others are still reported as soon as it's
+	// up.
+	stuck := make(chan struct{})
+	defer close(stuck)
+	tc.G.AddDrainHook(testDrainHook(func(mctx MetaContext) error {
+		<-stuck
+		return nil
+	}), "stuck")
+
+	start := time.Now()
+	res := tc.G.CallDrainHooks(mctx, 200*time.Millisecond)
+	require.Less(t, time.Since(start), 5*time.Second)
+	<-drained
+	require.Len(t, res, 3)
+	require.Equal(t, "quick", res[0].Name)
+	require.True(t, res[0].Drained)
+	require.Empty(t, res[0].Error)
+	require.Equal(t, "broken", res[1].Name)
+	require.False(t, res[1].Drained)
+	require.Equal(t, "no state", res[1].Error)
+	require.Equal(t, "stuck", res[2].Name)
+	require.False(t, res[2].Drained)
+	require.Contains(t, res[2].Error, "timed out")
+}
@@ -124,6 +124,8 @@ func (n NullConfiguration) GetLevelDBWriteBufferMB() (int, bool)           { ret
 func (n NullConfiguration) GetChatInboxSourceLocalizeThreads() (int, bool) { return 1, false }
 func (n NullConfiguration) GetAttachmentHTTPStartPort() (int, bool)        { return 0, false }
 func (n NullConfiguration) GetAttachmentDisableMulti() (bool, bool)        { return false, false }
+func (n NullConfiguration) GetIntegrationGRPCPort() (int, bool)            { return 0, false }
+func (n NullConfiguration) GetTelemetryMode() string                       { return "" }
 func (n NullConfiguration) GetDisableTeamAuditor() (bool, bool)            { return false, false }
 func (n NullConfiguration) GetDisableMerkleAuditor() (bool, bool)          { return false, false }
 func (n NullConfiguration) GetDisableSearchIndexer() (bool, bool)          { return false, false }
@@ -1037,6 +1039,34 @@ func (e *Env) GetAttachmentDisableMulti() bool {
 	)
 }
 
+// GetIntegrationGRPCPort is the local port the read-only gRPC API for
+// third-party tools is served on, or 0 if it isn't.
+func (e *Env) GetIntegrationGRPCPort() int {
+	return e.GetInt(0,
+		e.cmd.GetIntegrationGRPCPort,
+		func() (int, bool) { return e.getEnvInt("KEYBASE_INTEGRATION_GRPC_PORT") },
+		e.GetConfig().GetIntegrationGRPCPort,
+	)
+}
+
+// GetTelemetryMode is whether the service records performance telemetry,
+// which is kept in memory and never reported anywhere. It's off unless set
+// to local.
+func (e *Env) GetTelemetryMode() TelemetryMode {
+	return TelemetryMode(e.GetString(
+		e.cmd.GetTelemetryMode,
+		func() string { return os.Getenv("KEYBASE_TELEMETRY_MODE") },
+		e.GetConfig().GetTelemetryMode,
+		func() string { return string(TelemetryModeOff) },
+	))
+}
+
+// GetIntegrationTokenPath is where the token callers of the gRPC API for
+// third-party tools need is kept.
+func (e *Env) GetIntegrationTokenPath() string {
+	return filepath.Join(e.GetDataDir(), "integration_token")
+}
+
 func (e *Env) GetDisableTeamAuditor() bool {
 	return e.GetBool(false,
 		e.cmd.GetDisableTeamAuditor,
@@ -48,6 +48,13 @@ type DbNukeHook interface {
 	OnDbNuke(mctx MetaContext) error
 }
 
+// DrainHook is implemented by whatever runs long jobs in the background, like
+// chat archives. OnDrain stops them at a point they can pick up from after a
+// restart and saves their state, or gives up when mctx is done.
+type DrainHook interface {
+	OnDrain(mctx MetaContext) error
+}
+
 type GlobalContext struct {
 	Log                              logger.Logger         // Handles all logging
 	PerfLog                          logger.Logger         // Handles all performance event logging
@@ -55,6 +62,8 @@ type GlobalContext struct {
 	GUILogFile                       *logger.LogFileWriter // GUI logs
 	Env                              *Env                  // Env variables, cmdline args & config
 	SKBKeyringMu                     *sync.Mutex           // Protects all attempts to mutate the SKBKeyringFile
+	TeamExternalSyncMu               *sync.Mutex           // Protects the local state of team external syncs
+	SeitanExtrasMu                   *sync.Mutex           // Protects the locally stored seitan invite extras
 	Keyrings                         *Keyrings             // Gpg Keychains holding keys
 	perUserKeyringMu                 *sync.Mutex
 	perUserKeyring                   *PerUserKeyring             // Keyring holding per user keys
@@ -74,6 +83,7 @@ type GlobalContext struct {
 	RPCCanceler                      *RPCCanceler                // register live RPCs so they can be cancelleed en masse
 	IdentifyDispatch                 *IdentifyDispatch           // get notified of identify successes
 	Identify3State                   *Identify3State             // keep track of Identify3 sessions
+	DiskSpaceReservations            *DiskSpaceReservations      // disk space reserved by jobs that write to the local disk
 	vidMu                            *sync.Mutex                 // protect VID
 	RuntimeStats                     RuntimeStats                // performance runtime stats
 
@@ -141,6 +151,9 @@ type GlobalContext struct {
 	loginHooks         []LoginHook               // call these on login
 	logoutHooks        []NamedLogoutHook         // call these on logout
 	dbNukeHooks        []NamedDbNukeHook         // call these on dbnuke
+	drainHooks         []NamedDrainHook          // call these before a managed restart
+	telemetryMu        *sync.Mutex               // protects telemetry
+	telemetry          TelemetrySink             // counters and timings, kept locally if at all
 	GregorState        GregorState               // for dismissing gregor items that we've handled
 	GregorListener     GregorListener            // for alerting about clients connecting and registering UI protocols
 	oodiMu             *sync.RWMutex             // For manipulating the OutOfDateInfo
@@ -226,6 +239,8 @@ func NewGlobalContext() *GlobalContext {
 		PerfLog:            log,
 		VDL:                NewVDebugLog(log),
 		SKBKeyringMu:       new(sync.Mutex),
+		TeamExternalSyncMu: new(sync.Mutex),
+		SeitanExtrasMu:     new(sync.Mutex),
 		perUserKeyringMu:   new(sync.Mutex),
 		vidMu:              new(sync.Mutex),
 		cacheMu:            new(sync.RWMutex),
@@ -234,6 +249,7 @@ func NewGlobalContext() *GlobalContext {
 		clockMu:            new(sync.Mutex),
 		clock:              clockwork.NewRealClock(),
 		hookMu:             new(sync.RWMutex),
+		telemetryMu:        new(sync.Mutex),
 		oodiMu:             new(sync.RWMutex),
 		outOfDateInfo:      &keybase1.OutOfDateInfo{},
 		lastUpgradeWarning: new(time.Time),
@@ -309,6 +325,7 @@ func (g *GlobalContext) Init() *GlobalContext {
 	g.RPCCanceler = NewRPCCanceler()
 	g.IdentifyDispatch = NewIdentifyDispatch()
 	g.Identify3State = NewIdentify3State(g)
+	g.DiskSpaceReservations = NewDiskSpaceReservations(g)
 	g.GregorState = newNullGregorState()
 	g.LocalNetworkInstrumenterStorage = NewDiskInstrumentationStorage(g, keybase1.NetworkSource_LOCAL)
 	g.RemoteNetworkInstrumenterStorage = NewDiskInstrumentationStorage(g, keybase1.NetworkSource_REMOTE)
@@ -1558,6 +1575,65 @@ func (g *GlobalContext) ReplaceSecretStore(ctx context.Context) error {
 	return nil
 }
 
+// SetSecretStoreBackend makes the secret store use backend from now on,
+// including after a restart, by setting it in the config. It's for headless
+// signups, which have no passphrase to fall back on if the device's secret
+// ends up somewhere the service won't look. Since secrets already stored in
+// the old backend would be left behind, it refuses to switch if there are
+// any.
+func (g *GlobalContext) SetSecretStoreBackend(m MetaContext, backend keybase1.SecretStoreBackend) error {
+	matches := func() bool {
+		switch backend {
+		case keybase1.SecretStoreBackend_FILE:
+			return g.Env.ForceSecretStoreFile() && !g.Env.GetForceLinuxKeyring()
+		default:
+			return !g.Env.ForceSecretStoreFile() && g.Env.GetForceLinuxKeyring()
+		}
+	}
+	switch backend {
+	case keybase1.SecretStoreBackend_DEFAULT:
+		return nil
+	case keybase1.SecretStoreBackend_FILE, keybase1.SecretStoreBackend_SYSTEM:
+	default:
+		return fmt.Errorf("unknown secret store backend: %v", backend)
+	}
+	if matches() {
+		return nil
+	}
+
+	g.secretStoreMu.Lock()
+	defer g.secretStoreMu.Unlock()
+
+	users, err := g.secretStore.GetUsersWithStoredSecrets(m)
+	if err != nil {
+		return err
+	}
+	if len(users) > 0 {
+		return fmt.Errorf("can't switch the secret store to %v with secrets stored for %v",
+			backend, users)
+	}
+
+	w := g.Env.GetConfigWriter()
+	forceFile := backend == keybase1.SecretStoreBackend_FILE
+	if err := w.SetForceSecretStoreFile(forceFile); err != nil {
+		return err
+	}
+	if err := w.SetForceLinuxKeyring(!forceFile); err != nil {
+		return err
+	}
+	if err := g.ConfigReload(); err != nil {
+		return err
+	}
+	if !matches() {
+		return fmt.Errorf("the secret store is overridden by the environment or command line; "+
+			"can't switch it to %v", backend)
+	}
+
+	g.secretStore = NewSecretStoreLocked(m)
+	m.Debug("SetSecretStoreBackend(%v) success", backend)
+	return nil
+}
+
 func (g *GlobalContext) IsOneshot(ctx context.Context) (bool, error) {
 	uc, err := g.Env.GetConfig().GetUserConfig()
 	if err != nil {
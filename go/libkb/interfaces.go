@@ -107,6 +107,8 @@ type configGetter interface {
 	GetRememberPassphrase(NormalizedUsername) (bool, bool)
 	GetAttachmentHTTPStartPort() (int, bool)
 	GetAttachmentDisableMulti() (bool, bool)
+	GetIntegrationGRPCPort() (int, bool)
+	GetTelemetryMode() string
 	GetDisableTeamAuditor() (bool, bool)
 	GetDisableTeamBoxAuditor() (bool, bool)
 	GetDisableEKBackgroundKeygen() (bool, bool)
@@ -252,6 +254,8 @@ type ConfigWriter interface {
 	SetRememberPassphrase(NormalizedUsername, bool) error
 	SetPassphraseState(keybase1.PassphraseState) error
 	SetStayLoggedOut(bool) error
+	SetForceLinuxKeyring(bool) error
+	SetForceSecretStoreFile(bool) error
 	Reset()
 	BeginTransaction() (ConfigWriterTransacter, error)
 
@@ -1107,6 +1111,9 @@ type ChatHelper interface {
 	// InTeam gives a best effort to answer team membership based on the current state of the inbox cache
 	InTeam(context.Context, gregor1.UID, keybase1.TeamID) (bool, error)
 	BulkAddToConv(context.Context, gregor1.UID, chat1.ConversationID, []string) error
+	// SendTeamReadme DMs the team's readme, if it has one, to a member who
+	// was just added.
+	SendTeamReadme(ctx context.Context, teamID keybase1.TeamID, teamname, username string) error
 }
 
 // Resolver resolves human-readable usernames (joe) and user asssertions (joe+joe@github)
@@ -0,0 +1,54 @@
+package libkb
+
+import (
+	"fmt"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// NetworkClass is what the current connection costs to use, for deciding what
+// to download without the user asking for it.
+type NetworkClass int
+
+const (
+	// NetworkClassUnmetered is Wi-Fi or wired, and the desktop.
+	NetworkClassUnmetered NetworkClass = iota
+	NetworkClassCellular
+	// NetworkClassMetered is a connection the OS says is metered, like a
+	// phone's hotspot, or one it can't tell the kind of.
+	NetworkClassMetered
+	NetworkClassOffline
+)
+
+func (c NetworkClass) String() string {
+	switch c {
+	case NetworkClassUnmetered:
+		return "unmetered"
+	case NetworkClassCellular:
+		return "cellular"
+	case NetworkClassMetered:
+		return "metered"
+	case NetworkClassOffline:
+		return "offline"
+	default:
+		return fmt.Sprintf("NetworkClass(%d)", int(c))
+	}
+}
+
+// ClassifyNetwork returns the class of a connection in state, which the OS
+// may also say is metered.
+func ClassifyNetwork(state keybase1.MobileNetworkState, metered bool) NetworkClass {
+	switch state {
+	case keybase1.MobileNetworkState_NONE:
+		return NetworkClassOffline
+	case keybase1.MobileNetworkState_CELLULAR:
+		return NetworkClassCellular
+	case keybase1.MobileNetworkState_WIFI, keybase1.MobileNetworkState_NOTAVAILABLE:
+		if metered {
+			return NetworkClassMetered
+		}
+		return NetworkClassUnmetered
+	default:
+		return NetworkClassMetered
+	}
+}
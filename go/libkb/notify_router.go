@@ -37,6 +37,8 @@ type NotifyListener interface {
 	FavoritesChanged(uid keybase1.UID)
 	FSSubscriptionNotify(arg keybase1.FSSubscriptionNotifyArg)
 	FSSubscriptionNotifyPath(arg keybase1.FSSubscriptionNotifyPathArg)
+	SimpleFSArchiveProgress(progress keybase1.SimpleFSArchiveProgress)
+	FSQuotaAlert(alert keybase1.SimpleFSQuotaAlert)
 	PaperKeyCached(uid keybase1.UID, encKID keybase1.KID, sigKID keybase1.KID)
 	KeyfamilyChanged(uid keybase1.UID)
 	NewChatActivity(uid keybase1.UID, activity chat1.ChatActivity, source chat1.ChatActivitySource)
@@ -66,7 +68,7 @@ type NotifyListener interface {
 		bytesComplete, bytesTotal int64)
 	ChatAttachmentDownloadComplete(uid keybase1.UID, convID chat1.ConversationID, msgID chat1.MessageID)
 	ChatArchiveProgress(jobID chat1.ArchiveJobID,
-		messagesComplete, messagesTotal int64)
+		messagesComplete, messagesTotal int64, conv *chat1.ArchiveChatConvProgress)
 	ChatArchiveComplete(jobID chat1.ArchiveJobID)
 	ChatPaymentInfo(uid keybase1.UID, convID chat1.ConversationID, msgID chat1.MessageID, info chat1.UIPaymentInfo)
 	ChatRequestInfo(uid keybase1.UID, convID chat1.ConversationID, msgID chat1.MessageID, info chat1.UIRequestInfo)
@@ -74,6 +76,9 @@ type NotifyListener interface {
 	ChatConvUpdate(uid keybase1.UID, convID chat1.ConversationID)
 	ChatWelcomeMessageLoaded(teamID keybase1.TeamID, message chat1.WelcomeMessageDisplay)
 	ChatParticipantsInfo(participants map[chat1.ConvIDStr][]chat1.UIParticipant)
+	ChatSplitConversationProgress(convID chat1.ConversationID, messagesComplete, messagesTotal int64)
+	ChatMentionDigest(uid keybase1.UID, digest chat1.MentionDigest)
+	ChatIdentityChangeAlert(uid keybase1.UID, convID chat1.ConversationID, alert chat1.IdentityChangeAlert)
 	PGPKeyInSecretStoreFile()
 	BadgeState(badgeState keybase1.BadgeState)
 	ReachabilityChanged(r keybase1.Reachability)
@@ -145,6 +150,9 @@ func (n *NoopNotifyListener) FSSubscriptionNotify(arg keybase1.FSSubscriptionNot
 }
 func (n *NoopNotifyListener) FSSubscriptionNotifyPath(arg keybase1.FSSubscriptionNotifyPathArg) {
 }
+func (n *NoopNotifyListener) SimpleFSArchiveProgress(progress keybase1.SimpleFSArchiveProgress) {
+}
+func (n *NoopNotifyListener) FSQuotaAlert(alert keybase1.SimpleFSQuotaAlert) {}
 func (n *NoopNotifyListener) PaperKeyCached(uid keybase1.UID, encKID keybase1.KID, sigKID keybase1.KID) {
 }
 func (n *NoopNotifyListener) KeyfamilyChanged(uid keybase1.UID) {}
@@ -192,7 +200,8 @@ func (n *NoopNotifyListener) ChatAttachmentDownloadProgress(uid keybase1.UID, co
 func (n *NoopNotifyListener) ChatAttachmentDownloadComplete(uid keybase1.UID, convID chat1.ConversationID,
 	msgID chat1.MessageID) {
 }
-func (n *NoopNotifyListener) ChatArchiveProgress(jobID chat1.ArchiveJobID, messagesComplete, messagesTotal int64) {
+func (n *NoopNotifyListener) ChatArchiveProgress(jobID chat1.ArchiveJobID, messagesComplete, messagesTotal int64,
+	conv *chat1.ArchiveChatConvProgress) {
 }
 func (n *NoopNotifyListener) ChatArchiveComplete(jobID chat1.ArchiveJobID) {
 }
@@ -210,6 +219,13 @@ func (n *NoopNotifyListener) ChatWelcomeMessageLoaded(keybase1.TeamID, chat1.Wel
 func (n *NoopNotifyListener) ChatParticipantsInfo(
 	participants map[chat1.ConvIDStr][]chat1.UIParticipant) {
 }
+func (n *NoopNotifyListener) ChatSplitConversationProgress(convID chat1.ConversationID,
+	messagesComplete, messagesTotal int64) {
+}
+func (n *NoopNotifyListener) ChatMentionDigest(uid keybase1.UID, digest chat1.MentionDigest) {}
+func (n *NoopNotifyListener) ChatIdentityChangeAlert(uid keybase1.UID, convID chat1.ConversationID,
+	alert chat1.IdentityChangeAlert) {
+}
 
 func (n *NoopNotifyListener) PGPKeyInSecretStoreFile()                    {}
 func (n *NoopNotifyListener) BadgeState(badgeState keybase1.BadgeState)   {}
@@ -696,6 +712,58 @@ func (n *NotifyRouter) HandleFSFavoritesChanged() {
 	})
 }
 
+// HandleSimpleFSArchiveProgress is called as KBFS archive jobs copy and zip
+// files. It will broadcast the messages to all curious listeners.
+func (n *NotifyRouter) HandleSimpleFSArchiveProgress(progress keybase1.SimpleFSArchiveProgress) {
+	if n == nil {
+		return
+	}
+	// For all connections we currently have open...
+	n.cm.ApplyAll(func(id ConnectionID, xp rpc.Transporter) bool {
+		// If the connection wants the `simplefsarchive` notification type
+		if n.getNotificationChannels(id).Simplefsarchive {
+			// In the background do...
+			go func() {
+				// A send of a `SimpleFSArchiveProgress` RPC with the
+				// progress
+				_ = (keybase1.NotifySimpleFSArchiveProgressClient{
+					Cli: rpc.NewClient(xp, NewContextifiedErrorUnwrapper(n.G()), nil),
+				}).SimpleFSArchiveProgress(context.Background(), progress)
+			}()
+		}
+		return true
+	})
+	n.runListeners(func(listener NotifyListener) {
+		listener.SimpleFSArchiveProgress(progress)
+	})
+}
+
+// HandleFSQuotaAlert is called when the user's KBFS usage crosses one of
+// their quota alert thresholds. It will broadcast the messages to all
+// curious listeners.
+func (n *NotifyRouter) HandleFSQuotaAlert(alert keybase1.SimpleFSQuotaAlert) {
+	if n == nil {
+		return
+	}
+	// For all connections we currently have open...
+	n.cm.ApplyAll(func(id ConnectionID, xp rpc.Transporter) bool {
+		// If the connection wants the `kbfs` notification type
+		if n.getNotificationChannels(id).Kbfs {
+			// In the background do...
+			go func() {
+				// A send of a `FSQuotaAlert` RPC with the alert
+				_ = (keybase1.NotifyFSClient{
+					Cli: rpc.NewClient(xp, NewContextifiedErrorUnwrapper(n.G()), nil),
+				}).FSQuotaAlert(context.Background(), alert)
+			}()
+		}
+		return true
+	})
+	n.runListeners(func(listener NotifyListener) {
+		listener.FSQuotaAlert(alert)
+	})
+}
+
 // HandleFSActivity is called for any KBFS notification. It will broadcast the messages
 // to all curious listeners.
 func (n *NotifyRouter) HandleFSActivity(activity keybase1.FSNotification) {
@@ -1506,7 +1574,8 @@ func (n *NotifyRouter) HandleChatAttachmentDownloadComplete(ctx context.Context,
 	n.G().Log.CDebugf(ctx, "- Sent ChatAttachmentDownloadComplete notification")
 }
 
-func (n *NotifyRouter) HandleChatArchiveProgress(ctx context.Context, jobID chat1.ArchiveJobID, messagesComplete, messagesTotal int64) {
+func (n *NotifyRouter) HandleChatArchiveProgress(ctx context.Context, jobID chat1.ArchiveJobID, messagesComplete, messagesTotal int64,
+	conv *chat1.ArchiveChatConvProgress) {
 	if n == nil {
 		return
 	}
@@ -1522,6 +1591,7 @@ func (n *NotifyRouter) HandleChatArchiveProgress(ctx context.Context, jobID chat
 					JobID:            jobID,
 					MessagesComplete: messagesComplete,
 					MessagesTotal:    messagesTotal,
+					Conv:             conv,
 				})
 				wg.Done()
 			}()
@@ -1531,7 +1601,7 @@ func (n *NotifyRouter) HandleChatArchiveProgress(ctx context.Context, jobID chat
 	wg.Wait()
 
 	n.runListeners(func(listener NotifyListener) {
-		listener.ChatArchiveProgress(jobID, messagesComplete, messagesTotal)
+		listener.ChatArchiveProgress(jobID, messagesComplete, messagesTotal, conv)
 	})
 	n.G().Log.CDebugf(ctx, "- Sent ChatArchiveProgress notification")
 }
@@ -1693,6 +1763,98 @@ func (n *NotifyRouter) HandleChatParticipantsInfo(ctx context.Context,
 	})
 }
 
+func (n *NotifyRouter) HandleChatSplitConversationProgress(ctx context.Context, convID chat1.ConversationID,
+	messagesComplete, messagesTotal int64) {
+	if n == nil {
+		return
+	}
+	var wg sync.WaitGroup
+	n.G().Log.CDebugf(ctx, "+ Sending ChatSplitConversationProgress notification")
+	n.cm.ApplyAll(func(id ConnectionID, xp rpc.Transporter) bool {
+		if n.getNotificationChannels(id).Chat {
+			wg.Add(1)
+			go func() {
+				_ = (chat1.NotifyChatClient{
+					Cli: rpc.NewClient(xp, NewContextifiedErrorUnwrapper(n.G()), nil),
+				}).ChatSplitConversationProgress(context.Background(), chat1.ChatSplitConversationProgressArg{
+					ConvID:           convID,
+					MessagesComplete: messagesComplete,
+					MessagesTotal:    messagesTotal,
+				})
+				wg.Done()
+			}()
+		}
+		return true
+	})
+	wg.Wait()
+
+	n.runListeners(func(listener NotifyListener) {
+		listener.ChatSplitConversationProgress(convID, messagesComplete, messagesTotal)
+	})
+	n.G().Log.CDebugf(ctx, "- Sent ChatSplitConversationProgress notification")
+}
+
+func (n *NotifyRouter) HandleChatMentionDigest(ctx context.Context, uid keybase1.UID,
+	digest chat1.MentionDigest) {
+	if n == nil {
+		return
+	}
+	var wg sync.WaitGroup
+	n.G().Log.CDebugf(ctx, "+ Sending ChatMentionDigest notification")
+	n.cm.ApplyAll(func(id ConnectionID, xp rpc.Transporter) bool {
+		if n.getNotificationChannels(id).Chat {
+			wg.Add(1)
+			go func() {
+				_ = (chat1.NotifyChatClient{
+					Cli: rpc.NewClient(xp, NewContextifiedErrorUnwrapper(n.G()), nil),
+				}).ChatMentionDigest(context.Background(), chat1.ChatMentionDigestArg{
+					Uid:    uid,
+					Digest: digest,
+				})
+				wg.Done()
+			}()
+		}
+		return true
+	})
+	wg.Wait()
+
+	n.runListeners(func(listener NotifyListener) {
+		listener.ChatMentionDigest(uid, digest)
+	})
+	n.G().Log.CDebugf(ctx, "- Sent ChatMentionDigest notification")
+}
+
+func (n *NotifyRouter) HandleChatIdentityChangeAlert(ctx context.Context, uid keybase1.UID,
+	convID chat1.ConversationID, alert chat1.IdentityChangeAlert) {
+	if n == nil {
+		return
+	}
+	var wg sync.WaitGroup
+	n.G().Log.CDebugf(ctx, "+ Sending ChatIdentityChangeAlert notification")
+	n.cm.ApplyAll(func(id ConnectionID, xp rpc.Transporter) bool {
+		if n.getNotificationChannels(id).Chat {
+			wg.Add(1)
+			go func() {
+				_ = (chat1.NotifyChatClient{
+					Cli: rpc.NewClient(xp, NewContextifiedErrorUnwrapper(n.G()), nil),
+				}).ChatIdentityChangeAlert(context.Background(), chat1.ChatIdentityChangeAlertArg{
+					Uid:    uid,
+					ConvID: convID,
+					Alert:  alert,
+				})
+				wg.Done()
+			}()
+		}
+		return true
+	})
+	wg.Wait()
+
+	n.runListeners(func(listener NotifyListener) {
+		listener.ChatIdentityChangeAlert(uid, convID, alert)
+	})
+	n.G().Log.CDebugf(ctx, "- Sent ChatIdentityChangeAlert notification")
+}
+
 type notifyChatFn1 func(context.Context, *chat1.NotifyChatClient)
 type notifyChatFn2 func(context.Context, NotifyListener)
 
@@ -28,6 +28,28 @@ type SaltpackEncryptArg struct {
 // receivers from the given sender, and writes it to sink.  If
 // Binary is false, the data written to sink will be armored.
 func SaltpackEncrypt(m MetaContext, arg *SaltpackEncryptArg) error {
+	plainsink, err := NewSaltpackEncryptStream(arg)
+	if err != nil {
+		return err
+	}
+
+	n, err := io.Copy(plainsink, arg.Source)
+	if err != nil {
+		return err
+	}
+
+	m.Debug("Encrypt: wrote %d bytes", n)
+
+	if err := plainsink.Close(); err != nil {
+		return err
+	}
+	return arg.Sink.Close()
+}
+
+// NewSaltpackEncryptStream returns a stream that encrypts what's written to
+// it like SaltpackEncrypt does, for writers that don't have a source to read
+// from. arg.Source is ignored. Closing the stream doesn't close arg.Sink.
+func NewSaltpackEncryptStream(arg *SaltpackEncryptArg) (io.WriteCloser, error) {
 	var receiverBoxKeys []saltpack.BoxPublicKey
 	for _, k := range arg.Receivers {
 		// Since signcryption became the default, we never use visible
@@ -54,7 +76,7 @@ func SaltpackEncrypt(m MetaContext, arg *SaltpackEncryptArg) error {
 	var err error
 	if !arg.EncryptionOnlyMode {
 		if arg.SaltpackVersion.Major == 1 {
-			return errors.New("specifying saltpack version 1 requires repudiable authentication")
+			return nil, errors.New("specifying saltpack version 1 requires repudiable authentication")
 		}
 		var signer saltpack.SigningSecretKey
 		if !arg.SenderSigning.IsNil() {
@@ -73,18 +95,7 @@ func SaltpackEncrypt(m MetaContext, arg *SaltpackEncryptArg) error {
 		}
 	}
 	if err != nil {
-		return err
-	}
-
-	n, err := io.Copy(plainsink, arg.Source)
-	if err != nil {
-		return err
+		return nil, err
 	}
-
-	m.Debug("Encrypt: wrote %d bytes", n)
-
-	if err := plainsink.Close(); err != nil {
-		return err
-	}
-	return arg.Sink.Close()
+	return plainsink, nil
 }
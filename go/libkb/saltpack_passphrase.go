@@ -0,0 +1,122 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"github.com/keybase/saltpack"
+	"golang.org/x/crypto/scrypt"
+)
+
+// A saltpack message can be encrypted to a passphrase, as a symmetric
+// receiver whose key is derived from the passphrase with scrypt. The
+// receiver's identifier is a fixed prefix followed by the salt, so whoever
+// has the passphrase can derive the key again without knowing anything else
+// about the message.
+
+const (
+	saltpackPassphraseIdentifierPrefix = "keybase passphrase v1:"
+	saltpackPassphraseSaltLen          = 16
+	SaltpackPassphraseScryptCost       = 1 << 15
+	SaltpackPassphraseScryptR          = 8
+	SaltpackPassphraseScryptP          = 1
+)
+
+func saltpackPassphraseKey(passphrase string, salt []byte) (key saltpack.SymmetricKey, err error) {
+	b, err := scrypt.Key([]byte(passphrase), salt, SaltpackPassphraseScryptCost,
+		SaltpackPassphraseScryptR, SaltpackPassphraseScryptP, len(key))
+	if err != nil {
+		return key, err
+	}
+	copy(key[:], b)
+	return key, nil
+}
+
+// NewSaltpackPassphraseReceiver returns a symmetric receiver for
+// SaltpackEncryptArg that whoever knows passphrase can decrypt for.
+func NewSaltpackPassphraseReceiver(passphrase string) (res saltpack.ReceiverSymmetricKey, err error) {
+	if len(passphrase) < MinPassphraseLength {
+		return res, PassphraseError{Msg: "passphrase is too short"}
+	}
+	salt, err := RandBytes(saltpackPassphraseSaltLen)
+	if err != nil {
+		return res, err
+	}
+	key, err := saltpackPassphraseKey(passphrase, salt)
+	if err != nil {
+		return res, err
+	}
+	return saltpack.ReceiverSymmetricKey{
+		Key:        key,
+		Identifier: append([]byte(saltpackPassphraseIdentifierPrefix), salt...),
+	}, nil
+}
+
+// SaltpackPassphraseResolver resolves the keys of a message's passphrase
+// receivers, if it has any.
+type SaltpackPassphraseResolver struct {
+	Passphrase string
+}
+
+var _ saltpack.SymmetricKeyResolver = SaltpackPassphraseResolver{}
+
+func (r SaltpackPassphraseResolver) ResolveKeys(identifiers [][]byte) ([]*saltpack.SymmetricKey, error) {
+	res := make([]*saltpack.SymmetricKey, len(identifiers))
+	prefix := []byte(saltpackPassphraseIdentifierPrefix)
+	for i, identifier := range identifiers {
+		if !bytes.HasPrefix(identifier, prefix) ||
+			len(identifier) != len(prefix)+saltpackPassphraseSaltLen {
+			continue
+		}
+		key, err := saltpackPassphraseKey(r.Passphrase, identifier[len(prefix):])
+		if err != nil {
+			return nil, err
+		}
+		res[i] = &key
+	}
+	return res, nil
+}
+
+// saltpackPassphraseKeyring has no keys of its own, since a message
+// encrypted to a passphrase is decrypted with the resolver.
+type saltpackPassphraseKeyring struct {
+	emptyKeyring
+}
+
+var _ saltpack.SigncryptKeyring = saltpackPassphraseKeyring{}
+
+func (saltpackPassphraseKeyring) LookupBoxSecretKey(kids [][]byte) (int, saltpack.BoxSecretKey) {
+	return -1, nil
+}
+
+func (saltpackPassphraseKeyring) LookupBoxPublicKey(kid []byte) saltpack.BoxPublicKey {
+	return naclKeyring{}.LookupBoxPublicKey(kid)
+}
+
+func (saltpackPassphraseKeyring) GetAllBoxSecretKeys() []saltpack.BoxSecretKey {
+	return nil
+}
+
+func (k saltpackPassphraseKeyring) ImportBoxEphemeralKey(kid []byte) saltpack.BoxPublicKey {
+	return k.LookupBoxPublicKey(kid)
+}
+
+func (saltpackPassphraseKeyring) LookupSigningPublicKey(kid []byte) saltpack.SigningPublicKey {
+	return naclKeyring{}.LookupSigningPublicKey(kid)
+}
+
+// SaltpackPassphraseDecrypt decrypts a binary or armored saltpack message
+// from source that was encrypted to passphrase, and writes it to sink.
+func SaltpackPassphraseDecrypt(m MetaContext, source io.Reader, sink io.WriteCloser,
+	passphrase string) error {
+	if len(passphrase) == 0 {
+		return errors.New("a passphrase is needed")
+	}
+	_, err := SaltpackDecrypt(m, source, sink, saltpackPassphraseKeyring{}, nil, nil,
+		SaltpackPassphraseResolver{Passphrase: passphrase})
+	return err
+}
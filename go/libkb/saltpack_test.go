@@ -119,3 +119,38 @@ func TestSaltpackEncDec(t *testing.T) {
 		require.Equal(t, decError.Cause.Err, saltpack.ErrNoDecryptionKey)
 	}
 }
+
+func TestSaltpackPassphrase(t *testing.T) {
+	tc := SetupTest(t, "TestSaltpackPassphrase", 1)
+	defer tc.Cleanup()
+
+	m := NewMetaContextForTest(tc)
+
+	_, err := NewSaltpackPassphraseReceiver("short")
+	require.Error(t, err)
+	receiver, err := NewSaltpackPassphraseReceiver("correct horse battery")
+	require.NoError(t, err)
+
+	message := "The Magic Words are Squeamish Ossifrage"
+	var buf outputBuffer
+	plainsink, err := NewSaltpackEncryptStream(&SaltpackEncryptArg{
+		Sink:               &buf,
+		Binary:             true,
+		SymmetricReceivers: []saltpack.ReceiverSymmetricKey{receiver},
+	})
+	require.NoError(t, err)
+	_, err = plainsink.Write([]byte(message))
+	require.NoError(t, err)
+	require.NoError(t, plainsink.Close())
+	ciphertext := buf.String()
+
+	var out outputBuffer
+	err = SaltpackPassphraseDecrypt(m, strings.NewReader(ciphertext), &out, "correct horse battery")
+	require.NoError(t, err)
+	require.Equal(t, message, out.String())
+
+	out.Reset()
+	err = SaltpackPassphraseDecrypt(m, strings.NewReader(ciphertext), &out, "wrong horse battery")
+	require.Error(t, err)
+	require.Empty(t, out.String())
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"sync"
+	"time"
+
+	keybase1 "github.com/keybase/client/go/protocol/keybase1"
+	"github.com/keybase/clockwork"
+)
+
+// Telemetry is counters and timings recorded by the chat, KBFS and archive
+// code, for looking into how they perform. None of it is ever sent
+// anywhere. It's off by default; with telemetry_mode set to local, events
+// are kept in a ring buffer in memory, which `keybase ctl telemetry`
+// shows.
+
+type TelemetryMode string
+
+const (
+	TelemetryModeOff   TelemetryMode = "off"
+	TelemetryModeLocal TelemetryMode = "local"
+)
+
+// TelemetrySink is where telemetry events go.
+type TelemetrySink interface {
+	Count(name string, delta int64)
+	Timing(name string, d time.Duration)
+	// Events returns the events kept, oldest first, and whether any are.
+	Events() ([]keybase1.TelemetryEvent, bool)
+}
+
+type nullTelemetrySink struct{}
+
+func (nullTelemetrySink) Count(string, int64)                       {}
+func (nullTelemetrySink) Timing(string, time.Duration)              {}
+func (nullTelemetrySink) Events() ([]keybase1.TelemetryEvent, bool) { return nil, false }
+
+var _ TelemetrySink = nullTelemetrySink{}
+
+// How many events the local sink keeps.
+const localTelemetrySinkSize = 5000
+
+// LocalTelemetrySink keeps the latest events in memory.
+type LocalTelemetrySink struct {
+	sync.Mutex
+	clock  clockwork.Clock
+	events []keybase1.TelemetryEvent
+	// Where the next event goes, once the buffer's full.
+	next int
+}
+
+var _ TelemetrySink = (*LocalTelemetrySink)(nil)
+
+func NewLocalTelemetrySink(clock clockwork.Clock, size int) *LocalTelemetrySink {
+	return &LocalTelemetrySink{
+		clock:  clock,
+		events: make([]keybase1.TelemetryEvent, 0, size),
+	}
+}
+
+func (s *LocalTelemetrySink) add(name string, typ keybase1.TelemetryEventType, value int64) {
+	s.Lock()
+	defer s.Unlock()
+	event := keybase1.TelemetryEvent{
+		Time:  keybase1.ToTime(s.clock.Now()),
+		Name:  name,
+		Type:  typ,
+		Value: value,
+	}
+	if len(s.events) < cap(s.events) {
+		s.events = append(s.events, event)
+		return
+	}
+	s.events[s.next] = event
+	s.next = (s.next + 1) % len(s.events)
+}
+
+func (s *LocalTelemetrySink) Count(name string, delta int64) {
+	s.add(name, keybase1.TelemetryEventType_COUNT, delta)
+}
+
+func (s *LocalTelemetrySink) Timing(name string, d time.Duration) {
+	s.add(name, keybase1.TelemetryEventType_TIMING, d.Milliseconds())
+}
+
+func (s *LocalTelemetrySink) Events() ([]keybase1.TelemetryEvent, bool) {
+	s.Lock()
+	defer s.Unlock()
+	res := make([]keybase1.TelemetryEvent, 0, len(s.events))
+	res = append(res, s.events[s.next:]...)
+	res = append(res, s.events[:s.next]...)
+	return res, true
+}
+
+// Telemetry returns where telemetry events go, which depends on the
+// configured telemetry mode unless a sink has been set.
+func (g *GlobalContext) Telemetry() TelemetrySink {
+	g.telemetryMu.Lock()
+	defer g.telemetryMu.Unlock()
+	if g.telemetry == nil {
+		switch mode := g.Env.GetTelemetryMode(); mode {
+		case TelemetryModeOff:
+			g.telemetry = nullTelemetrySink{}
+		case TelemetryModeLocal:
+			g.telemetry = NewLocalTelemetrySink(g.Clock(), localTelemetrySinkSize)
+		default:
+			g.Log.Warning("Unknown telemetry mode %q; leaving telemetry off", mode)
+			g.telemetry = nullTelemetrySink{}
+		}
+	}
+	return g.telemetry
+}
+
+func (g *GlobalContext) SetTelemetrySink(sink TelemetrySink) {
+	g.telemetryMu.Lock()
+	defer g.telemetryMu.Unlock()
+	g.telemetry = sink
+}
+
+// TimeTelemetry records how long until the returned func is called, as in
+// `defer g.TimeTelemetry("chat.archive.job")()`.
+func (g *GlobalContext) TimeTelemetry(name string) func() {
+	start := g.Clock().Now()
+	return func() {
+		g.Telemetry().Timing(name, g.Clock().Since(start))
+	}
+}
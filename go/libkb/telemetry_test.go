@@ -0,0 +1,43 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"testing"
+	"time"
+
+	keybase1 "github.com/keybase/client/go/protocol/keybase1"
+	"github.com/keybase/clockwork"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTelemetry(t *testing.T) {
+	tc := SetupTest(t, "TestTelemetry", 1)
+	defer tc.Cleanup()
+
+	// Off by default.
+	tc.G.Telemetry().Count("test.count", 1)
+	events, enabled := tc.G.Telemetry().Events()
+	require.False(t, enabled)
+	require.Empty(t, events)
+
+	clock := clockwork.NewFakeClock()
+	sink := NewLocalTelemetrySink(clock, 3)
+	tc.G.SetTelemetrySink(sink)
+	events, enabled = tc.G.Telemetry().Events()
+	require.True(t, enabled)
+	require.Empty(t, events)
+
+	for i := int64(1); i <= 4; i++ {
+		tc.G.Telemetry().Count("test.count", i)
+	}
+	tc.G.Telemetry().Timing("test.timing", 1500*time.Millisecond)
+	// Only the latest are kept.
+	events, _ = tc.G.Telemetry().Events()
+	require.Equal(t, []keybase1.TelemetryEvent{
+		{Time: keybase1.ToTime(clock.Now()), Name: "test.count", Type: keybase1.TelemetryEventType_COUNT, Value: 3},
+		{Time: keybase1.ToTime(clock.Now()), Name: "test.count", Type: keybase1.TelemetryEventType_COUNT, Value: 4},
+		{Time: keybase1.ToTime(clock.Now()), Name: "test.timing", Type: keybase1.TelemetryEventType_TIMING, Value: 1500},
+	}, events)
+}
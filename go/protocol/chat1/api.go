@@ -1059,6 +1059,60 @@ func (o GetDeviceInfoRes) DeepCopy() GetDeviceInfoRes {
 	}
 }
 
+type DownloadAttachmentRes struct {
+	Message          string                        `codec:"message" json:"message"`
+	Offset           int64                         `codec:"offset" json:"offset"`
+	Bytes            int64                         `codec:"bytes" json:"bytes"`
+	Size             int64                         `codec:"size" json:"size"`
+	IdentifyFailures []keybase1.TLFIdentifyFailure `codec:"identifyFailures,omitempty" json:"identify_failures,omitempty"`
+	RateLimits       []RateLimitRes                `codec:"rateLimits,omitempty" json:"ratelimits,omitempty"`
+}
+
+func (o DownloadAttachmentRes) DeepCopy() DownloadAttachmentRes {
+	return DownloadAttachmentRes{
+		Message: o.Message,
+		Offset:  o.Offset,
+		Bytes:   o.Bytes,
+		Size:    o.Size,
+		IdentifyFailures: (func(x []keybase1.TLFIdentifyFailure) []keybase1.TLFIdentifyFailure {
+			if x == nil {
+				return nil
+			}
+			ret := make([]keybase1.TLFIdentifyFailure, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.IdentifyFailures),
+		RateLimits: (func(x []RateLimitRes) []RateLimitRes {
+			if x == nil {
+				return nil
+			}
+			ret := make([]RateLimitRes, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.RateLimits),
+	}
+}
+
+type DownloadAttachmentProgress struct {
+	MessageID     MessageID `codec:"messageID" json:"id"`
+	BytesComplete int64     `codec:"bytesComplete" json:"bytes_complete"`
+	BytesTotal    int64     `codec:"bytesTotal" json:"bytes_total"`
+}
+
+func (o DownloadAttachmentProgress) DeepCopy() DownloadAttachmentProgress {
+	return DownloadAttachmentProgress{
+		MessageID:     o.MessageID.DeepCopy(),
+		BytesComplete: o.BytesComplete,
+		BytesTotal:    o.BytesTotal,
+	}
+}
+
 type ApiInterface interface {
 }
 
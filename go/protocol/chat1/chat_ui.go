@@ -1166,10 +1166,11 @@ func (o UIMessageOutbox) DeepCopy() UIMessageOutbox {
 }
 
 type UIMessageJourneycard struct {
-	Ordinal        float64         `codec:"ordinal" json:"ordinal"`
-	CardType       JourneycardType `codec:"cardType" json:"cardType"`
-	HighlightMsgID MessageID       `codec:"highlightMsgID" json:"highlightMsgID"`
-	OpenTeam       bool            `codec:"openTeam" json:"openTeam"`
+	Ordinal        float64              `codec:"ordinal" json:"ordinal"`
+	CardType       JourneycardType      `codec:"cardType" json:"cardType"`
+	HighlightMsgID MessageID            `codec:"highlightMsgID" json:"highlightMsgID"`
+	OpenTeam       bool                 `codec:"openTeam" json:"openTeam"`
+	IdentityChange *IdentityChangeAlert `codec:"identityChange,omitempty" json:"identityChange,omitempty"`
 }
 
 func (o UIMessageJourneycard) DeepCopy() UIMessageJourneycard {
@@ -1178,6 +1179,13 @@ func (o UIMessageJourneycard) DeepCopy() UIMessageJourneycard {
 		CardType:       o.CardType.DeepCopy(),
 		HighlightMsgID: o.HighlightMsgID.DeepCopy(),
 		OpenTeam:       o.OpenTeam,
+		IdentityChange: (func(x *IdentityChangeAlert) *IdentityChangeAlert {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x).DeepCopy()
+			return &tmp
+		})(o.IdentityChange),
 	}
 }
 
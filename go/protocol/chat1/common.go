@@ -263,6 +263,8 @@ const (
 	MessageType_UNFURL             MessageType = 16
 	MessageType_FLIP               MessageType = 17
 	MessageType_PIN                MessageType = 18
+	MessageType_POLL               MessageType = 19
+	MessageType_TASK               MessageType = 20
 )
 
 func (o MessageType) DeepCopy() MessageType { return o }
@@ -287,6 +289,8 @@ var MessageTypeMap = map[string]MessageType{
 	"UNFURL":             16,
 	"FLIP":               17,
 	"PIN":                18,
+	"POLL":               19,
+	"TASK":               20,
 }
 
 var MessageTypeRevMap = map[MessageType]string{
@@ -309,6 +313,8 @@ var MessageTypeRevMap = map[MessageType]string{
 	16: "UNFURL",
 	17: "FLIP",
 	18: "PIN",
+	19: "POLL",
+	20: "TASK",
 }
 
 type TopicType int
@@ -197,6 +197,8 @@ var deletableMessageTypesByDelete = []MessageType{
 	MessageType_HEADLINE,
 	MessageType_SYSTEM,
 	MessageType_FLIP,
+	MessageType_POLL,
+	MessageType_TASK,
 }
 
 // Messages types NOT deletable by a DELETEHISTORY message.
@@ -241,6 +243,8 @@ var visibleMessageTypes = []MessageType{
 	MessageType_FLIP,
 	MessageType_HEADLINE,
 	MessageType_PIN,
+	MessageType_POLL,
+	MessageType_TASK,
 }
 
 // Visible chat messages appear visually as a message in the conv.
@@ -258,6 +262,8 @@ var badgeableMessageTypes = []MessageType{
 	MessageType_FLIP,
 	MessageType_HEADLINE,
 	MessageType_PIN,
+	MessageType_POLL,
+	MessageType_TASK,
 }
 
 // Message types that cause badges.
@@ -282,6 +288,8 @@ var snippetMessageTypes = []MessageType{
 	MessageType_FLIP,
 	MessageType_HEADLINE,
 	MessageType_PIN,
+	MessageType_POLL,
+	MessageType_TASK,
 }
 
 // Snippet chat messages can be the snippet of a conversation.
@@ -1051,6 +1059,10 @@ func (b MessageBody) SearchableText() string {
 		return b.Attachment().GetTitle()
 	case MessageType_FLIP:
 		return b.Flip().Text
+	case MessageType_POLL:
+		return strings.Join(append([]string{b.Poll().Question}, b.Poll().Options...), " ")
+	case MessageType_TASK:
+		return b.Task().Title
 	case MessageType_UNFURL:
 		return b.Unfurl().SearchableText()
 	case MessageType_SYSTEM:
@@ -1858,6 +1870,10 @@ func (r *GetInboxSummaryForCLILocalRes) SetOffline() {
 	r.Offline = true
 }
 
+func (r *InboxQueryForCLIRes) SetOffline() {
+	r.Offline = true
+}
+
 func (r *GetConversationForCLILocalRes) SetOffline() {
 	r.Offline = true
 }
@@ -2195,6 +2211,14 @@ func (r *GetInboxSummaryForCLILocalRes) SetRateLimits(rl []RateLimit) {
 	r.RateLimits = rl
 }
 
+func (r *InboxQueryForCLIRes) GetRateLimit() []RateLimit {
+	return r.RateLimits
+}
+
+func (r *InboxQueryForCLIRes) SetRateLimits(rl []RateLimit) {
+	r.RateLimits = rl
+}
+
 func (r *GetMessagesLocalRes) GetRateLimit() []RateLimit {
 	return r.RateLimits
 }
@@ -429,6 +429,42 @@ func (o MessagePin) DeepCopy() MessagePin {
 	}
 }
 
+type MessagePoll struct {
+	Question    string   `codec:"question" json:"question"`
+	Options     []string `codec:"options" json:"options"`
+	MultiSelect bool     `codec:"multiSelect" json:"multiSelect"`
+}
+
+func (o MessagePoll) DeepCopy() MessagePoll {
+	return MessagePoll{
+		Question: o.Question,
+		Options: (func(x []string) []string {
+			if x == nil {
+				return nil
+			}
+			ret := make([]string, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Options),
+		MultiSelect: o.MultiSelect,
+	}
+}
+
+type MessageTask struct {
+	Title    string `codec:"title" json:"title"`
+	Assignee string `codec:"assignee" json:"assignee"`
+}
+
+func (o MessageTask) DeepCopy() MessageTask {
+	return MessageTask{
+		Title:    o.Title,
+		Assignee: o.Assignee,
+	}
+}
+
 type MessageSystemType int
 
 const (
@@ -1229,6 +1265,8 @@ type MessageBody struct {
 	Unfurl__             *MessageUnfurl               `codec:"unfurl,omitempty" json:"unfurl,omitempty"`
 	Flip__               *MessageFlip                 `codec:"flip,omitempty" json:"flip,omitempty"`
 	Pin__                *MessagePin                  `codec:"pin,omitempty" json:"pin,omitempty"`
+	Poll__               *MessagePoll                 `codec:"poll,omitempty" json:"poll,omitempty"`
+	Task__               *MessageTask                 `codec:"task,omitempty" json:"task,omitempty"`
 }
 
 func (o *MessageBody) MessageType() (ret MessageType, err error) {
@@ -1318,6 +1356,16 @@ func (o *MessageBody) MessageType() (ret MessageType, err error) {
 			err = errors.New("unexpected nil value for Pin__")
 			return ret, err
 		}
+	case MessageType_POLL:
+		if o.Poll__ == nil {
+			err = errors.New("unexpected nil value for Poll__")
+			return ret, err
+		}
+	case MessageType_TASK:
+		if o.Task__ == nil {
+			err = errors.New("unexpected nil value for Task__")
+			return ret, err
+		}
 	}
 	return o.MessageType__, nil
 }
@@ -1492,6 +1540,26 @@ func (o MessageBody) Pin() (res MessagePin) {
 	return *o.Pin__
 }
 
+func (o MessageBody) Poll() (res MessagePoll) {
+	if o.MessageType__ != MessageType_POLL {
+		panic("wrong case accessed")
+	}
+	if o.Poll__ == nil {
+		return
+	}
+	return *o.Poll__
+}
+
+func (o MessageBody) Task() (res MessageTask) {
+	if o.MessageType__ != MessageType_TASK {
+		panic("wrong case accessed")
+	}
+	if o.Task__ == nil {
+		return
+	}
+	return *o.Task__
+}
+
 func NewMessageBodyWithText(v MessageText) MessageBody {
 	return MessageBody{
 		MessageType__: MessageType_TEXT,
@@ -1611,6 +1679,20 @@ func NewMessageBodyWithPin(v MessagePin) MessageBody {
 	}
 }
 
+func NewMessageBodyWithPoll(v MessagePoll) MessageBody {
+	return MessageBody{
+		MessageType__: MessageType_POLL,
+		Poll__:        &v,
+	}
+}
+
+func NewMessageBodyWithTask(v MessageTask) MessageBody {
+	return MessageBody{
+		MessageType__: MessageType_TASK,
+		Task__:        &v,
+	}
+}
+
 func (o MessageBody) DeepCopy() MessageBody {
 	return MessageBody{
 		MessageType__: o.MessageType__.DeepCopy(),
@@ -1733,6 +1815,20 @@ func (o MessageBody) DeepCopy() MessageBody {
 			tmp := (*x).DeepCopy()
 			return &tmp
 		})(o.Pin__),
+		Poll__: (func(x *MessagePoll) *MessagePoll {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x).DeepCopy()
+			return &tmp
+		})(o.Poll__),
+		Task__: (func(x *MessageTask) *MessageTask {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x).DeepCopy()
+			return &tmp
+		})(o.Task__),
 	}
 }
 
@@ -3154,6 +3250,7 @@ const (
 	JourneycardType_UNUSED           JourneycardType = 5
 	JourneycardType_CHANNEL_INACTIVE JourneycardType = 6
 	JourneycardType_MSG_NO_ANSWER    JourneycardType = 7
+	JourneycardType_IDENTITY_CHANGE  JourneycardType = 8
 )
 
 func (o JourneycardType) DeepCopy() JourneycardType { return o }
@@ -3167,6 +3264,7 @@ var JourneycardTypeMap = map[string]JourneycardType{
 	"UNUSED":           5,
 	"CHANNEL_INACTIVE": 6,
 	"MSG_NO_ANSWER":    7,
+	"IDENTITY_CHANGE":  8,
 }
 
 var JourneycardTypeRevMap = map[JourneycardType]string{
@@ -3178,6 +3276,7 @@ var JourneycardTypeRevMap = map[JourneycardType]string{
 	5: "UNUSED",
 	6: "CHANNEL_INACTIVE",
 	7: "MSG_NO_ANSWER",
+	8: "IDENTITY_CHANGE",
 }
 
 func (e JourneycardType) String() string {
@@ -3187,12 +3286,57 @@ func (e JourneycardType) String() string {
 	return fmt.Sprintf("%v", int(e))
 }
 
+type IdentityChangeType int
+
+const (
+	IdentityChangeType_RESET      IdentityChangeType = 0
+	IdentityChangeType_NEW_DEVICE IdentityChangeType = 1
+)
+
+func (o IdentityChangeType) DeepCopy() IdentityChangeType { return o }
+
+var IdentityChangeTypeMap = map[string]IdentityChangeType{
+	"RESET":      0,
+	"NEW_DEVICE": 1,
+}
+
+var IdentityChangeTypeRevMap = map[IdentityChangeType]string{
+	0: "RESET",
+	1: "NEW_DEVICE",
+}
+
+func (e IdentityChangeType) String() string {
+	if v, ok := IdentityChangeTypeRevMap[e]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(e))
+}
+
+type IdentityChangeAlert struct {
+	Username   string             `codec:"username" json:"username"`
+	ChangeType IdentityChangeType `codec:"changeType" json:"changeType"`
+	DeviceName string             `codec:"deviceName" json:"deviceName"`
+	Ctime      gregor1.Time       `codec:"ctime" json:"ctime"`
+	PrevID     MessageID          `codec:"prevID" json:"prevID"`
+}
+
+func (o IdentityChangeAlert) DeepCopy() IdentityChangeAlert {
+	return IdentityChangeAlert{
+		Username:   o.Username,
+		ChangeType: o.ChangeType.DeepCopy(),
+		DeviceName: o.DeviceName,
+		Ctime:      o.Ctime.DeepCopy(),
+		PrevID:     o.PrevID.DeepCopy(),
+	}
+}
+
 type MessageUnboxedJourneycard struct {
-	PrevID         MessageID       `codec:"prevID" json:"prevID"`
-	Ordinal        int             `codec:"ordinal" json:"ordinal"`
-	CardType       JourneycardType `codec:"cardType" json:"cardType"`
-	HighlightMsgID MessageID       `codec:"highlightMsgID" json:"highlightMsgID"`
-	OpenTeam       bool            `codec:"openTeam" json:"openTeam"`
+	PrevID         MessageID            `codec:"prevID" json:"prevID"`
+	Ordinal        int                  `codec:"ordinal" json:"ordinal"`
+	CardType       JourneycardType      `codec:"cardType" json:"cardType"`
+	HighlightMsgID MessageID            `codec:"highlightMsgID" json:"highlightMsgID"`
+	OpenTeam       bool                 `codec:"openTeam" json:"openTeam"`
+	IdentityChange *IdentityChangeAlert `codec:"identityChange,omitempty" json:"identityChange,omitempty"`
 }
 
 func (o MessageUnboxedJourneycard) DeepCopy() MessageUnboxedJourneycard {
@@ -3202,6 +3346,13 @@ func (o MessageUnboxedJourneycard) DeepCopy() MessageUnboxedJourneycard {
 		CardType:       o.CardType.DeepCopy(),
 		HighlightMsgID: o.HighlightMsgID.DeepCopy(),
 		OpenTeam:       o.OpenTeam,
+		IdentityChange: (func(x *IdentityChangeAlert) *IdentityChangeAlert {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x).DeepCopy()
+			return &tmp
+		})(o.IdentityChange),
 	}
 }
 
@@ -3425,6 +3576,26 @@ func (o ConversationPinnedMessage) DeepCopy() ConversationPinnedMessage {
 	}
 }
 
+type ChannelBanner struct {
+	ConvID    ConversationID `codec:"convID" json:"convID"`
+	Channel   string         `codec:"channel" json:"channel"`
+	Text      string         `codec:"text" json:"text"`
+	SetBy     string         `codec:"setBy" json:"setBy"`
+	Ctime     gregor1.Time   `codec:"ctime" json:"ctime"`
+	ExpiresAt gregor1.Time   `codec:"expiresAt" json:"expiresAt"`
+}
+
+func (o ChannelBanner) DeepCopy() ChannelBanner {
+	return ChannelBanner{
+		ConvID:    o.ConvID.DeepCopy(),
+		Channel:   o.Channel,
+		Text:      o.Text,
+		SetBy:     o.SetBy,
+		Ctime:     o.Ctime.DeepCopy(),
+		ExpiresAt: o.ExpiresAt.DeepCopy(),
+	}
+}
+
 type ConversationInfoLocal struct {
 	Id             ConversationID                 `codec:"id" json:"id"`
 	Triple         ConversationIDTriple           `codec:"triple" json:"triple"`
@@ -3447,6 +3618,7 @@ type ConversationInfoLocal struct {
 	Participants   []ConversationLocalParticipant `codec:"participants" json:"participants"`
 	FinalizeInfo   *ConversationFinalizeInfo      `codec:"finalizeInfo,omitempty" json:"finalizeInfo,omitempty"`
 	ResetNames     []string                       `codec:"resetNames" json:"resetNames"`
+	Banner         *ChannelBanner                 `codec:"banner,omitempty" json:"banner,omitempty"`
 }
 
 func (o ConversationInfoLocal) DeepCopy() ConversationInfoLocal {
@@ -3526,6 +3698,13 @@ func (o ConversationInfoLocal) DeepCopy() ConversationInfoLocal {
 			}
 			return ret
 		})(o.ResetNames),
+		Banner: (func(x *ChannelBanner) *ChannelBanner {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x).DeepCopy()
+			return &tmp
+		})(o.Banner),
 	}
 }
 
@@ -4673,6 +4852,117 @@ func (o GetInboxSummaryForCLILocalRes) DeepCopy() GetInboxSummaryForCLILocalRes
 	}
 }
 
+type InboxQueryForCLISort int
+
+const (
+	InboxQueryForCLISort_ACTIVITY     InboxQueryForCLISort = 0
+	InboxQueryForCLISort_UNREAD_FIRST InboxQueryForCLISort = 1
+	InboxQueryForCLISort_NAME         InboxQueryForCLISort = 2
+)
+
+func (o InboxQueryForCLISort) DeepCopy() InboxQueryForCLISort { return o }
+
+var InboxQueryForCLISortMap = map[string]InboxQueryForCLISort{
+	"ACTIVITY":     0,
+	"UNREAD_FIRST": 1,
+	"NAME":         2,
+}
+
+var InboxQueryForCLISortRevMap = map[InboxQueryForCLISort]string{
+	0: "ACTIVITY",
+	1: "UNREAD_FIRST",
+	2: "NAME",
+}
+
+func (e InboxQueryForCLISort) String() string {
+	if v, ok := InboxQueryForCLISortRevMap[e]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(e))
+}
+
+type InboxQueryForCLI struct {
+	TopicType    TopicType                  `codec:"topicType" json:"topicType"`
+	Visibility   keybase1.TLFVisibility     `codec:"visibility" json:"visibility"`
+	TeamName     string                     `codec:"teamName" json:"teamName"`
+	UnreadOnly   bool                       `codec:"unreadOnly" json:"unreadOnly"`
+	Status       []ConversationStatus       `codec:"status" json:"status"`
+	MemberStatus []ConversationMemberStatus `codec:"memberStatus" json:"memberStatus"`
+	After        string                     `codec:"after" json:"after"`
+	Before       string                     `codec:"before" json:"before"`
+	Sort         InboxQueryForCLISort       `codec:"sort" json:"sort"`
+	Limit        int                        `codec:"limit" json:"limit"`
+}
+
+func (o InboxQueryForCLI) DeepCopy() InboxQueryForCLI {
+	return InboxQueryForCLI{
+		TopicType:  o.TopicType.DeepCopy(),
+		Visibility: o.Visibility.DeepCopy(),
+		TeamName:   o.TeamName,
+		UnreadOnly: o.UnreadOnly,
+		Status: (func(x []ConversationStatus) []ConversationStatus {
+			if x == nil {
+				return nil
+			}
+			ret := make([]ConversationStatus, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Status),
+		MemberStatus: (func(x []ConversationMemberStatus) []ConversationMemberStatus {
+			if x == nil {
+				return nil
+			}
+			ret := make([]ConversationMemberStatus, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.MemberStatus),
+		After:  o.After,
+		Before: o.Before,
+		Sort:   o.Sort.DeepCopy(),
+		Limit:  o.Limit,
+	}
+}
+
+type InboxQueryForCLIRes struct {
+	Conversations []ConversationLocal `codec:"conversations" json:"conversations"`
+	Offline       bool                `codec:"offline" json:"offline"`
+	RateLimits    []RateLimit         `codec:"rateLimits" json:"rateLimits"`
+}
+
+func (o InboxQueryForCLIRes) DeepCopy() InboxQueryForCLIRes {
+	return InboxQueryForCLIRes{
+		Conversations: (func(x []ConversationLocal) []ConversationLocal {
+			if x == nil {
+				return nil
+			}
+			ret := make([]ConversationLocal, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Conversations),
+		Offline: o.Offline,
+		RateLimits: (func(x []RateLimit) []RateLimit {
+			if x == nil {
+				return nil
+			}
+			ret := make([]RateLimit, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.RateLimits),
+	}
+}
+
 type GetConversationForCLILocalQuery struct {
 	MarkAsRead   bool                `codec:"markAsRead" json:"markAsRead"`
 	MessageTypes []MessageType       `codec:"MessageTypes" json:"MessageTypes"`
@@ -4885,6 +5175,7 @@ func (o GetNextAttachmentMessageLocalRes) DeepCopy() GetNextAttachmentMessageLoc
 type DownloadAttachmentLocalRes struct {
 	RateLimits       []RateLimit                   `codec:"rateLimits" json:"rateLimits"`
 	IdentifyFailures []keybase1.TLFIdentifyFailure `codec:"identifyFailures" json:"identifyFailures"`
+	Size             int64                         `codec:"size" json:"size"`
 }
 
 func (o DownloadAttachmentLocalRes) DeepCopy() DownloadAttachmentLocalRes {
@@ -4911,6 +5202,7 @@ func (o DownloadAttachmentLocalRes) DeepCopy() DownloadAttachmentLocalRes {
 			}
 			return ret
 		})(o.IdentifyFailures),
+		Size: o.Size,
 	}
 }
 
@@ -6544,12 +6836,117 @@ func (o TrackGiphySelectRes) DeepCopy() TrackGiphySelectRes {
 	return TrackGiphySelectRes{}
 }
 
+type ArchiveChatFormat int
+
+const (
+	ArchiveChatFormat_TEXT ArchiveChatFormat = 0
+	ArchiveChatFormat_JSON ArchiveChatFormat = 1
+	ArchiveChatFormat_BOTH ArchiveChatFormat = 2
+)
+
+func (o ArchiveChatFormat) DeepCopy() ArchiveChatFormat { return o }
+
+var ArchiveChatFormatMap = map[string]ArchiveChatFormat{
+	"TEXT": 0,
+	"JSON": 1,
+	"BOTH": 2,
+}
+
+var ArchiveChatFormatRevMap = map[ArchiveChatFormat]string{
+	0: "TEXT",
+	1: "JSON",
+	2: "BOTH",
+}
+
+func (e ArchiveChatFormat) String() string {
+	if v, ok := ArchiveChatFormatRevMap[e]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(e))
+}
+
+type ArchiveChatCompression int
+
+const (
+	ArchiveChatCompression_GZIP ArchiveChatCompression = 0
+	ArchiveChatCompression_ZSTD ArchiveChatCompression = 1
+	ArchiveChatCompression_NONE ArchiveChatCompression = 2
+)
+
+func (o ArchiveChatCompression) DeepCopy() ArchiveChatCompression { return o }
+
+var ArchiveChatCompressionMap = map[string]ArchiveChatCompression{
+	"GZIP": 0,
+	"ZSTD": 1,
+	"NONE": 2,
+}
+
+var ArchiveChatCompressionRevMap = map[ArchiveChatCompression]string{
+	0: "GZIP",
+	1: "ZSTD",
+	2: "NONE",
+}
+
+func (e ArchiveChatCompression) String() string {
+	if v, ok := ArchiveChatCompressionRevMap[e]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(e))
+}
+
+type ArchiveChatEncryption int
+
+const (
+	ArchiveChatEncryption_NONE       ArchiveChatEncryption = 0
+	ArchiveChatEncryption_SELF       ArchiveChatEncryption = 1
+	ArchiveChatEncryption_PASSPHRASE ArchiveChatEncryption = 2
+)
+
+func (o ArchiveChatEncryption) DeepCopy() ArchiveChatEncryption { return o }
+
+var ArchiveChatEncryptionMap = map[string]ArchiveChatEncryption{
+	"NONE":       0,
+	"SELF":       1,
+	"PASSPHRASE": 2,
+}
+
+var ArchiveChatEncryptionRevMap = map[ArchiveChatEncryption]string{
+	0: "NONE",
+	1: "SELF",
+	2: "PASSPHRASE",
+}
+
+func (e ArchiveChatEncryption) String() string {
+	if v, ok := ArchiveChatEncryptionRevMap[e]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(e))
+}
+
 type ArchiveChatJobRequest struct {
-	JobID            ArchiveJobID                 `codec:"jobID" json:"jobID"`
-	OutputPath       string                       `codec:"outputPath" json:"outputPath"`
-	Query            *GetInboxLocalQuery          `codec:"query,omitempty" json:"query,omitempty"`
-	Compress         bool                         `codec:"compress" json:"compress"`
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+	JobID                   ArchiveJobID                 `codec:"jobID" json:"jobID"`
+	OutputPath              string                       `codec:"outputPath" json:"outputPath"`
+	Query                   *GetInboxLocalQuery          `codec:"query,omitempty" json:"query,omitempty"`
+	Compress                bool                         `codec:"compress" json:"compress"`
+	Compression             ArchiveChatCompression       `codec:"compression" json:"compression"`
+	CompressionLevel        int                          `codec:"compressionLevel" json:"compressionLevel"`
+	Encryption              ArchiveChatEncryption        `codec:"encryption" json:"encryption"`
+	Passphrase              string                       `codec:"passphrase" json:"passphrase"`
+	IdentifyBehavior        keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+	AttachmentsMetadataOnly bool                         `codec:"attachmentsMetadataOnly" json:"attachmentsMetadataOnly"`
+	SkipAttachments         bool                         `codec:"skipAttachments" json:"skipAttachments"`
+	MaxAttachmentSize       int64                        `codec:"maxAttachmentSize" json:"maxAttachmentSize"`
+	AttachmentMimeTypes     []string                     `codec:"attachmentMimeTypes" json:"attachmentMimeTypes"`
+	DedupeAttachments       bool                         `codec:"dedupeAttachments" json:"dedupeAttachments"`
+	DedupeMessages          bool                         `codec:"dedupeMessages" json:"dedupeMessages"`
+	IncludeContacts         bool                         `codec:"includeContacts" json:"includeContacts"`
+	LegalTranscript         bool                         `codec:"legalTranscript" json:"legalTranscript"`
+	Format                  ArchiveChatFormat            `codec:"format" json:"format"`
+	SqliteDatabase          bool                         `codec:"sqliteDatabase" json:"sqliteDatabase"`
+	IncludeHistory          bool                         `codec:"includeHistory" json:"includeHistory"`
+	After                   *gregor1.Time                `codec:"after,omitempty" json:"after,omitempty"`
+	Before                  *gregor1.Time                `codec:"before,omitempty" json:"before,omitempty"`
+	AppendTo                ArchiveJobID                 `codec:"appendTo" json:"appendTo"`
 }
 
 func (o ArchiveChatJobRequest) DeepCopy() ArchiveChatJobRequest {
@@ -6563,8 +6960,48 @@ func (o ArchiveChatJobRequest) DeepCopy() ArchiveChatJobRequest {
 			tmp := (*x).DeepCopy()
 			return &tmp
 		})(o.Query),
-		Compress:         o.Compress,
-		IdentifyBehavior: o.IdentifyBehavior.DeepCopy(),
+		Compress:                o.Compress,
+		Compression:             o.Compression.DeepCopy(),
+		CompressionLevel:        o.CompressionLevel,
+		Encryption:              o.Encryption.DeepCopy(),
+		Passphrase:              o.Passphrase,
+		IdentifyBehavior:        o.IdentifyBehavior.DeepCopy(),
+		AttachmentsMetadataOnly: o.AttachmentsMetadataOnly,
+		SkipAttachments:         o.SkipAttachments,
+		MaxAttachmentSize:       o.MaxAttachmentSize,
+		AttachmentMimeTypes: (func(x []string) []string {
+			if x == nil {
+				return nil
+			}
+			ret := make([]string, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.AttachmentMimeTypes),
+		DedupeAttachments: o.DedupeAttachments,
+		DedupeMessages:    o.DedupeMessages,
+		IncludeContacts:   o.IncludeContacts,
+		LegalTranscript:   o.LegalTranscript,
+		Format:            o.Format.DeepCopy(),
+		SqliteDatabase:    o.SqliteDatabase,
+		IncludeHistory:    o.IncludeHistory,
+		After: (func(x *gregor1.Time) *gregor1.Time {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x).DeepCopy()
+			return &tmp
+		})(o.After),
+		Before: (func(x *gregor1.Time) *gregor1.Time {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x).DeepCopy()
+			return &tmp
+		})(o.Before),
+		AppendTo: o.AppendTo.DeepCopy(),
 	}
 }
 
@@ -6591,25 +7028,34 @@ func (o ArchiveChatRes) DeepCopy() ArchiveChatRes {
 }
 
 type ArchiveChatConvCheckpoint struct {
-	Pagination Pagination `codec:"pagination" json:"pagination"`
-	Offset     int64      `codec:"offset" json:"offset"`
+	Pagination    Pagination `codec:"pagination" json:"pagination"`
+	Offset        int64      `codec:"offset" json:"offset"`
+	JsonOffset    int64      `codec:"jsonOffset" json:"jsonOffset"`
+	HistoryOffset int64      `codec:"historyOffset" json:"historyOffset"`
+	MaxMsgID      MessageID  `codec:"maxMsgID" json:"maxMsgID"`
+	AppendAfter   MessageID  `codec:"appendAfter" json:"appendAfter"`
 }
 
 func (o ArchiveChatConvCheckpoint) DeepCopy() ArchiveChatConvCheckpoint {
 	return ArchiveChatConvCheckpoint{
-		Pagination: o.Pagination.DeepCopy(),
-		Offset:     o.Offset,
+		Pagination:    o.Pagination.DeepCopy(),
+		Offset:        o.Offset,
+		JsonOffset:    o.JsonOffset,
+		HistoryOffset: o.HistoryOffset,
+		MaxMsgID:      o.MaxMsgID.DeepCopy(),
+		AppendAfter:   o.AppendAfter.DeepCopy(),
 	}
 }
 
 type ArchiveChatJob struct {
-	Request          ArchiveChatJobRequest                `codec:"request" json:"request"`
-	StartedAt        gregor1.Time                         `codec:"startedAt" json:"startedAt"`
-	Status           ArchiveChatJobStatus                 `codec:"status" json:"status"`
-	Err              string                               `codec:"err" json:"err"`
-	MessagesTotal    int64                                `codec:"messagesTotal" json:"messagesTotal"`
-	MessagesComplete int64                                `codec:"messagesComplete" json:"messagesComplete"`
-	Checkpoints      map[string]ArchiveChatConvCheckpoint `codec:"checkpoints" json:"checkpoints"`
+	Request               ArchiveChatJobRequest                `codec:"request" json:"request"`
+	StartedAt             gregor1.Time                         `codec:"startedAt" json:"startedAt"`
+	Status                ArchiveChatJobStatus                 `codec:"status" json:"status"`
+	Err                   string                               `codec:"err" json:"err"`
+	MessagesTotal         int64                                `codec:"messagesTotal" json:"messagesTotal"`
+	MessagesComplete      int64                                `codec:"messagesComplete" json:"messagesComplete"`
+	Checkpoints           map[string]ArchiveChatConvCheckpoint `codec:"checkpoints" json:"checkpoints"`
+	AppliedRedactionRules []ArchiveAppliedRedactionRules       `codec:"appliedRedactionRules" json:"appliedRedactionRules"`
 }
 
 func (o ArchiveChatJob) DeepCopy() ArchiveChatJob {
@@ -6632,6 +7078,17 @@ func (o ArchiveChatJob) DeepCopy() ArchiveChatJob {
 			}
 			return ret
 		})(o.Checkpoints),
+		AppliedRedactionRules: (func(x []ArchiveAppliedRedactionRules) []ArchiveAppliedRedactionRules {
+			if x == nil {
+				return nil
+			}
+			ret := make([]ArchiveAppliedRedactionRules, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.AppliedRedactionRules),
 	}
 }
 
@@ -6670,28 +7127,86 @@ func (e ArchiveChatJobStatus) String() string {
 	return fmt.Sprintf("%v", int(e))
 }
 
-type ArchiveChatListRes struct {
-	Jobs []ArchiveChatJob `codec:"jobs" json:"jobs"`
+type ArchiveChatScheduleFrequency int
+
+const (
+	ArchiveChatScheduleFrequency_DAILY  ArchiveChatScheduleFrequency = 0
+	ArchiveChatScheduleFrequency_WEEKLY ArchiveChatScheduleFrequency = 1
+)
+
+func (o ArchiveChatScheduleFrequency) DeepCopy() ArchiveChatScheduleFrequency { return o }
+
+var ArchiveChatScheduleFrequencyMap = map[string]ArchiveChatScheduleFrequency{
+	"DAILY":  0,
+	"WEEKLY": 1,
 }
 
-func (o ArchiveChatListRes) DeepCopy() ArchiveChatListRes {
-	return ArchiveChatListRes{
-		Jobs: (func(x []ArchiveChatJob) []ArchiveChatJob {
-			if x == nil {
-				return nil
-			}
-			ret := make([]ArchiveChatJob, len(x))
-			for i, v := range x {
-				vCopy := v.DeepCopy()
+var ArchiveChatScheduleFrequencyRevMap = map[ArchiveChatScheduleFrequency]string{
+	0: "DAILY",
+	1: "WEEKLY",
+}
+
+func (e ArchiveChatScheduleFrequency) String() string {
+	if v, ok := ArchiveChatScheduleFrequencyRevMap[e]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(e))
+}
+
+type ArchiveChatSchedule struct {
+	Frequency   ArchiveChatScheduleFrequency `codec:"frequency" json:"frequency"`
+	MinuteOfDay int                          `codec:"minuteOfDay" json:"minuteOfDay"`
+	Weekday     int                          `codec:"weekday" json:"weekday"`
+	NextRun     gregor1.Time                 `codec:"nextRun" json:"nextRun"`
+	LastJobID   ArchiveJobID                 `codec:"lastJobID" json:"lastJobID"`
+}
+
+func (o ArchiveChatSchedule) DeepCopy() ArchiveChatSchedule {
+	return ArchiveChatSchedule{
+		Frequency:   o.Frequency.DeepCopy(),
+		MinuteOfDay: o.MinuteOfDay,
+		Weekday:     o.Weekday,
+		NextRun:     o.NextRun.DeepCopy(),
+		LastJobID:   o.LastJobID.DeepCopy(),
+	}
+}
+
+type ArchiveChatListRes struct {
+	Jobs      []ArchiveChatJob                     `codec:"jobs" json:"jobs"`
+	Schedules map[ArchiveJobID]ArchiveChatSchedule `codec:"schedules" json:"schedules"`
+}
+
+func (o ArchiveChatListRes) DeepCopy() ArchiveChatListRes {
+	return ArchiveChatListRes{
+		Jobs: (func(x []ArchiveChatJob) []ArchiveChatJob {
+			if x == nil {
+				return nil
+			}
+			ret := make([]ArchiveChatJob, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
 				ret[i] = vCopy
 			}
 			return ret
 		})(o.Jobs),
+		Schedules: (func(x map[ArchiveJobID]ArchiveChatSchedule) map[ArchiveJobID]ArchiveChatSchedule {
+			if x == nil {
+				return nil
+			}
+			ret := make(map[ArchiveJobID]ArchiveChatSchedule, len(x))
+			for k, v := range x {
+				kCopy := k.DeepCopy()
+				vCopy := v.DeepCopy()
+				ret[kCopy] = vCopy
+			}
+			return ret
+		})(o.Schedules),
 	}
 }
 
 type ArchiveChatHistory struct {
-	JobHistory map[ArchiveJobID]ArchiveChatJob `codec:"jobHistory" json:"jobHistory"`
+	JobHistory map[ArchiveJobID]ArchiveChatJob      `codec:"jobHistory" json:"jobHistory"`
+	Schedules  map[ArchiveJobID]ArchiveChatSchedule `codec:"schedules" json:"schedules"`
 }
 
 func (o ArchiveChatHistory) DeepCopy() ArchiveChatHistory {
@@ -6708,2764 +7223,5368 @@ func (o ArchiveChatHistory) DeepCopy() ArchiveChatHistory {
 			}
 			return ret
 		})(o.JobHistory),
+		Schedules: (func(x map[ArchiveJobID]ArchiveChatSchedule) map[ArchiveJobID]ArchiveChatSchedule {
+			if x == nil {
+				return nil
+			}
+			ret := make(map[ArchiveJobID]ArchiveChatSchedule, len(x))
+			for k, v := range x {
+				kCopy := k.DeepCopy()
+				vCopy := v.DeepCopy()
+				ret[kCopy] = vCopy
+			}
+			return ret
+		})(o.Schedules),
 	}
 }
 
-type GetThreadLocalArg struct {
-	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
-	Reason           GetThreadReason              `codec:"reason" json:"reason"`
-	Query            *GetThreadQuery              `codec:"query,omitempty" json:"query,omitempty"`
-	Pagination       *Pagination                  `codec:"pagination,omitempty" json:"pagination,omitempty"`
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+type ArchiveChatExportedJob struct {
+	JobID            ArchiveJobID         `codec:"jobID" json:"jobID"`
+	StartedAt        gregor1.Time         `codec:"startedAt" json:"startedAt"`
+	Status           ArchiveChatJobStatus `codec:"status" json:"status"`
+	MessagesTotal    int64                `codec:"messagesTotal" json:"messagesTotal"`
+	MessagesComplete int64                `codec:"messagesComplete" json:"messagesComplete"`
+	Plaintext        *ArchiveChatJob      `codec:"plaintext,omitempty" json:"plaintext,omitempty"`
+	Sealed           []byte               `codec:"sealed" json:"sealed"`
 }
 
-type GetThreadNonblockArg struct {
-	SessionID        int                          `codec:"sessionID" json:"sessionID"`
-	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
-	CbMode           GetThreadNonblockCbMode      `codec:"cbMode" json:"cbMode"`
-	Reason           GetThreadReason              `codec:"reason" json:"reason"`
-	Pgmode           GetThreadNonblockPgMode      `codec:"pgmode" json:"pgmode"`
-	Query            *GetThreadQuery              `codec:"query,omitempty" json:"query,omitempty"`
-	KnownRemotes     []string                     `codec:"knownRemotes" json:"knownRemotes"`
-	Pagination       *UIPagination                `codec:"pagination,omitempty" json:"pagination,omitempty"`
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+func (o ArchiveChatExportedJob) DeepCopy() ArchiveChatExportedJob {
+	return ArchiveChatExportedJob{
+		JobID:            o.JobID.DeepCopy(),
+		StartedAt:        o.StartedAt.DeepCopy(),
+		Status:           o.Status.DeepCopy(),
+		MessagesTotal:    o.MessagesTotal,
+		MessagesComplete: o.MessagesComplete,
+		Plaintext: (func(x *ArchiveChatJob) *ArchiveChatJob {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x).DeepCopy()
+			return &tmp
+		})(o.Plaintext),
+		Sealed: (func(x []byte) []byte {
+			if x == nil {
+				return nil
+			}
+			return append([]byte{}, x...)
+		})(o.Sealed),
+	}
 }
 
-type GetUnreadlineArg struct {
-	SessionID        int                          `codec:"sessionID" json:"sessionID"`
-	ConvID           ConversationID               `codec:"convID" json:"convID"`
-	ReadMsgID        MessageID                    `codec:"readMsgID" json:"readMsgID"`
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+type ArchiveChatHistoryExport struct {
+	Version int                           `codec:"version" json:"version"`
+	PukGen  keybase1.PerUserKeyGeneration `codec:"pukGen" json:"pukGen"`
+	Jobs    []ArchiveChatExportedJob      `codec:"jobs" json:"jobs"`
 }
 
-type GetInboxAndUnboxLocalArg struct {
-	Query            *GetInboxLocalQuery          `codec:"query,omitempty" json:"query,omitempty"`
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+func (o ArchiveChatHistoryExport) DeepCopy() ArchiveChatHistoryExport {
+	return ArchiveChatHistoryExport{
+		Version: o.Version,
+		PukGen:  o.PukGen.DeepCopy(),
+		Jobs: (func(x []ArchiveChatExportedJob) []ArchiveChatExportedJob {
+			if x == nil {
+				return nil
+			}
+			ret := make([]ArchiveChatExportedJob, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Jobs),
+	}
 }
 
-type GetInboxAndUnboxUILocalArg struct {
-	Query            *GetInboxLocalQuery          `codec:"query,omitempty" json:"query,omitempty"`
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+type ArchiveChatImportHistoryRes struct {
+	Imported int `codec:"imported" json:"imported"`
+	Skipped  int `codec:"skipped" json:"skipped"`
 }
 
-type RequestInboxLayoutArg struct {
-	ReselectMode InboxLayoutReselectMode `codec:"reselectMode" json:"reselectMode"`
+func (o ArchiveChatImportHistoryRes) DeepCopy() ArchiveChatImportHistoryRes {
+	return ArchiveChatImportHistoryRes{
+		Imported: o.Imported,
+		Skipped:  o.Skipped,
+	}
 }
 
-type RequestInboxUnboxArg struct {
-	ConvIDs []ConversationID `codec:"convIDs" json:"convIDs"`
+type ArchiveChatSearchHit struct {
+	JobID         ArchiveJobID `codec:"jobID" json:"jobID"`
+	ConvName      string       `codec:"convName" json:"convName"`
+	MsgID         MessageID    `codec:"msgID" json:"msgID"`
+	AuthorAndTime string       `codec:"authorAndTime" json:"authorAndTime"`
+	Body          string       `codec:"body" json:"body"`
 }
 
-type RequestInboxSmallIncreaseArg struct {
+func (o ArchiveChatSearchHit) DeepCopy() ArchiveChatSearchHit {
+	return ArchiveChatSearchHit{
+		JobID:         o.JobID.DeepCopy(),
+		ConvName:      o.ConvName,
+		MsgID:         o.MsgID.DeepCopy(),
+		AuthorAndTime: o.AuthorAndTime,
+		Body:          o.Body,
+	}
 }
 
-type RequestInboxSmallResetArg struct {
+type ArchiveChatSearchRes struct {
+	Hits           []ArchiveChatSearchHit `codec:"hits" json:"hits"`
+	Truncated      bool                   `codec:"truncated" json:"truncated"`
+	UnreadableJobs []ArchiveJobID         `codec:"unreadableJobs" json:"unreadableJobs"`
 }
 
-type GetInboxNonblockLocalArg struct {
-	SessionID        int                          `codec:"sessionID" json:"sessionID"`
-	MaxUnbox         *int                         `codec:"maxUnbox,omitempty" json:"maxUnbox,omitempty"`
-	SkipUnverified   bool                         `codec:"skipUnverified" json:"skipUnverified"`
-	Query            *GetInboxLocalQuery          `codec:"query,omitempty" json:"query,omitempty"`
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+func (o ArchiveChatSearchRes) DeepCopy() ArchiveChatSearchRes {
+	return ArchiveChatSearchRes{
+		Hits: (func(x []ArchiveChatSearchHit) []ArchiveChatSearchHit {
+			if x == nil {
+				return nil
+			}
+			ret := make([]ArchiveChatSearchHit, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Hits),
+		Truncated: o.Truncated,
+		UnreadableJobs: (func(x []ArchiveJobID) []ArchiveJobID {
+			if x == nil {
+				return nil
+			}
+			ret := make([]ArchiveJobID, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.UnreadableJobs),
+	}
 }
 
-type PostLocalArg struct {
-	SessionID          int                          `codec:"sessionID" json:"sessionID"`
-	ConversationID     ConversationID               `codec:"conversationID" json:"conversationID"`
-	Msg                MessagePlaintext             `codec:"msg" json:"msg"`
-	ReplyTo            *MessageID                   `codec:"replyTo,omitempty" json:"replyTo,omitempty"`
-	IdentifyBehavior   keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
-	SkipInChatPayments bool                         `codec:"skipInChatPayments" json:"skipInChatPayments"`
-}
+type ArchiveChatVerifyProblem int
 
-type GenerateOutboxIDArg struct {
-}
+const (
+	ArchiveChatVerifyProblem_MISSING              ArchiveChatVerifyProblem = 0
+	ArchiveChatVerifyProblem_TRUNCATED            ArchiveChatVerifyProblem = 1
+	ArchiveChatVerifyProblem_TRAILING_DATA        ArchiveChatVerifyProblem = 2
+	ArchiveChatVerifyProblem_CHECKSUM_MISMATCH    ArchiveChatVerifyProblem = 3
+	ArchiveChatVerifyProblem_UNREADABLE           ArchiveChatVerifyProblem = 4
+	ArchiveChatVerifyProblem_INCOMPLETE           ArchiveChatVerifyProblem = 5
+	ArchiveChatVerifyProblem_UNKNOWN_CONVERSATION ArchiveChatVerifyProblem = 6
+)
 
-type PostLocalNonblockArg struct {
-	SessionID          int                          `codec:"sessionID" json:"sessionID"`
-	ConversationID     ConversationID               `codec:"conversationID" json:"conversationID"`
-	Msg                MessagePlaintext             `codec:"msg" json:"msg"`
-	ClientPrev         MessageID                    `codec:"clientPrev" json:"clientPrev"`
-	OutboxID           *OutboxID                    `codec:"outboxID,omitempty" json:"outboxID,omitempty"`
-	ReplyTo            *MessageID                   `codec:"replyTo,omitempty" json:"replyTo,omitempty"`
-	IdentifyBehavior   keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
-	SkipInChatPayments bool                         `codec:"skipInChatPayments" json:"skipInChatPayments"`
-}
+func (o ArchiveChatVerifyProblem) DeepCopy() ArchiveChatVerifyProblem { return o }
 
-type ForwardMessageArg struct {
-	SessionID        int                          `codec:"sessionID" json:"sessionID"`
-	SrcConvID        ConversationID               `codec:"srcConvID" json:"srcConvID"`
-	DstConvID        ConversationID               `codec:"dstConvID" json:"dstConvID"`
-	MsgID            MessageID                    `codec:"msgID" json:"msgID"`
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
-	Title            string                       `codec:"title" json:"title"`
+var ArchiveChatVerifyProblemMap = map[string]ArchiveChatVerifyProblem{
+	"MISSING":              0,
+	"TRUNCATED":            1,
+	"TRAILING_DATA":        2,
+	"CHECKSUM_MISMATCH":    3,
+	"UNREADABLE":           4,
+	"INCOMPLETE":           5,
+	"UNKNOWN_CONVERSATION": 6,
 }
 
-type ForwardMessageNonblockArg struct {
-	SessionID        int                          `codec:"sessionID" json:"sessionID"`
-	SrcConvID        ConversationID               `codec:"srcConvID" json:"srcConvID"`
-	DstConvID        ConversationID               `codec:"dstConvID" json:"dstConvID"`
-	MsgID            MessageID                    `codec:"msgID" json:"msgID"`
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
-	Title            string                       `codec:"title" json:"title"`
+var ArchiveChatVerifyProblemRevMap = map[ArchiveChatVerifyProblem]string{
+	0: "MISSING",
+	1: "TRUNCATED",
+	2: "TRAILING_DATA",
+	3: "CHECKSUM_MISMATCH",
+	4: "UNREADABLE",
+	5: "INCOMPLETE",
+	6: "UNKNOWN_CONVERSATION",
 }
 
-type PostTextNonblockArg struct {
-	SessionID         int                          `codec:"sessionID" json:"sessionID"`
-	ConversationID    ConversationID               `codec:"conversationID" json:"conversationID"`
-	TlfName           string                       `codec:"tlfName" json:"tlfName"`
-	TlfPublic         bool                         `codec:"tlfPublic" json:"tlfPublic"`
-	Body              string                       `codec:"body" json:"body"`
-	ClientPrev        MessageID                    `codec:"clientPrev" json:"clientPrev"`
-	ReplyTo           *MessageID                   `codec:"replyTo,omitempty" json:"replyTo,omitempty"`
-	OutboxID          *OutboxID                    `codec:"outboxID,omitempty" json:"outboxID,omitempty"`
-	IdentifyBehavior  keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
-	EphemeralLifetime *gregor1.DurationSec         `codec:"ephemeralLifetime,omitempty" json:"ephemeralLifetime,omitempty"`
+func (e ArchiveChatVerifyProblem) String() string {
+	if v, ok := ArchiveChatVerifyProblemRevMap[e]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(e))
 }
 
-type PostDeleteNonblockArg struct {
-	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
-	TlfName          string                       `codec:"tlfName" json:"tlfName"`
-	TlfPublic        bool                         `codec:"tlfPublic" json:"tlfPublic"`
-	Supersedes       MessageID                    `codec:"supersedes" json:"supersedes"`
-	ClientPrev       MessageID                    `codec:"clientPrev" json:"clientPrev"`
-	OutboxID         *OutboxID                    `codec:"outboxID,omitempty" json:"outboxID,omitempty"`
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+type ArchiveChatVerifyIssue struct {
+	Path    string                   `codec:"path" json:"path"`
+	Problem ArchiveChatVerifyProblem `codec:"problem" json:"problem"`
+	Detail  string                   `codec:"detail" json:"detail"`
 }
 
-type PostEditNonblockArg struct {
-	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
-	TlfName          string                       `codec:"tlfName" json:"tlfName"`
-	TlfPublic        bool                         `codec:"tlfPublic" json:"tlfPublic"`
-	Target           EditTarget                   `codec:"target" json:"target"`
-	Body             string                       `codec:"body" json:"body"`
-	OutboxID         *OutboxID                    `codec:"outboxID,omitempty" json:"outboxID,omitempty"`
-	ClientPrev       MessageID                    `codec:"clientPrev" json:"clientPrev"`
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+func (o ArchiveChatVerifyIssue) DeepCopy() ArchiveChatVerifyIssue {
+	return ArchiveChatVerifyIssue{
+		Path:    o.Path,
+		Problem: o.Problem.DeepCopy(),
+		Detail:  o.Detail,
+	}
 }
 
-type PostReactionNonblockArg struct {
-	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
-	TlfName          string                       `codec:"tlfName" json:"tlfName"`
-	TlfPublic        bool                         `codec:"tlfPublic" json:"tlfPublic"`
-	Supersedes       MessageID                    `codec:"supersedes" json:"supersedes"`
-	Body             string                       `codec:"body" json:"body"`
-	OutboxID         *OutboxID                    `codec:"outboxID,omitempty" json:"outboxID,omitempty"`
-	ClientPrev       MessageID                    `codec:"clientPrev" json:"clientPrev"`
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+type ArchiveChatVerifyRes struct {
+	JobID              ArchiveJobID             `codec:"jobID" json:"jobID"`
+	OutputPath         string                   `codec:"outputPath" json:"outputPath"`
+	ConvsChecked       int                      `codec:"convsChecked" json:"convsChecked"`
+	FilesChecked       int                      `codec:"filesChecked" json:"filesChecked"`
+	AttachmentsChecked int                      `codec:"attachmentsChecked" json:"attachmentsChecked"`
+	Issues             []ArchiveChatVerifyIssue `codec:"issues" json:"issues"`
 }
 
-type PostHeadlineNonblockArg struct {
-	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
-	TlfName          string                       `codec:"tlfName" json:"tlfName"`
-	TlfPublic        bool                         `codec:"tlfPublic" json:"tlfPublic"`
-	Headline         string                       `codec:"headline" json:"headline"`
-	OutboxID         *OutboxID                    `codec:"outboxID,omitempty" json:"outboxID,omitempty"`
-	ClientPrev       MessageID                    `codec:"clientPrev" json:"clientPrev"`
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+func (o ArchiveChatVerifyRes) DeepCopy() ArchiveChatVerifyRes {
+	return ArchiveChatVerifyRes{
+		JobID:              o.JobID.DeepCopy(),
+		OutputPath:         o.OutputPath,
+		ConvsChecked:       o.ConvsChecked,
+		FilesChecked:       o.FilesChecked,
+		AttachmentsChecked: o.AttachmentsChecked,
+		Issues: (func(x []ArchiveChatVerifyIssue) []ArchiveChatVerifyIssue {
+			if x == nil {
+				return nil
+			}
+			ret := make([]ArchiveChatVerifyIssue, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Issues),
+	}
 }
 
-type PostHeadlineArg struct {
-	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
-	TlfName          string                       `codec:"tlfName" json:"tlfName"`
-	TlfPublic        bool                         `codec:"tlfPublic" json:"tlfPublic"`
-	Headline         string                       `codec:"headline" json:"headline"`
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+type ArchiveChatImportedConv struct {
+	Id         string          `codec:"id" json:"id"`
+	Name       string          `codec:"name" json:"name"`
+	ConvID     *ConversationID `codec:"convID,omitempty" json:"convID,omitempty"`
+	SourcePath string          `codec:"sourcePath" json:"sourcePath"`
+	ImportedAt gregor1.Time    `codec:"importedAt" json:"importedAt"`
+	Messages   int             `codec:"messages" json:"messages"`
+	FirstCtime gregor1.Time    `codec:"firstCtime" json:"firstCtime"`
+	LastCtime  gregor1.Time    `codec:"lastCtime" json:"lastCtime"`
 }
 
-type PostMetadataNonblockArg struct {
-	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
-	TlfName          string                       `codec:"tlfName" json:"tlfName"`
-	TlfPublic        bool                         `codec:"tlfPublic" json:"tlfPublic"`
-	ChannelName      string                       `codec:"channelName" json:"channelName"`
-	OutboxID         *OutboxID                    `codec:"outboxID,omitempty" json:"outboxID,omitempty"`
-	ClientPrev       MessageID                    `codec:"clientPrev" json:"clientPrev"`
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+func (o ArchiveChatImportedConv) DeepCopy() ArchiveChatImportedConv {
+	return ArchiveChatImportedConv{
+		Id:   o.Id,
+		Name: o.Name,
+		ConvID: (func(x *ConversationID) *ConversationID {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x).DeepCopy()
+			return &tmp
+		})(o.ConvID),
+		SourcePath: o.SourcePath,
+		ImportedAt: o.ImportedAt.DeepCopy(),
+		Messages:   o.Messages,
+		FirstCtime: o.FirstCtime.DeepCopy(),
+		LastCtime:  o.LastCtime.DeepCopy(),
+	}
+}
+
+type ArchiveChatImportedMessage struct {
+	MsgID              MessageID           `codec:"msgID" json:"msgID"`
+	Type               string              `codec:"type" json:"type"`
+	Sender             string              `codec:"sender" json:"sender"`
+	Device             string              `codec:"device" json:"device"`
+	Ctime              gregor1.Time        `codec:"ctime" json:"ctime"`
+	Body               string              `codec:"body" json:"body"`
+	ReplyTo            MessageID           `codec:"replyTo" json:"replyTo"`
+	Deleted            bool                `codec:"deleted" json:"deleted"`
+	Exploded           bool                `codec:"exploded" json:"exploded"`
+	Reactions          map[string][]string `codec:"reactions" json:"reactions"`
+	AttachmentFilename string              `codec:"attachmentFilename" json:"attachmentFilename"`
+	AttachmentPath     string              `codec:"attachmentPath" json:"attachmentPath"`
+}
+
+func (o ArchiveChatImportedMessage) DeepCopy() ArchiveChatImportedMessage {
+	return ArchiveChatImportedMessage{
+		MsgID:    o.MsgID.DeepCopy(),
+		Type:     o.Type,
+		Sender:   o.Sender,
+		Device:   o.Device,
+		Ctime:    o.Ctime.DeepCopy(),
+		Body:     o.Body,
+		ReplyTo:  o.ReplyTo.DeepCopy(),
+		Deleted:  o.Deleted,
+		Exploded: o.Exploded,
+		Reactions: (func(x map[string][]string) map[string][]string {
+			if x == nil {
+				return nil
+			}
+			ret := make(map[string][]string, len(x))
+			for k, v := range x {
+				kCopy := k
+				vCopy := (func(x []string) []string {
+					if x == nil {
+						return nil
+					}
+					ret := make([]string, len(x))
+					for i, v := range x {
+						vCopy := v
+						ret[i] = vCopy
+					}
+					return ret
+				})(v)
+				ret[kCopy] = vCopy
+			}
+			return ret
+		})(o.Reactions),
+		AttachmentFilename: o.AttachmentFilename,
+		AttachmentPath:     o.AttachmentPath,
+	}
 }
 
-type PostMetadataArg struct {
-	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
-	TlfName          string                       `codec:"tlfName" json:"tlfName"`
-	TlfPublic        bool                         `codec:"tlfPublic" json:"tlfPublic"`
-	ChannelName      string                       `codec:"channelName" json:"channelName"`
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+type ArchiveChatImportRes struct {
+	Convs            []ArchiveChatImportedConv `codec:"convs" json:"convs"`
+	MessagesImported int                       `codec:"messagesImported" json:"messagesImported"`
 }
 
-type PostDeleteHistoryUptoArg struct {
-	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
-	TlfName          string                       `codec:"tlfName" json:"tlfName"`
-	TlfPublic        bool                         `codec:"tlfPublic" json:"tlfPublic"`
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
-	Upto             MessageID                    `codec:"upto" json:"upto"`
+func (o ArchiveChatImportRes) DeepCopy() ArchiveChatImportRes {
+	return ArchiveChatImportRes{
+		Convs: (func(x []ArchiveChatImportedConv) []ArchiveChatImportedConv {
+			if x == nil {
+				return nil
+			}
+			ret := make([]ArchiveChatImportedConv, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Convs),
+		MessagesImported: o.MessagesImported,
+	}
 }
 
-type PostDeleteHistoryThroughArg struct {
-	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
-	TlfName          string                       `codec:"tlfName" json:"tlfName"`
-	TlfPublic        bool                         `codec:"tlfPublic" json:"tlfPublic"`
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
-	Through          MessageID                    `codec:"through" json:"through"`
+type ArchiveChatImportedThread struct {
+	Conv     ArchiveChatImportedConv      `codec:"conv" json:"conv"`
+	Messages []ArchiveChatImportedMessage `codec:"messages" json:"messages"`
+	More     bool                         `codec:"more" json:"more"`
 }
 
-type PostDeleteHistoryByAgeArg struct {
-	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
-	TlfName          string                       `codec:"tlfName" json:"tlfName"`
-	TlfPublic        bool                         `codec:"tlfPublic" json:"tlfPublic"`
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
-	Age              gregor1.DurationSec          `codec:"age" json:"age"`
+func (o ArchiveChatImportedThread) DeepCopy() ArchiveChatImportedThread {
+	return ArchiveChatImportedThread{
+		Conv: o.Conv.DeepCopy(),
+		Messages: (func(x []ArchiveChatImportedMessage) []ArchiveChatImportedMessage {
+			if x == nil {
+				return nil
+			}
+			ret := make([]ArchiveChatImportedMessage, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Messages),
+		More: o.More,
+	}
 }
 
-type SetConversationStatusLocalArg struct {
-	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
-	Status           ConversationStatus           `codec:"status" json:"status"`
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
-}
+type ChatHistorySearchSource int
 
-type NewConversationsLocalArg struct {
-	NewConversationLocalArguments []NewConversationLocalArgument `codec:"newConversationLocalArguments" json:"newConversationLocalArguments"`
-	IdentifyBehavior              keybase1.TLFIdentifyBehavior   `codec:"identifyBehavior" json:"identifyBehavior"`
+const (
+	ChatHistorySearchSource_LIVE    ChatHistorySearchSource = 0
+	ChatHistorySearchSource_ARCHIVE ChatHistorySearchSource = 1
+)
+
+func (o ChatHistorySearchSource) DeepCopy() ChatHistorySearchSource { return o }
+
+var ChatHistorySearchSourceMap = map[string]ChatHistorySearchSource{
+	"LIVE":    0,
+	"ARCHIVE": 1,
 }
 
-type NewConversationLocalArg struct {
-	TlfName          string                       `codec:"tlfName" json:"tlfName"`
-	TopicType        TopicType                    `codec:"topicType" json:"topicType"`
-	TlfVisibility    keybase1.TLFVisibility       `codec:"tlfVisibility" json:"tlfVisibility"`
-	TopicName        *string                      `codec:"topicName,omitempty" json:"topicName,omitempty"`
-	MembersType      ConversationMembersType      `codec:"membersType" json:"membersType"`
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+var ChatHistorySearchSourceRevMap = map[ChatHistorySearchSource]string{
+	0: "LIVE",
+	1: "ARCHIVE",
 }
 
-type GetInboxSummaryForCLILocalArg struct {
-	Query GetInboxSummaryForCLILocalQuery `codec:"query" json:"query"`
+func (e ChatHistorySearchSource) String() string {
+	if v, ok := ChatHistorySearchSourceRevMap[e]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(e))
 }
 
-type GetConversationForCLILocalArg struct {
-	Query GetConversationForCLILocalQuery `codec:"query" json:"query"`
+type ChatHistorySearchHit struct {
+	Source   ChatHistorySearchSource `codec:"source" json:"source"`
+	ConvName string                  `codec:"convName" json:"convName"`
+	ConvID   *ConversationID         `codec:"convID,omitempty" json:"convID,omitempty"`
+	JobID    ArchiveJobID            `codec:"jobID" json:"jobID"`
+	MsgID    MessageID               `codec:"msgID" json:"msgID"`
+	Sender   string                  `codec:"sender" json:"sender"`
+	Ctime    gregor1.Time            `codec:"ctime" json:"ctime"`
+	Body     string                  `codec:"body" json:"body"`
 }
 
-type GetMessagesLocalArg struct {
-	ConversationID           ConversationID               `codec:"conversationID" json:"conversationID"`
-	MessageIDs               []MessageID                  `codec:"messageIDs" json:"messageIDs"`
-	DisableResolveSupersedes bool                         `codec:"disableResolveSupersedes" json:"disableResolveSupersedes"`
-	IdentifyBehavior         keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+func (o ChatHistorySearchHit) DeepCopy() ChatHistorySearchHit {
+	return ChatHistorySearchHit{
+		Source:   o.Source.DeepCopy(),
+		ConvName: o.ConvName,
+		ConvID: (func(x *ConversationID) *ConversationID {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x).DeepCopy()
+			return &tmp
+		})(o.ConvID),
+		JobID:  o.JobID.DeepCopy(),
+		MsgID:  o.MsgID.DeepCopy(),
+		Sender: o.Sender,
+		Ctime:  o.Ctime.DeepCopy(),
+		Body:   o.Body,
+	}
 }
 
-type PostFileAttachmentLocalArg struct {
-	SessionID int                   `codec:"sessionID" json:"sessionID"`
-	Arg       PostFileAttachmentArg `codec:"arg" json:"arg"`
+type ChatHistorySearchRes struct {
+	Hits             []ChatHistorySearchHit        `codec:"hits" json:"hits"`
+	Truncated        bool                          `codec:"truncated" json:"truncated"`
+	PercentIndexed   int                           `codec:"percentIndexed" json:"percentIndexed"`
+	UnreadableJobs   []ArchiveJobID                `codec:"unreadableJobs" json:"unreadableJobs"`
+	IdentifyFailures []keybase1.TLFIdentifyFailure `codec:"identifyFailures" json:"identifyFailures"`
 }
 
-type PostFileAttachmentLocalNonblockArg struct {
-	SessionID  int                   `codec:"sessionID" json:"sessionID"`
-	Arg        PostFileAttachmentArg `codec:"arg" json:"arg"`
-	ClientPrev MessageID             `codec:"clientPrev" json:"clientPrev"`
+func (o ChatHistorySearchRes) DeepCopy() ChatHistorySearchRes {
+	return ChatHistorySearchRes{
+		Hits: (func(x []ChatHistorySearchHit) []ChatHistorySearchHit {
+			if x == nil {
+				return nil
+			}
+			ret := make([]ChatHistorySearchHit, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Hits),
+		Truncated:      o.Truncated,
+		PercentIndexed: o.PercentIndexed,
+		UnreadableJobs: (func(x []ArchiveJobID) []ArchiveJobID {
+			if x == nil {
+				return nil
+			}
+			ret := make([]ArchiveJobID, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.UnreadableJobs),
+		IdentifyFailures: (func(x []keybase1.TLFIdentifyFailure) []keybase1.TLFIdentifyFailure {
+			if x == nil {
+				return nil
+			}
+			ret := make([]keybase1.TLFIdentifyFailure, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.IdentifyFailures),
+	}
 }
 
-type GetNextAttachmentMessageLocalArg struct {
-	ConvID           ConversationID               `codec:"convID" json:"convID"`
-	MessageID        MessageID                    `codec:"messageID" json:"messageID"`
-	BackInTime       bool                         `codec:"backInTime" json:"backInTime"`
-	AssetTypes       []AssetMetadataType          `codec:"assetTypes" json:"assetTypes"`
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+type ConversationContactsRes struct {
+	Vcards           string                        `codec:"vcards" json:"vcards"`
+	Count            int                           `codec:"count" json:"count"`
+	IdentifyFailures []keybase1.TLFIdentifyFailure `codec:"identifyFailures" json:"identifyFailures"`
 }
 
-type DownloadAttachmentLocalArg struct {
-	SessionID        int                          `codec:"sessionID" json:"sessionID"`
-	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
-	MessageID        MessageID                    `codec:"messageID" json:"messageID"`
-	Sink             keybase1.Stream              `codec:"sink" json:"sink"`
-	Preview          bool                         `codec:"preview" json:"preview"`
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+func (o ConversationContactsRes) DeepCopy() ConversationContactsRes {
+	return ConversationContactsRes{
+		Vcards: o.Vcards,
+		Count:  o.Count,
+		IdentifyFailures: (func(x []keybase1.TLFIdentifyFailure) []keybase1.TLFIdentifyFailure {
+			if x == nil {
+				return nil
+			}
+			ret := make([]keybase1.TLFIdentifyFailure, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.IdentifyFailures),
+	}
 }
 
-type DownloadFileAttachmentLocalArg struct {
-	SessionID        int                          `codec:"sessionID" json:"sessionID"`
-	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
-	MessageID        MessageID                    `codec:"messageID" json:"messageID"`
-	DownloadToCache  bool                         `codec:"downloadToCache" json:"downloadToCache"`
-	Preview          bool                         `codec:"preview" json:"preview"`
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+type SplitConversationLocalRes struct {
+	NewConvID        *ConversationID               `codec:"newConvID,omitempty" json:"newConvID,omitempty"`
+	FirstMsgID       MessageID                     `codec:"firstMsgID" json:"firstMsgID"`
+	LastMsgID        MessageID                     `codec:"lastMsgID" json:"lastMsgID"`
+	MessagesMoved    int                           `codec:"messagesMoved" json:"messagesMoved"`
+	MessagesSkipped  int                           `codec:"messagesSkipped" json:"messagesSkipped"`
+	IdentifyFailures []keybase1.TLFIdentifyFailure `codec:"identifyFailures" json:"identifyFailures"`
 }
 
-type ConfigureFileAttachmentDownloadLocalArg struct {
-	CacheDirOverride    string `codec:"cacheDirOverride" json:"cacheDirOverride"`
-	DownloadDirOverride string `codec:"downloadDirOverride" json:"downloadDirOverride"`
+func (o SplitConversationLocalRes) DeepCopy() SplitConversationLocalRes {
+	return SplitConversationLocalRes{
+		NewConvID: (func(x *ConversationID) *ConversationID {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x).DeepCopy()
+			return &tmp
+		})(o.NewConvID),
+		FirstMsgID:      o.FirstMsgID.DeepCopy(),
+		LastMsgID:       o.LastMsgID.DeepCopy(),
+		MessagesMoved:   o.MessagesMoved,
+		MessagesSkipped: o.MessagesSkipped,
+		IdentifyFailures: (func(x []keybase1.TLFIdentifyFailure) []keybase1.TLFIdentifyFailure {
+			if x == nil {
+				return nil
+			}
+			ret := make([]keybase1.TLFIdentifyFailure, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.IdentifyFailures),
+	}
 }
 
-type MakePreviewArg struct {
-	SessionID int      `codec:"sessionID" json:"sessionID"`
-	Filename  string   `codec:"filename" json:"filename"`
-	OutboxID  OutboxID `codec:"outboxID" json:"outboxID"`
+type ArchiveRedactionRule struct {
+	Name        string `codec:"name" json:"name"`
+	Pattern     string `codec:"pattern" json:"pattern"`
+	Replacement string `codec:"replacement" json:"replacement"`
 }
 
-type MakeAudioPreviewArg struct {
-	Amps     []float64 `codec:"amps" json:"amps"`
-	Duration int       `codec:"duration" json:"duration"`
+func (o ArchiveRedactionRule) DeepCopy() ArchiveRedactionRule {
+	return ArchiveRedactionRule{
+		Name:        o.Name,
+		Pattern:     o.Pattern,
+		Replacement: o.Replacement,
+	}
 }
 
-type GetUploadTempFileArg struct {
-	OutboxID OutboxID `codec:"outboxID" json:"outboxID"`
-	Filename string   `codec:"filename" json:"filename"`
+type ArchiveRedactionRules struct {
+	Rules []ArchiveRedactionRule `codec:"rules" json:"rules"`
 }
 
-type MakeUploadTempFileArg struct {
-	OutboxID OutboxID `codec:"outboxID" json:"outboxID"`
-	Filename string   `codec:"filename" json:"filename"`
-	Data     []byte   `codec:"data" json:"data"`
+func (o ArchiveRedactionRules) DeepCopy() ArchiveRedactionRules {
+	return ArchiveRedactionRules{
+		Rules: (func(x []ArchiveRedactionRule) []ArchiveRedactionRule {
+			if x == nil {
+				return nil
+			}
+			ret := make([]ArchiveRedactionRule, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Rules),
+	}
 }
 
-type CancelUploadTempFileArg struct {
-	OutboxID OutboxID `codec:"outboxID" json:"outboxID"`
+type ArchiveAppliedRedactionRules struct {
+	TeamID   keybase1.TeamID        `codec:"teamID" json:"teamID"`
+	TeamName string                 `codec:"teamName" json:"teamName"`
+	Rules    []ArchiveRedactionRule `codec:"rules" json:"rules"`
 }
 
-type CancelPostArg struct {
-	OutboxID OutboxID `codec:"outboxID" json:"outboxID"`
+func (o ArchiveAppliedRedactionRules) DeepCopy() ArchiveAppliedRedactionRules {
+	return ArchiveAppliedRedactionRules{
+		TeamID:   o.TeamID.DeepCopy(),
+		TeamName: o.TeamName,
+		Rules: (func(x []ArchiveRedactionRule) []ArchiveRedactionRule {
+			if x == nil {
+				return nil
+			}
+			ret := make([]ArchiveRedactionRule, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Rules),
+	}
 }
 
-type RetryPostArg struct {
-	OutboxID         OutboxID                      `codec:"outboxID" json:"outboxID"`
-	IdentifyBehavior *keybase1.TLFIdentifyBehavior `codec:"identifyBehavior,omitempty" json:"identifyBehavior,omitempty"`
+type NewMemberRestrictions struct {
+	NewMemberHours                int            `codec:"newMemberHours" json:"newMemberHours"`
+	MaxMessagesPerHour            int            `codec:"maxMessagesPerHour" json:"maxMessagesPerHour"`
+	BlockLinksAndAttachmentsHours int            `codec:"blockLinksAndAttachmentsHours" json:"blockLinksAndAttachmentsHours"`
+	ExemptUIDs                    []keybase1.UID `codec:"exemptUIDs" json:"exemptUIDs"`
 }
 
-type MarkAsReadLocalArg struct {
-	SessionID      int            `codec:"sessionID" json:"sessionID"`
-	ConversationID ConversationID `codec:"conversationID" json:"conversationID"`
-	MsgID          *MessageID     `codec:"msgID,omitempty" json:"msgID,omitempty"`
-	ForceUnread    bool           `codec:"forceUnread" json:"forceUnread"`
+func (o NewMemberRestrictions) DeepCopy() NewMemberRestrictions {
+	return NewMemberRestrictions{
+		NewMemberHours:                o.NewMemberHours,
+		MaxMessagesPerHour:            o.MaxMessagesPerHour,
+		BlockLinksAndAttachmentsHours: o.BlockLinksAndAttachmentsHours,
+		ExemptUIDs: (func(x []keybase1.UID) []keybase1.UID {
+			if x == nil {
+				return nil
+			}
+			ret := make([]keybase1.UID, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.ExemptUIDs),
+	}
 }
 
-type MarkTLFAsReadLocalArg struct {
-	SessionID int   `codec:"sessionID" json:"sessionID"`
-	TlfID     TLFID `codec:"tlfID" json:"tlfID"`
+type TrustedDeviceApproval struct {
+	Uid        keybase1.UID      `codec:"uid" json:"uid"`
+	DeviceID   keybase1.DeviceID `codec:"deviceID" json:"deviceID"`
+	Username   string            `codec:"username" json:"username"`
+	DeviceName string            `codec:"deviceName" json:"deviceName"`
+	ApprovedBy string            `codec:"approvedBy" json:"approvedBy"`
 }
 
-type FindConversationsLocalArg struct {
-	TlfName          string                       `codec:"tlfName" json:"tlfName"`
-	MembersType      ConversationMembersType      `codec:"membersType" json:"membersType"`
-	Visibility       keybase1.TLFVisibility       `codec:"visibility" json:"visibility"`
-	TopicType        TopicType                    `codec:"topicType" json:"topicType"`
-	TopicName        string                       `codec:"topicName" json:"topicName"`
-	OneChatPerTLF    *bool                        `codec:"oneChatPerTLF,omitempty" json:"oneChatPerTLF,omitempty"`
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+func (o TrustedDeviceApproval) DeepCopy() TrustedDeviceApproval {
+	return TrustedDeviceApproval{
+		Uid:        o.Uid.DeepCopy(),
+		DeviceID:   o.DeviceID.DeepCopy(),
+		Username:   o.Username,
+		DeviceName: o.DeviceName,
+		ApprovedBy: o.ApprovedBy,
+	}
 }
 
-type FindGeneralConvFromTeamIDArg struct {
-	TeamID keybase1.TeamID `codec:"teamID" json:"teamID"`
+type TrustedDeviceChannelPolicy struct {
+	ConvID           ConversationID          `codec:"convID" json:"convID"`
+	Channel          string                  `codec:"channel" json:"channel"`
+	MinDeviceAgeDays int                     `codec:"minDeviceAgeDays" json:"minDeviceAgeDays"`
+	Approved         []TrustedDeviceApproval `codec:"approved" json:"approved"`
+	SetBy            string                  `codec:"setBy" json:"setBy"`
+	Ctime            gregor1.Time            `codec:"ctime" json:"ctime"`
 }
 
-type UpdateTypingArg struct {
-	ConversationID ConversationID `codec:"conversationID" json:"conversationID"`
-	Typing         bool           `codec:"typing" json:"typing"`
+func (o TrustedDeviceChannelPolicy) DeepCopy() TrustedDeviceChannelPolicy {
+	return TrustedDeviceChannelPolicy{
+		ConvID:           o.ConvID.DeepCopy(),
+		Channel:          o.Channel,
+		MinDeviceAgeDays: o.MinDeviceAgeDays,
+		Approved: (func(x []TrustedDeviceApproval) []TrustedDeviceApproval {
+			if x == nil {
+				return nil
+			}
+			ret := make([]TrustedDeviceApproval, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Approved),
+		SetBy: o.SetBy,
+		Ctime: o.Ctime.DeepCopy(),
+	}
 }
 
-type UpdateUnsentTextArg struct {
-	ConversationID ConversationID `codec:"conversationID" json:"conversationID"`
-	TlfName        string         `codec:"tlfName" json:"tlfName"`
-	Text           string         `codec:"text" json:"text"`
+type TrustedDevicePolicies struct {
+	Channels []TrustedDeviceChannelPolicy `codec:"channels" json:"channels"`
 }
 
-type JoinConversationLocalArg struct {
-	TlfName    string                 `codec:"tlfName" json:"tlfName"`
-	TopicType  TopicType              `codec:"topicType" json:"topicType"`
-	Visibility keybase1.TLFVisibility `codec:"visibility" json:"visibility"`
-	TopicName  string                 `codec:"topicName" json:"topicName"`
+func (o TrustedDevicePolicies) DeepCopy() TrustedDevicePolicies {
+	return TrustedDevicePolicies{
+		Channels: (func(x []TrustedDeviceChannelPolicy) []TrustedDeviceChannelPolicy {
+			if x == nil {
+				return nil
+			}
+			ret := make([]TrustedDeviceChannelPolicy, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Channels),
+	}
 }
 
-type JoinConversationByIDLocalArg struct {
-	ConvID ConversationID `codec:"convID" json:"convID"`
+type TeamReadme struct {
+	Description string   `codec:"description" json:"description"`
+	Links       []string `codec:"links" json:"links"`
+	WelcomeText string   `codec:"welcomeText" json:"welcomeText"`
 }
 
-type LeaveConversationLocalArg struct {
-	ConvID ConversationID `codec:"convID" json:"convID"`
+func (o TeamReadme) DeepCopy() TeamReadme {
+	return TeamReadme{
+		Description: o.Description,
+		Links: (func(x []string) []string {
+			if x == nil {
+				return nil
+			}
+			ret := make([]string, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Links),
+		WelcomeText: o.WelcomeText,
+	}
 }
 
-type PreviewConversationByIDLocalArg struct {
-	ConvID ConversationID `codec:"convID" json:"convID"`
+type BulkDeleteMessagesRes struct {
+	NumMatched       int                           `codec:"numMatched" json:"numMatched"`
+	NumDeleted       int                           `codec:"numDeleted" json:"numDeleted"`
+	IdentifyFailures []keybase1.TLFIdentifyFailure `codec:"identifyFailures" json:"identifyFailures"`
 }
 
-type DeleteConversationLocalArg struct {
-	SessionID   int            `codec:"sessionID" json:"sessionID"`
-	ConvID      ConversationID `codec:"convID" json:"convID"`
-	ChannelName string         `codec:"channelName" json:"channelName"`
-	Confirmed   bool           `codec:"confirmed" json:"confirmed"`
+func (o BulkDeleteMessagesRes) DeepCopy() BulkDeleteMessagesRes {
+	return BulkDeleteMessagesRes{
+		NumMatched: o.NumMatched,
+		NumDeleted: o.NumDeleted,
+		IdentifyFailures: (func(x []keybase1.TLFIdentifyFailure) []keybase1.TLFIdentifyFailure {
+			if x == nil {
+				return nil
+			}
+			ret := make([]keybase1.TLFIdentifyFailure, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.IdentifyFailures),
+	}
 }
 
-type RemoveFromConversationLocalArg struct {
-	ConvID    ConversationID `codec:"convID" json:"convID"`
-	Usernames []string       `codec:"usernames" json:"usernames"`
+type ModerationAuditEntry struct {
+	Ctime        gregor1.Time   `codec:"ctime" json:"ctime"`
+	Admin        string         `codec:"admin" json:"admin"`
+	ConvID       ConversationID `codec:"convID" json:"convID"`
+	Channel      string         `codec:"channel" json:"channel"`
+	Senders      []string       `codec:"senders" json:"senders"`
+	After        *gregor1.Time  `codec:"after,omitempty" json:"after,omitempty"`
+	Before       *gregor1.Time  `codec:"before,omitempty" json:"before,omitempty"`
+	MessageTypes []MessageType  `codec:"messageTypes" json:"messageTypes"`
+	NumDeleted   int            `codec:"numDeleted" json:"numDeleted"`
 }
 
-type GetTLFConversationsLocalArg struct {
-	TlfName     string                  `codec:"tlfName" json:"tlfName"`
-	TopicType   TopicType               `codec:"topicType" json:"topicType"`
-	MembersType ConversationMembersType `codec:"membersType" json:"membersType"`
+func (o ModerationAuditEntry) DeepCopy() ModerationAuditEntry {
+	return ModerationAuditEntry{
+		Ctime:   o.Ctime.DeepCopy(),
+		Admin:   o.Admin,
+		ConvID:  o.ConvID.DeepCopy(),
+		Channel: o.Channel,
+		Senders: (func(x []string) []string {
+			if x == nil {
+				return nil
+			}
+			ret := make([]string, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Senders),
+		After: (func(x *gregor1.Time) *gregor1.Time {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x).DeepCopy()
+			return &tmp
+		})(o.After),
+		Before: (func(x *gregor1.Time) *gregor1.Time {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x).DeepCopy()
+			return &tmp
+		})(o.Before),
+		MessageTypes: (func(x []MessageType) []MessageType {
+			if x == nil {
+				return nil
+			}
+			ret := make([]MessageType, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.MessageTypes),
+		NumDeleted: o.NumDeleted,
+	}
 }
 
-type GetChannelMembershipsLocalArg struct {
-	TeamID keybase1.TeamID `codec:"teamID" json:"teamID"`
-	Uid    gregor1.UID     `codec:"uid" json:"uid"`
+type ModerationAuditLog struct {
+	Entries []ModerationAuditEntry `codec:"entries" json:"entries"`
 }
 
-type GetMutualTeamsLocalArg struct {
-	Usernames []string `codec:"usernames" json:"usernames"`
+func (o ModerationAuditLog) DeepCopy() ModerationAuditLog {
+	return ModerationAuditLog{
+		Entries: (func(x []ModerationAuditEntry) []ModerationAuditEntry {
+			if x == nil {
+				return nil
+			}
+			ret := make([]ModerationAuditEntry, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Entries),
+	}
 }
 
-type SetAppNotificationSettingsLocalArg struct {
-	ConvID      ConversationID                `codec:"convID" json:"convID"`
-	ChannelWide bool                          `codec:"channelWide" json:"channelWide"`
-	Settings    []AppNotificationSettingLocal `codec:"settings" json:"settings"`
+type NotificationRoutingWindow struct {
+	StartMinute int   `codec:"startMinute" json:"startMinute"`
+	EndMinute   int   `codec:"endMinute" json:"endMinute"`
+	Weekdays    []int `codec:"weekdays" json:"weekdays"`
 }
 
-type SetGlobalAppNotificationSettingsLocalArg struct {
-	Settings map[string]bool `codec:"settings" json:"settings"`
+func (o NotificationRoutingWindow) DeepCopy() NotificationRoutingWindow {
+	return NotificationRoutingWindow{
+		StartMinute: o.StartMinute,
+		EndMinute:   o.EndMinute,
+		Weekdays: (func(x []int) []int {
+			if x == nil {
+				return nil
+			}
+			ret := make([]int, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Weekdays),
+	}
 }
 
-type GetGlobalAppNotificationSettingsLocalArg struct {
+type NotificationRoutingRule struct {
+	DeviceID keybase1.DeviceID           `codec:"deviceID" json:"deviceID"`
+	Windows  []NotificationRoutingWindow `codec:"windows" json:"windows"`
 }
 
-type UnboxMobilePushNotificationArg struct {
-	Payload     string                  `codec:"payload" json:"payload"`
-	ConvID      string                  `codec:"convID" json:"convID"`
-	MembersType ConversationMembersType `codec:"membersType" json:"membersType"`
-	PushIDs     []string                `codec:"pushIDs" json:"pushIDs"`
-	ShouldAck   bool                    `codec:"shouldAck" json:"shouldAck"`
+func (o NotificationRoutingRule) DeepCopy() NotificationRoutingRule {
+	return NotificationRoutingRule{
+		DeviceID: o.DeviceID.DeepCopy(),
+		Windows: (func(x []NotificationRoutingWindow) []NotificationRoutingWindow {
+			if x == nil {
+				return nil
+			}
+			ret := make([]NotificationRoutingWindow, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Windows),
+	}
 }
 
-type AddTeamMemberAfterResetArg struct {
-	Username string         `codec:"username" json:"username"`
-	ConvID   ConversationID `codec:"convID" json:"convID"`
+type NotificationRoutingPreferences struct {
+	TimeZone string                    `codec:"timeZone" json:"timeZone"`
+	Rules    []NotificationRoutingRule `codec:"rules" json:"rules"`
 }
 
-type GetAllResetConvMembersArg struct {
+func (o NotificationRoutingPreferences) DeepCopy() NotificationRoutingPreferences {
+	return NotificationRoutingPreferences{
+		TimeZone: o.TimeZone,
+		Rules: (func(x []NotificationRoutingRule) []NotificationRoutingRule {
+			if x == nil {
+				return nil
+			}
+			ret := make([]NotificationRoutingRule, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Rules),
+	}
 }
 
-type SetConvRetentionLocalArg struct {
-	ConvID ConversationID  `codec:"convID" json:"convID"`
-	Policy RetentionPolicy `codec:"policy" json:"policy"`
+type MentionDigestSettings struct {
+	Disabled      bool              `codec:"disabled" json:"disabled"`
+	OptedOutTeams []keybase1.TeamID `codec:"optedOutTeams" json:"optedOutTeams"`
 }
 
-type SetTeamRetentionLocalArg struct {
-	TeamID keybase1.TeamID `codec:"teamID" json:"teamID"`
-	Policy RetentionPolicy `codec:"policy" json:"policy"`
+func (o MentionDigestSettings) DeepCopy() MentionDigestSettings {
+	return MentionDigestSettings{
+		Disabled: o.Disabled,
+		OptedOutTeams: (func(x []keybase1.TeamID) []keybase1.TeamID {
+			if x == nil {
+				return nil
+			}
+			ret := make([]keybase1.TeamID, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.OptedOutTeams),
+	}
 }
 
-type GetTeamRetentionLocalArg struct {
-	TeamID keybase1.TeamID `codec:"teamID" json:"teamID"`
+type MentionDigestItem struct {
+	MsgID   MessageID    `codec:"msgID" json:"msgID"`
+	Sender  string       `codec:"sender" json:"sender"`
+	Ctime   gregor1.Time `codec:"ctime" json:"ctime"`
+	Snippet string       `codec:"snippet" json:"snippet"`
 }
 
-type SetConvMinWriterRoleLocalArg struct {
-	ConvID ConversationID    `codec:"convID" json:"convID"`
-	Role   keybase1.TeamRole `codec:"role" json:"role"`
+func (o MentionDigestItem) DeepCopy() MentionDigestItem {
+	return MentionDigestItem{
+		MsgID:   o.MsgID.DeepCopy(),
+		Sender:  o.Sender,
+		Ctime:   o.Ctime.DeepCopy(),
+		Snippet: o.Snippet,
+	}
 }
 
-type UpgradeKBFSConversationToImpteamArg struct {
-	ConvID ConversationID `codec:"convID" json:"convID"`
+type MentionDigestConv struct {
+	ConvID       ConversationID      `codec:"convID" json:"convID"`
+	TlfName      string              `codec:"tlfName" json:"tlfName"`
+	Channel      string              `codec:"channel" json:"channel"`
+	MentionCount int                 `codec:"mentionCount" json:"mentionCount"`
+	Items        []MentionDigestItem `codec:"items" json:"items"`
 }
 
-type SearchRegexpArg struct {
-	SessionID        int                          `codec:"sessionID" json:"sessionID"`
-	ConvID           ConversationID               `codec:"convID" json:"convID"`
-	Query            string                       `codec:"query" json:"query"`
-	Opts             SearchOpts                   `codec:"opts" json:"opts"`
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+func (o MentionDigestConv) DeepCopy() MentionDigestConv {
+	return MentionDigestConv{
+		ConvID:       o.ConvID.DeepCopy(),
+		TlfName:      o.TlfName,
+		Channel:      o.Channel,
+		MentionCount: o.MentionCount,
+		Items: (func(x []MentionDigestItem) []MentionDigestItem {
+			if x == nil {
+				return nil
+			}
+			ret := make([]MentionDigestItem, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Items),
+	}
 }
 
-type CancelActiveInboxSearchArg struct {
+type MentionDigest struct {
+	Since gregor1.Time        `codec:"since" json:"since"`
+	Until gregor1.Time        `codec:"until" json:"until"`
+	Convs []MentionDigestConv `codec:"convs" json:"convs"`
 }
 
-type SearchInboxArg struct {
-	SessionID        int                          `codec:"sessionID" json:"sessionID"`
-	Query            string                       `codec:"query" json:"query"`
-	Opts             SearchOpts                   `codec:"opts" json:"opts"`
-	NamesOnly        bool                         `codec:"namesOnly" json:"namesOnly"`
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+func (o MentionDigest) DeepCopy() MentionDigest {
+	return MentionDigest{
+		Since: o.Since.DeepCopy(),
+		Until: o.Until.DeepCopy(),
+		Convs: (func(x []MentionDigestConv) []MentionDigestConv {
+			if x == nil {
+				return nil
+			}
+			ret := make([]MentionDigestConv, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Convs),
+	}
 }
 
-type SimpleSearchInboxConvNamesArg struct {
-	Query string `codec:"query" json:"query"`
+type IncidentModeChannel struct {
+	ConvID    ConversationID `codec:"convID" json:"convID"`
+	Channel   string         `codec:"channel" json:"channel"`
+	SetBy     string         `codec:"setBy" json:"setBy"`
+	Ctime     gregor1.Time   `codec:"ctime" json:"ctime"`
+	ExpiresAt gregor1.Time   `codec:"expiresAt" json:"expiresAt"`
 }
 
-type CancelActiveSearchArg struct {
+func (o IncidentModeChannel) DeepCopy() IncidentModeChannel {
+	return IncidentModeChannel{
+		ConvID:    o.ConvID.DeepCopy(),
+		Channel:   o.Channel,
+		SetBy:     o.SetBy,
+		Ctime:     o.Ctime.DeepCopy(),
+		ExpiresAt: o.ExpiresAt.DeepCopy(),
+	}
 }
 
-type ProfileChatSearchArg struct {
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+type IncidentModeSettings struct {
+	Channels []IncidentModeChannel `codec:"channels" json:"channels"`
 }
 
-type GetStaticConfigArg struct {
+func (o IncidentModeSettings) DeepCopy() IncidentModeSettings {
+	return IncidentModeSettings{
+		Channels: (func(x []IncidentModeChannel) []IncidentModeChannel {
+			if x == nil {
+				return nil
+			}
+			ret := make([]IncidentModeChannel, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Channels),
+	}
 }
 
-type ResolveUnfurlPromptArg struct {
-	ConvID           ConversationID               `codec:"convID" json:"convID"`
-	MsgID            MessageID                    `codec:"msgID" json:"msgID"`
-	Result           UnfurlPromptResult           `codec:"result" json:"result"`
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
-}
+type IncidentModeAction int
 
-type GetUnfurlSettingsArg struct {
+const (
+	IncidentModeAction_ENABLED  IncidentModeAction = 0
+	IncidentModeAction_DISABLED IncidentModeAction = 1
+	IncidentModeAction_EXPIRED  IncidentModeAction = 2
+)
+
+func (o IncidentModeAction) DeepCopy() IncidentModeAction { return o }
+
+var IncidentModeActionMap = map[string]IncidentModeAction{
+	"ENABLED":  0,
+	"DISABLED": 1,
+	"EXPIRED":  2,
 }
 
-type SaveUnfurlSettingsArg struct {
-	Mode      UnfurlMode `codec:"mode" json:"mode"`
-	Whitelist []string   `codec:"whitelist" json:"whitelist"`
+var IncidentModeActionRevMap = map[IncidentModeAction]string{
+	0: "ENABLED",
+	1: "DISABLED",
+	2: "EXPIRED",
 }
 
-type ToggleMessageCollapseArg struct {
-	ConvID   ConversationID `codec:"convID" json:"convID"`
-	MsgID    MessageID      `codec:"msgID" json:"msgID"`
-	Collapse bool           `codec:"collapse" json:"collapse"`
+func (e IncidentModeAction) String() string {
+	if v, ok := IncidentModeActionRevMap[e]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(e))
 }
 
-type BulkAddToConvArg struct {
-	ConvID    ConversationID `codec:"convID" json:"convID"`
-	Usernames []string       `codec:"usernames" json:"usernames"`
+type IncidentModeAuditEntry struct {
+	Ctime     gregor1.Time       `codec:"ctime" json:"ctime"`
+	Admin     string             `codec:"admin" json:"admin"`
+	ConvID    ConversationID     `codec:"convID" json:"convID"`
+	Channel   string             `codec:"channel" json:"channel"`
+	Action    IncidentModeAction `codec:"action" json:"action"`
+	ExpiresAt gregor1.Time       `codec:"expiresAt" json:"expiresAt"`
 }
 
-type BulkAddToManyConvsArg struct {
-	Conversations []ConversationID `codec:"conversations" json:"conversations"`
-	Usernames     []string         `codec:"usernames" json:"usernames"`
+func (o IncidentModeAuditEntry) DeepCopy() IncidentModeAuditEntry {
+	return IncidentModeAuditEntry{
+		Ctime:     o.Ctime.DeepCopy(),
+		Admin:     o.Admin,
+		ConvID:    o.ConvID.DeepCopy(),
+		Channel:   o.Channel,
+		Action:    o.Action.DeepCopy(),
+		ExpiresAt: o.ExpiresAt.DeepCopy(),
+	}
 }
 
-type PutReacjiSkinToneArg struct {
-	SkinTone keybase1.ReacjiSkinTone `codec:"skinTone" json:"skinTone"`
+type IncidentModeAuditLog struct {
+	Entries []IncidentModeAuditEntry `codec:"entries" json:"entries"`
 }
 
-type ResolveMaybeMentionArg struct {
-	Mention MaybeMention `codec:"mention" json:"mention"`
+func (o IncidentModeAuditLog) DeepCopy() IncidentModeAuditLog {
+	return IncidentModeAuditLog{
+		Entries: (func(x []IncidentModeAuditEntry) []IncidentModeAuditEntry {
+			if x == nil {
+				return nil
+			}
+			ret := make([]IncidentModeAuditEntry, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Entries),
+	}
 }
 
-type LoadGalleryArg struct {
-	SessionID int            `codec:"sessionID" json:"sessionID"`
-	ConvID    ConversationID `codec:"convID" json:"convID"`
-	Typ       GalleryItemTyp `codec:"typ" json:"typ"`
-	Num       int            `codec:"num" json:"num"`
-	FromMsgID *MessageID     `codec:"fromMsgID,omitempty" json:"fromMsgID,omitempty"`
+type ChannelBannerSettings struct {
+	Banners []ChannelBanner `codec:"banners" json:"banners"`
 }
 
-type LoadFlipArg struct {
-	HostConvID ConversationID `codec:"hostConvID" json:"hostConvID"`
-	HostMsgID  MessageID      `codec:"hostMsgID" json:"hostMsgID"`
-	FlipConvID ConversationID `codec:"flipConvID" json:"flipConvID"`
-	GameID     FlipGameID     `codec:"gameID" json:"gameID"`
+func (o ChannelBannerSettings) DeepCopy() ChannelBannerSettings {
+	return ChannelBannerSettings{
+		Banners: (func(x []ChannelBanner) []ChannelBanner {
+			if x == nil {
+				return nil
+			}
+			ret := make([]ChannelBanner, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Banners),
+	}
 }
 
-type LocationUpdateArg struct {
-	Coord Coordinate `codec:"coord" json:"coord"`
-}
+type ChannelBannerAction int
 
-type AdvertiseBotCommandsLocalArg struct {
-	Alias          *string                  `codec:"alias,omitempty" json:"alias,omitempty"`
-	Advertisements []AdvertiseCommandsParam `codec:"advertisements" json:"advertisements"`
-}
+const (
+	ChannelBannerAction_SET     ChannelBannerAction = 0
+	ChannelBannerAction_CLEARED ChannelBannerAction = 1
+	ChannelBannerAction_EXPIRED ChannelBannerAction = 2
+)
 
-type ListBotCommandsLocalArg struct {
-	ConvID ConversationID `codec:"convID" json:"convID"`
+func (o ChannelBannerAction) DeepCopy() ChannelBannerAction { return o }
+
+var ChannelBannerActionMap = map[string]ChannelBannerAction{
+	"SET":     0,
+	"CLEARED": 1,
+	"EXPIRED": 2,
 }
 
-type ListPublicBotCommandsLocalArg struct {
-	Username string `codec:"username" json:"username"`
+var ChannelBannerActionRevMap = map[ChannelBannerAction]string{
+	0: "SET",
+	1: "CLEARED",
+	2: "EXPIRED",
 }
 
-type ClearBotCommandsLocalArg struct {
-	Filter *ClearBotCommandsFilter `codec:"filter,omitempty" json:"filter,omitempty"`
+func (e ChannelBannerAction) String() string {
+	if v, ok := ChannelBannerActionRevMap[e]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(e))
 }
 
-type PinMessageArg struct {
-	ConvID ConversationID `codec:"convID" json:"convID"`
-	MsgID  MessageID      `codec:"msgID" json:"msgID"`
+type ChannelBannerAuditEntry struct {
+	Ctime     gregor1.Time        `codec:"ctime" json:"ctime"`
+	Admin     string              `codec:"admin" json:"admin"`
+	ConvID    ConversationID      `codec:"convID" json:"convID"`
+	Channel   string              `codec:"channel" json:"channel"`
+	Action    ChannelBannerAction `codec:"action" json:"action"`
+	Text      string              `codec:"text" json:"text"`
+	ExpiresAt gregor1.Time        `codec:"expiresAt" json:"expiresAt"`
 }
 
-type UnpinMessageArg struct {
-	ConvID ConversationID `codec:"convID" json:"convID"`
+func (o ChannelBannerAuditEntry) DeepCopy() ChannelBannerAuditEntry {
+	return ChannelBannerAuditEntry{
+		Ctime:     o.Ctime.DeepCopy(),
+		Admin:     o.Admin,
+		ConvID:    o.ConvID.DeepCopy(),
+		Channel:   o.Channel,
+		Action:    o.Action.DeepCopy(),
+		Text:      o.Text,
+		ExpiresAt: o.ExpiresAt.DeepCopy(),
+	}
 }
 
-type IgnorePinnedMessageArg struct {
-	ConvID ConversationID `codec:"convID" json:"convID"`
+type ChannelBannerAuditLog struct {
+	Entries []ChannelBannerAuditEntry `codec:"entries" json:"entries"`
 }
 
-type AddBotMemberArg struct {
-	ConvID      ConversationID            `codec:"convID" json:"convID"`
-	Username    string                    `codec:"username" json:"username"`
-	BotSettings *keybase1.TeamBotSettings `codec:"botSettings,omitempty" json:"botSettings,omitempty"`
-	Role        keybase1.TeamRole         `codec:"role" json:"role"`
+func (o ChannelBannerAuditLog) DeepCopy() ChannelBannerAuditLog {
+	return ChannelBannerAuditLog{
+		Entries: (func(x []ChannelBannerAuditEntry) []ChannelBannerAuditEntry {
+			if x == nil {
+				return nil
+			}
+			ret := make([]ChannelBannerAuditEntry, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Entries),
+	}
 }
 
-type EditBotMemberArg struct {
-	ConvID      ConversationID            `codec:"convID" json:"convID"`
-	Username    string                    `codec:"username" json:"username"`
-	BotSettings *keybase1.TeamBotSettings `codec:"botSettings,omitempty" json:"botSettings,omitempty"`
-	Role        keybase1.TeamRole         `codec:"role" json:"role"`
+type MembershipHook struct {
+	BotUsername string   `codec:"botUsername" json:"botUsername"`
+	Channels    []string `codec:"channels" json:"channels"`
 }
 
-type RemoveBotMemberArg struct {
-	ConvID   ConversationID `codec:"convID" json:"convID"`
-	Username string         `codec:"username" json:"username"`
+func (o MembershipHook) DeepCopy() MembershipHook {
+	return MembershipHook{
+		BotUsername: o.BotUsername,
+		Channels: (func(x []string) []string {
+			if x == nil {
+				return nil
+			}
+			ret := make([]string, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Channels),
+	}
 }
 
-type SetBotMemberSettingsArg struct {
-	ConvID      ConversationID           `codec:"convID" json:"convID"`
-	Username    string                   `codec:"username" json:"username"`
-	BotSettings keybase1.TeamBotSettings `codec:"botSettings" json:"botSettings"`
+type MembershipEventType int
+
+const (
+	MembershipEventType_JOINED        MembershipEventType = 0
+	MembershipEventType_LEFT          MembershipEventType = 1
+	MembershipEventType_ADDED_TO_TEAM MembershipEventType = 2
+)
+
+func (o MembershipEventType) DeepCopy() MembershipEventType { return o }
+
+var MembershipEventTypeMap = map[string]MembershipEventType{
+	"JOINED":        0,
+	"LEFT":          1,
+	"ADDED_TO_TEAM": 2,
 }
 
-type GetBotMemberSettingsArg struct {
-	ConvID   ConversationID `codec:"convID" json:"convID"`
-	Username string         `codec:"username" json:"username"`
+var MembershipEventTypeRevMap = map[MembershipEventType]string{
+	0: "JOINED",
+	1: "LEFT",
+	2: "ADDED_TO_TEAM",
 }
 
-type GetTeamRoleInConversationArg struct {
-	ConvID   ConversationID `codec:"convID" json:"convID"`
-	Username string         `codec:"username" json:"username"`
+func (e MembershipEventType) String() string {
+	if v, ok := MembershipEventTypeRevMap[e]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(e))
 }
 
-type AddBotConvSearchArg struct {
-	Term string `codec:"term" json:"term"`
+type MembershipEvent struct {
+	Id       string              `codec:"id" json:"id"`
+	Type     MembershipEventType `codec:"type" json:"type"`
+	Team     string              `codec:"team" json:"team"`
+	Channel  string              `codec:"channel" json:"channel"`
+	Username string              `codec:"username" json:"username"`
+	Actor    string              `codec:"actor" json:"actor"`
+	Ctime    gregor1.Time        `codec:"ctime" json:"ctime"`
 }
 
-type ForwardMessageConvSearchArg struct {
-	Term string `codec:"term" json:"term"`
+func (o MembershipEvent) DeepCopy() MembershipEvent {
+	return MembershipEvent{
+		Id:       o.Id,
+		Type:     o.Type.DeepCopy(),
+		Team:     o.Team,
+		Channel:  o.Channel,
+		Username: o.Username,
+		Actor:    o.Actor,
+		Ctime:    o.Ctime.DeepCopy(),
+	}
 }
 
-type TeamIDFromTLFNameArg struct {
-	TlfName     string                  `codec:"tlfName" json:"tlfName"`
-	MembersType ConversationMembersType `codec:"membersType" json:"membersType"`
-	TlfPublic   bool                    `codec:"tlfPublic" json:"tlfPublic"`
+type PollOptionResult struct {
+	Index  int      `codec:"index" json:"index"`
+	Option string   `codec:"option" json:"option"`
+	Voters []string `codec:"voters" json:"voters"`
 }
 
-type DismissJourneycardArg struct {
-	ConvID   ConversationID  `codec:"convID" json:"convID"`
-	CardType JourneycardType `codec:"cardType" json:"cardType"`
+func (o PollOptionResult) DeepCopy() PollOptionResult {
+	return PollOptionResult{
+		Index:  o.Index,
+		Option: o.Option,
+		Voters: (func(x []string) []string {
+			if x == nil {
+				return nil
+			}
+			ret := make([]string, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Voters),
+	}
 }
 
-type SetWelcomeMessageArg struct {
-	TeamID  keybase1.TeamID `codec:"teamID" json:"teamID"`
-	Message WelcomeMessage  `codec:"message" json:"message"`
+type PollResults struct {
+	MsgID       MessageID          `codec:"msgID" json:"msgID"`
+	Question    string             `codec:"question" json:"question"`
+	MultiSelect bool               `codec:"multiSelect" json:"multiSelect"`
+	Options     []PollOptionResult `codec:"options" json:"options"`
+	TotalVoters int                `codec:"totalVoters" json:"totalVoters"`
 }
 
-type GetWelcomeMessageArg struct {
-	TeamID keybase1.TeamID `codec:"teamID" json:"teamID"`
+func (o PollResults) DeepCopy() PollResults {
+	return PollResults{
+		MsgID:       o.MsgID.DeepCopy(),
+		Question:    o.Question,
+		MultiSelect: o.MultiSelect,
+		Options: (func(x []PollOptionResult) []PollOptionResult {
+			if x == nil {
+				return nil
+			}
+			ret := make([]PollOptionResult, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Options),
+		TotalVoters: o.TotalVoters,
+	}
 }
 
-type GetDefaultTeamChannelsLocalArg struct {
-	TeamID keybase1.TeamID `codec:"teamID" json:"teamID"`
+type AttachmentExpiryPolicy struct {
+	Days int `codec:"days" json:"days"`
 }
 
-type SetDefaultTeamChannelsLocalArg struct {
-	TeamID keybase1.TeamID `codec:"teamID" json:"teamID"`
-	Convs  []ConvIDStr     `codec:"convs" json:"convs"`
+func (o AttachmentExpiryPolicy) DeepCopy() AttachmentExpiryPolicy {
+	return AttachmentExpiryPolicy{
+		Days: o.Days,
+	}
 }
 
-type GetLastActiveForTLFArg struct {
-	TlfID TLFIDStr `codec:"tlfID" json:"tlfID"`
+type AttachmentExpiryProgress struct {
+	ExpiredThrough map[string]MessageID `codec:"expiredThrough" json:"expiredThrough"`
 }
 
-type GetLastActiveForTeamsArg struct {
+func (o AttachmentExpiryProgress) DeepCopy() AttachmentExpiryProgress {
+	return AttachmentExpiryProgress{
+		ExpiredThrough: (func(x map[string]MessageID) map[string]MessageID {
+			if x == nil {
+				return nil
+			}
+			ret := make(map[string]MessageID, len(x))
+			for k, v := range x {
+				kCopy := k
+				vCopy := v.DeepCopy()
+				ret[kCopy] = vCopy
+			}
+			return ret
+		})(o.ExpiredThrough),
+	}
 }
 
-type GetRecentJoinsLocalArg struct {
-	ConvID ConversationID `codec:"convID" json:"convID"`
+type AttachmentExpiryConv struct {
+	ConvID             ConversationID `codec:"convID" json:"convID"`
+	Channel            string         `codec:"channel" json:"channel"`
+	NumAttachments     int            `codec:"numAttachments" json:"numAttachments"`
+	NumBytes           int64          `codec:"numBytes" json:"numBytes"`
+	CoveredByRetention bool           `codec:"coveredByRetention" json:"coveredByRetention"`
 }
 
-type RefreshParticipantsArg struct {
-	ConvID ConversationID `codec:"convID" json:"convID"`
+func (o AttachmentExpiryConv) DeepCopy() AttachmentExpiryConv {
+	return AttachmentExpiryConv{
+		ConvID:             o.ConvID.DeepCopy(),
+		Channel:            o.Channel,
+		NumAttachments:     o.NumAttachments,
+		NumBytes:           o.NumBytes,
+		CoveredByRetention: o.CoveredByRetention,
+	}
 }
 
-type GetLastActiveAtLocalArg struct {
-	TeamID   keybase1.TeamID `codec:"teamID" json:"teamID"`
-	Username string          `codec:"username" json:"username"`
+type AttachmentExpiryReport struct {
+	Days           int                    `codec:"days" json:"days"`
+	Cutoff         gregor1.Time           `codec:"cutoff" json:"cutoff"`
+	DryRun         bool                   `codec:"dryRun" json:"dryRun"`
+	NumAttachments int                    `codec:"numAttachments" json:"numAttachments"`
+	NumBytes       int64                  `codec:"numBytes" json:"numBytes"`
+	Convs          []AttachmentExpiryConv `codec:"convs" json:"convs"`
 }
 
-type GetLastActiveAtMultiLocalArg struct {
-	TeamIDs  []keybase1.TeamID `codec:"teamIDs" json:"teamIDs"`
-	Username string            `codec:"username" json:"username"`
+func (o AttachmentExpiryReport) DeepCopy() AttachmentExpiryReport {
+	return AttachmentExpiryReport{
+		Days:           o.Days,
+		Cutoff:         o.Cutoff.DeepCopy(),
+		DryRun:         o.DryRun,
+		NumAttachments: o.NumAttachments,
+		NumBytes:       o.NumBytes,
+		Convs: (func(x []AttachmentExpiryConv) []AttachmentExpiryConv {
+			if x == nil {
+				return nil
+			}
+			ret := make([]AttachmentExpiryConv, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Convs),
+	}
 }
 
-type GetParticipantsArg struct {
-	ConvID ConversationID `codec:"convID" json:"convID"`
+type TaskInfo struct {
+	ConvID   ConversationID `codec:"convID" json:"convID"`
+	Channel  string         `codec:"channel" json:"channel"`
+	MsgID    MessageID      `codec:"msgID" json:"msgID"`
+	Title    string         `codec:"title" json:"title"`
+	Creator  string         `codec:"creator" json:"creator"`
+	Ctime    gregor1.Time   `codec:"ctime" json:"ctime"`
+	Assignee string         `codec:"assignee" json:"assignee"`
+	Done     bool           `codec:"done" json:"done"`
+	DoneBy   string         `codec:"doneBy" json:"doneBy"`
 }
 
-type AddEmojiArg struct {
-	ConvID         ConversationID `codec:"convID" json:"convID"`
-	Alias          string         `codec:"alias" json:"alias"`
-	Filename       string         `codec:"filename" json:"filename"`
-	AllowOverwrite bool           `codec:"allowOverwrite" json:"allowOverwrite"`
+func (o TaskInfo) DeepCopy() TaskInfo {
+	return TaskInfo{
+		ConvID:   o.ConvID.DeepCopy(),
+		Channel:  o.Channel,
+		MsgID:    o.MsgID.DeepCopy(),
+		Title:    o.Title,
+		Creator:  o.Creator,
+		Ctime:    o.Ctime.DeepCopy(),
+		Assignee: o.Assignee,
+		Done:     o.Done,
+		DoneBy:   o.DoneBy,
+	}
 }
 
-type AddEmojisArg struct {
-	ConvID         ConversationID `codec:"convID" json:"convID"`
-	Aliases        []string       `codec:"aliases" json:"aliases"`
-	Filenames      []string       `codec:"filenames" json:"filenames"`
-	AllowOverwrite []bool         `codec:"allowOverwrite" json:"allowOverwrite"`
+type WidgetFeedItem struct {
+	ConvID  ConversationID `codec:"convID" json:"convID"`
+	TlfName string         `codec:"tlfName" json:"tlfName"`
+	Channel string         `codec:"channel" json:"channel"`
+	MsgID   MessageID      `codec:"msgID" json:"msgID"`
+	Sender  string         `codec:"sender" json:"sender"`
+	Ctime   gregor1.Time   `codec:"ctime" json:"ctime"`
+	Snippet string         `codec:"snippet" json:"snippet"`
+	Pinned  bool           `codec:"pinned" json:"pinned"`
 }
 
-type AddEmojiAliasArg struct {
-	ConvID        ConversationID `codec:"convID" json:"convID"`
-	NewAlias      string         `codec:"newAlias" json:"newAlias"`
-	ExistingAlias string         `codec:"existingAlias" json:"existingAlias"`
+func (o WidgetFeedItem) DeepCopy() WidgetFeedItem {
+	return WidgetFeedItem{
+		ConvID:  o.ConvID.DeepCopy(),
+		TlfName: o.TlfName,
+		Channel: o.Channel,
+		MsgID:   o.MsgID.DeepCopy(),
+		Sender:  o.Sender,
+		Ctime:   o.Ctime.DeepCopy(),
+		Snippet: o.Snippet,
+		Pinned:  o.Pinned,
+	}
 }
 
-type RemoveEmojiArg struct {
-	ConvID ConversationID `codec:"convID" json:"convID"`
-	Alias  string         `codec:"alias" json:"alias"`
+type WidgetFeed struct {
+	Version int              `codec:"version" json:"version"`
+	Updated gregor1.Time     `codec:"updated" json:"updated"`
+	Items   []WidgetFeedItem `codec:"items" json:"items"`
 }
 
-type UserEmojisArg struct {
-	Opts   EmojiFetchOpts  `codec:"opts" json:"opts"`
-	ConvID *ConversationID `codec:"convID,omitempty" json:"convID,omitempty"`
+func (o WidgetFeed) DeepCopy() WidgetFeed {
+	return WidgetFeed{
+		Version: o.Version,
+		Updated: o.Updated.DeepCopy(),
+		Items: (func(x []WidgetFeedItem) []WidgetFeedItem {
+			if x == nil {
+				return nil
+			}
+			ret := make([]WidgetFeedItem, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Items),
+	}
 }
 
-type ToggleEmojiAnimationsArg struct {
-	Enabled bool `codec:"enabled" json:"enabled"`
+type AttachmentAutoDownloadRule struct {
+	Previews          bool  `codec:"previews" json:"previews"`
+	Attachments       bool  `codec:"attachments" json:"attachments"`
+	MaxAttachmentSize int64 `codec:"maxAttachmentSize" json:"maxAttachmentSize"`
 }
 
-type TrackGiphySelectArg struct {
-	SessionID int               `codec:"sessionID" json:"sessionID"`
-	Result    GiphySearchResult `codec:"result" json:"result"`
+func (o AttachmentAutoDownloadRule) DeepCopy() AttachmentAutoDownloadRule {
+	return AttachmentAutoDownloadRule{
+		Previews:          o.Previews,
+		Attachments:       o.Attachments,
+		MaxAttachmentSize: o.MaxAttachmentSize,
+	}
 }
 
-type ArchiveChatArg struct {
-	Req ArchiveChatJobRequest `codec:"req" json:"req"`
+type AttachmentAutoDownloadPolicy struct {
+	Wifi     AttachmentAutoDownloadRule `codec:"wifi" json:"wifi"`
+	Cellular AttachmentAutoDownloadRule `codec:"cellular" json:"cellular"`
+	Metered  AttachmentAutoDownloadRule `codec:"metered" json:"metered"`
 }
 
-type ArchiveChatListArg struct {
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+func (o AttachmentAutoDownloadPolicy) DeepCopy() AttachmentAutoDownloadPolicy {
+	return AttachmentAutoDownloadPolicy{
+		Wifi:     o.Wifi.DeepCopy(),
+		Cellular: o.Cellular.DeepCopy(),
+		Metered:  o.Metered.DeepCopy(),
+	}
 }
 
-type ArchiveChatDeleteArg struct {
-	JobID            ArchiveJobID                 `codec:"jobID" json:"jobID"`
-	DeleteOutputPath bool                         `codec:"deleteOutputPath" json:"deleteOutputPath"`
+type GetThreadLocalArg struct {
+	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
+	Reason           GetThreadReason              `codec:"reason" json:"reason"`
+	Query            *GetThreadQuery              `codec:"query,omitempty" json:"query,omitempty"`
+	Pagination       *Pagination                  `codec:"pagination,omitempty" json:"pagination,omitempty"`
 	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
 }
 
-type ArchiveChatPauseArg struct {
-	JobID            ArchiveJobID                 `codec:"jobID" json:"jobID"`
+type GetThreadNonblockArg struct {
+	SessionID        int                          `codec:"sessionID" json:"sessionID"`
+	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
+	CbMode           GetThreadNonblockCbMode      `codec:"cbMode" json:"cbMode"`
+	Reason           GetThreadReason              `codec:"reason" json:"reason"`
+	Pgmode           GetThreadNonblockPgMode      `codec:"pgmode" json:"pgmode"`
+	Query            *GetThreadQuery              `codec:"query,omitempty" json:"query,omitempty"`
+	KnownRemotes     []string                     `codec:"knownRemotes" json:"knownRemotes"`
+	Pagination       *UIPagination                `codec:"pagination,omitempty" json:"pagination,omitempty"`
 	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
 }
 
-type ArchiveChatResumeArg struct {
-	JobID            ArchiveJobID                 `codec:"jobID" json:"jobID"`
+type GetUnreadlineArg struct {
+	SessionID        int                          `codec:"sessionID" json:"sessionID"`
+	ConvID           ConversationID               `codec:"convID" json:"convID"`
+	ReadMsgID        MessageID                    `codec:"readMsgID" json:"readMsgID"`
 	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
 }
 
-type LocalInterface interface {
-	GetThreadLocal(context.Context, GetThreadLocalArg) (GetThreadLocalRes, error)
-	GetThreadNonblock(context.Context, GetThreadNonblockArg) (NonblockFetchRes, error)
-	GetUnreadline(context.Context, GetUnreadlineArg) (UnreadlineRes, error)
-	GetInboxAndUnboxLocal(context.Context, GetInboxAndUnboxLocalArg) (GetInboxAndUnboxLocalRes, error)
-	GetInboxAndUnboxUILocal(context.Context, GetInboxAndUnboxUILocalArg) (GetInboxAndUnboxUILocalRes, error)
-	RequestInboxLayout(context.Context, InboxLayoutReselectMode) error
-	RequestInboxUnbox(context.Context, []ConversationID) error
-	RequestInboxSmallIncrease(context.Context) error
-	RequestInboxSmallReset(context.Context) error
-	GetInboxNonblockLocal(context.Context, GetInboxNonblockLocalArg) (NonblockFetchRes, error)
-	PostLocal(context.Context, PostLocalArg) (PostLocalRes, error)
-	GenerateOutboxID(context.Context) (OutboxID, error)
-	PostLocalNonblock(context.Context, PostLocalNonblockArg) (PostLocalNonblockRes, error)
-	ForwardMessage(context.Context, ForwardMessageArg) (PostLocalRes, error)
-	ForwardMessageNonblock(context.Context, ForwardMessageNonblockArg) (PostLocalNonblockRes, error)
-	PostTextNonblock(context.Context, PostTextNonblockArg) (PostLocalNonblockRes, error)
-	PostDeleteNonblock(context.Context, PostDeleteNonblockArg) (PostLocalNonblockRes, error)
-	PostEditNonblock(context.Context, PostEditNonblockArg) (PostLocalNonblockRes, error)
-	PostReactionNonblock(context.Context, PostReactionNonblockArg) (PostLocalNonblockRes, error)
-	PostHeadlineNonblock(context.Context, PostHeadlineNonblockArg) (PostLocalNonblockRes, error)
-	PostHeadline(context.Context, PostHeadlineArg) (PostLocalRes, error)
-	PostMetadataNonblock(context.Context, PostMetadataNonblockArg) (PostLocalNonblockRes, error)
-	PostMetadata(context.Context, PostMetadataArg) (PostLocalRes, error)
-	PostDeleteHistoryUpto(context.Context, PostDeleteHistoryUptoArg) (PostLocalRes, error)
-	PostDeleteHistoryThrough(context.Context, PostDeleteHistoryThroughArg) (PostLocalRes, error)
-	PostDeleteHistoryByAge(context.Context, PostDeleteHistoryByAgeArg) (PostLocalRes, error)
-	SetConversationStatusLocal(context.Context, SetConversationStatusLocalArg) (SetConversationStatusLocalRes, error)
-	NewConversationsLocal(context.Context, NewConversationsLocalArg) (NewConversationsLocalRes, error)
-	NewConversationLocal(context.Context, NewConversationLocalArg) (NewConversationLocalRes, error)
-	GetInboxSummaryForCLILocal(context.Context, GetInboxSummaryForCLILocalQuery) (GetInboxSummaryForCLILocalRes, error)
-	GetConversationForCLILocal(context.Context, GetConversationForCLILocalQuery) (GetConversationForCLILocalRes, error)
-	GetMessagesLocal(context.Context, GetMessagesLocalArg) (GetMessagesLocalRes, error)
-	PostFileAttachmentLocal(context.Context, PostFileAttachmentLocalArg) (PostLocalRes, error)
-	PostFileAttachmentLocalNonblock(context.Context, PostFileAttachmentLocalNonblockArg) (PostLocalNonblockRes, error)
-	GetNextAttachmentMessageLocal(context.Context, GetNextAttachmentMessageLocalArg) (GetNextAttachmentMessageLocalRes, error)
-	DownloadAttachmentLocal(context.Context, DownloadAttachmentLocalArg) (DownloadAttachmentLocalRes, error)
-	DownloadFileAttachmentLocal(context.Context, DownloadFileAttachmentLocalArg) (DownloadFileAttachmentLocalRes, error)
-	ConfigureFileAttachmentDownloadLocal(context.Context, ConfigureFileAttachmentDownloadLocalArg) error
-	MakePreview(context.Context, MakePreviewArg) (MakePreviewRes, error)
-	MakeAudioPreview(context.Context, MakeAudioPreviewArg) (MakePreviewRes, error)
-	GetUploadTempFile(context.Context, GetUploadTempFileArg) (string, error)
-	MakeUploadTempFile(context.Context, MakeUploadTempFileArg) (string, error)
-	CancelUploadTempFile(context.Context, OutboxID) error
-	CancelPost(context.Context, OutboxID) error
-	RetryPost(context.Context, RetryPostArg) error
-	MarkAsReadLocal(context.Context, MarkAsReadLocalArg) (MarkAsReadLocalRes, error)
-	MarkTLFAsReadLocal(context.Context, MarkTLFAsReadLocalArg) (MarkTLFAsReadLocalRes, error)
-	FindConversationsLocal(context.Context, FindConversationsLocalArg) (FindConversationsLocalRes, error)
-	FindGeneralConvFromTeamID(context.Context, keybase1.TeamID) (InboxUIItem, error)
-	UpdateTyping(context.Context, UpdateTypingArg) error
-	UpdateUnsentText(context.Context, UpdateUnsentTextArg) error
-	JoinConversationLocal(context.Context, JoinConversationLocalArg) (JoinLeaveConversationLocalRes, error)
-	JoinConversationByIDLocal(context.Context, ConversationID) (JoinLeaveConversationLocalRes, error)
-	LeaveConversationLocal(context.Context, ConversationID) (JoinLeaveConversationLocalRes, error)
-	PreviewConversationByIDLocal(context.Context, ConversationID) (PreviewConversationLocalRes, error)
-	DeleteConversationLocal(context.Context, DeleteConversationLocalArg) (DeleteConversationLocalRes, error)
-	RemoveFromConversationLocal(context.Context, RemoveFromConversationLocalArg) (RemoveFromConversationLocalRes, error)
-	GetTLFConversationsLocal(context.Context, GetTLFConversationsLocalArg) (GetTLFConversationsLocalRes, error)
-	GetChannelMembershipsLocal(context.Context, GetChannelMembershipsLocalArg) (GetChannelMembershipsLocalRes, error)
-	GetMutualTeamsLocal(context.Context, []string) (GetMutualTeamsLocalRes, error)
-	SetAppNotificationSettingsLocal(context.Context, SetAppNotificationSettingsLocalArg) (SetAppNotificationSettingsLocalRes, error)
-	SetGlobalAppNotificationSettingsLocal(context.Context, map[string]bool) error
-	GetGlobalAppNotificationSettingsLocal(context.Context) (GlobalAppNotificationSettings, error)
-	UnboxMobilePushNotification(context.Context, UnboxMobilePushNotificationArg) (string, error)
-	AddTeamMemberAfterReset(context.Context, AddTeamMemberAfterResetArg) error
-	GetAllResetConvMembers(context.Context) (GetAllResetConvMembersRes, error)
-	SetConvRetentionLocal(context.Context, SetConvRetentionLocalArg) error
-	SetTeamRetentionLocal(context.Context, SetTeamRetentionLocalArg) error
-	GetTeamRetentionLocal(context.Context, keybase1.TeamID) (*RetentionPolicy, error)
-	SetConvMinWriterRoleLocal(context.Context, SetConvMinWriterRoleLocalArg) error
-	UpgradeKBFSConversationToImpteam(context.Context, ConversationID) error
-	SearchRegexp(context.Context, SearchRegexpArg) (SearchRegexpRes, error)
-	CancelActiveInboxSearch(context.Context) error
-	SearchInbox(context.Context, SearchInboxArg) (SearchInboxRes, error)
-	SimpleSearchInboxConvNames(context.Context, string) ([]SimpleSearchInboxConvNamesHit, error)
-	CancelActiveSearch(context.Context) error
-	ProfileChatSearch(context.Context, keybase1.TLFIdentifyBehavior) (map[ConvIDStr]ProfileSearchConvStats, error)
-	GetStaticConfig(context.Context) (StaticConfig, error)
-	ResolveUnfurlPrompt(context.Context, ResolveUnfurlPromptArg) error
-	GetUnfurlSettings(context.Context) (UnfurlSettingsDisplay, error)
-	SaveUnfurlSettings(context.Context, SaveUnfurlSettingsArg) error
-	ToggleMessageCollapse(context.Context, ToggleMessageCollapseArg) error
-	BulkAddToConv(context.Context, BulkAddToConvArg) error
-	BulkAddToManyConvs(context.Context, BulkAddToManyConvsArg) error
-	PutReacjiSkinTone(context.Context, keybase1.ReacjiSkinTone) (keybase1.UserReacjis, error)
-	ResolveMaybeMention(context.Context, MaybeMention) error
-	LoadGallery(context.Context, LoadGalleryArg) (LoadGalleryRes, error)
-	LoadFlip(context.Context, LoadFlipArg) (LoadFlipRes, error)
-	LocationUpdate(context.Context, Coordinate) error
-	AdvertiseBotCommandsLocal(context.Context, AdvertiseBotCommandsLocalArg) (AdvertiseBotCommandsLocalRes, error)
-	ListBotCommandsLocal(context.Context, ConversationID) (ListBotCommandsLocalRes, error)
-	ListPublicBotCommandsLocal(context.Context, string) (ListBotCommandsLocalRes, error)
-	ClearBotCommandsLocal(context.Context, *ClearBotCommandsFilter) (ClearBotCommandsLocalRes, error)
-	PinMessage(context.Context, PinMessageArg) (PinMessageRes, error)
-	UnpinMessage(context.Context, ConversationID) (PinMessageRes, error)
-	IgnorePinnedMessage(context.Context, ConversationID) error
-	AddBotMember(context.Context, AddBotMemberArg) error
-	EditBotMember(context.Context, EditBotMemberArg) error
-	RemoveBotMember(context.Context, RemoveBotMemberArg) error
-	SetBotMemberSettings(context.Context, SetBotMemberSettingsArg) error
-	GetBotMemberSettings(context.Context, GetBotMemberSettingsArg) (keybase1.TeamBotSettings, error)
-	GetTeamRoleInConversation(context.Context, GetTeamRoleInConversationArg) (keybase1.TeamRole, error)
-	AddBotConvSearch(context.Context, string) ([]ConvSearchHit, error)
-	ForwardMessageConvSearch(context.Context, string) ([]ConvSearchHit, error)
-	TeamIDFromTLFName(context.Context, TeamIDFromTLFNameArg) (keybase1.TeamID, error)
-	DismissJourneycard(context.Context, DismissJourneycardArg) error
-	SetWelcomeMessage(context.Context, SetWelcomeMessageArg) error
-	GetWelcomeMessage(context.Context, keybase1.TeamID) (WelcomeMessageDisplay, error)
-	GetDefaultTeamChannelsLocal(context.Context, keybase1.TeamID) (GetDefaultTeamChannelsLocalRes, error)
-	SetDefaultTeamChannelsLocal(context.Context, SetDefaultTeamChannelsLocalArg) (SetDefaultTeamChannelsLocalRes, error)
-	GetLastActiveForTLF(context.Context, TLFIDStr) (LastActiveStatus, error)
-	GetLastActiveForTeams(context.Context) (LastActiveStatusAll, error)
-	GetRecentJoinsLocal(context.Context, ConversationID) (int, error)
-	RefreshParticipants(context.Context, ConversationID) error
-	GetLastActiveAtLocal(context.Context, GetLastActiveAtLocalArg) (gregor1.Time, error)
-	GetLastActiveAtMultiLocal(context.Context, GetLastActiveAtMultiLocalArg) (map[keybase1.TeamID]gregor1.Time, error)
-	GetParticipants(context.Context, ConversationID) ([]ConversationLocalParticipant, error)
-	AddEmoji(context.Context, AddEmojiArg) (AddEmojiRes, error)
-	AddEmojis(context.Context, AddEmojisArg) (AddEmojisRes, error)
-	AddEmojiAlias(context.Context, AddEmojiAliasArg) (AddEmojiAliasRes, error)
-	RemoveEmoji(context.Context, RemoveEmojiArg) (RemoveEmojiRes, error)
-	UserEmojis(context.Context, UserEmojisArg) (UserEmojiRes, error)
-	ToggleEmojiAnimations(context.Context, bool) error
-	TrackGiphySelect(context.Context, TrackGiphySelectArg) (TrackGiphySelectRes, error)
-	ArchiveChat(context.Context, ArchiveChatJobRequest) (ArchiveChatRes, error)
-	ArchiveChatList(context.Context, keybase1.TLFIdentifyBehavior) (ArchiveChatListRes, error)
-	ArchiveChatDelete(context.Context, ArchiveChatDeleteArg) error
-	ArchiveChatPause(context.Context, ArchiveChatPauseArg) error
-	ArchiveChatResume(context.Context, ArchiveChatResumeArg) error
+type GetInboxAndUnboxLocalArg struct {
+	Query            *GetInboxLocalQuery          `codec:"query,omitempty" json:"query,omitempty"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
 }
 
-func LocalProtocol(i LocalInterface) rpc.Protocol {
-	return rpc.Protocol{
-		Name: "chat.1.local",
-		Methods: map[string]rpc.ServeHandlerDescription{
-			"getThreadLocal": {
+type GetInboxAndUnboxUILocalArg struct {
+	Query            *GetInboxLocalQuery          `codec:"query,omitempty" json:"query,omitempty"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type RequestInboxLayoutArg struct {
+	ReselectMode InboxLayoutReselectMode `codec:"reselectMode" json:"reselectMode"`
+}
+
+type RequestInboxUnboxArg struct {
+	ConvIDs []ConversationID `codec:"convIDs" json:"convIDs"`
+}
+
+type RequestInboxSmallIncreaseArg struct {
+}
+
+type RequestInboxSmallResetArg struct {
+}
+
+type GetInboxNonblockLocalArg struct {
+	SessionID        int                          `codec:"sessionID" json:"sessionID"`
+	MaxUnbox         *int                         `codec:"maxUnbox,omitempty" json:"maxUnbox,omitempty"`
+	SkipUnverified   bool                         `codec:"skipUnverified" json:"skipUnverified"`
+	Query            *GetInboxLocalQuery          `codec:"query,omitempty" json:"query,omitempty"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type PostLocalArg struct {
+	SessionID          int                          `codec:"sessionID" json:"sessionID"`
+	ConversationID     ConversationID               `codec:"conversationID" json:"conversationID"`
+	Msg                MessagePlaintext             `codec:"msg" json:"msg"`
+	ReplyTo            *MessageID                   `codec:"replyTo,omitempty" json:"replyTo,omitempty"`
+	IdentifyBehavior   keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+	SkipInChatPayments bool                         `codec:"skipInChatPayments" json:"skipInChatPayments"`
+}
+
+type GenerateOutboxIDArg struct {
+}
+
+type PostLocalNonblockArg struct {
+	SessionID          int                          `codec:"sessionID" json:"sessionID"`
+	ConversationID     ConversationID               `codec:"conversationID" json:"conversationID"`
+	Msg                MessagePlaintext             `codec:"msg" json:"msg"`
+	ClientPrev         MessageID                    `codec:"clientPrev" json:"clientPrev"`
+	OutboxID           *OutboxID                    `codec:"outboxID,omitempty" json:"outboxID,omitempty"`
+	ReplyTo            *MessageID                   `codec:"replyTo,omitempty" json:"replyTo,omitempty"`
+	IdentifyBehavior   keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+	SkipInChatPayments bool                         `codec:"skipInChatPayments" json:"skipInChatPayments"`
+}
+
+type ForwardMessageArg struct {
+	SessionID        int                          `codec:"sessionID" json:"sessionID"`
+	SrcConvID        ConversationID               `codec:"srcConvID" json:"srcConvID"`
+	DstConvID        ConversationID               `codec:"dstConvID" json:"dstConvID"`
+	MsgID            MessageID                    `codec:"msgID" json:"msgID"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+	Title            string                       `codec:"title" json:"title"`
+}
+
+type ForwardMessageNonblockArg struct {
+	SessionID        int                          `codec:"sessionID" json:"sessionID"`
+	SrcConvID        ConversationID               `codec:"srcConvID" json:"srcConvID"`
+	DstConvID        ConversationID               `codec:"dstConvID" json:"dstConvID"`
+	MsgID            MessageID                    `codec:"msgID" json:"msgID"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+	Title            string                       `codec:"title" json:"title"`
+}
+
+type PostTextNonblockArg struct {
+	SessionID         int                          `codec:"sessionID" json:"sessionID"`
+	ConversationID    ConversationID               `codec:"conversationID" json:"conversationID"`
+	TlfName           string                       `codec:"tlfName" json:"tlfName"`
+	TlfPublic         bool                         `codec:"tlfPublic" json:"tlfPublic"`
+	Body              string                       `codec:"body" json:"body"`
+	ClientPrev        MessageID                    `codec:"clientPrev" json:"clientPrev"`
+	ReplyTo           *MessageID                   `codec:"replyTo,omitempty" json:"replyTo,omitempty"`
+	OutboxID          *OutboxID                    `codec:"outboxID,omitempty" json:"outboxID,omitempty"`
+	IdentifyBehavior  keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+	EphemeralLifetime *gregor1.DurationSec         `codec:"ephemeralLifetime,omitempty" json:"ephemeralLifetime,omitempty"`
+}
+
+type PostDeleteNonblockArg struct {
+	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
+	TlfName          string                       `codec:"tlfName" json:"tlfName"`
+	TlfPublic        bool                         `codec:"tlfPublic" json:"tlfPublic"`
+	Supersedes       MessageID                    `codec:"supersedes" json:"supersedes"`
+	ClientPrev       MessageID                    `codec:"clientPrev" json:"clientPrev"`
+	OutboxID         *OutboxID                    `codec:"outboxID,omitempty" json:"outboxID,omitempty"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type PostEditNonblockArg struct {
+	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
+	TlfName          string                       `codec:"tlfName" json:"tlfName"`
+	TlfPublic        bool                         `codec:"tlfPublic" json:"tlfPublic"`
+	Target           EditTarget                   `codec:"target" json:"target"`
+	Body             string                       `codec:"body" json:"body"`
+	OutboxID         *OutboxID                    `codec:"outboxID,omitempty" json:"outboxID,omitempty"`
+	ClientPrev       MessageID                    `codec:"clientPrev" json:"clientPrev"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type PostReactionNonblockArg struct {
+	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
+	TlfName          string                       `codec:"tlfName" json:"tlfName"`
+	TlfPublic        bool                         `codec:"tlfPublic" json:"tlfPublic"`
+	Supersedes       MessageID                    `codec:"supersedes" json:"supersedes"`
+	Body             string                       `codec:"body" json:"body"`
+	OutboxID         *OutboxID                    `codec:"outboxID,omitempty" json:"outboxID,omitempty"`
+	ClientPrev       MessageID                    `codec:"clientPrev" json:"clientPrev"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type PostHeadlineNonblockArg struct {
+	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
+	TlfName          string                       `codec:"tlfName" json:"tlfName"`
+	TlfPublic        bool                         `codec:"tlfPublic" json:"tlfPublic"`
+	Headline         string                       `codec:"headline" json:"headline"`
+	OutboxID         *OutboxID                    `codec:"outboxID,omitempty" json:"outboxID,omitempty"`
+	ClientPrev       MessageID                    `codec:"clientPrev" json:"clientPrev"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type PostHeadlineArg struct {
+	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
+	TlfName          string                       `codec:"tlfName" json:"tlfName"`
+	TlfPublic        bool                         `codec:"tlfPublic" json:"tlfPublic"`
+	Headline         string                       `codec:"headline" json:"headline"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type PostMetadataNonblockArg struct {
+	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
+	TlfName          string                       `codec:"tlfName" json:"tlfName"`
+	TlfPublic        bool                         `codec:"tlfPublic" json:"tlfPublic"`
+	ChannelName      string                       `codec:"channelName" json:"channelName"`
+	OutboxID         *OutboxID                    `codec:"outboxID,omitempty" json:"outboxID,omitempty"`
+	ClientPrev       MessageID                    `codec:"clientPrev" json:"clientPrev"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type PostMetadataArg struct {
+	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
+	TlfName          string                       `codec:"tlfName" json:"tlfName"`
+	TlfPublic        bool                         `codec:"tlfPublic" json:"tlfPublic"`
+	ChannelName      string                       `codec:"channelName" json:"channelName"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type PostDeleteHistoryUptoArg struct {
+	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
+	TlfName          string                       `codec:"tlfName" json:"tlfName"`
+	TlfPublic        bool                         `codec:"tlfPublic" json:"tlfPublic"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+	Upto             MessageID                    `codec:"upto" json:"upto"`
+}
+
+type PostDeleteHistoryThroughArg struct {
+	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
+	TlfName          string                       `codec:"tlfName" json:"tlfName"`
+	TlfPublic        bool                         `codec:"tlfPublic" json:"tlfPublic"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+	Through          MessageID                    `codec:"through" json:"through"`
+}
+
+type PostDeleteHistoryByAgeArg struct {
+	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
+	TlfName          string                       `codec:"tlfName" json:"tlfName"`
+	TlfPublic        bool                         `codec:"tlfPublic" json:"tlfPublic"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+	Age              gregor1.DurationSec          `codec:"age" json:"age"`
+}
+
+type SetConversationStatusLocalArg struct {
+	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
+	Status           ConversationStatus           `codec:"status" json:"status"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type NewConversationsLocalArg struct {
+	NewConversationLocalArguments []NewConversationLocalArgument `codec:"newConversationLocalArguments" json:"newConversationLocalArguments"`
+	IdentifyBehavior              keybase1.TLFIdentifyBehavior   `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type NewConversationLocalArg struct {
+	TlfName          string                       `codec:"tlfName" json:"tlfName"`
+	TopicType        TopicType                    `codec:"topicType" json:"topicType"`
+	TlfVisibility    keybase1.TLFVisibility       `codec:"tlfVisibility" json:"tlfVisibility"`
+	TopicName        *string                      `codec:"topicName,omitempty" json:"topicName,omitempty"`
+	MembersType      ConversationMembersType      `codec:"membersType" json:"membersType"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type GetInboxSummaryForCLILocalArg struct {
+	Query GetInboxSummaryForCLILocalQuery `codec:"query" json:"query"`
+}
+
+type QueryInboxForCLILocalArg struct {
+	Query InboxQueryForCLI `codec:"query" json:"query"`
+}
+
+type GetConversationForCLILocalArg struct {
+	Query GetConversationForCLILocalQuery `codec:"query" json:"query"`
+}
+
+type GetMessagesLocalArg struct {
+	ConversationID           ConversationID               `codec:"conversationID" json:"conversationID"`
+	MessageIDs               []MessageID                  `codec:"messageIDs" json:"messageIDs"`
+	DisableResolveSupersedes bool                         `codec:"disableResolveSupersedes" json:"disableResolveSupersedes"`
+	IdentifyBehavior         keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type PostFileAttachmentLocalArg struct {
+	SessionID int                   `codec:"sessionID" json:"sessionID"`
+	Arg       PostFileAttachmentArg `codec:"arg" json:"arg"`
+}
+
+type PostFileAttachmentLocalNonblockArg struct {
+	SessionID  int                   `codec:"sessionID" json:"sessionID"`
+	Arg        PostFileAttachmentArg `codec:"arg" json:"arg"`
+	ClientPrev MessageID             `codec:"clientPrev" json:"clientPrev"`
+}
+
+type GetNextAttachmentMessageLocalArg struct {
+	ConvID           ConversationID               `codec:"convID" json:"convID"`
+	MessageID        MessageID                    `codec:"messageID" json:"messageID"`
+	BackInTime       bool                         `codec:"backInTime" json:"backInTime"`
+	AssetTypes       []AssetMetadataType          `codec:"assetTypes" json:"assetTypes"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type DownloadAttachmentLocalArg struct {
+	SessionID        int                          `codec:"sessionID" json:"sessionID"`
+	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
+	MessageID        MessageID                    `codec:"messageID" json:"messageID"`
+	Sink             keybase1.Stream              `codec:"sink" json:"sink"`
+	Preview          bool                         `codec:"preview" json:"preview"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+	Offset           int64                        `codec:"offset" json:"offset"`
+	Length           int64                        `codec:"length" json:"length"`
+}
+
+type DownloadFileAttachmentLocalArg struct {
+	SessionID        int                          `codec:"sessionID" json:"sessionID"`
+	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
+	MessageID        MessageID                    `codec:"messageID" json:"messageID"`
+	DownloadToCache  bool                         `codec:"downloadToCache" json:"downloadToCache"`
+	Preview          bool                         `codec:"preview" json:"preview"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type ConfigureFileAttachmentDownloadLocalArg struct {
+	CacheDirOverride    string `codec:"cacheDirOverride" json:"cacheDirOverride"`
+	DownloadDirOverride string `codec:"downloadDirOverride" json:"downloadDirOverride"`
+}
+
+type MakePreviewArg struct {
+	SessionID int      `codec:"sessionID" json:"sessionID"`
+	Filename  string   `codec:"filename" json:"filename"`
+	OutboxID  OutboxID `codec:"outboxID" json:"outboxID"`
+}
+
+type MakeAudioPreviewArg struct {
+	Amps     []float64 `codec:"amps" json:"amps"`
+	Duration int       `codec:"duration" json:"duration"`
+}
+
+type GetUploadTempFileArg struct {
+	OutboxID OutboxID `codec:"outboxID" json:"outboxID"`
+	Filename string   `codec:"filename" json:"filename"`
+}
+
+type MakeUploadTempFileArg struct {
+	OutboxID OutboxID `codec:"outboxID" json:"outboxID"`
+	Filename string   `codec:"filename" json:"filename"`
+	Data     []byte   `codec:"data" json:"data"`
+}
+
+type CancelUploadTempFileArg struct {
+	OutboxID OutboxID `codec:"outboxID" json:"outboxID"`
+}
+
+type CancelPostArg struct {
+	OutboxID OutboxID `codec:"outboxID" json:"outboxID"`
+}
+
+type RetryPostArg struct {
+	OutboxID         OutboxID                      `codec:"outboxID" json:"outboxID"`
+	IdentifyBehavior *keybase1.TLFIdentifyBehavior `codec:"identifyBehavior,omitempty" json:"identifyBehavior,omitempty"`
+}
+
+type MarkAsReadLocalArg struct {
+	SessionID      int            `codec:"sessionID" json:"sessionID"`
+	ConversationID ConversationID `codec:"conversationID" json:"conversationID"`
+	MsgID          *MessageID     `codec:"msgID,omitempty" json:"msgID,omitempty"`
+	ForceUnread    bool           `codec:"forceUnread" json:"forceUnread"`
+}
+
+type MarkTLFAsReadLocalArg struct {
+	SessionID int   `codec:"sessionID" json:"sessionID"`
+	TlfID     TLFID `codec:"tlfID" json:"tlfID"`
+}
+
+type FindConversationsLocalArg struct {
+	TlfName          string                       `codec:"tlfName" json:"tlfName"`
+	MembersType      ConversationMembersType      `codec:"membersType" json:"membersType"`
+	Visibility       keybase1.TLFVisibility       `codec:"visibility" json:"visibility"`
+	TopicType        TopicType                    `codec:"topicType" json:"topicType"`
+	TopicName        string                       `codec:"topicName" json:"topicName"`
+	OneChatPerTLF    *bool                        `codec:"oneChatPerTLF,omitempty" json:"oneChatPerTLF,omitempty"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type FindGeneralConvFromTeamIDArg struct {
+	TeamID keybase1.TeamID `codec:"teamID" json:"teamID"`
+}
+
+type UpdateTypingArg struct {
+	ConversationID ConversationID `codec:"conversationID" json:"conversationID"`
+	Typing         bool           `codec:"typing" json:"typing"`
+}
+
+type UpdateUnsentTextArg struct {
+	ConversationID ConversationID `codec:"conversationID" json:"conversationID"`
+	TlfName        string         `codec:"tlfName" json:"tlfName"`
+	Text           string         `codec:"text" json:"text"`
+}
+
+type JoinConversationLocalArg struct {
+	TlfName    string                 `codec:"tlfName" json:"tlfName"`
+	TopicType  TopicType              `codec:"topicType" json:"topicType"`
+	Visibility keybase1.TLFVisibility `codec:"visibility" json:"visibility"`
+	TopicName  string                 `codec:"topicName" json:"topicName"`
+}
+
+type JoinConversationByIDLocalArg struct {
+	ConvID ConversationID `codec:"convID" json:"convID"`
+}
+
+type LeaveConversationLocalArg struct {
+	ConvID ConversationID `codec:"convID" json:"convID"`
+}
+
+type PreviewConversationByIDLocalArg struct {
+	ConvID ConversationID `codec:"convID" json:"convID"`
+}
+
+type DeleteConversationLocalArg struct {
+	SessionID   int            `codec:"sessionID" json:"sessionID"`
+	ConvID      ConversationID `codec:"convID" json:"convID"`
+	ChannelName string         `codec:"channelName" json:"channelName"`
+	Confirmed   bool           `codec:"confirmed" json:"confirmed"`
+}
+
+type RemoveFromConversationLocalArg struct {
+	ConvID    ConversationID `codec:"convID" json:"convID"`
+	Usernames []string       `codec:"usernames" json:"usernames"`
+}
+
+type GetTLFConversationsLocalArg struct {
+	TlfName     string                  `codec:"tlfName" json:"tlfName"`
+	TopicType   TopicType               `codec:"topicType" json:"topicType"`
+	MembersType ConversationMembersType `codec:"membersType" json:"membersType"`
+}
+
+type GetChannelMembershipsLocalArg struct {
+	TeamID keybase1.TeamID `codec:"teamID" json:"teamID"`
+	Uid    gregor1.UID     `codec:"uid" json:"uid"`
+}
+
+type GetMutualTeamsLocalArg struct {
+	Usernames []string `codec:"usernames" json:"usernames"`
+}
+
+type SetAppNotificationSettingsLocalArg struct {
+	ConvID      ConversationID                `codec:"convID" json:"convID"`
+	ChannelWide bool                          `codec:"channelWide" json:"channelWide"`
+	Settings    []AppNotificationSettingLocal `codec:"settings" json:"settings"`
+}
+
+type SetGlobalAppNotificationSettingsLocalArg struct {
+	Settings map[string]bool `codec:"settings" json:"settings"`
+}
+
+type GetGlobalAppNotificationSettingsLocalArg struct {
+}
+
+type UnboxMobilePushNotificationArg struct {
+	Payload     string                  `codec:"payload" json:"payload"`
+	ConvID      string                  `codec:"convID" json:"convID"`
+	MembersType ConversationMembersType `codec:"membersType" json:"membersType"`
+	PushIDs     []string                `codec:"pushIDs" json:"pushIDs"`
+	ShouldAck   bool                    `codec:"shouldAck" json:"shouldAck"`
+}
+
+type AddTeamMemberAfterResetArg struct {
+	Username string         `codec:"username" json:"username"`
+	ConvID   ConversationID `codec:"convID" json:"convID"`
+}
+
+type GetAllResetConvMembersArg struct {
+}
+
+type SetConvRetentionLocalArg struct {
+	ConvID ConversationID  `codec:"convID" json:"convID"`
+	Policy RetentionPolicy `codec:"policy" json:"policy"`
+}
+
+type SetTeamRetentionLocalArg struct {
+	TeamID keybase1.TeamID `codec:"teamID" json:"teamID"`
+	Policy RetentionPolicy `codec:"policy" json:"policy"`
+}
+
+type GetTeamRetentionLocalArg struct {
+	TeamID keybase1.TeamID `codec:"teamID" json:"teamID"`
+}
+
+type SetConvMinWriterRoleLocalArg struct {
+	ConvID ConversationID    `codec:"convID" json:"convID"`
+	Role   keybase1.TeamRole `codec:"role" json:"role"`
+}
+
+type UpgradeKBFSConversationToImpteamArg struct {
+	ConvID ConversationID `codec:"convID" json:"convID"`
+}
+
+type SearchRegexpArg struct {
+	SessionID        int                          `codec:"sessionID" json:"sessionID"`
+	ConvID           ConversationID               `codec:"convID" json:"convID"`
+	Query            string                       `codec:"query" json:"query"`
+	Opts             SearchOpts                   `codec:"opts" json:"opts"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type CancelActiveInboxSearchArg struct {
+}
+
+type SearchInboxArg struct {
+	SessionID        int                          `codec:"sessionID" json:"sessionID"`
+	Query            string                       `codec:"query" json:"query"`
+	Opts             SearchOpts                   `codec:"opts" json:"opts"`
+	NamesOnly        bool                         `codec:"namesOnly" json:"namesOnly"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type SimpleSearchInboxConvNamesArg struct {
+	Query string `codec:"query" json:"query"`
+}
+
+type CancelActiveSearchArg struct {
+}
+
+type ProfileChatSearchArg struct {
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type GetStaticConfigArg struct {
+}
+
+type ResolveUnfurlPromptArg struct {
+	ConvID           ConversationID               `codec:"convID" json:"convID"`
+	MsgID            MessageID                    `codec:"msgID" json:"msgID"`
+	Result           UnfurlPromptResult           `codec:"result" json:"result"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type GetUnfurlSettingsArg struct {
+}
+
+type SaveUnfurlSettingsArg struct {
+	Mode      UnfurlMode `codec:"mode" json:"mode"`
+	Whitelist []string   `codec:"whitelist" json:"whitelist"`
+}
+
+type ToggleMessageCollapseArg struct {
+	ConvID   ConversationID `codec:"convID" json:"convID"`
+	MsgID    MessageID      `codec:"msgID" json:"msgID"`
+	Collapse bool           `codec:"collapse" json:"collapse"`
+}
+
+type BulkAddToConvArg struct {
+	ConvID    ConversationID `codec:"convID" json:"convID"`
+	Usernames []string       `codec:"usernames" json:"usernames"`
+}
+
+type BulkAddToManyConvsArg struct {
+	Conversations []ConversationID `codec:"conversations" json:"conversations"`
+	Usernames     []string         `codec:"usernames" json:"usernames"`
+}
+
+type PutReacjiSkinToneArg struct {
+	SkinTone keybase1.ReacjiSkinTone `codec:"skinTone" json:"skinTone"`
+}
+
+type ResolveMaybeMentionArg struct {
+	Mention MaybeMention `codec:"mention" json:"mention"`
+}
+
+type LoadGalleryArg struct {
+	SessionID int            `codec:"sessionID" json:"sessionID"`
+	ConvID    ConversationID `codec:"convID" json:"convID"`
+	Typ       GalleryItemTyp `codec:"typ" json:"typ"`
+	Num       int            `codec:"num" json:"num"`
+	FromMsgID *MessageID     `codec:"fromMsgID,omitempty" json:"fromMsgID,omitempty"`
+}
+
+type LoadFlipArg struct {
+	HostConvID ConversationID `codec:"hostConvID" json:"hostConvID"`
+	HostMsgID  MessageID      `codec:"hostMsgID" json:"hostMsgID"`
+	FlipConvID ConversationID `codec:"flipConvID" json:"flipConvID"`
+	GameID     FlipGameID     `codec:"gameID" json:"gameID"`
+}
+
+type LocationUpdateArg struct {
+	Coord Coordinate `codec:"coord" json:"coord"`
+}
+
+type AdvertiseBotCommandsLocalArg struct {
+	Alias          *string                  `codec:"alias,omitempty" json:"alias,omitempty"`
+	Advertisements []AdvertiseCommandsParam `codec:"advertisements" json:"advertisements"`
+}
+
+type ListBotCommandsLocalArg struct {
+	ConvID ConversationID `codec:"convID" json:"convID"`
+}
+
+type ListPublicBotCommandsLocalArg struct {
+	Username string `codec:"username" json:"username"`
+}
+
+type ClearBotCommandsLocalArg struct {
+	Filter *ClearBotCommandsFilter `codec:"filter,omitempty" json:"filter,omitempty"`
+}
+
+type PinMessageArg struct {
+	ConvID ConversationID `codec:"convID" json:"convID"`
+	MsgID  MessageID      `codec:"msgID" json:"msgID"`
+}
+
+type UnpinMessageArg struct {
+	ConvID ConversationID `codec:"convID" json:"convID"`
+}
+
+type IgnorePinnedMessageArg struct {
+	ConvID ConversationID `codec:"convID" json:"convID"`
+}
+
+type AddBotMemberArg struct {
+	ConvID      ConversationID            `codec:"convID" json:"convID"`
+	Username    string                    `codec:"username" json:"username"`
+	BotSettings *keybase1.TeamBotSettings `codec:"botSettings,omitempty" json:"botSettings,omitempty"`
+	Role        keybase1.TeamRole         `codec:"role" json:"role"`
+}
+
+type EditBotMemberArg struct {
+	ConvID      ConversationID            `codec:"convID" json:"convID"`
+	Username    string                    `codec:"username" json:"username"`
+	BotSettings *keybase1.TeamBotSettings `codec:"botSettings,omitempty" json:"botSettings,omitempty"`
+	Role        keybase1.TeamRole         `codec:"role" json:"role"`
+}
+
+type RemoveBotMemberArg struct {
+	ConvID   ConversationID `codec:"convID" json:"convID"`
+	Username string         `codec:"username" json:"username"`
+}
+
+type SetBotMemberSettingsArg struct {
+	ConvID      ConversationID           `codec:"convID" json:"convID"`
+	Username    string                   `codec:"username" json:"username"`
+	BotSettings keybase1.TeamBotSettings `codec:"botSettings" json:"botSettings"`
+}
+
+type GetBotMemberSettingsArg struct {
+	ConvID   ConversationID `codec:"convID" json:"convID"`
+	Username string         `codec:"username" json:"username"`
+}
+
+type GetTeamRoleInConversationArg struct {
+	ConvID   ConversationID `codec:"convID" json:"convID"`
+	Username string         `codec:"username" json:"username"`
+}
+
+type AddBotConvSearchArg struct {
+	Term string `codec:"term" json:"term"`
+}
+
+type ForwardMessageConvSearchArg struct {
+	Term string `codec:"term" json:"term"`
+}
+
+type TeamIDFromTLFNameArg struct {
+	TlfName     string                  `codec:"tlfName" json:"tlfName"`
+	MembersType ConversationMembersType `codec:"membersType" json:"membersType"`
+	TlfPublic   bool                    `codec:"tlfPublic" json:"tlfPublic"`
+}
+
+type DismissJourneycardArg struct {
+	ConvID   ConversationID  `codec:"convID" json:"convID"`
+	CardType JourneycardType `codec:"cardType" json:"cardType"`
+}
+
+type SetWelcomeMessageArg struct {
+	TeamID  keybase1.TeamID `codec:"teamID" json:"teamID"`
+	Message WelcomeMessage  `codec:"message" json:"message"`
+}
+
+type GetWelcomeMessageArg struct {
+	TeamID keybase1.TeamID `codec:"teamID" json:"teamID"`
+}
+
+type GetDefaultTeamChannelsLocalArg struct {
+	TeamID keybase1.TeamID `codec:"teamID" json:"teamID"`
+}
+
+type SetDefaultTeamChannelsLocalArg struct {
+	TeamID keybase1.TeamID `codec:"teamID" json:"teamID"`
+	Convs  []ConvIDStr     `codec:"convs" json:"convs"`
+}
+
+type GetLastActiveForTLFArg struct {
+	TlfID TLFIDStr `codec:"tlfID" json:"tlfID"`
+}
+
+type GetLastActiveForTeamsArg struct {
+}
+
+type GetRecentJoinsLocalArg struct {
+	ConvID ConversationID `codec:"convID" json:"convID"`
+}
+
+type RefreshParticipantsArg struct {
+	ConvID ConversationID `codec:"convID" json:"convID"`
+}
+
+type GetLastActiveAtLocalArg struct {
+	TeamID   keybase1.TeamID `codec:"teamID" json:"teamID"`
+	Username string          `codec:"username" json:"username"`
+}
+
+type GetLastActiveAtMultiLocalArg struct {
+	TeamIDs  []keybase1.TeamID `codec:"teamIDs" json:"teamIDs"`
+	Username string            `codec:"username" json:"username"`
+}
+
+type GetParticipantsArg struct {
+	ConvID ConversationID `codec:"convID" json:"convID"`
+}
+
+type AddEmojiArg struct {
+	ConvID         ConversationID `codec:"convID" json:"convID"`
+	Alias          string         `codec:"alias" json:"alias"`
+	Filename       string         `codec:"filename" json:"filename"`
+	AllowOverwrite bool           `codec:"allowOverwrite" json:"allowOverwrite"`
+}
+
+type AddEmojisArg struct {
+	ConvID         ConversationID `codec:"convID" json:"convID"`
+	Aliases        []string       `codec:"aliases" json:"aliases"`
+	Filenames      []string       `codec:"filenames" json:"filenames"`
+	AllowOverwrite []bool         `codec:"allowOverwrite" json:"allowOverwrite"`
+}
+
+type AddEmojiAliasArg struct {
+	ConvID        ConversationID `codec:"convID" json:"convID"`
+	NewAlias      string         `codec:"newAlias" json:"newAlias"`
+	ExistingAlias string         `codec:"existingAlias" json:"existingAlias"`
+}
+
+type RemoveEmojiArg struct {
+	ConvID ConversationID `codec:"convID" json:"convID"`
+	Alias  string         `codec:"alias" json:"alias"`
+}
+
+type UserEmojisArg struct {
+	Opts   EmojiFetchOpts  `codec:"opts" json:"opts"`
+	ConvID *ConversationID `codec:"convID,omitempty" json:"convID,omitempty"`
+}
+
+type ToggleEmojiAnimationsArg struct {
+	Enabled bool `codec:"enabled" json:"enabled"`
+}
+
+type TrackGiphySelectArg struct {
+	SessionID int               `codec:"sessionID" json:"sessionID"`
+	Result    GiphySearchResult `codec:"result" json:"result"`
+}
+
+type ArchiveChatArg struct {
+	Req ArchiveChatJobRequest `codec:"req" json:"req"`
+}
+
+type ArchiveChatListArg struct {
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type ArchiveChatDeleteArg struct {
+	JobID            ArchiveJobID                 `codec:"jobID" json:"jobID"`
+	DeleteOutputPath bool                         `codec:"deleteOutputPath" json:"deleteOutputPath"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type ArchiveChatPauseArg struct {
+	JobID            ArchiveJobID                 `codec:"jobID" json:"jobID"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type ArchiveChatResumeArg struct {
+	JobID            ArchiveJobID                 `codec:"jobID" json:"jobID"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type ArchiveChatSetScheduleArg struct {
+	JobID            ArchiveJobID                 `codec:"jobID" json:"jobID"`
+	Schedule         *ArchiveChatSchedule         `codec:"schedule,omitempty" json:"schedule,omitempty"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type ArchiveChatExportHistoryArg struct {
+	OutputPath       string                       `codec:"outputPath" json:"outputPath"`
+	Plaintext        bool                         `codec:"plaintext" json:"plaintext"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type ArchiveChatImportHistoryArg struct {
+	InputPath        string                       `codec:"inputPath" json:"inputPath"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type ArchiveChatSearchArg struct {
+	Query            string                       `codec:"query" json:"query"`
+	MaxHits          int                          `codec:"maxHits" json:"maxHits"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type ArchiveChatVerifyArg struct {
+	JobID            ArchiveJobID                 `codec:"jobID" json:"jobID"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type ArchiveChatImportArg struct {
+	InputPath        string                       `codec:"inputPath" json:"inputPath"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type ArchiveChatListImportedArg struct {
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type ArchiveChatGetImportedThreadArg struct {
+	Id               string                       `codec:"id" json:"id"`
+	BeforeMsgID      MessageID                    `codec:"beforeMsgID" json:"beforeMsgID"`
+	Num              int                          `codec:"num" json:"num"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type ArchiveChatDeleteImportedArg struct {
+	Id               string                       `codec:"id" json:"id"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type SearchChatHistoryArg struct {
+	Query            string                       `codec:"query" json:"query"`
+	MaxHits          int                          `codec:"maxHits" json:"maxHits"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type GetConversationContactsArg struct {
+	ConvID           ConversationID               `codec:"convID" json:"convID"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type SplitConversationLocalArg struct {
+	ConvID           ConversationID               `codec:"convID" json:"convID"`
+	AfterMsgID       MessageID                    `codec:"afterMsgID" json:"afterMsgID"`
+	TopicName        string                       `codec:"topicName" json:"topicName"`
+	DryRun           bool                         `codec:"dryRun" json:"dryRun"`
+	DeleteOriginals  bool                         `codec:"deleteOriginals" json:"deleteOriginals"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type GetArchiveRedactionRulesArg struct {
+	TeamID keybase1.TeamID `codec:"teamID" json:"teamID"`
+}
+
+type SetArchiveRedactionRulesArg struct {
+	TeamID keybase1.TeamID       `codec:"teamID" json:"teamID"`
+	Rules  ArchiveRedactionRules `codec:"rules" json:"rules"`
+}
+
+type GetNewMemberRestrictionsArg struct {
+	TeamID keybase1.TeamID `codec:"teamID" json:"teamID"`
+}
+
+type SetNewMemberRestrictionsArg struct {
+	TeamID       keybase1.TeamID       `codec:"teamID" json:"teamID"`
+	Restrictions NewMemberRestrictions `codec:"restrictions" json:"restrictions"`
+}
+
+type SetNewMemberRestrictionsExemptArg struct {
+	TeamID   keybase1.TeamID `codec:"teamID" json:"teamID"`
+	Username string          `codec:"username" json:"username"`
+	Exempt   bool            `codec:"exempt" json:"exempt"`
+}
+
+type GetTrustedDevicePolicyArg struct {
+	ConvID ConversationID `codec:"convID" json:"convID"`
+}
+
+type SetTrustedDevicePolicyArg struct {
+	ConvID           ConversationID `codec:"convID" json:"convID"`
+	MinDeviceAgeDays int            `codec:"minDeviceAgeDays" json:"minDeviceAgeDays"`
+}
+
+type SetTrustedDeviceApprovedArg struct {
+	ConvID     ConversationID `codec:"convID" json:"convID"`
+	Username   string         `codec:"username" json:"username"`
+	DeviceName string         `codec:"deviceName" json:"deviceName"`
+	Approved   bool           `codec:"approved" json:"approved"`
+}
+
+type BulkDeleteMessagesLocalArg struct {
+	ConvID           ConversationID               `codec:"convID" json:"convID"`
+	Senders          []string                     `codec:"senders" json:"senders"`
+	After            *gregor1.Time                `codec:"after,omitempty" json:"after,omitempty"`
+	Before           *gregor1.Time                `codec:"before,omitempty" json:"before,omitempty"`
+	MessageTypes     []MessageType                `codec:"messageTypes" json:"messageTypes"`
+	DryRun           bool                         `codec:"dryRun" json:"dryRun"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type GetModerationAuditLogArg struct {
+	TeamID keybase1.TeamID `codec:"teamID" json:"teamID"`
+}
+
+type GetNotificationRoutingPreferencesArg struct {
+}
+
+type SetNotificationRoutingPreferencesArg struct {
+	Prefs NotificationRoutingPreferences `codec:"prefs" json:"prefs"`
+}
+
+type GetMentionDigestSettingsArg struct {
+}
+
+type SetMentionDigestSettingsArg struct {
+	Settings MentionDigestSettings `codec:"settings" json:"settings"`
+}
+
+type GetMentionDigestArg struct {
+}
+
+type GetIncidentModeArg struct {
+	TeamID keybase1.TeamID `codec:"teamID" json:"teamID"`
+}
+
+type SetIncidentModeArg struct {
+	ConvID   ConversationID      `codec:"convID" json:"convID"`
+	Duration gregor1.DurationSec `codec:"duration" json:"duration"`
+}
+
+type GetIncidentModeAuditLogArg struct {
+	TeamID keybase1.TeamID `codec:"teamID" json:"teamID"`
+}
+
+type GetChannelBannerArg struct {
+	ConvID ConversationID `codec:"convID" json:"convID"`
+}
+
+type SetChannelBannerArg struct {
+	ConvID   ConversationID      `codec:"convID" json:"convID"`
+	Text     string              `codec:"text" json:"text"`
+	Duration gregor1.DurationSec `codec:"duration" json:"duration"`
+}
+
+type GetChannelBannerAuditLogArg struct {
+	TeamID keybase1.TeamID `codec:"teamID" json:"teamID"`
+}
+
+type GetMembershipHookArg struct {
+	TeamID keybase1.TeamID `codec:"teamID" json:"teamID"`
+}
+
+type SetMembershipHookArg struct {
+	TeamID keybase1.TeamID `codec:"teamID" json:"teamID"`
+	Hook   MembershipHook  `codec:"hook" json:"hook"`
+}
+
+type PostPollArg struct {
+	ConvID           ConversationID               `codec:"convID" json:"convID"`
+	Question         string                       `codec:"question" json:"question"`
+	Options          []string                     `codec:"options" json:"options"`
+	MultiSelect      bool                         `codec:"multiSelect" json:"multiSelect"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type VotePollArg struct {
+	ConvID           ConversationID               `codec:"convID" json:"convID"`
+	MsgID            MessageID                    `codec:"msgID" json:"msgID"`
+	Options          []int                        `codec:"options" json:"options"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type GetPollResultsArg struct {
+	ConvID           ConversationID               `codec:"convID" json:"convID"`
+	MsgID            MessageID                    `codec:"msgID" json:"msgID"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type GetAttachmentExpiryPolicyArg struct {
+	TeamID keybase1.TeamID `codec:"teamID" json:"teamID"`
+}
+
+type SetAttachmentExpiryPolicyArg struct {
+	TeamID keybase1.TeamID        `codec:"teamID" json:"teamID"`
+	Policy AttachmentExpiryPolicy `codec:"policy" json:"policy"`
+}
+
+type ExpireTeamAttachmentsArg struct {
+	TeamID keybase1.TeamID `codec:"teamID" json:"teamID"`
+	DryRun bool            `codec:"dryRun" json:"dryRun"`
+}
+
+type EnableWidgetFeedArg struct {
+	Key       []byte `codec:"key" json:"key"`
+	MaxRecent int    `codec:"maxRecent" json:"maxRecent"`
+}
+
+type DisableWidgetFeedArg struct {
+}
+
+type PinWidgetFeedMessageArg struct {
+	ConvID ConversationID `codec:"convID" json:"convID"`
+	MsgID  MessageID      `codec:"msgID" json:"msgID"`
+	Pinned bool           `codec:"pinned" json:"pinned"`
+}
+
+type GetWidgetFeedArg struct {
+}
+
+type AcknowledgeIdentityChangesArg struct {
+	ConvID ConversationID `codec:"convID" json:"convID"`
+}
+
+type GetAttachmentAutoDownloadPolicyArg struct {
+}
+
+type SetAttachmentAutoDownloadPolicyArg struct {
+	Policy AttachmentAutoDownloadPolicy `codec:"policy" json:"policy"`
+}
+
+type PostTaskArg struct {
+	ConvID           ConversationID               `codec:"convID" json:"convID"`
+	Title            string                       `codec:"title" json:"title"`
+	Assignee         string                       `codec:"assignee" json:"assignee"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type AssignTaskArg struct {
+	ConvID           ConversationID               `codec:"convID" json:"convID"`
+	MsgID            MessageID                    `codec:"msgID" json:"msgID"`
+	Assignee         string                       `codec:"assignee" json:"assignee"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type SetTaskDoneArg struct {
+	ConvID           ConversationID               `codec:"convID" json:"convID"`
+	MsgID            MessageID                    `codec:"msgID" json:"msgID"`
+	Done             bool                         `codec:"done" json:"done"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type ListOpenTasksArg struct {
+	ConvID           ConversationID               `codec:"convID" json:"convID"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type ListOpenTeamTasksArg struct {
+	TeamID keybase1.TeamID `codec:"teamID" json:"teamID"`
+}
+
+type GetTeamReadmeArg struct {
+	TeamID keybase1.TeamID `codec:"teamID" json:"teamID"`
+}
+
+type SetTeamReadmeArg struct {
+	TeamID keybase1.TeamID `codec:"teamID" json:"teamID"`
+	Readme TeamReadme      `codec:"readme" json:"readme"`
+}
+
+type LocalInterface interface {
+	GetThreadLocal(context.Context, GetThreadLocalArg) (GetThreadLocalRes, error)
+	GetThreadNonblock(context.Context, GetThreadNonblockArg) (NonblockFetchRes, error)
+	GetUnreadline(context.Context, GetUnreadlineArg) (UnreadlineRes, error)
+	GetInboxAndUnboxLocal(context.Context, GetInboxAndUnboxLocalArg) (GetInboxAndUnboxLocalRes, error)
+	GetInboxAndUnboxUILocal(context.Context, GetInboxAndUnboxUILocalArg) (GetInboxAndUnboxUILocalRes, error)
+	RequestInboxLayout(context.Context, InboxLayoutReselectMode) error
+	RequestInboxUnbox(context.Context, []ConversationID) error
+	RequestInboxSmallIncrease(context.Context) error
+	RequestInboxSmallReset(context.Context) error
+	GetInboxNonblockLocal(context.Context, GetInboxNonblockLocalArg) (NonblockFetchRes, error)
+	PostLocal(context.Context, PostLocalArg) (PostLocalRes, error)
+	GenerateOutboxID(context.Context) (OutboxID, error)
+	PostLocalNonblock(context.Context, PostLocalNonblockArg) (PostLocalNonblockRes, error)
+	ForwardMessage(context.Context, ForwardMessageArg) (PostLocalRes, error)
+	ForwardMessageNonblock(context.Context, ForwardMessageNonblockArg) (PostLocalNonblockRes, error)
+	PostTextNonblock(context.Context, PostTextNonblockArg) (PostLocalNonblockRes, error)
+	PostDeleteNonblock(context.Context, PostDeleteNonblockArg) (PostLocalNonblockRes, error)
+	PostEditNonblock(context.Context, PostEditNonblockArg) (PostLocalNonblockRes, error)
+	PostReactionNonblock(context.Context, PostReactionNonblockArg) (PostLocalNonblockRes, error)
+	PostHeadlineNonblock(context.Context, PostHeadlineNonblockArg) (PostLocalNonblockRes, error)
+	PostHeadline(context.Context, PostHeadlineArg) (PostLocalRes, error)
+	PostMetadataNonblock(context.Context, PostMetadataNonblockArg) (PostLocalNonblockRes, error)
+	PostMetadata(context.Context, PostMetadataArg) (PostLocalRes, error)
+	PostDeleteHistoryUpto(context.Context, PostDeleteHistoryUptoArg) (PostLocalRes, error)
+	PostDeleteHistoryThrough(context.Context, PostDeleteHistoryThroughArg) (PostLocalRes, error)
+	PostDeleteHistoryByAge(context.Context, PostDeleteHistoryByAgeArg) (PostLocalRes, error)
+	SetConversationStatusLocal(context.Context, SetConversationStatusLocalArg) (SetConversationStatusLocalRes, error)
+	NewConversationsLocal(context.Context, NewConversationsLocalArg) (NewConversationsLocalRes, error)
+	NewConversationLocal(context.Context, NewConversationLocalArg) (NewConversationLocalRes, error)
+	GetInboxSummaryForCLILocal(context.Context, GetInboxSummaryForCLILocalQuery) (GetInboxSummaryForCLILocalRes, error)
+	// Like getInboxSummaryForCLILocal, with more filters, and sorted by
+	// activity, unread first or by name. Ties are broken by conversation ID,
+	// so the order is the same from one call to the next.
+	QueryInboxForCLILocal(context.Context, InboxQueryForCLI) (InboxQueryForCLIRes, error)
+	GetConversationForCLILocal(context.Context, GetConversationForCLILocalQuery) (GetConversationForCLILocalRes, error)
+	GetMessagesLocal(context.Context, GetMessagesLocalArg) (GetMessagesLocalRes, error)
+	PostFileAttachmentLocal(context.Context, PostFileAttachmentLocalArg) (PostLocalRes, error)
+	PostFileAttachmentLocalNonblock(context.Context, PostFileAttachmentLocalNonblockArg) (PostLocalNonblockRes, error)
+	GetNextAttachmentMessageLocal(context.Context, GetNextAttachmentMessageLocalArg) (GetNextAttachmentMessageLocalRes, error)
+	DownloadAttachmentLocal(context.Context, DownloadAttachmentLocalArg) (DownloadAttachmentLocalRes, error)
+	DownloadFileAttachmentLocal(context.Context, DownloadFileAttachmentLocalArg) (DownloadFileAttachmentLocalRes, error)
+	ConfigureFileAttachmentDownloadLocal(context.Context, ConfigureFileAttachmentDownloadLocalArg) error
+	MakePreview(context.Context, MakePreviewArg) (MakePreviewRes, error)
+	MakeAudioPreview(context.Context, MakeAudioPreviewArg) (MakePreviewRes, error)
+	GetUploadTempFile(context.Context, GetUploadTempFileArg) (string, error)
+	MakeUploadTempFile(context.Context, MakeUploadTempFileArg) (string, error)
+	CancelUploadTempFile(context.Context, OutboxID) error
+	CancelPost(context.Context, OutboxID) error
+	RetryPost(context.Context, RetryPostArg) error
+	MarkAsReadLocal(context.Context, MarkAsReadLocalArg) (MarkAsReadLocalRes, error)
+	MarkTLFAsReadLocal(context.Context, MarkTLFAsReadLocalArg) (MarkTLFAsReadLocalRes, error)
+	FindConversationsLocal(context.Context, FindConversationsLocalArg) (FindConversationsLocalRes, error)
+	FindGeneralConvFromTeamID(context.Context, keybase1.TeamID) (InboxUIItem, error)
+	UpdateTyping(context.Context, UpdateTypingArg) error
+	UpdateUnsentText(context.Context, UpdateUnsentTextArg) error
+	JoinConversationLocal(context.Context, JoinConversationLocalArg) (JoinLeaveConversationLocalRes, error)
+	JoinConversationByIDLocal(context.Context, ConversationID) (JoinLeaveConversationLocalRes, error)
+	LeaveConversationLocal(context.Context, ConversationID) (JoinLeaveConversationLocalRes, error)
+	PreviewConversationByIDLocal(context.Context, ConversationID) (PreviewConversationLocalRes, error)
+	DeleteConversationLocal(context.Context, DeleteConversationLocalArg) (DeleteConversationLocalRes, error)
+	RemoveFromConversationLocal(context.Context, RemoveFromConversationLocalArg) (RemoveFromConversationLocalRes, error)
+	GetTLFConversationsLocal(context.Context, GetTLFConversationsLocalArg) (GetTLFConversationsLocalRes, error)
+	GetChannelMembershipsLocal(context.Context, GetChannelMembershipsLocalArg) (GetChannelMembershipsLocalRes, error)
+	GetMutualTeamsLocal(context.Context, []string) (GetMutualTeamsLocalRes, error)
+	SetAppNotificationSettingsLocal(context.Context, SetAppNotificationSettingsLocalArg) (SetAppNotificationSettingsLocalRes, error)
+	SetGlobalAppNotificationSettingsLocal(context.Context, map[string]bool) error
+	GetGlobalAppNotificationSettingsLocal(context.Context) (GlobalAppNotificationSettings, error)
+	UnboxMobilePushNotification(context.Context, UnboxMobilePushNotificationArg) (string, error)
+	AddTeamMemberAfterReset(context.Context, AddTeamMemberAfterResetArg) error
+	GetAllResetConvMembers(context.Context) (GetAllResetConvMembersRes, error)
+	SetConvRetentionLocal(context.Context, SetConvRetentionLocalArg) error
+	SetTeamRetentionLocal(context.Context, SetTeamRetentionLocalArg) error
+	GetTeamRetentionLocal(context.Context, keybase1.TeamID) (*RetentionPolicy, error)
+	SetConvMinWriterRoleLocal(context.Context, SetConvMinWriterRoleLocalArg) error
+	UpgradeKBFSConversationToImpteam(context.Context, ConversationID) error
+	SearchRegexp(context.Context, SearchRegexpArg) (SearchRegexpRes, error)
+	CancelActiveInboxSearch(context.Context) error
+	SearchInbox(context.Context, SearchInboxArg) (SearchInboxRes, error)
+	SimpleSearchInboxConvNames(context.Context, string) ([]SimpleSearchInboxConvNamesHit, error)
+	CancelActiveSearch(context.Context) error
+	ProfileChatSearch(context.Context, keybase1.TLFIdentifyBehavior) (map[ConvIDStr]ProfileSearchConvStats, error)
+	GetStaticConfig(context.Context) (StaticConfig, error)
+	ResolveUnfurlPrompt(context.Context, ResolveUnfurlPromptArg) error
+	GetUnfurlSettings(context.Context) (UnfurlSettingsDisplay, error)
+	SaveUnfurlSettings(context.Context, SaveUnfurlSettingsArg) error
+	ToggleMessageCollapse(context.Context, ToggleMessageCollapseArg) error
+	BulkAddToConv(context.Context, BulkAddToConvArg) error
+	BulkAddToManyConvs(context.Context, BulkAddToManyConvsArg) error
+	PutReacjiSkinTone(context.Context, keybase1.ReacjiSkinTone) (keybase1.UserReacjis, error)
+	ResolveMaybeMention(context.Context, MaybeMention) error
+	LoadGallery(context.Context, LoadGalleryArg) (LoadGalleryRes, error)
+	LoadFlip(context.Context, LoadFlipArg) (LoadFlipRes, error)
+	LocationUpdate(context.Context, Coordinate) error
+	AdvertiseBotCommandsLocal(context.Context, AdvertiseBotCommandsLocalArg) (AdvertiseBotCommandsLocalRes, error)
+	ListBotCommandsLocal(context.Context, ConversationID) (ListBotCommandsLocalRes, error)
+	ListPublicBotCommandsLocal(context.Context, string) (ListBotCommandsLocalRes, error)
+	ClearBotCommandsLocal(context.Context, *ClearBotCommandsFilter) (ClearBotCommandsLocalRes, error)
+	PinMessage(context.Context, PinMessageArg) (PinMessageRes, error)
+	UnpinMessage(context.Context, ConversationID) (PinMessageRes, error)
+	IgnorePinnedMessage(context.Context, ConversationID) error
+	AddBotMember(context.Context, AddBotMemberArg) error
+	EditBotMember(context.Context, EditBotMemberArg) error
+	RemoveBotMember(context.Context, RemoveBotMemberArg) error
+	SetBotMemberSettings(context.Context, SetBotMemberSettingsArg) error
+	GetBotMemberSettings(context.Context, GetBotMemberSettingsArg) (keybase1.TeamBotSettings, error)
+	GetTeamRoleInConversation(context.Context, GetTeamRoleInConversationArg) (keybase1.TeamRole, error)
+	AddBotConvSearch(context.Context, string) ([]ConvSearchHit, error)
+	ForwardMessageConvSearch(context.Context, string) ([]ConvSearchHit, error)
+	TeamIDFromTLFName(context.Context, TeamIDFromTLFNameArg) (keybase1.TeamID, error)
+	DismissJourneycard(context.Context, DismissJourneycardArg) error
+	SetWelcomeMessage(context.Context, SetWelcomeMessageArg) error
+	GetWelcomeMessage(context.Context, keybase1.TeamID) (WelcomeMessageDisplay, error)
+	GetDefaultTeamChannelsLocal(context.Context, keybase1.TeamID) (GetDefaultTeamChannelsLocalRes, error)
+	SetDefaultTeamChannelsLocal(context.Context, SetDefaultTeamChannelsLocalArg) (SetDefaultTeamChannelsLocalRes, error)
+	GetLastActiveForTLF(context.Context, TLFIDStr) (LastActiveStatus, error)
+	GetLastActiveForTeams(context.Context) (LastActiveStatusAll, error)
+	GetRecentJoinsLocal(context.Context, ConversationID) (int, error)
+	RefreshParticipants(context.Context, ConversationID) error
+	GetLastActiveAtLocal(context.Context, GetLastActiveAtLocalArg) (gregor1.Time, error)
+	GetLastActiveAtMultiLocal(context.Context, GetLastActiveAtMultiLocalArg) (map[keybase1.TeamID]gregor1.Time, error)
+	GetParticipants(context.Context, ConversationID) ([]ConversationLocalParticipant, error)
+	AddEmoji(context.Context, AddEmojiArg) (AddEmojiRes, error)
+	AddEmojis(context.Context, AddEmojisArg) (AddEmojisRes, error)
+	AddEmojiAlias(context.Context, AddEmojiAliasArg) (AddEmojiAliasRes, error)
+	RemoveEmoji(context.Context, RemoveEmojiArg) (RemoveEmojiRes, error)
+	UserEmojis(context.Context, UserEmojisArg) (UserEmojiRes, error)
+	ToggleEmojiAnimations(context.Context, bool) error
+	TrackGiphySelect(context.Context, TrackGiphySelectArg) (TrackGiphySelectRes, error)
+	ArchiveChat(context.Context, ArchiveChatJobRequest) (ArchiveChatRes, error)
+	ArchiveChatList(context.Context, keybase1.TLFIdentifyBehavior) (ArchiveChatListRes, error)
+	ArchiveChatDelete(context.Context, ArchiveChatDeleteArg) error
+	ArchiveChatPause(context.Context, ArchiveChatPauseArg) error
+	ArchiveChatResume(context.Context, ArchiveChatResumeArg) error
+	ArchiveChatSetSchedule(context.Context, ArchiveChatSetScheduleArg) error
+	ArchiveChatExportHistory(context.Context, ArchiveChatExportHistoryArg) error
+	ArchiveChatImportHistory(context.Context, ArchiveChatImportHistoryArg) (ArchiveChatImportHistoryRes, error)
+	ArchiveChatSearch(context.Context, ArchiveChatSearchArg) (ArchiveChatSearchRes, error)
+	ArchiveChatVerify(context.Context, ArchiveChatVerifyArg) (ArchiveChatVerifyRes, error)
+	ArchiveChatImport(context.Context, ArchiveChatImportArg) (ArchiveChatImportRes, error)
+	ArchiveChatListImported(context.Context, keybase1.TLFIdentifyBehavior) ([]ArchiveChatImportedConv, error)
+	ArchiveChatGetImportedThread(context.Context, ArchiveChatGetImportedThreadArg) (ArchiveChatImportedThread, error)
+	ArchiveChatDeleteImported(context.Context, ArchiveChatDeleteImportedArg) error
+	SearchChatHistory(context.Context, SearchChatHistoryArg) (ChatHistorySearchRes, error)
+	GetConversationContacts(context.Context, GetConversationContactsArg) (ConversationContactsRes, error)
+	SplitConversationLocal(context.Context, SplitConversationLocalArg) (SplitConversationLocalRes, error)
+	GetArchiveRedactionRules(context.Context, keybase1.TeamID) (ArchiveRedactionRules, error)
+	SetArchiveRedactionRules(context.Context, SetArchiveRedactionRulesArg) error
+	GetNewMemberRestrictions(context.Context, keybase1.TeamID) (NewMemberRestrictions, error)
+	SetNewMemberRestrictions(context.Context, SetNewMemberRestrictionsArg) error
+	SetNewMemberRestrictionsExempt(context.Context, SetNewMemberRestrictionsExemptArg) error
+	GetTrustedDevicePolicy(context.Context, ConversationID) (*TrustedDeviceChannelPolicy, error)
+	SetTrustedDevicePolicy(context.Context, SetTrustedDevicePolicyArg) error
+	SetTrustedDeviceApproved(context.Context, SetTrustedDeviceApprovedArg) error
+	BulkDeleteMessagesLocal(context.Context, BulkDeleteMessagesLocalArg) (BulkDeleteMessagesRes, error)
+	GetModerationAuditLog(context.Context, keybase1.TeamID) (ModerationAuditLog, error)
+	GetNotificationRoutingPreferences(context.Context) (NotificationRoutingPreferences, error)
+	SetNotificationRoutingPreferences(context.Context, NotificationRoutingPreferences) error
+	GetMentionDigestSettings(context.Context) (MentionDigestSettings, error)
+	SetMentionDigestSettings(context.Context, MentionDigestSettings) error
+	GetMentionDigest(context.Context) (MentionDigest, error)
+	GetIncidentMode(context.Context, keybase1.TeamID) (IncidentModeSettings, error)
+	SetIncidentMode(context.Context, SetIncidentModeArg) error
+	GetIncidentModeAuditLog(context.Context, keybase1.TeamID) (IncidentModeAuditLog, error)
+	GetChannelBanner(context.Context, ConversationID) (*ChannelBanner, error)
+	SetChannelBanner(context.Context, SetChannelBannerArg) error
+	GetChannelBannerAuditLog(context.Context, keybase1.TeamID) (ChannelBannerAuditLog, error)
+	GetMembershipHook(context.Context, keybase1.TeamID) (MembershipHook, error)
+	SetMembershipHook(context.Context, SetMembershipHookArg) error
+	PostPoll(context.Context, PostPollArg) (PostLocalRes, error)
+	VotePoll(context.Context, VotePollArg) error
+	GetPollResults(context.Context, GetPollResultsArg) (PollResults, error)
+	GetAttachmentExpiryPolicy(context.Context, keybase1.TeamID) (AttachmentExpiryPolicy, error)
+	SetAttachmentExpiryPolicy(context.Context, SetAttachmentExpiryPolicyArg) error
+	ExpireTeamAttachments(context.Context, ExpireTeamAttachmentsArg) (AttachmentExpiryReport, error)
+	EnableWidgetFeed(context.Context, EnableWidgetFeedArg) error
+	DisableWidgetFeed(context.Context) error
+	PinWidgetFeedMessage(context.Context, PinWidgetFeedMessageArg) error
+	GetWidgetFeed(context.Context) (WidgetFeed, error)
+	AcknowledgeIdentityChanges(context.Context, ConversationID) error
+	GetAttachmentAutoDownloadPolicy(context.Context) (AttachmentAutoDownloadPolicy, error)
+	SetAttachmentAutoDownloadPolicy(context.Context, AttachmentAutoDownloadPolicy) error
+	PostTask(context.Context, PostTaskArg) (PostLocalRes, error)
+	AssignTask(context.Context, AssignTaskArg) error
+	SetTaskDone(context.Context, SetTaskDoneArg) error
+	ListOpenTasks(context.Context, ListOpenTasksArg) ([]TaskInfo, error)
+	ListOpenTeamTasks(context.Context, keybase1.TeamID) ([]TaskInfo, error)
+	GetTeamReadme(context.Context, keybase1.TeamID) (TeamReadme, error)
+	SetTeamReadme(context.Context, SetTeamReadmeArg) error
+}
+
+func LocalProtocol(i LocalInterface) rpc.Protocol {
+	return rpc.Protocol{
+		Name: "chat.1.local",
+		Methods: map[string]rpc.ServeHandlerDescription{
+			"getThreadLocal": {
+				MakeArg: func() interface{} {
+					var ret [1]GetThreadLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]GetThreadLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]GetThreadLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.GetThreadLocal(ctx, typedArgs[0])
+					return
+				},
+			},
+			"getThreadNonblock": {
+				MakeArg: func() interface{} {
+					var ret [1]GetThreadNonblockArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]GetThreadNonblockArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]GetThreadNonblockArg)(nil), args)
+						return
+					}
+					ret, err = i.GetThreadNonblock(ctx, typedArgs[0])
+					return
+				},
+			},
+			"getUnreadline": {
+				MakeArg: func() interface{} {
+					var ret [1]GetUnreadlineArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]GetUnreadlineArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]GetUnreadlineArg)(nil), args)
+						return
+					}
+					ret, err = i.GetUnreadline(ctx, typedArgs[0])
+					return
+				},
+			},
+			"getInboxAndUnboxLocal": {
+				MakeArg: func() interface{} {
+					var ret [1]GetInboxAndUnboxLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]GetInboxAndUnboxLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]GetInboxAndUnboxLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.GetInboxAndUnboxLocal(ctx, typedArgs[0])
+					return
+				},
+			},
+			"getInboxAndUnboxUILocal": {
+				MakeArg: func() interface{} {
+					var ret [1]GetInboxAndUnboxUILocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]GetInboxAndUnboxUILocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]GetInboxAndUnboxUILocalArg)(nil), args)
+						return
+					}
+					ret, err = i.GetInboxAndUnboxUILocal(ctx, typedArgs[0])
+					return
+				},
+			},
+			"requestInboxLayout": {
+				MakeArg: func() interface{} {
+					var ret [1]RequestInboxLayoutArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]RequestInboxLayoutArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]RequestInboxLayoutArg)(nil), args)
+						return
+					}
+					err = i.RequestInboxLayout(ctx, typedArgs[0].ReselectMode)
+					return
+				},
+			},
+			"requestInboxUnbox": {
+				MakeArg: func() interface{} {
+					var ret [1]RequestInboxUnboxArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]RequestInboxUnboxArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]RequestInboxUnboxArg)(nil), args)
+						return
+					}
+					err = i.RequestInboxUnbox(ctx, typedArgs[0].ConvIDs)
+					return
+				},
+			},
+			"requestInboxSmallIncrease": {
+				MakeArg: func() interface{} {
+					var ret [1]RequestInboxSmallIncreaseArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					err = i.RequestInboxSmallIncrease(ctx)
+					return
+				},
+			},
+			"requestInboxSmallReset": {
+				MakeArg: func() interface{} {
+					var ret [1]RequestInboxSmallResetArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					err = i.RequestInboxSmallReset(ctx)
+					return
+				},
+			},
+			"getInboxNonblockLocal": {
+				MakeArg: func() interface{} {
+					var ret [1]GetInboxNonblockLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]GetInboxNonblockLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]GetInboxNonblockLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.GetInboxNonblockLocal(ctx, typedArgs[0])
+					return
+				},
+			},
+			"postLocal": {
+				MakeArg: func() interface{} {
+					var ret [1]PostLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]PostLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]PostLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.PostLocal(ctx, typedArgs[0])
+					return
+				},
+			},
+			"generateOutboxID": {
+				MakeArg: func() interface{} {
+					var ret [1]GenerateOutboxIDArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					ret, err = i.GenerateOutboxID(ctx)
+					return
+				},
+			},
+			"postLocalNonblock": {
+				MakeArg: func() interface{} {
+					var ret [1]PostLocalNonblockArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]PostLocalNonblockArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]PostLocalNonblockArg)(nil), args)
+						return
+					}
+					ret, err = i.PostLocalNonblock(ctx, typedArgs[0])
+					return
+				},
+			},
+			"forwardMessage": {
+				MakeArg: func() interface{} {
+					var ret [1]ForwardMessageArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]ForwardMessageArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]ForwardMessageArg)(nil), args)
+						return
+					}
+					ret, err = i.ForwardMessage(ctx, typedArgs[0])
+					return
+				},
+			},
+			"forwardMessageNonblock": {
+				MakeArg: func() interface{} {
+					var ret [1]ForwardMessageNonblockArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]ForwardMessageNonblockArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]ForwardMessageNonblockArg)(nil), args)
+						return
+					}
+					ret, err = i.ForwardMessageNonblock(ctx, typedArgs[0])
+					return
+				},
+			},
+			"postTextNonblock": {
+				MakeArg: func() interface{} {
+					var ret [1]PostTextNonblockArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]PostTextNonblockArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]PostTextNonblockArg)(nil), args)
+						return
+					}
+					ret, err = i.PostTextNonblock(ctx, typedArgs[0])
+					return
+				},
+			},
+			"postDeleteNonblock": {
+				MakeArg: func() interface{} {
+					var ret [1]PostDeleteNonblockArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]PostDeleteNonblockArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]PostDeleteNonblockArg)(nil), args)
+						return
+					}
+					ret, err = i.PostDeleteNonblock(ctx, typedArgs[0])
+					return
+				},
+			},
+			"postEditNonblock": {
+				MakeArg: func() interface{} {
+					var ret [1]PostEditNonblockArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]PostEditNonblockArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]PostEditNonblockArg)(nil), args)
+						return
+					}
+					ret, err = i.PostEditNonblock(ctx, typedArgs[0])
+					return
+				},
+			},
+			"postReactionNonblock": {
+				MakeArg: func() interface{} {
+					var ret [1]PostReactionNonblockArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]PostReactionNonblockArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]PostReactionNonblockArg)(nil), args)
+						return
+					}
+					ret, err = i.PostReactionNonblock(ctx, typedArgs[0])
+					return
+				},
+			},
+			"postHeadlineNonblock": {
+				MakeArg: func() interface{} {
+					var ret [1]PostHeadlineNonblockArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]PostHeadlineNonblockArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]PostHeadlineNonblockArg)(nil), args)
+						return
+					}
+					ret, err = i.PostHeadlineNonblock(ctx, typedArgs[0])
+					return
+				},
+			},
+			"postHeadline": {
+				MakeArg: func() interface{} {
+					var ret [1]PostHeadlineArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]PostHeadlineArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]PostHeadlineArg)(nil), args)
+						return
+					}
+					ret, err = i.PostHeadline(ctx, typedArgs[0])
+					return
+				},
+			},
+			"postMetadataNonblock": {
+				MakeArg: func() interface{} {
+					var ret [1]PostMetadataNonblockArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]PostMetadataNonblockArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]PostMetadataNonblockArg)(nil), args)
+						return
+					}
+					ret, err = i.PostMetadataNonblock(ctx, typedArgs[0])
+					return
+				},
+			},
+			"postMetadata": {
+				MakeArg: func() interface{} {
+					var ret [1]PostMetadataArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]PostMetadataArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]PostMetadataArg)(nil), args)
+						return
+					}
+					ret, err = i.PostMetadata(ctx, typedArgs[0])
+					return
+				},
+			},
+			"postDeleteHistoryUpto": {
+				MakeArg: func() interface{} {
+					var ret [1]PostDeleteHistoryUptoArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]PostDeleteHistoryUptoArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]PostDeleteHistoryUptoArg)(nil), args)
+						return
+					}
+					ret, err = i.PostDeleteHistoryUpto(ctx, typedArgs[0])
+					return
+				},
+			},
+			"postDeleteHistoryThrough": {
+				MakeArg: func() interface{} {
+					var ret [1]PostDeleteHistoryThroughArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]PostDeleteHistoryThroughArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]PostDeleteHistoryThroughArg)(nil), args)
+						return
+					}
+					ret, err = i.PostDeleteHistoryThrough(ctx, typedArgs[0])
+					return
+				},
+			},
+			"postDeleteHistoryByAge": {
+				MakeArg: func() interface{} {
+					var ret [1]PostDeleteHistoryByAgeArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]PostDeleteHistoryByAgeArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]PostDeleteHistoryByAgeArg)(nil), args)
+						return
+					}
+					ret, err = i.PostDeleteHistoryByAge(ctx, typedArgs[0])
+					return
+				},
+			},
+			"SetConversationStatusLocal": {
+				MakeArg: func() interface{} {
+					var ret [1]SetConversationStatusLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SetConversationStatusLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SetConversationStatusLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.SetConversationStatusLocal(ctx, typedArgs[0])
+					return
+				},
+			},
+			"newConversationsLocal": {
+				MakeArg: func() interface{} {
+					var ret [1]NewConversationsLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]NewConversationsLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]NewConversationsLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.NewConversationsLocal(ctx, typedArgs[0])
+					return
+				},
+			},
+			"newConversationLocal": {
+				MakeArg: func() interface{} {
+					var ret [1]NewConversationLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]NewConversationLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]NewConversationLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.NewConversationLocal(ctx, typedArgs[0])
+					return
+				},
+			},
+			"getInboxSummaryForCLILocal": {
+				MakeArg: func() interface{} {
+					var ret [1]GetInboxSummaryForCLILocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]GetInboxSummaryForCLILocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]GetInboxSummaryForCLILocalArg)(nil), args)
+						return
+					}
+					ret, err = i.GetInboxSummaryForCLILocal(ctx, typedArgs[0].Query)
+					return
+				},
+			},
+			"queryInboxForCLILocal": {
+				MakeArg: func() interface{} {
+					var ret [1]QueryInboxForCLILocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]QueryInboxForCLILocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]QueryInboxForCLILocalArg)(nil), args)
+						return
+					}
+					ret, err = i.QueryInboxForCLILocal(ctx, typedArgs[0].Query)
+					return
+				},
+			},
+			"getConversationForCLILocal": {
+				MakeArg: func() interface{} {
+					var ret [1]GetConversationForCLILocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]GetConversationForCLILocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]GetConversationForCLILocalArg)(nil), args)
+						return
+					}
+					ret, err = i.GetConversationForCLILocal(ctx, typedArgs[0].Query)
+					return
+				},
+			},
+			"GetMessagesLocal": {
+				MakeArg: func() interface{} {
+					var ret [1]GetMessagesLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]GetMessagesLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]GetMessagesLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.GetMessagesLocal(ctx, typedArgs[0])
+					return
+				},
+			},
+			"postFileAttachmentLocal": {
+				MakeArg: func() interface{} {
+					var ret [1]PostFileAttachmentLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]PostFileAttachmentLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]PostFileAttachmentLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.PostFileAttachmentLocal(ctx, typedArgs[0])
+					return
+				},
+			},
+			"postFileAttachmentLocalNonblock": {
+				MakeArg: func() interface{} {
+					var ret [1]PostFileAttachmentLocalNonblockArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]PostFileAttachmentLocalNonblockArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]PostFileAttachmentLocalNonblockArg)(nil), args)
+						return
+					}
+					ret, err = i.PostFileAttachmentLocalNonblock(ctx, typedArgs[0])
+					return
+				},
+			},
+			"getNextAttachmentMessageLocal": {
+				MakeArg: func() interface{} {
+					var ret [1]GetNextAttachmentMessageLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]GetNextAttachmentMessageLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]GetNextAttachmentMessageLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.GetNextAttachmentMessageLocal(ctx, typedArgs[0])
+					return
+				},
+			},
+			"DownloadAttachmentLocal": {
+				MakeArg: func() interface{} {
+					var ret [1]DownloadAttachmentLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]DownloadAttachmentLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]DownloadAttachmentLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.DownloadAttachmentLocal(ctx, typedArgs[0])
+					return
+				},
+			},
+			"DownloadFileAttachmentLocal": {
+				MakeArg: func() interface{} {
+					var ret [1]DownloadFileAttachmentLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]DownloadFileAttachmentLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]DownloadFileAttachmentLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.DownloadFileAttachmentLocal(ctx, typedArgs[0])
+					return
+				},
+			},
+			"ConfigureFileAttachmentDownloadLocal": {
+				MakeArg: func() interface{} {
+					var ret [1]ConfigureFileAttachmentDownloadLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]ConfigureFileAttachmentDownloadLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]ConfigureFileAttachmentDownloadLocalArg)(nil), args)
+						return
+					}
+					err = i.ConfigureFileAttachmentDownloadLocal(ctx, typedArgs[0])
+					return
+				},
+			},
+			"makePreview": {
+				MakeArg: func() interface{} {
+					var ret [1]MakePreviewArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]MakePreviewArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]MakePreviewArg)(nil), args)
+						return
+					}
+					ret, err = i.MakePreview(ctx, typedArgs[0])
+					return
+				},
+			},
+			"makeAudioPreview": {
+				MakeArg: func() interface{} {
+					var ret [1]MakeAudioPreviewArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]MakeAudioPreviewArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]MakeAudioPreviewArg)(nil), args)
+						return
+					}
+					ret, err = i.MakeAudioPreview(ctx, typedArgs[0])
+					return
+				},
+			},
+			"getUploadTempFile": {
+				MakeArg: func() interface{} {
+					var ret [1]GetUploadTempFileArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]GetUploadTempFileArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]GetUploadTempFileArg)(nil), args)
+						return
+					}
+					ret, err = i.GetUploadTempFile(ctx, typedArgs[0])
+					return
+				},
+			},
+			"makeUploadTempFile": {
+				MakeArg: func() interface{} {
+					var ret [1]MakeUploadTempFileArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]MakeUploadTempFileArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]MakeUploadTempFileArg)(nil), args)
+						return
+					}
+					ret, err = i.MakeUploadTempFile(ctx, typedArgs[0])
+					return
+				},
+			},
+			"cancelUploadTempFile": {
+				MakeArg: func() interface{} {
+					var ret [1]CancelUploadTempFileArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]CancelUploadTempFileArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]CancelUploadTempFileArg)(nil), args)
+						return
+					}
+					err = i.CancelUploadTempFile(ctx, typedArgs[0].OutboxID)
+					return
+				},
+			},
+			"CancelPost": {
+				MakeArg: func() interface{} {
+					var ret [1]CancelPostArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]CancelPostArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]CancelPostArg)(nil), args)
+						return
+					}
+					err = i.CancelPost(ctx, typedArgs[0].OutboxID)
+					return
+				},
+			},
+			"RetryPost": {
+				MakeArg: func() interface{} {
+					var ret [1]RetryPostArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]RetryPostArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]RetryPostArg)(nil), args)
+						return
+					}
+					err = i.RetryPost(ctx, typedArgs[0])
+					return
+				},
+			},
+			"markAsReadLocal": {
+				MakeArg: func() interface{} {
+					var ret [1]MarkAsReadLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]MarkAsReadLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]MarkAsReadLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.MarkAsReadLocal(ctx, typedArgs[0])
+					return
+				},
+			},
+			"markTLFAsReadLocal": {
+				MakeArg: func() interface{} {
+					var ret [1]MarkTLFAsReadLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]MarkTLFAsReadLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]MarkTLFAsReadLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.MarkTLFAsReadLocal(ctx, typedArgs[0])
+					return
+				},
+			},
+			"findConversationsLocal": {
+				MakeArg: func() interface{} {
+					var ret [1]FindConversationsLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]FindConversationsLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]FindConversationsLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.FindConversationsLocal(ctx, typedArgs[0])
+					return
+				},
+			},
+			"findGeneralConvFromTeamID": {
+				MakeArg: func() interface{} {
+					var ret [1]FindGeneralConvFromTeamIDArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]FindGeneralConvFromTeamIDArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]FindGeneralConvFromTeamIDArg)(nil), args)
+						return
+					}
+					ret, err = i.FindGeneralConvFromTeamID(ctx, typedArgs[0].TeamID)
+					return
+				},
+			},
+			"updateTyping": {
+				MakeArg: func() interface{} {
+					var ret [1]UpdateTypingArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]UpdateTypingArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]UpdateTypingArg)(nil), args)
+						return
+					}
+					err = i.UpdateTyping(ctx, typedArgs[0])
+					return
+				},
+			},
+			"updateUnsentText": {
 				MakeArg: func() interface{} {
-					var ret [1]GetThreadLocalArg
+					var ret [1]UpdateUnsentTextArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]GetThreadLocalArg)
+					typedArgs, ok := args.(*[1]UpdateUnsentTextArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]GetThreadLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]UpdateUnsentTextArg)(nil), args)
 						return
 					}
-					ret, err = i.GetThreadLocal(ctx, typedArgs[0])
+					err = i.UpdateUnsentText(ctx, typedArgs[0])
 					return
 				},
 			},
-			"getThreadNonblock": {
+			"joinConversationLocal": {
 				MakeArg: func() interface{} {
-					var ret [1]GetThreadNonblockArg
+					var ret [1]JoinConversationLocalArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]GetThreadNonblockArg)
+					typedArgs, ok := args.(*[1]JoinConversationLocalArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]GetThreadNonblockArg)(nil), args)
+						err = rpc.NewTypeError((*[1]JoinConversationLocalArg)(nil), args)
 						return
 					}
-					ret, err = i.GetThreadNonblock(ctx, typedArgs[0])
+					ret, err = i.JoinConversationLocal(ctx, typedArgs[0])
 					return
 				},
 			},
-			"getUnreadline": {
+			"joinConversationByIDLocal": {
 				MakeArg: func() interface{} {
-					var ret [1]GetUnreadlineArg
+					var ret [1]JoinConversationByIDLocalArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]GetUnreadlineArg)
+					typedArgs, ok := args.(*[1]JoinConversationByIDLocalArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]GetUnreadlineArg)(nil), args)
+						err = rpc.NewTypeError((*[1]JoinConversationByIDLocalArg)(nil), args)
 						return
 					}
-					ret, err = i.GetUnreadline(ctx, typedArgs[0])
+					ret, err = i.JoinConversationByIDLocal(ctx, typedArgs[0].ConvID)
 					return
 				},
 			},
-			"getInboxAndUnboxLocal": {
+			"leaveConversationLocal": {
 				MakeArg: func() interface{} {
-					var ret [1]GetInboxAndUnboxLocalArg
+					var ret [1]LeaveConversationLocalArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]GetInboxAndUnboxLocalArg)
+					typedArgs, ok := args.(*[1]LeaveConversationLocalArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]GetInboxAndUnboxLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]LeaveConversationLocalArg)(nil), args)
 						return
 					}
-					ret, err = i.GetInboxAndUnboxLocal(ctx, typedArgs[0])
+					ret, err = i.LeaveConversationLocal(ctx, typedArgs[0].ConvID)
 					return
 				},
 			},
-			"getInboxAndUnboxUILocal": {
+			"previewConversationByIDLocal": {
 				MakeArg: func() interface{} {
-					var ret [1]GetInboxAndUnboxUILocalArg
+					var ret [1]PreviewConversationByIDLocalArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]GetInboxAndUnboxUILocalArg)
+					typedArgs, ok := args.(*[1]PreviewConversationByIDLocalArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]GetInboxAndUnboxUILocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]PreviewConversationByIDLocalArg)(nil), args)
 						return
 					}
-					ret, err = i.GetInboxAndUnboxUILocal(ctx, typedArgs[0])
+					ret, err = i.PreviewConversationByIDLocal(ctx, typedArgs[0].ConvID)
 					return
 				},
 			},
-			"requestInboxLayout": {
+			"deleteConversationLocal": {
 				MakeArg: func() interface{} {
-					var ret [1]RequestInboxLayoutArg
+					var ret [1]DeleteConversationLocalArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]RequestInboxLayoutArg)
+					typedArgs, ok := args.(*[1]DeleteConversationLocalArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]RequestInboxLayoutArg)(nil), args)
+						err = rpc.NewTypeError((*[1]DeleteConversationLocalArg)(nil), args)
 						return
 					}
-					err = i.RequestInboxLayout(ctx, typedArgs[0].ReselectMode)
+					ret, err = i.DeleteConversationLocal(ctx, typedArgs[0])
 					return
 				},
 			},
-			"requestInboxUnbox": {
+			"removeFromConversationLocal": {
 				MakeArg: func() interface{} {
-					var ret [1]RequestInboxUnboxArg
+					var ret [1]RemoveFromConversationLocalArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]RequestInboxUnboxArg)
+					typedArgs, ok := args.(*[1]RemoveFromConversationLocalArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]RequestInboxUnboxArg)(nil), args)
+						err = rpc.NewTypeError((*[1]RemoveFromConversationLocalArg)(nil), args)
 						return
 					}
-					err = i.RequestInboxUnbox(ctx, typedArgs[0].ConvIDs)
+					ret, err = i.RemoveFromConversationLocal(ctx, typedArgs[0])
 					return
 				},
 			},
-			"requestInboxSmallIncrease": {
+			"getTLFConversationsLocal": {
 				MakeArg: func() interface{} {
-					var ret [1]RequestInboxSmallIncreaseArg
+					var ret [1]GetTLFConversationsLocalArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					err = i.RequestInboxSmallIncrease(ctx)
+					typedArgs, ok := args.(*[1]GetTLFConversationsLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]GetTLFConversationsLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.GetTLFConversationsLocal(ctx, typedArgs[0])
 					return
 				},
 			},
-			"requestInboxSmallReset": {
+			"getChannelMembershipsLocal": {
 				MakeArg: func() interface{} {
-					var ret [1]RequestInboxSmallResetArg
+					var ret [1]GetChannelMembershipsLocalArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					err = i.RequestInboxSmallReset(ctx)
+					typedArgs, ok := args.(*[1]GetChannelMembershipsLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]GetChannelMembershipsLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.GetChannelMembershipsLocal(ctx, typedArgs[0])
 					return
 				},
 			},
-			"getInboxNonblockLocal": {
+			"getMutualTeamsLocal": {
 				MakeArg: func() interface{} {
-					var ret [1]GetInboxNonblockLocalArg
+					var ret [1]GetMutualTeamsLocalArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]GetInboxNonblockLocalArg)
+					typedArgs, ok := args.(*[1]GetMutualTeamsLocalArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]GetInboxNonblockLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]GetMutualTeamsLocalArg)(nil), args)
 						return
 					}
-					ret, err = i.GetInboxNonblockLocal(ctx, typedArgs[0])
+					ret, err = i.GetMutualTeamsLocal(ctx, typedArgs[0].Usernames)
 					return
 				},
 			},
-			"postLocal": {
+			"setAppNotificationSettingsLocal": {
 				MakeArg: func() interface{} {
-					var ret [1]PostLocalArg
+					var ret [1]SetAppNotificationSettingsLocalArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]PostLocalArg)
+					typedArgs, ok := args.(*[1]SetAppNotificationSettingsLocalArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]PostLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SetAppNotificationSettingsLocalArg)(nil), args)
 						return
 					}
-					ret, err = i.PostLocal(ctx, typedArgs[0])
+					ret, err = i.SetAppNotificationSettingsLocal(ctx, typedArgs[0])
 					return
 				},
 			},
-			"generateOutboxID": {
+			"setGlobalAppNotificationSettingsLocal": {
 				MakeArg: func() interface{} {
-					var ret [1]GenerateOutboxIDArg
+					var ret [1]SetGlobalAppNotificationSettingsLocalArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					ret, err = i.GenerateOutboxID(ctx)
+					typedArgs, ok := args.(*[1]SetGlobalAppNotificationSettingsLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SetGlobalAppNotificationSettingsLocalArg)(nil), args)
+						return
+					}
+					err = i.SetGlobalAppNotificationSettingsLocal(ctx, typedArgs[0].Settings)
 					return
 				},
 			},
-			"postLocalNonblock": {
+			"getGlobalAppNotificationSettingsLocal": {
 				MakeArg: func() interface{} {
-					var ret [1]PostLocalNonblockArg
+					var ret [1]GetGlobalAppNotificationSettingsLocalArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]PostLocalNonblockArg)
+					ret, err = i.GetGlobalAppNotificationSettingsLocal(ctx)
+					return
+				},
+			},
+			"unboxMobilePushNotification": {
+				MakeArg: func() interface{} {
+					var ret [1]UnboxMobilePushNotificationArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]UnboxMobilePushNotificationArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]PostLocalNonblockArg)(nil), args)
+						err = rpc.NewTypeError((*[1]UnboxMobilePushNotificationArg)(nil), args)
 						return
 					}
-					ret, err = i.PostLocalNonblock(ctx, typedArgs[0])
+					ret, err = i.UnboxMobilePushNotification(ctx, typedArgs[0])
 					return
 				},
 			},
-			"forwardMessage": {
+			"addTeamMemberAfterReset": {
 				MakeArg: func() interface{} {
-					var ret [1]ForwardMessageArg
+					var ret [1]AddTeamMemberAfterResetArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]ForwardMessageArg)
+					typedArgs, ok := args.(*[1]AddTeamMemberAfterResetArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]ForwardMessageArg)(nil), args)
+						err = rpc.NewTypeError((*[1]AddTeamMemberAfterResetArg)(nil), args)
 						return
 					}
-					ret, err = i.ForwardMessage(ctx, typedArgs[0])
+					err = i.AddTeamMemberAfterReset(ctx, typedArgs[0])
 					return
 				},
 			},
-			"forwardMessageNonblock": {
+			"getAllResetConvMembers": {
 				MakeArg: func() interface{} {
-					var ret [1]ForwardMessageNonblockArg
+					var ret [1]GetAllResetConvMembersArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]ForwardMessageNonblockArg)
+					ret, err = i.GetAllResetConvMembers(ctx)
+					return
+				},
+			},
+			"setConvRetentionLocal": {
+				MakeArg: func() interface{} {
+					var ret [1]SetConvRetentionLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SetConvRetentionLocalArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]ForwardMessageNonblockArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SetConvRetentionLocalArg)(nil), args)
 						return
 					}
-					ret, err = i.ForwardMessageNonblock(ctx, typedArgs[0])
+					err = i.SetConvRetentionLocal(ctx, typedArgs[0])
 					return
 				},
 			},
-			"postTextNonblock": {
+			"setTeamRetentionLocal": {
 				MakeArg: func() interface{} {
-					var ret [1]PostTextNonblockArg
+					var ret [1]SetTeamRetentionLocalArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]PostTextNonblockArg)
+					typedArgs, ok := args.(*[1]SetTeamRetentionLocalArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]PostTextNonblockArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SetTeamRetentionLocalArg)(nil), args)
 						return
 					}
-					ret, err = i.PostTextNonblock(ctx, typedArgs[0])
+					err = i.SetTeamRetentionLocal(ctx, typedArgs[0])
 					return
 				},
 			},
-			"postDeleteNonblock": {
+			"getTeamRetentionLocal": {
 				MakeArg: func() interface{} {
-					var ret [1]PostDeleteNonblockArg
+					var ret [1]GetTeamRetentionLocalArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]PostDeleteNonblockArg)
+					typedArgs, ok := args.(*[1]GetTeamRetentionLocalArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]PostDeleteNonblockArg)(nil), args)
+						err = rpc.NewTypeError((*[1]GetTeamRetentionLocalArg)(nil), args)
 						return
 					}
-					ret, err = i.PostDeleteNonblock(ctx, typedArgs[0])
+					ret, err = i.GetTeamRetentionLocal(ctx, typedArgs[0].TeamID)
 					return
 				},
 			},
-			"postEditNonblock": {
+			"setConvMinWriterRoleLocal": {
 				MakeArg: func() interface{} {
-					var ret [1]PostEditNonblockArg
+					var ret [1]SetConvMinWriterRoleLocalArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]PostEditNonblockArg)
+					typedArgs, ok := args.(*[1]SetConvMinWriterRoleLocalArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]PostEditNonblockArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SetConvMinWriterRoleLocalArg)(nil), args)
 						return
 					}
-					ret, err = i.PostEditNonblock(ctx, typedArgs[0])
+					err = i.SetConvMinWriterRoleLocal(ctx, typedArgs[0])
 					return
 				},
 			},
-			"postReactionNonblock": {
+			"upgradeKBFSConversationToImpteam": {
 				MakeArg: func() interface{} {
-					var ret [1]PostReactionNonblockArg
+					var ret [1]UpgradeKBFSConversationToImpteamArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]PostReactionNonblockArg)
+					typedArgs, ok := args.(*[1]UpgradeKBFSConversationToImpteamArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]PostReactionNonblockArg)(nil), args)
+						err = rpc.NewTypeError((*[1]UpgradeKBFSConversationToImpteamArg)(nil), args)
+						return
+					}
+					err = i.UpgradeKBFSConversationToImpteam(ctx, typedArgs[0].ConvID)
+					return
+				},
+			},
+			"searchRegexp": {
+				MakeArg: func() interface{} {
+					var ret [1]SearchRegexpArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SearchRegexpArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SearchRegexpArg)(nil), args)
+						return
+					}
+					ret, err = i.SearchRegexp(ctx, typedArgs[0])
+					return
+				},
+			},
+			"cancelActiveInboxSearch": {
+				MakeArg: func() interface{} {
+					var ret [1]CancelActiveInboxSearchArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					err = i.CancelActiveInboxSearch(ctx)
+					return
+				},
+			},
+			"searchInbox": {
+				MakeArg: func() interface{} {
+					var ret [1]SearchInboxArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SearchInboxArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SearchInboxArg)(nil), args)
 						return
 					}
-					ret, err = i.PostReactionNonblock(ctx, typedArgs[0])
+					ret, err = i.SearchInbox(ctx, typedArgs[0])
 					return
 				},
 			},
-			"postHeadlineNonblock": {
+			"simpleSearchInboxConvNames": {
 				MakeArg: func() interface{} {
-					var ret [1]PostHeadlineNonblockArg
+					var ret [1]SimpleSearchInboxConvNamesArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]PostHeadlineNonblockArg)
+					typedArgs, ok := args.(*[1]SimpleSearchInboxConvNamesArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]PostHeadlineNonblockArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleSearchInboxConvNamesArg)(nil), args)
 						return
 					}
-					ret, err = i.PostHeadlineNonblock(ctx, typedArgs[0])
+					ret, err = i.SimpleSearchInboxConvNames(ctx, typedArgs[0].Query)
 					return
 				},
 			},
-			"postHeadline": {
+			"cancelActiveSearch": {
 				MakeArg: func() interface{} {
-					var ret [1]PostHeadlineArg
+					var ret [1]CancelActiveSearchArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]PostHeadlineArg)
-					if !ok {
-						err = rpc.NewTypeError((*[1]PostHeadlineArg)(nil), args)
-						return
-					}
-					ret, err = i.PostHeadline(ctx, typedArgs[0])
+					err = i.CancelActiveSearch(ctx)
 					return
 				},
 			},
-			"postMetadataNonblock": {
+			"profileChatSearch": {
 				MakeArg: func() interface{} {
-					var ret [1]PostMetadataNonblockArg
+					var ret [1]ProfileChatSearchArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]PostMetadataNonblockArg)
+					typedArgs, ok := args.(*[1]ProfileChatSearchArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]PostMetadataNonblockArg)(nil), args)
+						err = rpc.NewTypeError((*[1]ProfileChatSearchArg)(nil), args)
 						return
 					}
-					ret, err = i.PostMetadataNonblock(ctx, typedArgs[0])
+					ret, err = i.ProfileChatSearch(ctx, typedArgs[0].IdentifyBehavior)
 					return
 				},
 			},
-			"postMetadata": {
+			"getStaticConfig": {
 				MakeArg: func() interface{} {
-					var ret [1]PostMetadataArg
+					var ret [1]GetStaticConfigArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]PostMetadataArg)
-					if !ok {
-						err = rpc.NewTypeError((*[1]PostMetadataArg)(nil), args)
-						return
-					}
-					ret, err = i.PostMetadata(ctx, typedArgs[0])
+					ret, err = i.GetStaticConfig(ctx)
 					return
 				},
 			},
-			"postDeleteHistoryUpto": {
+			"resolveUnfurlPrompt": {
 				MakeArg: func() interface{} {
-					var ret [1]PostDeleteHistoryUptoArg
+					var ret [1]ResolveUnfurlPromptArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]PostDeleteHistoryUptoArg)
+					typedArgs, ok := args.(*[1]ResolveUnfurlPromptArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]PostDeleteHistoryUptoArg)(nil), args)
+						err = rpc.NewTypeError((*[1]ResolveUnfurlPromptArg)(nil), args)
 						return
 					}
-					ret, err = i.PostDeleteHistoryUpto(ctx, typedArgs[0])
+					err = i.ResolveUnfurlPrompt(ctx, typedArgs[0])
 					return
 				},
 			},
-			"postDeleteHistoryThrough": {
+			"getUnfurlSettings": {
 				MakeArg: func() interface{} {
-					var ret [1]PostDeleteHistoryThroughArg
+					var ret [1]GetUnfurlSettingsArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]PostDeleteHistoryThroughArg)
-					if !ok {
-						err = rpc.NewTypeError((*[1]PostDeleteHistoryThroughArg)(nil), args)
-						return
-					}
-					ret, err = i.PostDeleteHistoryThrough(ctx, typedArgs[0])
+					ret, err = i.GetUnfurlSettings(ctx)
 					return
 				},
 			},
-			"postDeleteHistoryByAge": {
+			"saveUnfurlSettings": {
 				MakeArg: func() interface{} {
-					var ret [1]PostDeleteHistoryByAgeArg
+					var ret [1]SaveUnfurlSettingsArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]PostDeleteHistoryByAgeArg)
+					typedArgs, ok := args.(*[1]SaveUnfurlSettingsArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]PostDeleteHistoryByAgeArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SaveUnfurlSettingsArg)(nil), args)
 						return
 					}
-					ret, err = i.PostDeleteHistoryByAge(ctx, typedArgs[0])
+					err = i.SaveUnfurlSettings(ctx, typedArgs[0])
 					return
 				},
 			},
-			"SetConversationStatusLocal": {
+			"toggleMessageCollapse": {
 				MakeArg: func() interface{} {
-					var ret [1]SetConversationStatusLocalArg
+					var ret [1]ToggleMessageCollapseArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SetConversationStatusLocalArg)
+					typedArgs, ok := args.(*[1]ToggleMessageCollapseArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SetConversationStatusLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]ToggleMessageCollapseArg)(nil), args)
 						return
 					}
-					ret, err = i.SetConversationStatusLocal(ctx, typedArgs[0])
+					err = i.ToggleMessageCollapse(ctx, typedArgs[0])
 					return
 				},
 			},
-			"newConversationsLocal": {
+			"bulkAddToConv": {
 				MakeArg: func() interface{} {
-					var ret [1]NewConversationsLocalArg
+					var ret [1]BulkAddToConvArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]NewConversationsLocalArg)
+					typedArgs, ok := args.(*[1]BulkAddToConvArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]NewConversationsLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]BulkAddToConvArg)(nil), args)
 						return
 					}
-					ret, err = i.NewConversationsLocal(ctx, typedArgs[0])
+					err = i.BulkAddToConv(ctx, typedArgs[0])
 					return
 				},
 			},
-			"newConversationLocal": {
+			"bulkAddToManyConvs": {
 				MakeArg: func() interface{} {
-					var ret [1]NewConversationLocalArg
+					var ret [1]BulkAddToManyConvsArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]NewConversationLocalArg)
+					typedArgs, ok := args.(*[1]BulkAddToManyConvsArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]NewConversationLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]BulkAddToManyConvsArg)(nil), args)
 						return
 					}
-					ret, err = i.NewConversationLocal(ctx, typedArgs[0])
+					err = i.BulkAddToManyConvs(ctx, typedArgs[0])
 					return
 				},
 			},
-			"getInboxSummaryForCLILocal": {
+			"putReacjiSkinTone": {
 				MakeArg: func() interface{} {
-					var ret [1]GetInboxSummaryForCLILocalArg
+					var ret [1]PutReacjiSkinToneArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]GetInboxSummaryForCLILocalArg)
+					typedArgs, ok := args.(*[1]PutReacjiSkinToneArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]GetInboxSummaryForCLILocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]PutReacjiSkinToneArg)(nil), args)
 						return
 					}
-					ret, err = i.GetInboxSummaryForCLILocal(ctx, typedArgs[0].Query)
+					ret, err = i.PutReacjiSkinTone(ctx, typedArgs[0].SkinTone)
 					return
 				},
 			},
-			"getConversationForCLILocal": {
+			"resolveMaybeMention": {
 				MakeArg: func() interface{} {
-					var ret [1]GetConversationForCLILocalArg
+					var ret [1]ResolveMaybeMentionArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]GetConversationForCLILocalArg)
+					typedArgs, ok := args.(*[1]ResolveMaybeMentionArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]GetConversationForCLILocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]ResolveMaybeMentionArg)(nil), args)
 						return
 					}
-					ret, err = i.GetConversationForCLILocal(ctx, typedArgs[0].Query)
+					err = i.ResolveMaybeMention(ctx, typedArgs[0].Mention)
 					return
 				},
 			},
-			"GetMessagesLocal": {
+			"loadGallery": {
 				MakeArg: func() interface{} {
-					var ret [1]GetMessagesLocalArg
+					var ret [1]LoadGalleryArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]GetMessagesLocalArg)
+					typedArgs, ok := args.(*[1]LoadGalleryArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]GetMessagesLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]LoadGalleryArg)(nil), args)
 						return
 					}
-					ret, err = i.GetMessagesLocal(ctx, typedArgs[0])
+					ret, err = i.LoadGallery(ctx, typedArgs[0])
 					return
 				},
 			},
-			"postFileAttachmentLocal": {
+			"loadFlip": {
 				MakeArg: func() interface{} {
-					var ret [1]PostFileAttachmentLocalArg
+					var ret [1]LoadFlipArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]PostFileAttachmentLocalArg)
+					typedArgs, ok := args.(*[1]LoadFlipArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]PostFileAttachmentLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]LoadFlipArg)(nil), args)
 						return
 					}
-					ret, err = i.PostFileAttachmentLocal(ctx, typedArgs[0])
+					ret, err = i.LoadFlip(ctx, typedArgs[0])
 					return
 				},
 			},
-			"postFileAttachmentLocalNonblock": {
+			"locationUpdate": {
 				MakeArg: func() interface{} {
-					var ret [1]PostFileAttachmentLocalNonblockArg
+					var ret [1]LocationUpdateArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]PostFileAttachmentLocalNonblockArg)
+					typedArgs, ok := args.(*[1]LocationUpdateArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]PostFileAttachmentLocalNonblockArg)(nil), args)
+						err = rpc.NewTypeError((*[1]LocationUpdateArg)(nil), args)
 						return
 					}
-					ret, err = i.PostFileAttachmentLocalNonblock(ctx, typedArgs[0])
+					err = i.LocationUpdate(ctx, typedArgs[0].Coord)
 					return
 				},
 			},
-			"getNextAttachmentMessageLocal": {
+			"advertiseBotCommandsLocal": {
 				MakeArg: func() interface{} {
-					var ret [1]GetNextAttachmentMessageLocalArg
+					var ret [1]AdvertiseBotCommandsLocalArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]GetNextAttachmentMessageLocalArg)
+					typedArgs, ok := args.(*[1]AdvertiseBotCommandsLocalArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]GetNextAttachmentMessageLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]AdvertiseBotCommandsLocalArg)(nil), args)
 						return
 					}
-					ret, err = i.GetNextAttachmentMessageLocal(ctx, typedArgs[0])
+					ret, err = i.AdvertiseBotCommandsLocal(ctx, typedArgs[0])
 					return
 				},
 			},
-			"DownloadAttachmentLocal": {
+			"listBotCommandsLocal": {
 				MakeArg: func() interface{} {
-					var ret [1]DownloadAttachmentLocalArg
+					var ret [1]ListBotCommandsLocalArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]DownloadAttachmentLocalArg)
+					typedArgs, ok := args.(*[1]ListBotCommandsLocalArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]DownloadAttachmentLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]ListBotCommandsLocalArg)(nil), args)
 						return
 					}
-					ret, err = i.DownloadAttachmentLocal(ctx, typedArgs[0])
+					ret, err = i.ListBotCommandsLocal(ctx, typedArgs[0].ConvID)
 					return
 				},
 			},
-			"DownloadFileAttachmentLocal": {
+			"listPublicBotCommandsLocal": {
 				MakeArg: func() interface{} {
-					var ret [1]DownloadFileAttachmentLocalArg
+					var ret [1]ListPublicBotCommandsLocalArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]DownloadFileAttachmentLocalArg)
+					typedArgs, ok := args.(*[1]ListPublicBotCommandsLocalArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]DownloadFileAttachmentLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]ListPublicBotCommandsLocalArg)(nil), args)
 						return
 					}
-					ret, err = i.DownloadFileAttachmentLocal(ctx, typedArgs[0])
+					ret, err = i.ListPublicBotCommandsLocal(ctx, typedArgs[0].Username)
 					return
 				},
 			},
-			"ConfigureFileAttachmentDownloadLocal": {
+			"clearBotCommandsLocal": {
 				MakeArg: func() interface{} {
-					var ret [1]ConfigureFileAttachmentDownloadLocalArg
+					var ret [1]ClearBotCommandsLocalArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]ConfigureFileAttachmentDownloadLocalArg)
+					typedArgs, ok := args.(*[1]ClearBotCommandsLocalArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]ConfigureFileAttachmentDownloadLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]ClearBotCommandsLocalArg)(nil), args)
 						return
 					}
-					err = i.ConfigureFileAttachmentDownloadLocal(ctx, typedArgs[0])
+					ret, err = i.ClearBotCommandsLocal(ctx, typedArgs[0].Filter)
 					return
 				},
 			},
-			"makePreview": {
+			"pinMessage": {
 				MakeArg: func() interface{} {
-					var ret [1]MakePreviewArg
+					var ret [1]PinMessageArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]MakePreviewArg)
+					typedArgs, ok := args.(*[1]PinMessageArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]MakePreviewArg)(nil), args)
+						err = rpc.NewTypeError((*[1]PinMessageArg)(nil), args)
 						return
 					}
-					ret, err = i.MakePreview(ctx, typedArgs[0])
+					ret, err = i.PinMessage(ctx, typedArgs[0])
 					return
 				},
 			},
-			"makeAudioPreview": {
+			"unpinMessage": {
 				MakeArg: func() interface{} {
-					var ret [1]MakeAudioPreviewArg
+					var ret [1]UnpinMessageArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]MakeAudioPreviewArg)
+					typedArgs, ok := args.(*[1]UnpinMessageArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]MakeAudioPreviewArg)(nil), args)
+						err = rpc.NewTypeError((*[1]UnpinMessageArg)(nil), args)
 						return
 					}
-					ret, err = i.MakeAudioPreview(ctx, typedArgs[0])
+					ret, err = i.UnpinMessage(ctx, typedArgs[0].ConvID)
 					return
 				},
 			},
-			"getUploadTempFile": {
+			"ignorePinnedMessage": {
 				MakeArg: func() interface{} {
-					var ret [1]GetUploadTempFileArg
+					var ret [1]IgnorePinnedMessageArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]GetUploadTempFileArg)
+					typedArgs, ok := args.(*[1]IgnorePinnedMessageArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]GetUploadTempFileArg)(nil), args)
+						err = rpc.NewTypeError((*[1]IgnorePinnedMessageArg)(nil), args)
 						return
 					}
-					ret, err = i.GetUploadTempFile(ctx, typedArgs[0])
+					err = i.IgnorePinnedMessage(ctx, typedArgs[0].ConvID)
 					return
 				},
 			},
-			"makeUploadTempFile": {
+			"addBotMember": {
 				MakeArg: func() interface{} {
-					var ret [1]MakeUploadTempFileArg
+					var ret [1]AddBotMemberArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]MakeUploadTempFileArg)
+					typedArgs, ok := args.(*[1]AddBotMemberArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]MakeUploadTempFileArg)(nil), args)
+						err = rpc.NewTypeError((*[1]AddBotMemberArg)(nil), args)
 						return
 					}
-					ret, err = i.MakeUploadTempFile(ctx, typedArgs[0])
+					err = i.AddBotMember(ctx, typedArgs[0])
 					return
 				},
 			},
-			"cancelUploadTempFile": {
+			"editBotMember": {
 				MakeArg: func() interface{} {
-					var ret [1]CancelUploadTempFileArg
+					var ret [1]EditBotMemberArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]CancelUploadTempFileArg)
+					typedArgs, ok := args.(*[1]EditBotMemberArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]CancelUploadTempFileArg)(nil), args)
+						err = rpc.NewTypeError((*[1]EditBotMemberArg)(nil), args)
 						return
 					}
-					err = i.CancelUploadTempFile(ctx, typedArgs[0].OutboxID)
+					err = i.EditBotMember(ctx, typedArgs[0])
 					return
 				},
 			},
-			"CancelPost": {
+			"removeBotMember": {
 				MakeArg: func() interface{} {
-					var ret [1]CancelPostArg
+					var ret [1]RemoveBotMemberArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]CancelPostArg)
+					typedArgs, ok := args.(*[1]RemoveBotMemberArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]CancelPostArg)(nil), args)
+						err = rpc.NewTypeError((*[1]RemoveBotMemberArg)(nil), args)
 						return
 					}
-					err = i.CancelPost(ctx, typedArgs[0].OutboxID)
+					err = i.RemoveBotMember(ctx, typedArgs[0])
 					return
 				},
 			},
-			"RetryPost": {
+			"setBotMemberSettings": {
 				MakeArg: func() interface{} {
-					var ret [1]RetryPostArg
+					var ret [1]SetBotMemberSettingsArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]RetryPostArg)
+					typedArgs, ok := args.(*[1]SetBotMemberSettingsArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]RetryPostArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SetBotMemberSettingsArg)(nil), args)
 						return
 					}
-					err = i.RetryPost(ctx, typedArgs[0])
+					err = i.SetBotMemberSettings(ctx, typedArgs[0])
 					return
 				},
 			},
-			"markAsReadLocal": {
+			"getBotMemberSettings": {
 				MakeArg: func() interface{} {
-					var ret [1]MarkAsReadLocalArg
+					var ret [1]GetBotMemberSettingsArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]MarkAsReadLocalArg)
+					typedArgs, ok := args.(*[1]GetBotMemberSettingsArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]MarkAsReadLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]GetBotMemberSettingsArg)(nil), args)
 						return
 					}
-					ret, err = i.MarkAsReadLocal(ctx, typedArgs[0])
+					ret, err = i.GetBotMemberSettings(ctx, typedArgs[0])
 					return
 				},
 			},
-			"markTLFAsReadLocal": {
+			"getTeamRoleInConversation": {
 				MakeArg: func() interface{} {
-					var ret [1]MarkTLFAsReadLocalArg
+					var ret [1]GetTeamRoleInConversationArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]MarkTLFAsReadLocalArg)
+					typedArgs, ok := args.(*[1]GetTeamRoleInConversationArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]MarkTLFAsReadLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]GetTeamRoleInConversationArg)(nil), args)
 						return
 					}
-					ret, err = i.MarkTLFAsReadLocal(ctx, typedArgs[0])
+					ret, err = i.GetTeamRoleInConversation(ctx, typedArgs[0])
 					return
 				},
 			},
-			"findConversationsLocal": {
+			"addBotConvSearch": {
 				MakeArg: func() interface{} {
-					var ret [1]FindConversationsLocalArg
+					var ret [1]AddBotConvSearchArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]FindConversationsLocalArg)
+					typedArgs, ok := args.(*[1]AddBotConvSearchArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]FindConversationsLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]AddBotConvSearchArg)(nil), args)
 						return
 					}
-					ret, err = i.FindConversationsLocal(ctx, typedArgs[0])
+					ret, err = i.AddBotConvSearch(ctx, typedArgs[0].Term)
 					return
 				},
 			},
-			"findGeneralConvFromTeamID": {
+			"forwardMessageConvSearch": {
 				MakeArg: func() interface{} {
-					var ret [1]FindGeneralConvFromTeamIDArg
+					var ret [1]ForwardMessageConvSearchArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]FindGeneralConvFromTeamIDArg)
+					typedArgs, ok := args.(*[1]ForwardMessageConvSearchArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]FindGeneralConvFromTeamIDArg)(nil), args)
+						err = rpc.NewTypeError((*[1]ForwardMessageConvSearchArg)(nil), args)
 						return
 					}
-					ret, err = i.FindGeneralConvFromTeamID(ctx, typedArgs[0].TeamID)
+					ret, err = i.ForwardMessageConvSearch(ctx, typedArgs[0].Term)
 					return
 				},
 			},
-			"updateTyping": {
+			"teamIDFromTLFName": {
 				MakeArg: func() interface{} {
-					var ret [1]UpdateTypingArg
+					var ret [1]TeamIDFromTLFNameArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]UpdateTypingArg)
+					typedArgs, ok := args.(*[1]TeamIDFromTLFNameArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]UpdateTypingArg)(nil), args)
+						err = rpc.NewTypeError((*[1]TeamIDFromTLFNameArg)(nil), args)
 						return
 					}
-					err = i.UpdateTyping(ctx, typedArgs[0])
+					ret, err = i.TeamIDFromTLFName(ctx, typedArgs[0])
 					return
 				},
 			},
-			"updateUnsentText": {
+			"dismissJourneycard": {
 				MakeArg: func() interface{} {
-					var ret [1]UpdateUnsentTextArg
+					var ret [1]DismissJourneycardArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]UpdateUnsentTextArg)
+					typedArgs, ok := args.(*[1]DismissJourneycardArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]UpdateUnsentTextArg)(nil), args)
+						err = rpc.NewTypeError((*[1]DismissJourneycardArg)(nil), args)
 						return
 					}
-					err = i.UpdateUnsentText(ctx, typedArgs[0])
+					err = i.DismissJourneycard(ctx, typedArgs[0])
 					return
 				},
 			},
-			"joinConversationLocal": {
+			"setWelcomeMessage": {
 				MakeArg: func() interface{} {
-					var ret [1]JoinConversationLocalArg
+					var ret [1]SetWelcomeMessageArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]JoinConversationLocalArg)
+					typedArgs, ok := args.(*[1]SetWelcomeMessageArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]JoinConversationLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SetWelcomeMessageArg)(nil), args)
 						return
 					}
-					ret, err = i.JoinConversationLocal(ctx, typedArgs[0])
+					err = i.SetWelcomeMessage(ctx, typedArgs[0])
 					return
 				},
 			},
-			"joinConversationByIDLocal": {
+			"getWelcomeMessage": {
 				MakeArg: func() interface{} {
-					var ret [1]JoinConversationByIDLocalArg
+					var ret [1]GetWelcomeMessageArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]JoinConversationByIDLocalArg)
+					typedArgs, ok := args.(*[1]GetWelcomeMessageArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]JoinConversationByIDLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]GetWelcomeMessageArg)(nil), args)
 						return
 					}
-					ret, err = i.JoinConversationByIDLocal(ctx, typedArgs[0].ConvID)
+					ret, err = i.GetWelcomeMessage(ctx, typedArgs[0].TeamID)
 					return
 				},
 			},
-			"leaveConversationLocal": {
+			"getDefaultTeamChannelsLocal": {
 				MakeArg: func() interface{} {
-					var ret [1]LeaveConversationLocalArg
+					var ret [1]GetDefaultTeamChannelsLocalArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]LeaveConversationLocalArg)
+					typedArgs, ok := args.(*[1]GetDefaultTeamChannelsLocalArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]LeaveConversationLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]GetDefaultTeamChannelsLocalArg)(nil), args)
 						return
 					}
-					ret, err = i.LeaveConversationLocal(ctx, typedArgs[0].ConvID)
+					ret, err = i.GetDefaultTeamChannelsLocal(ctx, typedArgs[0].TeamID)
 					return
 				},
 			},
-			"previewConversationByIDLocal": {
+			"setDefaultTeamChannelsLocal": {
 				MakeArg: func() interface{} {
-					var ret [1]PreviewConversationByIDLocalArg
+					var ret [1]SetDefaultTeamChannelsLocalArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]PreviewConversationByIDLocalArg)
+					typedArgs, ok := args.(*[1]SetDefaultTeamChannelsLocalArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]PreviewConversationByIDLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SetDefaultTeamChannelsLocalArg)(nil), args)
 						return
 					}
-					ret, err = i.PreviewConversationByIDLocal(ctx, typedArgs[0].ConvID)
+					ret, err = i.SetDefaultTeamChannelsLocal(ctx, typedArgs[0])
 					return
 				},
 			},
-			"deleteConversationLocal": {
+			"getLastActiveForTLF": {
 				MakeArg: func() interface{} {
-					var ret [1]DeleteConversationLocalArg
+					var ret [1]GetLastActiveForTLFArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]DeleteConversationLocalArg)
+					typedArgs, ok := args.(*[1]GetLastActiveForTLFArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]DeleteConversationLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]GetLastActiveForTLFArg)(nil), args)
 						return
 					}
-					ret, err = i.DeleteConversationLocal(ctx, typedArgs[0])
+					ret, err = i.GetLastActiveForTLF(ctx, typedArgs[0].TlfID)
 					return
 				},
 			},
-			"removeFromConversationLocal": {
+			"getLastActiveForTeams": {
 				MakeArg: func() interface{} {
-					var ret [1]RemoveFromConversationLocalArg
+					var ret [1]GetLastActiveForTeamsArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]RemoveFromConversationLocalArg)
-					if !ok {
-						err = rpc.NewTypeError((*[1]RemoveFromConversationLocalArg)(nil), args)
-						return
-					}
-					ret, err = i.RemoveFromConversationLocal(ctx, typedArgs[0])
+					ret, err = i.GetLastActiveForTeams(ctx)
 					return
 				},
 			},
-			"getTLFConversationsLocal": {
+			"getRecentJoinsLocal": {
 				MakeArg: func() interface{} {
-					var ret [1]GetTLFConversationsLocalArg
+					var ret [1]GetRecentJoinsLocalArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]GetTLFConversationsLocalArg)
+					typedArgs, ok := args.(*[1]GetRecentJoinsLocalArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]GetTLFConversationsLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]GetRecentJoinsLocalArg)(nil), args)
 						return
 					}
-					ret, err = i.GetTLFConversationsLocal(ctx, typedArgs[0])
+					ret, err = i.GetRecentJoinsLocal(ctx, typedArgs[0].ConvID)
 					return
 				},
 			},
-			"getChannelMembershipsLocal": {
+			"refreshParticipants": {
 				MakeArg: func() interface{} {
-					var ret [1]GetChannelMembershipsLocalArg
+					var ret [1]RefreshParticipantsArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]GetChannelMembershipsLocalArg)
+					typedArgs, ok := args.(*[1]RefreshParticipantsArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]GetChannelMembershipsLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]RefreshParticipantsArg)(nil), args)
 						return
 					}
-					ret, err = i.GetChannelMembershipsLocal(ctx, typedArgs[0])
+					err = i.RefreshParticipants(ctx, typedArgs[0].ConvID)
 					return
 				},
 			},
-			"getMutualTeamsLocal": {
+			"getLastActiveAtLocal": {
 				MakeArg: func() interface{} {
-					var ret [1]GetMutualTeamsLocalArg
+					var ret [1]GetLastActiveAtLocalArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]GetMutualTeamsLocalArg)
+					typedArgs, ok := args.(*[1]GetLastActiveAtLocalArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]GetMutualTeamsLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]GetLastActiveAtLocalArg)(nil), args)
 						return
 					}
-					ret, err = i.GetMutualTeamsLocal(ctx, typedArgs[0].Usernames)
+					ret, err = i.GetLastActiveAtLocal(ctx, typedArgs[0])
 					return
 				},
 			},
-			"setAppNotificationSettingsLocal": {
+			"getLastActiveAtMultiLocal": {
 				MakeArg: func() interface{} {
-					var ret [1]SetAppNotificationSettingsLocalArg
+					var ret [1]GetLastActiveAtMultiLocalArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SetAppNotificationSettingsLocalArg)
+					typedArgs, ok := args.(*[1]GetLastActiveAtMultiLocalArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SetAppNotificationSettingsLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]GetLastActiveAtMultiLocalArg)(nil), args)
 						return
 					}
-					ret, err = i.SetAppNotificationSettingsLocal(ctx, typedArgs[0])
+					ret, err = i.GetLastActiveAtMultiLocal(ctx, typedArgs[0])
 					return
 				},
 			},
-			"setGlobalAppNotificationSettingsLocal": {
+			"getParticipants": {
 				MakeArg: func() interface{} {
-					var ret [1]SetGlobalAppNotificationSettingsLocalArg
+					var ret [1]GetParticipantsArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SetGlobalAppNotificationSettingsLocalArg)
+					typedArgs, ok := args.(*[1]GetParticipantsArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SetGlobalAppNotificationSettingsLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]GetParticipantsArg)(nil), args)
 						return
 					}
-					err = i.SetGlobalAppNotificationSettingsLocal(ctx, typedArgs[0].Settings)
+					ret, err = i.GetParticipants(ctx, typedArgs[0].ConvID)
 					return
 				},
 			},
-			"getGlobalAppNotificationSettingsLocal": {
+			"addEmoji": {
 				MakeArg: func() interface{} {
-					var ret [1]GetGlobalAppNotificationSettingsLocalArg
+					var ret [1]AddEmojiArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					ret, err = i.GetGlobalAppNotificationSettingsLocal(ctx)
+					typedArgs, ok := args.(*[1]AddEmojiArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]AddEmojiArg)(nil), args)
+						return
+					}
+					ret, err = i.AddEmoji(ctx, typedArgs[0])
 					return
 				},
 			},
-			"unboxMobilePushNotification": {
+			"addEmojis": {
 				MakeArg: func() interface{} {
-					var ret [1]UnboxMobilePushNotificationArg
+					var ret [1]AddEmojisArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]UnboxMobilePushNotificationArg)
+					typedArgs, ok := args.(*[1]AddEmojisArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]UnboxMobilePushNotificationArg)(nil), args)
+						err = rpc.NewTypeError((*[1]AddEmojisArg)(nil), args)
 						return
 					}
-					ret, err = i.UnboxMobilePushNotification(ctx, typedArgs[0])
+					ret, err = i.AddEmojis(ctx, typedArgs[0])
 					return
 				},
 			},
-			"addTeamMemberAfterReset": {
+			"addEmojiAlias": {
 				MakeArg: func() interface{} {
-					var ret [1]AddTeamMemberAfterResetArg
+					var ret [1]AddEmojiAliasArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]AddTeamMemberAfterResetArg)
+					typedArgs, ok := args.(*[1]AddEmojiAliasArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]AddTeamMemberAfterResetArg)(nil), args)
+						err = rpc.NewTypeError((*[1]AddEmojiAliasArg)(nil), args)
 						return
 					}
-					err = i.AddTeamMemberAfterReset(ctx, typedArgs[0])
+					ret, err = i.AddEmojiAlias(ctx, typedArgs[0])
 					return
 				},
 			},
-			"getAllResetConvMembers": {
+			"removeEmoji": {
 				MakeArg: func() interface{} {
-					var ret [1]GetAllResetConvMembersArg
+					var ret [1]RemoveEmojiArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					ret, err = i.GetAllResetConvMembers(ctx)
+					typedArgs, ok := args.(*[1]RemoveEmojiArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]RemoveEmojiArg)(nil), args)
+						return
+					}
+					ret, err = i.RemoveEmoji(ctx, typedArgs[0])
 					return
 				},
 			},
-			"setConvRetentionLocal": {
+			"userEmojis": {
 				MakeArg: func() interface{} {
-					var ret [1]SetConvRetentionLocalArg
+					var ret [1]UserEmojisArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SetConvRetentionLocalArg)
+					typedArgs, ok := args.(*[1]UserEmojisArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SetConvRetentionLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]UserEmojisArg)(nil), args)
 						return
 					}
-					err = i.SetConvRetentionLocal(ctx, typedArgs[0])
+					ret, err = i.UserEmojis(ctx, typedArgs[0])
 					return
 				},
 			},
-			"setTeamRetentionLocal": {
+			"toggleEmojiAnimations": {
 				MakeArg: func() interface{} {
-					var ret [1]SetTeamRetentionLocalArg
+					var ret [1]ToggleEmojiAnimationsArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SetTeamRetentionLocalArg)
+					typedArgs, ok := args.(*[1]ToggleEmojiAnimationsArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SetTeamRetentionLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]ToggleEmojiAnimationsArg)(nil), args)
 						return
 					}
-					err = i.SetTeamRetentionLocal(ctx, typedArgs[0])
+					err = i.ToggleEmojiAnimations(ctx, typedArgs[0].Enabled)
 					return
 				},
 			},
-			"getTeamRetentionLocal": {
+			"trackGiphySelect": {
 				MakeArg: func() interface{} {
-					var ret [1]GetTeamRetentionLocalArg
+					var ret [1]TrackGiphySelectArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]GetTeamRetentionLocalArg)
+					typedArgs, ok := args.(*[1]TrackGiphySelectArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]GetTeamRetentionLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]TrackGiphySelectArg)(nil), args)
 						return
 					}
-					ret, err = i.GetTeamRetentionLocal(ctx, typedArgs[0].TeamID)
+					ret, err = i.TrackGiphySelect(ctx, typedArgs[0])
 					return
 				},
 			},
-			"setConvMinWriterRoleLocal": {
+			"archiveChat": {
 				MakeArg: func() interface{} {
-					var ret [1]SetConvMinWriterRoleLocalArg
+					var ret [1]ArchiveChatArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SetConvMinWriterRoleLocalArg)
+					typedArgs, ok := args.(*[1]ArchiveChatArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SetConvMinWriterRoleLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]ArchiveChatArg)(nil), args)
 						return
 					}
-					err = i.SetConvMinWriterRoleLocal(ctx, typedArgs[0])
+					ret, err = i.ArchiveChat(ctx, typedArgs[0].Req)
 					return
 				},
 			},
-			"upgradeKBFSConversationToImpteam": {
+			"archiveChatList": {
 				MakeArg: func() interface{} {
-					var ret [1]UpgradeKBFSConversationToImpteamArg
+					var ret [1]ArchiveChatListArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]UpgradeKBFSConversationToImpteamArg)
+					typedArgs, ok := args.(*[1]ArchiveChatListArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]UpgradeKBFSConversationToImpteamArg)(nil), args)
+						err = rpc.NewTypeError((*[1]ArchiveChatListArg)(nil), args)
 						return
 					}
-					err = i.UpgradeKBFSConversationToImpteam(ctx, typedArgs[0].ConvID)
+					ret, err = i.ArchiveChatList(ctx, typedArgs[0].IdentifyBehavior)
 					return
 				},
 			},
-			"searchRegexp": {
+			"archiveChatDelete": {
 				MakeArg: func() interface{} {
-					var ret [1]SearchRegexpArg
+					var ret [1]ArchiveChatDeleteArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SearchRegexpArg)
+					typedArgs, ok := args.(*[1]ArchiveChatDeleteArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SearchRegexpArg)(nil), args)
+						err = rpc.NewTypeError((*[1]ArchiveChatDeleteArg)(nil), args)
 						return
 					}
-					ret, err = i.SearchRegexp(ctx, typedArgs[0])
+					err = i.ArchiveChatDelete(ctx, typedArgs[0])
 					return
 				},
 			},
-			"cancelActiveInboxSearch": {
+			"archiveChatPause": {
 				MakeArg: func() interface{} {
-					var ret [1]CancelActiveInboxSearchArg
+					var ret [1]ArchiveChatPauseArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					err = i.CancelActiveInboxSearch(ctx)
+					typedArgs, ok := args.(*[1]ArchiveChatPauseArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]ArchiveChatPauseArg)(nil), args)
+						return
+					}
+					err = i.ArchiveChatPause(ctx, typedArgs[0])
 					return
 				},
 			},
-			"searchInbox": {
+			"archiveChatResume": {
 				MakeArg: func() interface{} {
-					var ret [1]SearchInboxArg
+					var ret [1]ArchiveChatResumeArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SearchInboxArg)
+					typedArgs, ok := args.(*[1]ArchiveChatResumeArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SearchInboxArg)(nil), args)
+						err = rpc.NewTypeError((*[1]ArchiveChatResumeArg)(nil), args)
 						return
 					}
-					ret, err = i.SearchInbox(ctx, typedArgs[0])
+					err = i.ArchiveChatResume(ctx, typedArgs[0])
 					return
 				},
 			},
-			"simpleSearchInboxConvNames": {
+			"archiveChatSetSchedule": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleSearchInboxConvNamesArg
+					var ret [1]ArchiveChatSetScheduleArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleSearchInboxConvNamesArg)
+					typedArgs, ok := args.(*[1]ArchiveChatSetScheduleArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleSearchInboxConvNamesArg)(nil), args)
+						err = rpc.NewTypeError((*[1]ArchiveChatSetScheduleArg)(nil), args)
 						return
 					}
-					ret, err = i.SimpleSearchInboxConvNames(ctx, typedArgs[0].Query)
+					err = i.ArchiveChatSetSchedule(ctx, typedArgs[0])
 					return
 				},
 			},
-			"cancelActiveSearch": {
+			"archiveChatExportHistory": {
 				MakeArg: func() interface{} {
-					var ret [1]CancelActiveSearchArg
+					var ret [1]ArchiveChatExportHistoryArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					err = i.CancelActiveSearch(ctx)
+					typedArgs, ok := args.(*[1]ArchiveChatExportHistoryArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]ArchiveChatExportHistoryArg)(nil), args)
+						return
+					}
+					err = i.ArchiveChatExportHistory(ctx, typedArgs[0])
 					return
 				},
 			},
-			"profileChatSearch": {
+			"archiveChatImportHistory": {
 				MakeArg: func() interface{} {
-					var ret [1]ProfileChatSearchArg
+					var ret [1]ArchiveChatImportHistoryArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]ProfileChatSearchArg)
+					typedArgs, ok := args.(*[1]ArchiveChatImportHistoryArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]ProfileChatSearchArg)(nil), args)
+						err = rpc.NewTypeError((*[1]ArchiveChatImportHistoryArg)(nil), args)
 						return
 					}
-					ret, err = i.ProfileChatSearch(ctx, typedArgs[0].IdentifyBehavior)
+					ret, err = i.ArchiveChatImportHistory(ctx, typedArgs[0])
 					return
 				},
 			},
-			"getStaticConfig": {
+			"archiveChatSearch": {
 				MakeArg: func() interface{} {
-					var ret [1]GetStaticConfigArg
+					var ret [1]ArchiveChatSearchArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					ret, err = i.GetStaticConfig(ctx)
+					typedArgs, ok := args.(*[1]ArchiveChatSearchArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]ArchiveChatSearchArg)(nil), args)
+						return
+					}
+					ret, err = i.ArchiveChatSearch(ctx, typedArgs[0])
 					return
 				},
 			},
-			"resolveUnfurlPrompt": {
+			"archiveChatVerify": {
 				MakeArg: func() interface{} {
-					var ret [1]ResolveUnfurlPromptArg
+					var ret [1]ArchiveChatVerifyArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]ResolveUnfurlPromptArg)
+					typedArgs, ok := args.(*[1]ArchiveChatVerifyArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]ResolveUnfurlPromptArg)(nil), args)
+						err = rpc.NewTypeError((*[1]ArchiveChatVerifyArg)(nil), args)
 						return
 					}
-					err = i.ResolveUnfurlPrompt(ctx, typedArgs[0])
+					ret, err = i.ArchiveChatVerify(ctx, typedArgs[0])
 					return
 				},
 			},
-			"getUnfurlSettings": {
+			"archiveChatImport": {
 				MakeArg: func() interface{} {
-					var ret [1]GetUnfurlSettingsArg
+					var ret [1]ArchiveChatImportArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					ret, err = i.GetUnfurlSettings(ctx)
+					typedArgs, ok := args.(*[1]ArchiveChatImportArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]ArchiveChatImportArg)(nil), args)
+						return
+					}
+					ret, err = i.ArchiveChatImport(ctx, typedArgs[0])
 					return
 				},
 			},
-			"saveUnfurlSettings": {
+			"archiveChatListImported": {
 				MakeArg: func() interface{} {
-					var ret [1]SaveUnfurlSettingsArg
+					var ret [1]ArchiveChatListImportedArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SaveUnfurlSettingsArg)
+					typedArgs, ok := args.(*[1]ArchiveChatListImportedArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SaveUnfurlSettingsArg)(nil), args)
+						err = rpc.NewTypeError((*[1]ArchiveChatListImportedArg)(nil), args)
 						return
 					}
-					err = i.SaveUnfurlSettings(ctx, typedArgs[0])
+					ret, err = i.ArchiveChatListImported(ctx, typedArgs[0].IdentifyBehavior)
 					return
 				},
 			},
-			"toggleMessageCollapse": {
+			"archiveChatGetImportedThread": {
 				MakeArg: func() interface{} {
-					var ret [1]ToggleMessageCollapseArg
+					var ret [1]ArchiveChatGetImportedThreadArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]ToggleMessageCollapseArg)
+					typedArgs, ok := args.(*[1]ArchiveChatGetImportedThreadArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]ToggleMessageCollapseArg)(nil), args)
+						err = rpc.NewTypeError((*[1]ArchiveChatGetImportedThreadArg)(nil), args)
 						return
 					}
-					err = i.ToggleMessageCollapse(ctx, typedArgs[0])
+					ret, err = i.ArchiveChatGetImportedThread(ctx, typedArgs[0])
 					return
 				},
 			},
-			"bulkAddToConv": {
+			"archiveChatDeleteImported": {
 				MakeArg: func() interface{} {
-					var ret [1]BulkAddToConvArg
+					var ret [1]ArchiveChatDeleteImportedArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]BulkAddToConvArg)
+					typedArgs, ok := args.(*[1]ArchiveChatDeleteImportedArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]BulkAddToConvArg)(nil), args)
+						err = rpc.NewTypeError((*[1]ArchiveChatDeleteImportedArg)(nil), args)
 						return
 					}
-					err = i.BulkAddToConv(ctx, typedArgs[0])
+					err = i.ArchiveChatDeleteImported(ctx, typedArgs[0])
 					return
 				},
 			},
-			"bulkAddToManyConvs": {
+			"searchChatHistory": {
 				MakeArg: func() interface{} {
-					var ret [1]BulkAddToManyConvsArg
+					var ret [1]SearchChatHistoryArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]BulkAddToManyConvsArg)
+					typedArgs, ok := args.(*[1]SearchChatHistoryArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]BulkAddToManyConvsArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SearchChatHistoryArg)(nil), args)
 						return
 					}
-					err = i.BulkAddToManyConvs(ctx, typedArgs[0])
+					ret, err = i.SearchChatHistory(ctx, typedArgs[0])
 					return
 				},
 			},
-			"putReacjiSkinTone": {
+			"getConversationContacts": {
 				MakeArg: func() interface{} {
-					var ret [1]PutReacjiSkinToneArg
+					var ret [1]GetConversationContactsArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]PutReacjiSkinToneArg)
+					typedArgs, ok := args.(*[1]GetConversationContactsArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]PutReacjiSkinToneArg)(nil), args)
+						err = rpc.NewTypeError((*[1]GetConversationContactsArg)(nil), args)
 						return
 					}
-					ret, err = i.PutReacjiSkinTone(ctx, typedArgs[0].SkinTone)
+					ret, err = i.GetConversationContacts(ctx, typedArgs[0])
 					return
 				},
 			},
-			"resolveMaybeMention": {
+			"splitConversationLocal": {
 				MakeArg: func() interface{} {
-					var ret [1]ResolveMaybeMentionArg
+					var ret [1]SplitConversationLocalArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]ResolveMaybeMentionArg)
+					typedArgs, ok := args.(*[1]SplitConversationLocalArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]ResolveMaybeMentionArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SplitConversationLocalArg)(nil), args)
 						return
 					}
-					err = i.ResolveMaybeMention(ctx, typedArgs[0].Mention)
+					ret, err = i.SplitConversationLocal(ctx, typedArgs[0])
 					return
 				},
 			},
-			"loadGallery": {
+			"getArchiveRedactionRules": {
 				MakeArg: func() interface{} {
-					var ret [1]LoadGalleryArg
+					var ret [1]GetArchiveRedactionRulesArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]LoadGalleryArg)
+					typedArgs, ok := args.(*[1]GetArchiveRedactionRulesArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]LoadGalleryArg)(nil), args)
+						err = rpc.NewTypeError((*[1]GetArchiveRedactionRulesArg)(nil), args)
 						return
 					}
-					ret, err = i.LoadGallery(ctx, typedArgs[0])
+					ret, err = i.GetArchiveRedactionRules(ctx, typedArgs[0].TeamID)
 					return
 				},
 			},
-			"loadFlip": {
+			"setArchiveRedactionRules": {
 				MakeArg: func() interface{} {
-					var ret [1]LoadFlipArg
+					var ret [1]SetArchiveRedactionRulesArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]LoadFlipArg)
+					typedArgs, ok := args.(*[1]SetArchiveRedactionRulesArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]LoadFlipArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SetArchiveRedactionRulesArg)(nil), args)
 						return
 					}
-					ret, err = i.LoadFlip(ctx, typedArgs[0])
+					err = i.SetArchiveRedactionRules(ctx, typedArgs[0])
 					return
 				},
 			},
-			"locationUpdate": {
+			"getNewMemberRestrictions": {
 				MakeArg: func() interface{} {
-					var ret [1]LocationUpdateArg
+					var ret [1]GetNewMemberRestrictionsArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]LocationUpdateArg)
+					typedArgs, ok := args.(*[1]GetNewMemberRestrictionsArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]LocationUpdateArg)(nil), args)
+						err = rpc.NewTypeError((*[1]GetNewMemberRestrictionsArg)(nil), args)
 						return
 					}
-					err = i.LocationUpdate(ctx, typedArgs[0].Coord)
+					ret, err = i.GetNewMemberRestrictions(ctx, typedArgs[0].TeamID)
 					return
 				},
 			},
-			"advertiseBotCommandsLocal": {
+			"setNewMemberRestrictions": {
 				MakeArg: func() interface{} {
-					var ret [1]AdvertiseBotCommandsLocalArg
+					var ret [1]SetNewMemberRestrictionsArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]AdvertiseBotCommandsLocalArg)
+					typedArgs, ok := args.(*[1]SetNewMemberRestrictionsArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]AdvertiseBotCommandsLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SetNewMemberRestrictionsArg)(nil), args)
 						return
 					}
-					ret, err = i.AdvertiseBotCommandsLocal(ctx, typedArgs[0])
+					err = i.SetNewMemberRestrictions(ctx, typedArgs[0])
 					return
 				},
 			},
-			"listBotCommandsLocal": {
+			"setNewMemberRestrictionsExempt": {
 				MakeArg: func() interface{} {
-					var ret [1]ListBotCommandsLocalArg
+					var ret [1]SetNewMemberRestrictionsExemptArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]ListBotCommandsLocalArg)
+					typedArgs, ok := args.(*[1]SetNewMemberRestrictionsExemptArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]ListBotCommandsLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SetNewMemberRestrictionsExemptArg)(nil), args)
 						return
 					}
-					ret, err = i.ListBotCommandsLocal(ctx, typedArgs[0].ConvID)
+					err = i.SetNewMemberRestrictionsExempt(ctx, typedArgs[0])
 					return
 				},
 			},
-			"listPublicBotCommandsLocal": {
+			"getTrustedDevicePolicy": {
 				MakeArg: func() interface{} {
-					var ret [1]ListPublicBotCommandsLocalArg
+					var ret [1]GetTrustedDevicePolicyArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]ListPublicBotCommandsLocalArg)
+					typedArgs, ok := args.(*[1]GetTrustedDevicePolicyArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]ListPublicBotCommandsLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]GetTrustedDevicePolicyArg)(nil), args)
 						return
 					}
-					ret, err = i.ListPublicBotCommandsLocal(ctx, typedArgs[0].Username)
+					ret, err = i.GetTrustedDevicePolicy(ctx, typedArgs[0].ConvID)
 					return
 				},
 			},
-			"clearBotCommandsLocal": {
+			"setTrustedDevicePolicy": {
 				MakeArg: func() interface{} {
-					var ret [1]ClearBotCommandsLocalArg
+					var ret [1]SetTrustedDevicePolicyArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]ClearBotCommandsLocalArg)
+					typedArgs, ok := args.(*[1]SetTrustedDevicePolicyArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]ClearBotCommandsLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SetTrustedDevicePolicyArg)(nil), args)
 						return
 					}
-					ret, err = i.ClearBotCommandsLocal(ctx, typedArgs[0].Filter)
+					err = i.SetTrustedDevicePolicy(ctx, typedArgs[0])
 					return
 				},
 			},
-			"pinMessage": {
+			"setTrustedDeviceApproved": {
 				MakeArg: func() interface{} {
-					var ret [1]PinMessageArg
+					var ret [1]SetTrustedDeviceApprovedArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]PinMessageArg)
+					typedArgs, ok := args.(*[1]SetTrustedDeviceApprovedArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]PinMessageArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SetTrustedDeviceApprovedArg)(nil), args)
 						return
 					}
-					ret, err = i.PinMessage(ctx, typedArgs[0])
+					err = i.SetTrustedDeviceApproved(ctx, typedArgs[0])
 					return
 				},
 			},
-			"unpinMessage": {
+			"bulkDeleteMessagesLocal": {
 				MakeArg: func() interface{} {
-					var ret [1]UnpinMessageArg
+					var ret [1]BulkDeleteMessagesLocalArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]UnpinMessageArg)
+					typedArgs, ok := args.(*[1]BulkDeleteMessagesLocalArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]UnpinMessageArg)(nil), args)
+						err = rpc.NewTypeError((*[1]BulkDeleteMessagesLocalArg)(nil), args)
 						return
 					}
-					ret, err = i.UnpinMessage(ctx, typedArgs[0].ConvID)
+					ret, err = i.BulkDeleteMessagesLocal(ctx, typedArgs[0])
 					return
 				},
 			},
-			"ignorePinnedMessage": {
+			"getModerationAuditLog": {
 				MakeArg: func() interface{} {
-					var ret [1]IgnorePinnedMessageArg
+					var ret [1]GetModerationAuditLogArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]IgnorePinnedMessageArg)
+					typedArgs, ok := args.(*[1]GetModerationAuditLogArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]IgnorePinnedMessageArg)(nil), args)
+						err = rpc.NewTypeError((*[1]GetModerationAuditLogArg)(nil), args)
 						return
 					}
-					err = i.IgnorePinnedMessage(ctx, typedArgs[0].ConvID)
+					ret, err = i.GetModerationAuditLog(ctx, typedArgs[0].TeamID)
 					return
 				},
 			},
-			"addBotMember": {
+			"getNotificationRoutingPreferences": {
 				MakeArg: func() interface{} {
-					var ret [1]AddBotMemberArg
+					var ret [1]GetNotificationRoutingPreferencesArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]AddBotMemberArg)
-					if !ok {
-						err = rpc.NewTypeError((*[1]AddBotMemberArg)(nil), args)
-						return
-					}
-					err = i.AddBotMember(ctx, typedArgs[0])
+					ret, err = i.GetNotificationRoutingPreferences(ctx)
 					return
 				},
 			},
-			"editBotMember": {
+			"setNotificationRoutingPreferences": {
 				MakeArg: func() interface{} {
-					var ret [1]EditBotMemberArg
+					var ret [1]SetNotificationRoutingPreferencesArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]EditBotMemberArg)
+					typedArgs, ok := args.(*[1]SetNotificationRoutingPreferencesArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]EditBotMemberArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SetNotificationRoutingPreferencesArg)(nil), args)
 						return
 					}
-					err = i.EditBotMember(ctx, typedArgs[0])
+					err = i.SetNotificationRoutingPreferences(ctx, typedArgs[0].Prefs)
 					return
 				},
 			},
-			"removeBotMember": {
+			"getMentionDigestSettings": {
 				MakeArg: func() interface{} {
-					var ret [1]RemoveBotMemberArg
+					var ret [1]GetMentionDigestSettingsArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]RemoveBotMemberArg)
-					if !ok {
-						err = rpc.NewTypeError((*[1]RemoveBotMemberArg)(nil), args)
-						return
-					}
-					err = i.RemoveBotMember(ctx, typedArgs[0])
+					ret, err = i.GetMentionDigestSettings(ctx)
 					return
 				},
 			},
-			"setBotMemberSettings": {
+			"setMentionDigestSettings": {
 				MakeArg: func() interface{} {
-					var ret [1]SetBotMemberSettingsArg
+					var ret [1]SetMentionDigestSettingsArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SetBotMemberSettingsArg)
+					typedArgs, ok := args.(*[1]SetMentionDigestSettingsArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SetBotMemberSettingsArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SetMentionDigestSettingsArg)(nil), args)
 						return
 					}
-					err = i.SetBotMemberSettings(ctx, typedArgs[0])
+					err = i.SetMentionDigestSettings(ctx, typedArgs[0].Settings)
 					return
 				},
 			},
-			"getBotMemberSettings": {
+			"getMentionDigest": {
 				MakeArg: func() interface{} {
-					var ret [1]GetBotMemberSettingsArg
+					var ret [1]GetMentionDigestArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]GetBotMemberSettingsArg)
-					if !ok {
-						err = rpc.NewTypeError((*[1]GetBotMemberSettingsArg)(nil), args)
-						return
-					}
-					ret, err = i.GetBotMemberSettings(ctx, typedArgs[0])
+					ret, err = i.GetMentionDigest(ctx)
 					return
 				},
 			},
-			"getTeamRoleInConversation": {
+			"getIncidentMode": {
 				MakeArg: func() interface{} {
-					var ret [1]GetTeamRoleInConversationArg
+					var ret [1]GetIncidentModeArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]GetTeamRoleInConversationArg)
+					typedArgs, ok := args.(*[1]GetIncidentModeArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]GetTeamRoleInConversationArg)(nil), args)
+						err = rpc.NewTypeError((*[1]GetIncidentModeArg)(nil), args)
 						return
 					}
-					ret, err = i.GetTeamRoleInConversation(ctx, typedArgs[0])
+					ret, err = i.GetIncidentMode(ctx, typedArgs[0].TeamID)
 					return
 				},
 			},
-			"addBotConvSearch": {
+			"setIncidentMode": {
 				MakeArg: func() interface{} {
-					var ret [1]AddBotConvSearchArg
+					var ret [1]SetIncidentModeArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]AddBotConvSearchArg)
+					typedArgs, ok := args.(*[1]SetIncidentModeArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]AddBotConvSearchArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SetIncidentModeArg)(nil), args)
 						return
 					}
-					ret, err = i.AddBotConvSearch(ctx, typedArgs[0].Term)
+					err = i.SetIncidentMode(ctx, typedArgs[0])
 					return
 				},
 			},
-			"forwardMessageConvSearch": {
+			"getIncidentModeAuditLog": {
 				MakeArg: func() interface{} {
-					var ret [1]ForwardMessageConvSearchArg
+					var ret [1]GetIncidentModeAuditLogArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]ForwardMessageConvSearchArg)
+					typedArgs, ok := args.(*[1]GetIncidentModeAuditLogArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]ForwardMessageConvSearchArg)(nil), args)
+						err = rpc.NewTypeError((*[1]GetIncidentModeAuditLogArg)(nil), args)
 						return
 					}
-					ret, err = i.ForwardMessageConvSearch(ctx, typedArgs[0].Term)
+					ret, err = i.GetIncidentModeAuditLog(ctx, typedArgs[0].TeamID)
 					return
 				},
 			},
-			"teamIDFromTLFName": {
+			"getChannelBanner": {
 				MakeArg: func() interface{} {
-					var ret [1]TeamIDFromTLFNameArg
+					var ret [1]GetChannelBannerArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]TeamIDFromTLFNameArg)
+					typedArgs, ok := args.(*[1]GetChannelBannerArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]TeamIDFromTLFNameArg)(nil), args)
+						err = rpc.NewTypeError((*[1]GetChannelBannerArg)(nil), args)
 						return
 					}
-					ret, err = i.TeamIDFromTLFName(ctx, typedArgs[0])
+					ret, err = i.GetChannelBanner(ctx, typedArgs[0].ConvID)
 					return
 				},
 			},
-			"dismissJourneycard": {
+			"setChannelBanner": {
 				MakeArg: func() interface{} {
-					var ret [1]DismissJourneycardArg
+					var ret [1]SetChannelBannerArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]DismissJourneycardArg)
+					typedArgs, ok := args.(*[1]SetChannelBannerArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]DismissJourneycardArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SetChannelBannerArg)(nil), args)
 						return
 					}
-					err = i.DismissJourneycard(ctx, typedArgs[0])
+					err = i.SetChannelBanner(ctx, typedArgs[0])
 					return
 				},
 			},
-			"setWelcomeMessage": {
+			"getChannelBannerAuditLog": {
 				MakeArg: func() interface{} {
-					var ret [1]SetWelcomeMessageArg
+					var ret [1]GetChannelBannerAuditLogArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SetWelcomeMessageArg)
+					typedArgs, ok := args.(*[1]GetChannelBannerAuditLogArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SetWelcomeMessageArg)(nil), args)
+						err = rpc.NewTypeError((*[1]GetChannelBannerAuditLogArg)(nil), args)
 						return
 					}
-					err = i.SetWelcomeMessage(ctx, typedArgs[0])
+					ret, err = i.GetChannelBannerAuditLog(ctx, typedArgs[0].TeamID)
 					return
 				},
 			},
-			"getWelcomeMessage": {
+			"getMembershipHook": {
 				MakeArg: func() interface{} {
-					var ret [1]GetWelcomeMessageArg
+					var ret [1]GetMembershipHookArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]GetWelcomeMessageArg)
+					typedArgs, ok := args.(*[1]GetMembershipHookArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]GetWelcomeMessageArg)(nil), args)
+						err = rpc.NewTypeError((*[1]GetMembershipHookArg)(nil), args)
 						return
 					}
-					ret, err = i.GetWelcomeMessage(ctx, typedArgs[0].TeamID)
+					ret, err = i.GetMembershipHook(ctx, typedArgs[0].TeamID)
 					return
 				},
 			},
-			"getDefaultTeamChannelsLocal": {
+			"setMembershipHook": {
 				MakeArg: func() interface{} {
-					var ret [1]GetDefaultTeamChannelsLocalArg
+					var ret [1]SetMembershipHookArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]GetDefaultTeamChannelsLocalArg)
+					typedArgs, ok := args.(*[1]SetMembershipHookArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]GetDefaultTeamChannelsLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SetMembershipHookArg)(nil), args)
 						return
 					}
-					ret, err = i.GetDefaultTeamChannelsLocal(ctx, typedArgs[0].TeamID)
+					err = i.SetMembershipHook(ctx, typedArgs[0])
 					return
 				},
 			},
-			"setDefaultTeamChannelsLocal": {
+			"postPoll": {
 				MakeArg: func() interface{} {
-					var ret [1]SetDefaultTeamChannelsLocalArg
+					var ret [1]PostPollArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SetDefaultTeamChannelsLocalArg)
+					typedArgs, ok := args.(*[1]PostPollArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SetDefaultTeamChannelsLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]PostPollArg)(nil), args)
 						return
 					}
-					ret, err = i.SetDefaultTeamChannelsLocal(ctx, typedArgs[0])
+					ret, err = i.PostPoll(ctx, typedArgs[0])
 					return
 				},
 			},
-			"getLastActiveForTLF": {
+			"votePoll": {
 				MakeArg: func() interface{} {
-					var ret [1]GetLastActiveForTLFArg
+					var ret [1]VotePollArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]GetLastActiveForTLFArg)
+					typedArgs, ok := args.(*[1]VotePollArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]GetLastActiveForTLFArg)(nil), args)
+						err = rpc.NewTypeError((*[1]VotePollArg)(nil), args)
 						return
 					}
-					ret, err = i.GetLastActiveForTLF(ctx, typedArgs[0].TlfID)
+					err = i.VotePoll(ctx, typedArgs[0])
 					return
 				},
 			},
-			"getLastActiveForTeams": {
+			"getPollResults": {
 				MakeArg: func() interface{} {
-					var ret [1]GetLastActiveForTeamsArg
+					var ret [1]GetPollResultsArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					ret, err = i.GetLastActiveForTeams(ctx)
+					typedArgs, ok := args.(*[1]GetPollResultsArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]GetPollResultsArg)(nil), args)
+						return
+					}
+					ret, err = i.GetPollResults(ctx, typedArgs[0])
 					return
 				},
 			},
-			"getRecentJoinsLocal": {
+			"getAttachmentExpiryPolicy": {
 				MakeArg: func() interface{} {
-					var ret [1]GetRecentJoinsLocalArg
+					var ret [1]GetAttachmentExpiryPolicyArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]GetRecentJoinsLocalArg)
+					typedArgs, ok := args.(*[1]GetAttachmentExpiryPolicyArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]GetRecentJoinsLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]GetAttachmentExpiryPolicyArg)(nil), args)
 						return
 					}
-					ret, err = i.GetRecentJoinsLocal(ctx, typedArgs[0].ConvID)
+					ret, err = i.GetAttachmentExpiryPolicy(ctx, typedArgs[0].TeamID)
 					return
 				},
 			},
-			"refreshParticipants": {
+			"setAttachmentExpiryPolicy": {
 				MakeArg: func() interface{} {
-					var ret [1]RefreshParticipantsArg
+					var ret [1]SetAttachmentExpiryPolicyArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]RefreshParticipantsArg)
+					typedArgs, ok := args.(*[1]SetAttachmentExpiryPolicyArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]RefreshParticipantsArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SetAttachmentExpiryPolicyArg)(nil), args)
 						return
 					}
-					err = i.RefreshParticipants(ctx, typedArgs[0].ConvID)
+					err = i.SetAttachmentExpiryPolicy(ctx, typedArgs[0])
 					return
 				},
 			},
-			"getLastActiveAtLocal": {
+			"expireTeamAttachments": {
 				MakeArg: func() interface{} {
-					var ret [1]GetLastActiveAtLocalArg
+					var ret [1]ExpireTeamAttachmentsArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]GetLastActiveAtLocalArg)
+					typedArgs, ok := args.(*[1]ExpireTeamAttachmentsArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]GetLastActiveAtLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]ExpireTeamAttachmentsArg)(nil), args)
 						return
 					}
-					ret, err = i.GetLastActiveAtLocal(ctx, typedArgs[0])
+					ret, err = i.ExpireTeamAttachments(ctx, typedArgs[0])
 					return
 				},
 			},
-			"getLastActiveAtMultiLocal": {
+			"enableWidgetFeed": {
 				MakeArg: func() interface{} {
-					var ret [1]GetLastActiveAtMultiLocalArg
+					var ret [1]EnableWidgetFeedArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]GetLastActiveAtMultiLocalArg)
+					typedArgs, ok := args.(*[1]EnableWidgetFeedArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]GetLastActiveAtMultiLocalArg)(nil), args)
+						err = rpc.NewTypeError((*[1]EnableWidgetFeedArg)(nil), args)
 						return
 					}
-					ret, err = i.GetLastActiveAtMultiLocal(ctx, typedArgs[0])
+					err = i.EnableWidgetFeed(ctx, typedArgs[0])
 					return
 				},
 			},
-			"getParticipants": {
+			"disableWidgetFeed": {
 				MakeArg: func() interface{} {
-					var ret [1]GetParticipantsArg
+					var ret [1]DisableWidgetFeedArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]GetParticipantsArg)
-					if !ok {
-						err = rpc.NewTypeError((*[1]GetParticipantsArg)(nil), args)
-						return
-					}
-					ret, err = i.GetParticipants(ctx, typedArgs[0].ConvID)
+					err = i.DisableWidgetFeed(ctx)
 					return
 				},
 			},
-			"addEmoji": {
+			"pinWidgetFeedMessage": {
 				MakeArg: func() interface{} {
-					var ret [1]AddEmojiArg
+					var ret [1]PinWidgetFeedMessageArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]AddEmojiArg)
+					typedArgs, ok := args.(*[1]PinWidgetFeedMessageArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]AddEmojiArg)(nil), args)
+						err = rpc.NewTypeError((*[1]PinWidgetFeedMessageArg)(nil), args)
 						return
 					}
-					ret, err = i.AddEmoji(ctx, typedArgs[0])
+					err = i.PinWidgetFeedMessage(ctx, typedArgs[0])
 					return
 				},
 			},
-			"addEmojis": {
+			"getWidgetFeed": {
 				MakeArg: func() interface{} {
-					var ret [1]AddEmojisArg
+					var ret [1]GetWidgetFeedArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]AddEmojisArg)
-					if !ok {
-						err = rpc.NewTypeError((*[1]AddEmojisArg)(nil), args)
-						return
-					}
-					ret, err = i.AddEmojis(ctx, typedArgs[0])
+					ret, err = i.GetWidgetFeed(ctx)
 					return
 				},
 			},
-			"addEmojiAlias": {
+			"acknowledgeIdentityChanges": {
 				MakeArg: func() interface{} {
-					var ret [1]AddEmojiAliasArg
+					var ret [1]AcknowledgeIdentityChangesArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]AddEmojiAliasArg)
+					typedArgs, ok := args.(*[1]AcknowledgeIdentityChangesArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]AddEmojiAliasArg)(nil), args)
+						err = rpc.NewTypeError((*[1]AcknowledgeIdentityChangesArg)(nil), args)
 						return
 					}
-					ret, err = i.AddEmojiAlias(ctx, typedArgs[0])
+					err = i.AcknowledgeIdentityChanges(ctx, typedArgs[0].ConvID)
 					return
 				},
 			},
-			"removeEmoji": {
+			"getAttachmentAutoDownloadPolicy": {
 				MakeArg: func() interface{} {
-					var ret [1]RemoveEmojiArg
+					var ret [1]GetAttachmentAutoDownloadPolicyArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]RemoveEmojiArg)
-					if !ok {
-						err = rpc.NewTypeError((*[1]RemoveEmojiArg)(nil), args)
-						return
-					}
-					ret, err = i.RemoveEmoji(ctx, typedArgs[0])
+					ret, err = i.GetAttachmentAutoDownloadPolicy(ctx)
 					return
 				},
 			},
-			"userEmojis": {
+			"setAttachmentAutoDownloadPolicy": {
 				MakeArg: func() interface{} {
-					var ret [1]UserEmojisArg
+					var ret [1]SetAttachmentAutoDownloadPolicyArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]UserEmojisArg)
+					typedArgs, ok := args.(*[1]SetAttachmentAutoDownloadPolicyArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]UserEmojisArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SetAttachmentAutoDownloadPolicyArg)(nil), args)
 						return
 					}
-					ret, err = i.UserEmojis(ctx, typedArgs[0])
+					err = i.SetAttachmentAutoDownloadPolicy(ctx, typedArgs[0].Policy)
 					return
 				},
 			},
-			"toggleEmojiAnimations": {
+			"postTask": {
 				MakeArg: func() interface{} {
-					var ret [1]ToggleEmojiAnimationsArg
+					var ret [1]PostTaskArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]ToggleEmojiAnimationsArg)
+					typedArgs, ok := args.(*[1]PostTaskArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]ToggleEmojiAnimationsArg)(nil), args)
+						err = rpc.NewTypeError((*[1]PostTaskArg)(nil), args)
 						return
 					}
-					err = i.ToggleEmojiAnimations(ctx, typedArgs[0].Enabled)
+					ret, err = i.PostTask(ctx, typedArgs[0])
 					return
 				},
 			},
-			"trackGiphySelect": {
+			"assignTask": {
 				MakeArg: func() interface{} {
-					var ret [1]TrackGiphySelectArg
+					var ret [1]AssignTaskArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]TrackGiphySelectArg)
+					typedArgs, ok := args.(*[1]AssignTaskArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]TrackGiphySelectArg)(nil), args)
+						err = rpc.NewTypeError((*[1]AssignTaskArg)(nil), args)
 						return
 					}
-					ret, err = i.TrackGiphySelect(ctx, typedArgs[0])
+					err = i.AssignTask(ctx, typedArgs[0])
 					return
 				},
 			},
-			"archiveChat": {
+			"setTaskDone": {
 				MakeArg: func() interface{} {
-					var ret [1]ArchiveChatArg
+					var ret [1]SetTaskDoneArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]ArchiveChatArg)
+					typedArgs, ok := args.(*[1]SetTaskDoneArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]ArchiveChatArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SetTaskDoneArg)(nil), args)
 						return
 					}
-					ret, err = i.ArchiveChat(ctx, typedArgs[0].Req)
+					err = i.SetTaskDone(ctx, typedArgs[0])
 					return
 				},
 			},
-			"archiveChatList": {
+			"listOpenTasks": {
 				MakeArg: func() interface{} {
-					var ret [1]ArchiveChatListArg
+					var ret [1]ListOpenTasksArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]ArchiveChatListArg)
+					typedArgs, ok := args.(*[1]ListOpenTasksArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]ArchiveChatListArg)(nil), args)
+						err = rpc.NewTypeError((*[1]ListOpenTasksArg)(nil), args)
 						return
 					}
-					ret, err = i.ArchiveChatList(ctx, typedArgs[0].IdentifyBehavior)
+					ret, err = i.ListOpenTasks(ctx, typedArgs[0])
 					return
 				},
 			},
-			"archiveChatDelete": {
+			"listOpenTeamTasks": {
 				MakeArg: func() interface{} {
-					var ret [1]ArchiveChatDeleteArg
+					var ret [1]ListOpenTeamTasksArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]ArchiveChatDeleteArg)
+					typedArgs, ok := args.(*[1]ListOpenTeamTasksArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]ArchiveChatDeleteArg)(nil), args)
+						err = rpc.NewTypeError((*[1]ListOpenTeamTasksArg)(nil), args)
 						return
 					}
-					err = i.ArchiveChatDelete(ctx, typedArgs[0])
+					ret, err = i.ListOpenTeamTasks(ctx, typedArgs[0].TeamID)
 					return
 				},
 			},
-			"archiveChatPause": {
+			"getTeamReadme": {
 				MakeArg: func() interface{} {
-					var ret [1]ArchiveChatPauseArg
+					var ret [1]GetTeamReadmeArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]ArchiveChatPauseArg)
+					typedArgs, ok := args.(*[1]GetTeamReadmeArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]ArchiveChatPauseArg)(nil), args)
+						err = rpc.NewTypeError((*[1]GetTeamReadmeArg)(nil), args)
 						return
 					}
-					err = i.ArchiveChatPause(ctx, typedArgs[0])
+					ret, err = i.GetTeamReadme(ctx, typedArgs[0].TeamID)
 					return
 				},
 			},
-			"archiveChatResume": {
+			"setTeamReadme": {
 				MakeArg: func() interface{} {
-					var ret [1]ArchiveChatResumeArg
+					var ret [1]SetTeamReadmeArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]ArchiveChatResumeArg)
+					typedArgs, ok := args.(*[1]SetTeamReadmeArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]ArchiveChatResumeArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SetTeamReadmeArg)(nil), args)
 						return
 					}
-					err = i.ArchiveChatResume(ctx, typedArgs[0])
+					err = i.SetTeamReadme(ctx, typedArgs[0])
 					return
 				},
 			},
@@ -9630,6 +12749,12 @@ func (c LocalClient) GetInboxSummaryForCLILocal(ctx context.Context, query GetIn
 	return
 }
 
+func (c LocalClient) QueryInboxForCLILocal(ctx context.Context, query InboxQueryForCLI) (res InboxQueryForCLIRes, err error) {
+	__arg := QueryInboxForCLILocalArg{Query: query}
+	err = c.Cli.Call(ctx, "chat.1.local.queryInboxForCLILocal", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
 func (c LocalClient) GetConversationForCLILocal(ctx context.Context, query GetConversationForCLILocalQuery) (res GetConversationForCLILocalRes, err error) {
 	__arg := GetConversationForCLILocalArg{Query: query}
 	err = c.Cli.Call(ctx, "chat.1.local.getConversationForCLILocal", []interface{}{__arg}, &res, 0*time.Millisecond)
@@ -10156,3 +13281,295 @@ func (c LocalClient) ArchiveChatResume(ctx context.Context, __arg ArchiveChatRes
 	err = c.Cli.Call(ctx, "chat.1.local.archiveChatResume", []interface{}{__arg}, nil, 0*time.Millisecond)
 	return
 }
+
+func (c LocalClient) ArchiveChatSetSchedule(ctx context.Context, __arg ArchiveChatSetScheduleArg) (err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.archiveChatSetSchedule", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) ArchiveChatExportHistory(ctx context.Context, __arg ArchiveChatExportHistoryArg) (err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.archiveChatExportHistory", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) ArchiveChatImportHistory(ctx context.Context, __arg ArchiveChatImportHistoryArg) (res ArchiveChatImportHistoryRes, err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.archiveChatImportHistory", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) ArchiveChatSearch(ctx context.Context, __arg ArchiveChatSearchArg) (res ArchiveChatSearchRes, err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.archiveChatSearch", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) ArchiveChatVerify(ctx context.Context, __arg ArchiveChatVerifyArg) (res ArchiveChatVerifyRes, err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.archiveChatVerify", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) ArchiveChatImport(ctx context.Context, __arg ArchiveChatImportArg) (res ArchiveChatImportRes, err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.archiveChatImport", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) ArchiveChatListImported(ctx context.Context, identifyBehavior keybase1.TLFIdentifyBehavior) (res []ArchiveChatImportedConv, err error) {
+	__arg := ArchiveChatListImportedArg{IdentifyBehavior: identifyBehavior}
+	err = c.Cli.Call(ctx, "chat.1.local.archiveChatListImported", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) ArchiveChatGetImportedThread(ctx context.Context, __arg ArchiveChatGetImportedThreadArg) (res ArchiveChatImportedThread, err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.archiveChatGetImportedThread", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) ArchiveChatDeleteImported(ctx context.Context, __arg ArchiveChatDeleteImportedArg) (err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.archiveChatDeleteImported", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) SearchChatHistory(ctx context.Context, __arg SearchChatHistoryArg) (res ChatHistorySearchRes, err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.searchChatHistory", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) GetConversationContacts(ctx context.Context, __arg GetConversationContactsArg) (res ConversationContactsRes, err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.getConversationContacts", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) SplitConversationLocal(ctx context.Context, __arg SplitConversationLocalArg) (res SplitConversationLocalRes, err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.splitConversationLocal", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) GetArchiveRedactionRules(ctx context.Context, teamID keybase1.TeamID) (res ArchiveRedactionRules, err error) {
+	__arg := GetArchiveRedactionRulesArg{TeamID: teamID}
+	err = c.Cli.Call(ctx, "chat.1.local.getArchiveRedactionRules", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) SetArchiveRedactionRules(ctx context.Context, __arg SetArchiveRedactionRulesArg) (err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.setArchiveRedactionRules", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) GetNewMemberRestrictions(ctx context.Context, teamID keybase1.TeamID) (res NewMemberRestrictions, err error) {
+	__arg := GetNewMemberRestrictionsArg{TeamID: teamID}
+	err = c.Cli.Call(ctx, "chat.1.local.getNewMemberRestrictions", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) SetNewMemberRestrictions(ctx context.Context, __arg SetNewMemberRestrictionsArg) (err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.setNewMemberRestrictions", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) SetNewMemberRestrictionsExempt(ctx context.Context, __arg SetNewMemberRestrictionsExemptArg) (err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.setNewMemberRestrictionsExempt", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) GetTrustedDevicePolicy(ctx context.Context, convID ConversationID) (res *TrustedDeviceChannelPolicy, err error) {
+	__arg := GetTrustedDevicePolicyArg{ConvID: convID}
+	err = c.Cli.Call(ctx, "chat.1.local.getTrustedDevicePolicy", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) SetTrustedDevicePolicy(ctx context.Context, __arg SetTrustedDevicePolicyArg) (err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.setTrustedDevicePolicy", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) SetTrustedDeviceApproved(ctx context.Context, __arg SetTrustedDeviceApprovedArg) (err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.setTrustedDeviceApproved", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) BulkDeleteMessagesLocal(ctx context.Context, __arg BulkDeleteMessagesLocalArg) (res BulkDeleteMessagesRes, err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.bulkDeleteMessagesLocal", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) GetModerationAuditLog(ctx context.Context, teamID keybase1.TeamID) (res ModerationAuditLog, err error) {
+	__arg := GetModerationAuditLogArg{TeamID: teamID}
+	err = c.Cli.Call(ctx, "chat.1.local.getModerationAuditLog", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) GetNotificationRoutingPreferences(ctx context.Context) (res NotificationRoutingPreferences, err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.getNotificationRoutingPreferences", []interface{}{GetNotificationRoutingPreferencesArg{}}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) SetNotificationRoutingPreferences(ctx context.Context, prefs NotificationRoutingPreferences) (err error) {
+	__arg := SetNotificationRoutingPreferencesArg{Prefs: prefs}
+	err = c.Cli.Call(ctx, "chat.1.local.setNotificationRoutingPreferences", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) GetMentionDigestSettings(ctx context.Context) (res MentionDigestSettings, err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.getMentionDigestSettings", []interface{}{GetMentionDigestSettingsArg{}}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) SetMentionDigestSettings(ctx context.Context, settings MentionDigestSettings) (err error) {
+	__arg := SetMentionDigestSettingsArg{Settings: settings}
+	err = c.Cli.Call(ctx, "chat.1.local.setMentionDigestSettings", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) GetMentionDigest(ctx context.Context) (res MentionDigest, err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.getMentionDigest", []interface{}{GetMentionDigestArg{}}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) GetIncidentMode(ctx context.Context, teamID keybase1.TeamID) (res IncidentModeSettings, err error) {
+	__arg := GetIncidentModeArg{TeamID: teamID}
+	err = c.Cli.Call(ctx, "chat.1.local.getIncidentMode", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) SetIncidentMode(ctx context.Context, __arg SetIncidentModeArg) (err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.setIncidentMode", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) GetIncidentModeAuditLog(ctx context.Context, teamID keybase1.TeamID) (res IncidentModeAuditLog, err error) {
+	__arg := GetIncidentModeAuditLogArg{TeamID: teamID}
+	err = c.Cli.Call(ctx, "chat.1.local.getIncidentModeAuditLog", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) GetChannelBanner(ctx context.Context, convID ConversationID) (res *ChannelBanner, err error) {
+	__arg := GetChannelBannerArg{ConvID: convID}
+	err = c.Cli.Call(ctx, "chat.1.local.getChannelBanner", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) SetChannelBanner(ctx context.Context, __arg SetChannelBannerArg) (err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.setChannelBanner", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) GetChannelBannerAuditLog(ctx context.Context, teamID keybase1.TeamID) (res ChannelBannerAuditLog, err error) {
+	__arg := GetChannelBannerAuditLogArg{TeamID: teamID}
+	err = c.Cli.Call(ctx, "chat.1.local.getChannelBannerAuditLog", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) GetMembershipHook(ctx context.Context, teamID keybase1.TeamID) (res MembershipHook, err error) {
+	__arg := GetMembershipHookArg{TeamID: teamID}
+	err = c.Cli.Call(ctx, "chat.1.local.getMembershipHook", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) SetMembershipHook(ctx context.Context, __arg SetMembershipHookArg) (err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.setMembershipHook", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) PostPoll(ctx context.Context, __arg PostPollArg) (res PostLocalRes, err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.postPoll", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) VotePoll(ctx context.Context, __arg VotePollArg) (err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.votePoll", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) GetPollResults(ctx context.Context, __arg GetPollResultsArg) (res PollResults, err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.getPollResults", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) GetAttachmentExpiryPolicy(ctx context.Context, teamID keybase1.TeamID) (res AttachmentExpiryPolicy, err error) {
+	__arg := GetAttachmentExpiryPolicyArg{TeamID: teamID}
+	err = c.Cli.Call(ctx, "chat.1.local.getAttachmentExpiryPolicy", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) SetAttachmentExpiryPolicy(ctx context.Context, __arg SetAttachmentExpiryPolicyArg) (err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.setAttachmentExpiryPolicy", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) ExpireTeamAttachments(ctx context.Context, __arg ExpireTeamAttachmentsArg) (res AttachmentExpiryReport, err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.expireTeamAttachments", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) EnableWidgetFeed(ctx context.Context, __arg EnableWidgetFeedArg) (err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.enableWidgetFeed", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) DisableWidgetFeed(ctx context.Context) (err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.disableWidgetFeed", []interface{}{DisableWidgetFeedArg{}}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) PinWidgetFeedMessage(ctx context.Context, __arg PinWidgetFeedMessageArg) (err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.pinWidgetFeedMessage", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) GetWidgetFeed(ctx context.Context) (res WidgetFeed, err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.getWidgetFeed", []interface{}{GetWidgetFeedArg{}}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) AcknowledgeIdentityChanges(ctx context.Context, convID ConversationID) (err error) {
+	__arg := AcknowledgeIdentityChangesArg{ConvID: convID}
+	err = c.Cli.Call(ctx, "chat.1.local.acknowledgeIdentityChanges", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) GetAttachmentAutoDownloadPolicy(ctx context.Context) (res AttachmentAutoDownloadPolicy, err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.getAttachmentAutoDownloadPolicy", []interface{}{GetAttachmentAutoDownloadPolicyArg{}}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) SetAttachmentAutoDownloadPolicy(ctx context.Context, policy AttachmentAutoDownloadPolicy) (err error) {
+	__arg := SetAttachmentAutoDownloadPolicyArg{Policy: policy}
+	err = c.Cli.Call(ctx, "chat.1.local.setAttachmentAutoDownloadPolicy", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) PostTask(ctx context.Context, __arg PostTaskArg) (res PostLocalRes, err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.postTask", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) AssignTask(ctx context.Context, __arg AssignTaskArg) (err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.assignTask", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) SetTaskDone(ctx context.Context, __arg SetTaskDoneArg) (err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.setTaskDone", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) ListOpenTasks(ctx context.Context, __arg ListOpenTasksArg) (res []TaskInfo, err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.listOpenTasks", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) ListOpenTeamTasks(ctx context.Context, teamID keybase1.TeamID) (res []TaskInfo, err error) {
+	__arg := ListOpenTeamTasksArg{TeamID: teamID}
+	err = c.Cli.Call(ctx, "chat.1.local.listOpenTeamTasks", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) GetTeamReadme(ctx context.Context, teamID keybase1.TeamID) (res TeamReadme, err error) {
+	__arg := GetTeamReadmeArg{TeamID: teamID}
+	err = c.Cli.Call(ctx, "chat.1.local.getTeamReadme", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) SetTeamReadme(ctx context.Context, __arg SetTeamReadmeArg) (err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.setTeamReadme", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
@@ -877,6 +877,53 @@ func (o ChatSyncIncrementalInfo) DeepCopy() ChatSyncIncrementalInfo {
 	}
 }
 
+type ArchiveChatConvPhase int
+
+const (
+	ArchiveChatConvPhase_MESSAGES    ArchiveChatConvPhase = 0
+	ArchiveChatConvPhase_ATTACHMENTS ArchiveChatConvPhase = 1
+	ArchiveChatConvPhase_DONE        ArchiveChatConvPhase = 2
+)
+
+func (o ArchiveChatConvPhase) DeepCopy() ArchiveChatConvPhase { return o }
+
+var ArchiveChatConvPhaseMap = map[string]ArchiveChatConvPhase{
+	"MESSAGES":    0,
+	"ATTACHMENTS": 1,
+	"DONE":        2,
+}
+
+var ArchiveChatConvPhaseRevMap = map[ArchiveChatConvPhase]string{
+	0: "MESSAGES",
+	1: "ATTACHMENTS",
+	2: "DONE",
+}
+
+func (e ArchiveChatConvPhase) String() string {
+	if v, ok := ArchiveChatConvPhaseRevMap[e]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(e))
+}
+
+type ArchiveChatConvProgress struct {
+	ConvID           ConversationID       `codec:"convID" json:"convID"`
+	ConvName         string               `codec:"convName" json:"convName"`
+	MessagesComplete int64                `codec:"messagesComplete" json:"messagesComplete"`
+	MessagesTotal    int64                `codec:"messagesTotal" json:"messagesTotal"`
+	Phase            ArchiveChatConvPhase `codec:"phase" json:"phase"`
+}
+
+func (o ArchiveChatConvProgress) DeepCopy() ArchiveChatConvProgress {
+	return ArchiveChatConvProgress{
+		ConvID:           o.ConvID.DeepCopy(),
+		ConvName:         o.ConvName,
+		MessagesComplete: o.MessagesComplete,
+		MessagesTotal:    o.MessagesTotal,
+		Phase:            o.Phase.DeepCopy(),
+	}
+}
+
 type ChatSyncResult struct {
 	SyncType__    SyncInboxResType         `codec:"syncType" json:"syncType"`
 	Incremental__ *ChatSyncIncrementalInfo `codec:"incremental,omitempty" json:"incremental,omitempty"`
@@ -1053,9 +1100,10 @@ type ChatAttachmentDownloadCompleteArg struct {
 }
 
 type ChatArchiveProgressArg struct {
-	JobID            ArchiveJobID `codec:"jobID" json:"jobID"`
-	MessagesComplete int64        `codec:"messagesComplete" json:"messagesComplete"`
-	MessagesTotal    int64        `codec:"messagesTotal" json:"messagesTotal"`
+	JobID            ArchiveJobID             `codec:"jobID" json:"jobID"`
+	MessagesComplete int64                    `codec:"messagesComplete" json:"messagesComplete"`
+	MessagesTotal    int64                    `codec:"messagesTotal" json:"messagesTotal"`
+	Conv             *ArchiveChatConvProgress `codec:"conv,omitempty" json:"conv,omitempty"`
 }
 
 type ChatArchiveCompleteArg struct {
@@ -1098,6 +1146,23 @@ type ChatParticipantsInfoArg struct {
 	Participants map[ConvIDStr][]UIParticipant `codec:"participants" json:"participants"`
 }
 
+type ChatSplitConversationProgressArg struct {
+	ConvID           ConversationID `codec:"convID" json:"convID"`
+	MessagesComplete int64          `codec:"messagesComplete" json:"messagesComplete"`
+	MessagesTotal    int64          `codec:"messagesTotal" json:"messagesTotal"`
+}
+
+type ChatMentionDigestArg struct {
+	Uid    keybase1.UID  `codec:"uid" json:"uid"`
+	Digest MentionDigest `codec:"digest" json:"digest"`
+}
+
+type ChatIdentityChangeAlertArg struct {
+	Uid    keybase1.UID        `codec:"uid" json:"uid"`
+	ConvID ConversationID      `codec:"convID" json:"convID"`
+	Alert  IdentityChangeAlert `codec:"alert" json:"alert"`
+}
+
 type NotifyChatInterface interface {
 	NewChatActivity(context.Context, NewChatActivityArg) error
 	ChatIdentifyUpdate(context.Context, keybase1.CanonicalTLFNameAndIDWithBreaks) error
@@ -1128,6 +1193,9 @@ type NotifyChatInterface interface {
 	ChatConvUpdate(context.Context, ChatConvUpdateArg) error
 	ChatWelcomeMessageLoaded(context.Context, ChatWelcomeMessageLoadedArg) error
 	ChatParticipantsInfo(context.Context, map[ConvIDStr][]UIParticipant) error
+	ChatSplitConversationProgress(context.Context, ChatSplitConversationProgressArg) error
+	ChatMentionDigest(context.Context, ChatMentionDigestArg) error
+	ChatIdentityChangeAlert(context.Context, ChatIdentityChangeAlertArg) error
 }
 
 func NotifyChatProtocol(i NotifyChatInterface) rpc.Protocol {
@@ -1569,6 +1637,51 @@ func NotifyChatProtocol(i NotifyChatInterface) rpc.Protocol {
 					return
 				},
 			},
+			"ChatSplitConversationProgress": {
+				MakeArg: func() interface{} {
+					var ret [1]ChatSplitConversationProgressArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]ChatSplitConversationProgressArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]ChatSplitConversationProgressArg)(nil), args)
+						return
+					}
+					err = i.ChatSplitConversationProgress(ctx, typedArgs[0])
+					return
+				},
+			},
+			"ChatMentionDigest": {
+				MakeArg: func() interface{} {
+					var ret [1]ChatMentionDigestArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]ChatMentionDigestArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]ChatMentionDigestArg)(nil), args)
+						return
+					}
+					err = i.ChatMentionDigest(ctx, typedArgs[0])
+					return
+				},
+			},
+			"ChatIdentityChangeAlert": {
+				MakeArg: func() interface{} {
+					var ret [1]ChatIdentityChangeAlertArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]ChatIdentityChangeAlertArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]ChatIdentityChangeAlertArg)(nil), args)
+						return
+					}
+					err = i.ChatIdentityChangeAlert(ctx, typedArgs[0])
+					return
+				},
+			},
 		},
 	}
 }
@@ -1727,3 +1840,18 @@ func (c NotifyChatClient) ChatParticipantsInfo(ctx context.Context, participants
 	err = c.Cli.Notify(ctx, "chat.1.NotifyChat.ChatParticipantsInfo", []interface{}{__arg}, 0*time.Millisecond)
 	return
 }
+
+func (c NotifyChatClient) ChatSplitConversationProgress(ctx context.Context, __arg ChatSplitConversationProgressArg) (err error) {
+	err = c.Cli.Notify(ctx, "chat.1.NotifyChat.ChatSplitConversationProgress", []interface{}{__arg}, 0*time.Millisecond)
+	return
+}
+
+func (c NotifyChatClient) ChatMentionDigest(ctx context.Context, __arg ChatMentionDigestArg) (err error) {
+	err = c.Cli.Notify(ctx, "chat.1.NotifyChat.ChatMentionDigest", []interface{}{__arg}, 0*time.Millisecond)
+	return
+}
+
+func (c NotifyChatClient) ChatIdentityChangeAlert(ctx context.Context, __arg ChatIdentityChangeAlertArg) (err error) {
+	err = c.Cli.Notify(ctx, "chat.1.NotifyChat.ChatIdentityChangeAlert", []interface{}{__arg}, 0*time.Millisecond)
+	return
+}
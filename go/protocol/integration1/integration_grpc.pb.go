@@ -0,0 +1,288 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+// The integration API is a read-only gRPC surface of the Keybase service for
+// third-party tools, so they can be written in any language gRPC supports
+// instead of speaking the service's framed-msgpack protocol.
+//
+// The service only serves it when integration_grpc_port is set in its
+// config (or KEYBASE_INTEGRATION_GRPC_PORT in its environment), on
+// 127.0.0.1 at that port, over HTTP/2 without TLS. Every call has to carry
+// "authorization: Bearer <token>" metadata, where the token is the contents
+// of the integration_token file in the service's data directory. Only unary
+// calls without compression are supported.
+//
+// Within v1, fields and methods are only ever added, never renamed,
+// renumbered or removed.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: keybase/integration/v1/integration.proto
+
+package integration1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Integration_GetStatus_FullMethodName         = "/keybase.integration.v1.Integration/GetStatus"
+	Integration_ListConversations_FullMethodName = "/keybase.integration.v1.Integration/ListConversations"
+	Integration_ReadChat_FullMethodName          = "/keybase.integration.v1.Integration/ReadChat"
+	Integration_ListFiles_FullMethodName         = "/keybase.integration.v1.Integration/ListFiles"
+	Integration_GetArchiveStatus_FullMethodName  = "/keybase.integration.v1.Integration/GetArchiveStatus"
+)
+
+// IntegrationClient is the client API for Integration service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type IntegrationClient interface {
+	// GetStatus returns who's logged in to the service, if anyone.
+	GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error)
+	// ListConversations returns the logged-in user's chat conversations,
+	// most recently active first.
+	ListConversations(ctx context.Context, in *ListConversationsRequest, opts ...grpc.CallOption) (*ListConversationsResponse, error)
+	// ReadChat returns the latest messages of a conversation, newest first.
+	// It doesn't mark them as read.
+	ReadChat(ctx context.Context, in *ReadChatRequest, opts ...grpc.CallOption) (*ReadChatResponse, error)
+	// ListFiles lists a directory in KBFS.
+	ListFiles(ctx context.Context, in *ListFilesRequest, opts ...grpc.CallOption) (*ListFilesResponse, error)
+	// GetArchiveStatus returns the KBFS archive jobs, oldest first.
+	GetArchiveStatus(ctx context.Context, in *GetArchiveStatusRequest, opts ...grpc.CallOption) (*GetArchiveStatusResponse, error)
+}
+
+type integrationClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewIntegrationClient(cc grpc.ClientConnInterface) IntegrationClient {
+	return &integrationClient{cc}
+}
+
+func (c *integrationClient) GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error) {
+	out := new(GetStatusResponse)
+	err := c.cc.Invoke(ctx, Integration_GetStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *integrationClient) ListConversations(ctx context.Context, in *ListConversationsRequest, opts ...grpc.CallOption) (*ListConversationsResponse, error) {
+	out := new(ListConversationsResponse)
+	err := c.cc.Invoke(ctx, Integration_ListConversations_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *integrationClient) ReadChat(ctx context.Context, in *ReadChatRequest, opts ...grpc.CallOption) (*ReadChatResponse, error) {
+	out := new(ReadChatResponse)
+	err := c.cc.Invoke(ctx, Integration_ReadChat_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *integrationClient) ListFiles(ctx context.Context, in *ListFilesRequest, opts ...grpc.CallOption) (*ListFilesResponse, error) {
+	out := new(ListFilesResponse)
+	err := c.cc.Invoke(ctx, Integration_ListFiles_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *integrationClient) GetArchiveStatus(ctx context.Context, in *GetArchiveStatusRequest, opts ...grpc.CallOption) (*GetArchiveStatusResponse, error) {
+	out := new(GetArchiveStatusResponse)
+	err := c.cc.Invoke(ctx, Integration_GetArchiveStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IntegrationServer is the server API for Integration service.
+// All implementations must embed UnimplementedIntegrationServer
+// for forward compatibility
+type IntegrationServer interface {
+	// GetStatus returns who's logged in to the service, if anyone.
+	GetStatus(context.Context, *GetStatusRequest) (*GetStatusResponse, error)
+	// ListConversations returns the logged-in user's chat conversations,
+	// most recently active first.
+	ListConversations(context.Context, *ListConversationsRequest) (*ListConversationsResponse, error)
+	// ReadChat returns the latest messages of a conversation, newest first.
+	// It doesn't mark them as read.
+	ReadChat(context.Context, *ReadChatRequest) (*ReadChatResponse, error)
+	// ListFiles lists a directory in KBFS.
+	ListFiles(context.Context, *ListFilesRequest) (*ListFilesResponse, error)
+	// GetArchiveStatus returns the KBFS archive jobs, oldest first.
+	GetArchiveStatus(context.Context, *GetArchiveStatusRequest) (*GetArchiveStatusResponse, error)
+	mustEmbedUnimplementedIntegrationServer()
+}
+
+// UnimplementedIntegrationServer must be embedded to have forward compatible implementations.
+type UnimplementedIntegrationServer struct {
+}
+
+func (UnimplementedIntegrationServer) GetStatus(context.Context, *GetStatusRequest) (*GetStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (UnimplementedIntegrationServer) ListConversations(context.Context, *ListConversationsRequest) (*ListConversationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListConversations not implemented")
+}
+func (UnimplementedIntegrationServer) ReadChat(context.Context, *ReadChatRequest) (*ReadChatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadChat not implemented")
+}
+func (UnimplementedIntegrationServer) ListFiles(context.Context, *ListFilesRequest) (*ListFilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFiles not implemented")
+}
+func (UnimplementedIntegrationServer) GetArchiveStatus(context.Context, *GetArchiveStatusRequest) (*GetArchiveStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetArchiveStatus not implemented")
+}
+func (UnimplementedIntegrationServer) mustEmbedUnimplementedIntegrationServer() {}
+
+// UnsafeIntegrationServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to IntegrationServer will
+// result in compilation errors.
+type UnsafeIntegrationServer interface {
+	mustEmbedUnimplementedIntegrationServer()
+}
+
+func RegisterIntegrationServer(s grpc.ServiceRegistrar, srv IntegrationServer) {
+	s.RegisterService(&Integration_ServiceDesc, srv)
+}
+
+func _Integration_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IntegrationServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Integration_GetStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IntegrationServer).GetStatus(ctx, req.(*GetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Integration_ListConversations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListConversationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IntegrationServer).ListConversations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Integration_ListConversations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IntegrationServer).ListConversations(ctx, req.(*ListConversationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Integration_ReadChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadChatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IntegrationServer).ReadChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Integration_ReadChat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IntegrationServer).ReadChat(ctx, req.(*ReadChatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Integration_ListFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IntegrationServer).ListFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Integration_ListFiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IntegrationServer).ListFiles(ctx, req.(*ListFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Integration_GetArchiveStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetArchiveStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IntegrationServer).GetArchiveStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Integration_GetArchiveStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IntegrationServer).GetArchiveStatus(ctx, req.(*GetArchiveStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Integration_ServiceDesc is the grpc.ServiceDesc for Integration service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Integration_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "keybase.integration.v1.Integration",
+	HandlerType: (*IntegrationServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetStatus",
+			Handler:    _Integration_GetStatus_Handler,
+		},
+		{
+			MethodName: "ListConversations",
+			Handler:    _Integration_ListConversations_Handler,
+		},
+		{
+			MethodName: "ReadChat",
+			Handler:    _Integration_ReadChat_Handler,
+		},
+		{
+			MethodName: "ListFiles",
+			Handler:    _Integration_ListFiles_Handler,
+		},
+		{
+			MethodName: "GetArchiveStatus",
+			Handler:    _Integration_GetArchiveStatus_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "keybase/integration/v1/integration.proto",
+}
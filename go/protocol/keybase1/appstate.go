@@ -78,7 +78,8 @@ func (e MobileNetworkState) String() string {
 }
 
 type UpdateMobileNetStateArg struct {
-	State string `codec:"state" json:"state"`
+	State       string `codec:"state" json:"state"`
+	IsExpensive bool   `codec:"isExpensive" json:"isExpensive"`
 }
 
 type PowerMonitorEventArg struct {
@@ -86,7 +87,7 @@ type PowerMonitorEventArg struct {
 }
 
 type AppStateInterface interface {
-	UpdateMobileNetState(context.Context, string) error
+	UpdateMobileNetState(context.Context, UpdateMobileNetStateArg) error
 	PowerMonitorEvent(context.Context, string) error
 }
 
@@ -105,7 +106,7 @@ func AppStateProtocol(i AppStateInterface) rpc.Protocol {
 						err = rpc.NewTypeError((*[1]UpdateMobileNetStateArg)(nil), args)
 						return
 					}
-					err = i.UpdateMobileNetState(ctx, typedArgs[0].State)
+					err = i.UpdateMobileNetState(ctx, typedArgs[0])
 					return
 				},
 			},
@@ -132,8 +133,7 @@ type AppStateClient struct {
 	Cli rpc.GenericClient
 }
 
-func (c AppStateClient) UpdateMobileNetState(ctx context.Context, state string) (err error) {
-	__arg := UpdateMobileNetStateArg{State: state}
+func (c AppStateClient) UpdateMobileNetState(ctx context.Context, __arg UpdateMobileNetStateArg) (err error) {
 	err = c.Cli.Call(ctx, "keybase.1.appState.updateMobileNetState", []interface{}{__arg}, nil, 0*time.Millisecond)
 	return
 }
@@ -131,6 +131,84 @@ func (e OnLoginStartupStatus) String() string {
 	return fmt.Sprintf("%v", int(e))
 }
 
+type BackgroundJobDrainResult struct {
+	Name    string `codec:"name" json:"name"`
+	Drained bool   `codec:"drained" json:"drained"`
+	Error   string `codec:"error" json:"error"`
+}
+
+func (o BackgroundJobDrainResult) DeepCopy() BackgroundJobDrainResult {
+	return BackgroundJobDrainResult{
+		Name:    o.Name,
+		Drained: o.Drained,
+		Error:   o.Error,
+	}
+}
+
+type TelemetryEventType int
+
+const (
+	TelemetryEventType_COUNT  TelemetryEventType = 0
+	TelemetryEventType_TIMING TelemetryEventType = 1
+)
+
+func (o TelemetryEventType) DeepCopy() TelemetryEventType { return o }
+
+var TelemetryEventTypeMap = map[string]TelemetryEventType{
+	"COUNT":  0,
+	"TIMING": 1,
+}
+
+var TelemetryEventTypeRevMap = map[TelemetryEventType]string{
+	0: "COUNT",
+	1: "TIMING",
+}
+
+func (e TelemetryEventType) String() string {
+	if v, ok := TelemetryEventTypeRevMap[e]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(e))
+}
+
+type TelemetryEvent struct {
+	Time  Time               `codec:"time" json:"time"`
+	Name  string             `codec:"name" json:"name"`
+	Type  TelemetryEventType `codec:"type" json:"type"`
+	Value int64              `codec:"value" json:"value"`
+}
+
+func (o TelemetryEvent) DeepCopy() TelemetryEvent {
+	return TelemetryEvent{
+		Time:  o.Time.DeepCopy(),
+		Name:  o.Name,
+		Type:  o.Type.DeepCopy(),
+		Value: o.Value,
+	}
+}
+
+type TelemetryEventsRes struct {
+	Enabled bool             `codec:"enabled" json:"enabled"`
+	Events  []TelemetryEvent `codec:"events" json:"events"`
+}
+
+func (o TelemetryEventsRes) DeepCopy() TelemetryEventsRes {
+	return TelemetryEventsRes{
+		Enabled: o.Enabled,
+		Events: (func(x []TelemetryEvent) []TelemetryEvent {
+			if x == nil {
+				return nil
+			}
+			ret := make([]TelemetryEvent, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Events),
+	}
+}
+
 type StopArg struct {
 	SessionID int      `codec:"sessionID" json:"sessionID"`
 	ExitCode  ExitCode `codec:"exitCode" json:"exitCode"`
@@ -191,6 +269,15 @@ type SetOnLoginStartupArg struct {
 type GetOnLoginStartupArg struct {
 }
 
+type DrainBackgroundJobsArg struct {
+	SessionID int         `codec:"sessionID" json:"sessionID"`
+	Timeout   DurationSec `codec:"timeout" json:"timeout"`
+}
+
+type GetTelemetryEventsArg struct {
+	SessionID int `codec:"sessionID" json:"sessionID"`
+}
+
 type CtlInterface interface {
 	Stop(context.Context, StopArg) error
 	StopService(context.Context, StopServiceArg) error
@@ -205,6 +292,15 @@ type CtlInterface interface {
 	DbKeysWithPrefixes(context.Context, DbKeysWithPrefixesArg) ([]DbKey, error)
 	SetOnLoginStartup(context.Context, bool) error
 	GetOnLoginStartup(context.Context) (OnLoginStartupStatus, error)
+	// Pauses long-running background jobs, like chat and KBFS archives, at a
+	// point they can pick up from, and saves their state, so they carry on by
+	// themselves after the service restarts. Waits up to timeout for them.
+	// Jobs that haven't drained by then are stopped the usual way when the
+	// service stops.
+	DrainBackgroundJobs(context.Context, DrainBackgroundJobsArg) ([]BackgroundJobDrainResult, error)
+	// Returns the performance counters and timings recorded by the service,
+	// which never leave this device.
+	GetTelemetryEvents(context.Context, int) (TelemetryEventsRes, error)
 }
 
 func CtlProtocol(i CtlInterface) rpc.Protocol {
@@ -401,6 +497,36 @@ func CtlProtocol(i CtlInterface) rpc.Protocol {
 					return
 				},
 			},
+			"drainBackgroundJobs": {
+				MakeArg: func() interface{} {
+					var ret [1]DrainBackgroundJobsArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]DrainBackgroundJobsArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]DrainBackgroundJobsArg)(nil), args)
+						return
+					}
+					ret, err = i.DrainBackgroundJobs(ctx, typedArgs[0])
+					return
+				},
+			},
+			"getTelemetryEvents": {
+				MakeArg: func() interface{} {
+					var ret [1]GetTelemetryEventsArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]GetTelemetryEventsArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]GetTelemetryEventsArg)(nil), args)
+						return
+					}
+					ret, err = i.GetTelemetryEvents(ctx, typedArgs[0].SessionID)
+					return
+				},
+			},
 		},
 	}
 }
@@ -478,3 +604,21 @@ func (c CtlClient) GetOnLoginStartup(ctx context.Context) (res OnLoginStartupSta
 	err = c.Cli.Call(ctx, "keybase.1.ctl.getOnLoginStartup", []interface{}{GetOnLoginStartupArg{}}, &res, 0*time.Millisecond)
 	return
 }
+
+// Pauses long-running background jobs, like chat and KBFS archives, at a
+// point they can pick up from, and saves their state, so they carry on by
+// themselves after the service restarts. Waits up to timeout for them.
+// Jobs that haven't drained by then are stopped the usual way when the
+// service stops.
+func (c CtlClient) DrainBackgroundJobs(ctx context.Context, __arg DrainBackgroundJobsArg) (res []BackgroundJobDrainResult, err error) {
+	err = c.Cli.Call(ctx, "keybase.1.ctl.drainBackgroundJobs", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+// Returns the performance counters and timings recorded by the service,
+// which never leave this device.
+func (c CtlClient) GetTelemetryEvents(ctx context.Context, sessionID int) (res TelemetryEventsRes, err error) {
+	__arg := GetTelemetryEventsArg{SessionID: sessionID}
+	err = c.Cli.Call(ctx, "keybase.1.ctl.getTelemetryEvents", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
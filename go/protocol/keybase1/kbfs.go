@@ -19,6 +19,18 @@ func (o KBFSTeamSettings) DeepCopy() KBFSTeamSettings {
 	}
 }
 
+type DiskSpaceNeed struct {
+	Path  string `codec:"path" json:"path"`
+	Bytes int64  `codec:"bytes" json:"bytes"`
+}
+
+func (o DiskSpaceNeed) DeepCopy() DiskSpaceNeed {
+	return DiskSpaceNeed{
+		Path:  o.Path,
+		Bytes: o.Bytes,
+	}
+}
+
 type FSEventArg struct {
 	Event FSNotification `codec:"event" json:"event"`
 }
@@ -88,6 +100,33 @@ type DecryptFavoritesArg struct {
 	DataToEncrypt []byte `codec:"dataToEncrypt" json:"dataToEncrypt"`
 }
 
+type FSArchiveProgressEventArg struct {
+	Progress SimpleFSArchiveProgress `codec:"progress" json:"progress"`
+}
+
+type FSQuotaAlertEventArg struct {
+	Alert SimpleFSQuotaAlert `codec:"alert" json:"alert"`
+}
+
+type EncryptArchiveStateArg struct {
+	DataToEncrypt []byte `codec:"dataToEncrypt" json:"dataToEncrypt"`
+}
+
+type DecryptArchiveStateArg struct {
+	DataToDecrypt []byte `codec:"dataToDecrypt" json:"dataToDecrypt"`
+}
+
+type ReserveDiskSpaceArg struct {
+	Key         string          `codec:"key" json:"key"`
+	Description string          `codec:"description" json:"description"`
+	Needs       []DiskSpaceNeed `codec:"needs" json:"needs"`
+	Wait        bool            `codec:"wait" json:"wait"`
+}
+
+type ReleaseDiskSpaceArg struct {
+	Key string `codec:"key" json:"key"`
+}
+
 type KbfsInterface interface {
 	// Idea is that kbfs would call the function below whenever these actions are
 	// performed on a file.
@@ -130,6 +169,22 @@ type KbfsInterface interface {
 	EncryptFavorites(context.Context, []byte) ([]byte, error)
 	// Decrypt cached favorites stored on disk.
 	DecryptFavorites(context.Context, []byte) ([]byte, error)
+	// FSArchiveProgressEvent is called by KBFS as archive jobs copy and zip
+	// files.
+	FSArchiveProgressEvent(context.Context, SimpleFSArchiveProgress) error
+	// FSQuotaAlertEvent is called by KBFS when the user's quota usage crosses
+	// one of their alert thresholds.
+	FSQuotaAlertEvent(context.Context, SimpleFSQuotaAlert) error
+	EncryptArchiveState(context.Context, []byte) ([]byte, error)
+	DecryptArchiveState(context.Context, []byte) ([]byte, error)
+	// Reserve disk space for a job that's about to write needs, so it doesn't
+	// race other jobs in the service for it. The reservation replaces any held
+	// under key already, which is how a job updates it as it goes, and it
+	// expires if it isn't updated for long enough. Fails if there isn't room,
+	// or if wait is true, waits until other reservations are released.
+	ReserveDiskSpace(context.Context, ReserveDiskSpaceArg) error
+	// Release the disk space reserved under key, if any.
+	ReleaseDiskSpace(context.Context, string) error
 }
 
 func KbfsProtocol(i KbfsInterface) rpc.Protocol {
@@ -356,6 +411,96 @@ func KbfsProtocol(i KbfsInterface) rpc.Protocol {
 					return
 				},
 			},
+			"FSArchiveProgressEvent": {
+				MakeArg: func() interface{} {
+					var ret [1]FSArchiveProgressEventArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]FSArchiveProgressEventArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]FSArchiveProgressEventArg)(nil), args)
+						return
+					}
+					err = i.FSArchiveProgressEvent(ctx, typedArgs[0].Progress)
+					return
+				},
+			},
+			"FSQuotaAlertEvent": {
+				MakeArg: func() interface{} {
+					var ret [1]FSQuotaAlertEventArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]FSQuotaAlertEventArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]FSQuotaAlertEventArg)(nil), args)
+						return
+					}
+					err = i.FSQuotaAlertEvent(ctx, typedArgs[0].Alert)
+					return
+				},
+			},
+			"encryptArchiveState": {
+				MakeArg: func() interface{} {
+					var ret [1]EncryptArchiveStateArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]EncryptArchiveStateArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]EncryptArchiveStateArg)(nil), args)
+						return
+					}
+					ret, err = i.EncryptArchiveState(ctx, typedArgs[0].DataToEncrypt)
+					return
+				},
+			},
+			"decryptArchiveState": {
+				MakeArg: func() interface{} {
+					var ret [1]DecryptArchiveStateArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]DecryptArchiveStateArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]DecryptArchiveStateArg)(nil), args)
+						return
+					}
+					ret, err = i.DecryptArchiveState(ctx, typedArgs[0].DataToDecrypt)
+					return
+				},
+			},
+			"reserveDiskSpace": {
+				MakeArg: func() interface{} {
+					var ret [1]ReserveDiskSpaceArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]ReserveDiskSpaceArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]ReserveDiskSpaceArg)(nil), args)
+						return
+					}
+					err = i.ReserveDiskSpace(ctx, typedArgs[0])
+					return
+				},
+			},
+			"releaseDiskSpace": {
+				MakeArg: func() interface{} {
+					var ret [1]ReleaseDiskSpaceArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]ReleaseDiskSpaceArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]ReleaseDiskSpaceArg)(nil), args)
+						return
+					}
+					err = i.ReleaseDiskSpace(ctx, typedArgs[0].Key)
+					return
+				},
+			},
 		},
 	}
 }
@@ -471,3 +616,42 @@ func (c KbfsClient) DecryptFavorites(ctx context.Context, dataToEncrypt []byte)
 	err = c.Cli.Call(ctx, "keybase.1.kbfs.decryptFavorites", []interface{}{__arg}, &res, 0*time.Millisecond)
 	return
 }
+
+// FSArchiveProgressEvent is called by KBFS as archive jobs copy and zip
+// files.
+func (c KbfsClient) FSArchiveProgressEvent(ctx context.Context, progress SimpleFSArchiveProgress) (err error) {
+	__arg := FSArchiveProgressEventArg{Progress: progress}
+	err = c.Cli.Call(ctx, "keybase.1.kbfs.FSArchiveProgressEvent", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+// FSQuotaAlertEvent is called by KBFS when the user's quota usage crosses
+// one of their alert thresholds.
+func (c KbfsClient) FSQuotaAlertEvent(ctx context.Context, alert SimpleFSQuotaAlert) (err error) {
+	__arg := FSQuotaAlertEventArg{Alert: alert}
+	err = c.Cli.Call(ctx, "keybase.1.kbfs.FSQuotaAlertEvent", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c KbfsClient) EncryptArchiveState(ctx context.Context, dataToEncrypt []byte) (res []byte, err error) {
+	__arg := EncryptArchiveStateArg{DataToEncrypt: dataToEncrypt}
+	err = c.Cli.Call(ctx, "keybase.1.kbfs.encryptArchiveState", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c KbfsClient) DecryptArchiveState(ctx context.Context, dataToDecrypt []byte) (res []byte, err error) {
+	__arg := DecryptArchiveStateArg{DataToDecrypt: dataToDecrypt}
+	err = c.Cli.Call(ctx, "keybase.1.kbfs.decryptArchiveState", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c KbfsClient) ReserveDiskSpace(ctx context.Context, __arg ReserveDiskSpaceArg) (err error) {
+	err = c.Cli.Call(ctx, "keybase.1.kbfs.reserveDiskSpace", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c KbfsClient) ReleaseDiskSpace(ctx context.Context, key string) (err error) {
+	__arg := ReleaseDiskSpaceArg{Key: key}
+	err = c.Cli.Call(ctx, "keybase.1.kbfs.releaseDiskSpace", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
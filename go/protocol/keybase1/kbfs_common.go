@@ -53,6 +53,7 @@ const (
 	FSNotificationType_FILE_RENAMED        FSNotificationType = 10
 	FSNotificationType_INITIALIZED         FSNotificationType = 11
 	FSNotificationType_SYNC_CONFIG_CHANGED FSNotificationType = 12
+	FSNotificationType_ARCHIVE             FSNotificationType = 13
 )
 
 func (o FSNotificationType) DeepCopy() FSNotificationType { return o }
@@ -71,6 +72,7 @@ var FSNotificationTypeMap = map[string]FSNotificationType{
 	"FILE_RENAMED":        10,
 	"INITIALIZED":         11,
 	"SYNC_CONFIG_CHANGED": 12,
+	"ARCHIVE":             13,
 }
 
 var FSNotificationTypeRevMap = map[FSNotificationType]string{
@@ -87,6 +89,7 @@ var FSNotificationTypeRevMap = map[FSNotificationType]string{
 	10: "FILE_RENAMED",
 	11: "INITIALIZED",
 	12: "SYNC_CONFIG_CHANGED",
+	13: "ARCHIVE",
 }
 
 func (e FSNotificationType) String() string {
@@ -43,6 +43,7 @@ type NotificationChannels struct {
 	Saltpack             bool `codec:"saltpack" json:"saltpack"`
 	AllowChatNotifySkips bool `codec:"allowChatNotifySkips" json:"allowChatNotifySkips"`
 	Chatarchive          bool `codec:"chatarchive" json:"chatarchive"`
+	Simplefsarchive      bool `codec:"simplefsarchive" json:"simplefsarchive"`
 }
 
 func (o NotificationChannels) DeepCopy() NotificationChannels {
@@ -80,6 +81,7 @@ func (o NotificationChannels) DeepCopy() NotificationChannels {
 		Saltpack:             o.Saltpack,
 		AllowChatNotifySkips: o.AllowChatNotifySkips,
 		Chatarchive:          o.Chatarchive,
+		Simplefsarchive:      o.Simplefsarchive,
 	}
 }
 
@@ -55,6 +55,10 @@ type FSSubscriptionNotifyArg struct {
 	Topic           SubscriptionTopic `codec:"topic" json:"topic"`
 }
 
+type FSQuotaAlertArg struct {
+	Alert SimpleFSQuotaAlert `codec:"alert" json:"alert"`
+}
+
 type NotifyFSInterface interface {
 	FSActivity(context.Context, FSNotification) error
 	FSPathUpdated(context.Context, string) error
@@ -66,6 +70,7 @@ type NotifyFSInterface interface {
 	FSOnlineStatusChanged(context.Context, bool) error
 	FSSubscriptionNotifyPath(context.Context, FSSubscriptionNotifyPathArg) error
 	FSSubscriptionNotify(context.Context, FSSubscriptionNotifyArg) error
+	FSQuotaAlert(context.Context, SimpleFSQuotaAlert) error
 }
 
 func NotifyFSProtocol(i NotifyFSInterface) rpc.Protocol {
@@ -217,6 +222,21 @@ func NotifyFSProtocol(i NotifyFSInterface) rpc.Protocol {
 					return
 				},
 			},
+			"FSQuotaAlert": {
+				MakeArg: func() interface{} {
+					var ret [1]FSQuotaAlertArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]FSQuotaAlertArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]FSQuotaAlertArg)(nil), args)
+						return
+					}
+					err = i.FSQuotaAlert(ctx, typedArgs[0].Alert)
+					return
+				},
+			},
 		},
 	}
 }
@@ -279,3 +299,9 @@ func (c NotifyFSClient) FSSubscriptionNotify(ctx context.Context, __arg FSSubscr
 	err = c.Cli.Notify(ctx, "keybase.1.NotifyFS.FSSubscriptionNotify", []interface{}{__arg}, 0*time.Millisecond)
 	return
 }
+
+func (c NotifyFSClient) FSQuotaAlert(ctx context.Context, alert SimpleFSQuotaAlert) (err error) {
+	__arg := FSQuotaAlertArg{Alert: alert}
+	err = c.Cli.Notify(ctx, "keybase.1.NotifyFS.FSQuotaAlert", []interface{}{__arg}, 0*time.Millisecond)
+	return
+}
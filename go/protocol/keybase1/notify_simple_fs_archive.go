@@ -0,0 +1,51 @@
+// Auto-generated to Go types and interfaces using avdl-compiler v1.4.10 (https://github.com/keybase/node-avdl-compiler)
+//   Input file: avdl/keybase1/notify_simple_fs_archive.avdl
+
+package keybase1
+
+import (
+	"github.com/keybase/go-framed-msgpack-rpc/rpc"
+	context "golang.org/x/net/context"
+	"time"
+)
+
+type SimpleFSArchiveProgressArg struct {
+	Progress SimpleFSArchiveProgress `codec:"progress" json:"progress"`
+}
+
+type NotifySimpleFSArchiveProgressInterface interface {
+	SimpleFSArchiveProgress(context.Context, SimpleFSArchiveProgress) error
+}
+
+func NotifySimpleFSArchiveProgressProtocol(i NotifySimpleFSArchiveProgressInterface) rpc.Protocol {
+	return rpc.Protocol{
+		Name: "keybase.1.NotifySimpleFSArchiveProgress",
+		Methods: map[string]rpc.ServeHandlerDescription{
+			"simpleFSArchiveProgress": {
+				MakeArg: func() interface{} {
+					var ret [1]SimpleFSArchiveProgressArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SimpleFSArchiveProgressArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SimpleFSArchiveProgressArg)(nil), args)
+						return
+					}
+					err = i.SimpleFSArchiveProgress(ctx, typedArgs[0].Progress)
+					return
+				},
+			},
+		},
+	}
+}
+
+type NotifySimpleFSArchiveProgressClient struct {
+	Cli rpc.GenericClient
+}
+
+func (c NotifySimpleFSArchiveProgressClient) SimpleFSArchiveProgress(ctx context.Context, progress SimpleFSArchiveProgress) (err error) {
+	__arg := SimpleFSArchiveProgressArg{Progress: progress}
+	err = c.Cli.Notify(ctx, "keybase.1.NotifySimpleFSArchiveProgress.simpleFSArchiveProgress", []interface{}{__arg}, 0*time.Millisecond)
+	return
+}
@@ -4,6 +4,7 @@
 package keybase1
 
 import (
+	"fmt"
 	"github.com/keybase/go-framed-msgpack-rpc/rpc"
 	context "golang.org/x/net/context"
 	"time"
@@ -35,14 +36,55 @@ func (o StartProofResult) DeepCopy() StartProofResult {
 	}
 }
 
+type ProofProvisionerType int
+
+const (
+	ProofProvisionerType_CLOUDFLARE ProofProvisionerType = 0
+	ProofProvisionerType_WEBHOOK    ProofProvisionerType = 1
+)
+
+func (o ProofProvisionerType) DeepCopy() ProofProvisionerType { return o }
+
+var ProofProvisionerTypeMap = map[string]ProofProvisionerType{
+	"CLOUDFLARE": 0,
+	"WEBHOOK":    1,
+}
+
+var ProofProvisionerTypeRevMap = map[ProofProvisionerType]string{
+	0: "CLOUDFLARE",
+	1: "WEBHOOK",
+}
+
+func (e ProofProvisionerType) String() string {
+	if v, ok := ProofProvisionerTypeRevMap[e]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(e))
+}
+
+type ProofProvisioner struct {
+	Type     ProofProvisionerType `codec:"type" json:"type"`
+	ApiToken string               `codec:"apiToken" json:"apiToken"`
+	Url      string               `codec:"url" json:"url"`
+}
+
+func (o ProofProvisioner) DeepCopy() ProofProvisioner {
+	return ProofProvisioner{
+		Type:     o.Type.DeepCopy(),
+		ApiToken: o.ApiToken,
+		Url:      o.Url,
+	}
+}
+
 type StartProofArg struct {
-	SessionID    int         `codec:"sessionID" json:"sessionID"`
-	Service      string      `codec:"service" json:"service"`
-	Username     string      `codec:"username" json:"username"`
-	Force        bool        `codec:"force" json:"force"`
-	PromptPosted bool        `codec:"promptPosted" json:"promptPosted"`
-	Auto         bool        `codec:"auto" json:"auto"`
-	SigVersion   *SigVersion `codec:"sigVersion,omitempty" json:"sigVersion,omitempty"`
+	SessionID    int               `codec:"sessionID" json:"sessionID"`
+	Service      string            `codec:"service" json:"service"`
+	Username     string            `codec:"username" json:"username"`
+	Force        bool              `codec:"force" json:"force"`
+	PromptPosted bool              `codec:"promptPosted" json:"promptPosted"`
+	Auto         bool              `codec:"auto" json:"auto"`
+	SigVersion   *SigVersion       `codec:"sigVersion,omitempty" json:"sigVersion,omitempty"`
+	Provisioner  *ProofProvisioner `codec:"provisioner,omitempty" json:"provisioner,omitempty"`
 }
 
 type CheckProofArg struct {
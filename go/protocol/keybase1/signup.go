@@ -4,6 +4,7 @@
 package keybase1
 
 import (
+	"fmt"
 	"github.com/keybase/go-framed-msgpack-rpc/rpc"
 	context "golang.org/x/net/context"
 	"time"
@@ -25,27 +26,58 @@ func (o SignupRes) DeepCopy() SignupRes {
 	}
 }
 
+type SecretStoreBackend int
+
+const (
+	SecretStoreBackend_DEFAULT SecretStoreBackend = 0
+	SecretStoreBackend_FILE    SecretStoreBackend = 1
+	SecretStoreBackend_SYSTEM  SecretStoreBackend = 2
+)
+
+func (o SecretStoreBackend) DeepCopy() SecretStoreBackend { return o }
+
+var SecretStoreBackendMap = map[string]SecretStoreBackend{
+	"DEFAULT": 0,
+	"FILE":    1,
+	"SYSTEM":  2,
+}
+
+var SecretStoreBackendRevMap = map[SecretStoreBackend]string{
+	0: "DEFAULT",
+	1: "FILE",
+	2: "SYSTEM",
+}
+
+func (e SecretStoreBackend) String() string {
+	if v, ok := SecretStoreBackendRevMap[e]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(e))
+}
+
 type CheckUsernameAvailableArg struct {
 	SessionID int    `codec:"sessionID" json:"sessionID"`
 	Username  string `codec:"username" json:"username"`
 }
 
 type SignupArg struct {
-	SessionID   int        `codec:"sessionID" json:"sessionID"`
-	Email       string     `codec:"email" json:"email"`
-	InviteCode  string     `codec:"inviteCode" json:"inviteCode"`
-	Passphrase  string     `codec:"passphrase" json:"passphrase"`
-	Username    string     `codec:"username" json:"username"`
-	DeviceName  string     `codec:"deviceName" json:"deviceName"`
-	DeviceType  DeviceType `codec:"deviceType" json:"deviceType"`
-	StoreSecret bool       `codec:"storeSecret" json:"storeSecret"`
-	SkipMail    bool       `codec:"skipMail" json:"skipMail"`
-	GenPGPBatch bool       `codec:"genPGPBatch" json:"genPGPBatch"`
-	GenPaper    bool       `codec:"genPaper" json:"genPaper"`
-	RandomPw    bool       `codec:"randomPw" json:"randomPw"`
-	VerifyEmail bool       `codec:"verifyEmail" json:"verifyEmail"`
-	BotToken    BotToken   `codec:"botToken" json:"botToken"`
-	SkipGPG     bool       `codec:"skipGPG" json:"skipGPG"`
+	SessionID          int                `codec:"sessionID" json:"sessionID"`
+	Email              string             `codec:"email" json:"email"`
+	InviteCode         string             `codec:"inviteCode" json:"inviteCode"`
+	Passphrase         string             `codec:"passphrase" json:"passphrase"`
+	Username           string             `codec:"username" json:"username"`
+	DeviceName         string             `codec:"deviceName" json:"deviceName"`
+	DeviceType         DeviceType         `codec:"deviceType" json:"deviceType"`
+	StoreSecret        bool               `codec:"storeSecret" json:"storeSecret"`
+	SkipMail           bool               `codec:"skipMail" json:"skipMail"`
+	GenPGPBatch        bool               `codec:"genPGPBatch" json:"genPGPBatch"`
+	GenPaper           bool               `codec:"genPaper" json:"genPaper"`
+	RandomPw           bool               `codec:"randomPw" json:"randomPw"`
+	VerifyEmail        bool               `codec:"verifyEmail" json:"verifyEmail"`
+	BotToken           BotToken           `codec:"botToken" json:"botToken"`
+	SkipGPG            bool               `codec:"skipGPG" json:"skipGPG"`
+	Headless           bool               `codec:"headless" json:"headless"`
+	SecretStoreBackend SecretStoreBackend `codec:"secretStoreBackend" json:"secretStoreBackend"`
 }
 
 type InviteRequestArg struct {
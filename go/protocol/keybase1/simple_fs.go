@@ -453,6 +453,7 @@ type Dirent struct {
 	PrefetchStatus       PrefetchStatus   `codec:"prefetchStatus" json:"prefetchStatus"`
 	PrefetchProgress     PrefetchProgress `codec:"prefetchProgress" json:"prefetchProgress"`
 	SymlinkTarget        string           `codec:"symlinkTarget" json:"symlinkTarget"`
+	MimeType             string           `codec:"mimeType" json:"mimeType"`
 }
 
 func (o Dirent) DeepCopy() Dirent {
@@ -466,6 +467,7 @@ func (o Dirent) DeepCopy() Dirent {
 		PrefetchStatus:       o.PrefetchStatus.DeepCopy(),
 		PrefetchProgress:     o.PrefetchProgress.DeepCopy(),
 		SymlinkTarget:        o.SymlinkTarget,
+		MimeType:             o.MimeType,
 	}
 }
 
@@ -1146,6 +1148,44 @@ func (o SimpleFSQuotaUsage) DeepCopy() SimpleFSQuotaUsage {
 	}
 }
 
+type SimpleFSQuotaAlertSettings struct {
+	Thresholds []int `codec:"thresholds" json:"thresholds"`
+	ChatToSelf bool  `codec:"chatToSelf" json:"chatToSelf"`
+}
+
+func (o SimpleFSQuotaAlertSettings) DeepCopy() SimpleFSQuotaAlertSettings {
+	return SimpleFSQuotaAlertSettings{
+		Thresholds: (func(x []int) []int {
+			if x == nil {
+				return nil
+			}
+			ret := make([]int, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Thresholds),
+		ChatToSelf: o.ChatToSelf,
+	}
+}
+
+type SimpleFSQuotaAlert struct {
+	Threshold  int   `codec:"threshold" json:"threshold"`
+	UsageBytes int64 `codec:"usageBytes" json:"usageBytes"`
+	LimitBytes int64 `codec:"limitBytes" json:"limitBytes"`
+	ChatToSelf bool  `codec:"chatToSelf" json:"chatToSelf"`
+}
+
+func (o SimpleFSQuotaAlert) DeepCopy() SimpleFSQuotaAlert {
+	return SimpleFSQuotaAlert{
+		Threshold:  o.Threshold,
+		UsageBytes: o.UsageBytes,
+		LimitBytes: o.LimitBytes,
+		ChatToSelf: o.ChatToSelf,
+	}
+}
+
 type FolderSyncMode int
 
 const (
@@ -1670,14 +1710,328 @@ func (o SimpleFSIndexProgress) DeepCopy() SimpleFSIndexProgress {
 	}
 }
 
+type SimpleFSCacheDB int
+
+const (
+	SimpleFSCacheDB_BLOCK_CACHE SimpleFSCacheDB = 0
+	SimpleFSCacheDB_SYNC_CACHE  SimpleFSCacheDB = 1
+	SimpleFSCacheDB_SYNC_CONFIG SimpleFSCacheDB = 2
+)
+
+func (o SimpleFSCacheDB) DeepCopy() SimpleFSCacheDB { return o }
+
+var SimpleFSCacheDBMap = map[string]SimpleFSCacheDB{
+	"BLOCK_CACHE": 0,
+	"SYNC_CACHE":  1,
+	"SYNC_CONFIG": 2,
+}
+
+var SimpleFSCacheDBRevMap = map[SimpleFSCacheDB]string{
+	0: "BLOCK_CACHE",
+	1: "SYNC_CACHE",
+	2: "SYNC_CONFIG",
+}
+
+func (e SimpleFSCacheDB) String() string {
+	if v, ok := SimpleFSCacheDBRevMap[e]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(e))
+}
+
+type SimpleFSCacheDBRepair struct {
+	Db             SimpleFSCacheDB `codec:"db" json:"db"`
+	Time           Time            `codec:"time" json:"time"`
+	Reason         string          `codec:"reason" json:"reason"`
+	QuarantinePath string          `codec:"quarantinePath" json:"quarantinePath"`
+}
+
+func (o SimpleFSCacheDBRepair) DeepCopy() SimpleFSCacheDBRepair {
+	return SimpleFSCacheDBRepair{
+		Db:             o.Db.DeepCopy(),
+		Time:           o.Time.DeepCopy(),
+		Reason:         o.Reason,
+		QuarantinePath: o.QuarantinePath,
+	}
+}
+
+type SimpleFSCacheRepairProgress struct {
+	InProgress bool                    `codec:"inProgress" json:"inProgress"`
+	CurrDB     SimpleFSCacheDB         `codec:"currDB" json:"currDB"`
+	DbsDone    int                     `codec:"dbsDone" json:"dbsDone"`
+	DbsTotal   int                     `codec:"dbsTotal" json:"dbsTotal"`
+	StartTime  Time                    `codec:"startTime" json:"startTime"`
+	EndTime    Time                    `codec:"endTime" json:"endTime"`
+	Error      string                  `codec:"error" json:"error"`
+	Repairs    []SimpleFSCacheDBRepair `codec:"repairs" json:"repairs"`
+}
+
+func (o SimpleFSCacheRepairProgress) DeepCopy() SimpleFSCacheRepairProgress {
+	return SimpleFSCacheRepairProgress{
+		InProgress: o.InProgress,
+		CurrDB:     o.CurrDB.DeepCopy(),
+		DbsDone:    o.DbsDone,
+		DbsTotal:   o.DbsTotal,
+		StartTime:  o.StartTime.DeepCopy(),
+		EndTime:    o.EndTime.DeepCopy(),
+		Error:      o.Error,
+		Repairs: (func(x []SimpleFSCacheDBRepair) []SimpleFSCacheDBRepair {
+			if x == nil {
+				return nil
+			}
+			ret := make([]SimpleFSCacheDBRepair, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Repairs),
+	}
+}
+
+type SimpleFSArchiveSource struct {
+	Path KBFSArchivedPath `codec:"path" json:"path"`
+	Root string           `codec:"root" json:"root"`
+}
+
+func (o SimpleFSArchiveSource) DeepCopy() SimpleFSArchiveSource {
+	return SimpleFSArchiveSource{
+		Path: o.Path.DeepCopy(),
+		Root: o.Root,
+	}
+}
+
+type SimpleFSArchiveDestinationType int
+
+const (
+	SimpleFSArchiveDestinationType_Local  SimpleFSArchiveDestinationType = 0
+	SimpleFSArchiveDestinationType_S3     SimpleFSArchiveDestinationType = 1
+	SimpleFSArchiveDestinationType_WebDAV SimpleFSArchiveDestinationType = 2
+	SimpleFSArchiveDestinationType_SFTP   SimpleFSArchiveDestinationType = 3
+)
+
+func (o SimpleFSArchiveDestinationType) DeepCopy() SimpleFSArchiveDestinationType { return o }
+
+var SimpleFSArchiveDestinationTypeMap = map[string]SimpleFSArchiveDestinationType{
+	"Local":  0,
+	"S3":     1,
+	"WebDAV": 2,
+	"SFTP":   3,
+}
+
+var SimpleFSArchiveDestinationTypeRevMap = map[SimpleFSArchiveDestinationType]string{
+	0: "Local",
+	1: "S3",
+	2: "WebDAV",
+	3: "SFTP",
+}
+
+func (e SimpleFSArchiveDestinationType) String() string {
+	if v, ok := SimpleFSArchiveDestinationTypeRevMap[e]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(e))
+}
+
+type SimpleFSArchiveDestination struct {
+	Type           SimpleFSArchiveDestinationType `codec:"type" json:"type"`
+	Url            string                         `codec:"url" json:"url"`
+	Region         string                         `codec:"region" json:"region"`
+	Endpoint       string                         `codec:"endpoint" json:"endpoint"`
+	Profile        string                         `codec:"profile" json:"profile"`
+	Username       string                         `codec:"username" json:"username"`
+	Password       string                         `codec:"password" json:"password"`
+	PrivateKeyPath string                         `codec:"privateKeyPath" json:"privateKeyPath"`
+	KnownHostsPath string                         `codec:"knownHostsPath" json:"knownHostsPath"`
+}
+
+func (o SimpleFSArchiveDestination) DeepCopy() SimpleFSArchiveDestination {
+	return SimpleFSArchiveDestination{
+		Type:           o.Type.DeepCopy(),
+		Url:            o.Url,
+		Region:         o.Region,
+		Endpoint:       o.Endpoint,
+		Profile:        o.Profile,
+		Username:       o.Username,
+		Password:       o.Password,
+		PrivateKeyPath: o.PrivateKeyPath,
+		KnownHostsPath: o.KnownHostsPath,
+	}
+}
+
+type SimpleFSArchiveCompression int
+
+const (
+	SimpleFSArchiveCompression_DEFAULT SimpleFSArchiveCompression = 0
+	SimpleFSArchiveCompression_STORE   SimpleFSArchiveCompression = 1
+	SimpleFSArchiveCompression_FAST    SimpleFSArchiveCompression = 2
+	SimpleFSArchiveCompression_BEST    SimpleFSArchiveCompression = 3
+)
+
+func (o SimpleFSArchiveCompression) DeepCopy() SimpleFSArchiveCompression { return o }
+
+var SimpleFSArchiveCompressionMap = map[string]SimpleFSArchiveCompression{
+	"DEFAULT": 0,
+	"STORE":   1,
+	"FAST":    2,
+	"BEST":    3,
+}
+
+var SimpleFSArchiveCompressionRevMap = map[SimpleFSArchiveCompression]string{
+	0: "DEFAULT",
+	1: "STORE",
+	2: "FAST",
+	3: "BEST",
+}
+
+func (e SimpleFSArchiveCompression) String() string {
+	if v, ok := SimpleFSArchiveCompressionRevMap[e]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(e))
+}
+
+type SimpleFSArchiveSpecialFiles int
+
+const (
+	SimpleFSArchiveSpecialFiles_SKIP  SimpleFSArchiveSpecialFiles = 0
+	SimpleFSArchiveSpecialFiles_ERROR SimpleFSArchiveSpecialFiles = 1
+)
+
+func (o SimpleFSArchiveSpecialFiles) DeepCopy() SimpleFSArchiveSpecialFiles { return o }
+
+var SimpleFSArchiveSpecialFilesMap = map[string]SimpleFSArchiveSpecialFiles{
+	"SKIP":  0,
+	"ERROR": 1,
+}
+
+var SimpleFSArchiveSpecialFilesRevMap = map[SimpleFSArchiveSpecialFiles]string{
+	0: "SKIP",
+	1: "ERROR",
+}
+
+func (e SimpleFSArchiveSpecialFiles) String() string {
+	if v, ok := SimpleFSArchiveSpecialFilesRevMap[e]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(e))
+}
+
+type SimpleFSArchiveSymlinks int
+
+const (
+	SimpleFSArchiveSymlinks_KEEP   SimpleFSArchiveSymlinks = 0
+	SimpleFSArchiveSymlinks_FOLLOW SimpleFSArchiveSymlinks = 1
+	SimpleFSArchiveSymlinks_SKIP   SimpleFSArchiveSymlinks = 2
+)
+
+func (o SimpleFSArchiveSymlinks) DeepCopy() SimpleFSArchiveSymlinks { return o }
+
+var SimpleFSArchiveSymlinksMap = map[string]SimpleFSArchiveSymlinks{
+	"KEEP":   0,
+	"FOLLOW": 1,
+	"SKIP":   2,
+}
+
+var SimpleFSArchiveSymlinksRevMap = map[SimpleFSArchiveSymlinks]string{
+	0: "KEEP",
+	1: "FOLLOW",
+	2: "SKIP",
+}
+
+func (e SimpleFSArchiveSymlinks) String() string {
+	if v, ok := SimpleFSArchiveSymlinksRevMap[e]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(e))
+}
+
+type SimpleFSArchiveLayout int
+
+const (
+	SimpleFSArchiveLayout_Tree  SimpleFSArchiveLayout = 0
+	SimpleFSArchiveLayout_Store SimpleFSArchiveLayout = 1
+)
+
+func (o SimpleFSArchiveLayout) DeepCopy() SimpleFSArchiveLayout { return o }
+
+var SimpleFSArchiveLayoutMap = map[string]SimpleFSArchiveLayout{
+	"Tree":  0,
+	"Store": 1,
+}
+
+var SimpleFSArchiveLayoutRevMap = map[SimpleFSArchiveLayout]string{
+	0: "Tree",
+	1: "Store",
+}
+
+func (e SimpleFSArchiveLayout) String() string {
+	if v, ok := SimpleFSArchiveLayoutRevMap[e]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(e))
+}
+
+type SimpleFSArchiveJobType int
+
+const (
+	SimpleFSArchiveJobType_Archive SimpleFSArchiveJobType = 0
+	SimpleFSArchiveJobType_Restore SimpleFSArchiveJobType = 1
+)
+
+func (o SimpleFSArchiveJobType) DeepCopy() SimpleFSArchiveJobType { return o }
+
+var SimpleFSArchiveJobTypeMap = map[string]SimpleFSArchiveJobType{
+	"Archive": 0,
+	"Restore": 1,
+}
+
+var SimpleFSArchiveJobTypeRevMap = map[SimpleFSArchiveJobType]string{
+	0: "Archive",
+	1: "Restore",
+}
+
+func (e SimpleFSArchiveJobType) String() string {
+	if v, ok := SimpleFSArchiveJobTypeRevMap[e]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(e))
+}
+
 type SimpleFSArchiveJobDesc struct {
-	JobID                string           `codec:"jobID" json:"jobID"`
-	KbfsPathWithRevision KBFSArchivedPath `codec:"kbfsPathWithRevision" json:"kbfsPathWithRevision"`
-	OverwriteZip         bool             `codec:"overwriteZip" json:"overwriteZip"`
-	StartTime            Time             `codec:"startTime" json:"startTime"`
-	StagingPath          string           `codec:"stagingPath" json:"stagingPath"`
-	TargetName           string           `codec:"targetName" json:"targetName"`
-	ZipFilePath          string           `codec:"zipFilePath" json:"zipFilePath"`
+	JobID                string                      `codec:"jobID" json:"jobID"`
+	KbfsPathWithRevision KBFSArchivedPath            `codec:"kbfsPathWithRevision" json:"kbfsPathWithRevision"`
+	OverwriteZip         bool                        `codec:"overwriteZip" json:"overwriteZip"`
+	StartTime            Time                        `codec:"startTime" json:"startTime"`
+	StagingPath          string                      `codec:"stagingPath" json:"stagingPath"`
+	TargetName           string                      `codec:"targetName" json:"targetName"`
+	ZipFilePath          string                      `codec:"zipFilePath" json:"zipFilePath"`
+	BytesPerSecond       int64                       `codec:"bytesPerSecond" json:"bytesPerSecond"`
+	ScheduleID           string                      `codec:"scheduleID" json:"scheduleID"`
+	BaseJobID            string                      `codec:"baseJobID" json:"baseJobID"`
+	BaseRevision         KBFSRevision                `codec:"baseRevision" json:"baseRevision"`
+	IncludeGlobs         []string                    `codec:"includeGlobs" json:"includeGlobs"`
+	ExcludeGlobs         []string                    `codec:"excludeGlobs" json:"excludeGlobs"`
+	MaxFileSize          int64                       `codec:"maxFileSize" json:"maxFileSize"`
+	WriteManifestJSON    bool                        `codec:"writeManifestJSON" json:"writeManifestJSON"`
+	Priority             int                         `codec:"priority" json:"priority"`
+	Sources              []SimpleFSArchiveSource     `codec:"sources" json:"sources"`
+	Destination          SimpleFSArchiveDestination  `codec:"destination" json:"destination"`
+	JobType              SimpleFSArchiveJobType      `codec:"jobType" json:"jobType"`
+	ManifestPath         string                      `codec:"manifestPath" json:"manifestPath"`
+	OverwriteExisting    bool                        `codec:"overwriteExisting" json:"overwriteExisting"`
+	MaxVolumeBytes       int64                       `codec:"maxVolumeBytes" json:"maxVolumeBytes"`
+	Compression          SimpleFSArchiveCompression  `codec:"compression" json:"compression"`
+	BestEffort           bool                        `codec:"bestEffort" json:"bestEffort"`
+	MaxRetries           int                         `codec:"maxRetries" json:"maxRetries"`
+	AllRevisions         bool                        `codec:"allRevisions" json:"allRevisions"`
+	MaxRevisions         int                         `codec:"maxRevisions" json:"maxRevisions"`
+	RevisionsSince       Time                        `codec:"revisionsSince" json:"revisionsSince"`
+	SpecialFiles         SimpleFSArchiveSpecialFiles `codec:"specialFiles" json:"specialFiles"`
+	Symlinks             SimpleFSArchiveSymlinks     `codec:"symlinks" json:"symlinks"`
+	Label                string                      `codec:"label" json:"label"`
+	Notes                string                      `codec:"notes" json:"notes"`
+	Layout               SimpleFSArchiveLayout       `codec:"layout" json:"layout"`
 }
 
 func (o SimpleFSArchiveJobDesc) DeepCopy() SimpleFSArchiveJobDesc {
@@ -1689,6 +2043,62 @@ func (o SimpleFSArchiveJobDesc) DeepCopy() SimpleFSArchiveJobDesc {
 		StagingPath:          o.StagingPath,
 		TargetName:           o.TargetName,
 		ZipFilePath:          o.ZipFilePath,
+		BytesPerSecond:       o.BytesPerSecond,
+		ScheduleID:           o.ScheduleID,
+		BaseJobID:            o.BaseJobID,
+		BaseRevision:         o.BaseRevision.DeepCopy(),
+		IncludeGlobs: (func(x []string) []string {
+			if x == nil {
+				return nil
+			}
+			ret := make([]string, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.IncludeGlobs),
+		ExcludeGlobs: (func(x []string) []string {
+			if x == nil {
+				return nil
+			}
+			ret := make([]string, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.ExcludeGlobs),
+		MaxFileSize:       o.MaxFileSize,
+		WriteManifestJSON: o.WriteManifestJSON,
+		Priority:          o.Priority,
+		Sources: (func(x []SimpleFSArchiveSource) []SimpleFSArchiveSource {
+			if x == nil {
+				return nil
+			}
+			ret := make([]SimpleFSArchiveSource, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Sources),
+		Destination:       o.Destination.DeepCopy(),
+		JobType:           o.JobType.DeepCopy(),
+		ManifestPath:      o.ManifestPath,
+		OverwriteExisting: o.OverwriteExisting,
+		MaxVolumeBytes:    o.MaxVolumeBytes,
+		Compression:       o.Compression.DeepCopy(),
+		BestEffort:        o.BestEffort,
+		MaxRetries:        o.MaxRetries,
+		AllRevisions:      o.AllRevisions,
+		MaxRevisions:      o.MaxRevisions,
+		RevisionsSince:    o.RevisionsSince.DeepCopy(),
+		SpecialFiles:      o.SpecialFiles.DeepCopy(),
+		Symlinks:          o.Symlinks.DeepCopy(),
+		Label:             o.Label,
+		Notes:             o.Notes,
+		Layout:            o.Layout.DeepCopy(),
 	}
 }
 
@@ -1699,6 +2109,7 @@ const (
 	SimpleFSFileArchiveState_InProgress SimpleFSFileArchiveState = 1
 	SimpleFSFileArchiveState_Complete   SimpleFSFileArchiveState = 2
 	SimpleFSFileArchiveState_Skipped    SimpleFSFileArchiveState = 3
+	SimpleFSFileArchiveState_Unchanged  SimpleFSFileArchiveState = 4
 )
 
 func (o SimpleFSFileArchiveState) DeepCopy() SimpleFSFileArchiveState { return o }
@@ -1708,6 +2119,7 @@ var SimpleFSFileArchiveStateMap = map[string]SimpleFSFileArchiveState{
 	"InProgress": 1,
 	"Complete":   2,
 	"Skipped":    3,
+	"Unchanged":  4,
 }
 
 var SimpleFSFileArchiveStateRevMap = map[SimpleFSFileArchiveState]string{
@@ -1715,6 +2127,7 @@ var SimpleFSFileArchiveStateRevMap = map[SimpleFSFileArchiveState]string{
 	1: "InProgress",
 	2: "Complete",
 	3: "Skipped",
+	4: "Unchanged",
 }
 
 func (e SimpleFSFileArchiveState) String() string {
@@ -1724,144 +2137,404 @@ func (e SimpleFSFileArchiveState) String() string {
 	return fmt.Sprintf("%v", int(e))
 }
 
-type SimpleFSArchiveFile struct {
-	State        SimpleFSFileArchiveState `codec:"state" json:"state"`
-	DirentType   DirentType               `codec:"direntType" json:"direntType"`
-	Sha256SumHex string                   `codec:"sha256SumHex" json:"sha256SumHex"`
+type SimpleFSArchiveCopyCheckpoint struct {
+	Offset      int64  `codec:"offset" json:"offset"`
+	Sha256State []byte `codec:"sha256State" json:"sha256State"`
 }
 
-func (o SimpleFSArchiveFile) DeepCopy() SimpleFSArchiveFile {
-	return SimpleFSArchiveFile{
-		State:        o.State.DeepCopy(),
-		DirentType:   o.DirentType.DeepCopy(),
-		Sha256SumHex: o.Sha256SumHex,
+func (o SimpleFSArchiveCopyCheckpoint) DeepCopy() SimpleFSArchiveCopyCheckpoint {
+	return SimpleFSArchiveCopyCheckpoint{
+		Offset: o.Offset,
+		Sha256State: (func(x []byte) []byte {
+			if x == nil {
+				return nil
+			}
+			return append([]byte{}, x...)
+		})(o.Sha256State),
 	}
 }
 
-type SimpleFSArchiveJobState struct {
-	Desc        SimpleFSArchiveJobDesc         `codec:"desc" json:"desc"`
-	Manifest    map[string]SimpleFSArchiveFile `codec:"manifest" json:"manifest"`
-	Phase       SimpleFSArchiveJobPhase        `codec:"phase" json:"phase"`
-	BytesTotal  int64                          `codec:"bytesTotal" json:"bytesTotal"`
-	BytesCopied int64                          `codec:"bytesCopied" json:"bytesCopied"`
-	BytesZipped int64                          `codec:"bytesZipped" json:"bytesZipped"`
+type SimpleFSArchiveFile struct {
+	State            SimpleFSFileArchiveState       `codec:"state" json:"state"`
+	DirentType       DirentType                     `codec:"direntType" json:"direntType"`
+	Sha256SumHex     string                         `codec:"sha256SumHex" json:"sha256SumHex"`
+	Volume           int                            `codec:"volume" json:"volume"`
+	Error            string                         `codec:"error" json:"error"`
+	DuplicateOf      string                         `codec:"duplicateOf" json:"duplicateOf"`
+	Mtime            Time                           `codec:"mtime" json:"mtime"`
+	Mode             int                            `codec:"mode" json:"mode"`
+	FidelityLoss     string                         `codec:"fidelityLoss" json:"fidelityLoss"`
+	SymlinkTarget    string                         `codec:"symlinkTarget" json:"symlinkTarget"`
+	Symlink          SimpleFSArchiveSymlinks        `codec:"symlink" json:"symlink"`
+	MaterializedFrom string                         `codec:"materializedFrom" json:"materializedFrom"`
+	CopyCheckpoint   *SimpleFSArchiveCopyCheckpoint `codec:"copyCheckpoint,omitempty" json:"copyCheckpoint,omitempty"`
 }
 
-func (o SimpleFSArchiveJobState) DeepCopy() SimpleFSArchiveJobState {
-	return SimpleFSArchiveJobState{
-		Desc: o.Desc.DeepCopy(),
-		Manifest: (func(x map[string]SimpleFSArchiveFile) map[string]SimpleFSArchiveFile {
+func (o SimpleFSArchiveFile) DeepCopy() SimpleFSArchiveFile {
+	return SimpleFSArchiveFile{
+		State:            o.State.DeepCopy(),
+		DirentType:       o.DirentType.DeepCopy(),
+		Sha256SumHex:     o.Sha256SumHex,
+		Volume:           o.Volume,
+		Error:            o.Error,
+		DuplicateOf:      o.DuplicateOf,
+		Mtime:            o.Mtime.DeepCopy(),
+		Mode:             o.Mode,
+		FidelityLoss:     o.FidelityLoss,
+		SymlinkTarget:    o.SymlinkTarget,
+		Symlink:          o.Symlink.DeepCopy(),
+		MaterializedFrom: o.MaterializedFrom,
+		CopyCheckpoint: (func(x *SimpleFSArchiveCopyCheckpoint) *SimpleFSArchiveCopyCheckpoint {
 			if x == nil {
 				return nil
 			}
-			ret := make(map[string]SimpleFSArchiveFile, len(x))
-			for k, v := range x {
-				kCopy := k
-				vCopy := v.DeepCopy()
-				ret[kCopy] = vCopy
-			}
-			return ret
-		})(o.Manifest),
-		Phase:       o.Phase.DeepCopy(),
-		BytesTotal:  o.BytesTotal,
-		BytesCopied: o.BytesCopied,
-		BytesZipped: o.BytesZipped,
+			tmp := (*x).DeepCopy()
+			return &tmp
+		})(o.CopyCheckpoint),
 	}
 }
 
-type SimpleFSArchiveJobPhase int
+type SimpleFSArchiveFidelityLoss struct {
+	Path string `codec:"path" json:"path"`
+	Loss string `codec:"loss" json:"loss"`
+}
+
+func (o SimpleFSArchiveFidelityLoss) DeepCopy() SimpleFSArchiveFidelityLoss {
+	return SimpleFSArchiveFidelityLoss{
+		Path: o.Path,
+		Loss: o.Loss,
+	}
+}
+
+type SimpleFSArchiveLargeFile struct {
+	Path string `codec:"path" json:"path"`
+	Size int64  `codec:"size" json:"size"`
+}
+
+func (o SimpleFSArchiveLargeFile) DeepCopy() SimpleFSArchiveLargeFile {
+	return SimpleFSArchiveLargeFile{
+		Path: o.Path,
+		Size: o.Size,
+	}
+}
+
+type SimpleFSArchiveSkippedFile struct {
+	Path  string `codec:"path" json:"path"`
+	Error string `codec:"error" json:"error"`
+}
+
+func (o SimpleFSArchiveSkippedFile) DeepCopy() SimpleFSArchiveSkippedFile {
+	return SimpleFSArchiveSkippedFile{
+		Path:  o.Path,
+		Error: o.Error,
+	}
+}
+
+type SimpleFSArchiveJobErrorClass int
 
 const (
-	SimpleFSArchiveJobPhase_Queued   SimpleFSArchiveJobPhase = 0
-	SimpleFSArchiveJobPhase_Indexing SimpleFSArchiveJobPhase = 1
-	SimpleFSArchiveJobPhase_Indexed  SimpleFSArchiveJobPhase = 2
-	SimpleFSArchiveJobPhase_Copying  SimpleFSArchiveJobPhase = 3
-	SimpleFSArchiveJobPhase_Copied   SimpleFSArchiveJobPhase = 4
-	SimpleFSArchiveJobPhase_Zipping  SimpleFSArchiveJobPhase = 5
-	SimpleFSArchiveJobPhase_Done     SimpleFSArchiveJobPhase = 6
+	SimpleFSArchiveJobErrorClass_Retryable SimpleFSArchiveJobErrorClass = 0
+	SimpleFSArchiveJobErrorClass_Fatal     SimpleFSArchiveJobErrorClass = 1
 )
 
-func (o SimpleFSArchiveJobPhase) DeepCopy() SimpleFSArchiveJobPhase { return o }
+func (o SimpleFSArchiveJobErrorClass) DeepCopy() SimpleFSArchiveJobErrorClass { return o }
 
-var SimpleFSArchiveJobPhaseMap = map[string]SimpleFSArchiveJobPhase{
-	"Queued":   0,
-	"Indexing": 1,
-	"Indexed":  2,
-	"Copying":  3,
-	"Copied":   4,
-	"Zipping":  5,
-	"Done":     6,
+var SimpleFSArchiveJobErrorClassMap = map[string]SimpleFSArchiveJobErrorClass{
+	"Retryable": 0,
+	"Fatal":     1,
 }
 
-var SimpleFSArchiveJobPhaseRevMap = map[SimpleFSArchiveJobPhase]string{
-	0: "Queued",
-	1: "Indexing",
-	2: "Indexed",
-	3: "Copying",
-	4: "Copied",
-	5: "Zipping",
-	6: "Done",
+var SimpleFSArchiveJobErrorClassRevMap = map[SimpleFSArchiveJobErrorClass]string{
+	0: "Retryable",
+	1: "Fatal",
 }
 
-func (e SimpleFSArchiveJobPhase) String() string {
-	if v, ok := SimpleFSArchiveJobPhaseRevMap[e]; ok {
+func (e SimpleFSArchiveJobErrorClass) String() string {
+	if v, ok := SimpleFSArchiveJobErrorClassRevMap[e]; ok {
 		return v
 	}
 	return fmt.Sprintf("%v", int(e))
 }
 
-type SimpleFSArchiveState struct {
-	Jobs        map[string]SimpleFSArchiveJobState `codec:"jobs" json:"jobs"`
-	LastUpdated Time                               `codec:"lastUpdated" json:"lastUpdated"`
+type SimpleFSArchiveJobErrorState struct {
+	Error      string                       `codec:"error" json:"error"`
+	NextRetry  Time                         `codec:"nextRetry" json:"nextRetry"`
+	ErrorClass SimpleFSArchiveJobErrorClass `codec:"errorClass" json:"errorClass"`
+	RetryCount int                          `codec:"retryCount" json:"retryCount"`
 }
 
-func (o SimpleFSArchiveState) DeepCopy() SimpleFSArchiveState {
-	return SimpleFSArchiveState{
-		Jobs: (func(x map[string]SimpleFSArchiveJobState) map[string]SimpleFSArchiveJobState {
-			if x == nil {
-				return nil
-			}
-			ret := make(map[string]SimpleFSArchiveJobState, len(x))
-			for k, v := range x {
-				kCopy := k
-				vCopy := v.DeepCopy()
-				ret[kCopy] = vCopy
-			}
-			return ret
-		})(o.Jobs),
-		LastUpdated: o.LastUpdated.DeepCopy(),
+func (o SimpleFSArchiveJobErrorState) DeepCopy() SimpleFSArchiveJobErrorState {
+	return SimpleFSArchiveJobErrorState{
+		Error:      o.Error,
+		NextRetry:  o.NextRetry.DeepCopy(),
+		ErrorClass: o.ErrorClass.DeepCopy(),
+		RetryCount: o.RetryCount,
 	}
 }
 
-type SimpleFSArchiveJobErrorState struct {
-	Error     string `codec:"error" json:"error"`
-	NextRetry Time   `codec:"nextRetry" json:"nextRetry"`
+type SimpleFSArchiveJobThroughput struct {
+	Copying   float64 `codec:"copying" json:"copying"`
+	Zipping   float64 `codec:"zipping" json:"zipping"`
+	Uploading float64 `codec:"uploading" json:"uploading"`
 }
 
-func (o SimpleFSArchiveJobErrorState) DeepCopy() SimpleFSArchiveJobErrorState {
-	return SimpleFSArchiveJobErrorState{
-		Error:     o.Error,
-		NextRetry: o.NextRetry.DeepCopy(),
+func (o SimpleFSArchiveJobThroughput) DeepCopy() SimpleFSArchiveJobThroughput {
+	return SimpleFSArchiveJobThroughput{
+		Copying:   o.Copying,
+		Zipping:   o.Zipping,
+		Uploading: o.Uploading,
 	}
 }
 
-type SimpleFSArchiveJobStatus struct {
-	Desc               SimpleFSArchiveJobDesc        `codec:"desc" json:"desc"`
-	Phase              SimpleFSArchiveJobPhase       `codec:"phase" json:"phase"`
-	CurrentTLFRevision KBFSRevision                  `codec:"currentTLFRevision" json:"currentTLFRevision"`
-	TodoCount          int                           `codec:"todoCount" json:"todoCount"`
-	InProgressCount    int                           `codec:"inProgressCount" json:"inProgressCount"`
-	CompleteCount      int                           `codec:"completeCount" json:"completeCount"`
-	SkippedCount       int                           `codec:"skippedCount" json:"skippedCount"`
-	TotalCount         int                           `codec:"totalCount" json:"totalCount"`
-	BytesTotal         int64                         `codec:"bytesTotal" json:"bytesTotal"`
-	BytesCopied        int64                         `codec:"bytesCopied" json:"bytesCopied"`
-	BytesZipped        int64                         `codec:"bytesZipped" json:"bytesZipped"`
-	Error              *SimpleFSArchiveJobErrorState `codec:"error,omitempty" json:"error,omitempty"`
+type SimpleFSArchiveJobState struct {
+	Desc                SimpleFSArchiveJobDesc         `codec:"desc" json:"desc"`
+	Manifest            map[string]SimpleFSArchiveFile `codec:"manifest" json:"manifest"`
+	Phase               SimpleFSArchiveJobPhase        `codec:"phase" json:"phase"`
+	BytesTotal          int64                          `codec:"bytesTotal" json:"bytesTotal"`
+	BytesCopied         int64                          `codec:"bytesCopied" json:"bytesCopied"`
+	BytesZipped         int64                          `codec:"bytesZipped" json:"bytesZipped"`
+	BaseManifest        map[string]SimpleFSArchiveFile `codec:"baseManifest" json:"baseManifest"`
+	Deleted             []string                       `codec:"deleted" json:"deleted"`
+	SkippedLargeFiles   []SimpleFSArchiveLargeFile     `codec:"skippedLargeFiles" json:"skippedLargeFiles"`
+	Error               string                         `codec:"error" json:"error"`
+	Paused              bool                           `codec:"paused" json:"paused"`
+	Zipped              bool                           `codec:"zipped" json:"zipped"`
+	BytesUploaded       int64                          `codec:"bytesUploaded" json:"bytesUploaded"`
+	VolumePaths         []string                       `codec:"volumePaths" json:"volumePaths"`
+	ErrorState          *SimpleFSArchiveJobErrorState  `codec:"errorState,omitempty" json:"errorState,omitempty"`
+	RetryCount          int                            `codec:"retryCount" json:"retryCount"`
+	RetryPhase          SimpleFSArchiveJobPhase        `codec:"retryPhase" json:"retryPhase"`
+	Throughput          SimpleFSArchiveJobThroughput   `codec:"throughput" json:"throughput"`
+	EstimatedCompletion Time                           `codec:"estimatedCompletion" json:"estimatedCompletion"`
+	DoneTime            Time                           `codec:"doneTime" json:"doneTime"`
+	SummaryPhase        SimpleFSArchiveJobPhase        `codec:"summaryPhase" json:"summaryPhase"`
+	VerifiedAt          Time                           `codec:"verifiedAt" json:"verifiedAt"`
+	VerifyIssues        []SimpleFSArchiveFileCheck     `codec:"verifyIssues" json:"verifyIssues"`
 }
 
-func (o SimpleFSArchiveJobStatus) DeepCopy() SimpleFSArchiveJobStatus {
-	return SimpleFSArchiveJobStatus{
+func (o SimpleFSArchiveJobState) DeepCopy() SimpleFSArchiveJobState {
+	return SimpleFSArchiveJobState{
+		Desc: o.Desc.DeepCopy(),
+		Manifest: (func(x map[string]SimpleFSArchiveFile) map[string]SimpleFSArchiveFile {
+			if x == nil {
+				return nil
+			}
+			ret := make(map[string]SimpleFSArchiveFile, len(x))
+			for k, v := range x {
+				kCopy := k
+				vCopy := v.DeepCopy()
+				ret[kCopy] = vCopy
+			}
+			return ret
+		})(o.Manifest),
+		Phase:       o.Phase.DeepCopy(),
+		BytesTotal:  o.BytesTotal,
+		BytesCopied: o.BytesCopied,
+		BytesZipped: o.BytesZipped,
+		BaseManifest: (func(x map[string]SimpleFSArchiveFile) map[string]SimpleFSArchiveFile {
+			if x == nil {
+				return nil
+			}
+			ret := make(map[string]SimpleFSArchiveFile, len(x))
+			for k, v := range x {
+				kCopy := k
+				vCopy := v.DeepCopy()
+				ret[kCopy] = vCopy
+			}
+			return ret
+		})(o.BaseManifest),
+		Deleted: (func(x []string) []string {
+			if x == nil {
+				return nil
+			}
+			ret := make([]string, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Deleted),
+		SkippedLargeFiles: (func(x []SimpleFSArchiveLargeFile) []SimpleFSArchiveLargeFile {
+			if x == nil {
+				return nil
+			}
+			ret := make([]SimpleFSArchiveLargeFile, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.SkippedLargeFiles),
+		Error:         o.Error,
+		Paused:        o.Paused,
+		Zipped:        o.Zipped,
+		BytesUploaded: o.BytesUploaded,
+		VolumePaths: (func(x []string) []string {
+			if x == nil {
+				return nil
+			}
+			ret := make([]string, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.VolumePaths),
+		ErrorState: (func(x *SimpleFSArchiveJobErrorState) *SimpleFSArchiveJobErrorState {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x).DeepCopy()
+			return &tmp
+		})(o.ErrorState),
+		RetryCount:          o.RetryCount,
+		RetryPhase:          o.RetryPhase.DeepCopy(),
+		Throughput:          o.Throughput.DeepCopy(),
+		EstimatedCompletion: o.EstimatedCompletion.DeepCopy(),
+		DoneTime:            o.DoneTime.DeepCopy(),
+		SummaryPhase:        o.SummaryPhase.DeepCopy(),
+		VerifiedAt:          o.VerifiedAt.DeepCopy(),
+		VerifyIssues: (func(x []SimpleFSArchiveFileCheck) []SimpleFSArchiveFileCheck {
+			if x == nil {
+				return nil
+			}
+			ret := make([]SimpleFSArchiveFileCheck, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.VerifyIssues),
+	}
+}
+
+type SimpleFSArchiveJobPhase int
+
+const (
+	SimpleFSArchiveJobPhase_Queued     SimpleFSArchiveJobPhase = 0
+	SimpleFSArchiveJobPhase_Indexing   SimpleFSArchiveJobPhase = 1
+	SimpleFSArchiveJobPhase_Indexed    SimpleFSArchiveJobPhase = 2
+	SimpleFSArchiveJobPhase_Copying    SimpleFSArchiveJobPhase = 3
+	SimpleFSArchiveJobPhase_Copied     SimpleFSArchiveJobPhase = 4
+	SimpleFSArchiveJobPhase_Zipping    SimpleFSArchiveJobPhase = 5
+	SimpleFSArchiveJobPhase_Done       SimpleFSArchiveJobPhase = 6
+	SimpleFSArchiveJobPhase_Cancelling SimpleFSArchiveJobPhase = 7
+	SimpleFSArchiveJobPhase_Failed     SimpleFSArchiveJobPhase = 8
+)
+
+func (o SimpleFSArchiveJobPhase) DeepCopy() SimpleFSArchiveJobPhase { return o }
+
+var SimpleFSArchiveJobPhaseMap = map[string]SimpleFSArchiveJobPhase{
+	"Queued":     0,
+	"Indexing":   1,
+	"Indexed":    2,
+	"Copying":    3,
+	"Copied":     4,
+	"Zipping":    5,
+	"Done":       6,
+	"Cancelling": 7,
+	"Failed":     8,
+}
+
+var SimpleFSArchiveJobPhaseRevMap = map[SimpleFSArchiveJobPhase]string{
+	0: "Queued",
+	1: "Indexing",
+	2: "Indexed",
+	3: "Copying",
+	4: "Copied",
+	5: "Zipping",
+	6: "Done",
+	7: "Cancelling",
+	8: "Failed",
+}
+
+func (e SimpleFSArchiveJobPhase) String() string {
+	if v, ok := SimpleFSArchiveJobPhaseRevMap[e]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(e))
+}
+
+type SimpleFSArchiveRetention struct {
+	DoneMaxAgeDays int `codec:"doneMaxAgeDays" json:"doneMaxAgeDays"`
+	MaxDoneJobs    int `codec:"maxDoneJobs" json:"maxDoneJobs"`
+}
+
+func (o SimpleFSArchiveRetention) DeepCopy() SimpleFSArchiveRetention {
+	return SimpleFSArchiveRetention{
+		DoneMaxAgeDays: o.DoneMaxAgeDays,
+		MaxDoneJobs:    o.MaxDoneJobs,
+	}
+}
+
+type SimpleFSArchiveState struct {
+	Jobs            map[string]SimpleFSArchiveJobState `codec:"jobs" json:"jobs"`
+	LastUpdated     Time                               `codec:"lastUpdated" json:"lastUpdated"`
+	Schedules       map[string]SimpleFSArchiveSchedule `codec:"schedules" json:"schedules"`
+	MaxStagingBytes int64                              `codec:"maxStagingBytes" json:"maxStagingBytes"`
+	Retention       SimpleFSArchiveRetention           `codec:"retention" json:"retention"`
+}
+
+func (o SimpleFSArchiveState) DeepCopy() SimpleFSArchiveState {
+	return SimpleFSArchiveState{
+		Jobs: (func(x map[string]SimpleFSArchiveJobState) map[string]SimpleFSArchiveJobState {
+			if x == nil {
+				return nil
+			}
+			ret := make(map[string]SimpleFSArchiveJobState, len(x))
+			for k, v := range x {
+				kCopy := k
+				vCopy := v.DeepCopy()
+				ret[kCopy] = vCopy
+			}
+			return ret
+		})(o.Jobs),
+		LastUpdated: o.LastUpdated.DeepCopy(),
+		Schedules: (func(x map[string]SimpleFSArchiveSchedule) map[string]SimpleFSArchiveSchedule {
+			if x == nil {
+				return nil
+			}
+			ret := make(map[string]SimpleFSArchiveSchedule, len(x))
+			for k, v := range x {
+				kCopy := k
+				vCopy := v.DeepCopy()
+				ret[kCopy] = vCopy
+			}
+			return ret
+		})(o.Schedules),
+		MaxStagingBytes: o.MaxStagingBytes,
+		Retention:       o.Retention.DeepCopy(),
+	}
+}
+
+type SimpleFSArchiveJobStatus struct {
+	Desc                SimpleFSArchiveJobDesc        `codec:"desc" json:"desc"`
+	Phase               SimpleFSArchiveJobPhase       `codec:"phase" json:"phase"`
+	CurrentTLFRevision  KBFSRevision                  `codec:"currentTLFRevision" json:"currentTLFRevision"`
+	TodoCount           int                           `codec:"todoCount" json:"todoCount"`
+	InProgressCount     int                           `codec:"inProgressCount" json:"inProgressCount"`
+	CompleteCount       int                           `codec:"completeCount" json:"completeCount"`
+	SkippedCount        int                           `codec:"skippedCount" json:"skippedCount"`
+	UnchangedCount      int                           `codec:"unchangedCount" json:"unchangedCount"`
+	TotalCount          int                           `codec:"totalCount" json:"totalCount"`
+	BytesTotal          int64                         `codec:"bytesTotal" json:"bytesTotal"`
+	BytesCopied         int64                         `codec:"bytesCopied" json:"bytesCopied"`
+	BytesZipped         int64                         `codec:"bytesZipped" json:"bytesZipped"`
+	Error               *SimpleFSArchiveJobErrorState `codec:"error,omitempty" json:"error,omitempty"`
+	SkippedLargeFiles   []SimpleFSArchiveLargeFile    `codec:"skippedLargeFiles" json:"skippedLargeFiles"`
+	SkippedFiles        []SimpleFSArchiveSkippedFile  `codec:"skippedFiles" json:"skippedFiles"`
+	Paused              bool                          `codec:"paused" json:"paused"`
+	BytesUploaded       int64                         `codec:"bytesUploaded" json:"bytesUploaded"`
+	VolumePaths         []string                      `codec:"volumePaths" json:"volumePaths"`
+	FidelityLosses      []SimpleFSArchiveFidelityLoss `codec:"fidelityLosses" json:"fidelityLosses"`
+	Throughput          SimpleFSArchiveJobThroughput  `codec:"throughput" json:"throughput"`
+	EstimatedCompletion Time                          `codec:"estimatedCompletion" json:"estimatedCompletion"`
+	VerifiedAt          Time                          `codec:"verifiedAt" json:"verifiedAt"`
+	VerifyIssues        []SimpleFSArchiveFileCheck    `codec:"verifyIssues" json:"verifyIssues"`
+}
+
+func (o SimpleFSArchiveJobStatus) DeepCopy() SimpleFSArchiveJobStatus {
+	return SimpleFSArchiveJobStatus{
 		Desc:               o.Desc.DeepCopy(),
 		Phase:              o.Phase.DeepCopy(),
 		CurrentTLFRevision: o.CurrentTLFRevision.DeepCopy(),
@@ -1869,6 +2542,7 @@ func (o SimpleFSArchiveJobStatus) DeepCopy() SimpleFSArchiveJobStatus {
 		InProgressCount:    o.InProgressCount,
 		CompleteCount:      o.CompleteCount,
 		SkippedCount:       o.SkippedCount,
+		UnchangedCount:     o.UnchangedCount,
 		TotalCount:         o.TotalCount,
 		BytesTotal:         o.BytesTotal,
 		BytesCopied:        o.BytesCopied,
@@ -1880,12 +2554,74 @@ func (o SimpleFSArchiveJobStatus) DeepCopy() SimpleFSArchiveJobStatus {
 			tmp := (*x).DeepCopy()
 			return &tmp
 		})(o.Error),
+		SkippedLargeFiles: (func(x []SimpleFSArchiveLargeFile) []SimpleFSArchiveLargeFile {
+			if x == nil {
+				return nil
+			}
+			ret := make([]SimpleFSArchiveLargeFile, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.SkippedLargeFiles),
+		SkippedFiles: (func(x []SimpleFSArchiveSkippedFile) []SimpleFSArchiveSkippedFile {
+			if x == nil {
+				return nil
+			}
+			ret := make([]SimpleFSArchiveSkippedFile, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.SkippedFiles),
+		Paused:        o.Paused,
+		BytesUploaded: o.BytesUploaded,
+		VolumePaths: (func(x []string) []string {
+			if x == nil {
+				return nil
+			}
+			ret := make([]string, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.VolumePaths),
+		FidelityLosses: (func(x []SimpleFSArchiveFidelityLoss) []SimpleFSArchiveFidelityLoss {
+			if x == nil {
+				return nil
+			}
+			ret := make([]SimpleFSArchiveFidelityLoss, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.FidelityLosses),
+		Throughput:          o.Throughput.DeepCopy(),
+		EstimatedCompletion: o.EstimatedCompletion.DeepCopy(),
+		VerifiedAt:          o.VerifiedAt.DeepCopy(),
+		VerifyIssues: (func(x []SimpleFSArchiveFileCheck) []SimpleFSArchiveFileCheck {
+			if x == nil {
+				return nil
+			}
+			ret := make([]SimpleFSArchiveFileCheck, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.VerifyIssues),
 	}
 }
 
 type SimpleFSArchiveStatus struct {
 	Jobs        map[string]SimpleFSArchiveJobStatus `codec:"jobs" json:"jobs"`
 	LastUpdated Time                                `codec:"lastUpdated" json:"lastUpdated"`
+	Schedules   map[string]SimpleFSArchiveSchedule  `codec:"schedules" json:"schedules"`
+	Retention   SimpleFSArchiveRetention            `codec:"retention" json:"retention"`
 }
 
 func (o SimpleFSArchiveStatus) DeepCopy() SimpleFSArchiveStatus {
@@ -1903,73 +2639,420 @@ func (o SimpleFSArchiveStatus) DeepCopy() SimpleFSArchiveStatus {
 			return ret
 		})(o.Jobs),
 		LastUpdated: o.LastUpdated.DeepCopy(),
+		Schedules: (func(x map[string]SimpleFSArchiveSchedule) map[string]SimpleFSArchiveSchedule {
+			if x == nil {
+				return nil
+			}
+			ret := make(map[string]SimpleFSArchiveSchedule, len(x))
+			for k, v := range x {
+				kCopy := k
+				vCopy := v.DeepCopy()
+				ret[kCopy] = vCopy
+			}
+			return ret
+		})(o.Schedules),
+		Retention: o.Retention.DeepCopy(),
 	}
 }
 
-type SimpleFSListArg struct {
-	OpID                OpID       `codec:"opID" json:"opID"`
-	Path                Path       `codec:"path" json:"path"`
-	Filter              ListFilter `codec:"filter" json:"filter"`
-	RefreshSubscription bool       `codec:"refreshSubscription" json:"refreshSubscription"`
+type SimpleFSArchiveJobSummary struct {
+	JobID                string                  `codec:"jobID" json:"jobID"`
+	DeviceID             DeviceID                `codec:"deviceID" json:"deviceID"`
+	Hostname             string                  `codec:"hostname" json:"hostname"`
+	KbfsPathWithRevision KBFSArchivedPath        `codec:"kbfsPathWithRevision" json:"kbfsPathWithRevision"`
+	Label                string                  `codec:"label" json:"label"`
+	Phase                SimpleFSArchiveJobPhase `codec:"phase" json:"phase"`
+	Error                string                  `codec:"error" json:"error"`
+	StartTime            Time                    `codec:"startTime" json:"startTime"`
+	EndTime              Time                    `codec:"endTime" json:"endTime"`
+	ZipFilePath          string                  `codec:"zipFilePath" json:"zipFilePath"`
+	ZipSize              int64                   `codec:"zipSize" json:"zipSize"`
+	ZipSHA256            string                  `codec:"zipSHA256" json:"zipSHA256"`
 }
 
-type SimpleFSListRecursiveArg struct {
-	OpID                OpID       `codec:"opID" json:"opID"`
-	Path                Path       `codec:"path" json:"path"`
-	Filter              ListFilter `codec:"filter" json:"filter"`
-	RefreshSubscription bool       `codec:"refreshSubscription" json:"refreshSubscription"`
+func (o SimpleFSArchiveJobSummary) DeepCopy() SimpleFSArchiveJobSummary {
+	return SimpleFSArchiveJobSummary{
+		JobID:                o.JobID,
+		DeviceID:             o.DeviceID.DeepCopy(),
+		Hostname:             o.Hostname,
+		KbfsPathWithRevision: o.KbfsPathWithRevision.DeepCopy(),
+		Label:                o.Label,
+		Phase:                o.Phase.DeepCopy(),
+		Error:                o.Error,
+		StartTime:            o.StartTime.DeepCopy(),
+		EndTime:              o.EndTime.DeepCopy(),
+		ZipFilePath:          o.ZipFilePath,
+		ZipSize:              o.ZipSize,
+		ZipSHA256:            o.ZipSHA256,
+	}
 }
 
-type SimpleFSListRecursiveToDepthArg struct {
-	OpID                OpID       `codec:"opID" json:"opID"`
-	Path                Path       `codec:"path" json:"path"`
-	Filter              ListFilter `codec:"filter" json:"filter"`
-	RefreshSubscription bool       `codec:"refreshSubscription" json:"refreshSubscription"`
-	Depth               int        `codec:"depth" json:"depth"`
+type SimpleFSLockInfo struct {
+	Path            KBFSPath `codec:"path" json:"path"`
+	AcquireTime     Time     `codec:"acquireTime" json:"acquireTime"`
+	LeaseExpiration Time     `codec:"leaseExpiration" json:"leaseExpiration"`
 }
 
-type SimpleFSReadListArg struct {
-	OpID OpID `codec:"opID" json:"opID"`
+func (o SimpleFSLockInfo) DeepCopy() SimpleFSLockInfo {
+	return SimpleFSLockInfo{
+		Path:            o.Path.DeepCopy(),
+		AcquireTime:     o.AcquireTime.DeepCopy(),
+		LeaseExpiration: o.LeaseExpiration.DeepCopy(),
+	}
 }
 
-type SimpleFSCopyArg struct {
-	OpID                   OpID `codec:"opID" json:"opID"`
-	Src                    Path `codec:"src" json:"src"`
-	Dest                   Path `codec:"dest" json:"dest"`
-	OverwriteExistingFiles bool `codec:"overwriteExistingFiles" json:"overwriteExistingFiles"`
-}
+type SimpleFSArchiveScheduleFrequency int
 
-type SimpleFSSymlinkArg struct {
-	Target string `codec:"target" json:"target"`
-	Link   Path   `codec:"link" json:"link"`
-}
+const (
+	SimpleFSArchiveScheduleFrequency_Daily  SimpleFSArchiveScheduleFrequency = 0
+	SimpleFSArchiveScheduleFrequency_Weekly SimpleFSArchiveScheduleFrequency = 1
+)
 
-type SimpleFSCopyRecursiveArg struct {
-	OpID                   OpID `codec:"opID" json:"opID"`
-	Src                    Path `codec:"src" json:"src"`
-	Dest                   Path `codec:"dest" json:"dest"`
-	OverwriteExistingFiles bool `codec:"overwriteExistingFiles" json:"overwriteExistingFiles"`
+func (o SimpleFSArchiveScheduleFrequency) DeepCopy() SimpleFSArchiveScheduleFrequency { return o }
+
+var SimpleFSArchiveScheduleFrequencyMap = map[string]SimpleFSArchiveScheduleFrequency{
+	"Daily":  0,
+	"Weekly": 1,
 }
 
-type SimpleFSMoveArg struct {
-	OpID                   OpID `codec:"opID" json:"opID"`
-	Src                    Path `codec:"src" json:"src"`
-	Dest                   Path `codec:"dest" json:"dest"`
-	OverwriteExistingFiles bool `codec:"overwriteExistingFiles" json:"overwriteExistingFiles"`
+var SimpleFSArchiveScheduleFrequencyRevMap = map[SimpleFSArchiveScheduleFrequency]string{
+	0: "Daily",
+	1: "Weekly",
 }
 
-type SimpleFSRenameArg struct {
-	Src  Path `codec:"src" json:"src"`
-	Dest Path `codec:"dest" json:"dest"`
+func (e SimpleFSArchiveScheduleFrequency) String() string {
+	if v, ok := SimpleFSArchiveScheduleFrequencyRevMap[e]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(e))
 }
 
-type SimpleFSOpenArg struct {
-	OpID  OpID      `codec:"opID" json:"opID"`
-	Dest  Path      `codec:"dest" json:"dest"`
-	Flags OpenFlags `codec:"flags" json:"flags"`
+type SimpleFSArchiveSchedule struct {
+	ScheduleID     string                           `codec:"scheduleID" json:"scheduleID"`
+	KbfsPath       KBFSPath                         `codec:"kbfsPath" json:"kbfsPath"`
+	OutputDir      string                           `codec:"outputDir" json:"outputDir"`
+	Frequency      SimpleFSArchiveScheduleFrequency `codec:"frequency" json:"frequency"`
+	Hour           int                              `codec:"hour" json:"hour"`
+	Weekday        int                              `codec:"weekday" json:"weekday"`
+	KeepCount      int                              `codec:"keepCount" json:"keepCount"`
+	BytesPerSecond int64                            `codec:"bytesPerSecond" json:"bytesPerSecond"`
+	NextRun        Time                             `codec:"nextRun" json:"nextRun"`
+	LastRun        Time                             `codec:"lastRun" json:"lastRun"`
+	LastJobID      string                           `codec:"lastJobID" json:"lastJobID"`
+	Outputs        []string                         `codec:"outputs" json:"outputs"`
+}
+
+func (o SimpleFSArchiveSchedule) DeepCopy() SimpleFSArchiveSchedule {
+	return SimpleFSArchiveSchedule{
+		ScheduleID:     o.ScheduleID,
+		KbfsPath:       o.KbfsPath.DeepCopy(),
+		OutputDir:      o.OutputDir,
+		Frequency:      o.Frequency.DeepCopy(),
+		Hour:           o.Hour,
+		Weekday:        o.Weekday,
+		KeepCount:      o.KeepCount,
+		BytesPerSecond: o.BytesPerSecond,
+		NextRun:        o.NextRun.DeepCopy(),
+		LastRun:        o.LastRun.DeepCopy(),
+		LastJobID:      o.LastJobID,
+		Outputs: (func(x []string) []string {
+			if x == nil {
+				return nil
+			}
+			ret := make([]string, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Outputs),
+	}
 }
 
-type SimpleFSSetStatArg struct {
+type SimpleFSArchiveFileCheckResult int
+
+const (
+	SimpleFSArchiveFileCheckResult_Ok         SimpleFSArchiveFileCheckResult = 0
+	SimpleFSArchiveFileCheckResult_Mismatch   SimpleFSArchiveFileCheckResult = 1
+	SimpleFSArchiveFileCheckResult_Missing    SimpleFSArchiveFileCheckResult = 2
+	SimpleFSArchiveFileCheckResult_Unexpected SimpleFSArchiveFileCheckResult = 3
+	SimpleFSArchiveFileCheckResult_Unreadable SimpleFSArchiveFileCheckResult = 4
+)
+
+func (o SimpleFSArchiveFileCheckResult) DeepCopy() SimpleFSArchiveFileCheckResult { return o }
+
+var SimpleFSArchiveFileCheckResultMap = map[string]SimpleFSArchiveFileCheckResult{
+	"Ok":         0,
+	"Mismatch":   1,
+	"Missing":    2,
+	"Unexpected": 3,
+	"Unreadable": 4,
+}
+
+var SimpleFSArchiveFileCheckResultRevMap = map[SimpleFSArchiveFileCheckResult]string{
+	0: "Ok",
+	1: "Mismatch",
+	2: "Missing",
+	3: "Unexpected",
+	4: "Unreadable",
+}
+
+func (e SimpleFSArchiveFileCheckResult) String() string {
+	if v, ok := SimpleFSArchiveFileCheckResultRevMap[e]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(e))
+}
+
+type SimpleFSArchiveFileCheck struct {
+	Path                 string                         `codec:"path" json:"path"`
+	Result               SimpleFSArchiveFileCheckResult `codec:"result" json:"result"`
+	ExpectedSha256SumHex string                         `codec:"expectedSha256SumHex" json:"expectedSha256SumHex"`
+	ActualSha256SumHex   string                         `codec:"actualSha256SumHex" json:"actualSha256SumHex"`
+	Error                string                         `codec:"error" json:"error"`
+}
+
+func (o SimpleFSArchiveFileCheck) DeepCopy() SimpleFSArchiveFileCheck {
+	return SimpleFSArchiveFileCheck{
+		Path:                 o.Path,
+		Result:               o.Result.DeepCopy(),
+		ExpectedSha256SumHex: o.ExpectedSha256SumHex,
+		ActualSha256SumHex:   o.ActualSha256SumHex,
+		Error:                o.Error,
+	}
+}
+
+type SimpleFSArchiveCheckArchiveResult struct {
+	Desc       SimpleFSArchiveJobDesc     `codec:"desc" json:"desc"`
+	OkCount    int                        `codec:"okCount" json:"okCount"`
+	IssueCount int                        `codec:"issueCount" json:"issueCount"`
+	Files      []SimpleFSArchiveFileCheck `codec:"files" json:"files"`
+}
+
+func (o SimpleFSArchiveCheckArchiveResult) DeepCopy() SimpleFSArchiveCheckArchiveResult {
+	return SimpleFSArchiveCheckArchiveResult{
+		Desc:       o.Desc.DeepCopy(),
+		OkCount:    o.OkCount,
+		IssueCount: o.IssueCount,
+		Files: (func(x []SimpleFSArchiveFileCheck) []SimpleFSArchiveFileCheck {
+			if x == nil {
+				return nil
+			}
+			ret := make([]SimpleFSArchiveFileCheck, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Files),
+	}
+}
+
+type SimpleFSFileHistory struct {
+	Revisions    []DirentWithRevision `codec:"revisions" json:"revisions"`
+	NextRevision KBFSRevision         `codec:"nextRevision" json:"nextRevision"`
+}
+
+func (o SimpleFSFileHistory) DeepCopy() SimpleFSFileHistory {
+	return SimpleFSFileHistory{
+		Revisions: (func(x []DirentWithRevision) []DirentWithRevision {
+			if x == nil {
+				return nil
+			}
+			ret := make([]DirentWithRevision, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Revisions),
+		NextRevision: o.NextRevision.DeepCopy(),
+	}
+}
+
+type SimpleFSArchiveProgress struct {
+	JobID               string                  `codec:"jobID" json:"jobID"`
+	Phase               SimpleFSArchiveJobPhase `codec:"phase" json:"phase"`
+	BytesTotal          int64                   `codec:"bytesTotal" json:"bytesTotal"`
+	BytesCopied         int64                   `codec:"bytesCopied" json:"bytesCopied"`
+	BytesZipped         int64                   `codec:"bytesZipped" json:"bytesZipped"`
+	BytesCopiedDelta    int64                   `codec:"bytesCopiedDelta" json:"bytesCopiedDelta"`
+	BytesZippedDelta    int64                   `codec:"bytesZippedDelta" json:"bytesZippedDelta"`
+	BytesUploaded       int64                   `codec:"bytesUploaded" json:"bytesUploaded"`
+	BytesUploadedDelta  int64                   `codec:"bytesUploadedDelta" json:"bytesUploadedDelta"`
+	EstimatedCompletion Time                    `codec:"estimatedCompletion" json:"estimatedCompletion"`
+}
+
+func (o SimpleFSArchiveProgress) DeepCopy() SimpleFSArchiveProgress {
+	return SimpleFSArchiveProgress{
+		JobID:               o.JobID,
+		Phase:               o.Phase.DeepCopy(),
+		BytesTotal:          o.BytesTotal,
+		BytesCopied:         o.BytesCopied,
+		BytesZipped:         o.BytesZipped,
+		BytesCopiedDelta:    o.BytesCopiedDelta,
+		BytesZippedDelta:    o.BytesZippedDelta,
+		BytesUploaded:       o.BytesUploaded,
+		BytesUploadedDelta:  o.BytesUploadedDelta,
+		EstimatedCompletion: o.EstimatedCompletion.DeepCopy(),
+	}
+}
+
+type SimpleFSArchiveInfoSource struct {
+	Path     string       `codec:"path" json:"path"`
+	TlfType  string       `codec:"tlfType" json:"tlfType"`
+	TlfName  string       `codec:"tlfName" json:"tlfName"`
+	Revision KBFSRevision `codec:"revision" json:"revision"`
+}
+
+func (o SimpleFSArchiveInfoSource) DeepCopy() SimpleFSArchiveInfoSource {
+	return SimpleFSArchiveInfoSource{
+		Path:     o.Path,
+		TlfType:  o.TlfType,
+		TlfName:  o.TlfName,
+		Revision: o.Revision.DeepCopy(),
+	}
+}
+
+type SimpleFSArchiveInfo struct {
+	FormatVersion int                         `codec:"formatVersion" json:"formatVersion"`
+	Username      string                      `codec:"username" json:"username"`
+	ArchivedTime  Time                        `codec:"archivedTime" json:"archivedTime"`
+	Sources       []SimpleFSArchiveInfoSource `codec:"sources" json:"sources"`
+	BytesTotal    int64                       `codec:"bytesTotal" json:"bytesTotal"`
+	Desc          SimpleFSArchiveJobDesc      `codec:"desc" json:"desc"`
+}
+
+func (o SimpleFSArchiveInfo) DeepCopy() SimpleFSArchiveInfo {
+	return SimpleFSArchiveInfo{
+		FormatVersion: o.FormatVersion,
+		Username:      o.Username,
+		ArchivedTime:  o.ArchivedTime.DeepCopy(),
+		Sources: (func(x []SimpleFSArchiveInfoSource) []SimpleFSArchiveInfoSource {
+			if x == nil {
+				return nil
+			}
+			ret := make([]SimpleFSArchiveInfoSource, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Sources),
+		BytesTotal: o.BytesTotal,
+		Desc:       o.Desc.DeepCopy(),
+	}
+}
+
+type SimpleFSArchiveJobStagingUsage struct {
+	JobID         string                  `codec:"jobID" json:"jobID"`
+	Phase         SimpleFSArchiveJobPhase `codec:"phase" json:"phase"`
+	StagingPath   string                  `codec:"stagingPath" json:"stagingPath"`
+	BytesOnDisk   int64                   `codec:"bytesOnDisk" json:"bytesOnDisk"`
+	BytesReserved int64                   `codec:"bytesReserved" json:"bytesReserved"`
+}
+
+func (o SimpleFSArchiveJobStagingUsage) DeepCopy() SimpleFSArchiveJobStagingUsage {
+	return SimpleFSArchiveJobStagingUsage{
+		JobID:         o.JobID,
+		Phase:         o.Phase.DeepCopy(),
+		StagingPath:   o.StagingPath,
+		BytesOnDisk:   o.BytesOnDisk,
+		BytesReserved: o.BytesReserved,
+	}
+}
+
+type SimpleFSArchiveStagingUsage struct {
+	MaxStagingBytes int64                            `codec:"maxStagingBytes" json:"maxStagingBytes"`
+	BytesOnDisk     int64                            `codec:"bytesOnDisk" json:"bytesOnDisk"`
+	BytesReserved   int64                            `codec:"bytesReserved" json:"bytesReserved"`
+	Jobs            []SimpleFSArchiveJobStagingUsage `codec:"jobs" json:"jobs"`
+}
+
+func (o SimpleFSArchiveStagingUsage) DeepCopy() SimpleFSArchiveStagingUsage {
+	return SimpleFSArchiveStagingUsage{
+		MaxStagingBytes: o.MaxStagingBytes,
+		BytesOnDisk:     o.BytesOnDisk,
+		BytesReserved:   o.BytesReserved,
+		Jobs: (func(x []SimpleFSArchiveJobStagingUsage) []SimpleFSArchiveJobStagingUsage {
+			if x == nil {
+				return nil
+			}
+			ret := make([]SimpleFSArchiveJobStagingUsage, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Jobs),
+	}
+}
+
+type SimpleFSListArg struct {
+	OpID                OpID       `codec:"opID" json:"opID"`
+	Path                Path       `codec:"path" json:"path"`
+	Filter              ListFilter `codec:"filter" json:"filter"`
+	RefreshSubscription bool       `codec:"refreshSubscription" json:"refreshSubscription"`
+	DetectMimeTypes     bool       `codec:"detectMimeTypes" json:"detectMimeTypes"`
+}
+
+type SimpleFSListRecursiveArg struct {
+	OpID                OpID       `codec:"opID" json:"opID"`
+	Path                Path       `codec:"path" json:"path"`
+	Filter              ListFilter `codec:"filter" json:"filter"`
+	RefreshSubscription bool       `codec:"refreshSubscription" json:"refreshSubscription"`
+}
+
+type SimpleFSListRecursiveToDepthArg struct {
+	OpID                OpID       `codec:"opID" json:"opID"`
+	Path                Path       `codec:"path" json:"path"`
+	Filter              ListFilter `codec:"filter" json:"filter"`
+	RefreshSubscription bool       `codec:"refreshSubscription" json:"refreshSubscription"`
+	Depth               int        `codec:"depth" json:"depth"`
+}
+
+type SimpleFSReadListArg struct {
+	OpID OpID `codec:"opID" json:"opID"`
+}
+
+type SimpleFSCopyArg struct {
+	OpID                   OpID `codec:"opID" json:"opID"`
+	Src                    Path `codec:"src" json:"src"`
+	Dest                   Path `codec:"dest" json:"dest"`
+	OverwriteExistingFiles bool `codec:"overwriteExistingFiles" json:"overwriteExistingFiles"`
+}
+
+type SimpleFSSymlinkArg struct {
+	Target string `codec:"target" json:"target"`
+	Link   Path   `codec:"link" json:"link"`
+}
+
+type SimpleFSCopyRecursiveArg struct {
+	OpID                   OpID `codec:"opID" json:"opID"`
+	Src                    Path `codec:"src" json:"src"`
+	Dest                   Path `codec:"dest" json:"dest"`
+	OverwriteExistingFiles bool `codec:"overwriteExistingFiles" json:"overwriteExistingFiles"`
+}
+
+type SimpleFSMoveArg struct {
+	OpID                   OpID `codec:"opID" json:"opID"`
+	Src                    Path `codec:"src" json:"src"`
+	Dest                   Path `codec:"dest" json:"dest"`
+	OverwriteExistingFiles bool `codec:"overwriteExistingFiles" json:"overwriteExistingFiles"`
+}
+
+type SimpleFSRenameArg struct {
+	Src  Path `codec:"src" json:"src"`
+	Dest Path `codec:"dest" json:"dest"`
+}
+
+type SimpleFSOpenArg struct {
+	OpID  OpID      `codec:"opID" json:"opID"`
+	Dest  Path      `codec:"dest" json:"dest"`
+	Flags OpenFlags `codec:"flags" json:"flags"`
+}
+
+type SimpleFSSetStatArg struct {
 	Dest Path       `codec:"dest" json:"dest"`
 	Flag DirentType `codec:"flag" json:"flag"`
 }
@@ -2065,6 +3148,13 @@ type SimpleFSGetTeamQuotaUsageArg struct {
 	TeamName TeamName `codec:"teamName" json:"teamName"`
 }
 
+type SimpleFSGetQuotaAlertSettingsArg struct {
+}
+
+type SimpleFSSetQuotaAlertSettingsArg struct {
+	Settings SimpleFSQuotaAlertSettings `codec:"settings" json:"settings"`
+}
+
 type SimpleFSResetArg struct {
 	Path  Path   `codec:"path" json:"path"`
 	TlfID string `codec:"tlfID" json:"tlfID"`
@@ -2223,9 +3313,30 @@ type SimpleFSCancelJournalUploadsArg struct {
 }
 
 type SimpleFSArchiveStartArg struct {
-	KbfsPath     KBFSPath `codec:"kbfsPath" json:"kbfsPath"`
-	OutputPath   string   `codec:"outputPath" json:"outputPath"`
-	OverwriteZip bool     `codec:"overwriteZip" json:"overwriteZip"`
+	KbfsPath          KBFSPath                    `codec:"kbfsPath" json:"kbfsPath"`
+	OutputPath        string                      `codec:"outputPath" json:"outputPath"`
+	OverwriteZip      bool                        `codec:"overwriteZip" json:"overwriteZip"`
+	BytesPerSecond    int64                       `codec:"bytesPerSecond" json:"bytesPerSecond"`
+	BaseJobID         string                      `codec:"baseJobID" json:"baseJobID"`
+	IncludeGlobs      []string                    `codec:"includeGlobs" json:"includeGlobs"`
+	ExcludeGlobs      []string                    `codec:"excludeGlobs" json:"excludeGlobs"`
+	MaxFileSize       int64                       `codec:"maxFileSize" json:"maxFileSize"`
+	WriteManifestJSON bool                        `codec:"writeManifestJSON" json:"writeManifestJSON"`
+	Priority          int                         `codec:"priority" json:"priority"`
+	AdditionalPaths   []KBFSPath                  `codec:"additionalPaths" json:"additionalPaths"`
+	Destination       SimpleFSArchiveDestination  `codec:"destination" json:"destination"`
+	MaxVolumeBytes    int64                       `codec:"maxVolumeBytes" json:"maxVolumeBytes"`
+	Compression       SimpleFSArchiveCompression  `codec:"compression" json:"compression"`
+	BestEffort        bool                        `codec:"bestEffort" json:"bestEffort"`
+	MaxRetries        int                         `codec:"maxRetries" json:"maxRetries"`
+	AllRevisions      bool                        `codec:"allRevisions" json:"allRevisions"`
+	MaxRevisions      int                         `codec:"maxRevisions" json:"maxRevisions"`
+	RevisionsSince    Time                        `codec:"revisionsSince" json:"revisionsSince"`
+	SpecialFiles      SimpleFSArchiveSpecialFiles `codec:"specialFiles" json:"specialFiles"`
+	Symlinks          SimpleFSArchiveSymlinks     `codec:"symlinks" json:"symlinks"`
+	Label             string                      `codec:"label" json:"label"`
+	Notes             string                      `codec:"notes" json:"notes"`
+	Layout            SimpleFSArchiveLayout       `codec:"layout" json:"layout"`
 }
 
 type SimpleFSArchiveCancelOrDismissJobArg struct {
@@ -2235,6 +3346,116 @@ type SimpleFSArchiveCancelOrDismissJobArg struct {
 type SimpleFSGetArchiveStatusArg struct {
 }
 
+type SimpleFSLockArg struct {
+	Path  KBFSPath     `codec:"path" json:"path"`
+	Lease DurationMsec `codec:"lease" json:"lease"`
+}
+
+type SimpleFSUnlockArg struct {
+	Path KBFSPath `codec:"path" json:"path"`
+}
+
+type SimpleFSListLocksArg struct {
+}
+
+type SimpleFSArchiveSetBytesPerSecondArg struct {
+	JobID          string `codec:"jobID" json:"jobID"`
+	BytesPerSecond int64  `codec:"bytesPerSecond" json:"bytesPerSecond"`
+}
+
+type SimpleFSArchiveSetLabelArg struct {
+	JobID string `codec:"jobID" json:"jobID"`
+	Label string `codec:"label" json:"label"`
+	Notes string `codec:"notes" json:"notes"`
+}
+
+type SimpleFSArchiveScheduleArg struct {
+	KbfsPath       KBFSPath                         `codec:"kbfsPath" json:"kbfsPath"`
+	OutputDir      string                           `codec:"outputDir" json:"outputDir"`
+	Frequency      SimpleFSArchiveScheduleFrequency `codec:"frequency" json:"frequency"`
+	Hour           int                              `codec:"hour" json:"hour"`
+	Weekday        int                              `codec:"weekday" json:"weekday"`
+	KeepCount      int                              `codec:"keepCount" json:"keepCount"`
+	BytesPerSecond int64                            `codec:"bytesPerSecond" json:"bytesPerSecond"`
+}
+
+type SimpleFSArchiveUnscheduleArg struct {
+	ScheduleID string `codec:"scheduleID" json:"scheduleID"`
+}
+
+type SimpleFSArchiveCheckArchiveArg struct {
+	JobID string `codec:"jobID" json:"jobID"`
+}
+
+type SimpleFSArchiveVerifyJobArg struct {
+	JobID       string `codec:"jobID" json:"jobID"`
+	ZipFilePath string `codec:"zipFilePath" json:"zipFilePath"`
+}
+
+type SimpleFSArchivePauseJobArg struct {
+	JobID string `codec:"jobID" json:"jobID"`
+}
+
+type SimpleFSArchiveResumeJobArg struct {
+	JobID string `codec:"jobID" json:"jobID"`
+}
+
+type SimpleFSArchiveRetryJobArg struct {
+	JobID string `codec:"jobID" json:"jobID"`
+}
+
+type SimpleFSArchiveDrainArg struct {
+	Timeout DurationSec `codec:"timeout" json:"timeout"`
+}
+
+type SimpleFSFileHistoryArg struct {
+	Path          Path         `codec:"path" json:"path"`
+	StartRevision KBFSRevision `codec:"startRevision" json:"startRevision"`
+	Num           int          `codec:"num" json:"num"`
+}
+
+type SimpleFSArchiveRestoreArg struct {
+	ZipFilePath       string   `codec:"zipFilePath" json:"zipFilePath"`
+	ManifestPath      string   `codec:"manifestPath" json:"manifestPath"`
+	KbfsPath          KBFSPath `codec:"kbfsPath" json:"kbfsPath"`
+	OverwriteExisting bool     `codec:"overwriteExisting" json:"overwriteExisting"`
+	BytesPerSecond    int64    `codec:"bytesPerSecond" json:"bytesPerSecond"`
+	Priority          int      `codec:"priority" json:"priority"`
+}
+
+type SimpleFSArchiveReadInfoArg struct {
+	ZipFilePath string `codec:"zipFilePath" json:"zipFilePath"`
+}
+
+type SimpleFSArchiveGetStagingUsageArg struct {
+}
+
+type SimpleFSArchiveSetMaxStagingBytesArg struct {
+	MaxStagingBytes int64 `codec:"maxStagingBytes" json:"maxStagingBytes"`
+}
+
+type SimpleFSArchiveCheckPartsArg struct {
+	PartsManifestPath string `codec:"partsManifestPath" json:"partsManifestPath"`
+}
+
+type SimpleFSArchiveSearchJobsArg struct {
+	Query string `codec:"query" json:"query"`
+}
+
+type SimpleFSArchiveSetRetentionArg struct {
+	Retention SimpleFSArchiveRetention `codec:"retention" json:"retention"`
+}
+
+type SimpleFSRepairCacheArg struct {
+	Rebuild bool `codec:"rebuild" json:"rebuild"`
+}
+
+type SimpleFSGetCacheRepairProgressArg struct {
+}
+
+type SimpleFSArchiveListAllDevicesArg struct {
+}
+
 type SimpleFSInterface interface {
 	// Begin list of items in directory at path.
 	// Retrieve results with readList().
@@ -2243,6 +3464,8 @@ type SimpleFSInterface interface {
 	// will begin sending `FSPathUpdated` notifications for the for the
 	// corresponding TLF, until another call refreshes the subscription on a
 	// different TLF.
+	// If `detectMimeTypes` is true, each file's `mimeType` is filled in, from its
+	// extension or, for small files, by sniffing its contents.
 	SimpleFSList(context.Context, SimpleFSListArg) error
 	// Begin recursive list of items in directory at path.
 	// If `refreshSubscription` is true and the path is a KBFS path, simpleFS
@@ -2334,6 +3557,12 @@ type SimpleFSInterface interface {
 	// the logged-in user has access to that team.  Any usage includes
 	// local journal usage as well.
 	SimpleFSGetTeamQuotaUsage(context.Context, TeamName) (SimpleFSQuotaUsage, error)
+	// simpleFSGetQuotaAlertSettings returns the quota alert settings of the
+	// logged-in user on this device.
+	SimpleFSGetQuotaAlertSettings(context.Context) (SimpleFSQuotaAlertSettings, error)
+	// simpleFSSetQuotaAlertSettings changes the quota alert settings of the
+	// logged-in user on this device.
+	SimpleFSSetQuotaAlertSettings(context.Context, SimpleFSQuotaAlertSettings) error
 	// simpleFSReset completely resets the KBFS folder referenced in `path`.
 	// It should only be called after explicit user confirmation.
 	SimpleFSReset(context.Context, SimpleFSResetArg) error
@@ -2376,6 +3605,71 @@ type SimpleFSInterface interface {
 	SimpleFSArchiveStart(context.Context, SimpleFSArchiveStartArg) (SimpleFSArchiveJobDesc, error)
 	SimpleFSArchiveCancelOrDismissJob(context.Context, string) error
 	SimpleFSGetArchiveStatus(context.Context) (SimpleFSArchiveStatus, error)
+	// Take an advisory lock on a KBFS path, using the same mdserver lock
+	// facility that coordinates git pushes. If the lock is held by another
+	// session, this blocks until it becomes available. The lock is released by
+	// simpleFSUnlock, or automatically once the lease expires. Calling it again
+	// on a path we already hold extends the lease.
+	SimpleFSLock(context.Context, SimpleFSLockArg) (SimpleFSLockInfo, error)
+	// Release an advisory lock taken by simpleFSLock.
+	SimpleFSUnlock(context.Context, KBFSPath) error
+	// List advisory locks currently held by this device.
+	SimpleFSListLocks(context.Context) ([]SimpleFSLockInfo, error)
+	// Change the copy throttle of an existing archive job. Takes effect
+	// immediately if the job is running. 0 means unlimited.
+	SimpleFSArchiveSetBytesPerSecond(context.Context, SimpleFSArchiveSetBytesPerSecondArg) error
+	// Change the label and notes of an existing archive job, in any phase.
+	// A zip that's already been made keeps the ones it was made with.
+	SimpleFSArchiveSetLabel(context.Context, SimpleFSArchiveSetLabelArg) error
+	SimpleFSArchiveSchedule(context.Context, SimpleFSArchiveScheduleArg) (SimpleFSArchiveSchedule, error)
+	SimpleFSArchiveUnschedule(context.Context, string) error
+	SimpleFSArchiveCheckArchive(context.Context, string) (SimpleFSArchiveCheckArchiveResult, error)
+	SimpleFSArchiveVerifyJob(context.Context, SimpleFSArchiveVerifyJobArg) (SimpleFSArchiveCheckArchiveResult, error)
+	// Stop working on an archive job, but keep its state and staging files
+	// so it can pick up where it left off when resumed.
+	SimpleFSArchivePauseJob(context.Context, string) error
+	SimpleFSArchiveResumeJob(context.Context, string) error
+	// Retry a job that failed, or is waiting to be retried after an error,
+	// right away, with its retry count starting over.
+	SimpleFSArchiveRetryJob(context.Context, string) error
+	// Stop working on all archive jobs, waiting up to timeout for the work in
+	// progress to stop at a point it can pick up from, and save their state.
+	// They're picked up again when KBFS restarts, or after a couple of
+	// minutes if it doesn't.
+	SimpleFSArchiveDrain(context.Context, DurationSec) error
+	SimpleFSFileHistory(context.Context, SimpleFSFileHistoryArg) (SimpleFSFileHistory, error)
+	SimpleFSArchiveRestore(context.Context, SimpleFSArchiveRestoreArg) (SimpleFSArchiveJobDesc, error)
+	SimpleFSArchiveReadInfo(context.Context, string) (SimpleFSArchiveInfo, error)
+	// Report how much disk space each archive job's staging path is using,
+	// and how much of the staging cap it has reserved.
+	SimpleFSArchiveGetStagingUsage(context.Context) (SimpleFSArchiveStagingUsage, error)
+	// Cap the total staging space of all archive jobs. A job doesn't start
+	// copying until its size fits under the cap, along with the jobs already
+	// staged. 0 removes the cap.
+	SimpleFSArchiveSetMaxStagingBytes(context.Context, int64) error
+	// Check the volumes of a split zip against the parts manifest written
+	// next to them (archive.parts.sha256 for archive.zip), wherever the set
+	// was copied to. Each volume is a file in the result, and volumes next to
+	// them that the manifest doesn't list are Unexpected. desc is read from
+	// the last volume, if it's there.
+	SimpleFSArchiveCheckParts(context.Context, string) (SimpleFSArchiveCheckArchiveResult, error)
+	// The jobs whose label, notes, job ID, paths or zip file path contain
+	// every word of query, ignoring case, newest first. An empty query
+	// matches every job.
+	SimpleFSArchiveSearchJobs(context.Context, string) ([]SimpleFSArchiveJobStatus, error)
+	// Set which Done archive jobs get dismissed on their own, along with
+	// their staging files, like simpleFSArchiveCancelOrDismissJob does.
+	// Scheduled runs are kept until their schedule has recorded their zip.
+	SimpleFSArchiveSetRetention(context.Context, SimpleFSArchiveRetention) error
+	// Check the local cache stores, moving aside any corrupt ones and
+	// rebuilding them; with rebuild set, rebuild all of them. Synced folder
+	// settings that can still be read are kept. Returns once the repair has
+	// started; follow it with simpleFSGetCacheRepairProgress.
+	SimpleFSRepairCache(context.Context, bool) error
+	SimpleFSGetCacheRepairProgress(context.Context) (SimpleFSCacheRepairProgress, error)
+	// List the archive jobs the user has finished on any of their devices,
+	// newest first. Jobs stay listed after they've been dismissed.
+	SimpleFSArchiveListAllDevices(context.Context) ([]SimpleFSArchiveJobSummary, error)
 }
 
 func SimpleFSProtocol(i SimpleFSInterface) rpc.Protocol {
@@ -2384,1016 +3678,1381 @@ func SimpleFSProtocol(i SimpleFSInterface) rpc.Protocol {
 		Methods: map[string]rpc.ServeHandlerDescription{
 			"simpleFSList": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSListArg
+					var ret [1]SimpleFSListArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SimpleFSListArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SimpleFSListArg)(nil), args)
+						return
+					}
+					err = i.SimpleFSList(ctx, typedArgs[0])
+					return
+				},
+			},
+			"simpleFSListRecursive": {
+				MakeArg: func() interface{} {
+					var ret [1]SimpleFSListRecursiveArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SimpleFSListRecursiveArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SimpleFSListRecursiveArg)(nil), args)
+						return
+					}
+					err = i.SimpleFSListRecursive(ctx, typedArgs[0])
+					return
+				},
+			},
+			"simpleFSListRecursiveToDepth": {
+				MakeArg: func() interface{} {
+					var ret [1]SimpleFSListRecursiveToDepthArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SimpleFSListRecursiveToDepthArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SimpleFSListRecursiveToDepthArg)(nil), args)
+						return
+					}
+					err = i.SimpleFSListRecursiveToDepth(ctx, typedArgs[0])
+					return
+				},
+			},
+			"simpleFSReadList": {
+				MakeArg: func() interface{} {
+					var ret [1]SimpleFSReadListArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SimpleFSReadListArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SimpleFSReadListArg)(nil), args)
+						return
+					}
+					ret, err = i.SimpleFSReadList(ctx, typedArgs[0].OpID)
+					return
+				},
+			},
+			"simpleFSCopy": {
+				MakeArg: func() interface{} {
+					var ret [1]SimpleFSCopyArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SimpleFSCopyArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SimpleFSCopyArg)(nil), args)
+						return
+					}
+					err = i.SimpleFSCopy(ctx, typedArgs[0])
+					return
+				},
+			},
+			"simpleFSSymlink": {
+				MakeArg: func() interface{} {
+					var ret [1]SimpleFSSymlinkArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SimpleFSSymlinkArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SimpleFSSymlinkArg)(nil), args)
+						return
+					}
+					err = i.SimpleFSSymlink(ctx, typedArgs[0])
+					return
+				},
+			},
+			"simpleFSCopyRecursive": {
+				MakeArg: func() interface{} {
+					var ret [1]SimpleFSCopyRecursiveArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SimpleFSCopyRecursiveArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SimpleFSCopyRecursiveArg)(nil), args)
+						return
+					}
+					err = i.SimpleFSCopyRecursive(ctx, typedArgs[0])
+					return
+				},
+			},
+			"simpleFSMove": {
+				MakeArg: func() interface{} {
+					var ret [1]SimpleFSMoveArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SimpleFSMoveArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SimpleFSMoveArg)(nil), args)
+						return
+					}
+					err = i.SimpleFSMove(ctx, typedArgs[0])
+					return
+				},
+			},
+			"simpleFSRename": {
+				MakeArg: func() interface{} {
+					var ret [1]SimpleFSRenameArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SimpleFSRenameArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SimpleFSRenameArg)(nil), args)
+						return
+					}
+					err = i.SimpleFSRename(ctx, typedArgs[0])
+					return
+				},
+			},
+			"simpleFSOpen": {
+				MakeArg: func() interface{} {
+					var ret [1]SimpleFSOpenArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SimpleFSOpenArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SimpleFSOpenArg)(nil), args)
+						return
+					}
+					err = i.SimpleFSOpen(ctx, typedArgs[0])
+					return
+				},
+			},
+			"simpleFSSetStat": {
+				MakeArg: func() interface{} {
+					var ret [1]SimpleFSSetStatArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SimpleFSSetStatArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SimpleFSSetStatArg)(nil), args)
+						return
+					}
+					err = i.SimpleFSSetStat(ctx, typedArgs[0])
+					return
+				},
+			},
+			"simpleFSRead": {
+				MakeArg: func() interface{} {
+					var ret [1]SimpleFSReadArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SimpleFSReadArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SimpleFSReadArg)(nil), args)
+						return
+					}
+					ret, err = i.SimpleFSRead(ctx, typedArgs[0])
+					return
+				},
+			},
+			"simpleFSWrite": {
+				MakeArg: func() interface{} {
+					var ret [1]SimpleFSWriteArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SimpleFSWriteArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SimpleFSWriteArg)(nil), args)
+						return
+					}
+					err = i.SimpleFSWrite(ctx, typedArgs[0])
+					return
+				},
+			},
+			"simpleFSRemove": {
+				MakeArg: func() interface{} {
+					var ret [1]SimpleFSRemoveArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SimpleFSRemoveArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SimpleFSRemoveArg)(nil), args)
+						return
+					}
+					err = i.SimpleFSRemove(ctx, typedArgs[0])
+					return
+				},
+			},
+			"simpleFSStat": {
+				MakeArg: func() interface{} {
+					var ret [1]SimpleFSStatArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SimpleFSStatArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SimpleFSStatArg)(nil), args)
+						return
+					}
+					ret, err = i.SimpleFSStat(ctx, typedArgs[0])
+					return
+				},
+			},
+			"simpleFSGetRevisions": {
+				MakeArg: func() interface{} {
+					var ret [1]SimpleFSGetRevisionsArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SimpleFSGetRevisionsArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SimpleFSGetRevisionsArg)(nil), args)
+						return
+					}
+					err = i.SimpleFSGetRevisions(ctx, typedArgs[0])
+					return
+				},
+			},
+			"simpleFSReadRevisions": {
+				MakeArg: func() interface{} {
+					var ret [1]SimpleFSReadRevisionsArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SimpleFSReadRevisionsArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SimpleFSReadRevisionsArg)(nil), args)
+						return
+					}
+					ret, err = i.SimpleFSReadRevisions(ctx, typedArgs[0].OpID)
+					return
+				},
+			},
+			"simpleFSMakeOpid": {
+				MakeArg: func() interface{} {
+					var ret [1]SimpleFSMakeOpidArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					ret, err = i.SimpleFSMakeOpid(ctx)
+					return
+				},
+			},
+			"simpleFSClose": {
+				MakeArg: func() interface{} {
+					var ret [1]SimpleFSCloseArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SimpleFSCloseArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SimpleFSCloseArg)(nil), args)
+						return
+					}
+					err = i.SimpleFSClose(ctx, typedArgs[0].OpID)
+					return
+				},
+			},
+			"simpleFSCancel": {
+				MakeArg: func() interface{} {
+					var ret [1]SimpleFSCancelArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SimpleFSCancelArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SimpleFSCancelArg)(nil), args)
+						return
+					}
+					err = i.SimpleFSCancel(ctx, typedArgs[0].OpID)
+					return
+				},
+			},
+			"simpleFSCheck": {
+				MakeArg: func() interface{} {
+					var ret [1]SimpleFSCheckArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSListArg)
+					typedArgs, ok := args.(*[1]SimpleFSCheckArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSListArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSCheckArg)(nil), args)
 						return
 					}
-					err = i.SimpleFSList(ctx, typedArgs[0])
+					ret, err = i.SimpleFSCheck(ctx, typedArgs[0].OpID)
 					return
 				},
 			},
-			"simpleFSListRecursive": {
+			"simpleFSGetOps": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSListRecursiveArg
+					var ret [1]SimpleFSGetOpsArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSListRecursiveArg)
+					ret, err = i.SimpleFSGetOps(ctx)
+					return
+				},
+			},
+			"simpleFSWait": {
+				MakeArg: func() interface{} {
+					var ret [1]SimpleFSWaitArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SimpleFSWaitArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSListRecursiveArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSWaitArg)(nil), args)
 						return
 					}
-					err = i.SimpleFSListRecursive(ctx, typedArgs[0])
+					err = i.SimpleFSWait(ctx, typedArgs[0].OpID)
 					return
 				},
 			},
-			"simpleFSListRecursiveToDepth": {
+			"simpleFSDumpDebuggingInfo": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSListRecursiveToDepthArg
+					var ret [1]SimpleFSDumpDebuggingInfoArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSListRecursiveToDepthArg)
+					err = i.SimpleFSDumpDebuggingInfo(ctx)
+					return
+				},
+			},
+			"simpleFSClearConflictState": {
+				MakeArg: func() interface{} {
+					var ret [1]SimpleFSClearConflictStateArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SimpleFSClearConflictStateArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSListRecursiveToDepthArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSClearConflictStateArg)(nil), args)
 						return
 					}
-					err = i.SimpleFSListRecursiveToDepth(ctx, typedArgs[0])
+					err = i.SimpleFSClearConflictState(ctx, typedArgs[0].Path)
 					return
 				},
 			},
-			"simpleFSReadList": {
+			"simpleFSFinishResolvingConflict": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSReadListArg
+					var ret [1]SimpleFSFinishResolvingConflictArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSReadListArg)
+					typedArgs, ok := args.(*[1]SimpleFSFinishResolvingConflictArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSReadListArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSFinishResolvingConflictArg)(nil), args)
 						return
 					}
-					ret, err = i.SimpleFSReadList(ctx, typedArgs[0].OpID)
+					err = i.SimpleFSFinishResolvingConflict(ctx, typedArgs[0].Path)
 					return
 				},
 			},
-			"simpleFSCopy": {
+			"simpleFSForceStuckConflict": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSCopyArg
+					var ret [1]SimpleFSForceStuckConflictArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSCopyArg)
+					typedArgs, ok := args.(*[1]SimpleFSForceStuckConflictArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSCopyArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSForceStuckConflictArg)(nil), args)
 						return
 					}
-					err = i.SimpleFSCopy(ctx, typedArgs[0])
+					err = i.SimpleFSForceStuckConflict(ctx, typedArgs[0].Path)
 					return
 				},
 			},
-			"simpleFSSymlink": {
+			"simpleFSSyncStatus": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSSymlinkArg
+					var ret [1]SimpleFSSyncStatusArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSSymlinkArg)
+					typedArgs, ok := args.(*[1]SimpleFSSyncStatusArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSSymlinkArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSSyncStatusArg)(nil), args)
 						return
 					}
-					err = i.SimpleFSSymlink(ctx, typedArgs[0])
+					ret, err = i.SimpleFSSyncStatus(ctx, typedArgs[0].Filter)
 					return
 				},
 			},
-			"simpleFSCopyRecursive": {
+			"simpleFSUserEditHistory": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSCopyRecursiveArg
+					var ret [1]SimpleFSUserEditHistoryArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSCopyRecursiveArg)
+					ret, err = i.SimpleFSUserEditHistory(ctx)
+					return
+				},
+			},
+			"simpleFSFolderEditHistory": {
+				MakeArg: func() interface{} {
+					var ret [1]SimpleFSFolderEditHistoryArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SimpleFSFolderEditHistoryArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSCopyRecursiveArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSFolderEditHistoryArg)(nil), args)
 						return
 					}
-					err = i.SimpleFSCopyRecursive(ctx, typedArgs[0])
+					ret, err = i.SimpleFSFolderEditHistory(ctx, typedArgs[0].Path)
 					return
 				},
 			},
-			"simpleFSMove": {
+			"simpleFSListFavorites": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSMoveArg
+					var ret [1]SimpleFSListFavoritesArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSMoveArg)
+					ret, err = i.SimpleFSListFavorites(ctx)
+					return
+				},
+			},
+			"simpleFSGetUserQuotaUsage": {
+				MakeArg: func() interface{} {
+					var ret [1]SimpleFSGetUserQuotaUsageArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					ret, err = i.SimpleFSGetUserQuotaUsage(ctx)
+					return
+				},
+			},
+			"simpleFSGetTeamQuotaUsage": {
+				MakeArg: func() interface{} {
+					var ret [1]SimpleFSGetTeamQuotaUsageArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SimpleFSGetTeamQuotaUsageArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSMoveArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSGetTeamQuotaUsageArg)(nil), args)
 						return
 					}
-					err = i.SimpleFSMove(ctx, typedArgs[0])
+					ret, err = i.SimpleFSGetTeamQuotaUsage(ctx, typedArgs[0].TeamName)
 					return
 				},
 			},
-			"simpleFSRename": {
+			"simpleFSGetQuotaAlertSettings": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSRenameArg
+					var ret [1]SimpleFSGetQuotaAlertSettingsArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSRenameArg)
+					ret, err = i.SimpleFSGetQuotaAlertSettings(ctx)
+					return
+				},
+			},
+			"simpleFSSetQuotaAlertSettings": {
+				MakeArg: func() interface{} {
+					var ret [1]SimpleFSSetQuotaAlertSettingsArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SimpleFSSetQuotaAlertSettingsArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSRenameArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSSetQuotaAlertSettingsArg)(nil), args)
 						return
 					}
-					err = i.SimpleFSRename(ctx, typedArgs[0])
+					err = i.SimpleFSSetQuotaAlertSettings(ctx, typedArgs[0].Settings)
 					return
 				},
 			},
-			"simpleFSOpen": {
+			"simpleFSReset": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSOpenArg
+					var ret [1]SimpleFSResetArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSOpenArg)
+					typedArgs, ok := args.(*[1]SimpleFSResetArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSOpenArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSResetArg)(nil), args)
 						return
 					}
-					err = i.SimpleFSOpen(ctx, typedArgs[0])
+					err = i.SimpleFSReset(ctx, typedArgs[0])
 					return
 				},
 			},
-			"simpleFSSetStat": {
+			"simpleFSFolderSyncConfigAndStatus": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSSetStatArg
+					var ret [1]SimpleFSFolderSyncConfigAndStatusArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSSetStatArg)
+					typedArgs, ok := args.(*[1]SimpleFSFolderSyncConfigAndStatusArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSSetStatArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSFolderSyncConfigAndStatusArg)(nil), args)
 						return
 					}
-					err = i.SimpleFSSetStat(ctx, typedArgs[0])
+					ret, err = i.SimpleFSFolderSyncConfigAndStatus(ctx, typedArgs[0].Path)
 					return
 				},
 			},
-			"simpleFSRead": {
+			"simpleFSSetFolderSyncConfig": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSReadArg
+					var ret [1]SimpleFSSetFolderSyncConfigArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSReadArg)
+					typedArgs, ok := args.(*[1]SimpleFSSetFolderSyncConfigArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSReadArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSSetFolderSyncConfigArg)(nil), args)
 						return
 					}
-					ret, err = i.SimpleFSRead(ctx, typedArgs[0])
+					err = i.SimpleFSSetFolderSyncConfig(ctx, typedArgs[0])
 					return
 				},
 			},
-			"simpleFSWrite": {
+			"simpleFSSyncConfigAndStatus": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSWriteArg
+					var ret [1]SimpleFSSyncConfigAndStatusArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSWriteArg)
+					typedArgs, ok := args.(*[1]SimpleFSSyncConfigAndStatusArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSWriteArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSSyncConfigAndStatusArg)(nil), args)
 						return
 					}
-					err = i.SimpleFSWrite(ctx, typedArgs[0])
+					ret, err = i.SimpleFSSyncConfigAndStatus(ctx, typedArgs[0].IdentifyBehavior)
 					return
 				},
 			},
-			"simpleFSRemove": {
+			"simpleFSGetFolder": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSRemoveArg
+					var ret [1]SimpleFSGetFolderArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSRemoveArg)
+					typedArgs, ok := args.(*[1]SimpleFSGetFolderArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSRemoveArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSGetFolderArg)(nil), args)
 						return
 					}
-					err = i.SimpleFSRemove(ctx, typedArgs[0])
+					ret, err = i.SimpleFSGetFolder(ctx, typedArgs[0].Path)
 					return
 				},
 			},
-			"simpleFSStat": {
+			"simpleFSGetOnlineStatus": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSStatArg
+					var ret [1]SimpleFSGetOnlineStatusArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSStatArg)
+					typedArgs, ok := args.(*[1]SimpleFSGetOnlineStatusArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSStatArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSGetOnlineStatusArg)(nil), args)
 						return
 					}
-					ret, err = i.SimpleFSStat(ctx, typedArgs[0])
+					ret, err = i.SimpleFSGetOnlineStatus(ctx, typedArgs[0].ClientID)
 					return
 				},
 			},
-			"simpleFSGetRevisions": {
+			"simpleFSCheckReachability": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSGetRevisionsArg
+					var ret [1]SimpleFSCheckReachabilityArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSGetRevisionsArg)
+					err = i.SimpleFSCheckReachability(ctx)
+					return
+				},
+			},
+			"simpleFSSetDebugLevel": {
+				MakeArg: func() interface{} {
+					var ret [1]SimpleFSSetDebugLevelArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SimpleFSSetDebugLevelArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSGetRevisionsArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSSetDebugLevelArg)(nil), args)
 						return
 					}
-					err = i.SimpleFSGetRevisions(ctx, typedArgs[0])
+					err = i.SimpleFSSetDebugLevel(ctx, typedArgs[0].Level)
 					return
 				},
 			},
-			"simpleFSReadRevisions": {
+			"simpleFSSettings": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSReadRevisionsArg
+					var ret [1]SimpleFSSettingsArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSReadRevisionsArg)
+					ret, err = i.SimpleFSSettings(ctx)
+					return
+				},
+			},
+			"simpleFSSetNotificationThreshold": {
+				MakeArg: func() interface{} {
+					var ret [1]SimpleFSSetNotificationThresholdArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SimpleFSSetNotificationThresholdArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSReadRevisionsArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSSetNotificationThresholdArg)(nil), args)
 						return
 					}
-					ret, err = i.SimpleFSReadRevisions(ctx, typedArgs[0].OpID)
+					err = i.SimpleFSSetNotificationThreshold(ctx, typedArgs[0].Threshold)
 					return
 				},
 			},
-			"simpleFSMakeOpid": {
+			"simpleFSSetSfmiBannerDismissed": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSMakeOpidArg
+					var ret [1]SimpleFSSetSfmiBannerDismissedArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					ret, err = i.SimpleFSMakeOpid(ctx)
+					typedArgs, ok := args.(*[1]SimpleFSSetSfmiBannerDismissedArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SimpleFSSetSfmiBannerDismissedArg)(nil), args)
+						return
+					}
+					err = i.SimpleFSSetSfmiBannerDismissed(ctx, typedArgs[0].Dismissed)
 					return
 				},
 			},
-			"simpleFSClose": {
+			"simpleFSSetSyncOnCellular": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSCloseArg
+					var ret [1]SimpleFSSetSyncOnCellularArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSCloseArg)
+					typedArgs, ok := args.(*[1]SimpleFSSetSyncOnCellularArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSCloseArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSSetSyncOnCellularArg)(nil), args)
 						return
 					}
-					err = i.SimpleFSClose(ctx, typedArgs[0].OpID)
+					err = i.SimpleFSSetSyncOnCellular(ctx, typedArgs[0].SyncOnCellular)
 					return
 				},
 			},
-			"simpleFSCancel": {
+			"simpleFSObfuscatePath": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSCancelArg
+					var ret [1]SimpleFSObfuscatePathArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSCancelArg)
+					typedArgs, ok := args.(*[1]SimpleFSObfuscatePathArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSCancelArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSObfuscatePathArg)(nil), args)
 						return
 					}
-					err = i.SimpleFSCancel(ctx, typedArgs[0].OpID)
+					ret, err = i.SimpleFSObfuscatePath(ctx, typedArgs[0].Path)
 					return
 				},
 			},
-			"simpleFSCheck": {
+			"simpleFSDeobfuscatePath": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSCheckArg
+					var ret [1]SimpleFSDeobfuscatePathArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSCheckArg)
+					typedArgs, ok := args.(*[1]SimpleFSDeobfuscatePathArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSCheckArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSDeobfuscatePathArg)(nil), args)
 						return
 					}
-					ret, err = i.SimpleFSCheck(ctx, typedArgs[0].OpID)
+					ret, err = i.SimpleFSDeobfuscatePath(ctx, typedArgs[0].Path)
 					return
 				},
 			},
-			"simpleFSGetOps": {
+			"simpleFSGetStats": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSGetOpsArg
+					var ret [1]SimpleFSGetStatsArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					ret, err = i.SimpleFSGetOps(ctx)
+					ret, err = i.SimpleFSGetStats(ctx)
 					return
 				},
 			},
-			"simpleFSWait": {
+			"simpleFSSubscribePath": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSWaitArg
+					var ret [1]SimpleFSSubscribePathArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSWaitArg)
+					typedArgs, ok := args.(*[1]SimpleFSSubscribePathArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSWaitArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSSubscribePathArg)(nil), args)
 						return
 					}
-					err = i.SimpleFSWait(ctx, typedArgs[0].OpID)
-					return
-				},
-			},
-			"simpleFSDumpDebuggingInfo": {
-				MakeArg: func() interface{} {
-					var ret [1]SimpleFSDumpDebuggingInfoArg
-					return &ret
-				},
-				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					err = i.SimpleFSDumpDebuggingInfo(ctx)
+					err = i.SimpleFSSubscribePath(ctx, typedArgs[0])
 					return
 				},
 			},
-			"simpleFSClearConflictState": {
+			"simpleFSSubscribeNonPath": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSClearConflictStateArg
+					var ret [1]SimpleFSSubscribeNonPathArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSClearConflictStateArg)
+					typedArgs, ok := args.(*[1]SimpleFSSubscribeNonPathArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSClearConflictStateArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSSubscribeNonPathArg)(nil), args)
 						return
 					}
-					err = i.SimpleFSClearConflictState(ctx, typedArgs[0].Path)
+					err = i.SimpleFSSubscribeNonPath(ctx, typedArgs[0])
 					return
 				},
 			},
-			"simpleFSFinishResolvingConflict": {
+			"simpleFSUnsubscribe": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSFinishResolvingConflictArg
+					var ret [1]SimpleFSUnsubscribeArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSFinishResolvingConflictArg)
+					typedArgs, ok := args.(*[1]SimpleFSUnsubscribeArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSFinishResolvingConflictArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSUnsubscribeArg)(nil), args)
 						return
 					}
-					err = i.SimpleFSFinishResolvingConflict(ctx, typedArgs[0].Path)
+					err = i.SimpleFSUnsubscribe(ctx, typedArgs[0])
 					return
 				},
 			},
-			"simpleFSForceStuckConflict": {
+			"simpleFSStartDownload": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSForceStuckConflictArg
+					var ret [1]SimpleFSStartDownloadArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSForceStuckConflictArg)
+					typedArgs, ok := args.(*[1]SimpleFSStartDownloadArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSForceStuckConflictArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSStartDownloadArg)(nil), args)
 						return
 					}
-					err = i.SimpleFSForceStuckConflict(ctx, typedArgs[0].Path)
+					ret, err = i.SimpleFSStartDownload(ctx, typedArgs[0])
 					return
 				},
 			},
-			"simpleFSSyncStatus": {
+			"simpleFSGetDownloadInfo": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSSyncStatusArg
+					var ret [1]SimpleFSGetDownloadInfoArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSSyncStatusArg)
+					typedArgs, ok := args.(*[1]SimpleFSGetDownloadInfoArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSSyncStatusArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSGetDownloadInfoArg)(nil), args)
 						return
 					}
-					ret, err = i.SimpleFSSyncStatus(ctx, typedArgs[0].Filter)
+					ret, err = i.SimpleFSGetDownloadInfo(ctx, typedArgs[0].DownloadID)
 					return
 				},
 			},
-			"simpleFSUserEditHistory": {
+			"simpleFSGetDownloadStatus": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSUserEditHistoryArg
+					var ret [1]SimpleFSGetDownloadStatusArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					ret, err = i.SimpleFSUserEditHistory(ctx)
+					ret, err = i.SimpleFSGetDownloadStatus(ctx)
 					return
 				},
 			},
-			"simpleFSFolderEditHistory": {
+			"simpleFSCancelDownload": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSFolderEditHistoryArg
+					var ret [1]SimpleFSCancelDownloadArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSFolderEditHistoryArg)
+					typedArgs, ok := args.(*[1]SimpleFSCancelDownloadArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSFolderEditHistoryArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSCancelDownloadArg)(nil), args)
 						return
 					}
-					ret, err = i.SimpleFSFolderEditHistory(ctx, typedArgs[0].Path)
+					err = i.SimpleFSCancelDownload(ctx, typedArgs[0].DownloadID)
 					return
 				},
 			},
-			"simpleFSListFavorites": {
+			"simpleFSDismissDownload": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSListFavoritesArg
+					var ret [1]SimpleFSDismissDownloadArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					ret, err = i.SimpleFSListFavorites(ctx)
+					typedArgs, ok := args.(*[1]SimpleFSDismissDownloadArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SimpleFSDismissDownloadArg)(nil), args)
+						return
+					}
+					err = i.SimpleFSDismissDownload(ctx, typedArgs[0].DownloadID)
 					return
 				},
 			},
-			"simpleFSGetUserQuotaUsage": {
+			"simpleFSConfigureDownload": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSGetUserQuotaUsageArg
+					var ret [1]SimpleFSConfigureDownloadArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					ret, err = i.SimpleFSGetUserQuotaUsage(ctx)
+					typedArgs, ok := args.(*[1]SimpleFSConfigureDownloadArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SimpleFSConfigureDownloadArg)(nil), args)
+						return
+					}
+					err = i.SimpleFSConfigureDownload(ctx, typedArgs[0])
 					return
 				},
 			},
-			"simpleFSGetTeamQuotaUsage": {
+			"simpleFSMakeTempDirForUpload": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSGetTeamQuotaUsageArg
+					var ret [1]SimpleFSMakeTempDirForUploadArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSGetTeamQuotaUsageArg)
-					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSGetTeamQuotaUsageArg)(nil), args)
-						return
-					}
-					ret, err = i.SimpleFSGetTeamQuotaUsage(ctx, typedArgs[0].TeamName)
+					ret, err = i.SimpleFSMakeTempDirForUpload(ctx)
 					return
 				},
 			},
-			"simpleFSReset": {
+			"simpleFSStartUpload": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSResetArg
+					var ret [1]SimpleFSStartUploadArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSResetArg)
+					typedArgs, ok := args.(*[1]SimpleFSStartUploadArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSResetArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSStartUploadArg)(nil), args)
 						return
 					}
-					err = i.SimpleFSReset(ctx, typedArgs[0])
+					ret, err = i.SimpleFSStartUpload(ctx, typedArgs[0])
 					return
 				},
 			},
-			"simpleFSFolderSyncConfigAndStatus": {
+			"simpleFSGetUploadStatus": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSFolderSyncConfigAndStatusArg
+					var ret [1]SimpleFSGetUploadStatusArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSFolderSyncConfigAndStatusArg)
-					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSFolderSyncConfigAndStatusArg)(nil), args)
-						return
-					}
-					ret, err = i.SimpleFSFolderSyncConfigAndStatus(ctx, typedArgs[0].Path)
+					ret, err = i.SimpleFSGetUploadStatus(ctx)
 					return
 				},
 			},
-			"simpleFSSetFolderSyncConfig": {
+			"simpleFSCancelUpload": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSSetFolderSyncConfigArg
+					var ret [1]SimpleFSCancelUploadArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSSetFolderSyncConfigArg)
+					typedArgs, ok := args.(*[1]SimpleFSCancelUploadArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSSetFolderSyncConfigArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSCancelUploadArg)(nil), args)
 						return
 					}
-					err = i.SimpleFSSetFolderSyncConfig(ctx, typedArgs[0])
+					err = i.SimpleFSCancelUpload(ctx, typedArgs[0].UploadID)
 					return
 				},
 			},
-			"simpleFSSyncConfigAndStatus": {
+			"simpleFSDismissUpload": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSSyncConfigAndStatusArg
+					var ret [1]SimpleFSDismissUploadArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSSyncConfigAndStatusArg)
+					typedArgs, ok := args.(*[1]SimpleFSDismissUploadArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSSyncConfigAndStatusArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSDismissUploadArg)(nil), args)
 						return
 					}
-					ret, err = i.SimpleFSSyncConfigAndStatus(ctx, typedArgs[0].IdentifyBehavior)
+					err = i.SimpleFSDismissUpload(ctx, typedArgs[0].UploadID)
 					return
 				},
 			},
-			"simpleFSGetFolder": {
+			"simpleFSGetFilesTabBadge": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSGetFolderArg
+					var ret [1]SimpleFSGetFilesTabBadgeArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSGetFolderArg)
-					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSGetFolderArg)(nil), args)
-						return
-					}
-					ret, err = i.SimpleFSGetFolder(ctx, typedArgs[0].Path)
+					ret, err = i.SimpleFSGetFilesTabBadge(ctx)
 					return
 				},
 			},
-			"simpleFSGetOnlineStatus": {
+			"simpleFSGetGUIFileContext": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSGetOnlineStatusArg
+					var ret [1]SimpleFSGetGUIFileContextArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSGetOnlineStatusArg)
+					typedArgs, ok := args.(*[1]SimpleFSGetGUIFileContextArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSGetOnlineStatusArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSGetGUIFileContextArg)(nil), args)
 						return
 					}
-					ret, err = i.SimpleFSGetOnlineStatus(ctx, typedArgs[0].ClientID)
+					ret, err = i.SimpleFSGetGUIFileContext(ctx, typedArgs[0].Path)
 					return
 				},
 			},
-			"simpleFSCheckReachability": {
+			"simpleFSUserIn": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSCheckReachabilityArg
+					var ret [1]SimpleFSUserInArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					err = i.SimpleFSCheckReachability(ctx)
+					typedArgs, ok := args.(*[1]SimpleFSUserInArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SimpleFSUserInArg)(nil), args)
+						return
+					}
+					err = i.SimpleFSUserIn(ctx, typedArgs[0].ClientID)
 					return
 				},
 			},
-			"simpleFSSetDebugLevel": {
+			"simpleFSUserOut": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSSetDebugLevelArg
+					var ret [1]SimpleFSUserOutArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSSetDebugLevelArg)
+					typedArgs, ok := args.(*[1]SimpleFSUserOutArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSSetDebugLevelArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSUserOutArg)(nil), args)
 						return
 					}
-					err = i.SimpleFSSetDebugLevel(ctx, typedArgs[0].Level)
+					err = i.SimpleFSUserOut(ctx, typedArgs[0].ClientID)
 					return
 				},
 			},
-			"simpleFSSettings": {
+			"simpleFSSearch": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSSettingsArg
+					var ret [1]SimpleFSSearchArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					ret, err = i.SimpleFSSettings(ctx)
+					typedArgs, ok := args.(*[1]SimpleFSSearchArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SimpleFSSearchArg)(nil), args)
+						return
+					}
+					ret, err = i.SimpleFSSearch(ctx, typedArgs[0])
 					return
 				},
 			},
-			"simpleFSSetNotificationThreshold": {
+			"simpleFSResetIndex": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSSetNotificationThresholdArg
+					var ret [1]SimpleFSResetIndexArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSSetNotificationThresholdArg)
-					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSSetNotificationThresholdArg)(nil), args)
-						return
-					}
-					err = i.SimpleFSSetNotificationThreshold(ctx, typedArgs[0].Threshold)
+					err = i.SimpleFSResetIndex(ctx)
 					return
 				},
 			},
-			"simpleFSSetSfmiBannerDismissed": {
+			"simpleFSGetIndexProgress": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSSetSfmiBannerDismissedArg
+					var ret [1]SimpleFSGetIndexProgressArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSSetSfmiBannerDismissedArg)
-					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSSetSfmiBannerDismissedArg)(nil), args)
-						return
-					}
-					err = i.SimpleFSSetSfmiBannerDismissed(ctx, typedArgs[0].Dismissed)
+					ret, err = i.SimpleFSGetIndexProgress(ctx)
 					return
 				},
 			},
-			"simpleFSSetSyncOnCellular": {
+			"simpleFSCancelJournalUploads": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSSetSyncOnCellularArg
+					var ret [1]SimpleFSCancelJournalUploadsArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSSetSyncOnCellularArg)
+					typedArgs, ok := args.(*[1]SimpleFSCancelJournalUploadsArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSSetSyncOnCellularArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSCancelJournalUploadsArg)(nil), args)
 						return
 					}
-					err = i.SimpleFSSetSyncOnCellular(ctx, typedArgs[0].SyncOnCellular)
+					err = i.SimpleFSCancelJournalUploads(ctx, typedArgs[0].Path)
 					return
 				},
 			},
-			"simpleFSObfuscatePath": {
+			"simpleFSArchiveStart": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSObfuscatePathArg
+					var ret [1]SimpleFSArchiveStartArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSObfuscatePathArg)
+					typedArgs, ok := args.(*[1]SimpleFSArchiveStartArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSObfuscatePathArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSArchiveStartArg)(nil), args)
 						return
 					}
-					ret, err = i.SimpleFSObfuscatePath(ctx, typedArgs[0].Path)
+					ret, err = i.SimpleFSArchiveStart(ctx, typedArgs[0])
 					return
 				},
 			},
-			"simpleFSDeobfuscatePath": {
+			"simpleFSArchiveCancelOrDismissJob": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSDeobfuscatePathArg
+					var ret [1]SimpleFSArchiveCancelOrDismissJobArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSDeobfuscatePathArg)
+					typedArgs, ok := args.(*[1]SimpleFSArchiveCancelOrDismissJobArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSDeobfuscatePathArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSArchiveCancelOrDismissJobArg)(nil), args)
 						return
 					}
-					ret, err = i.SimpleFSDeobfuscatePath(ctx, typedArgs[0].Path)
+					err = i.SimpleFSArchiveCancelOrDismissJob(ctx, typedArgs[0].JobID)
 					return
 				},
 			},
-			"simpleFSGetStats": {
+			"simpleFSGetArchiveStatus": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSGetStatsArg
+					var ret [1]SimpleFSGetArchiveStatusArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					ret, err = i.SimpleFSGetStats(ctx)
+					ret, err = i.SimpleFSGetArchiveStatus(ctx)
 					return
 				},
 			},
-			"simpleFSSubscribePath": {
+			"simpleFSLock": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSSubscribePathArg
+					var ret [1]SimpleFSLockArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSSubscribePathArg)
+					typedArgs, ok := args.(*[1]SimpleFSLockArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSSubscribePathArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSLockArg)(nil), args)
 						return
 					}
-					err = i.SimpleFSSubscribePath(ctx, typedArgs[0])
+					ret, err = i.SimpleFSLock(ctx, typedArgs[0])
 					return
 				},
 			},
-			"simpleFSSubscribeNonPath": {
+			"simpleFSUnlock": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSSubscribeNonPathArg
+					var ret [1]SimpleFSUnlockArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSSubscribeNonPathArg)
+					typedArgs, ok := args.(*[1]SimpleFSUnlockArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSSubscribeNonPathArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSUnlockArg)(nil), args)
 						return
 					}
-					err = i.SimpleFSSubscribeNonPath(ctx, typedArgs[0])
+					err = i.SimpleFSUnlock(ctx, typedArgs[0].Path)
 					return
 				},
 			},
-			"simpleFSUnsubscribe": {
+			"simpleFSListLocks": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSUnsubscribeArg
+					var ret [1]SimpleFSListLocksArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSUnsubscribeArg)
-					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSUnsubscribeArg)(nil), args)
-						return
-					}
-					err = i.SimpleFSUnsubscribe(ctx, typedArgs[0])
+					ret, err = i.SimpleFSListLocks(ctx)
 					return
 				},
 			},
-			"simpleFSStartDownload": {
+			"simpleFSArchiveSetBytesPerSecond": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSStartDownloadArg
+					var ret [1]SimpleFSArchiveSetBytesPerSecondArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSStartDownloadArg)
+					typedArgs, ok := args.(*[1]SimpleFSArchiveSetBytesPerSecondArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSStartDownloadArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSArchiveSetBytesPerSecondArg)(nil), args)
 						return
 					}
-					ret, err = i.SimpleFSStartDownload(ctx, typedArgs[0])
+					err = i.SimpleFSArchiveSetBytesPerSecond(ctx, typedArgs[0])
 					return
 				},
 			},
-			"simpleFSGetDownloadInfo": {
+			"simpleFSArchiveSetLabel": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSGetDownloadInfoArg
+					var ret [1]SimpleFSArchiveSetLabelArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSGetDownloadInfoArg)
+					typedArgs, ok := args.(*[1]SimpleFSArchiveSetLabelArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSGetDownloadInfoArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSArchiveSetLabelArg)(nil), args)
 						return
 					}
-					ret, err = i.SimpleFSGetDownloadInfo(ctx, typedArgs[0].DownloadID)
+					err = i.SimpleFSArchiveSetLabel(ctx, typedArgs[0])
 					return
 				},
 			},
-			"simpleFSGetDownloadStatus": {
-				MakeArg: func() interface{} {
-					var ret [1]SimpleFSGetDownloadStatusArg
-					return &ret
-				},
-				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					ret, err = i.SimpleFSGetDownloadStatus(ctx)
-					return
-				},
-			},
-			"simpleFSCancelDownload": {
+			"simpleFSArchiveSchedule": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSCancelDownloadArg
+					var ret [1]SimpleFSArchiveScheduleArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSCancelDownloadArg)
+					typedArgs, ok := args.(*[1]SimpleFSArchiveScheduleArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSCancelDownloadArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSArchiveScheduleArg)(nil), args)
 						return
 					}
-					err = i.SimpleFSCancelDownload(ctx, typedArgs[0].DownloadID)
+					ret, err = i.SimpleFSArchiveSchedule(ctx, typedArgs[0])
 					return
 				},
 			},
-			"simpleFSDismissDownload": {
+			"simpleFSArchiveUnschedule": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSDismissDownloadArg
+					var ret [1]SimpleFSArchiveUnscheduleArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSDismissDownloadArg)
+					typedArgs, ok := args.(*[1]SimpleFSArchiveUnscheduleArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSDismissDownloadArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSArchiveUnscheduleArg)(nil), args)
 						return
 					}
-					err = i.SimpleFSDismissDownload(ctx, typedArgs[0].DownloadID)
+					err = i.SimpleFSArchiveUnschedule(ctx, typedArgs[0].ScheduleID)
 					return
 				},
 			},
-			"simpleFSConfigureDownload": {
+			"simpleFSArchiveCheckArchive": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSConfigureDownloadArg
+					var ret [1]SimpleFSArchiveCheckArchiveArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSConfigureDownloadArg)
+					typedArgs, ok := args.(*[1]SimpleFSArchiveCheckArchiveArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSConfigureDownloadArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSArchiveCheckArchiveArg)(nil), args)
 						return
 					}
-					err = i.SimpleFSConfigureDownload(ctx, typedArgs[0])
+					ret, err = i.SimpleFSArchiveCheckArchive(ctx, typedArgs[0].JobID)
 					return
 				},
 			},
-			"simpleFSMakeTempDirForUpload": {
+			"simpleFSArchiveVerifyJob": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSMakeTempDirForUploadArg
+					var ret [1]SimpleFSArchiveVerifyJobArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					ret, err = i.SimpleFSMakeTempDirForUpload(ctx)
+					typedArgs, ok := args.(*[1]SimpleFSArchiveVerifyJobArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SimpleFSArchiveVerifyJobArg)(nil), args)
+						return
+					}
+					ret, err = i.SimpleFSArchiveVerifyJob(ctx, typedArgs[0])
 					return
 				},
 			},
-			"simpleFSStartUpload": {
+			"simpleFSArchivePauseJob": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSStartUploadArg
+					var ret [1]SimpleFSArchivePauseJobArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSStartUploadArg)
+					typedArgs, ok := args.(*[1]SimpleFSArchivePauseJobArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSStartUploadArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSArchivePauseJobArg)(nil), args)
 						return
 					}
-					ret, err = i.SimpleFSStartUpload(ctx, typedArgs[0])
+					err = i.SimpleFSArchivePauseJob(ctx, typedArgs[0].JobID)
 					return
 				},
 			},
-			"simpleFSGetUploadStatus": {
+			"simpleFSArchiveResumeJob": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSGetUploadStatusArg
+					var ret [1]SimpleFSArchiveResumeJobArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					ret, err = i.SimpleFSGetUploadStatus(ctx)
+					typedArgs, ok := args.(*[1]SimpleFSArchiveResumeJobArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SimpleFSArchiveResumeJobArg)(nil), args)
+						return
+					}
+					err = i.SimpleFSArchiveResumeJob(ctx, typedArgs[0].JobID)
 					return
 				},
 			},
-			"simpleFSCancelUpload": {
+			"simpleFSArchiveRetryJob": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSCancelUploadArg
+					var ret [1]SimpleFSArchiveRetryJobArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSCancelUploadArg)
+					typedArgs, ok := args.(*[1]SimpleFSArchiveRetryJobArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSCancelUploadArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSArchiveRetryJobArg)(nil), args)
 						return
 					}
-					err = i.SimpleFSCancelUpload(ctx, typedArgs[0].UploadID)
+					err = i.SimpleFSArchiveRetryJob(ctx, typedArgs[0].JobID)
 					return
 				},
 			},
-			"simpleFSDismissUpload": {
+			"simpleFSArchiveDrain": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSDismissUploadArg
+					var ret [1]SimpleFSArchiveDrainArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSDismissUploadArg)
+					typedArgs, ok := args.(*[1]SimpleFSArchiveDrainArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSDismissUploadArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSArchiveDrainArg)(nil), args)
 						return
 					}
-					err = i.SimpleFSDismissUpload(ctx, typedArgs[0].UploadID)
+					err = i.SimpleFSArchiveDrain(ctx, typedArgs[0].Timeout)
 					return
 				},
 			},
-			"simpleFSGetFilesTabBadge": {
+			"simpleFSFileHistory": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSGetFilesTabBadgeArg
+					var ret [1]SimpleFSFileHistoryArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					ret, err = i.SimpleFSGetFilesTabBadge(ctx)
+					typedArgs, ok := args.(*[1]SimpleFSFileHistoryArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SimpleFSFileHistoryArg)(nil), args)
+						return
+					}
+					ret, err = i.SimpleFSFileHistory(ctx, typedArgs[0])
 					return
 				},
 			},
-			"simpleFSGetGUIFileContext": {
+			"simpleFSArchiveRestore": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSGetGUIFileContextArg
+					var ret [1]SimpleFSArchiveRestoreArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSGetGUIFileContextArg)
+					typedArgs, ok := args.(*[1]SimpleFSArchiveRestoreArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSGetGUIFileContextArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSArchiveRestoreArg)(nil), args)
 						return
 					}
-					ret, err = i.SimpleFSGetGUIFileContext(ctx, typedArgs[0].Path)
+					ret, err = i.SimpleFSArchiveRestore(ctx, typedArgs[0])
 					return
 				},
 			},
-			"simpleFSUserIn": {
+			"simpleFSArchiveReadInfo": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSUserInArg
+					var ret [1]SimpleFSArchiveReadInfoArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSUserInArg)
+					typedArgs, ok := args.(*[1]SimpleFSArchiveReadInfoArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSUserInArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSArchiveReadInfoArg)(nil), args)
 						return
 					}
-					err = i.SimpleFSUserIn(ctx, typedArgs[0].ClientID)
+					ret, err = i.SimpleFSArchiveReadInfo(ctx, typedArgs[0].ZipFilePath)
 					return
 				},
 			},
-			"simpleFSUserOut": {
+			"simpleFSArchiveGetStagingUsage": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSUserOutArg
+					var ret [1]SimpleFSArchiveGetStagingUsageArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSUserOutArg)
-					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSUserOutArg)(nil), args)
-						return
-					}
-					err = i.SimpleFSUserOut(ctx, typedArgs[0].ClientID)
+					ret, err = i.SimpleFSArchiveGetStagingUsage(ctx)
 					return
 				},
 			},
-			"simpleFSSearch": {
+			"simpleFSArchiveSetMaxStagingBytes": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSSearchArg
+					var ret [1]SimpleFSArchiveSetMaxStagingBytesArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSSearchArg)
+					typedArgs, ok := args.(*[1]SimpleFSArchiveSetMaxStagingBytesArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSSearchArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSArchiveSetMaxStagingBytesArg)(nil), args)
 						return
 					}
-					ret, err = i.SimpleFSSearch(ctx, typedArgs[0])
+					err = i.SimpleFSArchiveSetMaxStagingBytes(ctx, typedArgs[0].MaxStagingBytes)
 					return
 				},
 			},
-			"simpleFSResetIndex": {
+			"simpleFSArchiveCheckParts": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSResetIndexArg
+					var ret [1]SimpleFSArchiveCheckPartsArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					err = i.SimpleFSResetIndex(ctx)
+					typedArgs, ok := args.(*[1]SimpleFSArchiveCheckPartsArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SimpleFSArchiveCheckPartsArg)(nil), args)
+						return
+					}
+					ret, err = i.SimpleFSArchiveCheckParts(ctx, typedArgs[0].PartsManifestPath)
 					return
 				},
 			},
-			"simpleFSGetIndexProgress": {
+			"simpleFSArchiveSearchJobs": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSGetIndexProgressArg
+					var ret [1]SimpleFSArchiveSearchJobsArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					ret, err = i.SimpleFSGetIndexProgress(ctx)
+					typedArgs, ok := args.(*[1]SimpleFSArchiveSearchJobsArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SimpleFSArchiveSearchJobsArg)(nil), args)
+						return
+					}
+					ret, err = i.SimpleFSArchiveSearchJobs(ctx, typedArgs[0].Query)
 					return
 				},
 			},
-			"simpleFSCancelJournalUploads": {
+			"simpleFSArchiveSetRetention": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSCancelJournalUploadsArg
+					var ret [1]SimpleFSArchiveSetRetentionArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSCancelJournalUploadsArg)
+					typedArgs, ok := args.(*[1]SimpleFSArchiveSetRetentionArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSCancelJournalUploadsArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSArchiveSetRetentionArg)(nil), args)
 						return
 					}
-					err = i.SimpleFSCancelJournalUploads(ctx, typedArgs[0].Path)
+					err = i.SimpleFSArchiveSetRetention(ctx, typedArgs[0].Retention)
 					return
 				},
 			},
-			"simpleFSArchiveStart": {
+			"simpleFSRepairCache": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSArchiveStartArg
+					var ret [1]SimpleFSRepairCacheArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSArchiveStartArg)
+					typedArgs, ok := args.(*[1]SimpleFSRepairCacheArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSArchiveStartArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SimpleFSRepairCacheArg)(nil), args)
 						return
 					}
-					ret, err = i.SimpleFSArchiveStart(ctx, typedArgs[0])
+					err = i.SimpleFSRepairCache(ctx, typedArgs[0].Rebuild)
 					return
 				},
 			},
-			"simpleFSArchiveCancelOrDismissJob": {
+			"simpleFSGetCacheRepairProgress": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSArchiveCancelOrDismissJobArg
+					var ret [1]SimpleFSGetCacheRepairProgressArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					typedArgs, ok := args.(*[1]SimpleFSArchiveCancelOrDismissJobArg)
-					if !ok {
-						err = rpc.NewTypeError((*[1]SimpleFSArchiveCancelOrDismissJobArg)(nil), args)
-						return
-					}
-					err = i.SimpleFSArchiveCancelOrDismissJob(ctx, typedArgs[0].JobID)
+					ret, err = i.SimpleFSGetCacheRepairProgress(ctx)
 					return
 				},
 			},
-			"simpleFSGetArchiveStatus": {
+			"simpleFSArchiveListAllDevices": {
 				MakeArg: func() interface{} {
-					var ret [1]SimpleFSGetArchiveStatusArg
+					var ret [1]SimpleFSArchiveListAllDevicesArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
-					ret, err = i.SimpleFSGetArchiveStatus(ctx)
+					ret, err = i.SimpleFSArchiveListAllDevices(ctx)
 					return
 				},
 			},
@@ -3412,6 +5071,8 @@ type SimpleFSClient struct {
 // will begin sending `FSPathUpdated` notifications for the for the
 // corresponding TLF, until another call refreshes the subscription on a
 // different TLF.
+// If `detectMimeTypes` is true, each file's `mimeType` is filled in, from its
+// extension or, for small files, by sniffing its contents.
 func (c SimpleFSClient) SimpleFSList(ctx context.Context, __arg SimpleFSListArg) (err error) {
 	err = c.Cli.Call(ctx, "keybase.1.SimpleFS.simpleFSList", []interface{}{__arg}, nil, 0*time.Millisecond)
 	return
@@ -3647,6 +5308,21 @@ func (c SimpleFSClient) SimpleFSGetTeamQuotaUsage(ctx context.Context, teamName
 	return
 }
 
+// simpleFSGetQuotaAlertSettings returns the quota alert settings of the
+// logged-in user on this device.
+func (c SimpleFSClient) SimpleFSGetQuotaAlertSettings(ctx context.Context) (res SimpleFSQuotaAlertSettings, err error) {
+	err = c.Cli.Call(ctx, "keybase.1.SimpleFS.simpleFSGetQuotaAlertSettings", []interface{}{SimpleFSGetQuotaAlertSettingsArg{}}, &res, 0*time.Millisecond)
+	return
+}
+
+// simpleFSSetQuotaAlertSettings changes the quota alert settings of the
+// logged-in user on this device.
+func (c SimpleFSClient) SimpleFSSetQuotaAlertSettings(ctx context.Context, settings SimpleFSQuotaAlertSettings) (err error) {
+	__arg := SimpleFSSetQuotaAlertSettingsArg{Settings: settings}
+	err = c.Cli.Call(ctx, "keybase.1.SimpleFS.simpleFSSetQuotaAlertSettings", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
 // simpleFSReset completely resets the KBFS folder referenced in `path`.
 // It should only be called after explicit user confirmation.
 func (c SimpleFSClient) SimpleFSReset(ctx context.Context, __arg SimpleFSResetArg) (err error) {
@@ -3868,3 +5544,165 @@ func (c SimpleFSClient) SimpleFSGetArchiveStatus(ctx context.Context) (res Simpl
 	err = c.Cli.Call(ctx, "keybase.1.SimpleFS.simpleFSGetArchiveStatus", []interface{}{SimpleFSGetArchiveStatusArg{}}, &res, 0*time.Millisecond)
 	return
 }
+
+// Take an advisory lock on a KBFS path, using the same mdserver lock
+// facility that coordinates git pushes. If the lock is held by another
+// session, this blocks until it becomes available. The lock is released by
+// simpleFSUnlock, or automatically once the lease expires. Calling it again
+// on a path we already hold extends the lease.
+func (c SimpleFSClient) SimpleFSLock(ctx context.Context, __arg SimpleFSLockArg) (res SimpleFSLockInfo, err error) {
+	err = c.Cli.Call(ctx, "keybase.1.SimpleFS.simpleFSLock", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+// Release an advisory lock taken by simpleFSLock.
+func (c SimpleFSClient) SimpleFSUnlock(ctx context.Context, path KBFSPath) (err error) {
+	__arg := SimpleFSUnlockArg{Path: path}
+	err = c.Cli.Call(ctx, "keybase.1.SimpleFS.simpleFSUnlock", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+// List advisory locks currently held by this device.
+func (c SimpleFSClient) SimpleFSListLocks(ctx context.Context) (res []SimpleFSLockInfo, err error) {
+	err = c.Cli.Call(ctx, "keybase.1.SimpleFS.simpleFSListLocks", []interface{}{SimpleFSListLocksArg{}}, &res, 0*time.Millisecond)
+	return
+}
+
+// Change the copy throttle of an existing archive job. Takes effect
+// immediately if the job is running. 0 means unlimited.
+func (c SimpleFSClient) SimpleFSArchiveSetBytesPerSecond(ctx context.Context, __arg SimpleFSArchiveSetBytesPerSecondArg) (err error) {
+	err = c.Cli.Call(ctx, "keybase.1.SimpleFS.simpleFSArchiveSetBytesPerSecond", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c SimpleFSClient) SimpleFSArchiveSetLabel(ctx context.Context, __arg SimpleFSArchiveSetLabelArg) (err error) {
+	err = c.Cli.Call(ctx, "keybase.1.SimpleFS.simpleFSArchiveSetLabel", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c SimpleFSClient) SimpleFSArchiveSchedule(ctx context.Context, __arg SimpleFSArchiveScheduleArg) (res SimpleFSArchiveSchedule, err error) {
+	err = c.Cli.Call(ctx, "keybase.1.SimpleFS.simpleFSArchiveSchedule", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c SimpleFSClient) SimpleFSArchiveUnschedule(ctx context.Context, scheduleID string) (err error) {
+	__arg := SimpleFSArchiveUnscheduleArg{ScheduleID: scheduleID}
+	err = c.Cli.Call(ctx, "keybase.1.SimpleFS.simpleFSArchiveUnschedule", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c SimpleFSClient) SimpleFSArchiveCheckArchive(ctx context.Context, jobID string) (res SimpleFSArchiveCheckArchiveResult, err error) {
+	__arg := SimpleFSArchiveCheckArchiveArg{JobID: jobID}
+	err = c.Cli.Call(ctx, "keybase.1.SimpleFS.simpleFSArchiveCheckArchive", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c SimpleFSClient) SimpleFSArchiveVerifyJob(ctx context.Context, __arg SimpleFSArchiveVerifyJobArg) (res SimpleFSArchiveCheckArchiveResult, err error) {
+	err = c.Cli.Call(ctx, "keybase.1.SimpleFS.simpleFSArchiveVerifyJob", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+// Stop working on an archive job, but keep its state and staging files
+// so it can pick up where it left off when resumed.
+func (c SimpleFSClient) SimpleFSArchivePauseJob(ctx context.Context, jobID string) (err error) {
+	__arg := SimpleFSArchivePauseJobArg{JobID: jobID}
+	err = c.Cli.Call(ctx, "keybase.1.SimpleFS.simpleFSArchivePauseJob", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c SimpleFSClient) SimpleFSArchiveResumeJob(ctx context.Context, jobID string) (err error) {
+	__arg := SimpleFSArchiveResumeJobArg{JobID: jobID}
+	err = c.Cli.Call(ctx, "keybase.1.SimpleFS.simpleFSArchiveResumeJob", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c SimpleFSClient) SimpleFSArchiveRetryJob(ctx context.Context, jobID string) (err error) {
+	__arg := SimpleFSArchiveRetryJobArg{JobID: jobID}
+	err = c.Cli.Call(ctx, "keybase.1.SimpleFS.simpleFSArchiveRetryJob", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+// Stop working on all archive jobs, waiting up to timeout for the work in
+// progress to stop at a point it can pick up from, and save their state.
+// They're picked up again when KBFS restarts, or after a couple of
+// minutes if it doesn't.
+func (c SimpleFSClient) SimpleFSArchiveDrain(ctx context.Context, timeout DurationSec) (err error) {
+	__arg := SimpleFSArchiveDrainArg{Timeout: timeout}
+	err = c.Cli.Call(ctx, "keybase.1.SimpleFS.simpleFSArchiveDrain", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c SimpleFSClient) SimpleFSFileHistory(ctx context.Context, __arg SimpleFSFileHistoryArg) (res SimpleFSFileHistory, err error) {
+	err = c.Cli.Call(ctx, "keybase.1.SimpleFS.simpleFSFileHistory", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c SimpleFSClient) SimpleFSArchiveRestore(ctx context.Context, __arg SimpleFSArchiveRestoreArg) (res SimpleFSArchiveJobDesc, err error) {
+	err = c.Cli.Call(ctx, "keybase.1.SimpleFS.simpleFSArchiveRestore", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c SimpleFSClient) SimpleFSArchiveReadInfo(ctx context.Context, zipFilePath string) (res SimpleFSArchiveInfo, err error) {
+	__arg := SimpleFSArchiveReadInfoArg{ZipFilePath: zipFilePath}
+	err = c.Cli.Call(ctx, "keybase.1.SimpleFS.simpleFSArchiveReadInfo", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+// Report how much disk space each archive job's staging path is using,
+// and how much of the staging cap it has reserved.
+func (c SimpleFSClient) SimpleFSArchiveGetStagingUsage(ctx context.Context) (res SimpleFSArchiveStagingUsage, err error) {
+	err = c.Cli.Call(ctx, "keybase.1.SimpleFS.simpleFSArchiveGetStagingUsage", []interface{}{SimpleFSArchiveGetStagingUsageArg{}}, &res, 0*time.Millisecond)
+	return
+}
+
+// Cap the total staging space of all archive jobs. A job doesn't start
+// copying until its size fits under the cap, along with the jobs already
+// staged. 0 removes the cap.
+func (c SimpleFSClient) SimpleFSArchiveSetMaxStagingBytes(ctx context.Context, maxStagingBytes int64) (err error) {
+	__arg := SimpleFSArchiveSetMaxStagingBytesArg{MaxStagingBytes: maxStagingBytes}
+	err = c.Cli.Call(ctx, "keybase.1.SimpleFS.simpleFSArchiveSetMaxStagingBytes", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c SimpleFSClient) SimpleFSArchiveCheckParts(ctx context.Context, partsManifestPath string) (res SimpleFSArchiveCheckArchiveResult, err error) {
+	__arg := SimpleFSArchiveCheckPartsArg{PartsManifestPath: partsManifestPath}
+	err = c.Cli.Call(ctx, "keybase.1.SimpleFS.simpleFSArchiveCheckParts", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c SimpleFSClient) SimpleFSArchiveSearchJobs(ctx context.Context, query string) (res []SimpleFSArchiveJobStatus, err error) {
+	__arg := SimpleFSArchiveSearchJobsArg{Query: query}
+	err = c.Cli.Call(ctx, "keybase.1.SimpleFS.simpleFSArchiveSearchJobs", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+// Set which Done archive jobs get dismissed on their own, along with
+// their staging files, like simpleFSArchiveCancelOrDismissJob does.
+// Scheduled runs are kept until their schedule has recorded their zip.
+func (c SimpleFSClient) SimpleFSArchiveSetRetention(ctx context.Context, retention SimpleFSArchiveRetention) (err error) {
+	__arg := SimpleFSArchiveSetRetentionArg{Retention: retention}
+	err = c.Cli.Call(ctx, "keybase.1.SimpleFS.simpleFSArchiveSetRetention", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+// Check the local cache stores, moving aside any corrupt ones and
+// rebuilding them; with rebuild set, rebuild all of them. Synced folder
+// settings that can still be read are kept. Returns once the repair has
+// started; follow it with simpleFSGetCacheRepairProgress.
+func (c SimpleFSClient) SimpleFSRepairCache(ctx context.Context, rebuild bool) (err error) {
+	__arg := SimpleFSRepairCacheArg{Rebuild: rebuild}
+	err = c.Cli.Call(ctx, "keybase.1.SimpleFS.simpleFSRepairCache", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c SimpleFSClient) SimpleFSGetCacheRepairProgress(ctx context.Context) (res SimpleFSCacheRepairProgress, err error) {
+	err = c.Cli.Call(ctx, "keybase.1.SimpleFS.simpleFSGetCacheRepairProgress", []interface{}{SimpleFSGetCacheRepairProgressArg{}}, &res, 0*time.Millisecond)
+	return
+}
+
+// List the archive jobs the user has finished on any of their devices,
+// newest first. Jobs stay listed after they've been dismissed.
+func (c SimpleFSClient) SimpleFSArchiveListAllDevices(ctx context.Context) (res []SimpleFSArchiveJobSummary, err error) {
+	err = c.Cli.Call(ctx, "keybase.1.SimpleFS.simpleFSArchiveListAllDevices", []interface{}{SimpleFSArchiveListAllDevicesArg{}}, &res, 0*time.Millisecond)
+	return
+}
@@ -4551,6 +4551,262 @@ func (o TeamTreeInitial) DeepCopy() TeamTreeInitial {
 	}
 }
 
+type TeamExternalSyncSourceType int
+
+const (
+	TeamExternalSyncSourceType_SCIM TeamExternalSyncSourceType = 0
+	TeamExternalSyncSourceType_LDIF TeamExternalSyncSourceType = 1
+)
+
+func (o TeamExternalSyncSourceType) DeepCopy() TeamExternalSyncSourceType { return o }
+
+var TeamExternalSyncSourceTypeMap = map[string]TeamExternalSyncSourceType{
+	"SCIM": 0,
+	"LDIF": 1,
+}
+
+var TeamExternalSyncSourceTypeRevMap = map[TeamExternalSyncSourceType]string{
+	0: "SCIM",
+	1: "LDIF",
+}
+
+func (e TeamExternalSyncSourceType) String() string {
+	if v, ok := TeamExternalSyncSourceTypeRevMap[e]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(e))
+}
+
+type TeamExternalSyncConfig struct {
+	TeamID        TeamID                     `codec:"teamID" json:"teamID"`
+	SourceType    TeamExternalSyncSourceType `codec:"sourceType" json:"sourceType"`
+	Source        string                     `codec:"source" json:"source"`
+	BearerToken   string                     `codec:"bearerToken" json:"bearerToken"`
+	Role          TeamRole                   `codec:"role" json:"role"`
+	RemoveMissing bool                       `codec:"removeMissing" json:"removeMissing"`
+	Interval      DurationSec                `codec:"interval" json:"interval"`
+}
+
+func (o TeamExternalSyncConfig) DeepCopy() TeamExternalSyncConfig {
+	return TeamExternalSyncConfig{
+		TeamID:        o.TeamID.DeepCopy(),
+		SourceType:    o.SourceType.DeepCopy(),
+		Source:        o.Source,
+		BearerToken:   o.BearerToken,
+		Role:          o.Role.DeepCopy(),
+		RemoveMissing: o.RemoveMissing,
+		Interval:      o.Interval.DeepCopy(),
+	}
+}
+
+type TeamExternalSyncReport struct {
+	DryRun         bool     `codec:"dryRun" json:"dryRun"`
+	SourceCount    int      `codec:"sourceCount" json:"sourceCount"`
+	Added          []string `codec:"added" json:"added"`
+	Removed        []string `codec:"removed" json:"removed"`
+	UnchangedCount int      `codec:"unchangedCount" json:"unchangedCount"`
+	Unresolved     []string `codec:"unresolved" json:"unresolved"`
+	Failures       []string `codec:"failures" json:"failures"`
+}
+
+func (o TeamExternalSyncReport) DeepCopy() TeamExternalSyncReport {
+	return TeamExternalSyncReport{
+		DryRun:      o.DryRun,
+		SourceCount: o.SourceCount,
+		Added: (func(x []string) []string {
+			if x == nil {
+				return nil
+			}
+			ret := make([]string, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Added),
+		Removed: (func(x []string) []string {
+			if x == nil {
+				return nil
+			}
+			ret := make([]string, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Removed),
+		UnchangedCount: o.UnchangedCount,
+		Unresolved: (func(x []string) []string {
+			if x == nil {
+				return nil
+			}
+			ret := make([]string, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Unresolved),
+		Failures: (func(x []string) []string {
+			if x == nil {
+				return nil
+			}
+			ret := make([]string, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Failures),
+	}
+}
+
+type TeamExternalSyncState struct {
+	Config     TeamExternalSyncConfig  `codec:"config" json:"config"`
+	LastRun    Time                    `codec:"lastRun" json:"lastRun"`
+	LastError  string                  `codec:"lastError" json:"lastError"`
+	LastReport *TeamExternalSyncReport `codec:"lastReport,omitempty" json:"lastReport,omitempty"`
+}
+
+func (o TeamExternalSyncState) DeepCopy() TeamExternalSyncState {
+	return TeamExternalSyncState{
+		Config:    o.Config.DeepCopy(),
+		LastRun:   o.LastRun.DeepCopy(),
+		LastError: o.LastError,
+		LastReport: (func(x *TeamExternalSyncReport) *TeamExternalSyncReport {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x).DeepCopy()
+			return &tmp
+		})(o.LastReport),
+	}
+}
+
+type SeitanInviteExtras struct {
+	TeamID            TeamID       `codec:"teamID" json:"teamID"`
+	InviteID          TeamInviteID `codec:"inviteID" json:"inviteID"`
+	Role              TeamRole     `codec:"role" json:"role"`
+	Channels          []string     `codec:"channels" json:"channels"`
+	WelcomeDMTemplate string       `codec:"welcomeDMTemplate" json:"welcomeDMTemplate"`
+	Ctime             Time         `codec:"ctime" json:"ctime"`
+}
+
+func (o SeitanInviteExtras) DeepCopy() SeitanInviteExtras {
+	return SeitanInviteExtras{
+		TeamID:   o.TeamID.DeepCopy(),
+		InviteID: o.InviteID.DeepCopy(),
+		Role:     o.Role.DeepCopy(),
+		Channels: (func(x []string) []string {
+			if x == nil {
+				return nil
+			}
+			ret := make([]string, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Channels),
+		WelcomeDMTemplate: o.WelcomeDMTemplate,
+		Ctime:             o.Ctime.DeepCopy(),
+	}
+}
+
+type SeitanTokenWithExtras struct {
+	Ikey   SeitanIKeyV2       `codec:"ikey" json:"ikey"`
+	Extras SeitanInviteExtras `codec:"extras" json:"extras"`
+}
+
+func (o SeitanTokenWithExtras) DeepCopy() SeitanTokenWithExtras {
+	return SeitanTokenWithExtras{
+		Ikey:   o.Ikey.DeepCopy(),
+		Extras: o.Extras.DeepCopy(),
+	}
+}
+
+type TeamSubteamRole struct {
+	Subteam TeamName `codec:"subteam" json:"subteam"`
+	Role    TeamRole `codec:"role" json:"role"`
+}
+
+func (o TeamSubteamRole) DeepCopy() TeamSubteamRole {
+	return TeamSubteamRole{
+		Subteam: o.Subteam.DeepCopy(),
+		Role:    o.Role.DeepCopy(),
+	}
+}
+
+type TeamSubteamCoverageMember struct {
+	Username    string            `codec:"username" json:"username"`
+	Role        TeamRole          `codec:"role" json:"role"`
+	MemberOf    []TeamSubteamRole `codec:"memberOf" json:"memberOf"`
+	MissingFrom []TeamName        `codec:"missingFrom" json:"missingFrom"`
+}
+
+func (o TeamSubteamCoverageMember) DeepCopy() TeamSubteamCoverageMember {
+	return TeamSubteamCoverageMember{
+		Username: o.Username,
+		Role:     o.Role.DeepCopy(),
+		MemberOf: (func(x []TeamSubteamRole) []TeamSubteamRole {
+			if x == nil {
+				return nil
+			}
+			ret := make([]TeamSubteamRole, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.MemberOf),
+		MissingFrom: (func(x []TeamName) []TeamName {
+			if x == nil {
+				return nil
+			}
+			ret := make([]TeamName, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.MissingFrom),
+	}
+}
+
+type TeamSubteamCoverage struct {
+	Team     TeamName                    `codec:"team" json:"team"`
+	Subteams []TeamName                  `codec:"subteams" json:"subteams"`
+	Members  []TeamSubteamCoverageMember `codec:"members" json:"members"`
+}
+
+func (o TeamSubteamCoverage) DeepCopy() TeamSubteamCoverage {
+	return TeamSubteamCoverage{
+		Team: o.Team.DeepCopy(),
+		Subteams: (func(x []TeamName) []TeamName {
+			if x == nil {
+				return nil
+			}
+			ret := make([]TeamName, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Subteams),
+		Members: (func(x []TeamSubteamCoverageMember) []TeamSubteamCoverageMember {
+			if x == nil {
+				return nil
+			}
+			ret := make([]TeamSubteamCoverageMember, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Members),
+	}
+}
+
 type GetUntrustedTeamInfoArg struct {
 	TeamName TeamName `codec:"teamName" json:"teamName"`
 }
@@ -4937,6 +5193,51 @@ type FindAssertionsInTeamNoResolveArg struct {
 	Assertions []string `codec:"assertions" json:"assertions"`
 }
 
+type TeamExternalSyncArg struct {
+	SessionID int                    `codec:"sessionID" json:"sessionID"`
+	Config    TeamExternalSyncConfig `codec:"config" json:"config"`
+	DryRun    bool                   `codec:"dryRun" json:"dryRun"`
+}
+
+type TeamSetExternalSyncArg struct {
+	SessionID int                    `codec:"sessionID" json:"sessionID"`
+	Config    TeamExternalSyncConfig `codec:"config" json:"config"`
+}
+
+type TeamClearExternalSyncArg struct {
+	SessionID int    `codec:"sessionID" json:"sessionID"`
+	TeamID    TeamID `codec:"teamID" json:"teamID"`
+}
+
+type TeamListExternalSyncsArg struct {
+	SessionID int `codec:"sessionID" json:"sessionID"`
+}
+
+type TeamCreateSeitanTokenWithExtrasArg struct {
+	SessionID         int            `codec:"sessionID" json:"sessionID"`
+	Teamname          string         `codec:"teamname" json:"teamname"`
+	Role              TeamRole       `codec:"role" json:"role"`
+	Label             SeitanKeyLabel `codec:"label" json:"label"`
+	Channels          []string       `codec:"channels" json:"channels"`
+	WelcomeDMTemplate string         `codec:"welcomeDMTemplate" json:"welcomeDMTemplate"`
+}
+
+type TeamListSeitanInviteExtrasArg struct {
+	SessionID int    `codec:"sessionID" json:"sessionID"`
+	TeamID    TeamID `codec:"teamID" json:"teamID"`
+}
+
+type TeamRevokeSeitanInviteArg struct {
+	SessionID int          `codec:"sessionID" json:"sessionID"`
+	TeamID    TeamID       `codec:"teamID" json:"teamID"`
+	InviteID  TeamInviteID `codec:"inviteID" json:"inviteID"`
+}
+
+type TeamGetSubteamCoverageArg struct {
+	SessionID int    `codec:"sessionID" json:"sessionID"`
+	TeamName  string `codec:"teamName" json:"teamName"`
+}
+
 type TeamsInterface interface {
 	GetUntrustedTeamInfo(context.Context, TeamName) (UntrustedTeamInfo, error)
 	TeamCreate(context.Context, TeamCreateArg) (TeamCreateResult, error)
@@ -5023,6 +5324,19 @@ type TeamsInterface interface {
 	GetAnnotatedTeamByName(context.Context, string) (AnnotatedTeam, error)
 	LoadTeamTreeMembershipsAsync(context.Context, LoadTeamTreeMembershipsAsyncArg) (TeamTreeInitial, error)
 	FindAssertionsInTeamNoResolve(context.Context, FindAssertionsInTeamNoResolveArg) ([]string, error)
+	TeamExternalSync(context.Context, TeamExternalSyncArg) (TeamExternalSyncReport, error)
+	TeamSetExternalSync(context.Context, TeamSetExternalSyncArg) error
+	TeamClearExternalSync(context.Context, TeamClearExternalSyncArg) error
+	TeamListExternalSyncs(context.Context, int) ([]TeamExternalSyncState, error)
+	// Like teamCreateSeitanTokenV2, but the invitee is also added to channels
+	// and sent welcomeDMTemplate when the invite is redeemed. Extras are kept
+	// on this device, so they're only applied if it handles the redemption.
+	TeamCreateSeitanTokenWithExtras(context.Context, TeamCreateSeitanTokenWithExtrasArg) (SeitanTokenWithExtras, error)
+	// Lists the extras of the team's outstanding invites created on this device.
+	TeamListSeitanInviteExtras(context.Context, TeamListSeitanInviteExtrasArg) ([]SeitanInviteExtras, error)
+	// Cancels the invite and forgets its extras.
+	TeamRevokeSeitanInvite(context.Context, TeamRevokeSeitanInviteArg) error
+	TeamGetSubteamCoverage(context.Context, TeamGetSubteamCoverageArg) (TeamSubteamCoverage, error)
 }
 
 func TeamsProtocol(i TeamsInterface) rpc.Protocol {
@@ -6029,6 +6343,126 @@ func TeamsProtocol(i TeamsInterface) rpc.Protocol {
 					return
 				},
 			},
+			"teamExternalSync": {
+				MakeArg: func() interface{} {
+					var ret [1]TeamExternalSyncArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]TeamExternalSyncArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]TeamExternalSyncArg)(nil), args)
+						return
+					}
+					ret, err = i.TeamExternalSync(ctx, typedArgs[0])
+					return
+				},
+			},
+			"teamSetExternalSync": {
+				MakeArg: func() interface{} {
+					var ret [1]TeamSetExternalSyncArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]TeamSetExternalSyncArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]TeamSetExternalSyncArg)(nil), args)
+						return
+					}
+					err = i.TeamSetExternalSync(ctx, typedArgs[0])
+					return
+				},
+			},
+			"teamClearExternalSync": {
+				MakeArg: func() interface{} {
+					var ret [1]TeamClearExternalSyncArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]TeamClearExternalSyncArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]TeamClearExternalSyncArg)(nil), args)
+						return
+					}
+					err = i.TeamClearExternalSync(ctx, typedArgs[0])
+					return
+				},
+			},
+			"teamListExternalSyncs": {
+				MakeArg: func() interface{} {
+					var ret [1]TeamListExternalSyncsArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]TeamListExternalSyncsArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]TeamListExternalSyncsArg)(nil), args)
+						return
+					}
+					ret, err = i.TeamListExternalSyncs(ctx, typedArgs[0].SessionID)
+					return
+				},
+			},
+			"teamCreateSeitanTokenWithExtras": {
+				MakeArg: func() interface{} {
+					var ret [1]TeamCreateSeitanTokenWithExtrasArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]TeamCreateSeitanTokenWithExtrasArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]TeamCreateSeitanTokenWithExtrasArg)(nil), args)
+						return
+					}
+					ret, err = i.TeamCreateSeitanTokenWithExtras(ctx, typedArgs[0])
+					return
+				},
+			},
+			"teamListSeitanInviteExtras": {
+				MakeArg: func() interface{} {
+					var ret [1]TeamListSeitanInviteExtrasArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]TeamListSeitanInviteExtrasArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]TeamListSeitanInviteExtrasArg)(nil), args)
+						return
+					}
+					ret, err = i.TeamListSeitanInviteExtras(ctx, typedArgs[0])
+					return
+				},
+			},
+			"teamRevokeSeitanInvite": {
+				MakeArg: func() interface{} {
+					var ret [1]TeamRevokeSeitanInviteArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]TeamRevokeSeitanInviteArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]TeamRevokeSeitanInviteArg)(nil), args)
+						return
+					}
+					err = i.TeamRevokeSeitanInvite(ctx, typedArgs[0])
+					return
+				},
+			},
+			"teamGetSubteamCoverage": {
+				MakeArg: func() interface{} {
+					var ret [1]TeamGetSubteamCoverageArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]TeamGetSubteamCoverageArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]TeamGetSubteamCoverageArg)(nil), args)
+						return
+					}
+					ret, err = i.TeamGetSubteamCoverage(ctx, typedArgs[0])
+					return
+				},
+			},
 		},
 	}
 }
@@ -6404,3 +6838,49 @@ func (c TeamsClient) FindAssertionsInTeamNoResolve(ctx context.Context, __arg Fi
 	err = c.Cli.Call(ctx, "keybase.1.teams.findAssertionsInTeamNoResolve", []interface{}{__arg}, &res, 0*time.Millisecond)
 	return
 }
+
+func (c TeamsClient) TeamExternalSync(ctx context.Context, __arg TeamExternalSyncArg) (res TeamExternalSyncReport, err error) {
+	err = c.Cli.Call(ctx, "keybase.1.teams.teamExternalSync", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c TeamsClient) TeamSetExternalSync(ctx context.Context, __arg TeamSetExternalSyncArg) (err error) {
+	err = c.Cli.Call(ctx, "keybase.1.teams.teamSetExternalSync", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c TeamsClient) TeamClearExternalSync(ctx context.Context, __arg TeamClearExternalSyncArg) (err error) {
+	err = c.Cli.Call(ctx, "keybase.1.teams.teamClearExternalSync", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c TeamsClient) TeamListExternalSyncs(ctx context.Context, sessionID int) (res []TeamExternalSyncState, err error) {
+	__arg := TeamListExternalSyncsArg{SessionID: sessionID}
+	err = c.Cli.Call(ctx, "keybase.1.teams.teamListExternalSyncs", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+// Like teamCreateSeitanTokenV2, but the invitee is also added to channels
+// and sent welcomeDMTemplate when the invite is redeemed. Extras are kept
+// on this device, so they're only applied if it handles the redemption.
+func (c TeamsClient) TeamCreateSeitanTokenWithExtras(ctx context.Context, __arg TeamCreateSeitanTokenWithExtrasArg) (res SeitanTokenWithExtras, err error) {
+	err = c.Cli.Call(ctx, "keybase.1.teams.teamCreateSeitanTokenWithExtras", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+// Lists the extras of the team's outstanding invites created on this device.
+func (c TeamsClient) TeamListSeitanInviteExtras(ctx context.Context, __arg TeamListSeitanInviteExtrasArg) (res []SeitanInviteExtras, err error) {
+	err = c.Cli.Call(ctx, "keybase.1.teams.teamListSeitanInviteExtras", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+// Cancels the invite and forgets its extras.
+func (c TeamsClient) TeamRevokeSeitanInvite(ctx context.Context, __arg TeamRevokeSeitanInviteArg) (err error) {
+	err = c.Cli.Call(ctx, "keybase.1.teams.teamRevokeSeitanInvite", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c TeamsClient) TeamGetSubteamCoverage(ctx context.Context, __arg TeamGetSubteamCoverageArg) (res TeamSubteamCoverage, err error) {
+	err = c.Cli.Call(ctx, "keybase.1.teams.teamGetSubteamCoverage", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
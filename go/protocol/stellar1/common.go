@@ -203,6 +203,8 @@ const (
 	RequestStatus_OK       RequestStatus = 0
 	RequestStatus_CANCELED RequestStatus = 1
 	RequestStatus_DONE     RequestStatus = 2
+	RequestStatus_DECLINED RequestStatus = 3
+	RequestStatus_EXPIRED  RequestStatus = 4
 )
 
 func (o RequestStatus) DeepCopy() RequestStatus { return o }
@@ -211,12 +213,16 @@ var RequestStatusMap = map[string]RequestStatus{
 	"OK":       0,
 	"CANCELED": 1,
 	"DONE":     2,
+	"DECLINED": 3,
+	"EXPIRED":  4,
 }
 
 var RequestStatusRevMap = map[RequestStatus]string{
 	0: "OK",
 	1: "CANCELED",
 	2: "DONE",
+	3: "DECLINED",
+	4: "EXPIRED",
 }
 
 func (e RequestStatus) String() string {
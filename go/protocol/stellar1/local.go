@@ -1313,6 +1313,75 @@ func (o StaticConfig) DeepCopy() StaticConfig {
 	}
 }
 
+type SpendingPolicyLocal struct {
+	PerTxLimitXLM   string `codec:"perTxLimitXLM" json:"perTxLimitXLM"`
+	DailyLimitXLM   string `codec:"dailyLimitXLM" json:"dailyLimitXLM"`
+	ConfirmAboveXLM string `codec:"confirmAboveXLM" json:"confirmAboveXLM"`
+}
+
+func (o SpendingPolicyLocal) DeepCopy() SpendingPolicyLocal {
+	return SpendingPolicyLocal{
+		PerTxLimitXLM:   o.PerTxLimitXLM,
+		DailyLimitXLM:   o.DailyLimitXLM,
+		ConfirmAboveXLM: o.ConfirmAboveXLM,
+	}
+}
+
+type SpendingAuditDecision int
+
+const (
+	SpendingAuditDecision_ALLOWED             SpendingAuditDecision = 0
+	SpendingAuditDecision_CONFIRMED           SpendingAuditDecision = 1
+	SpendingAuditDecision_BLOCKED             SpendingAuditDecision = 2
+	SpendingAuditDecision_CONFIRMATION_FAILED SpendingAuditDecision = 3
+	SpendingAuditDecision_POLICY_CHANGED      SpendingAuditDecision = 4
+)
+
+func (o SpendingAuditDecision) DeepCopy() SpendingAuditDecision { return o }
+
+var SpendingAuditDecisionMap = map[string]SpendingAuditDecision{
+	"ALLOWED":             0,
+	"CONFIRMED":           1,
+	"BLOCKED":             2,
+	"CONFIRMATION_FAILED": 3,
+	"POLICY_CHANGED":      4,
+}
+
+var SpendingAuditDecisionRevMap = map[SpendingAuditDecision]string{
+	0: "ALLOWED",
+	1: "CONFIRMED",
+	2: "BLOCKED",
+	3: "CONFIRMATION_FAILED",
+	4: "POLICY_CHANGED",
+}
+
+func (e SpendingAuditDecision) String() string {
+	if v, ok := SpendingAuditDecisionRevMap[e]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(e))
+}
+
+type SpendingAuditEntryLocal struct {
+	Ctime     TimeMs                `codec:"ctime" json:"ctime"`
+	From      AccountID             `codec:"from" json:"from"`
+	Recipient string                `codec:"recipient" json:"recipient"`
+	AmountXLM string                `codec:"amountXLM" json:"amountXLM"`
+	Decision  SpendingAuditDecision `codec:"decision" json:"decision"`
+	Reason    string                `codec:"reason" json:"reason"`
+}
+
+func (o SpendingAuditEntryLocal) DeepCopy() SpendingAuditEntryLocal {
+	return SpendingAuditEntryLocal{
+		Ctime:     o.Ctime.DeepCopy(),
+		From:      o.From.DeepCopy(),
+		Recipient: o.Recipient,
+		AmountXLM: o.AmountXLM,
+		Decision:  o.Decision.DeepCopy(),
+		Reason:    o.Reason,
+	}
+}
+
 type GetWalletAccountsLocalArg struct {
 	SessionID int `codec:"sessionID" json:"sessionID"`
 }
@@ -1765,6 +1834,28 @@ type SignTransactionXdrLocalArg struct {
 type GetStaticConfigLocalArg struct {
 }
 
+type GetSpendingPolicyLocalArg struct {
+	SessionID int `codec:"sessionID" json:"sessionID"`
+}
+
+type SetSpendingPolicyLocalArg struct {
+	SessionID int                 `codec:"sessionID" json:"sessionID"`
+	Policy    SpendingPolicyLocal `codec:"policy" json:"policy"`
+}
+
+type GetSpendingAuditLocalArg struct {
+	SessionID int `codec:"sessionID" json:"sessionID"`
+}
+
+type DeclineRequestLocalArg struct {
+	SessionID int              `codec:"sessionID" json:"sessionID"`
+	ReqID     KeybaseRequestID `codec:"reqID" json:"reqID"`
+}
+
+type GetPendingRequestsLocalArg struct {
+	SessionID int `codec:"sessionID" json:"sessionID"`
+}
+
 type LocalInterface interface {
 	GetWalletAccountsLocal(context.Context, int) ([]WalletAccountLocal, error)
 	GetWalletAccountLocal(context.Context, GetWalletAccountLocalArg) (WalletAccountLocal, error)
@@ -1847,6 +1938,11 @@ type LocalInterface interface {
 	GetPartnerUrlsLocal(context.Context, int) ([]PartnerUrl, error)
 	SignTransactionXdrLocal(context.Context, SignTransactionXdrLocalArg) (SignXdrResult, error)
 	GetStaticConfigLocal(context.Context) (StaticConfig, error)
+	GetSpendingPolicyLocal(context.Context, int) (SpendingPolicyLocal, error)
+	SetSpendingPolicyLocal(context.Context, SetSpendingPolicyLocalArg) error
+	GetSpendingAuditLocal(context.Context, int) ([]SpendingAuditEntryLocal, error)
+	DeclineRequestLocal(context.Context, DeclineRequestLocalArg) error
+	GetPendingRequestsLocal(context.Context, int) ([]RequestDetailsLocal, error)
 }
 
 func LocalProtocol(i LocalInterface) rpc.Protocol {
@@ -3043,6 +3139,81 @@ func LocalProtocol(i LocalInterface) rpc.Protocol {
 					return
 				},
 			},
+			"getSpendingPolicyLocal": {
+				MakeArg: func() interface{} {
+					var ret [1]GetSpendingPolicyLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]GetSpendingPolicyLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]GetSpendingPolicyLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.GetSpendingPolicyLocal(ctx, typedArgs[0].SessionID)
+					return
+				},
+			},
+			"setSpendingPolicyLocal": {
+				MakeArg: func() interface{} {
+					var ret [1]SetSpendingPolicyLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SetSpendingPolicyLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SetSpendingPolicyLocalArg)(nil), args)
+						return
+					}
+					err = i.SetSpendingPolicyLocal(ctx, typedArgs[0])
+					return
+				},
+			},
+			"getSpendingAuditLocal": {
+				MakeArg: func() interface{} {
+					var ret [1]GetSpendingAuditLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]GetSpendingAuditLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]GetSpendingAuditLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.GetSpendingAuditLocal(ctx, typedArgs[0].SessionID)
+					return
+				},
+			},
+			"declineRequestLocal": {
+				MakeArg: func() interface{} {
+					var ret [1]DeclineRequestLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]DeclineRequestLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]DeclineRequestLocalArg)(nil), args)
+						return
+					}
+					err = i.DeclineRequestLocal(ctx, typedArgs[0])
+					return
+				},
+			},
+			"getPendingRequestsLocal": {
+				MakeArg: func() interface{} {
+					var ret [1]GetPendingRequestsLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]GetPendingRequestsLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]GetPendingRequestsLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.GetPendingRequestsLocal(ctx, typedArgs[0].SessionID)
+					return
+				},
+			},
 		},
 	}
 }
@@ -3472,3 +3643,31 @@ func (c LocalClient) GetStaticConfigLocal(ctx context.Context) (res StaticConfig
 	err = c.Cli.Call(ctx, "stellar.1.local.getStaticConfigLocal", []interface{}{GetStaticConfigLocalArg{}}, &res, 0*time.Millisecond)
 	return
 }
+
+func (c LocalClient) GetSpendingPolicyLocal(ctx context.Context, sessionID int) (res SpendingPolicyLocal, err error) {
+	__arg := GetSpendingPolicyLocalArg{SessionID: sessionID}
+	err = c.Cli.Call(ctx, "stellar.1.local.getSpendingPolicyLocal", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) SetSpendingPolicyLocal(ctx context.Context, __arg SetSpendingPolicyLocalArg) (err error) {
+	err = c.Cli.Call(ctx, "stellar.1.local.setSpendingPolicyLocal", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) GetSpendingAuditLocal(ctx context.Context, sessionID int) (res []SpendingAuditEntryLocal, err error) {
+	__arg := GetSpendingAuditLocalArg{SessionID: sessionID}
+	err = c.Cli.Call(ctx, "stellar.1.local.getSpendingAuditLocal", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) DeclineRequestLocal(ctx context.Context, __arg DeclineRequestLocalArg) (err error) {
+	err = c.Cli.Call(ctx, "stellar.1.local.declineRequestLocal", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) GetPendingRequestsLocal(ctx context.Context, sessionID int) (res []RequestDetailsLocal, err error) {
+	__arg := GetPendingRequestsLocalArg{SessionID: sessionID}
+	err = c.Cli.Call(ctx, "stellar.1.local.getPendingRequestsLocal", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
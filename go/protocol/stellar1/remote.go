@@ -1068,6 +1068,21 @@ type PostAnyTransactionArg struct {
 	SignedTransaction string               `codec:"signedTransaction" json:"signedTransaction"`
 }
 
+type DeclineRequestArg struct {
+	Caller keybase1.UserVersion `codec:"caller" json:"caller"`
+	ReqID  KeybaseRequestID     `codec:"reqID" json:"reqID"`
+}
+
+type PendingRequestsArg struct {
+	Caller keybase1.UserVersion `codec:"caller" json:"caller"`
+}
+
+type FulfillRequestArg struct {
+	Caller keybase1.UserVersion `codec:"caller" json:"caller"`
+	ReqID  KeybaseRequestID     `codec:"reqID" json:"reqID"`
+	KbTxID KeybaseTransactionID `codec:"kbTxID" json:"kbTxID"`
+}
+
 type RemoteInterface interface {
 	Balances(context.Context, BalancesArg) ([]Balance, error)
 	Details(context.Context, DetailsArg) (AccountDetails, error)
@@ -1099,6 +1114,9 @@ type RemoteInterface interface {
 	ChangeTrustline(context.Context, ChangeTrustlineArg) error
 	FindPaymentPath(context.Context, FindPaymentPathArg) (PaymentPath, error)
 	PostAnyTransaction(context.Context, PostAnyTransactionArg) error
+	DeclineRequest(context.Context, DeclineRequestArg) error
+	PendingRequests(context.Context, keybase1.UserVersion) ([]RequestDetails, error)
+	FulfillRequest(context.Context, FulfillRequestArg) error
 }
 
 func RemoteProtocol(i RemoteInterface) rpc.Protocol {
@@ -1550,6 +1568,51 @@ func RemoteProtocol(i RemoteInterface) rpc.Protocol {
 					return
 				},
 			},
+			"declineRequest": {
+				MakeArg: func() interface{} {
+					var ret [1]DeclineRequestArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]DeclineRequestArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]DeclineRequestArg)(nil), args)
+						return
+					}
+					err = i.DeclineRequest(ctx, typedArgs[0])
+					return
+				},
+			},
+			"pendingRequests": {
+				MakeArg: func() interface{} {
+					var ret [1]PendingRequestsArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]PendingRequestsArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]PendingRequestsArg)(nil), args)
+						return
+					}
+					ret, err = i.PendingRequests(ctx, typedArgs[0].Caller)
+					return
+				},
+			},
+			"fulfillRequest": {
+				MakeArg: func() interface{} {
+					var ret [1]FulfillRequestArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]FulfillRequestArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]FulfillRequestArg)(nil), args)
+						return
+					}
+					err = i.FulfillRequest(ctx, typedArgs[0])
+					return
+				},
+			},
 		},
 	}
 }
@@ -1712,3 +1775,19 @@ func (c RemoteClient) PostAnyTransaction(ctx context.Context, __arg PostAnyTrans
 	err = c.Cli.Call(ctx, "stellar.1.remote.postAnyTransaction", []interface{}{__arg}, nil, 0*time.Millisecond)
 	return
 }
+
+func (c RemoteClient) DeclineRequest(ctx context.Context, __arg DeclineRequestArg) (err error) {
+	err = c.Cli.Call(ctx, "stellar.1.remote.declineRequest", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c RemoteClient) PendingRequests(ctx context.Context, caller keybase1.UserVersion) (res []RequestDetails, err error) {
+	__arg := PendingRequestsArg{Caller: caller}
+	err = c.Cli.Call(ctx, "stellar.1.remote.pendingRequests", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c RemoteClient) FulfillRequest(ctx context.Context, __arg FulfillRequestArg) (err error) {
+	err = c.Cli.Call(ctx, "stellar.1.remote.fulfillRequest", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
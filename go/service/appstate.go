@@ -33,12 +33,13 @@ func (a *appStateHandler) UpdateAppState(ctx context.Context, state keybase1.Mob
 	return nil
 }
 
-func (a *appStateHandler) UpdateMobileNetState(ctx context.Context, stateStr string) (err error) {
-	a.G().Log.CDebugf(ctx, "UpdateMobileNetState(%v)", stateStr)
+func (a *appStateHandler) UpdateMobileNetState(ctx context.Context, arg keybase1.UpdateMobileNetStateArg) (err error) {
+	a.G().Log.CDebugf(ctx, "UpdateMobileNetState(%v, %v)", arg.State, arg.IsExpensive)
 
 	// normalize what the frontend gives us, `bluetooth`, `ethernet`, and
 	// `wimax` are android only values.
 	var state keybase1.MobileNetworkState
+	stateStr := arg.State
 	switch stateStr {
 	case "bluetooth", "ethernet":
 		stateStr = "wifi"
@@ -50,6 +51,7 @@ func (a *appStateHandler) UpdateMobileNetState(ctx context.Context, stateStr str
 	if !ok {
 		state = keybase1.MobileNetworkState_UNKNOWN
 	}
+	a.G().MobileNetState.UpdateMetered(arg.IsExpensive)
 	a.G().MobileNetState.Update(state)
 	return nil
 }
@@ -35,6 +35,22 @@ func (c *CtlHandler) Stop(ctx context.Context, args keybase1.StopArg) error {
 	return nil
 }
 
+// DrainBackgroundJobs is called on the rpc keybase.1.ctl.drainBackgroundJobs,
+// ahead of a managed restart.
+func (c *CtlHandler) DrainBackgroundJobs(ctx context.Context, arg keybase1.DrainBackgroundJobsArg) (
+	res []keybase1.BackgroundJobDrainResult, err error) {
+	mctx := libkb.NewMetaContext(ctx, c.G())
+	defer mctx.Trace("DrainBackgroundJobs", &err)()
+	return c.G().CallDrainHooks(mctx, arg.Timeout.Duration()), nil
+}
+
+func (c *CtlHandler) GetTelemetryEvents(ctx context.Context, sessionID int) (res keybase1.TelemetryEventsRes, err error) {
+	mctx := libkb.NewMetaContext(ctx, c.G())
+	defer mctx.Trace("GetTelemetryEvents", &err)()
+	res.Events, res.Enabled = c.G().Telemetry().Events()
+	return res, nil
+}
+
 func (c *CtlHandler) StopService(ctx context.Context, args keybase1.StopServiceArg) error {
 	c.G().Log.Debug("Ctl: StopService")
 	c.service.Stop(args.ExitCode)
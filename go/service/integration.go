@@ -0,0 +1,283 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/chat/types"
+	"github.com/keybase/client/go/chat/utils"
+	"github.com/keybase/client/go/integration"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/integration1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	integrationDefaultReadLimit = 50
+	integrationMaxReadLimit     = 1000
+)
+
+// integrationBackend answers the integration API from the service's own
+// chat and SimpleFS state.
+type integrationBackend struct {
+	integration1.UnimplementedIntegrationServer
+	globals.Contextified
+	ri func() chat1.RemoteInterface
+}
+
+var _ integration1.IntegrationServer = (*integrationBackend)(nil)
+
+func newIntegrationBackend(g *globals.Context, ri func() chat1.RemoteInterface) *integrationBackend {
+	return &integrationBackend{
+		Contextified: globals.NewContextified(g),
+		ri:           ri,
+	}
+}
+
+func (b *integrationBackend) loggedInUID(ctx context.Context) (context.Context, gregor1.UID, error) {
+	ctx = globals.ChatCtx(ctx, b.G(), keybase1.TLFIdentifyBehavior_CHAT_SKIP, nil, nil)
+	uid, err := utils.AssertLoggedInUID(ctx, b.G())
+	if err != nil {
+		return ctx, nil, status.Error(codes.FailedPrecondition, "not logged in")
+	}
+	return ctx, uid, nil
+}
+
+func (b *integrationBackend) GetStatus(ctx context.Context, _ *integration1.GetStatusRequest) (
+	*integration1.GetStatusResponse, error) {
+	g := b.G().ExternalG()
+	res := &integration1.GetStatusResponse{Version: libkb.VersionString()}
+	if !g.ActiveDevice.Valid() {
+		return res, nil
+	}
+	res.LoggedIn = true
+	res.Username = g.Env.GetUsername().String()
+	res.DeviceName = g.ActiveDevice.Name()
+	return res, nil
+}
+
+func (b *integrationBackend) ListConversations(ctx context.Context, req *integration1.ListConversationsRequest) (
+	*integration1.ListConversationsResponse, error) {
+	ctx, uid, err := b.loggedInUID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	topicType := chat1.TopicType_CHAT
+	inbox, _, err := b.G().InboxSource.Read(ctx, uid, types.ConversationLocalizerBlocking,
+		types.InboxSourceDataSourceAll, nil, &chat1.GetInboxLocalQuery{
+			TopicType:  &topicType,
+			UnreadOnly: req.UnreadOnly,
+		})
+	if err != nil {
+		return nil, err
+	}
+	convs := make([]chat1.ConversationLocal, 0, len(inbox.Convs))
+	for _, conv := range inbox.Convs {
+		if conv.Error != nil {
+			continue
+		}
+		convs = append(convs, conv)
+	}
+	sort.SliceStable(convs, func(i, j int) bool {
+		return convs[i].GetMtime() > convs[j].GetMtime()
+	})
+	res := &integration1.ListConversationsResponse{}
+	for _, conv := range convs {
+		unread := conv.ReaderInfo.ReadMsgid < conv.ReaderInfo.MaxMsgid
+		if req.UnreadOnly && !unread {
+			continue
+		}
+		c := &integration1.Conversation{
+			Id:         string(conv.GetConvID().ConvIDStr()),
+			TlfName:    conv.Info.TlfName,
+			Unread:     unread,
+			ActiveAtMs: conv.GetMtime().UnixMilliseconds(),
+		}
+		if conv.GetMembersType() == chat1.ConversationMembersType_TEAM {
+			c.Channel = conv.GetTopicName()
+		}
+		res.Conversations = append(res.Conversations, c)
+	}
+	return res, nil
+}
+
+func (b *integrationBackend) ReadChat(ctx context.Context, req *integration1.ReadChatRequest) (
+	*integration1.ReadChatResponse, error) {
+	convID, err := chat1.MakeConvID(req.ConversationId)
+	if err != nil || len(convID) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"invalid conversation ID %q", req.ConversationId)
+	}
+	limit := int(req.Limit)
+	switch {
+	case limit == 0:
+		limit = integrationDefaultReadLimit
+	case limit < 0 || limit > integrationMaxReadLimit:
+		return nil, status.Errorf(codes.InvalidArgument,
+			"limit must be between 1 and %d", integrationMaxReadLimit)
+	}
+	ctx, uid, err := b.loggedInUID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tv, err := b.G().ConvSource.Pull(ctx, convID, uid, chat1.GetThreadReason_GENERAL, b.ri,
+		&chat1.GetThreadQuery{MarkAsRead: false}, &chat1.Pagination{Num: limit})
+	if err != nil {
+		return nil, err
+	}
+	res := &integration1.ReadChatResponse{}
+	for _, msg := range tv.Messages {
+		if !msg.IsValid() {
+			continue
+		}
+		valid := msg.Valid()
+		m := &integration1.ChatMessage{
+			Id:       int64(valid.ServerHeader.MessageID),
+			Sender:   valid.SenderUsername,
+			SentAtMs: valid.ServerHeader.Ctime.UnixMilliseconds(),
+			Type:     strings.ToLower(msg.GetMessageType().String()),
+		}
+		switch msg.GetMessageType() {
+		case chat1.MessageType_TEXT:
+			m.Text = valid.MessageBody.Text().Body
+		case chat1.MessageType_ATTACHMENT:
+			m.Text = valid.MessageBody.Attachment().Object.Title
+		}
+		res.Messages = append(res.Messages, m)
+	}
+	return res, nil
+}
+
+func (b *integrationBackend) simpleFS() *SimpleFSHandler {
+	return NewSimpleFSHandler(nil, b.G().ExternalG())
+}
+
+func (b *integrationBackend) ListFiles(ctx context.Context, req *integration1.ListFilesRequest) (
+	*integration1.ListFilesResponse, error) {
+	const root = "/keybase"
+	if !strings.HasPrefix(req.Path, root+"/") {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"path %q isn't under %s", req.Path, root)
+	}
+	path := keybase1.NewPathWithKbfsPath(strings.TrimPrefix(req.Path, root))
+
+	fs := b.simpleFS()
+	opid, err := fs.SimpleFSMakeOpid(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = fs.SimpleFSClose(ctx, opid) }()
+	err = fs.SimpleFSList(ctx, keybase1.SimpleFSListArg{
+		OpID:   opid,
+		Path:   path,
+		Filter: keybase1.ListFilter_FILTER_ALL_HIDDEN,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = fs.SimpleFSWait(ctx, opid); err != nil {
+		return nil, err
+	}
+	res := &integration1.ListFilesResponse{}
+	for {
+		// As in `keybase fs ls`, an error after the first batch just means
+		// the listing is done.
+		list, err := fs.SimpleFSReadList(ctx, opid)
+		if err != nil && len(res.Entries) == 0 {
+			return nil, err
+		}
+		if err != nil || len(list.Entries) == 0 {
+			break
+		}
+		for _, d := range list.Entries {
+			res.Entries = append(res.Entries, &integration1.FileEntry{
+				Name:         d.Name,
+				Type:         strings.ToLower(d.DirentType.String()),
+				Size:         int64(d.Size),
+				ModifiedAtMs: int64(d.Time),
+			})
+		}
+	}
+	sort.Slice(res.Entries, func(i, j int) bool {
+		return res.Entries[i].Name < res.Entries[j].Name
+	})
+	return res, nil
+}
+
+func (b *integrationBackend) GetArchiveStatus(ctx context.Context, _ *integration1.GetArchiveStatusRequest) (
+	*integration1.GetArchiveStatusResponse, error) {
+	archiveStatus, err := b.simpleFS().SimpleFSGetArchiveStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]keybase1.SimpleFSArchiveJobStatus, 0, len(archiveStatus.Jobs))
+	for _, job := range archiveStatus.Jobs {
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].Desc.StartTime < jobs[j].Desc.StartTime
+	})
+	res := &integration1.GetArchiveStatusResponse{}
+	for _, job := range jobs {
+		j := &integration1.ArchiveJob{
+			JobId:                 job.Desc.JobID,
+			JobType:               strings.ToLower(job.Desc.JobType.String()),
+			Path:                  job.Desc.KbfsPathWithRevision.Path,
+			Phase:                 strings.ToLower(job.Phase.String()),
+			Paused:                job.Paused,
+			BytesTotal:            job.BytesTotal,
+			BytesCopied:           job.BytesCopied,
+			BytesZipped:           job.BytesZipped,
+			BytesUploaded:         job.BytesUploaded,
+			EstimatedCompletionMs: int64(job.EstimatedCompletion),
+		}
+		if job.Error != nil {
+			j.Error = job.Error.Error
+		}
+		res.Jobs = append(res.Jobs, j)
+	}
+	return res, nil
+}
+
+// runIntegrationServer serves the integration API on localhost, if a port
+// for it is configured.
+func (d *Service) runIntegrationServer() {
+	port := d.G().Env.GetIntegrationGRPCPort()
+	if port == 0 {
+		return
+	}
+	token, err := integration.LoadOrCreateToken(d.G().Env.GetIntegrationTokenPath())
+	if err != nil {
+		d.G().Log.Warning("not serving the integration API: token: %v", err)
+		return
+	}
+	ln, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		d.G().Log.Warning("not serving the integration API: %v", err)
+		return
+	}
+	backend := newIntegrationBackend(globals.NewContext(d.G(), d.ChatG()), d.gregor.GetClient)
+	srv := integration.NewServer(backend, token)
+	go func() {
+		d.G().Log.Debug("serving the integration API on %s", ln.Addr())
+		if err := srv.Serve(ln); err != nil {
+			d.G().Log.Warning("integration API server stopped: %v", err)
+		}
+	}()
+	d.G().PushShutdownHook(func(mctx libkb.MetaContext) error {
+		d.G().Log.Debug("stopping the integration API server")
+		srv.Stop()
+		return nil
+	})
+}
@@ -4,6 +4,7 @@
 package service
 
 import (
+	"fmt"
 	"path/filepath"
 	"strings"
 
@@ -11,6 +12,7 @@ import (
 
 	"golang.org/x/net/context"
 
+	humanize "github.com/dustin/go-humanize"
 	"github.com/keybase/client/go/chat"
 	"github.com/keybase/client/go/chat/globals"
 	"github.com/keybase/client/go/libkb"
@@ -87,6 +89,35 @@ func (h *KBFSHandler) FSFavoritesChangedEvent(_ context.Context) (err error) {
 	return nil
 }
 
+func (h *KBFSHandler) FSArchiveProgressEvent(_ context.Context, progress keybase1.SimpleFSArchiveProgress) error {
+	h.G().NotifyRouter.HandleSimpleFSArchiveProgress(progress)
+	return nil
+}
+
+func (h *KBFSHandler) FSQuotaAlertEvent(ctx context.Context, alert keybase1.SimpleFSQuotaAlert) error {
+	h.G().NotifyRouter.HandleFSQuotaAlert(alert)
+	if alert.ChatToSelf {
+		h.sendQuotaAlertToSelf(ctx, alert)
+	}
+	return nil
+}
+
+// sendQuotaAlertToSelf sends alert to the current user as a chat message in
+// their conversation with themselves.
+func (h *KBFSHandler) sendQuotaAlertToSelf(ctx context.Context, alert keybase1.SimpleFSQuotaAlert) {
+	username := h.G().Env.GetUsername()
+	if username.IsNil() || h.G().ChatHelper == nil {
+		return
+	}
+	text := fmt.Sprintf("Your Keybase files are using over %d%% of your storage quota (%s of %s).",
+		alert.Threshold, humanize.Bytes(uint64(alert.UsageBytes)), humanize.Bytes(uint64(alert.LimitBytes)))
+	if _, err := h.G().ChatHelper.SendTextByNameNonblock(ctx, username.String(), nil,
+		chat1.ConversationMembersType_IMPTEAMNATIVE, keybase1.TLFIdentifyBehavior_CHAT_CLI,
+		text, nil); err != nil {
+		h.G().Log.CDebugf(ctx, "sendQuotaAlertToSelf: failed to send: %v", err)
+	}
+}
+
 func (h *KBFSHandler) FSSubscriptionNotifyEvent(_ context.Context, arg keybase1.FSSubscriptionNotifyEventArg) error {
 	h.G().NotifyRouter.HandleFSSubscriptionNotify(keybase1.FSSubscriptionNotifyArg(arg))
 	return nil
@@ -176,6 +207,15 @@ func (h *KBFSHandler) getKeyFn() func(context.Context) ([32]byte, error) {
 	return keyFn
 }
 
+// getArchiveStateKeyFn returns a function that gets an encryption key for
+// storing the SimpleFS archive state.
+func (h *KBFSHandler) getArchiveStateKeyFn() func(context.Context) ([32]byte, error) {
+	return func(ctx context.Context) ([32]byte, error) {
+		return encrypteddb.GetSecretBoxKey(ctx, h.G(),
+			libkb.EncryptionReasonKBFSArchiveState, "encrypting kbfs archive state")
+	}
+}
+
 // EncryptFavorites encrypts cached favorites to store on disk.
 func (h *KBFSHandler) EncryptFavorites(ctx context.Context,
 	dataToDecrypt []byte) (res []byte, err error) {
@@ -188,3 +228,38 @@ func (h *KBFSHandler) DecryptFavorites(ctx context.Context,
 	err = encrypteddb.DecodeBox(ctx, dataToEncrypt, h.getKeyFn(), &res)
 	return res, err
 }
+
+// EncryptArchiveState encrypts the SimpleFS archive state to store on disk.
+func (h *KBFSHandler) EncryptArchiveState(ctx context.Context,
+	dataToEncrypt []byte) (res []byte, err error) {
+	return encrypteddb.EncodeBox(ctx, dataToEncrypt, h.getArchiveStateKeyFn())
+}
+
+// DecryptArchiveState decrypts the SimpleFS archive state stored on disk.
+func (h *KBFSHandler) DecryptArchiveState(ctx context.Context,
+	dataToDecrypt []byte) (res []byte, err error) {
+	err = encrypteddb.DecodeBox(ctx, dataToDecrypt, h.getArchiveStateKeyFn(), &res)
+	return res, err
+}
+
+// kbfsDiskSpaceReservationKey keeps KBFS's reservations apart from the
+// service's own.
+func kbfsDiskSpaceReservationKey(key string) string {
+	return "kbfs:" + key
+}
+
+// ReserveDiskSpace reserves disk space for a KBFS job, so it doesn't race
+// the service's own jobs for it.
+func (h *KBFSHandler) ReserveDiskSpace(ctx context.Context,
+	arg keybase1.ReserveDiskSpaceArg) error {
+	mctx := libkb.NewMetaContext(ctx, h.G())
+	return h.G().DiskSpaceReservations.Reserve(mctx, kbfsDiskSpaceReservationKey(arg.Key),
+		arg.Description, arg.Needs, arg.Wait, libkb.DiskSpaceReservationTTL)
+}
+
+// ReleaseDiskSpace releases the disk space reserved by a KBFS job.
+func (h *KBFSHandler) ReleaseDiskSpace(ctx context.Context, key string) error {
+	mctx := libkb.NewMetaContext(ctx, h.G())
+	h.G().DiskSpaceReservations.Release(mctx, kbfsDiskSpaceReservationKey(key))
+	return nil
+}
@@ -381,6 +381,10 @@ func (d *Service) RunBackgroundOperations(uir *UIRouter) {
 	d.runBackgroundBoxAuditScheduler()
 	d.runBackgroundContactSync()
 	d.runBackgroundInviteFriendsPoll()
+	d.runBackgroundTeamExternalSync()
+	d.runBackgroundChatMentionDigest()
+	d.runBackgroundChatAttachmentExpiry()
+	d.runIntegrationServer()
 	d.runTLFUpgrade()
 	d.runTrackerLoader(ctx)
 	d.runRuntimeStats(ctx)
@@ -485,6 +489,7 @@ func (d *Service) SetupChatModules(ri func() chat1.RemoteInterface) {
 	g.AddDbNukeHook(g.Indexer, "Indexer")
 	g.ArchiveRegistry = chat.NewChatArchiveRegistry(g, ri)
 	g.AddDbNukeHook(g.ArchiveRegistry, "ChatArchiveRegistry")
+	g.AddDrainHook(g.ArchiveRegistry, "chat archives")
 	g.ServerCacheVersions = storage.NewServerVersions(g)
 
 	// Syncer and retriers
@@ -496,11 +501,15 @@ func (d *Service) SetupChatModules(ri func() chat1.RemoteInterface) {
 	g.EphemeralTracker = chat.NewEphemeralTracker(g)
 	g.AddLogoutHook(g.EphemeralTracker, "EphemeralTracker")
 	g.AddDbNukeHook(g.EphemeralTracker, "EphemeralTracker")
+	g.TeamPolicyCache = chat.NewTeamPolicyCache()
+	g.AddLogoutHook(g.TeamPolicyCache, "TeamPolicyCache")
+	g.AddDbNukeHook(g.TeamPolicyCache, "TeamPolicyCache")
 	g.ActivityNotifier = chat.NewNotifyRouterActivityRouter(g)
 
 	// Set up push handler with the badger
 	d.badger.SetInboxVersionSource(storage.NewInboxVersionSource(g))
 	pushHandler := chat.NewPushHandler(g)
+	pushHandler.SetRemoteInterface(ri)
 	g.PushHandler = pushHandler
 
 	// Message sending apparatus
@@ -653,6 +662,7 @@ func (d *Service) startupGregor() {
 func (d *Service) addGlobalHooks() {
 	d.G().AddLoginHook(d)
 	d.G().AddLogoutHook(d, "service/Service")
+	d.G().AddDrainHook(newKBFSArchiveDrainHook(d.G()), "KBFS archives")
 }
 
 func (d *Service) StartLoopbackServer(loginMode libkb.LoginAttempt) error {
@@ -943,6 +953,99 @@ func (d *Service) runBackgroundInviteFriendsPoll() {
 	})
 }
 
+func (d *Service) runBackgroundTeamExternalSync() {
+	// Each configured sync has its own interval; the task just wakes up
+	// often enough to notice when one of them is due.
+	eng := engine.NewBackgroundTask(d.G(), &engine.BackgroundTaskArgs{
+		Name: "TeamExternalSyncBackground",
+		F:    teams.ExternalSyncBackgroundRound,
+		Settings: engine.BackgroundTaskSettings{
+			Start:        2 * time.Minute,
+			StartStagger: 1 * time.Minute,
+			WakeUp:       1 * time.Minute,
+			Interval:     15 * time.Minute,
+			Limit:        10 * time.Minute,
+		},
+	})
+	go func() {
+		m := libkb.NewMetaContextBackground(d.G())
+		err := engine.RunEngine2(m, eng)
+		if err != nil {
+			m.Warning("background TeamExternalSync error: %v", err)
+		}
+	}()
+
+	d.G().PushShutdownHook(func(mctx libkb.MetaContext) error {
+		d.G().Log.Debug("stopping background TeamExternalSync")
+		eng.Shutdown()
+		return nil
+	})
+}
+
+func (d *Service) runBackgroundChatMentionDigest() {
+	// Digests are daily; waking up hourly is enough to send them close to
+	// on time, and the round itself is a no-op until one is due.
+	eng := engine.NewBackgroundTask(d.G(), &engine.BackgroundTaskArgs{
+		Name: "ChatMentionDigestBackground",
+		F: func(mctx libkb.MetaContext) error {
+			g := globals.NewContext(d.G(), d.ChatG())
+			return chat.MentionDigestBackgroundRound(mctx.Ctx(), g, d.gregor.GetClient)
+		},
+		Settings: engine.BackgroundTaskSettings{
+			Start:        5 * time.Minute,
+			StartStagger: 5 * time.Minute,
+			WakeUp:       5 * time.Minute,
+			Interval:     1 * time.Hour,
+			Limit:        5 * time.Minute,
+		},
+	})
+	go func() {
+		m := libkb.NewMetaContextBackground(d.G())
+		err := engine.RunEngine2(m, eng)
+		if err != nil {
+			m.Warning("background ChatMentionDigest error: %v", err)
+		}
+	}()
+
+	d.G().PushShutdownHook(func(mctx libkb.MetaContext) error {
+		d.G().Log.Debug("stopping background ChatMentionDigest")
+		eng.Shutdown()
+		return nil
+	})
+}
+
+func (d *Service) runBackgroundChatAttachmentExpiry() {
+	// Policies are in days, so a few rounds a day keeps assets from
+	// outliving them by much.
+	eng := engine.NewBackgroundTask(d.G(), &engine.BackgroundTaskArgs{
+		Name: "ChatAttachmentExpiryBackground",
+		F: func(mctx libkb.MetaContext) error {
+			g := globals.NewContext(d.G(), d.ChatG())
+			return chat.AttachmentExpiryBackgroundRound(mctx.Ctx(), g, d.gregor.GetClient)
+		},
+		Settings: engine.BackgroundTaskSettings{
+			Start:        15 * time.Minute,
+			StartStagger: 15 * time.Minute,
+			WakeUp:       15 * time.Minute,
+			Interval:     6 * time.Hour,
+			Limit:        30 * time.Minute,
+		},
+	})
+	go func() {
+		m := libkb.NewMetaContextBackground(d.G())
+		err := engine.RunEngine2(m, eng)
+		if err != nil {
+			m.Warning("background ChatAttachmentExpiry error: %v", err)
+		}
+	}()
+
+	d.G().PushShutdownHook(func(mctx libkb.MetaContext) error {
+		d.G().Log.Debug("stopping background ChatAttachmentExpiry")
+		eng.Shutdown()
+		return nil
+	})
+}
+
 func (d *Service) OnLogin(mctx libkb.MetaContext) error {
 	d.rekeyMaster.Login()
 	if err := d.gregordConnect(); err != nil {
@@ -109,6 +109,8 @@ func (h *SignupHandler) Signup(ctx context.Context, arg keybase1.SignupArg) (res
 		VerifyEmail:              arg.VerifyEmail,
 		BotToken:                 arg.BotToken,
 		SkipGPG:                  arg.SkipGPG,
+		Headless:                 arg.Headless,
+		SecretStoreBackend:       arg.SecretStoreBackend,
 	}
 	m := libkb.NewMetaContext(ctx, h.G()).WithUIs(uis)
 	eng := engine.NewSignupEngine(h.G(), &runarg)
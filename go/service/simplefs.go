@@ -289,6 +289,19 @@ func (s *SimpleFSHandler) SimpleFSGetRevisions(
 	return cli.SimpleFSGetRevisions(ctx, arg)
 }
 
+// SimpleFSFileHistory - Get the modification history of a single file
+func (s *SimpleFSHandler) SimpleFSFileHistory(
+	ctx context.Context, arg keybase1.SimpleFSFileHistoryArg) (
+	keybase1.SimpleFSFileHistory, error) {
+	cli, err := s.client(ctx)
+	if err != nil {
+		return keybase1.SimpleFSFileHistory{}, err
+	}
+	ctx, cancel := s.wrapContextWithTimeout(ctx)
+	defer cancel()
+	return cli.SimpleFSFileHistory(ctx, arg)
+}
+
 // SimpleFSReadRevisions - Get list of revisions in progress. Can
 // indicate status of pending to get more entries.
 func (s *SimpleFSHandler) SimpleFSReadRevisions(
@@ -453,6 +466,30 @@ func (s *SimpleFSHandler) SimpleFSGetTeamQuotaUsage(
 	return cli.SimpleFSGetTeamQuotaUsage(ctx, teamName)
 }
 
+// SimpleFSGetQuotaAlertSettings implements the SimpleFSInterface.
+func (s *SimpleFSHandler) SimpleFSGetQuotaAlertSettings(
+	ctx context.Context) (keybase1.SimpleFSQuotaAlertSettings, error) {
+	cli, err := s.client(ctx)
+	if err != nil {
+		return keybase1.SimpleFSQuotaAlertSettings{}, err
+	}
+	ctx, cancel := s.wrapContextWithTimeout(ctx)
+	defer cancel()
+	return cli.SimpleFSGetQuotaAlertSettings(ctx)
+}
+
+// SimpleFSSetQuotaAlertSettings implements the SimpleFSInterface.
+func (s *SimpleFSHandler) SimpleFSSetQuotaAlertSettings(
+	ctx context.Context, settings keybase1.SimpleFSQuotaAlertSettings) error {
+	cli, err := s.client(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := s.wrapContextWithTimeout(ctx)
+	defer cancel()
+	return cli.SimpleFSSetQuotaAlertSettings(ctx, settings)
+}
+
 // SimpleFSGetFolder implements the SimpleFSInterface.
 func (s *SimpleFSHandler) SimpleFSGetFolder(
 	ctx context.Context, kbfsPath keybase1.KBFSPath) (
@@ -910,6 +947,8 @@ func (s *SimpleFSHandler) SimpleFSCancelJournalUploads(
 // SimpleFSArchiveStart implements the SimpleFSInterface.
 func (s *SimpleFSHandler) SimpleFSArchiveStart(ctx context.Context,
 	arg keybase1.SimpleFSArchiveStartArg) (jobDesc keybase1.SimpleFSArchiveJobDesc, err error) {
+	defer s.G().TimeTelemetry("kbfs.archive.start")()
+	s.G().Telemetry().Count("kbfs.archive.jobs", 1)
 	cli, err := s.client(ctx)
 	if err != nil {
 		return keybase1.SimpleFSArchiveJobDesc{}, err
@@ -931,6 +970,80 @@ func (s *SimpleFSHandler) SimpleFSArchiveCancelOrDismissJob(ctx context.Context,
 	return cli.SimpleFSArchiveCancelOrDismissJob(ctx, jobID)
 }
 
+// SimpleFSArchivePauseJob implements the SimpleFSInterface.
+func (s *SimpleFSHandler) SimpleFSArchivePauseJob(ctx context.Context,
+	jobID string) (err error) {
+	cli, err := s.client(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := s.wrapContextWithTimeout(ctx)
+	defer cancel()
+	return cli.SimpleFSArchivePauseJob(ctx, jobID)
+}
+
+// SimpleFSArchiveResumeJob implements the SimpleFSInterface.
+func (s *SimpleFSHandler) SimpleFSArchiveResumeJob(ctx context.Context,
+	jobID string) (err error) {
+	cli, err := s.client(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := s.wrapContextWithTimeout(ctx)
+	defer cancel()
+	return cli.SimpleFSArchiveResumeJob(ctx, jobID)
+}
+
+// SimpleFSArchiveRetryJob implements the SimpleFSInterface.
+func (s *SimpleFSHandler) SimpleFSArchiveRetryJob(ctx context.Context,
+	jobID string) (err error) {
+	cli, err := s.client(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := s.wrapContextWithTimeout(ctx)
+	defer cancel()
+	return cli.SimpleFSArchiveRetryJob(ctx, jobID)
+}
+
+// SimpleFSArchiveDrain implements the SimpleFSInterface.
+func (s *SimpleFSHandler) SimpleFSArchiveDrain(ctx context.Context,
+	timeout keybase1.DurationSec) (err error) {
+	cli, err := s.client(ctx)
+	if err != nil {
+		return err
+	}
+	// KBFS gives up on its own once timeout is up.
+	ctx, cancel := context.WithTimeout(ctx, timeout.Duration()+simpleFSTimeout)
+	defer cancel()
+	return cli.SimpleFSArchiveDrain(ctx, timeout)
+}
+
+// kbfsArchiveDrainHook drains KBFS's archive jobs before a managed restart.
+type kbfsArchiveDrainHook struct {
+	libkb.Contextified
+}
+
+func newKBFSArchiveDrainHook(g *libkb.GlobalContext) kbfsArchiveDrainHook {
+	return kbfsArchiveDrainHook{Contextified: libkb.NewContextified(g)}
+}
+
+func (h kbfsArchiveDrainHook) OnDrain(mctx libkb.MetaContext) error {
+	xp := h.G().ConnectionManager.LookupByClientType(keybase1.ClientType_KBFS)
+	if xp == nil {
+		// Not running, so there's nothing to drain.
+		return nil
+	}
+	timeout := simpleFSTimeout
+	if deadline, ok := mctx.Ctx().Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	cli := &keybase1.SimpleFSClient{
+		Cli: rpc.NewClient(xp, libkb.NewContextifiedErrorUnwrapper(h.G()), nil),
+	}
+	return cli.SimpleFSArchiveDrain(mctx.Ctx(), keybase1.DurationSec(timeout.Seconds()))
+}
+
 // SimpleFSGetArchiveStatus implements the SimpleFSInterface.
 func (s *SimpleFSHandler) SimpleFSGetArchiveStatus(ctx context.Context) (
 	status keybase1.SimpleFSArchiveStatus, err error) {
@@ -942,3 +1055,228 @@ func (s *SimpleFSHandler) SimpleFSGetArchiveStatus(ctx context.Context) (
 	defer cancel()
 	return cli.SimpleFSGetArchiveStatus(ctx)
 }
+
+// SimpleFSArchiveSearchJobs implements the SimpleFSInterface.
+func (s *SimpleFSHandler) SimpleFSArchiveSearchJobs(ctx context.Context,
+	query string) ([]keybase1.SimpleFSArchiveJobStatus, error) {
+	cli, err := s.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := s.wrapContextWithTimeout(ctx)
+	defer cancel()
+	return cli.SimpleFSArchiveSearchJobs(ctx, query)
+}
+
+// SimpleFSArchiveListAllDevices implements the SimpleFSInterface.
+func (s *SimpleFSHandler) SimpleFSArchiveListAllDevices(ctx context.Context) (
+	[]keybase1.SimpleFSArchiveJobSummary, error) {
+	cli, err := s.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := s.wrapContextWithTimeout(ctx)
+	defer cancel()
+	return cli.SimpleFSArchiveListAllDevices(ctx)
+}
+
+// SimpleFSLock implements the SimpleFSInterface.
+func (s *SimpleFSHandler) SimpleFSLock(ctx context.Context,
+	arg keybase1.SimpleFSLockArg) (info keybase1.SimpleFSLockInfo, err error) {
+	cli, err := s.client(ctx)
+	if err != nil {
+		return keybase1.SimpleFSLockInfo{}, err
+	}
+	// No timeouts since this blocks for as long as another session holds
+	// the lock.
+	return cli.SimpleFSLock(ctx, arg)
+}
+
+// SimpleFSUnlock implements the SimpleFSInterface.
+func (s *SimpleFSHandler) SimpleFSUnlock(ctx context.Context,
+	path keybase1.KBFSPath) (err error) {
+	cli, err := s.client(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := s.wrapContextWithTimeout(ctx)
+	defer cancel()
+	return cli.SimpleFSUnlock(ctx, path)
+}
+
+// SimpleFSListLocks implements the SimpleFSInterface.
+func (s *SimpleFSHandler) SimpleFSListLocks(ctx context.Context) (
+	locks []keybase1.SimpleFSLockInfo, err error) {
+	cli, err := s.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := s.wrapContextWithTimeout(ctx)
+	defer cancel()
+	return cli.SimpleFSListLocks(ctx)
+}
+
+// SimpleFSArchiveSetBytesPerSecond implements the SimpleFSInterface.
+func (s *SimpleFSHandler) SimpleFSArchiveSetBytesPerSecond(ctx context.Context,
+	arg keybase1.SimpleFSArchiveSetBytesPerSecondArg) (err error) {
+	cli, err := s.client(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := s.wrapContextWithTimeout(ctx)
+	defer cancel()
+	return cli.SimpleFSArchiveSetBytesPerSecond(ctx, arg)
+}
+
+// SimpleFSArchiveSetLabel implements the SimpleFSInterface.
+func (s *SimpleFSHandler) SimpleFSArchiveSetLabel(ctx context.Context,
+	arg keybase1.SimpleFSArchiveSetLabelArg) (err error) {
+	cli, err := s.client(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := s.wrapContextWithTimeout(ctx)
+	defer cancel()
+	return cli.SimpleFSArchiveSetLabel(ctx, arg)
+}
+
+// SimpleFSArchiveSchedule implements the SimpleFSInterface.
+func (s *SimpleFSHandler) SimpleFSArchiveSchedule(ctx context.Context,
+	arg keybase1.SimpleFSArchiveScheduleArg) (
+	keybase1.SimpleFSArchiveSchedule, error) {
+	cli, err := s.client(ctx)
+	if err != nil {
+		return keybase1.SimpleFSArchiveSchedule{}, err
+	}
+	ctx, cancel := s.wrapContextWithTimeout(ctx)
+	defer cancel()
+	return cli.SimpleFSArchiveSchedule(ctx, arg)
+}
+
+// SimpleFSArchiveUnschedule implements the SimpleFSInterface.
+func (s *SimpleFSHandler) SimpleFSArchiveUnschedule(ctx context.Context,
+	scheduleID string) (err error) {
+	cli, err := s.client(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := s.wrapContextWithTimeout(ctx)
+	defer cancel()
+	return cli.SimpleFSArchiveUnschedule(ctx, scheduleID)
+}
+
+// SimpleFSArchiveCheckArchive implements the SimpleFSInterface.
+func (s *SimpleFSHandler) SimpleFSArchiveCheckArchive(ctx context.Context,
+	jobID string) (keybase1.SimpleFSArchiveCheckArchiveResult, error) {
+	cli, err := s.client(ctx)
+	if err != nil {
+		return keybase1.SimpleFSArchiveCheckArchiveResult{}, err
+	}
+	// No timeout here, since reading a large zip back can take a while.
+	return cli.SimpleFSArchiveCheckArchive(ctx, jobID)
+}
+
+// SimpleFSArchiveVerifyJob implements the SimpleFSInterface.
+func (s *SimpleFSHandler) SimpleFSArchiveVerifyJob(ctx context.Context,
+	arg keybase1.SimpleFSArchiveVerifyJobArg) (keybase1.SimpleFSArchiveCheckArchiveResult, error) {
+	cli, err := s.client(ctx)
+	if err != nil {
+		return keybase1.SimpleFSArchiveCheckArchiveResult{}, err
+	}
+	// No timeout here either; it reads the whole zip back too.
+	return cli.SimpleFSArchiveVerifyJob(ctx, arg)
+}
+
+// SimpleFSArchiveRestore implements the SimpleFSInterface.
+func (s *SimpleFSHandler) SimpleFSArchiveRestore(ctx context.Context,
+	arg keybase1.SimpleFSArchiveRestoreArg) (keybase1.SimpleFSArchiveJobDesc, error) {
+	cli, err := s.client(ctx)
+	if err != nil {
+		return keybase1.SimpleFSArchiveJobDesc{}, err
+	}
+	ctx, cancel := s.wrapContextWithTimeout(ctx)
+	defer cancel()
+	return cli.SimpleFSArchiveRestore(ctx, arg)
+}
+
+// SimpleFSArchiveReadInfo implements the SimpleFSInterface.
+func (s *SimpleFSHandler) SimpleFSArchiveReadInfo(ctx context.Context,
+	zipFilePath string) (keybase1.SimpleFSArchiveInfo, error) {
+	cli, err := s.client(ctx)
+	if err != nil {
+		return keybase1.SimpleFSArchiveInfo{}, err
+	}
+	ctx, cancel := s.wrapContextWithTimeout(ctx)
+	defer cancel()
+	return cli.SimpleFSArchiveReadInfo(ctx, zipFilePath)
+}
+
+// SimpleFSArchiveGetStagingUsage implements the SimpleFSInterface.
+func (s *SimpleFSHandler) SimpleFSArchiveGetStagingUsage(ctx context.Context) (
+	keybase1.SimpleFSArchiveStagingUsage, error) {
+	cli, err := s.client(ctx)
+	if err != nil {
+		return keybase1.SimpleFSArchiveStagingUsage{}, err
+	}
+	// No timeout here, since adding up big staging paths can take a while.
+	return cli.SimpleFSArchiveGetStagingUsage(ctx)
+}
+
+// SimpleFSArchiveSetMaxStagingBytes implements the SimpleFSInterface.
+func (s *SimpleFSHandler) SimpleFSArchiveSetMaxStagingBytes(ctx context.Context,
+	maxStagingBytes int64) (err error) {
+	cli, err := s.client(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := s.wrapContextWithTimeout(ctx)
+	defer cancel()
+	return cli.SimpleFSArchiveSetMaxStagingBytes(ctx, maxStagingBytes)
+}
+
+// SimpleFSRepairCache implements the SimpleFSInterface.
+func (s *SimpleFSHandler) SimpleFSRepairCache(ctx context.Context,
+	rebuild bool) (err error) {
+	cli, err := s.client(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := s.wrapContextWithTimeout(ctx)
+	defer cancel()
+	return cli.SimpleFSRepairCache(ctx, rebuild)
+}
+
+// SimpleFSGetCacheRepairProgress implements the SimpleFSInterface.
+func (s *SimpleFSHandler) SimpleFSGetCacheRepairProgress(
+	ctx context.Context) (keybase1.SimpleFSCacheRepairProgress, error) {
+	cli, err := s.client(ctx)
+	if err != nil {
+		return keybase1.SimpleFSCacheRepairProgress{}, err
+	}
+	ctx, cancel := s.wrapContextWithTimeout(ctx)
+	defer cancel()
+	return cli.SimpleFSGetCacheRepairProgress(ctx)
+}
+
+// SimpleFSArchiveSetRetention implements the SimpleFSInterface.
+func (s *SimpleFSHandler) SimpleFSArchiveSetRetention(ctx context.Context,
+	retention keybase1.SimpleFSArchiveRetention) (err error) {
+	cli, err := s.client(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := s.wrapContextWithTimeout(ctx)
+	defer cancel()
+	return cli.SimpleFSArchiveSetRetention(ctx, retention)
+}
+
+// SimpleFSArchiveCheckParts implements the SimpleFSInterface.
+func (s *SimpleFSHandler) SimpleFSArchiveCheckParts(ctx context.Context,
+	partsManifestPath string) (keybase1.SimpleFSArchiveCheckArchiveResult, error) {
+	cli, err := s.client(ctx)
+	if err != nil {
+		return keybase1.SimpleFSArchiveCheckArchiveResult{}, err
+	}
+	// No timeout here, since reading every volume back can take a while.
+	return cli.SimpleFSArchiveCheckParts(ctx, partsManifestPath)
+}
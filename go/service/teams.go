@@ -331,6 +331,13 @@ func (h *TeamsHandler) TeamAddMember(ctx context.Context, arg keybase1.TeamAddMe
 		return res, err
 	}
 
+	if !result.Invited {
+		go func() {
+			ctx := libkb.WithLogTag(context.Background(), "BG")
+			teams.SendTeamReadmeDM(ctx, h.G().ExternalG(), arg.TeamID, "", result.User.Username)
+		}()
+	}
+
 	if !arg.SendChatNotification {
 		return result, nil
 	}
@@ -413,6 +420,15 @@ func (h *TeamsHandler) TeamAddMembersMultiRole(ctx context.Context, arg keybase1
 	}
 	res = keybase1.TeamAddMembersResult{NotAdded: notAdded}
 
+	go func() {
+		ctx := libkb.WithLogTag(context.Background(), "BG")
+		for _, res := range added {
+			if !res.Invite && !res.Username.IsNil() {
+				teams.SendTeamReadmeDM(ctx, h.G().ExternalG(), arg.TeamID, "", res.Username.String())
+			}
+		}
+	}()
+
 	// AddMembers succeeded
 	if arg.SendChatNotification {
 		go func() {
@@ -969,3 +985,76 @@ func (h *TeamsHandler) FindAssertionsInTeamNoResolve(ctx context.Context, arg ke
 	mctx := libkb.NewMetaContext(ctx, h.G().ExternalG())
 	return teams.FindAssertionsInTeamNoResolve(mctx, arg.TeamID, arg.Assertions)
 }
+
+func (h *TeamsHandler) TeamExternalSync(ctx context.Context, arg keybase1.TeamExternalSyncArg) (res keybase1.TeamExternalSyncReport, err error) {
+	ctx = libkb.WithLogTag(ctx, "TM")
+	defer h.G().CTrace(ctx, fmt.Sprintf("TeamExternalSync(%s, dryRun=%v)", arg.Config.TeamID, arg.DryRun), &err)()
+	if err := assertLoggedIn(ctx, h.G().ExternalG()); err != nil {
+		return res, err
+	}
+	return teams.ExternalSync(ctx, h.G().ExternalG(), arg.Config, arg.DryRun)
+}
+
+func (h *TeamsHandler) TeamSetExternalSync(ctx context.Context, arg keybase1.TeamSetExternalSyncArg) (err error) {
+	ctx = libkb.WithLogTag(ctx, "TM")
+	defer h.G().CTrace(ctx, fmt.Sprintf("TeamSetExternalSync(%s)", arg.Config.TeamID), &err)()
+	if err := assertLoggedIn(ctx, h.G().ExternalG()); err != nil {
+		return err
+	}
+	return teams.SetExternalSync(ctx, h.G().ExternalG(), arg.Config)
+}
+
+func (h *TeamsHandler) TeamClearExternalSync(ctx context.Context, arg keybase1.TeamClearExternalSyncArg) (err error) {
+	ctx = libkb.WithLogTag(ctx, "TM")
+	defer h.G().CTrace(ctx, fmt.Sprintf("TeamClearExternalSync(%s)", arg.TeamID), &err)()
+	if err := assertLoggedIn(ctx, h.G().ExternalG()); err != nil {
+		return err
+	}
+	return teams.ClearExternalSync(ctx, h.G().ExternalG(), arg.TeamID)
+}
+
+func (h *TeamsHandler) TeamListExternalSyncs(ctx context.Context, sessionID int) (res []keybase1.TeamExternalSyncState, err error) {
+	ctx = libkb.WithLogTag(ctx, "TM")
+	defer h.G().CTrace(ctx, "TeamListExternalSyncs", &err)()
+	if err := assertLoggedIn(ctx, h.G().ExternalG()); err != nil {
+		return nil, err
+	}
+	return teams.ListExternalSyncs(ctx, h.G().ExternalG())
+}
+
+func (h *TeamsHandler) TeamCreateSeitanTokenWithExtras(ctx context.Context, arg keybase1.TeamCreateSeitanTokenWithExtrasArg) (res keybase1.SeitanTokenWithExtras, err error) {
+	ctx = libkb.WithLogTag(ctx, "TM")
+	defer h.G().CTrace(ctx, fmt.Sprintf("TeamCreateSeitanTokenWithExtras(%s)", arg.Teamname), &err)()
+	if err := assertLoggedIn(ctx, h.G().ExternalG()); err != nil {
+		return res, err
+	}
+	return teams.CreateSeitanTokenWithExtras(ctx, h.G().ExternalG(), arg.Teamname, arg.Role, arg.Label,
+		arg.Channels, arg.WelcomeDMTemplate)
+}
+
+func (h *TeamsHandler) TeamListSeitanInviteExtras(ctx context.Context, arg keybase1.TeamListSeitanInviteExtrasArg) (res []keybase1.SeitanInviteExtras, err error) {
+	ctx = libkb.WithLogTag(ctx, "TM")
+	defer h.G().CTrace(ctx, fmt.Sprintf("TeamListSeitanInviteExtras(%s)", arg.TeamID), &err)()
+	if err := assertLoggedIn(ctx, h.G().ExternalG()); err != nil {
+		return nil, err
+	}
+	return teams.ListSeitanInviteExtras(ctx, h.G().ExternalG(), arg.TeamID)
+}
+
+func (h *TeamsHandler) TeamRevokeSeitanInvite(ctx context.Context, arg keybase1.TeamRevokeSeitanInviteArg) (err error) {
+	ctx = libkb.WithLogTag(ctx, "TM")
+	defer h.G().CTrace(ctx, fmt.Sprintf("TeamRevokeSeitanInvite(%s, %s)", arg.TeamID, arg.InviteID), &err)()
+	if err := assertLoggedIn(ctx, h.G().ExternalG()); err != nil {
+		return err
+	}
+	return teams.RevokeSeitanInvite(ctx, h.G().ExternalG(), arg.TeamID, arg.InviteID)
+}
+
+func (h *TeamsHandler) TeamGetSubteamCoverage(ctx context.Context, arg keybase1.TeamGetSubteamCoverageArg) (res keybase1.TeamSubteamCoverage, err error) {
+	ctx = libkb.WithLogTag(ctx, "TM")
+	defer h.G().CTrace(ctx, fmt.Sprintf("TeamGetSubteamCoverage(%s)", arg.TeamName), &err)()
+	if err := assertLoggedIn(ctx, h.G().ExternalG()); err != nil {
+		return res, err
+	}
+	return teams.SubteamCoverage(ctx, h.G().ExternalG(), arg.TeamName)
+}
@@ -64,6 +64,9 @@ type Stellar struct {
 	accountsLock sync.Mutex
 	accounts     *AccountsCache
 
+	// Serializes reading and writing the spending policy and its audit log.
+	spendingLock sync.Mutex
+
 	// Slot for build payments that do not use BuildPaymentID.
 	buildPaymentSlot *slotctx.PrioritySlot
 
@@ -339,6 +342,10 @@ func (s *Stellar) handlePaymentStatus(mctx libkb.MetaContext, obm gregor.OutOfBa
 	}
 
 	s.G().NotifyRouter.HandleWalletPaymentStatusNotification(mctx.Ctx(), notifiedAccountID, paymentID)
+
+	if err := s.fulfillRequestForPayment(mctx, paymentID); err != nil {
+		mctx.Debug("fulfillRequestForPayment error: %s", err)
+	}
 }
 
 func (s *Stellar) handlePaymentNotification(mctx libkb.MetaContext, obm gregor.OutOfBandMessage) {
@@ -356,6 +363,10 @@ func (s *Stellar) handlePaymentNotification(mctx libkb.MetaContext, obm gregor.O
 		return
 	}
 	s.G().NotifyRouter.HandleWalletPaymentNotification(mctx.Ctx(), notifiedAccountID, msg.PaymentID)
+
+	if err := s.fulfillRequestForPayment(mctx, msg.PaymentID); err != nil {
+		mctx.Debug("fulfillRequestForPayment error: %s", err)
+	}
 }
 
 func (s *Stellar) findAccountFromPayment(mctx libkb.MetaContext, accountID stellar1.AccountID, payment *stellar1.PaymentLocal) (stellar1.AccountID, error) {
@@ -36,6 +36,9 @@ type Remoter interface {
 	SubmitRequest(ctx context.Context, post stellar1.RequestPost) (stellar1.KeybaseRequestID, error)
 	RequestDetails(ctx context.Context, requestID stellar1.KeybaseRequestID) (stellar1.RequestDetails, error)
 	CancelRequest(ctx context.Context, requestID stellar1.KeybaseRequestID) error
+	DeclineRequest(ctx context.Context, requestID stellar1.KeybaseRequestID) error
+	PendingRequests(ctx context.Context) ([]stellar1.RequestDetails, error)
+	FulfillRequest(ctx context.Context, requestID stellar1.KeybaseRequestID, kbTxID stellar1.KeybaseTransactionID) error
 	MarkAsRead(ctx context.Context, accountID stellar1.AccountID, mostRecentID stellar1.TransactionID) error
 	IsAccountMobileOnly(ctx context.Context, accountID stellar1.AccountID) (bool, error)
 	SetAccountMobileOnly(ctx context.Context, accountID stellar1.AccountID) error
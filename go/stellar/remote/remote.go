@@ -774,6 +774,60 @@ func CancelRequest(ctx context.Context, g *libkb.GlobalContext, requestID stella
 	return g.API.PostDecode(mctx, apiArg, &res)
 }
 
+// DeclineRequest is for the user being asked for payment; the user asking
+// cancels it instead.
+func DeclineRequest(ctx context.Context, g *libkb.GlobalContext, requestID stellar1.KeybaseRequestID) (err error) {
+	payload := make(libkb.JSONPayload)
+	payload["id"] = requestID
+	apiArg := libkb.APIArg{
+		Endpoint:    "stellar/declinerequest",
+		SessionType: libkb.APISessionTypeREQUIRED,
+		JSONPayload: payload,
+	}
+	var res libkb.AppStatusEmbed
+	mctx := libkb.NewMetaContext(ctx, g)
+	return g.API.PostDecode(mctx, apiArg, &res)
+}
+
+type pendingRequestsResult struct {
+	libkb.AppStatusEmbed
+	Requests []stellar1.RequestDetails `json:"requests"`
+}
+
+// PendingRequests returns the requests made by or of the current user that
+// are still waiting to be paid.
+func PendingRequests(ctx context.Context, g *libkb.GlobalContext) (ret []stellar1.RequestDetails, err error) {
+	mctx := libkb.NewMetaContext(ctx, g)
+	apiArg := libkb.APIArg{
+		Endpoint:        "stellar/pendingrequests",
+		SessionType:     libkb.APISessionTypeREQUIRED,
+		RetryCount:      3,
+		RetryMultiplier: 1.5,
+		InitialTimeout:  10 * time.Second,
+	}
+	var res pendingRequestsResult
+	if err := mctx.G().API.GetDecode(mctx, apiArg, &res); err != nil {
+		return ret, err
+	}
+	return res.Requests, nil
+}
+
+// FulfillRequest marks one of the current user's requests as paid by the
+// payment kbTxID.
+func FulfillRequest(ctx context.Context, g *libkb.GlobalContext, requestID stellar1.KeybaseRequestID, kbTxID stellar1.KeybaseTransactionID) (err error) {
+	payload := make(libkb.JSONPayload)
+	payload["id"] = requestID
+	payload["kb_tx_id"] = kbTxID
+	apiArg := libkb.APIArg{
+		Endpoint:    "stellar/fulfillrequest",
+		SessionType: libkb.APISessionTypeREQUIRED,
+		JSONPayload: payload,
+	}
+	var res libkb.AppStatusEmbed
+	mctx := libkb.NewMetaContext(ctx, g)
+	return g.API.PostDecode(mctx, apiArg, &res)
+}
+
 func MarkAsRead(ctx context.Context, g *libkb.GlobalContext, accountID stellar1.AccountID, mostRecentID stellar1.TransactionID) error {
 	payload := make(libkb.JSONPayload)
 	payload["account_id"] = accountID
@@ -98,6 +98,18 @@ func (r *RemoteNet) CancelRequest(ctx context.Context, requestID stellar1.Keybas
 	return CancelRequest(ctx, r.G(), requestID)
 }
 
+func (r *RemoteNet) DeclineRequest(ctx context.Context, requestID stellar1.KeybaseRequestID) error {
+	return DeclineRequest(ctx, r.G(), requestID)
+}
+
+func (r *RemoteNet) PendingRequests(ctx context.Context) ([]stellar1.RequestDetails, error) {
+	return PendingRequests(ctx, r.G())
+}
+
+func (r *RemoteNet) FulfillRequest(ctx context.Context, requestID stellar1.KeybaseRequestID, kbTxID stellar1.KeybaseTransactionID) error {
+	return FulfillRequest(ctx, r.G(), requestID, kbTxID)
+}
+
 func (r *RemoteNet) MarkAsRead(ctx context.Context, accountID stellar1.AccountID, mostRecentID stellar1.TransactionID) error {
 	return MarkAsRead(ctx, r.G(), accountID, mostRecentID)
 }
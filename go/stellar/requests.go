@@ -0,0 +1,112 @@
+package stellar
+
+import (
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/keybase/client/go/protocol/stellar1"
+	"github.com/keybase/stellarnet"
+)
+
+// A payment request doesn't have to be paid from its chat message: any
+// payment that arrives from the user asked, for the amount asked, settles
+// it. The requester's device notices these as payment notifications come
+// in, and tells the server which payment paid which request.
+
+// requestPaidBy returns whether the direct payment p, received by me, pays
+// the pending request.
+func requestPaidBy(me keybase1.UserVersion, request stellar1.RequestDetails, p stellar1.PaymentSummaryDirect) bool {
+	if request.Status != stellar1.RequestStatus_OK || !request.FromUser.Eq(me) {
+		return false
+	}
+	if request.ToUser == nil || !request.ToUser.Eq(p.From) || p.To == nil || !p.To.Eq(me) {
+		return false
+	}
+	if p.TxStatus != stellar1.TransactionStatus_SUCCESS {
+		return false
+	}
+
+	var paid string
+	switch {
+	case request.Asset != nil:
+		if !request.Asset.SameAsset(p.Asset) {
+			return false
+		}
+		paid = p.Amount
+	case request.Currency != nil:
+		// The payer converted the amount at their rate, so what they were
+		// going for is the display amount.
+		if p.DisplayCurrency == nil || p.DisplayAmount == nil ||
+			*p.DisplayCurrency != request.Currency.String() {
+			return false
+		}
+		paid = *p.DisplayAmount
+	default:
+		return false
+	}
+	want, err := stellarnet.ParseStellarAmount(request.Amount)
+	if err != nil {
+		return false
+	}
+	got, err := stellarnet.ParseStellarAmount(paid)
+	if err != nil {
+		return false
+	}
+	return got == want
+}
+
+// matchRequestForPayment returns the first of requests that p pays. Only
+// direct payments count; a relay isn't paid to anyone until it's claimed.
+func matchRequestForPayment(me keybase1.UserVersion, requests []stellar1.RequestDetails,
+	p stellar1.PaymentSummary) (request stellar1.RequestDetails, kbTxID stellar1.KeybaseTransactionID, ok bool) {
+	typ, err := p.Typ()
+	if err != nil || typ != stellar1.PaymentSummaryType_DIRECT {
+		return request, kbTxID, false
+	}
+	direct := p.Direct()
+	for _, request := range requests {
+		if requestPaidBy(me, request, direct) {
+			return request, direct.KbTxID, true
+		}
+	}
+	return request, kbTxID, false
+}
+
+// fulfillRequestForPayment marks the pending request of the current user's
+// that paymentID pays, if there is one, as done.
+func (s *Stellar) fulfillRequestForPayment(mctx libkb.MetaContext, paymentID stellar1.PaymentID) (err error) {
+	defer mctx.Trace("Stellar.fulfillRequestForPayment", &err)()
+
+	me, err := mctx.G().GetMeUV(mctx.Ctx())
+	if err != nil {
+		return err
+	}
+	requests, err := s.remoter.PendingRequests(mctx.Ctx())
+	if err != nil {
+		return err
+	}
+	var mine []stellar1.RequestDetails
+	for _, request := range requests {
+		if request.FromUser.Eq(me) {
+			mine = append(mine, request)
+		}
+	}
+	if len(mine) == 0 {
+		return nil
+	}
+
+	details, err := s.remoter.PaymentDetailsGeneric(mctx.Ctx(), stellar1.TransactionIDFromPaymentID(paymentID).String())
+	if err != nil {
+		return err
+	}
+	request, kbTxID, ok := matchRequestForPayment(me, mine, details.Summary)
+	if !ok {
+		return nil
+	}
+	mctx.Debug("payment %s pays request %s", paymentID, request.Id)
+	if err := s.remoter.FulfillRequest(mctx.Ctx(), request.Id, kbTxID); err != nil {
+		return err
+	}
+	mctx.G().NotifyRouter.HandleWalletRequestStatusNotification(mctx.Ctx(), request.Id)
+	DefaultLoader(mctx.G()).UpdateRequest(mctx.Ctx(), request.Id)
+	return nil
+}
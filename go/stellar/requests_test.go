@@ -0,0 +1,68 @@
+package stellar
+
+import (
+	"testing"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/keybase/client/go/protocol/stellar1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchRequestForPayment(t *testing.T) {
+	alice := keybase1.UserVersion{Uid: keybase1.UID("a"), EldestSeqno: 1}
+	bob := keybase1.UserVersion{Uid: keybase1.UID("b"), EldestSeqno: 1}
+	carol := keybase1.UserVersion{Uid: keybase1.UID("c"), EldestSeqno: 1}
+	xlm := stellar1.AssetNative()
+	usd := stellar1.OutsideCurrencyCode("USD")
+
+	requests := []stellar1.RequestDetails{
+		{Id: "canceled", FromUser: alice, ToUser: &bob, Amount: "5", Asset: &xlm,
+			Status: stellar1.RequestStatus_CANCELED},
+		{Id: "xlm", FromUser: alice, ToUser: &bob, Amount: "5", Asset: &xlm},
+		{Id: "usd", FromUser: alice, ToUser: &bob, Amount: "10", Currency: &usd},
+		{Id: "carol", FromUser: alice, ToUser: &carol, Amount: "3", Asset: &xlm},
+	}
+	direct := func(from keybase1.UserVersion, amount string) stellar1.PaymentSummaryDirect {
+		return stellar1.PaymentSummaryDirect{
+			KbTxID:   "kbtx",
+			TxStatus: stellar1.TransactionStatus_SUCCESS,
+			From:     from,
+			To:       &alice,
+			Amount:   amount,
+			Asset:    xlm,
+		}
+	}
+	match := func(p stellar1.PaymentSummaryDirect) stellar1.KeybaseRequestID {
+		request, kbTxID, ok := matchRequestForPayment(alice, requests, stellar1.NewPaymentSummaryWithDirect(p))
+		if !ok {
+			return ""
+		}
+		require.Equal(t, p.KbTxID, kbTxID)
+		return request.Id
+	}
+
+	require.EqualValues(t, "xlm", match(direct(bob, "5.0000000")))
+	require.EqualValues(t, "", match(direct(bob, "4.9")))
+	require.EqualValues(t, "carol", match(direct(carol, "3")))
+
+	pending := direct(bob, "5")
+	pending.TxStatus = stellar1.TransactionStatus_PENDING
+	require.EqualValues(t, "", match(pending))
+
+	// An outside currency request is matched by what the payer meant to
+	// send, whatever it came to in XLM.
+	inUSD := direct(bob, "71.2345")
+	displayAmount, displayCurrency := "10.00", "USD"
+	inUSD.DisplayAmount, inUSD.DisplayCurrency = &displayAmount, &displayCurrency
+	require.EqualValues(t, "usd", match(inUSD))
+
+	// Only the requester's device settles a request.
+	_, _, ok := matchRequestForPayment(bob, requests, stellar1.NewPaymentSummaryWithDirect(direct(bob, "5")))
+	require.False(t, ok)
+
+	_, _, ok = matchRequestForPayment(alice, requests, stellar1.NewPaymentSummaryWithRelay(stellar1.PaymentSummaryRelay{
+		From:   bob,
+		Amount: "5",
+	}))
+	require.False(t, ok)
+}
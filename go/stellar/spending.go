@@ -0,0 +1,335 @@
+package stellar
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/stellar1"
+	"github.com/keybase/stellarnet"
+)
+
+// Spending limits are a local safety net: they are stored on this device and
+// checked before any payment is signed. They don't stop another device (or
+// anyone holding the secret key) from spending.
+
+const spendingAuditMaxEntries = 500
+
+type spendingPolicyDBEntry struct {
+	Version int
+	Policy  stellar1.SpendingPolicyLocal
+	Audit   []stellar1.SpendingAuditEntryLocal // oldest first
+}
+
+type SpendingLimitError struct {
+	Msg string
+}
+
+func (e SpendingLimitError) Error() string {
+	return e.Msg
+}
+
+func spendingDBKey(mctx libkb.MetaContext) (libkb.DbKey, error) {
+	uv, err := mctx.G().GetMeUV(mctx.Ctx())
+	if err != nil {
+		return libkb.DbKey{}, err
+	}
+	return libkb.DbKey{
+		Typ: libkb.DBStellarSpendingPolicy,
+		Key: uv.String(),
+	}, nil
+}
+
+func loadSpendingLocked(mctx libkb.MetaContext) (res spendingPolicyDBEntry, err error) {
+	key, err := spendingDBKey(mctx)
+	if err != nil {
+		return res, err
+	}
+	found, err := mctx.G().LocalDb.GetInto(&res, key)
+	if err != nil {
+		return res, err
+	}
+	if !found || res.Version != 1 {
+		return spendingPolicyDBEntry{Version: 1}, nil
+	}
+	return res, nil
+}
+
+func storeSpendingLocked(mctx libkb.MetaContext, entry spendingPolicyDBEntry) error {
+	key, err := spendingDBKey(mctx)
+	if err != nil {
+		return err
+	}
+	if len(entry.Audit) > spendingAuditMaxEntries {
+		entry.Audit = entry.Audit[len(entry.Audit)-spendingAuditMaxEntries:]
+	}
+	entry.Version = 1
+	return mctx.G().LocalDb.PutObj(key, nil, entry)
+}
+
+// parseSpendingLimit returns 0 for "no limit".
+func parseSpendingLimit(s string) (int64, error) {
+	if len(s) == 0 {
+		return 0, nil
+	}
+	return stellarnet.ParseStellarAmount(s)
+}
+
+func isSpendingPolicyEmpty(p stellar1.SpendingPolicyLocal) bool {
+	return len(p.PerTxLimitXLM) == 0 && len(p.DailyLimitXLM) == 0 && len(p.ConfirmAboveXLM) == 0
+}
+
+// spendingLimitLoosened says whether going from before to after raises or
+// removes a limit.
+func spendingLimitLoosened(before, after string) (bool, error) {
+	oldLimit, err := parseSpendingLimit(before)
+	if err != nil {
+		return false, err
+	}
+	newLimit, err := parseSpendingLimit(after)
+	if err != nil {
+		return false, err
+	}
+	if oldLimit == 0 {
+		return false, nil
+	}
+	return newLimit == 0 || newLimit > oldLimit, nil
+}
+
+// confirmWithPassphrase asks for the account passphrase via the SecretUI in
+// mctx. There's no way to confirm a payment without one.
+func confirmWithPassphrase(mctx libkb.MetaContext, reason string) error {
+	secretUI := mctx.UIs().SecretUI
+	if secretUI == nil {
+		return SpendingLimitError{Msg: fmt.Sprintf("%s needs confirmation; please send it from the wallet", reason)}
+	}
+	username := mctx.G().GetEnv().GetUsername().String()
+	arg := libkb.DefaultPassphrasePromptArg(mctx, username)
+	arg.Prompt += " to confirm " + reason
+	res, err := secretUI.GetPassphrase(arg, nil)
+	if err != nil {
+		return err
+	}
+	_, err = libkb.VerifyPassphraseForLoggedInUser(mctx, res.Passphrase)
+	return err
+}
+
+// spentSince sums up the payments in the audit log that went ahead after
+// `since`. Payments that were let through but then failed to submit are
+// counted as well, so the daily limit errs on the safe side.
+func spentSince(audit []stellar1.SpendingAuditEntryLocal, since time.Time) (total int64) {
+	for _, entry := range audit {
+		if entry.Ctime.Time().Before(since) {
+			continue
+		}
+		switch entry.Decision {
+		case stellar1.SpendingAuditDecision_ALLOWED, stellar1.SpendingAuditDecision_CONFIRMED:
+			amount, err := stellarnet.ParseStellarAmount(entry.AmountXLM)
+			if err == nil {
+				total += amount
+			}
+		}
+	}
+	return total
+}
+
+// checkSpendingLimits checks a payment of amountXLM against entry's policy.
+// blocked says why it's over a limit, if it is; otherwise needsConfirmation
+// says whether the user has to confirm it.
+func checkSpendingLimits(entry spendingPolicyDBEntry, amountXLM string, now time.Time) (
+	blocked error, needsConfirmation bool, err error) {
+	amount, err := stellarnet.ParseStellarAmount(amountXLM)
+	if err != nil {
+		return nil, false, err
+	}
+	perTxLimit, err := parseSpendingLimit(entry.Policy.PerTxLimitXLM)
+	if err != nil {
+		return nil, false, err
+	}
+	dailyLimit, err := parseSpendingLimit(entry.Policy.DailyLimitXLM)
+	if err != nil {
+		return nil, false, err
+	}
+	confirmAbove, err := parseSpendingLimit(entry.Policy.ConfirmAboveXLM)
+	if err != nil {
+		return nil, false, err
+	}
+	switch {
+	case perTxLimit > 0 && amount > perTxLimit:
+		return SpendingLimitError{Msg: fmt.Sprintf("%s XLM is over your limit of %s XLM per payment",
+			amountXLM, entry.Policy.PerTxLimitXLM)}, false, nil
+	case dailyLimit > 0 && spentSince(entry.Audit, now.Add(-24*time.Hour))+amount > dailyLimit:
+		return SpendingLimitError{Msg: fmt.Sprintf("%s XLM would take you over your daily limit of %s XLM",
+			amountXLM, entry.Policy.DailyLimitXLM)}, false, nil
+	default:
+		return nil, confirmAbove > 0 && amount > confirmAbove, nil
+	}
+}
+
+// appendSpendingAuditLocked adds audit to the end of the audit log.
+func appendSpendingAuditLocked(mctx libkb.MetaContext, audit stellar1.SpendingAuditEntryLocal) error {
+	entry, err := loadSpendingLocked(mctx)
+	if err != nil {
+		return err
+	}
+	entry.Audit = append(entry.Audit, audit)
+	return storeSpendingLocked(mctx, entry)
+}
+
+// CheckSpendingPolicy is called before signing a payment of amountXLM. It
+// refuses payments over the per-transaction or daily limit, and prompts for
+// the passphrase for payments over the confirmation threshold. Every
+// decision goes into the audit log.
+func CheckSpendingPolicy(mctx libkb.MetaContext, from stellar1.AccountID, recipient string, amountXLM string) (err error) {
+	defer mctx.Trace(fmt.Sprintf("Stellar.CheckSpendingPolicy(%s)", amountXLM), &err)()
+
+	s := getGlobal(mctx.G())
+	s.spendingLock.Lock()
+	defer s.spendingLock.Unlock()
+	// The policy the user confirmed this payment under, if they have.
+	var confirmedUnder *stellar1.SpendingPolicyLocal
+	for {
+		entry, err := loadSpendingLocked(mctx)
+		if err != nil {
+			return err
+		}
+		if isSpendingPolicyEmpty(entry.Policy) {
+			return nil
+		}
+		now := mctx.G().Clock().Now()
+		blocked, needsConfirmation, err := checkSpendingLimits(entry, amountXLM, now)
+		if err != nil {
+			return err
+		}
+		audit := stellar1.SpendingAuditEntryLocal{
+			Ctime:     stellar1.ToTimeMs(now),
+			From:      from,
+			Recipient: recipient,
+			AmountXLM: amountXLM,
+			Decision:  stellar1.SpendingAuditDecision_ALLOWED,
+		}
+		switch {
+		case blocked != nil:
+			err = blocked
+			audit.Decision = stellar1.SpendingAuditDecision_BLOCKED
+		case needsConfirmation && confirmedUnder != nil && *confirmedUnder == entry.Policy:
+			audit.Decision = stellar1.SpendingAuditDecision_CONFIRMED
+		case needsConfirmation:
+			// Don't hold up other payments and policy changes while the
+			// user answers the prompt. Once they have, check the payment
+			// again, since the policy or the day's spending may have
+			// changed in the meantime.
+			policy := entry.Policy
+			s.spendingLock.Unlock()
+			err = confirmWithPassphrase(mctx, fmt.Sprintf("a payment of %s XLM", amountXLM))
+			s.spendingLock.Lock()
+			if err == nil {
+				confirmedUnder = &policy
+				continue
+			}
+			audit.Decision = stellar1.SpendingAuditDecision_CONFIRMATION_FAILED
+		}
+		if err != nil {
+			audit.Reason = err.Error()
+		}
+		if serr := appendSpendingAuditLocked(mctx, audit); serr != nil {
+			mctx.Debug("CheckSpendingPolicy: failed to store audit entry: %v", serr)
+			if err == nil {
+				// Without a record of this payment the daily limit can't be
+				// enforced, so don't let it through.
+				err = serr
+			}
+		}
+		return err
+	}
+}
+
+func GetSpendingPolicy(mctx libkb.MetaContext) (stellar1.SpendingPolicyLocal, error) {
+	s := getGlobal(mctx.G())
+	s.spendingLock.Lock()
+	defer s.spendingLock.Unlock()
+	entry, err := loadSpendingLocked(mctx)
+	if err != nil {
+		return stellar1.SpendingPolicyLocal{}, err
+	}
+	return entry.Policy, nil
+}
+
+// SetSpendingPolicy replaces the spending policy. Tightening limits goes
+// through right away; loosening or removing one needs the passphrase, or the
+// limits would be no use against someone with access to an unlocked device.
+func SetSpendingPolicy(mctx libkb.MetaContext, policy stellar1.SpendingPolicyLocal) (err error) {
+	defer mctx.Trace("Stellar.SetSpendingPolicy", &err)()
+
+	for _, limit := range []string{policy.PerTxLimitXLM, policy.DailyLimitXLM, policy.ConfirmAboveXLM} {
+		if _, err := parseSpendingLimit(limit); err != nil {
+			return fmt.Errorf("invalid limit %q: %v", limit, err)
+		}
+	}
+
+	s := getGlobal(mctx.G())
+	s.spendingLock.Lock()
+	defer s.spendingLock.Unlock()
+	// The policy the user confirmed loosening, if they have.
+	var confirmedFrom *stellar1.SpendingPolicyLocal
+	for {
+		entry, err := loadSpendingLocked(mctx)
+		if err != nil {
+			return err
+		}
+		loosened := false
+		for _, pair := range [][2]string{
+			{entry.Policy.PerTxLimitXLM, policy.PerTxLimitXLM},
+			{entry.Policy.DailyLimitXLM, policy.DailyLimitXLM},
+			{entry.Policy.ConfirmAboveXLM, policy.ConfirmAboveXLM},
+		} {
+			l, err := spendingLimitLoosened(pair[0], pair[1])
+			if err != nil {
+				return err
+			}
+			loosened = loosened || l
+		}
+		audit := stellar1.SpendingAuditEntryLocal{
+			Ctime:    stellar1.ToTimeMs(mctx.G().Clock().Now()),
+			Decision: stellar1.SpendingAuditDecision_POLICY_CHANGED,
+			Reason: fmt.Sprintf("per payment %q, daily %q, confirm above %q",
+				policy.PerTxLimitXLM, policy.DailyLimitXLM, policy.ConfirmAboveXLM),
+		}
+		if loosened && (confirmedFrom == nil || *confirmedFrom != entry.Policy) {
+			// As with payments, don't prompt with the lock held, and check
+			// again afterwards.
+			before := entry.Policy
+			s.spendingLock.Unlock()
+			err := confirmWithPassphrase(mctx, "raising your spending limits")
+			s.spendingLock.Lock()
+			if err == nil {
+				confirmedFrom = &before
+				continue
+			}
+			audit.Decision = stellar1.SpendingAuditDecision_CONFIRMATION_FAILED
+			audit.Reason = err.Error()
+			if serr := appendSpendingAuditLocked(mctx, audit); serr != nil {
+				mctx.Debug("SetSpendingPolicy: failed to store audit entry: %v", serr)
+			}
+			return err
+		}
+		entry.Policy = policy
+		entry.Audit = append(entry.Audit, audit)
+		return storeSpendingLocked(mctx, entry)
+	}
+}
+
+// GetSpendingAudit returns the audit log, newest first.
+func GetSpendingAudit(mctx libkb.MetaContext) (res []stellar1.SpendingAuditEntryLocal, err error) {
+	s := getGlobal(mctx.G())
+	s.spendingLock.Lock()
+	defer s.spendingLock.Unlock()
+	entry, err := loadSpendingLocked(mctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(entry.Audit) - 1; i >= 0; i-- {
+		res = append(res, entry.Audit[i])
+	}
+	return res, nil
+}
@@ -0,0 +1,81 @@
+package stellar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/protocol/stellar1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpendingLimitLoosened(t *testing.T) {
+	for _, tc := range []struct {
+		before, after string
+		loosened      bool
+	}{
+		{"", "", false},
+		{"", "100", false},
+		{"100", "50", false},
+		{"100", "100", false},
+		{"100", "100.0000001", true},
+		{"100", "", true},
+	} {
+		loosened, err := spendingLimitLoosened(tc.before, tc.after)
+		require.NoError(t, err)
+		require.Equal(t, tc.loosened, loosened, "%q -> %q", tc.before, tc.after)
+	}
+	_, err := spendingLimitLoosened("100", "lots")
+	require.Error(t, err)
+}
+
+func TestSpentSince(t *testing.T) {
+	now := time.Now()
+	entry := func(age time.Duration, amount string, decision stellar1.SpendingAuditDecision) stellar1.SpendingAuditEntryLocal {
+		return stellar1.SpendingAuditEntryLocal{
+			Ctime:     stellar1.ToTimeMs(now.Add(-age)),
+			AmountXLM: amount,
+			Decision:  decision,
+		}
+	}
+	audit := []stellar1.SpendingAuditEntryLocal{
+		entry(25*time.Hour, "1000", stellar1.SpendingAuditDecision_ALLOWED),
+		entry(2*time.Hour, "10", stellar1.SpendingAuditDecision_ALLOWED),
+		entry(time.Hour, "5.5", stellar1.SpendingAuditDecision_CONFIRMED),
+		entry(time.Hour, "200", stellar1.SpendingAuditDecision_BLOCKED),
+		entry(time.Minute, "300", stellar1.SpendingAuditDecision_CONFIRMATION_FAILED),
+	}
+	require.Equal(t, int64(155000000), spentSince(audit, now.Add(-24*time.Hour)))
+}
+
+func TestCheckSpendingLimits(t *testing.T) {
+	now := time.Now()
+	entry := spendingPolicyDBEntry{
+		Policy: stellar1.SpendingPolicyLocal{
+			PerTxLimitXLM:   "100",
+			DailyLimitXLM:   "150",
+			ConfirmAboveXLM: "50",
+		},
+		Audit: []stellar1.SpendingAuditEntryLocal{{
+			Ctime:     stellar1.ToTimeMs(now.Add(-time.Hour)),
+			AmountXLM: "60",
+			Decision:  stellar1.SpendingAuditDecision_CONFIRMED,
+		}},
+	}
+	for _, tc := range []struct {
+		amount            string
+		blocked           bool
+		needsConfirmation bool
+	}{
+		{"10", false, false},
+		{"60", false, true},
+		{"100.0000001", true, false},
+		{"91", true, false},
+	} {
+		blocked, needsConfirmation, err := checkSpendingLimits(entry, tc.amount, now)
+		require.NoError(t, err)
+		require.Equal(t, tc.blocked, blocked != nil, tc.amount)
+		require.Equal(t, tc.needsConfirmation, needsConfirmation, tc.amount)
+	}
+	_, _, err := checkSpendingLimits(entry, "lots", now)
+	require.Error(t, err)
+}
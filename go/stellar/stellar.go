@@ -600,6 +600,10 @@ func sendPayment(mctx libkb.MetaContext, walletState *WalletState, sendArg SendP
 		return res, err
 	}
 
+	if err := CheckSpendingPolicy(mctx, senderAccountID, string(sendArg.To), sendArg.Amount); err != nil {
+		return res, err
+	}
+
 	mctx.Debug("using stellar network passphrase: %q", stellarnet.Network().Passphrase)
 
 	baseFee := walletState.BaseFee(mctx)
@@ -825,6 +829,14 @@ func PathPaymentTx(mctx libkb.MetaContext, walletState *WalletState, sendArg Sen
 }
 
 func sendPathPayment(mctx libkb.MetaContext, walletState *WalletState, sendArg SendPathPaymentArg) (res SendPaymentResult, err error) {
+	// Spending limits are in XLM, so they only cover path payments that
+	// start out as XLM.
+	if sendArg.Path.SourceAsset.IsNativeXLM() {
+		if err := CheckSpendingPolicy(mctx, sendArg.From, string(sendArg.To), sendArg.Path.SourceAmountMax); err != nil {
+			return res, err
+		}
+	}
+
 	sig, senderEntry, recipient, err := PathPaymentTx(mctx, walletState, sendArg)
 	if err != nil {
 		return res, err
@@ -1020,6 +1032,21 @@ func SendMiniChatPayments(m libkb.MetaContext, walletState *WalletState, convID
 		return nil, err
 	}
 
+	var xlmTotal int64
+	var usernames []string
+	for _, payment := range payments {
+		spec, xlmAmount := specMiniChatPayment(m, walletState, payment)
+		if spec.Error == nil {
+			xlmTotal += xlmAmount
+		}
+		usernames = append(usernames, payment.Username.String())
+	}
+	err = CheckSpendingPolicy(m, senderAccountID, strings.Join(usernames, ","),
+		stellarnet.StringFromStellarAmount(xlmTotal))
+	if err != nil {
+		return nil, err
+	}
+
 	prepared, unlock, err := PrepareMiniChatPayments(m, walletState, senderSeed, convID, payments)
 	defer unlock()
 	if err != nil {
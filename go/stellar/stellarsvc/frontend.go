@@ -744,6 +744,8 @@ func (s *Server) SendPaymentLocal(ctx context.Context, arg stellar1.SendPaymentL
 	if err != nil {
 		return res, err
 	}
+	// Payments over the confirmation threshold prompt for the passphrase.
+	mctx = mctx.WithUIs(libkb.UIs{SecretUI: s.uiSource.SecretUI(s.G(), 0)})
 	return stellar.SendPaymentLocal(mctx, arg)
 }
 
@@ -757,6 +759,8 @@ func (s *Server) SendPathLocal(ctx context.Context, arg stellar1.SendPathLocalAr
 	if err != nil {
 		return res, err
 	}
+	// Payments over the confirmation threshold prompt for the passphrase.
+	mctx = mctx.WithUIs(libkb.UIs{SecretUI: s.uiSource.SecretUI(s.G(), 0)})
 
 	var pubMemo *stellarnet.Memo
 	if arg.PublicNote != "" {
@@ -863,6 +867,48 @@ func (s *Server) CancelRequestLocal(ctx context.Context, arg stellar1.CancelRequ
 	return s.remoter.CancelRequest(mctx.Ctx(), arg.ReqID)
 }
 
+func (s *Server) DeclineRequestLocal(ctx context.Context, arg stellar1.DeclineRequestLocalArg) (err error) {
+	mctx, fin, err := s.Preamble(ctx, preambleArg{
+		RPCName: "DeclineRequestLocal",
+		Err:     &err,
+	})
+	defer fin()
+	if err != nil {
+		return err
+	}
+
+	return s.remoter.DeclineRequest(mctx.Ctx(), arg.ReqID)
+}
+
+func (s *Server) GetPendingRequestsLocal(ctx context.Context, sessionID int) (res []stellar1.RequestDetailsLocal, err error) {
+	mctx, fin, err := s.Preamble(ctx, preambleArg{
+		RPCName: "GetPendingRequestsLocal",
+		Err:     &err,
+	})
+	defer fin()
+	if err != nil {
+		return nil, err
+	}
+
+	requests, err := s.remoter.PendingRequests(mctx.Ctx())
+	if err != nil {
+		return nil, err
+	}
+	res = []stellar1.RequestDetailsLocal{}
+	for _, details := range requests {
+		if details.Status != stellar1.RequestStatus_OK {
+			continue
+		}
+		local, err := stellar.TransformRequestDetails(mctx, details)
+		if err != nil {
+			mctx.Debug("GetPendingRequestsLocal: skipping request %s: %s", details.Id, err)
+			continue
+		}
+		res = append(res, *local)
+	}
+	return res, nil
+}
+
 func (s *Server) MarkAsReadLocal(ctx context.Context, arg stellar1.MarkAsReadLocalArg) (err error) {
 	mctx, fin, err := s.Preamble(ctx, preambleArg{
 		RPCName:       "MarkAsReadLocal",
@@ -1300,6 +1346,46 @@ func (s *Server) AssetWithdrawLocal(ctx context.Context, arg stellar1.AssetWithd
 	return ai.Withdraw(mctx)
 }
 
+func (s *Server) GetSpendingPolicyLocal(ctx context.Context, sessionID int) (res stellar1.SpendingPolicyLocal, err error) {
+	mctx, fin, err := s.Preamble(ctx, preambleArg{
+		RPCName:       "GetSpendingPolicyLocal",
+		Err:           &err,
+		RequireWallet: true,
+	})
+	defer fin()
+	if err != nil {
+		return res, err
+	}
+	return stellar.GetSpendingPolicy(mctx)
+}
+
+func (s *Server) SetSpendingPolicyLocal(ctx context.Context, arg stellar1.SetSpendingPolicyLocalArg) (err error) {
+	mctx, fin, err := s.Preamble(ctx, preambleArg{
+		RPCName:       "SetSpendingPolicyLocal",
+		Err:           &err,
+		RequireWallet: true,
+	})
+	defer fin()
+	if err != nil {
+		return err
+	}
+	mctx = mctx.WithUIs(libkb.UIs{SecretUI: s.uiSource.SecretUI(s.G(), arg.SessionID)})
+	return stellar.SetSpendingPolicy(mctx, arg.Policy)
+}
+
+func (s *Server) GetSpendingAuditLocal(ctx context.Context, sessionID int) (res []stellar1.SpendingAuditEntryLocal, err error) {
+	mctx, fin, err := s.Preamble(ctx, preambleArg{
+		RPCName:       "GetSpendingAuditLocal",
+		Err:           &err,
+		RequireWallet: true,
+	})
+	defer fin()
+	if err != nil {
+		return nil, err
+	}
+	return stellar.GetSpendingAudit(mctx)
+}
+
 func (s *Server) prepareAnchorInteractor(mctx libkb.MetaContext, accountID stellar1.AccountID, asset stellar1.Asset) (*anchorInteractor, error) {
 	// check that the user owns accountID
 	own, _, err := stellar.OwnAccountCached(mctx, accountID)
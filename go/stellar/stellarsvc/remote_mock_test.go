@@ -500,6 +500,18 @@ func (r *RemoteClientMock) CancelRequest(ctx context.Context, requestID stellar1
 	return r.Backend.CancelRequest(ctx, r.Tc, requestID)
 }
 
+func (r *RemoteClientMock) DeclineRequest(ctx context.Context, requestID stellar1.KeybaseRequestID) error {
+	return r.Backend.DeclineRequest(ctx, r.Tc, requestID)
+}
+
+func (r *RemoteClientMock) PendingRequests(ctx context.Context) ([]stellar1.RequestDetails, error) {
+	return r.Backend.PendingRequests(ctx, r.Tc)
+}
+
+func (r *RemoteClientMock) FulfillRequest(ctx context.Context, requestID stellar1.KeybaseRequestID, kbTxID stellar1.KeybaseTransactionID) error {
+	return r.Backend.FulfillRequest(ctx, r.Tc, requestID, kbTxID)
+}
+
 func (r *RemoteClientMock) MarkAsRead(ctx context.Context, acctID stellar1.AccountID, mostRecentID stellar1.TransactionID) error {
 	return r.Backend.MarkAsRead(ctx, r.Tc, acctID, mostRecentID)
 }
@@ -1268,6 +1280,72 @@ func (r *BackendMock) CancelRequest(ctx context.Context, tc *TestContext, reques
 	return nil
 }
 
+func (r *BackendMock) DeclineRequest(ctx context.Context, tc *TestContext, requestID stellar1.KeybaseRequestID) (err error) {
+	readError := func() error { return fmt.Errorf("could not find request with ID %s", requestID) }
+
+	details, ok := r.requests[requestID]
+	if !ok {
+		return readError()
+	}
+
+	caller, err := tc.G.GetMeUV(ctx)
+	if err != nil {
+		return fmt.Errorf("could not get self UV: %v", err)
+	}
+
+	if details.ToUser == nil || !details.ToUser.Eq(caller) {
+		return readError()
+	}
+	if details.Status != stellar1.RequestStatus_OK {
+		return fmt.Errorf("request %s is %s", requestID, details.Status)
+	}
+
+	details.Status = stellar1.RequestStatus_DECLINED
+	return nil
+}
+
+func (r *BackendMock) PendingRequests(ctx context.Context, tc *TestContext) (res []stellar1.RequestDetails, err error) {
+	caller, err := tc.G.GetMeUV(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get self UV: %v", err)
+	}
+
+	for _, details := range r.requests {
+		if details.Status != stellar1.RequestStatus_OK {
+			continue
+		}
+		if details.FromUser.Eq(caller) || (details.ToUser != nil && details.ToUser.Eq(caller)) {
+			res = append(res, *details)
+		}
+	}
+	return res, nil
+}
+
+func (r *BackendMock) FulfillRequest(ctx context.Context, tc *TestContext, requestID stellar1.KeybaseRequestID, kbTxID stellar1.KeybaseTransactionID) (err error) {
+	readError := func() error { return fmt.Errorf("could not find request with ID %s", requestID) }
+
+	details, ok := r.requests[requestID]
+	if !ok {
+		return readError()
+	}
+
+	caller, err := tc.G.GetMeUV(ctx)
+	if err != nil {
+		return fmt.Errorf("could not get self UV: %v", err)
+	}
+
+	if !details.FromUser.Eq(caller) {
+		return readError()
+	}
+	if details.Status != stellar1.RequestStatus_OK {
+		return fmt.Errorf("request %s is %s", requestID, details.Status)
+	}
+
+	details.Status = stellar1.RequestStatus_DONE
+	details.FundingKbTxID = kbTxID
+	return nil
+}
+
 func (r *BackendMock) MarkAsRead(ctx context.Context, tc *TestContext, acctID stellar1.AccountID, mostRecentID stellar1.TransactionID) error {
 	return nil
 }
@@ -193,6 +193,7 @@ func (s *Server) SendCLILocal(ctx context.Context, arg stellar1.SendCLILocalArg)
 	}
 	uis := libkb.UIs{
 		IdentifyUI: s.uiSource.IdentifyUI(s.G(), 0),
+		SecretUI:   s.uiSource.SecretUI(s.G(), 0),
 	}
 	mctx = mctx.WithUIs(uis)
 
@@ -278,6 +279,7 @@ func (s *Server) SendPathCLILocal(ctx context.Context, arg stellar1.SendPathCLIL
 
 	uis := libkb.UIs{
 		IdentifyUI: s.uiSource.IdentifyUI(s.G(), 0),
+		SecretUI:   s.uiSource.SecretUI(s.G(), 0),
 	}
 	mctx = mctx.WithUIs(uis)
 
@@ -642,6 +644,25 @@ func (s *Server) BatchLocal(ctx context.Context, arg stellar1.BatchLocalArg) (re
 		return res, err
 	}
 
+	var total int64
+	for _, payment := range arg.Payments {
+		amount, err := stellarnet.ParseStellarAmount(payment.Amount)
+		if err != nil {
+			return res, err
+		}
+		total += amount
+	}
+	mctx = mctx.WithUIs(libkb.UIs{SecretUI: s.uiSource.SecretUI(s.G(), 0)})
+	from, err := stellar.GetOwnPrimaryAccountID(mctx)
+	if err != nil {
+		return res, err
+	}
+	err = stellar.CheckSpendingPolicy(mctx, from, fmt.Sprintf("batch %s (%d payments)", arg.BatchID, len(arg.Payments)),
+		stellarnet.StringFromStellarAmount(total))
+	if err != nil {
+		return res, err
+	}
+
 	if arg.UseMulti {
 		res, err = stellar.BatchMulti(mctx, s.walletState, arg)
 		if err == nil {
@@ -1013,6 +1034,12 @@ func (s *Server) ApprovePathURILocal(ctx context.Context, arg stellar1.ApprovePa
 	}
 
 	if vp.CallbackURL != "" {
+		if sendArg.Path.SourceAsset.IsNativeXLM() {
+			err = stellar.CheckSpendingPolicy(mctx, sendArg.From, vp.Recipient, sendArg.Path.SourceAmountMax)
+			if err != nil {
+				return "", err
+			}
+		}
 		sig, _, _, err := stellar.PathPaymentTx(mctx, s.walletState, sendArg)
 		if err != nil {
 			return "", err
@@ -914,6 +914,55 @@ func TestRequestPaymentOutsideCurrency(t *testing.T) {
 	require.Equal(t, "$8.20 USD", details.AmountDescription)
 }
 
+func TestPendingRequests(t *testing.T) {
+	tcs, cleanup := setupNTests(t, 2)
+	defer cleanup()
+
+	acceptDisclaimer(tcs[0])
+	acceptDisclaimer(tcs[1])
+	xlm := stellar1.AssetNative()
+	var reqIDs []stellar1.KeybaseRequestID
+	for _, amount := range []string{"5", "7"} {
+		reqID, err := tcs[0].Srv.MakeRequestCLILocal(context.Background(), stellar1.MakeRequestCLILocalArg{
+			Recipient: tcs[1].Fu.Username,
+			Asset:     &xlm,
+			Amount:    amount,
+		})
+		require.NoError(t, err)
+		reqIDs = append(reqIDs, reqID)
+	}
+
+	// Both sides see both requests.
+	for _, tc := range tcs {
+		pending, err := tc.Srv.GetPendingRequestsLocal(context.Background(), 0)
+		require.NoError(t, err)
+		require.Len(t, pending, 2)
+	}
+
+	// Only the user asked can decline.
+	err := tcs[0].Srv.DeclineRequestLocal(context.Background(), stellar1.DeclineRequestLocalArg{
+		ReqID: reqIDs[0],
+	})
+	require.Error(t, err)
+	err = tcs[1].Srv.DeclineRequestLocal(context.Background(), stellar1.DeclineRequestLocalArg{
+		ReqID: reqIDs[0],
+	})
+	require.NoError(t, err)
+
+	details, err := tcs[0].Srv.GetRequestDetailsLocal(context.Background(), stellar1.GetRequestDetailsLocalArg{
+		ReqID: reqIDs[0],
+	})
+	require.NoError(t, err)
+	require.Equal(t, stellar1.RequestStatus_DECLINED, details.Status)
+
+	pending, err := tcs[1].Srv.GetPendingRequestsLocal(context.Background(), 0)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, reqIDs[1], pending[0].Id)
+	require.False(t, pending[0].FromCurrentUser)
+	require.Equal(t, "7 XLM", pending[0].AmountDescription)
+}
+
 func TestBundleFlows(t *testing.T) {
 	tcs, cleanup := setupNTests(t, 1)
 	defer cleanup()
@@ -768,4 +768,24 @@ func (s *stellarRetryClient) AssetWithdrawLocal(ctx context.Context, arg stellar
 	return s.cli.AssetWithdrawLocal(ctx, arg)
 }
 
+func (s *stellarRetryClient) GetSpendingPolicyLocal(ctx context.Context, sessionID int) (stellar1.SpendingPolicyLocal, error) {
+	return s.cli.GetSpendingPolicyLocal(ctx, sessionID)
+}
+
+func (s *stellarRetryClient) SetSpendingPolicyLocal(ctx context.Context, arg stellar1.SetSpendingPolicyLocalArg) error {
+	return s.cli.SetSpendingPolicyLocal(ctx, arg)
+}
+
+func (s *stellarRetryClient) GetSpendingAuditLocal(ctx context.Context, sessionID int) ([]stellar1.SpendingAuditEntryLocal, error) {
+	return s.cli.GetSpendingAuditLocal(ctx, sessionID)
+}
+
+func (s *stellarRetryClient) DeclineRequestLocal(ctx context.Context, arg stellar1.DeclineRequestLocalArg) error {
+	return s.cli.DeclineRequestLocal(ctx, arg)
+}
+
+func (s *stellarRetryClient) GetPendingRequestsLocal(ctx context.Context, sessionID int) ([]stellar1.RequestDetailsLocal, error) {
+	return s.cli.GetPendingRequestsLocal(ctx, sessionID)
+}
+
 var _ stellar1.LocalInterface = (*stellarRetryClient)(nil)
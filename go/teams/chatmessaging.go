@@ -182,3 +182,28 @@ func SendTeamChatChangeAvatar(mctx libkb.MetaContext, team, username string) boo
 
 	return true
 }
+
+// SendTeamReadmeDM DMs the readme of teamID, if it has one, to username,
+// who was just added to the team. It's best-effort, so failures are only
+// logged.
+func SendTeamReadmeDM(ctx context.Context, g *libkb.GlobalContext, teamID keybase1.TeamID, team, username string) {
+	if !g.Env.SendSystemChatMessages() {
+		g.Log.CDebugf(ctx, "Skipping SendTeamReadmeDM via environment flag")
+		return
+	}
+	if team == "" {
+		teamname, err := ResolveIDToName(ctx, g, teamID)
+		if err != nil {
+			g.Log.CDebugf(ctx, "SendTeamReadmeDM: failed to resolve team name: %s", err)
+			return
+		}
+		team = teamname.String()
+	}
+
+	// Ensure we have chat available
+	g.StartStandaloneChat()
+
+	if err := g.ChatHelper.SendTeamReadme(ctx, teamID, team, username); err != nil {
+		g.Log.CDebugf(ctx, "SendTeamReadmeDM: failed to send readme to %s: %s", username, err)
+	}
+}
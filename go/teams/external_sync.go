@@ -0,0 +1,492 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package teams
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/keybase/client/go/encrypteddb"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// External group sync keeps a team's membership in line with a directory
+// the admin already maintains elsewhere: a SCIM 2.0 endpoint, or an LDIF
+// export from LDAP. Directory entries are matched to Keybase users by
+// their verified email address.
+
+const externalSyncSCIMPageSize = 100
+
+type scimEmail struct {
+	Value   string `json:"value"`
+	Primary bool   `json:"primary"`
+}
+
+type scimUser struct {
+	UserName string      `json:"userName"`
+	Active   *bool       `json:"active"`
+	Emails   []scimEmail `json:"emails"`
+}
+
+func (u scimUser) email() string {
+	for _, e := range u.Emails {
+		if e.Primary && len(e.Value) > 0 {
+			return e.Value
+		}
+	}
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+	// Plenty of providers use the email address as the userName.
+	if strings.Contains(u.UserName, "@") {
+		return u.UserName
+	}
+	return ""
+}
+
+type scimListResponse struct {
+	TotalResults int        `json:"totalResults"`
+	StartIndex   int        `json:"startIndex"`
+	Resources    []scimUser `json:"Resources"`
+}
+
+func fetchSCIMEmails(mctx libkb.MetaContext, config keybase1.TeamExternalSyncConfig) (emails []string, err error) {
+	defer mctx.Trace("fetchSCIMEmails", &err)()
+
+	base, err := url.Parse(strings.TrimSuffix(config.Source, "/") + "/Users")
+	if err != nil {
+		return nil, err
+	}
+	if base.Scheme != "https" {
+		return nil, fmt.Errorf("SCIM endpoint must use https: %s", config.Source)
+	}
+	client := libkb.ProxyHTTPClient(mctx.G(), mctx.G().Env, "TeamExternalSync")
+	// SCIM indexes are 1-based.
+	startIndex := 1
+	for {
+		u := *base
+		q := u.Query()
+		q.Set("startIndex", strconv.Itoa(startIndex))
+		q.Set("count", strconv.Itoa(externalSyncSCIMPageSize))
+		u.RawQuery = q.Encode()
+		req, err := http.NewRequest("GET", u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		req = req.WithContext(mctx.Ctx())
+		req.Header.Set("Accept", "application/scim+json")
+		if len(config.BearerToken) > 0 {
+			req.Header.Set("Authorization", "Bearer "+config.BearerToken)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		var page scimListResponse
+		err = func() error {
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				return fmt.Errorf("SCIM endpoint returned %s", resp.Status)
+			}
+			return json.NewDecoder(resp.Body).Decode(&page)
+		}()
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range page.Resources {
+			if user.Active != nil && !*user.Active {
+				continue
+			}
+			if email := user.email(); len(email) > 0 {
+				emails = append(emails, email)
+			}
+		}
+		startIndex += len(page.Resources)
+		if len(page.Resources) == 0 || startIndex > page.TotalResults {
+			return emails, nil
+		}
+	}
+}
+
+// parseLDIFEmails pulls the mail attribute out of every entry in an LDIF
+// export. Continuation lines and base64 values are handled; everything
+// else in the file is ignored.
+func parseLDIFEmails(r io.Reader) (emails []string, err error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.HasPrefix(line, " ") && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	for _, line := range lines {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		i := strings.Index(line, ":")
+		if i < 0 || !strings.EqualFold(line[:i], "mail") {
+			continue
+		}
+		value := line[i+1:]
+		if strings.HasPrefix(value, ":") {
+			decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value[1:]))
+			if err != nil {
+				return nil, fmt.Errorf("bad base64 mail value: %v", err)
+			}
+			value = string(decoded)
+		}
+		if value = strings.TrimSpace(value); len(value) > 0 {
+			emails = append(emails, value)
+		}
+	}
+	return emails, nil
+}
+
+func readLDIFEmails(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parseLDIFEmails(f)
+}
+
+func fetchExternalSyncEmails(mctx libkb.MetaContext, config keybase1.TeamExternalSyncConfig) ([]string, error) {
+	var emails []string
+	var err error
+	switch config.SourceType {
+	case keybase1.TeamExternalSyncSourceType_SCIM:
+		emails, err = fetchSCIMEmails(mctx, config)
+	case keybase1.TeamExternalSyncSourceType_LDIF:
+		emails, err = readLDIFEmails(config.Source)
+	default:
+		return nil, fmt.Errorf("unknown external sync source type: %v", config.SourceType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var res []string
+	for _, email := range emails {
+		email = strings.ToLower(email)
+		if seen[email] {
+			continue
+		}
+		seen[email] = true
+		res = append(res, email)
+	}
+	return res, nil
+}
+
+func checkExternalSyncConfig(config keybase1.TeamExternalSyncConfig) error {
+	if len(config.Source) == 0 {
+		return errors.New("external sync needs a source")
+	}
+	switch config.Role {
+	case keybase1.TeamRole_READER, keybase1.TeamRole_WRITER:
+	default:
+		// Handing out admin rights based on a directory we don't control is
+		// a good way to lose a team.
+		return fmt.Errorf("external sync can only grant reader or writer, not %v", config.Role)
+	}
+	if config.Interval < 0 {
+		return errors.New("external sync interval can't be negative")
+	}
+	return nil
+}
+
+// ExternalSync reconciles the membership of config.TeamID against the
+// configured directory. Users in the directory but not on the team are added
+// with config.Role; with RemoveMissing set, readers and writers who aren't in
+// the directory are removed. Owners, admins and bots are never touched. With
+// dryRun, the report is computed and nothing is changed.
+func ExternalSync(ctx context.Context, g *libkb.GlobalContext, config keybase1.TeamExternalSyncConfig,
+	dryRun bool) (res keybase1.TeamExternalSyncReport, err error) {
+	mctx := libkb.NewMetaContext(ctx, g)
+	defer mctx.Trace(fmt.Sprintf("teams.ExternalSync(%v, dryRun=%v)", config.TeamID, dryRun), &err)()
+
+	res.DryRun = dryRun
+	if err := checkExternalSyncConfig(config); err != nil {
+		return res, err
+	}
+	team, err := GetForTeamManagementByTeamID(ctx, g, config.TeamID, true /* needAdmin */)
+	if err != nil {
+		return res, err
+	}
+	emails, err := fetchExternalSyncEmails(mctx, config)
+	if err != nil {
+		return res, err
+	}
+	res.SourceCount = len(emails)
+
+	wanted := make(map[keybase1.UID]string)
+	for _, email := range emails {
+		user, _, err := g.Resolver.ResolveUser(mctx, fmt.Sprintf("[%s]@email", email))
+		if err != nil {
+			if shouldPreventTeamCreation(err) {
+				// Don't remove half the team because the resolver is
+				// rate limiting us.
+				return res, err
+			}
+			res.Unresolved = append(res.Unresolved, email)
+			continue
+		}
+		wanted[user.Uid] = user.Username
+	}
+
+	members, err := team.Members()
+	if err != nil {
+		return res, err
+	}
+	current := make(map[keybase1.UID]bool)
+	for _, uvs := range [][]keybase1.UserVersion{members.Owners, members.Admins,
+		members.Writers, members.Readers, members.Bots, members.RestrictedBots} {
+		for _, uv := range uvs {
+			current[uv.Uid] = true
+		}
+	}
+
+	var toAdd []keybase1.UserRolePair
+	for uid, username := range wanted {
+		if current[uid] {
+			res.UnchangedCount++
+			continue
+		}
+		toAdd = append(toAdd, keybase1.UserRolePair{Assertion: username, Role: config.Role})
+		res.Added = append(res.Added, username)
+	}
+	var toRemove []keybase1.TeamMemberToRemove
+	if config.RemoveMissing {
+		for _, uv := range append(members.Writers, members.Readers...) {
+			if _, ok := wanted[uv.Uid]; ok {
+				continue
+			}
+			username, err := g.GetUPAKLoader().LookupUsername(ctx, uv.Uid)
+			if err != nil {
+				res.Failures = append(res.Failures, fmt.Sprintf("%v: %v", uv.Uid, err))
+				continue
+			}
+			toRemove = append(toRemove, keybase1.NewTeamMemberToRemoveWithAssertion(
+				keybase1.AssertionTeamMemberToRemove{Assertion: username.String()}))
+			res.Removed = append(res.Removed, username.String())
+		}
+	}
+	sort.Strings(res.Added)
+	sort.Strings(res.Removed)
+	sort.Strings(res.Unresolved)
+	if dryRun {
+		return res, nil
+	}
+
+	if len(toAdd) > 0 {
+		_, notAdded, err := AddMembers(ctx, g, config.TeamID, toAdd, nil /* emailInviteMsg */)
+		if err != nil {
+			return res, err
+		}
+		skipped := make(map[string]bool)
+		for _, user := range notAdded {
+			skipped[user.Username] = true
+			res.Failures = append(res.Failures, fmt.Sprintf("%s: blocked by contact settings", user.Username))
+		}
+		added := res.Added[:0]
+		for _, username := range res.Added {
+			if !skipped[username] {
+				added = append(added, username)
+			}
+		}
+		res.Added = added
+	}
+	if len(toRemove) > 0 {
+		rmres, err := RemoveMembers(ctx, g, config.TeamID, toRemove, true /* shouldNotErrorOnPartialFailure */)
+		if err != nil {
+			return res, err
+		}
+		failed := make(map[string]bool)
+		for _, failure := range rmres.Failures {
+			assertion := failure.TeamMember.Assertion().Assertion
+			failed[assertion] = true
+			msg := "unknown error"
+			if failure.ErrorAtTarget != nil {
+				msg = *failure.ErrorAtTarget
+			}
+			res.Failures = append(res.Failures, fmt.Sprintf("%s: %s", assertion, msg))
+		}
+		removed := res.Removed[:0]
+		for _, username := range res.Removed {
+			if !failed[username] {
+				removed = append(removed, username)
+			}
+		}
+		res.Removed = removed
+	}
+	sort.Strings(res.Failures)
+	return res, nil
+}
+
+func externalSyncDB(g *libkb.GlobalContext) *encrypteddb.EncryptedDB {
+	keyFn := func(ctx context.Context) ([32]byte, error) {
+		return encrypteddb.GetSecretBoxKey(ctx, g, libkb.EncryptionReasonTeamsLocalStorage,
+			"encrypt team external sync")
+	}
+	dbFn := func(g *libkb.GlobalContext) *libkb.JSONLocalDb {
+		return g.LocalDb
+	}
+	return encrypteddb.New(g, dbFn, keyFn)
+}
+
+func externalSyncDbKey(uid keybase1.UID) libkb.DbKey {
+	return libkb.DbKey{
+		Typ: libkb.DBTeamExternalSync,
+		Key: fmt.Sprintf("v0|%s", uid),
+	}
+}
+
+func loadExternalSyncsLocked(mctx libkb.MetaContext) (res []keybase1.TeamExternalSyncState, err error) {
+	uid := mctx.CurrentUID()
+	if uid.IsNil() {
+		return nil, libkb.LoginRequiredError{}
+	}
+	if _, err := externalSyncDB(mctx.G()).Get(mctx.Ctx(), externalSyncDbKey(uid), &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func storeExternalSyncsLocked(mctx libkb.MetaContext, syncs []keybase1.TeamExternalSyncState) error {
+	uid := mctx.CurrentUID()
+	if uid.IsNil() {
+		return libkb.LoginRequiredError{}
+	}
+	return externalSyncDB(mctx.G()).Put(mctx.Ctx(), externalSyncDbKey(uid), syncs)
+}
+
+// SetExternalSync saves a sync configuration for the team, replacing any
+// existing one. Syncs with a positive interval are run by the background
+// task; the history of the previous configuration is kept.
+func SetExternalSync(ctx context.Context, g *libkb.GlobalContext, config keybase1.TeamExternalSyncConfig) (err error) {
+	mctx := libkb.NewMetaContext(ctx, g)
+	defer mctx.Trace(fmt.Sprintf("teams.SetExternalSync(%v)", config.TeamID), &err)()
+
+	if err := checkExternalSyncConfig(config); err != nil {
+		return err
+	}
+	if _, err := GetForTeamManagementByTeamID(ctx, g, config.TeamID, true /* needAdmin */); err != nil {
+		return err
+	}
+	g.TeamExternalSyncMu.Lock()
+	defer g.TeamExternalSyncMu.Unlock()
+	syncs, err := loadExternalSyncsLocked(mctx)
+	if err != nil {
+		return err
+	}
+	for i, state := range syncs {
+		if state.Config.TeamID.Eq(config.TeamID) {
+			syncs[i].Config = config
+			return storeExternalSyncsLocked(mctx, syncs)
+		}
+	}
+	syncs = append(syncs, keybase1.TeamExternalSyncState{Config: config})
+	return storeExternalSyncsLocked(mctx, syncs)
+}
+
+// ClearExternalSync stops syncing the team. The team's membership is left
+// as it is.
+func ClearExternalSync(ctx context.Context, g *libkb.GlobalContext, teamID keybase1.TeamID) (err error) {
+	mctx := libkb.NewMetaContext(ctx, g)
+	defer mctx.Trace(fmt.Sprintf("teams.ClearExternalSync(%v)", teamID), &err)()
+
+	g.TeamExternalSyncMu.Lock()
+	defer g.TeamExternalSyncMu.Unlock()
+	syncs, err := loadExternalSyncsLocked(mctx)
+	if err != nil {
+		return err
+	}
+	kept := syncs[:0]
+	for _, state := range syncs {
+		if !state.Config.TeamID.Eq(teamID) {
+			kept = append(kept, state)
+		}
+	}
+	if len(kept) == len(syncs) {
+		return fmt.Errorf("no external sync configured for %v", teamID)
+	}
+	return storeExternalSyncsLocked(mctx, kept)
+}
+
+// ListExternalSyncs returns the configured syncs for the current user, with
+// bearer tokens blanked out.
+func ListExternalSyncs(ctx context.Context, g *libkb.GlobalContext) (res []keybase1.TeamExternalSyncState, err error) {
+	mctx := libkb.NewMetaContext(ctx, g)
+	defer mctx.Trace("teams.ListExternalSyncs", &err)()
+
+	g.TeamExternalSyncMu.Lock()
+	defer g.TeamExternalSyncMu.Unlock()
+	syncs, err := loadExternalSyncsLocked(mctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, state := range syncs {
+		state = state.DeepCopy()
+		state.Config.BearerToken = ""
+		res = append(res, state)
+	}
+	return res, nil
+}
+
+// ExternalSyncBackgroundRound runs every scheduled sync that is due, and
+// records how it went.
+func ExternalSyncBackgroundRound(mctx libkb.MetaContext) error {
+	if !mctx.ActiveDevice().Valid() {
+		mctx.Debug("ExternalSyncBackgroundRound: no active device, skipping")
+		return nil
+	}
+	mctx.G().TeamExternalSyncMu.Lock()
+	defer mctx.G().TeamExternalSyncMu.Unlock()
+	syncs, err := loadExternalSyncsLocked(mctx)
+	if err != nil {
+		return err
+	}
+	ran := false
+	for i, state := range syncs {
+		if state.Config.Interval <= 0 {
+			continue
+		}
+		now := mctx.G().Clock().Now()
+		if now.Before(state.LastRun.Time().Add(state.Config.Interval.Duration())) {
+			continue
+		}
+		report, err := ExternalSync(mctx.Ctx(), mctx.G(), state.Config, false /* dryRun */)
+		syncs[i].LastRun = keybase1.ToTime(now)
+		syncs[i].LastError = ""
+		if err != nil {
+			mctx.Debug("ExternalSyncBackgroundRound: sync for %v failed: %v", state.Config.TeamID, err)
+			syncs[i].LastError = err.Error()
+		} else {
+			syncs[i].LastReport = &report
+		}
+		ran = true
+	}
+	if !ran {
+		return nil
+	}
+	return storeExternalSyncsLocked(mctx, syncs)
+}
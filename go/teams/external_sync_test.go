@@ -0,0 +1,52 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package teams
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLDIFEmails(t *testing.T) {
+	ldif := `version: 1
+
+# alice
+dn: uid=alice,ou=people,dc=example,dc=com
+objectClass: inetOrgPerson
+mail: alice@example.com
+
+dn: uid=bob,ou=people,dc=example,dc=com
+MAIL: bob@exam
+ ple.com
+mailAlternateAddress: bob@other.example.com
+
+dn: uid=charlie,ou=people,dc=example,dc=com
+mail:: Y2hhcmxpZUBleGFtcGxlLmNvbQ==
+
+dn: uid=dave,ou=people,dc=example,dc=com
+cn: Dave
+`
+	emails, err := parseLDIFEmails(strings.NewReader(ldif))
+	require.NoError(t, err)
+	require.Equal(t, []string{"alice@example.com", "bob@example.com", "charlie@example.com"}, emails)
+
+	_, err = parseLDIFEmails(strings.NewReader("mail:: !!!\n"))
+	require.Error(t, err)
+}
+
+func TestCheckExternalSyncConfig(t *testing.T) {
+	config := keybase1.TeamExternalSyncConfig{
+		Source: "people.ldif",
+		Role:   keybase1.TeamRole_WRITER,
+	}
+	require.NoError(t, checkExternalSyncConfig(config))
+	config.Role = keybase1.TeamRole_ADMIN
+	require.Error(t, checkExternalSyncConfig(config))
+	config.Role = keybase1.TeamRole_READER
+	config.Source = ""
+	require.Error(t, checkExternalSyncConfig(config))
+}
@@ -487,6 +487,7 @@ func handleSBSSingle(ctx context.Context, g *libkb.GlobalContext, teamID keybase
 			g.Log.CDebugf(ctx, "sending welcome message for successful SBS handle")
 			SendChatInviteWelcomeMessage(ctx, g, team.Name().String(), category, invite.Inviter.Uid,
 				verifiedInvitee.Uid, invite.Role)
+			sendTeamReadmeDMToUID(ctx, g, team, verifiedInvitee.Uid)
 		}
 
 		return nil
@@ -541,10 +542,14 @@ func HandleOpenTeamAccessRequest(ctx context.Context, g *libkb.GlobalContext, ms
 		}
 
 		tx := CreateAddMemberTx(team)
+		var added []keybase1.UID
 		for _, tar := range msg.Tars {
 			uv := NewUserVersion(tar.Uid, tar.EldestSeqno)
 			err := tx.AddMemberByUV(ctx, uv, joinAsRole, nil)
 			g.Log.CDebugf(ctx, "Open team request: adding %v, returned err: %v", uv, err)
+			if err == nil {
+				added = append(added, tar.Uid)
+			}
 		}
 
 		if tx.IsEmpty() {
@@ -552,14 +557,31 @@ func HandleOpenTeamAccessRequest(ctx context.Context, g *libkb.GlobalContext, ms
 			return nil
 		}
 
-		return tx.Post(libkb.NewMetaContext(ctx, g))
+		if err := tx.Post(libkb.NewMetaContext(ctx, g)); err != nil {
+			return err
+		}
+		for _, uid := range added {
+			sendTeamReadmeDMToUID(ctx, g, team, uid)
+		}
+		return nil
 	})
 }
 
+// sendTeamReadmeDMToUID DMs team's readme to uid, who was just added to it.
+func sendTeamReadmeDMToUID(ctx context.Context, g *libkb.GlobalContext, team *Team, uid keybase1.UID) {
+	username, err := g.GetUPAKLoader().LookupUsername(ctx, uid)
+	if err != nil {
+		g.Log.CDebugf(ctx, "sendTeamReadmeDMToUID: failed to lookup username: %s", err)
+		return
+	}
+	SendTeamReadmeDM(ctx, g, team.ID, team.Name().String(), username.String())
+}
+
 type chatSeitanRecip struct {
-	inviter keybase1.UID
-	invitee keybase1.UID
-	role    keybase1.TeamRole
+	inviteID keybase1.TeamInviteID
+	inviter  keybase1.UID
+	invitee  keybase1.UID
+	role     keybase1.TeamRole
 }
 
 func HandleTeamSeitan(ctx context.Context, g *libkb.GlobalContext, msg keybase1.TeamSeitanMsg) (err error) {
@@ -660,9 +682,10 @@ func HandleTeamSeitan(ctx context.Context, g *libkb.GlobalContext, msg keybase1.
 		}
 
 		chats = append(chats, chatSeitanRecip{
-			inviter: invite.Inviter.Uid,
-			invitee: seitan.Uid,
-			role:    invite.Role,
+			inviteID: invite.Id,
+			inviter:  invite.Inviter.Uid,
+			invitee:  seitan.Uid,
+			role:     invite.Role,
 		})
 	}
 
@@ -680,6 +703,8 @@ func HandleTeamSeitan(ctx context.Context, g *libkb.GlobalContext, msg keybase1.
 				chat.inviter, chat.invitee, chat.role)
 			SendChatInviteWelcomeMessage(ctx, g, team.Name().String(), keybase1.TeamInviteCategory_SEITAN,
 				chat.inviter, chat.invitee, chat.role)
+			applySeitanInviteExtras(mctx, team, chat.inviteID, chat.invitee)
+			sendTeamReadmeDMToUID(ctx, g, team, chat.invitee)
 		}
 	}
 
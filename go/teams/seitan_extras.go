@@ -0,0 +1,287 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package teams
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/encrypteddb"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// Seitan invite extras are what happens to an invitee besides being added
+// to the team: joining some channels and getting a welcome DM. They aren't
+// part of the sigchain, so they're kept in the creating admin's local
+// storage, and applied by HandleTeamSeitan when that device processes the
+// redemption.
+
+const maxSeitanInviteChannels = 20
+
+func seitanExtrasDB(g *libkb.GlobalContext) *encrypteddb.EncryptedDB {
+	keyFn := func(ctx context.Context) ([32]byte, error) {
+		return encrypteddb.GetSecretBoxKey(ctx, g, libkb.EncryptionReasonTeamsLocalStorage,
+			"encrypt seitan invite extras")
+	}
+	dbFn := func(g *libkb.GlobalContext) *libkb.JSONLocalDb {
+		return g.LocalDb
+	}
+	return encrypteddb.New(g, dbFn, keyFn)
+}
+
+func seitanExtrasDbKey(uid keybase1.UID, teamID keybase1.TeamID) libkb.DbKey {
+	return libkb.DbKey{
+		Typ: libkb.DBTeamSeitanInviteExtras,
+		Key: fmt.Sprintf("v0|%s|%s", uid, teamID),
+	}
+}
+
+func loadSeitanExtrasLocked(mctx libkb.MetaContext, teamID keybase1.TeamID) (res []keybase1.SeitanInviteExtras, err error) {
+	uid := mctx.CurrentUID()
+	if uid.IsNil() {
+		return nil, libkb.LoginRequiredError{}
+	}
+	if _, err := seitanExtrasDB(mctx.G()).Get(mctx.Ctx(), seitanExtrasDbKey(uid, teamID), &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func storeSeitanExtrasLocked(mctx libkb.MetaContext, teamID keybase1.TeamID, extras []keybase1.SeitanInviteExtras) error {
+	uid := mctx.CurrentUID()
+	if uid.IsNil() {
+		return libkb.LoginRequiredError{}
+	}
+	key := seitanExtrasDbKey(uid, teamID)
+	if len(extras) == 0 {
+		return seitanExtrasDB(mctx.G()).Delete(mctx.Ctx(), key)
+	}
+	return seitanExtrasDB(mctx.G()).Put(mctx.Ctx(), key, extras)
+}
+
+// normalizeSeitanInviteChannels strips leading '#'s and drops duplicates,
+// keeping the order the channels were given in.
+func normalizeSeitanInviteChannels(channels []string) (res []string, err error) {
+	seen := make(map[string]bool)
+	for _, channel := range channels {
+		channel = strings.TrimLeft(strings.TrimSpace(channel), "#")
+		if len(channel) == 0 {
+			return nil, errors.New("empty channel name")
+		}
+		if seen[channel] {
+			continue
+		}
+		seen[channel] = true
+		res = append(res, channel)
+	}
+	if len(res) > maxSeitanInviteChannels {
+		return nil, fmt.Errorf("an invite can add to at most %d channels", maxSeitanInviteChannels)
+	}
+	return res, nil
+}
+
+// formatSeitanWelcomeDM fills in the welcome DM template for an invitee.
+func formatSeitanWelcomeDM(template, username, team string) string {
+	return strings.NewReplacer("{username}", username, "{team}", team).Replace(template)
+}
+
+func findSeitanInviteChannel(ctx context.Context, g *libkb.GlobalContext, teamname, channel string) (chat1.ConversationID, error) {
+	convs, err := g.ChatHelper.FindConversations(ctx, teamname, &channel,
+		chat1.TopicType_CHAT, chat1.ConversationMembersType_TEAM, keybase1.TLFVisibility_PRIVATE)
+	if err != nil {
+		return nil, err
+	}
+	switch len(convs) {
+	case 0:
+		return nil, fmt.Errorf("no channel #%s in %s", channel, teamname)
+	case 1:
+		return convs[0].Info.Id, nil
+	default:
+		return nil, fmt.Errorf("multiple channels #%s in %s", channel, teamname)
+	}
+}
+
+// CreateSeitanTokenWithExtras creates a V2 seitan token, and remembers the
+// channels and welcome DM to apply when it's redeemed.
+func CreateSeitanTokenWithExtras(ctx context.Context, g *libkb.GlobalContext, teamname string,
+	role keybase1.TeamRole, label keybase1.SeitanKeyLabel, channels []string,
+	welcomeDMTemplate string) (res keybase1.SeitanTokenWithExtras, err error) {
+	mctx := libkb.NewMetaContext(ctx, g)
+	defer mctx.Trace(fmt.Sprintf("teams.CreateSeitanTokenWithExtras(%s, %v)", teamname, role), &err)()
+
+	channels, err = normalizeSeitanInviteChannels(channels)
+	if err != nil {
+		return res, err
+	}
+	t, err := GetForTeamManagementByStringName(ctx, g, teamname, true)
+	if err != nil {
+		return res, err
+	}
+	if len(channels) > 0 {
+		g.StartStandaloneChat()
+		for _, channel := range channels {
+			if _, err := findSeitanInviteChannel(ctx, g, t.Name().String(), channel); err != nil {
+				return res, err
+			}
+		}
+	}
+
+	ikey, err := t.InviteSeitanV2(ctx, role, label)
+	if err != nil {
+		return res, err
+	}
+	sikey, err := ikey.GenerateSIKey()
+	if err != nil {
+		return res, err
+	}
+	scID, err := sikey.GenerateTeamInviteID()
+	if err != nil {
+		return res, err
+	}
+	extras := keybase1.SeitanInviteExtras{
+		TeamID:            t.ID,
+		InviteID:          keybase1.TeamInviteID(scID),
+		Role:              role,
+		Channels:          channels,
+		WelcomeDMTemplate: welcomeDMTemplate,
+		Ctime:             keybase1.ToTime(mctx.G().Clock().Now()),
+	}
+	res = keybase1.SeitanTokenWithExtras{
+		Ikey:   keybase1.SeitanIKeyV2(ikey),
+		Extras: extras,
+	}
+	if len(channels) == 0 && len(welcomeDMTemplate) == 0 {
+		return res, nil
+	}
+
+	mctx.G().SeitanExtrasMu.Lock()
+	defer mctx.G().SeitanExtrasMu.Unlock()
+	all, err := loadSeitanExtrasLocked(mctx, t.ID)
+	if err != nil {
+		return res, err
+	}
+	all = append(all, extras)
+	if err := storeSeitanExtrasLocked(mctx, t.ID, all); err != nil {
+		// The invite is out there already; better to cancel it than to
+		// let it be redeemed without what the admin asked for.
+		if cerr := removeInviteID(ctx, t, extras.InviteID); cerr != nil {
+			mctx.Debug("CreateSeitanTokenWithExtras: failed to cancel invite %s: %v", extras.InviteID, cerr)
+		}
+		return res, err
+	}
+	return res, nil
+}
+
+// ListSeitanInviteExtras returns the extras of the team's invites that are
+// still outstanding. Extras of invites which were redeemed or cancelled
+// elsewhere are dropped.
+func ListSeitanInviteExtras(ctx context.Context, g *libkb.GlobalContext, teamID keybase1.TeamID) (res []keybase1.SeitanInviteExtras, err error) {
+	mctx := libkb.NewMetaContext(ctx, g)
+	defer mctx.Trace(fmt.Sprintf("teams.ListSeitanInviteExtras(%v)", teamID), &err)()
+
+	t, err := GetForTeamManagementByTeamID(ctx, g, teamID, true /* needAdmin */)
+	if err != nil {
+		return nil, err
+	}
+	mctx.G().SeitanExtrasMu.Lock()
+	defer mctx.G().SeitanExtrasMu.Unlock()
+	all, err := loadSeitanExtrasLocked(mctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	for _, extras := range all {
+		if _, found := t.chain().FindActiveInviteMDByID(extras.InviteID); found {
+			res = append(res, extras)
+		}
+	}
+	if len(res) != len(all) {
+		if err := storeSeitanExtrasLocked(mctx, teamID, res); err != nil {
+			mctx.Debug("ListSeitanInviteExtras: failed to prune: %v", err)
+		}
+	}
+	return res, nil
+}
+
+// RevokeSeitanInvite cancels the invite, and forgets its extras.
+func RevokeSeitanInvite(ctx context.Context, g *libkb.GlobalContext, teamID keybase1.TeamID, inviteID keybase1.TeamInviteID) (err error) {
+	mctx := libkb.NewMetaContext(ctx, g)
+	defer mctx.Trace(fmt.Sprintf("teams.RevokeSeitanInvite(%v, %v)", teamID, inviteID), &err)()
+
+	if err := CancelInviteByID(ctx, g, teamID, inviteID); err != nil {
+		return err
+	}
+	_, err = takeSeitanInviteExtras(mctx, teamID, inviteID)
+	return err
+}
+
+// takeSeitanInviteExtras removes and returns the extras for the invite, if
+// there are any.
+func takeSeitanInviteExtras(mctx libkb.MetaContext, teamID keybase1.TeamID,
+	inviteID keybase1.TeamInviteID) (res *keybase1.SeitanInviteExtras, err error) {
+	mctx.G().SeitanExtrasMu.Lock()
+	defer mctx.G().SeitanExtrasMu.Unlock()
+	all, err := loadSeitanExtrasLocked(mctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	for i, extras := range all {
+		if extras.InviteID.Eq(inviteID) {
+			res = &extras
+			all = append(all[:i], all[i+1:]...)
+			return res, storeSeitanExtrasLocked(mctx, teamID, all)
+		}
+	}
+	return nil, nil
+}
+
+// applySeitanInviteExtras adds the invitee to the invite's channels and sends
+// them its welcome DM. It's best-effort: the invitee is already in the team,
+// so failures are only logged.
+func applySeitanInviteExtras(mctx libkb.MetaContext, team *Team, inviteID keybase1.TeamInviteID, invitee keybase1.UID) {
+	extras, err := takeSeitanInviteExtras(mctx, team.ID, inviteID)
+	if err != nil {
+		mctx.Debug("applySeitanInviteExtras: failed to load extras for %s: %v", inviteID, err)
+		return
+	}
+	if extras == nil {
+		return
+	}
+	inviteeName, err := mctx.G().GetUPAKLoader().LookupUsername(mctx.Ctx(), invitee)
+	if err != nil {
+		mctx.Debug("applySeitanInviteExtras: failed to lookup invitee username: %v", err)
+		return
+	}
+	teamname := team.Name().String()
+	mctx.G().StartStandaloneChat()
+
+	uid := gregor1.UID(mctx.CurrentUID().ToBytes())
+	for _, channel := range extras.Channels {
+		if channel == globals.DefaultTeamTopic {
+			continue
+		}
+		convID, err := findSeitanInviteChannel(mctx.Ctx(), mctx.G(), teamname, channel)
+		if err == nil {
+			err = mctx.G().ChatHelper.BulkAddToConv(mctx.Ctx(), uid, convID, []string{inviteeName.String()})
+		}
+		if err != nil {
+			mctx.Debug("applySeitanInviteExtras: failed to add %s to #%s: %v", inviteeName, channel, err)
+		}
+	}
+
+	if len(extras.WelcomeDMTemplate) > 0 {
+		text := formatSeitanWelcomeDM(extras.WelcomeDMTemplate, inviteeName.String(), teamname)
+		dm := fmt.Sprintf("%s,%s", mctx.G().Env.GetUsername(), inviteeName)
+		if _, err := mctx.G().ChatHelper.SendTextByNameNonblock(mctx.Ctx(), dm, nil,
+			chat1.ConversationMembersType_IMPTEAMNATIVE, keybase1.TLFIdentifyBehavior_CHAT_CLI,
+			text, nil); err != nil {
+			mctx.Debug("applySeitanInviteExtras: failed to send welcome DM to %s: %v", inviteeName, err)
+		}
+	}
+}
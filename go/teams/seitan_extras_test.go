@@ -0,0 +1,38 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package teams
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeSeitanInviteChannels(t *testing.T) {
+	channels, err := normalizeSeitanInviteChannels([]string{"#random", " eng ", "random", "##ops"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"random", "eng", "ops"}, channels)
+
+	channels, err = normalizeSeitanInviteChannels(nil)
+	require.NoError(t, err)
+	require.Empty(t, channels)
+
+	_, err = normalizeSeitanInviteChannels([]string{"eng", "#"})
+	require.Error(t, err)
+
+	var many []string
+	for i := 0; i <= maxSeitanInviteChannels; i++ {
+		many = append(many, fmt.Sprintf("c%d", i))
+	}
+	_, err = normalizeSeitanInviteChannels(many)
+	require.Error(t, err)
+}
+
+func TestFormatSeitanWelcomeDM(t *testing.T) {
+	require.Equal(t, "Hi alice, welcome to acme.eng! Say hi, alice.",
+		formatSeitanWelcomeDM("Hi {username}, welcome to {team}! Say hi, {username}.", "alice", "acme.eng"))
+	require.Equal(t, "no placeholders {user}",
+		formatSeitanWelcomeDM("no placeholders {user}", "alice", "acme"))
+}
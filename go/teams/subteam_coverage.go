@@ -0,0 +1,126 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package teams
+
+import (
+	"sort"
+
+	"golang.org/x/net/context"
+
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// teamMemberRoles maps each member of a team to their role in it.
+func teamMemberRoles(members keybase1.TeamMembers) map[keybase1.UID]keybase1.TeamRole {
+	roles := make(map[keybase1.UID]keybase1.TeamRole)
+	for role, uvs := range map[keybase1.TeamRole][]keybase1.UserVersion{
+		keybase1.TeamRole_OWNER:         members.Owners,
+		keybase1.TeamRole_ADMIN:         members.Admins,
+		keybase1.TeamRole_WRITER:        members.Writers,
+		keybase1.TeamRole_READER:        members.Readers,
+		keybase1.TeamRole_BOT:           members.Bots,
+		keybase1.TeamRole_RESTRICTEDBOT: members.RestrictedBots,
+	} {
+		for _, uv := range uvs {
+			roles[uv.Uid] = role
+		}
+	}
+	return roles
+}
+
+type subteamCoverageInput struct {
+	name  keybase1.TeamName
+	roles map[keybase1.UID]keybase1.TeamRole
+}
+
+// computeSubteamCoverage works out which of the subteams each member of the
+// parent team is in, from everyone's roles. Members whose username isn't in
+// usernames are left out.
+func computeSubteamCoverage(team keybase1.TeamName,
+	roles map[keybase1.UID]keybase1.TeamRole, subteams []subteamCoverageInput,
+	usernames map[keybase1.UID]string) (res keybase1.TeamSubteamCoverage) {
+	sort.Slice(subteams, func(i, j int) bool {
+		return subteams[i].name.String() < subteams[j].name.String()
+	})
+	res.Team = team
+	res.Subteams = []keybase1.TeamName{}
+	for _, subteam := range subteams {
+		res.Subteams = append(res.Subteams, subteam.name)
+	}
+	res.Members = []keybase1.TeamSubteamCoverageMember{}
+	for uid, role := range roles {
+		username, ok := usernames[uid]
+		if !ok {
+			continue
+		}
+		member := keybase1.TeamSubteamCoverageMember{
+			Username:    username,
+			Role:        role,
+			MemberOf:    []keybase1.TeamSubteamRole{},
+			MissingFrom: []keybase1.TeamName{},
+		}
+		for _, subteam := range subteams {
+			if subteamRole, ok := subteam.roles[uid]; ok {
+				member.MemberOf = append(member.MemberOf, keybase1.TeamSubteamRole{
+					Subteam: subteam.name,
+					Role:    subteamRole,
+				})
+			} else {
+				member.MissingFrom = append(member.MissingFrom, subteam.name)
+			}
+		}
+		res.Members = append(res.Members, member)
+	}
+	sort.Slice(res.Members, func(i, j int) bool {
+		return res.Members[i].Username < res.Members[j].Username
+	})
+	return res
+}
+
+// SubteamCoverage returns, for each member of the team teamName, which of
+// its subteams (at any depth) they're in and which they're missing from.
+// The caller has to be an admin of the team.
+func SubteamCoverage(ctx context.Context, g *libkb.GlobalContext, teamName string) (
+	res keybase1.TeamSubteamCoverage, err error) {
+	parent, err := Load(ctx, g, keybase1.LoadTeamArg{
+		Name:        teamName,
+		NeedAdmin:   true,
+		ForceRepoll: true,
+	})
+	if err != nil {
+		return res, err
+	}
+	members, err := parent.Members()
+	if err != nil {
+		return res, err
+	}
+	roles := teamMemberRoles(members)
+
+	subteams, err := parent.loadAllTransitiveSubteams(ctx, true /*forceRepoll*/)
+	if err != nil {
+		return res, err
+	}
+	var inputs []subteamCoverageInput
+	for _, subteam := range subteams {
+		subteamMembers, err := subteam.Members()
+		if err != nil {
+			return res, err
+		}
+		inputs = append(inputs, subteamCoverageInput{
+			name:  subteam.Name(),
+			roles: teamMemberRoles(subteamMembers),
+		})
+	}
+
+	usernames := make(map[keybase1.UID]string)
+	for uid := range roles {
+		username, err := g.GetUPAKLoader().LookupUsername(ctx, uid)
+		if err != nil {
+			return res, err
+		}
+		usernames[uid] = username.String()
+	}
+	return computeSubteamCoverage(parent.Name(), roles, inputs, usernames), nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package teams
+
+import (
+	"testing"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeSubteamCoverage(t *testing.T) {
+	teamName := func(s string) keybase1.TeamName {
+		name, err := keybase1.TeamNameFromString(s)
+		require.NoError(t, err)
+		return name
+	}
+	alice := keybase1.UID("295a7eea607af32040647123732bc819")
+	bob := keybase1.UID("afb5eda3154bc13c1df0189ce93ba119")
+	charlie := keybase1.UID("b7c2eaddcced7727bcb229751d91e419")
+
+	roles := teamMemberRoles(keybase1.TeamMembers{
+		Owners:  []keybase1.UserVersion{{Uid: alice, EldestSeqno: 1}},
+		Writers: []keybase1.UserVersion{{Uid: bob, EldestSeqno: 1}},
+		Readers: []keybase1.UserVersion{{Uid: charlie, EldestSeqno: 1}},
+	})
+	subteams := []subteamCoverageInput{
+		{
+			name: teamName("acme.ops.oncall"),
+			roles: teamMemberRoles(keybase1.TeamMembers{
+				Writers: []keybase1.UserVersion{{Uid: bob, EldestSeqno: 1}},
+			}),
+		},
+		{
+			name: teamName("acme.eng"),
+			roles: teamMemberRoles(keybase1.TeamMembers{
+				Admins:  []keybase1.UserVersion{{Uid: bob, EldestSeqno: 1}},
+				Readers: []keybase1.UserVersion{{Uid: charlie, EldestSeqno: 1}},
+			}),
+		},
+		{
+			name:  teamName("acme.ops"),
+			roles: teamMemberRoles(keybase1.TeamMembers{}),
+		},
+	}
+	usernames := map[keybase1.UID]string{
+		alice:   "alice",
+		bob:     "bob",
+		charlie: "charlie",
+	}
+
+	res := computeSubteamCoverage(teamName("acme"), roles, subteams, usernames)
+	require.Equal(t, "acme", res.Team.String())
+	require.Equal(t, []keybase1.TeamName{
+		teamName("acme.eng"), teamName("acme.ops"), teamName("acme.ops.oncall"),
+	}, res.Subteams)
+	require.Equal(t, []keybase1.TeamSubteamCoverageMember{
+		{
+			Username: "alice",
+			Role:     keybase1.TeamRole_OWNER,
+			MemberOf: []keybase1.TeamSubteamRole{},
+			MissingFrom: []keybase1.TeamName{
+				teamName("acme.eng"), teamName("acme.ops"), teamName("acme.ops.oncall"),
+			},
+		},
+		{
+			Username: "bob",
+			Role:     keybase1.TeamRole_WRITER,
+			MemberOf: []keybase1.TeamSubteamRole{
+				{Subteam: teamName("acme.eng"), Role: keybase1.TeamRole_ADMIN},
+				{Subteam: teamName("acme.ops.oncall"), Role: keybase1.TeamRole_WRITER},
+			},
+			MissingFrom: []keybase1.TeamName{teamName("acme.ops")},
+		},
+		{
+			Username: "charlie",
+			Role:     keybase1.TeamRole_READER,
+			MemberOf: []keybase1.TeamSubteamRole{
+				{Subteam: teamName("acme.eng"), Role: keybase1.TeamRole_READER},
+			},
+			MissingFrom: []keybase1.TeamName{teamName("acme.ops"), teamName("acme.ops.oncall")},
+		},
+	}, res.Members)
+}
@@ -61,7 +61,8 @@ export const useData = (initialOrdinal: T.Chat.Ordinal) => {
     ? () => openLocalPathInSystemFileManagerDesktop?.(downloadPath)
     : undefined
 
-  const path = fileURL || previewURL
+  // the user opened this, so it's downloaded whatever the network's auto download policy
+  const path = fileURL ? `${fileURL}&manual=true` : previewURL
   const progress = transferProgress
   const progressLabel = downloadPath
     ? undefined
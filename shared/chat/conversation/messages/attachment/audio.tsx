@@ -21,7 +21,7 @@ const AudioAttachment = () => {
   const onShowInFinder = () => {
     message.downloadPath && openLocalPathInSystemFileManagerDesktop?.(message.downloadPath)
   }
-  const url = !message.submitState && message.fileURL.length > 0 ? `${message.fileURL}&contentforce=true` : ''
+  const url = !message.submitState && message.fileURL.length > 0 ? `${message.fileURL}&contentforce=true&manual=true` : ''
   const showInFinder = !!message.downloadPath && !Kb.Styles.isMobile
   return (
     <Kb.Box2 direction="horizontal" fullWidth={true} alignItems="flex-start">
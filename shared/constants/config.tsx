@@ -73,7 +73,8 @@ export type Store = T.Immutable<{
     | 'reloggedIn'
     | 'startupOrReloginButNotInARush'
   mobileAppState: 'active' | 'background' | 'inactive' | 'unknown'
-  networkStatus?: {online: boolean; type: T.Config.ConnectionType; isInit?: boolean}
+  // isExpensive is whether the OS says the connection is metered, mobile only
+  networkStatus?: {online: boolean; type: T.Config.ConnectionType; isInit?: boolean; isExpensive?: boolean}
   notifySound: boolean
   openAtLogin: boolean
   outOfDate: T.Config.OutOfDate
@@ -200,7 +201,12 @@ type State = Store & {
     onEngineConnected: () => void
     onEngineDisonnected: () => void
     onEngineIncoming: (action: EngineGen.Actions) => void
-    osNetworkStatusChanged: (online: boolean, type: T.Config.ConnectionType, isInit?: boolean) => void
+    osNetworkStatusChanged: (
+      online: boolean,
+      type: T.Config.ConnectionType,
+      isInit?: boolean,
+      isExpensive?: boolean
+    ) => void
     openUnlockFolders: (devices: ReadonlyArray<T.RPCGen.Device>) => void
     powerMonitorEvent: (event: string) => void
     resetState: () => void
@@ -852,12 +858,18 @@ export const _useConfigState = Z.createZustand<State>((set, get) => {
         }))
       })
     },
-    osNetworkStatusChanged: (online: boolean, type: T.Config.ConnectionType, isInit?: boolean) => {
+    osNetworkStatusChanged: (
+      online: boolean,
+      type: T.Config.ConnectionType,
+      isInit?: boolean,
+      isExpensive?: boolean
+    ) => {
       const old = get().networkStatus
       set(s => {
         if (!s.networkStatus) {
-          s.networkStatus = {isInit, online, type}
+          s.networkStatus = {isExpensive, isInit, online, type}
         } else {
+          s.networkStatus.isExpensive = isExpensive
           s.networkStatus.isInit = isInit
           s.networkStatus.online = online
           s.networkStatus.type = type
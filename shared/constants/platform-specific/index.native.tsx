@@ -491,8 +491,10 @@ export const initPlatformListener = () => {
   C.useConfigState.subscribe((s, old) => {
     if (s.loggedIn === old.loggedIn) return
     const f = async () => {
-      const {type} = await NetInfo.fetch()
-      C.useConfigState.getState().dispatch.osNetworkStatusChanged(type !== 'none', type, true)
+      const {type, details} = await NetInfo.fetch()
+      C.useConfigState
+        .getState()
+        .dispatch.osNetworkStatusChanged(type !== 'none', type, true, !!details?.isConnectionExpensive)
     }
     C.ignorePromise(f())
   })
@@ -501,9 +503,10 @@ export const initPlatformListener = () => {
     if (s.networkStatus === old.networkStatus) return
     const type = s.networkStatus?.type
     if (!type) return
+    const isExpensive = !!s.networkStatus?.isExpensive
     const f = async () => {
       try {
-        await T.RPCGen.appStateUpdateMobileNetStateRpcPromise({state: type})
+        await T.RPCGen.appStateUpdateMobileNetStateRpcPromise({isExpensive, state: type})
       } catch (err) {
         console.warn('Error sending mobileNetStateUpdate', err)
       }
@@ -553,8 +556,10 @@ export const initPlatformListener = () => {
   C.ignorePromise(loadStartupDetails())
   initPushListener()
 
-  NetInfo.addEventListener(({type}) => {
-    C.useConfigState.getState().dispatch.osNetworkStatusChanged(type !== 'none', type)
+  NetInfo.addEventListener(({type, details}) => {
+    C.useConfigState
+      .getState()
+      .dispatch.osNetworkStatusChanged(type !== 'none', type, false, !!details?.isConnectionExpensive)
   })
 
   const initAudioModes = () => {
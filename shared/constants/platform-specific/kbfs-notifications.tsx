@@ -166,6 +166,7 @@ export function kbfsNotification(
       // independently.
       [T.RPCGen.FSNotificationType.initialized]: '',
       [T.RPCGen.FSNotificationType.connection]: '',
+      [T.RPCGen.FSNotificationType.archive]: '',
       // [FSNotificationType.syncConfigChanged]: 'Synchronization config changed',
     } as any
   )[notification.notificationType] as string | undefined
@@ -189,6 +190,15 @@ export function kbfsNotification(
     notify(title, {body}, 10, rateLimitKey)
   }
 
+  // Archive jobs finishing or failing for good, which the user would
+  // otherwise only see in the Files tab.
+  if (notification.notificationType === T.RPCGen.FSNotificationType.archive) {
+    const failed = notification.statusCode === T.RPCGen.FSStatusCode.error
+    const title = failed ? 'Keybase: Archive failed' : 'Keybase: Archive complete'
+    notify(title, {body: notification.status})
+    return
+  }
+
   // KBFS fires a notification when it changes state between connected
   // and disconnected (to the mdserver).  For now we just log it.
   if (notification.notificationType === T.RPCGen.FSNotificationType.connection) {
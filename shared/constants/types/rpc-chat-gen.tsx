@@ -359,6 +359,10 @@ export type MessageTypes = {
     inParam: {readonly srcConvID: ConversationID; readonly dstConvID: ConversationID; readonly msgID: MessageID; readonly identifyBehavior: Keybase1.TLFIdentifyBehavior; readonly title: String}
     outParam: PostLocalNonblockRes
   }
+  'chat.1.local.getAttachmentAutoDownloadPolicy': {
+    inParam: undefined
+    outParam: AttachmentAutoDownloadPolicy
+  }
   'chat.1.local.getBotMemberSettings': {
     inParam: {readonly convID: ConversationID; readonly username: String}
     outParam: Keybase1.TeamBotSettings
@@ -595,6 +599,10 @@ export type MessageTypes = {
     inParam: {readonly convID: ConversationID; readonly channelWide: Boolean; readonly settings?: ReadonlyArray<AppNotificationSettingLocal> | null}
     outParam: SetAppNotificationSettingsLocalRes
   }
+  'chat.1.local.setAttachmentAutoDownloadPolicy': {
+    inParam: {readonly policy: AttachmentAutoDownloadPolicy}
+    outParam: void
+  }
   'chat.1.local.setBotMemberSettings': {
     inParam: {readonly convID: ConversationID; readonly username: String; readonly botSettings: Keybase1.TeamBotSettings}
     outParam: void
@@ -1180,6 +1188,8 @@ export type Asset = {readonly filename: String; readonly region: String; readonl
 export type AssetMetadata = {assetType: AssetMetadataType.image; image: AssetMetadataImage} | {assetType: AssetMetadataType.video; video: AssetMetadataVideo} | {assetType: AssetMetadataType.none}
 export type AssetMetadataImage = {readonly width: Int; readonly height: Int; readonly audioAmps?: ReadonlyArray<Double> | null}
 export type AssetMetadataVideo = {readonly width: Int; readonly height: Int; readonly durationMs: Int; readonly isAudio: Boolean}
+export type AttachmentAutoDownloadPolicy = {readonly wifi: AttachmentAutoDownloadRule; readonly cellular: AttachmentAutoDownloadRule; readonly metered: AttachmentAutoDownloadRule}
+export type AttachmentAutoDownloadRule = {readonly previews: Boolean; readonly attachments: Boolean; readonly maxAttachmentSize: Int64}
 export type BodyPlaintext = {version: BodyPlaintextVersion.v1; v1: BodyPlaintextV1} | {version: BodyPlaintextVersion.v2; v2: BodyPlaintextV2} | {version: BodyPlaintextVersion.v3; v3: BodyPlaintextUnsupported} | {version: BodyPlaintextVersion.v4; v4: BodyPlaintextUnsupported} | {version: BodyPlaintextVersion.v5; v5: BodyPlaintextUnsupported} | {version: BodyPlaintextVersion.v6; v6: BodyPlaintextUnsupported} | {version: BodyPlaintextVersion.v7; v7: BodyPlaintextUnsupported} | {version: BodyPlaintextVersion.v8; v8: BodyPlaintextUnsupported} | {version: BodyPlaintextVersion.v9; v9: BodyPlaintextUnsupported} | {version: BodyPlaintextVersion.v10; v10: BodyPlaintextUnsupported}
 export type BodyPlaintextMetaInfo = {readonly crit: Boolean}
 export type BodyPlaintextUnsupported = {readonly mi: BodyPlaintextMetaInfo}
@@ -1716,6 +1726,7 @@ export const localFindConversationsLocalRpcPromise = (params: MessageTypes['chat
 export const localFindGeneralConvFromTeamIDRpcPromise = (params: MessageTypes['chat.1.local.findGeneralConvFromTeamID']['inParam'], waitingKey?: WaitingKey) => new Promise<MessageTypes['chat.1.local.findGeneralConvFromTeamID']['outParam']>((resolve, reject) => engine()._rpcOutgoing({method: 'chat.1.local.findGeneralConvFromTeamID', params, callback: (error: SimpleError, result: MessageTypes['chat.1.local.findGeneralConvFromTeamID']['outParam']) => (error ? reject(error) : resolve(result)), waitingKey}))
 export const localForwardMessageConvSearchRpcPromise = (params: MessageTypes['chat.1.local.forwardMessageConvSearch']['inParam'], waitingKey?: WaitingKey) => new Promise<MessageTypes['chat.1.local.forwardMessageConvSearch']['outParam']>((resolve, reject) => engine()._rpcOutgoing({method: 'chat.1.local.forwardMessageConvSearch', params, callback: (error: SimpleError, result: MessageTypes['chat.1.local.forwardMessageConvSearch']['outParam']) => (error ? reject(error) : resolve(result)), waitingKey}))
 export const localForwardMessageNonblockRpcPromise = (params: MessageTypes['chat.1.local.forwardMessageNonblock']['inParam'], waitingKey?: WaitingKey) => new Promise<MessageTypes['chat.1.local.forwardMessageNonblock']['outParam']>((resolve, reject) => engine()._rpcOutgoing({method: 'chat.1.local.forwardMessageNonblock', params, callback: (error: SimpleError, result: MessageTypes['chat.1.local.forwardMessageNonblock']['outParam']) => (error ? reject(error) : resolve(result)), waitingKey}))
+export const localGetAttachmentAutoDownloadPolicyRpcPromise = (params?: undefined, waitingKey?: WaitingKey) => new Promise<MessageTypes['chat.1.local.getAttachmentAutoDownloadPolicy']['outParam']>((resolve, reject) => engine()._rpcOutgoing({method: 'chat.1.local.getAttachmentAutoDownloadPolicy', params, callback: (error: SimpleError, result: MessageTypes['chat.1.local.getAttachmentAutoDownloadPolicy']['outParam']) => (error ? reject(error) : resolve(result)), waitingKey}))
 export const localGetBotMemberSettingsRpcPromise = (params: MessageTypes['chat.1.local.getBotMemberSettings']['inParam'], waitingKey?: WaitingKey) => new Promise<MessageTypes['chat.1.local.getBotMemberSettings']['outParam']>((resolve, reject) => engine()._rpcOutgoing({method: 'chat.1.local.getBotMemberSettings', params, callback: (error: SimpleError, result: MessageTypes['chat.1.local.getBotMemberSettings']['outParam']) => (error ? reject(error) : resolve(result)), waitingKey}))
 export const localGetChannelMembershipsLocalRpcPromise = (params: MessageTypes['chat.1.local.getChannelMembershipsLocal']['inParam'], waitingKey?: WaitingKey) => new Promise<MessageTypes['chat.1.local.getChannelMembershipsLocal']['outParam']>((resolve, reject) => engine()._rpcOutgoing({method: 'chat.1.local.getChannelMembershipsLocal', params, callback: (error: SimpleError, result: MessageTypes['chat.1.local.getChannelMembershipsLocal']['outParam']) => (error ? reject(error) : resolve(result)), waitingKey}))
 export const localGetDefaultTeamChannelsLocalRpcPromise = (params: MessageTypes['chat.1.local.getDefaultTeamChannelsLocal']['inParam'], waitingKey?: WaitingKey) => new Promise<MessageTypes['chat.1.local.getDefaultTeamChannelsLocal']['outParam']>((resolve, reject) => engine()._rpcOutgoing({method: 'chat.1.local.getDefaultTeamChannelsLocal', params, callback: (error: SimpleError, result: MessageTypes['chat.1.local.getDefaultTeamChannelsLocal']['outParam']) => (error ? reject(error) : resolve(result)), waitingKey}))
@@ -1777,6 +1788,7 @@ export const localRetryPostRpcPromise = (params: MessageTypes['chat.1.local.Retr
 export const localSaveUnfurlSettingsRpcPromise = (params: MessageTypes['chat.1.local.saveUnfurlSettings']['inParam'], waitingKey?: WaitingKey) => new Promise<MessageTypes['chat.1.local.saveUnfurlSettings']['outParam']>((resolve, reject) => engine()._rpcOutgoing({method: 'chat.1.local.saveUnfurlSettings', params, callback: (error: SimpleError, result: MessageTypes['chat.1.local.saveUnfurlSettings']['outParam']) => (error ? reject(error) : resolve(result)), waitingKey}))
 export const localSearchInboxRpcListener = (p: {params: MessageTypes['chat.1.local.searchInbox']['inParam']; incomingCallMap: IncomingCallMapType; customResponseIncomingCallMap?: CustomResponseIncomingCallMap; waitingKey?: WaitingKey}) => getEngineListener<typeof p, Promise<MessageTypes['chat.1.local.searchInbox']['outParam']>>()({method: 'chat.1.local.searchInbox', params: p.params, incomingCallMap: p.incomingCallMap, customResponseIncomingCallMap: p.customResponseIncomingCallMap, waitingKey: p.waitingKey})
 export const localSetAppNotificationSettingsLocalRpcPromise = (params: MessageTypes['chat.1.local.setAppNotificationSettingsLocal']['inParam'], waitingKey?: WaitingKey) => new Promise<MessageTypes['chat.1.local.setAppNotificationSettingsLocal']['outParam']>((resolve, reject) => engine()._rpcOutgoing({method: 'chat.1.local.setAppNotificationSettingsLocal', params, callback: (error: SimpleError, result: MessageTypes['chat.1.local.setAppNotificationSettingsLocal']['outParam']) => (error ? reject(error) : resolve(result)), waitingKey}))
+export const localSetAttachmentAutoDownloadPolicyRpcPromise = (params: MessageTypes['chat.1.local.setAttachmentAutoDownloadPolicy']['inParam'], waitingKey?: WaitingKey) => new Promise<MessageTypes['chat.1.local.setAttachmentAutoDownloadPolicy']['outParam']>((resolve, reject) => engine()._rpcOutgoing({method: 'chat.1.local.setAttachmentAutoDownloadPolicy', params, callback: (error: SimpleError, result: MessageTypes['chat.1.local.setAttachmentAutoDownloadPolicy']['outParam']) => (error ? reject(error) : resolve(result)), waitingKey}))
 export const localSetBotMemberSettingsRpcPromise = (params: MessageTypes['chat.1.local.setBotMemberSettings']['inParam'], waitingKey?: WaitingKey) => new Promise<MessageTypes['chat.1.local.setBotMemberSettings']['outParam']>((resolve, reject) => engine()._rpcOutgoing({method: 'chat.1.local.setBotMemberSettings', params, callback: (error: SimpleError, result: MessageTypes['chat.1.local.setBotMemberSettings']['outParam']) => (error ? reject(error) : resolve(result)), waitingKey}))
 export const localSetConvMinWriterRoleLocalRpcPromise = (params: MessageTypes['chat.1.local.setConvMinWriterRoleLocal']['inParam'], waitingKey?: WaitingKey) => new Promise<MessageTypes['chat.1.local.setConvMinWriterRoleLocal']['outParam']>((resolve, reject) => engine()._rpcOutgoing({method: 'chat.1.local.setConvMinWriterRoleLocal', params, callback: (error: SimpleError, result: MessageTypes['chat.1.local.setConvMinWriterRoleLocal']['outParam']) => (error ? reject(error) : resolve(result)), waitingKey}))
 export const localSetConvRetentionLocalRpcPromise = (params: MessageTypes['chat.1.local.setConvRetentionLocal']['inParam'], waitingKey?: WaitingKey) => new Promise<MessageTypes['chat.1.local.setConvRetentionLocal']['outParam']>((resolve, reject) => engine()._rpcOutgoing({method: 'chat.1.local.setConvRetentionLocal', params, callback: (error: SimpleError, result: MessageTypes['chat.1.local.setConvRetentionLocal']['outParam']) => (error ? reject(error) : resolve(result)), waitingKey}))
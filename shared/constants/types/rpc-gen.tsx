@@ -520,7 +520,7 @@ export type MessageTypes = {
     outParam: void
   }
   'keybase.1.appState.updateMobileNetState': {
-    inParam: {readonly state: String}
+    inParam: {readonly state: String; readonly isExpensive: Boolean}
     outParam: void
   }
   'keybase.1.avatars.loadTeamAvatars': {
@@ -1815,6 +1815,7 @@ export enum FSNotificationType {
   fileRenamed = 10,
   initialized = 11,
   syncConfigChanged = 12,
+  archive = 13,
 }
 
 export enum FSStatusCode {
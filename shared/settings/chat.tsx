@@ -423,6 +423,13 @@ class Chat extends React.Component<Props, State> {
               )}
             </Kb.Box2>
 
+            {C.isMobile && (
+              <>
+                <Kb.Divider style={styles.divider} />
+                <AutoDownload />
+              </>
+            )}
+
             {(showDesktopSound || showMobileSound) && (
               <>
                 <Kb.Divider style={styles.divider} />
@@ -456,6 +463,86 @@ class Chat extends React.Component<Props, State> {
   }
 }
 
+type AutoDownloadNetwork = keyof T.RPCChat.AttachmentAutoDownloadPolicy
+const autoDownloadNetworks: ReadonlyArray<{network: AutoDownloadNetwork; label: string}> = [
+  {label: 'On Wi-Fi', network: 'wifi'},
+  {label: 'On cellular', network: 'cellular'},
+  {label: 'On metered Wi-Fi or unknown networks', network: 'metered'},
+]
+const autoDownloadSizeLimit = 10 * 1024 * 1024
+
+// What the app downloads on its own on each kind of network. These are kept by the service on this device,
+// and anything you open is downloaded regardless.
+const AutoDownload = () => {
+  const [policy, setPolicy] = React.useState<T.RPCChat.AttachmentAutoDownloadPolicy | undefined>()
+  const [error, setError] = React.useState('')
+  const getPolicy = C.useRPC(T.RPCChat.localGetAttachmentAutoDownloadPolicyRpcPromise)
+  const setPolicyRPC = C.useRPC(T.RPCChat.localSetAttachmentAutoDownloadPolicyRpcPromise)
+  React.useEffect(() => {
+    getPolicy([undefined], setPolicy, e => setError(e.message))
+  }, [getPolicy])
+
+  const onChange = (network: AutoDownloadNetwork, rule: Partial<T.RPCChat.AttachmentAutoDownloadRule>) => {
+    if (!policy) return
+    const next = {...policy, [network]: {...policy[network], ...rule}}
+    const prev = policy
+    setPolicy(next)
+    setError('')
+    setPolicyRPC(
+      [{policy: next}],
+      () => {},
+      e => {
+        setPolicy(prev)
+        setError(e.message)
+      }
+    )
+  }
+
+  return (
+    <Kb.Box2 direction="vertical" fullWidth={true} gap="tiny" style={styles.innerContainer}>
+      <Kb.Text type="Header">Automatic downloads</Kb.Text>
+      <Kb.Text type="BodySmall">
+        Choose what your Keybase app downloads on its own to show your conversations.
+      </Kb.Text>
+      {autoDownloadNetworks.map(({label, network}) => {
+        const rule = policy?.[network]
+        return (
+          <Kb.Box2 key={network} direction="vertical" fullWidth={true} gap="xtiny">
+            <Kb.Text type="BodySemibold">{label}</Kb.Text>
+            <Kb.Checkbox
+              label="Image and video previews"
+              checked={!!rule?.previews}
+              disabled={!rule}
+              onCheck={previews => onChange(network, {previews})}
+            />
+            <Kb.Checkbox
+              label="Attachments"
+              checked={!!rule?.attachments}
+              disabled={!rule}
+              onCheck={attachments => onChange(network, {attachments})}
+            />
+            {!!rule?.attachments && (
+              <Kb.Checkbox
+                label="Only attachments up to 10 MB"
+                checked={rule.maxAttachmentSize > 0}
+                style={styles.checkboxIndented}
+                onCheck={limit =>
+                  onChange(network, {maxAttachmentSize: limit ? autoDownloadSizeLimit : 0})
+                }
+              />
+            )}
+          </Kb.Box2>
+        )
+      })}
+      {!!error && (
+        <Kb.Text type="BodySmall" style={styles.error}>
+          {error}
+        </Kb.Text>
+      )}
+    </Kb.Box2>
+  )
+}
+
 const TeamRow = ({
   checked,
   isOpen,